@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"context"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -20,6 +21,7 @@ import (
 	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/types"
 )
 
 // An accessor for reading collector containers. The Offline collector
@@ -222,6 +224,18 @@ func (self *CollectorAccessor) maybeSetZipPassword(
 
 	// metadata.json can be multiple rows
 	for _, row := range rows {
+		// The password is wrapped for multiple recipients.
+		recipients, pres := row.Get("Recipients")
+		if pres {
+			zip_pass, err := self.decryptRecipients(recipients)
+			if err != nil {
+				return nil, err
+			}
+
+			self.scope.SetContext(constants.ZIP_PASSWORDS, string(zip_pass))
+			return collectorPathToDelegatePath(full_path), nil
+		}
+
 		scheme, ok := row.GetString("Scheme")
 		if !ok {
 			// Maybe multiple rows?
@@ -257,6 +271,18 @@ func (self *CollectorAccessor) maybeSetZipPassword(
 			// collector.
 			return collectorPathToDelegatePath(full_path), nil
 		}
+
+		// PGP containers can only be unlocked with the private
+		// keys in the scope.
+		if strings.ToLower(scheme) == "pgp" {
+			zip_pass, err := self.decryptRecipients([]*ordereddict.Dict{row})
+			if err != nil {
+				return nil, err
+			}
+
+			self.scope.SetContext(constants.ZIP_PASSWORDS, string(zip_pass))
+			return collectorPathToDelegatePath(full_path), nil
+		}
 	}
 
 	// No metadata found - this might be a plain unencrypted
@@ -264,6 +290,68 @@ func (self *CollectorAccessor) maybeSetZipPassword(
 	return full_path, nil
 }
 
+// Recover the container password from the recipients list using the
+// private keys in the scope. Server admins may also use the server's
+// own key.
+func (self *CollectorAccessor) decryptRecipients(
+	recipients_any vfilter.Any) ([]byte, error) {
+	serialized, err := json.Marshal(recipients_any)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := []*crypto_utils.KeyRecipient{}
+	err = json.Unmarshal(serialized, &recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	private_keys := self.getPrivateKeys()
+	if vql_subsystem.CheckAccess(self.scope, acls.SERVER_ADMIN) == nil {
+		config_obj, ok := vql_subsystem.GetServerConfig(self.scope)
+		if ok && config_obj.Frontend != nil {
+			private_keys = append(private_keys, config_obj.Frontend.PrivateKey)
+		}
+	}
+
+	return crypto_utils.DecryptForRecipients(recipients, private_keys)
+}
+
+func (self *CollectorAccessor) getPrivateKeys() []string {
+	keys_any, pres := self.scope.Resolve(constants.COLLECTOR_PRIVATE_KEYS)
+	if !pres {
+		return nil
+	}
+
+	switch t := keys_any.(type) {
+	case types.StoredExpression:
+		keys_any = t.Reduce(context.Background(), self.scope)
+
+	case types.LazyExpr:
+		keys_any = t.ReduceWithScope(context.Background(), self.scope)
+	}
+
+	switch t := keys_any.(type) {
+	case string:
+		return []string{t}
+
+	case []string:
+		return t
+
+	case []vfilter.Any:
+		result := []string{}
+		for _, item := range t {
+			key, ok := item.(string)
+			if ok {
+				result = append(result, key)
+			}
+		}
+		return result
+	}
+
+	return nil
+}
+
 // Zip files typically use standard / path separators.
 func (self *CollectorAccessor) ParsePath(path string) (
 	*accessors.OSPath, error) {
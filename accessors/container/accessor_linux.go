@@ -0,0 +1,259 @@
+// +build linux
+
+package container
+
+// The container accessor provides access to files inside a running
+// container's mount namespace. The first path component is the
+// container ID (or name), the rest is the path inside the container:
+//
+//	/<container id>/etc/passwd
+//
+// Files are read through /proc/<pid>/root which the kernel resolves
+// inside the container's mount namespace.
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/artifacts"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+)
+
+type ContainerFileSystemAccessor struct {
+	scope    vfilter.Scope
+	delegate accessors.FileSystemAccessor
+	opts     Options
+
+	// Cache container lookups for the life of the query. The
+	// accessor may be used by several plugins at the same time.
+	mu   sync.Mutex
+	pids map[string]int
+}
+
+func (self *ContainerFileSystemAccessor) getPid(id string) (int, bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	pid, pres := self.pids[id]
+	return pid, pres
+}
+
+func (self *ContainerFileSystemAccessor) setPid(id string, pid int) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.pids[id] = pid
+}
+
+func (self *ContainerFileSystemAccessor) New(
+	scope vfilter.Scope) (accessors.FileSystemAccessor, error) {
+	err := vql_subsystem.CheckAccess(scope, acls.FILESYSTEM_READ)
+	if err != nil {
+		return nil, err
+	}
+
+	delegate, err := accessors.GetAccessor("file", scope)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := Options{}
+	config_obj, ok := artifacts.GetConfig(scope)
+	if ok {
+		opts.PreventExecve = config_obj.PreventExecve
+	}
+
+	return &ContainerFileSystemAccessor{
+		scope:    scope,
+		delegate: delegate,
+		opts:     opts,
+		pids:     make(map[string]int),
+	}, nil
+}
+
+func (self *ContainerFileSystemAccessor) ParsePath(
+	path string) (*accessors.OSPath, error) {
+	return accessors.NewLinuxOSPath(path)
+}
+
+// Map the container path to the real path under /proc/<pid>/root
+func (self *ContainerFileSystemAccessor) getDelegatePath(
+	path *accessors.OSPath) (*accessors.OSPath, error) {
+	if len(path.Components) == 0 {
+		return nil, fmt.Errorf("container: No container specified")
+	}
+
+	id := path.Components[0]
+	pid, pres := self.getPid(id)
+	if !pres {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		info, err := FindContainer(ctx, self.opts, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.Pid == 0 {
+			return nil, fmt.Errorf("container: %v is not running", id)
+		}
+		pid = info.Pid
+		self.setPid(id, pid)
+	}
+
+	root, err := self.delegate.ParsePath("/proc")
+	if err != nil {
+		return nil, err
+	}
+
+	return root.Append(strconv.Itoa(pid), "root").
+		Append(path.Components[1:]...), nil
+}
+
+// Wrap the file info so paths are reported relative to the
+// container and not the host.
+type containerFileInfo struct {
+	accessors.FileInfo
+	path *accessors.OSPath
+}
+
+func (self containerFileInfo) OSPath() *accessors.OSPath {
+	return self.path
+}
+
+func (self containerFileInfo) FullPath() string {
+	return self.path.String()
+}
+
+func (self containerFileInfo) Name() string {
+	return self.path.Basename()
+}
+
+func (self *ContainerFileSystemAccessor) listContainers(
+	path *accessors.OSPath) ([]accessors.FileInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	containers, err := ListContainers(ctx, self.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []accessors.FileInfo
+	for _, c := range containers {
+		if c.Pid == 0 {
+			continue
+		}
+		self.setPid(c.ID, c.Pid)
+
+		result = append(result, &accessors.VirtualFileInfo{
+			IsDir_: true,
+			Path:   path.Append(c.ID),
+			Mtime_: c.Created,
+			Btime_: c.Created,
+		})
+	}
+	return result, nil
+}
+
+func (self *ContainerFileSystemAccessor) ReadDir(
+	path string) ([]accessors.FileInfo, error) {
+	os_path, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return self.ReadDirWithOSPath(os_path)
+}
+
+func (self *ContainerFileSystemAccessor) ReadDirWithOSPath(
+	path *accessors.OSPath) ([]accessors.FileInfo, error) {
+	if len(path.Components) == 0 {
+		return self.listContainers(path)
+	}
+
+	delegate_path, err := self.getDelegatePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := self.delegate.ReadDirWithOSPath(delegate_path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]accessors.FileInfo, 0, len(children))
+	for _, child := range children {
+		result = append(result, &containerFileInfo{
+			FileInfo: child,
+			path:     path.Append(child.Name()),
+		})
+	}
+	return result, nil
+}
+
+func (self *ContainerFileSystemAccessor) Open(
+	path string) (accessors.ReadSeekCloser, error) {
+	os_path, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return self.OpenWithOSPath(os_path)
+}
+
+func (self *ContainerFileSystemAccessor) OpenWithOSPath(
+	path *accessors.OSPath) (accessors.ReadSeekCloser, error) {
+	delegate_path, err := self.getDelegatePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return self.delegate.OpenWithOSPath(delegate_path)
+}
+
+func (self *ContainerFileSystemAccessor) Lstat(
+	path string) (accessors.FileInfo, error) {
+	os_path, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return self.LstatWithOSPath(os_path)
+}
+
+func (self *ContainerFileSystemAccessor) LstatWithOSPath(
+	path *accessors.OSPath) (accessors.FileInfo, error) {
+	if len(path.Components) == 0 {
+		return &accessors.VirtualFileInfo{
+			IsDir_: true,
+			Path:   path,
+		}, nil
+	}
+
+	delegate_path, err := self.getDelegatePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file_info, err := self.delegate.LstatWithOSPath(delegate_path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &containerFileInfo{
+		FileInfo: file_info,
+		path:     path,
+	}, nil
+}
+
+func init() {
+	accessors.Register("container", &ContainerFileSystemAccessor{},
+		`Access files inside running containers.
+
+The first path component is the container ID or name - the rest of
+the path is interpreted inside the container's mount namespace.
+`)
+}
@@ -0,0 +1,93 @@
+package container
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+	"www.velocidex.com/golang/vfilter"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+func (self *DiscoveryTestSuite) scope() vfilter.Scope {
+	return vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+}
+
+func (self *DiscoveryTestSuite) TestAccessor() {
+	// The container's root is the test process's root so the temp
+	// directory is visible inside it.
+	filename := filepath.Join(self.tmpdir, "hello.txt")
+	assert.NoError(self.T(), os.WriteFile(filename, []byte("hello"), 0600))
+
+	accessor, err := accessors.GetAccessor("container", self.scope())
+	assert.NoError(self.T(), err)
+
+	// Only running containers are listed.
+	children, err := accessor.ReadDir("/")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(children))
+	assert.Equal(self.T(), webId, children[0].Name())
+	assert.True(self.T(), children[0].IsDir())
+
+	// Paths are reported relative to the container.
+	children, err = accessor.ReadDir("/web" + self.tmpdir)
+	assert.NoError(self.T(), err)
+
+	names := []string{}
+	for _, child := range children {
+		names = append(names, child.FullPath())
+	}
+	assert.Contains(self.T(), names, "/web"+filename)
+
+	stat, err := accessor.Lstat("/web" + filename)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "hello.txt", stat.Name())
+	assert.Equal(self.T(), "/web"+filename, stat.OSPath().String())
+	assert.Equal(self.T(), int64(5), stat.Size())
+
+	fd, err := accessor.Open("/web" + filename)
+	assert.NoError(self.T(), err)
+	data, err := ioutil.ReadAll(fd)
+	fd.Close()
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "hello", string(data))
+
+	_, err = accessor.Open("/db/etc/passwd")
+	assert.ErrorContains(self.T(), err, "not running")
+
+	_, err = accessor.Open("/missing/etc/passwd")
+	assert.ErrorContains(self.T(), err, "not found")
+}
+
+func (self *DiscoveryTestSuite) TestAccessorConcurrency() {
+	accessor, err := accessors.GetAccessor("container", self.scope())
+	assert.NoError(self.T(), err)
+
+	// Lookups of the same containers from many goroutines share
+	// the pid cache.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			if i%2 == 0 {
+				_, err := accessor.ReadDir("/")
+				assert.NoError(self.T(), err)
+				return
+			}
+
+			_, err := accessor.Lstat("/web" + self.tmpdir)
+			assert.NoError(self.T(), err)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,177 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+type criContainerList struct {
+	Containers []struct {
+		Id           string `json:"id"`
+		PodSandboxId string `json:"podSandboxId"`
+		Metadata     struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Image struct {
+			Image string `json:"image"`
+		} `json:"image"`
+		ImageRef  string            `json:"imageRef"`
+		State     string            `json:"state"`
+		CreatedAt string            `json:"createdAt"`
+		Labels    map[string]string `json:"labels"`
+	} `json:"containers"`
+}
+
+type criInspect struct {
+	Status struct {
+		Mounts []struct {
+			ContainerPath string `json:"containerPath"`
+			HostPath      string `json:"hostPath"`
+			Readonly      bool   `json:"readonly"`
+		} `json:"mounts"`
+	} `json:"status"`
+	Info struct {
+		Pid int `json:"pid"`
+	} `json:"info"`
+}
+
+type criPodList struct {
+	Items []struct {
+		Id       string `json:"id"`
+		Metadata struct {
+			Name      string `json:"name"`
+			Uid       string `json:"uid"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		State     string            `json:"state"`
+		CreatedAt string            `json:"createdAt"`
+		Labels    map[string]string `json:"labels"`
+	} `json:"items"`
+}
+
+// Find the first CRI socket that exists on this host.
+func criEndpoint() (string, error) {
+	for _, endpoint := range CRIEndpoints {
+		_, err := os.Stat(strings.TrimPrefix(endpoint, "unix://"))
+		if err == nil {
+			return endpoint, nil
+		}
+	}
+	return "", errors.New("No CRI runtime socket found")
+}
+
+func crictl(ctx context.Context, target interface{}, args ...string) error {
+	endpoint, err := criEndpoint()
+	if err != nil {
+		return err
+	}
+
+	path, err := exec.LookPath("crictl")
+	if err != nil {
+		return err
+	}
+
+	args = append([]string{"--runtime-endpoint", endpoint}, args...)
+	out, err := exec.CommandContext(ctx, path, args...).Output()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(out, target)
+}
+
+func runtimeName() string {
+	endpoint, _ := criEndpoint()
+	switch {
+	case strings.Contains(endpoint, "containerd"):
+		return "containerd"
+	case strings.Contains(endpoint, "crio"):
+		return "cri-o"
+	}
+	return "cri"
+}
+
+// CRI timestamps are nanoseconds since the epoch as a string.
+func parseCRITime(ts string) time.Time {
+	var ns int64
+	err := json.Unmarshal([]byte(ts), &ns)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, ns).UTC()
+}
+
+func listCRIContainers(ctx context.Context) ([]*ContainerInfo, error) {
+	list := &criContainerList{}
+	err := crictl(ctx, list, "ps", "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	runtime := runtimeName()
+
+	var result []*ContainerInfo
+	for _, c := range list.Containers {
+		info := &ContainerInfo{
+			ID:          c.Id,
+			Name:        c.Metadata.Name,
+			Runtime:     runtime,
+			Image:       c.Image.Image,
+			ImageDigest: c.ImageRef,
+			State:       strings.TrimPrefix(c.State, "CONTAINER_"),
+			PodID:       c.PodSandboxId,
+			Created:     parseCRITime(c.CreatedAt),
+			Labels:      c.Labels,
+		}
+
+		inspect := &criInspect{}
+		err := crictl(ctx, inspect, "inspect", "-o", "json", c.Id)
+		if err == nil {
+			info.Pid = inspect.Info.Pid
+			for _, m := range inspect.Status.Mounts {
+				info.Mounts = append(info.Mounts, Mount{
+					Source:      m.HostPath,
+					Destination: m.ContainerPath,
+					ReadOnly:    m.Readonly,
+				})
+			}
+		}
+
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+// List the Kubernetes pods scheduled on this node.
+func ListPods(ctx context.Context, opts Options) ([]*PodInfo, error) {
+	if opts.PreventExecve {
+		return nil, errors.New("Not allowed to execve by configuration.")
+	}
+
+	list := &criPodList{}
+	err := crictl(ctx, list, "pods", "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*PodInfo
+	for _, p := range list.Items {
+		result = append(result, &PodInfo{
+			ID:        p.Id,
+			Name:      p.Metadata.Name,
+			Namespace: p.Metadata.Namespace,
+			UID:       p.Metadata.Uid,
+			State:     strings.TrimPrefix(p.State, "SANDBOX_"),
+			Created:   parseCRITime(p.CreatedAt),
+			Labels:    p.Labels,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,269 @@
+package container
+
+// Discover containers running on this host. We support the docker
+// engine directly through its API socket, and any CRI compatible
+// runtime (containerd, CRI-O) through crictl.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+var (
+	DockerSocket = "/var/run/docker.sock"
+
+	// Candidate CRI endpoints, tried in order.
+	CRIEndpoints = []string{
+		"unix:///run/containerd/containerd.sock",
+		"unix:///var/run/crio/crio.sock",
+		"unix:///var/run/cri-dockerd.sock",
+	}
+)
+
+type Mount struct {
+	Source      string `json:"Source"`
+	Destination string `json:"Destination"`
+	ReadOnly    bool   `json:"ReadOnly"`
+}
+
+type ContainerInfo struct {
+	ID          string            `json:"ID"`
+	Name        string            `json:"Name"`
+	Runtime     string            `json:"Runtime"`
+	Image       string            `json:"Image"`
+	ImageDigest string            `json:"ImageDigest"`
+	State       string            `json:"State"`
+	Pid         int               `json:"Pid"`
+	PodID       string            `json:"PodID,omitempty"`
+	Created     time.Time         `json:"Created"`
+	Labels      map[string]string `json:"Labels"`
+	Mounts      []Mount           `json:"Mounts"`
+}
+
+type PodInfo struct {
+	ID        string            `json:"ID"`
+	Name      string            `json:"Name"`
+	Namespace string            `json:"Namespace"`
+	UID       string            `json:"UID"`
+	State     string            `json:"State"`
+	Created   time.Time         `json:"Created"`
+	Labels    map[string]string `json:"Labels"`
+}
+
+// Options control how discovery is done.
+type Options struct {
+	// If set we are not allowed to shell out to crictl.
+	PreventExecve bool
+}
+
+// List all containers from all available runtimes. Errors from
+// individual runtimes are ignored as long as one runtime answers.
+func ListContainers(ctx context.Context, opts Options) ([]*ContainerInfo, error) {
+	var result []*ContainerInfo
+	var last_err error
+	found := false
+
+	docker, err := listDockerContainers(ctx)
+	if err == nil {
+		found = true
+		result = append(result, docker...)
+	} else {
+		last_err = err
+	}
+
+	if !opts.PreventExecve {
+		cri, err := listCRIContainers(ctx)
+		if err == nil {
+			found = true
+			result = append(result, dedupContainers(result, cri)...)
+		} else {
+			last_err = err
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("No container runtime found: %w", last_err)
+	}
+
+	return result, nil
+}
+
+// Docker via cri-dockerd will show the same containers twice.
+func dedupContainers(
+	existing []*ContainerInfo, new_items []*ContainerInfo) []*ContainerInfo {
+	seen := make(map[string]bool)
+	for _, c := range existing {
+		seen[c.ID] = true
+	}
+
+	var result []*ContainerInfo
+	for _, c := range new_items {
+		if !seen[c.ID] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// Find a single container by its ID (or unique ID prefix) or name.
+func FindContainer(ctx context.Context,
+	opts Options, id string) (*ContainerInfo, error) {
+	containers, err := ListContainers(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *ContainerInfo
+	for _, c := range containers {
+		if c.ID == id || c.Name == id {
+			return c, nil
+		}
+
+		if len(id) >= 12 && len(c.ID) > len(id) && c.ID[:len(id)] == id {
+			if match != nil {
+				return nil, fmt.Errorf("Container id %v is ambiguous", id)
+			}
+			match = c
+		}
+	}
+
+	if match == nil {
+		return nil, fmt.Errorf("Container %v not found", id)
+	}
+	return match, nil
+}
+
+func dockerClient() *http.Client {
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", DockerSocket)
+			},
+		},
+	}
+}
+
+func dockerGet(ctx context.Context, client *http.Client,
+	path string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", "http://docker"+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Docker API %v: %v", path, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+type dockerContainer struct {
+	Id      string
+	Names   []string
+	Image   string
+	ImageID string
+	State   string
+	Created int64
+	Labels  map[string]string
+	Mounts  []struct {
+		Source      string
+		Destination string
+		RW          bool
+	}
+}
+
+type dockerInspect struct {
+	State struct {
+		Pid int
+	}
+}
+
+type dockerImage struct {
+	RepoDigests []string
+}
+
+func listDockerContainers(ctx context.Context) ([]*ContainerInfo, error) {
+	_, err := os.Stat(DockerSocket)
+	if err != nil {
+		return nil, err
+	}
+
+	client := dockerClient()
+
+	var containers []dockerContainer
+	err = dockerGet(ctx, client, "/containers/json", &containers)
+	if err != nil {
+		return nil, err
+	}
+
+	image_digests := make(map[string]string)
+
+	var result []*ContainerInfo
+	for _, c := range containers {
+		info := &ContainerInfo{
+			ID:          c.Id,
+			Runtime:     "docker",
+			Image:       c.Image,
+			ImageDigest: c.ImageID,
+			State:       c.State,
+			Created:     time.Unix(c.Created, 0).UTC(),
+			Labels:      c.Labels,
+		}
+
+		if len(c.Names) > 0 {
+			info.Name = trimSlash(c.Names[0])
+		}
+
+		for _, m := range c.Mounts {
+			info.Mounts = append(info.Mounts, Mount{
+				Source:      m.Source,
+				Destination: m.Destination,
+				ReadOnly:    !m.RW,
+			})
+		}
+
+		inspect := &dockerInspect{}
+		err := dockerGet(ctx, client, "/containers/"+c.Id+"/json", inspect)
+		if err == nil {
+			info.Pid = inspect.State.Pid
+		}
+
+		// Prefer the registry digest over the local image id.
+		digest, pres := image_digests[c.ImageID]
+		if !pres {
+			image := &dockerImage{}
+			err := dockerGet(ctx, client, "/images/"+c.ImageID+"/json", image)
+			if err == nil && len(image.RepoDigests) > 0 {
+				digest = image.RepoDigests[0]
+			}
+			image_digests[c.ImageID] = digest
+		}
+		if digest != "" {
+			info.ImageDigest = digest
+		}
+
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+func trimSlash(name string) string {
+	if len(name) > 0 && name[0] == '/' {
+		return name[1:]
+	}
+	return name
+}
@@ -0,0 +1,192 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+const (
+	webId = "0123456789abcdef0123"
+	dbId  = "0123456789abffff0000"
+)
+
+// Serves the parts of the docker engine API we use on a unix socket.
+type fakeDocker struct {
+	containers []dockerContainer
+	pids       map[string]int
+	digests    map[string][]string
+}
+
+func (self *fakeDocker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var result interface{}
+
+	switch {
+	case r.URL.Path == "/containers/json":
+		result = self.containers
+
+	case strings.HasPrefix(r.URL.Path, "/containers/"):
+		id := strings.TrimSuffix(
+			strings.TrimPrefix(r.URL.Path, "/containers/"), "/json")
+		pid, pres := self.pids[id]
+		if !pres {
+			http.NotFound(w, r)
+			return
+		}
+		inspect := &dockerInspect{}
+		inspect.State.Pid = pid
+		result = inspect
+
+	case strings.HasPrefix(r.URL.Path, "/images/"):
+		id := strings.TrimSuffix(
+			strings.TrimPrefix(r.URL.Path, "/images/"), "/json")
+		result = &dockerImage{RepoDigests: self.digests[id]}
+
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+type DiscoveryTestSuite struct {
+	suite.Suite
+
+	ctx    context.Context
+	cancel func()
+	tmpdir string
+	server *http.Server
+
+	docker_socket string
+	cri_endpoints []string
+}
+
+func (self *DiscoveryTestSuite) SetupTest() {
+	var err error
+	self.tmpdir, err = os.MkdirTemp("", "container")
+	assert.NoError(self.T(), err)
+
+	socket := filepath.Join(self.tmpdir, "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	assert.NoError(self.T(), err)
+
+	web := dockerContainer{
+		Id:      webId,
+		Names:   []string{"/web"},
+		Image:   "nginx",
+		ImageID: "sha256:aaaa",
+		State:   "running",
+		Created: 1700000000,
+		Labels:  map[string]string{"app": "web"},
+	}
+	web.Mounts = append(web.Mounts, struct {
+		Source      string
+		Destination string
+		RW          bool
+	}{Source: "/data", Destination: "/var/www"})
+
+	// The database is not running so it has no pid.
+	db := dockerContainer{
+		Id:      dbId,
+		Names:   []string{"/db"},
+		Image:   "nginx",
+		ImageID: "sha256:aaaa",
+		State:   "exited",
+	}
+
+	// Serve the test process as the container so its root is ours.
+	self.server = &http.Server{Handler: &fakeDocker{
+		containers: []dockerContainer{web, db},
+		pids:       map[string]int{webId: os.Getpid(), dbId: 0},
+		digests: map[string][]string{
+			"sha256:aaaa": {"nginx@sha256:bbbb"},
+		},
+	}}
+	go self.server.Serve(listener)
+
+	self.docker_socket = DockerSocket
+	self.cri_endpoints = CRIEndpoints
+	DockerSocket = socket
+	CRIEndpoints = nil
+
+	self.ctx, self.cancel = context.WithTimeout(
+		context.Background(), 60*time.Second)
+}
+
+func (self *DiscoveryTestSuite) TearDownTest() {
+	self.cancel()
+	self.server.Close()
+	DockerSocket = self.docker_socket
+	CRIEndpoints = self.cri_endpoints
+	os.RemoveAll(self.tmpdir)
+}
+
+func (self *DiscoveryTestSuite) TestListContainers() {
+	containers, err := ListContainers(self.ctx, Options{})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 2, len(containers))
+
+	web := containers[0]
+	assert.Equal(self.T(), webId, web.ID)
+	assert.Equal(self.T(), "web", web.Name)
+	assert.Equal(self.T(), "docker", web.Runtime)
+	assert.Equal(self.T(), "nginx@sha256:bbbb", web.ImageDigest)
+	assert.Equal(self.T(), os.Getpid(), web.Pid)
+	assert.Equal(self.T(), time.Unix(1700000000, 0).UTC(), web.Created)
+	assert.Equal(self.T(), []Mount{{
+		Source: "/data", Destination: "/var/www", ReadOnly: true,
+	}}, web.Mounts)
+
+	assert.Equal(self.T(), "db", containers[1].Name)
+	assert.Equal(self.T(), 0, containers[1].Pid)
+
+	// Without any runtime we fail.
+	DockerSocket = filepath.Join(self.tmpdir, "missing.sock")
+	_, err = ListContainers(self.ctx, Options{})
+	assert.ErrorContains(self.T(), err, "No container runtime found")
+}
+
+func (self *DiscoveryTestSuite) TestFindContainer() {
+	for _, id := range []string{"web", webId, webId[:13]} {
+		c, err := FindContainer(self.ctx, Options{}, id)
+		assert.NoError(self.T(), err)
+		assert.Equal(self.T(), webId, c.ID)
+	}
+
+	// Both containers share this prefix.
+	_, err := FindContainer(self.ctx, Options{}, webId[:12])
+	assert.ErrorContains(self.T(), err, "ambiguous")
+
+	// Prefixes must be at least 12 characters.
+	_, err = FindContainer(self.ctx, Options{}, webId[:6])
+	assert.ErrorContains(self.T(), err, "not found")
+}
+
+func TestDiscovery(t *testing.T) {
+	suite.Run(t, &DiscoveryTestSuite{})
+}
+
+func TestDedupContainers(t *testing.T) {
+	existing := []*ContainerInfo{{ID: "a"}, {ID: "b"}}
+	result := dedupContainers(existing, []*ContainerInfo{
+		{ID: "b"}, {ID: "c"},
+	})
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, "c", result[0].ID)
+}
+
+func TestParseCRITime(t *testing.T) {
+	assert.Equal(t, time.Unix(1700000000, 5).UTC(),
+		parseCRITime("1700000000000000005"))
+	assert.Equal(t, time.Time{}, parseCRITime("yesterday"))
+}
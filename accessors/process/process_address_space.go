@@ -155,11 +155,11 @@ func (self *ProcessReader) Seek(offset int64, whence int) (int64, error) {
 	return int64(self.offset), nil
 }
 
-func (self ProcessReader) Close() error {
+func (self *ProcessReader) Close() error {
 	return self.handle.Close()
 }
 
-func (self ProcessReader) Stat() (os.FileInfo, error) {
+func (self *ProcessReader) Stat() (os.FileInfo, error) {
 	full_path, _ := accessors.NewLinuxOSPath(fmt.Sprintf("%v", self.pid))
 	return &accessors.VirtualFileInfo{
 		Path:  full_path,
@@ -257,7 +257,7 @@ func init() {
 }
 
 var (
-	maps_regexp = regexp.MustCompile("(?P<Start>^[^-]+)-(?P<End>[^\\s]+)\\s+(?P<Perm>[^\\s]+)\\s+(?P<Size>[^\\s]+)\\s+[^\\s]+\\s+(?P<PermInt>[^\\s]+)\\s+(?P<Filename>.+?)(?P<Deleted> \\(deleted\\))?$")
+	maps_regexp = regexp.MustCompile("(?P<Start>^[^-]+)-(?P<End>[^\\s]+)\\s+(?P<Perm>[^\\s]+)\\s+(?P<Size>[^\\s]+)\\s+[^\\s]+\\s+(?P<PermInt>[^\\s]+)\\s*(?P<Filename>.*?)(?P<Deleted> \\(deleted\\))?$")
 )
 
 func GetVads(pid uint64) ([]*uploads.Range, error) {
@@ -114,6 +114,12 @@ const (
 	// Allowed raw datastore access
 	DATASTORE_ACCESS
 
+	// Allowed to read the recordings of remote shell sessions.
+	READ_SHELL_RECORDINGS
+
+	// Allowed to add, update and delete secrets.
+	SECRETS_MANAGER
+
 	// When adding new permission - update CheckAccess,
 	// GetRolePermissions and acl.proto
 )
@@ -160,6 +166,10 @@ func (self ACL_PERMISSION) String() string {
 		return "PREPARE_RESULTS"
 	case DATASTORE_ACCESS:
 		return "DATASTORE_ACCESS"
+	case READ_SHELL_RECORDINGS:
+		return "READ_SHELL_RECORDINGS"
+	case SECRETS_MANAGER:
+		return "SECRETS_MANAGER"
 
 	}
 	return fmt.Sprintf("%d", self)
@@ -208,6 +218,10 @@ func GetPermission(name string) ACL_PERMISSION {
 		return PREPARE_RESULTS
 	case "DATASTORE_ACCESS":
 		return DATASTORE_ACCESS
+	case "READ_SHELL_RECORDINGS":
+		return READ_SHELL_RECORDINGS
+	case "SECRETS_MANAGER":
+		return SECRETS_MANAGER
 
 	}
 	return NO_PERMISSIONS
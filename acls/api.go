@@ -4,4 +4,10 @@ import "errors"
 
 var (
 	PermissionDenied = errors.New("PermissionDenied")
+
+	// The operation must be approved by a second user first.
+	ApprovalRequired = errors.New("ApprovalRequired")
+
+	// The client's enrollment has not been approved yet.
+	EnrollmentPending = errors.New("EnrollmentPending")
 )
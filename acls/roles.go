@@ -32,6 +32,8 @@ var (
 		"MACHINE_STATE",
 		"PREPARE_RESULTS",
 		"DATASTORE_ACCESS",
+		"READ_SHELL_RECORDINGS",
+		"SECRETS_MANAGER",
 	}
 )
 
@@ -100,6 +102,14 @@ func DescribePermissions(token *acl_proto.ApiClientACL) []string {
 		result = append(result, "DATASTORE_ACCESS")
 	}
 
+	if token.ReadShellRecordings {
+		result = append(result, "READ_SHELL_RECORDINGS")
+	}
+
+	if token.SecretsManager {
+		result = append(result, "SECRETS_MANAGER")
+	}
+
 	return result
 }
 
@@ -143,6 +153,10 @@ func SetTokenPermission(
 			token.PrepareResults = true
 		case "DATASTORE_ACCESS":
 			token.DatastoreAccess = true
+		case "READ_SHELL_RECORDINGS":
+			token.ReadShellRecordings = true
+		case "SECRETS_MANAGER":
+			token.SecretsManager = true
 
 		default:
 			return errors.New("Unknown permission")
@@ -180,6 +194,7 @@ func GetRolePermissions(
 			result.FilesystemWrite = true
 			result.MachineState = true
 			result.PrepareResults = true
+			result.SecretsManager = true
 
 			// An administrator for the root org is allowed to
 			// manipulate orgs.
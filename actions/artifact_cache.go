@@ -0,0 +1,191 @@
+// The client caches the artifacts the server sends as dependencies of
+// a collection. Later collections may pin a dependency to a cached
+// version, in which case the server only sends a reference and the
+// client runs its cached copy. Since the cached definition carries
+// the tool hashes, pinned tools are verified without needing the
+// server.
+
+package actions
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"www.velocidex.com/golang/velociraptor/artifacts"
+	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	// How many versions of each artifact we keep.
+	maxCachedVersions = 5
+)
+
+var (
+	artifact_cache_mu sync.Mutex
+)
+
+func artifactCachePath(config_obj *config_proto.Config) (string, error) {
+	if config_obj.Client == nil {
+		return "", fmt.Errorf("Client not configured")
+	}
+
+	if config_obj.Client.ArtifactCachePath != "" {
+		return os.ExpandEnv(config_obj.Client.ArtifactCachePath), nil
+	}
+
+	writeback, err := config.WritebackLocation(config_obj.Client)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(writeback, filepath.Ext(writeback)) + ".artifacts", nil
+}
+
+func cachedArtifactPrefix(name string) string {
+	return utils.SanitizeString(name) + "@"
+}
+
+func cachedArtifactFilename(dir, name, version string) string {
+	return filepath.Join(dir, cachedArtifactPrefix(name)+
+		utils.SanitizeString(version)+".bin")
+}
+
+// Replace pinned references with the cached versions and cache all
+// other versioned artifacts for next time.
+func resolveArtifacts(
+	config_obj *config_proto.Config,
+	in []*artifacts_proto.Artifact) ([]*artifacts_proto.Artifact, error) {
+
+	artifact_cache_mu.Lock()
+	defer artifact_cache_mu.Unlock()
+
+	dir, err := artifactCachePath(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.ClientComponent)
+	result := make([]*artifacts_proto.Artifact, 0, len(in))
+	for _, artifact := range in {
+		if artifact.Pinned {
+			cached, err := readCachedArtifact(dir, artifact.Name, artifact.Version)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Pinned artifact %v version %v is not available: %w",
+					artifact.Name, artifact.Version, err)
+			}
+			result = append(result, cached)
+			continue
+		}
+
+		if artifact.Version != "" {
+			err := writeCachedArtifact(dir, artifact)
+			if err != nil {
+				logger.Error("Unable to cache artifact %v: %v",
+					artifact.Name, err)
+			}
+		}
+		result = append(result, artifact)
+	}
+
+	return result, nil
+}
+
+func readCachedArtifact(dir, name, version string) (
+	*artifacts_proto.Artifact, error) {
+	filename := cachedArtifactFilename(dir, name, version)
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &artifacts_proto.Artifact{}
+	err = proto.Unmarshal(data, result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the cached copy was not modified.
+	if result.Name != name || artifacts.ArtifactVersion(result) != version {
+		return nil, fmt.Errorf("Cached artifact %v is corrupted", filename)
+	}
+
+	// Keep recently used versions in the cache.
+	now := utils.GetTime().Now()
+	_ = os.Chtimes(filename, now, now)
+
+	return result, nil
+}
+
+func writeCachedArtifact(dir string, artifact *artifacts_proto.Artifact) error {
+	if artifacts.ArtifactVersion(artifact) != artifact.Version {
+		return fmt.Errorf("Version %v does not match the definition",
+			artifact.Version)
+	}
+
+	filename := cachedArtifactFilename(dir, artifact.Name, artifact.Version)
+	_, err := os.Stat(filename)
+	if err == nil {
+		// Already cached - just mark it as recently used.
+		now := utils.GetTime().Now()
+		return os.Chtimes(filename, now, now)
+	}
+
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	serialized, err := proto.Marshal(artifact)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(filename, serialized, 0600)
+	if err != nil {
+		return err
+	}
+
+	return pruneCachedArtifact(dir, artifact.Name)
+}
+
+// Remove the least recently used versions of the artifact.
+func pruneCachedArtifact(dir, name string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	prefix := cachedArtifactPrefix(name)
+	versions := []os.FileInfo{}
+	for _, f := range files {
+		if strings.HasPrefix(f.Name(), prefix) {
+			versions = append(versions, f)
+		}
+	}
+
+	if len(versions) <= maxCachedVersions {
+		return nil
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].ModTime().After(versions[j].ModTime())
+	})
+
+	for _, f := range versions[maxCachedVersions:] {
+		err := os.Remove(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package actions
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"www.velocidex.com/golang/velociraptor/artifacts"
+	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+	"www.velocidex.com/golang/velociraptor/config"
+)
+
+func makeVersionedArtifact(export string) *artifacts_proto.Artifact {
+	result := &artifacts_proto.Artifact{
+		Name:   "Custom.Dependency",
+		Export: export,
+	}
+	result.Version = artifacts.ArtifactVersion(result)
+	return result
+}
+
+func TestArtifactCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "artifact_cache_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	config_obj := config.GetDefaultConfig()
+	config_obj.Client.ArtifactCachePath = dir
+
+	artifact := makeVersionedArtifact("LET X = 1")
+	pinned := &artifacts_proto.Artifact{
+		Name:    artifact.Name,
+		Version: artifact.Version,
+		Pinned:  true,
+	}
+
+	// Pinning a version the client never received fails.
+	_, err = resolveArtifacts(config_obj, []*artifacts_proto.Artifact{pinned})
+	assert.Error(t, err)
+
+	// Receiving the full definition caches it.
+	resolved, err := resolveArtifacts(config_obj,
+		[]*artifacts_proto.Artifact{artifact})
+	assert.NoError(t, err)
+	assert.Equal(t, artifact, resolved[0])
+
+	// Now the pinned reference resolves to the cached definition.
+	resolved, err = resolveArtifacts(config_obj,
+		[]*artifacts_proto.Artifact{pinned})
+	assert.NoError(t, err)
+	assert.Equal(t, "LET X = 1", resolved[0].Export)
+
+	// A modified cache file is rejected.
+	filename := cachedArtifactFilename(dir, artifact.Name, artifact.Version)
+	modified := makeVersionedArtifact("LET X = 2")
+	modified.Version = artifact.Version
+	err = writeCachedArtifact(dir, modified)
+	assert.Error(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(filename, []byte("garbage"), 0600))
+	_, err = resolveArtifacts(config_obj, []*artifacts_proto.Artifact{pinned})
+	assert.Error(t, err)
+}
+
+func TestArtifactCachePruning(t *testing.T) {
+	dir, err := ioutil.TempDir("", "artifact_cache_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Cache more versions than we keep. Each version is used a
+	// minute after the previous one.
+	start := time.Now().Add(-time.Hour)
+	versions := []string{}
+	for i := 0; i <= maxCachedVersions; i++ {
+		artifact := makeVersionedArtifact(fmt.Sprintf("LET X = %v", i))
+		assert.NoError(t, writeCachedArtifact(dir, artifact))
+
+		filename := cachedArtifactFilename(dir, artifact.Name, artifact.Version)
+		used := start.Add(time.Duration(i) * time.Minute)
+		assert.NoError(t, os.Chtimes(filename, used, used))
+		versions = append(versions, artifact.Version)
+	}
+
+	assert.NoError(t, pruneCachedArtifact(dir, "Custom.Dependency"))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	assert.NoError(t, err)
+	assert.Equal(t, maxCachedVersions, len(files))
+
+	// The least recently used version is removed.
+	_, err = readCachedArtifact(dir, "Custom.Dependency", versions[0])
+	assert.Error(t, err)
+
+	_, err = readCachedArtifact(dir, "Custom.Dependency", versions[1])
+	assert.NoError(t, err)
+}
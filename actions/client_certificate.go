@@ -0,0 +1,29 @@
+package actions
+
+import (
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
+)
+
+// Store the TLS client certificate issued by the server in the
+// writeback so it is presented on the next connection.
+func StoreClientCertificate(
+	config_obj *config_proto.Config,
+	certificate *crypto_proto.Certificate) error {
+
+	writeback, err := config.GetWriteback(config_obj.Client)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the certificate is for our key.
+	_, err = crypto_utils.GetWritebackTLSCertificate(writeback, certificate.Pem)
+	if err != nil {
+		return err
+	}
+
+	writeback.ClientCertificate = string(certificate.Pem)
+	return config.UpdateWriteback(config_obj.Client, writeback)
+}
@@ -95,6 +95,10 @@ func (self *EventTable) equal(events []*actions_proto.VQLCollectorArgs) bool {
 			}
 		}
 
+		if lhs.SchedulePeriod != rhs.SchedulePeriod {
+			return false
+		}
+
 		if len(lhs.Env) != len(rhs.Env) {
 			return false
 		}
@@ -230,6 +234,14 @@ func (self UpdateEventTable) Run(
 			defer table.wg.Done()
 			defer service_wg.Done()
 
+			// Scheduled collections are regular queries which are
+			// repeated.
+			if event.SchedulePeriod > 0 {
+				table.runScheduled(config_obj, query_responder,
+					artifact_name, event)
+				return
+			}
+
 			// Event tables never time out
 			if event.Timeout == 0 {
 				event.Timeout = 99999999
@@ -263,10 +275,14 @@ func update_writeback(
 	config_obj *config_proto.Config,
 	event_table *actions_proto.VQLEventTable) error {
 
+	writeback_mu.Lock()
+	defer writeback_mu.Unlock()
+
 	// Read the existing writeback file - it is ok if it does not
 	// exist yet.
 	writeback, _ := config.GetWriteback(config_obj.Client)
 	writeback.EventQueries = event_table
+	pruneScheduledCollections(writeback, event_table)
 
 	return config.UpdateWriteback(config_obj.Client, writeback)
 }
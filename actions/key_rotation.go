@@ -0,0 +1,183 @@
+package actions
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	// Receives the client id when the client needs to restart its
+	// comms (e.g. after it changed its key).
+	ClientRestart = make(chan string)
+
+	// Give the client time to flush messages encrypted with the old
+	// key before restarting.
+	keyRotationRestartDelay = 10 * time.Second
+)
+
+// Generate a new private key and build the request asking the server
+// to accept it. The new key is kept in the writeback until the server
+// acknowledges it, so repeated requests reuse the same key.
+func StartKeyRotation(
+	config_obj *config_proto.Config) (*crypto_proto.VeloMessage, error) {
+	writeback, err := config.GetWriteback(config_obj.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	if writeback.ClientId == "" {
+		return nil, errors.New("StartKeyRotation: Client is not enrolled")
+	}
+
+	// TPM keys can not be stolen so there is no need to rotate them.
+	if writeback.HardwareKeyName != "" {
+		return nil, errors.New("StartKeyRotation: Client key is held in the TPM")
+	}
+
+	if writeback.PendingPrivateKey == "" {
+		pem_str, err := crypto_utils.GeneratePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		writeback.PendingPrivateKey = string(pem_str)
+		err = config.UpdateWriteback(config_obj.Client, writeback)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	private_key, err := crypto_utils.ParseRsaPrivateKeyFromPemStr(
+		[]byte(writeback.PendingPrivateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	// The CSR is for our existing client id, not the id derived from
+	// the new key.
+	template := x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName: writeback.ClientId,
+		},
+		SignatureAlgorithm: x509.SHA256WithRSA,
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &template, private_key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &crypto_proto.VeloMessage{
+		SessionId: constants.KEY_ROTATION_WELL_KNOWN_FLOW,
+		KeyRotation: &crypto_proto.KeyRotation{
+			Csr: pem.EncodeToMemory(&pem.Block{
+				Type:  "CERTIFICATE REQUEST",
+				Bytes: csr,
+			}),
+		},
+		Urgent: true,
+	}, nil
+}
+
+// The server accepted our new key - switch to it and restart the
+// comms.
+func CompleteKeyRotation(
+	config_obj *config_proto.Config,
+	rotation *crypto_proto.KeyRotation) error {
+	writeback, err := config.GetWriteback(config_obj.Client)
+	if err != nil {
+		return err
+	}
+
+	if writeback.PendingPrivateKey == "" {
+		return errors.New("CompleteKeyRotation: No key rotation in progress")
+	}
+
+	private_key, err := crypto_utils.ParseRsaPrivateKeyFromPemStr(
+		[]byte(writeback.PendingPrivateKey))
+	if err != nil {
+		return err
+	}
+
+	if crypto_utils.ClientIDFromPublicKey(&private_key.PublicKey) != rotation.KeyId {
+		return errors.New("CompleteKeyRotation: Server accepted a different key")
+	}
+
+	writeback.PrivateKey = writeback.PendingPrivateKey
+	writeback.PendingPrivateKey = ""
+	writeback.KeyCreated = uint64(time.Now().Unix())
+	writeback.KeyRotated = true
+
+	// The TLS client certificate was issued for the old key.
+	writeback.ClientCertificate = ""
+
+	err = config.UpdateWriteback(config_obj.Client, writeback)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		time.Sleep(keyRotationRestartDelay)
+
+		select {
+		case ClientRestart <- writeback.ClientId:
+		default:
+		}
+	}()
+
+	return nil
+}
+
+// The server recognized this machine as a re-imaged client and
+// accepted our key for the machine's prior client id - take it over
+// and restart the comms.
+func AdoptClientId(
+	config_obj *config_proto.Config,
+	rotation *crypto_proto.KeyRotation) error {
+	writeback, err := config.GetWriteback(config_obj.Client)
+	if err != nil {
+		return err
+	}
+
+	if writeback.ClientId == "" {
+		return errors.New("AdoptClientId: Client is not enrolled")
+	}
+
+	if !utils.ValidateClientId(rotation.ClientId) {
+		return errors.New("AdoptClientId: Invalid client id")
+	}
+
+	// Our client id is no longer derived from our key.
+	writeback.ClientId = rotation.ClientId
+	writeback.KeyRotated = true
+
+	// The TLS client certificate was issued for the old client id.
+	writeback.ClientCertificate = ""
+
+	err = config.UpdateWriteback(config_obj.Client, writeback)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		time.Sleep(keyRotationRestartDelay)
+
+		select {
+		case ClientRestart <- writeback.ClientId:
+		default:
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package actions_test
+
+import (
+	"crypto/rsa"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"www.velocidex.com/golang/velociraptor/actions"
+	"www.velocidex.com/golang/velociraptor/config"
+	crypto_client "www.velocidex.com/golang/velociraptor/crypto/client"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+)
+
+func TestKeyRotation(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "")
+	require.NoError(t, err)
+	tmpfile.Close()
+	defer os.Remove(tmpfile.Name())
+
+	// The crypto manager needs a valid CA certificate.
+	os.Setenv("VELOCIRAPTOR_CONFIG", test_utils.SERVER_CONFIG)
+	config_obj, err := new(config.Loader).
+		WithEnvLiteralLoader("VELOCIRAPTOR_CONFIG").LoadAndValidate()
+	require.NoError(t, err)
+
+	config_obj.Client.WritebackLinux = tmpfile.Name()
+	config_obj.Client.WritebackWindows = tmpfile.Name()
+	config_obj.Client.WritebackDarwin = tmpfile.Name()
+
+	// Enrol the client with a key.
+	key, err := crypto_utils.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	private_key, err := crypto_utils.ParseRsaPrivateKeyFromPemStr(key)
+	require.NoError(t, err)
+	client_id := crypto_utils.ClientIDFromPublicKey(&private_key.PublicKey)
+
+	writeback, err := config.GetWriteback(config_obj.Client)
+	require.NoError(t, err)
+	writeback.PrivateKey = string(key)
+	writeback.ClientId = client_id
+	require.NoError(t, config.UpdateWriteback(config_obj.Client, writeback))
+
+	msg, err := actions.StartKeyRotation(config_obj)
+	require.NoError(t, err)
+
+	// The CSR is for our client id but a new key.
+	csr, err := crypto_utils.ParseX509CSRFromPemStr(msg.KeyRotation.Csr)
+	require.NoError(t, err)
+	assert.NoError(t, csr.CheckSignature())
+	assert.Equal(t, client_id, csr.Subject.CommonName)
+
+	pending_id := crypto_utils.ClientIDFromPublicKey(
+		csr.PublicKey.(*rsa.PublicKey))
+	assert.NotEqual(t, client_id, pending_id)
+
+	// Repeated requests reuse the pending key.
+	msg, err = actions.StartKeyRotation(config_obj)
+	require.NoError(t, err)
+	csr, err = crypto_utils.ParseX509CSRFromPemStr(msg.KeyRotation.Csr)
+	require.NoError(t, err)
+	assert.Equal(t, pending_id, crypto_utils.ClientIDFromPublicKey(
+		csr.PublicKey.(*rsa.PublicKey)))
+
+	// The server must acknowledge the right key.
+	err = actions.CompleteKeyRotation(config_obj,
+		&crypto_proto.KeyRotation{KeyId: client_id})
+	assert.Error(t, err)
+
+	err = actions.CompleteKeyRotation(config_obj,
+		&crypto_proto.KeyRotation{KeyId: pending_id})
+	require.NoError(t, err)
+
+	writeback, err = config.GetWriteback(config_obj.Client)
+	require.NoError(t, err)
+	assert.True(t, writeback.KeyRotated)
+	assert.Equal(t, "", writeback.PendingPrivateKey)
+	assert.Equal(t, client_id, writeback.ClientId)
+
+	// The crypto manager uses the new key but keeps the client id.
+	manager, err := crypto_client.NewClientCryptoManagerFromWriteback(
+		config_obj, writeback)
+	require.NoError(t, err)
+	assert.Equal(t, client_id, manager.ClientId())
+}
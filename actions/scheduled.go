@@ -0,0 +1,127 @@
+// Scheduled collections are regular (non event) queries in the event
+// table which the client runs every SchedulePeriod seconds by
+// itself. This allows clients which rarely connect to the server
+// (e.g. laptops) to still produce periodic snapshots.
+//
+// The results are sent through the monitoring responder so they are
+// kept in the local event buffer while the server is unreachable. The
+// time each collection last ran is kept in the writeback file so the
+// schedule survives client restarts.
+
+package actions
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/responder"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	// Serialize updates to the writeback file from the event table.
+	writeback_mu sync.Mutex
+)
+
+func getScheduledLastRun(
+	config_obj *config_proto.Config, name string) uint64 {
+	writeback_mu.Lock()
+	defer writeback_mu.Unlock()
+
+	writeback, err := config.GetWriteback(config_obj.Client)
+	if err != nil {
+		return 0
+	}
+	return writeback.ScheduledCollections[name]
+}
+
+func setScheduledLastRun(
+	config_obj *config_proto.Config, name string, last_run uint64) error {
+	writeback_mu.Lock()
+	defer writeback_mu.Unlock()
+
+	writeback, err := config.GetWriteback(config_obj.Client)
+	if err != nil {
+		return err
+	}
+
+	if writeback.ScheduledCollections == nil {
+		writeback.ScheduledCollections = make(map[string]uint64)
+	}
+	writeback.ScheduledCollections[name] = last_run
+
+	return config.UpdateWriteback(config_obj.Client, writeback)
+}
+
+// Forget the schedules of collections no longer in the event table.
+func pruneScheduledCollections(
+	writeback *config_proto.Writeback,
+	event_table *actions_proto.VQLEventTable) {
+	current := make(map[string]bool)
+	for _, event := range event_table.Event {
+		if event.SchedulePeriod > 0 {
+			current[GetQueryName(event.Query)] = true
+		}
+	}
+
+	for name := range writeback.ScheduledCollections {
+		if !current[name] {
+			delete(writeback.ScheduledCollections, name)
+		}
+	}
+}
+
+// Run the query every period until the event table is closed. If the
+// query is overdue (e.g. the client was off) it runs immediately.
+func (self *EventTable) runScheduled(
+	config_obj *config_proto.Config,
+	query_responder responder.Responder,
+	name string,
+	event *actions_proto.VQLCollectorArgs) {
+
+	logger := logging.GetLogger(config_obj, &logging.ClientComponent)
+	period := time.Duration(event.SchedulePeriod) * time.Second
+
+	for {
+		now := utils.GetTime().Now()
+		last_run := getScheduledLastRun(config_obj, name)
+		if last_run > 0 {
+			delay := time.Unix(int64(last_run), 0).Add(period).Sub(now)
+			if delay > period {
+				// The clock went backwards - wait at most one period.
+				delay = period
+			}
+
+			if delay > 0 {
+				select {
+				case <-self.Ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+				now = utils.GetTime().Now()
+			}
+		}
+
+		// Record the run before starting so a crash during the
+		// collection does not cause it to run again immediately.
+		err := setScheduledLastRun(config_obj, name, uint64(now.Unix()))
+		if err != nil {
+			logger.Error("Scheduled collection %v: %v", name, err)
+		}
+
+		logger.Info("<green>Starting</> scheduled collection %s", name)
+		VQLClientAction{}.StartQuery(config_obj, self.Ctx, query_responder,
+			proto.Clone(event).(*actions_proto.VQLCollectorArgs))
+
+		select {
+		case <-self.Ctx.Done():
+			return
+		default:
+		}
+	}
+}
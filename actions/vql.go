@@ -121,8 +121,14 @@ func (self VQLClientAction) StartQuery(
 		return
 	}
 
+	dependencies, err := resolveArtifacts(config_obj, arg.Artifacts)
+	if err != nil {
+		responder.RaiseError(ctx, fmt.Sprintf("%v", err))
+		return
+	}
+
 	repository := manager.NewRepository()
-	for _, artifact := range arg.Artifacts {
+	for _, artifact := range dependencies {
 		artifact.BuiltIn = false
 		_, err := repository.LoadProto(artifact, true /* validate */)
 		if err != nil {
@@ -133,7 +139,8 @@ func (self VQLClientAction) StartQuery(
 	}
 
 	uploader := &uploads.VelociraptorUploader{
-		Responder: responder,
+		Responder:     responder,
+		PriorityClass: arg.PriorityClass,
 	}
 
 	builder := services.ScopeBuilder{
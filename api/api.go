@@ -157,6 +157,10 @@ func (self *ApiServer) CollectArtifact(
 
 	defer Instrument("CollectArtifact")()
 
+	ctx, span := startSpan(ctx, "CollectArtifact")
+	defer span.End()
+	span.SetAttribute("client_id", in.ClientId)
+
 	result := &flows_proto.ArtifactCollectorResponse{Request: in}
 
 	users := services.GetUserManager()
@@ -204,10 +208,12 @@ func (self *ApiServer) CollectArtifact(
 	flow_id, err := launcher.ScheduleArtifactCollection(
 		ctx, org_config_obj, acl_manager, repository, in, nil)
 	if err != nil {
+		span.SetError(err)
 		return nil, Status(self.verbose, err)
 	}
 
 	result.FlowId = flow_id
+	span.SetAttribute("flow_id", flow_id)
 
 	// Log this event as an Audit event.
 	logging.LogAudit(org_config_obj, principal, "ScheduleFlow",
@@ -369,6 +375,14 @@ func (self *ApiServer) GetFlowDetails(
 			"User is not allowed to launch flows.")
 	}
 
+	err = checkResultScope(ctx, org_config_obj, principal, resultScope{
+		ClientId: in.ClientId,
+		FlowId:   in.FlowId,
+	})
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
 	launcher, err := services.GetLauncher(org_config_obj)
 	if err != nil {
 		return nil, Status(self.verbose, err)
@@ -400,6 +414,14 @@ func (self *ApiServer) GetFlowRequests(
 			"User is not allowed to view flows.")
 	}
 
+	err = checkResultScope(ctx, org_config_obj, principal, resultScope{
+		ClientId: in.ClientId,
+		FlowId:   in.FlowId,
+	})
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
 	launcher, err := services.GetLauncher(org_config_obj)
 	if err != nil {
 		return nil, Status(self.verbose, err)
@@ -652,6 +674,16 @@ func (self *ApiServer) GetTable(
 			"User is not allowed to view results.")
 	}
 
+	err = checkResultScope(ctx, org_config_obj, principal, resultScope{
+		ClientId: in.ClientId,
+		FlowId:   in.FlowId,
+		HuntId:   in.HuntId,
+		Artifact: in.Artifact,
+	})
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
 	result, err := tables.GetTable(ctx, org_config_obj, in)
 	if err != nil {
 		return nil, Status(self.verbose, err)
@@ -787,7 +819,7 @@ func (self *ApiServer) SetArtifactFile(
 			"User is not allowed to modify artifacts (%v).", permissions))
 	}
 
-	definition, err := setArtifactFile(org_config_obj, principal, in, "")
+	definition, err := setArtifactFile(ctx, org_config_obj, principal, in, "")
 	if err != nil {
 		message := &api_proto.APIResponse{
 			Error:        true,
@@ -849,6 +881,16 @@ func (self *ApiServer) Query(
 			principal, permissions))
 	}
 
+	queries := make([]string, 0, len(in.Query))
+	for _, query := range in.Query {
+		queries = append(queries, query.VQL)
+	}
+
+	logging.LogAudit(org_config_obj, principal, "Query",
+		logrus.Fields{
+			"queries": queries,
+		})
+
 	return streamQuery(stream.Context(), org_config_obj, in, stream, principal)
 }
 
@@ -1111,7 +1153,9 @@ func startAPIServer(
 
 	creds := credentials.NewTLS(tls_config)
 
-	grpcServer := grpc.NewServer(grpc.Creds(creds))
+	grpcServer := grpc.NewServer(grpc.Creds(creds),
+		grpc.UnaryInterceptor(auditUnaryInterceptor(config_obj)),
+		grpc.StreamInterceptor(auditStreamInterceptor(config_obj)))
 	api_proto.RegisterAPIServer(
 		grpcServer,
 		&ApiServer{
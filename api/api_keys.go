@@ -0,0 +1,131 @@
+package api
+
+import (
+	"github.com/sirupsen/logrus"
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/api_keys"
+)
+
+// Managing API keys grants their policy so it requires the
+// SERVER_ADMIN permission in the org.
+func (self *ApiServer) checkApiKeyAccess(ctx context.Context) (
+	string, *config_proto.Config, error) {
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return "", nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.SERVER_ADMIN
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return "", nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to manage API keys.")
+	}
+
+	return principal, org_config_obj, nil
+}
+
+func (self *ApiServer) GetApiKeys(
+	ctx context.Context,
+	in *emptypb.Empty) (*api_proto.ApiKeys, error) {
+
+	defer Instrument("GetApiKeys")()
+
+	_, org_config_obj, err := self.checkApiKeyAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := api_keys.ListApiKeys(org_config_obj)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	return &api_proto.ApiKeys{Items: items}, nil
+}
+
+func (self *ApiServer) CreateApiKey(
+	ctx context.Context,
+	in *api_proto.ApiKey) (*api_proto.ApiKey, error) {
+
+	defer Instrument("CreateApiKey")()
+
+	principal, org_config_obj, err := self.checkApiKeyAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := api_keys.CreateApiKey(ctx, org_config_obj, principal, in)
+	if err != nil {
+		return nil, InvalidStatus(err.Error())
+	}
+
+	logging.LogAudit(org_config_obj, principal, "CreateApiKey",
+		logrus.Fields{
+			"key_id":      key.KeyId,
+			"description": key.Description,
+			"permissions": acls.DescribePermissions(key.Policy),
+			"expires":     key.Expires,
+		})
+
+	return key, nil
+}
+
+func (self *ApiServer) RotateApiKey(
+	ctx context.Context,
+	in *api_proto.ApiKey) (*api_proto.ApiKey, error) {
+
+	defer Instrument("RotateApiKey")()
+
+	principal, org_config_obj, err := self.checkApiKeyAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := api_keys.RotateApiKey(org_config_obj, in.KeyId, in.GracePeriod)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	logging.LogAudit(org_config_obj, principal, "RotateApiKey",
+		logrus.Fields{
+			"key_id":       in.KeyId,
+			"grace_period": in.GracePeriod,
+		})
+
+	return key, nil
+}
+
+func (self *ApiServer) DeleteApiKey(
+	ctx context.Context,
+	in *api_proto.ApiKey) (*emptypb.Empty, error) {
+
+	defer Instrument("DeleteApiKey")()
+
+	principal, org_config_obj, err := self.checkApiKeyAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = api_keys.DeleteApiKey(ctx, org_config_obj, in.KeyId)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	logging.LogAudit(org_config_obj, principal, "DeleteApiKey",
+		logrus.Fields{
+			"key_id": in.KeyId,
+		})
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"github.com/sirupsen/logrus"
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/approvals"
+)
+
+func (self *ApiServer) GetApprovals(
+	ctx context.Context,
+	in *api_proto.GetApprovalsRequest) (*api_proto.ApprovalRequests, error) {
+
+	defer Instrument("GetApprovals")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.READ_RESULTS
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to view approval requests.")
+	}
+
+	items, err := approvals.ListApprovals(org_config_obj, in.State)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	return &api_proto.ApprovalRequests{Items: items}, nil
+}
+
+func (self *ApiServer) RequestApproval(
+	ctx context.Context,
+	in *api_proto.ApprovalRequest) (*api_proto.ApprovalRequest, error) {
+
+	defer Instrument("RequestApproval")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.COLLECT_CLIENT
+	if in.Collection.GetClientId() == "server" {
+		permissions = acls.COLLECT_SERVER
+	}
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to launch flows.")
+	}
+
+	request, err := approvals.RequestApproval(org_config_obj, principal, in)
+	if err != nil {
+		return nil, InvalidStatus(err.Error())
+	}
+
+	logging.LogAudit(org_config_obj, principal, "RequestApproval",
+		logrus.Fields{
+			"approval_id":   request.ApprovalId,
+			"justification": request.Justification,
+			"reasons":       request.Reasons,
+			"details":       json.MustMarshalString(request),
+		})
+
+	return request, nil
+}
+
+func (self *ApiServer) DecideApproval(
+	ctx context.Context,
+	in *api_proto.ApprovalDecision) (*api_proto.ApprovalRequest, error) {
+
+	defer Instrument("DecideApproval")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	// The approvals service checks the approver may launch the
+	// operation.
+	request, err := approvals.Decide(ctx, org_config_obj, principal, in)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	operation := "RejectOperation"
+	if in.Approve {
+		operation = "ApproveOperation"
+	}
+
+	logging.LogAudit(org_config_obj, principal, operation,
+		logrus.Fields{
+			"approval_id": request.ApprovalId,
+			"requester":   request.Requester,
+			"comment":     request.Comment,
+			"state":       request.State,
+			"flow_id":     request.FlowId,
+			"hunt_id":     request.HuntId,
+			"error":       request.Error,
+		})
+
+	return request, nil
+}
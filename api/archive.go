@@ -0,0 +1,136 @@
+package api
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/sirupsen/logrus"
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/flows/archive"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// Export a flow or hunt into an archive in the downloads area so it
+// can be imported into another server.
+func (self *ApiServer) ExportArchive(
+	ctx context.Context,
+	in *api_proto.ExportArchiveRequest) (*api_proto.CreateDownloadResponse, error) {
+
+	defer Instrument("ExportArchive")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.PREPARE_RESULTS
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf(
+			"User is not allowed to create downloads (%v).", permissions))
+	}
+
+	var archive_path api.FSPathSpec
+	if in.HuntId != "" {
+		archive_path = paths.NewHuntPathManager(in.HuntId).GetArchiveFile()
+	} else if in.ClientId != "" && in.FlowId != "" {
+		archive_path = paths.NewFlowPathManager(
+			in.ClientId, in.FlowId).GetArchiveFile()
+	} else {
+		return nil, InvalidStatus("Either a hunt or a flow must be specified")
+	}
+
+	logging.LogAudit(org_config_obj, principal, "ExportArchive",
+		logrus.Fields{"request": in})
+
+	file_store_factory := file_store.GetFileStore(org_config_obj)
+	fd, err := file_store_factory.WriteFile(archive_path)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	defer fd.Close()
+
+	err = fd.Truncate()
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	if in.HuntId != "" {
+		err = archive.ExportHunt(ctx, org_config_obj, fd, in.HuntId)
+	} else {
+		err = archive.ExportFlow(ctx, org_config_obj, fd,
+			in.ClientId, in.FlowId)
+	}
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	return &api_proto.CreateDownloadResponse{
+		VfsPath: archive_path.AsClientPath(),
+	}, nil
+}
+
+// Import an archive previously uploaded through the form upload
+// handler.
+func (self *ApiServer) ImportArchive(
+	ctx context.Context,
+	in *api_proto.ImportArchiveRequest) (*api_proto.ImportArchiveResponse, error) {
+
+	defer Instrument("ImportArchive")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.COLLECT_SERVER
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to import archives.")
+	}
+
+	if in.Filename == "" {
+		return nil, InvalidStatus("Filename must be specified")
+	}
+
+	logging.LogAudit(org_config_obj, principal, "ImportArchive",
+		logrus.Fields{"request": in})
+
+	path_manager := paths.NewFormUploadPathManager(
+		org_config_obj, path.Base(in.Filename))
+
+	file_store_factory := file_store.GetFileStore(org_config_obj)
+	fd, err := file_store_factory.ReadFile(path_manager.Path())
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	defer fd.Close()
+
+	stat, err := fd.Stat()
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	result, err := archive.Import(ctx, org_config_obj,
+		utils.MakeReaderAtter(fd), stat.Size(),
+		archive.ImportOptions{Remap: in.Remap})
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/artifact_sync"
+)
+
+// Receives push webhooks from the artifact git repository. The
+// webhook is not authenticated as a user so it can only trigger a
+// sync - the changes still need to be approved. Requests must carry
+// the configured secret, either as a GitHub style HMAC signature or a
+// GitLab style token.
+func artifactRepoWebhookHandler(config_obj *config_proto.Config) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sync_config := config_obj.ArtifactRepoSync
+		if sync_config == nil || sync_config.WebhookSecret == "" {
+			returnError(w, http.StatusNotFound, "Webhook is not configured")
+			return
+		}
+
+		if r.Method != "POST" {
+			returnError(w, http.StatusMethodNotAllowed, "Only POST is allowed")
+			return
+		}
+
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, 10<<20))
+		if err != nil {
+			returnError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if !checkWebhookSecret(r, body, sync_config.WebhookSecret) {
+			logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+			logger.Error("ArtifactRepoWebhook: Invalid secret from %v",
+				r.RemoteAddr)
+			returnError(w, http.StatusForbidden, "Invalid secret")
+			return
+		}
+
+		artifact_sync.Notify()
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func checkWebhookSecret(r *http.Request, body []byte, secret string) bool {
+	token := r.Header.Get("X-Gitlab-Token")
+	if token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+	}
+
+	signature := strings.TrimPrefix(
+		r.Header.Get("X-Hub-Signature-256"), "sha256=")
+	decoded, err := hex.DecodeString(signature)
+	if err != nil || len(decoded) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(decoded, mac.Sum(nil))
+}
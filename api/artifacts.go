@@ -19,6 +19,8 @@ package api
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"regexp"
 	"strings"
@@ -33,9 +35,11 @@ import (
 	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/constants"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
 	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
 	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/artifact_tests"
 	"www.velocidex.com/golang/velociraptor/third_party/zip"
 	"www.velocidex.com/golang/velociraptor/utils"
 )
@@ -107,7 +111,8 @@ func ensureArtifactPrefix(definition, prefix string) string {
 		})
 }
 
-func setArtifactFile(config_obj *config_proto.Config, principal string,
+func setArtifactFile(ctx context.Context,
+	config_obj *config_proto.Config, principal string,
 	in *api_proto.SetArtifactRequest,
 	required_prefix string) (
 	*artifacts_proto.Artifact, error) {
@@ -138,6 +143,12 @@ func setArtifactFile(config_obj *config_proto.Config, principal string,
 			principal, artifact_definition.Name)
 
 	case api_proto.SetArtifactRequest_SET:
+		// Artifacts with failing tests are not activated.
+		err = artifact_tests.CheckArtifact(ctx, config_obj, principal, in.Artifact)
+		if err != nil {
+			return nil, InvalidStatus(err.Error())
+		}
+
 		return manager.SetArtifactFile(
 			config_obj, principal, in.Artifact, required_prefix)
 	}
@@ -318,9 +329,21 @@ func (self *ApiServer) LoadArtifactPack(
 			"User is not allowed to upload artifact packs.")
 	}
 
+	verification, err := checkArtifactPackSignature(org_config_obj, in.Data)
+	if err != nil {
+		logging.LogAudit(org_config_obj, principal, "LoadArtifactPack",
+			logrus.Fields{
+				"error": err.Error(),
+			})
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
 	prefix := constants.ARTIFACT_PACK_NAME_PREFIX
 
-	result := &api_proto.LoadArtifactPackResponse{}
+	result := &api_proto.LoadArtifactPackResponse{
+		Signer:  verification.Signer,
+		Trusted: verification.Trusted,
+	}
 	buffer := bytes.NewReader(in.Data)
 	zip_reader, err := zip.NewReader(buffer, int64(len(in.Data)))
 	if err != nil {
@@ -352,12 +375,13 @@ func (self *ApiServer) LoadArtifactPack(
 				Artifact: artifact_definition,
 			}
 
-			definition, err := setArtifactFile(
+			definition, err := setArtifactFile(ctx,
 				org_config_obj, principal, request, prefix)
 			if err == nil {
 				logging.LogAudit(org_config_obj, principal, "LoadArtifactPack",
 					logrus.Fields{
 						"artifact": definition.Name,
+						"signer":   verification.Signer,
 						"details":  request.Artifact,
 					})
 
@@ -375,6 +399,37 @@ func (self *ApiServer) LoadArtifactPack(
 	return result, nil
 }
 
+// Verify the pack's signature and enforce the server's signing
+// policy. Packs with invalid signatures are always refused.
+func checkArtifactPackSignature(config_obj *config_proto.Config,
+	data []byte) (*crypto_utils.PackVerification, error) {
+	var trusted_keys []string
+	require_signed := false
+	if config_obj.Defaults != nil {
+		trusted_keys = config_obj.Defaults.ArtifactPackSigningKeys
+		require_signed = config_obj.Defaults.RequireSignedArtifactPacks
+	}
+
+	verification, err := crypto_utils.VerifyArtifactPack(data, trusted_keys)
+	if err != nil {
+		return nil, err
+	}
+
+	if require_signed {
+		if !verification.Signed {
+			return nil, errors.New("Artifact pack is not signed")
+		}
+
+		if !verification.Trusted {
+			return nil, fmt.Errorf(
+				"Artifact pack is signed by an untrusted signer %v",
+				verification.Signer)
+		}
+	}
+
+	return verification, nil
+}
+
 // MakeCollectorRequest is a convenience function for creating
 // flows_proto.ArtifactCollectorArgs protobufs.
 func MakeCollectorRequest(
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/audit"
+)
+
+// Record every API call in the audit log.
+func auditAPICall(ctx context.Context,
+	config_obj *config_proto.Config, method string, err error) {
+
+	audit_service := audit.GetAuditService()
+	if audit_service == nil || !audit_service.ShouldAuditMethod(method) {
+		return
+	}
+
+	// Calls from unknown users are audited against the root org.
+	principal := ""
+	users := services.GetUserManager()
+	user_record, org_config_obj, user_err := users.GetUserFromContext(ctx)
+	if user_err == nil {
+		principal = user_record.Name
+		config_obj = org_config_obj
+	}
+
+	logging.LogAudit(config_obj, principal, "APICall",
+		logrus.Fields{
+			"method": method,
+			"status": status.Code(err).String(),
+		})
+}
+
+func auditUnaryInterceptor(
+	config_obj *config_proto.Config) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		resp, err := handler(ctx, req)
+		auditAPICall(ctx, config_obj, info.FullMethod, err)
+		return resp, err
+	}
+}
+
+func auditStreamInterceptor(
+	config_obj *config_proto.Config) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		err := handler(srv, stream)
+		auditAPICall(stream.Context(), config_obj, info.FullMethod, err)
+		return err
+	}
+}
@@ -0,0 +1,71 @@
+package authenticators
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/api_keys"
+)
+
+// Get the API key from the Authorization header.
+func getApiKey(r *http.Request) (string, bool) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return token, api_keys.IsApiKey(token)
+}
+
+// Requests carrying an API key (Authorization: Bearer AK.XXXX...)
+// are authenticated by the key instead of the GUI
+// authenticator. Browsers do not send the key by themselves so these
+// requests skip the CSRF protection of the fallback handler too.
+func ApiKeyHandler(config_obj *config_proto.Config,
+	parent http.Handler, fallback http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := getApiKey(r)
+		if !ok {
+			fallback.ServeHTTP(w, r)
+			return
+		}
+
+		remote, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			remote = r.RemoteAddr
+		}
+
+		key, err := api_keys.Authenticate(token, remote)
+		if err != nil {
+			logging.LogAudit(config_obj, "", "Invalid API key",
+				logrus.Fields{
+					"remote": r.RemoteAddr,
+					"status": http.StatusUnauthorized,
+					"err":    err.Error(),
+				})
+
+			http.Error(w, "authorization failed", http.StatusUnauthorized)
+			return
+		}
+
+		// The key may only be used in the org it was created in.
+		r.Header.Set("Grpc-Metadata-Orgid", key.OrgId)
+
+		user_info := &api_proto.VelociraptorUser{
+			Name: key.KeyId,
+		}
+
+		// Must use json encoding because grpc can not handle
+		// binary data in metadata.
+		serialized, _ := json.Marshal(user_info)
+		ctx := context.WithValue(
+			r.Context(), constants.GRPC_USER_CONTEXT, string(serialized))
+
+		GetLoggingHandler(config_obj)(parent).ServeHTTP(
+			w, r.WithContext(ctx))
+	})
+}
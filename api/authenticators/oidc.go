@@ -158,6 +158,15 @@ func (self *OidcAuthenticator) oauthOidcCallback(
 			return
 		}
 
+		err = self.syncGroups(r.Context(), provider, oauthToken, userInfo)
+		if err != nil {
+			logging.GetLogger(self.config_obj, &logging.GUIComponent).
+				Error("can not synchronize OIDC groups for %v: %v",
+					userInfo.Email, err)
+			http.Redirect(w, r, self.base, http.StatusTemporaryRedirect)
+			return
+		}
+
 		cookie, err := getSignedJWTTokenCookie(
 			self.config_obj, self.authenticator,
 			&Claims{
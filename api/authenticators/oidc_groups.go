@@ -0,0 +1,116 @@
+package authenticators
+
+import (
+	"context"
+
+	oidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+	"www.velocidex.com/golang/velociraptor/acls"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/users"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// Replace the user's roles and org memberships with those granted
+// by their identity provider groups. This is a no-op unless group
+// mappings are configured.
+func (self *OidcAuthenticator) syncGroups(
+	ctx context.Context,
+	provider *oidc.Provider,
+	token *oauth2.Token,
+	user_info *oidc.UserInfo) error {
+
+	if len(self.authenticator.OidcGroupMappings) == 0 {
+		return nil
+	}
+
+	claim := self.authenticator.OidcGroupsClaim
+	if claim == "" {
+		claim = "groups"
+	}
+
+	var groups []string
+	claims := make(map[string]interface{})
+	if user_info.Claims(&claims) == nil {
+		groups = append(groups, groupsFromClaim(claims[claim])...)
+	}
+
+	// Many providers only include the groups in the ID token.
+	raw_id_token, ok := token.Extra("id_token").(string)
+	if ok {
+		verifier := provider.Verifier(&oidc.Config{
+			ClientID: self.authenticator.OauthClientId,
+		})
+		id_token, err := verifier.Verify(ctx, raw_id_token)
+		if err != nil {
+			logging.GetLogger(self.config_obj, &logging.GUIComponent).
+				Error("can not verify ID token from OIDC provider: %v", err)
+		} else {
+			claims := make(map[string]interface{})
+			if id_token.Claims(&claims) == nil {
+				groups = append(groups, groupsFromClaim(claims[claim])...)
+			}
+		}
+	}
+
+	roles_by_org := rolesForGroups(self.authenticator.OidcGroupMappings, groups)
+	logging.LogAudit(self.config_obj, user_info.Email, "OidcGroupSync",
+		logrus.Fields{
+			"groups": groups,
+			"roles":  roles_by_org,
+		})
+
+	return users.SyncUserRoles(ctx, user_info.Email, roles_by_org)
+}
+
+// The groups claim is usually a list of strings but some providers
+// send a single string.
+func groupsFromClaim(claim interface{}) []string {
+	switch t := claim.(type) {
+	case string:
+		return []string{t}
+
+	case []interface{}:
+		result := make([]string, 0, len(t))
+		for _, i := range t {
+			group, ok := i.(string)
+			if ok {
+				result = append(result, group)
+			}
+		}
+		return result
+	}
+
+	return nil
+}
+
+// Resolve the roles granted in each org by the user's groups.
+func rolesForGroups(mappings []*config_proto.OidcGroupMapping,
+	groups []string) map[string][]string {
+
+	result := make(map[string][]string)
+	for _, mapping := range mappings {
+		if !utils.InString(groups, mapping.Group) {
+			continue
+		}
+
+		orgs := mapping.Orgs
+		if len(orgs) == 0 {
+			orgs = []string{"root"}
+		}
+
+		for _, org_id := range orgs {
+			org_id = utils.NormalizedOrgId(org_id)
+			for _, role := range mapping.Roles {
+				if acls.ValidateRole(role) &&
+					!utils.InString(result[org_id], role) {
+					result[org_id] = append(result[org_id], role)
+				}
+			}
+		}
+	}
+
+	return result
+}
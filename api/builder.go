@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
@@ -310,6 +311,11 @@ func StartFrontendHttps(
 		return err
 	}
 
+	err = configureClientCertificates(config_obj, tls_config)
+	if err != nil {
+		return err
+	}
+
 	listenAddr := fmt.Sprintf(
 		"%s:%d",
 		config_obj.Frontend.BindAddress,
@@ -327,6 +333,14 @@ func StartFrontendHttps(
 		TLSConfig:    tls_config,
 	}
 
+	if config_obj.Frontend.EnableHttp3 {
+		err = startHTTP3Server(ctx, wg, server_obj, server,
+			logging.GetLogger(config_obj, &logging.FrontendComponent))
+		if err != nil {
+			return err
+		}
+	}
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -470,6 +484,11 @@ func StartFrontendWithAutocert(
 		return err
 	}
 
+	err = configureClientCertificates(config_obj, tls_config)
+	if err != nil {
+		return err
+	}
+
 	// Autocert selects its own certificates by itself
 	// https://cs.opensource.google/go/x/crypto/+/refs/tags/v0.5.0:acme/autocert/autocert.go;l=227
 	cert_manager_config := certManager.TLSConfig()
@@ -490,6 +509,16 @@ func StartFrontendWithAutocert(
 		TLSConfig:    tls_config,
 	}
 
+	// The GUI and frontend share this server.
+	if config_obj.Frontend.EnableHttp3 ||
+		(config_obj.GUI != nil && config_obj.GUI.EnableHttp3) {
+		err = startHTTP3Server(ctx, wg, server_obj, server,
+			logging.GetLogger(config_obj, &logging.FrontendComponent))
+		if err != nil {
+			return err
+		}
+	}
+
 	// We must have port 80 open to serve the HTTP 01 challenge.
 	go func() {
 		err := http.ListenAndServe(":http", certManager.HTTPHandler(nil))
@@ -639,6 +668,13 @@ func StartSelfSignedGUI(
 		TLSConfig:    tls_config,
 	}
 
+	if config_obj.GUI.EnableHttp3 {
+		err = startHTTP3Server(ctx, wg, nil, server, logger)
+		if err != nil {
+			return err
+		}
+	}
+
 	logger.Info("GUI is ready to handle TLS requests on <green>https://%s:%d/",
 		get_hostname(config_obj.Frontend.Hostname, config_obj.GUI.BindAddress),
 		config_obj.GUI.BindPort)
@@ -680,6 +716,50 @@ func get_hostname(fe_hostname, bind_addr string) string {
 	return bind_addr
 }
 
+// Ask clients for the certificates issued to them by our CA. In
+// "require" mode connections without a valid client certificate are
+// refused during the handshake.
+func configureClientCertificates(
+	config_obj *config_proto.Config, in *tls.Config) error {
+	if config_obj.Frontend == nil ||
+		config_obj.Frontend.ClientCertificates == "" {
+		return nil
+	}
+
+	if config_obj.Client == nil {
+		return errors.New("Client CA not configured")
+	}
+
+	ca_pool := x509.NewCertPool()
+	if !ca_pool.AppendCertsFromPEM([]byte(config_obj.Client.CaCertificate)) {
+		return errors.New("Unable to parse CA certificate")
+	}
+	in.ClientCAs = ca_pool
+
+	switch config_obj.Frontend.ClientCertificates {
+	case "issue":
+		in.ClientAuth = tls.VerifyClientCertIfGiven
+
+	case "require":
+		in.ClientAuth = tls.RequireAndVerifyClientCert
+
+		// Browsers do not have a client certificate so we can not
+		// require one when the GUI shares the frontend port.
+		if config_obj.GUI != nil &&
+			config_obj.GUI.BindPort == config_obj.Frontend.BindPort {
+			logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+			logger.Info("<red>Client certificates can not be required</> when the GUI shares the frontend port.")
+			in.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+
+	default:
+		return fmt.Errorf("Invalid Frontend.client_certificates setting: %v",
+			config_obj.Frontend.ClientCertificates)
+	}
+
+	return nil
+}
+
 // Prepare a TLS config with correct cipher choices.
 func getTLSConfig(config_obj *config_proto.Config, in *tls.Config) error {
 	certs, err := getCertificates(config_obj)
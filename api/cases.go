@@ -0,0 +1,152 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/cases"
+)
+
+func (self *ApiServer) GetCases(
+	ctx context.Context,
+	in *api_proto.CasesRequest) (*api_proto.Cases, error) {
+
+	defer Instrument("GetCases")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.READ_RESULTS
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to view cases.")
+	}
+
+	items, err := cases.ListCases(org_config_obj, in)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	return &api_proto.Cases{Items: items}, nil
+}
+
+func (self *ApiServer) SetCase(
+	ctx context.Context,
+	in *api_proto.Case) (*api_proto.Case, error) {
+
+	defer Instrument("SetCase")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.NOTEBOOK_EDITOR
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to edit cases.")
+	}
+
+	var result *api_proto.Case
+	if in.CaseId == "" {
+		result, err = cases.NewCase(org_config_obj, principal, in)
+	} else {
+		result, err = cases.UpdateCase(org_config_obj, principal, in)
+	}
+	if err != nil {
+		return nil, caseStatus(self.verbose, err)
+	}
+
+	logging.LogAudit(org_config_obj, principal, "SetCase",
+		logrus.Fields{
+			"case_id": result.CaseId,
+			"details": json.MustMarshalString(in),
+		})
+
+	return result, nil
+}
+
+func (self *ApiServer) AddCaseNote(
+	ctx context.Context,
+	in *api_proto.CaseNoteRequest) (*api_proto.Case, error) {
+
+	defer Instrument("AddCaseNote")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.NOTEBOOK_EDITOR
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to edit cases.")
+	}
+
+	result, err := cases.AddNote(org_config_obj, principal, in.CaseId, in.Note)
+	if err != nil {
+		return nil, caseStatus(self.verbose, err)
+	}
+	return result, nil
+}
+
+func (self *ApiServer) DeleteCase(
+	ctx context.Context,
+	in *api_proto.CasesRequest) (*emptypb.Empty, error) {
+
+	defer Instrument("DeleteCase")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.NOTEBOOK_EDITOR
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to delete cases.")
+	}
+
+	err = cases.DeleteCase(org_config_obj, in.CaseId)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	logging.LogAudit(org_config_obj, principal, "DeleteCase",
+		logrus.Fields{
+			"case_id": in.CaseId,
+		})
+
+	return &emptypb.Empty{}, nil
+}
+
+// Validation errors are the caller's fault.
+func caseStatus(verbose bool, err error) error {
+	if errors.Is(err, cases.InvalidCaseError) {
+		return InvalidStatus(err.Error())
+	}
+	return Status(verbose, err)
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/indexing"
+)
+
+func (self *ApiServer) GetClientGroups(
+	ctx context.Context,
+	in *emptypb.Empty) (*api_proto.ClientGroups, error) {
+
+	defer Instrument("GetClientGroups")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.READ_RESULTS
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to view client groups.")
+	}
+
+	items, err := indexing.ListClientGroups(org_config_obj)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	return &api_proto.ClientGroups{Items: items}, nil
+}
+
+func (self *ApiServer) SetClientGroup(
+	ctx context.Context,
+	in *api_proto.ClientGroup) (*api_proto.ClientGroup, error) {
+
+	defer Instrument("SetClientGroup")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.LABEL_CLIENT
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to edit client groups.")
+	}
+
+	in.Creator = principal
+	err = indexing.SetClientGroup(org_config_obj, in)
+	if err != nil {
+		if errors.Is(err, indexing.InvalidGroupError) {
+			return nil, InvalidStatus(err.Error())
+		}
+		return nil, Status(self.verbose, err)
+	}
+
+	logging.LogAudit(org_config_obj, principal, "SetClientGroup",
+		logrus.Fields{
+			"name":  in.Name,
+			"query": in.Query,
+		})
+
+	return in, nil
+}
+
+func (self *ApiServer) DeleteClientGroup(
+	ctx context.Context,
+	in *api_proto.ClientGroup) (*emptypb.Empty, error) {
+
+	defer Instrument("DeleteClientGroup")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.LABEL_CLIENT
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to delete client groups.")
+	}
+
+	err = indexing.DeleteClientGroup(org_config_obj, in.Name)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	logging.LogAudit(org_config_obj, principal, "DeleteClientGroup",
+		logrus.Fields{
+			"name": in.Name,
+		})
+
+	return &emptypb.Empty{}, nil
+}
@@ -171,8 +171,15 @@ func (self *ApiServer) GetClientFlows(
 			"User is not allowed to view flows.")
 	}
 
+	err = checkResultScope(ctx, org_config_obj, user_name,
+		resultScope{ClientId: in.ClientId})
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	in_scope := artifactScopeFilter(org_config_obj, user_name)
 	filter := func(flow *flows_proto.ArtifactCollectorContext) bool {
-		return true
+		return in_scope(flow.Request.GetArtifacts())
 	}
 
 	if in.Artifact != "" {
@@ -182,7 +189,8 @@ func (self *ApiServer) GetClientFlows(
 		}
 
 		filter = func(flow *flows_proto.ArtifactCollectorContext) bool {
-			if flow.Request == nil {
+			if flow.Request == nil ||
+				!in_scope(flow.Request.Artifacts) {
 				return false
 			}
 
@@ -46,8 +46,10 @@ import (
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
 	"www.velocidex.com/golang/velociraptor/api/authenticators"
 	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/api/ratelimit"
 	"www.velocidex.com/golang/velociraptor/api/tables"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
 	"www.velocidex.com/golang/velociraptor/file_store"
 	"www.velocidex.com/golang/velociraptor/file_store/api"
 	"www.velocidex.com/golang/velociraptor/file_store/csv"
@@ -247,9 +249,15 @@ func getRows(
 
 	// We want an event table.
 	if request.Type == "CLIENT_EVENT" || request.Type == "SERVER_EVENT" {
+		// Scheduled client artifacts are stored with the events
+		// of the monitoring flow.
+		flow_id := request.FlowId
+		if flow_id == "" && request.Type == "CLIENT_EVENT" {
+			flow_id = constants.MONITORING_WELL_KNOWN_FLOW
+		}
+
 		path_manager, err := artifacts.NewArtifactPathManager(
-			config_obj, request.ClientId, request.FlowId,
-			request.Artifact)
+			config_obj, request.ClientId, flow_id, request.Artifact)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -411,6 +419,12 @@ func downloadTable() http.Handler {
 			return
 		}
 
+		limits := ratelimit.FromContext(r.Context())
+		if !limits.AllowRows() {
+			ratelimit.TooManyRequests(w, "rows", time.Minute)
+			return
+		}
+
 		opts := json.GetJsonOptsForTimezone(request.Timezone)
 		switch request.DownloadFormat {
 		case "csv":
@@ -435,6 +449,9 @@ func downloadTable() http.Handler {
 				org_config_obj, scope, w,
 				csv.WriteHeaders, opts)
 			for row := range row_chan {
+				if limits.WaitRow(r.Context()) != nil {
+					break
+				}
 				csv_writer.Write(
 					filterColumns(request.Columns, transform(row)))
 			}
@@ -460,6 +477,9 @@ func downloadTable() http.Handler {
 				})
 
 			for row := range row_chan {
+				if limits.WaitRow(r.Context()) != nil {
+					return
+				}
 				serialized, err := json.MarshalWithOptions(
 					filterColumns(request.Columns, transform(row)),
 					json.GetJsonOptsForTimezone(request.Timezone))
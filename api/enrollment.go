@@ -0,0 +1,81 @@
+package api
+
+import (
+	"github.com/sirupsen/logrus"
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/enrollment"
+)
+
+func (self *ApiServer) GetEnrollments(
+	ctx context.Context,
+	in *api_proto.GetEnrollmentsRequest) (*api_proto.EnrollmentRecords, error) {
+
+	defer Instrument("GetEnrollments")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.READ_RESULTS
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to view enrollments.")
+	}
+
+	items, err := enrollment.ListEnrollments(ctx, org_config_obj, in.State)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	return &api_proto.EnrollmentRecords{Items: items}, nil
+}
+
+func (self *ApiServer) DecideEnrollment(
+	ctx context.Context,
+	in *api_proto.EnrollmentDecision) (*api_proto.EnrollmentRecord, error) {
+
+	defer Instrument("DecideEnrollment")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	// Approving a client allows it to be collected from.
+	permissions := acls.COLLECT_CLIENT
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to approve clients.")
+	}
+
+	record, err := enrollment.Decide(ctx, org_config_obj, principal, in)
+	if err != nil {
+		return nil, InvalidStatus(err.Error())
+	}
+
+	operation := "RejectEnrollment"
+	if in.Approve {
+		operation = "ApproveEnrollment"
+	}
+
+	logging.LogAudit(org_config_obj, principal, operation,
+		logrus.Fields{
+			"client_id": record.ClientId,
+			"comment":   record.Comment,
+		})
+
+	return record, nil
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vql/tools/explain"
+)
+
+// Run a query in the context of a notebook or a flow and report
+// where the time is spent.
+func (self *ApiServer) ExplainQuery(
+	ctx context.Context,
+	in *api_proto.ExplainRequest) (*api_proto.ExplainResponse, error) {
+
+	defer Instrument("ExplainQuery")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.NOTEBOOK_EDITOR
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to run queries.")
+	}
+
+	if in.Query == "" {
+		return nil, InvalidStatus("Query must be specified")
+	}
+
+	env := ordereddict.NewDict()
+	if in.NotebookId != "" {
+		if !strings.HasPrefix(in.NotebookId, "N.") {
+			return nil, InvalidStatus("Invalid NoteboookId")
+		}
+
+		notebook_manager, err := services.GetNotebookManager(org_config_obj)
+		if err != nil {
+			return nil, Status(self.verbose, err)
+		}
+
+		notebook_metadata, err := notebook_manager.GetNotebook(ctx, in.NotebookId)
+		if err != nil {
+			return nil, Status(self.verbose, err)
+		}
+
+		if !notebook_manager.CheckNotebookAccess(notebook_metadata, principal) {
+			return nil, InvalidStatus("Notebook is not shared with user.")
+		}
+
+		env.Set("NotebookId", in.NotebookId)
+		for _, item := range notebook_metadata.Env {
+			env.Set(item.Key, item.Value)
+		}
+	}
+
+	if in.ClientId != "" {
+		env.Set("ClientId", in.ClientId)
+	}
+
+	if in.FlowId != "" {
+		env.Set("FlowId", in.FlowId)
+	}
+
+	for _, item := range in.Env {
+		env.Set(item.Key, item.Value)
+	}
+
+	logging.LogAudit(org_config_obj, principal, "ExplainQuery",
+		logrus.Fields{
+			"query":       in.Query,
+			"notebook_id": in.NotebookId,
+			"client_id":   in.ClientId,
+			"flow_id":     in.FlowId,
+		})
+
+	manager, err := services.GetRepositoryManager(org_config_obj)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	log_writer := &explainLogWriter{}
+	scope := manager.BuildScope(services.ScopeBuilder{
+		Config:     org_config_obj,
+		ACLManager: acl_managers.NewServerACLManager(org_config_obj, principal),
+		Env:        env,
+		Logger:     log.New(log_writer, "", 0),
+	})
+	defer scope.Close()
+
+	timeout := in.Timeout
+	if timeout == 0 {
+		timeout = 600
+	}
+
+	sub_ctx, cancel := context.WithTimeout(
+		ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	result, err := explain.Explain(sub_ctx, scope, in.Query)
+	if err != nil {
+		return nil, InvalidStatus(err.Error())
+	}
+
+	if sub_ctx.Err() != nil {
+		scope.Log("ExplainQuery: Query timed out after %v seconds", timeout)
+	}
+
+	result.Log = log_writer.Lines()
+	return result, nil
+}
+
+type explainLogWriter struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (self *explainLogWriter) Write(b []byte) (int, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.lines = append(self.lines, string(bytes.TrimRight(b, "\n")))
+	return len(b), nil
+}
+
+func (self *explainLogWriter) Lines() []string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return append([]string{}, self.lines...)
+}
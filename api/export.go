@@ -0,0 +1,227 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/gorilla/schema"
+	"github.com/sirupsen/logrus"
+	context "golang.org/x/net/context"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/api/ratelimit"
+	"www.velocidex.com/golang/velociraptor/api/tables"
+	"www.velocidex.com/golang/velociraptor/file_store/csv"
+	"www.velocidex.com/golang/velociraptor/file_store/parquet"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+// Exposes the table rows to the export query.
+type tableRows struct {
+	rows      <-chan *ordereddict.Dict
+	transform func(row *ordereddict.Dict) *ordereddict.Dict
+}
+
+func (self *tableRows) Eval(
+	ctx context.Context, scope vfilter.Scope) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		for row := range self.rows {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- self.transform(row):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+// Export a table as specified by the v1/GetTable API. Unlike
+// DownloadTable, the caller may specify a VQL query to filter and
+// project the rows on the server so only the interesting rows are
+// downloaded.
+func exportTable() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &api_proto.GetTableRequest{}
+		decoder := schema.NewDecoder()
+		decoder.SetAliasTag("json")
+		err := decoder.Decode(request, r.URL.Query())
+		if err != nil {
+			returnError(w, 404, err.Error())
+			return
+		}
+
+		org_manager, err := services.GetOrgManager()
+		if err != nil {
+			returnError(w, 404, err.Error())
+			return
+		}
+
+		org_config_obj, err := org_manager.GetOrgConfig(request.OrgId)
+		if err != nil {
+			returnError(w, 404, err.Error())
+			return
+		}
+
+		user_record := GetUserInfo(r.Context(), org_config_obj)
+		principal := user_record.Name
+		if principal == "" {
+			returnError(w, 403, "Unauthenticated access.")
+			return
+		}
+
+		perm, err := services.CheckAccess(
+			org_config_obj, principal, acls.READ_RESULTS)
+		if !perm || err != nil {
+			returnError(w, 403, "Unauthenticated access.")
+			return
+		}
+
+		query := request.Query
+		if query == "" {
+			query = "SELECT * FROM rows"
+
+		} else {
+			// Running arbitrary VQL requires the same permission as
+			// in notebooks.
+			perm, err := services.CheckAccess(
+				org_config_obj, principal, acls.NOTEBOOK_EDITOR)
+			if !perm || err != nil {
+				returnError(w, 403, "User is not allowed to run queries.")
+				return
+			}
+		}
+
+		vql, err := vfilter.Parse(query)
+		if err != nil {
+			returnError(w, 400, err.Error())
+			return
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		row_chan, closer, log_path, err := getRows(ctx, org_config_obj, request)
+		if err != nil {
+			returnError(w, 400, "Invalid request")
+			return
+		}
+		defer closer()
+
+		manager, err := services.GetRepositoryManager(org_config_obj)
+		if err != nil {
+			returnError(w, 500, err.Error())
+			return
+		}
+
+		scope := manager.BuildScope(services.ScopeBuilder{
+			Config: org_config_obj,
+			Env: ordereddict.NewDict().
+				Set("rows", &tableRows{
+					rows:      row_chan,
+					transform: getTransformer(ctx, org_config_obj, request),
+				}),
+			ACLManager: acl_managers.NewServerACLManager(
+				org_config_obj, principal),
+			Logger: logging.NewPlainLogger(
+				org_config_obj, &logging.FrontendComponent),
+		})
+		defer scope.Close()
+
+		download_name := request.DownloadFilename
+		if download_name == "" {
+			download_name = strings.Replace(log_path.Base(), "\"", "", -1)
+		}
+		download_name = strings.TrimSuffix(download_name, ".json")
+
+		format := request.DownloadFormat
+		switch format {
+		case "csv", "parquet":
+		default:
+			format = "jsonl"
+		}
+		download_name += "." + format
+
+		limits := ratelimit.FromContext(r.Context())
+		if !limits.AllowRows() {
+			ratelimit.TooManyRequests(w, "rows", time.Minute)
+			return
+		}
+
+		// From here on we already sent the headers and we can not
+		// really report an error to the client.
+		w.Header().Set("Content-Disposition", "attachment; filename="+
+			url.PathEscape(download_name))
+		w.Header().Set("Content-Type", "binary/octet-stream")
+		w.WriteHeader(200)
+
+		logging.LogAudit(org_config_obj, principal, "ExportTable",
+			logrus.Fields{
+				"request": request,
+				"remote":  r.RemoteAddr,
+			})
+
+		opts := json.GetJsonOptsForTimezone(request.Timezone)
+		rows := vql.Eval(ctx, scope)
+
+		switch format {
+		case "csv":
+			csv_writer := csv.GetCSVAppender(
+				org_config_obj, scope, w, csv.WriteHeaders, opts)
+			for row := range rows {
+				if limits.WaitRow(ctx) != nil {
+					break
+				}
+				csv_writer.Write(filterColumns(request.Columns,
+					vfilter.RowToDict(ctx, scope, row)))
+			}
+			csv_writer.Close()
+
+		case "parquet":
+			parquet_writer := parquet.NewWriter(w, opts)
+			parquet_writer.SetSchema(result_sets.NewSchema(
+				tables.GetColumnTypes(org_config_obj, request)))
+			for row := range rows {
+				if limits.WaitRow(ctx) != nil {
+					break
+				}
+				err := parquet_writer.Write(filterColumns(request.Columns,
+					vfilter.RowToDict(ctx, scope, row)))
+				if err != nil {
+					return
+				}
+			}
+			_ = parquet_writer.Close()
+
+		default:
+			for row := range rows {
+				if limits.WaitRow(ctx) != nil {
+					return
+				}
+				serialized, err := json.MarshalWithOptions(
+					filterColumns(request.Columns,
+						vfilter.RowToDict(ctx, scope, row)), opts)
+				if err != nil {
+					return
+				}
+
+				// Write line delimited JSON
+				_, _ = w.Write(serialized)
+				_, _ = w.Write([]byte{'\n'})
+			}
+		}
+	})
+}
@@ -0,0 +1,421 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/Velocidex/ordereddict"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"www.velocidex.com/golang/velociraptor/api/authenticators"
+	"www.velocidex.com/golang/velociraptor/api/graphql"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/grpc_client"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	graphqlDefaultLimit = 50
+	graphqlMaxLimit     = 10000
+)
+
+type graphqlRequest struct {
+	Query     string            `json:"query"`
+	Variables *ordereddict.Dict `json:"variables"`
+}
+
+func getIntArg(args *ordereddict.Dict, name string, default_value uint64) uint64 {
+	value, pres := args.Get(name)
+	if !pres || utils.IsNil(value) {
+		return default_value
+	}
+
+	result, ok := utils.ToInt64(value)
+	if !ok || result < 0 {
+		return default_value
+	}
+	return uint64(result)
+}
+
+func getStringArg(args *ordereddict.Dict, name string) string {
+	value, _ := args.GetString(name)
+	return value
+}
+
+func getBoolArg(args *ordereddict.Dict, name string) bool {
+	value, _ := args.GetBool(name)
+	return value
+}
+
+// Pagination arguments are common to all lists.
+func getLimitArgs(args *ordereddict.Dict) (offset, limit uint64) {
+	offset = getIntArg(args, "offset", 0)
+	limit = getIntArg(args, "limit", graphqlDefaultLimit)
+	if limit > graphqlMaxLimit {
+		limit = graphqlMaxLimit
+	}
+	return offset, limit
+}
+
+// Table cells are sent as strings by the API - decode them back into
+// objects so they can be selected from.
+func decodeCell(cell string) interface{} {
+	if len(cell) > 0 && cell[0] == '{' {
+		result := ordereddict.NewDict()
+		if result.UnmarshalJSON([]byte(cell)) == nil {
+			return result
+		}
+	}
+
+	var result interface{}
+	if json.Unmarshal([]byte(cell), &result) == nil {
+		return result
+	}
+	return cell
+}
+
+// A page of results from a GetTable style API call.
+func tableToDict(table *api_proto.GetTableResponse) *ordereddict.Dict {
+	rows := make([]*ordereddict.Dict, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		item := ordereddict.NewDict()
+		for idx, column := range table.Columns {
+			if idx < len(row.Cell) {
+				item.Set(column, decodeCell(row.Cell[idx]))
+			}
+		}
+		rows = append(rows, item)
+	}
+
+	return ordereddict.NewDict().
+		Set("total_rows", table.TotalRows).
+		Set("columns", table.Columns).
+		Set("rows", rows)
+}
+
+// The GraphQL schema maps types onto the gRPC API. Object fields
+// which are not explicitly defined are taken from the protobuf
+// response.
+func newGraphqlSchema(client api_proto.APIClient) graphql.Schema {
+	getResults := func(ctx context.Context,
+		in *api_proto.GetTableRequest,
+		args *ordereddict.Dict) (interface{}, error) {
+		in.StartRow, in.Rows = getLimitArgs(args)
+		table, err := client.GetTable(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		return tableToDict(table), nil
+	}
+
+	clientFlows := func(ctx context.Context,
+		parent, args *ordereddict.Dict) (interface{}, error) {
+		offset, limit := getLimitArgs(args)
+		client_id := getStringArg(args, "client_id")
+		if parent != nil {
+			client_id = getStringArg(parent, "client_id")
+		}
+
+		flows, err := client.GetClientFlows(ctx, &api_proto.ApiFlowRequest{
+			ClientId:        client_id,
+			Offset:          offset,
+			Count:           limit,
+			Artifact:        getStringArg(args, "artifact"),
+			IncludeArchived: getBoolArg(args, "include_archived"),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]*ordereddict.Dict, 0, len(flows.Items))
+		for _, item := range flows.Items {
+			result = append(result, json.ConvertProtoToOrderedDict(item))
+		}
+		return result, nil
+	}
+
+	return graphql.Schema{
+		"Query": {
+			"clients": {
+				Type: "Client",
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					offset, limit := getLimitArgs(args)
+					query := getStringArg(args, "search")
+					if query == "" {
+						query = "all"
+					}
+
+					clients, err := client.ListClients(ctx,
+						&api_proto.SearchClientsRequest{
+							Query:  query,
+							Offset: offset,
+							Limit:  limit,
+						})
+					if err != nil {
+						return nil, err
+					}
+
+					result := make([]*ordereddict.Dict, 0, len(clients.Items))
+					for _, item := range clients.Items {
+						result = append(result, json.ConvertProtoToOrderedDict(item))
+					}
+					return result, nil
+				},
+			},
+			"client": {
+				Type: "Client",
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					api_client, err := client.GetClient(ctx,
+						&api_proto.GetClientRequest{
+							ClientId: getStringArg(args, "client_id"),
+						})
+					if err != nil {
+						return nil, err
+					}
+					return json.ConvertProtoToOrderedDict(api_client), nil
+				},
+			},
+			"flows": {
+				Type:    "Flow",
+				Resolve: clientFlows,
+			},
+			"flow": {
+				Type: "Flow",
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					details, err := client.GetFlowDetails(ctx,
+						&api_proto.ApiFlowRequest{
+							ClientId: getStringArg(args, "client_id"),
+							FlowId:   getStringArg(args, "flow_id"),
+						})
+					if err != nil {
+						return nil, err
+					}
+					if details.Context == nil {
+						return nil, nil
+					}
+					return json.ConvertProtoToOrderedDict(details.Context), nil
+				},
+			},
+			"hunts": {
+				Type: "Hunt",
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					offset, limit := getLimitArgs(args)
+					hunts, err := client.ListHunts(ctx,
+						&api_proto.ListHuntsRequest{
+							Offset:          offset,
+							Count:           limit,
+							IncludeArchived: getBoolArg(args, "include_archived"),
+						})
+					if err != nil {
+						return nil, err
+					}
+
+					result := make([]*ordereddict.Dict, 0, len(hunts.Items))
+					for _, item := range hunts.Items {
+						result = append(result, json.ConvertProtoToOrderedDict(item))
+					}
+					return result, nil
+				},
+			},
+			"hunt": {
+				Type: "Hunt",
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					hunt, err := client.GetHunt(ctx, &api_proto.GetHuntRequest{
+						HuntId: getStringArg(args, "hunt_id"),
+					})
+					if err != nil {
+						return nil, err
+					}
+					return json.ConvertProtoToOrderedDict(hunt), nil
+				},
+			},
+			"results": {
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					return getResults(ctx, &api_proto.GetTableRequest{
+						ClientId: getStringArg(args, "client_id"),
+						FlowId:   getStringArg(args, "flow_id"),
+						HuntId:   getStringArg(args, "hunt_id"),
+						Artifact: getStringArg(args, "artifact"),
+						Type:     getStringArg(args, "type"),
+					}, args)
+				},
+			},
+			"me": {
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					user, err := client.GetUserUITraits(ctx, &emptypb.Empty{})
+					if err != nil {
+						return nil, err
+					}
+					return json.ConvertProtoToOrderedDict(user), nil
+				},
+			},
+		},
+
+		"Client": {
+			"flows": {
+				Type:    "Flow",
+				Resolve: clientFlows,
+			},
+		},
+
+		"Flow": {
+			"results": {
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					return getResults(ctx, &api_proto.GetTableRequest{
+						ClientId: getStringArg(parent, "client_id"),
+						FlowId:   getStringArg(parent, "session_id"),
+						Artifact: getStringArg(args, "artifact"),
+						Type:     getStringArg(args, "type"),
+					}, args)
+				},
+			},
+		},
+
+		"Hunt": {
+			"flows": {
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					in := &api_proto.GetTableRequest{
+						HuntId: getStringArg(parent, "hunt_id"),
+					}
+					in.StartRow, in.Rows = getLimitArgs(args)
+					table, err := client.GetHuntFlows(ctx, in)
+					if err != nil {
+						return nil, err
+					}
+					return tableToDict(table), nil
+				},
+			},
+			"results": {
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					offset, limit := getLimitArgs(args)
+					table, err := client.GetHuntResults(ctx,
+						&api_proto.GetHuntResultsRequest{
+							HuntId:   getStringArg(parent, "hunt_id"),
+							Artifact: getStringArg(args, "artifact"),
+							Offset:   offset,
+							Count:    limit,
+						})
+					if err != nil {
+						return nil, err
+					}
+					return tableToDict(table), nil
+				},
+			},
+		},
+	}
+}
+
+func parseGraphqlRequest(
+	w http.ResponseWriter, r *http.Request) (*graphqlRequest, error) {
+	request := &graphqlRequest{}
+	if r.Method == "GET" {
+		request.Query = r.URL.Query().Get("query")
+		variables := r.URL.Query().Get("variables")
+		if variables != "" {
+			request.Variables = ordereddict.NewDict()
+			err := request.Variables.UnmarshalJSON([]byte(variables))
+			if err != nil {
+				return nil, err
+			}
+		}
+		return request, nil
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, 1024*1024))
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(body, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Query == "" {
+		return nil, errors.New("No query specified")
+	}
+
+	return request, nil
+}
+
+// A GraphQL endpoint over the gRPC API. Queries are resolved by
+// calling the API as the authenticated user so the usual ACL checks
+// apply.
+func graphqlHandler(
+	ctx context.Context,
+	config_obj *config_proto.Config) (http.Handler, error) {
+	opts, err := getGatewayDialOptions(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.DialContext(ctx,
+		grpc_client.GetAPIConnectionString(config_obj), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	return newGraphqlHTTPHandler(
+		newGraphqlSchema(api_proto.NewAPIClient(conn))), nil
+}
+
+func newGraphqlHTTPHandler(schema graphql.Schema) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" && r.Method != "POST" {
+			returnError(w, http.StatusMethodNotAllowed, "Only GET and POST are supported")
+			return
+		}
+
+		request, err := parseGraphqlRequest(w, r)
+		if err != nil {
+			returnError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Relay the authenticated user and their selected org to
+		// the gRPC server just like the gRPC gateway does for the
+		// Grpc-Metadata-OrgId header.
+		md := map[string]string{
+			"METHOD": r.Method,
+			"OrgId":  authenticators.GetOrgIdFromRequest(r),
+		}
+		username, ok := r.Context().Value(constants.GRPC_USER_CONTEXT).(string)
+		if ok {
+			md["USER"] = username
+		}
+		query_ctx := metadata.NewOutgoingContext(r.Context(), metadata.New(md))
+
+		response := graphql.Execute(query_ctx, schema,
+			request.Query, request.Variables)
+
+		serialized, err := json.Marshal(response)
+		if err != nil {
+			returnError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(serialized)
+	})
+}
@@ -0,0 +1,211 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+// Resolves the value of a field. The parent is the object the field
+// is selected from (nil for top level fields).
+type Resolver func(ctx context.Context,
+	parent *ordereddict.Dict, args *ordereddict.Dict) (interface{}, error)
+
+type FieldDefinition struct {
+	// The object type of the field's value, used to look up the
+	// fields selected from it. Values of unknown types are simply
+	// projected.
+	Type string
+
+	// If not set the value is taken from the parent's member of the
+	// same name.
+	Resolve Resolver
+}
+
+// A Schema maps type names to their fields. Top level fields are
+// defined on the "Query" type.
+type Schema map[string]map[string]*FieldDefinition
+
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+type Response struct {
+	Data   *ordereddict.Dict `json:"data"`
+	Errors []*Error          `json:"errors,omitempty"`
+}
+
+// The maximum number of resolvers called for a single query. Each
+// resolver is an API call so fields nested under lists are limited
+// here rather than by the parser.
+var MaxResolverCalls = 1000
+
+type executor struct {
+	schema    Schema
+	variables *ordereddict.Dict
+	errors    []*Error
+
+	resolver_calls int
+}
+
+func (self *executor) addError(path []interface{}, err error) {
+	self.errors = append(self.errors, &Error{
+		Message: err.Error(),
+		Path:    append([]interface{}{}, path...),
+	})
+}
+
+// Replace variable references with their values.
+func (self *executor) resolveArgs(args *ordereddict.Dict) *ordereddict.Dict {
+	result := ordereddict.NewDict()
+	for _, k := range args.Keys() {
+		v, _ := args.Get(k)
+		result.Set(k, self.resolveValue(v))
+	}
+	return result
+}
+
+func (self *executor) resolveValue(value interface{}) interface{} {
+	switch t := value.(type) {
+	case Variable:
+		v, _ := self.variables.Get(string(t))
+		return v
+
+	case []interface{}:
+		result := make([]interface{}, 0, len(t))
+		for _, item := range t {
+			result = append(result, self.resolveValue(item))
+		}
+		return result
+
+	case *ordereddict.Dict:
+		return self.resolveArgs(t)
+	}
+	return value
+}
+
+func (self *executor) selectFields(ctx context.Context,
+	type_name string, parent *ordereddict.Dict,
+	selection []*Field, path []interface{}) *ordereddict.Dict {
+
+	result := ordereddict.NewDict()
+	fields := self.schema[type_name]
+
+	for _, field := range selection {
+		field_path := append(path, field.Key())
+
+		if field.Name == "__typename" {
+			result.Set(field.Key(), type_name)
+			continue
+		}
+
+		definition, pres := fields[field.Name]
+		if !pres {
+			// Top level fields must be defined.
+			if parent == nil {
+				self.addError(field_path, fmt.Errorf(
+					"Unknown field %v on %v", field.Name, type_name))
+				result.Set(field.Key(), nil)
+				continue
+			}
+			definition = &FieldDefinition{}
+		}
+
+		var value interface{}
+		if definition.Resolve != nil {
+			self.resolver_calls++
+			if self.resolver_calls > MaxResolverCalls {
+				// Only report the first field over the limit.
+				if self.resolver_calls == MaxResolverCalls+1 {
+					self.addError(field_path, fmt.Errorf(
+						"Query too complex: more than %v fields resolved",
+						MaxResolverCalls))
+				}
+				result.Set(field.Key(), nil)
+				continue
+			}
+
+			var err error
+			value, err = definition.Resolve(
+				ctx, parent, self.resolveArgs(field.Arguments))
+			if err != nil {
+				self.addError(field_path, err)
+				result.Set(field.Key(), nil)
+				continue
+			}
+
+		} else if parent != nil {
+			value, _ = parent.Get(field.Name)
+		}
+
+		result.Set(field.Key(), self.complete(ctx, definition.Type,
+			value, field.Selection, field_path))
+	}
+
+	return result
+}
+
+// Apply the selection set to the value.
+func (self *executor) complete(ctx context.Context,
+	type_name string, value interface{},
+	selection []*Field, path []interface{}) interface{} {
+
+	if len(selection) == 0 || value == nil {
+		return value
+	}
+
+	switch t := value.(type) {
+	case *ordereddict.Dict:
+		return self.selectFields(ctx, type_name, t, selection, path)
+
+	case []*ordereddict.Dict:
+		result := make([]interface{}, 0, len(t))
+		for idx, item := range t {
+			result = append(result, self.selectFields(
+				ctx, type_name, item, selection, append(path, idx)))
+		}
+		return result
+	}
+
+	// Other lists (e.g. []interface{} from generic dicts)
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice {
+		result := make([]interface{}, 0, rv.Len())
+		for idx := 0; idx < rv.Len(); idx++ {
+			result = append(result, self.complete(ctx, type_name,
+				rv.Index(idx).Interface(), selection, append(path, idx)))
+		}
+		return result
+	}
+
+	// Selecting from a scalar just returns the scalar.
+	return value
+}
+
+// Execute the query against the schema. Errors resolving individual
+// fields are reported in the response rather than failing the query.
+func Execute(ctx context.Context, schema Schema,
+	query string, variables *ordereddict.Dict) *Response {
+	selection, err := Parse(query)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+
+	if variables == nil {
+		variables = ordereddict.NewDict()
+	}
+
+	self := &executor{
+		schema:    schema,
+		variables: variables,
+	}
+
+	data := self.selectFields(ctx, "Query", nil, selection, nil)
+	return &Response{
+		Data:   data,
+		Errors: self.errors,
+	}
+}
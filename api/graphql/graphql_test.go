@@ -0,0 +1,129 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+var (
+	testSchema = Schema{
+		"Query": {
+			"clients": {
+				Type: "Client",
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					limit, _ := args.GetInt64("limit")
+					result := []*ordereddict.Dict{}
+					for i := int64(0); i < limit; i++ {
+						result = append(result, ordereddict.NewDict().
+							Set("client_id", "C.123").
+							Set("os_info", ordereddict.NewDict().
+								Set("hostname", "host").
+								Set("system", "windows")).
+							Set("labels", []interface{}{"a", "b"}))
+					}
+					return result, nil
+				},
+			},
+			"fail": {
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					return nil, errors.New("Permission denied")
+				},
+			},
+		},
+		"Client": {
+			"flows": {
+				Resolve: func(ctx context.Context,
+					parent, args *ordereddict.Dict) (interface{}, error) {
+					client_id, _ := parent.GetString("client_id")
+					artifact, _ := args.GetString("artifact")
+					return []*ordereddict.Dict{ordereddict.NewDict().
+						Set("client_id", client_id).
+						Set("session_id", "F.1").
+						Set("artifact", artifact)}, nil
+				},
+			},
+		},
+	}
+)
+
+func TestGraphQL(t *testing.T) {
+	ctx := context.Background()
+
+	response := Execute(ctx, testSchema, `
+query Clients($artifact: String) {
+  # Comments are ignored
+  clients(limit: 1) {
+    client_id
+    host: os_info { hostname }
+    flows(artifact: $artifact) { session_id, artifact }
+    __typename
+  }
+  fail
+}`, ordereddict.NewDict().Set("artifact", "Generic.Client.Info"))
+
+	assert.Equal(t, `{"data":{"clients":[{"client_id":"C.123","host":{"hostname":"host"},"flows":[{"session_id":"F.1","artifact":"Generic.Client.Info"}],"__typename":"Client"}],"fail":null},"errors":[{"message":"Permission denied","path":["fail"]}]}`,
+		json.MustMarshalString(response))
+
+	// Unknown top level fields are an error.
+	response = Execute(ctx, testSchema, `{ users { name } }`, nil)
+	assert.Equal(t, `{"data":{"users":null},"errors":[{"message":"Unknown field users on Query","path":["users"]}]}`,
+		json.MustMarshalString(response))
+
+	// Syntax errors and unsupported operations.
+	for _, query := range []string{
+		`{ clients(limit: 1) { client_id }`,
+		`mutation { clients }`,
+		`{ clients { ...ClientFields } }`,
+		`{ clients(search: "unterminated) }`,
+	} {
+		response = Execute(ctx, testSchema, query, nil)
+		assert.True(t, response.Data == nil)
+		assert.Equal(t, 1, len(response.Errors), query)
+	}
+}
+
+func TestGraphQLLimits(t *testing.T) {
+	ctx := context.Background()
+
+	// Deeply nested queries are rejected before they run.
+	query := "{ clients(limit: 1) "
+	for i := 0; i < MaxDepth; i++ {
+		query += "{ flows "
+	}
+	query += strings.Repeat("}", MaxDepth+1)
+
+	response := Execute(ctx, testSchema, query, nil)
+	assert.True(t, response.Data == nil)
+	assert.Equal(t, 1, len(response.Errors))
+	assert.Contains(t, response.Errors[0].Message, "nested too deeply")
+
+	// So are queries selecting too many fields.
+	query = "{ clients(limit: 1) { " +
+		strings.Repeat("client_id ", MaxFields) + "} }"
+	response = Execute(ctx, testSchema, query, nil)
+	assert.True(t, response.Data == nil)
+	assert.Equal(t, 1, len(response.Errors))
+	assert.Contains(t, response.Errors[0].Message, "too many fields")
+
+	// Nested resolvers stop being called once the query resolves
+	// too many fields.
+	defer func(old int) { MaxResolverCalls = old }(MaxResolverCalls)
+	MaxResolverCalls = 5
+
+	response = Execute(ctx, testSchema,
+		`{ clients(limit: 10) { flows { session_id } } }`, nil)
+	assert.Equal(t, 1, len(response.Errors))
+	assert.Equal(t, `{"message":"Query too complex: more than 5 fields resolved","path":["clients",4,"flows"]}`,
+		json.MustMarshalString(response.Errors[0]))
+
+	clients, _ := response.Data.Get("clients")
+	assert.Equal(t, 10, len(clients.([]interface{})))
+}
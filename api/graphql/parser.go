@@ -0,0 +1,370 @@
+/*
+  A minimal GraphQL query parser.
+
+  We only support the subset of the language needed to select data
+  from the API: a single query operation with (optionally aliased)
+  fields, arguments, variables and nested selection sets. Fragments,
+  directives and mutations are not supported.
+
+  Queries are limited in depth and in the number of fields they
+  select so a single request can not fan out into an unbounded
+  number of API calls.
+*/
+
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+type Field struct {
+	Alias     string
+	Name      string
+	Arguments *ordereddict.Dict
+	Selection []*Field
+}
+
+// The key the field's value is returned under.
+func (self *Field) Key() string {
+	if self.Alias != "" {
+		return self.Alias
+	}
+	return self.Name
+}
+
+// A reference to a variable which is resolved at execution time.
+type Variable string
+
+var (
+	// The maximum nesting of selection sets in a query.
+	MaxDepth = 8
+
+	// The maximum number of fields selected by a query.
+	MaxFields = 200
+)
+
+type parser struct {
+	input  string
+	pos    int
+	fields int
+}
+
+func (self *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("GraphQL syntax error at offset %d: %s",
+		self.pos, fmt.Sprintf(format, args...))
+}
+
+// Skip whitespace, commas (which are insignificant in GraphQL) and
+// comments.
+func (self *parser) skip() {
+	for self.pos < len(self.input) {
+		c := self.input[self.pos]
+		switch {
+		case c == '#':
+			for self.pos < len(self.input) && self.input[self.pos] != '\n' {
+				self.pos++
+			}
+		case c == ',' || unicode.IsSpace(rune(c)):
+			self.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (self *parser) peek() byte {
+	self.skip()
+	if self.pos >= len(self.input) {
+		return 0
+	}
+	return self.input[self.pos]
+}
+
+func (self *parser) expect(c byte) error {
+	if self.peek() != c {
+		return self.errorf("expected '%c'", c)
+	}
+	self.pos++
+	return nil
+}
+
+func isNameChar(c byte, first bool) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+		(!first && c >= '0' && c <= '9')
+}
+
+func (self *parser) name() (string, error) {
+	self.skip()
+	start := self.pos
+	for self.pos < len(self.input) &&
+		isNameChar(self.input[self.pos], self.pos == start) {
+		self.pos++
+	}
+	if start == self.pos {
+		return "", self.errorf("expected a name")
+	}
+	return self.input[start:self.pos], nil
+}
+
+func (self *parser) value() (interface{}, error) {
+	switch c := self.peek(); {
+	case c == '$':
+		self.pos++
+		name, err := self.name()
+		return Variable(name), err
+
+	case c == '"':
+		return self.string()
+
+	case c == '[':
+		self.pos++
+		result := []interface{}{}
+		for self.peek() != ']' {
+			if self.peek() == 0 {
+				return nil, self.errorf("unterminated list")
+			}
+			item, err := self.value()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, item)
+		}
+		self.pos++
+		return result, nil
+
+	case c == '{':
+		self.pos++
+		result := ordereddict.NewDict()
+		for self.peek() != '}' {
+			key, err := self.name()
+			if err != nil {
+				return nil, err
+			}
+			err = self.expect(':')
+			if err != nil {
+				return nil, err
+			}
+			item, err := self.value()
+			if err != nil {
+				return nil, err
+			}
+			result.Set(key, item)
+		}
+		self.pos++
+		return result, nil
+
+	case c == '-' || (c >= '0' && c <= '9'):
+		return self.number()
+
+	case isNameChar(c, true):
+		name, err := self.name()
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+
+		// Enum values are passed as strings.
+		return name, nil
+	}
+
+	return nil, self.errorf("expected a value")
+}
+
+func (self *parser) string() (string, error) {
+	start := self.pos
+	self.pos++
+	for self.pos < len(self.input) {
+		switch self.input[self.pos] {
+		case '\\':
+			self.pos += 2
+			continue
+		case '"':
+			self.pos++
+			return strconv.Unquote(self.input[start:self.pos])
+		}
+		self.pos++
+	}
+	return "", self.errorf("unterminated string")
+}
+
+func (self *parser) number() (interface{}, error) {
+	start := self.pos
+	for self.pos < len(self.input) &&
+		strings.IndexByte("-+0123456789.eE", self.input[self.pos]) >= 0 {
+		self.pos++
+	}
+	token := self.input[start:self.pos]
+
+	integer, err := strconv.ParseInt(token, 10, 64)
+	if err == nil {
+		return integer, nil
+	}
+
+	float, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		return nil, self.errorf("invalid number %v", token)
+	}
+	return float, nil
+}
+
+func (self *parser) arguments() (*ordereddict.Dict, error) {
+	result := ordereddict.NewDict()
+	if self.peek() != '(' {
+		return result, nil
+	}
+	self.pos++
+
+	for self.peek() != ')' {
+		name, err := self.name()
+		if err != nil {
+			return nil, err
+		}
+
+		err = self.expect(':')
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := self.value()
+		if err != nil {
+			return nil, err
+		}
+		result.Set(name, value)
+	}
+	self.pos++
+
+	return result, nil
+}
+
+func (self *parser) selectionSet(depth int) ([]*Field, error) {
+	if depth > MaxDepth {
+		return nil, fmt.Errorf(
+			"GraphQL query is nested too deeply (maximum depth %d)", MaxDepth)
+	}
+
+	err := self.expect('{')
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Field
+	for self.peek() != '}' {
+		if self.peek() == 0 {
+			return nil, self.errorf("unterminated selection set")
+		}
+
+		if strings.HasPrefix(self.input[self.pos:], "...") {
+			return nil, self.errorf("fragments are not supported")
+		}
+
+		self.fields++
+		if self.fields > MaxFields {
+			return nil, fmt.Errorf(
+				"GraphQL query selects too many fields (maximum %d)", MaxFields)
+		}
+
+		field := &Field{}
+		field.Name, err = self.name()
+		if err != nil {
+			return nil, err
+		}
+
+		if self.peek() == ':' {
+			self.pos++
+			field.Alias = field.Name
+			field.Name, err = self.name()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		field.Arguments, err = self.arguments()
+		if err != nil {
+			return nil, err
+		}
+
+		if self.peek() == '@' {
+			return nil, self.errorf("directives are not supported")
+		}
+
+		if self.peek() == '{' {
+			field.Selection, err = self.selectionSet(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result = append(result, field)
+	}
+	self.pos++
+
+	return result, nil
+}
+
+// Skip the variable definitions in the operation header - variables
+// are not typed checked.
+func (self *parser) variableDefinitions() error {
+	if self.peek() != '(' {
+		return nil
+	}
+
+	for self.pos < len(self.input) {
+		c := self.input[self.pos]
+		self.pos++
+		if c == ')' {
+			return nil
+		}
+	}
+	return self.errorf("unterminated variable definitions")
+}
+
+// Parse a query document into the top level selection set.
+func Parse(query string) ([]*Field, error) {
+	self := &parser{input: query}
+
+	if self.peek() != '{' {
+		operation, err := self.name()
+		if err != nil {
+			return nil, err
+		}
+
+		if operation != "query" {
+			return nil, self.errorf("only query operations are supported")
+		}
+
+		if isNameChar(self.peek(), true) {
+			_, err = self.name()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = self.variableDefinitions()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := self.selectionSet(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if self.peek() != 0 {
+		return nil, self.errorf("only a single operation is supported")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+// Serves clients from a different set in each org, the way the API
+// server selects the org from the call metadata.
+type orgsAPIClient struct {
+	api_proto.APIClient
+
+	clients map[string][]*api_proto.ApiClient
+}
+
+func (self *orgsAPIClient) ListClients(ctx context.Context,
+	in *api_proto.SearchClientsRequest,
+	opts ...grpc.CallOption) (*api_proto.SearchClientsResponse, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+
+	result := &api_proto.SearchClientsResponse{}
+	for _, org_id := range md.Get("OrgId") {
+		for _, user := range md.Get("USER") {
+			if user == "mike" {
+				result.Items = append(result.Items, self.clients[org_id]...)
+			}
+		}
+	}
+	return result, nil
+}
+
+func TestGraphqlOrgs(t *testing.T) {
+	handler := newGraphqlHTTPHandler(newGraphqlSchema(&orgsAPIClient{
+		clients: map[string][]*api_proto.ApiClient{
+			"root": {{ClientId: "C.1"}},
+			"O1":   {{ClientId: "C.2"}, {ClientId: "C.3"}},
+		},
+	}))
+
+	query := func(org_id string) string {
+		r := httptest.NewRequest("POST", "/api/v1/graphql",
+			strings.NewReader(`{"query": "{ clients { client_id } }"}`))
+		if org_id != "" {
+			r.Header.Set("Grpc-Metadata-OrgId", org_id)
+		}
+		r = r.WithContext(context.WithValue(
+			r.Context(), constants.GRPC_USER_CONTEXT, "mike"))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		body, err := ioutil.ReadAll(w.Result().Body)
+		assert.NoError(t, err)
+		return string(body)
+	}
+
+	// Queries run in the org selected by the GUI.
+	assert.Equal(t, `{"data":{"clients":[{"client_id":"C.2"},{"client_id":"C.3"}]}}`,
+		query("O1"))
+
+	// Without an org the root org is used.
+	assert.Equal(t, `{"data":{"clients":[{"client_id":"C.1"}]}}`, query(""))
+}
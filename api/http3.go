@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/server"
+)
+
+// HTTP/3 runs over QUIC (UDP) which copes much better with high
+// latency and lossy links (e.g. satellite or cellular endpoints)
+// than TCP. We serve exactly the same handler on the UDP port
+// matching the TCP port. Clients and browsers learn about it through
+// the Alt-Svc header and fall back to HTTP/2 over TCP by themselves
+// when UDP is blocked.
+
+// Serve the TCP server's handler over HTTP/3 as well. Must be called
+// before the TCP server starts serving. If server_obj is specified
+// the HTTP/3 listener sheds load like the TCP listener.
+func startHTTP3Server(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	server_obj *server.Server,
+	tcp_server *http.Server,
+	logger *logging.LogContext) error {
+
+	h3_server := &http3.Server{
+		Addr:      tcp_server.Addr,
+		Handler:   tcp_server.Handler,
+		TLSConfig: http3.ConfigureTLSConfig(tcp_server.TLSConfig.Clone()),
+		QuicConfig: &quic.Config{
+			// Clients hold long poll requests open for a while.
+			MaxIdleTimeout: tcp_server.IdleTimeout,
+		},
+	}
+
+	var listener http3.QUICEarlyListener
+	var err error
+	if server_obj != nil {
+		listener, err = server_obj.NewQuicLoadSheddingListener(
+			h3_server.Addr, h3_server.TLSConfig, h3_server.QuicConfig)
+	} else {
+		listener, err = quic.ListenAddrEarly(
+			h3_server.Addr, h3_server.TLSConfig, h3_server.QuicConfig)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Advertise HTTP/3 on the TCP server.
+	tcp_server.Handler = advertiseHTTP3(h3_server, tcp_server.Handler)
+
+	logger.Info("Serving HTTP/3 (QUIC) on <green>udp://%v", h3_server.Addr)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		err := h3_server.ServeListener(listener)
+		if err != nil && err != quic.ErrServerClosed &&
+			err != http.ErrServerClosed {
+			logger.Error("HTTP/3 server error: %v", err)
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+
+		err := h3_server.CloseGracefully(10 * time.Second)
+		if err == nil {
+			err = h3_server.Close()
+		}
+		if err != nil {
+			logger.Error("HTTP/3 server error during shutdown %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// Add the Alt-Svc header so clients switch to HTTP/3.
+func advertiseHTTP3(h3_server *http3.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor < 3 {
+			_ = h3_server.SetQuicHeaders(w.Header())
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"time"
 
 	"github.com/Velocidex/ordereddict"
@@ -39,6 +40,12 @@ func (self *ApiServer) GetHuntFlows(
 			"User is not allowed to view hunt results.")
 	}
 
+	err = checkResultScope(ctx, org_config_obj, principal,
+		resultScope{HuntId: in.HuntId})
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
 	hunt_dispatcher, err := services.GetHuntDispatcher(org_config_obj)
 	if err != nil {
 		return nil, Status(self.verbose, err)
@@ -160,6 +167,11 @@ func (self *ApiServer) CreateHunt(
 
 		hunt_id, err := hunt_dispatcher.CreateHunt(
 			ctx, org_config_obj, acl_manager, in)
+		if errors.Is(err, acls.ApprovalRequired) {
+			// The user needs to request approval for the hunt.
+			return nil, Status(self.verbose, err)
+		}
+
 		if err != nil {
 			logger.Error("CreateHunt: GetOrgConfig %v", err)
 			continue
@@ -220,7 +232,9 @@ func (self *ApiServer) ModifyHunt(
 		return nil, Status(self.verbose, err)
 	}
 
-	err = hunt_dispatcher.ModifyHunt(ctx, org_config_obj, in, in.Creator)
+	acl_manager := acl_managers.NewServerACLManager(org_config_obj, in.Creator)
+	err = hunt_dispatcher.ModifyHunt(
+		ctx, org_config_obj, acl_manager, in, in.Creator)
 	if err != nil {
 		return nil, Status(self.verbose, err)
 	}
@@ -315,6 +329,12 @@ func (self *ApiServer) GetHunt(
 		return nil, InvalidStatus("Hunt not found")
 	}
 
+	err = checkResultScope(ctx, org_config_obj, principal,
+		resultScope{HuntId: in.HuntId})
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
 	return result, nil
 }
 
@@ -338,6 +358,12 @@ func (self *ApiServer) GetHuntResults(
 			"User is not allowed to view results.")
 	}
 
+	err = checkResultScope(ctx, org_config_obj, principal,
+		resultScope{HuntId: in.HuntId, Artifact: in.Artifact})
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
 	env := ordereddict.NewDict().
 		Set("HuntID", in.HuntId).
 		Set("ArtifactName", in.Artifact)
@@ -427,6 +453,38 @@ func (self *ApiServer) EstimateHunt(
 			}, nil
 		}
 
+		groups := in.Condition.GetGroups()
+		if groups != nil && len(groups.Group) > 0 {
+			// A client in any of the groups will be scheduled.
+			seen := make(map[string]bool)
+			scope := vql_subsystem.MakeScope()
+			for _, group := range groups.Group {
+				search_chan, err := indexer.SearchClientsChan(
+					ctx, scope, org_config_obj, "group:"+group, principal)
+				if err != nil {
+					return nil, Status(self.verbose, err)
+				}
+
+				for api_client := range search_chan {
+					is_client_recent(api_client.ClientId, seen)
+				}
+			}
+
+			// Remove any excluded labels.
+			if in.Condition.ExcludedLabels != nil {
+				for _, label := range in.Condition.ExcludedLabels.Label {
+					for entity := range indexer.SearchIndexWithPrefix(
+						ctx, org_config_obj, "label:"+label) {
+						delete(seen, entity.Entity)
+					}
+				}
+			}
+
+			return &api_proto.HuntStats{
+				TotalClientsScheduled: uint64(len(seen)),
+			}, nil
+		}
+
 		os_condition := in.Condition.GetOs()
 		if os_condition != nil &&
 			os_condition.Os != api_proto.HuntOsCondition_ALL {
@@ -1,10 +1,13 @@
 package api
 
 import (
+	"context"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/metadata"
+	"www.velocidex.com/golang/velociraptor/tracing"
 )
 
 var (
@@ -32,3 +35,22 @@ func Instrument(api string) func() time.Duration {
 
 	return timer.ObserveDuration
 }
+
+// Start a span for the API call. Callers may continue their own
+// trace by sending a traceparent in the gRPC metadata (through the
+// gateway use the Grpc-Metadata-Traceparent header).
+func startSpan(ctx context.Context, api string) (context.Context, *tracing.Span) {
+	var ctx_with_span context.Context
+	var span *tracing.Span
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok && len(md.Get("traceparent")) > 0 {
+		ctx_with_span, span = tracing.StartSpanWithParent(
+			ctx, "api."+api, md.Get("traceparent")[0])
+	} else {
+		ctx_with_span, span = tracing.StartSpan(ctx, "api."+api)
+	}
+	span.SetKind(tracing.SPAN_KIND_SERVER)
+
+	return ctx_with_span, span
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"github.com/sirupsen/logrus"
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/kape"
+)
+
+func (self *ApiServer) ImportKapeTargets(
+	ctx context.Context,
+	in *api_proto.VFSFileBuffer) (*api_proto.LoadArtifactPackResponse, error) {
+
+	defer Instrument("ImportKapeTargets")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.ARTIFACT_WRITER
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to import KAPE targets.")
+	}
+
+	targets, err := kape.ReadTargetsFromZip(in.Data)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	result, err := kape.ImportTargets(org_config_obj, principal, targets)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	logging.LogAudit(org_config_obj, principal, "ImportKapeTargets",
+		logrus.Fields{
+			"targets":   len(targets),
+			"artifacts": result.SuccessfulArtifacts,
+		})
+
+	return result, nil
+}
+
+func (self *ApiServer) GetKapeTargets(
+	ctx context.Context,
+	in *emptypb.Empty) (*api_proto.KapeTargets, error) {
+
+	defer Instrument("GetKapeTargets")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.READ_RESULTS
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to view KAPE targets.")
+	}
+
+	items, err := kape.ListTargets(org_config_obj)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	return &api_proto.KapeTargets{Items: items}, nil
+}
@@ -41,6 +41,26 @@ func (m *MockAPIClient) EXPECT() *MockAPIClientMockRecorder {
 	return m.recorder
 }
 
+// AddCaseNote mocks base method.
+func (m *MockAPIClient) AddCaseNote(arg0 context.Context, arg1 *proto0.CaseNoteRequest, arg2 ...grpc.CallOption) (*proto0.Case, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddCaseNote", varargs...)
+	ret0, _ := ret[0].(*proto0.Case)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCaseNote indicates an expected call of AddCaseNote.
+func (mr *MockAPIClientMockRecorder) AddCaseNote(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCaseNote", reflect.TypeOf((*MockAPIClient)(nil).AddCaseNote), varargs...)
+}
+
 // CancelFlow mocks base method.
 func (m *MockAPIClient) CancelFlow(arg0 context.Context, arg1 *proto0.ApiFlowRequest, arg2 ...grpc.CallOption) (*proto0.StartFlowResponse, error) {
 	m.ctrl.T.Helper()
@@ -121,6 +141,26 @@ func (mr *MockAPIClientMockRecorder) CollectArtifact(arg0, arg1 interface{}, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectArtifact", reflect.TypeOf((*MockAPIClient)(nil).CollectArtifact), varargs...)
 }
 
+// CreateApiKey mocks base method.
+func (m *MockAPIClient) CreateApiKey(arg0 context.Context, arg1 *proto0.ApiKey, arg2 ...grpc.CallOption) (*proto0.ApiKey, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateApiKey", varargs...)
+	ret0, _ := ret[0].(*proto0.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApiKey indicates an expected call of CreateApiKey.
+func (mr *MockAPIClientMockRecorder) CreateApiKey(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApiKey", reflect.TypeOf((*MockAPIClient)(nil).CreateApiKey), varargs...)
+}
+
 // CreateDownloadFile mocks base method.
 func (m *MockAPIClient) CreateDownloadFile(arg0 context.Context, arg1 *proto0.CreateDownloadRequest, arg2 ...grpc.CallOption) (*proto0.CreateDownloadResponse, error) {
 	m.ctrl.T.Helper()
@@ -201,6 +241,106 @@ func (mr *MockAPIClientMockRecorder) CreateUser(arg0, arg1 interface{}, arg2 ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAPIClient)(nil).CreateUser), varargs...)
 }
 
+// DecideApproval mocks base method.
+func (m *MockAPIClient) DecideApproval(arg0 context.Context, arg1 *proto0.ApprovalDecision, arg2 ...grpc.CallOption) (*proto0.ApprovalRequest, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DecideApproval", varargs...)
+	ret0, _ := ret[0].(*proto0.ApprovalRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideApproval indicates an expected call of DecideApproval.
+func (mr *MockAPIClientMockRecorder) DecideApproval(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideApproval", reflect.TypeOf((*MockAPIClient)(nil).DecideApproval), varargs...)
+}
+
+// DecideEnrollment mocks base method.
+func (m *MockAPIClient) DecideEnrollment(arg0 context.Context, arg1 *proto0.EnrollmentDecision, arg2 ...grpc.CallOption) (*proto0.EnrollmentRecord, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DecideEnrollment", varargs...)
+	ret0, _ := ret[0].(*proto0.EnrollmentRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideEnrollment indicates an expected call of DecideEnrollment.
+func (mr *MockAPIClientMockRecorder) DecideEnrollment(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideEnrollment", reflect.TypeOf((*MockAPIClient)(nil).DecideEnrollment), varargs...)
+}
+
+// DeleteApiKey mocks base method.
+func (m *MockAPIClient) DeleteApiKey(arg0 context.Context, arg1 *proto0.ApiKey, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteApiKey", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteApiKey indicates an expected call of DeleteApiKey.
+func (mr *MockAPIClientMockRecorder) DeleteApiKey(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApiKey", reflect.TypeOf((*MockAPIClient)(nil).DeleteApiKey), varargs...)
+}
+
+// DeleteCase mocks base method.
+func (m *MockAPIClient) DeleteCase(arg0 context.Context, arg1 *proto0.CasesRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteCase", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCase indicates an expected call of DeleteCase.
+func (mr *MockAPIClientMockRecorder) DeleteCase(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCase", reflect.TypeOf((*MockAPIClient)(nil).DeleteCase), varargs...)
+}
+
+// DeleteClientGroup mocks base method.
+func (m *MockAPIClient) DeleteClientGroup(arg0 context.Context, arg1 *proto0.ClientGroup, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteClientGroup", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteClientGroup indicates an expected call of DeleteClientGroup.
+func (mr *MockAPIClientMockRecorder) DeleteClientGroup(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClientGroup", reflect.TypeOf((*MockAPIClient)(nil).DeleteClientGroup), varargs...)
+}
+
 // DeleteSubject mocks base method.
 func (m *MockAPIClient) DeleteSubject(arg0 context.Context, arg1 *proto0.DataRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -241,6 +381,86 @@ func (mr *MockAPIClientMockRecorder) EstimateHunt(arg0, arg1 interface{}, arg2 .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateHunt", reflect.TypeOf((*MockAPIClient)(nil).EstimateHunt), varargs...)
 }
 
+// ExplainQuery mocks base method.
+func (m *MockAPIClient) ExplainQuery(arg0 context.Context, arg1 *proto0.ExplainRequest, arg2 ...grpc.CallOption) (*proto0.ExplainResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExplainQuery", varargs...)
+	ret0, _ := ret[0].(*proto0.ExplainResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainQuery indicates an expected call of ExplainQuery.
+func (mr *MockAPIClientMockRecorder) ExplainQuery(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainQuery", reflect.TypeOf((*MockAPIClient)(nil).ExplainQuery), varargs...)
+}
+
+// ExportArchive mocks base method.
+func (m *MockAPIClient) ExportArchive(arg0 context.Context, arg1 *proto0.ExportArchiveRequest, arg2 ...grpc.CallOption) (*proto0.CreateDownloadResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExportArchive", varargs...)
+	ret0, _ := ret[0].(*proto0.CreateDownloadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportArchive indicates an expected call of ExportArchive.
+func (mr *MockAPIClientMockRecorder) ExportArchive(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportArchive", reflect.TypeOf((*MockAPIClient)(nil).ExportArchive), varargs...)
+}
+
+// GetApiKeys mocks base method.
+func (m *MockAPIClient) GetApiKeys(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*proto0.ApiKeys, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetApiKeys", varargs...)
+	ret0, _ := ret[0].(*proto0.ApiKeys)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeys indicates an expected call of GetApiKeys.
+func (mr *MockAPIClientMockRecorder) GetApiKeys(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeys", reflect.TypeOf((*MockAPIClient)(nil).GetApiKeys), varargs...)
+}
+
+// GetApprovals mocks base method.
+func (m *MockAPIClient) GetApprovals(arg0 context.Context, arg1 *proto0.GetApprovalsRequest, arg2 ...grpc.CallOption) (*proto0.ApprovalRequests, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetApprovals", varargs...)
+	ret0, _ := ret[0].(*proto0.ApprovalRequests)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovals indicates an expected call of GetApprovals.
+func (mr *MockAPIClientMockRecorder) GetApprovals(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovals", reflect.TypeOf((*MockAPIClient)(nil).GetApprovals), varargs...)
+}
+
 // GetArtifactFile mocks base method.
 func (m *MockAPIClient) GetArtifactFile(arg0 context.Context, arg1 *proto0.GetArtifactRequest, arg2 ...grpc.CallOption) (*proto0.GetArtifactResponse, error) {
 	m.ctrl.T.Helper()
@@ -281,6 +501,26 @@ func (mr *MockAPIClientMockRecorder) GetArtifacts(arg0, arg1 interface{}, arg2 .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifacts", reflect.TypeOf((*MockAPIClient)(nil).GetArtifacts), varargs...)
 }
 
+// GetCases mocks base method.
+func (m *MockAPIClient) GetCases(arg0 context.Context, arg1 *proto0.CasesRequest, arg2 ...grpc.CallOption) (*proto0.Cases, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCases", varargs...)
+	ret0, _ := ret[0].(*proto0.Cases)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCases indicates an expected call of GetCases.
+func (mr *MockAPIClientMockRecorder) GetCases(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCases", reflect.TypeOf((*MockAPIClient)(nil).GetCases), varargs...)
+}
+
 // GetClient mocks base method.
 func (m *MockAPIClient) GetClient(arg0 context.Context, arg1 *proto0.GetClientRequest, arg2 ...grpc.CallOption) (*proto0.ApiClient, error) {
 	m.ctrl.T.Helper()
@@ -321,6 +561,26 @@ func (mr *MockAPIClientMockRecorder) GetClientFlows(arg0, arg1 interface{}, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientFlows", reflect.TypeOf((*MockAPIClient)(nil).GetClientFlows), varargs...)
 }
 
+// GetClientGroups mocks base method.
+func (m *MockAPIClient) GetClientGroups(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*proto0.ClientGroups, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetClientGroups", varargs...)
+	ret0, _ := ret[0].(*proto0.ClientGroups)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientGroups indicates an expected call of GetClientGroups.
+func (mr *MockAPIClientMockRecorder) GetClientGroups(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientGroups", reflect.TypeOf((*MockAPIClient)(nil).GetClientGroups), varargs...)
+}
+
 // GetClientMetadata mocks base method.
 func (m *MockAPIClient) GetClientMetadata(arg0 context.Context, arg1 *proto0.GetClientRequest, arg2 ...grpc.CallOption) (*proto0.ClientMetadata, error) {
 	m.ctrl.T.Helper()
@@ -361,6 +621,46 @@ func (mr *MockAPIClientMockRecorder) GetClientMonitoringState(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientMonitoringState", reflect.TypeOf((*MockAPIClient)(nil).GetClientMonitoringState), varargs...)
 }
 
+// GetCompletions mocks base method.
+func (m *MockAPIClient) GetCompletions(arg0 context.Context, arg1 *proto0.CompletionsRequest, arg2 ...grpc.CallOption) (*proto0.KeywordCompletions, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCompletions", varargs...)
+	ret0, _ := ret[0].(*proto0.KeywordCompletions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletions indicates an expected call of GetCompletions.
+func (mr *MockAPIClientMockRecorder) GetCompletions(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletions", reflect.TypeOf((*MockAPIClient)(nil).GetCompletions), varargs...)
+}
+
+// GetEnrollments mocks base method.
+func (m *MockAPIClient) GetEnrollments(arg0 context.Context, arg1 *proto0.GetEnrollmentsRequest, arg2 ...grpc.CallOption) (*proto0.EnrollmentRecords, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetEnrollments", varargs...)
+	ret0, _ := ret[0].(*proto0.EnrollmentRecords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnrollments indicates an expected call of GetEnrollments.
+func (mr *MockAPIClientMockRecorder) GetEnrollments(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnrollments", reflect.TypeOf((*MockAPIClient)(nil).GetEnrollments), varargs...)
+}
+
 // GetFlowDetails mocks base method.
 func (m *MockAPIClient) GetFlowDetails(arg0 context.Context, arg1 *proto0.ApiFlowRequest, arg2 ...grpc.CallOption) (*proto0.FlowDetails, error) {
 	m.ctrl.T.Helper()
@@ -481,6 +781,26 @@ func (mr *MockAPIClientMockRecorder) GetHuntResults(arg0, arg1 interface{}, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHuntResults", reflect.TypeOf((*MockAPIClient)(nil).GetHuntResults), varargs...)
 }
 
+// GetKapeTargets mocks base method.
+func (m *MockAPIClient) GetKapeTargets(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*proto0.KapeTargets, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetKapeTargets", varargs...)
+	ret0, _ := ret[0].(*proto0.KapeTargets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKapeTargets indicates an expected call of GetKapeTargets.
+func (mr *MockAPIClientMockRecorder) GetKapeTargets(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKapeTargets", reflect.TypeOf((*MockAPIClient)(nil).GetKapeTargets), varargs...)
+}
+
 // GetKeywordCompletions mocks base method.
 func (m *MockAPIClient) GetKeywordCompletions(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*proto0.KeywordCompletions, error) {
 	m.ctrl.T.Helper()
@@ -541,6 +861,26 @@ func (mr *MockAPIClientMockRecorder) GetNotebooks(arg0, arg1 interface{}, arg2 .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotebooks", reflect.TypeOf((*MockAPIClient)(nil).GetNotebooks), varargs...)
 }
 
+// GetOrgUsage mocks base method.
+func (m *MockAPIClient) GetOrgUsage(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*proto0.OrgUsage, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetOrgUsage", varargs...)
+	ret0, _ := ret[0].(*proto0.OrgUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgUsage indicates an expected call of GetOrgUsage.
+func (mr *MockAPIClientMockRecorder) GetOrgUsage(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgUsage", reflect.TypeOf((*MockAPIClient)(nil).GetOrgUsage), varargs...)
+}
+
 // GetReport mocks base method.
 func (m *MockAPIClient) GetReport(arg0 context.Context, arg1 *proto0.GetReportRequest, arg2 ...grpc.CallOption) (*proto0.GetReportResponse, error) {
 	m.ctrl.T.Helper()
@@ -581,6 +921,26 @@ func (mr *MockAPIClientMockRecorder) GetServerMonitoringState(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerMonitoringState", reflect.TypeOf((*MockAPIClient)(nil).GetServerMonitoringState), varargs...)
 }
 
+// GetSignature mocks base method.
+func (m *MockAPIClient) GetSignature(arg0 context.Context, arg1 *proto0.SignatureRequest, arg2 ...grpc.CallOption) (*proto0.Completion, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSignature", varargs...)
+	ret0, _ := ret[0].(*proto0.Completion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignature indicates an expected call of GetSignature.
+func (mr *MockAPIClientMockRecorder) GetSignature(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignature", reflect.TypeOf((*MockAPIClient)(nil).GetSignature), varargs...)
+}
+
 // GetSubject mocks base method.
 func (m *MockAPIClient) GetSubject(arg0 context.Context, arg1 *proto0.DataRequest, arg2 ...grpc.CallOption) (*proto0.DataResponse, error) {
 	m.ctrl.T.Helper()
@@ -741,6 +1101,46 @@ func (mr *MockAPIClientMockRecorder) GetUsers(arg0, arg1 interface{}, arg2 ...in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockAPIClient)(nil).GetUsers), varargs...)
 }
 
+// ImportArchive mocks base method.
+func (m *MockAPIClient) ImportArchive(arg0 context.Context, arg1 *proto0.ImportArchiveRequest, arg2 ...grpc.CallOption) (*proto0.ImportArchiveResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ImportArchive", varargs...)
+	ret0, _ := ret[0].(*proto0.ImportArchiveResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportArchive indicates an expected call of ImportArchive.
+func (mr *MockAPIClientMockRecorder) ImportArchive(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportArchive", reflect.TypeOf((*MockAPIClient)(nil).ImportArchive), varargs...)
+}
+
+// ImportKapeTargets mocks base method.
+func (m *MockAPIClient) ImportKapeTargets(arg0 context.Context, arg1 *proto0.VFSFileBuffer, arg2 ...grpc.CallOption) (*proto0.LoadArtifactPackResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ImportKapeTargets", varargs...)
+	ret0, _ := ret[0].(*proto0.LoadArtifactPackResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportKapeTargets indicates an expected call of ImportKapeTargets.
+func (mr *MockAPIClientMockRecorder) ImportKapeTargets(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportKapeTargets", reflect.TypeOf((*MockAPIClient)(nil).ImportKapeTargets), varargs...)
+}
+
 // LabelClients mocks base method.
 func (m *MockAPIClient) LabelClients(arg0 context.Context, arg1 *proto0.LabelClientsRequest, arg2 ...grpc.CallOption) (*proto0.APIResponse, error) {
 	m.ctrl.T.Helper()
@@ -1001,6 +1401,66 @@ func (mr *MockAPIClientMockRecorder) ReformatVQL(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReformatVQL", reflect.TypeOf((*MockAPIClient)(nil).ReformatVQL), varargs...)
 }
 
+// RenderDocument mocks base method.
+func (m *MockAPIClient) RenderDocument(arg0 context.Context, arg1 *proto0.RenderDocumentRequest, arg2 ...grpc.CallOption) (*proto0.RenderDocumentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RenderDocument", varargs...)
+	ret0, _ := ret[0].(*proto0.RenderDocumentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderDocument indicates an expected call of RenderDocument.
+func (mr *MockAPIClientMockRecorder) RenderDocument(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderDocument", reflect.TypeOf((*MockAPIClient)(nil).RenderDocument), varargs...)
+}
+
+// RequestApproval mocks base method.
+func (m *MockAPIClient) RequestApproval(arg0 context.Context, arg1 *proto0.ApprovalRequest, arg2 ...grpc.CallOption) (*proto0.ApprovalRequest, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RequestApproval", varargs...)
+	ret0, _ := ret[0].(*proto0.ApprovalRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestApproval indicates an expected call of RequestApproval.
+func (mr *MockAPIClientMockRecorder) RequestApproval(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestApproval", reflect.TypeOf((*MockAPIClient)(nil).RequestApproval), varargs...)
+}
+
+// RotateApiKey mocks base method.
+func (m *MockAPIClient) RotateApiKey(arg0 context.Context, arg1 *proto0.ApiKey, arg2 ...grpc.CallOption) (*proto0.ApiKey, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RotateApiKey", varargs...)
+	ret0, _ := ret[0].(*proto0.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateApiKey indicates an expected call of RotateApiKey.
+func (mr *MockAPIClientMockRecorder) RotateApiKey(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateApiKey", reflect.TypeOf((*MockAPIClient)(nil).RotateApiKey), varargs...)
+}
+
 // SetArtifactFile mocks base method.
 func (m *MockAPIClient) SetArtifactFile(arg0 context.Context, arg1 *proto0.SetArtifactRequest, arg2 ...grpc.CallOption) (*proto0.APIResponse, error) {
 	m.ctrl.T.Helper()
@@ -1021,6 +1481,46 @@ func (mr *MockAPIClientMockRecorder) SetArtifactFile(arg0, arg1 interface{}, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArtifactFile", reflect.TypeOf((*MockAPIClient)(nil).SetArtifactFile), varargs...)
 }
 
+// SetCase mocks base method.
+func (m *MockAPIClient) SetCase(arg0 context.Context, arg1 *proto0.Case, arg2 ...grpc.CallOption) (*proto0.Case, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetCase", varargs...)
+	ret0, _ := ret[0].(*proto0.Case)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCase indicates an expected call of SetCase.
+func (mr *MockAPIClientMockRecorder) SetCase(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCase", reflect.TypeOf((*MockAPIClient)(nil).SetCase), varargs...)
+}
+
+// SetClientGroup mocks base method.
+func (m *MockAPIClient) SetClientGroup(arg0 context.Context, arg1 *proto0.ClientGroup, arg2 ...grpc.CallOption) (*proto0.ClientGroup, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetClientGroup", varargs...)
+	ret0, _ := ret[0].(*proto0.ClientGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetClientGroup indicates an expected call of SetClientGroup.
+func (mr *MockAPIClientMockRecorder) SetClientGroup(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClientGroup", reflect.TypeOf((*MockAPIClient)(nil).SetClientGroup), varargs...)
+}
+
 // SetClientMetadata mocks base method.
 func (m *MockAPIClient) SetClientMetadata(arg0 context.Context, arg1 *proto0.ClientMetadata, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -1181,6 +1681,26 @@ func (mr *MockAPIClientMockRecorder) SetUserRoles(arg0, arg1 interface{}, arg2 .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRoles", reflect.TypeOf((*MockAPIClient)(nil).SetUserRoles), varargs...)
 }
 
+// TailResultSet mocks base method.
+func (m *MockAPIClient) TailResultSet(arg0 context.Context, arg1 *proto0.TailResultSetRequest, arg2 ...grpc.CallOption) (proto0.API_TailResultSetClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TailResultSet", varargs...)
+	ret0, _ := ret[0].(proto0.API_TailResultSetClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TailResultSet indicates an expected call of TailResultSet.
+func (mr *MockAPIClientMockRecorder) TailResultSet(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TailResultSet", reflect.TypeOf((*MockAPIClient)(nil).TailResultSet), varargs...)
+}
+
 // UpdateNotebook mocks base method.
 func (m *MockAPIClient) UpdateNotebook(arg0 context.Context, arg1 *proto0.NotebookMetadata, arg2 ...grpc.CallOption) (*proto0.NotebookMetadata, error) {
 	m.ctrl.T.Helper()
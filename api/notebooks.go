@@ -211,6 +211,11 @@ func (self *ApiServer) UpdateNotebook(
 	in.CreatedTime = old_notebook.CreatedTime
 	in.NotebookId = old_notebook.NotebookId
 
+	err = checkNotebookSchedule(org_config_obj, principal, old_notebook, in)
+	if err != nil {
+		return nil, err
+	}
+
 	// Filter out any empty cells.
 	cell_metadata := make([]*api_proto.NotebookCell, 0, len(in.CellMetadata))
 	for i := 0; i < len(in.CellMetadata); i++ {
@@ -224,6 +229,52 @@ func (self *ApiServer) UpdateNotebook(
 	return in, notebook_manager.UpdateNotebook(ctx, in)
 }
 
+// Scheduled notebooks run as the user who set the schedule. The run
+// state is only maintained by the scheduler.
+func checkNotebookSchedule(
+	config_obj *config_proto.Config, principal string,
+	old_notebook, in *api_proto.NotebookMetadata) error {
+
+	old_schedule := old_notebook.Schedule
+	if old_schedule == nil {
+		old_schedule = &api_proto.NotebookSchedule{}
+	}
+
+	if in.Schedule == nil || in.Schedule.PeriodSeconds == 0 {
+		return nil
+	}
+
+	in.Schedule.LastRun = old_schedule.LastRun
+	in.Schedule.NextRun = old_schedule.NextRun
+	in.Schedule.LastError = old_schedule.LastError
+
+	// Schedule is unchanged - keep running it as the same user.
+	if in.Schedule.PeriodSeconds == old_schedule.PeriodSeconds &&
+		in.Schedule.ExportType == old_schedule.ExportType &&
+		in.Schedule.WebhookUrl == old_schedule.WebhookUrl &&
+		utils.StringSliceEq(in.Schedule.EmailTo, old_schedule.EmailTo) {
+		in.Schedule.Principal = old_schedule.Principal
+		return nil
+	}
+
+	// Sending results outside the server is restricted like the
+	// mail() plugin.
+	if len(in.Schedule.EmailTo) > 0 || in.Schedule.WebhookUrl != "" {
+		perm, err := services.CheckAccess(config_obj, principal, acls.SERVER_ADMIN)
+		if !perm || err != nil {
+			return status.Error(codes.PermissionDenied,
+				"User is not allowed to deliver notebook exports.")
+		}
+	}
+
+	in.Schedule.Principal = principal
+	if in.Schedule.PeriodSeconds != old_schedule.PeriodSeconds {
+		in.Schedule.NextRun = 0
+	}
+
+	return nil
+}
+
 func (self *ApiServer) GetNotebookCell(
 	ctx context.Context,
 	in *api_proto.NotebookCellRequest) (*api_proto.NotebookCell, error) {
@@ -312,6 +363,14 @@ func (self *ApiServer) UpdateNotebookCell(
 		return nil, InvalidStatus("Notebook is not shared with user.")
 	}
 
+	logging.LogAudit(org_config_obj, principal, "UpdateNotebookCell",
+		logrus.Fields{
+			"notebook": in.NotebookId,
+			"cell":     in.CellId,
+			"type":     in.Type,
+			"input":    in.Input,
+		})
+
 	return notebook_manager.UpdateNotebookCell(
 		ctx, notebook_metadata, principal, in)
 }
@@ -404,9 +463,12 @@ func (self *ApiServer) CreateNotebookDownloadFile(
 	case "zip":
 		return &emptypb.Empty{}, exportZipNotebook(
 			org_config_obj, in.NotebookId, principal)
+	case "pdf":
+		return &emptypb.Empty{}, exportRenderedNotebook(
+			org_config_obj, in.NotebookId, principal, "pdf")
 	default:
-		return &emptypb.Empty{}, exportHTMLNotebook(
-			org_config_obj, in.NotebookId, principal)
+		return &emptypb.Empty{}, exportRenderedNotebook(
+			org_config_obj, in.NotebookId, principal, "html")
 	}
 }
 
@@ -463,8 +525,9 @@ func exportZipNotebook(
 	return nil
 }
 
-func exportHTMLNotebook(config_obj *config_proto.Config,
-	notebook_id, principal string) error {
+// Render the notebook into an html or pdf document.
+func exportRenderedNotebook(config_obj *config_proto.Config,
+	notebook_id, principal, export_type string) error {
 	db, err := datastore.GetDB(config_obj)
 	if err != nil {
 		return err
@@ -488,6 +551,11 @@ func exportHTMLNotebook(config_obj *config_proto.Config,
 
 	file_store_factory := file_store.GetFileStore(config_obj)
 	filename := notebook_path_manager.HtmlExport()
+	exporter := reporting.ExportNotebookToHTML
+	if export_type == "pdf" {
+		filename = notebook_path_manager.PdfExport()
+		exporter = reporting.ExportNotebookToPDF
+	}
 
 	writer, err := file_store_factory.WriteFile(filename)
 	if err != nil {
@@ -500,7 +568,7 @@ func exportHTMLNotebook(config_obj *config_proto.Config,
 
 	stats := &api_proto.ContainerStats{
 		Timestamp:  uint64(time.Now().Unix()),
-		Type:       "html",
+		Type:       export_type,
 		Components: path_specs.AsGenericComponentList(filename),
 	}
 	stats_path := notebook_path_manager.PathStats(filename)
@@ -524,7 +592,7 @@ func exportHTMLNotebook(config_obj *config_proto.Config,
 			db.SetSubject(config_obj, stats_path, stats)
 		}()
 
-		err := reporting.ExportNotebookToHTML(
+		err := exporter(
 			sub_ctx, config_obj, notebook.NotebookId, tee_writer)
 		if err != nil {
 			logger := logging.GetLogger(config_obj, &logging.GUIComponent)
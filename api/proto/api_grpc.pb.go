@@ -1,4 +1,5 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// source: api.proto
 
 package proto
 
@@ -39,6 +40,10 @@ type APIClient interface {
 	GetClient(ctx context.Context, in *GetClientRequest, opts ...grpc.CallOption) (*ApiClient, error)
 	GetClientMetadata(ctx context.Context, in *GetClientRequest, opts ...grpc.CallOption) (*ClientMetadata, error)
 	SetClientMetadata(ctx context.Context, in *ClientMetadata, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Saved client groups
+	GetClientGroups(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClientGroups, error)
+	SetClientGroup(ctx context.Context, in *ClientGroup, opts ...grpc.CallOption) (*ClientGroup, error)
+	DeleteClientGroup(ctx context.Context, in *ClientGroup, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetClientFlows(ctx context.Context, in *ApiFlowRequest, opts ...grpc.CallOption) (*ApiFlowResponse, error)
 	// Users
 	GetUserUITraits(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ApiUser, error)
@@ -47,12 +52,26 @@ type APIClient interface {
 	GetUsers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Users, error)
 	// List all the GUI users in orgs in which we are a member
 	GetGlobalUsers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Users, error)
+	// Report the current org's resource usage and quotas.
+	GetOrgUsage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*OrgUsage, error)
 	GetUserRoles(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserRoles, error)
 	SetUserRoles(ctx context.Context, in *UserRoles, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetUser(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*VelociraptorUser, error)
 	CreateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetUserFavorites(ctx context.Context, in *Favorite, opts ...grpc.CallOption) (*Favorites, error)
 	SetPassword(ctx context.Context, in *SetPasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// API keys for scripts and integrations.
+	GetApiKeys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ApiKeys, error)
+	CreateApiKey(ctx context.Context, in *ApiKey, opts ...grpc.CallOption) (*ApiKey, error)
+	RotateApiKey(ctx context.Context, in *ApiKey, opts ...grpc.CallOption) (*ApiKey, error)
+	DeleteApiKey(ctx context.Context, in *ApiKey, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Approval of dangerous operations.
+	GetApprovals(ctx context.Context, in *GetApprovalsRequest, opts ...grpc.CallOption) (*ApprovalRequests, error)
+	RequestApproval(ctx context.Context, in *ApprovalRequest, opts ...grpc.CallOption) (*ApprovalRequest, error)
+	DecideApproval(ctx context.Context, in *ApprovalDecision, opts ...grpc.CallOption) (*ApprovalRequest, error)
+	// Approval of newly enrolled clients.
+	GetEnrollments(ctx context.Context, in *GetEnrollmentsRequest, opts ...grpc.CallOption) (*EnrollmentRecords, error)
+	DecideEnrollment(ctx context.Context, in *EnrollmentDecision, opts ...grpc.CallOption) (*EnrollmentRecord, error)
 	// VFS
 	VFSListDirectory(ctx context.Context, in *VFSListRequest, opts ...grpc.CallOption) (*VFSListResponse, error)
 	VFSListDirectoryFiles(ctx context.Context, in *GetTableRequest, opts ...grpc.CallOption) (*GetTableResponse, error)
@@ -67,17 +86,30 @@ type APIClient interface {
 	GetFlowRequests(ctx context.Context, in *ApiFlowRequest, opts ...grpc.CallOption) (*ApiFlowRequestDetails, error)
 	// VQL assistance
 	GetKeywordCompletions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*KeywordCompletions, error)
+	// Completions generated from the plugins, functions and
+	// artifacts available on this server.
+	GetCompletions(ctx context.Context, in *CompletionsRequest, opts ...grpc.CallOption) (*KeywordCompletions, error)
+	GetSignature(ctx context.Context, in *SignatureRequest, opts ...grpc.CallOption) (*Completion, error)
 	ReformatVQL(ctx context.Context, in *ReformatVQLMessage, opts ...grpc.CallOption) (*ReformatVQLMessage, error)
+	// Run a query and report the time spent in each stage and plugin.
+	ExplainQuery(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error)
 	// Artifacts
 	GetArtifacts(ctx context.Context, in *GetArtifactsRequest, opts ...grpc.CallOption) (*proto1.ArtifactDescriptors, error)
 	GetArtifactFile(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (*GetArtifactResponse, error)
 	SetArtifactFile(ctx context.Context, in *SetArtifactRequest, opts ...grpc.CallOption) (*APIResponse, error)
 	LoadArtifactPack(ctx context.Context, in *VFSFileBuffer, opts ...grpc.CallOption) (*LoadArtifactPackResponse, error)
+	// Convert the KAPE targets (.tkape files) in a zip file into
+	// artifacts.
+	ImportKapeTargets(ctx context.Context, in *VFSFileBuffer, opts ...grpc.CallOption) (*LoadArtifactPackResponse, error)
+	GetKapeTargets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*KapeTargets, error)
 	// Tools
 	GetToolInfo(ctx context.Context, in *proto1.Tool, opts ...grpc.CallOption) (*proto1.Tool, error)
 	SetToolInfo(ctx context.Context, in *proto1.Tool, opts ...grpc.CallOption) (*proto1.Tool, error)
 	// Reporting and post processing.
 	GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error)
+	// Render notebooks, cases and reports into html or pdf
+	// documents on the server.
+	RenderDocument(ctx context.Context, in *RenderDocumentRequest, opts ...grpc.CallOption) (*RenderDocumentResponse, error)
 	// Server Monitoring Artifacts - manage the Server Monitoring
 	// Service..
 	GetServerMonitoringState(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*proto.ArtifactCollectorArgs, error)
@@ -93,6 +125,9 @@ type APIClient interface {
 	ListAvailableEventResults(ctx context.Context, in *ListAvailableEventResultsRequest, opts ...grpc.CallOption) (*ListAvailableEventResultsResponse, error)
 	// Schedule downloads.
 	CreateDownloadFile(ctx context.Context, in *CreateDownloadRequest, opts ...grpc.CallOption) (*CreateDownloadResponse, error)
+	// Move flows and hunts between servers.
+	ExportArchive(ctx context.Context, in *ExportArchiveRequest, opts ...grpc.CallOption) (*CreateDownloadResponse, error)
+	ImportArchive(ctx context.Context, in *ImportArchiveRequest, opts ...grpc.CallOption) (*ImportArchiveResponse, error)
 	// Notebook management
 	GetNotebooks(ctx context.Context, in *NotebookCellRequest, opts ...grpc.CallOption) (*Notebooks, error)
 	NewNotebook(ctx context.Context, in *NotebookMetadata, opts ...grpc.CallOption) (*NotebookMetadata, error)
@@ -103,12 +138,21 @@ type APIClient interface {
 	CancelNotebookCell(ctx context.Context, in *NotebookCellRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CreateNotebookDownloadFile(ctx context.Context, in *NotebookExportRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UploadNotebookAttachment(ctx context.Context, in *NotebookFileUploadRequest, opts ...grpc.CallOption) (*NotebookFileUploadResponse, error)
+	// Case management
+	GetCases(ctx context.Context, in *CasesRequest, opts ...grpc.CallOption) (*Cases, error)
+	// Create a new case (if case_id is not set) or update an
+	// existing case.
+	SetCase(ctx context.Context, in *Case, opts ...grpc.CallOption) (*Case, error)
+	AddCaseNote(ctx context.Context, in *CaseNoteRequest, opts ...grpc.CallOption) (*Case, error)
+	DeleteCase(ctx context.Context, in *CasesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// This can be used by API clients to fetch file content.
 	VFSGetBuffer(ctx context.Context, in *VFSFileBuffer, opts ...grpc.CallOption) (*VFSFileBuffer, error)
 	// Streaming free form VQL.
 	Query(ctx context.Context, in *proto2.VQLCollectorArgs, opts ...grpc.CallOption) (API_QueryClient, error)
 	// Watch for events from the master.
 	WatchEvent(ctx context.Context, in *EventRequest, opts ...grpc.CallOption) (API_WatchEventClient, error)
+	// Stream new rows of a result set as they are written.
+	TailResultSet(ctx context.Context, in *TailResultSetRequest, opts ...grpc.CallOption) (API_TailResultSetClient, error)
 	// Push the events to the master
 	PushEvents(ctx context.Context, in *PushEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Push monitoring event to the server.
@@ -247,6 +291,33 @@ func (c *aPIClient) SetClientMetadata(ctx context.Context, in *ClientMetadata, o
 	return out, nil
 }
 
+func (c *aPIClient) GetClientGroups(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClientGroups, error) {
+	out := new(ClientGroups)
+	err := c.cc.Invoke(ctx, "/proto.API/GetClientGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SetClientGroup(ctx context.Context, in *ClientGroup, opts ...grpc.CallOption) (*ClientGroup, error) {
+	out := new(ClientGroup)
+	err := c.cc.Invoke(ctx, "/proto.API/SetClientGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteClientGroup(ctx context.Context, in *ClientGroup, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/proto.API/DeleteClientGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) GetClientFlows(ctx context.Context, in *ApiFlowRequest, opts ...grpc.CallOption) (*ApiFlowResponse, error) {
 	out := new(ApiFlowResponse)
 	err := c.cc.Invoke(ctx, "/proto.API/GetClientFlows", in, out, opts...)
@@ -292,6 +363,15 @@ func (c *aPIClient) GetGlobalUsers(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *aPIClient) GetOrgUsage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*OrgUsage, error) {
+	out := new(OrgUsage)
+	err := c.cc.Invoke(ctx, "/proto.API/GetOrgUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) GetUserRoles(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserRoles, error) {
 	out := new(UserRoles)
 	err := c.cc.Invoke(ctx, "/proto.API/GetUserRoles", in, out, opts...)
@@ -346,6 +426,87 @@ func (c *aPIClient) SetPassword(ctx context.Context, in *SetPasswordRequest, opt
 	return out, nil
 }
 
+func (c *aPIClient) GetApiKeys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ApiKeys, error) {
+	out := new(ApiKeys)
+	err := c.cc.Invoke(ctx, "/proto.API/GetApiKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateApiKey(ctx context.Context, in *ApiKey, opts ...grpc.CallOption) (*ApiKey, error) {
+	out := new(ApiKey)
+	err := c.cc.Invoke(ctx, "/proto.API/CreateApiKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RotateApiKey(ctx context.Context, in *ApiKey, opts ...grpc.CallOption) (*ApiKey, error) {
+	out := new(ApiKey)
+	err := c.cc.Invoke(ctx, "/proto.API/RotateApiKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteApiKey(ctx context.Context, in *ApiKey, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/proto.API/DeleteApiKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetApprovals(ctx context.Context, in *GetApprovalsRequest, opts ...grpc.CallOption) (*ApprovalRequests, error) {
+	out := new(ApprovalRequests)
+	err := c.cc.Invoke(ctx, "/proto.API/GetApprovals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RequestApproval(ctx context.Context, in *ApprovalRequest, opts ...grpc.CallOption) (*ApprovalRequest, error) {
+	out := new(ApprovalRequest)
+	err := c.cc.Invoke(ctx, "/proto.API/RequestApproval", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DecideApproval(ctx context.Context, in *ApprovalDecision, opts ...grpc.CallOption) (*ApprovalRequest, error) {
+	out := new(ApprovalRequest)
+	err := c.cc.Invoke(ctx, "/proto.API/DecideApproval", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetEnrollments(ctx context.Context, in *GetEnrollmentsRequest, opts ...grpc.CallOption) (*EnrollmentRecords, error) {
+	out := new(EnrollmentRecords)
+	err := c.cc.Invoke(ctx, "/proto.API/GetEnrollments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DecideEnrollment(ctx context.Context, in *EnrollmentDecision, opts ...grpc.CallOption) (*EnrollmentRecord, error) {
+	out := new(EnrollmentRecord)
+	err := c.cc.Invoke(ctx, "/proto.API/DecideEnrollment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) VFSListDirectory(ctx context.Context, in *VFSListRequest, opts ...grpc.CallOption) (*VFSListResponse, error) {
 	out := new(VFSListResponse)
 	err := c.cc.Invoke(ctx, "/proto.API/VFSListDirectory", in, out, opts...)
@@ -445,6 +606,24 @@ func (c *aPIClient) GetKeywordCompletions(ctx context.Context, in *emptypb.Empty
 	return out, nil
 }
 
+func (c *aPIClient) GetCompletions(ctx context.Context, in *CompletionsRequest, opts ...grpc.CallOption) (*KeywordCompletions, error) {
+	out := new(KeywordCompletions)
+	err := c.cc.Invoke(ctx, "/proto.API/GetCompletions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetSignature(ctx context.Context, in *SignatureRequest, opts ...grpc.CallOption) (*Completion, error) {
+	out := new(Completion)
+	err := c.cc.Invoke(ctx, "/proto.API/GetSignature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) ReformatVQL(ctx context.Context, in *ReformatVQLMessage, opts ...grpc.CallOption) (*ReformatVQLMessage, error) {
 	out := new(ReformatVQLMessage)
 	err := c.cc.Invoke(ctx, "/proto.API/ReformatVQL", in, out, opts...)
@@ -454,6 +633,15 @@ func (c *aPIClient) ReformatVQL(ctx context.Context, in *ReformatVQLMessage, opt
 	return out, nil
 }
 
+func (c *aPIClient) ExplainQuery(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error) {
+	out := new(ExplainResponse)
+	err := c.cc.Invoke(ctx, "/proto.API/ExplainQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) GetArtifacts(ctx context.Context, in *GetArtifactsRequest, opts ...grpc.CallOption) (*proto1.ArtifactDescriptors, error) {
 	out := new(proto1.ArtifactDescriptors)
 	err := c.cc.Invoke(ctx, "/proto.API/GetArtifacts", in, out, opts...)
@@ -490,6 +678,24 @@ func (c *aPIClient) LoadArtifactPack(ctx context.Context, in *VFSFileBuffer, opt
 	return out, nil
 }
 
+func (c *aPIClient) ImportKapeTargets(ctx context.Context, in *VFSFileBuffer, opts ...grpc.CallOption) (*LoadArtifactPackResponse, error) {
+	out := new(LoadArtifactPackResponse)
+	err := c.cc.Invoke(ctx, "/proto.API/ImportKapeTargets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetKapeTargets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*KapeTargets, error) {
+	out := new(KapeTargets)
+	err := c.cc.Invoke(ctx, "/proto.API/GetKapeTargets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) GetToolInfo(ctx context.Context, in *proto1.Tool, opts ...grpc.CallOption) (*proto1.Tool, error) {
 	out := new(proto1.Tool)
 	err := c.cc.Invoke(ctx, "/proto.API/GetToolInfo", in, out, opts...)
@@ -517,6 +723,15 @@ func (c *aPIClient) GetReport(ctx context.Context, in *GetReportRequest, opts ..
 	return out, nil
 }
 
+func (c *aPIClient) RenderDocument(ctx context.Context, in *RenderDocumentRequest, opts ...grpc.CallOption) (*RenderDocumentResponse, error) {
+	out := new(RenderDocumentResponse)
+	err := c.cc.Invoke(ctx, "/proto.API/RenderDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) GetServerMonitoringState(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*proto.ArtifactCollectorArgs, error) {
 	out := new(proto.ArtifactCollectorArgs)
 	err := c.cc.Invoke(ctx, "/proto.API/GetServerMonitoringState", in, out, opts...)
@@ -571,6 +786,24 @@ func (c *aPIClient) CreateDownloadFile(ctx context.Context, in *CreateDownloadRe
 	return out, nil
 }
 
+func (c *aPIClient) ExportArchive(ctx context.Context, in *ExportArchiveRequest, opts ...grpc.CallOption) (*CreateDownloadResponse, error) {
+	out := new(CreateDownloadResponse)
+	err := c.cc.Invoke(ctx, "/proto.API/ExportArchive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ImportArchive(ctx context.Context, in *ImportArchiveRequest, opts ...grpc.CallOption) (*ImportArchiveResponse, error) {
+	out := new(ImportArchiveResponse)
+	err := c.cc.Invoke(ctx, "/proto.API/ImportArchive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) GetNotebooks(ctx context.Context, in *NotebookCellRequest, opts ...grpc.CallOption) (*Notebooks, error) {
 	out := new(Notebooks)
 	err := c.cc.Invoke(ctx, "/proto.API/GetNotebooks", in, out, opts...)
@@ -652,6 +885,42 @@ func (c *aPIClient) UploadNotebookAttachment(ctx context.Context, in *NotebookFi
 	return out, nil
 }
 
+func (c *aPIClient) GetCases(ctx context.Context, in *CasesRequest, opts ...grpc.CallOption) (*Cases, error) {
+	out := new(Cases)
+	err := c.cc.Invoke(ctx, "/proto.API/GetCases", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SetCase(ctx context.Context, in *Case, opts ...grpc.CallOption) (*Case, error) {
+	out := new(Case)
+	err := c.cc.Invoke(ctx, "/proto.API/SetCase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) AddCaseNote(ctx context.Context, in *CaseNoteRequest, opts ...grpc.CallOption) (*Case, error) {
+	out := new(Case)
+	err := c.cc.Invoke(ctx, "/proto.API/AddCaseNote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteCase(ctx context.Context, in *CasesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/proto.API/DeleteCase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) VFSGetBuffer(ctx context.Context, in *VFSFileBuffer, opts ...grpc.CallOption) (*VFSFileBuffer, error) {
 	out := new(VFSFileBuffer)
 	err := c.cc.Invoke(ctx, "/proto.API/VFSGetBuffer", in, out, opts...)
@@ -725,6 +994,38 @@ func (x *aPIWatchEventClient) Recv() (*EventResponse, error) {
 	return m, nil
 }
 
+func (c *aPIClient) TailResultSet(ctx context.Context, in *TailResultSetRequest, opts ...grpc.CallOption) (API_TailResultSetClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[2], "/proto.API/TailResultSet", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPITailResultSetClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_TailResultSetClient interface {
+	Recv() (*TailResultSetResponse, error)
+	grpc.ClientStream
+}
+
+type aPITailResultSetClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPITailResultSetClient) Recv() (*TailResultSetResponse, error) {
+	m := new(TailResultSetResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *aPIClient) PushEvents(ctx context.Context, in *PushEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/proto.API/PushEvents", in, out, opts...)
@@ -809,6 +1110,10 @@ type APIServer interface {
 	GetClient(context.Context, *GetClientRequest) (*ApiClient, error)
 	GetClientMetadata(context.Context, *GetClientRequest) (*ClientMetadata, error)
 	SetClientMetadata(context.Context, *ClientMetadata) (*emptypb.Empty, error)
+	// Saved client groups
+	GetClientGroups(context.Context, *emptypb.Empty) (*ClientGroups, error)
+	SetClientGroup(context.Context, *ClientGroup) (*ClientGroup, error)
+	DeleteClientGroup(context.Context, *ClientGroup) (*emptypb.Empty, error)
 	GetClientFlows(context.Context, *ApiFlowRequest) (*ApiFlowResponse, error)
 	// Users
 	GetUserUITraits(context.Context, *emptypb.Empty) (*ApiUser, error)
@@ -817,12 +1122,26 @@ type APIServer interface {
 	GetUsers(context.Context, *emptypb.Empty) (*Users, error)
 	// List all the GUI users in orgs in which we are a member
 	GetGlobalUsers(context.Context, *emptypb.Empty) (*Users, error)
+	// Report the current org's resource usage and quotas.
+	GetOrgUsage(context.Context, *emptypb.Empty) (*OrgUsage, error)
 	GetUserRoles(context.Context, *UserRequest) (*UserRoles, error)
 	SetUserRoles(context.Context, *UserRoles) (*emptypb.Empty, error)
 	GetUser(context.Context, *UserRequest) (*VelociraptorUser, error)
 	CreateUser(context.Context, *UpdateUserRequest) (*emptypb.Empty, error)
 	GetUserFavorites(context.Context, *Favorite) (*Favorites, error)
 	SetPassword(context.Context, *SetPasswordRequest) (*emptypb.Empty, error)
+	// API keys for scripts and integrations.
+	GetApiKeys(context.Context, *emptypb.Empty) (*ApiKeys, error)
+	CreateApiKey(context.Context, *ApiKey) (*ApiKey, error)
+	RotateApiKey(context.Context, *ApiKey) (*ApiKey, error)
+	DeleteApiKey(context.Context, *ApiKey) (*emptypb.Empty, error)
+	// Approval of dangerous operations.
+	GetApprovals(context.Context, *GetApprovalsRequest) (*ApprovalRequests, error)
+	RequestApproval(context.Context, *ApprovalRequest) (*ApprovalRequest, error)
+	DecideApproval(context.Context, *ApprovalDecision) (*ApprovalRequest, error)
+	// Approval of newly enrolled clients.
+	GetEnrollments(context.Context, *GetEnrollmentsRequest) (*EnrollmentRecords, error)
+	DecideEnrollment(context.Context, *EnrollmentDecision) (*EnrollmentRecord, error)
 	// VFS
 	VFSListDirectory(context.Context, *VFSListRequest) (*VFSListResponse, error)
 	VFSListDirectoryFiles(context.Context, *GetTableRequest) (*GetTableResponse, error)
@@ -837,17 +1156,30 @@ type APIServer interface {
 	GetFlowRequests(context.Context, *ApiFlowRequest) (*ApiFlowRequestDetails, error)
 	// VQL assistance
 	GetKeywordCompletions(context.Context, *emptypb.Empty) (*KeywordCompletions, error)
+	// Completions generated from the plugins, functions and
+	// artifacts available on this server.
+	GetCompletions(context.Context, *CompletionsRequest) (*KeywordCompletions, error)
+	GetSignature(context.Context, *SignatureRequest) (*Completion, error)
 	ReformatVQL(context.Context, *ReformatVQLMessage) (*ReformatVQLMessage, error)
+	// Run a query and report the time spent in each stage and plugin.
+	ExplainQuery(context.Context, *ExplainRequest) (*ExplainResponse, error)
 	// Artifacts
 	GetArtifacts(context.Context, *GetArtifactsRequest) (*proto1.ArtifactDescriptors, error)
 	GetArtifactFile(context.Context, *GetArtifactRequest) (*GetArtifactResponse, error)
 	SetArtifactFile(context.Context, *SetArtifactRequest) (*APIResponse, error)
 	LoadArtifactPack(context.Context, *VFSFileBuffer) (*LoadArtifactPackResponse, error)
+	// Convert the KAPE targets (.tkape files) in a zip file into
+	// artifacts.
+	ImportKapeTargets(context.Context, *VFSFileBuffer) (*LoadArtifactPackResponse, error)
+	GetKapeTargets(context.Context, *emptypb.Empty) (*KapeTargets, error)
 	// Tools
 	GetToolInfo(context.Context, *proto1.Tool) (*proto1.Tool, error)
 	SetToolInfo(context.Context, *proto1.Tool) (*proto1.Tool, error)
 	// Reporting and post processing.
 	GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error)
+	// Render notebooks, cases and reports into html or pdf
+	// documents on the server.
+	RenderDocument(context.Context, *RenderDocumentRequest) (*RenderDocumentResponse, error)
 	// Server Monitoring Artifacts - manage the Server Monitoring
 	// Service..
 	GetServerMonitoringState(context.Context, *emptypb.Empty) (*proto.ArtifactCollectorArgs, error)
@@ -863,6 +1195,9 @@ type APIServer interface {
 	ListAvailableEventResults(context.Context, *ListAvailableEventResultsRequest) (*ListAvailableEventResultsResponse, error)
 	// Schedule downloads.
 	CreateDownloadFile(context.Context, *CreateDownloadRequest) (*CreateDownloadResponse, error)
+	// Move flows and hunts between servers.
+	ExportArchive(context.Context, *ExportArchiveRequest) (*CreateDownloadResponse, error)
+	ImportArchive(context.Context, *ImportArchiveRequest) (*ImportArchiveResponse, error)
 	// Notebook management
 	GetNotebooks(context.Context, *NotebookCellRequest) (*Notebooks, error)
 	NewNotebook(context.Context, *NotebookMetadata) (*NotebookMetadata, error)
@@ -873,12 +1208,21 @@ type APIServer interface {
 	CancelNotebookCell(context.Context, *NotebookCellRequest) (*emptypb.Empty, error)
 	CreateNotebookDownloadFile(context.Context, *NotebookExportRequest) (*emptypb.Empty, error)
 	UploadNotebookAttachment(context.Context, *NotebookFileUploadRequest) (*NotebookFileUploadResponse, error)
+	// Case management
+	GetCases(context.Context, *CasesRequest) (*Cases, error)
+	// Create a new case (if case_id is not set) or update an
+	// existing case.
+	SetCase(context.Context, *Case) (*Case, error)
+	AddCaseNote(context.Context, *CaseNoteRequest) (*Case, error)
+	DeleteCase(context.Context, *CasesRequest) (*emptypb.Empty, error)
 	// This can be used by API clients to fetch file content.
 	VFSGetBuffer(context.Context, *VFSFileBuffer) (*VFSFileBuffer, error)
 	// Streaming free form VQL.
 	Query(*proto2.VQLCollectorArgs, API_QueryServer) error
 	// Watch for events from the master.
 	WatchEvent(*EventRequest, API_WatchEventServer) error
+	// Stream new rows of a result set as they are written.
+	TailResultSet(*TailResultSetRequest, API_TailResultSetServer) error
 	// Push the events to the master
 	PushEvents(context.Context, *PushEventRequest) (*emptypb.Empty, error)
 	// Push monitoring event to the server.
@@ -936,6 +1280,15 @@ func (UnimplementedAPIServer) GetClientMetadata(context.Context, *GetClientReque
 func (UnimplementedAPIServer) SetClientMetadata(context.Context, *ClientMetadata) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetClientMetadata not implemented")
 }
+func (UnimplementedAPIServer) GetClientGroups(context.Context, *emptypb.Empty) (*ClientGroups, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientGroups not implemented")
+}
+func (UnimplementedAPIServer) SetClientGroup(context.Context, *ClientGroup) (*ClientGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetClientGroup not implemented")
+}
+func (UnimplementedAPIServer) DeleteClientGroup(context.Context, *ClientGroup) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteClientGroup not implemented")
+}
 func (UnimplementedAPIServer) GetClientFlows(context.Context, *ApiFlowRequest) (*ApiFlowResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetClientFlows not implemented")
 }
@@ -951,6 +1304,9 @@ func (UnimplementedAPIServer) GetUsers(context.Context, *emptypb.Empty) (*Users,
 func (UnimplementedAPIServer) GetGlobalUsers(context.Context, *emptypb.Empty) (*Users, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetGlobalUsers not implemented")
 }
+func (UnimplementedAPIServer) GetOrgUsage(context.Context, *emptypb.Empty) (*OrgUsage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrgUsage not implemented")
+}
 func (UnimplementedAPIServer) GetUserRoles(context.Context, *UserRequest) (*UserRoles, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserRoles not implemented")
 }
@@ -969,6 +1325,33 @@ func (UnimplementedAPIServer) GetUserFavorites(context.Context, *Favorite) (*Fav
 func (UnimplementedAPIServer) SetPassword(context.Context, *SetPasswordRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetPassword not implemented")
 }
+func (UnimplementedAPIServer) GetApiKeys(context.Context, *emptypb.Empty) (*ApiKeys, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApiKeys not implemented")
+}
+func (UnimplementedAPIServer) CreateApiKey(context.Context, *ApiKey) (*ApiKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApiKey not implemented")
+}
+func (UnimplementedAPIServer) RotateApiKey(context.Context, *ApiKey) (*ApiKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateApiKey not implemented")
+}
+func (UnimplementedAPIServer) DeleteApiKey(context.Context, *ApiKey) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteApiKey not implemented")
+}
+func (UnimplementedAPIServer) GetApprovals(context.Context, *GetApprovalsRequest) (*ApprovalRequests, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApprovals not implemented")
+}
+func (UnimplementedAPIServer) RequestApproval(context.Context, *ApprovalRequest) (*ApprovalRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestApproval not implemented")
+}
+func (UnimplementedAPIServer) DecideApproval(context.Context, *ApprovalDecision) (*ApprovalRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecideApproval not implemented")
+}
+func (UnimplementedAPIServer) GetEnrollments(context.Context, *GetEnrollmentsRequest) (*EnrollmentRecords, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEnrollments not implemented")
+}
+func (UnimplementedAPIServer) DecideEnrollment(context.Context, *EnrollmentDecision) (*EnrollmentRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecideEnrollment not implemented")
+}
 func (UnimplementedAPIServer) VFSListDirectory(context.Context, *VFSListRequest) (*VFSListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VFSListDirectory not implemented")
 }
@@ -1002,9 +1385,18 @@ func (UnimplementedAPIServer) GetFlowRequests(context.Context, *ApiFlowRequest)
 func (UnimplementedAPIServer) GetKeywordCompletions(context.Context, *emptypb.Empty) (*KeywordCompletions, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetKeywordCompletions not implemented")
 }
+func (UnimplementedAPIServer) GetCompletions(context.Context, *CompletionsRequest) (*KeywordCompletions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompletions not implemented")
+}
+func (UnimplementedAPIServer) GetSignature(context.Context, *SignatureRequest) (*Completion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSignature not implemented")
+}
 func (UnimplementedAPIServer) ReformatVQL(context.Context, *ReformatVQLMessage) (*ReformatVQLMessage, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReformatVQL not implemented")
 }
+func (UnimplementedAPIServer) ExplainQuery(context.Context, *ExplainRequest) (*ExplainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainQuery not implemented")
+}
 func (UnimplementedAPIServer) GetArtifacts(context.Context, *GetArtifactsRequest) (*proto1.ArtifactDescriptors, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetArtifacts not implemented")
 }
@@ -1017,6 +1409,12 @@ func (UnimplementedAPIServer) SetArtifactFile(context.Context, *SetArtifactReque
 func (UnimplementedAPIServer) LoadArtifactPack(context.Context, *VFSFileBuffer) (*LoadArtifactPackResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoadArtifactPack not implemented")
 }
+func (UnimplementedAPIServer) ImportKapeTargets(context.Context, *VFSFileBuffer) (*LoadArtifactPackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportKapeTargets not implemented")
+}
+func (UnimplementedAPIServer) GetKapeTargets(context.Context, *emptypb.Empty) (*KapeTargets, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKapeTargets not implemented")
+}
 func (UnimplementedAPIServer) GetToolInfo(context.Context, *proto1.Tool) (*proto1.Tool, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetToolInfo not implemented")
 }
@@ -1026,6 +1424,9 @@ func (UnimplementedAPIServer) SetToolInfo(context.Context, *proto1.Tool) (*proto
 func (UnimplementedAPIServer) GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetReport not implemented")
 }
+func (UnimplementedAPIServer) RenderDocument(context.Context, *RenderDocumentRequest) (*RenderDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderDocument not implemented")
+}
 func (UnimplementedAPIServer) GetServerMonitoringState(context.Context, *emptypb.Empty) (*proto.ArtifactCollectorArgs, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetServerMonitoringState not implemented")
 }
@@ -1044,6 +1445,12 @@ func (UnimplementedAPIServer) ListAvailableEventResults(context.Context, *ListAv
 func (UnimplementedAPIServer) CreateDownloadFile(context.Context, *CreateDownloadRequest) (*CreateDownloadResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateDownloadFile not implemented")
 }
+func (UnimplementedAPIServer) ExportArchive(context.Context, *ExportArchiveRequest) (*CreateDownloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportArchive not implemented")
+}
+func (UnimplementedAPIServer) ImportArchive(context.Context, *ImportArchiveRequest) (*ImportArchiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportArchive not implemented")
+}
 func (UnimplementedAPIServer) GetNotebooks(context.Context, *NotebookCellRequest) (*Notebooks, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNotebooks not implemented")
 }
@@ -1071,6 +1478,18 @@ func (UnimplementedAPIServer) CreateNotebookDownloadFile(context.Context, *Noteb
 func (UnimplementedAPIServer) UploadNotebookAttachment(context.Context, *NotebookFileUploadRequest) (*NotebookFileUploadResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UploadNotebookAttachment not implemented")
 }
+func (UnimplementedAPIServer) GetCases(context.Context, *CasesRequest) (*Cases, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCases not implemented")
+}
+func (UnimplementedAPIServer) SetCase(context.Context, *Case) (*Case, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCase not implemented")
+}
+func (UnimplementedAPIServer) AddCaseNote(context.Context, *CaseNoteRequest) (*Case, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCaseNote not implemented")
+}
+func (UnimplementedAPIServer) DeleteCase(context.Context, *CasesRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCase not implemented")
+}
 func (UnimplementedAPIServer) VFSGetBuffer(context.Context, *VFSFileBuffer) (*VFSFileBuffer, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VFSGetBuffer not implemented")
 }
@@ -1080,6 +1499,9 @@ func (UnimplementedAPIServer) Query(*proto2.VQLCollectorArgs, API_QueryServer) e
 func (UnimplementedAPIServer) WatchEvent(*EventRequest, API_WatchEventServer) error {
 	return status.Errorf(codes.Unimplemented, "method WatchEvent not implemented")
 }
+func (UnimplementedAPIServer) TailResultSet(*TailResultSetRequest, API_TailResultSetServer) error {
+	return status.Errorf(codes.Unimplemented, "method TailResultSet not implemented")
+}
 func (UnimplementedAPIServer) PushEvents(context.Context, *PushEventRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PushEvents not implemented")
 }
@@ -1348,6 +1770,60 @@ func _API_SetClientMetadata_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetClientGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetClientGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/GetClientGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetClientGroups(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SetClientGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientGroup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SetClientGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/SetClientGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SetClientGroup(ctx, req.(*ClientGroup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DeleteClientGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientGroup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DeleteClientGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/DeleteClientGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteClientGroup(ctx, req.(*ClientGroup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_GetClientFlows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ApiFlowRequest)
 	if err := dec(in); err != nil {
@@ -1438,26 +1914,44 @@ func _API_GetGlobalUsers_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
-func _API_GetUserRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserRequest)
+func _API_GetOrgUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServer).GetUserRoles(ctx, in)
+		return srv.(APIServer).GetOrgUsage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.API/GetUserRoles",
+		FullMethod: "/proto.API/GetOrgUsage",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).GetUserRoles(ctx, req.(*UserRequest))
+		return srv.(APIServer).GetOrgUsage(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _API_SetUserRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserRoles)
+func _API_GetUserRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetUserRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/GetUserRoles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetUserRoles(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SetUserRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRoles)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -1546,6 +2040,168 @@ func _API_SetPassword_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetApiKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetApiKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/GetApiKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetApiKeys(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CreateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApiKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/CreateApiKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateApiKey(ctx, req.(*ApiKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RotateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApiKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RotateApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/RotateApiKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RotateApiKey(ctx, req.(*ApiKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DeleteApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApiKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DeleteApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/DeleteApiKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteApiKey(ctx, req.(*ApiKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetApprovalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/GetApprovals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetApprovals(ctx, req.(*GetApprovalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RequestApproval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RequestApproval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/RequestApproval",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RequestApproval(ctx, req.(*ApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DecideApproval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovalDecision)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DecideApproval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/DecideApproval",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DecideApproval(ctx, req.(*ApprovalDecision))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetEnrollments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEnrollmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetEnrollments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/GetEnrollments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetEnrollments(ctx, req.(*GetEnrollmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DecideEnrollment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollmentDecision)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DecideEnrollment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/DecideEnrollment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DecideEnrollment(ctx, req.(*EnrollmentDecision))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_VFSListDirectory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(VFSListRequest)
 	if err := dec(in); err != nil {
@@ -1744,6 +2400,42 @@ func _API_GetKeywordCompletions_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetCompletions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompletionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetCompletions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/GetCompletions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetCompletions(ctx, req.(*CompletionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetSignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetSignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/GetSignature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetSignature(ctx, req.(*SignatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_ReformatVQL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReformatVQLMessage)
 	if err := dec(in); err != nil {
@@ -1762,6 +2454,24 @@ func _API_ReformatVQL_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_ExplainQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ExplainQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/ExplainQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ExplainQuery(ctx, req.(*ExplainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_GetArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetArtifactsRequest)
 	if err := dec(in); err != nil {
@@ -1834,6 +2544,42 @@ func _API_LoadArtifactPack_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_ImportKapeTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VFSFileBuffer)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ImportKapeTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/ImportKapeTargets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ImportKapeTargets(ctx, req.(*VFSFileBuffer))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetKapeTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetKapeTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/GetKapeTargets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetKapeTargets(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_GetToolInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(proto1.Tool)
 	if err := dec(in); err != nil {
@@ -1888,6 +2634,24 @@ func _API_GetReport_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_RenderDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RenderDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/RenderDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RenderDocument(ctx, req.(*RenderDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_GetServerMonitoringState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1996,6 +2760,42 @@ func _API_CreateDownloadFile_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_ExportArchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportArchiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ExportArchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/ExportArchive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ExportArchive(ctx, req.(*ExportArchiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ImportArchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportArchiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ImportArchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/ImportArchive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ImportArchive(ctx, req.(*ImportArchiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_GetNotebooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NotebookCellRequest)
 	if err := dec(in); err != nil {
@@ -2158,6 +2958,78 @@ func _API_UploadNotebookAttachment_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetCases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetCases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/GetCases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetCases(ctx, req.(*CasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SetCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Case)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SetCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/SetCase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SetCase(ctx, req.(*Case))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_AddCaseNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaseNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).AddCaseNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/AddCaseNote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).AddCaseNote(ctx, req.(*CaseNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DeleteCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DeleteCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.API/DeleteCase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteCase(ctx, req.(*CasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_VFSGetBuffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(VFSFileBuffer)
 	if err := dec(in); err != nil {
@@ -2218,6 +3090,27 @@ func (x *aPIWatchEventServer) Send(m *EventResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _API_TailResultSet_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailResultSetRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).TailResultSet(m, &aPITailResultSetServer{stream})
+}
+
+type API_TailResultSetServer interface {
+	Send(*TailResultSetResponse) error
+	grpc.ServerStream
+}
+
+type aPITailResultSetServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPITailResultSetServer) Send(m *TailResultSetResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _API_PushEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PushEventRequest)
 	if err := dec(in); err != nil {
@@ -2403,6 +3296,18 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetClientMetadata",
 			Handler:    _API_SetClientMetadata_Handler,
 		},
+		{
+			MethodName: "GetClientGroups",
+			Handler:    _API_GetClientGroups_Handler,
+		},
+		{
+			MethodName: "SetClientGroup",
+			Handler:    _API_SetClientGroup_Handler,
+		},
+		{
+			MethodName: "DeleteClientGroup",
+			Handler:    _API_DeleteClientGroup_Handler,
+		},
 		{
 			MethodName: "GetClientFlows",
 			Handler:    _API_GetClientFlows_Handler,
@@ -2423,6 +3328,10 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetGlobalUsers",
 			Handler:    _API_GetGlobalUsers_Handler,
 		},
+		{
+			MethodName: "GetOrgUsage",
+			Handler:    _API_GetOrgUsage_Handler,
+		},
 		{
 			MethodName: "GetUserRoles",
 			Handler:    _API_GetUserRoles_Handler,
@@ -2447,6 +3356,42 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetPassword",
 			Handler:    _API_SetPassword_Handler,
 		},
+		{
+			MethodName: "GetApiKeys",
+			Handler:    _API_GetApiKeys_Handler,
+		},
+		{
+			MethodName: "CreateApiKey",
+			Handler:    _API_CreateApiKey_Handler,
+		},
+		{
+			MethodName: "RotateApiKey",
+			Handler:    _API_RotateApiKey_Handler,
+		},
+		{
+			MethodName: "DeleteApiKey",
+			Handler:    _API_DeleteApiKey_Handler,
+		},
+		{
+			MethodName: "GetApprovals",
+			Handler:    _API_GetApprovals_Handler,
+		},
+		{
+			MethodName: "RequestApproval",
+			Handler:    _API_RequestApproval_Handler,
+		},
+		{
+			MethodName: "DecideApproval",
+			Handler:    _API_DecideApproval_Handler,
+		},
+		{
+			MethodName: "GetEnrollments",
+			Handler:    _API_GetEnrollments_Handler,
+		},
+		{
+			MethodName: "DecideEnrollment",
+			Handler:    _API_DecideEnrollment_Handler,
+		},
 		{
 			MethodName: "VFSListDirectory",
 			Handler:    _API_VFSListDirectory_Handler,
@@ -2491,10 +3436,22 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetKeywordCompletions",
 			Handler:    _API_GetKeywordCompletions_Handler,
 		},
+		{
+			MethodName: "GetCompletions",
+			Handler:    _API_GetCompletions_Handler,
+		},
+		{
+			MethodName: "GetSignature",
+			Handler:    _API_GetSignature_Handler,
+		},
 		{
 			MethodName: "ReformatVQL",
 			Handler:    _API_ReformatVQL_Handler,
 		},
+		{
+			MethodName: "ExplainQuery",
+			Handler:    _API_ExplainQuery_Handler,
+		},
 		{
 			MethodName: "GetArtifacts",
 			Handler:    _API_GetArtifacts_Handler,
@@ -2511,6 +3468,14 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LoadArtifactPack",
 			Handler:    _API_LoadArtifactPack_Handler,
 		},
+		{
+			MethodName: "ImportKapeTargets",
+			Handler:    _API_ImportKapeTargets_Handler,
+		},
+		{
+			MethodName: "GetKapeTargets",
+			Handler:    _API_GetKapeTargets_Handler,
+		},
 		{
 			MethodName: "GetToolInfo",
 			Handler:    _API_GetToolInfo_Handler,
@@ -2523,6 +3488,10 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetReport",
 			Handler:    _API_GetReport_Handler,
 		},
+		{
+			MethodName: "RenderDocument",
+			Handler:    _API_RenderDocument_Handler,
+		},
 		{
 			MethodName: "GetServerMonitoringState",
 			Handler:    _API_GetServerMonitoringState_Handler,
@@ -2547,6 +3516,14 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateDownloadFile",
 			Handler:    _API_CreateDownloadFile_Handler,
 		},
+		{
+			MethodName: "ExportArchive",
+			Handler:    _API_ExportArchive_Handler,
+		},
+		{
+			MethodName: "ImportArchive",
+			Handler:    _API_ImportArchive_Handler,
+		},
 		{
 			MethodName: "GetNotebooks",
 			Handler:    _API_GetNotebooks_Handler,
@@ -2583,6 +3560,22 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UploadNotebookAttachment",
 			Handler:    _API_UploadNotebookAttachment_Handler,
 		},
+		{
+			MethodName: "GetCases",
+			Handler:    _API_GetCases_Handler,
+		},
+		{
+			MethodName: "SetCase",
+			Handler:    _API_SetCase_Handler,
+		},
+		{
+			MethodName: "AddCaseNote",
+			Handler:    _API_AddCaseNote_Handler,
+		},
+		{
+			MethodName: "DeleteCase",
+			Handler:    _API_DeleteCase_Handler,
+		},
 		{
 			MethodName: "VFSGetBuffer",
 			Handler:    _API_VFSGetBuffer_Handler,
@@ -2627,6 +3620,11 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _API_WatchEvent_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "TailResultSet",
+			Handler:       _API_TailResultSet_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api.proto",
 }
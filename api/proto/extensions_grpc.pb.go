@@ -0,0 +1,166 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// source: extensions.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// VQLExtensionClient is the client API for VQLExtension service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VQLExtensionClient interface {
+	Describe(ctx context.Context, in *ExtensionDescribeRequest, opts ...grpc.CallOption) (*ExtensionDescription, error)
+	Call(ctx context.Context, in *ExtensionCallRequest, opts ...grpc.CallOption) (VQLExtension_CallClient, error)
+}
+
+type vQLExtensionClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVQLExtensionClient(cc grpc.ClientConnInterface) VQLExtensionClient {
+	return &vQLExtensionClient{cc}
+}
+
+func (c *vQLExtensionClient) Describe(ctx context.Context, in *ExtensionDescribeRequest, opts ...grpc.CallOption) (*ExtensionDescription, error) {
+	out := new(ExtensionDescription)
+	err := c.cc.Invoke(ctx, "/proto.VQLExtension/Describe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vQLExtensionClient) Call(ctx context.Context, in *ExtensionCallRequest, opts ...grpc.CallOption) (VQLExtension_CallClient, error) {
+	stream, err := c.cc.NewStream(ctx, &VQLExtension_ServiceDesc.Streams[0], "/proto.VQLExtension/Call", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vQLExtensionCallClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VQLExtension_CallClient interface {
+	Recv() (*ExtensionCallResponse, error)
+	grpc.ClientStream
+}
+
+type vQLExtensionCallClient struct {
+	grpc.ClientStream
+}
+
+func (x *vQLExtensionCallClient) Recv() (*ExtensionCallResponse, error) {
+	m := new(ExtensionCallResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// VQLExtensionServer is the server API for VQLExtension service.
+// All implementations must embed UnimplementedVQLExtensionServer
+// for forward compatibility
+type VQLExtensionServer interface {
+	Describe(context.Context, *ExtensionDescribeRequest) (*ExtensionDescription, error)
+	Call(*ExtensionCallRequest, VQLExtension_CallServer) error
+	mustEmbedUnimplementedVQLExtensionServer()
+}
+
+// UnimplementedVQLExtensionServer must be embedded to have forward compatible implementations.
+type UnimplementedVQLExtensionServer struct {
+}
+
+func (UnimplementedVQLExtensionServer) Describe(context.Context, *ExtensionDescribeRequest) (*ExtensionDescription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Describe not implemented")
+}
+func (UnimplementedVQLExtensionServer) Call(*ExtensionCallRequest, VQLExtension_CallServer) error {
+	return status.Errorf(codes.Unimplemented, "method Call not implemented")
+}
+func (UnimplementedVQLExtensionServer) mustEmbedUnimplementedVQLExtensionServer() {}
+
+// UnsafeVQLExtensionServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VQLExtensionServer will
+// result in compilation errors.
+type UnsafeVQLExtensionServer interface {
+	mustEmbedUnimplementedVQLExtensionServer()
+}
+
+func RegisterVQLExtensionServer(s grpc.ServiceRegistrar, srv VQLExtensionServer) {
+	s.RegisterService(&VQLExtension_ServiceDesc, srv)
+}
+
+func _VQLExtension_Describe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtensionDescribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VQLExtensionServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.VQLExtension/Describe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VQLExtensionServer).Describe(ctx, req.(*ExtensionDescribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VQLExtension_Call_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExtensionCallRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VQLExtensionServer).Call(m, &vQLExtensionCallServer{stream})
+}
+
+type VQLExtension_CallServer interface {
+	Send(*ExtensionCallResponse) error
+	grpc.ServerStream
+}
+
+type vQLExtensionCallServer struct {
+	grpc.ServerStream
+}
+
+func (x *vQLExtensionCallServer) Send(m *ExtensionCallResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// VQLExtension_ServiceDesc is the grpc.ServiceDesc for VQLExtension service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VQLExtension_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.VQLExtension",
+	HandlerType: (*VQLExtensionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Describe",
+			Handler:    _VQLExtension_Describe_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Call",
+			Handler:       _VQLExtension_Call_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "extensions.proto",
+}
@@ -26,7 +26,9 @@ type OrgRecord struct {
 	Nonce string `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	Id    string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
 	// Deprecated do not use
-	OrgId string `protobuf:"bytes,4,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	OrgId     string             `protobuf:"bytes,4,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Quota     *OrgQuota          `protobuf:"bytes,5,opt,name=quota,proto3" json:"quota,omitempty"`
+	Artifacts *OrgArtifactPolicy `protobuf:"bytes,6,opt,name=artifacts,proto3" json:"artifacts,omitempty"`
 }
 
 func (x *OrgRecord) Reset() {
@@ -89,20 +91,346 @@ func (x *OrgRecord) GetOrgId() string {
 	return ""
 }
 
+func (x *OrgRecord) GetQuota() *OrgQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *OrgRecord) GetArtifacts() *OrgArtifactPolicy {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+// Each org has its own artifact repository which overlays the built
+// in and root org artifacts. The policy controls which of those the
+// org sees. Hidden artifacts may be replaced by org artifacts of the
+// same name.
+type OrgArtifactPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Regular expressions of the root org's custom artifacts visible
+	// in the org. All are visible if none are given.
+	Share []string `protobuf:"bytes,1,rep,name=share,proto3" json:"share,omitempty"`
+	// Regular expressions of built in and root org artifacts hidden
+	// from the org.
+	Hide []string `protobuf:"bytes,2,rep,name=hide,proto3" json:"hide,omitempty"`
+	// Prevent org artifacts from replacing visible root org artifacts
+	// of the same name. Built in artifacts can never be replaced.
+	ProtectRootArtifacts bool `protobuf:"varint,3,opt,name=protect_root_artifacts,json=protectRootArtifacts,proto3" json:"protect_root_artifacts,omitempty"`
+}
+
+func (x *OrgArtifactPolicy) Reset() {
+	*x = OrgArtifactPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_orgs_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrgArtifactPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgArtifactPolicy) ProtoMessage() {}
+
+func (x *OrgArtifactPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_orgs_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgArtifactPolicy.ProtoReflect.Descriptor instead.
+func (*OrgArtifactPolicy) Descriptor() ([]byte, []int) {
+	return file_orgs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrgArtifactPolicy) GetShare() []string {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+func (x *OrgArtifactPolicy) GetHide() []string {
+	if x != nil {
+		return x.Hide
+	}
+	return nil
+}
+
+func (x *OrgArtifactPolicy) GetProtectRootArtifacts() bool {
+	if x != nil {
+		return x.ProtectRootArtifacts
+	}
+	return false
+}
+
+// Limits on the resources an org may use. A value of 0 means
+// unlimited.
+type OrgQuota struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxConcurrentHunts uint64 `protobuf:"varint,1,opt,name=max_concurrent_hunts,json=maxConcurrentHunts,proto3" json:"max_concurrent_hunts,omitempty"`
+	MaxFilestoreBytes  uint64 `protobuf:"varint,2,opt,name=max_filestore_bytes,json=maxFilestoreBytes,proto3" json:"max_filestore_bytes,omitempty"`
+	MaxRowsPerDay      uint64 `protobuf:"varint,3,opt,name=max_rows_per_day,json=maxRowsPerDay,proto3" json:"max_rows_per_day,omitempty"`
+	MaxNotebookCpuSec  uint64 `protobuf:"varint,4,opt,name=max_notebook_cpu_sec,json=maxNotebookCpuSec,proto3" json:"max_notebook_cpu_sec,omitempty"`
+	// Server artifact queries and notebook cells the org may run at
+	// the same time.
+	MaxServerConcurrency uint64 `protobuf:"varint,5,opt,name=max_server_concurrency,json=maxServerConcurrency,proto3" json:"max_server_concurrency,omitempty"`
+}
+
+func (x *OrgQuota) Reset() {
+	*x = OrgQuota{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_orgs_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrgQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgQuota) ProtoMessage() {}
+
+func (x *OrgQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_orgs_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgQuota.ProtoReflect.Descriptor instead.
+func (*OrgQuota) Descriptor() ([]byte, []int) {
+	return file_orgs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrgQuota) GetMaxConcurrentHunts() uint64 {
+	if x != nil {
+		return x.MaxConcurrentHunts
+	}
+	return 0
+}
+
+func (x *OrgQuota) GetMaxFilestoreBytes() uint64 {
+	if x != nil {
+		return x.MaxFilestoreBytes
+	}
+	return 0
+}
+
+func (x *OrgQuota) GetMaxRowsPerDay() uint64 {
+	if x != nil {
+		return x.MaxRowsPerDay
+	}
+	return 0
+}
+
+func (x *OrgQuota) GetMaxNotebookCpuSec() uint64 {
+	if x != nil {
+		return x.MaxNotebookCpuSec
+	}
+	return 0
+}
+
+func (x *OrgQuota) GetMaxServerConcurrency() uint64 {
+	if x != nil {
+		return x.MaxServerConcurrency
+	}
+	return 0
+}
+
+// The resources used by an org. Daily counters are reset at midnight
+// UTC.
+type OrgUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrgId string `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// The UTC day the daily counters apply to (e.g. 2023-01-30)
+	Day            string `protobuf:"bytes,2,opt,name=day,proto3" json:"day,omitempty"`
+	RunningHunts   uint64 `protobuf:"varint,3,opt,name=running_hunts,json=runningHunts,proto3" json:"running_hunts,omitempty"`
+	FilestoreBytes uint64 `protobuf:"varint,4,opt,name=filestore_bytes,json=filestoreBytes,proto3" json:"filestore_bytes,omitempty"`
+	// When the filestore size was last measured.
+	FilestoreScanTime uint64 `protobuf:"varint,5,opt,name=filestore_scan_time,json=filestoreScanTime,proto3" json:"filestore_scan_time,omitempty"`
+	RowsToday         uint64 `protobuf:"varint,6,opt,name=rows_today,json=rowsToday,proto3" json:"rows_today,omitempty"`
+	// Time spent calculating notebook cells today.
+	NotebookCpuSecToday float64   `protobuf:"fixed64,7,opt,name=notebook_cpu_sec_today,json=notebookCpuSecToday,proto3" json:"notebook_cpu_sec_today,omitempty"`
+	Quota               *OrgQuota `protobuf:"bytes,8,opt,name=quota,proto3" json:"quota,omitempty"`
+}
+
+func (x *OrgUsage) Reset() {
+	*x = OrgUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_orgs_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrgUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgUsage) ProtoMessage() {}
+
+func (x *OrgUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_orgs_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgUsage.ProtoReflect.Descriptor instead.
+func (*OrgUsage) Descriptor() ([]byte, []int) {
+	return file_orgs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OrgUsage) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *OrgUsage) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *OrgUsage) GetRunningHunts() uint64 {
+	if x != nil {
+		return x.RunningHunts
+	}
+	return 0
+}
+
+func (x *OrgUsage) GetFilestoreBytes() uint64 {
+	if x != nil {
+		return x.FilestoreBytes
+	}
+	return 0
+}
+
+func (x *OrgUsage) GetFilestoreScanTime() uint64 {
+	if x != nil {
+		return x.FilestoreScanTime
+	}
+	return 0
+}
+
+func (x *OrgUsage) GetRowsToday() uint64 {
+	if x != nil {
+		return x.RowsToday
+	}
+	return 0
+}
+
+func (x *OrgUsage) GetNotebookCpuSecToday() float64 {
+	if x != nil {
+		return x.NotebookCpuSecToday
+	}
+	return 0
+}
+
+func (x *OrgUsage) GetQuota() *OrgQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
 var File_orgs_proto protoreflect.FileDescriptor
 
 var file_orgs_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x6f, 0x72, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0x5c, 0x0a, 0x09, 0x4f, 0x72, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6f, 0x72,
-	0x67, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x72, 0x67, 0x49,
-	0x64, 0x42, 0x31, 0x5a, 0x2f, 0x77, 0x77, 0x77, 0x2e, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x64,
-	0x65, 0x78, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f, 0x76, 0x65,
-	0x6c, 0x6f, 0x63, 0x69, 0x72, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x74, 0x6f, 0x22, 0xbb, 0x01, 0x0a, 0x09, 0x4f, 0x72, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6f,
+	0x72, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x72, 0x67,
+	0x49, 0x64, 0x12, 0x25, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x72, 0x67, 0x51, 0x75, 0x6f,
+	0x74, 0x61, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x36, 0x0a, 0x09, 0x61, 0x72, 0x74,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x72, 0x67, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x73, 0x22, 0x73, 0x0a, 0x11, 0x4f, 0x72, 0x67, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x69, 0x64, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x68, 0x69, 0x64, 0x65,
+	0x12, 0x34, 0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x5f, 0x72, 0x6f, 0x6f, 0x74,
+	0x5f, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x14, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x52, 0x6f, 0x6f, 0x74, 0x41, 0x72, 0x74,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x22, 0xfc, 0x01, 0x0a, 0x08, 0x4f, 0x72, 0x67, 0x51, 0x75,
+	0x6f, 0x74, 0x61, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x48, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x6f, 0x77,
+	0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0d, 0x6d, 0x61, 0x78, 0x52, 0x6f, 0x77, 0x73, 0x50, 0x65, 0x72, 0x44, 0x61, 0x79, 0x12, 0x2f,
+	0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x5f, 0x63,
+	0x70, 0x75, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x6d, 0x61,
+	0x78, 0x4e, 0x6f, 0x74, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x43, 0x70, 0x75, 0x53, 0x65, 0x63, 0x12,
+	0x34, 0x0a, 0x16, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x63, 0x6f,
+	0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x14, 0x6d, 0x61, 0x78, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0xac, 0x02, 0x0a, 0x08, 0x4f, 0x72, 0x67, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6f, 0x72, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x61, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x61, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x72,
+	0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x68, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x48, 0x75, 0x6e, 0x74, 0x73,
+	0x12, 0x27, 0x0a, 0x0f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x73, 0x63, 0x61, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x53, 0x63, 0x61, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x6f, 0x77,
+	0x73, 0x5f, 0x74, 0x6f, 0x64, 0x61, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x72,
+	0x6f, 0x77, 0x73, 0x54, 0x6f, 0x64, 0x61, 0x79, 0x12, 0x33, 0x0a, 0x16, 0x6e, 0x6f, 0x74, 0x65,
+	0x62, 0x6f, 0x6f, 0x6b, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x73, 0x65, 0x63, 0x5f, 0x74, 0x6f, 0x64,
+	0x61, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x6e, 0x6f, 0x74, 0x65, 0x62, 0x6f,
+	0x6f, 0x6b, 0x43, 0x70, 0x75, 0x53, 0x65, 0x63, 0x54, 0x6f, 0x64, 0x61, 0x79, 0x12, 0x25, 0x0a,
+	0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x72, 0x67, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x52, 0x05, 0x71,
+	0x75, 0x6f, 0x74, 0x61, 0x42, 0x31, 0x5a, 0x2f, 0x77, 0x77, 0x77, 0x2e, 0x76, 0x65, 0x6c, 0x6f,
+	0x63, 0x69, 0x64, 0x65, 0x78, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67,
+	0x2f, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x72, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -117,16 +445,22 @@ func file_orgs_proto_rawDescGZIP() []byte {
 	return file_orgs_proto_rawDescData
 }
 
-var file_orgs_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_orgs_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_orgs_proto_goTypes = []interface{}{
-	(*OrgRecord)(nil), // 0: proto.OrgRecord
+	(*OrgRecord)(nil),         // 0: proto.OrgRecord
+	(*OrgArtifactPolicy)(nil), // 1: proto.OrgArtifactPolicy
+	(*OrgQuota)(nil),          // 2: proto.OrgQuota
+	(*OrgUsage)(nil),          // 3: proto.OrgUsage
 }
 var file_orgs_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2, // 0: proto.OrgRecord.quota:type_name -> proto.OrgQuota
+	1, // 1: proto.OrgRecord.artifacts:type_name -> proto.OrgArtifactPolicy
+	2, // 2: proto.OrgUsage.quota:type_name -> proto.OrgQuota
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_orgs_proto_init() }
@@ -147,6 +481,42 @@ func file_orgs_proto_init() {
 				return nil
 			}
 		}
+		file_orgs_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrgArtifactPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_orgs_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrgQuota); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_orgs_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrgUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -154,7 +524,7 @@ func file_orgs_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_orgs_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
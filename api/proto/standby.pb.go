@@ -0,0 +1,426 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: standby.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReplicationOp_Type int32
+
+const (
+	ReplicationOp_SET_SUBJECT    ReplicationOp_Type = 0
+	ReplicationOp_DELETE_SUBJECT ReplicationOp_Type = 1
+	ReplicationOp_WRITE_FILE     ReplicationOp_Type = 2
+	ReplicationOp_TRUNCATE_FILE  ReplicationOp_Type = 3
+	ReplicationOp_DELETE_FILE    ReplicationOp_Type = 4
+	ReplicationOp_MOVE_FILE      ReplicationOp_Type = 5
+)
+
+// Enum value maps for ReplicationOp_Type.
+var (
+	ReplicationOp_Type_name = map[int32]string{
+		0: "SET_SUBJECT",
+		1: "DELETE_SUBJECT",
+		2: "WRITE_FILE",
+		3: "TRUNCATE_FILE",
+		4: "DELETE_FILE",
+		5: "MOVE_FILE",
+	}
+	ReplicationOp_Type_value = map[string]int32{
+		"SET_SUBJECT":    0,
+		"DELETE_SUBJECT": 1,
+		"WRITE_FILE":     2,
+		"TRUNCATE_FILE":  3,
+		"DELETE_FILE":    4,
+		"MOVE_FILE":      5,
+	}
+)
+
+func (x ReplicationOp_Type) Enum() *ReplicationOp_Type {
+	p := new(ReplicationOp_Type)
+	*p = x
+	return p
+}
+
+func (x ReplicationOp_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReplicationOp_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_standby_proto_enumTypes[0].Descriptor()
+}
+
+func (ReplicationOp_Type) Type() protoreflect.EnumType {
+	return &file_standby_proto_enumTypes[0]
+}
+
+func (x ReplicationOp_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReplicationOp_Type.Descriptor instead.
+func (ReplicationOp_Type) EnumDescriptor() ([]byte, []int) {
+	return file_standby_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// A single datastore or file store write to apply on the standby.
+type ReplicationOp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type  ReplicationOp_Type `protobuf:"varint,1,opt,name=type,proto3,enum=proto.ReplicationOp_Type" json:"type,omitempty"`
+	OrgId string             `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// A datastore path for subjects, otherwise a file store path.
+	Pathspec *DSPathSpec `protobuf:"bytes,3,opt,name=pathspec,proto3" json:"pathspec,omitempty"`
+	// Target of MOVE_FILE.
+	Destination *DSPathSpec `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
+	Data        []byte      `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	// Ops are numbered per node and per run (epoch) so the standby
+	// can skip ops it already applied when a batch is resent.
+	Node     string `protobuf:"bytes,6,opt,name=node,proto3" json:"node,omitempty"`
+	Epoch    int64  `protobuf:"varint,7,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Sequence uint64 `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *ReplicationOp) Reset() {
+	*x = ReplicationOp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_standby_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicationOp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationOp) ProtoMessage() {}
+
+func (x *ReplicationOp) ProtoReflect() protoreflect.Message {
+	mi := &file_standby_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationOp.ProtoReflect.Descriptor instead.
+func (*ReplicationOp) Descriptor() ([]byte, []int) {
+	return file_standby_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReplicationOp) GetType() ReplicationOp_Type {
+	if x != nil {
+		return x.Type
+	}
+	return ReplicationOp_SET_SUBJECT
+}
+
+func (x *ReplicationOp) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ReplicationOp) GetPathspec() *DSPathSpec {
+	if x != nil {
+		return x.Pathspec
+	}
+	return nil
+}
+
+func (x *ReplicationOp) GetDestination() *DSPathSpec {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *ReplicationOp) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ReplicationOp) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *ReplicationOp) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ReplicationOp) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type ReplicationBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ops []*ReplicationOp `protobuf:"bytes,1,rep,name=ops,proto3" json:"ops,omitempty"`
+}
+
+func (x *ReplicationBatch) Reset() {
+	*x = ReplicationBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_standby_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicationBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationBatch) ProtoMessage() {}
+
+func (x *ReplicationBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_standby_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationBatch.ProtoReflect.Descriptor instead.
+func (*ReplicationBatch) Descriptor() ([]byte, []int) {
+	return file_standby_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReplicationBatch) GetOps() []*ReplicationOp {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+type ReplicationAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The last op applied.
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *ReplicationAck) Reset() {
+	*x = ReplicationAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_standby_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicationAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationAck) ProtoMessage() {}
+
+func (x *ReplicationAck) ProtoReflect() protoreflect.Message {
+	mi := &file_standby_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationAck.ProtoReflect.Descriptor instead.
+func (*ReplicationAck) Descriptor() ([]byte, []int) {
+	return file_standby_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReplicationAck) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+var File_standby_proto protoreflect.FileDescriptor
+
+var file_standby_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x62, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x83, 0x03, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x2e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6f, 0x72, 0x67, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x12,
+	0x2d, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x68, 0x73, 0x70, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x53, 0x50, 0x61, 0x74, 0x68,
+	0x53, 0x70, 0x65, 0x63, 0x52, 0x08, 0x70, 0x61, 0x74, 0x68, 0x73, 0x70, 0x65, 0x63, 0x12, 0x33,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x53, 0x50, 0x61,
+	0x74, 0x68, 0x53, 0x70, 0x65, 0x63, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x6e, 0x0a,
+	0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x45, 0x54, 0x5f, 0x53, 0x55, 0x42,
+	0x4a, 0x45, 0x43, 0x54, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45,
+	0x5f, 0x53, 0x55, 0x42, 0x4a, 0x45, 0x43, 0x54, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x57, 0x52,
+	0x49, 0x54, 0x45, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x52,
+	0x55, 0x4e, 0x43, 0x41, 0x54, 0x45, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x03, 0x12, 0x0f, 0x0a,
+	0x0b, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x04, 0x12, 0x0d,
+	0x0a, 0x09, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x05, 0x22, 0x3a, 0x0a,
+	0x10, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x26, 0x0a, 0x03, 0x6f, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4f, 0x70, 0x52, 0x03, 0x6f, 0x70, 0x73, 0x22, 0x2c, 0x0a, 0x0e, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x32, 0x48, 0x0a, 0x07, 0x53, 0x74, 0x61, 0x6e, 0x64,
+	0x62, 0x79, 0x12, 0x3d, 0x0a, 0x09, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x63, 0x6b, 0x22,
+	0x00, 0x42, 0x31, 0x5a, 0x2f, 0x77, 0x77, 0x77, 0x2e, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x64,
+	0x65, 0x78, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f, 0x76, 0x65,
+	0x6c, 0x6f, 0x63, 0x69, 0x72, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_standby_proto_rawDescOnce sync.Once
+	file_standby_proto_rawDescData = file_standby_proto_rawDesc
+)
+
+func file_standby_proto_rawDescGZIP() []byte {
+	file_standby_proto_rawDescOnce.Do(func() {
+		file_standby_proto_rawDescData = protoimpl.X.CompressGZIP(file_standby_proto_rawDescData)
+	})
+	return file_standby_proto_rawDescData
+}
+
+var file_standby_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_standby_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_standby_proto_goTypes = []interface{}{
+	(ReplicationOp_Type)(0),  // 0: proto.ReplicationOp.Type
+	(*ReplicationOp)(nil),    // 1: proto.ReplicationOp
+	(*ReplicationBatch)(nil), // 2: proto.ReplicationBatch
+	(*ReplicationAck)(nil),   // 3: proto.ReplicationAck
+	(*DSPathSpec)(nil),       // 4: proto.DSPathSpec
+}
+var file_standby_proto_depIdxs = []int32{
+	0, // 0: proto.ReplicationOp.type:type_name -> proto.ReplicationOp.Type
+	4, // 1: proto.ReplicationOp.pathspec:type_name -> proto.DSPathSpec
+	4, // 2: proto.ReplicationOp.destination:type_name -> proto.DSPathSpec
+	1, // 3: proto.ReplicationBatch.ops:type_name -> proto.ReplicationOp
+	2, // 4: proto.Standby.Replicate:input_type -> proto.ReplicationBatch
+	3, // 5: proto.Standby.Replicate:output_type -> proto.ReplicationAck
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_standby_proto_init() }
+func file_standby_proto_init() {
+	if File_standby_proto != nil {
+		return
+	}
+	file_datastore_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_standby_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicationOp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_standby_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicationBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_standby_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicationAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_standby_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_standby_proto_goTypes,
+		DependencyIndexes: file_standby_proto_depIdxs,
+		EnumInfos:         file_standby_proto_enumTypes,
+		MessageInfos:      file_standby_proto_msgTypes,
+	}.Build()
+	File_standby_proto = out.File
+	file_standby_proto_rawDesc = nil
+	file_standby_proto_goTypes = nil
+	file_standby_proto_depIdxs = nil
+}
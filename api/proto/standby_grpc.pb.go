@@ -0,0 +1,102 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// source: standby.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// StandbyClient is the client API for Standby service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StandbyClient interface {
+	Replicate(ctx context.Context, in *ReplicationBatch, opts ...grpc.CallOption) (*ReplicationAck, error)
+}
+
+type standbyClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStandbyClient(cc grpc.ClientConnInterface) StandbyClient {
+	return &standbyClient{cc}
+}
+
+func (c *standbyClient) Replicate(ctx context.Context, in *ReplicationBatch, opts ...grpc.CallOption) (*ReplicationAck, error) {
+	out := new(ReplicationAck)
+	err := c.cc.Invoke(ctx, "/proto.Standby/Replicate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StandbyServer is the server API for Standby service.
+// All implementations must embed UnimplementedStandbyServer
+// for forward compatibility
+type StandbyServer interface {
+	Replicate(context.Context, *ReplicationBatch) (*ReplicationAck, error)
+	mustEmbedUnimplementedStandbyServer()
+}
+
+// UnimplementedStandbyServer must be embedded to have forward compatible implementations.
+type UnimplementedStandbyServer struct {
+}
+
+func (UnimplementedStandbyServer) Replicate(context.Context, *ReplicationBatch) (*ReplicationAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replicate not implemented")
+}
+func (UnimplementedStandbyServer) mustEmbedUnimplementedStandbyServer() {}
+
+// UnsafeStandbyServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StandbyServer will
+// result in compilation errors.
+type UnsafeStandbyServer interface {
+	mustEmbedUnimplementedStandbyServer()
+}
+
+func RegisterStandbyServer(s grpc.ServiceRegistrar, srv StandbyServer) {
+	s.RegisterService(&Standby_ServiceDesc, srv)
+}
+
+func _Standby_Replicate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicationBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StandbyServer).Replicate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Standby/Replicate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StandbyServer).Replicate(ctx, req.(*ReplicationBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Standby_ServiceDesc is the grpc.ServiceDesc for Standby service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Standby_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.Standby",
+	HandlerType: (*StandbyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Replicate",
+			Handler:    _Standby_Replicate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "standby.proto",
+}
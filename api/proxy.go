@@ -34,6 +34,7 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 	"www.velocidex.com/golang/velociraptor/api/authenticators"
 	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/api/ratelimit"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/constants"
 	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
@@ -135,14 +136,36 @@ func PrepareGUIMux(
 
 	base := config_obj.GUI.BasePath
 
-	mux.Handle(base+"/api/", csrfProtect(config_obj,
-		auther.AuthenticateUserHandler(h)))
+	// Each user and API key is rate limited after it is
+	// authenticated.
+	limiter := ratelimit.NewLimiter(config_obj)
+
+	// Scripts and integrations may use an API key instead of the GUI
+	// authentication on the API and download endpoints.
+	with_api_keys := func(handler http.Handler) http.Handler {
+		handler = limiter.Handler(handler)
+		return authenticators.ApiKeyHandler(config_obj, handler,
+			csrfProtect(config_obj, auther.AuthenticateUserHandler(handler)))
+	}
+
+	mux.Handle(base+"/api/", with_api_keys(h))
 
-	mux.Handle(base+"/api/v1/DownloadTable", csrfProtect(config_obj,
-		auther.AuthenticateUserHandler(downloadTable())))
+	graphql_handler, err := graphqlHandler(ctx, config_obj)
+	if err != nil {
+		return nil, err
+	}
 
-	mux.Handle(base+"/api/v1/DownloadVFSFile", csrfProtect(config_obj,
-		auther.AuthenticateUserHandler(vfsFileDownloadHandler())))
+	mux.Handle(base+"/api/v1/graphql", csrfProtect(config_obj,
+		auther.AuthenticateUserHandler(limiter.Handler(graphql_handler))))
+
+	mux.Handle(base+"/api/v1/DownloadTable", with_api_keys(
+		ratelimit.ExportHandler(downloadTable())))
+
+	mux.Handle(base+"/api/v1/ExportTable", with_api_keys(
+		ratelimit.ExportHandler(exportTable())))
+
+	mux.Handle(base+"/api/v1/DownloadVFSFile", with_api_keys(
+		ratelimit.ExportHandler(vfsFileDownloadHandler())))
 
 	mux.Handle(base+"/api/v1/UploadTool", csrfProtect(config_obj,
 		auther.AuthenticateUserHandler(toolUploadHandler())))
@@ -151,10 +174,9 @@ func PrepareGUIMux(
 		auther.AuthenticateUserHandler(formUploadHandler())))
 
 	// Serve prepared zip files.
-	mux.Handle(base+"/downloads/", csrfProtect(config_obj,
-		auther.AuthenticateUserHandler(
-			http.StripPrefix(base,
-				downloadFileStore([]string{"downloads"})))))
+	mux.Handle(base+"/downloads/", with_api_keys(
+		ratelimit.ExportHandler(http.StripPrefix(base,
+			downloadFileStore([]string{"downloads"})))))
 
 	// Serve notebook items
 	mux.Handle(base+"/notebooks/", csrfProtect(config_obj,
@@ -174,6 +196,10 @@ func PrepareGUIMux(
 			http.StripPrefix(base,
 				downloadFileStore([]string{"clients"})))))
 
+	// The webhook authenticates with its own secret.
+	mux.Handle(base+"/api/v1/ArtifactRepoWebhook",
+		artifactRepoWebhookHandler(config_obj))
+
 	// Assets etc do not need auth.
 	install_static_assets(config_obj, mux)
 
@@ -236,8 +262,32 @@ func GetAPIHandler(
 			}),
 	)
 
-	// We use a dedicated gw certificate. The gRPC server will
-	// only accept a relayed username from us.
+	opts, err := getGatewayDialOptions(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	bind_addr := grpc_client.GetAPIConnectionString(config_obj)
+	err = api_proto.RegisterAPIHandlerFromEndpoint(
+		ctx, grpc_proxy_mux, bind_addr, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	base := config_obj.GUI.BasePath
+
+	reverse_proxy_mux := http.NewServeMux()
+	reverse_proxy_mux.Handle(base+"/api/v1/",
+		http.StripPrefix(base, grpc_proxy_mux))
+
+	return reverse_proxy_mux, nil
+}
+
+// Options for connecting to the gRPC server as the gateway. We use a
+// dedicated gw certificate. The gRPC server will only accept a
+// relayed username from us.
+func getGatewayDialOptions(
+	config_obj *config_proto.Config) ([]grpc.DialOption, error) {
 	cert, err := tls.X509KeyPair(
 		[]byte(config_obj.GUI.GwCertificate),
 		[]byte(config_obj.GUI.GwPrivateKey))
@@ -272,22 +322,7 @@ func GetAPIHandler(
 		ServerName:   config_obj.Client.PinnedServerName,
 	})
 
-	opts := []grpc.DialOption{
+	return []grpc.DialOption{
 		grpc.WithTransportCredentials(creds),
-	}
-
-	bind_addr := grpc_client.GetAPIConnectionString(config_obj)
-	err = api_proto.RegisterAPIHandlerFromEndpoint(
-		ctx, grpc_proxy_mux, bind_addr, opts)
-	if err != nil {
-		return nil, err
-	}
-
-	base := config_obj.GUI.BasePath
-
-	reverse_proxy_mux := http.NewServeMux()
-	reverse_proxy_mux.Handle(base+"/api/v1/",
-		http.StripPrefix(base, grpc_proxy_mux))
-
-	return reverse_proxy_mux, nil
+	}, nil
 }
@@ -0,0 +1,338 @@
+/*
+  Rate limits for the API server.
+
+  Each principal (GUI user or API key) gets its own limits so a
+  runaway integration can not degrade the GUI for everyone
+  else. Requests exceeding the limits are rejected with 429 Too Many
+  Requests.
+
+  The limiter tracks:
+
+  1. The rate of API requests.
+  2. The number of concurrent downloads and exports.
+  3. The number of rows streamed by table downloads and exports per
+     minute. Streams are throttled to this rate.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/services/api_keys"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	// Principals not seen for this long are forgotten.
+	EXPIRY = 10 * time.Minute
+)
+
+var (
+	rateLimitedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gui_api_rate_limited",
+			Help: "Number of API requests rejected by rate limits.",
+		},
+		[]string{"limit"},
+	)
+
+	activeExportsGauge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "gui_api_active_exports",
+			Help: "Number of downloads and exports currently running.",
+		})
+
+	TooManyRequestsError = errors.New("Too many requests")
+)
+
+type contextKey int
+
+const limitsKey contextKey = 0
+
+// The limits applied to a single principal.
+type Limits struct {
+	mu sync.Mutex
+
+	config   *config_proto.ApiRateLimit
+	requests *rate.Limiter
+	rows     *rate.Limiter
+	exports  uint64
+
+	last_seen time.Time
+}
+
+func newLimits(config *config_proto.ApiRateLimit) *Limits {
+	result := &Limits{}
+	result.update(config)
+
+	return result
+}
+
+// Apply new limits. Principals start with a full budget.
+func (self *Limits) update(config *config_proto.ApiRateLimit) {
+	requests := rate.NewLimiter(rate.Inf, 0)
+	rows := rate.NewLimiter(rate.Inf, 0)
+
+	if config.RequestsPerSecond > 0 {
+		burst := int(config.Burst)
+		if burst == 0 {
+			burst = int(math.Ceil(config.RequestsPerSecond))
+		}
+		requests = rate.NewLimiter(
+			rate.Limit(config.RequestsPerSecond), burst)
+	}
+
+	if config.RowsPerMinute > 0 {
+		rows = rate.NewLimiter(
+			rate.Limit(float64(config.RowsPerMinute)/60),
+			int(config.RowsPerMinute))
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.config = config
+	self.requests = requests
+	self.rows = rows
+}
+
+func (self *Limits) getLimiters() (requests *rate.Limiter, rows *rate.Limiter) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.requests, self.rows
+}
+
+// Allow a new request.
+func (self *Limits) AllowRequest() bool {
+	if self == nil {
+		return true
+	}
+	requests, _ := self.getLimiters()
+	return requests.AllowN(utils.GetTime().Now(), 1)
+}
+
+// Start an export. The returned function must be called when the
+// export is done.
+func (self *Limits) StartExport() (func(), error) {
+	if self == nil {
+		return func() {}, nil
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.config.ConcurrentExports > 0 &&
+		self.exports >= self.config.ConcurrentExports {
+		return nil, TooManyRequestsError
+	}
+
+	self.exports++
+	activeExportsGauge.Inc()
+
+	return func() {
+		self.mu.Lock()
+		defer self.mu.Unlock()
+
+		self.exports--
+		activeExportsGauge.Dec()
+	}, nil
+}
+
+// Can we start streaming rows? Streams are rejected if the rows
+// budget is already exhausted. This takes the first row from the
+// budget.
+func (self *Limits) AllowRows() bool {
+	if self == nil {
+		return true
+	}
+	_, rows := self.getLimiters()
+	return rows.Allow()
+}
+
+// Block until the row may be streamed.
+func (self *Limits) WaitRow(ctx context.Context) error {
+	if self == nil {
+		return nil
+	}
+	_, rows := self.getLimiters()
+	return rows.Wait(ctx)
+}
+
+// Get the limits of the principal from the context.
+func FromContext(ctx context.Context) *Limits {
+	limits, _ := ctx.Value(limitsKey).(*Limits)
+	return limits
+}
+
+type Limiter struct {
+	mu         sync.Mutex
+	config     *config_proto.ApiRateLimits
+	principals map[string]*Limits
+}
+
+func NewLimiter(config_obj *config_proto.Config) *Limiter {
+	result := &Limiter{
+		config:     getRateLimits(config_obj),
+		principals: make(map[string]*Limits),
+	}
+
+	// Apply new limits to the principals when the config is
+	// reloaded.
+	config.RegisterReloadHandler("api_rate_limits",
+		func(ctx context.Context, change *config.ConfigChange) error {
+			if change.Changed(config.RELOAD_RATE_LIMITS) {
+				result.Update(config_obj)
+			}
+			return nil
+		})
+
+	return result
+}
+
+func getRateLimits(config_obj *config_proto.Config) *config_proto.ApiRateLimits {
+	if config_obj.GUI != nil && config_obj.GUI.RateLimits != nil {
+		return config_obj.GUI.RateLimits
+	}
+	return &config_proto.ApiRateLimits{}
+}
+
+// Apply the rate limits from the config to all principals.
+func (self *Limiter) Update(config_obj *config_proto.Config) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.config = getRateLimits(config_obj)
+	for principal, limits := range self.principals {
+		limits.update(self.getConfig(principal))
+	}
+}
+
+// Get the configured limits for the principal.
+func (self *Limiter) getConfig(principal string) *config_proto.ApiRateLimit {
+	config, pres := self.config.Principals[principal]
+	if pres && config != nil {
+		return config
+	}
+
+	if api_keys.IsApiKey(principal) {
+		config = self.config.ApiKeys
+	} else {
+		config = self.config.Users
+	}
+
+	if config == nil {
+		return &config_proto.ApiRateLimit{}
+	}
+	return config
+}
+
+func (self *Limiter) GetLimits(principal string) *Limits {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	now := utils.GetTime().Now()
+	limits, pres := self.principals[principal]
+	if !pres {
+		self.expire(now)
+
+		limits = newLimits(self.getConfig(principal))
+		self.principals[principal] = limits
+	}
+
+	limits.last_seen = now
+	return limits
+}
+
+// Forget idle principals. Principals with running exports are kept
+// so the concurrency limit is still enforced.
+func (self *Limiter) expire(now time.Time) {
+	for k, v := range self.principals {
+		v.mu.Lock()
+		idle := v.exports == 0 && now.Sub(v.last_seen) > EXPIRY
+		v.mu.Unlock()
+
+		if idle {
+			delete(self.principals, k)
+		}
+	}
+}
+
+func getPrincipal(r *http.Request) string {
+	serialized, ok := r.Context().Value(constants.GRPC_USER_CONTEXT).(string)
+	if !ok {
+		return ""
+	}
+
+	user_info := &api_proto.VelociraptorUser{}
+	err := json.Unmarshal([]byte(serialized), user_info)
+	if err != nil {
+		return ""
+	}
+	return user_info.Name
+}
+
+// Reject the request with 429 Too Many Requests.
+func TooManyRequests(w http.ResponseWriter, limit string, retry time.Duration) {
+	rateLimitedCounter.WithLabelValues(limit).Inc()
+
+	seconds := int(math.Ceil(retry.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	http.Error(w, "Too many requests", http.StatusTooManyRequests)
+}
+
+// Reject requests above the principal's request rate and make the
+// principal's limits available to the handlers. This must be
+// installed after the authenticator.
+func (self *Limiter) Handler(parent http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := getPrincipal(r)
+		if principal == "" {
+			parent.ServeHTTP(w, r)
+			return
+		}
+
+		limits := self.GetLimits(principal)
+		if !limits.AllowRequest() {
+			TooManyRequests(w, "requests", time.Second)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), limitsKey, limits)
+		parent.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Limit the number of concurrent downloads and exports of the
+// principal. Must be installed after Handler().
+func ExportHandler(parent http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits := FromContext(r.Context())
+		closer, err := limits.StartExport()
+		if err != nil {
+			TooManyRequests(w, "exports", 10*time.Second)
+			return
+		}
+		defer closer()
+
+		parent.ServeHTTP(w, r)
+	})
+}
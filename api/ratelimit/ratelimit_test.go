@@ -0,0 +1,158 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+func makeRequest(principal string) *http.Request {
+	r := httptest.NewRequest("GET", "/api/v1/GetTable", nil)
+	ctx := context.WithValue(r.Context(), constants.GRPC_USER_CONTEXT,
+		`{"name":"`+principal+`"}`)
+	return r.WithContext(ctx)
+}
+
+func serve(handler http.Handler, principal string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, makeRequest(principal))
+	return w
+}
+
+func TestRequestRate(t *testing.T) {
+	clock := &utils.MockClock{MockNow: time.Unix(1600000000, 0)}
+	defer utils.MockTime(clock)()
+
+	limiter := NewLimiter(&config_proto.Config{
+		GUI: &config_proto.GUIConfig{
+			RateLimits: &config_proto.ApiRateLimits{
+				ApiKeys: &config_proto.ApiRateLimit{
+					RequestsPerSecond: 1,
+					Burst:             2,
+				},
+				Principals: map[string]*config_proto.ApiRateLimit{
+					"AK.UNLIMITED": {},
+				},
+			},
+		},
+	})
+
+	handler := limiter.Handler(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {}))
+
+	// The burst is allowed then requests are rejected.
+	assert.Equal(t, 200, serve(handler, "AK.XXX").Code)
+	assert.Equal(t, 200, serve(handler, "AK.XXX").Code)
+
+	w := serve(handler, "AK.XXX")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	// Other principals are not affected.
+	assert.Equal(t, 200, serve(handler, "AK.YYY").Code)
+
+	// Users and overridden keys have no limits.
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, 200, serve(handler, "admin").Code)
+		assert.Equal(t, 200, serve(handler, "AK.UNLIMITED").Code)
+	}
+
+	// The rate recovers with time.
+	clock.MockNow = clock.MockNow.Add(time.Second)
+	assert.Equal(t, 200, serve(handler, "AK.XXX").Code)
+}
+
+func TestConcurrentExports(t *testing.T) {
+	limiter := NewLimiter(&config_proto.Config{
+		GUI: &config_proto.GUIConfig{
+			RateLimits: &config_proto.ApiRateLimits{
+				Users: &config_proto.ApiRateLimit{
+					ConcurrentExports: 1,
+				},
+			},
+		},
+	})
+
+	started := make(chan bool)
+	release := make(chan bool)
+	handler := limiter.Handler(ExportHandler(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			started <- true
+			<-release
+		})))
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.Equal(t, 200, serve(handler, "admin").Code)
+	}()
+	<-started
+
+	// A second export is rejected while the first is running.
+	assert.Equal(t, http.StatusTooManyRequests, serve(handler, "admin").Code)
+
+	close(release)
+	wg.Wait()
+
+	// Once the first export is done we can start another.
+	go func() { <-started }()
+	assert.Equal(t, 200, serve(handler, "admin").Code)
+}
+
+func TestRows(t *testing.T) {
+	limits := newLimits(&config_proto.ApiRateLimit{RowsPerMinute: 2})
+
+	assert.True(t, limits.AllowRows())
+	assert.True(t, limits.AllowRows())
+	assert.False(t, limits.AllowRows())
+
+	// Waiting for a row respects the context.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	assert.Error(t, limits.WaitRow(ctx))
+
+	// Without limits everything is allowed.
+	var no_limits *Limits
+	assert.True(t, no_limits.AllowRows())
+	assert.NoError(t, no_limits.WaitRow(ctx))
+}
+
+func TestUpdate(t *testing.T) {
+	clock := &utils.MockClock{MockNow: time.Unix(1600000000, 0)}
+	defer utils.MockTime(clock)()
+
+	config_obj := &config_proto.Config{
+		GUI: &config_proto.GUIConfig{
+			RateLimits: &config_proto.ApiRateLimits{
+				Users: &config_proto.ApiRateLimit{
+					RequestsPerSecond: 1,
+					Burst:             1,
+				},
+			},
+		},
+	}
+	limiter := NewLimiter(config_obj)
+
+	handler := limiter.Handler(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {}))
+
+	assert.Equal(t, 200, serve(handler, "admin").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve(handler, "admin").Code)
+
+	// Removing the limit applies to existing principals.
+	config_obj.GUI.RateLimits = nil
+	limiter.Update(config_obj)
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, 200, serve(handler, "admin").Code)
+	}
+}
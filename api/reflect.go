@@ -18,23 +18,35 @@
 package api
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/Velocidex/yaml/v2"
 	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	"www.velocidex.com/golang/velociraptor/artifacts/assets"
-	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/lsp"
 	"www.velocidex.com/golang/vfilter"
 	"www.velocidex.com/golang/vfilter/types"
 )
 
 var (
 	doc_regex = regexp.MustCompile("doc=(.+)")
+
+	server_description_once sync.Once
+	server_description      []*api_proto.Completion
 )
 
 // Loads the api description from the embedded asset
@@ -81,6 +93,91 @@ func IntrospectDescription() []*api_proto.Completion {
 	return result
 }
 
+// Describe the plugins and functions compiled into this binary. The
+// descriptions are taken from the reference docs where available
+// but only items which actually exist are returned.
+func ServerDescription() []*api_proto.Completion {
+	server_description_once.Do(func() {
+		docs := make(map[string]*api_proto.Completion)
+		descriptions, err := LoadApiDescription()
+		if err == nil {
+			for _, item := range descriptions {
+				docs[item.Type+":"+item.Name] = item
+			}
+		}
+
+		for _, item := range IntrospectDescription() {
+			doc, pres := docs[item.Type+":"+item.Name]
+			if pres {
+				mergeDescription(item, doc)
+			}
+			server_description = append(server_description, item)
+		}
+
+		sort.Slice(server_description, func(i, j int) bool {
+			return server_description[i].Name < server_description[j].Name
+		})
+	})
+
+	return server_description
+}
+
+func mergeDescription(item, doc *api_proto.Completion) {
+	if doc.Description != "" {
+		item.Description = doc.Description
+	}
+	item.Category = doc.Category
+	item.Version = doc.Version
+
+	arg_docs := make(map[string]*api_proto.ArgDescriptor)
+	for _, arg := range doc.Args {
+		arg_docs[arg.Name] = arg
+	}
+
+	for _, arg := range item.Args {
+		arg_doc, pres := arg_docs[arg.Name]
+		if pres && arg_doc.Description != "" {
+			arg.Description = arg_doc.Description
+		}
+	}
+}
+
+func keywordCompletions() []*api_proto.Completion {
+	return []*api_proto.Completion{
+		{Name: "SELECT", Type: "Keyword"},
+		{Name: "FROM", Type: "Keyword"},
+		{Name: "LET", Type: "Keyword"},
+		{Name: "WHERE", Type: "Keyword"},
+		{Name: "LIMIT", Type: "Keyword"},
+		{Name: "GROUP BY", Type: "Keyword"},
+		{Name: "ORDER BY", Type: "Keyword"},
+	}
+}
+
+// All the completions for the org: keywords, plugins, functions and
+// the artifacts in the org's repository.
+func getCompletions(ctx context.Context,
+	org_config_obj *config_proto.Config) ([]*api_proto.Completion, error) {
+	result := keywordCompletions()
+	result = append(result, ServerDescription()...)
+
+	manager, err := services.GetRepositoryManager(org_config_obj)
+	if err != nil {
+		return nil, err
+	}
+	repository, err := manager.GetGlobalRepository(org_config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := lsp.ArtifactCompletions(ctx, org_config_obj, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(result, artifacts...), nil
+}
+
 func (self *ApiServer) GetKeywordCompletions(
 	ctx context.Context,
 	in *emptypb.Empty) (*api_proto.KeywordCompletions, error) {
@@ -91,50 +188,93 @@ func (self *ApiServer) GetKeywordCompletions(
 		return nil, Status(self.verbose, err)
 	}
 
-	result := &api_proto.KeywordCompletions{
-		Items: []*api_proto.Completion{
-			{Name: "SELECT", Type: "Keyword"},
-			{Name: "FROM", Type: "Keyword"},
-			{Name: "LET", Type: "Keyword"},
-			{Name: "WHERE", Type: "Keyword"},
-			{Name: "LIMIT", Type: "Keyword"},
-			{Name: "GROUP BY", Type: "Keyword"},
-			{Name: "ORDER BY", Type: "Keyword"},
-		},
+	items, err := getCompletions(ctx, org_config_obj)
+	if err != nil {
+		return nil, Status(self.verbose, err)
 	}
 
-	descriptions, err := LoadApiDescription()
+	return &api_proto.KeywordCompletions{Items: items}, nil
+}
+
+func (self *ApiServer) GetCompletions(
+	ctx context.Context,
+	in *api_proto.CompletionsRequest) (*api_proto.KeywordCompletions, error) {
+
+	defer Instrument("GetCompletions")()
+
+	users := services.GetUserManager()
+	_, org_config_obj, err := users.GetUserFromContext(ctx)
 	if err != nil {
-		descriptions = IntrospectDescription()
+		return nil, Status(self.verbose, err)
 	}
-	result.Items = append(result.Items, descriptions...)
 
-	manager, err := services.GetRepositoryManager(org_config_obj)
+	items, err := getCompletions(ctx, org_config_obj)
 	if err != nil {
 		return nil, Status(self.verbose, err)
 	}
-	repository, err := manager.GetGlobalRepository(org_config_obj)
+
+	result := &api_proto.KeywordCompletions{}
+	for _, item := range items {
+		if in.Prefix != "" && !strings.HasPrefix(
+			strings.ToLower(item.Name), strings.ToLower(in.Prefix)) {
+			continue
+		}
+
+		if len(in.Types) > 0 && !utils.InString(in.Types, item.Type) {
+			continue
+		}
+
+		result.Items = append(result.Items, item)
+	}
+
+	// The etag covers the filtered items so it changes when
+	// anything the client asked for changes.
+	serialized, err := proto.MarshalOptions{Deterministic: true}.Marshal(result)
 	if err != nil {
 		return nil, Status(self.verbose, err)
 	}
-	names, err := repository.List(ctx, org_config_obj)
+	hash := sha256.Sum256(serialized)
+	result.Etag = hex.EncodeToString(hash[:])
+
+	if in.Etag == result.Etag {
+		result.Items = nil
+		result.NotModified = true
+	}
+
+	return result, nil
+}
+
+func (self *ApiServer) GetSignature(
+	ctx context.Context,
+	in *api_proto.SignatureRequest) (*api_proto.Completion, error) {
+
+	defer Instrument("GetSignature")()
+
+	users := services.GetUserManager()
+	_, org_config_obj, err := users.GetUserFromContext(ctx)
 	if err != nil {
 		return nil, Status(self.verbose, err)
 	}
 
-	for _, name := range names {
-		artifact, pres := repository.Get(org_config_obj, name)
-		if !pres {
-			continue
+	if in.Name == "" {
+		return nil, InvalidStatus("Name must be specified")
+	}
+
+	items, err := getCompletions(ctx, org_config_obj)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	// Artifact references may name a source
+	// (Artifact.Windows.Sys.Users/Source).
+	name := strings.SplitN(in.Name, "/", 2)[0]
+	for _, item := range items {
+		if item.Name == name {
+			return item, nil
 		}
-		result.Items = append(result.Items, &api_proto.Completion{
-			Name: "Artifact." + name,
-			Type: "Artifact",
-			Args: getArtifactParamDescriptors(artifact),
-		})
 	}
 
-	return result, nil
+	return nil, status.Error(codes.NotFound, "Unknown name "+name)
 }
 
 func getArgDescriptors(
@@ -169,22 +309,10 @@ func getArgDescriptors(
 				Name:        k,
 				Description: doc + required,
 				Type:        target,
+				Repeated:    v.Repeated,
+				Required:    required != "",
 			})
 		}
 	}
 	return args
 }
-
-func getArtifactParamDescriptors(artifact *artifacts_proto.Artifact) []*api_proto.ArgDescriptor {
-	args := []*api_proto.ArgDescriptor{}
-
-	for _, parameter := range artifact.Parameters {
-		args = append(args, &api_proto.ArgDescriptor{
-			Name:        parameter.Name,
-			Description: parameter.Description,
-			Type:        "Artifact Parameter",
-		})
-	}
-
-	return args
-}
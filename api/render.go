@@ -0,0 +1,147 @@
+package api
+
+import (
+	"bytes"
+
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"www.velocidex.com/golang/velociraptor/acls"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/reporting"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/cases"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+)
+
+// Render a notebook, case or report into a standalone html or pdf
+// document so it can be saved or mailed without the GUI.
+func (self *ApiServer) RenderDocument(
+	ctx context.Context,
+	in *api_proto.RenderDocumentRequest) (*api_proto.RenderDocumentResponse, error) {
+
+	defer Instrument("RenderDocument")()
+
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.READ_RESULTS
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return nil, status.Error(codes.PermissionDenied,
+			"User is not allowed to render documents.")
+	}
+
+	result := &api_proto.RenderDocumentResponse{}
+	render := reporting.RenderHTML
+	extension := ".html"
+	switch in.Format {
+	case "", "html":
+		result.ContentType = "text/html"
+	case "pdf":
+		result.ContentType = "application/pdf"
+		render = reporting.RenderPDF
+		extension = ".pdf"
+	default:
+		return nil, InvalidStatus("Unsupported format " + in.Format)
+	}
+
+	notebook_manager, err := services.GetNotebookManager(org_config_obj)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+
+	var doc *reporting.Document
+
+	switch {
+	case in.NotebookId != "":
+		notebook, err := notebook_manager.GetNotebook(ctx, in.NotebookId)
+		if err != nil {
+			return nil, Status(self.verbose, err)
+		}
+
+		if !notebook_manager.CheckNotebookAccess(notebook, principal) {
+			return nil, InvalidStatus("Notebook is not shared with user.")
+		}
+
+		doc, err = reporting.NotebookDocument(org_config_obj, in.NotebookId)
+		if err != nil {
+			return nil, Status(self.verbose, err)
+		}
+		result.Filename = in.NotebookId + extension
+
+	case in.CaseId != "":
+		case_obj, err := cases.GetCase(org_config_obj, in.CaseId)
+		if err != nil {
+			return nil, Status(self.verbose, err)
+		}
+
+		// Only include the notebooks the user may read.
+		notebook_ids := []string{}
+		for _, notebook_id := range case_obj.Notebooks {
+			notebook, err := notebook_manager.GetNotebook(ctx, notebook_id)
+			if err == nil &&
+				notebook_manager.CheckNotebookAccess(notebook, principal) {
+				notebook_ids = append(notebook_ids, notebook_id)
+			}
+		}
+
+		doc, err = reporting.CaseDocument(org_config_obj, case_obj, notebook_ids)
+		if err != nil {
+			return nil, Status(self.verbose, err)
+		}
+		result.Filename = in.CaseId + extension
+
+	case in.Report != nil:
+		manager, err := services.GetRepositoryManager(org_config_obj)
+		if err != nil {
+			return nil, Status(self.verbose, err)
+		}
+
+		global_repo, err := manager.GetGlobalRepository(org_config_obj)
+		if err != nil {
+			return nil, Status(self.verbose, err)
+		}
+
+		acl_manager := acl_managers.NewServerACLManager(org_config_obj, principal)
+		report, err := getReport(
+			ctx, org_config_obj, acl_manager, global_repo, in.Report)
+		if err != nil {
+			return nil, Status(self.verbose, err)
+		}
+
+		data := make(map[string]*actions_proto.VQLResponse)
+		err = json.Unmarshal([]byte(report.Data), &data)
+		if err != nil {
+			return nil, Status(self.verbose, err)
+		}
+
+		doc = &reporting.Document{
+			Title: in.Report.Artifact,
+			Sections: []*reporting.DocumentSection{{
+				HTML: report.Template,
+				Data: data,
+			}},
+		}
+		result.Filename = in.Report.Artifact + extension
+
+	default:
+		return nil, InvalidStatus(
+			"One of notebook_id, case_id or report must be specified")
+	}
+
+	buf := &bytes.Buffer{}
+	err = render(ctx, org_config_obj, doc, buf)
+	if err != nil {
+		return nil, Status(self.verbose, err)
+	}
+	result.Data = buf.Bytes()
+
+	return result, nil
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"www.velocidex.com/golang/velociraptor/acls"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/launcher"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+)
+
+// The results a principal reads are restricted to the same artifact
+// and label scope they may collect with. Any of the fields may be
+// empty.
+type resultScope struct {
+	ClientId string
+	FlowId   string
+	HuntId   string
+	Artifact string
+}
+
+func checkResultScope(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	principal string, scope resultScope) error {
+	acl_manager := acl_managers.NewServerACLManager(config_obj, principal)
+	scoped, ok := acl_manager.(vql_subsystem.ScopedACLManager)
+	if !ok {
+		return nil
+	}
+
+	if scope.Artifact != "" {
+		artifact, _ := paths.SplitFullSourceName(scope.Artifact)
+		err := launcher.CheckArtifactScope(acl_manager, []string{artifact})
+		if err != nil {
+			return err
+		}
+	}
+
+	if scope.HuntId != "" {
+		hunt_dispatcher, err := services.GetHuntDispatcher(config_obj)
+		if err != nil {
+			return err
+		}
+
+		hunt, pres := hunt_dispatcher.GetHunt(scope.HuntId)
+		if !pres {
+			return fmt.Errorf("Hunt %v not found", scope.HuntId)
+		}
+
+		err = launcher.CheckArtifactScope(
+			acl_manager, hunt.StartRequest.GetArtifacts())
+		if err != nil {
+			return err
+		}
+
+		return launcher.CheckHuntScope(acl_manager, hunt)
+	}
+
+	if scope.ClientId == "" {
+		if scope.Artifact == "" {
+			return nil
+		}
+
+		// Events across all clients are only available to
+		// principals without a label scope.
+		allowed, err := scoped.CheckLabelScope()
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return fmt.Errorf("%w: a client must be specified",
+				acls.PermissionDenied)
+		}
+		return nil
+	}
+
+	err := launcher.CheckClientScope(ctx, config_obj, acl_manager, scope.ClientId)
+	if err != nil {
+		return err
+	}
+
+	if scope.FlowId != "" {
+		launcher_service, err := services.GetLauncher(config_obj)
+		if err != nil {
+			return err
+		}
+
+		flow, err := launcher_service.GetFlowDetails(
+			config_obj, scope.ClientId, scope.FlowId)
+		if err != nil {
+			return err
+		}
+
+		return launcher.CheckArtifactScope(
+			acl_manager, flow.Context.GetRequest().GetArtifacts())
+	}
+
+	return nil
+}
+
+// Returns a filter which hides collections of artifacts outside the
+// principal's artifact scope.
+func artifactScopeFilter(
+	config_obj *config_proto.Config, principal string) func([]string) bool {
+	acl_manager := acl_managers.NewServerACLManager(config_obj, principal)
+	return func(artifacts []string) bool {
+		return launcher.CheckArtifactScope(acl_manager, artifacts) == nil
+	}
+}
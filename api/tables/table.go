@@ -19,10 +19,14 @@ package tables
 
 import (
 	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	errors "github.com/go-errors/errors"
 	context "golang.org/x/net/context"
+	"google.golang.org/protobuf/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
 	"www.velocidex.com/golang/velociraptor/datastore"
 	file_store "www.velocidex.com/golang/velociraptor/file_store"
 	"www.velocidex.com/golang/velociraptor/file_store/api"
@@ -38,6 +42,23 @@ import (
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 )
 
+var (
+	table_cache_mu sync.Mutex
+	table_cache    *result_sets.ResultCache
+)
+
+// Pages of result sets are cached so repeatedly viewing the same
+// table does not scan the JSONL files again.
+func getTableCache(config_obj *config_proto.Config) *result_sets.ResultCache {
+	table_cache_mu.Lock()
+	defer table_cache_mu.Unlock()
+
+	if table_cache == nil {
+		table_cache = result_sets.NewResultCache(config_obj, "GetTable")
+	}
+	return table_cache
+}
+
 func GetTable(
 	ctx context.Context,
 	config_obj *config_proto.Config,
@@ -98,7 +119,7 @@ func getTable(
 	}
 
 	result := &api_proto.GetTableResponse{
-		ColumnTypes: getColumnTypes(config_obj, in),
+		ColumnTypes: GetColumnTypes(config_obj, in),
 	}
 
 	path_spec, err := GetPathSpec(config_obj, in)
@@ -108,10 +129,21 @@ func getTable(
 
 	file_store_factory := file_store.GetFileStore(config_obj)
 
+	// The file store is per org so the org is part of the key.
+	cache_key := result_sets.MakeCacheKey("GetTable",
+		[]interface{}{config_obj.OrgId, in},
+		result_sets.ResultSetVersion(file_store_factory, path_spec))
+	cached, pres := getTableCache(config_obj).Get(cache_key)
+	if pres {
+		cached_result := cached.(*api_proto.GetTableResponse)
+		return proto.Clone(cached_result).(*api_proto.GetTableResponse), nil
+	}
+
 	options, err := getTableOptions(in)
 	if err != nil {
 		return result, err
 	}
+	options.Schema = result_sets.NewSchema(result.ColumnTypes)
 
 	rs_reader, err := result_sets.NewResultSetReaderWithOptions(
 		ctx, config_obj,
@@ -143,6 +175,11 @@ func getTable(
 
 	opts := json.GetJsonOptsForTimezone(in.Timezone)
 
+	// Only the requested columns are read from the result set.
+	if len(in.Columns) > 0 {
+		result.Columns = in.Columns
+	}
+
 	// Unpack the rows into the output protobuf
 	for row := range rs_reader.Rows(ctx) {
 		if result.Columns == nil {
@@ -164,12 +201,17 @@ func getTable(
 		}
 	}
 
+	// Only cache complete pages.
+	if ctx.Err() == nil {
+		getTableCache(config_obj).Set(cache_key, proto.Clone(result))
+	}
+
 	return result, nil
 }
 
 // The GUI is requesting table data. This function tries to figure out
 // the column types.
-func getColumnTypes(
+func GetColumnTypes(
 	config_obj *config_proto.Config,
 	in *api_proto.GetTableRequest) []*artifacts_proto.ColumnType {
 
@@ -245,6 +287,12 @@ func GetPathSpec(
 	} else if in.HuntId != "" && in.Type == "hunt_status" {
 		return paths.NewHuntPathManager(in.HuntId).ClientErrors(), nil
 
+	} else if in.HuntId != "" && in.Type == "hunt_results" {
+		return paths.NewHuntPathManager(in.HuntId).Results(in.Artifact), nil
+
+	} else if in.HuntId != "" && in.Type == "hunt_stack" {
+		return paths.NewHuntPathManager(in.HuntId).Stack(in.Artifact), nil
+
 	} else if in.NotebookId != "" && in.CellId != "" && in.Type == "logs" {
 		return paths.NewNotebookPathManager(in.NotebookId).Cell(
 			in.CellId).Logs(), nil
@@ -272,7 +320,7 @@ func getEventTable(
 	in *api_proto.GetTableRequest) (
 	*api_proto.GetTableResponse, error) {
 	path_manager, err := artifacts.NewArtifactPathManager(
-		config_obj, in.ClientId, in.FlowId, in.Artifact)
+		config_obj, in.ClientId, eventFlowId(in), in.Artifact)
 	if err != nil {
 		return nil, err
 	}
@@ -286,13 +334,22 @@ func getEventTableLogs(
 	in *api_proto.GetTableRequest) (
 	*api_proto.GetTableResponse, error) {
 	path_manager, err := artifacts.NewArtifactLogPathManager(
-		config_obj, in.ClientId, "", in.Artifact)
+		config_obj, in.ClientId, eventFlowId(in), in.Artifact)
 	if err != nil {
 		return nil, err
 	}
 	return getEventTableWithPathManager(ctx, config_obj, in, path_manager)
 }
 
+// Client events are collected by the monitoring flow. This also
+// finds the results of scheduled client artifacts.
+func eventFlowId(in *api_proto.GetTableRequest) string {
+	if in.FlowId == "" && strings.HasPrefix(in.Type, "CLIENT_EVENT") {
+		return constants.MONITORING_WELL_KNOWN_FLOW
+	}
+	return in.FlowId
+}
+
 func getEventTableWithPathManager(
 	ctx context.Context,
 	config_obj *config_proto.Config,
@@ -326,6 +383,11 @@ func getEventTableWithPathManager(
 
 	opts := json.GetJsonOptsForTimezone(in.Timezone)
 
+	// Only the requested columns are read from the result set.
+	if len(in.Columns) > 0 {
+		result.Columns = in.Columns
+	}
+
 	// Unpack the rows into the output protobuf
 	for row := range rs_reader.Rows(ctx) {
 		if result.Columns == nil {
@@ -419,6 +481,7 @@ func getTableOptions(in *api_proto.GetTableRequest) (
 
 	options.StartIdx = in.StartIdx
 	options.EndIdx = in.EndIdx
+	options.Columns = in.Columns
 
 	return options, nil
 }
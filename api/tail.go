@@ -0,0 +1,400 @@
+package api
+
+// Stream new rows of a result set to API clients as they are
+// written so they do not need to poll.
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+)
+
+var (
+	// How often to check a flow result set for new rows.
+	tailPollInterval = time.Second
+
+	// Maximum number of rows sent in one response.
+	tailBatchSize = 1000
+)
+
+type tailSender func(client_id, flow_id string,
+	jsonl []byte, row_count int) error
+
+func (self *ApiServer) TailResultSet(
+	in *api_proto.TailResultSetRequest,
+	stream api_proto.API_TailResultSetServer) error {
+
+	defer Instrument("TailResultSet")()
+
+	ctx := stream.Context()
+	users := services.GetUserManager()
+	user_record, org_config_obj, err := users.GetUserFromContext(ctx)
+	if err != nil {
+		return Status(self.verbose, err)
+	}
+	principal := user_record.Name
+
+	permissions := acls.READ_RESULTS
+	perm, err := services.CheckAccess(org_config_obj, principal, permissions)
+	if !perm || err != nil {
+		return status.Error(codes.PermissionDenied,
+			"User is not allowed to view results.")
+	}
+
+	if in.Artifact == "" {
+		return status.Error(codes.InvalidArgument, "Artifact must be specified")
+	}
+
+	err = checkResultScope(ctx, org_config_obj, principal, resultScope{
+		ClientId: in.ClientId,
+		FlowId:   in.FlowId,
+		HuntId:   in.HuntId,
+		Artifact: in.Artifact,
+	})
+	if err != nil {
+		return Status(self.verbose, err)
+	}
+
+	// Wait here for orderly shutdown of streams.
+	self.wg.Add(1)
+	defer self.wg.Done()
+
+	send := func(client_id, flow_id string, jsonl []byte, row_count int) error {
+		return stream.Send(&api_proto.TailResultSetResponse{
+			ClientId: client_id,
+			FlowId:   flow_id,
+			Jsonl:    jsonl,
+			RowCount: uint64(row_count),
+		})
+	}
+
+	switch {
+	case in.HuntId != "":
+		err = tailHunt(ctx, org_config_obj, principal, in, send)
+
+	case in.FlowId != "":
+		if in.ClientId == "" {
+			return status.Error(codes.InvalidArgument,
+				"ClientId must be specified for flows")
+		}
+
+		start_row := int64(0)
+		if in.NewRowsOnly {
+			start_row = -1
+		}
+		err = tailFlow(ctx, org_config_obj, in.ClientId, in.FlowId,
+			in.Artifact, start_row, send)
+
+	default:
+		err = tailEvents(ctx, org_config_obj, principal, in, send)
+	}
+
+	return Status(self.verbose, err)
+}
+
+// Send the rows of a flow result set from start_row (-1 means the
+// current end) and keep sending new rows until the flow is done.
+func tailFlow(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id, flow_id, artifact string,
+	start_row int64, send tailSender) error {
+
+	launcher, err := services.GetLauncher(config_obj)
+	if err != nil {
+		return err
+	}
+
+	if start_row < 0 {
+		start_row, err = countRows(ctx, config_obj, client_id, flow_id, artifact)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		// Check the state before reading the rows so rows written
+		// just before completion are not missed.
+		details, err := launcher.GetFlowDetails(config_obj, client_id, flow_id)
+		if err != nil {
+			return err
+		}
+
+		running := details.Context != nil &&
+			details.Context.State == flows_proto.ArtifactCollectorContext_RUNNING
+
+		sent, err := sendRows(ctx, config_obj, client_id, flow_id, artifact,
+			start_row, send)
+		if err != nil {
+			return err
+		}
+		start_row += sent
+
+		if !running {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(tailPollInterval):
+		}
+	}
+}
+
+func openFlowResultSet(
+	config_obj *config_proto.Config,
+	client_id, flow_id, artifact string) (result_sets.ResultSetReader, error) {
+	path_manager, err := artifacts.NewArtifactPathManager(
+		config_obj, client_id, flow_id, artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	return result_sets.NewResultSetReader(
+		file_store_factory, path_manager.Path())
+}
+
+func countRows(ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id, flow_id, artifact string) (int64, error) {
+	rs_reader, err := openFlowResultSet(config_obj, client_id, flow_id, artifact)
+	if err != nil {
+		return 0, err
+	}
+	defer rs_reader.Close()
+
+	if rs_reader.TotalRows() >= 0 {
+		return rs_reader.TotalRows(), nil
+	}
+
+	// No index so we need to count the rows.
+	json_chan, err := rs_reader.JSON(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	total := int64(0)
+	for range json_chan {
+		total++
+	}
+	return total, nil
+}
+
+// Send the rows after start_row in batches and return how many were
+// sent.
+func sendRows(ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id, flow_id, artifact string,
+	start_row int64, send tailSender) (int64, error) {
+	rs_reader, err := openFlowResultSet(config_obj, client_id, flow_id, artifact)
+	if err != nil {
+		return 0, err
+	}
+	defer rs_reader.Close()
+
+	// Nothing new was written yet.
+	total := rs_reader.TotalRows()
+	if total >= 0 && total <= start_row {
+		return 0, nil
+	}
+
+	err = rs_reader.SeekToRow(start_row)
+	if err != nil {
+		return 0, nil
+	}
+
+	json_chan, err := rs_reader.JSON(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := int64(0)
+	batch := &bytes.Buffer{}
+	row_count := 0
+
+	// Only complete lines are returned so a row still being written
+	// is picked up on the next poll.
+	for row := range json_chan {
+		batch.Write(row)
+		row_count++
+
+		if row_count >= tailBatchSize {
+			err := send(client_id, flow_id, batch.Bytes(), row_count)
+			if err != nil {
+				return sent, err
+			}
+			sent += int64(row_count)
+			batch = &bytes.Buffer{}
+			row_count = 0
+		}
+	}
+
+	if row_count > 0 {
+		err := send(client_id, flow_id, batch.Bytes(), row_count)
+		if err != nil {
+			return sent, err
+		}
+		sent += int64(row_count)
+	}
+
+	return sent, nil
+}
+
+// Hunt results are written by many flows. Send the results of the
+// hunt's flows as each flow completes.
+func tailHunt(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	principal string,
+	in *api_proto.TailResultSetRequest,
+	send tailSender) error {
+
+	hunt_dispatcher, err := services.GetHuntDispatcher(config_obj)
+	if err != nil {
+		return err
+	}
+
+	_, pres := hunt_dispatcher.GetHunt(in.HuntId)
+	if !pres {
+		return status.Error(codes.InvalidArgument, "No hunt known")
+	}
+
+	j, err := services.GetJournal(config_obj)
+	if err != nil {
+		return err
+	}
+
+	// Start watching before sending the existing results so no
+	// completions are missed.
+	events, cancel := j.Watch(ctx, "System.Flow.Completion",
+		"TailResultSet-"+principal)
+	defer cancel()
+
+	// Flows we already sent.
+	seen := make(map[string]bool)
+
+	if !in.NewRowsOnly {
+		scope := vql_subsystem.MakeScope()
+		defer scope.Close()
+
+		for flow := range hunt_dispatcher.GetFlows(
+			ctx, config_obj, scope, in.HuntId, 0) {
+			if flow.Context == nil ||
+				flow.Context.State == flows_proto.ArtifactCollectorContext_RUNNING {
+				continue
+			}
+
+			seen[flow.Context.SessionId] = true
+			_, err := sendRows(ctx, config_obj, flow.Context.ClientId,
+				flow.Context.SessionId, in.Artifact, 0, send)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case row, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			flow, err := journal.GetFlowFromQueue(config_obj, row)
+			if err != nil || flow.Request == nil ||
+				flow.Request.Creator != in.HuntId ||
+				seen[flow.SessionId] {
+				continue
+			}
+
+			seen[flow.SessionId] = true
+			_, err = sendRows(ctx, config_obj, flow.ClientId,
+				flow.SessionId, in.Artifact, 0, send)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Send new events as they arrive.
+func tailEvents(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	principal string,
+	in *api_proto.TailResultSetRequest,
+	send tailSender) error {
+
+	path_manager, err := artifacts.NewArtifactPathManager(
+		config_obj, in.ClientId, "", in.Artifact)
+	if err != nil {
+		return err
+	}
+
+	if !path_manager.IsEvent() {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf(
+			"%v is not an event artifact - specify a FlowId or HuntId",
+			in.Artifact))
+	}
+
+	j, err := services.GetJournal(config_obj)
+	if err != nil {
+		return err
+	}
+
+	events, cancel := j.Watch(ctx, in.Artifact, "TailResultSet-"+principal)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case row, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			client_id := getClientId(row)
+			if in.ClientId != "" && client_id != in.ClientId {
+				continue
+			}
+
+			serialized, err := json.MarshalJsonl([]*ordereddict.Dict{row})
+			if err != nil {
+				continue
+			}
+
+			err = send(client_id, "", serialized, 1)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func getClientId(row *ordereddict.Dict) string {
+	client_id, _ := row.GetString("ClientId")
+	return client_id
+}
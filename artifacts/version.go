@@ -0,0 +1,25 @@
+package artifacts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/protobuf/proto"
+	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+)
+
+// The version of an artifact sent to clients is a hash of its
+// definition. Clients use it to cache and verify the artifacts they
+// receive.
+func ArtifactVersion(artifact *artifacts_proto.Artifact) string {
+	artifact = proto.Clone(artifact).(*artifacts_proto.Artifact)
+	artifact.Version = ""
+	artifact.Pinned = false
+
+	serialized, err := proto.MarshalOptions{Deterministic: true}.Marshal(artifact)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(serialized)
+	return hex.EncodeToString(hash[:8])
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/flows/archive"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/startup"
+)
+
+var (
+	archive_command = app.Command(
+		"archive", "Move flows and hunts between servers.")
+
+	archive_command_export = archive_command.Command(
+		"export", "Export a flow or a hunt into an archive.")
+
+	archive_command_export_output = archive_command_export.Arg(
+		"output", "Path to write the archive to").Required().String()
+
+	archive_command_export_client_id = archive_command_export.Flag(
+		"client_id", "The client id of the flow to export").String()
+
+	archive_command_export_flow_id = archive_command_export.Flag(
+		"flow_id", "The flow id to export").String()
+
+	archive_command_export_hunt_id = archive_command_export.Flag(
+		"hunt_id", "The hunt id to export").String()
+
+	archive_command_import = archive_command.Command(
+		"import", "Import an archive exported from another server.")
+
+	archive_command_import_file = archive_command_import.Arg(
+		"file", "The archive to import").Required().ExistingFile()
+
+	archive_command_import_remap = archive_command_import.Flag(
+		"new_ids", "Assign new ids to the imported flows and hunt "+
+			"instead of keeping the original ids").Bool()
+)
+
+func doArchiveExport() error {
+	config_obj, err := makeDefaultConfigLoader().
+		WithRequiredFrontend().
+		WithRequiredUser().
+		LoadAndValidate()
+	if err != nil {
+		return fmt.Errorf("loading config file: %w", err)
+	}
+
+	if *archive_command_export_hunt_id == "" &&
+		(*archive_command_export_client_id == "" ||
+			*archive_command_export_flow_id == "") {
+		return fmt.Errorf("Either --hunt_id or --client_id and --flow_id must be specified")
+	}
+
+	ctx, cancel := install_sig_handler()
+	defer cancel()
+
+	config_obj.Services = services.GenericToolServices()
+	sm, err := startup.StartToolServices(ctx, config_obj)
+	defer sm.Close()
+
+	if err != nil {
+		return err
+	}
+
+	err = sm.Start(datastore.StartMemcacheFileService)
+	if err != nil {
+		return fmt.Errorf("Starting services: %w", err)
+	}
+
+	fd, err := os.OpenFile(*archive_command_export_output,
+		os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	if *archive_command_export_hunt_id != "" {
+		err = archive.ExportHunt(ctx, config_obj, fd,
+			*archive_command_export_hunt_id)
+	} else {
+		err = archive.ExportFlow(ctx, config_obj, fd,
+			*archive_command_export_client_id,
+			*archive_command_export_flow_id)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote archive to %v\n", *archive_command_export_output)
+	return nil
+}
+
+func doArchiveImport() error {
+	config_obj, err := makeDefaultConfigLoader().
+		WithRequiredFrontend().
+		WithRequiredUser().
+		LoadAndValidate()
+	if err != nil {
+		return fmt.Errorf("loading config file: %w", err)
+	}
+
+	ctx, cancel := install_sig_handler()
+	defer cancel()
+
+	// The indexer is needed to add new clients.
+	config_obj.Services = services.GenericToolServices()
+	config_obj.Services.IndexServer = true
+
+	sm, err := startup.StartToolServices(ctx, config_obj)
+	defer sm.Close()
+
+	if err != nil {
+		return err
+	}
+
+	err = sm.Start(datastore.StartMemcacheFileService)
+	if err != nil {
+		return fmt.Errorf("Starting services: %w", err)
+	}
+
+	fd, err := os.Open(*archive_command_import_file)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	stat, err := fd.Stat()
+	if err != nil {
+		return err
+	}
+
+	result, err := archive.Import(ctx, config_obj, fd, stat.Size(),
+		archive.ImportOptions{Remap: *archive_command_import_remap})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(json.StringIndent(result))
+	return nil
+}
+
+func init() {
+	command_handlers = append(command_handlers, func(command string) bool {
+		switch command {
+		case archive_command_export.FullCommand():
+			FatalIfError(archive_command_export, doArchiveExport)
+
+		case archive_command_import.FullCommand():
+			FatalIfError(archive_command_import, doArchiveImport)
+
+		default:
+			return false
+		}
+		return true
+	})
+}
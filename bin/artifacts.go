@@ -20,6 +20,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"regexp"
 	"strings"
@@ -30,6 +31,7 @@ import (
 	"github.com/Velocidex/yaml/v2"
 	"www.velocidex.com/golang/velociraptor/config"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
 	"www.velocidex.com/golang/velociraptor/executor"
 	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
 	logging "www.velocidex.com/golang/velociraptor/logging"
@@ -111,6 +113,17 @@ var (
 
 	artifact_command_collect_hardmemory = artifact_command_collect.Flag(
 		"hard_memory_limit", "If we reach this memory limit in bytes we exit.").Uint64()
+
+	artifact_command_sign = artifact_command.Command(
+		"sign", "Sign an artifact pack (a zip file of artifact definitions)")
+	artifact_command_sign_key = artifact_command_sign.Flag(
+		"key", "A PEM encoded RSA private key to sign with").Required().String()
+	artifact_command_sign_signer = artifact_command_sign.Flag(
+		"signer", "A name for the signer").String()
+	artifact_command_sign_input = artifact_command_sign.Arg(
+		"input", "The artifact pack to sign").Required().String()
+	artifact_command_sign_output = artifact_command_sign.Arg(
+		"output", "Where to write the signed artifact pack").Required().String()
 )
 
 func listArtifactsHint() []string {
@@ -417,9 +430,45 @@ func maybeAddDefinitionsDirectory(config_obj *config_proto.Config) error {
 	return nil
 }
 
+func doArtifactSign() error {
+	key_pem, err := ioutil.ReadFile(*artifact_command_sign_key)
+	if err != nil {
+		return fmt.Errorf("Unable to read key: %w", err)
+	}
+
+	key, err := crypto_utils.ParseRsaPrivateKeyFromPemStr(key_pem)
+	if err != nil {
+		return fmt.Errorf("Unable to parse key: %w", err)
+	}
+
+	data, err := ioutil.ReadFile(*artifact_command_sign_input)
+	if err != nil {
+		return fmt.Errorf("Unable to read file: %w", err)
+	}
+
+	signed, err := crypto_utils.SignArtifactPack(
+		data, key, *artifact_command_sign_signer)
+	if err != nil {
+		return fmt.Errorf("Signing: %w", err)
+	}
+
+	err = ioutil.WriteFile(*artifact_command_sign_output, signed, 0644)
+	if err != nil {
+		return fmt.Errorf("Unable to write file: %w", err)
+	}
+
+	// Servers must trust this key in Defaults.artifact_pack_signing_keys
+	fmt.Printf("Signed %v. Add this key to Defaults.artifact_pack_signing_keys:\n%s",
+		*artifact_command_sign_output, crypto_utils.PublicKeyToPem(&key.PublicKey))
+	return nil
+}
+
 func init() {
 	command_handlers = append(command_handlers, func(command string) bool {
 		switch command {
+		case artifact_command_sign.FullCommand():
+			FatalIfError(artifact_command_sign, doArtifactSign)
+
 		case artifact_command_list.FullCommand():
 			FatalIfError(artifact_command_list, doArtifactList)
 
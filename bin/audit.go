@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/audit"
+	"www.velocidex.com/golang/velociraptor/startup"
+)
+
+var (
+	audit_command = app.Command("audit", "Manage the audit log")
+
+	audit_verify = audit_command.Command(
+		"verify", "Verify the hash chain and signatures of the audit log")
+
+	audit_verify_start = audit_verify.Flag(
+		"start", "Only verify records after this time (RFC3339)").String()
+
+	audit_verify_end = audit_verify.Flag(
+		"end", "Only verify records before this time (RFC3339)").String()
+)
+
+func parseAuditTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func doAuditVerify() error {
+	config_obj, err := makeDefaultConfigLoader().
+		WithRequiredFrontend().
+		WithRequiredUser().
+		WithRequiredLogging().LoadAndValidate()
+	if err != nil {
+		return fmt.Errorf("loading config file: %w", err)
+	}
+	config_obj.Services = services.GenericToolServices()
+
+	start, err := parseAuditTime(*audit_verify_start)
+	if err != nil {
+		return fmt.Errorf("--start: %w", err)
+	}
+
+	end, err := parseAuditTime(*audit_verify_end)
+	if err != nil {
+		return fmt.Errorf("--end: %w", err)
+	}
+
+	ctx, cancel := install_sig_handler()
+	defer cancel()
+
+	sm, err := startup.StartToolServices(ctx, config_obj)
+	defer sm.Close()
+
+	if err != nil {
+		return err
+	}
+
+	summary, err := audit.Verify(ctx, config_obj, start, end,
+		func(row *ordereddict.Dict) {
+			fmt.Println(json.MustMarshalString(row))
+		})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(json.MustMarshalIndent(summary)))
+
+	verified, _ := summary.Get("Verified")
+	if verified != true {
+		return fmt.Errorf("Audit log verification failed")
+	}
+
+	return nil
+}
+
+func init() {
+	command_handlers = append(command_handlers, func(command string) bool {
+		switch command {
+		case audit_verify.FullCommand():
+			FatalIfError(audit_verify, doAuditVerify)
+
+		default:
+			return false
+		}
+		return true
+	})
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/backup"
+)
+
+var (
+	backup_command = app.Command(
+		"backup", "Manage online backups of the datastore.")
+
+	backup_command_create = backup_command.Command(
+		"create", "Take a snapshot now. Stop the server first - "+
+			"a running server takes its own snapshots.")
+
+	backup_command_list = backup_command.Command(
+		"list", "List the snapshots in the backup repository.")
+
+	backup_command_verify = backup_command.Command(
+		"verify", "Check the integrity of a snapshot.")
+
+	backup_command_verify_snapshot = backup_command_verify.Flag(
+		"snapshot", "The snapshot id to verify (default the latest)").String()
+
+	backup_command_restore = backup_command.Command(
+		"restore", "Restore the datastore from a snapshot. The server "+
+			"must be stopped and the datastore directory empty.")
+
+	backup_command_restore_time = backup_command_restore.Flag(
+		"time", "Restore the datastore as of this time (RFC3339)").String()
+
+	backup_command_restore_snapshot = backup_command_restore.Flag(
+		"snapshot", "The snapshot id to restore").String()
+
+	backup_command_restore_output = backup_command_restore.Flag(
+		"output", "Restore into this directory instead of the "+
+			"configured datastore").String()
+)
+
+func doBackupCreate() error {
+	config_obj, err := makeDefaultConfigLoader().
+		WithRequiredFrontend().
+		WithRequiredLogging().
+		LoadAndValidate()
+	if err != nil {
+		return fmt.Errorf("Unable to load config file: %w", err)
+	}
+
+	ctx, cancel := install_sig_handler()
+	defer cancel()
+
+	manifest, err := backup.Snapshot(ctx, config_obj)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(json.MustMarshalIndent(manifest.Summary())))
+	return nil
+}
+
+func doBackupList() error {
+	config_obj, err := makeDefaultConfigLoader().
+		WithRequiredFrontend().
+		LoadAndValidate()
+	if err != nil {
+		return fmt.Errorf("Unable to load config file: %w", err)
+	}
+
+	repository, err := backup.GetRepository(config_obj)
+	if err != nil {
+		return err
+	}
+
+	ids, err := repository.ListSnapshots()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		manifest, err := repository.LoadManifest(id)
+		if err != nil {
+			fmt.Printf("%v: %v\n", id, err)
+			continue
+		}
+
+		fmt.Printf("%v: consistent as of %v, %v files (%v bytes)\n",
+			id, time.Unix(manifest.Time, 0).UTC().Format(time.RFC3339),
+			manifest.TotalFiles, manifest.TotalBytes)
+	}
+	return nil
+}
+
+func doBackupVerify() error {
+	config_obj, err := makeDefaultConfigLoader().
+		WithRequiredFrontend().
+		LoadAndValidate()
+	if err != nil {
+		return fmt.Errorf("Unable to load config file: %w", err)
+	}
+
+	repository, err := backup.GetRepository(config_obj)
+	if err != nil {
+		return err
+	}
+
+	var manifest *backup.Manifest
+	if *backup_command_verify_snapshot != "" {
+		manifest, err = repository.LoadManifest(*backup_command_verify_snapshot)
+	} else {
+		manifest, err = repository.FindSnapshot(time.Time{})
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := install_sig_handler()
+	defer cancel()
+
+	err = backup.Verify(ctx, repository, manifest)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Snapshot %v verified: %v files\n", manifest.Id,
+		manifest.TotalFiles)
+	return nil
+}
+
+func doBackupRestore() error {
+	config_obj, err := makeDefaultConfigLoader().
+		WithRequiredFrontend().
+		WithRequiredLogging().
+		LoadAndValidate()
+	if err != nil {
+		return fmt.Errorf("Unable to load config file: %w", err)
+	}
+
+	options := backup.RestoreOptions{
+		SnapshotId: *backup_command_restore_snapshot,
+		Output:     *backup_command_restore_output,
+	}
+
+	if *backup_command_restore_time != "" {
+		options.Time, err = time.Parse(
+			time.RFC3339, *backup_command_restore_time)
+		if err != nil {
+			return fmt.Errorf("--time: %w", err)
+		}
+	}
+
+	ctx, cancel := install_sig_handler()
+	defer cancel()
+
+	manifest, err := backup.Restore(ctx, config_obj, options)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.ToolComponent)
+	logger.Info("Restored snapshot %v (consistent as of %v): %v files.",
+		manifest.Id, time.Unix(manifest.Time, 0).UTC().Format(time.RFC3339),
+		manifest.TotalFiles)
+	return nil
+}
+
+func init() {
+	command_handlers = append(command_handlers, func(command string) bool {
+		switch command {
+		case backup_command_create.FullCommand():
+			FatalIfError(backup_command_create, doBackupCreate)
+
+		case backup_command_list.FullCommand():
+			FatalIfError(backup_command_list, doBackupList)
+
+		case backup_command_verify.FullCommand():
+			FatalIfError(backup_command_verify, doBackupVerify)
+
+		case backup_command_restore.FullCommand():
+			FatalIfError(backup_command_restore, doBackupRestore)
+
+		default:
+			return false
+		}
+		return true
+	})
+}
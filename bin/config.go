@@ -34,6 +34,7 @@ import (
 	"www.velocidex.com/golang/velociraptor/config"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/crypto"
+	crypto_client "www.velocidex.com/golang/velociraptor/crypto/client"
 	"www.velocidex.com/golang/velociraptor/json"
 	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/services"
@@ -106,6 +107,10 @@ var (
 	config_reissue_server_key = config_command.Command(
 		"reissue_key",
 		"Reissue all certificates with the same keys.")
+
+	config_hybrid_keys = config_command.Command(
+		"hybrid_keys",
+		"Generate a new config file with a hybrid X25519+ML-KEM key pair for client communications.")
 )
 
 func maybeGetOrgConfig(
@@ -292,6 +297,39 @@ func doRotateKeyConfig() error {
 	return nil
 }
 
+// Clients start using the hybrid key agreement once they receive a
+// client config containing the server's hybrid public key.
+func doHybridKeysConfig() error {
+	config_obj, err := makeDefaultConfigLoader().
+		WithRequiredFrontend().LoadAndValidate()
+	if err != nil {
+		return err
+	}
+
+	hybrid_key, err := crypto_client.GenerateHybridKey()
+	if err != nil {
+		return err
+	}
+
+	parsed, err := crypto_client.ParseHybridPrivateKey(hybrid_key)
+	if err != nil {
+		return err
+	}
+
+	config_obj.Frontend.HybridPrivateKey = base64.StdEncoding.EncodeToString(
+		hybrid_key)
+	config_obj.Client.ServerHybridPublicKey = base64.StdEncoding.EncodeToString(
+		parsed.PublicKey())
+
+	res, err := yaml.Marshal(config_obj)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%v", string(res))
+
+	return nil
+}
+
 func doReissueServerKeys() error {
 	config_obj, err := makeDefaultConfigLoader().
 		WithRequiredFrontend().LoadAndValidate()
@@ -516,6 +554,9 @@ func init() {
 		case config_reissue_server_key.FullCommand():
 			FatalIfError(config_reissue_server_key, doReissueServerKeys)
 
+		case config_hybrid_keys.FullCommand():
+			FatalIfError(config_hybrid_keys, doHybridKeysConfig)
+
 		case config_client_command.FullCommand():
 			FatalIfError(config_client_command, doDumpClientConfig)
 
@@ -54,16 +54,20 @@ func doFrontendWithPanicGuard() error {
 
 // Start the frontend
 func doFrontend() error {
-	config_obj, err := makeDefaultConfigLoader().
+	loader := makeDefaultConfigLoader().
 		WithRequiredFrontend().
 		WithRequiredUser().
-		WithRequiredLogging().LoadAndValidate()
+		WithRequiredLogging()
+	config_obj, err := loader.LoadAndValidate()
 	if err != nil {
 		logging.FlushPrelogs(config.GetDefaultConfig())
 		return fmt.Errorf("loading config file: %w", err)
 	}
 
-	ctx, cancel := install_sig_handler()
+	// Keep the config as loaded to detect changes when reloading it.
+	reloader := startup.NewConfigReloader(config_obj, config_obj, loader.Reload)
+
+	ctx, cancel, reload := install_reload_sig_handler()
 	defer cancel()
 
 	// Come up with a suitable services plan depending on the frontend
@@ -110,6 +114,9 @@ func doFrontend() error {
 	}
 	defer sm.Close()
 
+	// Reload the config on SIGHUP or when the config file changes.
+	reloader.Start(sm.Ctx, sm.Wg, *config_path, reload)
+
 	// Wait here for completion.
 	sm.Wg.Wait()
 
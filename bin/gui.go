@@ -67,8 +67,8 @@ func doGUI() error {
 		// client. It is useful for demonstration purposes and
 		// to just be able to use the notebook and build an
 		// offline collector.
-		logging.Prelog("No valid config found - " +
-			"will generare a new one at <green>" + server_config_path)
+		logging.Prelog("No valid config found - "+
+			"will generare a new one at <green>%v", server_config_path)
 
 		config_obj = config.GetDefaultConfig()
 		err := generateNewKeys(config_obj)
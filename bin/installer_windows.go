@@ -241,6 +241,14 @@ func installService(
 		logger.Info("SetRecoveryActions() failed: %s", err)
 	}
 
+	anti_tamper := config_obj.Client.WindowsInstaller.AntiTamper
+	if anti_tamper != nil && anti_tamper.HardenRecovery {
+		err = hardenServiceRecovery(s)
+		if err != nil {
+			logger.Info("hardenServiceRecovery() failed: %s", err)
+		}
+	}
+
 	// Try to create an event source but dont sweat it if it does
 	// not work.
 	err = eventlog.InstallAsEventCreate(
@@ -435,6 +443,20 @@ type VelociraptorService struct {
 	mu    sync.Mutex
 	comms *http_comms.HTTPCommunicator
 	name  string
+
+	tamper *tamperMonitor
+}
+
+func (self *VelociraptorService) SetTamperMonitor(tamper *tamperMonitor) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.tamper = tamper
+}
+
+func (self *VelociraptorService) GetTamperMonitor() *tamperMonitor {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return self.tamper
 }
 
 func (self *VelociraptorService) SetPause(value bool) {
@@ -469,8 +491,22 @@ loop:
 			case svc.Interrogate:
 				changes <- c.CurrentStatus
 			case svc.Stop, svc.Shutdown:
+				changes <- svc.Status{State: svc.StopPending}
+				tamper := self.GetTamperMonitor()
+				if tamper != nil {
+					// Only a stop request is suspicious - the
+					// system may shut down at any time.
+					if c.Cmd == svc.Stop {
+						tamper.ReportStopAttempt("Service stop requested")
+					}
+					tamper.Close()
+				}
 				break loop
 			case svc.Pause:
+				tamper := self.GetTamperMonitor()
+				if tamper != nil {
+					tamper.ReportStopAttempt("Service pause requested")
+				}
 				changes <- svc.Status{
 					State:   svc.Paused,
 					Accepts: cmdsAccepted,
@@ -535,6 +571,11 @@ func runOnce(ctx context.Context,
 		return
 	}
 
+	tamper := newTamperMonitor(ctx, config_obj, exe.Outbound)
+	result.SetTamperMonitor(tamper)
+	tamper.Start()
+	defer tamper.Close()
+
 	sm, err := startup.StartClientServices(ctx, config_obj, exe, on_error)
 	defer sm.Close()
 
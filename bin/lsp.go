@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"www.velocidex.com/golang/velociraptor/api"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/startup"
+	"www.velocidex.com/golang/velociraptor/vql/lsp"
+)
+
+var (
+	lsp_command = app.Command("lsp",
+		"Serve the Language Server Protocol for VQL and artifacts on stdin/stdout")
+)
+
+func doLSP() error {
+	config_obj, err := makeDefaultConfigLoader().
+		WithNullLoader().LoadAndValidate()
+	if err != nil {
+		return fmt.Errorf("Unable to load config file: %w", err)
+	}
+
+	ctx, cancel := install_sig_handler()
+	defer cancel()
+
+	sm, err := startup.StartToolServices(ctx, config_obj)
+	defer sm.Close()
+
+	if err != nil {
+		return err
+	}
+
+	// Copy the slice since the artifacts are appended to it.
+	completions := append([]*api_proto.Completion{},
+		api.ServerDescription()...)
+
+	repository, err := getRepository(config_obj)
+	if err != nil {
+		return err
+	}
+
+	artifacts, err := lsp.ArtifactCompletions(sm.Ctx, config_obj, repository)
+	if err != nil {
+		return err
+	}
+	completions = append(completions, artifacts...)
+
+	logger := logging.GetLogger(config_obj, &logging.ToolComponent)
+	logger.Info("Starting LSP server with %v completions", len(completions))
+
+	// Stdout carries the protocol - logging goes to stderr.
+	return lsp.NewServer(completions, repository).Serve(
+		sm.Ctx, os.Stdin, os.Stdout)
+}
+
+func init() {
+	command_handlers = append(command_handlers, func(command string) bool {
+		switch command {
+		case lsp_command.FullCommand():
+			FatalIfError(lsp_command, doLSP)
+
+		default:
+			return false
+		}
+		return true
+	})
+}
@@ -1,5 +1,3 @@
-// +build XXXX
-
 /*
    Velociraptor - Dig Deeper
    Copyright (C) 2019-2022 Rapid7 Inc.
@@ -20,58 +18,198 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"path"
+	"math/rand"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
+	"time"
 
-	config "www.velocidex.com/golang/velociraptor/config"
+	"google.golang.org/protobuf/proto"
+	"www.velocidex.com/golang/velociraptor/config"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
 	"www.velocidex.com/golang/velociraptor/executor"
-	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/http_comms"
+	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/server"
-	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/startup"
 )
 
 var (
 	pool_client_command = app.Command(
-		"pool_client", "Run a pool client for load testing.")
+		"pool_client", "Simulate many clients for load testing the server.")
 
 	pool_client_number = pool_client_command.Flag(
-		"number", "Total number of clients to run.").Int()
+		"number", "Total number of clients to run.").Default("2").Int()
 
 	pool_client_writeback_dir = pool_client_command.Flag(
 		"writeback_dir", "The directory to store all writebacks.").Default(".").
 		ExistingDir()
 
-	pool_client_concurrency = pool_client_command.Flag(
-		"concurrency", "How many real queries to run.").Default("10").Int()
+	pool_client_start_rate = pool_client_command.Flag(
+		"start_rate", "How many clients to start per second.").
+		Default("50").Float64()
+
+	pool_client_events_per_minute = pool_client_command.Flag(
+		"events_per_minute", "Average number of events each client sends per minute.").
+		Default("1").Float64()
+
+	pool_client_event_artifacts = pool_client_command.Flag(
+		"event_artifact", "Send events for this artifact instead of the "+
+			"client event table (can be repeated).").Strings()
+
+	pool_client_rows = pool_client_command.Flag(
+		"rows", "Number of rows each client returns for each query "+
+			"in a collection or hunt.").Default("10").Int()
+
+	pool_client_row_size = pool_client_command.Flag(
+		"row_size", "Bytes of padding added to each row.").Default("0").Int()
+
+	pool_client_response_delay = pool_client_command.Flag(
+		"response_delay", "Collections complete after a random delay up to this long.").
+		Default("10s").Duration()
+
+	pool_client_error_rate = pool_client_command.Flag(
+		"error_rate", "Fraction of collections which fail.").
+		Default("0").Float64()
+
+	pool_client_flap_interval = pool_client_command.Flag(
+		"flap_interval", "How often each client may disconnect (0 to never disconnect).").
+		Default("0s").Duration()
+
+	pool_client_flap_probability = pool_client_command.Flag(
+		"flap_probability", "Chance a client disconnects at each flap interval.").
+		Default("0.1").Float64()
+
+	pool_client_flap_downtime = pool_client_command.Flag(
+		"flap_downtime", "Disconnected clients reconnect after a random delay up to this long.").
+		Default("1m").Duration()
 )
 
-type counter struct {
-	i  int
-	mu sync.Mutex
+type poolStats struct {
+	started int64
+	online  int64
 }
 
-func (self *counter) Inc() {
-	self.mu.Lock()
-	defer self.mu.Unlock()
-	self.i++
+func (self *poolStats) report(ctx context.Context,
+	config_obj *config_proto.Config) {
+	logger := logging.GetLogger(config_obj, &logging.ClientComponent)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-time.After(10 * time.Second):
+			events, collections := executor.GetSimulatorStats()
+			logger.Info("Pool client: %v clients started, %v online, "+
+				"%v events and %v collections sent",
+				atomic.LoadInt64(&self.started),
+				atomic.LoadInt64(&self.online),
+				events, collections)
+		}
+	}
+}
+
+// Each client needs its own writeback and must not share the file
+// based buffers with the other clients.
+func makePoolClientConfig(
+	config_obj *config_proto.Config, i int) (*config_proto.Config, error) {
+	client_config := proto.Clone(config_obj).(*config_proto.Config)
+
+	filename := fmt.Sprintf("pool_client.yaml.%d", i)
+	client_config.Client.WritebackLinux = filepath.Join(
+		*pool_client_writeback_dir, filename)
+	client_config.Client.WritebackWindows = client_config.Client.WritebackLinux
+	client_config.Client.WritebackDarwin = client_config.Client.WritebackLinux
+	client_config.Client.UseTpm = false
+
+	if client_config.Client.LocalBuffer != nil {
+		client_config.Client.LocalBuffer.DiskSize = 0
+		client_config.Client.LocalBuffer.EventDiskSize = 0
+	}
+
+	// Creates the client's key on first use.
+	err := crypto_utils.VerifyConfig(client_config)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid config: %w", err)
+	}
+
+	return client_config, nil
+}
+
+// Run the comms for a simulated client. When flapping is enabled the
+// comms are periodically torn down and restarted to simulate clients
+// going offline.
+func runPoolClient(ctx context.Context,
+	config_obj *config_proto.Config,
+	exe executor.Executor, stats *poolStats) {
+
+	logger := logging.GetLogger(config_obj, &logging.ClientComponent)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	for {
+		comms_ctx, cancel := context.WithCancel(ctx)
+		wg := &sync.WaitGroup{}
+
+		_, err := http_comms.StartHttpCommunicatorService(
+			comms_ctx, wg, config_obj, exe,
+			func(ctx context.Context, config_obj *config_proto.Config) {})
+		if err != nil {
+			logger.Error("Pool client %v: %v", exe.ClientId(), err)
+			cancel()
+			return
+		}
+
+		atomic.AddInt64(&stats.online, 1)
+		waitForFlap(ctx, rng)
+		atomic.AddInt64(&stats.online, -1)
+
+		cancel()
+		wg.Wait()
+
+		downtime := time.Duration(0)
+		if *pool_client_flap_downtime > 0 {
+			downtime = time.Duration(rng.Int63n(int64(*pool_client_flap_downtime)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(downtime):
+		}
+	}
+}
 
-	if self.i%100 == 0 {
-		fmt.Printf("Starting %v clients\n", self.i)
+// Blocks until the client should disconnect.
+func waitForFlap(ctx context.Context, rng *rand.Rand) {
+	if *pool_client_flap_interval <= 0 {
+		<-ctx.Done()
+		return
 	}
 
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-time.After(*pool_client_flap_interval):
+			if rng.Float64() < *pool_client_flap_probability {
+				return
+			}
+		}
+	}
 }
 
 func doPoolClient() error {
 	number_of_clients := *pool_client_number
 	if number_of_clients <= 0 {
-		number_of_clients = 2
+		return fmt.Errorf("--number must be positive")
 	}
 
-	client_config, err := makeDefaultConfigLoader().
+	config_obj, err := makeDefaultConfigLoader().
 		WithRequiredClient().
 		WithVerbose(*verbose_flag).
 		LoadAndValidate()
@@ -82,67 +220,57 @@ func doPoolClient() error {
 	ctx, cancel := install_sig_handler()
 	defer cancel()
 
-	sm := services.NewServiceManager(ctx, client_config)
+	sm, err := startup.StartPoolClientServices(ctx, config_obj)
 	defer sm.Close()
+	if err != nil {
+		return err
+	}
 
-	server.IncreaseLimits(client_config)
-
-	// Make a copy of all the configs for each client.
-	configs := make([]*config_proto.Config, 0, number_of_clients)
-	serialized, _ := json.Marshal(client_config)
+	server.IncreaseLimits(config_obj)
 
-	for i := 0; i < number_of_clients; i++ {
-		client_config := &config_proto.Config{}
-		err := json.Unmarshal(serialized, &client_config)
-		if err != nil {
-			return fmt.Errorf("Copying configs: %w", err)
-		}
-		configs = append(configs, client_config)
+	options := executor.SimulatorOptions{
+		EventsPerMinute: *pool_client_events_per_minute,
+		EventArtifacts:  *pool_client_event_artifacts,
+		RowsPerQuery:    *pool_client_rows,
+		RowSize:         *pool_client_row_size,
+		ResponseDelay:   *pool_client_response_delay,
+		ErrorRate:       *pool_client_error_rate,
 	}
 
-	c := counter{}
+	stats := &poolStats{}
+	go stats.report(ctx, config_obj)
 
-	for i := 0; i < number_of_clients; i++ {
-		go func(i int) error {
-			client_config := configs[i]
-			filename := fmt.Sprintf("pool_client.yaml.%d", i)
-			client_config.Client.WritebackLinux = path.Join(
-				*pool_client_writeback_dir, filename)
-
-			// Create an in memory ring buffer because the file ring
-			// buffer assumes there is only one communicator!
-			client_config.Client.WritebackWindows = client_config.Client.WritebackLinux
-			if client_config.Client.LocalBuffer != nil {
-				client_config.Client.LocalBuffer.DiskSize = 0
-			}
-			client_config.Client.Concurrency = uint64(*pool_client_concurrency)
-
-			// Make sure the config is ok.
-			err = crypto_utils.VerifyConfig(client_config)
-			if err != nil {
-				return fmt.Errorf("Invalid config: %w", err)
-			}
+	// Start clients gradually so the server is not hit by all
+	// enrollments at once.
+	start_delay := time.Duration(0)
+	if *pool_client_start_rate > 0 {
+		start_delay = time.Duration(float64(time.Second) / *pool_client_start_rate)
+	}
 
-			writeback, err := config.GetWriteback(client_config.Client)
-			if err != nil {
-				return err
-			}
+	for i := 0; i < number_of_clients; i++ {
+		client_config, err := makePoolClientConfig(config_obj, i)
+		if err != nil {
+			return err
+		}
 
-			exe, err := executor.NewPoolClientExecutor(
-				ctx, writeback.ClientId, client_config, i)
-			if err != nil {
-				return fmt.Errorf("Can not create executor: %w", err)
-			}
+		writeback, err := config.GetWriteback(client_config.Client)
+		if err != nil {
+			return err
+		}
 
-			err = startup.StartPoolClientServices(sm, client_config, exe)
-			if err != nil {
-				return err
-			}
+		client_options := options
+		client_options.Hostname = fmt.Sprintf("pool-client-%d", i)
+		exe := executor.NewSimulatedExecutor(
+			ctx, writeback.ClientId, client_options)
 
-			c.Inc()
+		go runPoolClient(ctx, client_config, exe, stats)
+		atomic.AddInt64(&stats.started, 1)
 
+		select {
+		case <-ctx.Done():
 			return nil
-		}(i)
+		case <-time.After(start_delay):
+		}
 	}
 
 	// Block forever.
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/standby"
+)
+
+var (
+	standby_command = app.Command(
+		"standby", "Manage a warm standby server.")
+
+	standby_command_serve = standby_command.Command(
+		"serve", "Receive datastore replication from the frontends.")
+
+	standby_command_promote = standby_command.Command(
+		"promote", "Stop accepting replication so this server can "+
+			"be started as the frontend.")
+
+	standby_command_status = standby_command.Command(
+		"status", "Show the replication state of this standby.")
+)
+
+func doStandbyServe() error {
+	config_obj, err := makeDefaultConfigLoader().
+		WithRequiredFrontend().
+		WithRequiredLogging().
+		LoadAndValidate()
+	if err != nil {
+		return fmt.Errorf("Unable to load config file: %w", err)
+	}
+
+	if config_obj.Datastore.Replication == nil ||
+		config_obj.Datastore.Replication.BindAddress == "" {
+		return errors.New("Datastore.replication.bind_address is not configured")
+	}
+
+	ctx, cancel := install_sig_handler()
+	defer cancel()
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	_, err = standby.StartStandbyServer(ctx, wg, config_obj)
+	if err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+func doStandbyPromote() error {
+	config_obj, err := makeDefaultConfigLoader().
+		WithRequiredFrontend().
+		LoadAndValidate()
+	if err != nil {
+		return fmt.Errorf("Unable to load config file: %w", err)
+	}
+
+	err = standby.Promote(config_obj)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.ToolComponent)
+	logger.Info("Standby promoted. Stop the standby serve command, clear " +
+		"Datastore.replication.bind_address and start the frontend on " +
+		"this host. Point the frontend DNS name at this host.")
+	return nil
+}
+
+func doStandbyStatus() error {
+	config_obj, err := makeDefaultConfigLoader().
+		WithRequiredFrontend().
+		LoadAndValidate()
+	if err != nil {
+		return fmt.Errorf("Unable to load config file: %w", err)
+	}
+
+	state, err := standby.LoadState(config_obj)
+	if err != nil {
+		return err
+	}
+
+	if state.LastReplication > 0 {
+		fmt.Printf("Last replication: %v\n",
+			time.Unix(state.LastReplication, 0).UTC())
+	}
+
+	if state.Promoted {
+		fmt.Printf("Promoted: %v\n", time.Unix(state.PromotedTime, 0).UTC())
+	}
+
+	for node, node_state := range state.Nodes {
+		fmt.Printf("Frontend %v: last op %v (run %v)\n", node,
+			node_state.Sequence, time.Unix(0, node_state.Epoch).UTC())
+	}
+	return nil
+}
+
+func init() {
+	command_handlers = append(command_handlers, func(command string) bool {
+		switch command {
+		case standby_command_serve.FullCommand():
+			FatalIfError(standby_command_serve, doStandbyServe)
+
+		case standby_command_promote.FullCommand():
+			FatalIfError(standby_command_promote, doStandbyPromote)
+
+		case standby_command_status.FullCommand():
+			FatalIfError(standby_command_status, doStandbyStatus)
+
+		default:
+			return false
+		}
+		return true
+	})
+}
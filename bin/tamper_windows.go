@@ -0,0 +1,404 @@
+// +build windows
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/executor"
+	"www.velocidex.com/golang/velociraptor/logging"
+)
+
+var (
+	watchdog_command = service_command.Command(
+		"watchdog", "Restart the service when the client is killed - only called by the service.").Hidden()
+
+	watchdog_command_pid = watchdog_command.Flag(
+		"pid", "The pid of the client to watch.").Int()
+
+	watchdog_command_service = watchdog_command.Flag(
+		"service_name", "The service to restart.").String()
+)
+
+const (
+	// Only SYSTEM and Administrators may access the file.
+	protectedFileSDDL = "D:P(A;;FA;;;SY)(A;;FA;;;BA)"
+
+	// Give the comms a chance to deliver a tamper event before the
+	// service stops or pauses.
+	tamperFlushDelay = 5 * time.Second
+)
+
+// SERVICE_FAILURE_ACTIONS_FLAG
+type serviceFailureActionsFlag struct {
+	FailureActionsOnNonCrashFailures int32
+}
+
+// Restart the service quickly after any failure. By default the
+// recovery actions only apply when the service crashes, but not when
+// it is killed or exits with an error.
+func hardenServiceRecovery(s *mgr.Service) error {
+	err := s.SetRecoveryActions([]mgr.RecoveryAction{
+		{Type: mgr.ServiceRestart, Delay: 5 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: 5 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: 30 * time.Second},
+	}, 24*60*60)
+	if err != nil {
+		return err
+	}
+
+	flag := serviceFailureActionsFlag{FailureActionsOnNonCrashFailures: 1}
+	return windows.ChangeServiceConfig2(s.Handle,
+		windows.SERVICE_CONFIG_FAILURE_ACTIONS_FLAG,
+		(*byte)(unsafe.Pointer(&flag)))
+}
+
+// The writeback contains the client's private key so it should only
+// be accessible to privileged users.
+func protectWriteback(config_obj *config_proto.Config) error {
+	filename, err := config.WritebackLocation(config_obj.Client)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the file exists so it is protected from the start.
+	_, err = os.Stat(filename)
+	if err != nil {
+		writeback, err := config.GetWriteback(config_obj.Client)
+		if err != nil {
+			return err
+		}
+		err = config.UpdateWriteback(config_obj.Client, writeback)
+		if err != nil {
+			return err
+		}
+	}
+
+	sd, err := windows.SecurityDescriptorFromString(protectedFileSDDL)
+	if err != nil {
+		return err
+	}
+
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return err
+	}
+
+	return windows.SetNamedSecurityInfo(filename, windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|
+			windows.PROTECTED_DACL_SECURITY_INFORMATION,
+		nil, nil, dacl, nil)
+}
+
+func isServiceRunning(name string) (bool, error) {
+	m, err := mgr.Connect()
+	if err != nil {
+		return false, err
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return false, err
+	}
+	defer s.Close()
+
+	status, err := s.Query()
+	if err != nil {
+		return false, err
+	}
+
+	return status.State == svc.Running || status.State == svc.StartPending, nil
+}
+
+// The watchdog waits for the client to exit. The client kills the
+// watchdog before it stops normally, so if the client exits first it
+// was killed and we start the service again.
+func doWatchdog() error {
+	handle, err := windows.OpenProcess(
+		windows.SYNCHRONIZE, false, uint32(*watchdog_command_pid))
+	if err != nil {
+		return fmt.Errorf("OpenProcess %v: %w", *watchdog_command_pid, err)
+	}
+	defer windows.CloseHandle(handle)
+
+	_, err = windows.WaitForSingleObject(handle, windows.INFINITE)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < 10; i++ {
+		// Give the service manager time to notice the client is
+		// gone - it may restart it by itself.
+		time.Sleep(5 * time.Second)
+
+		running, err := isServiceRunning(*watchdog_command_service)
+		if err == nil && running {
+			return nil
+		}
+		_ = startService(*watchdog_command_service)
+	}
+
+	return fmt.Errorf("Unable to restart service %v", *watchdog_command_service)
+}
+
+// Implements the client side anti tamper protections while the
+// service is running.
+type tamperMonitor struct {
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+	once   sync.Once
+
+	config_obj   *config_proto.Config
+	anti_tamper  *config_proto.WindowsAntiTamperConfig
+	service_name string
+	logger       *logging.LogContext
+
+	output chan *crypto_proto.VeloMessage
+}
+
+func newTamperMonitor(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	output chan *crypto_proto.VeloMessage) *tamperMonitor {
+
+	anti_tamper := &config_proto.WindowsAntiTamperConfig{}
+	service_name := ""
+	if config_obj.Client.WindowsInstaller != nil {
+		service_name = config_obj.Client.WindowsInstaller.ServiceName
+		if config_obj.Client.WindowsInstaller.AntiTamper != nil {
+			anti_tamper = config_obj.Client.WindowsInstaller.AntiTamper
+		}
+	}
+
+	sub_ctx, cancel := context.WithCancel(ctx)
+	return &tamperMonitor{
+		ctx:          sub_ctx,
+		cancel:       cancel,
+		config_obj:   config_obj,
+		anti_tamper:  anti_tamper,
+		service_name: service_name,
+		logger:       logging.GetLogger(config_obj, &logging.ClientComponent),
+		output:       output,
+	}
+}
+
+func (self *tamperMonitor) Start() {
+	if self.anti_tamper.ProtectWriteback {
+		err := protectWriteback(self.config_obj)
+		if err != nil {
+			self.logger.Error("Unable to protect writeback file: %v", err)
+		}
+	}
+
+	if self.anti_tamper.ReportTampering {
+		self.checkUncleanExit()
+
+		self.wg.Add(1)
+		go self.watchBinary()
+	}
+
+	if self.anti_tamper.Watchdog && self.service_name != "" {
+		self.wg.Add(1)
+		go self.superviseWatchdog()
+	}
+}
+
+// Called when the service is asked to stop or pause.
+func (self *tamperMonitor) ReportStopAttempt(details string) {
+	if !self.anti_tamper.ReportTampering {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(self.ctx, tamperFlushDelay)
+	defer cancel()
+
+	executor.SendTamperEvent(ctx, self.output, "ServiceStopAttempt", details)
+	<-ctx.Done()
+}
+
+// Stop the protections. The service is shutting down normally so
+// the watchdog must not restart it.
+func (self *tamperMonitor) Close() {
+	self.once.Do(func() {
+		self.cancel()
+		self.wg.Wait()
+
+		if self.anti_tamper.ReportTampering {
+			self.setServiceRunning(false)
+		}
+	})
+}
+
+func (self *tamperMonitor) report(event, details string) {
+	self.logger.Error("Tampering detected: %v: %v", event, details)
+	executor.SendTamperEvent(self.ctx, self.output, event, details)
+}
+
+func (self *tamperMonitor) setServiceRunning(value bool) bool {
+	writeback, err := config.GetWriteback(self.config_obj.Client)
+	if err != nil {
+		return false
+	}
+
+	last_value := writeback.ServiceRunning
+	writeback.ServiceRunning = value
+	err = config.UpdateWriteback(self.config_obj.Client, writeback)
+	if err != nil {
+		self.logger.Error("Unable to update writeback: %v", err)
+	}
+	return last_value
+}
+
+// If the flag is still set from the last run we did not shut down
+// cleanly.
+func (self *tamperMonitor) checkUncleanExit() {
+	if self.setServiceRunning(true) {
+		self.report("UncleanExit",
+			"The client did not shut down cleanly - it may have been killed")
+	}
+}
+
+// Check the executable for modifications every minute. Comparing
+// the size and modification time is cheap, but they can be faked so
+// we also compare the hash from time to time.
+func (self *tamperMonitor) watchBinary() {
+	defer self.wg.Done()
+
+	executable, err := os.Executable()
+	if err != nil {
+		return
+	}
+
+	stat, err := os.Stat(executable)
+	if err != nil {
+		return
+	}
+
+	hash, err := hashFile(executable)
+	if err != nil {
+		return
+	}
+
+	for i := 1; ; i++ {
+		select {
+		case <-self.ctx.Done():
+			return
+		case <-time.After(time.Minute):
+		}
+
+		new_stat, err := os.Stat(executable)
+		if err != nil {
+			continue
+		}
+
+		if new_stat.Size() == stat.Size() &&
+			new_stat.ModTime() == stat.ModTime() && i%15 != 0 {
+			continue
+		}
+		stat = new_stat
+
+		new_hash, err := hashFile(executable)
+		if err != nil || new_hash == hash {
+			continue
+		}
+
+		self.report("BinaryModified", fmt.Sprintf(
+			"%v changed: sha256 was %v now %v", executable, hash, new_hash))
+		hash = new_hash
+	}
+}
+
+func hashFile(filename string) (string, error) {
+	fd, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer fd.Close()
+
+	hasher := sha256.New()
+	_, err = io.Copy(hasher, fd)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// Keep a watchdog process running for as long as the client runs.
+func (self *tamperMonitor) superviseWatchdog() {
+	defer self.wg.Done()
+
+	executable, err := os.Executable()
+	if err != nil {
+		self.logger.Error("Unable to start watchdog: %v", err)
+		return
+	}
+
+	for {
+		cmd := exec.Command(executable, "service", "watchdog",
+			"--pid", strconv.Itoa(os.Getpid()),
+			"--service_name", self.service_name)
+		err := cmd.Start()
+		if err != nil {
+			self.logger.Error("Unable to start watchdog: %v", err)
+
+		} else {
+			self.logger.Info("<green>Started</> watchdog with pid %v",
+				cmd.Process.Pid)
+
+			done := make(chan error, 1)
+			go func() {
+				done <- cmd.Wait()
+			}()
+
+			select {
+			case <-self.ctx.Done():
+				_ = cmd.Process.Kill()
+				<-done
+				return
+
+			case err := <-done:
+				if self.anti_tamper.ReportTampering {
+					self.report("WatchdogTerminated", fmt.Sprintf(
+						"Watchdog process %v exited: %v", cmd.Process.Pid, err))
+				}
+			}
+		}
+
+		select {
+		case <-self.ctx.Done():
+			return
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+func init() {
+	command_handlers = append(command_handlers, func(command string) bool {
+		switch command {
+		case watchdog_command.FullCommand():
+			FatalIfError(watchdog_command, doWatchdog)
+
+		default:
+			return false
+		}
+		return true
+	})
+}
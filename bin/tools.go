@@ -5,12 +5,14 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
 
 	"github.com/Velocidex/yaml/v2"
 	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
 	"www.velocidex.com/golang/velociraptor/file_store"
 	"www.velocidex.com/golang/velociraptor/paths"
 	"www.velocidex.com/golang/velociraptor/services"
@@ -49,6 +51,15 @@ var (
 	third_party_upload_binary_path = third_party_upload.
 					Arg("path", "Path to file or a URL").String()
 
+	third_party_sign = third_party.Command(
+		"sign", "Sign a client binary so it may be installed with upgrade_client()")
+	third_party_sign_key = third_party_sign.Flag(
+		"key", "A PEM encoded RSA private key to sign with").Required().String()
+	third_party_sign_input = third_party_sign.Arg(
+		"input", "The binary to sign").Required().String()
+	third_party_sign_output = third_party_sign.Arg(
+		"output", "Where to write the signed binary").Required().String()
+
 	url_regexp = regexp.MustCompile("^https?://")
 )
 
@@ -215,6 +226,38 @@ func doThirdPartyUpload() error {
 	return err
 }
 
+func doThirdPartySign() error {
+	key_pem, err := ioutil.ReadFile(*third_party_sign_key)
+	if err != nil {
+		return fmt.Errorf("Unable to read key: %w", err)
+	}
+
+	key, err := crypto_utils.ParseRsaPrivateKeyFromPemStr(key_pem)
+	if err != nil {
+		return fmt.Errorf("Unable to parse key: %w", err)
+	}
+
+	data, err := ioutil.ReadFile(*third_party_sign_input)
+	if err != nil {
+		return fmt.Errorf("Unable to read file: %w", err)
+	}
+
+	signed, err := crypto_utils.SignBinary(data, key)
+	if err != nil {
+		return fmt.Errorf("Signing: %w", err)
+	}
+
+	err = ioutil.WriteFile(*third_party_sign_output, signed, 0755)
+	if err != nil {
+		return fmt.Errorf("Unable to write file: %w", err)
+	}
+
+	// Clients must trust this key in Client.upgrade_signing_keys
+	fmt.Printf("Signed %v. Add this key to Client.upgrade_signing_keys:\n%s",
+		*third_party_sign_output, crypto_utils.PublicKeyToPem(&key.PublicKey))
+	return nil
+}
+
 func init() {
 	command_handlers = append(command_handlers, func(command string) bool {
 		switch command {
@@ -227,6 +270,9 @@ func init() {
 		case third_party_rm.FullCommand():
 			FatalIfError(third_party_rm, doThirdPartyRm)
 
+		case third_party_sign.FullCommand():
+			FatalIfError(third_party_sign, doThirdPartySign)
+
 		default:
 			return false
 		}
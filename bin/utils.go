@@ -158,3 +158,31 @@ func install_sig_handler() (context.Context, context.CancelFunc) {
 	return ctx, cancel
 
 }
+
+// Like install_sig_handler but SIGHUP asks the frontend to reload its
+// config instead of shutting down.
+func install_reload_sig_handler() (
+	context.Context, context.CancelFunc, <-chan os.Signal) {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		select {
+		case <-quit:
+			// Ordered shutdown now.
+			cancel()
+
+		case <-ctx.Done():
+			return
+		}
+	}()
+
+	return ctx, cancel, reload
+}
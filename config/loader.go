@@ -435,29 +435,35 @@ func (self *Loader) Validate(config_obj *config_proto.Config) error {
 		}
 	}
 
+	return self.validateSections(config_obj, self.use_writeback)
+}
+
+// Validate each section of the config and fill in defaults.
+func (self *Loader) validateSections(
+	config_obj *config_proto.Config, use_writeback bool) error {
 	if config_obj.Autoexec != nil {
-		err = ValidateAutoexecConfig(config_obj)
+		err := ValidateAutoexecConfig(config_obj)
 		if err != nil {
 			return err
 		}
 	}
 
 	if config_obj.Frontend != nil {
-		err = ValidateFrontendConfig(config_obj)
+		err := ValidateFrontendConfig(config_obj)
 		if err != nil {
 			return err
 		}
 	}
 
 	if config_obj.Datastore != nil {
-		err = ValidateDatastoreConfig(config_obj)
+		err := ValidateDatastoreConfig(config_obj)
 		if err != nil {
 			return err
 		}
 	}
 
 	if config_obj.Client != nil {
-		if self.use_writeback {
+		if use_writeback {
 			err := self.loadWriteback(config_obj)
 			if err != nil {
 				return err
@@ -490,6 +496,34 @@ func (self *Loader) LoadAndValidate() (*config_proto.Config, error) {
 	return nil, errors.New("Unable to load config from any source.")
 }
 
+// Load the config again for a running server. This applies the
+// mutators and checks the config is valid but skips initializing
+// logging and the custom validators since those have process wide
+// side effects which were already applied at startup.
+func (self *Loader) Reload() (*config_proto.Config, error) {
+	for _, loader := range self.loaders {
+		result, err := loader.loader_func(self)
+		if err != nil {
+			_, ok := err.(HardError)
+			if ok {
+				return nil, err
+			}
+			continue
+		}
+
+		result.Verbose = self.verbose
+		for _, mutator := range self.config_mutators {
+			err = mutator.config_mutator_func(result)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return result, self.validateSections(result, false)
+	}
+	return nil, errors.New("Unable to load config from any source.")
+}
+
 func read_embedded_config() (*config_proto.Config, error) {
 	idx := bytes.IndexByte(FileConfigDefaultYaml, '\n')
 	if FileConfigDefaultYaml[idx+1] == '#' {
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// Sections of the config which may be changed while the server is
+// running. Changes to any other part of the config need a restart.
+const (
+	RELOAD_LOGGING            = "Logging"
+	RELOAD_FRONTEND_RESOURCES = "Frontend.resources"
+	RELOAD_RATE_LIMITS        = "GUI.rate_limits"
+	RELOAD_CLIENT_MONITORING  = "Frontend.default_client_monitoring_artifacts"
+	RELOAD_SERVER_MONITORING  = "Frontend.default_server_monitoring_artifacts"
+)
+
+// Describes a reload of the config.
+type ConfigChange struct {
+	// The config before and after the reload.
+	Old, New *config_proto.Config
+
+	// The reloadable sections which changed.
+	Sections []string
+}
+
+func (self *ConfigChange) Changed(section string) bool {
+	for _, s := range self.Sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// Components which cache settings from the config register a
+// handler to apply changes when the config is reloaded. Handlers
+// are called after the live config objects were updated.
+type ReloadHandler func(ctx context.Context, change *ConfigChange) error
+
+var (
+	reload_mu       sync.Mutex
+	reload_handlers = make(map[string]ReloadHandler)
+)
+
+// Register a handler under a unique name. Registering a handler
+// with the same name replaces the old one. Returns a function to
+// remove the handler.
+func RegisterReloadHandler(name string, handler ReloadHandler) func() {
+	reload_mu.Lock()
+	defer reload_mu.Unlock()
+
+	reload_handlers[name] = handler
+
+	return func() {
+		reload_mu.Lock()
+		defer reload_mu.Unlock()
+
+		delete(reload_handlers, name)
+	}
+}
+
+// Call all the handlers in name order. All handlers are called even
+// if some fail and the first error is returned.
+func NotifyReload(ctx context.Context, change *ConfigChange) error {
+	reload_mu.Lock()
+	names := make([]string, 0, len(reload_handlers))
+	for name := range reload_handlers {
+		names = append(names, name)
+	}
+	handlers := make([]ReloadHandler, 0, len(names))
+	sort.Strings(names)
+	for _, name := range names {
+		handlers = append(handlers, reload_handlers[name])
+	}
+	reload_mu.Unlock()
+
+	var result error
+	for _, handler := range handlers {
+		err := handler(ctx, change)
+		if err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
+
+// Get the reloadable sections which differ between the configs.
+func ReloadableChanges(old_config, new_config *config_proto.Config) []string {
+	var result []string
+
+	if !proto.Equal(old_config.Logging, new_config.Logging) {
+		result = append(result, RELOAD_LOGGING)
+	}
+
+	if !proto.Equal(old_config.Frontend.GetResources(),
+		new_config.Frontend.GetResources()) {
+		result = append(result, RELOAD_FRONTEND_RESOURCES)
+	}
+
+	if !proto.Equal(old_config.GUI.GetRateLimits(),
+		new_config.GUI.GetRateLimits()) {
+		result = append(result, RELOAD_RATE_LIMITS)
+	}
+
+	if !utils.StringSliceEq(old_config.Frontend.GetDefaultClientMonitoringArtifacts(),
+		new_config.Frontend.GetDefaultClientMonitoringArtifacts()) {
+		result = append(result, RELOAD_CLIENT_MONITORING)
+	}
+
+	if !utils.StringSliceEq(old_config.Frontend.GetDefaultServerMonitoringArtifacts(),
+		new_config.Frontend.GetDefaultServerMonitoringArtifacts()) {
+		result = append(result, RELOAD_SERVER_MONITORING)
+	}
+
+	return result
+}
+
+// Does the new config change anything which can not be reloaded?
+func RestartRequired(old_config, new_config *config_proto.Config) bool {
+	old_config = stripReloadable(old_config)
+	new_config = stripReloadable(new_config)
+
+	return !proto.Equal(old_config, new_config)
+}
+
+// Copy the reloadable sections of the new config into the live
+// config.
+func ApplyReloadable(config_obj, new_config *config_proto.Config) {
+	config_obj.Logging = proto.Clone(
+		new_config.Logging).(*config_proto.LoggingConfig)
+
+	if config_obj.Frontend != nil && new_config.Frontend != nil {
+		config_obj.Frontend.Resources = proto.Clone(
+			new_config.Frontend.Resources).(*config_proto.FrontendResourceControl)
+		config_obj.Frontend.DefaultClientMonitoringArtifacts = append([]string{},
+			new_config.Frontend.DefaultClientMonitoringArtifacts...)
+		config_obj.Frontend.DefaultServerMonitoringArtifacts = append([]string{},
+			new_config.Frontend.DefaultServerMonitoringArtifacts...)
+	}
+
+	if config_obj.GUI != nil && new_config.GUI != nil {
+		config_obj.GUI.RateLimits = proto.Clone(
+			new_config.GUI.RateLimits).(*config_proto.ApiRateLimits)
+	}
+}
+
+func stripReloadable(config_obj *config_proto.Config) *config_proto.Config {
+	result := proto.Clone(config_obj).(*config_proto.Config)
+	result.Logging = nil
+	result.Verbose = false
+
+	if result.Frontend != nil {
+		result.Frontend.Resources = nil
+		result.Frontend.DefaultClientMonitoringArtifacts = nil
+		result.Frontend.DefaultServerMonitoringArtifacts = nil
+	}
+
+	if result.GUI != nil {
+		result.GUI.RateLimits = nil
+	}
+
+	return result
+}
+
+// Apply a change of the default artifacts to a list of artifacts the
+// user may have edited since: artifacts removed from the defaults
+// are removed and new defaults are added. Other artifacts are left
+// alone.
+func MergeDefaults(current, old_defaults, new_defaults []string) []string {
+	result := []string{}
+	for _, name := range current {
+		if utils.InString(old_defaults, name) &&
+			!utils.InString(new_defaults, name) {
+			continue
+		}
+		result = append(result, name)
+	}
+
+	for _, name := range new_defaults {
+		if !utils.InString(result, name) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
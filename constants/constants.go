@@ -32,6 +32,9 @@ const (
 	HUNT_PREFIX             = "H."
 	ORG_PREFIX              = "O"
 
+	CLIENT_CERTIFICATE_WELL_KNOWN_FLOW = "E.ClientCertificate"
+	KEY_ROTATION_WELL_KNOWN_FLOW       = "E.KeyRotation"
+
 	// Well known flows - Request ID:
 	LOG_SINK   uint64 = 980
 	STATS_SINK uint64 = 981
@@ -63,6 +66,9 @@ const (
 	ARTIFACT_PACK_NAME_PREFIX   = "Packs."
 	ARTIFACT_CUSTOM_NAME_PREFIX = "Custom."
 
+	// Artifacts converted from KAPE targets
+	ARTIFACT_KAPE_NAME_PREFIX = "KAPE.Targets."
+
 	// USER record encoded in grpc context
 	GRPC_USER_CONTEXT key = iota
 
@@ -92,6 +98,10 @@ const (
 	// Set in the scope with one or more passwords
 	ZIP_PASSWORDS = "ZIP_PASSWORDS"
 
+	// Set in the scope with one or more private keys (PEM RSA or
+	// armored PGP keys) to unlock offline collector containers.
+	COLLECTOR_PRIVATE_KEYS = "COLLECTOR_PRIVATE_KEYS"
+
 	PinnedServerName = "VelociraptorServer"
 
 	CLIENT_API_VERSION = uint32(4)
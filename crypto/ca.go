@@ -26,6 +26,7 @@ import (
 	"encoding/pem"
 	"math/big"
 	"net"
+	"strings"
 	"time"
 
 	errors "github.com/go-errors/errors"
@@ -266,3 +267,88 @@ func ReissueServerCert(config_obj *config_proto.Config,
 		)),
 	}, nil
 }
+
+// Issue a TLS client certificate for an enrolled client. The client
+// presents a CSR for its existing key - the certificate's common name
+// is the client id so the frontend can tie the TLS connection to the
+// client.
+func GenerateClientCert(config_obj *config_proto.Config,
+	client_id string, enrolled_key *rsa.PublicKey,
+	csr_pem []byte) (string, error) {
+	if config_obj.CA == nil || config_obj.Client == nil {
+		return "", errors.New("No CA configured.")
+	}
+
+	csr, err := crypto_utils.ParseX509CSRFromPemStr(csr_pem)
+	if err != nil {
+		return "", err
+	}
+
+	public_key, ok := csr.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return "", errors.New("Not RSA algorithm")
+	}
+
+	// The CSR must be for the client's enrolled key. Clients in
+	// orgs have the org id appended to their client id. NOTE:
+	// Clients which rotated their key no longer have a client id
+	// derived from the key so we compare with the key we have for
+	// them.
+	err = csr.CheckSignature()
+	if err != nil {
+		return "", err
+	}
+
+	common_name := csr.Subject.CommonName
+	if enrolled_key == nil || !public_key.Equal(enrolled_key) ||
+		(client_id != common_name &&
+			!strings.HasPrefix(client_id, common_name+"-")) {
+		return "", errors.New("Invalid CSR")
+	}
+
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return "", err
+	}
+
+	ca_cert, err := utils.ParseX509CertFromPemStr([]byte(
+		config_obj.Client.CaCertificate))
+	if err != nil {
+		return "", err
+	}
+
+	ca_private_key, err := utils.ParseRsaPrivateKeyFromPemStr(
+		[]byte(config_obj.CA.PrivateKey))
+	if err != nil {
+		return "", err
+	}
+
+	start_time := time.Now()
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   client_id,
+			Organization: []string{"Velociraptor"},
+		},
+		NotBefore:   start_time,
+		NotAfter:    start_time.Add(365 * 24 * time.Hour),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+
+		BasicConstraintsValid: true,
+	}
+
+	derBytes, err := x509.CreateCertificate(
+		rand.Reader, &template, ca_cert, public_key, ca_private_key)
+	if err != nil {
+		return "", errors.Wrap(err, 0)
+	}
+
+	return string(pem.EncodeToMemory(
+		&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: derBytes,
+		},
+	)), nil
+}
@@ -45,6 +45,15 @@ type _Cipher struct {
 	encrypted_cipher          []byte
 	encrypted_cipher_metadata []byte
 	authenticated             bool
+
+	// Set when the session uses the hybrid key agreement. The
+	// payload is then encrypted with payload_properties rather than
+	// cipher_properties.
+	hybrid_encapsulation []byte
+	payload_properties   *crypto_proto.CipherProperties
+
+	// The hybrid public key this outbound cipher was encapsulated to.
+	peer_hybrid_key []byte
 }
 
 func (self *_Cipher) Size() int {
@@ -55,19 +64,40 @@ func (self *_Cipher) CipherProperties() *crypto_proto.CipherProperties {
 	return self.cipher_properties
 }
 
+// The properties used to encrypt the message payload.
+func (self *_Cipher) PayloadProperties() *crypto_proto.CipherProperties {
+	if self.payload_properties != nil {
+		return self.payload_properties
+	}
+	return self.cipher_properties
+}
+
 func (self *_Cipher) ClientCommunication() *crypto_proto.ClientCommunication {
 	return &crypto_proto.ClientCommunication{
 		EncryptedCipher:         self.encrypted_cipher,
 		EncryptedCipherMetadata: self.encrypted_cipher_metadata,
 		PacketIv:                make([]byte, self.key_size/8),
 		ApiVersion:              constants.CLIENT_API_VERSION,
+		HybridEncapsulation:     self.hybrid_encapsulation,
 	}
 }
 
 func NewCipher(
 	source string,
-	private_key *rsa.PrivateKey,
+	private_key crypto.Signer,
 	public_key *rsa.PublicKey) (*_Cipher, error) {
+	return NewHybridCipher(source, private_key, public_key, nil, nil)
+}
+
+// Create a cipher which advertises our hybrid public key
+// (hybrid_public_key) and, if the peer has a hybrid key
+// (peer_hybrid_key), also performs the hybrid key agreement with
+// it. Either may be nil.
+func NewHybridCipher(
+	source string,
+	private_key crypto.Signer,
+	public_key *rsa.PublicKey,
+	hybrid_public_key, peer_hybrid_key []byte) (*_Cipher, error) {
 
 	result := &_Cipher{
 		source:   source,
@@ -79,6 +109,8 @@ func NewCipher(
 		MetadataIv: make([]byte, result.key_size/8),
 		HmacKey:    make([]byte, result.key_size/8),
 		HmacType:   crypto_proto.CipherProperties_FULL_HMAC,
+
+		HybridPublicKey: hybrid_public_key,
 	}
 
 	_, err := rand.Read(result.cipher_properties.Key)
@@ -107,8 +139,7 @@ func NewCipher(
 
 	hashed := sha256.Sum256(serialized_cipher)
 	RsaSignCounter.Inc()
-	signature, err := rsa.SignPKCS1v15(
-		rand.Reader, private_key, crypto.SHA256, hashed[:])
+	signature, err := private_key.Sign(rand.Reader, hashed[:], crypto.SHA256)
 	if err != nil {
 		return nil, errors.Wrap(err, 0)
 	}
@@ -139,5 +170,20 @@ func NewCipher(
 	}
 	result.encrypted_cipher_metadata = encrypted_cipher_metadata
 
+	if len(peer_hybrid_key) > 0 {
+		encapsulation, shared_secret, err := HybridEncapsulate(peer_hybrid_key)
+		if err != nil {
+			return nil, err
+		}
+
+		result.payload_properties, err = deriveHybridProperties(
+			result.cipher_properties, encapsulation, shared_secret)
+		if err != nil {
+			return nil, err
+		}
+		result.hybrid_encapsulation = encapsulation
+		result.peer_hybrid_key = peer_hybrid_key
+	}
+
 	return result, nil
 }
@@ -3,6 +3,7 @@ package client
 import (
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/base64"
 
 	"github.com/Velocidex/ttlcache/v2"
 	"github.com/go-errors/errors"
@@ -59,8 +60,18 @@ func NewClientCryptoManager(config_obj *config_proto.Config, client_private_key_
 		return nil, err
 	}
 
+	return NewClientCryptoManagerWithKey(config_obj, private_key)
+}
+
+// The private key may be held in the TPM.
+func NewClientCryptoManagerWithKey(
+	config_obj *config_proto.Config,
+	private_key crypto_utils.ClientPrivateKey) (*ClientCryptoManager, error) {
+	var err error
+
 	logger := logging.GetLogger(config_obj, &logging.ClientComponent)
-	client_id := crypto_utils.ClientIDFromPublicKey(&private_key.PublicKey)
+	client_id := crypto_utils.ClientIDFromPublicKey(
+		private_key.Public().(*rsa.PublicKey))
 	logger.Info("Starting Crypto for client %v", client_id)
 
 	roots := x509.NewCertPool()
@@ -75,7 +86,7 @@ func NewClientCryptoManager(config_obj *config_proto.Config, client_private_key_
 		lru_size = config_obj.Frontend.Resources.ExpectedClients
 	}
 
-	return &ClientCryptoManager{CryptoManager{
+	result := &ClientCryptoManager{CryptoManager{
 		client_id:           client_id,
 		private_key:         private_key,
 		Resolver:            NewInMemoryPublicKeyResolver(),
@@ -83,5 +94,53 @@ func NewClientCryptoManager(config_obj *config_proto.Config, client_private_key_
 		unauthenticated_lru: ttlcache.NewCache(),
 		caPool:              roots,
 		logger:              logger,
-	}}, nil
+	}}
+
+	// Configs carrying the server's hybrid key opt into the hybrid
+	// key agreement. Our own hybrid key is ephemeral - the server
+	// learns it from our cipher.
+	if config_obj.Client.ServerHybridPublicKey != "" {
+		result.peer_hybrid_key, err = base64.StdEncoding.DecodeString(
+			config_obj.Client.ServerHybridPublicKey)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+
+		serialized, err := GenerateHybridKey()
+		if err != nil {
+			return nil, err
+		}
+
+		result.hybrid_key, err = ParseHybridPrivateKey(serialized)
+		if err != nil {
+			return nil, err
+		}
+		result.hybrid_public_key = result.hybrid_key.PublicKey()
+		logger.Info("Using hybrid key agreement with the server")
+	}
+
+	return result, nil
+}
+
+// Create the client crypto manager from the writeback. Clients which
+// rotated their key keep the client id they were originally enrolled
+// with.
+func NewClientCryptoManagerFromWriteback(
+	config_obj *config_proto.Config,
+	writeback *config_proto.Writeback) (*ClientCryptoManager, error) {
+	private_key, err := crypto_utils.GetWritebackPrivateKey(writeback)
+	if err != nil {
+		return nil, err
+	}
+
+	manager, err := NewClientCryptoManagerWithKey(config_obj, private_key)
+	if err != nil {
+		return nil, err
+	}
+
+	if writeback.KeyRotated && writeback.ClientId != "" {
+		manager.client_id = writeback.ClientId
+	}
+
+	return manager, nil
 }
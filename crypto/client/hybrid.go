@@ -0,0 +1,166 @@
+package client
+
+import (
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/mlkem"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/go-errors/errors"
+	"google.golang.org/protobuf/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+)
+
+// The hybrid key agreement combines X25519 with ML-KEM-768 so session
+// keys remain secure as long as either of them (or RSA) is not
+// broken. Keys and encapsulations are the concatenation of the X25519
+// part and the ML-KEM part:
+//
+//   private key:   X25519 private key (32) | ML-KEM seed (64)
+//   public key:    X25519 public key (32)  | ML-KEM encapsulation key (1184)
+//   encapsulation: X25519 ephemeral public key (32) | ML-KEM ciphertext (1088)
+
+const (
+	x25519KeySize     = 32
+	hybridKeyInfo     = "Velociraptor hybrid session key"
+	hybridPrivateSize = x25519KeySize + mlkem.SeedSize
+	hybridPublicSize  = x25519KeySize + mlkem.EncapsulationKeySize768
+	hybridEncapSize   = x25519KeySize + mlkem.CiphertextSize768
+)
+
+type HybridPrivateKey struct {
+	x25519 *ecdh.PrivateKey
+	mlkem  *mlkem.DecapsulationKey768
+}
+
+// Generate a new serialized hybrid private key.
+func GenerateHybridKey() ([]byte, error) {
+	x25519_key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	mlkem_key, err := mlkem.GenerateKey768()
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	result := append([]byte{}, x25519_key.Bytes()...)
+	return append(result, mlkem_key.Bytes()...), nil
+}
+
+func ParseHybridPrivateKey(serialized []byte) (*HybridPrivateKey, error) {
+	if len(serialized) != hybridPrivateSize {
+		return nil, errors.New("Invalid hybrid private key")
+	}
+
+	x25519_key, err := ecdh.X25519().NewPrivateKey(serialized[:x25519KeySize])
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	mlkem_key, err := mlkem.NewDecapsulationKey768(serialized[x25519KeySize:])
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	return &HybridPrivateKey{x25519: x25519_key, mlkem: mlkem_key}, nil
+}
+
+// Keys are stored base64 encoded in the config file.
+func ParseHybridPrivateKeyString(encoded string) (*HybridPrivateKey, error) {
+	serialized, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+	return ParseHybridPrivateKey(serialized)
+}
+
+func (self *HybridPrivateKey) PublicKey() []byte {
+	result := append([]byte{}, self.x25519.PublicKey().Bytes()...)
+	return append(result, self.mlkem.EncapsulationKey().Bytes()...)
+}
+
+// Produce an encapsulation for the holder of public_key and the
+// shared secret it protects.
+func HybridEncapsulate(public_key []byte) (
+	encapsulation []byte, shared_secret []byte, err error) {
+	if len(public_key) != hybridPublicSize {
+		return nil, nil, errors.New("Invalid hybrid public key")
+	}
+
+	peer_x25519, err := ecdh.X25519().NewPublicKey(public_key[:x25519KeySize])
+	if err != nil {
+		return nil, nil, errors.Wrap(err, 0)
+	}
+
+	peer_mlkem, err := mlkem.NewEncapsulationKey768(public_key[x25519KeySize:])
+	if err != nil {
+		return nil, nil, errors.Wrap(err, 0)
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, 0)
+	}
+
+	x25519_secret, err := ephemeral.ECDH(peer_x25519)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, 0)
+	}
+
+	mlkem_secret, ciphertext := peer_mlkem.Encapsulate()
+
+	encapsulation = append([]byte{}, ephemeral.PublicKey().Bytes()...)
+	encapsulation = append(encapsulation, ciphertext...)
+
+	return encapsulation, append(x25519_secret, mlkem_secret...), nil
+}
+
+// Recover the shared secret from an encapsulation made to our public
+// key.
+func (self *HybridPrivateKey) Decapsulate(encapsulation []byte) ([]byte, error) {
+	if len(encapsulation) != hybridEncapSize {
+		return nil, errors.New("Invalid hybrid encapsulation")
+	}
+
+	ephemeral, err := ecdh.X25519().NewPublicKey(encapsulation[:x25519KeySize])
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	x25519_secret, err := self.x25519.ECDH(ephemeral)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	mlkem_secret, err := self.mlkem.Decapsulate(encapsulation[x25519KeySize:])
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	return append(x25519_secret, mlkem_secret...), nil
+}
+
+// Derive the cipher properties used to encrypt the payload. The key
+// depends on both the RSA protected cipher key and the hybrid shared
+// secret so recovering the RSA key alone is not enough to decrypt
+// recorded traffic.
+func deriveHybridProperties(
+	cipher_properties *crypto_proto.CipherProperties,
+	encapsulation, shared_secret []byte) (*crypto_proto.CipherProperties, error) {
+	secret := append([]byte{}, cipher_properties.Key...)
+	secret = append(secret, shared_secret...)
+
+	key, err := hkdf.Key(sha256.New, secret, encapsulation,
+		hybridKeyInfo, len(cipher_properties.Key))
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	result := proto.Clone(cipher_properties).(*crypto_proto.CipherProperties)
+	result.Key = key
+	return result, nil
+}
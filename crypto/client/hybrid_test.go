@@ -0,0 +1,57 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+)
+
+func TestHybridKeyAgreement(t *testing.T) {
+	serialized, err := GenerateHybridKey()
+	assert.NoError(t, err)
+
+	private_key, err := ParseHybridPrivateKey(serialized)
+	assert.NoError(t, err)
+
+	encapsulation, shared_secret, err := HybridEncapsulate(
+		private_key.PublicKey())
+	assert.NoError(t, err)
+
+	decapsulated, err := private_key.Decapsulate(encapsulation)
+	assert.NoError(t, err)
+	assert.Equal(t, shared_secret, decapsulated)
+
+	// A different key can not recover the secret.
+	other_serialized, err := GenerateHybridKey()
+	assert.NoError(t, err)
+
+	other_key, err := ParseHybridPrivateKey(other_serialized)
+	assert.NoError(t, err)
+
+	other_secret, err := other_key.Decapsulate(encapsulation)
+	assert.NoError(t, err)
+	assert.NotEqual(t, shared_secret, other_secret)
+
+	// The derived key depends on both the cipher key and the shared
+	// secret but keeps the rest of the cipher.
+	cipher_properties := &crypto_proto.CipherProperties{
+		Key:     []byte("0123456789abcdef"),
+		HmacKey: []byte("fedcba9876543210"),
+	}
+	derived, err := deriveHybridProperties(
+		cipher_properties, encapsulation, shared_secret)
+	assert.NoError(t, err)
+	assert.Equal(t, 16, len(derived.Key))
+	assert.NotEqual(t, cipher_properties.Key, derived.Key)
+	assert.Equal(t, cipher_properties.HmacKey, derived.HmacKey)
+
+	other_derived, err := deriveHybridProperties(
+		cipher_properties, encapsulation, other_secret)
+	assert.NoError(t, err)
+	assert.NotEqual(t, derived.Key, other_derived.Key)
+
+	// Corrupt encapsulations are rejected.
+	_, err = private_key.Decapsulate(encapsulation[1:])
+	assert.Error(t, err)
+}
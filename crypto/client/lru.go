@@ -81,6 +81,23 @@ func (self *CipherLRU) GetOutboundCipher(destination string) (*_Cipher, bool) {
 	return old_entry.outbound_cipher, true
 }
 
+func (self *CipherLRU) GetInboundCipher(source string) (*_Cipher, bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	element, pres := self.by_source[source]
+	if !pres {
+		return nil, false
+	}
+
+	old_entry := element.Value.(*entry)
+	if old_entry.inbound_cipher == nil {
+		return nil, false
+	}
+
+	return old_entry.inbound_cipher, true
+}
+
 func (self *CipherLRU) Set(source string, inbound_cipher, output_cipher *_Cipher) {
 	self.mu.Lock()
 	defer self.mu.Unlock()
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/aes"
 	"crypto/cipher"
@@ -22,6 +23,7 @@ import (
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	vcrypto "www.velocidex.com/golang/velociraptor/crypto"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/crypto/tpm"
 	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
 	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/services"
@@ -30,7 +32,7 @@ import (
 
 type CryptoManager struct {
 	config      *config_proto.Config
-	private_key *rsa.PrivateKey
+	private_key crypto_utils.ClientPrivateKey
 
 	Resolver  PublicKeyResolver
 	client_id string
@@ -48,6 +50,17 @@ type CryptoManager struct {
 
 	caPool *x509.CertPool
 
+	// Our hybrid key used to decapsulate hybrid messages sent to us.
+	hybrid_key *HybridPrivateKey
+
+	// The public part of hybrid_key advertised to our peers so they
+	// can use the hybrid key agreement in their replies.
+	hybrid_public_key []byte
+
+	// A hybrid public key configured for all peers (i.e. the
+	// server's key on the client).
+	peer_hybrid_key []byte
+
 	logger *logging.LogContext
 }
 
@@ -68,7 +81,7 @@ func (self *CryptoManager) Delete(client_id string) {
 
 func (self *CryptoManager) GetCSR() ([]byte, error) {
 	subj := pkix.Name{
-		CommonName: crypto_utils.ClientIDFromPublicKey(&self.private_key.PublicKey),
+		CommonName: self.client_id,
 	}
 
 	template := x509.CertificateRequest{
@@ -83,6 +96,16 @@ func (self *CryptoManager) GetCSR() ([]byte, error) {
 		Bytes: csrBytes}), nil
 }
 
+// Describe the TPM holding our private key. Returns nil if the key is
+// not held in a TPM.
+func (self *CryptoManager) GetKeyAttestation() (*crypto_proto.KeyAttestation, error) {
+	key, ok := self.private_key.(tpm.Key)
+	if !ok {
+		return nil, nil
+	}
+	return key.Attestation()
+}
+
 func NewCryptoManager(config_obj *config_proto.Config,
 	client_id string,
 	private_key_pem []byte,
@@ -106,6 +129,16 @@ func NewCryptoManager(config_obj *config_proto.Config,
 	}
 
 	result.unauthenticated_lru.SetTTL(time.Second * 60)
+
+	if config_obj.Frontend != nil &&
+		config_obj.Frontend.HybridPrivateKey != "" {
+		result.hybrid_key, err = ParseHybridPrivateKeyString(
+			config_obj.Frontend.HybridPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return result, nil
 }
 
@@ -125,6 +158,10 @@ func CalcHMAC(
 	binary.LittleEndian.PutUint32(temp, comms.ApiVersion)
 	msg = append(msg, temp...)
 
+	// Only hybrid messages include the encapsulation so older peers
+	// still verify.
+	msg = append(msg, comms.HybridEncapsulation...)
+
 	mac := hmac.New(sha1.New, cipher.HmacKey)
 	_, _ = mac.Write(msg)
 	return mac.Sum(nil)
@@ -200,12 +237,13 @@ func (self *CryptoManager) getAuthState(
 	// Verify the cipher signature using the certificate known for
 	// the sender.
 	client_id := utils.ClientIdFromSource(cipher_metadata.Source)
-	public_key, pres := self.Resolver.GetPublicKey(
-		config_obj, cipher_metadata.Source)
+	subject := cipher_metadata.Source
+	public_key, pres := self.Resolver.GetPublicKey(config_obj, subject)
 	if !pres {
 		// Try to extract an org id from the source in case the public
 		// key was added without one.
-		public_key, pres = self.Resolver.GetPublicKey(config_obj, client_id)
+		subject = client_id
+		public_key, pres = self.Resolver.GetPublicKey(config_obj, subject)
 		if !pres {
 			// We dont know who we are talking to so we can not trust
 			// them.
@@ -220,12 +258,52 @@ func (self *CryptoManager) getAuthState(
 	err := rsa.VerifyPKCS1v15(public_key, crypto.SHA256, hashed[:],
 		cipher_metadata.Signature)
 	if err != nil {
+		// The client may have just switched to its rotated key.
+		if self.verifyPendingKey(config_obj, subject,
+			hashed[:], cipher_metadata.Signature) {
+			return true, nil
+		}
 		return false, errors.Wrap(err, 0)
 	}
 
 	return true, nil
 }
 
+// Check the signature against the client's pending key. If the client
+// is using its new key we promote it.
+func (self *CryptoManager) verifyPendingKey(
+	config_obj *config_proto.Config,
+	subject string, hashed, signature []byte) bool {
+	resolver, ok := self.Resolver.(KeyRotationResolver)
+	if !ok {
+		return false
+	}
+
+	public_key, pres := resolver.GetPendingPublicKey(config_obj, subject)
+	if !pres {
+		return false
+	}
+
+	RsaVerifyCounter.Inc()
+	err := rsa.VerifyPKCS1v15(public_key, crypto.SHA256, hashed, signature)
+	if err != nil {
+		return false
+	}
+
+	err = resolver.PromotePendingKey(config_obj, subject, public_key)
+	if err != nil {
+		self.logger.Error("Unable to promote rotated key for %v: %v",
+			subject, err)
+		return false
+	}
+
+	// Our cached outbound cipher was encrypted to the old key.
+	self.cipher_lru.Delete(subject)
+	self.logger.Info("Client %v is now using its rotated key", subject)
+
+	return true
+}
+
 func (self *CryptoManager) getCachedCipher(encrypted_cipher []byte) (*_Cipher, bool) {
 	cipher, ok := self.cipher_lru.GetByInboundCipher(encrypted_cipher)
 	if ok {
@@ -268,8 +346,14 @@ func (self *CryptoManager) Decrypt(cipher_text []byte) (*vcrypto.MessageInfo, er
 			return nil, errors.New("HMAC did not verify")
 		}
 
+		// The encapsulation does not change during the session.
+		if !bytes.Equal(cipher.hybrid_encapsulation,
+			communications.HybridEncapsulation) {
+			return nil, errors.New("Hybrid encapsulation changed")
+		}
+
 		msg_info, _, err := self.extractMessageInfo(
-			cipher.cipher_properties, communications)
+			cipher.PayloadProperties(), communications)
 		if err != nil {
 			return nil, err
 		}
@@ -283,11 +367,9 @@ func (self *CryptoManager) Decrypt(cipher_text []byte) (*vcrypto.MessageInfo, er
 
 	// Decrypt the CipherProperties
 	RsaDecryptCounter.Inc()
-	serialized_cipher, err := rsa.DecryptOAEP(
-		sha1.New(), rand.Reader,
-		self.private_key,
-		communications.EncryptedCipher,
-		[]byte(""))
+	serialized_cipher, err := self.private_key.Decrypt(
+		rand.Reader, communications.EncryptedCipher,
+		&rsa.OAEPOptions{Hash: crypto.SHA1})
 	if err != nil {
 		return nil, err
 	}
@@ -320,12 +402,18 @@ func (self *CryptoManager) Decrypt(cipher_text []byte) (*vcrypto.MessageInfo, er
 		return nil, errors.Wrap(err, 0)
 	}
 
-	msg_info, org_config_obj, err := self.extractMessageInfo(
+	payload_properties, err := self.getPayloadProperties(
 		cipher_properties, communications)
 	if err != nil {
 		return nil, err
 	}
 
+	msg_info, org_config_obj, err := self.extractMessageInfo(
+		payload_properties, communications)
+	if err != nil {
+		return nil, err
+	}
+
 	// Verify the cipher metadata signature.
 	cipher_metadata.Source = utils.ClientIdFromSourceAndOrg(
 		cipher_metadata.Source, msg_info.OrgId)
@@ -350,6 +438,9 @@ func (self *CryptoManager) Decrypt(cipher_text []byte) (*vcrypto.MessageInfo, er
 				encrypted_cipher:  communications.EncryptedCipher,
 				cipher_properties: cipher_properties,
 				authenticated:     msg_info.Authenticated,
+
+				hybrid_encapsulation: communications.HybridEncapsulation,
+				payload_properties:   payload_properties,
 			},
 			nil, /* outbound_cipher */
 		)
@@ -363,10 +454,52 @@ func (self *CryptoManager) Decrypt(cipher_text []byte) (*vcrypto.MessageInfo, er
 			encrypted_cipher:  communications.EncryptedCipher,
 			cipher_properties: cipher_properties,
 			authenticated:     false,
+
+			hybrid_encapsulation: communications.HybridEncapsulation,
+			payload_properties:   payload_properties,
 		})
 	return msg_info, nil
 }
 
+// Messages using the hybrid key agreement are encrypted with a key
+// derived from the cipher key and the hybrid shared secret.
+func (self *CryptoManager) getPayloadProperties(
+	cipher_properties *crypto_proto.CipherProperties,
+	communications *crypto_proto.ClientCommunication) (
+	*crypto_proto.CipherProperties, error) {
+	if len(communications.HybridEncapsulation) == 0 {
+		return cipher_properties, nil
+	}
+
+	if self.hybrid_key == nil {
+		return nil, errors.New("Hybrid message received but no hybrid key is configured")
+	}
+
+	shared_secret, err := self.hybrid_key.Decapsulate(
+		communications.HybridEncapsulation)
+	if err != nil {
+		return nil, err
+	}
+
+	return deriveHybridProperties(cipher_properties,
+		communications.HybridEncapsulation, shared_secret)
+}
+
+// The destination's hybrid public key if it has one. Clients learn
+// the server's key from their config while the server learns the
+// client's key from the cipher the client last sent.
+func (self *CryptoManager) getPeerHybridKey(destination string) []byte {
+	if len(self.peer_hybrid_key) > 0 {
+		return self.peer_hybrid_key
+	}
+
+	inbound_cipher, ok := self.cipher_lru.GetInboundCipher(destination)
+	if ok {
+		return inbound_cipher.cipher_properties.HybridPublicKey
+	}
+	return nil
+}
+
 // Decrypt the message from the communications using the cipher
 // properties.
 func (self *CryptoManager) extractMessageInfo(
@@ -470,6 +603,14 @@ func (self *CryptoManager) Encrypt(
 	// to enable the remote end to cache it - thereby saving RSA
 	// operations for all messages in the session.
 	output_cipher, ok := self.cipher_lru.GetOutboundCipher(destination)
+
+	// Start a new session if the peer's hybrid key changed (e.g. the
+	// client restarted or was upgraded to a hybrid config).
+	peer_hybrid_key := self.getPeerHybridKey(destination)
+	if ok && !bytes.Equal(output_cipher.peer_hybrid_key, peer_hybrid_key) {
+		ok = false
+	}
+
 	if !ok {
 		// Build a new cipher
 		public_key, pres := self.Resolver.GetPublicKey(org_config_obj, destination)
@@ -479,7 +620,9 @@ func (self *CryptoManager) Encrypt(
 				destination)
 		}
 
-		cipher, err := NewCipher(self.client_id, self.private_key, public_key)
+		cipher, err := NewHybridCipher(self.client_id,
+			self.private_key, public_key,
+			self.hybrid_public_key, peer_hybrid_key)
 		if err != nil {
 			return nil, err
 		}
@@ -510,7 +653,7 @@ func (self *CryptoManager) Encrypt(
 	}
 
 	encrypted_serialized_packed_message_list, err := EncryptSymmetric(
-		output_cipher.cipher_properties,
+		output_cipher.PayloadProperties(),
 		serialized_packed_message_list,
 		comms.PacketIv)
 	if err != nil {
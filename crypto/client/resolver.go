@@ -36,6 +36,17 @@ type PublicKeyResolver interface {
 	Clear() // Flush all internal caches.
 }
 
+// Resolvers which support client key rotation hold the client's new
+// key until the client starts using it (see crypto_proto.KeyRotation).
+type KeyRotationResolver interface {
+	GetPendingPublicKey(
+		config_obj *config_proto.Config, subject string) (*rsa.PublicKey, bool)
+
+	// Replace the client's key with the pending key.
+	PromotePendingKey(
+		config_obj *config_proto.Config, subject string, key *rsa.PublicKey) error
+}
+
 type inMemoryPublicKeyResolver struct {
 	mu          sync.Mutex
 	public_keys map[string]*rsa.PublicKey
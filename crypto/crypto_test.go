@@ -21,6 +21,7 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/tls"
 	"strings"
 	"sync"
 	"testing"
@@ -34,6 +35,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"www.velocidex.com/golang/velociraptor/config"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/crypto"
 	"www.velocidex.com/golang/velociraptor/crypto/client"
 	crypto_client "www.velocidex.com/golang/velociraptor/crypto/client"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
@@ -61,6 +63,21 @@ func (self *TestSuite) SetupTest() {
 	self.ConfigObj.Client.WritebackLinux = ""
 	self.ConfigObj.Client.WritebackWindows = ""
 
+	// The certificates in the test config expire so we issue fresh
+	// ones from a new CA.
+	ca_bundle, err := crypto.GenerateCACert(2048)
+	require.NoError(self.T(), err)
+
+	self.ConfigObj.Client.CaCertificate = ca_bundle.Cert
+	self.ConfigObj.CA.PrivateKey = ca_bundle.PrivateKey
+
+	frontend_bundle, err := crypto.GenerateServerCert(
+		self.ConfigObj, self.ConfigObj.Client.PinnedServerName)
+	require.NoError(self.T(), err)
+
+	self.ConfigObj.Frontend.Certificate = frontend_bundle.Cert
+	self.ConfigObj.Frontend.PrivateKey = frontend_bundle.PrivateKey
+
 	self.TestSuite.SetupTest()
 
 	key, err := crypto_utils.GeneratePrivateKey()
@@ -324,6 +341,36 @@ func (self *TestSuite) TestClientIDFromPublicKey() {
 	assert.True(t, strings.HasPrefix(client_id, "C."))
 }
 
+func (self *TestSuite) TestGenerateClientCert() {
+	t := self.T()
+
+	csr_pem, err := self.client_manager.GetCSR()
+	assert.NoError(t, err)
+
+	cert_pem, err := crypto.GenerateClientCert(self.ConfigObj,
+		self.client_id, &self.client_private_key.PublicKey, csr_pem)
+	assert.NoError(t, err)
+
+	cert, err := crypto_utils.ParseX509CertFromPemStr([]byte(cert_pem))
+	assert.NoError(t, err)
+	assert.Equal(t, self.client_id, cert.Subject.CommonName)
+
+	// The certificate must be usable with the client's key.
+	_, err = tls.X509KeyPair([]byte(cert_pem),
+		[]byte(self.ConfigObj.Writeback.PrivateKey))
+	assert.NoError(t, err)
+
+	// A client can not get a certificate for another client.
+	_, err = crypto.GenerateClientCert(self.ConfigObj,
+		"C.1234", &self.client_private_key.PublicKey, csr_pem)
+	assert.Error(t, err)
+
+	// The CSR must be for the key the client is enrolled with.
+	_, err = crypto.GenerateClientCert(self.ConfigObj,
+		self.client_id, &self.server_private_key.PublicKey, csr_pem)
+	assert.Error(t, err)
+}
+
 func TestMain(t *testing.T) {
 	suite.Run(t, new(TestSuite))
 }
@@ -4,12 +4,16 @@ import (
 	"context"
 
 	errors "github.com/go-errors/errors"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 	"www.velocidex.com/golang/velociraptor/utils"
 )
 
+// The field number of MessageList.job
+const messageListJobField = 1
+
 // Once a message is decoded the MessageInfo contains metadata about it.
 type MessageInfo struct {
 	// Communication version:
@@ -28,6 +32,9 @@ type MessageInfo struct {
 
 // Apply the callback on each job message. This saves memory since we
 // immediately use the decompressed buffer and not hold it around.
+// Jobs are decoded one at a time so only a single job (e.g. one
+// upload chunk) is held in memory at once, rather than the entire
+// message list.
 func (self *MessageInfo) IterateJobs(
 	ctx context.Context, config_obj *config_proto.Config,
 	processor func(ctx context.Context,
@@ -41,13 +48,8 @@ func (self *MessageInfo) IterateJobs(
 			raw = decompressed
 
 		}
-		message_list := &crypto_proto.MessageList{}
-		err := proto.Unmarshal(raw, message_list)
-		if err != nil {
-			return errors.Wrap(err, 0)
-		}
 
-		for _, job := range message_list.Job {
+		err := iterateMessageList(raw, func(job *crypto_proto.VeloMessage) {
 			if self.Authenticated {
 				job.AuthState = crypto_proto.VeloMessage_AUTHENTICATED
 			}
@@ -55,8 +57,48 @@ func (self *MessageInfo) IterateJobs(
 			job.OrgId = self.OrgId
 
 			rerr = processor(ctx, job)
+		})
+		if err != nil {
+			return errors.Wrap(err, 0)
 		}
 	}
 
 	return rerr
 }
+
+// Decode each job in a serialized MessageList in turn.
+func iterateMessageList(
+	raw []byte, cb func(job *crypto_proto.VeloMessage)) error {
+	for len(raw) > 0 {
+		num, typ, n := protowire.ConsumeTag(raw)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		raw = raw[n:]
+
+		// Skip unknown fields.
+		if num != messageListJobField || typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, raw)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			raw = raw[n:]
+			continue
+		}
+
+		serialized, n := protowire.ConsumeBytes(raw)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		raw = raw[n:]
+
+		job := &crypto_proto.VeloMessage{}
+		err := proto.Unmarshal(serialized, job)
+		if err != nil {
+			return err
+		}
+		cb(job)
+	}
+
+	return nil
+}
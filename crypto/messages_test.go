@@ -0,0 +1,60 @@
+package crypto
+
+import (
+	"context"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+func TestIterateJobs(t *testing.T) {
+	message_list := &crypto_proto.MessageList{}
+	for _, session_id := range []string{"F.1", "F.2", "F.3"} {
+		message_list.Job = append(message_list.Job, &crypto_proto.VeloMessage{
+			SessionId: session_id,
+			FileBuffer: &actions_proto.FileBuffer{
+				Data: []byte("data of " + session_id),
+			},
+		})
+	}
+
+	serialized, err := proto.Marshal(message_list)
+	assert.NoError(t, err)
+
+	compressed, err := utils.Compress(serialized)
+	assert.NoError(t, err)
+
+	message_info := &MessageInfo{
+		RawCompressed: [][]byte{compressed, compressed},
+		Authenticated: true,
+		Source:        "C.1234",
+		Compression:   crypto_proto.PackedMessageList_ZCOMPRESSION,
+	}
+
+	var seen []string
+	err = message_info.IterateJobs(context.Background(), &config_proto.Config{},
+		func(ctx context.Context, msg *crypto_proto.VeloMessage) error {
+			assert.Equal(t, "C.1234", msg.Source)
+			assert.Equal(t, crypto_proto.VeloMessage_AUTHENTICATED, msg.AuthState)
+			assert.Equal(t, "data of "+msg.SessionId, string(msg.FileBuffer.Data))
+			seen = append(seen, msg.SessionId)
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"F.1", "F.2", "F.3", "F.1", "F.2", "F.3"}, seen)
+
+	// A truncated message list is an error.
+	message_info = &MessageInfo{
+		RawCompressed: [][]byte{serialized[:len(serialized)-2]},
+	}
+	err = message_info.IterateJobs(context.Background(), &config_proto.Config{},
+		func(ctx context.Context, msg *crypto_proto.VeloMessage) error {
+			return nil
+		})
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// Clients that rotated their key no longer have a client id derived
+// from the key. They may only re-enrol with the key we already have
+// for them.
+func (self *ServerCryptoManager) isCurrentKey(
+	config_obj *config_proto.Config,
+	common_name string, public_key *rsa.PublicKey) bool {
+	existing, pres := self.Resolver.GetPublicKey(config_obj,
+		utils.ClientIdFromConfigObj(common_name, config_obj))
+	return pres && existing.Equal(public_key)
+}
+
+// The key the client is currently enrolled with.
+func GetPublicKey(
+	config_obj *config_proto.Config,
+	client_id string) (*rsa.PublicKey, error) {
+	client_path_manager := paths.NewClientPathManager(client_id)
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	pem := &crypto_proto.PublicKey{}
+	err = db.GetSubject(config_obj, client_path_manager.Key(), pem)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pem.Pem) == 0 {
+		return nil, fmt.Errorf("Client %v is not enrolled", client_id)
+	}
+
+	return crypto_utils.PemToPublicKey(pem.Pem)
+}
+
+// Store a new key for the client. The client keeps using its current
+// key until it receives our acknowledgement so the new key only
+// replaces the current key when we see it used.
+func SetPendingPublicKey(
+	config_obj *config_proto.Config,
+	client_id string, key *rsa.PublicKey) error {
+	client_path_manager := paths.NewClientPathManager(client_id)
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	pem := &crypto_proto.PublicKey{
+		Pem:        crypto_utils.PublicKeyToPem(key),
+		EnrollTime: uint64(time.Now().Unix()),
+	}
+	return db.SetSubjectWithCompletion(config_obj,
+		client_path_manager.PendingKey(), pem, nil)
+}
+
+func (self *serverPublicKeyResolver) GetPendingPublicKey(
+	config_obj *config_proto.Config,
+	client_id string) (*rsa.PublicKey, bool) {
+	client_path_manager := paths.NewClientPathManager(client_id)
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, false
+	}
+
+	pem := &crypto_proto.PublicKey{}
+	err = db.GetSubject(config_obj, client_path_manager.PendingKey(), pem)
+	if err != nil || len(pem.Pem) == 0 {
+		return nil, false
+	}
+
+	key, err := crypto_utils.PemToPublicKey(pem.Pem)
+	if err != nil {
+		return nil, false
+	}
+
+	return key, true
+}
+
+func (self *serverPublicKeyResolver) PromotePendingKey(
+	config_obj *config_proto.Config,
+	client_id string, key *rsa.PublicKey) error {
+	err := self.SetPublicKey(config_obj, client_id, key)
+	if err != nil {
+		return err
+	}
+
+	client_path_manager := paths.NewClientPathManager(client_id)
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	err = db.DeleteSubject(config_obj, client_path_manager.PendingKey())
+	if err != nil {
+		return err
+	}
+
+	// Let the other frontends know they need to flush their
+	// ciphers.
+	journal, err := services.GetJournal(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return journal.PushRowsToArtifact(config_obj,
+		[]*ordereddict.Dict{ordereddict.NewDict().
+			Set("ClientId", client_id)},
+		"Server.Internal.ClientKeyRotated", "server", "")
+}
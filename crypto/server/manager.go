@@ -53,7 +53,8 @@ func (self *ServerCryptoManager) AddCertificateRequest(
 	// corresponds with the public key this client presents. This
 	// avoids the possibility of impersonation since the
 	// public/private key pair is tied into the client id itself.
-	if common_name != crypto_utils.ClientIDFromPublicKey(public_key) {
+	if common_name != crypto_utils.ClientIDFromPublicKey(public_key) &&
+		!self.isCurrentKey(config_obj, common_name, public_key) {
 		return "", errors.New("Invalid CSR")
 	}
 	err = self.Resolver.SetPublicKey(
@@ -115,6 +116,20 @@ func NewServerCryptoManager(
 			return nil
 		})
 
+	// Cached ciphers are encrypted to the client's old key.
+	err = journal.WatchQueueWithCB(ctx, config_obj, wg,
+		"Server.Internal.ClientKeyRotated",
+		"CryptoServerManager",
+		func(ctx context.Context,
+			config_obj *config_proto.Config,
+			row *ordereddict.Dict) error {
+			client_id, pres := row.GetString("ClientId")
+			if pres {
+				server_manager.Delete(client_id)
+			}
+			return nil
+		})
+
 	return server_manager, nil
 }
 
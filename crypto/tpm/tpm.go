@@ -0,0 +1,62 @@
+// Keeps the client's private key in a TPM so it can not be copied
+// to another machine.
+package tpm
+
+import (
+	"crypto"
+	"errors"
+	"sync"
+
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+)
+
+var (
+	NotSupportedError = errors.New("TPM keys are not supported on this platform")
+	KeyNotFoundError  = errors.New("TPM key not found")
+
+	mu        sync.Mutex
+	open_keys = make(map[string]Key)
+)
+
+// An RSA private key held in the TPM. The key material never leaves
+// the TPM so callers can only sign and decrypt with it.
+type Key interface {
+	crypto.Signer
+	crypto.Decrypter
+
+	// The key name used to open the key again.
+	Name() string
+
+	// Describe the TPM holding the key for the server.
+	Attestation() (*crypto_proto.KeyAttestation, error)
+
+	// Remove the key from the TPM.
+	Delete() error
+}
+
+// Keys stay open for the life of the process so they are only opened
+// once.
+func OpenKey(name string) (Key, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	key, pres := open_keys[name]
+	if pres {
+		return key, nil
+	}
+
+	key, err := openKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	open_keys[name] = key
+	return key, nil
+}
+
+func forgetKey(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	delete(open_keys, name)
+}
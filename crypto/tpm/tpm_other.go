@@ -0,0 +1,11 @@
+// +build !windows
+
+package tpm
+
+func CreateKey(name string) (Key, error) {
+	return nil, NotSupportedError
+}
+
+func openKey(name string) (Key, error) {
+	return nil, NotSupportedError
+}
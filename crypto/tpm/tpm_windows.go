@@ -0,0 +1,425 @@
+// +build windows
+
+package tpm
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+)
+
+// The TPM is accessed through the Platform Crypto Provider (PCP) key
+// storage provider of the CNG API.
+const (
+	MS_PLATFORM_CRYPTO_PROVIDER = "Microsoft Platform Crypto Provider"
+
+	NCRYPT_MACHINE_KEY_FLAG = 0x20
+
+	NCRYPT_ALLOW_EXPORT_FLAG           = 0x1
+	NCRYPT_ALLOW_PLAINTEXT_EXPORT_FLAG = 0x2
+
+	NCRYPT_ALLOW_DECRYPT_FLAG = 0x1
+	NCRYPT_ALLOW_SIGNING_FLAG = 0x2
+
+	BCRYPT_PAD_PKCS1 = 0x2
+	BCRYPT_PAD_OAEP  = 0x4
+	BCRYPT_PAD_PSS   = 0x8
+
+	NTE_BAD_KEYSET = 0x80090016
+
+	rsaKeySize = 2048
+
+	// Size of the BCRYPT_RSAKEY_BLOB header (six DWORDs) preceding
+	// the big endian public exponent and modulus in an exported
+	// RSAPUBLICBLOB.
+	rsaBlobHeaderSize = 24
+)
+
+var (
+	ncrypt = windows.NewLazySystemDLL("ncrypt.dll")
+
+	procNCryptOpenStorageProvider = ncrypt.NewProc("NCryptOpenStorageProvider")
+	procNCryptCreatePersistedKey  = ncrypt.NewProc("NCryptCreatePersistedKey")
+	procNCryptOpenKey             = ncrypt.NewProc("NCryptOpenKey")
+	procNCryptSetProperty         = ncrypt.NewProc("NCryptSetProperty")
+	procNCryptGetProperty         = ncrypt.NewProc("NCryptGetProperty")
+	procNCryptFinalizeKey         = ncrypt.NewProc("NCryptFinalizeKey")
+	procNCryptExportKey           = ncrypt.NewProc("NCryptExportKey")
+	procNCryptSignHash            = ncrypt.NewProc("NCryptSignHash")
+	procNCryptDecrypt             = ncrypt.NewProc("NCryptDecrypt")
+	procNCryptDeleteKey           = ncrypt.NewProc("NCryptDeleteKey")
+	procNCryptFreeObject          = ncrypt.NewProc("NCryptFreeObject")
+)
+
+type BCRYPT_PKCS1_PADDING_INFO struct {
+	pszAlgId *uint16
+}
+
+type BCRYPT_PSS_PADDING_INFO struct {
+	pszAlgId *uint16
+	cbSalt   uint32
+}
+
+type BCRYPT_OAEP_PADDING_INFO struct {
+	pszAlgId *uint16
+	pbLabel  *byte
+	cbLabel  uint32
+}
+
+type windowsKey struct {
+	mu sync.Mutex
+
+	name     string
+	provider uintptr
+	handle   uintptr
+	public   *rsa.PublicKey
+}
+
+// Pointers converted to uintptr in calls to this function are kept
+// alive until it returns.
+//
+//go:uintptrescapes
+func callNCrypt(proc *windows.LazyProc, args ...uintptr) error {
+	err := proc.Find()
+	if err != nil {
+		return NotSupportedError
+	}
+
+	ret, _, _ := proc.Call(args...)
+	if ret != 0 {
+		if uint32(ret) == NTE_BAD_KEYSET {
+			return KeyNotFoundError
+		}
+		return fmt.Errorf("%v: 0x%08x", proc.Name, uint32(ret))
+	}
+	return nil
+}
+
+func utf16Ptr(s string) *uint16 {
+	result, _ := windows.UTF16PtrFromString(s)
+	return result
+}
+
+func openProvider() (uintptr, error) {
+	var provider uintptr
+	err := callNCrypt(procNCryptOpenStorageProvider,
+		uintptr(unsafe.Pointer(&provider)),
+		uintptr(unsafe.Pointer(utf16Ptr(MS_PLATFORM_CRYPTO_PROVIDER))), 0)
+	if err != nil {
+		// There is no TPM on this machine.
+		if err == KeyNotFoundError {
+			return 0, NotSupportedError
+		}
+		return 0, err
+	}
+	return provider, nil
+}
+
+func setDwordProperty(handle uintptr, name string, value uint32) error {
+	return callNCrypt(procNCryptSetProperty, handle,
+		uintptr(unsafe.Pointer(utf16Ptr(name))),
+		uintptr(unsafe.Pointer(&value)), 4, 0)
+}
+
+func getProperty(handle uintptr, name string) ([]byte, error) {
+	var size uint32
+	err := callNCrypt(procNCryptGetProperty, handle,
+		uintptr(unsafe.Pointer(utf16Ptr(name))), 0, 0,
+		uintptr(unsafe.Pointer(&size)), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, nil
+	}
+
+	buf := make([]byte, size)
+	err = callNCrypt(procNCryptGetProperty, handle,
+		uintptr(unsafe.Pointer(utf16Ptr(name))),
+		uintptr(unsafe.Pointer(&buf[0])), uintptr(size),
+		uintptr(unsafe.Pointer(&size)), 0)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:size], nil
+}
+
+// Create a new non-exportable RSA key in the TPM.
+func CreateKey(name string) (Key, error) {
+	provider, err := openProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	var handle uintptr
+	err = callNCrypt(procNCryptCreatePersistedKey, provider,
+		uintptr(unsafe.Pointer(&handle)),
+		uintptr(unsafe.Pointer(utf16Ptr("RSA"))),
+		uintptr(unsafe.Pointer(utf16Ptr(name))),
+		0, NCRYPT_MACHINE_KEY_FLAG)
+	if err != nil {
+		callNCrypt(procNCryptFreeObject, provider)
+		return nil, err
+	}
+
+	key := &windowsKey{name: name, provider: provider, handle: handle}
+
+	// The same key is used to sign our ciphers and decrypt the
+	// server's ciphers.
+	err = setDwordProperty(handle, "Length", rsaKeySize)
+	if err == nil {
+		err = setDwordProperty(handle, "Key Usage",
+			NCRYPT_ALLOW_DECRYPT_FLAG|NCRYPT_ALLOW_SIGNING_FLAG)
+	}
+
+	if err == nil {
+		err = callNCrypt(procNCryptFinalizeKey, handle, 0)
+	}
+
+	if err == nil {
+		err = key.loadPublicKey()
+	}
+
+	if err != nil {
+		key.Delete()
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func openKey(name string) (Key, error) {
+	provider, err := openProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	var handle uintptr
+	err = callNCrypt(procNCryptOpenKey, provider,
+		uintptr(unsafe.Pointer(&handle)),
+		uintptr(unsafe.Pointer(utf16Ptr(name))),
+		0, NCRYPT_MACHINE_KEY_FLAG)
+	if err != nil {
+		callNCrypt(procNCryptFreeObject, provider)
+		return nil, err
+	}
+
+	key := &windowsKey{name: name, provider: provider, handle: handle}
+	err = key.loadPublicKey()
+	if err != nil {
+		key.close()
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (self *windowsKey) loadPublicKey() error {
+	blob_type := uintptr(unsafe.Pointer(utf16Ptr("RSAPUBLICBLOB")))
+
+	var size uint32
+	err := callNCrypt(procNCryptExportKey, self.handle, 0, blob_type, 0,
+		0, 0, uintptr(unsafe.Pointer(&size)), 0)
+	if err != nil {
+		return err
+	}
+
+	blob := make([]byte, size)
+	err = callNCrypt(procNCryptExportKey, self.handle, 0, blob_type, 0,
+		uintptr(unsafe.Pointer(&blob[0])), uintptr(size),
+		uintptr(unsafe.Pointer(&size)), 0)
+	if err != nil {
+		return err
+	}
+
+	self.public, err = parseRSAPublicBlob(blob[:size])
+	return err
+}
+
+func parseRSAPublicBlob(blob []byte) (*rsa.PublicKey, error) {
+	if len(blob) < rsaBlobHeaderSize {
+		return nil, fmt.Errorf("Invalid RSA public key blob")
+	}
+
+	exp_size := binary.LittleEndian.Uint32(blob[8:])
+	mod_size := binary.LittleEndian.Uint32(blob[12:])
+
+	exp_end := rsaBlobHeaderSize + int(exp_size)
+	mod_end := exp_end + int(mod_size)
+	if len(blob) < mod_end {
+		return nil, fmt.Errorf("Invalid RSA public key blob")
+	}
+
+	return &rsa.PublicKey{
+		E: int(new(big.Int).SetBytes(blob[rsaBlobHeaderSize:exp_end]).Int64()),
+		N: new(big.Int).SetBytes(blob[exp_end:mod_end]),
+	}, nil
+}
+
+func (self *windowsKey) Name() string {
+	return self.name
+}
+
+func (self *windowsKey) Public() crypto.PublicKey {
+	return self.public
+}
+
+func hashAlgId(hash crypto.Hash) (*uint16, error) {
+	switch hash {
+	case crypto.SHA1:
+		return utf16Ptr("SHA1"), nil
+	case crypto.SHA256:
+		return utf16Ptr("SHA256"), nil
+	case crypto.SHA384:
+		return utf16Ptr("SHA384"), nil
+	case crypto.SHA512:
+		return utf16Ptr("SHA512"), nil
+	}
+	return nil, fmt.Errorf("Unsupported hash %v", hash)
+}
+
+// Sign the digest with PKCS1v15 or PSS padding.
+func (self *windowsKey) Sign(
+	rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	alg_id, err := hashAlgId(opts.HashFunc())
+	if err != nil {
+		return nil, err
+	}
+
+	var padding_info uintptr
+	var flags uintptr
+
+	pss_opts, ok := opts.(*rsa.PSSOptions)
+	if ok {
+		// TLS 1.3 client certificates use PSS with the salt length
+		// equal to the hash length.
+		salt_length := pss_opts.SaltLength
+		if salt_length <= 0 {
+			salt_length = opts.HashFunc().Size()
+		}
+		info := &BCRYPT_PSS_PADDING_INFO{
+			pszAlgId: alg_id,
+			cbSalt:   uint32(salt_length),
+		}
+		padding_info = uintptr(unsafe.Pointer(info))
+		flags = BCRYPT_PAD_PSS
+
+	} else {
+		info := &BCRYPT_PKCS1_PADDING_INFO{pszAlgId: alg_id}
+		padding_info = uintptr(unsafe.Pointer(info))
+		flags = BCRYPT_PAD_PKCS1
+	}
+
+	signature := make([]byte, self.public.Size())
+	var size uint32
+	err = callNCrypt(procNCryptSignHash, self.handle, padding_info,
+		uintptr(unsafe.Pointer(&digest[0])), uintptr(len(digest)),
+		uintptr(unsafe.Pointer(&signature[0])), uintptr(len(signature)),
+		uintptr(unsafe.Pointer(&size)), flags)
+	if err != nil {
+		return nil, err
+	}
+
+	return signature[:size], nil
+}
+
+// Decrypt an RSA OAEP encrypted message.
+func (self *windowsKey) Decrypt(
+	rand io.Reader, msg []byte, opts crypto.DecrypterOpts) ([]byte, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	oaep_opts, ok := opts.(*rsa.OAEPOptions)
+	if !ok {
+		return nil, fmt.Errorf("Only RSA OAEP decryption is supported")
+	}
+
+	alg_id, err := hashAlgId(oaep_opts.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &BCRYPT_OAEP_PADDING_INFO{pszAlgId: alg_id}
+	if len(oaep_opts.Label) > 0 {
+		info.pbLabel = &oaep_opts.Label[0]
+		info.cbLabel = uint32(len(oaep_opts.Label))
+	}
+
+	if len(msg) == 0 {
+		return nil, fmt.Errorf("Nothing to decrypt")
+	}
+
+	plain_text := make([]byte, self.public.Size())
+	var size uint32
+	err = callNCrypt(procNCryptDecrypt, self.handle,
+		uintptr(unsafe.Pointer(&msg[0])), uintptr(len(msg)),
+		uintptr(unsafe.Pointer(info)),
+		uintptr(unsafe.Pointer(&plain_text[0])), uintptr(len(plain_text)),
+		uintptr(unsafe.Pointer(&size)), BCRYPT_PAD_OAEP)
+	if err != nil {
+		return nil, err
+	}
+
+	return plain_text[:size], nil
+}
+
+func (self *windowsKey) Attestation() (*crypto_proto.KeyAttestation, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := &crypto_proto.KeyAttestation{
+		Provider: MS_PLATFORM_CRYPTO_PROVIDER,
+	}
+
+	platform_type, err := getProperty(self.provider, "PCP_PLATFORM_TYPE")
+	if err == nil && len(platform_type) >= 2 {
+		result.PlatformType = windows.UTF16ToString(unsafe.Slice(
+			(*uint16)(unsafe.Pointer(&platform_type[0])), len(platform_type)/2))
+	}
+
+	// Not all TPMs have an endorsement key certificate.
+	result.EkPublic, _ = getProperty(self.provider, "PCP_EKPUB")
+	result.EkCertificate, _ = getProperty(self.provider, "PCP_EKCERT")
+
+	export_policy, err := getProperty(self.handle, "Export Policy")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(export_policy) >= 4 {
+		policy := binary.LittleEndian.Uint32(export_policy)
+		result.NonExportable = policy&(NCRYPT_ALLOW_EXPORT_FLAG|
+			NCRYPT_ALLOW_PLAINTEXT_EXPORT_FLAG) == 0
+	}
+
+	return result, nil
+}
+
+// NCryptDeleteKey also frees the key handle.
+func (self *windowsKey) Delete() error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	forgetKey(self.name)
+
+	err := callNCrypt(procNCryptDeleteKey, self.handle, 0)
+	callNCrypt(procNCryptFreeObject, self.provider)
+	return err
+}
+
+func (self *windowsKey) close() {
+	callNCrypt(procNCryptFreeObject, self.handle)
+	callNCrypt(procNCryptFreeObject, self.provider)
+}
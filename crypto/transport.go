@@ -30,6 +30,11 @@ type ICryptoManager interface {
 	Decrypt(cipher_text []byte) (*MessageInfo, error)
 }
 
+// Implemented by crypto managers whose key may be held in a TPM.
+type IKeyAttestor interface {
+	GetKeyAttestation() (*crypto_proto.KeyAttestation, error)
+}
+
 type IClientCryptoManager interface {
 	ICryptoManager
 	AddCertificate(config_obj *config_proto.Config, certificate_pem []byte) (string, error)
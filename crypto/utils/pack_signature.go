@@ -0,0 +1,235 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/go-errors/errors"
+)
+
+// Artifact packs are zip files of artifact definitions. A signed pack
+// carries a PackSignatureFile member holding an RSA PKCS1v15
+// signature over a manifest of the SHA256 hashes of all the other
+// members, and the public key that made it. Any member that is
+// added, removed or modified after signing invalidates the
+// signature.
+
+const (
+	PackSignatureFile = "signature.json"
+)
+
+var (
+	InvalidPackSignatureError = errors.New("Invalid artifact pack signature")
+)
+
+type PackSignature struct {
+	// A name the signer chose for themselves. This is only
+	// informational - the key determines if the pack is trusted.
+	Signer    string `json:"signer,omitempty"`
+	PublicKey string `json:"public_key"`
+	Signature []byte `json:"signature"`
+}
+
+type PackVerification struct {
+	Signed bool
+
+	// Set when the pack is signed by one of the trusted keys.
+	Trusted bool
+
+	// The subject of the trusted certificate, or the name the
+	// signer chose.
+	Signer string
+}
+
+// Return a copy of the pack signed with the key. Any previous
+// signature is replaced.
+func SignArtifactPack(pack []byte, key *rsa.PrivateKey, signer string) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(pack), int64(len(pack)))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := packManifest(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(manifest)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	serialized, err := json.MarshalIndent(&PackSignature{
+		Signer:    signer,
+		PublicKey: string(PublicKeyToPem(&key.PublicKey)),
+		Signature: signature,
+	}, "", " ")
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for _, member := range reader.File {
+		if member.Name == PackSignatureFile {
+			continue
+		}
+
+		err = writer.Copy(member)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fd, err := writer.CreateHeader(&zip.FileHeader{
+		Name:     PackSignatureFile,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = fd.Write(serialized)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Verify the pack's signature. Unsigned packs are not an error but
+// a signature that does not match the pack is.
+func VerifyArtifactPack(pack []byte, trusted_keys []string) (*PackVerification, error) {
+	result := &PackVerification{}
+
+	reader, err := zip.NewReader(bytes.NewReader(pack), int64(len(pack)))
+	if err != nil {
+		return nil, err
+	}
+
+	var signature_member *zip.File
+	for _, member := range reader.File {
+		if member.Name == PackSignatureFile {
+			signature_member = member
+		}
+	}
+
+	if signature_member == nil {
+		return result, nil
+	}
+
+	fd, err := signature_member.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	signature := &PackSignature{}
+	err = json.NewDecoder(fd).Decode(signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidPackSignatureError, err)
+	}
+
+	public_key, err := PemToPublicKey([]byte(signature.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidPackSignatureError, err)
+	}
+
+	manifest, err := packManifest(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(manifest)
+	err = rsa.VerifyPKCS1v15(public_key, crypto.SHA256, hash[:],
+		signature.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: pack was modified after signing",
+			InvalidPackSignatureError)
+	}
+
+	result.Signed = true
+	result.Signer = signature.Signer
+	if result.Signer == "" {
+		result.Signer = KeyFingerprint(public_key)
+	}
+
+	for _, trusted_key := range trusted_keys {
+		key, err := parseSigningKey([]byte(trusted_key))
+		if err != nil {
+			return nil, err
+		}
+
+		if !key.Equal(public_key) {
+			continue
+		}
+
+		result.Trusted = true
+
+		// Prefer the name from the trusted certificate.
+		cert, err := ParseX509CertFromPemStr([]byte(trusted_key))
+		if err == nil {
+			result.Signer = GetSubjectName(cert)
+		}
+		break
+	}
+
+	return result, nil
+}
+
+// A short identifier for a public key.
+func KeyFingerprint(key *rsa.PublicKey) string {
+	hash := sha256.Sum256(x509.MarshalPKCS1PublicKey(key))
+	return hex.EncodeToString(hash[:8])
+}
+
+// The manifest lists the hash of every member except the signature,
+// sorted by name.
+func packManifest(reader *zip.Reader) ([]byte, error) {
+	lines := []string{}
+	for _, member := range reader.File {
+		if member.Name == PackSignatureFile {
+			continue
+		}
+
+		fd, err := member.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		hash := sha256.New()
+		_, err = io.Copy(hash, fd)
+		fd.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, fmt.Sprintf("%x  %s\n", hash.Sum(nil), member.Name))
+	}
+
+	sort.Strings(lines)
+
+	result := &bytes.Buffer{}
+	for _, line := range lines {
+		result.WriteString(line)
+	}
+	return result.Bytes(), nil
+}
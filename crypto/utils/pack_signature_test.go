@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makePack(t *testing.T, files map[string]string) []byte {
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for name, data := range files {
+		fd, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = fd.Write([]byte(data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func TestArtifactPackSignature(t *testing.T) {
+	cert_pem, key_pem := makeX509Recipient(t, "Artifact Author")
+	key, err := ParseRsaPrivateKeyFromPemStr([]byte(key_pem))
+	require.NoError(t, err)
+
+	pack := makePack(t, map[string]string{
+		"Custom.Test.yaml": "name: Custom.Test\n",
+	})
+
+	// Unsigned packs are reported as such.
+	result, err := VerifyArtifactPack(pack, []string{cert_pem})
+	require.NoError(t, err)
+	assert.False(t, result.Signed)
+
+	signed, err := SignArtifactPack(pack, key, "Someone")
+	require.NoError(t, err)
+
+	// Trusted certificates name the signer.
+	result, err = VerifyArtifactPack(signed, []string{cert_pem})
+	require.NoError(t, err)
+	assert.True(t, result.Signed)
+	assert.True(t, result.Trusted)
+	assert.Equal(t, "Artifact Author", result.Signer)
+
+	// Signatures by other keys are valid but not trusted.
+	result, err = VerifyArtifactPack(signed, nil)
+	require.NoError(t, err)
+	assert.True(t, result.Signed)
+	assert.False(t, result.Trusted)
+	assert.Equal(t, "Someone", result.Signer)
+
+	// Adding an artifact to a signed pack invalidates the signature.
+	reader, err := zip.NewReader(bytes.NewReader(signed), int64(len(signed)))
+	require.NoError(t, err)
+
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for _, member := range reader.File {
+		require.NoError(t, writer.Copy(member))
+	}
+	fd, err := writer.Create("Custom.Evil.yaml")
+	require.NoError(t, err)
+	_, err = fd.Write([]byte("name: Custom.Evil\n"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	_, err = VerifyArtifactPack(buffer.Bytes(), []string{cert_pem})
+	assert.True(t, errors.Is(err, InvalidPackSignatureError))
+}
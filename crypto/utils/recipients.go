@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"golang.org/x/crypto/openpgp"
+
+	// Keys without hash preferences default to RIPEMD160.
+	_ "golang.org/x/crypto/ripemd160"
+)
+
+// The offline collector protects its container with a random session
+// password. The password is wrapped separately for each recipient so
+// any one of the recipients' private keys can recover it:
+//
+// - X509 certificates wrap the password with RSA-OAEP.
+// - OpenPGP public keys wrap the password in a PGP message.
+
+const (
+	RECIPIENT_X509 = "X509"
+	RECIPIENT_PGP  = "PGP"
+)
+
+type KeyRecipient struct {
+	Scheme string `json:"Scheme"`
+
+	// A human readable name for the recipient (the certificate
+	// subject or the PGP identity).
+	Recipient string `json:"Recipient"`
+
+	// Serialized as base64 in the metadata file.
+	EncryptedPass []byte `json:"EncryptedPass"`
+}
+
+// Wrap the password for each of the public keys. Keys may be PEM
+// encoded X509 certificates or armored OpenPGP public keys.
+func EncryptForRecipients(
+	password []byte, public_keys []string) ([]*KeyRecipient, error) {
+	if len(public_keys) == 0 {
+		return nil, errors.New("At least one recipient public key is required")
+	}
+
+	result := make([]*KeyRecipient, 0, len(public_keys))
+	for idx, public_key := range public_keys {
+		recipient, err := encryptForRecipient(password, public_key)
+		if err != nil {
+			return nil, fmt.Errorf("Recipient %v: %w", idx, err)
+		}
+		result = append(result, recipient)
+	}
+
+	return result, nil
+}
+
+func encryptForRecipient(password []byte, public_key string) (*KeyRecipient, error) {
+	if isPGPKey(public_key) {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(public_key))
+		if err != nil {
+			return nil, err
+		}
+		if len(entities) == 0 {
+			return nil, errors.New("No PGP keys found")
+		}
+
+		var b bytes.Buffer
+		writer, err := openpgp.Encrypt(&b, entities[:1], nil, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = writer.Write(password)
+		if err != nil {
+			return nil, err
+		}
+
+		err = writer.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		return &KeyRecipient{
+			Scheme:        RECIPIENT_PGP,
+			Recipient:     pgpIdentity(entities[0]),
+			EncryptedPass: b.Bytes(),
+		}, nil
+	}
+
+	cert, err := ParseX509CertFromPemStr([]byte(public_key))
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := EncryptWithX509PubKey(password, cert)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyRecipient{
+		Scheme:        RECIPIENT_X509,
+		Recipient:     GetSubjectName(cert),
+		EncryptedPass: ciphertext,
+	}, nil
+}
+
+// Try to recover the password from any of the recipients using any
+// of the private keys. Private keys may be PEM encoded RSA keys or
+// armored (unprotected) OpenPGP secret keys.
+func DecryptForRecipients(
+	recipients []*KeyRecipient, private_keys []string) ([]byte, error) {
+	for _, private_key := range private_keys {
+		for _, recipient := range recipients {
+			password, err := decryptForRecipient(recipient, private_key)
+			if err == nil {
+				return password, nil
+			}
+		}
+	}
+
+	return nil, errors.New("No private key is able to decrypt the container password")
+}
+
+func decryptForRecipient(
+	recipient *KeyRecipient, private_key string) ([]byte, error) {
+	switch strings.ToUpper(recipient.Scheme) {
+	case RECIPIENT_PGP:
+		if !isPGPKey(private_key) {
+			return nil, errors.New("Not a PGP key")
+		}
+
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(private_key))
+		if err != nil {
+			return nil, err
+		}
+
+		message, err := openpgp.ReadMessage(
+			bytes.NewReader(recipient.EncryptedPass), entities, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return ioutil.ReadAll(message.UnverifiedBody)
+
+	case RECIPIENT_X509:
+		if isPGPKey(private_key) {
+			return nil, errors.New("Not an RSA key")
+		}
+
+		key, err := ParseRsaPrivateKeyFromPemStr([]byte(private_key))
+		if err != nil {
+			return nil, err
+		}
+
+		return DecryptRSAOAEP(key, recipient.EncryptedPass)
+	}
+
+	return nil, errors.New("Unsupported recipient scheme " + recipient.Scheme)
+}
+
+func isPGPKey(key string) bool {
+	return strings.Contains(key, "-----BEGIN PGP ")
+}
+
+func pgpIdentity(entity *openpgp.Entity) string {
+	names := make([]string, 0, len(entity.Identities))
+	for name := range entity.Identities {
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return entity.PrimaryKey.KeyIdString()
+	}
+
+	sort.Strings(names)
+	return names[0]
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func makeX509Recipient(t *testing.T, name string) (cert_pem, key_pem string) {
+	private_pem, err := GeneratePrivateKey()
+	require.NoError(t, err)
+
+	key, err := ParseRsaPrivateKeyFromPemStr(private_pem)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(
+		rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type: "CERTIFICATE", Bytes: der})), string(private_pem)
+}
+
+func makePGPRecipient(t *testing.T, name string) (public_key, private_key string) {
+	entity, err := openpgp.NewEntity(name, "", name+"@example.com", nil)
+	require.NoError(t, err)
+
+	public := &bytes.Buffer{}
+	w, err := armor.Encode(public, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	w.Close()
+
+	private := &bytes.Buffer{}
+	w, err = armor.Encode(private, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivate(w, nil))
+	w.Close()
+
+	return public.String(), private.String()
+}
+
+func TestEncryptForRecipients(t *testing.T) {
+	x509_cert, x509_key := makeX509Recipient(t, "Responder1")
+	pgp_public, pgp_private := makePGPRecipient(t, "Responder2")
+	_, other_key := makeX509Recipient(t, "Other")
+
+	password := []byte("SessionPassword")
+	recipients, err := EncryptForRecipients(
+		password, []string{x509_cert, pgp_public})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(recipients))
+
+	assert.Equal(t, RECIPIENT_X509, recipients[0].Scheme)
+	assert.Equal(t, "Responder1", recipients[0].Recipient)
+	assert.Equal(t, RECIPIENT_PGP, recipients[1].Scheme)
+	assert.Contains(t, recipients[1].Recipient, "Responder2")
+
+	// Either recipient can recover the password.
+	for _, private_key := range []string{x509_key, pgp_private} {
+		decrypted, err := DecryptForRecipients(recipients, []string{private_key})
+		assert.NoError(t, err)
+		assert.Equal(t, password, decrypted)
+	}
+
+	// Other keys can not.
+	_, err = DecryptForRecipients(recipients, []string{other_key})
+	assert.Error(t, err)
+
+	// Invalid public keys are rejected.
+	_, err = EncryptForRecipients(password, []string{"hello"})
+	assert.Error(t, err)
+
+	_, err = EncryptForRecipients(password, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+
+	"github.com/go-errors/errors"
+)
+
+// Client binaries distributed for self upgrade carry an embedded
+// signature appended to the end of the file:
+//
+//   binary | RSA PKCS1v15 signature of SHA256(binary) |
+//   4 bytes signature length (little endian) | BinarySignatureMagic
+//
+// Executables remain valid with the trailer appended.
+
+const (
+	BinarySignatureMagic = "VELOSIG1"
+)
+
+// Append a signature trailer to data.
+func SignBinary(data []byte, key *rsa.PrivateKey) ([]byte, error) {
+	hash := sha256.Sum256(data)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	length := make([]byte, 4)
+	binary.LittleEndian.PutUint32(length, uint32(len(signature)))
+
+	result := make([]byte, 0, len(data)+len(signature)+4+len(BinarySignatureMagic))
+	result = append(result, data...)
+	result = append(result, signature...)
+	result = append(result, length...)
+	result = append(result, BinarySignatureMagic...)
+
+	return result, nil
+}
+
+// Verify the signature trailer against any of the trusted public
+// keys and return the binary without the trailer.
+func VerifyBinarySignature(data []byte, trusted_keys []string) ([]byte, error) {
+	trailer_len := 4 + len(BinarySignatureMagic)
+	if len(data) < trailer_len ||
+		!bytes.Equal(data[len(data)-len(BinarySignatureMagic):],
+			[]byte(BinarySignatureMagic)) {
+		return nil, errors.New("Binary is not signed")
+	}
+
+	signature_len := int(binary.LittleEndian.Uint32(
+		data[len(data)-trailer_len:]))
+	if signature_len <= 0 || signature_len > len(data)-trailer_len {
+		return nil, errors.New("Invalid binary signature")
+	}
+
+	end := len(data) - trailer_len
+	signature := data[end-signature_len : end]
+	payload := data[:end-signature_len]
+	hash := sha256.Sum256(payload)
+
+	for _, trusted_key := range trusted_keys {
+		public_key, err := parseSigningKey([]byte(trusted_key))
+		if err != nil {
+			return nil, err
+		}
+
+		err = rsa.VerifyPKCS1v15(public_key, crypto.SHA256, hash[:], signature)
+		if err == nil {
+			return payload, nil
+		}
+	}
+
+	return nil, errors.New("Binary signature does not match any trusted key")
+}
+
+// Signing keys may be given as a public key or a certificate in PEM
+// encoding.
+func parseSigningKey(pem_str []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(pem_str)
+	if block == nil {
+		return nil, errors.New("Unable to parse signing key")
+	}
+
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		rsa_key, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("Signing key must be an RSA key")
+		}
+		return rsa_key, nil
+
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		rsa_key, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("Signing key must be an RSA key")
+		}
+		return rsa_key, nil
+	}
+
+	return nil, errors.New("Unsupported signing key type " + block.Type)
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinarySignature(t *testing.T) {
+	pem, err := GeneratePrivateKey()
+	require.NoError(t, err)
+
+	key, err := ParseRsaPrivateKeyFromPemStr(pem)
+	require.NoError(t, err)
+
+	other_pem, err := GeneratePrivateKey()
+	require.NoError(t, err)
+
+	other_key, err := ParseRsaPrivateKeyFromPemStr(other_pem)
+	require.NoError(t, err)
+
+	binary := []byte("MZ This is a binary")
+	signed, err := SignBinary(binary, key)
+	require.NoError(t, err)
+
+	trusted := []string{
+		string(PublicKeyToPem(&other_key.PublicKey)),
+		string(PublicKeyToPem(&key.PublicKey)),
+	}
+
+	payload, err := VerifyBinarySignature(signed, trusted)
+	assert.NoError(t, err)
+	assert.Equal(t, binary, payload)
+
+	// Only trusted keys are accepted.
+	_, err = VerifyBinarySignature(signed, trusted[:1])
+	assert.Error(t, err)
+
+	// Tampering with the binary invalidates the signature.
+	signed[3] = 'X'
+	_, err = VerifyBinarySignature(signed, trusted)
+	assert.Error(t, err)
+
+	_, err = VerifyBinarySignature(binary, trusted)
+	assert.Error(t, err)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/go-errors/errors"
 	"www.velocidex.com/golang/velociraptor/config"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/crypto/tpm"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 	"www.velocidex.com/golang/vfilter"
 )
@@ -216,21 +217,24 @@ func VerifyConfig(config_obj *config_proto.Config) error {
 		writeback = &config_proto.Writeback{}
 	}
 
-	if writeback.PrivateKey == "" {
-		fmt.Println("Generating new private key....")
-		pem, err := GeneratePrivateKey()
-		if err != nil {
-			return errors.Wrap(err, 0)
+	// A writeback copied from another machine refers to a TPM key
+	// this machine does not have. Start a new client identity rather
+	// than clone the other client.
+	if writeback.HardwareKeyName != "" {
+		_, err := tpm.OpenKey(writeback.HardwareKeyName)
+		if err == tpm.KeyNotFoundError {
+			fmt.Println("TPM key not found - generating a new client identity")
+			writeback.HardwareKeyName = ""
 		}
+	}
 
-		private_key, err := ParseRsaPrivateKeyFromPemStr(pem)
+	if writeback.PrivateKey == "" && writeback.HardwareKeyName == "" {
+		fmt.Println("Generating new private key....")
+		err := GenerateWritebackKey(config_obj.Client, writeback)
 		if err != nil {
 			return errors.Wrap(err, 0)
 		}
 
-		// Add a client id for information here
-		writeback.ClientId = ClientIDFromPublicKey(&private_key.PublicKey)
-		writeback.PrivateKey = string(pem)
 		err = config.UpdateWriteback(config_obj.Client, writeback)
 		if err != nil {
 			return fmt.Errorf("During UpdateWriteback: %w", err)
@@ -239,13 +243,14 @@ func VerifyConfig(config_obj *config_proto.Config) error {
 
 	// Make sure the client if is set in the writeback.
 	if writeback.ClientId == "" {
-		private_key, err := ParseRsaPrivateKeyFromPemStr([]byte(writeback.PrivateKey))
+		private_key, err := GetWritebackPrivateKey(writeback)
 		if err != nil {
 			return errors.Wrap(err, 0)
 		}
 
 		// Add a client id for information here
-		writeback.ClientId = ClientIDFromPublicKey(&private_key.PublicKey)
+		writeback.ClientId = ClientIDFromPublicKey(
+			private_key.Public().(*rsa.PublicKey))
 		err = config.UpdateWriteback(config_obj.Client, writeback)
 		if err != nil {
 			return fmt.Errorf("During UpdateWriteback: %w", err)
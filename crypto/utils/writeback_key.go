@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/crypto/tpm"
+)
+
+// The client's private key. It is either stored in the writeback or
+// held in the TPM (Client.use_tpm).
+type ClientPrivateKey interface {
+	crypto.Signer
+	crypto.Decrypter
+}
+
+func GetWritebackPrivateKey(
+	writeback *config_proto.Writeback) (ClientPrivateKey, error) {
+	if writeback.HardwareKeyName != "" {
+		return tpm.OpenKey(writeback.HardwareKeyName)
+	}
+
+	return ParseRsaPrivateKeyFromPemStr([]byte(writeback.PrivateKey))
+}
+
+// Create a new client identity in the writeback. The key is created
+// in the TPM if configured and available, otherwise it is stored in
+// the writeback.
+func GenerateWritebackKey(
+	config_obj *config_proto.ClientConfig,
+	writeback *config_proto.Writeback) error {
+	var public_key *rsa.PublicKey
+
+	writeback.PrivateKey = ""
+	writeback.HardwareKeyName = ""
+
+	if config_obj.UseTpm {
+		suffix := make([]byte, 8)
+		_, _ = rand.Read(suffix)
+
+		key, err := tpm.CreateKey(fmt.Sprintf("Velociraptor-%x", suffix))
+		if err == nil {
+			writeback.HardwareKeyName = key.Name()
+			public_key = key.Public().(*rsa.PublicKey)
+		} else {
+			fmt.Printf("Unable to create TPM key, the key will be "+
+				"stored in the writeback: %v\n", err)
+		}
+	}
+
+	if public_key == nil {
+		pem, err := GeneratePrivateKey()
+		if err != nil {
+			return err
+		}
+
+		private_key, err := ParseRsaPrivateKeyFromPemStr(pem)
+		if err != nil {
+			return err
+		}
+
+		writeback.PrivateKey = string(pem)
+		public_key = &private_key.PublicKey
+	}
+
+	// A new key means a new client id so any key rotation state is
+	// reset.
+	writeback.ClientId = ClientIDFromPublicKey(public_key)
+	writeback.KeyCreated = uint64(time.Now().Unix())
+	writeback.KeyRotated = false
+	writeback.PendingPrivateKey = ""
+	writeback.ClientCertificate = ""
+
+	return nil
+}
+
+// Build the TLS client certificate for the writeback key.
+func GetWritebackTLSCertificate(
+	writeback *config_proto.Writeback, cert_pem []byte) (*tls.Certificate, error) {
+	if writeback.HardwareKeyName == "" {
+		cert, err := tls.X509KeyPair(cert_pem, []byte(writeback.PrivateKey))
+		if err != nil {
+			return nil, err
+		}
+		return &cert, nil
+	}
+
+	key, err := tpm.OpenKey(writeback.HardwareKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(cert_pem)
+	if block == nil {
+		return nil, errors.New("Unable to parse client certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	if !key.Public().(*rsa.PublicKey).Equal(cert.PublicKey) {
+		return nil, errors.New("Client certificate does not match private key")
+	}
+
+	return &tls.Certificate{
+		Certificate: [][]byte{block.Bytes},
+		PrivateKey:  key,
+		Leaf:        cert,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"crypto/rsa"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+func TestGenerateWritebackKey(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Would create a key in the TPM")
+	}
+
+	// Without a TPM we fall back to storing the key in the
+	// writeback.
+	writeback := &config_proto.Writeback{
+		ClientId:          "C.1234",
+		KeyRotated:        true,
+		PendingPrivateKey: "pending",
+	}
+	err := GenerateWritebackKey(
+		&config_proto.ClientConfig{UseTpm: true}, writeback)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "", writeback.HardwareKeyName)
+	assert.NotEqual(t, "", writeback.PrivateKey)
+	assert.False(t, writeback.KeyRotated)
+	assert.Equal(t, "", writeback.PendingPrivateKey)
+
+	private_key, err := GetWritebackPrivateKey(writeback)
+	assert.NoError(t, err)
+	assert.Equal(t, writeback.ClientId,
+		ClientIDFromPublicKey(private_key.Public().(*rsa.PublicKey)))
+}
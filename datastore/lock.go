@@ -0,0 +1,191 @@
+package datastore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+)
+
+const (
+	// A lock file older than this belongs to a frontend which died
+	// while holding it and may be broken.
+	LOCK_STALE_AGE = 30 * time.Second
+
+	LOCK_TIMEOUT       = 10 * time.Second
+	LOCK_POLL_INTERVAL = 10 * time.Millisecond
+)
+
+// Datastores which may be shared by several frontends implement
+// this to serialize read-modify-write cycles across all of them.
+type SubjectLocker interface {
+	// Blocks until the lock is held and returns a function to
+	// release it.
+	LockSubject(config_obj *config_proto.Config,
+		urn api.DSPathSpec) (func(), error)
+}
+
+type subjectLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+var (
+	subject_locks_mu sync.Mutex
+	subject_locks    = make(map[string]*subjectLock)
+)
+
+// Serialize goroutines in this process. The lock is removed when the
+// last holder releases it.
+func lockInProcess(key string) func() {
+	subject_locks_mu.Lock()
+	lock, pres := subject_locks[key]
+	if !pres {
+		lock = &subjectLock{}
+		subject_locks[key] = lock
+	}
+	lock.refs++
+	subject_locks_mu.Unlock()
+
+	lock.mu.Lock()
+
+	return func() {
+		lock.mu.Unlock()
+
+		subject_locks_mu.Lock()
+		defer subject_locks_mu.Unlock()
+
+		lock.refs--
+		if lock.refs == 0 {
+			delete(subject_locks, key)
+		}
+	}
+}
+
+// Lock the subject for a read-modify-write cycle. Datastores which
+// are not shared between processes are only locked in this process.
+func LockSubject(config_obj *config_proto.Config,
+	urn api.DSPathSpec) (func(), error) {
+	db, err := GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := lockInProcess(config_obj.OrgId + urn.AsClientPath())
+
+	locker, ok := db.(SubjectLocker)
+	if !ok {
+		return unlock, nil
+	}
+
+	unlock_subject, err := locker.LockSubject(config_obj, urn)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+
+	return func() {
+		unlock_subject()
+		unlock()
+	}, nil
+}
+
+// Frontends sharing the datastore directory lock a subject by
+// exclusively creating a lock file next to it.
+func lockSubjectFile(config_obj *config_proto.Config,
+	urn api.DSPathSpec) (func(), error) {
+	if config_obj.Datastore == nil {
+		return nil, datastoreNotConfiguredError
+	}
+
+	filename := urn.AsDatastoreFilename(config_obj) + ".lock"
+	deadline := time.Now().Add(LOCK_TIMEOUT)
+
+	for {
+		fd, err := os.OpenFile(filename,
+			os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0660)
+		if err == nil {
+			fd.Close()
+			return heartbeat(filename), nil
+		}
+
+		switch {
+		case os.IsNotExist(err):
+			// Try to create intermediate directories and try again.
+			err = os.MkdirAll(filepath.Dir(filename), 0700)
+			if err != nil {
+				return nil, err
+			}
+			continue
+
+		case os.IsExist(err):
+			stat, err := os.Stat(filename)
+			if err == nil && time.Since(stat.ModTime()) > LOCK_STALE_AGE {
+				_ = os.Remove(filename)
+				continue
+			}
+
+		default:
+			return nil, err
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("Timed out waiting for lock on %v",
+				urn.AsClientPath())
+		}
+		time.Sleep(LOCK_POLL_INTERVAL)
+	}
+}
+
+// Keep touching the lock file while it is held so other frontends
+// do not consider it stale.
+func heartbeat(filename string) func() {
+	done := make(chan bool)
+
+	go func() {
+		ticker := time.NewTicker(LOCK_STALE_AGE / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				now := time.Now()
+				_ = os.Chtimes(filename, now, now)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		_ = os.Remove(filename)
+	}
+}
+
+func (self *FileBaseDataStore) LockSubject(
+	config_obj *config_proto.Config,
+	urn api.DSPathSpec) (func(), error) {
+	return lockSubjectFile(config_obj, urn)
+}
+
+// Another frontend may have changed the subject since we cached it
+// so drop it from the cache while we hold the lock.
+func (self *MemcacheFileDataStore) LockSubject(
+	config_obj *config_proto.Config,
+	urn api.DSPathSpec) (func(), error) {
+	unlock, err := lockSubjectFile(config_obj, urn)
+	if err != nil {
+		return nil, err
+	}
+
+	self.mu.Lock()
+	_ = self.cache.data_cache.Remove(urn.AsDatastoreFilename(config_obj))
+	self.mu.Unlock()
+
+	return unlock, nil
+}
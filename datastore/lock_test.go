@@ -0,0 +1,146 @@
+package datastore
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/path_specs"
+)
+
+type LockTestSuite struct {
+	suite.Suite
+	config_obj *config_proto.Config
+	dirname    string
+}
+
+func (self *LockTestSuite) SetupTest() {
+	var err error
+	self.dirname, err = os.MkdirTemp("", "datastore_lock_test")
+	assert.NoError(self.T(), err)
+
+	self.config_obj = config.GetDefaultConfig()
+	self.config_obj.Datastore.Location = self.dirname
+	self.config_obj.Datastore.FilestoreDirectory = self.dirname
+
+	OverrideDatastoreImplementation(&FileBaseDataStore{})
+}
+
+func (self *LockTestSuite) TearDownTest() {
+	Reset()
+	os.RemoveAll(self.dirname)
+}
+
+func (self *LockTestSuite) TestLockFile() {
+	urn := path_specs.NewSafeDatastorePath("a", "b")
+
+	unlock, err := lockSubjectFile(self.config_obj, urn)
+	assert.NoError(self.T(), err)
+
+	// Another frontend has to wait for the lock.
+	locked := make(chan bool)
+	go func() {
+		unlock, err := lockSubjectFile(self.config_obj, urn)
+		assert.NoError(self.T(), err)
+		unlock()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		self.T().Fatalf("Lock acquired while held")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+	<-locked
+
+	// The lock file is removed once released.
+	_, err = os.Stat(urn.AsDatastoreFilename(self.config_obj) + ".lock")
+	assert.True(self.T(), os.IsNotExist(err))
+}
+
+func (self *LockTestSuite) TestStaleLock() {
+	urn := path_specs.NewSafeDatastorePath("a", "b")
+
+	// A frontend died while holding the lock.
+	unlock, err := lockSubjectFile(self.config_obj, urn)
+	assert.NoError(self.T(), err)
+	defer unlock()
+
+	stale := time.Now().Add(-2 * LOCK_STALE_AGE)
+	assert.NoError(self.T(), os.Chtimes(
+		urn.AsDatastoreFilename(self.config_obj)+".lock", stale, stale))
+
+	unlock2, err := lockSubjectFile(self.config_obj, urn)
+	assert.NoError(self.T(), err)
+	unlock2()
+}
+
+func (self *LockTestSuite) TestModifySubject() {
+	urn := path_specs.NewSafeDatastorePath("a", "b")
+
+	message := &crypto_proto.VeloMessage{}
+	err := GetExistingSubject(self.config_obj, urn, message)
+	assert.True(self.T(), errors.Is(err, os.ErrNotExist))
+
+	// Concurrent updates are not lost.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			message := &crypto_proto.VeloMessage{}
+			err := ModifySubject(self.config_obj, urn, message,
+				func(exists bool) error {
+					if !exists {
+						message.SessionId = "F.1234"
+					}
+					message.RequestId++
+					return nil
+				})
+			assert.NoError(self.T(), err)
+		}()
+	}
+	wg.Wait()
+
+	err = GetExistingSubject(self.config_obj, urn, message)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "F.1234", message.SessionId)
+	assert.Equal(self.T(), uint64(10), message.RequestId)
+
+	// Errors from the callback abort the update.
+	err = ModifySubject(self.config_obj, urn, message,
+		func(exists bool) error {
+			message.RequestId = 0
+			return os.ErrPermission
+		})
+	assert.True(self.T(), errors.Is(err, os.ErrPermission))
+
+	err = GetExistingSubject(self.config_obj, urn, message)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), uint64(10), message.RequestId)
+
+	// Deleting returns the deleted subject.
+	deleted := &crypto_proto.VeloMessage{}
+	assert.NoError(self.T(), DeleteSubject(self.config_obj, urn, deleted))
+	assert.Equal(self.T(), "F.1234", deleted.SessionId)
+
+	err = DeleteSubject(self.config_obj, urn, deleted)
+	assert.True(self.T(), errors.Is(err, os.ErrNotExist))
+
+	// Unless we do not care if it existed.
+	assert.NoError(self.T(), DeleteSubject(self.config_obj, urn, nil))
+}
+
+func TestLocks(t *testing.T) {
+	suite.Run(t, &LockTestSuite{})
+}
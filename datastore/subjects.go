@@ -0,0 +1,134 @@
+package datastore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+)
+
+// Helpers for services which keep one record per subject.
+
+// Reads an existing subject. Missing subjects are reported as
+// os.ErrNotExist - some datastores return an empty object for them
+// instead.
+func GetExistingSubject(config_obj *config_proto.Config,
+	urn api.DSPathSpec, message proto.Message) error {
+	db, err := GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	err = db.GetSubject(config_obj, urn, message)
+	if err != nil {
+		return err
+	}
+
+	if proto.Size(message) == 0 {
+		return fmt.Errorf("While opening %v: %w",
+			urn.AsClientPath(), os.ErrNotExist)
+	}
+
+	return nil
+}
+
+// Reads the subject, calls cb to update it and writes it back while
+// holding the subject lock. message is empty and exists is false if
+// the subject was never written. If cb returns an error the subject
+// is not written.
+func ModifySubject(config_obj *config_proto.Config,
+	urn api.DSPathSpec, message proto.Message,
+	cb func(exists bool) error) error {
+	unlock, err := LockSubject(config_obj, urn)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	exists := true
+	err = GetExistingSubject(config_obj, urn, message)
+	if errors.Is(err, os.ErrNotExist) {
+		proto.Reset(message)
+		exists = false
+
+	} else if err != nil {
+		return err
+	}
+
+	err = cb(exists)
+	if err != nil {
+		return err
+	}
+
+	db, err := GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.SetSubject(config_obj, urn, message)
+}
+
+// Deletes the subject while holding the subject lock. If message is
+// not nil the subject must exist - it is read into message and
+// os.ErrNotExist is returned if it was never written.
+func DeleteSubject(config_obj *config_proto.Config,
+	urn api.DSPathSpec, message proto.Message) error {
+	unlock, err := LockSubject(config_obj, urn)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if message != nil {
+		err = GetExistingSubject(config_obj, urn, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	db, err := GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	// Wait for the delete to hit the disk before we release the
+	// lock. Retrying datastores may complete more than once.
+	done := make(chan bool)
+	var once sync.Once
+	err = db.DeleteSubjectWithCompletion(config_obj, urn, func() {
+		once.Do(func() { close(done) })
+	})
+	if err != nil {
+		return err
+	}
+	<-done
+
+	return nil
+}
+
+// Lists the subjects directly below urn.
+func ListSubjects(config_obj *config_proto.Config,
+	urn api.DSPathSpec) ([]api.DSPathSpec, error) {
+	db, err := GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := db.ListChildren(config_obj, urn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]api.DSPathSpec, 0, len(children))
+	for _, child := range children {
+		if !child.IsDir() {
+			result = append(result, child)
+		}
+	}
+
+	return result, nil
+}
@@ -102,8 +102,7 @@ func (self *ClientExecutor) processRequestPlugin(
 		r := recover()
 		if r != nil {
 			logger := logging.GetLogger(config_obj, &logging.ClientComponent)
-			logger.Error(fmt.Sprintf("Panic %v: %v",
-				r, string(debug.Stack())))
+			logger.Error("Panic %v: %v", r, string(debug.Stack()))
 		}
 	}()
 
@@ -147,6 +146,20 @@ func (self *ClientExecutor) processRequestPlugin(
 		return
 	}
 
+	if req.KeyRotation != nil {
+		self.processKeyRotation(config_obj, req.KeyRotation)
+		return
+	}
+
+	if req.ClientCertificate != nil {
+		err := actions.StoreClientCertificate(config_obj, req.ClientCertificate)
+		if err != nil {
+			logger := logging.GetLogger(config_obj, &logging.ClientComponent)
+			logger.Error("Unable to store client certificate: %v", err)
+		}
+		return
+	}
+
 	responder.MakeErrorResponse(self.Outbound,
 		req.SessionId, fmt.Sprintf(
 			"Unsupported payload for message: %v", json.MustMarshalString(req)))
@@ -217,3 +230,40 @@ func NewClientExecutor(
 
 	return result, nil
 }
+
+// The server either asks us to rotate our key, acknowledges our new
+// key or hands us the prior client id of this machine.
+func (self *ClientExecutor) processKeyRotation(
+	config_obj *config_proto.Config,
+	rotation *crypto_proto.KeyRotation) {
+	logger := logging.GetLogger(config_obj, &logging.ClientComponent)
+
+	if rotation.ClientId != "" {
+		err := actions.AdoptClientId(config_obj, rotation)
+		if err != nil {
+			logger.Error("Unable to take over client id: %v", err)
+			return
+		}
+		logger.Info("Taking over prior client id %v", rotation.ClientId)
+		return
+	}
+
+	if rotation.KeyId != "" {
+		err := actions.CompleteKeyRotation(config_obj, rotation)
+		if err != nil {
+			logger.Error("Unable to complete key rotation: %v", err)
+			return
+		}
+		logger.Info("Switched to rotated key %v", rotation.KeyId)
+		return
+	}
+
+	if rotation.Request {
+		msg, err := actions.StartKeyRotation(config_obj)
+		if err != nil {
+			logger.Error("Unable to rotate key: %v", err)
+			return
+		}
+		self.SendToServer(msg)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 	"www.velocidex.com/golang/velociraptor/responder"
+	"www.velocidex.com/golang/velociraptor/tracing"
 )
 
 // New servers issue a FlowRequest message forcing the client to
@@ -15,6 +16,12 @@ func (self *ClientExecutor) ProcessFlowRequest(
 	ctx context.Context,
 	config_obj *config_proto.Config, req *crypto_proto.VeloMessage) {
 
+	ctx, span := tracing.StartSpanWithParent(ctx,
+		"executor.ProcessFlowRequest", req.TraceParent)
+	defer span.End()
+	span.SetAttribute("flow_id", req.SessionId)
+	span.SetAttribute("queries", len(req.FlowRequest.VQLClientActions))
+
 	flow_manager := responder.GetFlowManager(ctx, config_obj)
 	flow_context := flow_manager.FlowContext(self.Outbound, req)
 	defer flow_context.Close()
@@ -22,8 +29,12 @@ func (self *ClientExecutor) ProcessFlowRequest(
 	// Control concurrency for the entire collection at once. If a
 	// collection has many queries, they all run concurrently.
 	if !req.Urgent {
+		_, wait_span := tracing.StartSpan(ctx, "executor.WaitForConcurrency")
 		cancel, err := self.concurrency.StartConcurrencyControl(ctx)
+		wait_span.SetError(err)
+		wait_span.End()
 		if err != nil {
+			span.SetError(err)
 			responder.MakeErrorResponse(
 				self.Outbound, req.SessionId, err.Error())
 			return
@@ -38,7 +49,10 @@ func (self *ClientExecutor) ProcessFlowRequest(
 		sub_ctx, responder_obj := flow_context.NewResponder(arg)
 		defer responder_obj.Close()
 
+		_, query_span := tracing.StartSpan(ctx, "executor.StartQuery")
+		query_span.SetAttribute("query", actions.GetQueryName(arg.Query))
 		actions.VQLClientAction{}.StartQuery(
 			config_obj, sub_ctx, responder_obj, arg)
+		query_span.End()
 	}
 }
@@ -0,0 +1,347 @@
+/*
+   The simulated executor pretends to be an enrolled client for load
+   testing the server (see the pool_client command).
+
+   Unlike the real ClientExecutor it never runs any VQL. Collections
+   are answered with synthetic rows for every query and client event
+   artifacts produce synthetic events at a configurable rate. This
+   keeps the cost of each simulated client low enough to run tens of
+   thousands of them from a single process, so the load is dominated
+   by the comms and the server's processing of the results.
+*/
+
+package executor
+
+import (
+	"context"
+	"encoding/hex"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	simulatedEvents      uint64
+	simulatedCollections uint64
+)
+
+type SimulatorOptions struct {
+	// Average number of events each client sends per minute. Events
+	// are spread randomly over time rather than sent in lock step.
+	EventsPerMinute float64
+
+	// Send events for these artifacts instead of the artifacts in
+	// the client event table sent by the server.
+	EventArtifacts []string
+
+	// Number of rows returned for each query in a collection.
+	RowsPerQuery int
+
+	// Size of the padding added to each row.
+	RowSize int
+
+	// Collections complete after a random delay up to this long.
+	ResponseDelay time.Duration
+
+	// Fraction of collections which fail with an error.
+	ErrorRate float64
+
+	// Used to make host names unique.
+	Hostname string
+}
+
+type SimulatedExecutor struct {
+	mu sync.Mutex
+
+	client_id string
+	options   SimulatorOptions
+
+	Inbound  chan *crypto_proto.VeloMessage
+	Outbound chan *crypto_proto.VeloMessage
+
+	// Names of the client event artifacts currently installed.
+	event_artifacts []string
+
+	cancelled map[string]bool
+
+	rng *rand.Rand
+	seq uint64
+}
+
+func (self *SimulatedExecutor) ClientId() string {
+	return self.client_id
+}
+
+func (self *SimulatedExecutor) ReadFromServer() *crypto_proto.VeloMessage {
+	return <-self.Inbound
+}
+
+func (self *SimulatedExecutor) SendToServer(message *crypto_proto.VeloMessage) {
+	self.Outbound <- message
+}
+
+func (self *SimulatedExecutor) ReadResponse() <-chan *crypto_proto.VeloMessage {
+	return self.Outbound
+}
+
+func (self *SimulatedExecutor) ProcessRequest(
+	ctx context.Context,
+	message *crypto_proto.VeloMessage) {
+
+	switch {
+	case message.Cancel != nil:
+		self.mu.Lock()
+		self.cancelled[message.SessionId] = true
+		self.mu.Unlock()
+
+	case message.UpdateEventTable != nil:
+		self.updateEventTable(message.UpdateEventTable)
+
+	case message.FlowRequest != nil:
+		// Do not block the comms while the collection runs.
+		go self.processFlowRequest(ctx, message)
+	}
+}
+
+func (self *SimulatedExecutor) updateEventTable(
+	table *actions_proto.VQLEventTable) {
+	var artifacts []string
+	for _, event := range table.Event {
+		for _, query := range event.Query {
+			if query.Name != "" {
+				artifacts = append(artifacts, query.Name)
+			}
+		}
+	}
+
+	self.mu.Lock()
+	self.event_artifacts = artifacts
+	self.mu.Unlock()
+}
+
+// Returns a random duration up to max.
+func (self *SimulatedExecutor) jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return time.Duration(self.rng.Int63n(int64(max)))
+}
+
+func (self *SimulatedExecutor) chance(p float64) bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.rng.Float64() < p
+}
+
+// Random padding does not compress so the rows cost the server as
+// much as real data.
+func (self *SimulatedExecutor) padding(size int) string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	buf := make([]byte, (size+1)/2)
+	_, _ = self.rng.Read(buf)
+	return hex.EncodeToString(buf)[:size]
+}
+
+func (self *SimulatedExecutor) isCancelled(flow_id string) bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.cancelled[flow_id]
+}
+
+func (self *SimulatedExecutor) send(
+	ctx context.Context, message *crypto_proto.VeloMessage) {
+	select {
+	case <-ctx.Done():
+	case self.Outbound <- message:
+	}
+}
+
+// Build some synthetic rows. The rows look enough like the
+// interrogation results to give each client a unique host name.
+func (self *SimulatedExecutor) makeRows(name string, count int) string {
+	rows := make([]*ordereddict.Dict, 0, count)
+	for i := 0; i < count; i++ {
+		row := ordereddict.NewDict().
+			Set("ClientId", self.client_id).
+			Set("Hostname", self.options.Hostname).
+			Set("Fqdn", self.options.Hostname).
+			Set("OS", "linux").
+			Set("Artifact", name).
+			Set("Seq", atomic.AddUint64(&self.seq, 1)).
+			Set("Time", utils.GetTime().Now().Unix())
+
+		if self.options.RowSize > 0 {
+			row.Set("Data", self.padding(self.options.RowSize))
+		}
+		rows = append(rows, row)
+	}
+
+	serialized, _ := json.MarshalJsonl(rows)
+	return string(serialized)
+}
+
+func (self *SimulatedExecutor) processFlowRequest(
+	ctx context.Context, req *crypto_proto.VeloMessage) {
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-Clock.After(self.jitter(self.options.ResponseDelay)):
+	}
+
+	if self.isCancelled(req.SessionId) {
+		return
+	}
+
+	now := uint64(utils.GetTime().Now().UnixNano())
+	stats := &crypto_proto.FlowStats{FlowComplete: true}
+	queries := req.FlowRequest.VQLClientActions
+
+	for idx, arg := range queries {
+		var query *actions_proto.VQLRequest
+		for _, q := range arg.Query {
+			if q.Name != "" {
+				query = q
+			}
+		}
+
+		status := &crypto_proto.VeloStatus{
+			FirstActive:  now,
+			LastActive:   now,
+			QueryId:      int64(idx),
+			TotalQueries: int64(len(queries)),
+		}
+		stats.QueryStatus = append(stats.QueryStatus, status)
+
+		if query == nil {
+			continue
+		}
+		status.Artifact = query.Name
+
+		if self.chance(self.options.ErrorRate) {
+			status.Status = crypto_proto.VeloStatus_GENERIC_ERROR
+			status.ErrorMessage = "Simulated error"
+			continue
+		}
+
+		if self.options.RowsPerQuery <= 0 {
+			continue
+		}
+
+		self.send(ctx, &crypto_proto.VeloMessage{
+			SessionId: req.SessionId,
+			VQLResponse: &actions_proto.VQLResponse{
+				Query:         query,
+				QueryId:       uint64(idx),
+				JSONLResponse: self.makeRows(query.Name, self.options.RowsPerQuery),
+				TotalRows:     uint64(self.options.RowsPerQuery),
+				Timestamp:     now / 1000,
+			},
+		})
+
+		status.ResultRows = int64(self.options.RowsPerQuery)
+		status.NamesWithResponse = []string{query.Name}
+	}
+
+	self.send(ctx, &crypto_proto.VeloMessage{
+		SessionId: req.SessionId,
+		RequestId: constants.STATS_SINK,
+		FlowStats: stats,
+	})
+	atomic.AddUint64(&simulatedCollections, 1)
+}
+
+// Send a single event from one of the installed event artifacts.
+func (self *SimulatedExecutor) sendEvent(ctx context.Context) {
+	self.mu.Lock()
+	artifacts := self.options.EventArtifacts
+	if len(artifacts) == 0 {
+		artifacts = self.event_artifacts
+	}
+	if len(artifacts) == 0 {
+		self.mu.Unlock()
+		return
+	}
+	name := artifacts[self.rng.Intn(len(artifacts))]
+	self.mu.Unlock()
+
+	self.send(ctx, &crypto_proto.VeloMessage{
+		SessionId: constants.MONITORING_WELL_KNOWN_FLOW,
+		VQLResponse: &actions_proto.VQLResponse{
+			Query:         &actions_proto.VQLRequest{Name: name},
+			JSONLResponse: self.makeRows(name, 1),
+			TotalRows:     1,
+			Timestamp: uint64(
+				utils.GetTime().Now().UTC().UnixNano() / 1000),
+		},
+	})
+	atomic.AddUint64(&simulatedEvents, 1)
+}
+
+func (self *SimulatedExecutor) runEvents(ctx context.Context) {
+	if self.options.EventsPerMinute <= 0 {
+		return
+	}
+
+	mean := float64(time.Minute) / self.options.EventsPerMinute
+	for {
+		// Exponential inter arrival times make the events of many
+		// clients look like independent hosts.
+		self.mu.Lock()
+		delay := time.Duration(self.rng.ExpFloat64() * mean)
+		self.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-Clock.After(delay):
+			self.sendEvent(ctx)
+		}
+	}
+}
+
+// Copy the options so callers can share them between clients.
+func NewSimulatedExecutor(
+	ctx context.Context, client_id string,
+	options SimulatorOptions) *SimulatedExecutor {
+
+	if options.Hostname == "" {
+		options.Hostname = client_id
+	}
+	options.EventArtifacts = append([]string{}, options.EventArtifacts...)
+
+	result := &SimulatedExecutor{
+		client_id: client_id,
+		options:   options,
+		Inbound:   make(chan *crypto_proto.VeloMessage, 10),
+		Outbound:  make(chan *crypto_proto.VeloMessage, 100),
+		cancelled: make(map[string]bool),
+		rng:       rand.New(rand.NewSource(utils.GetTime().Now().UnixNano())),
+	}
+
+	go result.runEvents(ctx)
+
+	return result
+}
+
+// Totals over all simulated clients in this process.
+func GetSimulatorStats() (events uint64, collections uint64) {
+	return atomic.LoadUint64(&simulatedEvents),
+		atomic.LoadUint64(&simulatedCollections)
+}
@@ -0,0 +1,77 @@
+package executor
+
+import (
+	"context"
+	"strings"
+
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+func (self *ExecutorTestSuite) TestSimulatedCollection() {
+	ctx, cancel := context.WithCancel(self.Ctx)
+	defer cancel()
+
+	exe := NewSimulatedExecutor(ctx, "C.1234", SimulatorOptions{
+		RowsPerQuery: 3,
+		Hostname:     "pool-client-1",
+	})
+
+	exe.ProcessRequest(ctx, &crypto_proto.VeloMessage{
+		SessionId: "F.1234",
+		FlowRequest: &crypto_proto.FlowRequest{
+			VQLClientActions: []*actions_proto.VQLCollectorArgs{{
+				Query: []*actions_proto.VQLRequest{
+					{VQL: "LET X = 1"},
+					{Name: "Generic.Client.Info/BasicInformation",
+						VQL: "SELECT * FROM info()"},
+				},
+			}},
+		},
+	})
+
+	response := <-exe.ReadResponse()
+	assert.Equal(self.T(), "F.1234", response.SessionId)
+	assert.Equal(self.T(), uint64(3), response.VQLResponse.TotalRows)
+	assert.Equal(self.T(), "Generic.Client.Info/BasicInformation",
+		response.VQLResponse.Query.Name)
+	assert.Equal(self.T(), 3,
+		strings.Count(response.VQLResponse.JSONLResponse,
+			`"Hostname":"pool-client-1"`))
+
+	stats := <-exe.ReadResponse()
+	assert.Equal(self.T(), constants.STATS_SINK, stats.RequestId)
+	assert.True(self.T(), stats.FlowStats.FlowComplete)
+	assert.Equal(self.T(), int64(3), stats.FlowStats.QueryStatus[0].ResultRows)
+	assert.Equal(self.T(), crypto_proto.VeloStatus_OK,
+		stats.FlowStats.QueryStatus[0].Status)
+}
+
+func (self *ExecutorTestSuite) TestSimulatedEvents() {
+	ctx, cancel := context.WithCancel(self.Ctx)
+	defer cancel()
+
+	exe := NewSimulatedExecutor(ctx, "C.1234", SimulatorOptions{
+		EventsPerMinute: 60000,
+	})
+
+	// Events are only sent for artifacts in the event table.
+	exe.ProcessRequest(ctx, &crypto_proto.VeloMessage{
+		UpdateEventTable: &actions_proto.VQLEventTable{
+			Event: []*actions_proto.VQLCollectorArgs{{
+				Query: []*actions_proto.VQLRequest{
+					{Name: "Generic.Client.Stats", VQL: "SELECT * FROM info()"},
+				},
+			}},
+		},
+	})
+
+	response := <-exe.ReadResponse()
+	assert.Equal(self.T(), constants.MONITORING_WELL_KNOWN_FLOW,
+		response.SessionId)
+	assert.Equal(self.T(), "Generic.Client.Stats",
+		response.VQLResponse.Query.Name)
+	assert.Equal(self.T(), uint64(1), response.VQLResponse.TotalRows)
+}
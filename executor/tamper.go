@@ -0,0 +1,52 @@
+package executor
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	TAMPER_ARTIFACT = "Windows.Events.ClientTamper"
+)
+
+// Report an attempt to tamper with the client to the server. Tamper
+// events are urgent so they skip ahead of any queued results - the
+// client may not be running for much longer.
+func SendTamperEvent(
+	ctx context.Context,
+	output chan *crypto_proto.VeloMessage,
+	event, details string) {
+
+	now := utils.GetTime().Now()
+	row := ordereddict.NewDict().
+		Set("Timestamp", now.Unix()).
+		Set("Event", event).
+		Set("Details", details)
+
+	serialized, err := json.MarshalJsonl([]*ordereddict.Dict{row})
+	if err != nil {
+		return
+	}
+
+	message := &crypto_proto.VeloMessage{
+		SessionId: constants.MONITORING_WELL_KNOWN_FLOW,
+		Urgent:    true,
+		VQLResponse: &actions_proto.VQLResponse{
+			Query:         &actions_proto.VQLRequest{Name: TAMPER_ARTIFACT},
+			JSONLResponse: string(serialized),
+			TotalRows:     1,
+			Timestamp:     uint64(now.UTC().UnixNano() / 1000),
+		},
+	}
+
+	select {
+	case <-ctx.Done():
+	case output <- message:
+	}
+}
@@ -0,0 +1,23 @@
+package executor
+
+import (
+	"www.velocidex.com/golang/velociraptor/constants"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+func (self *ExecutorTestSuite) TestSendTamperEvent() {
+	output := make(chan *crypto_proto.VeloMessage, 1)
+
+	SendTamperEvent(self.Ctx, output, "ServiceStopAttempt", "Stopped")
+	message := <-output
+
+	// Tamper events are sent ahead of other messages.
+	assert.True(self.T(), message.Urgent)
+	assert.Equal(self.T(), constants.MONITORING_WELL_KNOWN_FLOW,
+		message.SessionId)
+	assert.Equal(self.T(), TAMPER_ARTIFACT, message.VQLResponse.Query.Name)
+	assert.Equal(self.T(), uint64(1), message.VQLResponse.TotalRows)
+	assert.Contains(self.T(), message.VQLResponse.JSONLResponse,
+		`"Event":"ServiceStopAttempt","Details":"Stopped"`)
+}
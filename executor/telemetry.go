@@ -0,0 +1,189 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/shirou/gopsutil/v3/process"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/responder"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	TELEMETRY_ARTIFACT = "Generic.Client.Telemetry"
+)
+
+var (
+	Telemetry = &TelemetryService{}
+)
+
+// The comms report the size of their local buffers through this.
+type BufferSizer interface {
+	TotalSize() uint64
+}
+
+// The telemetry service reports the client's own resource usage to
+// the server as a low volume event stream. Unlike the
+// Generic.Client.Stats artifact this does not need any VQL to run so
+// it is always available.
+type TelemetryService struct {
+	mu sync.Mutex
+
+	ring_buffer  BufferSizer
+	event_buffer BufferSizer
+
+	// The longest time a message waited in the ring buffer before
+	// being sent since the last sample.
+	max_queue_latency time.Duration
+
+	proc *process.Process
+
+	last_cpu  float64
+	last_time time.Time
+}
+
+func (self *TelemetryService) SetBuffers(ring_buffer, event_buffer BufferSizer) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.ring_buffer = ring_buffer
+	self.event_buffer = event_buffer
+}
+
+func (self *TelemetryService) UpdateQueueLatency(latency time.Duration) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if latency > self.max_queue_latency {
+		self.max_queue_latency = latency
+	}
+}
+
+// Take a single sample of the client's resource usage.
+func (self *TelemetryService) Sample(ctx context.Context,
+	config_obj *config_proto.Config) *ordereddict.Dict {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	now := utils.GetTime().Now()
+	result := ordereddict.NewDict().
+		Set("Timestamp", now.Unix())
+
+	if self.proc == nil {
+		self.proc, _ = process.NewProcess(int32(os.Getpid()))
+	}
+
+	if self.proc != nil {
+		mem, err := self.proc.MemoryInfo()
+		if err == nil {
+			result.Set("RSS", mem.RSS)
+		}
+
+		times, err := self.proc.Times()
+		if err == nil {
+			cpu := times.User + times.System
+			result.Set("CPU", cpu)
+
+			// Percent of one core used since the last sample.
+			if !self.last_time.IsZero() && now.After(self.last_time) {
+				result.Set("CPUPercent", (cpu-self.last_cpu)*100/
+					now.Sub(self.last_time).Seconds())
+			}
+			self.last_cpu = cpu
+			self.last_time = now
+		}
+	}
+
+	result.Set("Goroutines", runtime.NumGoroutine())
+
+	if self.ring_buffer != nil {
+		result.Set("RingBufferSize", self.ring_buffer.TotalSize())
+	}
+
+	if self.event_buffer != nil {
+		result.Set("EventBufferSize", self.event_buffer.TotalSize())
+	}
+
+	result.Set("QueueLatency", self.max_queue_latency.Seconds())
+	self.max_queue_latency = 0
+
+	// Tag the sample with the flows that were running at the time.
+	flow_manager := responder.GetFlowManager(ctx, config_obj)
+	result.Set("Flows", flow_manager.Summaries())
+
+	return result
+}
+
+func (self *TelemetryService) Start(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config,
+	output_chan chan *crypto_proto.VeloMessage,
+	period time.Duration) {
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		// Results are sent as a monitoring event so they are buffered
+		// when the server is not reachable.
+		query_responder := responder.NewMonitoringResponder(
+			ctx, config_obj, output_chan, TELEMETRY_ARTIFACT)
+		query := &actions_proto.VQLRequest{Name: TELEMETRY_ARTIFACT}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-Clock.After(period):
+				row := self.Sample(ctx, config_obj)
+				serialized, err := json.MarshalJsonl([]*ordereddict.Dict{row})
+				if err != nil {
+					continue
+				}
+
+				query_responder.AddResponse(&crypto_proto.VeloMessage{
+					VQLResponse: &actions_proto.VQLResponse{
+						Query:         query,
+						JSONLResponse: string(serialized),
+						TotalRows:     1,
+						Timestamp: uint64(
+							utils.GetTime().Now().UTC().UnixNano() / 1000),
+					},
+				})
+			}
+		}
+	}()
+}
+
+func StartTelemetryService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config,
+	output_chan chan *crypto_proto.VeloMessage) error {
+	if config_obj.Client == nil || config_obj.Client.DisableTelemetry {
+		return nil
+	}
+
+	period := time.Duration(config_obj.Client.TelemetryPeriod) * time.Second
+	if period == 0 {
+		period = time.Minute
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.ClientComponent)
+	logger.Info("<green>Starting</> client telemetry every %v", period)
+
+	Telemetry.Start(ctx, wg, config_obj, output_chan, period)
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"time"
+
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/responder"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+type testBuffer uint64
+
+func (self testBuffer) TotalSize() uint64 {
+	return uint64(self)
+}
+
+func (self *ExecutorTestSuite) TestTelemetrySample() {
+	output := make(chan *crypto_proto.VeloMessage, 10)
+
+	// Start a flow with some results.
+	flow_manager := responder.GetFlowManager(self.Ctx, self.ConfigObj)
+	flow_context := flow_manager.FlowContext(output, &crypto_proto.VeloMessage{
+		SessionId: "F.1234",
+	})
+	defer flow_context.Close()
+
+	_, responder_obj := flow_context.NewResponder(&actions_proto.VQLCollectorArgs{})
+	defer responder_obj.Close()
+
+	responder_obj.AddResponse(&crypto_proto.VeloMessage{
+		VQLResponse: &actions_proto.VQLResponse{
+			Query:     &actions_proto.VQLRequest{Name: "Generic.Client.Info"},
+			TotalRows: 5,
+		},
+	})
+
+	telemetry := &TelemetryService{}
+	telemetry.SetBuffers(testBuffer(100), nil)
+	telemetry.UpdateQueueLatency(2 * time.Second)
+	telemetry.UpdateQueueLatency(time.Second)
+
+	row := telemetry.Sample(self.Ctx, self.ConfigObj)
+
+	goroutines, _ := row.GetInt64("Goroutines")
+	assert.True(self.T(), goroutines > 0)
+
+	size, _ := row.Get("RingBufferSize")
+	assert.Equal(self.T(), uint64(100), size)
+
+	// No event buffer is configured.
+	_, pres := row.Get("EventBufferSize")
+	assert.True(self.T(), !pres)
+
+	// The longest latency is reported.
+	latency, _ := row.Get("QueueLatency")
+	assert.Equal(self.T(), float64(2), latency)
+
+	// The sample is tagged with the running flow.
+	flows, _ := row.Get("Flows")
+	assert.Equal(self.T(), []*responder.FlowSummary{{
+		FlowId:     "F.1234",
+		Artifacts:  []string{"Generic.Client.Info"},
+		ResultRows: 5,
+	}}, flows)
+
+	// The latency is reset after each sample.
+	row = telemetry.Sample(self.Ctx, self.ConfigObj)
+	latency, _ = row.Get("QueueLatency")
+	assert.Equal(self.T(), float64(0), latency)
+}
@@ -72,6 +72,9 @@ type FileAdapter struct {
 	*os.File
 
 	PathSpec_ FSPathSpec
+
+	// Set when the file is mapped into memory.
+	mapped []byte
 }
 
 func (self *FileAdapter) PathSpec() FSPathSpec {
@@ -85,3 +88,16 @@ func (self *FileAdapter) Stat() (FileInfo, error) {
 	}
 	return NewFileInfoAdapter(stat, self.PathSpec_, nil), nil
 }
+
+// Release the mapping before closing the file.
+func (self *FileAdapter) Close() error {
+	if self.mapped != nil {
+		err := munmap(self.mapped)
+		self.mapped = nil
+		if err != nil {
+			self.File.Close()
+			return err
+		}
+	}
+	return self.File.Close()
+}
@@ -38,6 +38,9 @@ func GetExtensionForFilestore(path_spec FSPathSpec) string {
 	case PATH_TYPE_FILESTORE_JSON_TIME_INDEX:
 		return ".json.tidx"
 
+	case PATH_TYPE_FILESTORE_JSON_WAL:
+		return ".json.wal"
+
 	case PATH_TYPE_FILESTORE_SPARSE_IDX:
 		return ".idx"
 
@@ -47,6 +50,9 @@ func GetExtensionForFilestore(path_spec FSPathSpec) string {
 	case PATH_TYPE_FILESTORE_DOWNLOAD_REPORT:
 		return ".html"
 
+	case PATH_TYPE_FILESTORE_DOWNLOAD_PDF:
+		return ".pdf"
+
 	case PATH_TYPE_FILESTORE_TMP:
 		return ".tmp"
 
@@ -94,6 +100,10 @@ func GetFileStorePathTypeFromExtension(name string) (PathType, string) {
 		return PATH_TYPE_FILESTORE_JSON_TIME_INDEX, name[:len(name)-10]
 	}
 
+	if strings.HasSuffix(name, ".json.wal") {
+		return PATH_TYPE_FILESTORE_JSON_WAL, name[:len(name)-9]
+	}
+
 	if strings.HasSuffix(name, ".json.db") {
 		return PATH_TYPE_FILESTORE_DB_JSON, name[:len(name)-8]
 	}
@@ -110,6 +120,10 @@ func GetFileStorePathTypeFromExtension(name string) (PathType, string) {
 		return PATH_TYPE_FILESTORE_DOWNLOAD_REPORT, name[:len(name)-5]
 	}
 
+	if strings.HasSuffix(name, ".pdf") {
+		return PATH_TYPE_FILESTORE_DOWNLOAD_PDF, name[:len(name)-4]
+	}
+
 	if strings.HasSuffix(name, ".tmp") {
 		return PATH_TYPE_FILESTORE_TMP, name[:len(name)-4]
 	}
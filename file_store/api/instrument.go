@@ -21,6 +21,14 @@ var (
 		[]string{"tag", "action", "datastore"},
 	)
 
+	FilestoreBytesWritten = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "filestore_bytes_written",
+			Help: "Total number of bytes written to the filestore.",
+		},
+		[]string{"datastore"},
+	)
+
 	// Simulate running on a very slow filesystem (EFS)
 	clock_mu    sync.Mutex
 	inject_time = 0
@@ -0,0 +1,10 @@
+package api
+
+// Readers of files on a local filesystem can map the file into
+// memory. This is much faster for random access into large files
+// (e.g. seeking into a result set using its index).
+type MmapReader interface {
+	// Map the entire file. The returned buffer is only valid until
+	// the reader is closed.
+	Mmap() ([]byte, error)
+}
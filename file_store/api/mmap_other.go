@@ -0,0 +1,13 @@
+// +build !linux,!darwin,!freebsd
+
+package api
+
+import "errors"
+
+func (self *FileAdapter) Mmap() ([]byte, error) {
+	return nil, errors.New("Mmap not supported on this platform")
+}
+
+func munmap(data []byte) error {
+	return nil
+}
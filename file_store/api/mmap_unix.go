@@ -0,0 +1,34 @@
+// +build linux darwin freebsd
+
+package api
+
+import "syscall"
+
+func (self *FileAdapter) Mmap() ([]byte, error) {
+	if self.mapped != nil {
+		return self.mapped, nil
+	}
+
+	stat, err := self.File.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty files can not be mapped.
+	if stat.Size() == 0 {
+		return []byte{}, nil
+	}
+
+	data, err := syscall.Mmap(int(self.File.Fd()), 0, int(stat.Size()),
+		syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+
+	self.mapped = data
+	return data, nil
+}
+
+func munmap(data []byte) error {
+	return syscall.Munmap(data)
+}
@@ -106,6 +106,13 @@ const (
 
 	// Arbitrary extensions.
 	PATH_TYPE_FILESTORE_ANY
+
+	// The write ahead log of a result set. Added last because path
+	// types are exchanged over gRPC.
+	PATH_TYPE_FILESTORE_JSON_WAL
+
+	// Rendered PDF documents in the download folder.
+	PATH_TYPE_FILESTORE_DOWNLOAD_PDF
 )
 
 type _PathSpec interface {
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// Truncate the file to size. Used to roll back writes which were
+// interrupted by a crash.
+//
+// File store files can only be truncated to 0 so the part to keep is
+// copied to a temporary file which is moved over the original.
+func TruncateFile(file_store FileStore, path FSPathSpec, size int64) error {
+	stat, err := file_store.StatFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if stat.Size() <= size {
+		return nil
+	}
+
+	reader, err := file_store.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	tmp_path := path.Dir().AddChild(path.Base() +
+		GetExtensionForFilestore(path)).SetType(PATH_TYPE_FILESTORE_TMP)
+
+	writer, err := file_store.WriteFile(tmp_path)
+	if err != nil {
+		return err
+	}
+
+	err = writer.Truncate()
+	if err != nil {
+		writer.Close()
+		return err
+	}
+
+	_, err = io.Copy(writer, io.LimitReader(reader, size))
+	if err != nil {
+		writer.Close()
+		return err
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		writer.Close()
+		return err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	return file_store.Move(tmp_path, path)
+}
@@ -0,0 +1,20 @@
+package api
+
+// File stores that buffer writes in memory before writing them to
+// storage may also provide writers that write through
+// immediately. This is used for large bulk data (e.g. uploads) where
+// buffering just adds memory pressure without combining any writes.
+type UnbufferedFileStore interface {
+	WriteFileUnbuffered(filename FSPathSpec) (FileWriter, error)
+}
+
+// Open a writer that writes directly to storage if the file store
+// supports it.
+func WriteFileUnbuffered(
+	file_store FileStore, filename FSPathSpec) (FileWriter, error) {
+	unbuffered, ok := file_store.(UnbufferedFileStore)
+	if ok {
+		return unbuffered.WriteFileUnbuffered(filename)
+	}
+	return file_store.WriteFile(filename)
+}
@@ -64,7 +64,9 @@ func (self *DirectoryFileWriter) Write(data []byte) (int, error) {
 		return 0, err
 	}
 
-	return self.Fd.Write(data)
+	n, err := self.Fd.Write(data)
+	api.FilestoreBytesWritten.WithLabelValues("DirectoryFileWriter").Add(float64(n))
+	return n, err
 }
 
 func (self *DirectoryFileWriter) Truncate() error {
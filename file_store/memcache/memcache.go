@@ -311,6 +311,24 @@ func (self *MemcacheFileStore) WriteFileWithCompletion(
 	return result, nil
 }
 
+// Write directly to the delegate, bypassing the write cache. If the
+// file is already cached we have to go through the cache to keep the
+// writes in order, but flush it on close.
+func (self *MemcacheFileStore) WriteFileUnbuffered(
+	path api.FSPathSpec) (api.FileWriter, error) {
+	defer api.Instrument("write_open_unbuffered", "MemcacheFileStore", path)()
+
+	self.mu.Lock()
+	_, err := self.data_cache.Get(path.AsClientPath())
+	if err == nil {
+		self.mu.Unlock()
+		return self.WriteFileWithCompletion(path, utils.SyncCompleter)
+	}
+	defer self.mu.Unlock()
+
+	return self.delegate.WriteFile(path)
+}
+
 func (self *MemcacheFileStore) StatFile(path api.FSPathSpec) (api.FileInfo, error) {
 	defer api.Instrument("stat", "MemcacheFileStore", path)()
 
@@ -51,6 +51,44 @@ func (self *MemcacheTestSuite) TestFileReadWrite() {
 	assert.Equal(self.T(), string(out), data)
 }
 
+// Unbuffered writes are visible as soon as the writer is closed.
+func (self *MemcacheTestSuite) TestFileWriteUnbuffered() {
+	filename := path_specs.NewSafeFilestorePath("test", "upload")
+	for _, data := range []string{"Hello ", "world"} {
+		fd, err := api.WriteFileUnbuffered(self.file_store, filename)
+		assert.NoError(self.T(), err)
+
+		_, err = fd.Write([]byte(data))
+		assert.NoError(self.T(), err)
+		fd.Close()
+	}
+
+	read_fd, err := self.file_store.ReadFile(filename)
+	assert.NoError(self.T(), err)
+	out, err := ioutil.ReadAll(read_fd)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "Hello world", string(out))
+
+	// A file already held in the write cache is flushed in order.
+	fd, err := self.file_store.WriteFile(filename)
+	assert.NoError(self.T(), err)
+	_, err = fd.Write([]byte("!"))
+	assert.NoError(self.T(), err)
+	fd.Close()
+
+	fd, err = api.WriteFileUnbuffered(self.file_store, filename)
+	assert.NoError(self.T(), err)
+	_, err = fd.Write([]byte("?"))
+	assert.NoError(self.T(), err)
+	fd.Close()
+
+	read_fd, err = self.file_store.ReadFile(filename)
+	assert.NoError(self.T(), err)
+	out, err = ioutil.ReadAll(read_fd)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "Hello world!?", string(out))
+}
+
 func (self *MemcacheTestSuite) TestFileWriteCompletions() {
 	var mu sync.Mutex
 	result := []string{}
@@ -0,0 +1,101 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Parquet metadata is serialized using the Thrift compact
+// protocol. We only need to write a handful of structs so we encode
+// them by hand rather than bring in the Thrift runtime.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+type thriftWriter struct {
+	buf bytes.Buffer
+
+	// The last field id written in each nested struct - field ids
+	// are delta encoded.
+	last_field []int16
+}
+
+func (self *thriftWriter) Bytes() []byte {
+	return self.buf.Bytes()
+}
+
+func (self *thriftWriter) varint(value uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], value)
+	self.buf.Write(buf[:n])
+}
+
+func (self *thriftWriter) zigzag(value int64) {
+	self.varint(uint64((value << 1) ^ (value >> 63)))
+}
+
+func (self *thriftWriter) fieldHeader(id int16, field_type byte) {
+	last := len(self.last_field) - 1
+	delta := id - self.last_field[last]
+	if delta > 0 && delta <= 15 {
+		self.buf.WriteByte(byte(delta)<<4 | field_type)
+	} else {
+		self.buf.WriteByte(field_type)
+		self.zigzag(int64(id))
+	}
+	self.last_field[last] = id
+}
+
+func (self *thriftWriter) I32Field(id int16, value int32) {
+	self.fieldHeader(id, thriftI32)
+	self.zigzag(int64(value))
+}
+
+func (self *thriftWriter) I64Field(id int16, value int64) {
+	self.fieldHeader(id, thriftI64)
+	self.zigzag(value)
+}
+
+func (self *thriftWriter) StringField(id int16, value string) {
+	self.fieldHeader(id, thriftBinary)
+	self.String(value)
+}
+
+func (self *thriftWriter) String(value string) {
+	self.varint(uint64(len(value)))
+	self.buf.WriteString(value)
+}
+
+func (self *thriftWriter) I32(value int32) {
+	self.zigzag(int64(value))
+}
+
+// Elements must be written by the caller after the list header.
+func (self *thriftWriter) ListField(id int16, element_type byte, size int) {
+	self.fieldHeader(id, thriftList)
+	if size < 15 {
+		self.buf.WriteByte(byte(size)<<4 | element_type)
+	} else {
+		self.buf.WriteByte(0xf0 | element_type)
+		self.varint(uint64(size))
+	}
+}
+
+func (self *thriftWriter) StructField(id int16) {
+	self.fieldHeader(id, thriftStruct)
+	self.StructBegin()
+}
+
+// Used for top level structs and list elements.
+func (self *thriftWriter) StructBegin() {
+	self.last_field = append(self.last_field, 0)
+}
+
+func (self *thriftWriter) StructEnd() {
+	self.buf.WriteByte(0)
+	self.last_field = self.last_field[:len(self.last_field)-1]
+}
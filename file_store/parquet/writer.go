@@ -0,0 +1,364 @@
+// A minimal Parquet writer used to export result sets.
+//
+// Result set rows are schemaless so columns are written as optional
+// UTF8 strings (non string values are JSON encoded). If the artifact
+// declares typed columns, timestamp columns are written as
+// TIMESTAMP_MICROS and integer columns as INT64. Pages
+// use the PLAIN encoding without compression which all Parquet
+// readers support. The columns are taken from the rows of the first
+// row group - columns which only appear in later rows are dropped.
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+)
+
+const (
+	MAGIC = "PAR1"
+
+	// Number of rows buffered in memory before they are written.
+	ROW_GROUP_SIZE = 10000
+
+	typeInt64          = 2
+	typeByteArray      = 6
+	repetitionOptional = 1
+	convertedTypeUTF8  = 0
+	encodingPlain      = 0
+	encodingRLE        = 3
+	codecUncompressed  = 0
+	pageTypeData       = 0
+
+	convertedTypeTimestampMicros = 10
+)
+
+type columnChunk struct {
+	offset     int64
+	size       int64
+	num_values int64
+}
+
+type rowGroup struct {
+	columns  []columnChunk
+	size     int64
+	num_rows int64
+}
+
+type Writer struct {
+	w      io.Writer
+	opts   *json.EncOpts
+	schema *result_sets.Schema
+
+	offset     int64
+	columns    []string
+	rows       []*ordereddict.Dict
+	row_groups []rowGroup
+	total_rows int64
+}
+
+func NewWriter(w io.Writer, opts *json.EncOpts) *Writer {
+	return &Writer{w: w, opts: opts}
+}
+
+// Typed columns in the schema are written with a matching Parquet
+// type. Must be called before the first row is written.
+func (self *Writer) SetSchema(schema *result_sets.Schema) {
+	self.schema = schema
+}
+
+func (self *Writer) physicalType(column string) int32 {
+	switch self.schema.ColumnType(column) {
+	case result_sets.COLUMN_TIMESTAMP, result_sets.COLUMN_INTEGER:
+		return typeInt64
+	}
+	return typeByteArray
+}
+
+func (self *Writer) Write(row *ordereddict.Dict) error {
+	self.rows = append(self.rows, row)
+	if len(self.rows) >= ROW_GROUP_SIZE {
+		return self.flush()
+	}
+	return nil
+}
+
+// Write the remaining rows and the file metadata.
+func (self *Writer) Close() error {
+	err := self.flush()
+	if err != nil {
+		return err
+	}
+
+	// An empty file still needs the header.
+	err = self.writeMagic()
+	if err != nil {
+		return err
+	}
+
+	metadata := self.fileMetadata()
+	err = self.write(metadata)
+	if err != nil {
+		return err
+	}
+
+	footer := make([]byte, 4)
+	binary.LittleEndian.PutUint32(footer, uint32(len(metadata)))
+	err = self.write(footer)
+	if err != nil {
+		return err
+	}
+
+	return self.write([]byte(MAGIC))
+}
+
+func (self *Writer) write(data []byte) error {
+	n, err := self.w.Write(data)
+	self.offset += int64(n)
+	return err
+}
+
+func (self *Writer) writeMagic() error {
+	if self.offset > 0 {
+		return nil
+	}
+	return self.write([]byte(MAGIC))
+}
+
+func (self *Writer) flush() error {
+	if len(self.rows) == 0 {
+		return nil
+	}
+
+	err := self.writeMagic()
+	if err != nil {
+		return err
+	}
+
+	if self.columns == nil {
+		self.columns = getColumns(self.rows)
+	}
+
+	group := rowGroup{num_rows: int64(len(self.rows))}
+	for _, column := range self.columns {
+		header, page := self.encodePage(column)
+
+		chunk := columnChunk{
+			offset:     self.offset,
+			size:       int64(len(header) + len(page)),
+			num_values: int64(len(self.rows)),
+		}
+
+		err := self.write(header)
+		if err != nil {
+			return err
+		}
+
+		err = self.write(page)
+		if err != nil {
+			return err
+		}
+
+		group.columns = append(group.columns, chunk)
+		group.size += chunk.size
+	}
+
+	self.row_groups = append(self.row_groups, group)
+	self.total_rows += group.num_rows
+	self.rows = nil
+
+	return nil
+}
+
+// Each column chunk is written as a single data page.
+func (self *Writer) encodePage(column string) (header []byte, page []byte) {
+	defined := make([]bool, 0, len(self.rows))
+	values := &bytes.Buffer{}
+	length := make([]byte, 4)
+	int64_value := make([]byte, 8)
+	column_type := self.schema.ColumnType(column)
+
+	for _, row := range self.rows {
+		value, pres := row.Get(column)
+		if !pres || value == nil {
+			defined = append(defined, false)
+			continue
+		}
+
+		// Values which do not fit the typed column are null.
+		switch column_type {
+		case result_sets.COLUMN_TIMESTAMP:
+			ts, ok := result_sets.ToTimestamp(value)
+			defined = append(defined, ok)
+			if ok {
+				binary.LittleEndian.PutUint64(int64_value, uint64(ts.UnixMicro()))
+				values.Write(int64_value)
+			}
+
+		case result_sets.COLUMN_INTEGER:
+			i, ok := result_sets.ToInteger(value)
+			defined = append(defined, ok)
+			if ok {
+				binary.LittleEndian.PutUint64(int64_value, uint64(i))
+				values.Write(int64_value)
+			}
+
+		default:
+			defined = append(defined, true)
+			str := self.toString(value)
+			binary.LittleEndian.PutUint32(length, uint32(len(str)))
+			values.Write(length)
+			values.WriteString(str)
+		}
+	}
+
+	levels := encodeDefinitionLevels(defined)
+	binary.LittleEndian.PutUint32(length, uint32(len(levels)))
+
+	page = make([]byte, 0, 4+len(levels)+values.Len())
+	page = append(page, length...)
+	page = append(page, levels...)
+	page = append(page, values.Bytes()...)
+
+	w := &thriftWriter{}
+	w.StructBegin()
+	w.I32Field(1, pageTypeData)
+	w.I32Field(2, int32(len(page)))
+	w.I32Field(3, int32(len(page)))
+	w.StructField(5)
+	w.I32Field(1, int32(len(self.rows)))
+	w.I32Field(2, encodingPlain)
+	w.I32Field(3, encodingRLE)
+	w.I32Field(4, encodingRLE)
+	w.StructEnd()
+	w.StructEnd()
+
+	return w.Bytes(), page
+}
+
+func (self *Writer) toString(value interface{}) string {
+	str, ok := value.(string)
+	if ok {
+		return str
+	}
+
+	serialized, err := json.MarshalWithOptions(value, self.opts)
+	if err != nil {
+		return ""
+	}
+
+	// Times and other values serialized as strings do not need the
+	// quotes.
+	result := string(serialized)
+	if len(result) >= 2 && strings.HasPrefix(result, "\"") &&
+		strings.HasSuffix(result, "\"") {
+		var unquoted string
+		err := json.Unmarshal(serialized, &unquoted)
+		if err == nil {
+			return unquoted
+		}
+	}
+	return result
+}
+
+func (self *Writer) fileMetadata() []byte {
+	w := &thriftWriter{}
+	w.StructBegin()
+	w.I32Field(1, 1)
+
+	// The schema is a flattened tree with a root element.
+	w.ListField(2, thriftStruct, len(self.columns)+1)
+	w.StructBegin()
+	w.StringField(4, "schema")
+	w.I32Field(5, int32(len(self.columns)))
+	w.StructEnd()
+
+	for _, column := range self.columns {
+		w.StructBegin()
+		w.I32Field(1, self.physicalType(column))
+		w.I32Field(3, repetitionOptional)
+		w.StringField(4, column)
+		switch self.schema.ColumnType(column) {
+		case result_sets.COLUMN_TIMESTAMP:
+			w.I32Field(6, convertedTypeTimestampMicros)
+		case result_sets.COLUMN_INTEGER:
+		default:
+			w.I32Field(6, convertedTypeUTF8)
+		}
+		w.StructEnd()
+	}
+
+	w.I64Field(3, self.total_rows)
+
+	w.ListField(4, thriftStruct, len(self.row_groups))
+	for _, group := range self.row_groups {
+		w.StructBegin()
+		w.ListField(1, thriftStruct, len(group.columns))
+		for idx, chunk := range group.columns {
+			w.StructBegin()
+			w.I64Field(2, chunk.offset)
+			w.StructField(3)
+			w.I32Field(1, self.physicalType(self.columns[idx]))
+			w.ListField(2, thriftI32, 2)
+			w.I32(encodingPlain)
+			w.I32(encodingRLE)
+			w.ListField(3, thriftBinary, 1)
+			w.String(self.columns[idx])
+			w.I32Field(4, codecUncompressed)
+			w.I64Field(5, chunk.num_values)
+			w.I64Field(6, chunk.size)
+			w.I64Field(7, chunk.size)
+			w.I64Field(9, chunk.offset)
+			w.StructEnd()
+			w.StructEnd()
+		}
+		w.I64Field(2, group.size)
+		w.I64Field(3, group.num_rows)
+		w.StructEnd()
+	}
+
+	w.StringField(6, "Velociraptor")
+	w.StructEnd()
+
+	return w.Bytes()
+}
+
+// Definition levels have a bit width of 1 (the column is either
+// null or not). We write them as a single bit packed run.
+func encodeDefinitionLevels(defined []bool) []byte {
+	groups := (len(defined) + 7) / 8
+
+	result := make([]byte, binary.MaxVarintLen64+groups)
+	n := binary.PutUvarint(result, uint64(groups<<1|1))
+
+	packed := result[n : n+groups]
+	for idx, value := range defined {
+		if value {
+			packed[idx/8] |= 1 << (idx % 8)
+		}
+	}
+
+	return result[:n+groups]
+}
+
+// The union of all the columns in the rows, in the order they
+// appear.
+func getColumns(rows []*ordereddict.Dict) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		for _, key := range row.Keys() {
+			if !seen[key] {
+				seen[key] = true
+				result = append(result, key)
+			}
+		}
+	}
+	return result
+}
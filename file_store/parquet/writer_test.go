@@ -0,0 +1,215 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/stretchr/testify/assert"
+	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+)
+
+// A generic Thrift compact protocol decoder so we can check the
+// metadata we write.
+type thriftReader struct {
+	buf *bytes.Reader
+}
+
+func (self *thriftReader) zigzag() int64 {
+	value, _ := binary.ReadUvarint(self.buf)
+	return int64(value>>1) ^ -int64(value&1)
+}
+
+func (self *thriftReader) value(value_type byte) interface{} {
+	switch value_type {
+	case 1:
+		return true
+	case 2:
+		return false
+	case thriftI32, thriftI64:
+		return self.zigzag()
+	case thriftBinary:
+		length, _ := binary.ReadUvarint(self.buf)
+		data := make([]byte, length)
+		_, _ = self.buf.Read(data)
+		return string(data)
+	case thriftList:
+		header, _ := self.buf.ReadByte()
+		size := uint64(header >> 4)
+		if size == 15 {
+			size, _ = binary.ReadUvarint(self.buf)
+		}
+		result := []interface{}{}
+		for i := uint64(0); i < size; i++ {
+			result = append(result, self.value(header&0x0f))
+		}
+		return result
+	case thriftStruct:
+		return self.Struct()
+	}
+	panic(fmt.Sprintf("Unsupported type %v", value_type))
+}
+
+func (self *thriftReader) Struct() map[int64]interface{} {
+	result := make(map[int64]interface{})
+	last := int64(0)
+	for {
+		header, _ := self.buf.ReadByte()
+		if header == 0 {
+			return result
+		}
+
+		id := last + int64(header>>4)
+		if header>>4 == 0 {
+			id = self.zigzag()
+		}
+		last = id
+		result[id] = self.value(header & 0x0f)
+	}
+}
+
+func TestParquetWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := NewWriter(buf, nil)
+
+	for i := 0; i < 10; i++ {
+		row := ordereddict.NewDict().
+			Set("Name", fmt.Sprintf("Row %v", i))
+		if i%3 == 0 {
+			row.Set("Count", i).
+				Set("Time", time.Unix(1600000000, 0).UTC())
+		}
+		assert.NoError(t, writer.Write(row))
+	}
+	assert.NoError(t, writer.Close())
+
+	data := buf.Bytes()
+	assert.Equal(t, MAGIC, string(data[:4]))
+	assert.Equal(t, MAGIC, string(data[len(data)-4:]))
+
+	length := binary.LittleEndian.Uint32(data[len(data)-8:])
+	footer := data[len(data)-8-int(length) : len(data)-8]
+
+	reader := &thriftReader{buf: bytes.NewReader(footer)}
+	metadata := reader.Struct()
+
+	// Total number of rows
+	assert.Equal(t, int64(10), metadata[3])
+
+	// The schema has a root element followed by all the columns.
+	schema := metadata[2].([]interface{})
+	names := []interface{}{}
+	for _, element := range schema {
+		names = append(names, element.(map[int64]interface{})[4])
+	}
+	assert.Equal(t, []interface{}{"schema", "Name", "Count", "Time"}, names)
+
+	// A single row group with a chunk per column.
+	row_groups := metadata[4].([]interface{})
+	assert.Equal(t, 1, len(row_groups))
+
+	columns := row_groups[0].(map[int64]interface{})[1].([]interface{})
+	assert.Equal(t, 3, len(columns))
+
+	// Decode the Count column: Only every third row has a value.
+	chunk := columns[1].(map[int64]interface{})
+	offset := chunk[2].(int64)
+
+	reader = &thriftReader{buf: bytes.NewReader(data[offset:])}
+	page_header := reader.Struct()
+	page_offset := int(offset) + len(data[offset:]) - reader.buf.Len()
+	page := data[page_offset : page_offset+int(page_header[3].(int64))]
+
+	levels_length := binary.LittleEndian.Uint32(page)
+	levels := page[4 : 4+levels_length]
+
+	// A single bit packed run of 2 groups.
+	assert.Equal(t, []byte{2<<1 | 1, 0x49, 0x02}, levels)
+
+	values := []string{}
+	page = page[4+levels_length:]
+	for len(page) > 0 {
+		length := binary.LittleEndian.Uint32(page)
+		values = append(values, string(page[4:4+length]))
+		page = page[4+length:]
+	}
+	assert.Equal(t, []string{"0", "3", "6", "9"}, values)
+
+	// Times are exported as strings.
+	chunk = columns[2].(map[int64]interface{})
+	offset = chunk[2].(int64)
+	assert.Contains(t, string(data[offset:]), "2020-09-13T12:26:40Z")
+}
+
+func TestParquetWriterEmpty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := NewWriter(buf, nil)
+	assert.NoError(t, writer.Close())
+
+	data := buf.Bytes()
+	assert.Equal(t, MAGIC, string(data[:4]))
+	assert.Equal(t, MAGIC, string(data[len(data)-4:]))
+}
+
+func TestParquetWriterSchema(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := NewWriter(buf, nil)
+	writer.SetSchema(result_sets.NewSchema([]*artifacts_proto.ColumnType{
+		{Name: "Time", Type: result_sets.COLUMN_TIMESTAMP},
+		{Name: "Size", Type: result_sets.COLUMN_INTEGER},
+	}))
+
+	assert.NoError(t, writer.Write(ordereddict.NewDict().
+		Set("Time", "2020-09-13T12:26:40Z").
+		Set("Size", "10")))
+	assert.NoError(t, writer.Write(ordereddict.NewDict().
+		Set("Time", int64(1600000001)).
+		Set("Size", "not a number")))
+	assert.NoError(t, writer.Close())
+
+	data := buf.Bytes()
+	length := binary.LittleEndian.Uint32(data[len(data)-8:])
+	footer := data[len(data)-8-int(length) : len(data)-8]
+	metadata := (&thriftReader{buf: bytes.NewReader(footer)}).Struct()
+
+	// Both columns are INT64 and the time is a TIMESTAMP_MICROS.
+	schema := metadata[2].([]interface{})
+	time_element := schema[1].(map[int64]interface{})
+	assert.Equal(t, int64(typeInt64), time_element[1])
+	assert.Equal(t, int64(convertedTypeTimestampMicros), time_element[6])
+
+	size_element := schema[2].(map[int64]interface{})
+	assert.Equal(t, int64(typeInt64), size_element[1])
+	assert.Nil(t, size_element[6])
+
+	// Decode the time column.
+	row_groups := metadata[4].([]interface{})
+	columns := row_groups[0].(map[int64]interface{})[1].([]interface{})
+	offset := columns[0].(map[int64]interface{})[2].(int64)
+
+	reader := &thriftReader{buf: bytes.NewReader(data[offset:])}
+	page_header := reader.Struct()
+	page_offset := int(offset) + len(data[offset:]) - reader.buf.Len()
+	page := data[page_offset : page_offset+int(page_header[3].(int64))]
+
+	levels_length := binary.LittleEndian.Uint32(page)
+	page = page[4+levels_length:]
+	assert.Equal(t, 16, len(page))
+	assert.Equal(t, uint64(1600000000000000), binary.LittleEndian.Uint64(page))
+	assert.Equal(t, uint64(1600000001000000), binary.LittleEndian.Uint64(page[8:]))
+
+	// The invalid size is null.
+	offset = columns[1].(map[int64]interface{})[2].(int64)
+	reader = &thriftReader{buf: bytes.NewReader(data[offset:])}
+	page_header = reader.Struct()
+	page_offset = int(offset) + len(data[offset:]) - reader.buf.Len()
+	page = data[page_offset : page_offset+int(page_header[3].(int64))]
+
+	levels_length = binary.LittleEndian.Uint32(page)
+	assert.Equal(t, []byte{1<<1 | 1, 0x01}, page[4:4+levels_length])
+	assert.Equal(t, uint64(10), binary.LittleEndian.Uint64(page[4+levels_length:]))
+}
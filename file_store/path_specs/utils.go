@@ -86,3 +86,20 @@ func DSFromGenericComponentList(components []string) api.DSPathSpec {
 	}
 	return pathspec
 }
+
+// Returns true if the path components are used as is when building
+// filenames. Callers that serialize a path spec need this to rebuild
+// the same path later.
+func IsSafe(path interface{}) bool {
+	switch t := path.(type) {
+	case DSPathSpec:
+		return t.is_safe
+	case *DSPathSpec:
+		return t.is_safe
+	case FSPathSpec:
+		return t.is_safe
+	case *FSPathSpec:
+		return t.is_safe
+	}
+	return false
+}
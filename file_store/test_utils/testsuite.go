@@ -33,6 +33,9 @@ type: INTERNAL
 name: Server.Internal.ClientDelete
 type: INTERNAL
 `, `
+name: Server.Internal.ClientKeyRotated
+type: INTERNAL
+`, `
 name: Server.Internal.Label
 type: INTERNAL
 `, `
@@ -54,6 +57,9 @@ type: SERVER_EVENT
 name: Server.Internal.ArtifactModification
 type: SERVER_EVENT
 `, `
+name: Server.Audit.Logs
+type: SERVER_EVENT
+`, `
 name: Server.Internal.FrontendMetrics
 type: INTERNAL
 `, `
@@ -194,6 +200,12 @@ func (self *TestSuite) TearDownTest() {
 		self.Sm.Close()
 	}
 
+	// Wait for services to flush their state before clearing the
+	// stores, otherwise it leaks into the next test.
+	if self.Wg != nil {
+		self.Wg.Wait()
+	}
+
 	// These may not be memory based in the test switched to other
 	// data stores.
 	file_store_factory, ok := file_store.GetFileStore(
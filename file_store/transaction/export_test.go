@@ -0,0 +1,16 @@
+package transaction
+
+// Flush the files and log the subjects without writing them, as if
+// the server crashed part way through the commit.
+func (self *Transaction) CommitJournalOnly() error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.done = true
+	subjects, err := self.journalSubjects()
+	if err != nil {
+		return err
+	}
+
+	return self.commitFiles(subjects)
+}
@@ -0,0 +1,147 @@
+package transaction
+
+import (
+	"errors"
+	"os"
+
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// A file store that journals the files written in a transaction
+// before writing them to the real file store.
+type JournalingFileStore struct {
+	tx *Transaction
+}
+
+func (self *JournalingFileStore) ReadFile(
+	filename api.FSPathSpec) (api.FileReader, error) {
+	return self.tx.file_store.ReadFile(filename)
+}
+
+func (self *JournalingFileStore) WriteFile(
+	filename api.FSPathSpec) (api.FileWriter, error) {
+	return self.WriteFileWithCompletion(filename, utils.BackgroundWriter)
+}
+
+func (self *JournalingFileStore) WriteFileWithCompletion(
+	filename api.FSPathSpec,
+	completion func()) (api.FileWriter, error) {
+	self.tx.mu.Lock()
+	defer self.tx.mu.Unlock()
+
+	err := self.tx.journalFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	writer, err := self.tx.file_store.WriteFileWithCompletion(
+		filename, completion)
+	if err != nil {
+		return nil, err
+	}
+
+	self.tx.writers = append(self.tx.writers, writer)
+
+	return &journalingWriter{FileWriter: writer, tx: self.tx, path: filename}, nil
+}
+
+func (self *JournalingFileStore) StatFile(
+	filename api.FSPathSpec) (api.FileInfo, error) {
+	return self.tx.file_store.StatFile(filename)
+}
+
+func (self *JournalingFileStore) ListDirectory(
+	dirname api.FSPathSpec) ([]api.FileInfo, error) {
+	return self.tx.file_store.ListDirectory(dirname)
+}
+
+func (self *JournalingFileStore) Delete(filename api.FSPathSpec) error {
+	self.tx.mu.Lock()
+	defer self.tx.mu.Unlock()
+
+	err := self.tx.journalFile(filename)
+	if err != nil {
+		return err
+	}
+
+	err = self.tx.file_store.Delete(filename)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return self.tx.updateFile(filename, func(file *journalFile) {
+		file.Created = true
+		file.Size = 0
+	})
+}
+
+func (self *JournalingFileStore) Move(src, dest api.FSPathSpec) error {
+	self.tx.mu.Lock()
+	defer self.tx.mu.Unlock()
+
+	err := self.tx.journalFile(src)
+	if err == nil {
+		err = self.tx.journalFile(dest)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = self.tx.file_store.Move(src, dest)
+	if err != nil {
+		return err
+	}
+
+	stat, err := self.tx.file_store.StatFile(dest)
+	if err != nil {
+		return err
+	}
+
+	// The destination holds the source's data now. It is only
+	// removed on rollback if both files were created in the
+	// transaction (e.g. a temporary file moved into place).
+	src_created := self.tx.files[journalKey(src)].Created
+	err = self.tx.updateFile(dest, func(file *journalFile) {
+		if !file.Created || !src_created {
+			file.Created = false
+			file.Size = stat.Size()
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return self.tx.updateFile(src, func(file *journalFile) {
+		file.Created = true
+		file.Size = 0
+	})
+}
+
+func (self *JournalingFileStore) Close() error {
+	return nil
+}
+
+type journalingWriter struct {
+	api.FileWriter
+	tx   *Transaction
+	path api.FSPathSpec
+}
+
+func (self *journalingWriter) Truncate() error {
+	self.tx.mu.Lock()
+	defer self.tx.mu.Unlock()
+
+	if self.tx.done {
+		return transactionDoneError
+	}
+
+	err := self.FileWriter.Truncate()
+	if err != nil {
+		return err
+	}
+
+	return self.tx.updateFile(self.path, func(file *journalFile) {
+		file.Size = 0
+	})
+}
@@ -0,0 +1,242 @@
+package transaction
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/file_store/path_specs"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+)
+
+// The journal is a JSONL file with one entry per line. Entries are
+// appended and flushed before the change they describe is made, so a
+// partial last line was never acted on and is ignored.
+
+var (
+	journal_id uint64
+)
+
+type journalFile struct {
+	Components []string     `json:"components"`
+	Type       api.PathType `json:"type"`
+	Safe       bool         `json:"safe,omitempty"`
+
+	// The file is truncated back to this size on rollback.
+	Size int64 `json:"size,omitempty"`
+
+	// The file did not exist before the transaction and is removed
+	// on rollback.
+	Created bool `json:"created,omitempty"`
+}
+
+func (self *journalFile) pathSpec() api.FSPathSpec {
+	if self.Safe {
+		return path_specs.NewSafeFilestorePath(self.Components...).
+			SetType(self.Type)
+	}
+	return path_specs.NewUnsafeFilestorePath(self.Components...).
+		SetType(self.Type)
+}
+
+func journalKey(path api.FSPathSpec) string {
+	if path_specs.IsSafe(path) {
+		return "safe:" + path.AsClientPath()
+	}
+	return path.AsClientPath()
+}
+
+type journalSubject struct {
+	Components []string     `json:"components"`
+	Type       api.PathType `json:"type"`
+	Safe       bool         `json:"safe,omitempty"`
+	Data       []byte       `json:"data"`
+
+	message proto.Message
+}
+
+func (self *journalSubject) pathSpec() api.DSPathSpec {
+	if self.Safe {
+		return path_specs.NewSafeDatastorePath(self.Components...).
+			SetType(self.Type)
+	}
+	return path_specs.NewUnsafeDatastorePath(self.Components...).
+		SetType(self.Type)
+}
+
+type journalEntry struct {
+	// The state to roll the file back to. Later entries for the
+	// same file replace earlier ones.
+	File *journalFile `json:"file,omitempty"`
+
+	// The files are flushed and the subjects are logged - the
+	// transaction is committed.
+	Subjects  []*journalSubject `json:"subjects,omitempty"`
+	Committed bool              `json:"committed,omitempty"`
+}
+
+// Each frontend keeps its journals in its own directory so it only
+// recovers its own transactions.
+func journalDirectory(config_obj *config_proto.Config) api.FSPathSpec {
+	return paths.TRANSACTIONS_ROOT.AddChild(
+		services.GetNodeName(config_obj.Frontend))
+}
+
+func newJournalPath(config_obj *config_proto.Config) api.FSPathSpec {
+	id := atomic.AddUint64(&journal_id, 1)
+	return journalDirectory(config_obj).AddChild(
+		fmt.Sprintf("%d-%d", time.Now().UnixNano(), id))
+}
+
+// Append the entry to the journal and flush it to storage. Must be
+// called with the lock held.
+func (self *Transaction) appendJournal(entry *journalEntry) error {
+	serialized, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if self.journal_path == nil {
+		self.journal_path = newJournalPath(self.config_obj)
+	}
+
+	fd, err := self.file_store.WriteFile(self.journal_path)
+	if err != nil {
+		return err
+	}
+
+	_, err = fd.Write(append(serialized, '\n'))
+	if err != nil {
+		fd.Close()
+		return err
+	}
+
+	err = fd.Flush()
+	if err != nil {
+		fd.Close()
+		return err
+	}
+
+	return fd.Close()
+}
+
+// Truncate the files back to their state before the transaction.
+func rollbackFiles(
+	file_store_factory api.FileStore, files map[string]*journalFile) error {
+	for _, file := range files {
+		path := file.pathSpec()
+		if file.Created {
+			err := file_store_factory.Delete(path)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			continue
+		}
+
+		err := api.TruncateFile(file_store_factory, path, file.Size)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Complete or roll back any transactions that were interrupted by a
+// crash. This must be called on startup before any new transactions
+// are started.
+func Recover(config_obj *config_proto.Config) error {
+	file_store_factory := file_store.GetFileStore(config_obj)
+	children, err := file_store_factory.ListDirectory(
+		journalDirectory(config_obj))
+	if err != nil {
+		// No journals
+		return nil
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+
+	for _, child := range children {
+		journal_path := child.PathSpec()
+		if journal_path.Type() != api.PATH_TYPE_FILESTORE_JSON {
+			continue
+		}
+
+		files, committed, err := readJournal(file_store_factory, journal_path)
+		if err != nil {
+			return err
+		}
+
+		if committed != nil {
+			logger.Info("<green>Transactions</>: Completing journal %v (%v subjects)",
+				journal_path.Base(), len(committed.Subjects))
+			err = setSubjects(config_obj, committed.Subjects)
+
+		} else {
+			logger.Info("<green>Transactions</>: Rolling back journal %v (%v files)",
+				journal_path.Base(), len(files))
+			err = rollbackFiles(file_store_factory, files)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = file_store_factory.Delete(journal_path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the journaled files and the commit entry if the
+// transaction was committed.
+func readJournal(
+	file_store_factory api.FileStore,
+	journal_path api.FSPathSpec) (
+	map[string]*journalFile, *journalEntry, error) {
+	fd, err := file_store_factory.ReadFile(journal_path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer fd.Close()
+
+	files := make(map[string]*journalFile)
+	var committed *journalEntry
+
+	reader := bufio.NewReader(fd)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			// A partial line was never flushed completely.
+			break
+		}
+
+		entry := &journalEntry{}
+		err = json.Unmarshal(line, entry)
+		if err != nil {
+			break
+		}
+
+		if entry.File != nil {
+			files[journalKey(entry.File.pathSpec())] = entry.File
+		}
+
+		if entry.Committed {
+			committed = entry
+		}
+	}
+
+	return files, committed, nil
+}
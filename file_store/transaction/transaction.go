@@ -0,0 +1,360 @@
+/*
+  A lightweight transaction for the file store.
+
+  A collection writes its results, their row index, its logs and its
+  stats to separate files. If the server crashes part way through
+  writing them, the collection is left inconsistent - for example the
+  stats may claim more rows than the result set holds, or the index
+  may point past the end of the data.
+
+  Writes through the Transaction's file store go directly to the real
+  file store. Before a file is first written, the transaction appends
+  its path and size to a journal. Commit() flushes the files, logs
+  the datastore subjects staged in the transaction, writes them and
+  finally removes the journal.
+
+  If the server crashes before the subjects are logged, Recover()
+  truncates the files back to their journaled sizes on the next
+  start. Once they are logged, Recover() writes the subjects
+  instead. Either all the writes of the transaction are visible or
+  none are.
+
+  Only appends can be rolled back - a file which is truncated,
+  deleted or replaced in the transaction is rolled back to its new
+  state. Subjects are held in memory until the commit so they should
+  be small (e.g. the stats of a collection).
+*/
+
+package transaction
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/file_store/path_specs"
+)
+
+var (
+	transactionDoneError = errors.New("Transaction is already done")
+)
+
+type stagedSubject struct {
+	path    api.DSPathSpec
+	message proto.Message
+}
+
+type Transaction struct {
+	mu sync.Mutex
+
+	config_obj *config_proto.Config
+	file_store api.FileStore
+
+	// The journal is created when the first file is written.
+	journal_path api.FSPathSpec
+
+	// The journaled state of the files written in the transaction.
+	files map[string]*journalFile
+
+	// Writers opened in the transaction are flushed on commit.
+	writers []api.FileWriter
+
+	// Staged subjects in the order they were first set.
+	subjects      map[string]*stagedSubject
+	subject_order []string
+
+	// Called after the transaction is committed.
+	on_commit []func()
+
+	done bool
+}
+
+func NewTransaction(config_obj *config_proto.Config) *Transaction {
+	return &Transaction{
+		config_obj: config_obj,
+		file_store: file_store.GetFileStore(config_obj),
+		files:      make(map[string]*journalFile),
+		subjects:   make(map[string]*stagedSubject),
+	}
+}
+
+// A file store that journals all writes in the transaction.
+func (self *Transaction) FileStore() api.FileStore {
+	return &JournalingFileStore{tx: self}
+}
+
+// Record the state of the file before the transaction first touches
+// it. Must be called with the lock held.
+func (self *Transaction) journalFile(filename api.FSPathSpec) error {
+	if self.done {
+		return transactionDoneError
+	}
+
+	key := journalKey(filename)
+	_, pres := self.files[key]
+	if pres {
+		return nil
+	}
+
+	file := &journalFile{
+		Components: filename.Components(),
+		Type:       filename.Type(),
+		Safe:       path_specs.IsSafe(filename),
+	}
+
+	stat, err := self.file_store.StatFile(filename)
+	if err == nil {
+		file.Size = stat.Size()
+
+	} else if errors.Is(err, os.ErrNotExist) {
+		file.Created = true
+
+	} else {
+		return err
+	}
+
+	err = self.appendJournal(&journalEntry{File: file})
+	if err != nil {
+		return err
+	}
+
+	self.files[key] = file
+	return nil
+}
+
+// The file was truncated, deleted or replaced in the transaction so
+// it can only be rolled back to its new state. Must be called with
+// the lock held.
+func (self *Transaction) updateFile(
+	filename api.FSPathSpec, cb func(file *journalFile)) error {
+	file, pres := self.files[journalKey(filename)]
+	if !pres {
+		return nil
+	}
+
+	updated := *file
+	cb(&updated)
+
+	err := self.appendJournal(&journalEntry{File: &updated})
+	if err != nil {
+		return err
+	}
+
+	*file = updated
+	return nil
+}
+
+// Stage a datastore write.
+func (self *Transaction) SetSubject(
+	urn api.DSPathSpec, message proto.Message) error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.done {
+		return transactionDoneError
+	}
+
+	key := urn.String()
+	_, pres := self.subjects[key]
+	if !pres {
+		self.subject_order = append(self.subject_order, key)
+	}
+
+	// Take a copy in case the caller modifies the message before
+	// the commit.
+	self.subjects[key] = &stagedSubject{
+		path:    urn,
+		message: proto.Clone(message),
+	}
+	return nil
+}
+
+// Register a callback to run once the transaction is committed. This
+// is useful for sending notifications about the written data.
+func (self *Transaction) OnCommit(cb func()) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.on_commit = append(self.on_commit, cb)
+}
+
+func (self *Transaction) IsEmpty() bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return len(self.files) == 0 && len(self.subjects) == 0
+}
+
+// Atomically commit the written files together with the staged
+// subjects.
+func (self *Transaction) Commit() error {
+	self.mu.Lock()
+	if self.done {
+		self.mu.Unlock()
+		return transactionDoneError
+	}
+	self.done = true
+
+	subjects, err := self.journalSubjects()
+	if err == nil {
+		err = self.commitFiles(subjects)
+	}
+	on_commit := self.on_commit
+	self.mu.Unlock()
+
+	if err != nil {
+		// Leave the journal in place so the transaction is
+		// completed or rolled back by Recover() on the next
+		// start.
+		return err
+	}
+
+	err = setSubjects(self.config_obj, subjects)
+	if err != nil {
+		return err
+	}
+
+	if self.journal_path != nil {
+		err = self.file_store.Delete(self.journal_path)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, cb := range on_commit {
+		cb()
+	}
+	return nil
+}
+
+// Flush the files and log the subjects. From here on the transaction
+// is committed. Must be called with the lock held.
+func (self *Transaction) commitFiles(subjects []*journalSubject) error {
+	if self.journal_path == nil {
+		return nil
+	}
+
+	err := self.flushWriters()
+	if err != nil {
+		return err
+	}
+
+	return self.appendJournal(&journalEntry{
+		Subjects:  subjects,
+		Committed: true,
+	})
+}
+
+// Roll back all the writes of the transaction.
+func (self *Transaction) Abort() error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.done {
+		return nil
+	}
+	self.done = true
+
+	if self.journal_path == nil {
+		return nil
+	}
+
+	// Make sure nothing is flushed after we truncate the files.
+	err := self.flushWriters()
+	if err != nil {
+		return err
+	}
+
+	err = rollbackFiles(self.file_store, self.files)
+	if err != nil {
+		return err
+	}
+
+	return self.file_store.Delete(self.journal_path)
+}
+
+// Must be called with the lock held.
+func (self *Transaction) flushWriters() error {
+	for _, writer := range self.writers {
+		err := writer.Flush()
+		if err != nil {
+			return err
+		}
+	}
+	self.writers = nil
+	return nil
+}
+
+// Serialize the staged subjects for the journal. Must be called with
+// the lock held.
+func (self *Transaction) journalSubjects() ([]*journalSubject, error) {
+	result := make([]*journalSubject, 0, len(self.subject_order))
+	for _, key := range self.subject_order {
+		subject := self.subjects[key]
+		data, err := encodeSubject(subject.path, subject.message)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, &journalSubject{
+			Components: subject.path.Components(),
+			Type:       subject.path.Type(),
+			Safe:       path_specs.IsSafe(subject.path),
+			Data:       data,
+			message:    subject.message,
+		})
+	}
+
+	return result, nil
+}
+
+// Serialize the message the same way the datastore does so it can be
+// replayed as a raw buffer.
+func encodeSubject(urn api.DSPathSpec, message proto.Message) ([]byte, error) {
+	if urn.Type() == api.PATH_TYPE_DATASTORE_JSON {
+		return protojson.Marshal(message)
+	}
+	return proto.Marshal(message)
+}
+
+// Apply the subjects directly through the datastore so caches are
+// updated.
+func setSubjects(config_obj *config_proto.Config, subjects []*journalSubject) error {
+	if len(subjects) == 0 {
+		return nil
+	}
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	for _, subject := range subjects {
+		if subject.message != nil {
+			err = db.SetSubjectWithCompletion(
+				config_obj, subject.pathSpec(), subject.message, nil)
+		} else {
+			err = setRawSubject(config_obj, db, subject)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setRawSubject(config_obj *config_proto.Config,
+	db datastore.DataStore, subject *journalSubject) error {
+	raw_db, ok := db.(datastore.RawDataStore)
+	if !ok {
+		return errors.New("Datastore does not support raw writes")
+	}
+
+	return raw_db.SetBuffer(config_obj, subject.pathSpec(), subject.Data, nil)
+}
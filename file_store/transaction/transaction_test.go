@@ -0,0 +1,198 @@
+package transaction_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/file_store/path_specs"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/file_store/transaction"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+type TransactionTestSuite struct {
+	test_utils.TestSuite
+
+	result_path api.FSPathSpec
+}
+
+func (self *TransactionTestSuite) SetupTest() {
+	self.TestSuite.SetupTest()
+
+	self.result_path = path_specs.NewUnsafeFilestorePath(
+		"clients", "C.1234", "F.1234", "results")
+
+	// Start with two committed rows.
+	self.writeRows(file_store.GetFileStore(self.ConfigObj), 0, 2)
+}
+
+func (self *TransactionTestSuite) writeRows(
+	file_store_factory api.FileStore, start, end int) {
+	rs_writer, err := result_sets.NewResultSetWriter(
+		file_store_factory, self.result_path, json.DefaultEncOpts(),
+		utils.SyncCompleter, result_sets.AppendMode)
+	assert.NoError(self.T(), err)
+	defer rs_writer.Close()
+
+	for i := start; i < end; i++ {
+		rs_writer.Write(ordereddict.NewDict().Set("Row", i))
+	}
+}
+
+func (self *TransactionTestSuite) readRows(
+	file_store_factory api.FileStore) []int64 {
+	rs_reader, err := result_sets.NewResultSetReader(
+		file_store_factory, self.result_path)
+	assert.NoError(self.T(), err)
+	defer rs_reader.Close()
+
+	result := []int64{}
+	for row := range rs_reader.Rows(context.Background()) {
+		value, _ := row.GetInt64("Row")
+		result = append(result, value)
+	}
+	return result
+}
+
+func (self *TransactionTestSuite) stats() *flows_proto.ArtifactCollectorContext {
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	stats := &flows_proto.ArtifactCollectorContext{}
+	_ = db.GetSubject(self.ConfigObj,
+		paths.NewFlowPathManager("C.1234", "F.1234").Stats(), stats)
+	return stats
+}
+
+func (self *TransactionTestSuite) journals() []api.FileInfo {
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+	children, _ := file_store_factory.ListDirectory(
+		paths.TRANSACTIONS_ROOT.AddChild(
+			services.GetNodeName(self.ConfigObj.Frontend)))
+	return children
+}
+
+func (self *TransactionTestSuite) journalData() string {
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+
+	result := ""
+	for _, journal := range self.journals() {
+		fd, err := file_store_factory.ReadFile(journal.PathSpec())
+		assert.NoError(self.T(), err)
+
+		data, err := ioutil.ReadAll(fd)
+		assert.NoError(self.T(), err)
+		fd.Close()
+
+		result += string(data)
+	}
+	return result
+}
+
+func (self *TransactionTestSuite) TestCommit() {
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+
+	tx := transaction.NewTransaction(self.ConfigObj)
+	self.writeRows(tx.FileStore(), 2, 4)
+	assert.NoError(self.T(), tx.SetSubject(
+		paths.NewFlowPathManager("C.1234", "F.1234").Stats(),
+		&flows_proto.ArtifactCollectorContext{TotalCollectedRows: 4}))
+
+	committed := false
+	tx.OnCommit(func() { committed = true })
+
+	// The rows are written directly but the stats are staged until
+	// the commit.
+	assert.Equal(self.T(), []int64{0, 1, 2, 3}, self.readRows(file_store_factory))
+	assert.Equal(self.T(), uint64(0), self.stats().TotalCollectedRows)
+
+	// Only the files are journaled - not their data.
+	assert.Equal(self.T(), 1, len(self.journals()))
+	assert.NotContains(self.T(), self.journalData(), "Row")
+
+	assert.NoError(self.T(), tx.Commit())
+	assert.True(self.T(), committed)
+
+	assert.Equal(self.T(), []int64{0, 1, 2, 3}, self.readRows(file_store_factory))
+	assert.Equal(self.T(), uint64(4), self.stats().TotalCollectedRows)
+
+	// The journal is removed after the commit.
+	assert.Equal(self.T(), 0, len(self.journals()))
+
+	// The transaction can not be reused.
+	assert.Error(self.T(), tx.Commit())
+}
+
+func (self *TransactionTestSuite) TestAbort() {
+	tx := transaction.NewTransaction(self.ConfigObj)
+	self.writeRows(tx.FileStore(), 2, 4)
+	assert.NoError(self.T(), tx.Abort())
+
+	// The written rows are rolled back.
+	assert.Equal(self.T(), []int64{0, 1},
+		self.readRows(file_store.GetFileStore(self.ConfigObj)))
+	assert.Equal(self.T(), 0, len(self.journals()))
+}
+
+func (self *TransactionTestSuite) TestRecover() {
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+
+	tx := transaction.NewTransaction(self.ConfigObj)
+	self.writeRows(tx.FileStore(), 2, 4)
+	assert.NoError(self.T(), tx.SetSubject(
+		paths.NewFlowPathManager("C.1234", "F.1234").Stats(),
+		&flows_proto.ArtifactCollectorContext{TotalCollectedRows: 4}))
+
+	// Crash before the commit after part of another row is
+	// appended.
+	fd, err := file_store_factory.WriteFile(self.result_path)
+	assert.NoError(self.T(), err)
+	_, err = fd.Write([]byte(`{"Row":`))
+	assert.NoError(self.T(), err)
+	fd.Close()
+
+	// Recovering rolls back the transaction.
+	assert.NoError(self.T(), transaction.Recover(self.ConfigObj))
+
+	assert.Equal(self.T(), []int64{0, 1}, self.readRows(file_store_factory))
+	assert.Equal(self.T(), uint64(0), self.stats().TotalCollectedRows)
+	assert.Equal(self.T(), 0, len(self.journals()))
+}
+
+func (self *TransactionTestSuite) TestRecoverCommitted() {
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+
+	tx := transaction.NewTransaction(self.ConfigObj)
+	self.writeRows(tx.FileStore(), 2, 4)
+	assert.NoError(self.T(), tx.SetSubject(
+		paths.NewFlowPathManager("C.1234", "F.1234").Stats(),
+		&flows_proto.ArtifactCollectorContext{TotalCollectedRows: 4}))
+
+	// Crash after the subjects are logged but before they are
+	// written.
+	assert.NoError(self.T(), tx.CommitJournalOnly())
+	assert.Equal(self.T(), uint64(0), self.stats().TotalCollectedRows)
+
+	// Recovering completes the transaction.
+	assert.NoError(self.T(), transaction.Recover(self.ConfigObj))
+
+	assert.Equal(self.T(), []int64{0, 1, 2, 3}, self.readRows(file_store_factory))
+	assert.Equal(self.T(), uint64(4), self.stats().TotalCollectedRows)
+	assert.Equal(self.T(), 0, len(self.journals()))
+}
+
+func TestTransaction(t *testing.T) {
+	suite.Run(t, &TransactionTestSuite{})
+}
@@ -0,0 +1,256 @@
+package archive_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/flows/archive"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/launcher"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	client_id = "C.1234"
+	flow_id   = "F.1"
+	hunt_id   = "H.1"
+	artifact  = "Generic.Client.Info/BasicInformation"
+)
+
+type ArchiveTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *ArchiveTestSuite) SetupTest() {
+	self.ConfigObj = self.TestSuite.LoadConfig()
+	self.ConfigObj.Services.HuntDispatcher = true
+
+	self.TestSuite.SetupTest()
+
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	hunt_obj := &api_proto.Hunt{
+		HuntId:          hunt_id,
+		State:           api_proto.Hunt_RUNNING,
+		ArtifactSources: []string{artifact},
+	}
+	hunt_path_manager := paths.NewHuntPathManager(hunt_id)
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj,
+		hunt_path_manager.Path(), hunt_obj))
+
+	self.writeResultSet(hunt_path_manager.Clients(), ordereddict.NewDict().
+		Set("HuntId", hunt_id).
+		Set("ClientId", client_id).
+		Set("FlowId", flow_id))
+
+	self.writeResultSet(hunt_path_manager.Results(artifact),
+		ordereddict.NewDict().
+			Set("Hostname", "MyHost").
+			Set("FlowId", flow_id).
+			Set("ClientId", client_id))
+
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj,
+		paths.NewClientPathManager(client_id).Path(),
+		&actions_proto.ClientInfo{
+			ClientId: client_id,
+			Hostname: "MyHost",
+		}))
+
+	flow_path_manager := paths.NewFlowPathManager(client_id, flow_id)
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj,
+		flow_path_manager.Path(), &flows_proto.ArtifactCollectorContext{
+			ClientId:             client_id,
+			SessionId:            flow_id,
+			Request:              &flows_proto.ArtifactCollectorArgs{Creator: hunt_id},
+			ArtifactsWithResults: []string{artifact},
+			State:                flows_proto.ArtifactCollectorContext_FINISHED,
+		}))
+
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj,
+		flow_path_manager.Task(), &api_proto.ApiFlowRequestDetails{
+			Items: []*crypto_proto.VeloMessage{{SessionId: flow_id}},
+		}))
+
+	self.writeResultSet(flow_path_manager.Log(),
+		ordereddict.NewDict().Set("message", "Hello"))
+
+	path_manager, err := artifacts.NewArtifactPathManager(
+		self.ConfigObj, client_id, flow_id, artifact)
+	assert.NoError(self.T(), err)
+	self.writeResultSet(path_manager.Path(),
+		ordereddict.NewDict().Set("Hostname", "MyHost"))
+
+	upload := flow_path_manager.GetUploadsFile("file", "/etc/passwd")
+	self.writeResultSet(flow_path_manager.UploadMetadata(),
+		ordereddict.NewDict().
+			Set("vfs_path", "/etc/passwd").
+			Set("_Components", upload.Path().Components()))
+
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+	fd, err := file_store_factory.WriteFile(upload.Path())
+	assert.NoError(self.T(), err)
+	_, err = fd.Write([]byte("root:x:0:0"))
+	assert.NoError(self.T(), err)
+	fd.Close()
+}
+
+func (self *ArchiveTestSuite) writeResultSet(
+	path_spec api.FSPathSpec, rows ...*ordereddict.Dict) {
+	writer, err := result_sets.NewResultSetWriter(
+		file_store.GetFileStore(self.ConfigObj), path_spec,
+		json.DefaultEncOpts(), utils.SyncCompleter, result_sets.TruncateMode)
+	assert.NoError(self.T(), err)
+	defer writer.Close()
+
+	for _, row := range rows {
+		writer.Write(row)
+	}
+}
+
+func (self *ArchiveTestSuite) TestHuntRemap() {
+	buf := &bytes.Buffer{}
+	err := archive.ExportHunt(self.Ctx, self.ConfigObj, buf, hunt_id)
+	assert.NoError(self.T(), err)
+
+	reader := bytes.NewReader(buf.Bytes())
+
+	// The hunt and flow already exist on this server.
+	_, err = archive.Import(self.Ctx, self.ConfigObj,
+		reader, int64(buf.Len()), archive.ImportOptions{})
+	assert.True(self.T(), errors.Is(err, archive.AlreadyExistsError))
+
+	result, err := archive.Import(self.Ctx, self.ConfigObj,
+		reader, int64(buf.Len()), archive.ImportOptions{Remap: true})
+	assert.NoError(self.T(), err)
+
+	assert.NotEqual(self.T(), hunt_id, result.HuntId)
+	assert.Equal(self.T(), hunt_id, result.OriginalHuntId)
+	assert.Equal(self.T(), 1, len(result.Flows))
+
+	new_flow_id := result.Flows[0].FlowId
+	assert.NotEqual(self.T(), flow_id, new_flow_id)
+	assert.Equal(self.T(), flow_id, result.Flows[0].OriginalFlowId)
+
+	// The imported hunt must not schedule any new collections.
+	dispatcher, err := services.GetHuntDispatcher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	hunt_obj, pres := dispatcher.GetHunt(result.HuntId)
+	assert.True(self.T(), pres)
+	assert.Equal(self.T(), api_proto.Hunt_STOPPED, hunt_obj.State)
+
+	// Participation and merged results refer to the new ids.
+	hunt_path_manager := paths.NewHuntPathManager(result.HuntId)
+	rows := test_utils.FileReadRows(self.T(), self.ConfigObj,
+		hunt_path_manager.Clients())
+	assert.Equal(self.T(), 1, len(rows))
+	assert.Equal(self.T(), result.HuntId, utils.GetString(rows[0], "HuntId"))
+	assert.Equal(self.T(), new_flow_id, utils.GetString(rows[0], "FlowId"))
+
+	rows = test_utils.FileReadRows(self.T(), self.ConfigObj,
+		hunt_path_manager.Results(artifact))
+	assert.Equal(self.T(), 1, len(rows))
+	assert.Equal(self.T(), new_flow_id, utils.GetString(rows[0], "FlowId"))
+
+	collection_context, err := launcher.LoadCollectionContext(
+		self.ConfigObj, client_id, new_flow_id)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), result.HuntId, collection_context.Request.Creator)
+
+	self.checkFlow(client_id, new_flow_id)
+}
+
+func (self *ArchiveTestSuite) TestFlowPreserveIds() {
+	buf := &bytes.Buffer{}
+	err := archive.ExportFlow(self.Ctx, self.ConfigObj, buf, client_id, flow_id)
+	assert.NoError(self.T(), err)
+
+	// Remove the flow and the client so the import recreates them.
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	assert.NoError(self.T(), db.DeleteSubject(self.ConfigObj,
+		paths.NewFlowPathManager(client_id, flow_id).Path()))
+	assert.NoError(self.T(), db.DeleteSubject(self.ConfigObj,
+		paths.NewClientPathManager(client_id).Path()))
+
+	result, err := archive.Import(self.Ctx, self.ConfigObj,
+		bytes.NewReader(buf.Bytes()), int64(buf.Len()),
+		archive.ImportOptions{})
+	assert.NoError(self.T(), err)
+
+	assert.Equal(self.T(), "", result.HuntId)
+	assert.Equal(self.T(), 1, len(result.Flows))
+	assert.Equal(self.T(), flow_id, result.Flows[0].FlowId)
+
+	client_info := &actions_proto.ClientInfo{}
+	assert.NoError(self.T(), db.GetSubject(self.ConfigObj,
+		paths.NewClientPathManager(client_id).Path(), client_info))
+	assert.Equal(self.T(), "MyHost", client_info.Hostname)
+
+	self.checkFlow(client_id, flow_id)
+}
+
+func (self *ArchiveTestSuite) checkFlow(client_id, flow_id string) {
+	flow_path_manager := paths.NewFlowPathManager(client_id, flow_id)
+
+	collection_context, err := launcher.LoadCollectionContext(
+		self.ConfigObj, client_id, flow_id)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), flow_id, collection_context.SessionId)
+
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	tasks := &api_proto.ApiFlowRequestDetails{}
+	assert.NoError(self.T(), db.GetSubject(self.ConfigObj,
+		flow_path_manager.Task(), tasks))
+	assert.Equal(self.T(), flow_id, tasks.Items[0].SessionId)
+
+	rows := test_utils.FileReadRows(self.T(), self.ConfigObj,
+		flow_path_manager.Log())
+	assert.Equal(self.T(), 1, len(rows))
+
+	path_manager, err := artifacts.NewArtifactPathManager(
+		self.ConfigObj, client_id, flow_id, artifact)
+	assert.NoError(self.T(), err)
+	rows = test_utils.FileReadRows(self.T(), self.ConfigObj,
+		path_manager.Path())
+	assert.Equal(self.T(), 1, len(rows))
+	assert.Equal(self.T(), "MyHost", utils.GetString(rows[0], "Hostname"))
+
+	// The upload metadata points at the imported file.
+	rows = test_utils.FileReadRows(self.T(), self.ConfigObj,
+		flow_path_manager.UploadMetadata())
+	assert.Equal(self.T(), 1, len(rows))
+
+	components, pres := rows[0].GetStrings("_Components")
+	assert.True(self.T(), pres)
+
+	upload := flow_path_manager.GetUploadsFile("file", "/etc/passwd")
+	assert.Equal(self.T(), upload.Path().Components(), components)
+
+	assert.Equal(self.T(), "root:x:0:0",
+		test_utils.FileReadAll(self.T(), self.ConfigObj, upload.Path()))
+}
+
+func TestArchive(t *testing.T) {
+	suite.Run(t, &ArchiveTestSuite{})
+}
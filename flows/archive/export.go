@@ -0,0 +1,424 @@
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	artifact_paths "www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/launcher"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+type exporter struct {
+	ctx        context.Context
+	config_obj *config_proto.Config
+	file_store api.FileStore
+	db         datastore.DataStore
+
+	zip      *zip.Writer
+	manifest *Manifest
+}
+
+func newExporter(
+	ctx context.Context,
+	config_obj *config_proto.Config, out io.Writer,
+	archive_type string) (*exporter, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exporter{
+		ctx:        ctx,
+		config_obj: config_obj,
+		file_store: file_store.GetFileStore(config_obj),
+		db:         db,
+		zip:        zip.NewWriter(out),
+		manifest: &Manifest{
+			Version:    ARCHIVE_VERSION,
+			Type:       archive_type,
+			ExportTime: utils.GetTime().Now().Unix(),
+			Clients:    make(map[string]string),
+		},
+	}, nil
+}
+
+// Write the manifest and finish the zip file. The underlying writer
+// is not closed.
+func (self *exporter) Close() error {
+	serialized, err := json.MarshalIndent(self.manifest)
+	if err != nil {
+		return err
+	}
+
+	err = self.writeMember(MANIFEST_NAME, serialized)
+	if err != nil {
+		return err
+	}
+
+	return self.zip.Close()
+}
+
+// Export a single flow into an archive written to out.
+func ExportFlow(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	out io.Writer, client_id, flow_id string) error {
+
+	self, err := newExporter(ctx, config_obj, out, ARCHIVE_TYPE_FLOW)
+	if err != nil {
+		return err
+	}
+
+	err = self.addFlow(client_id, flow_id)
+	if err != nil {
+		return err
+	}
+
+	return self.Close()
+}
+
+// Export a hunt together with all its flows into an archive written
+// to out.
+func ExportHunt(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	out io.Writer, hunt_id string) error {
+
+	self, err := newExporter(ctx, config_obj, out, ARCHIVE_TYPE_HUNT)
+	if err != nil {
+		return err
+	}
+
+	// Read the hunt from the datastore rather than the hunt
+	// dispatcher so hunts can be exported by offline tools.
+	hunt_path_manager := paths.NewHuntPathManager(hunt_id)
+	hunt_obj := &api_proto.Hunt{}
+	err = self.db.GetSubject(config_obj, hunt_path_manager.Path(), hunt_obj)
+	if err != nil || hunt_obj.HuntId != hunt_id {
+		return fmt.Errorf("Unknown hunt %v", hunt_id)
+	}
+
+	self.manifest.HuntId = hunt_id
+	err = self.writeProto("hunt.json", hunt_obj)
+	if err != nil {
+		return err
+	}
+
+	_, err = self.copyResultSet(hunt_path_manager.Clients(), "hunt_clients.json")
+	if err != nil {
+		return err
+	}
+
+	for _, artifact_source := range hunt_obj.ArtifactSources {
+		member := fmt.Sprintf("hunt_results/%d.json", len(self.manifest.HuntResults))
+		ok, err := self.copyResultSet(
+			hunt_path_manager.Results(artifact_source), member)
+		if err != nil {
+			return err
+		}
+		if ok {
+			self.manifest.HuntResults = append(self.manifest.HuntResults,
+				&ResultSetEntry{Artifact: artifact_source, Member: member})
+		}
+	}
+
+	flows, err := getHuntFlows(ctx, config_obj, hunt_id)
+	if err != nil {
+		return err
+	}
+
+	for _, flow := range flows {
+		err = self.addFlow(flow.ClientId, flow.FlowId)
+		if err != nil {
+			// The collection may never have been scheduled.
+			if errors.Is(err, errFlowNotFound) {
+				continue
+			}
+			return err
+		}
+	}
+
+	return self.Close()
+}
+
+// Export all the collections of a client into an archive written to
+// out.
+func ExportClient(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	out io.Writer, client_id string) error {
+
+	self, err := newExporter(ctx, config_obj, out, ARCHIVE_TYPE_CLIENT)
+	if err != nil {
+		return err
+	}
+
+	launcher, err := services.GetLauncher(config_obj)
+	if err != nil {
+		return err
+	}
+
+	flows, err := launcher.GetFlows(config_obj, client_id,
+		true, nil, 0, math.MaxUint32)
+	if err != nil {
+		return err
+	}
+
+	for _, flow := range flows.Items {
+		err = self.addFlow(client_id, flow.SessionId)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Keep the client record even if it has no collections.
+	err = self.addClient(client_id)
+	if err != nil {
+		return err
+	}
+
+	return self.Close()
+}
+
+var errFlowNotFound = errors.New("Flow not found")
+
+func (self *exporter) addFlow(client_id, flow_id string) error {
+
+	collection_context, err := launcher.LoadCollectionContext(
+		self.config_obj, client_id, flow_id)
+	if err != nil {
+		return fmt.Errorf("%w: %v %v", errFlowNotFound, client_id, flow_id)
+	}
+
+	prefix := flowPrefix(client_id, flow_id)
+	entry := &FlowEntry{
+		ClientId: client_id,
+		FlowId:   flow_id,
+	}
+
+	err = self.writeProto(prefix+"collection_context.json", collection_context)
+	if err != nil {
+		return err
+	}
+
+	flow_path_manager := paths.NewFlowPathManager(client_id, flow_id)
+	tasks := &api_proto.ApiFlowRequestDetails{}
+	err = self.db.GetSubject(self.config_obj, flow_path_manager.Task(), tasks)
+	if err == nil {
+		err = self.writeProto(prefix+"requests.json", tasks)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = self.copyResultSet(flow_path_manager.Log(), prefix+"logs.json")
+	if err != nil {
+		return err
+	}
+
+	for _, artifact := range collection_context.ArtifactsWithResults {
+		mode := getArtifactMode(self.config_obj, client_id, artifact)
+		path_manager := artifact_paths.NewArtifactPathManagerWithMode(
+			self.config_obj, client_id, flow_id, artifact, mode)
+
+		member := fmt.Sprintf("%sresults/%d.json", prefix, len(entry.Results))
+		ok, err := self.copyResultSet(path_manager.Path(), member)
+		if err != nil {
+			return err
+		}
+		if ok {
+			entry.Results = append(entry.Results, &ResultSetEntry{
+				Artifact: artifact,
+				Mode:     mode,
+				Member:   member,
+			})
+		}
+	}
+
+	_, err = self.copyResultSet(flow_path_manager.UploadMetadata(),
+		prefix+"uploads.json")
+	if err != nil {
+		return err
+	}
+
+	// Store all the files in the upload directory - this includes
+	// the indexes of sparse files.
+	upload_root := flow_path_manager.UploadContainer()
+	root_len := len(upload_root.Components())
+	err = api.Walk(self.file_store, upload_root,
+		func(urn api.FSPathSpec, info os.FileInfo) error {
+			member := fmt.Sprintf("%suploads/%d", prefix, len(entry.Uploads))
+			_, err := self.copyFile(urn, member)
+			if err != nil {
+				return err
+			}
+
+			entry.Uploads = append(entry.Uploads, &UploadEntry{
+				Components: utils.CopySlice(urn.Components()[root_len:]),
+				Type:       urn.Type(),
+				Member:     member,
+			})
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	err = self.addClient(client_id)
+	if err != nil {
+		return err
+	}
+
+	self.manifest.Flows = append(self.manifest.Flows, entry)
+	return nil
+}
+
+func (self *exporter) addClient(client_id string) error {
+	if client_id == "server" {
+		return nil
+	}
+
+	_, pres := self.manifest.Clients[client_id]
+	if pres {
+		return nil
+	}
+
+	client_info := &actions_proto.ClientInfo{}
+	client_path_manager := paths.NewClientPathManager(client_id)
+	err := self.db.GetSubject(self.config_obj,
+		client_path_manager.Path(), client_info)
+	if err != nil || client_info.ClientId == "" {
+		return nil
+	}
+
+	member := "clients/" + client_id + ".json"
+	err = self.writeProto(member, client_info)
+	if err != nil {
+		return err
+	}
+
+	self.manifest.Clients[client_id] = member
+	return nil
+}
+
+// Copy a result set and its index into the archive. Returns false
+// if the result set does not exist.
+func (self *exporter) copyResultSet(
+	path api.FSPathSpec, member string) (bool, error) {
+	ok, err := self.copyFile(path, member)
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	_, err = self.copyFile(
+		path.SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX), member+".index")
+	return true, err
+}
+
+// Copy a file store file into the archive. Returns false if the file
+// does not exist.
+func (self *exporter) copyFile(
+	path api.FSPathSpec, member string) (bool, error) {
+	fd, err := self.file_store.ReadFile(path)
+	if err != nil {
+		return false, nil
+	}
+	defer fd.Close()
+
+	out_fd, err := self.zip.Create(member)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = utils.Copy(self.ctx, out_fd, fd)
+	return true, err
+}
+
+func (self *exporter) writeProto(member string, message proto.Message) error {
+	serialized, err := protojson.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return self.writeMember(member, serialized)
+}
+
+func (self *exporter) writeMember(member string, data []byte) error {
+	out_fd, err := self.zip.Create(member)
+	if err != nil {
+		return err
+	}
+
+	_, err = out_fd.Write(data)
+	return err
+}
+
+// The mode decides where the results are stored. Artifacts removed
+// since the collection are assumed to be regular collections.
+func getArtifactMode(
+	config_obj *config_proto.Config, client_id, artifact string) int {
+	artifact_name, _ := paths.SplitFullSourceName(artifact)
+	mode, err := artifact_paths.GetArtifactMode(config_obj, artifact_name)
+	if err == nil && (mode == paths.MODE_CLIENT || mode == paths.MODE_SERVER) {
+		return mode
+	}
+
+	if client_id == "server" {
+		return paths.MODE_SERVER
+	}
+	return paths.MODE_CLIENT
+}
+
+type huntFlow struct {
+	ClientId string
+	FlowId   string
+}
+
+// Read the hunt's participation records.
+func getHuntFlows(
+	ctx context.Context,
+	config_obj *config_proto.Config, hunt_id string) ([]huntFlow, error) {
+	file_store_factory := file_store.GetFileStore(config_obj)
+	reader, err := result_sets.NewResultSetReader(file_store_factory,
+		paths.NewHuntPathManager(hunt_id).Clients())
+	if err != nil {
+		// No clients were scheduled yet.
+		return nil, nil
+	}
+	defer reader.Close()
+
+	var result []huntFlow
+	seen := make(map[huntFlow]bool)
+	for row := range reader.Rows(ctx) {
+		client_id, _ := row.GetString("ClientId")
+		flow_id, _ := row.GetString("FlowId")
+		if client_id == "" || flow_id == "" {
+			continue
+		}
+
+		flow := huntFlow{ClientId: client_id, FlowId: flow_id}
+		if !seen[flow] {
+			seen[flow] = true
+			result = append(result, flow)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,594 @@
+package archive
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	artifact_paths "www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/hunt_dispatcher"
+	"www.velocidex.com/golang/velociraptor/services/launcher"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	AlreadyExistsError = errors.New("Already exists")
+)
+
+type ImportOptions struct {
+	// Give the imported flows and hunt new ids. Otherwise the
+	// original ids are kept and the import fails if any of them
+	// already exist.
+	Remap bool
+}
+
+type importer struct {
+	ctx        context.Context
+	config_obj *config_proto.Config
+	file_store api.FileStore
+	db         datastore.DataStore
+
+	members  map[string]*zip.File
+	manifest *Manifest
+	options  ImportOptions
+
+	// Maps the original ids to the ids on this server.
+	hunt_id  string
+	flow_ids map[string]string
+}
+
+// Import an archive created by ExportFlow(), ExportHunt() or
+// ExportClient().
+func Import(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	reader io.ReaderAt, size int64,
+	options ImportOptions) (*api_proto.ImportArchiveResponse, error) {
+
+	zip_reader, err := zip.NewReader(reader, size)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	self := &importer{
+		ctx:        ctx,
+		config_obj: config_obj,
+		file_store: file_store.GetFileStore(config_obj),
+		db:         db,
+		members:    make(map[string]*zip.File),
+		manifest:   &Manifest{},
+		options:    options,
+		flow_ids:   make(map[string]string),
+	}
+
+	for _, member := range zip_reader.File {
+		self.members[member.Name] = member
+	}
+
+	err = self.readJSON(MANIFEST_NAME, self.manifest)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid archive: %w", err)
+	}
+
+	if self.manifest.Version != ARCHIVE_VERSION {
+		return nil, fmt.Errorf("Unsupported archive version %v",
+			self.manifest.Version)
+	}
+
+	// Check everything before writing anything so a failed import
+	// does not leave partial data behind.
+	err = self.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = self.assignIds()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api_proto.ImportArchiveResponse{}
+	for client_id, member := range self.manifest.Clients {
+		err = self.importClient(client_id, member)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, flow := range self.manifest.Flows {
+		err = self.importFlow(flow)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Flows = append(result.Flows, &api_proto.ImportedFlow{
+			ClientId:       flow.ClientId,
+			FlowId:         self.flowId(flow.ClientId, flow.FlowId),
+			OriginalFlowId: flow.FlowId,
+		})
+	}
+
+	if self.manifest.Type == ARCHIVE_TYPE_HUNT {
+		err = self.importHunt()
+		if err != nil {
+			return nil, err
+		}
+
+		result.HuntId = self.hunt_id
+		result.OriginalHuntId = self.manifest.HuntId
+	}
+
+	return result, nil
+}
+
+// Ids from the manifest end up in file store paths so they must be
+// well formed.
+func (self *importer) validate() error {
+	switch self.manifest.Type {
+	case ARCHIVE_TYPE_FLOW:
+		if len(self.manifest.Flows) != 1 {
+			return errors.New("Invalid archive: Expected a single flow")
+		}
+
+	case ARCHIVE_TYPE_CLIENT:
+		// A client may not have any collections.
+
+	case ARCHIVE_TYPE_HUNT:
+		if !constants.HuntIdRegex.MatchString(self.manifest.HuntId) {
+			return fmt.Errorf("Invalid archive: Invalid hunt id %v",
+				self.manifest.HuntId)
+		}
+
+	default:
+		return fmt.Errorf("Invalid archive: Unknown type %v",
+			self.manifest.Type)
+	}
+
+	for _, flow := range self.manifest.Flows {
+		if flow.ClientId != "server" &&
+			!constants.ClientIdRegex.MatchString(flow.ClientId) {
+			return fmt.Errorf("Invalid archive: Invalid client id %v",
+				flow.ClientId)
+		}
+
+		if !strings.HasPrefix(flow.FlowId, constants.FLOW_PREFIX) ||
+			strings.ContainsAny(flow.FlowId, "/\\") {
+			return fmt.Errorf("Invalid archive: Invalid flow id %v",
+				flow.FlowId)
+		}
+
+		for _, result := range flow.Results {
+			if result.Mode != paths.MODE_CLIENT &&
+				result.Mode != paths.MODE_SERVER {
+				return fmt.Errorf("Invalid archive: Invalid mode for %v",
+					result.Artifact)
+			}
+		}
+
+		for _, upload := range flow.Uploads {
+			for _, component := range upload.Components {
+				if component == "" || component == "." || component == ".." {
+					return fmt.Errorf(
+						"Invalid archive: Invalid upload path %v",
+						upload.Components)
+				}
+			}
+		}
+	}
+
+	for client_id := range self.manifest.Clients {
+		if !constants.ClientIdRegex.MatchString(client_id) {
+			return fmt.Errorf("Invalid archive: Invalid client id %v",
+				client_id)
+		}
+	}
+
+	return nil
+}
+
+// Decide the ids of the imported flows and hunt. Without remapping
+// the original ids are kept but they must not clash with existing
+// ones.
+func (self *importer) assignIds() error {
+	if self.manifest.Type == ARCHIVE_TYPE_HUNT {
+		self.hunt_id = self.manifest.HuntId
+		if self.options.Remap {
+			self.hunt_id = hunt_dispatcher.GetNewHuntId()
+
+		} else {
+			hunt_obj := &api_proto.Hunt{}
+			err := self.db.GetSubject(self.config_obj,
+				paths.NewHuntPathManager(self.hunt_id).Path(), hunt_obj)
+			if err == nil && hunt_obj.HuntId != "" {
+				return fmt.Errorf("%w: hunt %v", AlreadyExistsError,
+					self.hunt_id)
+			}
+		}
+	}
+
+	for _, flow := range self.manifest.Flows {
+		flow_id := flow.FlowId
+		if self.options.Remap {
+			flow_id = launcher.NewFlowId(flow.ClientId)
+
+		} else {
+			_, err := launcher.LoadCollectionContext(
+				self.config_obj, flow.ClientId, flow.FlowId)
+			if err == nil {
+				return fmt.Errorf("%w: flow %v of client %v",
+					AlreadyExistsError, flow.FlowId, flow.ClientId)
+			}
+		}
+		self.flow_ids[flow.ClientId+"/"+flow.FlowId] = flow_id
+	}
+
+	return nil
+}
+
+func (self *importer) flowId(client_id, flow_id string) string {
+	new_flow_id, pres := self.flow_ids[client_id+"/"+flow_id]
+	if pres {
+		return new_flow_id
+	}
+	return flow_id
+}
+
+// Create clients this server does not know about yet. Existing
+// client records are left alone.
+func (self *importer) importClient(client_id, member string) error {
+	client_path_manager := paths.NewClientPathManager(client_id)
+	existing := &actions_proto.ClientInfo{}
+	err := self.db.GetSubject(self.config_obj,
+		client_path_manager.Path(), existing)
+	if err == nil && existing.ClientId != "" {
+		return nil
+	}
+
+	client_info := &actions_proto.ClientInfo{}
+	err = self.readProto(member, client_info)
+	if err != nil {
+		return err
+	}
+
+	if client_info.ClientId != client_id {
+		return fmt.Errorf("Invalid archive: Client record for %v", client_id)
+	}
+
+	err = self.db.SetSubject(self.config_obj,
+		client_path_manager.Path(), client_info)
+	if err != nil {
+		return err
+	}
+
+	indexer, err := services.GetIndexer(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	for _, term := range []string{
+		"all", // This is used for "." search
+		client_id,
+		"host:" + client_info.Fqdn,
+		"host:" + client_info.Hostname,
+	} {
+		err = indexer.SetIndex(client_id, term)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *importer) importFlow(flow *FlowEntry) error {
+	prefix := flowPrefix(flow.ClientId, flow.FlowId)
+	flow_id := self.flowId(flow.ClientId, flow.FlowId)
+	flow_path_manager := paths.NewFlowPathManager(flow.ClientId, flow_id)
+
+	collection_context := &flows_proto.ArtifactCollectorContext{}
+	err := self.readProto(prefix+"collection_context.json", collection_context)
+	if err != nil {
+		return err
+	}
+
+	if collection_context.ClientId != flow.ClientId ||
+		collection_context.SessionId != flow.FlowId {
+		return fmt.Errorf("Invalid archive: Collection context for %v",
+			flow.FlowId)
+	}
+
+	collection_context.SessionId = flow_id
+	if collection_context.Request != nil && self.manifest.HuntId != "" &&
+		collection_context.Request.Creator == self.manifest.HuntId {
+		collection_context.Request.Creator = self.hunt_id
+	}
+
+	tasks := &api_proto.ApiFlowRequestDetails{}
+	err = self.readProto(prefix+"requests.json", tasks)
+	if err == nil {
+		if tasks.FlowId != "" {
+			tasks.FlowId = flow_id
+		}
+		for _, item := range tasks.Items {
+			if item.SessionId == flow.FlowId {
+				item.SessionId = flow_id
+			}
+		}
+
+		err = self.db.SetSubject(self.config_obj, flow_path_manager.Task(), tasks)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = self.copyResultSet(prefix+"logs.json", flow_path_manager.Log(), nil)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range flow.Results {
+		path_manager := artifact_paths.NewArtifactPathManagerWithMode(
+			self.config_obj, flow.ClientId, flow_id,
+			result.Artifact, result.Mode)
+		err = self.copyResultSet(result.Member, path_manager.Path(), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The upload metadata refers to the files by their file store
+	// path which includes the flow id.
+	var rewrite func(row *ordereddict.Dict)
+	if flow_id != flow.FlowId {
+		rewrite = func(row *ordereddict.Dict) {
+			components, pres := row.GetStrings("_Components")
+			if pres && len(components) > 3 &&
+				components[0] == "clients" &&
+				components[2] == "collections" &&
+				components[3] == flow.FlowId {
+				components = utils.CopySlice(components)
+				components[3] = flow_id
+				row.Update("_Components", components)
+			}
+		}
+	}
+
+	err = self.copyResultSet(prefix+"uploads.json",
+		flow_path_manager.UploadMetadata(), rewrite)
+	if err != nil {
+		return err
+	}
+
+	upload_root := flow_path_manager.UploadContainer()
+	for _, upload := range flow.Uploads {
+		err = self.copyFile(upload.Member,
+			upload_root.AddChild(upload.Components...).SetType(upload.Type))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Write the collection context last - the flow is only visible
+	// once all its data is in place.
+	return self.db.SetSubject(self.config_obj,
+		flow_path_manager.Path(), collection_context)
+}
+
+func (self *importer) importHunt() error {
+	hunt_obj := &api_proto.Hunt{}
+	err := self.readProto("hunt.json", hunt_obj)
+	if err != nil {
+		return err
+	}
+
+	if hunt_obj.HuntId != self.manifest.HuntId {
+		return fmt.Errorf("Invalid archive: Hunt record for %v",
+			self.manifest.HuntId)
+	}
+
+	hunt_obj.HuntId = self.hunt_id
+
+	// The hunt must not schedule collections on this server's
+	// clients.
+	if hunt_obj.State != api_proto.Hunt_ARCHIVED {
+		hunt_obj.State = api_proto.Hunt_STOPPED
+	}
+
+	hunt_path_manager := paths.NewHuntPathManager(self.hunt_id)
+
+	var rewrite func(row *ordereddict.Dict)
+	if self.options.Remap {
+		rewrite = func(row *ordereddict.Dict) {
+			_, pres := row.Get("HuntId")
+			if pres {
+				row.Update("HuntId", self.hunt_id)
+			}
+
+			client_id, _ := row.GetString("ClientId")
+			flow_id, pres := row.GetString("FlowId")
+			if pres {
+				row.Update("FlowId", self.flowId(client_id, flow_id))
+			}
+		}
+	}
+
+	err = self.copyResultSet("hunt_clients.json",
+		hunt_path_manager.Clients(), rewrite)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range self.manifest.HuntResults {
+		err = self.copyResultSet(result.Member,
+			hunt_path_manager.Results(result.Artifact), rewrite)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = self.db.SetSubject(self.config_obj, hunt_path_manager.Path(), hunt_obj)
+	if err != nil {
+		return err
+	}
+
+	// Offline tools do not run the hunt dispatcher - the server will
+	// pick up the hunt on its next refresh.
+	dispatcher, err := services.GetHuntDispatcher(self.config_obj)
+	if err != nil {
+		return nil
+	}
+	return dispatcher.Refresh(self.config_obj)
+}
+
+// Copy a result set from the archive. If rewrite is specified each
+// row is passed through it and the index is rebuilt, otherwise the
+// data is copied as is. Missing result sets are skipped.
+func (self *importer) copyResultSet(
+	member string, dest api.FSPathSpec,
+	rewrite func(row *ordereddict.Dict)) error {
+
+	_, pres := self.members[member]
+	if !pres {
+		return nil
+	}
+
+	if rewrite == nil {
+		err := self.copyFile(member, dest)
+		if err != nil {
+			return err
+		}
+
+		_, pres := self.members[member+".index"]
+		if !pres {
+			return nil
+		}
+		return self.copyFile(member+".index",
+			dest.SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX))
+	}
+
+	fd, err := self.members[member].Open()
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	writer, err := result_sets.NewResultSetWriter(self.file_store, dest,
+		json.DefaultEncOpts(), utils.SyncCompleter, result_sets.TruncateMode)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	reader := bufio.NewReader(fd)
+	for {
+		line, err := reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			row := ordereddict.NewDict()
+			parse_err := row.UnmarshalJSON(line)
+			if parse_err != nil {
+				return parse_err
+			}
+			rewrite(row)
+			writer.Write(row)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (self *importer) copyFile(member string, dest api.FSPathSpec) error {
+	zip_member, pres := self.members[member]
+	if !pres {
+		return fmt.Errorf("Invalid archive: Missing member %v", member)
+	}
+
+	fd, err := zip_member.Open()
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	out_fd, err := api.WriteFileUnbuffered(self.file_store, dest)
+	if err != nil {
+		return err
+	}
+	defer out_fd.Close()
+
+	err = out_fd.Truncate()
+	if err != nil {
+		return err
+	}
+
+	_, err = utils.Copy(self.ctx, out_fd, fd)
+	return err
+}
+
+func (self *importer) readMember(member string) ([]byte, error) {
+	zip_member, pres := self.members[member]
+	if !pres {
+		return nil, fmt.Errorf("Missing member %v", member)
+	}
+
+	fd, err := zip_member.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	data, err := io.ReadAll(io.LimitReader(fd, MAX_JSON_MEMBER_SIZE+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > MAX_JSON_MEMBER_SIZE {
+		return nil, fmt.Errorf("Member %v is too large", member)
+	}
+	return data, nil
+}
+
+func (self *importer) readJSON(member string, target interface{}) error {
+	data, err := self.readMember(member)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+func (self *importer) readProto(member string, message proto.Message) error {
+	data, err := self.readMember(member)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(data, message)
+}
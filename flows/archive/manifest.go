@@ -0,0 +1,82 @@
+/*
+  Archives move flows and hunts between servers.
+
+  An archive is a zip file holding everything the server stores about
+  the collections - the collection context, the requests, the logs,
+  the result sets with their indexes and all uploaded files. Exporting
+  a hunt also stores the hunt object, its participation records and
+  the merged hunt results as well as all the hunt's collections.
+  Exporting a client stores all the client's collections.
+
+  The manifest lists the content of the archive. Members are only
+  ever found through the manifest, so the names of the members do not
+  matter to the importer and never determine where data is written.
+
+  Unlike the downloads prepared for users, archives store the raw
+  files the server keeps, so importing them into another server
+  (e.g. moving collections out of an air-gapped deployment) recreates
+  the flows and hunts exactly as they were.
+*/
+
+package archive
+
+import (
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+)
+
+const (
+	ARCHIVE_VERSION = 1
+
+	MANIFEST_NAME = "manifest.json"
+
+	ARCHIVE_TYPE_FLOW   = "flow"
+	ARCHIVE_TYPE_HUNT   = "hunt"
+	ARCHIVE_TYPE_CLIENT = "client"
+
+	// JSON members are read into memory so we limit their size.
+	MAX_JSON_MEMBER_SIZE = 10 * 1024 * 1024
+)
+
+type Manifest struct {
+	Version    int    `json:"version"`
+	Type       string `json:"type"`
+	ExportTime int64  `json:"export_time"`
+
+	HuntId      string            `json:"hunt_id,omitempty"`
+	HuntResults []*ResultSetEntry `json:"hunt_results,omitempty"`
+
+	Flows []*FlowEntry `json:"flows"`
+
+	// Client records keyed by client id. Clients unknown to the
+	// importing server are created from these.
+	Clients map[string]string `json:"clients,omitempty"`
+}
+
+type FlowEntry struct {
+	ClientId string            `json:"client_id"`
+	FlowId   string            `json:"flow_id"`
+	Results  []*ResultSetEntry `json:"results,omitempty"`
+	Uploads  []*UploadEntry    `json:"uploads,omitempty"`
+}
+
+// The result set's index (if any) is stored next to it with an
+// .index suffix.
+type ResultSetEntry struct {
+	Artifact string `json:"artifact"`
+
+	// The artifact mode determines where the result set is stored.
+	Mode int `json:"mode"`
+
+	Member string `json:"member"`
+}
+
+type UploadEntry struct {
+	// Components below the flow's upload directory.
+	Components []string     `json:"components"`
+	Type       api.PathType `json:"type"`
+	Member     string       `json:"member"`
+}
+
+func flowPrefix(client_id, flow_id string) string {
+	return "flows/" + client_id + "/" + flow_id + "/"
+}
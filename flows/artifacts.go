@@ -36,6 +36,7 @@ import (
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 	"www.velocidex.com/golang/velociraptor/datastore"
 	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
 	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
 	"www.velocidex.com/golang/velociraptor/json"
 	"www.velocidex.com/golang/velociraptor/logging"
@@ -44,6 +45,7 @@ import (
 	"www.velocidex.com/golang/velociraptor/result_sets"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/services/launcher"
+	"www.velocidex.com/golang/velociraptor/services/quotas"
 	utils "www.velocidex.com/golang/velociraptor/utils"
 )
 
@@ -432,6 +434,8 @@ func ArtifactCollectorProcessOneMessage(
 				rowCounter.Add(float64(response.TotalRows))
 			}
 
+			quotas.AddRows(config_obj, rows_written)
+
 			// Update the artifacts with results in the
 			// context.
 			if rows_written > 0 {
@@ -467,6 +471,7 @@ func CheckForStatus(
 	// Update our record of all the status messages from this
 	// collection.
 	updateQueryStats(config_obj, collection_context, message.Status)
+	observeQueryDuration(message.Status)
 	launcher.UpdateFlowStats(&collection_context.ArtifactCollectorContext)
 
 	// Update the active time for each response.
@@ -501,7 +506,8 @@ func appendUploadDataToFile(
 		file_buffer.Pathspec.Accessor,
 		file_buffer.Pathspec.Path)
 
-	fd, err := file_store_factory.WriteFile(file_path_manager.Path())
+	fd, err := api.WriteFileUnbuffered(
+		file_store_factory, file_path_manager.Path())
 	if err != nil {
 		// If we fail to write this one file we keep going -
 		// otherwise the flow will be terminated.
@@ -726,7 +732,7 @@ func (self *FlowRunner) ProcessSingleMessage(
 				return nil
 			}
 
-			logger.Error(fmt.Sprintf("Unable to load flow %s: %v", job.SessionId, err))
+			logger.Error("Unable to load flow %s: %v", job.SessionId, err)
 
 			client_manager, err := services.GetClientInfoManager(self.config_obj)
 			if err != nil {
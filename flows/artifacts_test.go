@@ -17,6 +17,7 @@ import (
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
 	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
 	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
 	"www.velocidex.com/golang/velociraptor/paths"
@@ -68,6 +69,9 @@ type: CLIENT_EVENT
 `, `
 name: Generic.Client.Profile
 type: CLIENT
+`, `
+name: Linux.Sys.BashShell
+type: CLIENT
 `})
 
 	db, err := datastore.GetDB(self.ConfigObj)
@@ -350,6 +354,73 @@ func (self *TestSuite) TestResourceLimits() {
 		flows_proto.ArtifactCollectorContext_ERROR)
 }
 
+// Shell flows are recorded into a separate result set.
+func (self *TestSuite) TestShellRecording() {
+	closer := utils.MockTime(&utils.MockClock{MockNow: time.Unix(100, 0)})
+	defer closer()
+
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	flow_path_manager := paths.NewFlowPathManager(self.client_id, "F.Shell")
+	err = db.SetSubject(self.ConfigObj, flow_path_manager.Path(),
+		&flows_proto.ArtifactCollectorContext{
+			SessionId:  "F.Shell",
+			ClientId:   self.client_id,
+			CreateTime: 90 * 1000000,
+			Request: &flows_proto.ArtifactCollectorArgs{
+				Specs: []*flows_proto.ArtifactSpec{{
+					Artifact: "Linux.Sys.BashShell",
+					Parameters: &flows_proto.ArtifactParameters{
+						Env: []*actions_proto.VQLEnv{
+							{Key: "Command", Value: "id"},
+						},
+					},
+				}},
+			},
+		})
+	assert.NoError(self.T(), err)
+
+	runner := NewFlowRunner(self.ConfigObj)
+	for _, jsonl := range []string{
+		`{"Stdout":"uid=0(root)","Stderr":"","ReturnCode":0}` + "\n",
+		`{"Stdout":"","Stderr":"warning","ReturnCode":0}` + "\n",
+	} {
+		err = runner.VQLResponse(self.Ctx, self.client_id, "F.Shell",
+			&actions_proto.VQLResponse{
+				JSONLResponse: jsonl,
+				TotalRows:     1,
+				Query: &actions_proto.VQLRequest{
+					Name: "Linux.Sys.BashShell",
+				},
+			})
+		assert.NoError(self.T(), err)
+	}
+
+	// Other artifacts are not recorded.
+	err = runner.VQLResponse(self.Ctx, self.client_id, "F.Other",
+		&actions_proto.VQLResponse{
+			JSONLResponse: `{"Stdout":"hello"}` + "\n",
+			TotalRows:     1,
+			Query: &actions_proto.VQLRequest{
+				Name: "Generic.Client.Info/BasicInformation",
+			},
+		})
+	assert.NoError(self.T(), err)
+
+	data := test_utils.FileReadAll(self.T(), self.ConfigObj,
+		flow_path_manager.ShellRecording())
+	assert.Equal(self.T(), `{"Timestamp":90000000,"Type":"i","Data":"id\n"}
+{"Timestamp":100000000,"Type":"o","Data":"uid=0(root)"}
+{"Timestamp":100000000,"Type":"o","Data":"warning"}
+`, data)
+
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+	_, err = file_store_factory.StatFile(
+		paths.NewFlowPathManager(self.client_id, "F.Other").ShellRecording())
+	assert.Error(self.T(), err)
+}
+
 func (self *TestSuite) TestClientUploaderStoreFile() {
 	resp := responder.TestResponderWithFlowId(
 		self.ConfigObj, "TestClientUploaderStoreFile")
@@ -0,0 +1,56 @@
+package flows
+
+import (
+	"context"
+	"fmt"
+
+	"www.velocidex.com/golang/velociraptor/crypto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	crypto_server "www.velocidex.com/golang/velociraptor/crypto/server"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// Enrolled clients send a CSR to request a TLS client certificate
+// for mutual TLS with the frontend. The message is already
+// authenticated so the CSR must match the client's key.
+func (self *ClientFlowRunner) ClientCertificateRequest(
+	ctx context.Context, client_id string,
+	msg *crypto_proto.VeloMessage) error {
+
+	if self.config_obj.Frontend == nil ||
+		self.config_obj.Frontend.ClientCertificates == "" {
+		return nil
+	}
+
+	enrolled_key, err := crypto_server.GetPublicKey(self.config_obj, client_id)
+	if err != nil {
+		return fmt.Errorf("ClientCertificateRequest: %w", err)
+	}
+
+	cert, err := crypto.GenerateClientCert(
+		self.config_obj, client_id, enrolled_key, msg.CSR.Pem)
+	if err != nil {
+		return fmt.Errorf("ClientCertificateRequest: %w", err)
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	logger.Info("Issued TLS client certificate for %v", client_id)
+
+	client_manager, err := services.GetClientInfoManager(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	return client_manager.QueueMessageForClient(ctx, client_id,
+		&crypto_proto.VeloMessage{
+			SessionId: msg.SessionId,
+			ClientCertificate: &crypto_proto.Certificate{
+				Type: crypto_proto.Certificate_CRT,
+				Pem:  []byte(cert),
+				Cn:   client_id,
+			},
+		},
+		services.NOTIFY_CLIENT, utils.BackgroundWriter)
+}
@@ -3,6 +3,7 @@ package flows
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/Velocidex/ordereddict"
@@ -15,6 +16,8 @@ import (
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 	"www.velocidex.com/golang/velociraptor/datastore"
 	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/file_store/transaction"
 	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
 	"www.velocidex.com/golang/velociraptor/json"
 	"www.velocidex.com/golang/velociraptor/logging"
@@ -23,6 +26,8 @@ import (
 	"www.velocidex.com/golang/velociraptor/result_sets"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/services/launcher"
+	"www.velocidex.com/golang/velociraptor/services/quotas"
+	"www.velocidex.com/golang/velociraptor/tracing"
 	utils "www.velocidex.com/golang/velociraptor/utils"
 )
 
@@ -46,6 +51,12 @@ type ClientFlowRunner struct {
 	// System.Flow.Completion to attempt to open the collection before
 	// everything is written.
 	completer *utils.Completer
+
+	// While a batch of messages is processed, the writes of each
+	// flow are journaled in a transaction so the result sets, logs
+	// and stats of the flow are committed or rolled back together.
+	mu           sync.Mutex
+	transactions map[string]*transaction.Transaction
 }
 
 func NewFlowRunner(config_obj *config_proto.Config) *ClientFlowRunner {
@@ -59,6 +70,57 @@ func NewFlowRunner(config_obj *config_proto.Config) *ClientFlowRunner {
 
 func (self *ClientFlowRunner) Complete() {}
 
+// Returns the transaction journaling the writes of the flow, or nil
+// if writes are not journaled.
+func (self *ClientFlowRunner) getTransaction(
+	flow_id string) *transaction.Transaction {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.transactions == nil {
+		return nil
+	}
+
+	tx, pres := self.transactions[flow_id]
+	if !pres {
+		tx = transaction.NewTransaction(self.config_obj)
+		self.transactions[flow_id] = tx
+	}
+	return tx
+}
+
+func (self *ClientFlowRunner) getFileStore(flow_id string) api.FileStore {
+	tx := self.getTransaction(flow_id)
+	if tx != nil {
+		return tx.FileStore()
+	}
+	return file_store.GetFileStore(self.config_obj)
+}
+
+func (self *ClientFlowRunner) startTransactions() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.transactions = make(map[string]*transaction.Transaction)
+}
+
+// Commit the journaled writes of all flows and go back to writing
+// without a journal.
+func (self *ClientFlowRunner) commitTransactions() (err error) {
+	self.mu.Lock()
+	transactions := self.transactions
+	self.transactions = nil
+	self.mu.Unlock()
+
+	for flow_id, tx := range transactions {
+		tx_err := tx.Commit()
+		if tx_err != nil {
+			err = fmt.Errorf("Committing flow %v: %w", flow_id, tx_err)
+		}
+	}
+	return err
+}
+
 func (self *ClientFlowRunner) ProcessMonitoringMessage(
 	ctx context.Context, msg *crypto_proto.VeloMessage) error {
 
@@ -82,7 +144,7 @@ func (self *ClientFlowRunner) ProcessMonitoringMessage(
 	}
 
 	if msg.FileBuffer != nil {
-		err := self.FileBuffer(client_id, flow_id, msg.FileBuffer)
+		err := self.FileBuffer(ctx, client_id, flow_id, msg.FileBuffer)
 		if err != nil {
 			return fmt.Errorf("FileBuffer: %w", err)
 		}
@@ -146,6 +208,8 @@ func (self *ClientFlowRunner) MonitoringVQLResponse(
 		return err
 	}
 
+	quotas.AddRows(self.config_obj, response.TotalRows)
+
 	return journal.PushJsonlToArtifact(
 		self.config_obj,
 		[]byte(response.JSONLResponse), int(response.TotalRows),
@@ -153,7 +217,7 @@ func (self *ClientFlowRunner) MonitoringVQLResponse(
 }
 
 func (self *ClientFlowRunner) ProcessSingleMessage(
-	ctx context.Context, msg *crypto_proto.VeloMessage) error {
+	ctx context.Context, msg *crypto_proto.VeloMessage) (err error) {
 
 	flow_id := msg.SessionId
 	client_id := msg.Source
@@ -162,14 +226,34 @@ func (self *ClientFlowRunner) ProcessSingleMessage(
 		return self.ProcessMonitoringMessage(ctx, msg)
 	}
 
+	// Responses to traced collections carry the trace from the
+	// launcher.
+	ctx, span := tracing.StartSpanWithParent(
+		ctx, "frontend.ProcessMessage", msg.TraceParent)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+	span.SetKind(tracing.SPAN_KIND_SERVER)
+	span.SetAttribute("client_id", client_id)
+	span.SetAttribute("flow_id", flow_id)
+
 	// Should never happen because these are filled in from the crypto
 	// envelope.
 	if flow_id == "" || client_id == "" {
 		return fmt.Errorf("Empty SessionId: %v", msg)
 	}
 
+	if msg.CSR != nil {
+		return self.ClientCertificateRequest(ctx, client_id, msg)
+	}
+
+	if msg.KeyRotation != nil {
+		return self.KeyRotation(ctx, client_id, msg)
+	}
+
 	if msg.LogMessage != nil {
-		err := self.LogMessage(client_id, flow_id, msg.LogMessage)
+		err := self.LogMessage(ctx, client_id, flow_id, msg.LogMessage)
 		if err != nil {
 			return fmt.Errorf("LogMessage: %w", err)
 		}
@@ -177,7 +261,7 @@ func (self *ClientFlowRunner) ProcessSingleMessage(
 	}
 
 	if msg.VQLResponse != nil {
-		err := self.VQLResponse(client_id, flow_id, msg.VQLResponse)
+		err := self.VQLResponse(ctx, client_id, flow_id, msg.VQLResponse)
 		if err != nil {
 			return fmt.Errorf("VQLResponse: %w", err)
 		}
@@ -193,7 +277,7 @@ func (self *ClientFlowRunner) ProcessSingleMessage(
 	}
 
 	if msg.FileBuffer != nil {
-		err := self.FileBuffer(client_id, flow_id, msg.FileBuffer)
+		err := self.FileBuffer(ctx, client_id, flow_id, msg.FileBuffer)
 		if err != nil {
 			return fmt.Errorf("FileBuffer: %w", err)
 		}
@@ -204,7 +288,7 @@ func (self *ClientFlowRunner) ProcessSingleMessage(
 }
 
 func (self *ClientFlowRunner) FileBuffer(
-	client_id, flow_id string,
+	ctx context.Context, client_id, flow_id string,
 	file_buffer *actions_proto.FileBuffer) error {
 
 	if file_buffer == nil || file_buffer.Pathspec == nil {
@@ -220,7 +304,18 @@ func (self *ClientFlowRunner) FileBuffer(
 	file_path_manager := flow_path_manager.GetUploadsFile(
 		file_buffer.Pathspec.Accessor, file_buffer.Pathspec.Path)
 
-	fd, err := file_store_factory.WriteFile(file_path_manager.Path())
+	_, span := tracing.StartSpan(ctx, "file_store.Write")
+	span.SetAttribute("path", file_path_manager.Path().AsClientPath())
+	span.SetAttribute("offset", file_buffer.Offset)
+	span.SetAttribute("bytes", len(file_buffer.Data))
+	defer span.End()
+
+	// Upload chunks can be large so they are written straight to
+	// storage rather than held in the file store's write cache. The
+	// client only discards the chunk once we reply so the chunk must
+	// be stored by then.
+	fd, err := api.WriteFileUnbuffered(
+		file_store_factory, file_path_manager.Path())
 	if err != nil {
 		// If we fail to write this one file we keep going -
 		// otherwise the flow will be terminated.
@@ -358,31 +453,57 @@ func (self *ClientFlowRunner) FlowStats(
 
 	// Store the updated flow object in the datastore
 	flow_path_manager := paths.NewFlowPathManager(client_id, flow_id)
-	db, err := datastore.GetDB(self.config_obj)
-	if err != nil {
-		return err
-	}
 
-	// Just a blind write will eventually hit the disk.
-	err = db.SetSubjectWithCompletion(self.config_obj,
-		flow_path_manager.Stats(), stats, nil)
-	if err != nil {
-		return err
+	// The stats are committed together with the results they
+	// describe.
+	tx := self.getTransaction(flow_id)
+	if tx != nil {
+		err := tx.SetSubject(flow_path_manager.Stats(), stats)
+		if err != nil {
+			return err
+		}
+
+	} else {
+		db, err := datastore.GetDB(self.config_obj)
+		if err != nil {
+			return err
+		}
+
+		// Just a blind write will eventually hit the disk.
+		err = db.SetSubjectWithCompletion(self.config_obj,
+			flow_path_manager.Stats(), stats, nil)
+		if err != nil {
+			return err
+		}
 	}
 
 	// If this is the final response, then we will notify a flow
 	// completion.
 	if msg.FlowComplete {
+		for _, s := range stats.QueryStats {
+			observeQueryDuration(s)
+		}
+
 		row := ordereddict.NewDict().
 			Set("Timestamp", time.Now().UTC().Unix()).
 			Set("Flow", stats).
 			Set("FlowId", flow_id).
 			Set("ClientId", client_id)
 
-		journal, err := services.GetJournal(self.config_obj)
-		if err == nil {
-			journal.PushRowsToArtifactAsync(
-				self.config_obj, row, "System.Flow.Completion")
+		notify := func() {
+			journal, err := services.GetJournal(self.config_obj)
+			if err == nil {
+				journal.PushRowsToArtifactAsync(
+					self.config_obj, row, "System.Flow.Completion")
+			}
+		}
+
+		// Listeners may open the collection as soon as they are
+		// notified so wait until it is written.
+		if tx != nil {
+			tx.OnCommit(notify)
+		} else {
+			notify()
 		}
 	}
 
@@ -390,7 +511,7 @@ func (self *ClientFlowRunner) FlowStats(
 }
 
 func (self *ClientFlowRunner) VQLResponse(
-	client_id, flow_id string,
+	ctx context.Context, client_id, flow_id string,
 	response *actions_proto.VQLResponse) error {
 
 	if response == nil || response.Query == nil || response.Query.Name == "" {
@@ -408,35 +529,66 @@ func (self *ClientFlowRunner) VQLResponse(
 		return err
 	}
 
-	file_store_factory := file_store.GetFileStore(self.config_obj)
+	_, span := tracing.StartSpan(ctx, "result_sets.WriteJSONL")
+	span.SetAttribute("path", path_manager.Path().AsClientPath())
+	span.SetAttribute("rows", response.TotalRows)
+	span.SetAttribute("bytes", len(response.JSONLResponse))
+	defer span.End()
+
+	file_store_factory := self.getFileStore(flow_id)
 	rs_writer, err := result_sets.NewResultSetWriter(
 		file_store_factory, path_manager.Path(), json.DefaultEncOpts(),
 		self.completer.GetCompletionFunc(),
 		result_sets.AppendMode)
 	if err != nil {
+		span.SetError(err)
 		return err
 	}
 	defer rs_writer.Close()
 
-	rs_writer.WriteJSONL(
-		[]byte(response.JSONLResponse), response.TotalRows)
+	// Typed columns declared by the artifact are normalized before
+	// the rows are stored.
+	jsonl := []byte(response.JSONLResponse)
+	schema := services.GetArtifactSchema(self.config_obj, response.Query.Name)
+	if schema != nil {
+		normalized, err := schema.NormalizeJSONL(jsonl, json.DefaultEncOpts())
+		if err == nil {
+			jsonl = normalized
+		}
+	}
+
+	rs_writer.WriteJSONL(jsonl, response.TotalRows)
+	quotas.AddRows(self.config_obj, response.TotalRows)
+
+	// Shell sessions are also kept in a separate recording.
+	if IsShellArtifact(response.Query.Name) {
+		return recordShellSession(self.config_obj, file_store_factory,
+			self.completer.GetCompletionFunc(),
+			client_id, flow_id, response)
+	}
 
 	return nil
 }
 
 func (self *ClientFlowRunner) LogMessage(
-	client_id, flow_id string,
+	ctx context.Context, client_id, flow_id string,
 	msg *crypto_proto.LogMessage) error {
 
 	flow_path_manager := paths.NewFlowPathManager(client_id, flow_id).Log()
 
+	_, span := tracing.StartSpan(ctx, "result_sets.WriteJSONL")
+	span.SetAttribute("path", flow_path_manager.AsClientPath())
+	span.SetAttribute("rows", msg.NumberOfRows)
+	defer span.End()
+
 	// Append logs to messages from previous packets.
-	file_store_factory := file_store.GetFileStore(self.config_obj)
+	file_store_factory := self.getFileStore(flow_id)
 	rs_writer, err := result_sets.NewResultSetWriter(
 		file_store_factory, flow_path_manager,
 		json.DefaultEncOpts(), self.completer.GetCompletionFunc(),
 		result_sets.AppendMode)
 	if err != nil {
+		span.SetError(err)
 		return err
 	}
 	defer rs_writer.Close()
@@ -461,5 +613,13 @@ func (self *ClientFlowRunner) ProcessMessages(ctx context.Context,
 		logger.Error("ForemanCheckin for client %v: %v", message_info.Source, err)
 	}
 
-	return message_info.IterateJobs(ctx, self.config_obj, self.ProcessSingleMessage)
+	self.startTransactions()
+	err = message_info.IterateJobs(ctx, self.config_obj, self.ProcessSingleMessage)
+
+	// Commit even if some messages failed - the rest were processed.
+	commit_err := self.commitTransactions()
+	if err != nil {
+		return err
+	}
+	return commit_err
 }
@@ -1,12 +1,54 @@
 package flows
 
 import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"google.golang.org/protobuf/proto"
 	artifacts "www.velocidex.com/golang/velociraptor/artifacts"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 )
 
+var (
+	collectionDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "collection_duration_seconds",
+			Help:    "Time taken by each artifact query on the client.",
+			Buckets: prometheus.ExponentialBuckets(0.1, 4, 10),
+		},
+		[]string{"artifact", "status"},
+	)
+)
+
+// Record the duration of a completed query. Progress reports are
+// ignored since the query is still running. Names must already be
+// deobfuscated.
+func observeQueryDuration(status *crypto_proto.VeloStatus) {
+	if status == nil || status.Status == crypto_proto.VeloStatus_PROGRESS {
+		return
+	}
+
+	// Clients do not always fill in the artifact so fall back to
+	// the sources that returned rows.
+	artifact := status.Artifact
+	if artifact == "" && len(status.NamesWithResponse) > 0 {
+		artifact = status.NamesWithResponse[0]
+	}
+	if artifact == "" {
+		artifact = "unknown"
+	}
+
+	state := "ok"
+	if status.Status != crypto_proto.VeloStatus_OK {
+		state = "error"
+	}
+
+	collectionDuration.WithLabelValues(artifact, state).Observe(
+		time.Duration(status.Duration).Seconds())
+}
+
 func deobfuscateNames(config_obj *config_proto.Config,
 	names []string) []string {
 	deobfuscated_names := make([]string, 0, len(names))
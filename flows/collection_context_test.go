@@ -0,0 +1,33 @@
+package flows
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+)
+
+func TestObserveQueryDuration(t *testing.T) {
+	before := testutil.CollectAndCount(collectionDuration)
+
+	// Progress reports are not recorded.
+	observeQueryDuration(&crypto_proto.VeloStatus{
+		Status:            crypto_proto.VeloStatus_PROGRESS,
+		NamesWithResponse: []string{"Test.Metrics.Progress"},
+	})
+	assert.Equal(t, before, testutil.CollectAndCount(collectionDuration))
+
+	// Falls back to the names with response for the artifact label.
+	observeQueryDuration(&crypto_proto.VeloStatus{
+		Status:            crypto_proto.VeloStatus_OK,
+		Duration:          int64(2 * time.Second),
+		NamesWithResponse: []string{"Test.Metrics.Complete"},
+	})
+	observeQueryDuration(&crypto_proto.VeloStatus{
+		Status:   crypto_proto.VeloStatus_GENERIC_ERROR,
+		Artifact: "Test.Metrics.Complete",
+	})
+	assert.Equal(t, before+2, testutil.CollectAndCount(collectionDuration))
+}
@@ -28,6 +28,7 @@ import (
 	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/enrollment"
 	utils "www.velocidex.com/golang/velociraptor/utils"
 )
 
@@ -55,6 +56,12 @@ func CheckClientStatus(
 		return nil
 	}
 
+	// Clients waiting for enrollment approval get neither the
+	// monitoring table nor hunts. Once approved they catch up here.
+	if !enrollment.IsApproved(config_obj, client_id) {
+		return nil
+	}
+
 	// Check the client's event table for validity.
 	client_event_manager, err := services.ClientEventManager(config_obj)
 	if err != nil {
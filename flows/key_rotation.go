@@ -0,0 +1,68 @@
+package flows
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	crypto_server "www.velocidex.com/golang/velociraptor/crypto/server"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// The client sends a CSR for its new key over the channel
+// authenticated by its current key. We keep the new key as pending
+// and acknowledge it - the client then switches over and keeps its
+// client id.
+func (self *ClientFlowRunner) KeyRotation(
+	ctx context.Context, client_id string,
+	msg *crypto_proto.VeloMessage) error {
+
+	csr, err := crypto_utils.ParseX509CSRFromPemStr(msg.KeyRotation.Csr)
+	if err != nil {
+		return fmt.Errorf("KeyRotation: %w", err)
+	}
+
+	// Prove the client holds the new private key.
+	err = csr.CheckSignature()
+	if err != nil {
+		return fmt.Errorf("KeyRotation: %w", err)
+	}
+
+	public_key, ok := csr.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("KeyRotation: Not RSA algorithm")
+	}
+
+	if csr.Subject.CommonName != utils.ClientIdFromSource(client_id) {
+		return fmt.Errorf("KeyRotation: CSR for %v received from %v",
+			csr.Subject.CommonName, client_id)
+	}
+
+	err = crypto_server.SetPendingPublicKey(self.config_obj, client_id, public_key)
+	if err != nil {
+		return err
+	}
+
+	key_id := crypto_utils.ClientIDFromPublicKey(public_key)
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	logger.Info("Client %v is rotating its key to %v", client_id, key_id)
+
+	client_manager, err := services.GetClientInfoManager(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	return client_manager.QueueMessageForClient(ctx, client_id,
+		&crypto_proto.VeloMessage{
+			SessionId: msg.SessionId,
+			KeyRotation: &crypto_proto.KeyRotation{
+				KeyId: key_id,
+			},
+		},
+		services.NOTIFY_CLIENT, utils.BackgroundWriter)
+}
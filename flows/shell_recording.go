@@ -0,0 +1,123 @@
+package flows
+
+import (
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	utils "www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	// Artifacts driving a remote shell. Their sessions are recorded
+	// so shell usage can be audited.
+	SHELL_ARTIFACTS = []string{
+		"Windows.System.PowerShell",
+		"Windows.System.CmdShell",
+		"Linux.Sys.BashShell",
+	}
+)
+
+func IsShellArtifact(name string) bool {
+	return utils.InString(SHELL_ARTIFACTS, name)
+}
+
+// Append the responses of a shell artifact to the flow's shell
+// recording. Similar to the asciicast format, each event carries the
+// time it was observed (in microseconds): the input event is
+// timestamped when the flow was created and output events when they
+// reached the server.
+func recordShellSession(
+	config_obj *config_proto.Config,
+	file_store_factory api.FileStore,
+	completion func(),
+	client_id, flow_id string,
+	response *actions_proto.VQLResponse) error {
+
+	flow_path_manager := paths.NewFlowPathManager(client_id, flow_id)
+	recording_path := flow_path_manager.ShellRecording()
+
+	rs_writer, err := result_sets.NewResultSetWriter(
+		file_store_factory, recording_path, json.DefaultEncOpts(),
+		completion, result_sets.AppendMode)
+	if err != nil {
+		return err
+	}
+	defer rs_writer.Close()
+
+	// The recording starts with the command that was sent.
+	stat, err := file_store_factory.StatFile(recording_path)
+	if err != nil || stat.Size() == 0 {
+		event, err := getShellInput(config_obj, client_id, flow_id)
+		if err != nil {
+			return err
+		}
+		rs_writer.Write(event)
+	}
+
+	rows, err := utils.ParseJsonToDicts([]byte(response.JSONLResponse))
+	if err != nil {
+		return err
+	}
+
+	now := utils.GetTime().Now()
+	for _, row := range rows {
+		for _, field := range []string{"Stdout", "Stderr"} {
+			data, _ := row.GetString(field)
+			if data == "" {
+				continue
+			}
+			rs_writer.Write(shellEvent(now, "o", data))
+		}
+	}
+
+	return nil
+}
+
+func getShellInput(
+	config_obj *config_proto.Config,
+	client_id, flow_id string) (*ordereddict.Dict, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	collection_context := &flows_proto.ArtifactCollectorContext{}
+	err = db.GetSubject(config_obj,
+		paths.NewFlowPathManager(client_id, flow_id).Path(),
+		collection_context)
+	if err != nil {
+		return nil, err
+	}
+
+	command := ""
+	if collection_context.Request != nil {
+		for _, spec := range collection_context.Request.Specs {
+			if !IsShellArtifact(spec.Artifact) || spec.Parameters == nil {
+				continue
+			}
+			for _, env := range spec.Parameters.Env {
+				if env.Key == "Command" {
+					command = env.Value
+				}
+			}
+		}
+	}
+
+	create_time := time.Unix(0, int64(collection_context.CreateTime)*1000)
+	return shellEvent(create_time, "i", command+"\n"), nil
+}
+
+func shellEvent(timestamp time.Time, event_type, data string) *ordereddict.Dict {
+	return ordereddict.NewDict().
+		Set("Timestamp", timestamp.UnixNano()/1000).
+		Set("Type", event_type).
+		Set("Data", data)
+}
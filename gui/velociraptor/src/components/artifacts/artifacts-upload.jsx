@@ -22,7 +22,14 @@ export default class ArtifactsUpload extends React.Component {
     state = {
         pack_file: null,
         loading: false,
+
+        // The pack contains KAPE targets (.tkape files) to convert.
+        kape: false,
         uploaded: [],
+
+        // Who signed the uploaded pack.
+        signer: "",
+        trusted: false,
     }
 
     componentDidMount() {
@@ -45,13 +52,17 @@ export default class ArtifactsUpload extends React.Component {
             };
 
             this.setState({loading: true});
-            api.post("v1/LoadArtifactPack", request,
+            let endpoint = this.state.kape ?
+                "v1/ImportKapeTargets" : "v1/LoadArtifactPack";
+            api.post(endpoint, request,
                      this.source.token).then(response => {
                 let uploaded = _.map(response.data.successful_artifacts,
                                      (x, idx)=>{
                                          return {name: x, id: idx};
                                      });
-                this.setState({loading:false, uploaded: uploaded});
+                this.setState({loading:false, uploaded: uploaded,
+                               signer: response.data.signer,
+                               trusted: response.data.trusted});
             });
         };
         reader.readAsDataURL(this.state.pack_file);
@@ -98,10 +109,22 @@ export default class ArtifactsUpload extends React.Component {
                           </Form.File.Label>
                         </Form.File>
                       </InputGroup>
+                      <Form.Check
+                        type="checkbox"
+                        label={T("Convert KAPE targets (.tkape files)")}
+                        checked={this.state.kape}
+                        onChange={e => this.setState({kape: e.target.checked})}
+                      />
                     </Form>
 
                     :
-
+                    <>
+                    { this.state.signer &&
+                      <div className="mb-3">
+                        {T("Signed by")} {this.state.signer}
+                        { !this.state.trusted && " (" + T("untrusted") + ")" }
+                      </div>
+                    }
                     <BootstrapTable
                       hover
                       condensed
@@ -113,6 +136,7 @@ export default class ArtifactsUpload extends React.Component {
                       columns={columns}
                       filter={ filterFactory() }
                     />
+                    </>
 
                   }
               </Modal.Body>
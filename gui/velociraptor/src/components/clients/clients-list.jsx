@@ -601,5 +601,13 @@ export function getClientColumns() {
         {dataField: "os_info.hostname", text: T("Hostname"), sort: true},
         {dataField: "os_info.fqdn", text: T("FQDN"), sort: true},
         {dataField: "os_info.release", text: T("OS Version")},
+        {dataField: "health.score", text: T("Health"),
+         formatter: (cell, row) => {
+             if (!row.health) {
+                 return <></>;
+             }
+             let issues = _.join(row.health.issues, "\n");
+             return <span title={issues}>{row.health.score || 0}</span>;
+         }},
     ]);
 }
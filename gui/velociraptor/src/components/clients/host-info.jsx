@@ -36,7 +36,7 @@ const INTERROGATE_POLL_TIME = 2000;
 var quarantine_artifacts = {
     "windows": "Windows.Remediation.Quarantine",
     "linux": "Linux.Remediation.Quarantine",
-    "macos": "MacOS.Remediation.Quarantine",
+    "darwin": "MacOS.Remediation.Quarantine",
 };
 
 class QuarantineDialog extends Component {
@@ -501,7 +501,8 @@ class VeloHostInfo extends Component {
     render() {
         let client_id = this.props.client && this.props.client.client_id;
         let info = this.getClientInfo();
-        let is_quarantined = info.labels.includes("Quarantine");
+        let is_quarantined = info.labels.includes("Quarantine") ||
+            info.quarantined;
 
         return (
             <>
@@ -440,6 +440,7 @@ export function formatColumns(columns) {
             };
         }
         switch(x.type) {
+        case "integer":
         case "number":
             if (!_.isObject(x.style)) {
                 x.style = {};
@@ -567,6 +568,13 @@ export function formatColumns(columns) {
             break;
 
 
+            // Typed columns enforced by the server which are rendered
+            // as plain strings.
+        case "hash":
+        case "client_path":
+            x.type = null;
+            break;
+
             // Types supported by the underlying BootstrapTable - just
             // pass them on.
         case "string":
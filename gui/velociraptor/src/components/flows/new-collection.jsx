@@ -970,6 +970,7 @@ class NewCollectionWizard extends React.Component {
                     />
 
                   <NewCollectionConfigParameters
+                    client_id={client_id}
                     parameters={this.state.parameters}
                     setParameters={this.setParameters}
                     artifacts={this.state.artifacts}
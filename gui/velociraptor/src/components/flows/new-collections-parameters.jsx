@@ -112,6 +112,9 @@ export default class NewCollectionConfigParameters extends React.Component {
         parameters: PropTypes.object,
         setParameters: PropTypes.func.isRequired,
         paginator: PropTypes.object,
+
+        // The client the collection is for (if any).
+        client_id: PropTypes.string,
     };
 
     setValue = (artifact, name, value) => {
@@ -158,6 +161,7 @@ export default class NewCollectionConfigParameters extends React.Component {
             return (
                 <VeloForm param={param} key={idx} name={idx}
                           value={value}
+                          client_id={this.props.client_id}
                           setValue={(value) => this.setValue(
                               artifact.name,
                               param.name,
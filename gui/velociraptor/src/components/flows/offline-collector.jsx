@@ -126,6 +126,7 @@ class OfflineCollectorParameters  extends React.Component {
                         <option value="GCS">{T("Google Cloud Bucket")}</option>
                         <option value="S3">{T("AWS Bucket")}</option>
                         <option value="SFTP">{T("SFTP Upload")}</option>
+                        <option value="Azure">{T("Azure SAS URL")}</option>
                       </Form.Control>
                     </Col>
                   </Form.Group>
@@ -347,6 +348,41 @@ class OfflineCollectorParameters  extends React.Component {
                     </>
                   }
 
+                  { this.props.parameters.target === "Azure" &&
+                    <Form.Group as={Row}>
+                      <Form.Label column sm="3">{T("SAS URL")}</Form.Label>
+                      <Col sm="8">
+                        <Form.Control as="textarea" rows={3}
+                                      placeholder={T("A container SAS URL with write permission")}
+                                      spellCheck="false"
+                                      value={this.props.parameters.target_args.sas_url}
+                                      onChange={e => {
+                                          this.props.parameters.target_args.sas_url = e.target.value;
+                                          this.props.setParameters(this.props.parameters);
+                                      }}
+                        />
+                      </Col>
+                    </Form.Group>
+                  }
+
+                  { (this.props.parameters.target === "SFTP" ||
+                     this.props.parameters.target === "Azure") &&
+                    <Form.Group as={Row}>
+                      <Form.Label column sm="3">{T("Max Upload Rate")}</Form.Label>
+                      <Col sm="8">
+                        <Form.Control type="number"
+                                      placeholder={T("Bytes per second (0 for unlimited)")}
+                                      value={this.props.parameters.target_args.max_rate || ""}
+                                      onChange={e => {
+                                          this.props.parameters.target_args.max_rate =
+                                              parseInt(e.target.value) || 0;
+                                          this.props.setParameters(this.props.parameters);
+                                      }}
+                        />
+                      </Col>
+                    </Form.Group>
+                  }
+
                   <Form.Group as={Row}>
                     <Form.Label column sm="3">{T("Velociraptor Binary")}</Form.Label>
                     <Col sm="8">
@@ -0,0 +1,108 @@
+import React from 'react';
+import PropTypes from 'prop-types';
+import _ from 'lodash';
+import axios from 'axios';
+import Form from 'react-bootstrap/Form';
+import api from '../core/api-service.jsx';
+import T from '../i8n/i8n.jsx';
+
+// Matches absolute Windows (C:\ or \\server\share) and Unix paths -
+// the launcher rejects anything else.
+const absolutePathRegex = RegExp("^([a-zA-Z]:[\\\\/]|\\\\\\\\|/)");
+
+// Split a path typed by the user into the directory being completed
+// and the partial name within it.
+function splitPath(path) {
+    let idx = Math.max(path.lastIndexOf("/"), path.lastIndexOf("\\"));
+    return {
+        dirname: path.substring(0, idx + 1),
+        components: _.filter(path.substring(0, idx).split(/[\\/]/)),
+    };
+}
+
+// A text input for a path on the endpoint. When a client is known the
+// directories already present in its VFS are offered as suggestions.
+export default class ClientPathForm extends React.Component {
+    static propTypes = {
+        client_id: PropTypes.string,
+        value: PropTypes.string,
+        setValue: PropTypes.func.isRequired,
+    };
+
+    state = {
+        suggestions: [],
+        dirname: undefined,
+    }
+
+    list_id = _.uniqueId("client-path-")
+
+    componentDidMount = () => {
+        this.source = axios.CancelToken.source();
+        this.fetchSuggestions(this.props.value || "");
+    }
+
+    componentWillUnmount() {
+        this.source.cancel();
+    }
+
+    fetchSuggestions = path=>{
+        let client_id = this.props.client_id;
+        if (!client_id || client_id === "server") {
+            return;
+        }
+
+        let {dirname, components} = splitPath(path);
+        if (dirname === this.state.dirname) {
+            return;
+        }
+        this.setState({dirname: dirname, suggestions: []});
+
+        if (_.isEmpty(components)) {
+            return;
+        }
+
+        this.source.cancel();
+        this.source = axios.CancelToken.source();
+
+        api.get("v1/VFSListDirectory/" + client_id, {
+            vfs_components: ["auto", ...components],
+        }, this.source.token).then(response=>{
+            if (response.cancel) return;
+
+            let rows = JSON.parse(response.data.Response || "[]");
+            let suggestions = [];
+            _.each(rows, row=>{
+                if (row.Mode && row.Mode[0] === "d") {
+                    suggestions.push(dirname + row.Name);
+                }
+            });
+            this.setState({suggestions: suggestions});
+        });
+    }
+
+    setValue = value=>{
+        this.fetchSuggestions(value);
+        this.props.setValue(value);
+    }
+
+    render() {
+        let value = this.props.value || "";
+        let invalid = value !== "" && !absolutePathRegex.test(value);
+
+        return (
+            <>
+              <Form.Control
+                placeholder={T("Absolute path on the endpoint")}
+                className={ invalid && 'invalid' }
+                list={this.list_id}
+                onChange={e=>this.setValue(e.currentTarget.value)}
+                value={value} />
+              <datalist id={this.list_id}>
+                {_.map(this.state.suggestions, (x, idx)=>{
+                    return <option key={idx} value={x}/>;
+                })}
+              </datalist>
+            </>
+        );
+    }
+};
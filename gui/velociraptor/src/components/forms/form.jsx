@@ -12,6 +12,7 @@ import RegEx from './regex.jsx';
 import RegExArray from './regex_array.jsx';
 import UploadFileForm from './upload.jsx';
 import YaraEditor from './yara.jsx';
+import ClientPathForm from './client-path.jsx';
 import Tooltip from 'react-bootstrap/Tooltip';
 import OverlayTrigger from 'react-bootstrap/OverlayTrigger';
 import ButtonGroup from 'react-bootstrap/ButtonGroup';
@@ -70,6 +71,9 @@ export default class VeloForm extends React.Component {
         param: PropTypes.object,
         value: PropTypes.string,
         setValue: PropTypes.func.isRequired,
+
+        // Used to suggest paths for client_path parameters.
+        client_id: PropTypes.string,
     };
 
     state = {
@@ -471,6 +475,92 @@ export default class VeloForm extends React.Component {
                 </Form.Group>
             );
 
+        case "timestamp_range":
+            // value prop is two ISO timestamps separated by /
+            let range = (this.props.value || "").split("/");
+            let range_dates = _.map([range[0], range[1]], x=>{
+                let date = x ? convertToDate(x) : null;
+                if(_.isDate(date) && this.state.isUTC) {
+                    date = localTimeFromUTCTime(date);
+                }
+                return date;
+            });
+
+            let setRange = (idx, value)=>{
+                let parts = [range[0] || "", range[1] || ""];
+                if (!_.isDate(value)) {
+                    parts[idx] = "";
+                } else if(this.state.isUTC) {
+                    parts[idx] = utcTimeFromLocalTime(
+                        convertToDate(value)).toISOString();
+                } else {
+                    parts[idx] = convertToDate(value).toISOString();
+                }
+
+                if (!parts[0] && !parts[1]) {
+                    this.props.setValue(undefined);
+                } else {
+                    this.props.setValue(parts.join("/"));
+                }
+            };
+
+            return (
+                <Form.Group as={Row}>
+                  <Form.Label column sm="3">
+                    <OverlayTrigger
+                      delay={{show: 250, hide: 400}}
+                      overlay={(props)=>renderToolTip(props, param)}>
+                      <div>
+                        {name}
+                      </div>
+                    </OverlayTrigger>
+                  </Form.Label>
+                  <Col sm="8">
+                    <DateTimePicker
+                      showLeadingZeros={true}
+                      onChange={(value) => setRange(0, value)}
+                      value={range_dates[0]}
+                    />
+                    <span className="timestamp-range-separator">-</span>
+                    <DateTimePicker
+                      showLeadingZeros={true}
+                      onChange={(value) => setRange(1, value)}
+                      value={range_dates[1]}
+                    />
+                    {this.state.isUTC ?
+                     <Button variant="default-outline"
+                             onClick={() => this.setState({isUTC: false})}
+                             size="sm">UTC</Button>:
+                     <Button variant="default-outline"
+                             onClick={() => this.setState({isUTC: true})}
+                             size="sm">Local</Button>
+                    }
+                  </Col>
+                </Form.Group>
+            );
+
+        case "client_path":
+            return (
+                <Form.Group as={Row}>
+                  <Form.Label column sm="3">
+                    <OverlayTrigger
+                      delay={{show: 250, hide: 400}}
+                      overlay={(props)=>renderToolTip(props, param)}>
+                      <div>
+                        {name}
+                      </div>
+                    </OverlayTrigger>
+                  </Form.Label>
+                  <Col sm="8">
+                    <ClientPathForm
+                      client_id={this.props.client_id}
+                      value={this.props.value}
+                      setValue={this.props.setValue}
+                    />
+                  </Col>
+                </Form.Group>
+            );
+
         case "choices":
             return (
                 <Form.Group as={Row}>
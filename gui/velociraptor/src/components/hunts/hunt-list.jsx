@@ -99,7 +99,9 @@ class HuntList extends React.Component {
         });
     }
 
-    stopHunt = () => {
+    // A paused hunt does not schedule new clients but collections
+    // already scheduled keep running.
+    pauseHunt = () => {
         let hunt_id = this.props.selected_hunt &&
             this.props.selected_hunt.hunt_id;
 
@@ -108,6 +110,20 @@ class HuntList extends React.Component {
         api.post("v1/ModifyHunt", {
             state: "PAUSED",
             hunt_id: hunt_id,
+        }, this.source.token).then((response) => {
+            this.props.updateHunts();
+        });
+    }
+
+    stopHunt = () => {
+        let hunt_id = this.props.selected_hunt &&
+            this.props.selected_hunt.hunt_id;
+
+        if (!hunt_id) {return;};
+
+        api.post("v1/ModifyHunt", {
+            state: "STOPPED",
+            hunt_id: hunt_id,
         }, this.source.token).then((response) => {
             this.props.updateHunts();
 
@@ -335,6 +351,14 @@ class HuntList extends React.Component {
                           variant="default">
                     <FontAwesomeIcon icon="play"/>
                   </Button>
+                  <Button data-tooltip={T("Pause Hunt")}
+                          data-position="right"
+                          className="btn-tooltip"
+                          disabled={state !== 'RUNNING'}
+                          onClick={this.pauseHunt}
+                          variant="default">
+                    <FontAwesomeIcon icon="pause"/>
+                  </Button>
                   <Button data-tooltip={T("Stop Hunt")}
                           data-position="right"
                           className="btn-tooltip"
@@ -64,6 +64,7 @@ const Deutsch = {
     "Goto Page": "Gehe zu Seite",
     "Table is Empty": "Die Tabelle ist leer",
     "OS Version": "Version des Betriebssystems",
+    "Health": "Zustand",
     "Select a label": "Wählen Sie ein Label aus",
     "Expand": "Aufklappen",
     "Collapse": "Zuklappen",
@@ -280,6 +281,7 @@ const Deutsch = {
     "Scheduled":"Geplant",
     "New Hunt":"Neue Hunt",
     "Run Hunt":"Hunt starten",
+    "Pause Hunt":"Hunt pausieren",
     "Stop Hunt":"Hunt beenden",
     "Delete Hunt":"Hunt löschen",
     "Copy Hunt":"Hunt kopieren",
@@ -583,6 +585,7 @@ const Deutsch = {
     "Type":"Typ",
     "Export notebooks":"Notizbücher exportieren",
     "Export to HTML":"Nach HTML exportieren",
+    "Export to PDF":"Nach PDF exportieren",
     "Export to Zip":"Nach Zip exportieren",
 
     "Permanently delete Notebook":"Notizbuch endgültig löschen",
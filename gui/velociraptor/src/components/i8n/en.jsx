@@ -223,6 +223,8 @@ const English = {
     "Perm_MACHINE_STATE" : "Machine State",
     "Perm_PREPARE_RESULTS" : "Prepare Results",
     "Perm_DATASTORE_ACCESS" : "Datastore Access",
+    "Perm_READ_SHELL_RECORDINGS" : "Read Shell Recordings",
+    "Perm_SECRETS_MANAGER" : "Secrets Manager",
 
 
     "ToolPerm_ALL_QUERY" : "Issue all queries without restriction",
@@ -244,6 +246,8 @@ const English = {
     "ToolPerm_MACHINE_STATE" : "Allowed to collect state information from machines (e.g. pslist())",
     "ToolPerm_PREPARE_RESULTS" : "Allowed to create zip files",
     "ToolPerm_DATASTORE_ACCESS" : " Allowed raw datastore access",
+    "ToolPerm_READ_SHELL_RECORDINGS" : "Allowed to read the recorded input and output of remote shell sessions",
+    "ToolPerm_SECRETS_MANAGER" : "Allowed to add, update and delete secrets used by artifacts",
 
 
 
@@ -65,6 +65,7 @@ const Spanish = {
     "Goto Page": "Ir a página",
     "Table is Empty": "Tabla vacía",
     "OS Version": "Versión del sistema operativo",
+    "Health": "Salud",
     "Select a label": "Seleccionar una etiqueta",
     "Expand": "Expandir",
     "Collapse": "Contraer",
@@ -279,6 +280,7 @@ const Spanish = {
     "Scheduled":"Programado",
     "New Hunt":"Nuevo Hunt",
     "Run Hunt":"Ejecutar Hunt",
+    "Pause Hunt":"Pausar Hunt",
     "Stop Hunt":"Detener Hunt",
     "Delete Hunt":"Eliminar Hunt",
     "Copy Hunt":"Copiar Hunt",
@@ -584,6 +586,7 @@ const Spanish = {
     "Type":"Tipo",
     "Export notebooks":"Exportar bloc de notas",
     "Export to HTML":"Exportar a HTML",
+    "Export to PDF":"Exportar a PDF",
     "Export to Zip":"Exportar a Zip",
 
     "Permanently delete Notebook":"Eliminar bloc de notas permanentemente",
@@ -66,6 +66,7 @@ const French = {
     "Goto Page": "Aller à la page",
     "Table is Empty": "Le tableau est vide",
     "OS Version": "Version du système d'exploitation",
+    "Health": "Santé",
     "Select a label": "Sélectionner un libellé",
     "Expand": "Développer",
     "Collapse": "Panne",
@@ -282,6 +283,7 @@ const French = {
     "Scheduled":"Programmé",
     "New Hunt":"Nouvelle chasse",
     "Run Hunt":"Exécutez la chasse",
+    "Pause Hunt":"Suspendre la chasse",
     "Stop Hunt":"Arrêtez la chasse",
     "Delete Hunt":"Supprimer la chasse",
     "Copy Hunt":"Copier la chasse",
@@ -587,6 +589,7 @@ const French = {
     "Type":"Type",
     "Export notebooks":"Exporter les carnets de notes",
     "Export to HTML":"Exporter au format HTML",
+    "Export to PDF":"Exporter au format PDF",
     "Export to Zip":"Exporter au format Zip",
 
     "Permanently delete Notebook":"Supprimer définitivement le carnet de notes",
@@ -63,6 +63,7 @@ const Japanese = {
     "Goto Page": "ページを開く",
     "Table is Empty": "テーブルが空",
     "OS Version": "OSバージョン",
+    "Health": "健全性",
     "Select a label": "ラベルの選択",
     "Expand": "拡大する",
     "Collapse": "閉じる",
@@ -274,6 +275,7 @@ const Japanese = {
     "Scheduled":"予定されている",
     "New Hunt":"新規ハント",
     "Run Hunt":"ハントの実行",
+    "Pause Hunt":"ハントの一時停止",
     "Stop Hunt":"ハントの停止",
     "Delete Hunt":"ハントの削除",
     "Copy Hunt":"ハントのコピー",
@@ -565,6 +567,7 @@ const Japanese = {
     "Type":"タイプ",
     "Export notebooks":"ノートブックのエクスポート",
     "Export to HTML":"HTMLのエクスポート",
+    "Export to PDF":"PDFのエクスポート",
     "Export to Zip":"Zipのエクスポート",
 
     "Permanently delete Notebook":"ノートブックを永久に削除する",
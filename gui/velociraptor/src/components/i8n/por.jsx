@@ -66,6 +66,7 @@ const Portuguese = {
     "Goto Page": "Ir para a página",
     "Table is Empty": "A tabela está vazia",
     "OS Version": "Versão do Sistema Operacional",
+    "Health": "Saúde",
     "Select a label": "Selecione um rótulo",
     "Expand": "Expandir",
     "Collapse": "Detalhamento",
@@ -280,6 +281,7 @@ const Portuguese = {
     "Scheduled":"Planejado",
     "New Hunt":"Nova Investigação",
     "Run Hunt":"Executar Investigação",
+    "Pause Hunt":"Pausar Investigação",
     "Stop Hunt":"Parar Investigação",
     "Delete Hunt":"Excluir Investigação",
     "Copy Hunt":"Copiar a Investigação",
@@ -585,6 +587,7 @@ const Portuguese = {
     "Type":"Tipo",
     "Export notebooks":"Exportar notebooks",
     "Export to HTML":"Exportar para HTML",
+    "Export to PDF":"Exportar para PDF",
     "Export to Zip":"Exportar para Zip",
 
     "Permanently delete Notebook":"Excluir Notebook permanentemente",
@@ -125,6 +125,10 @@ export default class ExportNotebook extends React.Component {
                             onClick={()=>this.exportNotebook("html")} >
                       {T("Export to HTML")}
                     </Button>
+                    <Button variant="default"
+                            onClick={()=>this.exportNotebook("pdf")} >
+                      {T("Export to PDF")}
+                    </Button>
                     <Button variant="default"
                             onClick={()=>this.exportNotebook("zip")} >
                       {T("Export to Zip")}
@@ -0,0 +1,104 @@
+package http_comms
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"sync"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
+)
+
+var (
+	// Request a new certificate when the current one expires
+	// within this time.
+	clientCertificateRenewal = 30 * 24 * time.Hour
+
+	// How often we check the certificate.
+	clientCertificateCheckPeriod = 24 * time.Hour
+)
+
+// Present the client certificate stored in the writeback when the
+// frontend asks for one. We read the writeback on each handshake so a
+// newly issued certificate is used immediately.
+func getClientCertificate(config_obj *config_proto.Config) func(
+	*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return func(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		writeback, err := config.GetWriteback(config_obj.Client)
+		if err != nil || writeback.ClientCertificate == "" {
+			// An empty certificate means we do not send one.
+			return &tls.Certificate{}, nil
+		}
+
+		cert, err := crypto_utils.GetWritebackTLSCertificate(
+			writeback, []byte(writeback.ClientCertificate))
+		if err != nil {
+			return &tls.Certificate{}, nil
+		}
+		return cert, nil
+	}
+}
+
+// Do we need to ask the server for a new client certificate?
+func clientCertificateNeedsRenewal(
+	config_obj *config_proto.Config, now time.Time) bool {
+	writeback, err := config.GetWriteback(config_obj.Client)
+	if err != nil {
+		return false
+	}
+
+	block, _ := pem.Decode([]byte(writeback.ClientCertificate))
+	if block == nil {
+		return true
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+
+	return now.Add(clientCertificateRenewal).After(cert.NotAfter)
+}
+
+// Send our CSR to the server. The server replies with a signed
+// certificate which the executor stores in the writeback.
+func (self *Enroller) RequestClientCertificate() {
+	csr_pem, err := self.manager.GetCSR()
+	if err != nil {
+		return
+	}
+
+	self.logger.Info("Requesting client certificate")
+	self.executor.SendToServer(&crypto_proto.VeloMessage{
+		SessionId: constants.CLIENT_CERTIFICATE_WELL_KNOWN_FLOW,
+		CSR: &crypto_proto.Certificate{
+			Type: crypto_proto.Certificate_CSR,
+			Pem:  csr_pem,
+		},
+	})
+}
+
+// Keep the client certificate fresh.
+func (self *HTTPCommunicator) maintainClientCertificate(
+	ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for {
+		if clientCertificateNeedsRenewal(
+			self.config_obj, self.enroller.clock.Now()) {
+			self.enroller.RequestClientCertificate()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(clientCertificateCheckPeriod):
+		}
+	}
+}
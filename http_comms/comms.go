@@ -92,12 +92,23 @@ func (self *Enroller) MaybeEnrol() {
 		self.last_enrollment_time = time.Now()
 		self.logger.Info("Enrolling")
 
+		csr := &crypto_proto.Certificate{
+			Type: crypto_proto.Certificate_CSR,
+			Pem:  csr_pem,
+		}
+
+		// Tell the server our key is held in the TPM.
+		attestor, ok := self.manager.(crypto.IKeyAttestor)
+		if ok {
+			csr.Attestation, err = attestor.GetKeyAttestation()
+			if err != nil {
+				self.logger.Error("Unable to get TPM key attestation: %v", err)
+			}
+		}
+
 		go self.executor.SendToServer(&crypto_proto.VeloMessage{
 			SessionId: constants.ENROLLMENT_WELL_KNOWN_FLOW,
-			CSR: &crypto_proto.Certificate{
-				Type: crypto_proto.Certificate_CSR,
-				Pem:  csr_pem,
-			},
+			CSR:       csr,
 			// Enrolment messages should be sent
 			// immediately and not queued client side.
 			Urgent: true,
@@ -131,10 +142,16 @@ type HTTPConnector struct {
 	minPoll, maxPoll time.Duration
 	maxPollDev       uint64
 	// Used to cycle through the urls slice.
-	mu               sync.Mutex
-	current_url_idx  int
-	last_success_idx int
-	urls             []string
+	mu              sync.Mutex
+	current_url_idx int
+	urls            []string
+
+	// Ranks the frontends so we fail over to the healthiest one.
+	health *frontendHealth
+
+	// Frontends which failed since the last successful request. Once
+	// all have failed we back off.
+	tried map[int]bool
 
 	client *http.Client
 
@@ -146,6 +163,12 @@ type HTTPConnector struct {
 	// an error occurs or we are further redirected.
 	redirect_to_server int
 
+	// Used when long poll HTTP is degraded.
+	websocket *websocketTransport
+
+	// Preferred over TCP when enabled.
+	http3 *http3Transport
+
 	clock utils.Clock
 }
 
@@ -199,11 +222,26 @@ func NewHTTPConnector(
 		crypto.AddPublicRoots(tls_config.RootCAs)
 	}
 
+	if config_obj.Client.UseClientCertificate {
+		tls_config.GetClientCertificate = getClientCertificate(config_obj)
+	}
+
 	timeout := config_obj.Client.ConnectionTimeout
 	if timeout == 0 {
 		timeout = 300 // 5 Min default
 	}
 
+	dialer := &net.Dialer{
+		Timeout:   time.Duration(timeout) * time.Second,
+		KeepAlive: time.Duration(timeout) * time.Second,
+		DualStack: true,
+	}
+
+	proxy_dialer, err := NewProxyDialer(config_obj, dialer, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	self := &HTTPConnector{
 		config_obj: config_obj,
 		manager:    manager,
@@ -219,7 +257,16 @@ func NewHTTPConnector(
 		maxPoll:    time.Duration(max_poll) * time.Second,
 		maxPollDev: maxPollDev,
 
-		urls: urls,
+		urls:   urls,
+		health: newFrontendHealth(),
+		tried:  make(map[int]bool),
+
+		websocket: newWebsocketTransport(config_obj, tls_config, proxy_dialer,
+			time.Duration(timeout)*time.Second,
+			time.Duration(max_poll)*time.Second, logger, clock),
+
+		http3: newHTTP3Transport(config_obj, tls_config, proxy_dialer,
+			time.Duration(timeout)*time.Second, logger, clock),
 
 		client: &http.Client{
 			// Let us handle redirect ourselves.
@@ -227,12 +274,8 @@ func NewHTTPConnector(
 				return http.ErrUseLastResponse
 			},
 			Transport: &http.Transport{
-				DialContext: (&net.Dialer{
-					Timeout:   time.Duration(timeout) * time.Second,
-					KeepAlive: time.Duration(timeout) * time.Second,
-					DualStack: true,
-				}).DialContext,
-				Proxy:                 proxyHandler,
+				DialContext:           proxy_dialer.DialContext,
+				Proxy:                 proxy_dialer.Proxy,
 				MaxIdleConns:          100,
 				IdleConnTimeout:       time.Duration(timeout) * time.Second,
 				TLSHandshakeTimeout:   time.Duration(timeout) * time.Second,
@@ -259,10 +302,28 @@ func (self *HTTPConnector) GetCurrentUrl(handler string) string {
 	return self.urls[self.current_url_idx] + handler
 }
 
+// The base URL of the current frontend.
+func (self *HTTPConnector) getCurrentServerUrl() string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.urls[self.current_url_idx]
+}
+
 func (self *HTTPConnector) Post(
 	ctx context.Context, name, handler string,
 	data []byte, urgent bool) (*bytes.Buffer, error) {
 
+	// Try the websocket first if it is active, otherwise fall back
+	// to HTTP for this request.
+	if self.websocket.ShouldUse() {
+		encrypted, err := self.postWebsocket(ctx, name, handler, data, urgent)
+		if err == nil || err == EnrolError || ctx.Err() != nil {
+			return encrypted, err
+		}
+		self.websocket.Fail(err)
+	}
+
 	reader := bytes.NewReader(data)
 	req, err := http.NewRequestWithContext(ctx,
 		"POST", self.GetCurrentUrl(handler), reader)
@@ -291,10 +352,13 @@ func (self *HTTPConnector) Post(
 		req.Header.Set("X-Priority", "urgent")
 	}
 
-	resp, err := self.client.Do(req)
+	resp, err := self.do(req)
 	if err != nil && err != io.EOF {
 		self.logger.Info("Post to %v returned %v - advancing to next server\n",
 			self.GetCurrentUrl(handler), err)
+		if ctx.Err() == nil {
+			self.websocket.RecordHTTPResult(false)
+		}
 
 		// POST error - rotate to next URL
 		self.advanceToNextServer(ctx)
@@ -376,15 +440,15 @@ func (self *HTTPConnector) Post(
 		// ioutil.ReadAll()
 		n, err := utils.Copy(ctx, encrypted, resp.Body)
 		if err != nil {
+			if ctx.Err() == nil {
+				self.websocket.RecordHTTPResult(false)
+			}
 			return nil, errors.Wrap(err, 0)
 		}
 
 		self.logger.Info("%s: received %d bytes", name, n)
-
-		// Remember the last successful index.
-		self.mu.Lock()
-		self.last_success_idx = self.current_url_idx
-		self.mu.Unlock()
+		self.websocket.RecordHTTPResult(true)
+		self.recordSuccess()
 
 		return encrypted, nil
 
@@ -399,46 +463,114 @@ func (self *HTTPConnector) Post(
 	}
 }
 
+// Send the request over HTTP/3 if we can, otherwise over TCP.
+func (self *HTTPConnector) do(req *http.Request) (*http.Response, error) {
+	if self.http3.ShouldUse(req) {
+		resp, err := self.http3.Do(req)
+		if err == nil || req.Context().Err() != nil {
+			return resp, err
+		}
+		self.http3.Fail(err)
+
+		// Send the request again over TCP.
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+
+	return self.client.Do(req)
+}
+
+func (self *HTTPConnector) postWebsocket(
+	ctx context.Context, name, handler string,
+	data []byte, urgent bool) (*bytes.Buffer, error) {
+
+	status, encrypted, err := self.websocket.Post(ctx,
+		self.getCurrentServerUrl(), handler, data, urgent)
+	if err != nil {
+		return nil, err
+	}
+
+	self.logger.Info("%s: sent %d bytes over websocket, response with status: %v",
+		name, len(data), status)
+
+	switch status {
+	case 406:
+		return nil, EnrolError
+
+	case 200:
+		self.logger.Info("%s: received %d bytes", name, encrypted.Len())
+		self.recordSuccess()
+
+		return encrypted, nil
+
+	default:
+		return nil, fmt.Errorf("Websocket request returned status %v", status)
+	}
+}
+
+// Remember the current frontend works and report it.
+func (self *HTTPConnector) recordSuccess() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.health.RecordSuccess(self.current_url_idx)
+	self.tried = make(map[int]bool)
+	utils.SetCommsFrontend(self.urls[self.current_url_idx])
+}
+
 // When we have any failures contacting any server, we advance our url
-// index to the next frontend. When we went all the way around the
-// loop we wait to backoff.  Therefore when switching from one FE to
-// another we wont necessarily wait but if all frontends are down we
-// wait once per loop.
+// index to the healthiest frontend we did not try yet. When all
+// frontends failed we wait to backoff.  Therefore when switching from
+// one FE to another we wont necessarily wait but if all frontends are
+// down we wait once per loop.
 func (self *HTTPConnector) advanceToNextServer(ctx context.Context) {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
+	self.health.RecordFailure(self.current_url_idx)
+	self.tried[self.current_url_idx] = true
+
 	// Advance the current URL to the next one in
 	// line. Reset the server name (will be fetched from
 	// the PEM) and do not use redirects.
-	self.current_url_idx = ((self.current_url_idx + 1) % len(self.urls))
 	self.redirect_to_server = 0
 	self.server_name = ""
 
-	// We are cycling through all our frontend's PEM keys
-	// in this loop. Once we go all the way around we
-	// sleep to back off.
-	if self.current_url_idx == self.last_success_idx {
-		wait := self.maxPoll + time.Duration(
-			GetRand()(int(self.maxPollDev)))*time.Second
+	next := self.health.Best(len(self.urls), self.current_url_idx, self.tried)
+	if next >= 0 {
+		self.current_url_idx = next
+		self.logger.Info("Failing over to frontend %v", self.urls[next])
+		return
+	}
 
-		self.logger.Info(
-			"Waiting for a reachable server: %v", wait)
+	// We tried all our frontends in this loop. Start again with
+	// the healthiest frontend but sleep to back off.
+	self.tried = make(map[int]bool)
+	self.current_url_idx = self.health.Best(
+		len(self.urls), self.current_url_idx, nil)
 
-		// While we wait to reconnect we need to update the nanny or
-		// we get killed.
-		if executor.Nanny != nil {
-			executor.Nanny.UpdatePumpRbToServer()
-			executor.Nanny.UpdateReadFromServer()
-		}
+	wait := self.maxPoll + time.Duration(
+		GetRand()(int(self.maxPollDev)))*time.Second
 
-		// Add random wait between polls to avoid
-		// synchronization of endpoints.
-		select {
-		case <-self.clock.After(wait):
-		case <-ctx.Done():
-			return
-		}
+	self.logger.Info(
+		"Waiting for a reachable server: %v", wait)
+
+	// While we wait to reconnect we need to update the nanny or
+	// we get killed.
+	if executor.Nanny != nil {
+		executor.Nanny.UpdatePumpRbToServer()
+		executor.Nanny.UpdateReadFromServer()
+	}
+
+	// Add random wait between polls to avoid
+	// synchronization of endpoints.
+	select {
+	case <-self.clock.After(wait):
+	case <-ctx.Done():
+		return
 	}
 }
 
@@ -703,6 +835,12 @@ func (self *NotificationReader) sendToURL(
 
 	if !urgent {
 		self.limiter.Wait(ctx)
+
+		// Urgent messages are exempt from the bandwidth limits.
+		err = utils.GetBandwidthLimiter().WaitComms(ctx, len(cipher_text))
+		if err != nil {
+			return err
+		}
 	}
 	encrypted, err := self.connector.Post(ctx, self.name,
 		self.handler, cipher_text, urgent)
@@ -863,6 +1001,26 @@ func (self *HTTPCommunicator) Run(
 	self.receiver.Start(ctx, wg)
 	self.sender.Start(ctx, wg)
 
+	if self.config_obj.Client.UseClientCertificate {
+		wg.Add(1)
+		go self.maintainClientCertificate(ctx, wg)
+	}
+
+	connector, ok := self.receiver.connector.(*HTTPConnector)
+	if ok {
+		connector.StartProbing(ctx, wg)
+
+		err := StartRelay(ctx, wg, self.config_obj, connector)
+		if err != nil {
+			self.logger.Error("Unable to start relay: %v", err)
+		}
+	}
+
+	if self.config_obj.Client.KeyRotationDays > 0 {
+		wg.Add(1)
+		go self.maintainKeyRotation(ctx, wg)
+	}
+
 	<-ctx.Done()
 }
 
@@ -888,6 +1046,15 @@ func NewHTTPCommunicator(
 		return nil, err
 	}
 
+	// Bandwidth limits apply to the communicator and to uploads so
+	// they are shared by the entire client.
+	bandwidth_limiter, err := utils.NewBandwidthLimiter(
+		config_obj.Client.GetBandwidth())
+	if err != nil {
+		return nil, err
+	}
+	utils.SetBandwidthLimiter(bandwidth_limiter)
+
 	rb := NewLocalBuffer(ctx, config_obj)
 
 	// Truncate the file to ensure we always start with a clean
@@ -924,6 +1091,7 @@ func NewHTTPCommunicator(
 	if err != nil {
 		return nil, err
 	}
+	sender.event_buffer = NewLocalEventBuffer(config_obj)
 
 	// The receiver receives messages from the server.
 
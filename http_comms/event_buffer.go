@@ -0,0 +1,533 @@
+package http_comms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// The EventBuffer holds client monitoring events while the server is
+// unreachable. Unlike the FileBasedRingBuffer it is kept across
+// restarts and never blocks the executor - when it is full the oldest
+// events are discarded.
+//
+// The buffer is a directory of segment files. Each record is:
+//   8 bytes length | 12 bytes nonce | AES-GCM(8 bytes timestamp | item)
+//
+// The state file records how far into the oldest segment the server
+// has acknowledged so we do not resend events after a restart.
+
+const (
+	eventSegmentSuffix = ".seg"
+	eventStateFile     = "state"
+	eventNonceSize     = 12
+
+	defaultEventMaxAge = 7 * 24 * 60 * 60
+
+	minEventSegmentSize = 64 * 1024
+)
+
+type eventSegment struct {
+	id   uint64
+	size int64
+}
+
+type EventBuffer struct {
+	mu sync.Mutex
+
+	dir  string
+	aead cipher.AEAD
+
+	max_size     int64
+	max_age      time.Duration
+	segment_size int64
+
+	// Oldest segment first. The last segment is being written to.
+	segments   []*eventSegment
+	total_size int64
+	writer     *os.File
+
+	// Records before this offset in the oldest segment were already
+	// delivered.
+	read_offset int64
+
+	// Leases start at this segment index and offset.
+	lease_idx    int
+	lease_offset int64
+	leased_bytes int64
+
+	closed bool
+
+	clock   utils.Clock
+	log_ctx *logging.LogContext
+}
+
+func (self *EventBuffer) segmentPath(id uint64) string {
+	return filepath.Join(self.dir, fmt.Sprintf("%016x%s", id, eventSegmentSuffix))
+}
+
+func (self *EventBuffer) Enqueue(item []byte) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.closed {
+		return
+	}
+
+	plain_text := make([]byte, 8, 8+len(item))
+	binary.LittleEndian.PutUint64(plain_text, uint64(self.clock.Now().Unix()))
+	plain_text = append(plain_text, item...)
+
+	record := make([]byte, 8+eventNonceSize, 8+eventNonceSize+
+		len(plain_text)+self.aead.Overhead())
+	nonce := record[8:]
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return
+	}
+	record = self.aead.Seal(record, nonce, plain_text, nil)
+	binary.LittleEndian.PutUint64(record, uint64(len(record)-8))
+
+	current := self.segments[len(self.segments)-1]
+	_, err = self.writer.WriteAt(record, current.size)
+	if err != nil {
+		self.log_ctx.Errorf("Event Buffer: Unable to write event: %v", err)
+		return
+	}
+	current.size += int64(len(record))
+	self.total_size += int64(len(record))
+
+	if current.size >= self.segment_size {
+		err = self.startSegment(current.id + 1)
+		if err != nil {
+			self.log_ctx.Errorf("Event Buffer: %v", err)
+		}
+	}
+
+	// Make room by discarding the oldest segments.
+	for self.total_size > self.max_size && len(self.segments) > 1 {
+		self.dropOldestSegment()
+	}
+}
+
+// Start writing to a new segment. Assumes the lock is held.
+func (self *EventBuffer) startSegment(id uint64) error {
+	fd, err := os.OpenFile(self.segmentPath(id),
+		os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if self.writer != nil {
+		self.writer.Close()
+	}
+	self.writer = fd
+	self.segments = append(self.segments, &eventSegment{id: id})
+
+	return nil
+}
+
+// Assumes the lock is held.
+func (self *EventBuffer) dropOldestSegment() {
+	oldest := self.segments[0]
+	self.segments = self.segments[1:]
+	self.total_size -= oldest.size
+
+	if self.lease_idx > 0 {
+		self.lease_idx--
+		self.leased_bytes -= oldest.size - self.read_offset
+	} else {
+		self.lease_offset = 0
+		self.leased_bytes = 0
+	}
+	self.read_offset = 0
+
+	os.Remove(self.segmentPath(oldest.id))
+
+	self.log_ctx.WithFields(logrus.Fields{
+		"segment": oldest.id,
+		"size":    oldest.size,
+	}).Info("Event Buffer: Buffer full - discarding oldest events")
+}
+
+func (self *EventBuffer) AvailableBytes() uint64 {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return uint64(self.total_size - self.read_offset - self.leased_bytes)
+}
+
+func (self *EventBuffer) TotalSize() uint64 {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return uint64(self.total_size - self.read_offset)
+}
+
+func (self *EventBuffer) Lease(size uint64) []byte {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := []byte{}
+	if self.closed {
+		return result
+	}
+
+	now := self.clock.Now()
+	length_buf := make([]byte, 8)
+
+	for self.lease_idx < len(self.segments) &&
+		uint64(len(result)) <= size {
+		segment := self.segments[self.lease_idx]
+
+		// Move to the next segment.
+		if self.lease_offset+8 > segment.size {
+			if self.lease_idx == len(self.segments)-1 {
+				break
+			}
+			self.leased_bytes += segment.size - self.lease_offset
+			self.lease_idx++
+			self.lease_offset = 0
+			continue
+		}
+
+		record, err := self.readRecord(segment, self.lease_offset, length_buf)
+		if err != nil {
+			// A torn write at the end of the segment (e.g. we
+			// crashed while writing) - skip the rest of it.
+			self.log_ctx.Errorf("Event Buffer: Possible corruption detected: %v", err)
+			self.leased_bytes += segment.size - self.lease_offset
+			self.lease_offset = segment.size
+			continue
+		}
+
+		self.lease_offset += 8 + int64(len(record))
+		self.leased_bytes += 8 + int64(len(record))
+
+		plain_text, err := self.aead.Open(nil, record[:eventNonceSize],
+			record[eventNonceSize:], nil)
+		if err != nil || len(plain_text) < 8 {
+			continue
+		}
+
+		// Events which are too old are not useful any more.
+		timestamp := time.Unix(int64(binary.LittleEndian.Uint64(plain_text)), 0)
+		if now.Sub(timestamp) > self.max_age {
+			continue
+		}
+
+		result = append(result, plain_text[8:]...)
+	}
+
+	return result
+}
+
+func (self *EventBuffer) readRecord(
+	segment *eventSegment, offset int64, length_buf []byte) ([]byte, error) {
+	var fd *os.File
+	if segment == self.segments[len(self.segments)-1] {
+		fd = self.writer
+	} else {
+		var err error
+		fd, err = os.Open(self.segmentPath(segment.id))
+		if err != nil {
+			return nil, err
+		}
+		defer fd.Close()
+	}
+
+	_, err := fd.ReadAt(length_buf, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	length := int64(binary.LittleEndian.Uint64(length_buf))
+	if length <= eventNonceSize || length > constants.MAX_MEMORY*2 ||
+		offset+8+length > segment.size {
+		return nil, errors.New("Invalid record length")
+	}
+
+	record := make([]byte, length)
+	_, err = fd.ReadAt(record, offset+8)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Discard the leased events since the server has received them.
+func (self *EventBuffer) Commit() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.closed {
+		return
+	}
+
+	for i := 0; i < self.lease_idx; i++ {
+		oldest := self.segments[0]
+		self.segments = self.segments[1:]
+		self.total_size -= oldest.size
+		os.Remove(self.segmentPath(oldest.id))
+	}
+
+	self.read_offset = self.lease_offset
+	self.lease_idx = 0
+	self.leased_bytes = 0
+
+	// Everything was delivered - start again with an empty segment.
+	current := self.segments[0]
+	if len(self.segments) == 1 && self.read_offset == current.size &&
+		current.size > 0 {
+		err := self.startSegment(current.id + 1)
+		if err == nil {
+			self.segments = self.segments[1:]
+			self.total_size -= current.size
+			self.read_offset = 0
+			self.lease_offset = 0
+			os.Remove(self.segmentPath(current.id))
+		}
+	}
+
+	self.writeState()
+}
+
+// Record how much of the oldest segment was delivered. Assumes the
+// lock is held.
+func (self *EventBuffer) writeState() {
+	state := make([]byte, 16)
+	binary.LittleEndian.PutUint64(state, self.segments[0].id)
+	binary.LittleEndian.PutUint64(state[8:], uint64(self.read_offset))
+
+	err := ioutil.WriteFile(filepath.Join(self.dir, eventStateFile), state, 0600)
+	if err != nil {
+		self.log_ctx.Errorf("Event Buffer: Unable to write state: %v", err)
+	}
+}
+
+func (self *EventBuffer) Reset() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.closed {
+		return
+	}
+
+	next_id := self.segments[len(self.segments)-1].id + 1
+	for _, segment := range self.segments {
+		os.Remove(self.segmentPath(segment.id))
+	}
+
+	self.segments = nil
+	self.total_size = 0
+	self.read_offset = 0
+	self.lease_idx = 0
+	self.lease_offset = 0
+	self.leased_bytes = 0
+
+	err := self.startSegment(next_id)
+	if err != nil {
+		self.log_ctx.Errorf("Event Buffer: %v", err)
+		self.closed = true
+		return
+	}
+	self.writeState()
+}
+
+// Unlike the other ring buffers the event buffer is kept on disk so
+// it can be sent after a restart.
+func (self *EventBuffer) Close() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.closed {
+		return
+	}
+	self.closed = true
+	self.writer.Close()
+}
+
+func getEventBufferKey(config_obj *config_proto.Config) ([]byte, error) {
+	writeback, err := config.GetWriteback(config_obj.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(writeback.EventBufferKey) == 32 {
+		return writeback.EventBufferKey, nil
+	}
+
+	key := make([]byte, 32)
+	_, err = rand.Read(key)
+	if err != nil {
+		return nil, err
+	}
+
+	writeback.EventBufferKey = key
+	err = config.UpdateWriteback(config_obj.Client, writeback)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func NewEventBuffer(
+	config_obj *config_proto.Config,
+	log_ctx *logging.LogContext) (*EventBuffer, error) {
+
+	if config_obj.Client == nil || config_obj.Client.LocalBuffer == nil ||
+		config_obj.Client.LocalBuffer.EventDiskSize == 0 {
+		return nil, errors.New("Event buffer not configured")
+	}
+	local_buffer := config_obj.Client.LocalBuffer
+
+	filename := getLocalBufferName(config_obj)
+	if filename == "" {
+		return nil, errors.New("Unsupport platform")
+	}
+
+	key, err := getEventBufferKey(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	max_age := local_buffer.EventMaxAge
+	if max_age == 0 {
+		max_age = defaultEventMaxAge
+	}
+
+	segment_size := int64(local_buffer.EventDiskSize / 16)
+	if segment_size < minEventSegmentSize {
+		segment_size = minEventSegmentSize
+	}
+
+	self := &EventBuffer{
+		dir:          filename + ".events",
+		aead:         aead,
+		max_size:     int64(local_buffer.EventDiskSize),
+		max_age:      time.Duration(max_age) * time.Second,
+		segment_size: segment_size,
+		clock:        utils.GetTime(),
+		log_ctx:      log_ctx,
+	}
+
+	err = os.MkdirAll(self.dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	err = self.loadSegments()
+	if err != nil {
+		return nil, err
+	}
+
+	log_ctx.WithFields(logrus.Fields{
+		"directory": self.dir,
+		"max_size":  self.max_size,
+		"pending":   self.total_size - self.read_offset,
+	}).Info("Event Buffer: Creation")
+
+	return self, nil
+}
+
+// Load the segments left over from previous runs and start a new
+// segment to write to.
+func (self *EventBuffer) loadSegments() error {
+	files, err := ioutil.ReadDir(self.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		name := file.Name()
+		if !strings.HasSuffix(name, eventSegmentSuffix) {
+			continue
+		}
+
+		id, err := strconv.ParseUint(
+			strings.TrimSuffix(name, eventSegmentSuffix), 16, 64)
+		if err != nil {
+			continue
+		}
+
+		self.segments = append(self.segments, &eventSegment{
+			id: id, size: file.Size()})
+	}
+
+	sort.Slice(self.segments, func(i, j int) bool {
+		return self.segments[i].id < self.segments[j].id
+	})
+
+	state, err := ioutil.ReadFile(filepath.Join(self.dir, eventStateFile))
+	if err == nil && len(state) == 16 {
+		state_id := binary.LittleEndian.Uint64(state)
+
+		// Segments older than the state were already delivered.
+		for len(self.segments) > 0 && self.segments[0].id < state_id {
+			os.Remove(self.segmentPath(self.segments[0].id))
+			self.segments = self.segments[1:]
+		}
+
+		if len(self.segments) > 0 && self.segments[0].id == state_id {
+			self.read_offset = int64(binary.LittleEndian.Uint64(state[8:]))
+			if self.read_offset > self.segments[0].size {
+				self.read_offset = self.segments[0].size
+			}
+		}
+	}
+	self.lease_offset = self.read_offset
+
+	next_id := uint64(1)
+	for _, segment := range self.segments {
+		self.total_size += segment.size
+		next_id = segment.id + 1
+	}
+
+	// Never append to an old segment since it may end with a torn
+	// write.
+	return self.startSegment(next_id)
+}
+
+// Returns nil if the event buffer is not configured.
+func NewLocalEventBuffer(config_obj *config_proto.Config) IRingBuffer {
+	if config_obj.Client == nil || config_obj.Client.LocalBuffer == nil ||
+		config_obj.Client.LocalBuffer.EventDiskSize == 0 {
+		return nil
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.ClientComponent)
+	event_buffer, err := NewEventBuffer(config_obj, logger)
+	if err != nil {
+		logger.Error("Unable to create the event buffer - events will not be kept while offline: %v", err)
+		return nil
+	}
+	return event_buffer
+}
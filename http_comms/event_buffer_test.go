@@ -0,0 +1,110 @@
+package http_comms
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+func eventBufferConfig(t *testing.T, dir string) *config_proto.Config {
+	config_obj := config.GetDefaultConfig()
+	writeback := filepath.Join(dir, "writeback.yaml")
+	config_obj.Client.WritebackLinux = writeback
+	config_obj.Client.WritebackWindows = writeback
+	config_obj.Client.WritebackDarwin = writeback
+
+	filename := filepath.Join(dir, "buffer.bin")
+	config_obj.Client.LocalBuffer.FilenameLinux = filename
+	config_obj.Client.LocalBuffer.FilenameWindows = filename
+	config_obj.Client.LocalBuffer.FilenameDarwin = filename
+	config_obj.Client.LocalBuffer.EventDiskSize = 200 * 1024
+	config_obj.Client.LocalBuffer.EventMaxAge = 3600
+
+	return config_obj
+}
+
+func openEventBuffer(
+	t *testing.T, config_obj *config_proto.Config) *EventBuffer {
+	null_logger, _ := test.NewNullLogger()
+	event_buffer, err := NewEventBuffer(
+		config_obj, &logging.LogContext{Logger: null_logger})
+	require.NoError(t, err)
+	return event_buffer
+}
+
+func TestEventBufferPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "event_buffer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	config_obj := eventBufferConfig(t, dir)
+
+	event_buffer := openEventBuffer(t, config_obj)
+	event_buffer.Enqueue([]byte("Hello"))
+	event_buffer.Enqueue([]byte("World"))
+	event_buffer.Close()
+
+	// Events are not stored in the clear.
+	data, err := ioutil.ReadFile(filepath.Join(
+		dir, "buffer.bin.events", "0000000000000001.seg"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "Hello")
+
+	// Events survive a restart and are sent oldest first.
+	event_buffer = openEventBuffer(t, config_obj)
+	assert.Equal(t, "HelloWorld", string(event_buffer.Lease(100)))
+	event_buffer.Commit()
+	event_buffer.Enqueue([]byte("Goodbye"))
+	event_buffer.Close()
+
+	// Delivered events are not sent again.
+	event_buffer = openEventBuffer(t, config_obj)
+	assert.Equal(t, "Goodbye", string(event_buffer.Lease(100)))
+	event_buffer.Commit()
+	assert.Equal(t, uint64(0), event_buffer.TotalSize())
+	event_buffer.Close()
+}
+
+func TestEventBufferLimits(t *testing.T) {
+	dir, err := ioutil.TempDir("", "event_buffer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	clock := &utils.MockClock{MockNow: time.Unix(1600000000, 0)}
+	defer utils.MockTime(clock)()
+
+	config_obj := eventBufferConfig(t, dir)
+	event_buffer := openEventBuffer(t, config_obj)
+	defer event_buffer.Close()
+
+	// Fill the buffer well past its size - the oldest events are
+	// discarded.
+	item := make([]byte, 1024)
+	for i := 0; i < 1000; i++ {
+		item[0] = byte(i)
+		event_buffer.Enqueue(item)
+	}
+	assert.True(t, event_buffer.TotalSize() <= 200*1024)
+
+	leased := event_buffer.Lease(1024)
+	assert.True(t, len(leased) > 0)
+	assert.NotEqual(t, byte(0), leased[0])
+	event_buffer.Reset()
+
+	// Expired events are dropped.
+	event_buffer.Enqueue([]byte("Old"))
+	clock.MockNow = clock.MockNow.Add(2 * time.Hour)
+	event_buffer.Enqueue([]byte("New"))
+
+	assert.Equal(t, "New", string(event_buffer.Lease(100)))
+}
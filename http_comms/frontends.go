@@ -0,0 +1,217 @@
+package http_comms
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"www.velocidex.com/golang/velociraptor/constants"
+)
+
+const (
+	// Weight of the latest observation in the moving averages.
+	healthAlpha = 0.3
+
+	// Scores closer than this are considered equal so we do not
+	// flap between similar frontends.
+	healthTolerance = 0.05
+
+	// Switch away from the current frontend when another frontend
+	// scores better by at least this much.
+	healthSwitchMargin = 0.25
+
+	// How long to wait for a frontend to answer a probe.
+	probeTimeout = 10 * time.Second
+)
+
+type frontendStats struct {
+	// Moving average of the failure rate between 0 and 1.
+	error_rate float64
+
+	// Moving average of the probe latency - 0 if never probed.
+	latency time.Duration
+}
+
+// Tracks the health of each frontend so the client can pick the best
+// one. Frontends are ranked by their recent error rate and probe
+// latency.
+type frontendHealth struct {
+	mu    sync.Mutex
+	stats []*frontendStats
+}
+
+func newFrontendHealth() *frontendHealth {
+	return &frontendHealth{}
+}
+
+// Frontends may be added by redirects so the stats grow as needed.
+func (self *frontendHealth) get(idx int) *frontendStats {
+	for len(self.stats) <= idx {
+		self.stats = append(self.stats, &frontendStats{})
+	}
+	return self.stats[idx]
+}
+
+func (self *frontendHealth) RecordSuccess(idx int) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	stats := self.get(idx)
+	stats.error_rate *= 1 - healthAlpha
+}
+
+func (self *frontendHealth) RecordFailure(idx int) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	stats := self.get(idx)
+	stats.error_rate = stats.error_rate*(1-healthAlpha) + healthAlpha
+}
+
+// A successful probe which took this long.
+func (self *frontendHealth) RecordLatency(idx int, latency time.Duration) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	stats := self.get(idx)
+	stats.error_rate *= 1 - healthAlpha
+	if stats.latency == 0 {
+		stats.latency = latency
+	} else {
+		stats.latency = time.Duration(
+			float64(stats.latency)*(1-healthAlpha) + float64(latency)*healthAlpha)
+	}
+}
+
+// Lower is better. A second of latency weighs the same as a frontend
+// which always fails.
+func (self *frontendHealth) score(idx int) float64 {
+	stats := self.get(idx)
+	return stats.error_rate + stats.latency.Seconds()
+}
+
+// Pick the healthiest of the n frontends, skipping excluded ones. Ties
+// are broken in round robin order starting after the current
+// frontend. Returns -1 if all frontends are excluded.
+func (self *frontendHealth) Best(n, current int, exclude map[int]bool) int {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	best := -1
+	best_score := 0.0
+	for i := 1; i <= n; i++ {
+		idx := (current + i) % n
+		if exclude[idx] {
+			continue
+		}
+
+		score := self.score(idx)
+		if best < 0 || score < best_score-healthTolerance {
+			best = idx
+			best_score = score
+		}
+	}
+
+	return best
+}
+
+// Should we leave the current frontend for a better one?
+func (self *frontendHealth) ShouldSwitch(current, candidate int) bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return candidate != current &&
+		self.score(current)-self.score(candidate) >= healthSwitchMargin
+}
+
+// Periodically probe all frontends so we know which are healthy
+// before we need to fail over.
+func (self *HTTPConnector) StartProbing(ctx context.Context, wg *sync.WaitGroup) {
+	period := self.config_obj.Client.FrontendProbePeriod
+	if period < 0 || len(self.urls) < 2 {
+		return
+	}
+
+	if period == 0 {
+		period = 300
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Duration(period) * time.Second):
+				self.probeFrontends(ctx)
+			}
+		}
+	}()
+}
+
+func (self *HTTPConnector) probeFrontends(ctx context.Context) {
+	self.mu.Lock()
+	urls := append([]string{}, self.urls...)
+	self.mu.Unlock()
+
+	for idx, url := range urls {
+		start := time.Now()
+		err := self.probe(ctx, url)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			self.logger.Debug("Probing frontend %v: %v", url, err)
+			self.health.RecordFailure(idx)
+			continue
+		}
+		self.health.RecordLatency(idx, time.Since(start))
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	best := self.health.Best(len(self.urls), self.current_url_idx, nil)
+	if best < 0 || !self.health.ShouldSwitch(self.current_url_idx, best) {
+		return
+	}
+
+	self.logger.Info("Switching from frontend %v to healthier frontend %v",
+		self.urls[self.current_url_idx], self.urls[best])
+
+	// Force a rekey with the new frontend on the next request.
+	self.current_url_idx = best
+	self.redirect_to_server = 0
+	self.server_name = ""
+}
+
+// Fetch the server.pem as a cheap check that the frontend is up.
+func (self *HTTPConnector) probe(ctx context.Context, url string) error {
+	sub_ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(sub_ctx, "GET", url+"server.pem", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", constants.USER_AGENT)
+
+	resp, err := self.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, constants.MAX_MEMORY))
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(resp.Status)
+	}
+	return nil
+}
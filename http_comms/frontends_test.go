@@ -0,0 +1,37 @@
+package http_comms
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrontendHealthRanking(t *testing.T) {
+	health := newFrontendHealth()
+
+	// Without any information we go round robin.
+	assert.Equal(t, 1, health.Best(3, 0, nil))
+	assert.Equal(t, 0, health.Best(3, 2, nil))
+
+	// Failing frontends are avoided.
+	health.RecordFailure(1)
+	assert.Equal(t, 2, health.Best(3, 0, nil))
+
+	// Faster frontends are preferred.
+	health.RecordLatency(0, 500*time.Millisecond)
+	health.RecordLatency(2, 20*time.Millisecond)
+	assert.Equal(t, 2, health.Best(3, 2, nil))
+
+	// Excluded frontends are skipped.
+	assert.Equal(t, 0, health.Best(3, 2, map[int]bool{2: true, 1: true}))
+	assert.Equal(t, -1, health.Best(3, 2, map[int]bool{0: true, 1: true, 2: true}))
+
+	// Only switch for a significant improvement.
+	assert.True(t, health.ShouldSwitch(0, 2))
+	assert.False(t, health.ShouldSwitch(2, 0))
+
+	// Frontends learned from redirects are tracked too.
+	health.RecordFailure(5)
+	assert.Equal(t, 3, health.Best(6, 2, nil))
+}
@@ -0,0 +1,116 @@
+package http_comms
+
+import (
+	"crypto/tls"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// When Client.use_http3 is set we send requests over HTTP/3 (QUIC)
+// which copes better with high latency and lossy links. Many
+// networks block UDP so when QUIC fails we downgrade to HTTP/2 over
+// TCP and only try QUIC again after a while. QUIC can not be
+// tunneled through HTTP proxies so it is never used with a proxy.
+
+const (
+	TRANSPORT_HTTP3 = "http3"
+
+	// After HTTP/3 fails we stay on TCP for this long before trying
+	// it again.
+	http3Backoff = 10 * time.Minute
+
+	// When UDP is blocked the handshake never completes. Give up
+	// quickly so we downgrade to TCP without delaying comms too
+	// much.
+	http3HandshakeTimeout = 10 * time.Second
+)
+
+type http3Transport struct {
+	mu sync.Mutex
+
+	enabled       bool
+	backoff_until time.Time
+
+	round_tripper *http3.RoundTripper
+	client        *http.Client
+
+	logger *logging.LogContext
+	clock  utils.Clock
+}
+
+func newHTTP3Transport(
+	config_obj *config_proto.Config,
+	tls_config *tls.Config,
+	proxy_dialer *ProxyDialer,
+	timeout time.Duration,
+	logger *logging.LogContext,
+	clock utils.Clock) *http3Transport {
+
+	tls_config = tls_config.Clone()
+	tls_config.MinVersion = tls.VersionTLS13
+
+	round_tripper := &http3.RoundTripper{
+		TLSClientConfig: tls_config,
+		QuicConfig: &quic.Config{
+			HandshakeIdleTimeout: http3HandshakeTimeout,
+			MaxIdleTimeout:       timeout,
+			KeepAlivePeriod:      timeout / 4,
+		},
+	}
+
+	return &http3Transport{
+		enabled:       config_obj.Client.UseHttp3 && !proxy_dialer.enabled,
+		round_tripper: round_tripper,
+		client: &http.Client{
+			// Let us handle redirect ourselves.
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+			Transport: round_tripper,
+		},
+		logger: logger,
+		clock:  clock,
+	}
+}
+
+// Should the request go over HTTP/3?
+func (self *http3Transport) ShouldUse(req *http.Request) bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if !self.enabled || self.clock.Now().Before(self.backoff_until) {
+		return false
+	}
+
+	// The proxy may also come from the environment.
+	proxy, err := http.ProxyFromEnvironment(req)
+	return err == nil && proxy == nil
+}
+
+func (self *http3Transport) Do(req *http.Request) (*http.Response, error) {
+	resp, err := self.client.Do(req)
+	if err == nil {
+		utils.SetCommsTransport(TRANSPORT_HTTP3)
+	}
+	return resp, err
+}
+
+// HTTP/3 failed - downgrade to TCP for a while.
+func (self *http3Transport) Fail(err error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.logger.Info("HTTP/3 transport failed: %v - falling back to HTTP/2", err)
+
+	self.backoff_until = self.clock.Now().Add(http3Backoff)
+	utils.SetCommsTransport(TRANSPORT_HTTP)
+
+	self.round_tripper.CloseIdleConnections()
+}
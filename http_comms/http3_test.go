@@ -0,0 +1,55 @@
+package http_comms
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"www.velocidex.com/golang/velociraptor/config"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+func newTestHTTP3Transport(use_http3 bool, proxy string) (
+	*http3Transport, *utils.MockClock) {
+	config_obj := config.GetDefaultConfig()
+	config_obj.Client.UseHttp3 = use_http3
+	config_obj.Client.Proxy = proxy
+
+	null_logger, _ := test.NewNullLogger()
+	clock := &utils.MockClock{MockNow: time.Unix(1000, 0)}
+
+	logger := &logging.LogContext{Logger: null_logger}
+	dialer, _ := NewProxyDialer(config_obj, &net.Dialer{}, logger)
+
+	return newHTTP3Transport(config_obj, &tls.Config{}, dialer,
+		time.Second, logger, clock), clock
+}
+
+func TestHTTP3TransportSelection(t *testing.T) {
+	req := httptest.NewRequest("POST", "https://www.example.com/reader", nil)
+
+	// HTTP/3 is opt in.
+	transport, _ := newTestHTTP3Transport(false, "")
+	assert.False(t, transport.ShouldUse(req))
+
+	transport, clock := newTestHTTP3Transport(true, "")
+	assert.True(t, transport.ShouldUse(req))
+
+	// A failure downgrades to TCP for a while.
+	transport.Fail(context.DeadlineExceeded)
+	assert.False(t, transport.ShouldUse(req))
+	assert.Equal(t, TRANSPORT_HTTP, utils.GetCommsTransport())
+
+	clock.MockNow = clock.MockNow.Add(http3Backoff)
+	assert.True(t, transport.ShouldUse(req))
+
+	// QUIC can not go through a proxy.
+	transport, _ = newTestHTTP3Transport(true, "http://proxy:8080/")
+	assert.False(t, transport.ShouldUse(req))
+}
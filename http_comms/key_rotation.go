@@ -0,0 +1,62 @@
+package http_comms
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/actions"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+var (
+	// How often we check the age of our key.
+	keyRotationCheckPeriod = time.Hour
+)
+
+// Is our private key older than the configured maximum age?
+func keyNeedsRotation(config_obj *config_proto.Config, now time.Time) bool {
+	writeback, err := config.GetWriteback(config_obj.Client)
+	if err != nil || writeback.HardwareKeyName != "" {
+		return false
+	}
+
+	// Keys created before we tracked their age start counting from
+	// now.
+	if writeback.KeyCreated == 0 {
+		writeback.KeyCreated = uint64(now.Unix())
+		_ = config.UpdateWriteback(config_obj.Client, writeback)
+		return false
+	}
+
+	max_age := time.Duration(config_obj.Client.KeyRotationDays) * 24 * time.Hour
+	return now.After(time.Unix(int64(writeback.KeyCreated), 0).Add(max_age))
+}
+
+// Rotate our key when it becomes too old. The server acknowledges the
+// new key and the executor then switches to it.
+func (self *HTTPCommunicator) maintainKeyRotation(
+	ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for {
+		if keyNeedsRotation(self.config_obj, self.enroller.clock.Now()) {
+			self.logger.Info("Private key is older than %v days - rotating",
+				self.config_obj.Client.KeyRotationDays)
+
+			msg, err := actions.StartKeyRotation(self.config_obj)
+			if err != nil {
+				self.logger.Error("Unable to rotate key: %v", err)
+			} else {
+				self.enroller.executor.SendToServer(msg)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(keyRotationCheckPeriod):
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package http_comms
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test vectors from MS-NLMP section 4.2.4
+func TestNTLMv2(t *testing.T) {
+	assert.Equal(t, "0c868a403bfd7a93a3001ef22ef02e3f",
+		hex.EncodeToString(ntowfv2("User", "Password", "Domain")))
+
+	target_info, _ := hex.DecodeString(
+		"02000c0044006f006d00610069006e00" +
+			"01000c005300650072007600650072000000" + "0000")
+
+	challenge := &ntlmChallenge{
+		serverChallenge: []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef},
+		targetInfo:      target_info,
+	}
+	client_challenge := []byte{0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa}
+
+	lm, nt := ntlmv2Responses("User", "Password", "Domain", challenge,
+		client_challenge, make([]byte, 8))
+
+	assert.Equal(t, "86c35097ac9cec102554764a57cccc19aaaaaaaaaaaaaaaa",
+		hex.EncodeToString(lm))
+	assert.Equal(t, "68cd0ab851e51c96aabc927bebef6a1c",
+		hex.EncodeToString(nt[:16]))
+}
+
+func TestNTLMAuthenticateMessage(t *testing.T) {
+	challenge, _ := hex.DecodeString(
+		"4e544c4d53535000020000000c000c003000000035828ae2" +
+			"0123456789abcdef0000000000000000" +
+			"080008003c000000" +
+			"44006f006d00610069006e00" +
+			"01000000" + "00000000")
+
+	msg, err := ntlmAuthenticateMessage(`Domain\User`, "Password",
+		"COMPUTER", challenge)
+	assert.NoError(t, err)
+	assert.Equal(t, ntlmSignature, msg[:8])
+
+	// The user name field points at the unicode user name.
+	offset := binary.LittleEndian.Uint32(msg[40:])
+	assert.Equal(t, ntlmUnicode("User"), msg[offset:offset+8])
+
+	_, err = ntlmAuthenticateMessage("User", "Password", "", []byte("junk"))
+	assert.Error(t, err)
+}
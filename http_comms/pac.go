@@ -0,0 +1,204 @@
+package http_comms
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/go-errors/errors"
+	"github.com/robertkrimen/otto"
+)
+
+// Helpers available to PAC scripts which can be expressed in plain
+// Javascript. dnsResolve() and myIpAddress() are provided by Go.
+const pacHelpers = `
+function isPlainHostName(host) {
+  return host.indexOf('.') < 0;
+}
+
+function dnsDomainIs(host, domain) {
+  return host.length >= domain.length &&
+    host.substring(host.length - domain.length) == domain;
+}
+
+function localHostOrDomainIs(host, hostdom) {
+  return host == hostdom || hostdom.lastIndexOf(host + '.', 0) == 0;
+}
+
+function isResolvable(host) {
+  return dnsResolve(host) != null;
+}
+
+function dnsDomainLevels(host) {
+  return host.split('.').length - 1;
+}
+
+function convert_addr(ipchars) {
+  var bytes = ipchars.split('.');
+  return ((bytes[0] & 0xff) << 24) | ((bytes[1] & 0xff) << 16) |
+    ((bytes[2] & 0xff) << 8) | (bytes[3] & 0xff);
+}
+
+function isInNet(ipaddr, pattern, maskstr) {
+  if (!/^\d+\.\d+\.\d+\.\d+$/.test(ipaddr)) {
+    ipaddr = dnsResolve(ipaddr);
+    if (ipaddr == null) {
+      return false;
+    }
+  }
+  var host = convert_addr(ipaddr);
+  var pat = convert_addr(pattern);
+  var mask = convert_addr(maskstr);
+  return (host & mask) == (pat & mask);
+}
+
+function shExpMatch(url, pattern) {
+  pattern = pattern.replace(/\./g, '\\.');
+  pattern = pattern.replace(/\*/g, '.*');
+  pattern = pattern.replace(/\?/g, '.');
+  return new RegExp('^' + pattern + '$').test(url);
+}
+
+var wdays = {SUN: 0, MON: 1, TUE: 2, WED: 3, THU: 4, FRI: 5, SAT: 6};
+
+function weekdayRange(wd1, wd2, gmt) {
+  var now = new Date();
+  var today = (gmt == 'GMT') ? now.getUTCDay() : now.getDay();
+  var start = wdays[wd1];
+  var end = (wd2 in wdays) ? wdays[wd2] : start;
+  if (start <= end) {
+    return start <= today && today <= end;
+  }
+  return today >= start || today <= end;
+}
+
+function timeRange(h1, h2) {
+  var hour = new Date().getHours();
+  if (typeof h2 != 'number') {
+    return hour == h1;
+  }
+  return h1 <= hour && hour < h2;
+}
+
+function dateRange() {
+  return false;
+}
+`
+
+// Evaluates a proxy auto-config script.
+type PACResolver struct {
+	// The otto vm is not thread safe.
+	mu sync.Mutex
+	vm *otto.Otto
+}
+
+func NewPACResolver(script string) (*PACResolver, error) {
+	vm := otto.New()
+
+	err := vm.Set("dnsResolve", func(call otto.FunctionCall) otto.Value {
+		addrs, err := net.LookupIP(call.Argument(0).String())
+		if err == nil {
+			for _, addr := range addrs {
+				if addr.To4() != nil {
+					value, _ := vm.ToValue(addr.String())
+					return value
+				}
+			}
+		}
+		return otto.NullValue()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = vm.Set("myIpAddress", func(call otto.FunctionCall) otto.Value {
+		value, _ := vm.ToValue(myIpAddress())
+		return value
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = vm.Run(pacHelpers)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = vm.Run(script)
+	if err != nil {
+		return nil, errors.New("Unable to load PAC script: " + err.Error())
+	}
+
+	return &PACResolver{vm: vm}, nil
+}
+
+func (self *PACResolver) FindProxyForURL(url, host string) (string, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	value, err := self.vm.Call("FindProxyForURL", nil, url, host)
+	if err != nil {
+		return "", err
+	}
+	return value.ToString()
+}
+
+// The address of the interface used to reach the internet.
+func myIpAddress() string {
+	conn, err := net.Dial("udp", "8.8.8.8:53")
+	if err != nil {
+		return "127.0.0.1"
+	}
+	defer conn.Close()
+
+	addr, ok := conn.LocalAddr().(*net.UDPAddr)
+	if !ok {
+		return "127.0.0.1"
+	}
+	return addr.IP.String()
+}
+
+// Parse the result of FindProxyForURL, e.g.
+// "PROXY proxy1:8080; SOCKS5 proxy2:1080; DIRECT"
+// into a list of proxies to try in order. DIRECT is represented as a
+// nil url.
+func parsePACResult(result string) ([]*url.URL, error) {
+	var proxies []*url.URL
+
+	for _, item := range strings.Split(result, ";") {
+		fields := strings.Fields(item)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if strings.ToUpper(fields[0]) == "DIRECT" {
+			proxies = append(proxies, nil)
+			continue
+		}
+
+		if len(fields) != 2 {
+			return nil, errors.New("Invalid PAC result: " + item)
+		}
+
+		var scheme string
+		switch strings.ToUpper(fields[0]) {
+		case "PROXY", "HTTP":
+			scheme = "http"
+		case "HTTPS":
+			scheme = "https"
+		case "SOCKS", "SOCKS5":
+			scheme = "socks5"
+		default:
+			return nil, errors.New("Unsupported PAC proxy type: " + fields[0])
+		}
+
+		proxies = append(proxies, &url.URL{Scheme: scheme, Host: fields[1]})
+	}
+
+	if len(proxies) == 0 {
+		return nil, errors.New("Empty PAC result")
+	}
+
+	return proxies, nil
+}
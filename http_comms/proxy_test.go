@@ -0,0 +1,69 @@
+package http_comms
+
+import (
+	"net"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+)
+
+func TestParsePACResult(t *testing.T) {
+	proxies, err := parsePACResult(
+		"PROXY proxy1:8080; SOCKS5 proxy2:1080;HTTPS proxy3:8443; DIRECT")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(proxies))
+	assert.Equal(t, "http://proxy1:8080", proxies[0].String())
+	assert.Equal(t, "socks5://proxy2:1080", proxies[1].String())
+	assert.Equal(t, "https://proxy3:8443", proxies[2].String())
+	assert.Nil(t, proxies[3])
+
+	_, err = parsePACResult("")
+	assert.Error(t, err)
+
+	_, err = parsePACResult("FTP proxy:21")
+	assert.Error(t, err)
+}
+
+func TestProxyRules(t *testing.T) {
+	config_obj := config.GetDefaultConfig()
+	config_obj.Client.ProxyRules = []*config_proto.ProxyRule{{
+		Url:   "https://fe1.example.com:8000/",
+		Proxy: "proxy.example.com:3128",
+	}, {
+		Url:   "https://fe2.example.com/",
+		Proxy: "direct",
+	}}
+
+	null_logger, _ := test.NewNullLogger()
+	dialer, err := NewProxyDialer(config_obj, &net.Dialer{},
+		&logging.LogContext{Logger: null_logger})
+	assert.NoError(t, err)
+	assert.True(t, dialer.enabled)
+
+	proxies, err := dialer.proxiesFor("fe1.example.com:8000")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://proxy.example.com:3128", proxies[0].String())
+
+	proxies, err = dialer.proxiesFor("fe2.example.com:443")
+	assert.NoError(t, err)
+	assert.Nil(t, proxies[0])
+}
+
+func TestSelectNTLMScheme(t *testing.T) {
+	scheme, token := selectNTLMScheme(
+		[]string{`Basic realm="proxy"`, "Negotiate", "NTLM"}, "")
+	assert.Equal(t, "NTLM", scheme)
+	assert.Nil(t, token)
+
+	scheme, token = selectNTLMScheme([]string{"Negotiate TlRMTVNTUAAC"}, "Negotiate")
+	assert.Equal(t, "Negotiate", scheme)
+	assert.Equal(t, []byte("NTLMSSP\x00\x02"), token)
+
+	// A repeated empty challenge means the credentials were rejected.
+	scheme, _ = selectNTLMScheme([]string{"NTLM"}, "NTLM")
+	assert.Equal(t, "", scheme)
+}
@@ -0,0 +1,235 @@
+package http_comms
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+)
+
+var (
+	relayRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "client_relay_requests",
+			Help: "Number of peer requests relayed to the frontends.",
+		},
+		[]string{"status"},
+	)
+
+	// The frontend handlers peers may reach through the relay.
+	relayHandlers = map[string]bool{
+		"server.pem":       true,
+		"control":          true,
+		"reader":           true,
+		"send_messages":    true,
+		"receive_messages": true,
+		"ws":               true,
+	}
+)
+
+const (
+	DEFAULT_RELAY_MAX_CONNECTIONS = 1000
+)
+
+// Relays the comms of peer clients to the frontend the connector
+// currently talks to. Requests are forwarded as they are - messages
+// are encrypted for the server and signed by each peer, and peers
+// check the server.pem is signed by the CA, so the relay can not read
+// or forge peer traffic.
+//
+// Frontends redirecting a peer to another frontend would send it
+// somewhere it can not reach so redirects are reported as errors
+// instead. The peer then tries again through the relay.
+type Relay struct {
+	connector *HTTPConnector
+	logger    *logging.LogContext
+
+	allowed []*net.IPNet
+
+	// Limits the number of concurrent peer requests.
+	slots chan bool
+
+	proxy *httputil.ReverseProxy
+}
+
+func NewRelay(
+	config_obj *config_proto.Config,
+	connector *HTTPConnector) (*Relay, error) {
+
+	relay_config := config_obj.Client.GetRelay()
+	if relay_config == nil {
+		return nil, fmt.Errorf("Client.relay not configured")
+	}
+
+	max_connections := relay_config.MaxConnections
+	if max_connections == 0 {
+		max_connections = DEFAULT_RELAY_MAX_CONNECTIONS
+	}
+
+	self := &Relay{
+		connector: connector,
+		logger:    connector.logger,
+		slots:     make(chan bool, max_connections),
+	}
+
+	for _, network := range relay_config.AllowedNetworks {
+		_, ip_net, err := net.ParseCIDR(network)
+		if err != nil {
+			return nil, fmt.Errorf("Client.relay.allowed_networks: %w", err)
+		}
+		self.allowed = append(self.allowed, ip_net)
+	}
+
+	self.proxy = &httputil.ReverseProxy{
+		Director: self.director,
+
+		// Use the same TLS settings and proxies as our own comms.
+		Transport: connector.client.Transport,
+
+		// The reader long poll streams padding to keep the
+		// connection alive - pass it on immediately.
+		FlushInterval: -1,
+
+		ModifyResponse: func(resp *http.Response) error {
+			if resp.StatusCode == http.StatusMovedPermanently {
+				return fmt.Errorf("Frontend redirected peer to %v",
+					resp.Header.Get("Location"))
+			}
+			return nil
+		},
+
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			self.logger.Info("Relay: Forwarding %v for %v: %v",
+				r.URL.Path, r.RemoteAddr, err)
+			relayRequests.WithLabelValues("error").Inc()
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+
+	return self, nil
+}
+
+// Point the request at the current frontend.
+func (self *Relay) director(req *http.Request) {
+	target, err := url.Parse(self.connector.getCurrentServerUrl())
+	if err != nil {
+		return
+	}
+
+	handler := strings.TrimPrefix(req.URL.Path, "/")
+	req.URL.Scheme = target.Scheme
+	req.URL.Host = target.Host
+	req.URL.Path = target.Path + handler
+	req.Host = target.Host
+}
+
+func (self *Relay) isAllowed(remote_addr string) bool {
+	if len(self.allowed) == 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(remote_addr)
+	if err != nil {
+		host = remote_addr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range self.allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *Relay) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !self.isAllowed(r.RemoteAddr) {
+		relayRequests.WithLabelValues("denied").Inc()
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	if !relayHandlers[strings.TrimPrefix(r.URL.Path, "/")] {
+		http.NotFound(w, r)
+		return
+	}
+
+	select {
+	case self.slots <- true:
+		defer func() { <-self.slots }()
+	default:
+		relayRequests.WithLabelValues("busy").Inc()
+		http.Error(w, "Relay is busy", http.StatusServiceUnavailable)
+		return
+	}
+
+	self.proxy.ServeHTTP(w, r)
+	relayRequests.WithLabelValues("relayed").Inc()
+}
+
+// Accept peer connections until the context is done.
+func StartRelay(
+	ctx context.Context, wg *sync.WaitGroup,
+	config_obj *config_proto.Config,
+	connector *HTTPConnector) error {
+
+	relay_config := config_obj.Client.GetRelay()
+	if relay_config == nil || relay_config.BindPort == 0 {
+		return nil
+	}
+
+	relay, err := NewRelay(config_obj, connector)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("%v:%v",
+		relay_config.BindAddress, relay_config.BindPort))
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           relay,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	relay.logger.Info("Relay: Relaying peer comms from %v", listener.Addr())
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+
+		err := server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			relay.logger.Error("Relay: %v", err)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		<-ctx.Done()
+		shutdown_ctx, cancel := context.WithTimeout(
+			context.Background(), 10*time.Second)
+		defer cancel()
+
+		_ = server.Shutdown(shutdown_ctx)
+	}()
+
+	return nil
+}
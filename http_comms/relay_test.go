@@ -0,0 +1,116 @@
+package http_comms
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+func newTestRelay(t *testing.T, frontend_url string,
+	relay_config *config_proto.ClientRelayConfig) *httptest.Server {
+	config_obj := config.GetDefaultConfig()
+	config_obj.Client.Relay = relay_config
+
+	null_logger, _ := test.NewNullLogger()
+	logger := &logging.LogContext{Logger: null_logger}
+	clock := &utils.MockClock{MockNow: time.Unix(1000, 0)}
+
+	connector, err := NewHTTPConnector(config_obj, nil, logger,
+		[]string{frontend_url + "/base/"}, clock)
+	assert.NoError(t, err)
+
+	relay, err := NewRelay(config_obj, connector)
+	assert.NoError(t, err)
+
+	return httptest.NewServer(relay)
+}
+
+func TestRelay(t *testing.T) {
+	frontend := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/base/control":
+				body, _ := ioutil.ReadAll(r.Body)
+				w.Header().Set("X-Forwarded", r.Header.Get("X-Forwarded-For"))
+				w.Write([]byte(r.Header.Get("X-Priority") + ":" + string(body)))
+
+			case "/base/reader":
+				http.Redirect(w, r, "https://other.example.com/", 301)
+
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+	defer frontend.Close()
+
+	relay := newTestRelay(t, frontend.URL, &config_proto.ClientRelayConfig{})
+	defer relay.Close()
+
+	// Peer messages are forwarded as they are.
+	req, _ := http.NewRequest("POST", relay.URL+"/control",
+		strings.NewReader("encrypted"))
+	req.Header.Set("X-Priority", "urgent")
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "urgent:encrypted", string(body))
+	assert.Equal(t, "127.0.0.1", resp.Header.Get("X-Forwarded"))
+
+	// Peers can not be redirected to frontends they can not reach.
+	resp, err = http.Post(relay.URL+"/reader", "application/binary", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+
+	// Only the comms handlers are relayed.
+	resp, err = http.Get(relay.URL + "/app/index.html")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestRelayAllowedNetworks(t *testing.T) {
+	frontend := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("pem"))
+		}))
+	defer frontend.Close()
+
+	relay := newTestRelay(t, frontend.URL, &config_proto.ClientRelayConfig{
+		AllowedNetworks: []string{"10.0.0.0/8"},
+	})
+	defer relay.Close()
+
+	resp, err := http.Get(relay.URL + "/server.pem")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	relay = newTestRelay(t, frontend.URL, &config_proto.ClientRelayConfig{
+		AllowedNetworks: []string{"10.0.0.0/8", "127.0.0.0/8"},
+	})
+	defer relay.Close()
+
+	resp, err = http.Get(relay.URL + "/server.pem")
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "pem", string(body))
+
+	_, err = NewRelay(config.GetDefaultConfig(), nil)
+	assert.Error(t, err)
+}
@@ -34,6 +34,7 @@ import (
 	"golang.org/x/time/rate"
 	"google.golang.org/protobuf/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
 	"www.velocidex.com/golang/velociraptor/crypto"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 	"www.velocidex.com/golang/velociraptor/executor"
@@ -56,9 +57,17 @@ type Sender struct {
 
 	ring_buffer IRingBuffer
 
+	// Monitoring events are kept in this buffer if it is
+	// configured so they survive long periods offline.
+	event_buffer IRingBuffer
+
 	// An in-memory ring buffer for urgent packets.
 	urgent_buffer *RingBuffer
 
+	// When the oldest message not yet leased from the ring buffer
+	// was queued. Used to report the queue latency.
+	oldest_pending time.Time
+
 	clock utils.Clock
 }
 
@@ -68,6 +77,9 @@ func (self *Sender) CleanOnExit(ctx context.Context, wg *sync.WaitGroup) {
 	<-ctx.Done()
 	self.urgent_buffer.Close()
 	self.ring_buffer.Close()
+	if self.event_buffer != nil {
+		self.event_buffer.Close()
+	}
 }
 
 // Persistent loop to pump messages from the executor to the ring
@@ -146,10 +158,24 @@ func (self *Sender) PumpExecutorToRingBuffer(
 					continue
 				}
 
+				// The event buffer never blocks - it drops the
+				// oldest events when it is full.
+				if self.event_buffer != nil &&
+					msg.SessionId == constants.MONITORING_WELL_KNOWN_FLOW {
+					self.event_buffer.Enqueue(serialized_msg)
+					continue
+				}
+
 				// RingBuffer.Enqueue may block if there is
 				// no room in the ring buffer. While waiting
 				// here we block the executor channel.
 				self.ring_buffer.Enqueue(serialized_msg)
+
+				self.mu.Lock()
+				if self.oldest_pending.IsZero() {
+					self.oldest_pending = self.clock.Now()
+				}
+				self.mu.Unlock()
 			}
 
 			// We have just filled the message queue with
@@ -201,6 +227,11 @@ func (self *Sender) PumpRingBufferToSendMessage(
 			}
 
 			// Grab some messages from the ring buffer.
+			self.mu.Lock()
+			oldest_pending := self.oldest_pending
+			self.oldest_pending = time.Time{}
+			self.mu.Unlock()
+
 			compressed_messages = LeaseAndCompress(self.ring_buffer,
 				self.config_obj.Client.MaxUploadSize, compression)
 			if len(compressed_messages) > 0 {
@@ -210,6 +241,23 @@ func (self *Sender) PumpRingBufferToSendMessage(
 				// from the ring buffer.
 				self.sendMessageList(ctx, compressed_messages, !URGENT, compression)
 				self.ring_buffer.Commit()
+
+				// Roughly how long the oldest message we just sent
+				// waited in the queue.
+				if !oldest_pending.IsZero() {
+					executor.Telemetry.UpdateQueueLatency(
+						self.clock.Now().Sub(oldest_pending))
+				}
+			}
+
+			// Drain the event buffer oldest first.
+			if self.event_buffer != nil {
+				compressed_messages = LeaseAndCompress(self.event_buffer,
+					self.config_obj.Client.MaxUploadSize, compression)
+				if len(compressed_messages) > 0 {
+					self.sendMessageList(ctx, compressed_messages, !URGENT, compression)
+					self.event_buffer.Commit()
+				}
 			}
 		}
 
@@ -237,6 +285,8 @@ func (self *Sender) PumpRingBufferToSendMessage(
 func (self *Sender) Start(
 	ctx context.Context, wg *sync.WaitGroup) {
 
+	executor.Telemetry.SetBuffers(self.ring_buffer, self.event_buffer)
+
 	wg.Add(1)
 	go self.PumpExecutorToRingBuffer(ctx, wg)
 
@@ -29,8 +29,8 @@ func StartHttpCommunicatorService(
 		return nil, err
 	}
 
-	manager, err := crypto_client.NewClientCryptoManager(
-		config_obj, []byte(writeback.PrivateKey))
+	manager, err := crypto_client.NewClientCryptoManagerFromWriteback(
+		config_obj, writeback)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,348 @@
+package http_comms
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"golang.org/x/net/websocket"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// The websocket transport carries exactly the same encrypted
+// payloads as the long poll HTTP transport, but over a persistent
+// connection per handler. Some inspecting proxies buffer or
+// terminate long poll responses which breaks the HTTP transport;
+// websockets are usually tunneled through untouched.
+//
+// Each request is a single binary message: a flags byte followed by
+// the cipher text. Each response is a single binary message: a two
+// byte big endian HTTP status followed by the response body (see
+// server/websocket.go).
+
+const (
+	TRANSPORT_AUTO      = "auto"
+	TRANSPORT_HTTP      = "http"
+	TRANSPORT_WEBSOCKET = "websocket"
+
+	WEBSOCKET_FLAG_URGENT = 1
+
+	// Number of consecutive failed long polls before we consider
+	// HTTP degraded and switch to websockets. Only connection level
+	// failures (resets, truncated bodies, timeouts) count - a server
+	// returning an error status is not a transport problem.
+	websocketFailureThreshold = 3
+
+	// After the websocket transport fails we stay on HTTP for this
+	// long before trying it again.
+	websocketBackoff = 10 * time.Minute
+)
+
+// A websocket connection for a single handler. Requests on the same
+// handler are serialized so the server sees them in the order they
+// were sent.
+type websocketConn struct {
+	mu sync.Mutex
+
+	// Protects the connection so it may be closed while a request
+	// is in flight.
+	conn_mu sync.Mutex
+	url     string
+	conn    *websocket.Conn
+}
+
+// Get the open connection to the server url if there is one.
+func (self *websocketConn) get(server_url string) *websocket.Conn {
+	self.conn_mu.Lock()
+	defer self.conn_mu.Unlock()
+
+	// The server changed, reconnect.
+	if self.conn != nil && self.url != server_url {
+		self.conn.Close()
+		self.conn = nil
+	}
+	return self.conn
+}
+
+func (self *websocketConn) set(server_url string, conn *websocket.Conn) {
+	self.conn_mu.Lock()
+	defer self.conn_mu.Unlock()
+
+	self.url = server_url
+	self.conn = conn
+}
+
+func (self *websocketConn) close() {
+	self.conn_mu.Lock()
+	defer self.conn_mu.Unlock()
+
+	if self.conn != nil {
+		self.conn.Close()
+		self.conn = nil
+	}
+}
+
+type websocketTransport struct {
+	mu sync.Mutex
+
+	mode          string
+	active        bool
+	http_failures int
+	backoff_until time.Time
+
+	tls_config   *tls.Config
+	proxy_dialer *ProxyDialer
+	timeout      time.Duration
+	max_poll     time.Duration
+	logger       *logging.LogContext
+	clock        utils.Clock
+
+	conns map[string]*websocketConn
+}
+
+func newWebsocketTransport(
+	config_obj *config_proto.Config,
+	tls_config *tls.Config,
+	proxy_dialer *ProxyDialer,
+	timeout, max_poll time.Duration,
+	logger *logging.LogContext,
+	clock utils.Clock) *websocketTransport {
+
+	mode := config_obj.Client.Transport
+	if mode == "" {
+		mode = TRANSPORT_AUTO
+	}
+
+	self := &websocketTransport{
+		mode:         mode,
+		active:       mode == TRANSPORT_WEBSOCKET,
+		tls_config:   tls_config,
+		proxy_dialer: proxy_dialer,
+		timeout:      timeout,
+		max_poll:     max_poll,
+		logger:       logger,
+		clock:        clock,
+		conns:        make(map[string]*websocketConn),
+	}
+	self.recordTransport()
+
+	return self
+}
+
+// Must be called with the lock held.
+func (self *websocketTransport) recordTransport() {
+	if self.active {
+		utils.SetCommsTransport(TRANSPORT_WEBSOCKET)
+	} else {
+		utils.SetCommsTransport(TRANSPORT_HTTP)
+	}
+}
+
+// Should the next request go over the websocket?
+func (self *websocketTransport) ShouldUse() bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.mode == TRANSPORT_HTTP || !self.active {
+		return false
+	}
+
+	return !self.clock.Now().Before(self.backoff_until)
+}
+
+// Track the health of the HTTP transport. Enough consecutive
+// failures switch us to websockets.
+func (self *websocketTransport) RecordHTTPResult(success bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if success {
+		self.http_failures = 0
+		return
+	}
+
+	self.http_failures++
+	if self.mode == TRANSPORT_HTTP || self.active ||
+		self.http_failures < websocketFailureThreshold ||
+		self.clock.Now().Before(self.backoff_until) {
+		return
+	}
+
+	self.logger.Info("Long poll HTTP appears degraded after %v failures - "+
+		"switching to websocket transport", self.http_failures)
+	self.active = true
+	self.recordTransport()
+}
+
+// The websocket transport failed - fall back to HTTP for a while.
+func (self *websocketTransport) Fail(err error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.logger.Info("Websocket transport failed: %v - falling back to HTTP", err)
+
+	self.http_failures = 0
+	self.backoff_until = self.clock.Now().Add(websocketBackoff)
+
+	// When websockets are preferred we retry them after the backoff.
+	self.active = self.mode == TRANSPORT_WEBSOCKET
+	utils.SetCommsTransport(TRANSPORT_HTTP)
+
+	for _, c := range self.conns {
+		c.close()
+	}
+}
+
+func (self *websocketTransport) getConn(handler string) *websocketConn {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	conn, pres := self.conns[handler]
+	if !pres {
+		conn = &websocketConn{}
+		self.conns[handler] = conn
+	}
+	return conn
+}
+
+// Send the data to the handler and wait for the response. Returns
+// the HTTP status the server produced and the body.
+func (self *websocketTransport) Post(
+	ctx context.Context, server_url, handler string,
+	data []byte, urgent bool) (int, *bytes.Buffer, error) {
+
+	conn := self.getConn(handler)
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	ws := conn.get(server_url)
+	if ws == nil {
+		var err error
+		ws, err = self.dial(ctx, server_url, handler)
+		if err != nil {
+			return 0, nil, err
+		}
+		conn.set(server_url, ws)
+
+		self.mu.Lock()
+		self.recordTransport()
+		self.mu.Unlock()
+	}
+
+	// Abort the request if the context is cancelled.
+	done := make(chan bool)
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			ws.Close()
+		case <-done:
+		}
+	}()
+
+	// The reader handler may hold the request for up to max_poll
+	// before responding.
+	err := ws.SetDeadline(time.Now().Add(self.timeout + self.max_poll))
+	if err != nil {
+		conn.close()
+		return 0, nil, err
+	}
+
+	var flags byte
+	if urgent {
+		flags |= WEBSOCKET_FLAG_URGENT
+	}
+
+	frame := make([]byte, 0, len(data)+1)
+	frame = append(frame, flags)
+	frame = append(frame, data...)
+
+	err = websocket.Message.Send(ws, frame)
+	if err != nil {
+		conn.close()
+		return 0, nil, errors.Wrap(err, 0)
+	}
+
+	var reply []byte
+	err = websocket.Message.Receive(ws, &reply)
+	if err != nil {
+		conn.close()
+		return 0, nil, errors.Wrap(err, 0)
+	}
+
+	if len(reply) < 2 {
+		conn.close()
+		return 0, nil, errors.New("Invalid websocket response")
+	}
+
+	status := int(binary.BigEndian.Uint16(reply[:2]))
+	return status, bytes.NewBuffer(reply[2:]), nil
+}
+
+func (self *websocketTransport) dial(
+	ctx context.Context, server_url, handler string) (*websocket.Conn, error) {
+
+	location, err := url.Parse(server_url)
+	if err != nil {
+		return nil, err
+	}
+
+	// The server URL already includes the base path.
+	origin := *location
+	location.Path += "ws"
+	location.RawQuery = "h=" + url.QueryEscape(handler)
+
+	switch location.Scheme {
+	case "https":
+		location.Scheme = "wss"
+	case "http":
+		location.Scheme = "ws"
+	default:
+		return nil, errors.New("Unsupported scheme for websocket: " +
+			location.Scheme)
+	}
+
+	config, err := websocket.NewConfig(location.String(), origin.String())
+	if err != nil {
+		return nil, err
+	}
+	config.Header.Set("User-Agent", constants.USER_AGENT)
+
+	// Websockets are always tunneled through the proxy.
+	conn, err := self.proxy_dialer.DialTunnel(ctx, "tcp", hostPort(&origin))
+	if err != nil {
+		return nil, err
+	}
+
+	if location.Scheme == "wss" {
+		tls_config := self.tls_config.Clone()
+		if tls_config.ServerName == "" {
+			tls_config.ServerName = origin.Hostname()
+		}
+
+		tls_conn := tls.Client(conn, tls_config)
+		err = tls_conn.HandshakeContext(ctx)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tls_conn
+	}
+
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return ws, nil
+}
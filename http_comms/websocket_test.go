@@ -0,0 +1,134 @@
+package http_comms
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+	"www.velocidex.com/golang/velociraptor/config"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+func newTestWebsocketTransport(mode string) (
+	*websocketTransport, *utils.MockClock) {
+	config_obj := config.GetDefaultConfig()
+	config_obj.Client.Transport = mode
+
+	null_logger, _ := test.NewNullLogger()
+	clock := &utils.MockClock{MockNow: time.Unix(1000, 0)}
+
+	logger := &logging.LogContext{Logger: null_logger}
+	dialer, _ := NewProxyDialer(config_obj, &net.Dialer{}, logger)
+
+	return newWebsocketTransport(config_obj, &tls.Config{}, dialer,
+		time.Second, time.Second, logger, clock), clock
+}
+
+func TestWebsocketTransportSelection(t *testing.T) {
+	transport, clock := newTestWebsocketTransport("")
+	assert.False(t, transport.ShouldUse())
+	assert.Equal(t, TRANSPORT_HTTP, utils.GetCommsTransport())
+
+	// A success resets the failure count.
+	transport.RecordHTTPResult(false)
+	transport.RecordHTTPResult(false)
+	transport.RecordHTTPResult(true)
+	transport.RecordHTTPResult(false)
+	assert.False(t, transport.ShouldUse())
+
+	// Enough consecutive failures switch to websockets.
+	transport.RecordHTTPResult(false)
+	transport.RecordHTTPResult(false)
+	assert.True(t, transport.ShouldUse())
+	assert.Equal(t, TRANSPORT_WEBSOCKET, utils.GetCommsTransport())
+
+	// A websocket failure falls back to HTTP and backs off.
+	transport.Fail(context.Canceled)
+	assert.False(t, transport.ShouldUse())
+	assert.Equal(t, TRANSPORT_HTTP, utils.GetCommsTransport())
+
+	for i := 0; i < websocketFailureThreshold; i++ {
+		transport.RecordHTTPResult(false)
+	}
+	assert.False(t, transport.ShouldUse())
+
+	clock.MockNow = clock.MockNow.Add(websocketBackoff)
+	for i := 0; i < websocketFailureThreshold; i++ {
+		transport.RecordHTTPResult(false)
+	}
+	assert.True(t, transport.ShouldUse())
+
+	// Websockets are never used in http mode.
+	transport, _ = newTestWebsocketTransport(TRANSPORT_HTTP)
+	for i := 0; i < websocketFailureThreshold; i++ {
+		transport.RecordHTTPResult(false)
+	}
+	assert.False(t, transport.ShouldUse())
+}
+
+func TestWebsocketTransportPost(t *testing.T) {
+	var requests_mu sync.Mutex
+	var requests []string
+
+	server := httptest.NewServer(websocket.Server{
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			handler := ws.Request().URL.Query().Get("h")
+			for {
+				var frame []byte
+				err := websocket.Message.Receive(ws, &frame)
+				if err != nil {
+					return
+				}
+
+				requests_mu.Lock()
+				requests = append(requests, handler+":"+string(frame[1:]))
+				requests_mu.Unlock()
+
+				status := uint16(http.StatusOK)
+				if frame[0]&WEBSOCKET_FLAG_URGENT != 0 {
+					status = http.StatusNotAcceptable
+				}
+
+				reply := make([]byte, 2)
+				binary.BigEndian.PutUint16(reply, status)
+				reply = append(reply, frame[1:]...)
+				websocket.Message.Send(ws, reply)
+			}
+		},
+	})
+	defer server.Close()
+
+	transport, _ := newTestWebsocketTransport(TRANSPORT_WEBSOCKET)
+	assert.True(t, transport.ShouldUse())
+
+	ctx := context.Background()
+	status, body, err := transport.Post(ctx, server.URL+"/", "reader",
+		[]byte("hello"), false)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "hello", body.String())
+
+	// The connection is reused for the same handler.
+	status, body, err = transport.Post(ctx, server.URL+"/", "reader",
+		[]byte("world"), true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotAcceptable, status)
+	assert.Equal(t, "world", body.String())
+
+	requests_mu.Lock()
+	defer requests_mu.Unlock()
+	assert.Equal(t, []string{"reader:hello", "reader:world"}, requests)
+}
@@ -0,0 +1,226 @@
+/*
+  IOC tables are named lists of indicators kept on the server.
+
+  Each indicator is a row with at least a Type and a Value column
+  (e.g. Type=sha256, Value=...). Tables are usually filled from an
+  external threat intelligence platform (see services/misp) and are
+  read with the ioc_table() plugin, for example to build the
+  parameters of a hunt.
+
+  Indicators may have an Expires column (seconds since the epoch).
+  Expired indicators are no longer returned by ReadTable().
+*/
+
+package iocs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// Values of these types are case insensitive.
+var caseInsensitiveTypes = []string{
+	"md5", "sha1", "sha256", "sha512", "domain", "hostname",
+	"email-src", "email-dst", "ip-src", "ip-dst",
+}
+
+// Normalize the value so the same indicator always has the same key.
+func Key(ioc_type, value string) string {
+	value = strings.TrimSpace(value)
+	if utils.InString(caseInsensitiveTypes, ioc_type) {
+		value = strings.ToLower(value)
+	}
+	return ioc_type + "|" + value
+}
+
+func GetTable(config_obj *config_proto.Config,
+	name string) (*api_proto.IOCTable, error) {
+	result := &api_proto.IOCTable{}
+	err := datastore.GetExistingSubject(config_obj,
+		paths.NewIOCTablePathManager(name).Path(), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func ListTables(config_obj *config_proto.Config) ([]*api_proto.IOCTable, error) {
+	children, err := datastore.ListSubjects(config_obj, paths.IOC_TABLES_ROOT)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api_proto.IOCTable, 0, len(children))
+	for _, child := range children {
+		if child.Type() != api.PATH_TYPE_DATASTORE_PROTO {
+			continue
+		}
+
+		table, err := GetTable(config_obj, child.Base())
+		if err != nil {
+			continue
+		}
+		result = append(result, table)
+	}
+
+	return result, nil
+}
+
+// Replace the indicators in the table. Duplicate indicators are
+// removed - the last one wins.
+func SetTable(config_obj *config_proto.Config,
+	table *api_proto.IOCTable, rows []*ordereddict.Dict) error {
+	if table.Name == "" {
+		return errors.New("IOC table must have a name")
+	}
+
+	path_manager := paths.NewIOCTablePathManager(table.Name)
+	unlock, err := datastore.LockSubject(config_obj, path_manager.Path())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Dedup the rows while keeping their order.
+	seen := make(map[string]int)
+	unique := make([]*ordereddict.Dict, 0, len(rows))
+	for _, row := range rows {
+		ioc_type, _ := row.GetString("Type")
+		value, _ := row.GetString("Value")
+		if value == "" {
+			continue
+		}
+
+		key := Key(ioc_type, value)
+		idx, pres := seen[key]
+		if pres {
+			unique[idx] = row
+			continue
+		}
+		seen[key] = len(unique)
+		unique = append(unique, row)
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	writer, err := result_sets.NewResultSetWriter(file_store_factory,
+		path_manager.IOCs(), json.DefaultEncOpts(),
+		utils.SyncCompleter, result_sets.TruncateMode)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range unique {
+		writer.Write(row)
+	}
+	writer.Close()
+
+	table.TotalIocs = uint64(len(unique))
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.SetSubject(config_obj, path_manager.Path(), table)
+}
+
+// Update the table metadata without changing the indicators.
+func SetTableMetadata(config_obj *config_proto.Config,
+	table *api_proto.IOCTable) error {
+	urn := paths.NewIOCTablePathManager(table.Name).Path()
+	unlock, err := datastore.LockSubject(config_obj, urn)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.SetSubject(config_obj, urn, table)
+}
+
+func DeleteTable(config_obj *config_proto.Config, name string) error {
+	path_manager := paths.NewIOCTablePathManager(name)
+	unlock, err := datastore.LockSubject(config_obj, path_manager.Path())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	_ = file_store_factory.Delete(path_manager.IOCs())
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.DeleteSubject(config_obj, path_manager.Path())
+}
+
+// Indicators without an Expires column never expire.
+func IsExpired(row *ordereddict.Dict, now time.Time) bool {
+	value, pres := row.Get("Expires")
+	if !pres {
+		return false
+	}
+
+	expires, ok := utils.ToInt64(value)
+	return ok && expires > 0 && expires <= now.Unix()
+}
+
+// Read the indicators in the table. Expired indicators are skipped.
+func ReadTable(ctx context.Context,
+	config_obj *config_proto.Config,
+	name string) (<-chan *ordereddict.Dict, error) {
+
+	_, err := GetTable(config_obj, name)
+	if err != nil {
+		return nil, err
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	reader, err := result_sets.NewResultSetReader(file_store_factory,
+		paths.NewIOCTablePathManager(name).IOCs())
+	if err != nil {
+		return nil, err
+	}
+
+	output_chan := make(chan *ordereddict.Dict)
+	go func() {
+		defer close(output_chan)
+		defer reader.Close()
+
+		now := utils.GetTime().Now()
+		for row := range reader.Rows(ctx) {
+			if IsExpired(row, now) {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan, nil
+}
@@ -1,10 +1,36 @@
 package logging
 
 import (
+	"sync"
+
 	"github.com/sirupsen/logrus"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 )
 
+// An AuditWriter receives every audit event (e.g. to maintain a
+// tamper evident audit log).
+type AuditWriter func(config_obj *config_proto.Config,
+	principal, operation string, details logrus.Fields)
+
+var (
+	audit_mu     sync.Mutex
+	audit_writer AuditWriter
+)
+
+// Install an audit writer. The returned function removes it again.
+func SetAuditWriter(writer AuditWriter) func() {
+	audit_mu.Lock()
+	defer audit_mu.Unlock()
+
+	audit_writer = writer
+	return func() {
+		audit_mu.Lock()
+		defer audit_mu.Unlock()
+
+		audit_writer = nil
+	}
+}
+
 // A wrapper around audit logging. Audit events need to have more
 // structure than the other events, so they can be easily
 // searched. This wrapper ensures the minimal amount of information is
@@ -17,4 +43,12 @@ func LogAudit(
 	details["principal"] = principal
 	logger := GetLogger(config_obj, &Audit)
 	logger.WithFields(details).Info(operation)
+
+	audit_mu.Lock()
+	writer := audit_writer
+	audit_mu.Unlock()
+
+	if writer != nil {
+		writer(config_obj, principal, operation, details)
+	}
 }
@@ -99,6 +99,30 @@ func InitLogging(config_obj *config_proto.Config) error {
 	return nil
 }
 
+// Reopen the log files with the new logging config. Loggers already
+// handed out keep working - their hooks are replaced in place.
+func Reload(config_obj *config_proto.Config) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if Manager == nil {
+		return errors.New("Logging not initialized")
+	}
+
+	Manager.mu.Lock()
+	defer Manager.mu.Unlock()
+
+	for component, logger := range Manager.contexts {
+		new_logger, err := Manager.makeNewComponent(config_obj, component)
+		if err != nil {
+			return err
+		}
+		logger.ReplaceHooks(new_logger.Hooks)
+	}
+
+	return nil
+}
+
 func ClearMemoryLogs() {
 	memory_log_mu.Lock()
 	memory_logs = nil
@@ -136,7 +160,7 @@ func FlushPrelogs(config_obj *config_proto.Config) {
 	memory_log_mu.Unlock()
 
 	for _, msg := range lprelogs {
-		logger.Info(msg)
+		logger.Info("%s", msg)
 	}
 	prelogs = make([]string, 0)
 }
@@ -427,6 +451,25 @@ func GetLogger(config_obj *config_proto.Config, component *string) *LogContext {
 	return Manager.GetLogger(config_obj, component)
 }
 
+// Add a hook to the loggers of all components. Services use this to
+// act on the server's own log messages.
+func AddHook(hook logrus.Hook) {
+	mu.Lock()
+	lManager := Manager
+	mu.Unlock()
+
+	if lManager == nil {
+		return
+	}
+
+	lManager.mu.Lock()
+	defer lManager.mu.Unlock()
+
+	for _, ctx := range lManager.contexts {
+		ctx.Logger.AddHook(hook)
+	}
+}
+
 type stackTracer interface {
 	Stack() []byte
 }
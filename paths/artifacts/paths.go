@@ -8,6 +8,7 @@ import (
 	"time"
 
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
 	"www.velocidex.com/golang/velociraptor/file_store"
 	"www.velocidex.com/golang/velociraptor/file_store/api"
 	"www.velocidex.com/golang/velociraptor/logging"
@@ -59,6 +60,13 @@ func NewArtifactPathManager(
 		return nil, err
 	}
 
+	// Client artifacts scheduled from the client monitoring table
+	// are stored with the client's monitoring events.
+	if mode == paths.MODE_CLIENT &&
+		flow_id == constants.MONITORING_WELL_KNOWN_FLOW {
+		mode = paths.MODE_CLIENT_EVENT
+	}
+
 	file_store_factory := file_store.GetFileStore(config_obj)
 	return &ArtifactPathManager{
 		config_obj:         config_obj,
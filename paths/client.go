@@ -46,12 +46,38 @@ func (self ClientPathManager) Metadata() api.DSPathSpec {
 		SetType(api.PATH_TYPE_DATASTORE_JSON)
 }
 
+// The client's health as computed by the client health service.
+func (self ClientPathManager) Health() api.DSPathSpec {
+	return self.root.AddChild("health").
+		SetType(api.PATH_TYPE_DATASTORE_JSON)
+}
+
+// The deception canaries planted on the client.
+func (self ClientPathManager) Canaries() api.DSPathSpec {
+	return self.root.AddChild("canaries").
+		SetType(api.PATH_TYPE_DATASTORE_JSON)
+}
+
+// An archive of all the client's collections. It is kept outside the
+// client's directory so it survives when the client is purged.
+func (self ClientPathManager) GetArchiveFile() api.FSPathSpec {
+	return DOWNLOADS_ROOT.AddUnsafeChild(self.client_id,
+		fmt.Sprintf("Archive %v", self.client_id))
+}
+
 // Store each client's public key so we can communicate with it.
 func (self ClientPathManager) Key() api.DSPathSpec {
 	return self.root.AddChild("key").
 		SetTag("ClientKey")
 }
 
+// A new key the client is rotating to. It replaces the key above once
+// the client starts using it.
+func (self ClientPathManager) PendingKey() api.DSPathSpec {
+	return self.root.AddChild("pending_key").
+		SetTag("ClientKey")
+}
+
 // Queue tasks for the client in a directory within the client's main directory.
 func (self ClientPathManager) TasksDirectory() api.DSPathSpec {
 	return self.root.AddChild("tasks").
@@ -23,6 +23,12 @@ func (self *PathManagerTestSuite) TestClientPathManager() {
 	assert.Equal(self.T(), "/ds/clients/C.123/key.db",
 		self.getDatastorePath(manager.Key()))
 
+	assert.Equal(self.T(), "/ds/clients/C.123/health.json.db",
+		self.getDatastorePath(manager.Health()))
+
+	assert.Equal(self.T(), "/fs/downloads/C.123/Archive C.123.zip",
+		self.getFilestorePath(manager.GetArchiveFile()))
+
 	assert.Equal(self.T(), "/ds/clients/C.123/tasks/1234.db",
 		self.getDatastorePath(manager.Task(1234)))
 
@@ -39,6 +39,51 @@ var (
 	HUNTS_ROOT = path_specs.NewSafeDatastorePath("hunts").
 			SetType(api.PATH_TYPE_DATASTORE_PROTO)
 
+	HUNT_SCHEDULES_ROOT = path_specs.NewSafeDatastorePath("hunt_schedules").
+				SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	LABEL_RULES_ROOT = path_specs.NewUnsafeDatastorePath("label_rules").
+				SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	CLIENT_GROUPS_ROOT = path_specs.NewUnsafeDatastorePath("client_groups").
+				SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	IOC_TABLES_ROOT = path_specs.NewUnsafeDatastorePath("ioc_tables").
+			SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	FEDERATED_HUNTS_ROOT = path_specs.NewSafeDatastorePath("federated_hunts").
+				SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	CASES_ROOT = path_specs.NewSafeDatastorePath("cases").
+			SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	KAPE_TARGETS_ROOT = path_specs.NewUnsafeDatastorePath("kape_targets").
+				SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	ARTIFACT_SYNC_STATE = path_specs.NewUnsafeDatastorePath("artifact_sync").
+				SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	ARTIFACT_TESTS_ROOT = path_specs.NewUnsafeDatastorePath("artifact_tests").
+				SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	SECRETS_ROOT = path_specs.NewUnsafeDatastorePath("secrets").
+			SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	API_KEYS_ROOT = path_specs.NewUnsafeDatastorePath("api_keys").
+			SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	APPROVALS_ROOT = path_specs.NewUnsafeDatastorePath("approvals").
+			SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	// Clients waiting for enrollment approval, by state.
+	ENROLLMENT_ROOT = path_specs.NewUnsafeDatastorePath("enrollment").
+			SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
+	// The lineage of coalesced clients. Kept outside the client's
+	// directory so it survives when the client is deleted.
+	CLIENT_LINEAGE_ROOT = path_specs.NewUnsafeDatastorePath("client_lineage").
+				SetType(api.PATH_TYPE_DATASTORE_PROTO)
+
 	USERS_ROOT = path_specs.NewUnsafeDatastorePath("users").
 			SetType(api.PATH_TYPE_DATASTORE_JSON)
 
@@ -48,6 +93,28 @@ var (
 	ORGS_ROOT = path_specs.NewSafeDatastorePath("orgs").
 			SetType(api.PATH_TYPE_DATASTORE_JSON)
 
+	// A snapshot of the client info cache used to warm up the cache
+	// on startup.
+	CLIENT_INFO_SNAPSHOT = path_specs.NewUnsafeFilestorePath(
+		"client_info", "snapshot").
+		SetType(api.PATH_TYPE_FILESTORE_JSON)
+
+	// Journals of file store transactions that are being committed.
+	TRANSACTIONS_ROOT = path_specs.NewUnsafeFilestorePath("transactions").
+				SetType(api.PATH_TYPE_FILESTORE_JSON)
+
+	// The aggregated buckets of materialized views.
+	MATERIALIZED_VIEWS_ROOT = path_specs.NewUnsafeFilestorePath("views").
+				SetType(api.PATH_TYPE_FILESTORE_JSON)
+
+	// The term index of uploaded files.
+	UPLOAD_INDEX_ROOT = path_specs.NewUnsafeFilestorePath("upload_index").
+				SetType(api.PATH_TYPE_FILESTORE_JSON)
+
+	// The term index of collection results.
+	RESULT_INDEX_ROOT = path_specs.NewUnsafeFilestorePath("result_index").
+				SetType(api.PATH_TYPE_FILESTORE_JSON)
+
 	// The public directory is exported without authentication and
 	// is used to distribute the client binaries.
 	PUBLIC_ROOT = path_specs.NewUnsafeFilestorePath("public").
@@ -77,4 +144,8 @@ var (
 
 	ThirdPartyInventory = path_specs.NewSafeDatastorePath(
 		"config", "inventory").SetType(api.PATH_TYPE_DATASTORE_JSON)
+
+	// The org's resource usage accounted against its quota.
+	OrgUsageURN = path_specs.NewSafeDatastorePath(
+		"config", "usage").SetType(api.PATH_TYPE_DATASTORE_JSON)
 )
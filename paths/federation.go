@@ -0,0 +1,32 @@
+package paths
+
+import "www.velocidex.com/golang/velociraptor/file_store/api"
+
+type FederatedHuntPathManager struct {
+	hunt_id string
+}
+
+func NewFederatedHuntPathManager(hunt_id string) *FederatedHuntPathManager {
+	return &FederatedHuntPathManager{hunt_id: hunt_id}
+}
+
+// The federated hunt's metadata.
+func (self FederatedHuntPathManager) Path() api.DSPathSpec {
+	return FEDERATED_HUNTS_ROOT.AddChild(self.hunt_id)
+}
+
+// The results fetched from a single peer.
+func (self FederatedHuntPathManager) PeerResults(
+	peer, artifact_source string) api.FSPathSpec {
+	return FEDERATED_HUNTS_ROOT.AddChild(self.hunt_id).AsFilestorePath().
+		AddUnsafeChild("peers", peer, artifact_source).
+		SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
+
+// The results of all the peers merged together.
+func (self FederatedHuntPathManager) Results(
+	artifact_source string) api.FSPathSpec {
+	return FEDERATED_HUNTS_ROOT.AddChild(self.hunt_id).AsFilestorePath().
+		AddUnsafeChild("results", artifact_source).
+		SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
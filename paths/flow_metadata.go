@@ -70,6 +70,15 @@ func (self FlowPathManager) Log() api.FSPathSpec {
 		SetType(api.PATH_TYPE_FILESTORE_JSON)
 }
 
+// The recorded input and output of a remote shell session. Reading
+// it requires the READ_SHELL_RECORDINGS permission.
+func (self FlowPathManager) ShellRecording() api.FSPathSpec {
+	return self.Path().AddChild("shell_recording").
+		AsFilestorePath().
+		SetTag("ShellRecording").
+		SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
+
 func (self FlowPathManager) LogLegacy() api.FSPathSpec {
 	return self.Path().AddChild("logs").
 		AsFilestorePath().
@@ -92,6 +101,16 @@ func (self FlowPathManager) UploadMetadata() api.FSPathSpec {
 	return self.Path().AddChild("uploads").AsFilestorePath()
 }
 
+// The uploads of the flow which were added to the upload index.
+func (self FlowPathManager) UploadIndex() api.FSPathSpec {
+	return self.Path().AddChild("upload_index").AsFilestorePath()
+}
+
+// The artifacts of the flow which were added to the result index.
+func (self FlowPathManager) ResultIndex() api.FSPathSpec {
+	return self.Path().AddChild("result_index").AsFilestorePath()
+}
+
 func (self FlowPathManager) UploadContainer() api.FSPathSpec {
 	return self.Path().AddUnsafeChild("uploads").
 		AsFilestorePath().
@@ -125,6 +144,12 @@ func (self FlowPathManager) GetDownloadsFile(
 	return DOWNLOADS_ROOT.AddUnsafeChild(self.client_id, self.flow_id, filename)
 }
 
+// An archive of the flow for importing into another server.
+func (self FlowPathManager) GetArchiveFile() api.FSPathSpec {
+	return DOWNLOADS_ROOT.AddUnsafeChild(self.client_id, self.flow_id,
+		fmt.Sprintf("Archive %v-%v", self.client_id, self.flow_id))
+}
+
 func (self FlowPathManager) GetReportsFile(hostname string) api.FSPathSpec {
 	// If there is no hostname we drop the leading -
 	if hostname != "" {
@@ -23,6 +23,9 @@ func (self *PathManagerTestSuite) TestFlowPathManager() {
 	assert.Equal(self.T(), "/fs/downloads/C.123/F.1234/HostnameX-C.123-F.1234.zip",
 		self.getFilestorePath(manager.GetDownloadsFile("HostnameX", false)))
 
+	assert.Equal(self.T(), "/fs/downloads/C.123/F.1234/Archive C.123-F.1234.zip",
+		self.getFilestorePath(manager.GetArchiveFile()))
+
 	assert.Equal(self.T(), "/fs/downloads/C.123/F.1234/Report HostnameX-C.123-F.1234.html",
 		self.getFilestorePath(manager.GetReportsFile("HostnameX")))
 
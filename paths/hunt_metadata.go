@@ -36,6 +36,13 @@ func (self HuntPathManager) GetHuntDownloadsStats(only_combined bool,
 		AsDatastorePath()
 }
 
+// An archive of the hunt for importing into another server.
+func (self HuntPathManager) GetArchiveFile() api.FSPathSpec {
+	return DOWNLOADS_ROOT.AddUnsafeChild(
+		"hunts", self.hunt_id, "Archive "+self.hunt_id).SetType(
+		api.PATH_TYPE_FILESTORE_DOWNLOAD_ZIP)
+}
+
 func NewHuntPathManager(hunt_id string) *HuntPathManager {
 	return &HuntPathManager{
 		path:    HUNTS_ROOT.AddChild(hunt_id),
@@ -47,6 +54,15 @@ func (self HuntPathManager) Stats() api.DSPathSpec {
 	return self.path.AddChild("stats")
 }
 
+// The retry history of a client's collections in the hunt.
+func (self HuntPathManager) Retries(client_id string) api.DSPathSpec {
+	return self.path.AddChild("retries", client_id)
+}
+
+func (self HuntPathManager) RetriesDirectory() api.DSPathSpec {
+	return self.path.AddChild("retries")
+}
+
 func (self HuntPathManager) HuntDirectory() api.DSPathSpec {
 	return HUNTS_ROOT
 }
@@ -61,3 +77,39 @@ func (self HuntPathManager) ClientErrors() api.FSPathSpec {
 	return HUNTS_ROOT.AddChild(self.hunt_id + "_errors").
 		AsFilestorePath()
 }
+
+// The results of all the hunt's collections of an artifact source
+// merged into a single result set.
+func (self HuntPathManager) Results(artifact_source string) api.FSPathSpec {
+	artifact, source := SplitFullSourceName(artifact_source)
+	result := HUNTS_ROOT.AddChild(self.hunt_id, "results").
+		AsFilestorePath().AddUnsafeChild(artifact)
+	if source != "" {
+		result = result.AddUnsafeChild(source)
+	}
+	return result
+}
+
+// The frequency stack of an artifact source's results across all
+// the hunt's clients.
+func (self HuntPathManager) Stack(artifact_source string) api.FSPathSpec {
+	artifact, source := SplitFullSourceName(artifact_source)
+	result := HUNTS_ROOT.AddChild(self.hunt_id, "stacks").
+		AsFilestorePath().AddUnsafeChild(artifact)
+	if source != "" {
+		result = result.AddUnsafeChild(source)
+	}
+	return result
+}
+
+// Describes the hunt's stacks and records that the hunt was stacked.
+func (self HuntPathManager) StackSummary() api.FSPathSpec {
+	return HUNTS_ROOT.AddChild(self.hunt_id, "stack_summary").
+		AsFilestorePath().SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
+
+// Records where the hunt's results were archived to object storage.
+func (self HuntPathManager) ArchiveManifest() api.FSPathSpec {
+	return HUNTS_ROOT.AddChild(self.hunt_id, "archive_manifest").
+		AsFilestorePath().SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
@@ -15,6 +15,9 @@ func (self *PathManagerTestSuite) TestHuntPathManager() {
 			true /* only_combined */, "你好世界", false,
 		)))
 
+	assert.Equal(self.T(), "/fs/downloads/hunts/H.1234/Archive H.1234.zip",
+		self.getFilestorePath(manager.GetArchiveFile()))
+
 	assert.Equal(self.T(), "/ds/hunts/H.1234/stats.db",
 		self.getDatastorePath(manager.Stats()))
 
@@ -23,4 +26,10 @@ func (self *PathManagerTestSuite) TestHuntPathManager() {
 
 	assert.Equal(self.T(), "/fs/hunts/H.1234_errors.json",
 		self.getFilestorePath(manager.ClientErrors()))
+
+	assert.Equal(self.T(), "/fs/hunts/H.1234/results/Generic.Client.Info/Users.json",
+		self.getFilestorePath(manager.Results("Generic.Client.Info/Users")))
+
+	assert.Equal(self.T(), "/fs/hunts/H.1234/results/Windows.System.Pslist.json",
+		self.getFilestorePath(manager.Results("Windows.System.Pslist")))
 }
@@ -52,6 +52,20 @@ func (self IndexPathManager) SnapshotDirectory() api.FSPathSpec {
 		SetType(api.PATH_TYPE_FILESTORE_ANY)
 }
 
+// Each shard of the index is snapshotted separately so only shards
+// that changed need to be written.
+func (self IndexPathManager) ShardSnapshot(shard int) api.FSPathSpec {
+	return CLIENT_INDEX_URN.AddChild("shards", fmt.Sprintf("%03d", shard)).
+		AsFilestorePath().
+		SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
+
+func (self IndexPathManager) ShardSnapshotDirectory() api.FSPathSpec {
+	return CLIENT_INDEX_URN.AddChild("shards").
+		AsFilestorePath().
+		SetType(api.PATH_TYPE_FILESTORE_ANY)
+}
+
 func (self IndexPathManager) TermPartitions(term string) []string {
 	return splitTermToParts(term)
 }
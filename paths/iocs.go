@@ -0,0 +1,22 @@
+package paths
+
+import "www.velocidex.com/golang/velociraptor/file_store/api"
+
+type IOCTablePathManager struct {
+	name string
+}
+
+func NewIOCTablePathManager(name string) *IOCTablePathManager {
+	return &IOCTablePathManager{name: name}
+}
+
+// The table's metadata.
+func (self IOCTablePathManager) Path() api.DSPathSpec {
+	return IOC_TABLES_ROOT.AddChild(self.name)
+}
+
+// The indicators are stored in a result set.
+func (self IOCTablePathManager) IOCs() api.FSPathSpec {
+	return IOC_TABLES_ROOT.AddChild(self.name).AsFilestorePath().
+		SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
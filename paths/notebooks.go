@@ -68,6 +68,13 @@ func (self *NotebookPathManager) HtmlExport() api.FSPathSpec {
 		SetType(api.PATH_TYPE_FILESTORE_DOWNLOAD_REPORT)
 }
 
+func (self *NotebookPathManager) PdfExport() api.FSPathSpec {
+	return DOWNLOADS_ROOT.AddChild("notebooks", self.notebook_id,
+		fmt.Sprintf("%s-%s", self.notebook_id,
+			self.Clock.Now().UTC().Format("20060102150405Z"))).
+		SetType(api.PATH_TYPE_FILESTORE_DOWNLOAD_PDF)
+}
+
 func (self *NotebookPathManager) ZipExport() api.FSPathSpec {
 	return DOWNLOADS_ROOT.AddChild("notebooks", self.notebook_id,
 		fmt.Sprintf("%s-%s", self.notebook_id,
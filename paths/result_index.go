@@ -0,0 +1,30 @@
+package paths
+
+import (
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+)
+
+type ResultIndexPathManager struct{}
+
+func NewResultIndexPathManager() *ResultIndexPathManager {
+	return &ResultIndexPathManager{}
+}
+
+// The index is partitioned by the month the collections were created
+// in so searches within a time range only read some partitions.
+func (self ResultIndexPathManager) PartitionsDirectory() api.FSPathSpec {
+	return RESULT_INDEX_ROOT
+}
+
+func (self ResultIndexPathManager) PartitionName(month time.Time) string {
+	return month.UTC().Format("2006-01")
+}
+
+// Each shard of a partition is a result set of the postings of the
+// terms which hash to it.
+func (self ResultIndexPathManager) Shard(partition, shard string) api.FSPathSpec {
+	return RESULT_INDEX_ROOT.AddChild(partition, shard).
+		SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
@@ -64,3 +64,43 @@ func (self *SuperTimelinePathManager) GetChild(
 			AsFilestorePath(),
 	}
 }
+
+// Client timelines are built by the timeline builder service from
+// the client's collections.
+type ClientTimelinePathManager struct {
+	client_id string
+	name      string
+}
+
+func NewClientTimelinePathManager(
+	client_id, name string) *ClientTimelinePathManager {
+	return &ClientTimelinePathManager{
+		client_id: client_id,
+		name:      name,
+	}
+}
+
+// The directory containing all the client's timelines.
+func (self *ClientTimelinePathManager) Directory() api.DSPathSpec {
+	return CLIENTS_ROOT.AddChild(self.client_id, "timelines")
+}
+
+// Where we store the timeline's metadata.
+func (self *ClientTimelinePathManager) Path() api.DSPathSpec {
+	return self.Directory().AddUnsafeChild(self.name).
+		SetTag("ClientTimeline")
+}
+
+// The merged events are stored in an indexed timeline.
+func (self *ClientTimelinePathManager) Timeline() *TimelinePathManager {
+	return NewTimelinePathManager(self.name,
+		self.Directory().AddUnsafeChild(self.name, "events").
+			AsFilestorePath())
+}
+
+// Annotations are kept separately so they survive rebuilding the
+// timeline.
+func (self *ClientTimelinePathManager) Annotations() api.FSPathSpec {
+	return self.Directory().AddUnsafeChild(self.name, "annotations").
+		AsFilestorePath().SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
@@ -0,0 +1,23 @@
+package paths
+
+import (
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+)
+
+type UploadIndexPathManager struct{}
+
+func NewUploadIndexPathManager() *UploadIndexPathManager {
+	return &UploadIndexPathManager{}
+}
+
+// The shards of the term index.
+func (self UploadIndexPathManager) ShardsDirectory() api.FSPathSpec {
+	return UPLOAD_INDEX_ROOT.AddChild("terms")
+}
+
+// Each shard is a result set of the postings of the terms which hash
+// to it.
+func (self UploadIndexPathManager) Shard(shard string) api.FSPathSpec {
+	return self.ShardsDirectory().AddChild(shard).
+		SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
@@ -0,0 +1,28 @@
+package paths
+
+import (
+	"strconv"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+)
+
+type MaterializedViewPathManager struct {
+	name string
+}
+
+func NewMaterializedViewPathManager(name string) *MaterializedViewPathManager {
+	return &MaterializedViewPathManager{name: name}
+}
+
+// All the buckets of the view are stored in this directory.
+func (self MaterializedViewPathManager) Directory() api.FSPathSpec {
+	return MATERIALIZED_VIEWS_ROOT.AddUnsafeChild(self.name)
+}
+
+// Each bucket is a result set named by its start time in epoch
+// seconds.
+func (self MaterializedViewPathManager) Bucket(start time.Time) api.FSPathSpec {
+	return self.Directory().AddChild(strconv.FormatInt(start.Unix(), 10)).
+		SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
@@ -0,0 +1,367 @@
+package reporting
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+// Charts are drawn on the server so rendered documents do not need
+// the GUI's javascript. Like the GUI charts, the first column is the
+// X axis (or the name of a bar group) and the remaining numeric
+// columns are the series.
+
+const (
+	CHART_WIDTH  = 600
+	CHART_HEIGHT = 300
+
+	chartLeft   = 60
+	chartRight  = 470
+	chartTop    = 20
+	chartBottom = 260
+)
+
+var (
+	chart_palette = []string{
+		"#8884d8", "#82ca9d", "#ffc658", "#ff7300",
+		"#0088fe", "#00c49f", "#ff8042", "#a4de6c",
+	}
+
+	// The GUI chart directives and the type of chart they draw.
+	chart_types = map[string]string{
+		"grr-line-chart":         "line",
+		"notebook-line-chart":    "line",
+		"time-chart":             "time",
+		"notebook-time-chart":    "time",
+		"bar-chart":              "bar",
+		"notebook-bar-chart":     "bar",
+		"scatter-chart":          "scatter",
+		"notebook-scatter-chart": "scatter",
+	}
+)
+
+type chart struct {
+	Type    string
+	Columns []string
+	Rows    []*ordereddict.Dict
+}
+
+type point struct {
+	X, Y float64
+}
+
+// A shape in chart coordinates - the origin is the top left corner.
+type shape struct {
+	// One of line, rect, circle or text
+	Kind   string
+	Points []point
+	X, Y   float64
+	W, H   float64
+	Color  string
+
+	Text string
+	Size float64
+
+	// Text alignment: start, middle or end
+	Anchor string
+}
+
+type drawing struct {
+	Width, Height float64
+	Shapes        []*shape
+}
+
+func (self *drawing) add(s *shape) {
+	self.Shapes = append(self.Shapes, s)
+}
+
+func (self *drawing) text(x, y float64, text, anchor string) {
+	self.add(&shape{Kind: "text", X: x, Y: y, Text: text,
+		Size: 10, Anchor: anchor, Color: "#333333"})
+}
+
+func (self *drawing) line(color string, points ...point) {
+	self.add(&shape{Kind: "line", Points: points, Color: color})
+}
+
+// Lay the chart out into shapes.
+func (self *chart) Draw() *drawing {
+	result := &drawing{Width: CHART_WIDTH, Height: CHART_HEIGHT}
+	if len(self.Columns) < 2 || len(self.Rows) == 0 {
+		result.text(CHART_WIDTH/2, CHART_HEIGHT/2, "No data", "middle")
+		return result
+	}
+
+	x_column := self.Columns[0]
+	series := self.Columns[1:]
+
+	// The Y range covers all the series and 0.
+	min_y, max_y := 0.0, 0.0
+	for _, row := range self.Rows {
+		for _, column := range series {
+			y, ok := chartNumber(row, column)
+			if ok {
+				min_y = math.Min(min_y, y)
+				max_y = math.Max(max_y, y)
+			}
+		}
+	}
+	if max_y == min_y {
+		max_y = min_y + 1
+	}
+
+	scale_y := func(y float64) float64 {
+		return chartBottom - (y-min_y)/(max_y-min_y)*(chartBottom-chartTop)
+	}
+
+	// Axes and Y ticks
+	result.line("#666666", point{chartLeft, chartTop},
+		point{chartLeft, chartBottom}, point{chartRight, chartBottom})
+	for i := 0; i <= 4; i++ {
+		y := min_y + (max_y-min_y)*float64(i)/4
+		result.line("#dddddd", point{chartLeft, scale_y(y)},
+			point{chartRight, scale_y(y)})
+		result.text(chartLeft-5, scale_y(y)+3, formatNumber(y), "end")
+	}
+
+	if self.Type == "bar" {
+		self.drawBars(result, x_column, series, scale_y)
+	} else {
+		self.drawXY(result, x_column, series, scale_y)
+	}
+
+	// Legend
+	for i, column := range series {
+		y := float64(chartTop + 15*i)
+		result.add(&shape{Kind: "rect", X: chartRight + 15, Y: y,
+			W: 10, H: 10, Color: chart_palette[i%len(chart_palette)]})
+		result.text(chartRight+30, y+9, truncate(column, 20), "start")
+	}
+
+	return result
+}
+
+func (self *chart) drawBars(result *drawing, x_column string,
+	series []string, scale_y func(float64) float64) {
+	group_width := float64(chartRight-chartLeft) / float64(len(self.Rows))
+	bar_width := group_width * 0.8 / float64(len(series))
+
+	// Only label as many groups as fit.
+	label_every := int(math.Ceil(float64(len(self.Rows)) / 10))
+
+	for i, row := range self.Rows {
+		x := chartLeft + group_width*float64(i) + group_width*0.1
+		for j, column := range series {
+			y, ok := chartNumber(row, column)
+			if !ok {
+				continue
+			}
+
+			top, bottom := scale_y(y), scale_y(0)
+			if top > bottom {
+				top, bottom = bottom, top
+			}
+			result.add(&shape{Kind: "rect",
+				X: x + bar_width*float64(j), Y: top,
+				W: bar_width, H: bottom - top,
+				Color: chart_palette[j%len(chart_palette)]})
+		}
+
+		if i%label_every == 0 {
+			name, _ := row.Get(x_column)
+			result.text(x+group_width*0.4, chartBottom+15,
+				truncate(fmt.Sprintf("%v", name), 12), "middle")
+		}
+	}
+}
+
+func (self *chart) drawXY(result *drawing, x_column string,
+	series []string, scale_y func(float64) float64) {
+
+	// Rows without a usable X value are skipped.
+	type xy_row struct {
+		x   float64
+		row *ordereddict.Dict
+	}
+	rows := []xy_row{}
+	for i, row := range self.Rows {
+		var x float64
+		var ok bool
+		switch self.Type {
+		case "time":
+			x, ok = chartTime(row, x_column)
+		default:
+			x, ok = chartNumber(row, x_column)
+			if !ok && self.Type == "line" {
+				x, ok = float64(i), true
+			}
+		}
+		if ok {
+			rows = append(rows, xy_row{x: x, row: row})
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].x < rows[j].x
+	})
+
+	min_x, max_x := rows[0].x, rows[len(rows)-1].x
+	if max_x == min_x {
+		max_x = min_x + 1
+	}
+	scale_x := func(x float64) float64 {
+		return chartLeft + (x-min_x)/(max_x-min_x)*(chartRight-chartLeft)
+	}
+
+	for i := 0; i <= 4; i++ {
+		x := min_x + (max_x-min_x)*float64(i)/4
+		label := formatNumber(x)
+		if self.Type == "time" {
+			label = time.Unix(int64(x), 0).UTC().Format("2006-01-02 15:04")
+		}
+		result.text(scale_x(x), chartBottom+15, label, "middle")
+	}
+
+	for j, column := range series {
+		color := chart_palette[j%len(chart_palette)]
+		points := []point{}
+		for _, row := range rows {
+			y, ok := chartNumber(row.row, column)
+			if !ok {
+				continue
+			}
+			p := point{scale_x(row.x), scale_y(y)}
+
+			if self.Type == "scatter" {
+				result.add(&shape{Kind: "circle", X: p.X, Y: p.Y,
+					W: 3, Color: color})
+				continue
+			}
+			points = append(points, p)
+		}
+
+		if len(points) > 0 {
+			result.line(color, points...)
+		}
+	}
+}
+
+// Serialize the drawing as an SVG element.
+func (self *drawing) SVG() string {
+	result := &strings.Builder{}
+	fmt.Fprintf(result, `<svg xmlns="http://www.w3.org/2000/svg" `+
+		`class="chart" width="%v" height="%v" viewBox="0 0 %v %v">`,
+		self.Width, self.Height, self.Width, self.Height)
+
+	for _, s := range self.Shapes {
+		switch s.Kind {
+		case "line":
+			points := []string{}
+			for _, p := range s.Points {
+				points = append(points, fmt.Sprintf("%.1f,%.1f", p.X, p.Y))
+			}
+			fmt.Fprintf(result,
+				`<polyline fill="none" stroke="%s" points="%s"/>`,
+				s.Color, strings.Join(points, " "))
+
+		case "rect":
+			fmt.Fprintf(result,
+				`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`,
+				s.X, s.Y, s.W, s.H, s.Color)
+
+		case "circle":
+			fmt.Fprintf(result,
+				`<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"/>`,
+				s.X, s.Y, s.W, s.Color)
+
+		case "text":
+			fmt.Fprintf(result,
+				`<text x="%.1f" y="%.1f" font-size="%v" `+
+					`font-family="Helvetica, Arial, sans-serif" `+
+					`text-anchor="%s" fill="%s">%s</text>`,
+				s.X, s.Y, s.Size, s.Anchor, s.Color,
+				html.EscapeString(s.Text))
+		}
+	}
+
+	result.WriteString("</svg>")
+	return result.String()
+}
+
+func chartNumber(row *ordereddict.Dict, column string) (float64, bool) {
+	value, pres := row.Get(column)
+	if !pres {
+		return 0, false
+	}
+
+	switch t := value.(type) {
+	case int:
+		return float64(t), true
+	case int64:
+		return float64(t), true
+	case uint64:
+		return float64(t), true
+	case float64:
+		return t, true
+	case bool:
+		if t {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		result, err := strconv.ParseFloat(t, 64)
+		return result, err == nil
+	}
+	return 0, false
+}
+
+// Times are returned as seconds since the epoch.
+func chartTime(row *ordereddict.Dict, column string) (float64, bool) {
+	value, pres := row.Get(column)
+	if !pres {
+		return 0, false
+	}
+
+	switch t := value.(type) {
+	case time.Time:
+		return float64(t.UnixNano()) / 1e9, true
+
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, t)
+		if err == nil {
+			return float64(parsed.UnixNano()) / 1e9, true
+		}
+	}
+
+	// Numbers are epoch seconds or milliseconds.
+	result, ok := chartNumber(row, column)
+	if ok && result > 1e11 {
+		result /= 1000
+	}
+	return result, ok
+}
+
+func formatNumber(value float64) string {
+	if math.Abs(value) >= 1e6 || (value != 0 && math.Abs(value) < 0.01) {
+		return strconv.FormatFloat(value, 'g', 3, 64)
+	}
+	result := strconv.FormatFloat(value, 'f', 2, 64)
+	return strings.TrimSuffix(strings.TrimRight(result, "0"), ".")
+}
+
+func truncate(value string, length int) string {
+	runes := []rune(value)
+	if len(runes) <= length {
+		return value
+	}
+	return string(runes[:length-1]) + "…"
+}
@@ -0,0 +1,814 @@
+package reporting
+
+// A minimal PDF writer for rendered documents.
+//
+// Documents are laid out as a flow of blocks (headings, paragraphs,
+// preformatted text, tables, charts and images) on A4 pages. Only
+// the standard PDF fonts are used so nothing needs to be embedded,
+// which limits the text to the WinAnsi (Latin 1) character set.
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	pdfPageWidth  = 595.28
+	pdfPageHeight = 841.89
+	pdfMargin     = 40.0
+	pdfTextWidth  = pdfPageWidth - 2*pdfMargin
+
+	pdfTextSize  = 10.0
+	pdfTableSize = 7.0
+	pdfPreSize   = 8.0
+)
+
+const (
+	fontRegular = iota
+	fontBold
+	fontMono
+)
+
+var (
+	pdf_font_names = []string{"Helvetica", "Helvetica-Bold", "Courier"}
+
+	// Glyph widths of the printable ASCII characters (32-126) from
+	// the standard font metrics, in 1/1000 of the font size.
+	helvetica_widths = []int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278,
+		333, 278, 278, 556, 556, 556, 556, 556, 556, 556, 556, 556, 556,
+		278, 278, 584, 584, 584, 556, 1015, 667, 667, 722, 722, 667, 611,
+		778, 722, 278, 500, 667, 556, 833, 722, 778, 667, 778, 722, 667,
+		611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, 333,
+		556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833,
+		556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500,
+		334, 260, 334, 584,
+	}
+
+	helvetica_bold_widths = []int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278,
+		333, 278, 278, 556, 556, 556, 556, 556, 556, 556, 556, 556, 556,
+		333, 333, 584, 584, 584, 611, 975, 722, 722, 722, 722, 667, 611,
+		778, 722, 278, 556, 722, 611, 833, 722, 778, 667, 778, 722, 667,
+		611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556, 333,
+		556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889,
+		611, 611, 611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500,
+		389, 280, 389, 584,
+	}
+
+	// Characters outside Latin 1 which WinAnsi encodes.
+	win_ansi_extra = map[rune]byte{
+		'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93,
+		'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	}
+)
+
+// Encode text in WinAnsi - unsupported characters are replaced.
+func winAnsi(text string) []byte {
+	result := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			result = append(result, ' ')
+		case r >= 32 && r < 127, r >= 160 && r < 256:
+			result = append(result, byte(r))
+		default:
+			c, pres := win_ansi_extra[r]
+			if !pres {
+				c = '?'
+			}
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func pdfString(text string) string {
+	result := &strings.Builder{}
+	result.WriteByte('(')
+	for _, c := range winAnsi(text) {
+		if c == '(' || c == ')' || c == '\\' {
+			result.WriteByte('\\')
+		}
+		result.WriteByte(c)
+	}
+	result.WriteByte(')')
+	return result.String()
+}
+
+func textWidth(font int, size float64, text string) float64 {
+	total := 0
+	for _, c := range winAnsi(text) {
+		switch {
+		case font == fontMono:
+			total += 600
+		case c >= 32 && c < 127 && font == fontBold:
+			total += helvetica_bold_widths[c-32]
+		case c >= 32 && c < 127:
+			total += helvetica_widths[c-32]
+		case c == 0x85:
+			total += 1000
+		default:
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Truncate the text to fit in the width.
+func fitText(font int, size, width float64, text string) string {
+	if textWidth(font, size, text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		result := string(runes) + "…"
+		if textWidth(font, size, result) <= width {
+			return result
+		}
+	}
+	return ""
+}
+
+func pdfColor(color string) (float64, float64, float64) {
+	value, err := strconv.ParseUint(strings.TrimPrefix(color, "#"), 16, 32)
+	if err != nil || len(color) != 7 {
+		return 0, 0, 0
+	}
+	return float64(value>>16&0xff) / 255,
+		float64(value>>8&0xff) / 255,
+		float64(value&0xff) / 255
+}
+
+// A span of text in a single font.
+type pdfRun struct {
+	Text string
+	Font int
+}
+
+type pdfImage struct {
+	Width, Height int
+
+	// Flate compressed RGB pixels
+	Data []byte
+}
+
+type pdfDocument struct {
+	Title string
+
+	pages  []*bytes.Buffer
+	images []*pdfImage
+
+	// The current page and the position of the next block on it.
+	page *bytes.Buffer
+	y    float64
+
+	// The inline runs of the paragraph being collected.
+	paragraph []pdfRun
+}
+
+func newPDFDocument(title string) *pdfDocument {
+	result := &pdfDocument{Title: title}
+	result.newPage()
+	return result
+}
+
+func (self *pdfDocument) newPage() {
+	self.page = &bytes.Buffer{}
+	self.pages = append(self.pages, self.page)
+	self.y = pdfPageHeight - pdfMargin
+}
+
+// Start a new page unless there is enough space left for the block.
+func (self *pdfDocument) ensure(height float64) {
+	if self.y-height < pdfMargin && self.y < pdfPageHeight-pdfMargin {
+		self.newPage()
+	}
+}
+
+func (self *pdfDocument) drawText(font int, size, x, y float64, text string) {
+	fmt.Fprintf(self.page, "BT /F%d %.2f Tf %.2f %.2f Td %s Tj ET\n",
+		font+1, size, x, y, pdfString(text))
+}
+
+func (self *pdfDocument) fillRect(color string, x, y, w, h float64) {
+	r, g, b := pdfColor(color)
+	fmt.Fprintf(self.page, "%.3f %.3f %.3f rg %.2f %.2f %.2f %.2f re f 0 g\n",
+		r, g, b, x, y, w, h)
+}
+
+func (self *pdfDocument) space(height float64) {
+	self.y -= height
+}
+
+func (self *pdfDocument) addRun(text string, font int) {
+	self.paragraph = append(self.paragraph, pdfRun{Text: text, Font: font})
+}
+
+// Lay out the collected paragraph.
+func (self *pdfDocument) flush() {
+	runs := self.paragraph
+	self.paragraph = nil
+	self.text(runs, pdfTextSize)
+}
+
+// Word wrap the runs into lines of text.
+func (self *pdfDocument) text(runs []pdfRun, size float64) {
+	// Words may be made of several runs (e.g. "a<b>b</b>").
+	words := [][]pdfRun{}
+	join := false
+	for _, run := range runs {
+		if run.Text == "" {
+			continue
+		}
+		starts_with_space := strings.TrimLeft(run.Text, " \t\r\n") != run.Text
+		for i, field := range strings.Fields(run.Text) {
+			part := pdfRun{Text: field, Font: run.Font}
+			if i == 0 && join && !starts_with_space && len(words) > 0 {
+				words[len(words)-1] = append(words[len(words)-1], part)
+				continue
+			}
+			words = append(words, []pdfRun{part})
+		}
+		join = strings.TrimRight(run.Text, " \t\r\n") == run.Text
+	}
+
+	if len(words) == 0 {
+		return
+	}
+
+	line_height := size * 1.3
+	space := textWidth(fontRegular, size, " ")
+	line := [][]pdfRun{}
+	width := 0.0
+
+	emit := func() {
+		self.ensure(line_height)
+		self.y -= line_height
+		// Consecutive words in the same font are drawn together.
+		segments := []pdfRun{}
+		for i, word := range line {
+			for j, part := range word {
+				text := part.Text
+				if i > 0 && j == 0 {
+					text = " " + text
+				}
+				last := len(segments) - 1
+				if last >= 0 && segments[last].Font == part.Font {
+					segments[last].Text += text
+					continue
+				}
+				segments = append(segments, pdfRun{Text: text, Font: part.Font})
+			}
+		}
+
+		x := pdfMargin
+		for _, segment := range segments {
+			self.drawText(segment.Font, size, x, self.y+size*0.3, segment.Text)
+			x += textWidth(segment.Font, size, segment.Text)
+		}
+		line = nil
+		width = 0
+	}
+
+	for _, word := range words {
+		word_width := 0.0
+		for _, part := range word {
+			word_width += textWidth(part.Font, size, part.Text)
+		}
+
+		if len(line) > 0 && width+space+word_width > pdfTextWidth {
+			emit()
+		}
+
+		// Words longer than a line are truncated.
+		if word_width > pdfTextWidth && len(word) == 1 {
+			word = []pdfRun{{
+				Text: fitText(word[0].Font, size, pdfTextWidth, word[0].Text),
+				Font: word[0].Font,
+			}}
+		}
+
+		if len(line) > 0 {
+			width += space
+		}
+		line = append(line, word)
+		width += word_width
+	}
+	emit()
+	self.space(size * 0.5)
+}
+
+func (self *pdfDocument) heading(text string, level int) {
+	sizes := []float64{18, 15, 13}
+	size := 11.0
+	if level >= 1 && level <= len(sizes) {
+		size = sizes[level-1]
+	}
+
+	self.ensure(size * 4)
+	self.space(size * 0.5)
+	self.text([]pdfRun{{Text: text, Font: fontBold}}, size)
+}
+
+// Preformatted text keeps its lines and is wrapped at the page width.
+func (self *pdfDocument) pre(text string) {
+	line_height := pdfPreSize * 1.25
+	width := pdfTextWidth - 8.0
+	max_chars := int(width / textWidth(fontMono, pdfPreSize, " "))
+
+	lines := []string{}
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		runes := []rune(strings.ReplaceAll(line, "\t", "    "))
+		for len(runes) > max_chars {
+			lines = append(lines, string(runes[:max_chars]))
+			runes = runes[max_chars:]
+		}
+		lines = append(lines, string(runes))
+	}
+
+	for _, line := range lines {
+		self.ensure(line_height)
+		self.y -= line_height
+		self.fillRect("#f5f5f5", pdfMargin, self.y, pdfTextWidth, line_height)
+		self.drawText(fontMono, pdfPreSize, pdfMargin+4, self.y+2.5, line)
+	}
+	self.space(pdfTextSize * 0.5)
+}
+
+func (self *pdfDocument) rule() {
+	self.ensure(10)
+	self.y -= 5
+	fmt.Fprintf(self.page, "0.8 G %.2f %.2f m %.2f %.2f l S 0 G\n",
+		pdfMargin, self.y, pdfMargin+pdfTextWidth, self.y)
+	self.y -= 5
+}
+
+func (self *pdfDocument) table(t *table) {
+	if len(t.Columns) == 0 {
+		return
+	}
+
+	// Columns are as wide as their content up to a limit, and are
+	// scaled down if they do not fit.
+	widths := make([]float64, len(t.Columns))
+	total := 0.0
+	for i, column := range t.Columns {
+		widths[i] = textWidth(fontBold, pdfTableSize, column)
+		for j, row := range t.Rows {
+			if j > 100 {
+				break
+			}
+			if i < len(row) {
+				w := textWidth(fontRegular, pdfTableSize, row[i])
+				if w > widths[i] {
+					widths[i] = w
+				}
+			}
+		}
+		if widths[i] > 200 {
+			widths[i] = 200
+		}
+		widths[i] += 4
+		total += widths[i]
+	}
+	if total > pdfTextWidth {
+		for i := range widths {
+			widths[i] = widths[i] * pdfTextWidth / total
+		}
+		total = pdfTextWidth
+	}
+
+	row_height := pdfTableSize * 1.5
+	draw_row := func(cells []string, font int, background string) {
+		self.y -= row_height
+		if background != "" {
+			self.fillRect(background, pdfMargin, self.y, total, row_height)
+		}
+		x := pdfMargin
+		for i, w := range widths {
+			if i < len(cells) {
+				cell := strings.Join(strings.Fields(cells[i]), " ")
+				self.drawText(font, pdfTableSize, x+2, self.y+3,
+					fitText(font, pdfTableSize, w-4, cell))
+			}
+			x += w
+		}
+	}
+
+	// The header is repeated on each page.
+	self.ensure(row_height * 3)
+	draw_row(t.Columns, fontBold, "#dddddd")
+	for i, row := range t.Rows {
+		if self.y-row_height < pdfMargin {
+			self.newPage()
+			draw_row(t.Columns, fontBold, "#dddddd")
+		}
+
+		background := ""
+		if i%2 == 1 {
+			background = "#f5f5f5"
+		}
+		draw_row(row, fontRegular, background)
+	}
+	self.space(pdfTextSize * 0.5)
+
+	if t.Truncated > 0 {
+		self.text([]pdfRun{{
+			Text: fmt.Sprintf("%d more rows not shown.", t.Truncated),
+		}}, pdfTextSize)
+	}
+}
+
+// Draw the chart shapes, flipping them into PDF coordinates.
+func (self *pdfDocument) drawing(d *drawing) {
+	scale := 1.0
+	if d.Width > pdfTextWidth {
+		scale = pdfTextWidth / d.Width
+	}
+
+	self.ensure(d.Height*scale + 10)
+	top := self.y
+	self.y -= d.Height*scale + 10
+
+	px := func(x float64) float64 { return pdfMargin + x*scale }
+	py := func(y float64) float64 { return top - y*scale }
+
+	for _, s := range d.Shapes {
+		r, g, b := pdfColor(s.Color)
+		switch s.Kind {
+		case "line":
+			if len(s.Points) == 0 {
+				continue
+			}
+			fmt.Fprintf(self.page, "%.3f %.3f %.3f RG 0.75 w", r, g, b)
+			for i, p := range s.Points {
+				op := "l"
+				if i == 0 {
+					op = "m"
+				}
+				fmt.Fprintf(self.page, " %.2f %.2f %s", px(p.X), py(p.Y), op)
+			}
+			fmt.Fprintf(self.page, " S 0 G\n")
+
+		case "rect":
+			self.fillRect(s.Color, px(s.X), py(s.Y+s.H), s.W*scale, s.H*scale)
+
+		case "circle":
+			// Circles are approximated by 4 bezier curves.
+			x, y, radius := px(s.X), py(s.Y), s.W*scale
+			k := radius * 0.5523
+			fmt.Fprintf(self.page, "%.3f %.3f %.3f rg %.2f %.2f m "+
+				"%.2f %.2f %.2f %.2f %.2f %.2f c "+
+				"%.2f %.2f %.2f %.2f %.2f %.2f c "+
+				"%.2f %.2f %.2f %.2f %.2f %.2f c "+
+				"%.2f %.2f %.2f %.2f %.2f %.2f c f 0 g\n", r, g, b,
+				x+radius, y,
+				x+radius, y+k, x+k, y+radius, x, y+radius,
+				x-k, y+radius, x-radius, y+k, x-radius, y,
+				x-radius, y-k, x-k, y-radius, x, y-radius,
+				x+k, y-radius, x+radius, y-k, x+radius, y)
+
+		case "text":
+			size := s.Size * scale
+			x := px(s.X)
+			switch s.Anchor {
+			case "middle":
+				x -= textWidth(fontRegular, size, s.Text) / 2
+			case "end":
+				x -= textWidth(fontRegular, size, s.Text)
+			}
+			fmt.Fprintf(self.page, "%.3f %.3f %.3f rg ", r, g, b)
+			self.drawText(fontRegular, size, x, py(s.Y), s.Text)
+			fmt.Fprintf(self.page, "0 g\n")
+		}
+	}
+}
+
+func (self *pdfDocument) image(data []byte) error {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	// Transparent pixels are drawn over white.
+	bounds := img.Bounds()
+	pixels := &bytes.Buffer{}
+	compressor := zlib.NewWriter(pixels)
+	row := make([]byte, 0, bounds.Dx()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row = row[:0]
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			row = append(row,
+				byte((r+0xffff-a)>>8),
+				byte((g+0xffff-a)>>8),
+				byte((b+0xffff-a)>>8))
+		}
+		_, err = compressor.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+	err = compressor.Close()
+	if err != nil {
+		return err
+	}
+
+	self.images = append(self.images, &pdfImage{
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+		Data:   pixels.Bytes(),
+	})
+
+	// Images are shown at 96 DPI, scaled down to fit the page.
+	width := float64(bounds.Dx()) * 0.75
+	height := float64(bounds.Dy()) * 0.75
+	max_height := pdfPageHeight - 2*pdfMargin
+	if width > pdfTextWidth {
+		height = height * pdfTextWidth / width
+		width = pdfTextWidth
+	}
+	if height > max_height {
+		width = width * max_height / height
+		height = max_height
+	}
+
+	self.ensure(height + 5)
+	self.y -= height
+	fmt.Fprintf(self.page, "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n",
+		width, height, pdfMargin, self.y, len(self.images))
+	self.space(5)
+
+	return nil
+}
+
+// Serialize the document.
+func (self *pdfDocument) Write(output io.Writer) error {
+	buf := &bytes.Buffer{}
+	offsets := []int{}
+
+	// Objects are numbered from 1 in the order they are written.
+	object := func(format string, args ...interface{}) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%d 0 obj\n", len(offsets))
+		fmt.Fprintf(buf, format, args...)
+		buf.WriteString("\nendobj\n")
+	}
+	stream := func(dict string, data []byte) {
+		object("<< %s /Length %d >>\nstream\n%s\nendstream",
+			dict, len(data), data)
+	}
+
+	// The catalog, page tree, info and fonts come first, followed
+	// by the images and then a page and content stream per page.
+	first_image := 4 + len(pdf_font_names)
+	first_page := first_image + len(self.images)
+	kids := []string{}
+	for i := range self.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", first_page+2*i))
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object("<< /Type /Pages /Kids [%s] /Count %d >>",
+		strings.Join(kids, " "), len(self.pages))
+	object("<< /Title %s /Producer (Velociraptor) /CreationDate (D:%s) >>",
+		pdfString(self.Title),
+		utils.GetTime().Now().UTC().Format("20060102150405Z"))
+
+	resources := &strings.Builder{}
+	resources.WriteString("<< /Font <<")
+	for i, name := range pdf_font_names {
+		object("<< /Type /Font /Subtype /Type1 /BaseFont /%s "+
+			"/Encoding /WinAnsiEncoding >>", name)
+		fmt.Fprintf(resources, " /F%d %d 0 R", i+1, 4+i)
+	}
+	resources.WriteString(" >>")
+
+	if len(self.images) > 0 {
+		resources.WriteString(" /XObject <<")
+		for i, img := range self.images {
+			stream(fmt.Sprintf("/Type /XObject /Subtype /Image "+
+				"/Width %d /Height %d /ColorSpace /DeviceRGB "+
+				"/BitsPerComponent 8 /Filter /FlateDecode",
+				img.Width, img.Height), img.Data)
+			fmt.Fprintf(resources, " /Im%d %d 0 R", i+1, first_image+i)
+		}
+		resources.WriteString(" >>")
+	}
+	resources.WriteString(" >>")
+
+	for i, page := range self.pages {
+		object("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources %s /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, resources.String(),
+			first_page+2*i+1)
+
+		// Number the pages in the footer.
+		content := &bytes.Buffer{}
+		content.Write(page.Bytes())
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(self.pages))
+		fmt.Fprintf(content, "0.5 g BT /F1 8 Tf %.2f %.2f Td %s Tj ET 0 g\n",
+			pdfPageWidth-pdfMargin-textWidth(fontRegular, 8, footer),
+			pdfMargin/2, pdfString(footer))
+		stream("", content.Bytes())
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\n"+
+		"startxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := output.Write(buf.Bytes())
+	return err
+}
+
+// Render the document as a PDF file.
+func RenderPDF(ctx context.Context,
+	config_obj *config_proto.Config,
+	doc *Document, output io.Writer) error {
+	resolver := &resolver{ctx: ctx, config_obj: config_obj}
+
+	pdf := newPDFDocument(doc.Title)
+	pdf.heading(doc.Title, 1)
+
+	for _, section := range doc.Sections {
+		root, err := resolver.parse(section)
+		if err != nil {
+			return err
+		}
+
+		if section.Title != "" {
+			pdf.heading(section.Title, 2)
+		}
+		pdf.walk(resolver, root, section, fontRegular)
+		pdf.flush()
+		pdf.space(pdfTextSize)
+	}
+
+	return pdf.Write(output)
+}
+
+// Lay out the HTML tree.
+func (self *pdfDocument) walk(resolver *resolver,
+	node *nethtml.Node, section *DocumentSection, font int) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == nethtml.TextNode {
+			self.addRun(child.Data, font)
+			continue
+		}
+
+		if child.Type != nethtml.ElementNode {
+			continue
+		}
+
+		switch child.Data {
+		case "script", "style", "head", "title":
+
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			self.flush()
+			self.heading(textContent(child), int(child.Data[1]-'0'))
+
+		case "pre":
+			self.flush()
+			self.pre(textContent(child))
+
+		case "br":
+			self.flush()
+
+		case "hr":
+			self.flush()
+			self.rule()
+
+		case "b", "strong":
+			self.walk(resolver, child, section, fontBold)
+
+		case "code", "tt", "kbd":
+			self.walk(resolver, child, section, fontMono)
+
+		case "li":
+			self.flush()
+			self.addRun("• ", font)
+			self.walk(resolver, child, section, font)
+			self.flush()
+
+		case "table":
+			self.flush()
+			self.table(htmlTable(child))
+
+		case "img":
+			self.flush()
+			data, err := resolver.image(getAttr(child, "src"))
+			if err == nil {
+				err = self.image(data)
+			}
+			if err != nil {
+				self.errorText(err)
+			}
+
+		case "grr-csv-viewer", "inline-table-viewer":
+			self.flush()
+			t, err := resolver.table(child, section)
+			if err != nil {
+				self.errorText(err)
+				continue
+			}
+			self.table(t)
+
+		case "grr-timeline":
+			self.flush()
+			self.errorText(fmt.Errorf("Timelines can not be rendered"))
+
+		case "p", "div", "ul", "ol", "blockquote", "section", "dl", "dt", "dd":
+			self.flush()
+			self.walk(resolver, child, section, font)
+			self.flush()
+
+		default:
+			if isDirective(child.Data) {
+				self.flush()
+				c, err := resolver.chart(child, section)
+				if err != nil {
+					self.errorText(err)
+					continue
+				}
+				self.drawing(c.Draw())
+				continue
+			}
+
+			// Other elements are inline.
+			self.walk(resolver, child, section, font)
+		}
+	}
+}
+
+func (self *pdfDocument) errorText(err error) {
+	self.text([]pdfRun{{Text: "Error: " + err.Error()}}, pdfTextSize)
+}
+
+func textContent(node *nethtml.Node) string {
+	if node.Type == nethtml.TextNode {
+		return node.Data
+	}
+
+	result := &strings.Builder{}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		result.WriteString(textContent(child))
+	}
+	return result.String()
+}
+
+// Extract the cells of an HTML table (e.g. from markdown).
+func htmlTable(node *nethtml.Node) *table {
+	result := &table{}
+
+	var walk func(node *nethtml.Node)
+	walk = func(node *nethtml.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != nethtml.ElementNode {
+				continue
+			}
+			if child.Data != "tr" {
+				walk(child)
+				continue
+			}
+
+			row := []string{}
+			header := false
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == nethtml.ElementNode &&
+					(cell.Data == "td" || cell.Data == "th") {
+					row = append(row, strings.TrimSpace(textContent(cell)))
+					header = header || cell.Data == "th"
+				}
+			}
+
+			if result.Columns == nil && (header || len(result.Rows) == 0) {
+				result.Columns = row
+				continue
+			}
+			result.Rows = append(result.Rows, row)
+		}
+	}
+	walk(node)
+
+	return result
+}
@@ -0,0 +1,553 @@
+package reporting
+
+// Render notebooks, cases and artifact reports into standalone HTML
+// or PDF documents on the server.
+//
+// The GUI renders tables and charts with javascript which fetches
+// the data from the server. Rendered documents must stand alone so
+// the tables, charts and images are resolved here: tables are
+// expanded from the result sets, charts are drawn (as SVG in HTML
+// documents) and images are inlined.
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	// Tables are truncated in rendered documents.
+	MAX_RENDER_TABLE_ROWS = 1000
+	MAX_RENDER_CHART_ROWS = 10000
+)
+
+var (
+	dataKeyRegex    = regexp.MustCompile(`data\['([^']+)'\]`)
+	attachmentRegex = regexp.MustCompile(
+		`^/notebooks/(N\.[^/]+)/(NA\.[^.]+\.png)$`)
+)
+
+// A document is made of sections of HTML produced by the GUI
+// template engine - e.g. notebook cells or artifact reports.
+type Document struct {
+	Title    string
+	Sections []*DocumentSection
+}
+
+type DocumentSection struct {
+	// An optional heading for the section.
+	Title string
+	HTML  string
+
+	// Inline data referenced by the tables and charts of artifact
+	// reports (see GuiTemplateEngine.Data)
+	Data map[string]*actions_proto.VQLResponse
+}
+
+type table struct {
+	Columns []string
+	Rows    [][]string
+
+	// Number of rows not included.
+	Truncated int
+}
+
+// Loads the data referenced by the GUI directives.
+type resolver struct {
+	ctx        context.Context
+	config_obj *config_proto.Config
+}
+
+// Parse the section's HTML into a tree under a div.
+func (self *resolver) parse(section *DocumentSection) (*nethtml.Node, error) {
+	root := &nethtml.Node{
+		Type:     nethtml.ElementNode,
+		Data:     "div",
+		DataAtom: atom.Div,
+	}
+
+	nodes, err := nethtml.ParseFragment(strings.NewReader(section.HTML), root)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range nodes {
+		root.AppendChild(node)
+	}
+
+	fixSelfClosing(root)
+	return root, nil
+}
+
+// The GUI directives are written as self closing tags which HTML
+// does not support for unknown elements, so the parser makes the
+// following content their children. Move it back out.
+func fixSelfClosing(node *nethtml.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == nethtml.ElementNode && isDirective(child.Data) {
+			for child.LastChild != nil {
+				grand_child := child.LastChild
+				child.RemoveChild(grand_child)
+				node.InsertBefore(grand_child, child.NextSibling)
+			}
+			continue
+		}
+		fixSelfClosing(child)
+	}
+}
+
+func isDirective(name string) bool {
+	switch name {
+	case "grr-csv-viewer", "inline-table-viewer", "grr-timeline":
+		return true
+	}
+	_, pres := chart_types[name]
+	return pres
+}
+
+func getAttr(node *nethtml.Node, name string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// Rows referenced by a directive - either through the params of a
+// notebook table or the inline data of a report.
+func (self *resolver) rows(node *nethtml.Node,
+	section *DocumentSection, limit int) ([]string, []*ordereddict.Dict, int, error) {
+
+	params := getAttr(node, "params")
+	if node.Data == "grr-csv-viewer" ||
+		strings.HasPrefix(node.Data, "notebook-") {
+		return self.tableRows(params, limit)
+	}
+
+	// Reports refer to their data by key.
+	key := getAttr(node, "value")
+	m := dataKeyRegex.FindStringSubmatch(key)
+	if len(m) > 1 {
+		key = m[1]
+	}
+	key, _ = url.QueryUnescape(key)
+
+	data, pres := section.Data[key]
+	if !pres {
+		return nil, nil, 0, fmt.Errorf("Unknown data %v", key)
+	}
+
+	rows, err := utils.ParseJsonToDicts([]byte(data.Response))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	columns := data.Columns
+	if len(columns) == 0 && len(rows) > 0 {
+		columns = rows[0].Keys()
+	}
+
+	truncated := 0
+	if len(rows) > limit {
+		truncated = len(rows) - limit
+		rows = rows[:limit]
+	}
+
+	return columns, rows, truncated, nil
+}
+
+func (self *resolver) tableRows(params string, limit int) (
+	[]string, []*ordereddict.Dict, int, error) {
+	unescaped, err := url.QueryUnescape(params)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	request := &api_proto.GetTableRequest{}
+	err = json.Unmarshal([]byte(unescaped), request)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	path_manager := paths.NewNotebookPathManager(request.NotebookId).Cell(
+		request.CellId).QueryStorage(request.TableId)
+	reader, err := result_sets.NewResultSetReader(
+		file_store.GetFileStore(self.config_obj), path_manager.Path())
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer reader.Close()
+
+	var columns []string
+	rows := []*ordereddict.Dict{}
+	for row := range reader.Rows(self.ctx) {
+		if columns == nil {
+			columns = row.Keys()
+		}
+		rows = append(rows, row)
+		if len(rows) >= limit {
+			break
+		}
+	}
+
+	truncated := int(reader.TotalRows()) - len(rows)
+	if truncated < 0 {
+		truncated = 0
+	}
+
+	return columns, rows, truncated, nil
+}
+
+func (self *resolver) table(node *nethtml.Node,
+	section *DocumentSection) (*table, error) {
+	columns, rows, truncated, err := self.rows(
+		node, section, MAX_RENDER_TABLE_ROWS)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &table{Columns: columns, Truncated: truncated}
+	for _, row := range rows {
+		cells := make([]string, 0, len(columns))
+		for _, column := range columns {
+			value, _ := row.Get(column)
+			cells = append(cells, formatCell(value))
+		}
+		result.Rows = append(result.Rows, cells)
+	}
+
+	return result, nil
+}
+
+func formatCell(value interface{}) string {
+	switch t := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case time.Time:
+		return t.UTC().Format(time.RFC3339)
+	case int, int64, uint64, float64, bool:
+		return fmt.Sprintf("%v", t)
+	}
+	return json.MustMarshalString(value)
+}
+
+func (self *resolver) chart(node *nethtml.Node,
+	section *DocumentSection) (*chart, error) {
+	columns, rows, _, err := self.rows(node, section, MAX_RENDER_CHART_ROWS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chart{
+		Type:    chart_types[node.Data],
+		Columns: columns,
+		Rows:    rows,
+	}, nil
+}
+
+// Load an image attached to a notebook.
+func (self *resolver) image(src string) ([]byte, error) {
+	if strings.HasPrefix(src, "data:image/png;base64,") {
+		return base64.StdEncoding.DecodeString(
+			strings.TrimPrefix(src, "data:image/png;base64,"))
+	}
+
+	m := attachmentRegex.FindStringSubmatch(src)
+	if len(m) < 3 {
+		return nil, errors.New("Only notebook attachments are supported")
+	}
+
+	item_path := paths.NewNotebookPathManager(m[1]).Cell("").Item(m[2])
+	fd, err := file_store.GetFileStore(self.config_obj).ReadFile(item_path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	return io.ReadAll(fd)
+}
+
+// Render the document as a standalone HTML page.
+func RenderHTML(ctx context.Context,
+	config_obj *config_proto.Config,
+	doc *Document, output io.Writer) error {
+	resolver := &resolver{ctx: ctx, config_obj: config_obj}
+
+	_, err := fmt.Fprintf(output, HtmlPreable, html.EscapeString(doc.Title))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(output, "<h1>%s</h1>\n", html.EscapeString(doc.Title))
+	if err != nil {
+		return err
+	}
+
+	for _, section := range doc.Sections {
+		root, err := resolver.parse(section)
+		if err != nil {
+			return err
+		}
+		resolver.resolveHTML(root, section)
+
+		buf := &bytes.Buffer{}
+		buf.WriteString("<div class=\"notebook-cell\">\n")
+		if section.Title != "" {
+			fmt.Fprintf(buf, "<h2>%s</h2>\n", html.EscapeString(section.Title))
+		}
+		for child := root.FirstChild; child != nil; child = child.NextSibling {
+			err = nethtml.Render(buf, child)
+			if err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</div>\n")
+
+		_, err = output.Write(buf.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = output.Write([]byte(HtmlPostscript))
+	return err
+}
+
+// Replace the GUI directives with plain HTML.
+func (self *resolver) resolveHTML(node *nethtml.Node, section *DocumentSection) {
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		if child.Type != nethtml.ElementNode {
+			child = next
+			continue
+		}
+
+		replacement := ""
+		switch {
+		case child.Data == "grr-csv-viewer" || child.Data == "inline-table-viewer":
+			t, err := self.table(child, section)
+			if err != nil {
+				replacement = renderError(err)
+			} else {
+				replacement = t.HTML()
+			}
+
+		case child.Data == "grr-timeline":
+			replacement = renderError(
+				errors.New("Timelines can not be rendered"))
+
+		case isDirective(child.Data):
+			c, err := self.chart(child, section)
+			if err != nil {
+				replacement = renderError(err)
+			} else {
+				replacement = c.Draw().SVG()
+			}
+
+		case child.Data == "img":
+			src := getAttr(child, "src")
+			data, err := self.image(src)
+			if err == nil {
+				setAttr(child, "src", "data:image/png;base64,"+
+					base64.StdEncoding.EncodeToString(data))
+			}
+
+		default:
+			self.resolveHTML(child, section)
+		}
+
+		if replacement != "" {
+			nodes, err := nethtml.ParseFragment(
+				strings.NewReader(replacement), node)
+			if err == nil {
+				for _, n := range nodes {
+					node.InsertBefore(n, child)
+				}
+				node.RemoveChild(child)
+			}
+		}
+
+		child = next
+	}
+}
+
+func setAttr(node *nethtml.Node, name, value string) {
+	for i := range node.Attr {
+		if node.Attr[i].Key == name {
+			node.Attr[i].Val = value
+			return
+		}
+	}
+	node.Attr = append(node.Attr, nethtml.Attribute{Key: name, Val: value})
+}
+
+func renderError(err error) string {
+	return fmt.Sprintf("<div class=\"error\">%s</div>",
+		html.EscapeString(err.Error()))
+}
+
+func (self *table) HTML() string {
+	result := &strings.Builder{}
+	result.WriteString("<table class=\"table table-striped\">\n<thead>\n<tr>\n")
+	for _, column := range self.Columns {
+		fmt.Fprintf(result, "<th>%s</th>\n", html.EscapeString(column))
+	}
+	result.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range self.Rows {
+		result.WriteString("<tr>\n")
+		for _, cell := range row {
+			fmt.Fprintf(result, "<td>%s</td>\n", html.EscapeString(cell))
+		}
+		result.WriteString("</tr>\n")
+	}
+	result.WriteString("</tbody>\n</table>\n")
+
+	if self.Truncated > 0 {
+		fmt.Fprintf(result, "<p>%d more rows not shown.</p>\n", self.Truncated)
+	}
+	return result.String()
+}
+
+// Render the notebook into a PDF file.
+func ExportNotebookToPDF(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	notebook_id string, output io.Writer) error {
+	doc, err := NotebookDocument(config_obj, notebook_id)
+	if err != nil {
+		return err
+	}
+
+	return RenderPDF(ctx, config_obj, doc, output)
+}
+
+// A document with the notebook's cells.
+func NotebookDocument(
+	config_obj *config_proto.Config, notebook_id string) (*Document, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	notebook_path_manager := paths.NewNotebookPathManager(notebook_id)
+	notebook := &api_proto.NotebookMetadata{}
+	err = db.GetSubject(config_obj, notebook_path_manager.Path(), notebook)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Document{Title: notebook.Name}
+	for _, cell_md := range notebook.CellMetadata {
+		cell := &api_proto.NotebookCell{}
+		err = db.GetSubject(config_obj,
+			notebook_path_manager.Cell(cell_md.CellId).Path(), cell)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Sections = append(result.Sections, &DocumentSection{
+			HTML: cell.Output,
+		})
+	}
+
+	return result, nil
+}
+
+// A case summary followed by the case's notebooks.
+func CaseDocument(
+	config_obj *config_proto.Config,
+	case_obj *api_proto.Case, notebook_ids []string) (*Document, error) {
+	summary := &strings.Builder{}
+	if case_obj.Description != "" {
+		fmt.Fprintf(summary, "<p>%s</p>\n", html.EscapeString(case_obj.Description))
+	}
+
+	flows := []string{}
+	for _, flow := range case_obj.Flows {
+		flows = append(flows, flow.ClientId+"/"+flow.FlowId)
+	}
+
+	fields := [][]string{
+		{"Case", case_obj.CaseId},
+		{"Status", case_obj.Status},
+		{"Creator", case_obj.Creator},
+		{"Assignees", strings.Join(case_obj.Assignees, ", ")},
+		{"Tags", strings.Join(case_obj.Tags, ", ")},
+		{"Created", formatTimestamp(case_obj.CreateTime)},
+		{"Modified", formatTimestamp(case_obj.ModifiedTime)},
+		{"Clients", strings.Join(case_obj.Clients, ", ")},
+		{"Flows", strings.Join(flows, ", ")},
+		{"Hunts", strings.Join(case_obj.Hunts, ", ")},
+	}
+	summary.WriteString((&table{
+		Columns: []string{"Field", "Value"},
+		Rows:    fields,
+	}).HTML())
+
+	result := &Document{
+		Title: case_obj.Title,
+		Sections: []*DocumentSection{{
+			Title: "Summary",
+			HTML:  summary.String(),
+		}},
+	}
+
+	if len(case_obj.Notes) > 0 {
+		notes := &table{Columns: []string{"Time", "User", "Note"}}
+		for _, note := range case_obj.Notes {
+			notes.Rows = append(notes.Rows, []string{
+				formatTimestamp(note.Timestamp), note.User, note.Note})
+		}
+		result.Sections = append(result.Sections, &DocumentSection{
+			Title: "Notes",
+			HTML:  notes.HTML(),
+		})
+	}
+
+	for _, notebook_id := range notebook_ids {
+		notebook, err := NotebookDocument(config_obj, notebook_id)
+		if err != nil {
+			return nil, fmt.Errorf("Notebook %v: %w", notebook_id, err)
+		}
+
+		for i, section := range notebook.Sections {
+			if i == 0 {
+				section.Title = notebook.Title
+			}
+			result.Sections = append(result.Sections, section)
+		}
+	}
+
+	return result, nil
+}
+
+func formatTimestamp(ts int64) string {
+	if ts == 0 {
+		return ""
+	}
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,168 @@
+package reporting_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/reporting"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+type RenderTestSuite struct {
+	test_utils.TestSuite
+}
+
+// Add a notebook with a single cell showing a table and a chart of
+// the same rows.
+func (self *RenderTestSuite) addNotebook(notebook_id string) {
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	path_manager := paths.NewNotebookPathManager(notebook_id)
+	writer, err := result_sets.NewResultSetWriter(
+		file_store.GetFileStore(self.ConfigObj),
+		path_manager.Cell("NC.1").QueryStorage(0).Path(),
+		json.DefaultEncOpts(), utils.SyncCompleter, result_sets.TruncateMode)
+	assert.NoError(self.T(), err)
+	for i := 0; i < 5; i++ {
+		writer.Write(ordereddict.NewDict().
+			Set("Name", fmt.Sprintf("Host%d", i)).
+			Set("Count", i*10))
+	}
+	writer.Close()
+
+	params := url.QueryEscape(json.MustMarshalString(
+		&api_proto.GetTableRequest{
+			NotebookId: notebook_id,
+			CellId:     "NC.1",
+			TableId:    0,
+		}))
+
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj,
+		path_manager.Cell("NC.1").Path(), &api_proto.NotebookCell{
+			CellId: "NC.1",
+			Output: fmt.Sprintf(`<h2>Counts (by host)</h2>
+<grr-csv-viewer base-url="'v1/GetTable'" params='%s' />
+<p>Hits per host</p>
+<notebook-bar-chart base-url="'v1/GetTable'" params='%s' />
+`, params, params),
+		}))
+
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj,
+		path_manager.Path(), &api_proto.NotebookMetadata{
+			NotebookId: notebook_id,
+			Name:       "Weekly Report",
+			CellMetadata: []*api_proto.NotebookCell{{
+				CellId: "NC.1",
+			}},
+		}))
+}
+
+func (self *RenderTestSuite) TestRenderHTML() {
+	self.addNotebook("N.1")
+
+	doc, err := reporting.NotebookDocument(self.ConfigObj, "N.1")
+	assert.NoError(self.T(), err)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(self.T(), reporting.RenderHTML(
+		self.Ctx, self.ConfigObj, doc, buf))
+	out := buf.String()
+
+	// The table is expanded and the chart is drawn.
+	assert.Contains(self.T(), out, "<title>Weekly Report</title>")
+	assert.Contains(self.T(), out, "<th>Name</th>")
+	assert.Contains(self.T(), out, "<td>Host4</td>")
+	assert.Contains(self.T(), out, "<td>40</td>")
+	assert.Contains(self.T(), out, `<svg xmlns="http://www.w3.org/2000/svg"`)
+	assert.NotContains(self.T(), out, "grr-csv-viewer")
+	assert.NotContains(self.T(), out, "notebook-bar-chart")
+
+	// The content following the directives is kept.
+	assert.Contains(self.T(), out, "<p>Hits per host</p>")
+
+	// One bar per row, one legend entry and the plot area.
+	assert.Equal(self.T(), 6,
+		len(regexp.MustCompile(`<rect `).FindAllString(out, -1)))
+}
+
+func (self *RenderTestSuite) TestRenderReport() {
+	doc := &reporting.Document{
+		Title: "Server.Monitor.Health",
+		Sections: []*reporting.DocumentSection{{
+			HTML: `<inline-table-viewer value="table1" />` +
+				`<grr-line-chart value="data['table1']" params='{}' />`,
+			Data: map[string]*actions_proto.VQLResponse{
+				"table1": {
+					Columns:  []string{"Time", "CPU"},
+					Response: `[{"Time":1,"CPU":5},{"Time":2,"CPU":7}]`,
+				},
+			},
+		}},
+	}
+
+	buf := &bytes.Buffer{}
+	assert.NoError(self.T(), reporting.RenderHTML(
+		self.Ctx, self.ConfigObj, doc, buf))
+	out := buf.String()
+
+	assert.Contains(self.T(), out, "<th>CPU</th>")
+	assert.Contains(self.T(), out, "<td>7</td>")
+	assert.Contains(self.T(), out, "<polyline")
+}
+
+func (self *RenderTestSuite) TestRenderPDF() {
+	self.addNotebook("N.2")
+
+	doc, err := reporting.NotebookDocument(self.ConfigObj, "N.2")
+	assert.NoError(self.T(), err)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(self.T(), reporting.RenderPDF(
+		self.Ctx, self.ConfigObj, doc, buf))
+	out := buf.Bytes()
+
+	assert.True(self.T(), bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(self.T(), bytes.HasSuffix(out, []byte("%%EOF\n")))
+
+	// Text is escaped and the table cells are drawn.
+	assert.Contains(self.T(), string(out), `(Counts \(by host\)) Tj`)
+	assert.Contains(self.T(), string(out), "(Host4) Tj")
+	assert.Contains(self.T(), string(out), "(Hits per host) Tj")
+
+	// The xref table points at each object.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	assert.Equal(self.T(), 2, len(m))
+	xref, err := strconv.Atoi(string(m[1]))
+	assert.NoError(self.T(), err)
+	assert.True(self.T(), bytes.HasPrefix(out[xref:], []byte("xref\n")))
+
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(
+		out[xref:], -1)
+	assert.True(self.T(), len(offsets) > 5)
+	for i, offset := range offsets {
+		start, err := strconv.Atoi(string(offset[1]))
+		assert.NoError(self.T(), err)
+		assert.True(self.T(), bytes.HasPrefix(out[start:],
+			[]byte(fmt.Sprintf("%d 0 obj\n", i+1))))
+	}
+}
+
+func TestRender(t *testing.T) {
+	suite.Run(t, &RenderTestSuite{})
+}
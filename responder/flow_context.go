@@ -56,6 +56,13 @@ type FlowContext struct {
 	// The name of the artifact
 	artifact string
 
+	// Copied from the request to all the responses so the server
+	// can link them to the trace of the collection.
+	trace_parent string
+
+	// When the flow started running on the client.
+	start_time time.Time
+
 	// We ensure to only send the final flow complete message
 	// once. This will trigger a System.Flow.Completion event on the
 	// server.
@@ -110,6 +117,8 @@ func newFlowContext(ctx context.Context,
 		config_obj:     config_obj,
 		flow_id:        flow_id,
 		owner:          owner,
+		start_time:     utils.GetTime().Now(),
+		trace_parent:   req.TraceParent,
 	}
 
 	go func() {
@@ -214,8 +223,9 @@ func (self *FlowContext) flushLogMessages(ctx context.Context) {
 	buf, id, count, error_message := self.GetLogMessages()
 	if len(buf) > 0 {
 		self.output <- &crypto_proto.VeloMessage{
-			SessionId: self.flow_id,
-			RequestId: constants.LOG_SINK,
+			SessionId:   self.flow_id,
+			RequestId:   constants.LOG_SINK,
+			TraceParent: self.trace_parent,
 			LogMessage: &crypto_proto.LogMessage{
 				Id:           int64(id),
 				NumberOfRows: count,
@@ -263,6 +273,40 @@ func (self *FlowContext) NewResponder(
 	return self.ctx, responder
 }
 
+// A short summary of a running flow reported in the client's
+// telemetry.
+type FlowSummary struct {
+	FlowId        string
+	Artifacts     []string
+	Duration      float64 // Seconds since the flow started.
+	ResultRows    int64
+	LogRows       int64
+	UploadedBytes int64
+}
+
+func (self *FlowContext) Summary() *FlowSummary {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := &FlowSummary{
+		FlowId: self.flow_id,
+		Duration: utils.GetTime().Now().Sub(
+			self.start_time).Round(time.Second).Seconds(),
+	}
+
+	for _, r := range self.responders {
+		status := r.GetStatus()
+		for _, name := range status.NamesWithResponse {
+			addNameWithResponse(&result.Artifacts, name)
+		}
+		result.ResultRows += status.ResultRows
+		result.LogRows += status.LogRows
+		result.UploadedBytes += status.UploadedBytes
+	}
+
+	return result
+}
+
 // Returns some stats to send to the server. The stats are sent in a
 // rate limited way - not too frequently.
 func (self *FlowContext) MaybeSendStats() *crypto_proto.VeloMessage {
@@ -300,9 +344,10 @@ func (self *FlowContext) SendStats() {
 // Prepare a FlowStat message to send the server.
 func (self *FlowContext) getStats() *crypto_proto.VeloMessage {
 	result := &crypto_proto.VeloMessage{
-		SessionId: self.flow_id,
-		RequestId: constants.STATS_SINK,
-		FlowStats: &crypto_proto.FlowStats{},
+		SessionId:   self.flow_id,
+		RequestId:   constants.STATS_SINK,
+		FlowStats:   &crypto_proto.FlowStats{},
+		TraceParent: self.trace_parent,
 	}
 
 	// Fill in all the responder's stats.
@@ -21,6 +21,7 @@ package responder
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
@@ -130,6 +131,27 @@ func (self *FlowManager) FlowContext(
 	return flow_context
 }
 
+// Summarize all the flows currently running on the client.
+func (self *FlowManager) Summaries() []*FlowSummary {
+	self.mu.Lock()
+	flows := make([]*FlowContext, 0, len(self.in_flight))
+	for _, flow_context := range self.in_flight {
+		flows = append(flows, flow_context)
+	}
+	self.mu.Unlock()
+
+	result := make([]*FlowSummary, 0, len(flows))
+	for _, flow_context := range flows {
+		result = append(result, flow_context.Summary())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FlowId < result[j].FlowId
+	})
+
+	return result
+}
+
 func GetFlowManager(
 	ctx context.Context, config_obj *config_proto.Config) *FlowManager {
 	mu.Lock()
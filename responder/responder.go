@@ -141,6 +141,7 @@ func (self *FlowResponder) AddResponse(message *crypto_proto.VeloMessage) {
 	self.mu.Unlock()
 
 	message.SessionId = self.flow_context.SessionId()
+	message.TraceParent = self.flow_context.trace_parent
 
 	select {
 	case <-self.ctx.Done():
@@ -52,6 +52,13 @@ type ResultSetReader interface {
 	TotalRows() int64
 }
 
+// Result set readers that can skip deserializing the columns the
+// caller does not need.
+type ColumnProjector interface {
+	// Only emit these columns in this order.
+	SetColumns(columns []string)
+}
+
 type TimedResultSetReader interface {
 	SeekToTime(start time.Time) error
 	SetMaxTime(end time.Time)
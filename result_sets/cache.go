@@ -0,0 +1,132 @@
+package result_sets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/Velocidex/ttlcache/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/json"
+)
+
+var (
+	resultCacheHits = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "result_cache_hits",
+			Help: "Number of results served from the result cache.",
+		},
+		[]string{"cache"},
+	)
+
+	resultCacheMisses = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "result_cache_misses",
+			Help: "Number of results that had to be computed again.",
+		},
+		[]string{"cache"},
+	)
+)
+
+// A ResultCache holds values computed from result sets (e.g. GUI
+// table pages or notebook cells) so they do not need to be computed
+// again while the underlying result sets are unchanged.
+//
+// Callers build the key from the query, its parameters and the
+// version of the result sets it reads (see MakeCacheKey and
+// ResultSetVersion), so any change to these results in a miss.
+type ResultCache struct {
+	name string
+
+	// nil if the cache is disabled.
+	lru *ttlcache.Cache
+}
+
+func NewResultCache(
+	config_obj *config_proto.Config, name string) *ResultCache {
+	result := &ResultCache{name: name}
+
+	timeout := int64(600)
+	size := uint64(1000)
+	if config_obj.Defaults != nil {
+		if config_obj.Defaults.ResultCacheTimeoutSec != 0 {
+			timeout = config_obj.Defaults.ResultCacheTimeoutSec
+		}
+		if config_obj.Defaults.ResultCacheSize > 0 {
+			size = config_obj.Defaults.ResultCacheSize
+		}
+	}
+
+	if timeout < 0 {
+		return result
+	}
+
+	result.lru = ttlcache.NewCache()
+	result.lru.SetCacheSizeLimit(int(size))
+	_ = result.lru.SetTTL(time.Duration(timeout) * time.Second)
+
+	return result
+}
+
+func (self *ResultCache) Get(key string) (interface{}, bool) {
+	if self.lru == nil || key == "" {
+		return nil, false
+	}
+
+	value, err := self.lru.Get(key)
+	if err != nil {
+		resultCacheMisses.WithLabelValues(self.name).Inc()
+		return nil, false
+	}
+
+	resultCacheHits.WithLabelValues(self.name).Inc()
+	return value, true
+}
+
+func (self *ResultCache) Set(key string, value interface{}) {
+	if self.lru == nil || key == "" {
+		return
+	}
+	_ = self.lru.Set(key, value)
+}
+
+func (self *ResultCache) Remove(key string) {
+	if self.lru == nil {
+		return
+	}
+	_ = self.lru.Remove(key)
+}
+
+// Build a cache key from the query text, its parameters and the
+// version of the result sets it reads. An empty version means the
+// result is not cacheable so we return an empty key.
+func MakeCacheKey(query string, params interface{}, version string) string {
+	if version == "" {
+		return ""
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(query))
+	hash.Write([]byte{0})
+	hash.Write([]byte(json.MustMarshalString(params)))
+	hash.Write([]byte{0})
+	hash.Write([]byte(version))
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// The version of a result set changes whenever it is written
+// to. Returns an empty string if the result set does not exist.
+func ResultSetVersion(
+	file_store_factory api.FileStore, path api.FSPathSpec) string {
+	stat, err := file_store_factory.StatFile(path)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d:%d", stat.Size(), stat.ModTime().UnixNano())
+}
@@ -0,0 +1,60 @@
+package result_sets
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+// Restrict the rows produced by the reader to the columns. Readers
+// that implement ColumnProjector avoid deserializing the other
+// columns, otherwise the columns are dropped after the row is read.
+func ProjectColumns(
+	reader ResultSetReader, columns []string) ResultSetReader {
+	if len(columns) == 0 {
+		return reader
+	}
+
+	projector, ok := reader.(ColumnProjector)
+	if ok {
+		projector.SetColumns(columns)
+		return reader
+	}
+
+	return &projectedReader{ResultSetReader: reader, columns: columns}
+}
+
+type projectedReader struct {
+	ResultSetReader
+	columns []string
+}
+
+func (self *projectedReader) Rows(ctx context.Context) <-chan *ordereddict.Dict {
+	output := make(chan *ordereddict.Dict)
+
+	go func() {
+		defer close(output)
+
+		for row := range self.ResultSetReader.Rows(ctx) {
+			select {
+			case <-ctx.Done():
+				return
+			case output <- ProjectRow(row, self.columns):
+			}
+		}
+	}()
+
+	return output
+}
+
+// Return a new row with only the columns present in the row.
+func ProjectRow(row *ordereddict.Dict, columns []string) *ordereddict.Dict {
+	result := ordereddict.NewDict()
+	for _, column := range columns {
+		value, pres := row.Get(column)
+		if pres {
+			result.Set(column, value)
+		}
+	}
+	return result
+}
@@ -25,6 +25,14 @@ type ResultSetOptions struct {
 	FilterRegex  *regexp.Regexp
 	StartIdx     uint64
 	EndIdx       uint64
+
+	// If specified, only these columns are read from each row.
+	Columns []string
+
+	// Typed columns are sorted by their value rather than their
+	// serialized form (e.g. timestamps and integers stored as
+	// strings).
+	Schema *Schema
 }
 
 type TimedFactory interface {
@@ -0,0 +1,253 @@
+package result_sets
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/araddon/dateparse"
+	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// Column types which are enforced when rows are written. Other
+// column types in the artifact's column_types are only rendering
+// hints for the GUI.
+const (
+	COLUMN_TIMESTAMP   = "timestamp"
+	COLUMN_INTEGER     = "integer"
+	COLUMN_CLIENT_PATH = "client_path"
+	COLUMN_HASH        = "hash"
+)
+
+func IsTypedColumn(column_type string) bool {
+	switch column_type {
+	case COLUMN_TIMESTAMP, COLUMN_INTEGER, COLUMN_CLIENT_PATH, COLUMN_HASH:
+		return true
+	}
+	return false
+}
+
+// A Schema normalizes the typed columns of a result set so readers
+// (sorting, timelines, exports) do not need to guess the type of
+// each column from its values:
+//
+//   - timestamp columns are UTC times. Epoch seconds, milliseconds,
+//     microseconds or nanoseconds and time strings are converted.
+//   - integer columns are 64 bit integers, parsed from strings if
+//     needed.
+//   - client_path columns are strings.
+//   - hash columns are lower case hex strings.
+//
+// Values which can not be converted are left as they are so no data
+// is lost.
+type Schema struct {
+	columns []*artifacts_proto.ColumnType
+	types   map[string]string
+}
+
+// Returns nil if there are no typed columns. A nil Schema leaves rows
+// unchanged.
+func NewSchema(column_types []*artifacts_proto.ColumnType) *Schema {
+	result := &Schema{
+		types: make(map[string]string),
+	}
+
+	for _, column := range column_types {
+		if IsTypedColumn(column.Type) {
+			result.columns = append(result.columns, column)
+			result.types[column.Name] = column.Type
+		}
+	}
+
+	if len(result.columns) == 0 {
+		return nil
+	}
+	return result
+}
+
+func (self *Schema) ColumnType(name string) string {
+	if self == nil {
+		return ""
+	}
+	return self.types[name]
+}
+
+// The names of the columns with this type, in the order they were
+// declared.
+func (self *Schema) ColumnsOfType(column_type string) []string {
+	if self == nil {
+		return nil
+	}
+
+	var result []string
+	for _, column := range self.columns {
+		if column.Type == column_type {
+			result = append(result, column.Name)
+		}
+	}
+	return result
+}
+
+// Convert the typed columns in the row in place.
+func (self *Schema) Normalize(row *ordereddict.Dict) *ordereddict.Dict {
+	if self == nil || row == nil {
+		return row
+	}
+
+	for _, column := range self.columns {
+		value, pres := row.Get(column.Name)
+		if !pres || utils.IsNil(value) {
+			continue
+		}
+
+		normalized, ok := NormalizeValue(column.Type, value)
+		if ok {
+			row.Update(column.Name, normalized)
+		}
+	}
+	return row
+}
+
+// Normalize already serialized JSONL rows (e.g. as sent by the
+// client).
+func (self *Schema) NormalizeJSONL(
+	serialized []byte, opts *json.EncOpts) ([]byte, error) {
+	if self == nil {
+		return serialized, nil
+	}
+
+	rows, err := utils.ParseJsonToDicts(serialized)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &bytes.Buffer{}
+	for _, row := range rows {
+		out, err := json.MarshalWithOptions(self.Normalize(row), opts)
+		if err != nil {
+			return nil, err
+		}
+		result.Write(out)
+		result.WriteByte('\n')
+	}
+	return result.Bytes(), nil
+}
+
+// Convert a single value to the column type. Returns false if the
+// value can not be converted.
+func NormalizeValue(column_type string, value interface{}) (interface{}, bool) {
+	switch column_type {
+	case COLUMN_TIMESTAMP:
+		ts, ok := ToTimestamp(value)
+		return ts, ok
+
+	case COLUMN_INTEGER:
+		return ToInteger(value)
+
+	case COLUMN_CLIENT_PATH:
+		switch t := value.(type) {
+		case string:
+			return t, true
+		case []byte:
+			return string(t), true
+		case interface{ String() string }:
+			return t.String(), true
+		}
+		return nil, false
+
+	case COLUMN_HASH:
+		switch t := value.(type) {
+		case []byte:
+			return hex.EncodeToString(t), true
+		case string:
+			hash := strings.ToLower(strings.TrimSpace(t))
+			_, err := hex.DecodeString(hash)
+			return hash, err == nil
+		}
+		return nil, false
+	}
+
+	return nil, false
+}
+
+func ToTimestamp(value interface{}) (time.Time, bool) {
+	switch t := value.(type) {
+	case time.Time:
+		return t.UTC(), !t.IsZero()
+
+	case *time.Time:
+		if t == nil {
+			return time.Time{}, false
+		}
+		return ToTimestamp(*t)
+
+	case float64:
+		sec, dec := math.Modf(t)
+		if sec > 20000000000 {
+			return ToTimestamp(int64(t))
+		}
+		return time.Unix(int64(sec), int64(dec*1e9)).UTC(), t != 0
+
+	case string:
+		t = strings.TrimSpace(t)
+		if t == "" {
+			return time.Time{}, false
+		}
+
+		// It might really be an epoch encoded as a string.
+		epoch, err := strconv.ParseInt(t, 10, 64)
+		if err == nil {
+			return ToTimestamp(epoch)
+		}
+
+		ts, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			ts, err = dateparse.ParseAny(t)
+			if err != nil {
+				return time.Time{}, false
+			}
+		}
+		return ts.UTC(), true
+	}
+
+	epoch, ok := utils.ToInt64(value)
+	if !ok || epoch == 0 {
+		return time.Time{}, false
+	}
+	return utils.ParseTimeFromInt64(epoch).UTC(), true
+}
+
+func ToInteger(value interface{}) (int64, bool) {
+	switch t := value.(type) {
+	case bool:
+		return 0, false
+
+	case float64:
+		if t != math.Trunc(t) {
+			return 0, false
+		}
+		return int64(t), true
+
+	case string:
+		t = strings.TrimSpace(t)
+		result, err := strconv.ParseInt(t, 0, 64)
+		if err == nil {
+			return result, true
+		}
+
+		// Sizes are sometimes reported as floats.
+		float_value, err := strconv.ParseFloat(t, 64)
+		if err != nil || float_value != math.Trunc(float_value) {
+			return 0, false
+		}
+		return int64(float_value), true
+	}
+
+	return utils.ToInt64(value)
+}
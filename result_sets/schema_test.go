@@ -0,0 +1,88 @@
+package result_sets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/stretchr/testify/assert"
+	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+)
+
+func TestSchema(t *testing.T) {
+	// Only enforced types make a schema.
+	assert.Nil(t, NewSchema([]*artifacts_proto.ColumnType{
+		{Name: "Data", Type: "tree"},
+	}))
+
+	schema := NewSchema([]*artifacts_proto.ColumnType{
+		{Name: "Created", Type: COLUMN_TIMESTAMP},
+		{Name: "Modified", Type: COLUMN_TIMESTAMP},
+		{Name: "Size", Type: COLUMN_INTEGER},
+		{Name: "OSPath", Type: COLUMN_CLIENT_PATH},
+		{Name: "Hash", Type: COLUMN_HASH},
+		{Name: "Data", Type: "tree"},
+	})
+	assert.Equal(t, []string{"Created", "Modified"},
+		schema.ColumnsOfType(COLUMN_TIMESTAMP))
+	assert.Equal(t, "", schema.ColumnType("Data"))
+
+	row := schema.Normalize(ordereddict.NewDict().
+		Set("Created", int64(1600000000000)).
+		Set("Modified", "2020-09-13 12:26:40").
+		Set("Size", "0x10").
+		Set("OSPath", []byte(`C:\Windows`)).
+		Set("Hash", " ABCDEF01 ").
+		Set("Data", "12"))
+
+	expected := time.Unix(1600000000, 0).UTC()
+	created, _ := row.Get("Created")
+	assert.Equal(t, expected, created)
+
+	modified, _ := row.Get("Modified")
+	assert.Equal(t, expected, modified)
+
+	size, _ := row.Get("Size")
+	assert.Equal(t, int64(16), size)
+
+	os_path, _ := row.Get("OSPath")
+	assert.Equal(t, `C:\Windows`, os_path)
+
+	hash, _ := row.Get("Hash")
+	assert.Equal(t, "abcdef01", hash)
+
+	// Untyped columns are not touched.
+	data, _ := row.Get("Data")
+	assert.Equal(t, "12", data)
+
+	// Values which can not be converted are kept.
+	row = schema.Normalize(ordereddict.NewDict().
+		Set("Size", "large").
+		Set("Hash", "not a hash"))
+	size, _ = row.Get("Size")
+	assert.Equal(t, "large", size)
+
+	hash, _ = row.Get("Hash")
+	assert.Equal(t, "not a hash", hash)
+
+	// A nil schema does nothing.
+	var empty *Schema
+	assert.Equal(t, row, empty.Normalize(row))
+}
+
+func TestSchemaJSONL(t *testing.T) {
+	schema := NewSchema([]*artifacts_proto.ColumnType{
+		{Name: "Time", Type: COLUMN_TIMESTAMP},
+		{Name: "Size", Type: COLUMN_INTEGER},
+	})
+
+	normalized, err := schema.NormalizeJSONL([]byte(
+		`{"Time":1600000000,"Size":"5"}
+{"Time":"2020-09-13T22:26:40+10:00","Size":6}
+`), json.DefaultEncOpts())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Time":"2020-09-13T12:26:40Z","Size":5}
+{"Time":"2020-09-13T12:26:40Z","Size":6}
+`, string(normalized))
+}
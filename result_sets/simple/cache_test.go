@@ -0,0 +1,56 @@
+package simple_test
+
+import (
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+func (self *ResultSetTestSuite) TestResultCache() {
+	path_manager := paths.NewFlowPathManager("C.12314", self.flow_id).Log()
+	rs, err := result_sets.NewResultSetWriter(self.file_store, path_manager,
+		nil, utils.SyncCompleter, result_sets.TruncateMode)
+	assert.NoError(self.T(), err)
+	rs.Write(ordereddict.NewDict().Set("Foo", 1))
+	rs.Close()
+
+	cache := result_sets.NewResultCache(self.ConfigObj, "Test")
+
+	// A missing result set is not cacheable.
+	missing := paths.NewFlowPathManager("C.12315", self.flow_id).Log()
+	assert.Equal(self.T(), "", result_sets.MakeCacheKey("SELECT * FROM X",
+		nil, result_sets.ResultSetVersion(self.file_store, missing)))
+
+	key := result_sets.MakeCacheKey("SELECT * FROM X", "params",
+		result_sets.ResultSetVersion(self.file_store, path_manager))
+	cache.Set(key, "cached")
+
+	value, pres := cache.Get(key)
+	assert.True(self.T(), pres)
+	assert.Equal(self.T(), "cached", value)
+
+	// Different parameters use a different key.
+	_, pres = cache.Get(result_sets.MakeCacheKey("SELECT * FROM X", "other",
+		result_sets.ResultSetVersion(self.file_store, path_manager)))
+	assert.False(self.T(), pres)
+
+	// Writing to the result set changes the version.
+	rs, err = result_sets.NewResultSetWriter(self.file_store, path_manager,
+		nil, utils.SyncCompleter, result_sets.AppendMode)
+	assert.NoError(self.T(), err)
+	rs.Write(ordereddict.NewDict().Set("Foo", 2))
+	rs.Close()
+
+	_, pres = cache.Get(result_sets.MakeCacheKey("SELECT * FROM X", "params",
+		result_sets.ResultSetVersion(self.file_store, path_manager)))
+	assert.False(self.T(), pres)
+
+	// A negative timeout disables the cache.
+	self.ConfigObj.Defaults.ResultCacheTimeoutSec = -1
+	disabled := result_sets.NewResultCache(self.ConfigObj, "Disabled")
+	disabled.Set(key, "cached")
+	_, pres = disabled.Get(key)
+	assert.False(self.T(), pres)
+}
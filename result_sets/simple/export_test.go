@@ -0,0 +1,8 @@
+package simple
+
+import "www.velocidex.com/golang/velociraptor/result_sets"
+
+func IsMapped(reader result_sets.ResultSetReader) bool {
+	impl, ok := reader.(*ResultSetReaderImpl)
+	return ok && impl.data != nil
+}
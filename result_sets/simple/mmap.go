@@ -0,0 +1,179 @@
+package simple
+
+// Large result sets stored on a local filesystem are mapped into
+// memory instead of being scanned through a buffered reader. The row
+// index is used to seek directly to the start of a row without
+// reading the file up to it.
+
+// Only result sets without a writer or a WAL are mapped. A writer
+// which opens later may still truncate the file under the mapping
+// (e.g. when a notebook cell is recalculated) so all reads of the
+// mapping recover from the fault and copy the rows out of it.
+
+// Callers may also restrict the columns they need (e.g. the columns
+// visible in a GUI table). Only those columns are then deserialized
+// from each row.
+
+import (
+	"bytes"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/Velocidex/json"
+	"github.com/Velocidex/ordereddict"
+	"github.com/valyala/fastjson"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+)
+
+// Smaller result sets are read normally.
+const mmapThreshold = 1024 * 1024
+
+// Try to map the data file into memory.
+func mmapResultSet(fd api.FileReader) []byte {
+	mapper, ok := fd.(api.MmapReader)
+	if !ok {
+		return nil
+	}
+
+	stat, err := fd.Stat()
+	if err != nil || stat.Size() < mmapThreshold {
+		return nil
+	}
+
+	data, err := mapper.Mmap()
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// Accessing the mapping past the end of a truncated file raises
+// SIGBUS. Turn the fault into a panic and report it as an error.
+func readMapped(cb func()) (err error) {
+	defer debug.SetPanicOnFault(debug.SetPanicOnFault(true))
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("Result set changed while reading: %v", r)
+		}
+	}()
+
+	cb()
+	return nil
+}
+
+// Copy the next line out of the mapping. Returns nil at the end of
+// the data or if the file was truncated.
+func copyLine(data []byte, offset int) ([]byte, int) {
+	var line []byte
+	next := offset
+
+	err := readMapped(func() {
+		line, next = nextLine(data, offset)
+		line = append([]byte(nil), line...)
+	})
+	if err != nil {
+		return nil, len(data)
+	}
+	return line, next
+}
+
+// Returns the next line in data starting at offset and the offset
+// of the following line.
+func nextLine(data []byte, offset int) ([]byte, int) {
+	if offset >= len(data) {
+		return nil, offset
+	}
+
+	end := bytes.IndexByte(data[offset:], '\n')
+	if end < 0 {
+		return data[offset:], len(data)
+	}
+	end += offset + 1
+	return data[offset:end], end
+}
+
+// Seek the mapped data to the row. The index entry holds the offset
+// of the blob and the number of rows to skip within it.
+func (self *ResultSetReaderImpl) seekMapped(start int64) error {
+	offset := int64(0)
+	row_count := start
+
+	if self.idx_fd != nil && self.total_rows > 0 {
+		value, err := self.readIndexEntry(start)
+		if err != nil {
+			return err
+		}
+		offset = value & offset_mask
+		row_count = value >> 40
+	}
+
+	if offset > int64(len(self.data)) {
+		offset = int64(len(self.data))
+	}
+
+	next := int(offset)
+	err := readMapped(func() {
+		for i := int64(0); i < row_count; i++ {
+			var line []byte
+			line, next = nextLine(self.data, next)
+			if line == nil {
+				break
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	self.data_offset = next
+	return nil
+}
+
+// Deserialize a row, keeping only the projected columns if
+// specified.
+func decodeRow(
+	parser *fastjson.Parser, row_data []byte,
+	columns []string) (*ordereddict.Dict, error) {
+	item := ordereddict.NewDict()
+	if len(columns) == 0 {
+		err := item.UnmarshalJSON(row_data)
+		return item, err
+	}
+
+	// Only parse the structure of the row here. The values of the
+	// required columns are then fully deserialized.
+	value, err := parser.ParseBytes(row_data)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := value.Object()
+	if err != nil {
+		return nil, err
+	}
+
+	projected := make([]byte, 0, len(row_data))
+	projected = append(projected, '{')
+	for _, column := range columns {
+		column_value := obj.Get(column)
+		if column_value == nil {
+			continue
+		}
+
+		if len(projected) > 1 {
+			projected = append(projected, ',')
+		}
+		key, err := json.Marshal(column)
+		if err != nil {
+			return nil, err
+		}
+		projected = append(projected, key...)
+		projected = append(projected, ':')
+		projected = column_value.MarshalTo(projected)
+	}
+	projected = append(projected, '}')
+
+	err = item.UnmarshalJSON(projected)
+	return item, err
+}
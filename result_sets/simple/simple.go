@@ -34,9 +34,13 @@ import (
 	"io"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/Velocidex/json"
 	"github.com/Velocidex/ordereddict"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/valyala/fastjson"
 	"www.velocidex.com/golang/velociraptor/file_store/api"
 	vjson "www.velocidex.com/golang/velociraptor/json"
 	"www.velocidex.com/golang/velociraptor/result_sets"
@@ -47,6 +51,31 @@ const (
 	offset_mask = 1<<40 - 1
 )
 
+var (
+	resultSetWriteLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "result_set_write_latency",
+			Help:    "Latency to write a batch of rows to a result set.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 4, 8),
+		})
+
+	resultSetRowsWritten = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "result_set_rows_written",
+		Help: "Total number of rows written to result sets.",
+	})
+
+	resultSetBytesWritten = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "result_set_bytes_written",
+		Help: "Total number of bytes written to result sets.",
+	})
+)
+
+func observeResultSetWrite(start time.Time, rows uint64, size int) {
+	resultSetWriteLatency.Observe(time.Since(start).Seconds())
+	resultSetRowsWritten.Add(float64(rows))
+	resultSetBytesWritten.Add(float64(size))
+}
+
 type ResultSetWriterImpl struct {
 	mu       sync.Mutex
 	rows     [][]byte
@@ -54,6 +83,19 @@ type ResultSetWriterImpl struct {
 	fd       api.FileWriter
 	index_fd api.FileWriter
 
+	file_store_factory api.FileStore
+	log_path           api.FSPathSpec
+
+	// Called once the files are on storage to clear the WAL (see
+	// wal.go).
+	done func()
+
+	// The completion waits for the files to be flushed on Close().
+	sync_completion bool
+
+	// Once a batch fails the writer stops appending to the files.
+	err error
+
 	sync bool
 }
 
@@ -101,8 +143,15 @@ func (self *ResultSetWriterImpl) WriteJSONL(serialized []byte, total_rows uint64
 		}
 	}
 
-	_, _ = self.fd.Write(serialized)
-	_, _ = self.index_fd.Write(offsets.Bytes())
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	start := time.Now()
+	err = self.commit(offset, serialized, offsets.Bytes())
+	if err != nil {
+		return
+	}
+	observeResultSetWrite(start, total_rows, len(serialized))
 }
 
 func (self *ResultSetWriterImpl) Write(row *ordereddict.Dict) {
@@ -118,7 +167,7 @@ func (self *ResultSetWriterImpl) Write(row *ordereddict.Dict) {
 
 	self.rows = append(self.rows, serialized)
 	if len(self.rows) > 10000 {
-		self._Flush()
+		_ = self._Flush()
 	}
 }
 
@@ -129,15 +178,21 @@ func (self *ResultSetWriterImpl) Flush() {
 	defer self.mu.Unlock()
 
 	if len(self.rows) > 0 {
-		self._Flush()
+		_ = self._Flush()
 	}
 }
 
-func (self *ResultSetWriterImpl) _Flush() {
+func (self *ResultSetWriterImpl) _Flush() error {
+	// Reset the slice but keep the capacity.
+	defer func() {
+		self.rows = self.rows[:0]
+	}()
+
 	offset, err := self.fd.Size()
 	if err != nil {
-		return
+		return err
 	}
+	data_size := offset
 
 	out := &bytes.Buffer{}
 	offsets := new(bytes.Buffer)
@@ -148,28 +203,78 @@ func (self *ResultSetWriterImpl) _Flush() {
 		out.Write([]byte{'\n'})
 		err = binary.Write(offsets, binary.LittleEndian, offset)
 		if err != nil {
-			return
+			return err
 		}
 
 		// Include the line feed in the count.
 		offset += int64(len(row) + 1)
 	}
 
-	_, _ = self.fd.Write(out.Bytes())
-	_, _ = self.index_fd.Write(offsets.Bytes())
+	start := time.Now()
+	err = self.commit(data_size, out.Bytes(), offsets.Bytes())
+	if err != nil {
+		return err
+	}
+	observeResultSetWrite(start, uint64(len(self.rows)), out.Len())
+
+	return nil
+}
 
-	// Reset the slice but keep the capacity.
-	self.rows = self.rows[:0]
+// Append a batch of rows and their index entries. The sizes before
+// the batch are logged in the WAL first so a batch which is only
+// partially written to storage can be rolled back. The error is kept
+// in self.err and fails all further batches, so callers which can
+// not return it may ignore it.
+func (self *ResultSetWriterImpl) commit(
+	data_size int64, data, index []byte) error {
+	if self.err != nil {
+		return self.err
+	}
+
+	index_size, err := self.index_fd.Size()
+	if err == nil {
+		err = logBatch(self.file_store_factory, self.log_path,
+			walRecord{DataSize: data_size, IndexSize: index_size})
+	}
+	if err != nil {
+		self.err = err
+		return err
+	}
+
+	_, err = self.fd.Write(data)
+	if err == nil {
+		_, err = self.index_fd.Write(index)
+	}
+
+	if err != nil {
+		self.err = err
+		failBatch(self.log_path)
+		return err
+	}
+
+	return nil
 }
 
 func (self *ResultSetWriterImpl) Close() {
 	self.Flush()
+
 	self.fd.Close()
 	self.index_fd.Close()
 
 	if self.sync {
-		self.fd.Flush()
-		self.index_fd.Flush()
+		err := self.fd.Flush()
+		if err == nil {
+			err = self.index_fd.Flush()
+		}
+		if err != nil {
+			failBatch(self.log_path)
+		}
+	}
+
+	// Otherwise the files are on storage when the file store calls
+	// the completion.
+	if self.sync || self.sync_completion {
+		self.done()
 	}
 }
 
@@ -182,41 +287,74 @@ func (self ResultSetFactory) NewResultSetWriter(
 	completion func(),
 	truncate result_sets.WriteMode) (result_sets.ResultSetWriter, error) {
 
-	result := &ResultSetWriterImpl{opts: opts}
+	result := &ResultSetWriterImpl{
+		opts:               opts,
+		file_store_factory: file_store_factory,
+		log_path:           log_path,
+	}
 
 	// If no path is provided, we are just a log sink
 	if utils.IsNil(log_path) {
 		return &NullResultSetWriter{}, nil
 	}
 
+	// Roll back any batch interrupted by a crash before we append
+	// to the result set.
+	err := openWriter(file_store_factory, log_path)
+	if err != nil {
+		return nil, err
+	}
+
+	var once sync.Once
+	result.done = func() {
+		once.Do(func() {
+			closeWriter(file_store_factory, log_path)
+		})
+	}
+
+	// The WAL is cleared once both files are written by the file
+	// store.
+	data_completion := completion
+	index_completion := completion
+	if utils.CompareFuncs(completion, utils.SyncCompleter) {
+		result.sync_completion = true
+	} else {
+		completer := utils.NewCompleter(func() {
+			result.done()
+			if completion != nil {
+				completion()
+			}
+		})
+		data_completion = completer.GetCompletionFunc()
+		index_completion = completer.GetCompletionFunc()
+	}
+
 	fd, err := file_store_factory.WriteFileWithCompletion(
-		log_path, completion)
+		log_path, data_completion)
 	if err != nil {
+		result.done()
 		return nil, err
 	}
 
-	idx_fd, err := file_store_factory.WriteFile(log_path.
-		SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX))
+	idx_fd, err := file_store_factory.WriteFileWithCompletion(log_path.
+		SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX), index_completion)
 	if err != nil {
 		fd.Close()
+		result.done()
 		return nil, err
 	}
 
 	if truncate {
 		err = fd.Truncate()
-		if err != nil {
-			fd.Close()
-			idx_fd.Close()
-			return nil, err
+		if err == nil {
+			err = idx_fd.Truncate()
 		}
-
-		err = idx_fd.Truncate()
 		if err != nil {
 			fd.Close()
 			idx_fd.Close()
+			result.done()
 			return nil, err
 		}
-
 	}
 
 	result.fd = fd
@@ -231,6 +369,18 @@ type ResultSetReaderImpl struct {
 	fd         api.FileReader
 	idx_fd     api.FileReader
 	log_path   api.FSPathSpec
+
+	// Large result sets are mapped into memory. data_offset is the
+	// row SeekToRow() found - each reader keeps its own offset.
+	data        []byte
+	data_offset int
+
+	// Only deserialize these columns.
+	columns []string
+}
+
+func (self *ResultSetReaderImpl) SetColumns(columns []string) {
+	self.columns = columns
 }
 
 func (self *ResultSetReaderImpl) TotalRows() int64 {
@@ -240,6 +390,10 @@ func (self *ResultSetReaderImpl) TotalRows() int64 {
 // Seeks the fd to the starting location. If successful then fd is
 // ready to be read from row at a time.
 func (self *ResultSetReaderImpl) SeekToRow(start int64) error {
+	if self.data != nil {
+		return self.seekMapped(start)
+	}
+
 	// Nothing to do.
 	if start == 0 {
 		return nil
@@ -258,13 +412,7 @@ func (self *ResultSetReaderImpl) SeekToRow(start int64) error {
 	}
 
 	// Get the index entry for this row
-	_, err := self.idx_fd.Seek(8*start, io.SeekStart)
-	if err != nil {
-		return err
-	}
-
-	value := int64(0)
-	err = binary.Read(self.idx_fd, binary.LittleEndian, &value)
+	value, err := self.readIndexEntry(start)
 	if err != nil {
 		return err
 	}
@@ -300,6 +448,17 @@ func (self *ResultSetReaderImpl) SeekToRow(start int64) error {
 	return err
 }
 
+func (self *ResultSetReaderImpl) readIndexEntry(row int64) (int64, error) {
+	_, err := self.idx_fd.Seek(8*row, io.SeekStart)
+	if err != nil {
+		return 0, err
+	}
+
+	value := int64(0)
+	err = binary.Read(self.idx_fd, binary.LittleEndian, &value)
+	return value, err
+}
+
 // Start generating rows from the result set.
 func (self *ResultSetReaderImpl) Rows(ctx context.Context) <-chan *ordereddict.Dict {
 	output := make(chan *ordereddict.Dict)
@@ -307,24 +466,20 @@ func (self *ResultSetReaderImpl) Rows(ctx context.Context) <-chan *ordereddict.D
 	go func() {
 		defer close(output)
 
-		reader := bufio.NewReader(self.fd)
+		var parser fastjson.Parser
+		next_row := self.nextRowFunc()
 		for {
-			row_data, err := reader.ReadBytes('\n')
-			if err != nil {
-				return
-			}
+			row_data := next_row()
 
 			// We have reached the end.
 			if len(row_data) == 0 {
 				return
 			}
 
-			item := ordereddict.NewDict()
-
 			// We failed to unmarshal one line of
 			// JSON - it may be corrupted, go to
 			// the next one.
-			err = item.UnmarshalJSON(row_data)
+			item, err := decodeRow(&parser, row_data, self.columns)
 			if err != nil {
 				continue
 			}
@@ -346,12 +501,9 @@ func (self *ResultSetReaderImpl) JSON(ctx context.Context) (<-chan []byte, error
 	go func() {
 		defer close(output)
 
-		reader := bufio.NewReader(self.fd)
+		next_row := self.nextRowFunc()
 		for {
-			row_data, err := reader.ReadBytes('\n')
-			if err != nil {
-				return
-			}
+			row_data := next_row()
 
 			// We have reached the end.
 			if len(row_data) == 0 {
@@ -369,6 +521,29 @@ func (self *ResultSetReaderImpl) JSON(ctx context.Context) (<-chan []byte, error
 	return output, nil
 }
 
+// Returns a function producing the serialized rows in turn, or nil at
+// the end of the result set. Rows are sliced from the mapped data
+// if possible.
+func (self *ResultSetReaderImpl) nextRowFunc() func() []byte {
+	if self.data != nil {
+		offset := self.data_offset
+		return func() []byte {
+			var line []byte
+			line, offset = copyLine(self.data, offset)
+			return line
+		}
+	}
+
+	reader := bufio.NewReader(self.fd)
+	return func() []byte {
+		row_data, err := reader.ReadBytes('\n')
+		if err != nil {
+			return nil
+		}
+		return row_data
+	}
+}
+
 // Only used in tests - not safe for general use.
 func GetAllResults(self result_sets.ResultSetReader) []*ordereddict.Dict {
 	result := []*ordereddict.Dict{}
@@ -435,12 +610,33 @@ func (self ResultSetFactory) NewResultSetReader(
 		}
 	}
 
-	return &ResultSetReaderImpl{
+	// Rows past the logged sizes are being written or were
+	// interrupted by a crash so only the committed rows are read.
+	record, pres := readWalRecord(file_store_factory, log_path)
+	if pres {
+		fd = &committedReader{FileReader: fd, size: record.DataSize}
+		if idx_fd != nil {
+			idx_fd = &committedReader{
+				FileReader: idx_fd, size: record.IndexSize}
+		}
+		if total_rows > record.IndexSize/8 {
+			total_rows = record.IndexSize / 8
+		}
+	}
+
+	result := &ResultSetReaderImpl{
 		total_rows: total_rows,
 		fd:         fd,
 		idx_fd:     idx_fd,
 		log_path:   log_path,
-	}, nil
+	}
+
+	// Only map result sets which are not changing.
+	if !pres && !isWriterOpen(log_path) {
+		result.data = mmapResultSet(fd)
+	}
+
+	return result, nil
 }
 
 func countLines(serialized []byte) uint64 {
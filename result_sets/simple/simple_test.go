@@ -1,9 +1,13 @@
 package simple_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"hash/crc32"
 	"io/ioutil"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -262,6 +266,189 @@ func (self *ResultSetTestSuite) TestResultSetWriterWriteJSONL() {
 	assert.Equal(self.T(), value, int64(3))
 }
 
+func (self *ResultSetTestSuite) TestResultSetWriterRecovery() {
+	self.client_id = "C.12314"
+
+	path_manager := paths.NewFlowPathManager(self.client_id, self.flow_id).Log()
+	rs, err := result_sets.NewResultSetWriter(self.file_store, path_manager,
+		nil, utils.SyncCompleter, result_sets.TruncateMode)
+	assert.NoError(self.T(), err)
+	for i := 1; i <= 3; i++ {
+		rs.Write(ordereddict.NewDict().Set("Foo", i))
+	}
+	rs.Close()
+
+	// Simulate a crash while a writer flushes the next batch: The
+	// log records the committed sizes but the files contain a
+	// partial row and index entry.
+	rs, err = result_sets.NewResultSetWriter(self.file_store, path_manager,
+		nil, utils.SyncCompleter, result_sets.AppendMode)
+	assert.NoError(self.T(), err)
+
+	self.simulateCrash(path_manager)
+
+	// Readers only see the committed rows while the writer is open.
+	rs_reader, err := result_sets.NewResultSetReader(self.file_store, path_manager)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), int64(3), rs_reader.TotalRows())
+	assert.Equal(self.T(), 3, len(simple.GetAllResults(rs_reader)))
+	rs_reader.Close()
+	rs.Close()
+
+	// Opening the result set for writing rolls back the partial
+	// batch. Sync writers clear the log once the files are flushed.
+	rs, err = result_sets.NewResultSetWriter(self.file_store, path_manager,
+		nil, utils.SyncCompleter, result_sets.AppendMode)
+	assert.NoError(self.T(), err)
+	rs.SetSync()
+	rs.Write(ordereddict.NewDict().Set("Foo", 5))
+	rs.Close()
+
+	_, err = self.file_store.StatFile(
+		path_manager.SetType(api.PATH_TYPE_FILESTORE_JSON_WAL))
+	assert.Error(self.T(), err)
+
+	rs_reader, err = result_sets.NewResultSetReader(self.file_store, path_manager)
+	assert.NoError(self.T(), err)
+	defer rs_reader.Close()
+
+	assert.Equal(self.T(), int64(4), rs_reader.TotalRows())
+
+	err = rs_reader.SeekToRow(3)
+	assert.NoError(self.T(), err)
+
+	rows := simple.GetAllResults(rs_reader)
+	assert.Equal(self.T(), 1, len(rows))
+	value, _ := rows[0].GetInt64("Foo")
+	assert.Equal(self.T(), int64(5), value)
+}
+
+// The server crashed while a batch was written so no writer is open
+// when the result set is read.
+func (self *ResultSetTestSuite) TestResultSetReaderAfterCrash() {
+	self.client_id = "C.12315"
+
+	path_manager := paths.NewFlowPathManager(self.client_id, self.flow_id).Log()
+	wal_path := path_manager.SetType(api.PATH_TYPE_FILESTORE_JSON_WAL)
+
+	var mu sync.Mutex
+	completed := false
+
+	rs, err := result_sets.NewResultSetWriter(self.file_store, path_manager,
+		nil, func() {
+			mu.Lock()
+			completed = true
+			mu.Unlock()
+		}, result_sets.TruncateMode)
+	assert.NoError(self.T(), err)
+	for i := 1; i <= 3; i++ {
+		rs.Write(ordereddict.NewDict().Set("Foo", i))
+	}
+	rs.Close()
+
+	// The log is cleared once the file store wrote the files.
+	vtesting.WaitUntil(time.Second, self.T(), func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return completed
+	})
+	_, err = self.file_store.StatFile(wal_path)
+	assert.Error(self.T(), err)
+
+	self.simulateCrash(path_manager)
+
+	rs_reader, err := result_sets.NewResultSetReader(self.file_store, path_manager)
+	assert.NoError(self.T(), err)
+	defer rs_reader.Close()
+
+	assert.Equal(self.T(), int64(3), rs_reader.TotalRows())
+
+	rows := simple.GetAllResults(rs_reader)
+	assert.Equal(self.T(), 3, len(rows))
+	value, _ := rows[2].GetInt64("Foo")
+	assert.Equal(self.T(), int64(3), value)
+}
+
+// Large result sets are mapped into memory when the file store is
+// on disk.
+func (self *ResultSetTestSuite) TestResultSetLargeReader() {
+	path_manager := paths.NewFlowPathManager(self.client_id, self.flow_id).Log()
+	rs, err := result_sets.NewResultSetWriter(self.file_store, path_manager,
+		nil, utils.SyncCompleter, result_sets.TruncateMode)
+	assert.NoError(self.T(), err)
+
+	padding := strings.Repeat("X", 100)
+	for i := 0; i < 20000; i++ {
+		rs.Write(ordereddict.NewDict().
+			Set("Row", i).
+			Set("Padding", padding).
+			Set("Dict", ordereddict.NewDict().Set("Foo", i)))
+	}
+	rs.Close()
+
+	rs_reader, err := result_sets.NewResultSetReader(self.file_store, path_manager)
+	assert.NoError(self.T(), err)
+	defer rs_reader.Close()
+
+	assert.Equal(self.T(), int64(20000), rs_reader.TotalRows())
+
+	// Seek directly to a row using the index.
+	err = rs_reader.SeekToRow(12345)
+	assert.NoError(self.T(), err)
+
+	rows := simple.GetAllResults(rs_reader)
+	assert.Equal(self.T(), 20000-12345, len(rows))
+	value, _ := rows[0].GetInt64("Row")
+	assert.Equal(self.T(), int64(12345), value)
+	assert.Equal(self.T(), []string{"Row", "Padding", "Dict"}, rows[0].Keys())
+
+	// Only read some columns in the requested order.
+	rs_reader = result_sets.ProjectColumns(rs_reader, []string{"Dict", "Row"})
+	err = rs_reader.SeekToRow(19999)
+	assert.NoError(self.T(), err)
+
+	rows = simple.GetAllResults(rs_reader)
+	assert.Equal(self.T(), 1, len(rows))
+	assert.Equal(self.T(), []string{"Dict", "Row"}, rows[0].Keys())
+	assert.Equal(self.T(), `{"Dict":{"Foo":19999},"Row":19999}`,
+		json.MustMarshalString(rows[0]))
+}
+
+// Leave the result set as a crash while flushing a batch would: The
+// log records the committed sizes but the files contain a partial row
+// and index entry.
+func (self *ResultSetTestSuite) simulateCrash(path_manager api.FSPathSpec) {
+	index_path := path_manager.SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX)
+	data_size := self.fileSize(path_manager)
+	index_size := self.fileSize(index_path)
+
+	record := new(bytes.Buffer)
+	binary.Write(record, binary.LittleEndian, uint32(0x4c415756))
+	binary.Write(record, binary.LittleEndian, data_size)
+	binary.Write(record, binary.LittleEndian, index_size)
+	binary.Write(record, binary.LittleEndian, crc32.ChecksumIEEE(record.Bytes()))
+
+	self.appendFile(path_manager.SetType(api.PATH_TYPE_FILESTORE_JSON_WAL),
+		record.Bytes())
+	self.appendFile(path_manager, []byte("{\"Foo\":4}\n{\"Fo"))
+	self.appendFile(index_path, make([]byte, 11))
+}
+
+func (self *ResultSetTestSuite) fileSize(path api.FSPathSpec) int64 {
+	stat, err := self.file_store.StatFile(path)
+	assert.NoError(self.T(), err)
+	return stat.Size()
+}
+
+func (self *ResultSetTestSuite) appendFile(path api.FSPathSpec, data []byte) {
+	fd, err := self.file_store.WriteFile(path)
+	assert.NoError(self.T(), err)
+	defer fd.Close()
+
+	_, err = fd.Write(data)
+	assert.NoError(self.T(), err)
+}
+
 func TestResultSets(t *testing.T) {
 	suite.Run(t, &ResultSetTestSuite{})
 }
@@ -293,6 +480,50 @@ func (self *ResultSetTestSuiteFileBased) TearDownTest() {
 	os.RemoveAll(self.dir)
 }
 
+// A writer truncates the result set under a reader which mapped it.
+func (self *ResultSetTestSuiteFileBased) TestTruncateMappedReader() {
+	path_manager := paths.NewFlowPathManager(self.client_id, self.flow_id).Log()
+	rs, err := result_sets.NewResultSetWriter(self.file_store, path_manager,
+		nil, utils.SyncCompleter, result_sets.TruncateMode)
+	assert.NoError(self.T(), err)
+
+	padding := strings.Repeat("X", 100)
+	for i := 0; i < 20000; i++ {
+		rs.Write(ordereddict.NewDict().Set("Row", i).Set("Padding", padding))
+	}
+	rs.Close()
+
+	rs_reader, err := result_sets.NewResultSetReader(self.file_store, path_manager)
+	assert.NoError(self.T(), err)
+	defer rs_reader.Close()
+
+	assert.True(self.T(), simple.IsMapped(rs_reader))
+
+	err = rs_reader.SeekToRow(10)
+	assert.NoError(self.T(), err)
+
+	// Result sets are not mapped while a writer is open.
+	rs, err = result_sets.NewResultSetWriter(self.file_store, path_manager,
+		nil, utils.SyncCompleter, result_sets.TruncateMode)
+	assert.NoError(self.T(), err)
+
+	open_reader, err := result_sets.NewResultSetReader(self.file_store, path_manager)
+	assert.NoError(self.T(), err)
+	assert.False(self.T(), simple.IsMapped(open_reader))
+	open_reader.Close()
+
+	rs.Write(ordereddict.NewDict().Set("Row", 0))
+	rs.Close()
+
+	// Reading past the end of the truncated file stops the reader
+	// instead of crashing the server.
+	rows := simple.GetAllResults(rs_reader)
+	assert.True(self.T(), len(rows) < 20000-10)
+
+	rows = simple.GetAllResults(rs_reader)
+	assert.True(self.T(), len(rows) < 20000-10)
+}
+
 func TestResultSetWriterFileBased(t *testing.T) {
 	suite.Run(t, &ResultSetTestSuiteFileBased{
 		ResultSetTestSuite: ResultSetTestSuite{},
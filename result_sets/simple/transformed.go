@@ -15,6 +15,11 @@ import (
 	"www.velocidex.com/golang/vfilter"
 )
 
+// Timestamp columns are sorted on this hidden column. The sorter
+// spills large tables to disk where times are serialized as strings
+// which do not sort correctly.
+const sortKeyColumn = "_SortKey"
+
 func (self ResultSetFactory) NewResultSetReaderWithOptions(
 	ctx context.Context,
 	config_obj *config_proto.Config,
@@ -23,8 +28,14 @@ func (self ResultSetFactory) NewResultSetReaderWithOptions(
 	options result_sets.ResultSetOptions) (result_sets.ResultSetReader, error) {
 
 	// First do the filtering and then do the sorting.
-	return self.getFilteredReader(ctx, config_obj, file_store_factory,
+	reader, err := self.getFilteredReader(ctx, config_obj, file_store_factory,
 		log_path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Finally only read the requested columns.
+	return result_sets.ProjectColumns(reader, options.Columns), nil
 }
 
 func (self ResultSetFactory) getFilteredReader(
@@ -182,10 +193,15 @@ func (self ResultSetFactory) getSortedReader(
 		return nil, err
 	}
 
+	sort_column := options.SortColumn
+	if options.Schema.ColumnType(sort_column) == result_sets.COLUMN_TIMESTAMP {
+		sort_column = sortKeyColumn
+	}
+
 	sorter_input_chan := make(chan vfilter.Row)
 	sorted_chan := sorter.MergeSorter{10000}.Sort(
 		ctx, scope, sorter_input_chan,
-		options.SortColumn, options.SortAsc)
+		sort_column, options.SortAsc)
 
 	sub_ctx, sub_cancel := context.WithTimeout(ctx, getExpiry(config_obj))
 	defer sub_cancel()
@@ -204,6 +220,16 @@ func (self ResultSetFactory) getSortedReader(
 				if !ok {
 					return
 				}
+				row = options.Schema.Normalize(row)
+				if sort_column == sortKeyColumn {
+					var key int64
+					value, _ := row.Get(options.SortColumn)
+					ts, ok := value.(time.Time)
+					if ok {
+						key = ts.UnixNano()
+					}
+					row.Set(sortKeyColumn, key)
+				}
 				sorter_input_chan <- row
 			}
 		}
@@ -212,6 +238,9 @@ func (self ResultSetFactory) getSortedReader(
 	for row := range sorted_chan {
 		row_dict, ok := row.(*ordereddict.Dict)
 		if ok {
+			if sort_column == sortKeyColumn {
+				row_dict.Delete(sortKeyColumn)
+			}
 			writer.Write(row_dict)
 		}
 	}
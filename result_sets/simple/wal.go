@@ -0,0 +1,290 @@
+// A write ahead log for result sets.
+
+// A result set consists of two files - the JSONL data and the row
+// index. A server crash while the files are flushed to storage may
+// leave a partial row at the end of the data file or index entries
+// pointing past it, corrupting the result set.
+
+// Before the first batch of a writer is written, the sizes of the
+// files on storage are recorded in the .json.wal file. The file
+// store may buffer the batches and flush them later, so the log is
+// only cleared once the file store reports both files as written
+// (the writer's completion). If a batch fails to write the log is
+// kept.
+
+// A log without a writer in this process means the files may hold
+// rows which never fully reached storage: Readers only read up to the
+// logged sizes, and the next writer rolls the files back to them.
+
+package simple
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"sync"
+
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+)
+
+const (
+	walMagic      = 0x4c415756 // VWAL
+	walRecordSize = 4 + 8 + 8 + 4
+)
+
+// The writes to a result set which are not yet on storage.
+type walState struct {
+	// Writers whose files are not yet on storage.
+	pending int
+
+	// The log records the sizes before the first of these writes.
+	logged bool
+
+	// A batch failed so the log must be kept.
+	failed bool
+}
+
+var (
+	writers_mu sync.Mutex
+
+	// Result sets with pending writers in this process.
+	open_writers = make(map[string]*walState)
+)
+
+// Register a new writer of the result set. If no other writer is
+// pending any batch interrupted by a crash is rolled back first.
+func openWriter(
+	file_store_factory api.FileStore, log_path api.FSPathSpec) error {
+	writers_mu.Lock()
+	defer writers_mu.Unlock()
+
+	key := log_path.String()
+	state, pres := open_writers[key]
+	if !pres {
+		err := recoverResultSet(file_store_factory, log_path)
+		if err != nil {
+			return err
+		}
+		state = &walState{}
+		open_writers[key] = state
+	}
+	state.pending++
+
+	return nil
+}
+
+// Called once the writer's files are on storage. The log is cleared
+// when the last pending writer is done.
+func closeWriter(
+	file_store_factory api.FileStore, log_path api.FSPathSpec) {
+	writers_mu.Lock()
+	defer writers_mu.Unlock()
+
+	key := log_path.String()
+	state, pres := open_writers[key]
+	if !pres {
+		return
+	}
+
+	state.pending--
+	if state.pending > 0 {
+		return
+	}
+	delete(open_writers, key)
+
+	if state.logged && !state.failed {
+		_ = file_store_factory.Delete(walPath(log_path))
+	}
+}
+
+// Log the sizes on storage before a batch is written, unless an
+// earlier batch already logged them.
+func logBatch(
+	file_store_factory api.FileStore,
+	log_path api.FSPathSpec, record walRecord) error {
+	writers_mu.Lock()
+	defer writers_mu.Unlock()
+
+	state, pres := open_writers[log_path.String()]
+	if !pres || state.logged {
+		return nil
+	}
+
+	err := writeWalRecord(file_store_factory, log_path, record)
+	if err != nil {
+		return err
+	}
+	state.logged = true
+
+	return nil
+}
+
+// Keep the log so the failed batch is rolled back.
+func failBatch(log_path api.FSPathSpec) {
+	writers_mu.Lock()
+	defer writers_mu.Unlock()
+
+	state, pres := open_writers[log_path.String()]
+	if pres {
+		state.failed = true
+	}
+}
+
+func isWriterOpen(log_path api.FSPathSpec) bool {
+	writers_mu.Lock()
+	defer writers_mu.Unlock()
+
+	_, pres := open_writers[log_path.String()]
+	return pres
+}
+
+type walRecord struct {
+	DataSize  int64
+	IndexSize int64
+}
+
+func (self walRecord) Serialize() []byte {
+	out := new(bytes.Buffer)
+	_ = binary.Write(out, binary.LittleEndian, uint32(walMagic))
+	_ = binary.Write(out, binary.LittleEndian, self.DataSize)
+	_ = binary.Write(out, binary.LittleEndian, self.IndexSize)
+	_ = binary.Write(out, binary.LittleEndian, crc32.ChecksumIEEE(out.Bytes()))
+	return out.Bytes()
+}
+
+// Parse a WAL record. A partial or corrupted record means the server
+// crashed while writing the log itself, before any data was written,
+// so there is nothing to roll back.
+func parseWalRecord(data []byte) (*walRecord, bool) {
+	if len(data) < walRecordSize {
+		return nil, false
+	}
+
+	data = data[:walRecordSize]
+	if binary.LittleEndian.Uint32(data) != walMagic ||
+		binary.LittleEndian.Uint32(data[20:]) !=
+			crc32.ChecksumIEEE(data[:20]) {
+		return nil, false
+	}
+
+	return &walRecord{
+		DataSize:  int64(binary.LittleEndian.Uint64(data[4:])),
+		IndexSize: int64(binary.LittleEndian.Uint64(data[12:])),
+	}, true
+}
+
+func walPath(log_path api.FSPathSpec) api.FSPathSpec {
+	return log_path.SetType(api.PATH_TYPE_FILESTORE_JSON_WAL)
+}
+
+// Read the pending WAL record of the result set if there is one.
+func readWalRecord(
+	file_store_factory api.FileStore,
+	log_path api.FSPathSpec) (*walRecord, bool) {
+	fd, err := file_store_factory.ReadFile(walPath(log_path))
+	if err != nil {
+		return nil, false
+	}
+	defer fd.Close()
+
+	data := make([]byte, walRecordSize)
+	_, err = io.ReadFull(fd, data)
+	if err != nil {
+		return nil, false
+	}
+
+	return parseWalRecord(data)
+}
+
+// Record the committed sizes of the result set and flush the log to
+// storage.
+func writeWalRecord(
+	file_store_factory api.FileStore,
+	log_path api.FSPathSpec, record walRecord) error {
+	fd, err := file_store_factory.WriteFile(walPath(log_path))
+	if err != nil {
+		return err
+	}
+
+	err = fd.Truncate()
+	if err != nil {
+		fd.Close()
+		return err
+	}
+
+	_, err = fd.Write(record.Serialize())
+	if err != nil {
+		fd.Close()
+		return err
+	}
+
+	err = fd.Flush()
+	if err != nil {
+		fd.Close()
+		return err
+	}
+
+	return fd.Close()
+}
+
+// Roll back any batch that was interrupted by a crash. Must be called
+// before the result set files are opened for writing.
+func recoverResultSet(
+	file_store_factory api.FileStore,
+	log_path api.FSPathSpec) error {
+
+	_, err := file_store_factory.StatFile(walPath(log_path))
+	if err != nil {
+		return nil
+	}
+
+	record, pres := readWalRecord(file_store_factory, log_path)
+	if pres {
+		err = api.TruncateFile(file_store_factory, log_path, record.DataSize)
+		if err != nil {
+			return err
+		}
+
+		err = api.TruncateFile(file_store_factory, log_path.SetType(
+			api.PATH_TYPE_FILESTORE_JSON_INDEX), record.IndexSize)
+		if err != nil {
+			return err
+		}
+	}
+
+	return file_store_factory.Delete(walPath(log_path))
+}
+
+// Limits reads of a result set file to its committed size.
+type committedReader struct {
+	api.FileReader
+	size   int64
+	offset int64
+}
+
+func (self *committedReader) Read(buff []byte) (int, error) {
+	remaining := self.size - self.offset
+	if remaining <= 0 {
+		return 0, io.EOF
+	}
+
+	if int64(len(buff)) > remaining {
+		buff = buff[:remaining]
+	}
+
+	n, err := self.FileReader.Read(buff)
+	self.offset += int64(n)
+	return n, err
+}
+
+func (self *committedReader) Seek(offset int64, whence int) (int64, error) {
+	if whence == io.SeekEnd {
+		offset += self.size
+		whence = io.SeekStart
+	}
+
+	offset, err := self.FileReader.Seek(offset, whence)
+	self.offset = offset
+	return offset, err
+}
@@ -25,6 +25,11 @@ func (self *ResultSetReaderWrapper) TotalRows() int64 {
 	return int64(self.end_idx - self.start_idx)
 }
 
+func (self *ResultSetReaderWrapper) SetColumns(columns []string) {
+	self.ResultSetReader = result_sets.ProjectColumns(
+		self.ResultSetReader, columns)
+}
+
 func (self *ResultSetReaderWrapper) JSON(ctx context.Context) (<-chan []byte, error) {
 	return nil, errors.New("ResultSetReaderWrapper.JSON Not implemented")
 }
@@ -119,6 +119,11 @@ var (
 			Buckets: prometheus.LinearBuckets(0.1, 1, 10),
 		},
 	)
+
+	inboundQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "frontend_inbound_queue_depth",
+		Help: "Number of client requests waiting for a concurrency slot.",
+	})
 )
 
 func PrepareFrontendMux(
@@ -145,6 +150,9 @@ func PrepareFrontendMux(
 	// Receive new messages from the server.
 	router.Handle(base+"/receive_messages", RecordHTTPStats(reader(server_obj)))
 
+	// Clients fall back to a websocket when long poll is degraded.
+	router.Handle(base+"/ws", websocketHandler(config_obj, server_obj))
+
 	// Publicly accessible part of the filestore. NOTE: this
 	// does not have to be a physical directory - it is served
 	// from the filestore.
@@ -210,9 +218,11 @@ func readWithLimits(
 
 	// Read the data from the POST request into a
 	buffer := &bytes.Buffer{}
-	max_upload_size := config_obj.Frontend.Resources.MaxUploadSize
-	if max_upload_size == 0 {
-		max_upload_size = 5000000
+	max_upload_size := getMaxUploadSize(config_obj)
+
+	// Size the buffer up front to avoid copying it as it grows.
+	if req.ContentLength > 0 && uint64(req.ContentLength) < max_upload_size*2 {
+		buffer.Grow(int(req.ContentLength))
 	}
 
 	reader := io.LimitReader(req.Body, int64(max_upload_size*2))
@@ -225,8 +235,9 @@ func readWithLimits(
 		reader = ratelimit.Reader(reader, bucket)
 	}
 
-	if server_obj.Bucket != nil {
-		reader = ratelimit.Reader(reader, server_obj.Bucket)
+	bucket := server_obj.Bucket()
+	if bucket != nil {
+		reader = ratelimit.Reader(reader, bucket)
 	}
 
 	n, err := utils.Copy(ctx, buffer, reader)
@@ -257,6 +268,24 @@ func readWithLimits(
 	return message_info, nil
 }
 
+func getMaxUploadSize(config_obj *config_proto.Config) uint64 {
+	max_upload_size := config_obj.Frontend.Resources.MaxUploadSize
+	if max_upload_size == 0 {
+		max_upload_size = 5000000
+	}
+	return max_upload_size
+}
+
+// The memory to reserve for reading the request. Clients always send
+// the content length but we assume the largest message if not.
+func getRequestSize(config_obj *config_proto.Config, req *http.Request) int64 {
+	max_size := int64(getMaxUploadSize(config_obj) * 2)
+	if req.ContentLength > 0 && req.ContentLength < max_size {
+		return req.ContentLength
+	}
+	return max_size
+}
+
 // This handler is used to receive messages from the client to the
 // server. These connections are short lived - the client will just
 // post its message and then disconnect.
@@ -306,7 +335,9 @@ func control(
 				concurrencyWaitHistorgram.Observe(v)
 			}))
 
+			inboundQueueDepth.Inc()
 			cancel, err := server_obj.Concurrency().StartConcurrencyControl(ctx)
+			inboundQueueDepth.Dec()
 			if err != nil {
 				http.Error(w, "Timeout", http.StatusRequestTimeout)
 				timeoutCounter.Inc()
@@ -328,6 +359,26 @@ func control(
 			timer.ObserveDuration()
 		}()
 
+		// Reserve memory for the payload. The memory is released
+		// once the message is processed and the response is sent -
+		// the response acknowledges the client's messages (and any
+		// upload chunks in them) so the client may send more.
+		release, err := server_obj.upload_budget.Reserve(
+			ctx, getRequestSize(config_obj, req))
+		if err != nil {
+			http.Error(w, "Timeout", http.StatusRequestTimeout)
+			timeoutCounter.Inc()
+			return
+		}
+
+		// Once processing starts it releases the memory instead.
+		processing := false
+		defer func() {
+			if !processing {
+				release()
+			}
+		}()
+
 		// Read the payload from the client.
 		message_info, err := readWithLimits(ctx, config_obj, server_obj, req)
 		if err != nil {
@@ -375,6 +426,13 @@ func control(
 			return
 		}
 
+		if !clientCertificateMatches(req, message_info.Source) {
+			server_obj.Info("Client certificate does not match %v",
+				message_info.Source)
+			http.Error(w, "", http.StatusForbidden)
+			return
+		}
+
 		// From here below we have received the client payload
 		// and it should not resend it to us. We need to keep
 		// the client blocked until we finish processing the
@@ -386,10 +444,15 @@ func control(
 		w.WriteHeader(http.StatusOK)
 		flusher.Flush()
 
+		processing = true
 		sync := make(chan []byte)
 		go func() {
 			defer close(sync)
 
+			// The message is still in memory until it is processed
+			// even if the client goes away.
+			defer release()
+
 			// Process the request with a different context - if the
 			// client disconnects quickly the request context will be
 			// cancelled and aborted, but we do not want this to
@@ -495,6 +558,11 @@ func reader(server_obj *Server) http.Handler {
 			return
 		}
 
+		if !clientCertificateMatches(req, message_info.Source) {
+			http.Error(w, "", http.StatusForbidden)
+			return
+		}
+
 		// Recover the org for this client
 		org_manager, err := services.GetOrgManager()
 		if err != nil {
@@ -675,6 +743,18 @@ func reader(server_obj *Server) http.Handler {
 	})
 }
 
+// When the client presented a TLS certificate it must have been
+// issued to the client that sent the message. This stops a client
+// from using its certificate to impersonate another client.
+func clientCertificateMatches(req *http.Request, source string) bool {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 ||
+		len(req.TLS.VerifiedChains[0]) == 0 {
+		return true
+	}
+
+	return req.TLS.VerifiedChains[0][0].Subject.CommonName == source
+}
+
 // Record the status of the request so we can log it.
 type statusRecorder struct {
 	http.ResponseWriter
@@ -44,6 +44,15 @@ func enroll(
 		return err
 	}
 
+	// The attestation is reported by the client so it is recorded
+	// for information.
+	attestation := csr.Attestation
+	if attestation != nil {
+		logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+		logger.Info("Client %v enrolled with a TPM key (%v %v)",
+			client_id, attestation.Provider, attestation.PlatformType)
+	}
+
 	journal, err := services.GetJournal(config_obj)
 	if err != nil {
 		return err
@@ -52,6 +61,9 @@ func enroll(
 	return journal.PushRowsToArtifact(config_obj,
 		[]*ordereddict.Dict{
 			ordereddict.NewDict().
-				Set("ClientId", client_id)},
+				Set("ClientId", client_id).
+				Set("TPMKey", attestation != nil).
+				Set("TPMPlatform", attestation.GetPlatformType()).
+				Set("KeyNonExportable", attestation.GetNonExportable())},
 		"Server.Internal.Enrollment", client_id, "")
 }
@@ -1,11 +1,13 @@
 package server
 
 import (
+	"context"
+	"crypto/tls"
 	"net"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	"www.velocidex.com/golang/velociraptor/utils"
+	"github.com/quic-go/quic-go"
 )
 
 var (
@@ -17,7 +19,7 @@ var (
 
 type LoadSheddingListener struct {
 	net.Listener
-	throttler *utils.Throttler
+	server *Server
 }
 
 func (self *LoadSheddingListener) Accept() (net.Conn, error) {
@@ -27,7 +29,9 @@ func (self *LoadSheddingListener) Accept() (net.Conn, error) {
 			return res, err
 		}
 
-		if self.throttler == nil || self.throttler.Ready() {
+		// The throttler may be replaced when the config is reloaded.
+		throttler := self.server.Throttler()
+		if throttler == nil || throttler.Ready() {
 			return res, err
 		}
 
@@ -45,7 +49,45 @@ func (self *Server) NewLoadSheddingListener(addr string) (*LoadSheddingListener,
 	}
 
 	return &LoadSheddingListener{
-		Listener:  ln,
-		throttler: self.throttler,
+		Listener: ln,
+		server:   self,
 	}, err, ln.Close
 }
+
+// Sheds load from the HTTP/3 listener the same way as for TCP.
+type QuicLoadSheddingListener struct {
+	*quic.EarlyListener
+	server *Server
+}
+
+func (self *QuicLoadSheddingListener) Accept(
+	ctx context.Context) (quic.EarlyConnection, error) {
+	for {
+		res, err := self.EarlyListener.Accept(ctx)
+		if err != nil {
+			return res, err
+		}
+
+		throttler := self.server.Throttler()
+		if throttler == nil || throttler.Ready() {
+			return res, err
+		}
+
+		loadshedCounter.Inc()
+		_ = res.CloseWithError(0, "load shedding")
+	}
+}
+
+func (self *Server) NewQuicLoadSheddingListener(
+	addr string, tls_config *tls.Config,
+	quic_config *quic.Config) (*QuicLoadSheddingListener, error) {
+	ln, err := quic.ListenAddrEarly(addr, tls_config, quic_config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuicLoadSheddingListener{
+		EarlyListener: ln,
+		server:        self,
+	}, nil
+}
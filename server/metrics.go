@@ -16,8 +16,35 @@ var (
 		},
 		[]string{"status"},
 	)
+
+	transportRequestCounters = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "frontend_http_transport_requests",
+			Help: "Count of client requests by transport (http/1.1, http/2 or http/3).",
+		},
+		[]string{"transport"},
+	)
+
+	transportBytesCounters = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "frontend_http_transport_received_bytes",
+			Help: "Bytes received from clients by transport.",
+		},
+		[]string{"transport"},
+	)
 )
 
+func getTransport(r *http.Request) string {
+	switch r.ProtoMajor {
+	case 3:
+		return "http/3"
+	case 2:
+		return "http/2"
+	default:
+		return "http/1.1"
+	}
+}
+
 func RecordHTTPStats(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		rec := &statusRecorder{
@@ -25,6 +52,13 @@ func RecordHTTPStats(next http.Handler) http.Handler {
 			w.(http.Flusher),
 			200, nil}
 
+		transport := getTransport(r)
+		transportRequestCounters.WithLabelValues(transport).Inc()
+		if r.ContentLength > 0 {
+			transportBytesCounters.WithLabelValues(transport).Add(
+				float64(r.ContentLength))
+		}
+
 		next.ServeHTTP(rec, r)
 		status := fmt.Sprintf("%v", rec.status)
 		httpErrorStatusCounters.WithLabelValues(status).Inc()
@@ -21,7 +21,6 @@ package server
 import (
 	"context"
 	"errors"
-	"fmt"
 	"runtime"
 	"sync"
 	"time"
@@ -29,6 +28,7 @@ import (
 	"github.com/juju/ratelimit"
 	"github.com/prometheus/client_golang/prometheus"
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	"www.velocidex.com/golang/velociraptor/config"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/constants"
 	"www.velocidex.com/golang/velociraptor/crypto"
@@ -44,17 +44,23 @@ type Server struct {
 	manager *crypto_server.ServerCryptoManager
 	logger  *logging.LogContext
 
-	// Limit concurrency for processing messages.
+	// Limit concurrency for processing messages. These are replaced
+	// when the frontend resources are reloaded.
 	mu                  sync.Mutex
 	concurrency         *utils.Concurrency
 	reader_concurrency  *utils.Concurrency
 	concurrency_timeout time.Duration
 	throttler           *utils.Throttler
+	bucket              *ratelimit.Bucket
+
+	// Limits the memory used by messages being received.
+	upload_budget *uploadBudget
 
 	// The server dynamically adjusts concurrency. This signals exit.
 	done chan bool
 
-	Bucket  *ratelimit.Bucket
+	unregister func()
+
 	Healthy int32
 }
 
@@ -72,8 +78,28 @@ func (self *Server) ReaderConcurrency() *utils.Concurrency {
 	return self.reader_concurrency
 }
 
+func (self *Server) Throttler() *utils.Throttler {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.throttler
+}
+
+// The global upload rate limit (nil if not limited).
+func (self *Server) Bucket() *ratelimit.Bucket {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.bucket
+}
+
 func (self *Server) Close() {
 	close(self.done)
+	self.unregister()
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
 	if self.throttler != nil {
 		self.throttler.Close()
 	}
@@ -91,49 +117,89 @@ func NewServer(ctx context.Context,
 		return nil, err
 	}
 
+	result := &Server{
+		manager: manager,
+		logger:  logging.GetLogger(config_obj, &logging.FrontendComponent),
+		done:    make(chan bool),
+	}
+	result.setResources(config_obj)
+
+	// Apply changes to the frontend resources without dropping
+	// client connections.
+	result.unregister = config.RegisterReloadHandler("frontend_resources",
+		func(ctx context.Context, change *config.ConfigChange) error {
+			if change.Changed(config.RELOAD_FRONTEND_RESOURCES) {
+				result.logger.Info("Reloading frontend resource limits")
+				result.setResources(config_obj)
+			}
+			return nil
+		})
+
+	return result, nil
+}
+
+// Size the concurrency and rate limits from the config. Requests in
+// flight keep using the old limits until they complete.
+func (self *Server) setResources(config_obj *config_proto.Config) {
+	resources := config_obj.Frontend.Resources
+	if resources == nil {
+		resources = &config_proto.FrontendResourceControl{}
+	}
+
 	// This number mainly affects memory use during large tranfers
 	// as it controls the number of concurrent clients that may be
 	// transferring data (each will use some memory to
 	// buffer). This should not be too large relative to the
 	// available CPU cores.
-	concurrency := config_obj.Frontend.Resources.Concurrency
+	concurrency := resources.Concurrency
 	if concurrency == 0 {
 		concurrency = 2 * uint64(runtime.GOMAXPROCS(0))
 	}
 
-	concurrency_timeout := config_obj.Frontend.Resources.ConcurrencyTimeout
+	concurrency_timeout := resources.ConcurrencyTimeout
 	if concurrency_timeout == 0 {
 		concurrency_timeout = 600
 	}
+	timeout := time.Duration(concurrency_timeout) * time.Second
 
-	result := Server{
-		manager:             manager,
-		logger:              logging.GetLogger(config_obj, &logging.FrontendComponent),
-		concurrency_timeout: time.Duration(concurrency_timeout) * time.Second,
-		done:                make(chan bool),
+	max_upload_memory := resources.MaxUploadMemory
+	if max_upload_memory == 0 {
+		max_upload_memory = 50 * getMaxUploadSize(config_obj)
 	}
 
-	result.concurrency = utils.NewConcurrencyControl(
-		int(concurrency), result.concurrency_timeout)
-
-	result.reader_concurrency = utils.NewConcurrencyControl(
-		int(100), result.concurrency_timeout)
+	var throttler *utils.Throttler
+	if resources.ConnectionsPerSecond > 0 {
+		self.logger.Info("Throttling connections to %v QPS",
+			resources.ConnectionsPerSecond)
+		throttler = utils.NewThrottler(resources.ConnectionsPerSecond)
+	}
 
-	if config_obj.Frontend.Resources.ConnectionsPerSecond > 0 {
-		result.logger.Info("Throttling connections to %v QPS",
-			config_obj.Frontend.Resources.ConnectionsPerSecond)
-		result.throttler = utils.NewThrottler(config_obj.Frontend.Resources.ConnectionsPerSecond)
+	var bucket *ratelimit.Bucket
+	if resources.GlobalUploadRate > 0 {
+		self.logger.Info("Global upload rate set to %v bytes per second",
+			resources.GlobalUploadRate)
+		bucket = ratelimit.NewBucketWithRate(
+			float64(resources.GlobalUploadRate), 1024*1024)
 	}
 
-	if config_obj.Frontend.Resources.GlobalUploadRate > 0 {
-		result.logger.Info("Global upload rate set to %v bytes per second",
-			config_obj.Frontend.Resources.GlobalUploadRate)
-		result.Bucket = ratelimit.NewBucketWithRate(
-			float64(config_obj.Frontend.Resources.GlobalUploadRate),
-			1024*1024)
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.concurrency_timeout = timeout
+	self.concurrency = utils.NewConcurrencyControl(int(concurrency), timeout)
+	self.reader_concurrency = utils.NewConcurrencyControl(int(100), timeout)
+
+	if self.upload_budget == nil {
+		self.upload_budget = newUploadBudget(int64(max_upload_memory), timeout)
+	} else {
+		self.upload_budget.Resize(int64(max_upload_memory), timeout)
 	}
 
-	return &result, nil
+	if self.throttler != nil {
+		self.throttler.Close()
+	}
+	self.throttler = throttler
+	self.bucket = bucket
 }
 
 // We only process enrollment messages when the client is not fully
@@ -155,7 +221,7 @@ func (self *Server) ProcessSingleUnauthenticatedMessage(
 
 		err = enroll(ctx, config_obj, self, message.CSR)
 		if err != nil {
-			self.logger.Error(fmt.Sprintf("Enrol Error: %s", err))
+			self.logger.Error("Enrol Error: %s", err)
 		}
 		return err
 	}
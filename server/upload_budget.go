@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	uploadMemoryGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "frontend_upload_memory_bytes",
+		Help: "Size of client POST messages currently held in memory.",
+	})
+)
+
+// Limits the total size of client messages held in memory by the
+// frontend. Each POST reserves its size before it is read and
+// releases it once it is processed and acknowledged to the
+// client. When the budget is exhausted clients wait for their turn,
+// which slows down their uploads.
+type uploadBudget struct {
+	mu     sync.Mutex
+	size   int64
+	in_use int64
+
+	// Closed and replaced each time memory is released to wake
+	// waiters.
+	released chan bool
+
+	timeout time.Duration
+}
+
+func newUploadBudget(size int64, timeout time.Duration) *uploadBudget {
+	return &uploadBudget{
+		size:     size,
+		released: make(chan bool),
+		timeout:  timeout,
+	}
+}
+
+// Reserve the memory for a message, waiting until it is
+// available. The returned function releases the memory again.
+func (self *uploadBudget) Reserve(
+	ctx context.Context, size int64) (func(), error) {
+	self.mu.Lock()
+	deadline := time.After(self.timeout)
+	self.mu.Unlock()
+
+	for {
+		self.mu.Lock()
+
+		// A single message larger than the budget is still allowed
+		// through on its own.
+		if self.in_use == 0 || self.in_use+size <= self.size {
+			self.in_use += size
+			uploadMemoryGauge.Add(float64(size))
+			self.mu.Unlock()
+
+			return func() { self.release(size) }, nil
+		}
+		released := self.released
+		self.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, errors.New("UploadBudget: Timed out due to cancellation")
+		case <-deadline:
+			return nil, errors.New("Timed out waiting for upload memory")
+		}
+	}
+}
+
+func (self *uploadBudget) release(size int64) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.in_use -= size
+	uploadMemoryGauge.Sub(float64(size))
+
+	close(self.released)
+	self.released = make(chan bool)
+}
+
+// Change the size of the budget. Messages already holding memory
+// keep it.
+func (self *uploadBudget) Resize(size int64, timeout time.Duration) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.size = size
+	self.timeout = timeout
+
+	// Wake up waiters in case the budget grew.
+	close(self.released)
+	self.released = make(chan bool)
+}
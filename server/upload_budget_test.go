@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadBudget(t *testing.T) {
+	budget := newUploadBudget(100, time.Second)
+	ctx := context.Background()
+
+	release_1, err := budget.Reserve(ctx, 60)
+	assert.NoError(t, err)
+
+	// Does not fit - wait until the first message is released.
+	reserved := make(chan func())
+	go func() {
+		release_2, err := budget.Reserve(ctx, 60)
+		assert.NoError(t, err)
+		reserved <- release_2
+	}()
+
+	select {
+	case <-reserved:
+		t.Fatalf("Reserved more than the budget")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	release_1()
+	release_2 := <-reserved
+
+	// A message larger than the budget is let through on its own
+	// but times out while other messages hold memory.
+	budget.timeout = 100 * time.Millisecond
+	_, err = budget.Reserve(ctx, 200)
+	assert.Error(t, err)
+
+	release_2()
+	release_3, err := budget.Reserve(ctx, 200)
+	assert.NoError(t, err)
+	release_3()
+
+	assert.Equal(t, int64(0), budget.in_use)
+}
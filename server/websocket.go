@@ -0,0 +1,120 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/http"
+
+	"golang.org/x/net/websocket"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+const (
+	WEBSOCKET_FLAG_URGENT = 1
+)
+
+// Serve client comms over a websocket. This is used by clients when
+// long poll HTTP is degraded (e.g. by inspecting proxies). Each
+// message from the client is a flags byte followed by the cipher
+// text and is dispatched to the same handler that serves the HTTP
+// transport. The reply is a single message with a two byte status
+// code followed by the response body (see http_comms/websocket.go).
+func websocketHandler(
+	config_obj *config_proto.Config, server_obj *Server) http.Handler {
+	handlers := map[string]http.Handler{
+		"control": RecordHTTPStats(control(config_obj, server_obj)),
+		"reader":  RecordHTTPStats(reader(server_obj)),
+	}
+	handlers["send_messages"] = handlers["control"]
+	handlers["receive_messages"] = handlers["reader"]
+
+	return websocket.Server{
+		// Clients are not browsers so do not verify the Origin.
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+
+			req := ws.Request()
+			handler, pres := handlers[req.URL.Query().Get("h")]
+			if !pres {
+				return
+			}
+
+			for {
+				var frame []byte
+				err := websocket.Message.Receive(ws, &frame)
+				if err != nil || len(frame) == 0 {
+					return
+				}
+
+				reply := serveWebsocketMessage(handler, req, frame)
+				err = websocket.Message.Send(ws, reply)
+				if err != nil {
+					server_obj.Debug("websocket: %v", err)
+					return
+				}
+			}
+		},
+	}
+}
+
+func serveWebsocketMessage(
+	handler http.Handler, ws_req *http.Request, frame []byte) []byte {
+	req, err := http.NewRequestWithContext(ws_req.Context(),
+		"POST", ws_req.URL.String(), bytes.NewReader(frame[1:]))
+	if err != nil {
+		return websocketReply(http.StatusBadRequest, nil)
+	}
+
+	req.RemoteAddr = ws_req.RemoteAddr
+	req.TLS = ws_req.TLS
+	req.Header = ws_req.Header.Clone()
+	req.Header.Set("Content-Type", "application/binary")
+	if frame[0]&WEBSOCKET_FLAG_URGENT != 0 {
+		req.Header.Set("X-Priority", "urgent")
+	}
+
+	w := &websocketResponseWriter{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+	handler.ServeHTTP(w, req)
+
+	return websocketReply(w.status, w.body.Bytes())
+}
+
+func websocketReply(status int, body []byte) []byte {
+	reply := make([]byte, 2, len(body)+2)
+	binary.BigEndian.PutUint16(reply, uint16(status))
+	return append(reply, body...)
+}
+
+// Collects the handler's response so it can be sent as a single
+// websocket message. Pad packets written by the handlers are simply
+// accumulated and ignored by the client.
+type websocketResponseWriter struct {
+	header       http.Header
+	status       int
+	wrote_header bool
+	body         bytes.Buffer
+}
+
+func (self *websocketResponseWriter) Header() http.Header {
+	return self.header
+}
+
+func (self *websocketResponseWriter) Write(data []byte) (int, error) {
+	self.wrote_header = true
+	return self.body.Write(data)
+}
+
+func (self *websocketResponseWriter) WriteHeader(status int) {
+	if !self.wrote_header {
+		self.status = status
+		self.wrote_header = true
+	}
+}
+
+func (self *websocketResponseWriter) Flush() {}
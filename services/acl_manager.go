@@ -1,6 +1,9 @@
 package services
 
 import (
+	"path"
+	"strings"
+
 	"www.velocidex.com/golang/velociraptor/acls"
 	acl_proto "www.velocidex.com/golang/velociraptor/acls/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
@@ -163,11 +166,54 @@ func CheckAccessWithToken(
 	case acls.DATASTORE_ACCESS:
 		return token.DatastoreAccess, nil
 
+	case acls.READ_SHELL_RECORDINGS:
+		return token.ReadShellRecordings, nil
+
+	case acls.SECRETS_MANAGER:
+		return token.SecretsManager, nil
+
 	}
 
 	return false, nil
 }
 
+// Is the artifact within the token's artifact scope? An empty scope
+// allows all artifacts.
+func CheckArtifactScopeWithToken(
+	token *acl_proto.ApiClientACL, artifact string) bool {
+	if token.SuperUser || len(token.ArtifactScope) == 0 {
+		return true
+	}
+
+	for _, pattern := range token.ArtifactScope {
+		matched, err := path.Match(pattern, artifact)
+		if err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Does any of the labels fall within the token's label scope? An
+// empty scope allows all clients.
+func CheckLabelScopeWithToken(
+	token *acl_proto.ApiClientACL, labels ...string) bool {
+	if token.SuperUser || len(token.LabelScope) == 0 {
+		return true
+	}
+
+	for _, label := range labels {
+		for _, allowed_label := range token.LabelScope {
+			if strings.EqualFold(label, allowed_label) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func GrantRoles(
 	config_obj *config_proto.Config,
 	principal string,
@@ -0,0 +1,422 @@
+/*
+  API keys let scripts and integrations (e.g. SOAR platforms) call
+  the HTTP API without a client certificate.
+
+  Each key acts as its own principal (the key id, e.g. AK.XXXX) with
+  its own policy in the org the key was created in. The policy is
+  usually narrow - for example the reader role for read only access,
+  or the collect_client permission with a label_scope so the key can
+  only launch collections on some clients.
+
+  Keys are stored in the root org's datastore so they can be found
+  when a request is authenticated. Only a hash of the key is stored -
+  the key itself is returned once when it is created or rotated.
+  Keys expire, and when a key is rotated the previous key may remain
+  valid for a grace period so integrations can be updated.
+*/
+
+package api_keys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"www.velocidex.com/golang/velociraptor/acls"
+	acl_proto "www.velocidex.com/golang/velociraptor/acls/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	KEY_PREFIX = "AK."
+
+	// Keys expire after 90 days unless an expiry is given.
+	DEFAULT_EXPIRY = 90 * 24 * time.Hour
+
+	// Only record the last use of a key this often to avoid writing
+	// the key on every request.
+	LAST_USED_RESOLUTION = time.Minute
+)
+
+var (
+	InvalidKeyError = errors.New("Invalid API key")
+)
+
+func NewKeyId() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+
+	binary.BigEndian.PutUint32(buf, uint32(utils.GetTime().Now().Unix()))
+	result := base32.HexEncoding.EncodeToString(buf)[:13]
+
+	return KEY_PREFIX + result
+}
+
+// Keys are stored in the root org so they can be found without
+// knowing the org.
+func getRootConfig() (*config_proto.Config, error) {
+	org_manager, err := services.GetOrgManager()
+	if err != nil {
+		return nil, err
+	}
+	return org_manager.GetOrgConfig(services.ROOT_ORG_ID)
+}
+
+func hashSecret(secret string) []byte {
+	hash := sha256.Sum256([]byte(secret))
+	return hash[:]
+}
+
+// Generate a new token for the key and store its hash.
+func newToken(key *api_proto.ApiKey) (string, error) {
+	buf := make([]byte, 32)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+	key.Hash = hashSecret(secret)
+
+	return key.KeyId + "." + secret, nil
+}
+
+// Split the token into the key id and the secret.
+func parseToken(token string) (string, string, error) {
+	idx := strings.LastIndex(token, ".")
+	if !strings.HasPrefix(token, KEY_PREFIX) || idx <= len(KEY_PREFIX) {
+		return "", "", InvalidKeyError
+	}
+	return token[:idx], token[idx+1:], nil
+}
+
+// Keys never leave the server with their hashes.
+func Redact(key *api_proto.ApiKey) *api_proto.ApiKey {
+	result := proto.Clone(key).(*api_proto.ApiKey)
+	result.Hash = nil
+	result.PreviousHash = nil
+	result.Token = ""
+	return result
+}
+
+func validatePolicy(policy *acl_proto.ApiClientACL) error {
+	if policy == nil {
+		return errors.New("API keys must have a policy")
+	}
+
+	if policy.SuperUser {
+		return errors.New("API keys can not be super users")
+	}
+
+	for _, role := range policy.Roles {
+		if !acls.ValidateRole(role) {
+			return fmt.Errorf("Invalid role %v", role)
+		}
+	}
+	return nil
+}
+
+func GetApiKey(key_id string) (*api_proto.ApiKey, error) {
+	root_config_obj, err := getRootConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api_proto.ApiKey{}
+	err = datastore.GetExistingSubject(root_config_obj,
+		paths.API_KEYS_ROOT.AddChild(key_id), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func setApiKey(key *api_proto.ApiKey) error {
+	root_config_obj, err := getRootConfig()
+	if err != nil {
+		return err
+	}
+
+	db, err := datastore.GetDB(root_config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.SetSubject(root_config_obj,
+		paths.API_KEYS_ROOT.AddChild(key.KeyId), key)
+}
+
+// Update the key under lock.
+func modifyApiKey(key_id string,
+	cb func(key *api_proto.ApiKey) error) (*api_proto.ApiKey, error) {
+	root_config_obj, err := getRootConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &api_proto.ApiKey{}
+	err = datastore.ModifySubject(root_config_obj,
+		paths.API_KEYS_ROOT.AddChild(key_id), key,
+		func(exists bool) error {
+			if !exists {
+				return os.ErrNotExist
+			}
+			return cb(key)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Users are stored in the root org.
+func setKeyUser(key_id string) error {
+	root_config_obj, err := getRootConfig()
+	if err != nil {
+		return err
+	}
+
+	db, err := datastore.GetDB(root_config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.SetSubject(root_config_obj,
+		paths.NewUserPathManager(key_id).Path(),
+		&api_proto.VelociraptorUser{Name: key_id})
+}
+
+// Get a key belonging to the org.
+func getOrgApiKey(config_obj *config_proto.Config,
+	key_id string) (*api_proto.ApiKey, error) {
+	key, err := GetApiKey(key_id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !utils.CompareOrgIds(key.OrgId, config_obj.OrgId) {
+		return nil, os.ErrNotExist
+	}
+	return key, nil
+}
+
+// Create a new key in the org. The returned key contains the token
+// which is not stored anywhere.
+func CreateApiKey(ctx context.Context, config_obj *config_proto.Config,
+	creator string, request *api_proto.ApiKey) (*api_proto.ApiKey, error) {
+	err := validatePolicy(request.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	now := utils.GetTime().Now()
+	key := &api_proto.ApiKey{
+		KeyId:       NewKeyId(),
+		Description: request.Description,
+		OrgId:       utils.NormalizedOrgId(config_obj.OrgId),
+		Creator:     creator,
+		Policy:      request.Policy,
+		CreateTime:  uint64(now.Unix()),
+		Expires:     request.Expires,
+	}
+
+	if key.Expires == 0 {
+		key.Expires = uint64(now.Add(DEFAULT_EXPIRY).Unix())
+	}
+
+	if key.Expires <= uint64(now.Unix()) {
+		return nil, errors.New("API key expiry must be in the future")
+	}
+
+	token, err := newToken(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// The key acts as its own principal so it needs a user record
+	// and a policy. The user manager refuses names with the key
+	// prefix so the record is written directly.
+	err = setKeyUser(key.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = services.SetPolicy(config_obj, key.KeyId, key.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setApiKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	result := Redact(key)
+	result.Token = token
+	return result, nil
+}
+
+// Issue a new token for the key. The previous token remains valid
+// for the grace period.
+func RotateApiKey(config_obj *config_proto.Config,
+	key_id string, grace_period uint64) (*api_proto.ApiKey, error) {
+	var token string
+	key, err := modifyApiKey(key_id, func(key *api_proto.ApiKey) error {
+		if !utils.CompareOrgIds(key.OrgId, config_obj.OrgId) {
+			return os.ErrNotExist
+		}
+
+		now := uint64(utils.GetTime().Now().Unix())
+		key.PreviousHash = nil
+		key.PreviousExpires = 0
+		if grace_period > 0 {
+			key.PreviousHash = key.Hash
+			key.PreviousExpires = now + grace_period
+		}
+		key.RotateTime = now
+
+		var err error
+		token, err = newToken(key)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := Redact(key)
+	result.Token = token
+	return result, nil
+}
+
+// Remove the key and its principal.
+func DeleteApiKey(ctx context.Context,
+	config_obj *config_proto.Config, key_id string) error {
+	// The org of a key never changes.
+	_, err := getOrgApiKey(config_obj, key_id)
+	if err != nil {
+		return err
+	}
+
+	root_config_obj, err := getRootConfig()
+	if err != nil {
+		return err
+	}
+
+	err = datastore.DeleteSubject(root_config_obj,
+		paths.API_KEYS_ROOT.AddChild(key_id), &api_proto.ApiKey{})
+	if err != nil {
+		return err
+	}
+
+	// Clear the policy first so any cached permissions are dropped
+	// immediately.
+	err = services.SetPolicy(config_obj, key_id, &acl_proto.ApiClientACL{})
+	if err != nil {
+		return err
+	}
+
+	return services.GetUserManager().DeleteUser(ctx, config_obj, key_id)
+}
+
+// List the keys of the org without their hashes.
+func ListApiKeys(
+	config_obj *config_proto.Config) ([]*api_proto.ApiKey, error) {
+	root_config_obj, err := getRootConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := datastore.ListSubjects(root_config_obj, paths.API_KEYS_ROOT)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api_proto.ApiKey, 0, len(children))
+	for _, child := range children {
+		key, err := GetApiKey(child.Base())
+		if err != nil ||
+			!utils.CompareOrgIds(key.OrgId, config_obj.OrgId) {
+			continue
+		}
+		result = append(result, Redact(key))
+	}
+
+	return result, nil
+}
+
+// Check the token and return the key it belongs to. The key's last
+// use is recorded.
+func Authenticate(token, remote string) (*api_proto.ApiKey, error) {
+	key_id, secret, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := GetApiKey(key_id)
+	if err != nil {
+		return nil, InvalidKeyError
+	}
+
+	now := utils.GetTime().Now()
+	if uint64(now.Unix()) >= key.Expires {
+		return nil, fmt.Errorf("%w: %v expired", InvalidKeyError, key_id)
+	}
+
+	hash := hashSecret(secret)
+	valid := subtle.ConstantTimeCompare(hash, key.Hash) == 1
+	if !valid && len(key.PreviousHash) > 0 &&
+		uint64(now.Unix()) < key.PreviousExpires {
+		valid = subtle.ConstantTimeCompare(hash, key.PreviousHash) == 1
+	}
+
+	if !valid {
+		return nil, fmt.Errorf("%w: %v", InvalidKeyError, key_id)
+	}
+
+	last_used := time.Unix(int64(key.LastUsed), 0)
+	if now.Sub(last_used) >= LAST_USED_RESOLUTION ||
+		key.LastUsedFrom != remote {
+		key.LastUsed = uint64(now.Unix())
+		key.LastUsedFrom = remote
+
+		// Only record the use so we do not undo a concurrent
+		// rotation. The key is valid even if we fail to record it.
+		_, err = modifyApiKey(key_id, func(stored *api_proto.ApiKey) error {
+			stored.LastUsed = key.LastUsed
+			stored.LastUsedFrom = key.LastUsedFrom
+			return nil
+		})
+		if err != nil {
+			root_config_obj, err1 := getRootConfig()
+			if err1 == nil {
+				logger := logging.GetLogger(root_config_obj, &logging.GUIComponent)
+				logger.Error("Authenticate: recording use of %v: %v", key_id, err)
+			}
+		}
+	}
+
+	return Redact(key), nil
+}
+
+// Is this request authenticated by an API key?
+func IsApiKey(token string) bool {
+	return strings.HasPrefix(token, KEY_PREFIX)
+}
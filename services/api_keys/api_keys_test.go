@@ -0,0 +1,153 @@
+package api_keys_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/acls"
+	acl_proto "www.velocidex.com/golang/velociraptor/acls/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/api_keys"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+type ApiKeysTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *ApiKeysTestSuite) TestApiKeys() {
+	ctx := context.Background()
+
+	clock := &utils.MockClock{MockNow: time.Unix(1600000000, 0)}
+	defer utils.MockTime(clock)()
+
+	// Keys may not be super users.
+	_, err := api_keys.CreateApiKey(ctx, self.ConfigObj, "admin",
+		&api_proto.ApiKey{
+			Policy: &acl_proto.ApiClientACL{SuperUser: true},
+		})
+	assert.Error(self.T(), err)
+
+	key, err := api_keys.CreateApiKey(ctx, self.ConfigObj, "admin",
+		&api_proto.ApiKey{
+			Description: "SOAR",
+			Policy:      &acl_proto.ApiClientACL{Roles: []string{"reader"}},
+		})
+	assert.NoError(self.T(), err)
+	assert.NotEmpty(self.T(), key.Token)
+	assert.Empty(self.T(), key.Hash)
+	assert.Equal(self.T(), uint64(1600000000)+
+		uint64(api_keys.DEFAULT_EXPIRY.Seconds()), key.Expires)
+
+	// The key acts as its own principal.
+	ok, err := services.CheckAccess(self.ConfigObj, key.KeyId, acls.READ_RESULTS)
+	assert.NoError(self.T(), err)
+	assert.True(self.T(), ok)
+
+	ok, _ = services.CheckAccess(self.ConfigObj, key.KeyId, acls.COLLECT_CLIENT)
+	assert.False(self.T(), ok)
+
+	// Users can not take over the key's principal.
+	err = services.GetUserManager().SetUser(ctx,
+		&api_proto.VelociraptorUser{Name: key.KeyId})
+	assert.ErrorContains(self.T(), err, "reserved for API keys")
+
+	user_record, err := services.GetUserManager().GetUser(ctx, key.KeyId)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), key.KeyId, user_record.Name)
+
+	// Authenticating records the last use.
+	authenticated, err := api_keys.Authenticate(key.Token, "10.0.0.1")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), key.KeyId, authenticated.KeyId)
+
+	stored, err := api_keys.GetApiKey(key.KeyId)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), uint64(1600000000), stored.LastUsed)
+	assert.Equal(self.T(), "10.0.0.1", stored.LastUsedFrom)
+	assert.Empty(self.T(), stored.Token)
+
+	// A wrong secret is rejected.
+	_, err = api_keys.Authenticate(key.KeyId+".XXXX", "10.0.0.1")
+	assert.True(self.T(), errors.Is(err, api_keys.InvalidKeyError))
+
+	// Rotate the key - the old token is valid for the grace period.
+	old_token := key.Token
+	rotated, err := api_keys.RotateApiKey(self.ConfigObj, key.KeyId, 60)
+	assert.NoError(self.T(), err)
+	assert.NotEqual(self.T(), old_token, rotated.Token)
+
+	_, err = api_keys.Authenticate(rotated.Token, "10.0.0.1")
+	assert.NoError(self.T(), err)
+
+	_, err = api_keys.Authenticate(old_token, "10.0.0.1")
+	assert.NoError(self.T(), err)
+
+	clock.MockNow = clock.MockNow.Add(2 * time.Minute)
+	_, err = api_keys.Authenticate(old_token, "10.0.0.1")
+	assert.True(self.T(), errors.Is(err, api_keys.InvalidKeyError))
+
+	_, err = api_keys.Authenticate(rotated.Token, "10.0.0.1")
+	assert.NoError(self.T(), err)
+
+	// Keys are rejected after they expire.
+	clock.MockNow = clock.MockNow.Add(api_keys.DEFAULT_EXPIRY)
+	_, err = api_keys.Authenticate(rotated.Token, "10.0.0.1")
+	assert.True(self.T(), errors.Is(err, api_keys.InvalidKeyError))
+
+	// Deleting the key removes its principal.
+	assert.NoError(self.T(), api_keys.DeleteApiKey(
+		ctx, self.ConfigObj, key.KeyId))
+
+	_, err = api_keys.GetApiKey(key.KeyId)
+	assert.Error(self.T(), err)
+
+	ok, _ = services.CheckAccess(self.ConfigObj, key.KeyId, acls.READ_RESULTS)
+	assert.False(self.T(), ok)
+}
+
+func (self *ApiKeysTestSuite) TestOrgs() {
+	ctx := context.Background()
+
+	org_manager, err := services.GetOrgManager()
+	assert.NoError(self.T(), err)
+
+	_, err = org_manager.CreateNewOrg("O1", "O1")
+	assert.NoError(self.T(), err)
+
+	org_config, err := org_manager.GetOrgConfig("O1")
+	assert.NoError(self.T(), err)
+
+	key, err := api_keys.CreateApiKey(ctx, org_config, "admin",
+		&api_proto.ApiKey{
+			Policy: &acl_proto.ApiClientACL{Roles: []string{"reader"}},
+		})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "O1", key.OrgId)
+
+	// The key is only visible in its own org.
+	keys, err := api_keys.ListApiKeys(org_config)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(keys))
+
+	keys, err = api_keys.ListApiKeys(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 0, len(keys))
+
+	// And can not be managed from other orgs.
+	_, err = api_keys.RotateApiKey(self.ConfigObj, key.KeyId, 0)
+	assert.Error(self.T(), err)
+
+	assert.Error(self.T(), api_keys.DeleteApiKey(
+		ctx, self.ConfigObj, key.KeyId))
+}
+
+func TestApiKeys(t *testing.T) {
+	suite.Run(t, &ApiKeysTestSuite{})
+}
@@ -0,0 +1,530 @@
+/*
+  Dual authorization for dangerous operations.
+
+  When the approval_policy section is configured, collecting an
+  artifact which requires one of the policy's permissions (by default
+  EXECVE or FILESYSTEM_WRITE), or whose name matches one of the
+  policy's artifact regexes (e.g. the Quarantine artifacts), is
+  refused by the launcher and the hunt dispatcher with
+  acls.ApprovalRequired.
+
+  Instead the user files an approval request with a justification. A
+  second user, who must themselves hold the permissions required by
+  the operation, approves or rejects the request. Once approved, the
+  operation is launched as the original requester.
+
+  Requests are stored in the org's datastore under /approvals/ and
+  keep a full record of the decision. Every change is also sent to
+  the Server.Internal.Approval event artifact so users can be
+  notified (e.g. by email rules).
+*/
+
+package approvals
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"google.golang.org/protobuf/proto"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+)
+
+const (
+	APPROVAL_PREFIX = "AP."
+
+	STATE_PENDING  = "PENDING"
+	STATE_APPROVED = "APPROVED"
+	STATE_REJECTED = "REJECTED"
+	STATE_EXPIRED  = "EXPIRED"
+	STATE_FAILED   = "FAILED"
+
+	DEFAULT_EXPIRY_HOURS = 24
+
+	EVENT_ARTIFACT = "Server.Internal.Approval"
+)
+
+var (
+	InvalidApprovalError = errors.New("Invalid approval request")
+
+	defaultPermissions = []string{"EXECVE", "FILESYSTEM_WRITE"}
+)
+
+func NewApprovalId() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+
+	binary.BigEndian.PutUint32(buf, uint32(utils.GetTime().Now().Unix()))
+	result := base32.HexEncoding.EncodeToString(buf)[:13]
+
+	return APPROVAL_PREFIX + result
+}
+
+// Get the reasons the artifacts require approval. No reasons means
+// the artifacts may be collected without approval.
+func GetReasons(config_obj *config_proto.Config,
+	repository services.Repository, artifacts []string) ([]string, error) {
+	policy := config_obj.ApprovalPolicy
+	if policy == nil {
+		return nil, nil
+	}
+
+	permissions := policy.Permissions
+	if len(permissions) == 0 {
+		permissions = defaultPermissions
+	}
+
+	var regexes []*regexp.Regexp
+	for _, re := range policy.Artifacts {
+		compiled, err := regexp.Compile(re)
+		if err != nil {
+			return nil, fmt.Errorf("approval_policy: %w", err)
+		}
+		regexes = append(regexes, compiled)
+	}
+
+	var result []string
+	for _, name := range artifacts {
+		for _, re := range regexes {
+			if re.MatchString(name) {
+				result = append(result, fmt.Sprintf(
+					"%v is a restricted artifact", name))
+				break
+			}
+		}
+
+		artifact, pres := repository.Get(config_obj, name)
+		if !pres {
+			continue
+		}
+
+		for _, perm := range artifact.RequiredPermissions {
+			for _, restricted := range permissions {
+				if acls.GetPermission(perm) == acls.GetPermission(restricted) {
+					result = append(result, fmt.Sprintf(
+						"%v requires %v", name, acls.GetPermission(perm)))
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// The approved request being launched is carried in the context so
+// an approval can only be used by launch().
+type approvalContextKey struct{}
+
+func withApproval(ctx context.Context,
+	request *api_proto.ApprovalRequest) context.Context {
+	return context.WithValue(ctx, approvalContextKey{}, request)
+}
+
+// Called by the launcher and hunt dispatcher before launching an
+// operation on behalf of a principal. Internal calls without a
+// principal do not need approval.
+func CheckApproval(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	acl_manager vql_subsystem.ACLManager,
+	repository services.Repository,
+	collector_request *flows_proto.ArtifactCollectorArgs) error {
+
+	principal_manager, ok := acl_manager.(vql_subsystem.PrincipalACLManager)
+	if !ok {
+		return nil
+	}
+
+	principal := principal_manager.GetPrincipal()
+	if principal == "" || (config_obj.Client != nil &&
+		principal == config_obj.Client.PinnedServerName) {
+		return nil
+	}
+
+	reasons, err := GetReasons(config_obj, repository,
+		collector_request.Artifacts)
+	if err != nil || len(reasons) == 0 {
+		return err
+	}
+
+	approval_id := collector_request.ApprovalId
+	if approval_id == "" {
+		return fmt.Errorf("%w: %v", acls.ApprovalRequired,
+			strings.Join(reasons, ", "))
+	}
+
+	request, ok := ctx.Value(approvalContextKey{}).(*api_proto.ApprovalRequest)
+	if !ok || request.ApprovalId != approval_id ||
+		request.State != STATE_APPROVED ||
+		request.Requester != principal {
+		return fmt.Errorf("%w: %v is not approved", acls.ApprovalRequired,
+			approval_id)
+	}
+
+	// The operation must be exactly the one that was approved,
+	// including its parameters.
+	approved := getOperation(request)
+	if approved == nil {
+		return fmt.Errorf("%w: %v approves a different operation",
+			acls.ApprovalRequired, approval_id)
+	}
+
+	approved = proto.Clone(approved).(*flows_proto.ArtifactCollectorArgs)
+	approved.ApprovalId = approval_id
+	if !proto.Equal(approved, collector_request) {
+		return fmt.Errorf("%w: %v approves a different operation",
+			acls.ApprovalRequired, approval_id)
+	}
+
+	return nil
+}
+
+// The collection the request will launch. For hunts this is the
+// hunt's start request.
+func getOperation(
+	request *api_proto.ApprovalRequest) *flows_proto.ArtifactCollectorArgs {
+	if request.Collection != nil {
+		return request.Collection
+	}
+	return request.Hunt.GetStartRequest()
+}
+
+func GetApproval(config_obj *config_proto.Config,
+	approval_id string) (*api_proto.ApprovalRequest, error) {
+	result := &api_proto.ApprovalRequest{}
+	err := datastore.GetExistingSubject(config_obj,
+		paths.APPROVALS_ROOT.AddChild(approval_id), result)
+	if err != nil {
+		return nil, err
+	}
+
+	checkExpiry(result)
+	return result, nil
+}
+
+// Pending requests expire without being written.
+func checkExpiry(request *api_proto.ApprovalRequest) {
+	if request.State == STATE_PENDING &&
+		uint64(utils.GetTime().Now().Unix()) >= request.Expires {
+		request.State = STATE_EXPIRED
+	}
+}
+
+func setApproval(config_obj *config_proto.Config,
+	request *api_proto.ApprovalRequest) error {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.SetSubject(config_obj,
+		paths.APPROVALS_ROOT.AddChild(request.ApprovalId), request)
+}
+
+// List the requests in the org, newest first, optionally only those
+// in a state.
+func ListApprovals(config_obj *config_proto.Config,
+	state string) ([]*api_proto.ApprovalRequest, error) {
+	children, err := datastore.ListSubjects(config_obj, paths.APPROVALS_ROOT)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api_proto.ApprovalRequest, 0, len(children))
+	for _, child := range children {
+		request, err := GetApproval(config_obj, child.Base())
+		if err != nil {
+			continue
+		}
+
+		if state != "" && request.State != state {
+			continue
+		}
+		result = append(result, request)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreateTime > result[j].CreateTime
+	})
+
+	return result, nil
+}
+
+// File a new request for the collection or hunt in the template.
+func RequestApproval(config_obj *config_proto.Config,
+	principal string, template *api_proto.ApprovalRequest) (
+	*api_proto.ApprovalRequest, error) {
+
+	if (template.Collection == nil) == (template.Hunt == nil) {
+		return nil, fmt.Errorf(
+			"%w: exactly one of a collection or a hunt must be given",
+			InvalidApprovalError)
+	}
+
+	if template.Justification == "" {
+		return nil, fmt.Errorf("%w: a justification is required",
+			InvalidApprovalError)
+	}
+
+	request := &api_proto.ApprovalRequest{
+		ApprovalId:    NewApprovalId(),
+		Requester:     principal,
+		Justification: template.Justification,
+		State:         STATE_PENDING,
+	}
+
+	if template.Collection != nil {
+		request.Collection = proto.Clone(
+			template.Collection).(*flows_proto.ArtifactCollectorArgs)
+		request.Collection.Creator = principal
+		request.Collection.CompiledCollectorArgs = nil
+	} else {
+		request.Hunt = proto.Clone(template.Hunt).(*api_proto.Hunt)
+		request.Hunt.Creator = principal
+
+		// Approvals are per org.
+		if len(request.Hunt.OrgIds) > 0 {
+			return nil, fmt.Errorf(
+				"%w: hunts in other orgs can not be approved",
+				InvalidApprovalError)
+		}
+	}
+
+	operation := getOperation(request)
+	if operation == nil || len(operation.Artifacts) == 0 {
+		return nil, fmt.Errorf("%w: no artifacts to collect",
+			InvalidApprovalError)
+	}
+
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	repository, err := manager.GetGlobalRepository(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Reasons, err = GetReasons(
+		config_obj, repository, operation.Artifacts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(request.Reasons) == 0 {
+		return nil, fmt.Errorf("%w: the operation does not require approval",
+			InvalidApprovalError)
+	}
+
+	expiry_hours := config_obj.ApprovalPolicy.ExpiryHours
+	if expiry_hours == 0 {
+		expiry_hours = DEFAULT_EXPIRY_HOURS
+	}
+
+	now := utils.GetTime().Now()
+	request.CreateTime = uint64(now.Unix())
+	request.Expires = uint64(now.Add(
+		time.Duration(expiry_hours) * time.Hour).Unix())
+
+	err = setApproval(config_obj, request)
+	if err != nil {
+		return nil, err
+	}
+
+	notify(config_obj, request)
+
+	return request, nil
+}
+
+// The approver must hold the permissions needed to launch the
+// operation themselves.
+func checkApprover(config_obj *config_proto.Config,
+	repository services.Repository,
+	approver string, request *api_proto.ApprovalRequest) error {
+
+	if approver == request.Requester {
+		return fmt.Errorf("%w: requests must be approved by another user",
+			acls.PermissionDenied)
+	}
+
+	operation := getOperation(request)
+	permissions := []acls.ACL_PERMISSION{acls.COLLECT_CLIENT}
+	if operation.ClientId == "server" {
+		permissions = []acls.ACL_PERMISSION{acls.COLLECT_SERVER}
+	}
+
+	for _, name := range operation.Artifacts {
+		artifact, pres := repository.Get(config_obj, name)
+		if !pres {
+			continue
+		}
+		for _, perm := range artifact.RequiredPermissions {
+			permissions = append(permissions, acls.GetPermission(perm))
+		}
+	}
+
+	for _, perm := range permissions {
+		ok, err := services.CheckAccess(config_obj, approver, perm)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: approver needs %v",
+				acls.PermissionDenied, perm)
+		}
+	}
+
+	return nil
+}
+
+// Approve or reject the request. Approved operations are launched
+// immediately as the requester.
+func Decide(ctx context.Context, config_obj *config_proto.Config,
+	approver string, decision *api_proto.ApprovalDecision) (
+	*api_proto.ApprovalRequest, error) {
+
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	repository, err := manager.GetGlobalRepository(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &api_proto.ApprovalRequest{}
+	err = datastore.ModifySubject(config_obj,
+		paths.APPROVALS_ROOT.AddChild(decision.ApprovalId), request,
+		func(exists bool) error {
+			if !exists {
+				return os.ErrNotExist
+			}
+
+			checkExpiry(request)
+			if request.State != STATE_PENDING {
+				return fmt.Errorf("%w: request is %v",
+					InvalidApprovalError, request.State)
+			}
+
+			err := checkApprover(config_obj, repository, approver, request)
+			if err != nil {
+				return err
+			}
+
+			request.Approver = approver
+			request.Comment = decision.Comment
+			request.DecisionTime = uint64(utils.GetTime().Now().Unix())
+
+			if !decision.Approve {
+				request.State = STATE_REJECTED
+				return nil
+			}
+
+			// Record the approval before launching so a failed
+			// launch is never retried.
+			request.State = STATE_APPROVED
+			err = setApproval(config_obj, request)
+			if err != nil {
+				return err
+			}
+
+			err = launch(ctx, config_obj, repository, request)
+			if err != nil {
+				request.State = STATE_FAILED
+				request.Error = err.Error()
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	notify(config_obj, request)
+
+	return request, nil
+}
+
+// Launch the approved operation as the requester.
+func launch(ctx context.Context, config_obj *config_proto.Config,
+	repository services.Repository,
+	request *api_proto.ApprovalRequest) error {
+
+	ctx = withApproval(ctx, request)
+
+	// The requester's own permissions still apply.
+	acl_manager := acl_managers.NewServerACLManager(
+		config_obj, request.Requester)
+
+	if request.Collection != nil {
+		collection := proto.Clone(
+			request.Collection).(*flows_proto.ArtifactCollectorArgs)
+		collection.ApprovalId = request.ApprovalId
+
+		launcher, err := services.GetLauncher(config_obj)
+		if err != nil {
+			return err
+		}
+
+		request.FlowId, err = launcher.ScheduleArtifactCollection(
+			ctx, config_obj, acl_manager, repository, collection, nil)
+		return err
+	}
+
+	hunt := proto.Clone(request.Hunt).(*api_proto.Hunt)
+	hunt.StartRequest.ApprovalId = request.ApprovalId
+
+	dispatcher, err := services.GetHuntDispatcher(config_obj)
+	if err != nil {
+		return err
+	}
+
+	request.HuntId, err = dispatcher.CreateHunt(
+		ctx, config_obj, acl_manager, hunt)
+	return err
+}
+
+// Send the request to the event artifact so users can be notified.
+func notify(config_obj *config_proto.Config,
+	request *api_proto.ApprovalRequest) {
+	journal, err := services.GetJournal(config_obj)
+	if err != nil {
+		return
+	}
+
+	operation := getOperation(request)
+	journal.PushRowsToArtifactAsync(config_obj,
+		ordereddict.NewDict().
+			Set("ApprovalId", request.ApprovalId).
+			Set("State", request.State).
+			Set("Requester", request.Requester).
+			Set("Justification", request.Justification).
+			Set("Reasons", request.Reasons).
+			Set("ClientId", operation.GetClientId()).
+			Set("Artifacts", operation.GetArtifacts()).
+			Set("Approver", request.Approver).
+			Set("Comment", request.Comment).
+			Set("FlowId", request.FlowId).
+			Set("HuntId", request.HuntId).
+			Set("Error", request.Error),
+		EVENT_ARTIFACT)
+}
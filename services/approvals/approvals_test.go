@@ -0,0 +1,279 @@
+package approvals_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+	"www.velocidex.com/golang/velociraptor/acls"
+	acl_proto "www.velocidex.com/golang/velociraptor/acls/proto"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/approvals"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+)
+
+type ApprovalsTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *ApprovalsTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Services.HuntDispatcher = true
+	self.ConfigObj.ApprovalPolicy = &config_proto.ApprovalPolicyConfig{
+		Artifacts: []string{`\.Quarantine$`},
+	}
+	self.LoadArtifacts([]string{`
+name: Server.Internal.Approval
+type: SERVER_EVENT
+`, `
+name: Custom.Safe
+sources:
+- query: SELECT * FROM info()
+`, `
+name: Custom.Exec
+required_permissions:
+- EXECVE
+sources:
+- query: SELECT * FROM info()
+`, `
+name: Custom.Quarantine
+sources:
+- query: SELECT * FROM info()
+`})
+	self.TestSuite.SetupTest()
+
+	for user, policy := range map[string]*acl_proto.ApiClientACL{
+		"requester": {CollectClient: true, Execve: true},
+		"approver":  {Roles: []string{"administrator"}},
+		"reader":    {Roles: []string{"reader"}},
+	} {
+		assert.NoError(self.T(), services.SetPolicy(self.ConfigObj, user, policy))
+	}
+}
+
+func (self *ApprovalsTestSuite) collect(
+	principal string, request *flows_proto.ArtifactCollectorArgs) (string, error) {
+	manager, err := services.GetRepositoryManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	repository, err := manager.GetGlobalRepository(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	launcher, err := services.GetLauncher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	return launcher.ScheduleArtifactCollection(self.Ctx, self.ConfigObj,
+		acl_managers.NewServerACLManager(self.ConfigObj, principal),
+		repository, request, nil)
+}
+
+func (self *ApprovalsTestSuite) TestCollection() {
+	// Safe artifacts do not need approval.
+	_, err := self.collect("requester", &flows_proto.ArtifactCollectorArgs{
+		ClientId:  "C.1234",
+		Artifacts: []string{"Custom.Safe"},
+	})
+	assert.NoError(self.T(), err)
+
+	request := &flows_proto.ArtifactCollectorArgs{
+		ClientId:  "C.1234",
+		Artifacts: []string{"Custom.Exec"},
+	}
+	_, err = self.collect("requester", request)
+	assert.ErrorIs(self.T(), err, acls.ApprovalRequired)
+
+	// Internal calls do not need approval.
+	_, err = self.collect(
+		self.ConfigObj.Client.PinnedServerName, request)
+	assert.NoError(self.T(), err)
+
+	// A justification is required.
+	_, err = approvals.RequestApproval(self.ConfigObj, "requester",
+		&api_proto.ApprovalRequest{Collection: request})
+	assert.Error(self.T(), err)
+
+	pending, err := approvals.RequestApproval(self.ConfigObj, "requester",
+		&api_proto.ApprovalRequest{
+			Collection:    request,
+			Justification: "Incident 42",
+		})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), approvals.STATE_PENDING, pending.State)
+	assert.Equal(self.T(), []string{"Custom.Exec requires EXECVE"},
+		pending.Reasons)
+
+	// Users can not use a pending approval.
+	request.ApprovalId = pending.ApprovalId
+	_, err = self.collect("requester", request)
+	assert.ErrorIs(self.T(), err, acls.ApprovalRequired)
+
+	// The requester can not approve their own request.
+	decision := &api_proto.ApprovalDecision{
+		ApprovalId: pending.ApprovalId,
+		Approve:    true,
+	}
+	_, err = approvals.Decide(self.Ctx, self.ConfigObj, "requester", decision)
+	assert.ErrorIs(self.T(), err, acls.PermissionDenied)
+
+	// Neither can users who may not launch the operation.
+	_, err = approvals.Decide(self.Ctx, self.ConfigObj, "reader", decision)
+	assert.ErrorIs(self.T(), err, acls.PermissionDenied)
+
+	approved, err := approvals.Decide(
+		self.Ctx, self.ConfigObj, "approver", decision)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), approvals.STATE_APPROVED, approved.State)
+	assert.Equal(self.T(), "approver", approved.Approver)
+	assert.True(self.T(), strings.HasPrefix(approved.FlowId, "F."))
+
+	// The approval can only be used once.
+	_, err = self.collect("requester", request)
+	assert.ErrorIs(self.T(), err, acls.ApprovalRequired)
+
+	_, err = approvals.Decide(self.Ctx, self.ConfigObj, "approver", decision)
+	assert.Error(self.T(), err)
+
+	// The request records the decision.
+	stored, err := approvals.GetApproval(self.ConfigObj, pending.ApprovalId)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), approved.FlowId, stored.FlowId)
+	assert.Equal(self.T(), "Incident 42", stored.Justification)
+}
+
+func (self *ApprovalsTestSuite) TestRejectAndExpire() {
+	clock := &utils.MockClock{MockNow: time.Unix(1600000000, 0)}
+	defer utils.MockTime(clock)()
+
+	request := &flows_proto.ArtifactCollectorArgs{
+		ClientId:  "C.1234",
+		Artifacts: []string{"Custom.Quarantine"},
+	}
+
+	first, err := approvals.RequestApproval(self.ConfigObj, "requester",
+		&api_proto.ApprovalRequest{
+			Collection:    request,
+			Justification: "Contain host",
+		})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), []string{
+		"Custom.Quarantine is a restricted artifact"}, first.Reasons)
+
+	rejected, err := approvals.Decide(self.Ctx, self.ConfigObj, "approver",
+		&api_proto.ApprovalDecision{
+			ApprovalId: first.ApprovalId,
+			Comment:    "Not this host",
+		})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), approvals.STATE_REJECTED, rejected.State)
+	assert.Equal(self.T(), "", rejected.FlowId)
+
+	second, err := approvals.RequestApproval(self.ConfigObj, "requester",
+		&api_proto.ApprovalRequest{
+			Collection:    request,
+			Justification: "Contain host",
+		})
+	assert.NoError(self.T(), err)
+
+	// Pending requests expire.
+	clock.MockNow = clock.MockNow.Add(25 * time.Hour)
+	_, err = approvals.Decide(self.Ctx, self.ConfigObj, "approver",
+		&api_proto.ApprovalDecision{
+			ApprovalId: second.ApprovalId,
+			Approve:    true,
+		})
+	assert.Error(self.T(), err)
+
+	expired, err := approvals.ListApprovals(
+		self.ConfigObj, approvals.STATE_EXPIRED)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(expired))
+	assert.Equal(self.T(), second.ApprovalId, expired[0].ApprovalId)
+}
+
+func (self *ApprovalsTestSuite) TestHunt() {
+	hunt := &api_proto.Hunt{
+		StartRequest: &flows_proto.ArtifactCollectorArgs{
+			Artifacts: []string{"Custom.Exec"},
+		},
+	}
+
+	dispatcher, err := services.GetHuntDispatcher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	_, err = dispatcher.CreateHunt(self.Ctx, self.ConfigObj,
+		acl_managers.NewServerACLManager(self.ConfigObj, "requester"), hunt)
+	assert.ErrorIs(self.T(), err, acls.ApprovalRequired)
+
+	pending, err := approvals.RequestApproval(self.ConfigObj, "requester",
+		&api_proto.ApprovalRequest{
+			Hunt:          hunt,
+			Justification: "Sweep",
+		})
+	assert.NoError(self.T(), err)
+
+	approved, err := approvals.Decide(self.Ctx, self.ConfigObj, "approver",
+		&api_proto.ApprovalDecision{
+			ApprovalId: pending.ApprovalId,
+			Approve:    true,
+		})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), approvals.STATE_APPROVED, approved.State)
+	assert.True(self.T(), strings.HasPrefix(approved.HuntId, "H."))
+}
+
+func (self *ApprovalsTestSuite) TestCheckApproval() {
+	manager, err := services.GetRepositoryManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	repository, err := manager.GetGlobalRepository(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	acl_manager := acl_managers.NewServerACLManager(self.ConfigObj, "requester")
+	approved := &api_proto.ApprovalRequest{
+		ApprovalId: "AP.1234",
+		State:      approvals.STATE_APPROVED,
+		Requester:  "requester",
+		Collection: &flows_proto.ArtifactCollectorArgs{
+			ClientId:  "C.1234",
+			Artifacts: []string{"Custom.Exec"},
+			Specs: []*flows_proto.ArtifactSpec{{
+				Artifact: "Custom.Exec",
+				Parameters: &flows_proto.ArtifactParameters{
+					Env: []*actions_proto.VQLEnv{{Key: "Command", Value: "id"}},
+				},
+			}},
+		},
+	}
+
+	request := proto.Clone(approved.Collection).(*flows_proto.ArtifactCollectorArgs)
+	request.ApprovalId = approved.ApprovalId
+
+	// The approval is only honored when launched with it.
+	err = approvals.CheckApproval(self.Ctx, self.ConfigObj,
+		acl_manager, repository, request)
+	assert.ErrorIs(self.T(), err, acls.ApprovalRequired)
+
+	ctx := approvals.WithApproval(self.Ctx, approved)
+	err = approvals.CheckApproval(ctx, self.ConfigObj,
+		acl_manager, repository, request)
+	assert.NoError(self.T(), err)
+
+	// Changing the parameters needs a new approval.
+	request.Specs[0].Parameters.Env[0].Value = "rm -rf /"
+	err = approvals.CheckApproval(ctx, self.ConfigObj,
+		acl_manager, repository, request)
+	assert.ErrorIs(self.T(), err, acls.ApprovalRequired)
+}
+
+func TestApprovals(t *testing.T) {
+	suite.Run(t, &ApprovalsTestSuite{})
+}
@@ -0,0 +1,4 @@
+package approvals
+
+// Launch the operation with an approved request, as launch() does.
+var WithApproval = withApproval
@@ -0,0 +1,534 @@
+/*
+  Syncs custom artifacts from a git repository.
+
+  Teams that keep their artifacts in git want changes to go through
+  code review rather than being edited in the GUI. This service
+  periodically (or when a push webhook arrives) clones the configured
+  branch and compares the artifacts in it with the live repository.
+
+  Changes are not applied immediately. They are staged together with
+  the commit they came from and only become live once an
+  administrator approves that commit. Approving a commit adds and
+  modifies the changed artifacts and deletes artifacts which were
+  previously installed from the repository but have since been
+  removed from it. A rejected commit is not staged again - the next
+  commit to the branch will be.
+
+  Only the root org syncs from the repository.
+*/
+
+package artifact_sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/artifact_tests"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	ACTION_ADD    = "ADD"
+	ACTION_MODIFY = "MODIFY"
+	ACTION_DELETE = "DELETE"
+)
+
+var (
+	// Serialize syncs and approvals.
+	sync_mu sync.Mutex
+
+	// Signals the service to sync now.
+	sync_chan = make(chan bool, 1)
+
+	NotConfiguredError = errors.New("Artifact repository sync is not configured")
+)
+
+// Ask the service to sync as soon as possible. Used by the webhook.
+func Notify() {
+	select {
+	case sync_chan <- true:
+	default:
+	}
+}
+
+func GetState(config_obj *config_proto.Config) (*api_proto.ArtifactSyncState, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing was synced yet.
+	state := &api_proto.ArtifactSyncState{}
+	err = db.GetSubject(config_obj, paths.ARTIFACT_SYNC_STATE, state)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func setState(config_obj *config_proto.Config,
+	state *api_proto.ArtifactSyncState) error {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.SetSubject(config_obj, paths.ARTIFACT_SYNC_STATE, state)
+}
+
+// Fetch the branch and stage the changes it would make to the live
+// artifacts.
+func Sync(ctx context.Context,
+	config_obj *config_proto.Config) (*api_proto.ArtifactSyncState, error) {
+	sync_config := config_obj.ArtifactRepoSync
+	if sync_config == nil || sync_config.Url == "" {
+		return nil, NotConfiguredError
+	}
+
+	sync_mu.Lock()
+	defer sync_mu.Unlock()
+
+	state, err := GetState(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	state.Url = sync_config.Url
+	state.Branch = getBranch(sync_config)
+	state.LastSync = uint64(utils.GetTime().Now().Unix())
+
+	commit, files, err := fetchArtifacts(ctx, sync_config)
+	if err != nil {
+		state.LastError = err.Error()
+		_ = setState(config_obj, state)
+		return nil, err
+	}
+	state.LastError = ""
+
+	// Already reviewed.
+	if commit == state.StagedCommit || commit == state.LiveCommit ||
+		commit == state.RejectedCommit {
+		return state, setState(config_obj, state)
+	}
+
+	changes, err := diffArtifacts(config_obj, sync_config, state, files)
+	if err != nil {
+		return nil, err
+	}
+
+	state.StagedCommit = ""
+	state.StagedTime = 0
+	state.Changes = nil
+
+	// Nothing to approve so this commit is already live.
+	if len(changes) == 0 {
+		state.LiveCommit = commit
+		return state, setState(config_obj, state)
+	}
+
+	state.StagedCommit = commit
+	state.StagedTime = state.LastSync
+	state.Changes = changes
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("ArtifactSync: Staged %v changes from commit %v for approval",
+		len(changes), commit)
+
+	return state, setState(config_obj, state)
+}
+
+// Compare the artifacts in the repository with the live artifacts.
+func diffArtifacts(config_obj *config_proto.Config,
+	sync_config *config_proto.ArtifactRepoSyncConfig,
+	state *api_proto.ArtifactSyncState,
+	files map[string]string) ([]*api_proto.ArtifactSyncChange, error) {
+
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	repository, err := manager.GetGlobalRepository(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := getPrefix(sync_config)
+	result := []*api_proto.ArtifactSyncChange{}
+	seen := make(map[string]bool)
+
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		definition := files[filename]
+		change := &api_proto.ArtifactSyncChange{
+			Filename:   filename,
+			Action:     ACTION_ADD,
+			Definition: definition,
+		}
+
+		artifact, err := manager.NewRepository().LoadYaml(definition,
+			services.ValidateArtifact, !services.ArtifactIsBuiltIn)
+		if err != nil {
+			change.Error = err.Error()
+			result = append(result, change)
+			continue
+		}
+		change.Name = artifact.Name
+
+		if !strings.HasPrefix(artifact.Name, prefix) {
+			change.Error = fmt.Sprintf(
+				"Artifact name must start with '%v'", prefix)
+			result = append(result, change)
+			continue
+		}
+
+		if seen[artifact.Name] {
+			change.Error = "Artifact is defined in more than one file"
+			result = append(result, change)
+			continue
+		}
+		seen[artifact.Name] = true
+
+		current, pres := repository.Get(config_obj, artifact.Name)
+		if pres {
+			if current.Raw == definition {
+				continue
+			}
+			change.Action = ACTION_MODIFY
+			change.Previous = current.Raw
+		}
+
+		result = append(result, change)
+	}
+
+	// Artifacts we installed before that were removed from the
+	// repository.
+	for _, name := range state.Artifacts {
+		if seen[name] {
+			continue
+		}
+
+		current, pres := repository.Get(config_obj, name)
+		if !pres {
+			continue
+		}
+
+		result = append(result, &api_proto.ArtifactSyncChange{
+			Name:     name,
+			Action:   ACTION_DELETE,
+			Previous: current.Raw,
+		})
+	}
+
+	return result, nil
+}
+
+// Apply the staged changes. The commit must match the staged commit
+// so an approval is always for the changes that were reviewed.
+func Approve(ctx context.Context,
+	config_obj *config_proto.Config, principal, commit string) (
+	*api_proto.ArtifactSyncState, error) {
+	sync_config := config_obj.ArtifactRepoSync
+	if sync_config == nil {
+		return nil, NotConfiguredError
+	}
+
+	sync_mu.Lock()
+	defer sync_mu.Unlock()
+
+	state, err := GetState(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkStagedCommit(state, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	installed := make(map[string]bool)
+	for _, name := range state.Artifacts {
+		installed[name] = true
+	}
+
+	prefix := getPrefix(sync_config)
+	for _, change := range state.Changes {
+		if change.Error != "" {
+			continue
+		}
+
+		switch change.Action {
+		case ACTION_DELETE:
+			err = manager.DeleteArtifactFile(config_obj, principal, change.Name)
+			if err == nil {
+				delete(installed, change.Name)
+			}
+
+		default:
+			// Artifacts with failing tests are not activated.
+			err = artifact_tests.CheckArtifact(ctx, config_obj, principal,
+				change.Definition)
+			if err != nil {
+				break
+			}
+
+			_, err = manager.SetArtifactFile(config_obj, principal,
+				change.Definition, prefix)
+			if err == nil {
+				installed[change.Name] = true
+			}
+		}
+
+		if err != nil {
+			change.Error = err.Error()
+			continue
+		}
+
+		logging.LogAudit(config_obj, principal, "ArtifactSyncApprove",
+			logrus.Fields{
+				"commit":   commit,
+				"action":   change.Action,
+				"artifact": change.Name,
+			})
+	}
+
+	state.Artifacts = nil
+	for name := range installed {
+		state.Artifacts = append(state.Artifacts, name)
+	}
+	sort.Strings(state.Artifacts)
+
+	state.LiveCommit = commit
+	state.LiveTime = uint64(utils.GetTime().Now().Unix())
+	state.ApprovedBy = principal
+	state.StagedCommit = ""
+	state.StagedTime = 0
+
+	// Report the applied changes (with any errors applying them)
+	// but do not store them.
+	changes := state.Changes
+	state.Changes = nil
+	err = setState(config_obj, state)
+	state.Changes = changes
+
+	return state, err
+}
+
+// Discard the staged changes.
+func Reject(config_obj *config_proto.Config, principal, commit string) (
+	*api_proto.ArtifactSyncState, error) {
+	sync_mu.Lock()
+	defer sync_mu.Unlock()
+
+	state, err := GetState(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkStagedCommit(state, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.LogAudit(config_obj, principal, "ArtifactSyncReject",
+		logrus.Fields{
+			"commit": commit,
+		})
+
+	state.RejectedCommit = commit
+	state.RejectedBy = principal
+	state.StagedCommit = ""
+	state.StagedTime = 0
+	state.Changes = nil
+
+	return state, setState(config_obj, state)
+}
+
+func checkStagedCommit(state *api_proto.ArtifactSyncState, commit string) error {
+	if state.StagedCommit == "" {
+		return errors.New("No changes are staged")
+	}
+
+	if commit != state.StagedCommit {
+		return fmt.Errorf("Staged changes are for commit %v not %v",
+			state.StagedCommit, commit)
+	}
+
+	return nil
+}
+
+// Clone the branch and read all the artifacts in it. Returns the
+// commit and a map of file names (relative to the repository) to
+// definitions.
+func fetchArtifacts(ctx context.Context,
+	sync_config *config_proto.ArtifactRepoSyncConfig) (
+	string, map[string]string, error) {
+
+	tmpdir, err := ioutil.TempDir("", "artifact_sync")
+	if err != nil {
+		return "", nil, err
+	}
+	defer os.RemoveAll(tmpdir)
+
+	_, err = runGit(ctx, sync_config, "", "clone", "--quiet", "--depth", "1",
+		"--single-branch", "--branch", getBranch(sync_config),
+		"--", sync_config.Url, tmpdir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	commit, err := runGit(ctx, sync_config, tmpdir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", nil, err
+	}
+
+	root := filepath.Join(tmpdir, filepath.FromSlash(sync_config.Path))
+	files := make(map[string]string)
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(tmpdir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(name)] = string(data)
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return commit, files, nil
+}
+
+func runGit(ctx context.Context,
+	sync_config *config_proto.ArtifactRepoSyncConfig,
+	dir string, args ...string) (string, error) {
+	git_path := sync_config.GitPath
+	if git_path == "" {
+		git_path = "git"
+	}
+
+	sub_ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	cmd := exec.CommandContext(sub_ctx, git_path, args...)
+	cmd.Dir = dir
+
+	// Never prompt for credentials.
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %v: %w: %v", args[0], err,
+			strings.TrimSpace(string(output)))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+func getBranch(sync_config *config_proto.ArtifactRepoSyncConfig) string {
+	if sync_config.Branch == "" {
+		return "main"
+	}
+	return sync_config.Branch
+}
+
+func getPrefix(sync_config *config_proto.ArtifactRepoSyncConfig) string {
+	if sync_config.RequiredPrefix == "" {
+		return constants.ARTIFACT_CUSTOM_NAME_PREFIX
+	}
+	return sync_config.RequiredPrefix
+}
+
+func NewArtifactSyncService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	sync_config := config_obj.ArtifactRepoSync
+	if sync_config == nil || sync_config.Url == "" {
+		return nil
+	}
+
+	interval := time.Duration(3600) * time.Second
+	if sync_config.SyncPeriodSeconds > 0 {
+		interval = time.Duration(sync_config.SyncPeriodSeconds) * time.Second
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> artifact repository sync from %v",
+		sync_config.Url)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		// Sync as soon as we start.
+		delay := time.Duration(0)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-sync_chan:
+			case <-time.After(delay):
+			}
+
+			delay = interval
+			_, err := Sync(ctx, config_obj)
+			if err != nil {
+				logger.Error("ArtifactSync: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package artifact_sync_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/artifact_sync"
+)
+
+const (
+	helloArtifact = `
+name: Custom.Hello
+sources:
+- query: SELECT "Hello" FROM scope()
+`
+
+	helloArtifactV2 = `
+name: Custom.Hello
+sources:
+- query: SELECT "Hello World" FROM scope()
+`
+
+	goodbyeArtifact = `
+name: Custom.Goodbye
+sources:
+- query: SELECT "Goodbye" FROM scope()
+`
+
+	builtinArtifact = `
+name: Generic.Client.Info
+sources:
+- query: SELECT * FROM info()
+`
+)
+
+type ArtifactSyncTestSuite struct {
+	test_utils.TestSuite
+
+	git_dir string
+}
+
+func (self *ArtifactSyncTestSuite) SetupTest() {
+	_, err := exec.LookPath("git")
+	if err != nil {
+		self.T().Skip("git is not available")
+	}
+
+	self.TestSuite.SetupTest()
+
+	self.git_dir, err = ioutil.TempDir("", "artifact_sync_test")
+	assert.NoError(self.T(), err)
+
+	self.git("init", "--quiet", "--initial-branch", "main")
+
+	self.ConfigObj.ArtifactRepoSync = &config_proto.ArtifactRepoSyncConfig{
+		Url:  self.git_dir,
+		Path: "artifacts",
+	}
+}
+
+func (self *ArtifactSyncTestSuite) TearDownTest() {
+	self.TestSuite.TearDownTest()
+	os.RemoveAll(self.git_dir)
+}
+
+func (self *ArtifactSyncTestSuite) git(args ...string) {
+	args = append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"},
+		args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = self.git_dir
+	output, err := cmd.CombinedOutput()
+	assert.NoError(self.T(), err, string(output))
+}
+
+func (self *ArtifactSyncTestSuite) commit(files map[string]string) {
+	for name, data := range files {
+		path := filepath.Join(self.git_dir, "artifacts", name)
+		if data == "" {
+			assert.NoError(self.T(), os.Remove(path))
+			continue
+		}
+
+		assert.NoError(self.T(), os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(self.T(), ioutil.WriteFile(path, []byte(data), 0600))
+	}
+
+	self.git("add", "-A")
+	self.git("commit", "--quiet", "-m", "Update")
+}
+
+func (self *ArtifactSyncTestSuite) getArtifact(name string) (string, bool) {
+	manager, err := services.GetRepositoryManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	repository, err := manager.GetGlobalRepository(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	artifact, pres := repository.Get(self.ConfigObj, name)
+	if !pres {
+		return "", false
+	}
+	return artifact.Raw, true
+}
+
+func (self *ArtifactSyncTestSuite) TestSyncAndApprove() {
+	ctx := context.Background()
+
+	self.commit(map[string]string{
+		"Hello.yaml":   helloArtifact,
+		"Goodbye.yaml": goodbyeArtifact,
+		"Client.yaml":  builtinArtifact,
+	})
+
+	state, err := artifact_sync.Sync(ctx, self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.NotEqual(self.T(), "", state.StagedCommit)
+	assert.Equal(self.T(), 3, len(state.Changes))
+
+	// Artifacts outside the required prefix are refused.
+	assert.Equal(self.T(), "artifacts/Client.yaml", state.Changes[0].Filename)
+	assert.Contains(self.T(), state.Changes[0].Error, "Custom.")
+	assert.Equal(self.T(), "ADD", state.Changes[1].Action)
+
+	// Nothing is live until it is approved.
+	_, pres := self.getArtifact("Custom.Hello")
+	assert.False(self.T(), pres)
+
+	// Approvals must be for the staged commit.
+	_, err = artifact_sync.Approve(ctx, self.ConfigObj, "admin", "1234")
+	assert.Error(self.T(), err)
+
+	state, err = artifact_sync.Approve(ctx, self.ConfigObj, "admin", state.StagedCommit)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "admin", state.ApprovedBy)
+	assert.Equal(self.T(), []string{"Custom.Goodbye", "Custom.Hello"},
+		state.Artifacts)
+
+	_, pres = self.getArtifact("Custom.Hello")
+	assert.True(self.T(), pres)
+
+	// Syncing the same commit stages nothing.
+	state, err = artifact_sync.Sync(ctx, self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "", state.StagedCommit)
+
+	// Modify one artifact and remove the other.
+	self.commit(map[string]string{
+		"Hello.yaml":   helloArtifactV2,
+		"Goodbye.yaml": "",
+	})
+
+	state, err = artifact_sync.Sync(ctx, self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 3, len(state.Changes))
+	assert.Equal(self.T(), "MODIFY", state.Changes[1].Action)
+	assert.Equal(self.T(), helloArtifact, state.Changes[1].Previous)
+	assert.Equal(self.T(), "DELETE", state.Changes[2].Action)
+	assert.Equal(self.T(), "Custom.Goodbye", state.Changes[2].Name)
+
+	// A rejected commit is not staged again.
+	commit := state.StagedCommit
+	state, err = artifact_sync.Reject(self.ConfigObj, "admin", commit)
+	assert.NoError(self.T(), err)
+
+	state, err = artifact_sync.Sync(ctx, self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "", state.StagedCommit)
+	assert.Equal(self.T(), commit, state.RejectedCommit)
+
+	raw, _ := self.getArtifact("Custom.Hello")
+	assert.Equal(self.T(), helloArtifact, raw)
+
+	// The next commit is staged and applied.
+	self.commit(map[string]string{
+		"Client.yaml": "",
+	})
+
+	state, err = artifact_sync.Sync(ctx, self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 2, len(state.Changes))
+
+	state, err = artifact_sync.Approve(ctx, self.ConfigObj, "admin", state.StagedCommit)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), []string{"Custom.Hello"}, state.Artifacts)
+
+	raw, _ = self.getArtifact("Custom.Hello")
+	assert.Equal(self.T(), helloArtifactV2, raw)
+
+	_, pres = self.getArtifact("Custom.Goodbye")
+	assert.False(self.T(), pres)
+}
+
+func TestArtifactSync(t *testing.T) {
+	suite.Run(t, &ArtifactSyncTestSuite{})
+}
@@ -0,0 +1,438 @@
+/*
+  Runs the test cases embedded in artifact definitions.
+
+  An artifact may carry a list of tests. Each test writes its sample
+  files into a temporary directory, runs the artifact on the server
+  with the test's parameters and compares the rows with the expected
+  rows and the golden output.
+
+  Tests run on demand and before a new version of an artifact is
+  activated, so a broken parser is caught before it is collected
+  from the endpoints. The results of each run are stored against the
+  artifact version (the hash of its definition).
+
+  Tests run with the permissions of the user who runs them.
+*/
+
+package artifact_tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	// Replaced in parameter values with the directory holding the
+	// test's files.
+	TEST_DIR = "%TEST_DIR%"
+
+	testTimeout = time.Minute
+)
+
+var (
+	TestFailedError = errors.New("Artifact tests failed")
+
+	parameterRegex = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+)
+
+// A hash of the definition identifies the artifact version.
+func Version(definition string) string {
+	hash := sha256.Sum256([]byte(definition))
+	return hex.EncodeToString(hash[:])
+}
+
+// Run the tests of the artifact definition. The definition need not
+// be in the repository yet.
+func RunTests(ctx context.Context, config_obj *config_proto.Config,
+	principal, definition string) (*api_proto.ArtifactTestRun, error) {
+
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	global_repository, err := manager.GetGlobalRepository(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// Test the new definition without activating it.
+	repository := global_repository.Copy()
+	artifact, err := repository.LoadYaml(definition,
+		services.ValidateArtifact, !services.ArtifactIsBuiltIn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api_proto.ArtifactTestRun{
+		Artifact:  artifact.Name,
+		Version:   Version(definition),
+		Timestamp: uint64(utils.GetTime().Now().Unix()),
+		Principal: principal,
+		Passed:    true,
+	}
+
+	for _, test := range artifact.Tests {
+		test_result := runTest(ctx, config_obj, principal,
+			repository, artifact, test)
+		if !test_result.Passed {
+			result.Passed = false
+		}
+		result.Results = append(result.Results, test_result)
+	}
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.SetSubject(config_obj, paths.ARTIFACT_TESTS_ROOT.AddChild(
+		artifact.Name, result.Version), result)
+	return result, err
+}
+
+// Run the tests of an artifact in the repository.
+func RunTestsForArtifact(ctx context.Context, config_obj *config_proto.Config,
+	principal, name string) (*api_proto.ArtifactTestRun, error) {
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	repository, err := manager.GetGlobalRepository(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, pres := repository.Get(config_obj, name)
+	if !pres {
+		return nil, fmt.Errorf("Artifact %v not found", name)
+	}
+
+	return RunTests(ctx, config_obj, principal, artifact.Raw)
+}
+
+// Run the artifact's tests (if it has any) before it is
+// activated. Returns an error describing the failed tests.
+func CheckArtifact(ctx context.Context, config_obj *config_proto.Config,
+	principal, definition string) error {
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return err
+	}
+
+	artifact, err := manager.NewRepository().LoadYaml(definition,
+		services.ValidateArtifact, !services.ArtifactIsBuiltIn)
+	if err != nil {
+		return err
+	}
+
+	if len(artifact.Tests) == 0 {
+		return nil
+	}
+
+	result, err := RunTests(ctx, config_obj, principal, definition)
+	if err != nil {
+		return err
+	}
+
+	if result.Passed {
+		return nil
+	}
+
+	failed := []string{}
+	for _, test := range result.Results {
+		if !test.Passed {
+			failed = append(failed, fmt.Sprintf("%v: %v", test.Name, test.Error))
+		}
+	}
+
+	return fmt.Errorf("%w: %v", TestFailedError, strings.Join(failed, ", "))
+}
+
+// List the stored test runs of all versions of the artifact, most
+// recent first.
+func ListResults(config_obj *config_proto.Config,
+	name string) ([]*api_proto.ArtifactTestRun, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := db.ListChildren(config_obj,
+		paths.ARTIFACT_TESTS_ROOT.AddChild(name))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*api_proto.ArtifactTestRun{}
+	for _, child := range children {
+		if child.IsDir() {
+			continue
+		}
+
+		run := &api_proto.ArtifactTestRun{}
+		err := db.GetSubject(config_obj, child, run)
+		if err != nil || run.Artifact == "" {
+			continue
+		}
+		result = append(result, run)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Timestamp > result[j].Timestamp
+	})
+
+	return result, nil
+}
+
+func runTest(ctx context.Context, config_obj *config_proto.Config,
+	principal string, repository services.Repository,
+	artifact *artifacts_proto.Artifact,
+	test *artifacts_proto.ArtifactTest) *api_proto.ArtifactTestResult {
+
+	result := &api_proto.ArtifactTestResult{Name: test.Name}
+	log_buffer := &logBuffer{}
+
+	rows, err := runArtifact(ctx, config_obj, principal, repository,
+		artifact, test, log_buffer)
+	result.Log = log_buffer.Lines()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Rows = uint64(len(rows))
+	output, err := json.MarshalIndent(rows)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Output = string(output)
+
+	err = compareRows(output, test)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Passed = true
+	return result
+}
+
+func runArtifact(ctx context.Context, config_obj *config_proto.Config,
+	principal string, repository services.Repository,
+	artifact *artifacts_proto.Artifact,
+	test *artifacts_proto.ArtifactTest,
+	log_buffer *logBuffer) ([]*ordereddict.Dict, error) {
+
+	tmpdir, err := ioutil.TempDir("", "artifact_test")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpdir)
+
+	for _, file := range test.Files {
+		err = writeTestFile(tmpdir, file)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Pass the parameters in scope variables so they need not be
+	// escaped.
+	env := ordereddict.NewDict()
+	args := []string{}
+	if test.Source != "" {
+		env.Set("TestSource", test.Source)
+		args = append(args, "source=TestSource")
+	}
+
+	for idx, parameter := range test.Parameters {
+		if !parameterRegex.MatchString(parameter.Key) {
+			return nil, fmt.Errorf("Invalid parameter name %v", parameter.Key)
+		}
+
+		name := fmt.Sprintf("TestParameter%d", idx)
+		env.Set(name, strings.ReplaceAll(parameter.Value, TEST_DIR, tmpdir))
+		args = append(args, parameter.Key+"="+name)
+	}
+
+	query := fmt.Sprintf("SELECT * FROM Artifact.%s(%s)",
+		artifact.Name, strings.Join(args, ", "))
+	vql, err := vfilter.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	sub_ctx, cancel := context.WithTimeout(ctx, testTimeout)
+	defer cancel()
+
+	scope := manager.BuildScope(services.ScopeBuilder{
+		Config:     config_obj,
+		ACLManager: acl_managers.NewServerACLManager(config_obj, principal),
+		Env:        env,
+		Repository: repository,
+		Logger:     log.New(log_buffer, "", 0),
+	})
+	defer scope.Close()
+
+	rows := []*ordereddict.Dict{}
+	for row := range vql.Eval(sub_ctx, scope) {
+		// The source is the same for all rows so it need not be
+		// in the expected output.
+		dict := vfilter.RowToDict(sub_ctx, scope, row)
+		dict.Delete("_Source")
+		rows = append(rows, dict)
+	}
+
+	if sub_ctx.Err() != nil {
+		return nil, fmt.Errorf("Test timed out after %v", testTimeout)
+	}
+
+	return rows, nil
+}
+
+// Files may only be written into the test directory.
+func writeTestFile(tmpdir string, file *artifacts_proto.ArtifactTestFile) error {
+	name := filepath.Clean(filepath.FromSlash(file.Name))
+	if name == "." || filepath.IsAbs(name) ||
+		strings.HasPrefix(name, ".."+string(filepath.Separator)) || name == ".." {
+		return fmt.Errorf("Invalid test file name %v", file.Name)
+	}
+
+	data := []byte(file.Data)
+	if file.Base64 {
+		decoded, err := base64.StdEncoding.DecodeString(file.Data)
+		if err != nil {
+			return fmt.Errorf("Test file %v: %w", file.Name, err)
+		}
+		data = decoded
+	}
+
+	path := filepath.Join(tmpdir, name)
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// Compare the JSON encoded output with the test's expectations. The
+// expected rows are checked first as they report what is missing.
+func compareRows(output []byte, test *artifacts_proto.ArtifactTest) error {
+	actual := []interface{}{}
+	err := json.Unmarshal(output, &actual)
+	if err != nil {
+		return err
+	}
+
+	if test.Expected != "" {
+		expected := []map[string]interface{}{}
+		err := json.Unmarshal([]byte(test.Expected), &expected)
+		if err != nil {
+			return fmt.Errorf("Invalid expected rows: %w", err)
+		}
+
+		for _, expected_row := range expected {
+			if !containsRow(actual, expected_row) {
+				serialized, _ := json.Marshal(expected_row)
+				return fmt.Errorf("Expected row %v not found", string(serialized))
+			}
+		}
+	}
+
+	if test.Golden != "" {
+		golden := []interface{}{}
+		err := json.Unmarshal([]byte(test.Golden), &golden)
+		if err != nil {
+			return fmt.Errorf("Invalid golden output: %w", err)
+		}
+
+		if !reflect.DeepEqual(actual, golden) {
+			return errors.New("Output does not match the golden output")
+		}
+	}
+
+	return nil
+}
+
+func containsRow(rows []interface{}, expected map[string]interface{}) bool {
+	for _, row := range rows {
+		row_map, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		matched := true
+		for k, v := range expected {
+			if !reflect.DeepEqual(row_map[k], v) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Collects the messages logged by the query.
+type logBuffer struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (self *logBuffer) Write(b []byte) (int, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	// Skip the query stats.
+	line := string(bytes.TrimRight(b, "\n"))
+	if !strings.HasPrefix(line, "DEBUG:") {
+		self.lines = append(self.lines, line)
+	}
+	return len(b), nil
+}
+
+func (self *logBuffer) Lines() []string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return append([]string{}, self.lines...)
+}
@@ -0,0 +1,124 @@
+package artifact_tests_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/artifact_tests"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+	_ "www.velocidex.com/golang/velociraptor/vql/parsers/syslog"
+)
+
+const linesArtifact = `
+name: Custom.Test.Lines
+parameters:
+  - name: Path
+sources:
+  - query: |
+      SELECT Line FROM parse_lines(filename=Path)
+tests:
+  - name: Sample
+    parameters:
+      - key: Path
+        value: "%TEST_DIR%/logs/sample.txt"
+    files:
+      - name: logs/sample.txt
+        data: |
+          hello
+          world
+    expected: |
+      [{"Line": "world"}]
+    golden: |
+      [{"Line": "hello"}, {"Line": "world"}]
+  - name: Binary
+    parameters:
+      - key: Path
+        value: "%TEST_DIR%/sample.bin"
+    files:
+      - name: sample.bin
+        data: aGkK
+        base64: true
+    golden: |
+      [{"Line": "hi"}]
+`
+
+type ArtifactTestsTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *ArtifactTestsTestSuite) SetupTest() {
+	self.TestSuite.SetupTest()
+
+	err := services.GrantRoles(self.ConfigObj, "admin", []string{"administrator"})
+	assert.NoError(self.T(), err)
+}
+
+func (self *ArtifactTestsTestSuite) TestRunTests() {
+	ctx := context.Background()
+
+	run, err := artifact_tests.RunTests(ctx, self.ConfigObj, "admin", linesArtifact)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "Custom.Test.Lines", run.Artifact)
+	assert.Equal(self.T(), 2, len(run.Results))
+	for _, result := range run.Results {
+		assert.True(self.T(), result.Passed, result.Error)
+	}
+	assert.True(self.T(), run.Passed)
+	assert.Equal(self.T(), uint64(2), run.Results[0].Rows)
+
+	// A new version of the artifact which breaks the parser.
+	broken := strings.Replace(linesArtifact,
+		"SELECT Line FROM", "SELECT upcase(string=Line) AS Line FROM", 1)
+
+	err = artifact_tests.CheckArtifact(ctx, self.ConfigObj, "admin", broken)
+	assert.True(self.T(), errors.Is(err, artifact_tests.TestFailedError))
+	assert.Contains(self.T(), err.Error(), "Expected row")
+
+	// Results are kept for each version.
+	runs, err := artifact_tests.ListResults(self.ConfigObj, "Custom.Test.Lines")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 2, len(runs))
+
+	versions := map[string]bool{}
+	for _, run := range runs {
+		versions[run.Version] = run.Passed
+	}
+	assert.True(self.T(), versions[artifact_tests.Version(linesArtifact)])
+	assert.False(self.T(), versions[artifact_tests.Version(broken)])
+
+	// Artifacts without tests are not checked.
+	err = artifact_tests.CheckArtifact(ctx, self.ConfigObj, "admin", `
+name: Custom.NoTests
+sources:
+  - query: SELECT * FROM info()
+`)
+	assert.NoError(self.T(), err)
+}
+
+func (self *ArtifactTestsTestSuite) TestInvalidFiles() {
+	run, err := artifact_tests.RunTests(context.Background(),
+		self.ConfigObj, "admin", `
+name: Custom.Test.Escape
+sources:
+  - query: SELECT * FROM scope()
+tests:
+  - name: Escape
+    files:
+      - name: ../../escape.txt
+        data: Hello
+`)
+	assert.NoError(self.T(), err)
+	assert.False(self.T(), run.Passed)
+	assert.Contains(self.T(), run.Results[0].Error, "Invalid test file name")
+}
+
+func TestArtifactTests(t *testing.T) {
+	suite.Run(t, &ArtifactTestsTestSuite{})
+}
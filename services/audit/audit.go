@@ -0,0 +1,323 @@
+/*
+  The audit service maintains a tamper evident log of security
+  relevant operations.
+
+  All calls to logging.LogAudit() are written to the
+  Server.Audit.Logs event artifact in the root org. Each record
+  contains the hash of the previous record and a signature over its
+  own hash made with the frontend's private key. Removing, reordering
+  or modifying any record therefore breaks the chain, and a record
+  can not be forged without access to the server's key.
+*/
+
+package audit
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	artifact_paths "www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	AUDIT_ARTIFACT = "Server.Audit.Logs"
+)
+
+type AuditRecord struct {
+	Seq       uint64
+	Timestamp string
+	OrgId     string
+	Principal string
+	Operation string
+
+	// The details are kept serialized so the hash does not depend
+	// on how they are parsed.
+	Details string
+
+	PrevHash  string
+	Hash      string
+	Signature string
+}
+
+// The hash covers all the fields of the record and the hash of the
+// previous record.
+func (self *AuditRecord) CalculateHash() string {
+	hash := sha256.New()
+	for _, field := range []string{
+		self.PrevHash, fmt.Sprintf("%d", self.Seq), self.Timestamp,
+		self.OrgId, self.Principal, self.Operation, self.Details} {
+		hash.Write([]byte(field))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Calculate the record's hash and sign it with the key.
+func (self *AuditRecord) Sign(key *rsa.PrivateKey) error {
+	self.Hash = self.CalculateHash()
+
+	digest, err := hex.DecodeString(self.Hash)
+	if err != nil {
+		return err
+	}
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest)
+	if err != nil {
+		return err
+	}
+	self.Signature = hex.EncodeToString(signature)
+	return nil
+}
+
+func (self *AuditRecord) ToDict() *ordereddict.Dict {
+	return ordereddict.NewDict().
+		Set("Seq", self.Seq).
+		Set("Timestamp", self.Timestamp).
+		Set("OrgId", self.OrgId).
+		Set("Principal", self.Principal).
+		Set("Operation", self.Operation).
+		Set("Details", self.Details).
+		Set("PrevHash", self.PrevHash).
+		Set("Hash", self.Hash).
+		Set("Signature", self.Signature)
+}
+
+func RecordFromDict(row *ordereddict.Dict) *AuditRecord {
+	seq, _ := row.Get("Seq")
+	seq_int, _ := utils.ToInt64(seq)
+
+	getString := func(name string) string {
+		value, _ := row.GetString(name)
+		return value
+	}
+
+	return &AuditRecord{
+		Seq:       uint64(seq_int),
+		Timestamp: getString("Timestamp"),
+		OrgId:     getString("OrgId"),
+		Principal: getString("Principal"),
+		Operation: getString("Operation"),
+		Details:   getString("Details"),
+		PrevHash:  getString("PrevHash"),
+		Hash:      getString("Hash"),
+		Signature: getString("Signature"),
+	}
+}
+
+type AuditService struct {
+	mu sync.Mutex
+
+	// Records are always written to the root org.
+	config_obj *config_proto.Config
+	key        *rsa.PrivateKey
+
+	seq       uint64
+	last_hash string
+
+	exclude_methods []string
+	exporter        *Exporter
+}
+
+func (self *AuditService) WriteAudit(
+	config_obj *config_proto.Config,
+	principal, operation string, details logrus.Fields) {
+
+	err := self.write(config_obj, principal, operation, details)
+	if err != nil {
+		logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+		logger.Error("AuditService: %v", err)
+	}
+}
+
+func (self *AuditService) write(
+	config_obj *config_proto.Config,
+	principal, operation string, details logrus.Fields) error {
+
+	serialized, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	journal, err := services.GetJournal(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	// Records must be written in order so the chain is preserved.
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	record := &AuditRecord{
+		Seq:       self.seq + 1,
+		Timestamp: utils.GetTime().Now().UTC().Format(time.RFC3339Nano),
+		OrgId:     utils.NormalizedOrgId(config_obj.OrgId),
+		Principal: principal,
+		Operation: operation,
+		Details:   string(serialized),
+		PrevHash:  self.last_hash,
+	}
+	err = record.Sign(self.key)
+	if err != nil {
+		return err
+	}
+
+	row := record.ToDict()
+	err = journal.PushRowsToArtifact(self.config_obj,
+		[]*ordereddict.Dict{row}, AUDIT_ARTIFACT, "server", "")
+	if err != nil {
+		return err
+	}
+
+	self.seq = record.Seq
+	self.last_hash = record.Hash
+
+	if self.exporter != nil {
+		self.exporter.Export(row)
+	}
+
+	return nil
+}
+
+// Should the API method be audited?
+func (self *AuditService) ShouldAuditMethod(method string) bool {
+	for _, excluded := range self.exclude_methods {
+		if strings.HasSuffix(method, "/"+excluded) || method == excluded {
+			return false
+		}
+	}
+	return true
+}
+
+// Find the last record written so new records continue the chain.
+func (self *AuditService) loadChainHead(ctx context.Context) error {
+	path_manager, err := artifact_paths.NewArtifactPathManager(
+		self.config_obj, "server", "", AUDIT_ARTIFACT)
+	if err != nil {
+		return err
+	}
+
+	file_store_factory := file_store.GetFileStore(self.config_obj)
+	reader, err := result_sets.NewTimedResultSetReader(
+		ctx, file_store_factory, path_manager)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	files := reader.GetAvailableFiles(ctx)
+	for i := len(files) - 1; i >= 0; i-- {
+		rs_reader, err := result_sets.NewResultSetReader(
+			file_store_factory, files[i].Path)
+		if err != nil {
+			continue
+		}
+
+		total_rows := rs_reader.TotalRows()
+		if total_rows <= 0 || rs_reader.SeekToRow(total_rows-1) != nil {
+			rs_reader.Close()
+			continue
+		}
+
+		for row := range rs_reader.Rows(ctx) {
+			record := RecordFromDict(row)
+			self.seq = record.Seq
+			self.last_hash = record.Hash
+		}
+		rs_reader.Close()
+		return nil
+	}
+
+	return nil
+}
+
+var (
+	mu      sync.Mutex
+	service *AuditService
+)
+
+// Returns the running audit service or nil if it is not running.
+func GetAuditService() *AuditService {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return service
+}
+
+func NewAuditService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	if config_obj.Audit != nil && config_obj.Audit.Disabled {
+		return nil
+	}
+
+	if config_obj.Frontend == nil {
+		return errors.New("AuditService: frontend not configured")
+	}
+
+	key, err := crypto_utils.ParseRsaPrivateKeyFromPemStr(
+		[]byte(config_obj.Frontend.PrivateKey))
+	if err != nil {
+		return fmt.Errorf("AuditService: %w", err)
+	}
+
+	self := &AuditService{
+		config_obj: config_obj,
+		key:        key,
+	}
+
+	err = self.loadChainHead(ctx)
+	if err != nil {
+		return fmt.Errorf("AuditService: %w", err)
+	}
+
+	if config_obj.Audit != nil {
+		self.exclude_methods = config_obj.Audit.ExcludeApiMethods
+		if config_obj.Audit.ExportUrl != "" {
+			self.exporter = NewExporter(ctx, wg, config_obj)
+		}
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> audit service at sequence %v.", self.seq)
+
+	mu.Lock()
+	service = self
+	mu.Unlock()
+
+	remove := logging.SetAuditWriter(self.WriteAudit)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		<-ctx.Done()
+		remove()
+
+		mu.Lock()
+		service = nil
+		mu.Unlock()
+	}()
+
+	return nil
+}
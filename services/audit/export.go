@@ -0,0 +1,137 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/vql/networking"
+)
+
+const (
+	// Records queued while the export endpoint is slow. Beyond this
+	// records are dropped from the export (they remain in the audit
+	// log).
+	exportQueueSize = 10000
+	exportInterval  = time.Second
+)
+
+// The Exporter streams audit records to an external SIEM as JSONL
+// over HTTP.
+type Exporter struct {
+	config_obj *config_proto.Config
+	queue      chan *ordereddict.Dict
+	batch_size int
+}
+
+// Queue a record for export. Never blocks.
+func (self *Exporter) Export(row *ordereddict.Dict) {
+	select {
+	case self.queue <- row:
+	default:
+		logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+		logger.Error("AuditService: export queue full, dropping record")
+	}
+}
+
+func (self *Exporter) send(ctx context.Context,
+	client *http.Client, batch []*ordereddict.Dict) error {
+
+	serialized, err := json.MarshalJsonl(batch)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		self.config_obj.Audit.ExportUrl, bytes.NewReader(serialized))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if self.config_obj.Audit.ExportAuthorization != "" {
+		req.Header.Set("Authorization", self.config_obj.Audit.ExportAuthorization)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("export endpoint returned %v", resp.Status)
+	}
+	return nil
+}
+
+func (self *Exporter) run(ctx context.Context) {
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+
+	client, err := networking.GetDefaultHTTPClient(self.config_obj.Client, "")
+	if err != nil {
+		logger.Error("AuditService: export disabled: %v", err)
+		return
+	}
+
+	var batch []*ordereddict.Dict
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		err := self.send(ctx, client, batch)
+		if err != nil {
+			logger.Error("AuditService: exporting %v records: %v",
+				len(batch), err)
+		}
+		batch = nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			flush()
+			return
+
+		case row := <-self.queue:
+			batch = append(batch, row)
+			if len(batch) >= self.batch_size {
+				flush()
+			}
+
+		case <-time.After(exportInterval):
+			flush()
+		}
+	}
+}
+
+func NewExporter(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) *Exporter {
+
+	batch_size := int(config_obj.Audit.ExportBatchSize)
+	if batch_size == 0 {
+		batch_size = 100
+	}
+
+	self := &Exporter{
+		config_obj: config_obj,
+		queue:      make(chan *ordereddict.Dict, exportQueueSize),
+		batch_size: batch_size,
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		self.run(ctx)
+	}()
+
+	return self
+}
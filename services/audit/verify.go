@@ -0,0 +1,193 @@
+package audit
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	artifact_paths "www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+)
+
+// The Verifier checks a sequence of audit records for breaks in the
+// chain and invalid signatures.
+type Verifier struct {
+	key *rsa.PublicKey
+
+	started   bool
+	first_seq uint64
+	last_seq  uint64
+	last_hash string
+
+	records uint64
+	errors  uint64
+}
+
+// Check the next record. Returns an error describing the problem if
+// the record does not follow the previous one or was not signed by
+// the server.
+func (self *Verifier) Check(record *AuditRecord) (err error) {
+	self.records++
+	defer func() {
+		if err != nil {
+			self.errors++
+		}
+
+		// Continue verifying from this record so a single
+		// break is only reported once.
+		self.started = true
+		self.last_seq = record.Seq
+		self.last_hash = record.Hash
+	}()
+
+	if !self.started {
+		self.first_seq = record.Seq
+
+	} else if record.Seq != self.last_seq+1 {
+		return fmt.Errorf("Sequence gap: expected %v got %v",
+			self.last_seq+1, record.Seq)
+
+	} else if record.PrevHash != self.last_hash {
+		return errors.New("Previous hash does not match the preceding record")
+	}
+
+	if record.CalculateHash() != record.Hash {
+		return errors.New("Record hash mismatch: record was modified")
+	}
+
+	digest, err := hex.DecodeString(record.Hash)
+	if err != nil {
+		return err
+	}
+
+	signature, err := hex.DecodeString(record.Signature)
+	if err != nil {
+		return err
+	}
+
+	err = rsa.VerifyPKCS1v15(self.key, crypto.SHA256, digest, signature)
+	if err != nil {
+		return errors.New("Invalid signature")
+	}
+
+	return nil
+}
+
+func (self *Verifier) Summary() *ordereddict.Dict {
+	return ordereddict.NewDict().
+		Set("Records", self.records).
+		Set("Errors", self.errors).
+		Set("FirstSeq", self.first_seq).
+		Set("LastSeq", self.last_seq).
+		Set("LastHash", self.last_hash).
+		Set("Verified", self.errors == 0)
+}
+
+func NewVerifier(config_obj *config_proto.Config) (*Verifier, error) {
+	if config_obj.Frontend == nil {
+		return nil, errors.New("Frontend not configured")
+	}
+
+	cert, err := crypto_utils.ParseX509CertFromPemStr(
+		[]byte(config_obj.Frontend.Certificate))
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("Frontend certificate does not have an RSA key")
+	}
+
+	return &Verifier{key: key}, nil
+}
+
+// Read the audit records between start and end (if not zero).
+func ReadRecords(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	start, end time.Time) (<-chan *ordereddict.Dict, error) {
+
+	path_manager, err := artifact_paths.NewArtifactPathManager(
+		config_obj, "server", "", AUDIT_ARTIFACT)
+	if err != nil {
+		return nil, err
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	reader, err := result_sets.NewTimedResultSetReader(
+		ctx, file_store_factory, path_manager)
+	if err != nil {
+		return nil, err
+	}
+
+	if !start.IsZero() {
+		err = reader.SeekToTime(start)
+		if err != nil {
+			reader.Close()
+			return nil, err
+		}
+	}
+
+	if !end.IsZero() {
+		reader.SetMaxTime(end)
+	}
+
+	output_chan := make(chan *ordereddict.Dict)
+	go func() {
+		defer close(output_chan)
+		defer reader.Close()
+
+		for row := range reader.Rows(ctx) {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan, nil
+}
+
+// Verify the audit log between start and end. Each problem found is
+// passed to the callback and a summary is returned.
+func Verify(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	start, end time.Time,
+	problem func(row *ordereddict.Dict)) (*ordereddict.Dict, error) {
+
+	verifier, err := NewVerifier(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ReadRecords(ctx, config_obj, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for row := range rows {
+		record := RecordFromDict(row)
+		err := verifier.Check(record)
+		if err != nil {
+			problem(ordereddict.NewDict().
+				Set("Seq", record.Seq).
+				Set("Timestamp", record.Timestamp).
+				Set("Principal", record.Principal).
+				Set("Operation", record.Operation).
+				Set("Error", err.Error()))
+		}
+	}
+
+	return verifier.Summary(), nil
+}
@@ -0,0 +1,386 @@
+/*
+  Online backups of the datastore and file store.
+
+  Backups used to be made by stopping the server and copying the
+  datastore directory with rsync. The backup service instead takes
+  snapshots while the server is running:
+
+  1. The datastore and file store directories are walked and each
+     file is copied into the backup repository, stored by the SHA256
+     hash of its contents. Files which did not change since the
+     previous snapshot (same size and modification time) are not read
+     again and identical contents are only stored once, so snapshots
+     are incremental.
+
+  2. While the snapshot runs, the write barrier records every file
+     the server writes. These files are copied again after the walk.
+
+  3. Finally the barrier holds back all writes, the datastore is
+     flushed and the last files written are copied. The snapshot is
+     consistent as of this moment.
+
+  Each snapshot is described by a manifest listing every file with
+  its hash. The manifest is stored with its own hash and all contents
+  are verified when they are restored. Restoring a snapshot (see
+  `velociraptor backup restore`) rebuilds the datastore as of any
+  snapshot time.
+
+  Writes made by other processes (e.g. minions writing to a shared
+  file store) are not held back by the barrier. Snapshots taken with
+  `velociraptor backup create` while the server is running are not
+  consistent - stop the server first.
+*/
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	DEFAULT_INTERVAL  = 24 * time.Hour
+	DEFAULT_RETENTION = 7
+
+	// Files written during the walk are copied again until fewer
+	// than this remain, then the rest are copied with writes held
+	// back.
+	MAX_FROZEN_FILES = 1000
+	MAX_CATCHUP      = 5
+)
+
+var (
+	notSupportedError = errors.New("Not supported")
+
+	// Only one snapshot may run at the same time.
+	mu sync.Mutex
+)
+
+func IsEnabled(config_obj *config_proto.Config) bool {
+	return config_obj.Datastore != nil &&
+		config_obj.Datastore.Backup != nil &&
+		config_obj.Datastore.Backup.Location != ""
+}
+
+func GetRepository(config_obj *config_proto.Config) (*Repository, error) {
+	if !IsEnabled(config_obj) {
+		return nil, errors.New("Datastore.backup.location is not configured")
+	}
+	return NewRepository(config_obj.Datastore.Backup.Location)
+}
+
+type root struct {
+	name string
+	path string
+}
+
+// The directories to back up. The file store is usually in the
+// datastore directory.
+func getRoots(config_obj *config_proto.Config) []root {
+	result := []root{{
+		name: "datastore",
+		path: filepath.Clean(config_obj.Datastore.Location),
+	}}
+
+	filestore := filepath.Clean(config_obj.Datastore.FilestoreDirectory)
+	if config_obj.Datastore.FilestoreDirectory != "" &&
+		filestore != result[0].path {
+		result = append(result, root{name: "filestore", path: filestore})
+	}
+	return result
+}
+
+type snapshotter struct {
+	repository *Repository
+	roots      []root
+
+	// Never back up the repository itself.
+	exclude string
+
+	previous map[string]*FileEntry
+	entries  map[string]*FileEntry
+	manifest *Manifest
+}
+
+// Copy the file into the snapshot if it changed.
+func (self *snapshotter) addFile(root root, path string, info os.FileInfo) error {
+	relative, err := filepath.Rel(root.path, path)
+	if err != nil {
+		return err
+	}
+
+	entry := &FileEntry{
+		Root:    root.name,
+		Path:    filepath.ToSlash(relative),
+		Size:    info.Size(),
+		ModTime: info.ModTime().UnixNano(),
+	}
+
+	previous, pres := self.previous[entry.key()]
+	if pres && previous.Size == entry.Size &&
+		previous.ModTime == entry.ModTime &&
+		self.repository.HasBlob(previous.Sha256) {
+		entry.Sha256 = previous.Sha256
+
+	} else {
+		hash, stored, err := self.repository.StoreFile(path)
+		if err != nil {
+			// The file was removed while we were walking.
+			if errors.Is(err, os.ErrNotExist) {
+				delete(self.entries, entry.key())
+				return nil
+			}
+			return err
+		}
+
+		entry.Sha256 = hash
+		if stored > 0 {
+			self.manifest.NewFiles++
+			self.manifest.NewBytes += stored
+		}
+	}
+
+	self.entries[entry.key()] = entry
+	return nil
+}
+
+func (self *snapshotter) walk(ctx context.Context, root root) error {
+	return filepath.Walk(root.path,
+		func(path string, info os.FileInfo, err error) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			// Files may be removed while we walk.
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+
+			if info.IsDir() {
+				if path == self.exclude {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+
+			return self.addFile(root, path, info)
+		})
+}
+
+// Copy the files written since the last time.
+func (self *snapshotter) copyDirty(filenames []string) error {
+	for _, filename := range filenames {
+		filename = filepath.Clean(filename)
+		if self.exclude != "" && strings.HasPrefix(filename, self.exclude) {
+			continue
+		}
+
+		for _, root := range self.roots {
+			if !strings.HasPrefix(filename, root.path+string(filepath.Separator)) {
+				continue
+			}
+
+			info, err := os.Lstat(filename)
+			if errors.Is(err, os.ErrNotExist) {
+				relative, _ := filepath.Rel(root.path, filename)
+				delete(self.entries, root.name+":"+filepath.ToSlash(relative))
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			if info.Mode().IsRegular() {
+				err = self.addFile(root, filename, info)
+				if err != nil {
+					return err
+				}
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func flush(config_obj *config_proto.Config) {
+	db, err := datastore.GetDB(config_obj)
+	if err == nil {
+		flusher, ok := db.(api.Flusher)
+		if ok {
+			flusher.Flush()
+		}
+	}
+
+	flusher, ok := file_store.GetFileStore(config_obj).(api.Flusher)
+	if ok {
+		flusher.Flush()
+	}
+}
+
+// Take a snapshot of the datastore and file store.
+func Snapshot(ctx context.Context,
+	config_obj *config_proto.Config) (*Manifest, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	repository, err := GetRepository(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	started := utils.GetTime().Now().UTC()
+	self := &snapshotter{
+		repository: repository,
+		roots:      getRoots(config_obj),
+		exclude:    filepath.Clean(config_obj.Datastore.Backup.Location),
+		previous:   make(map[string]*FileEntry),
+		entries:    make(map[string]*FileEntry),
+		manifest: &Manifest{
+			Id:      started.Format(SNAPSHOT_ID_FORMAT),
+			Started: started.Unix(),
+		},
+	}
+
+	_, err = os.Stat(repository.manifestPath(self.manifest.Id))
+	if err == nil {
+		return nil, errors.New("A snapshot was already taken at " +
+			self.manifest.Id)
+	}
+
+	// Unchanged files keep the hash they had in the last snapshot.
+	previous, err := repository.FindSnapshot(time.Time{})
+	if err == nil {
+		self.manifest.Parent = previous.Id
+		for _, entry := range previous.Files {
+			self.previous[entry.key()] = entry
+		}
+	}
+
+	gBarrier.startTracking()
+	defer gBarrier.stopTracking()
+
+	for _, root := range self.roots {
+		err := self.walk(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Catch up with the files written during the walk.
+	for i := 0; i < MAX_CATCHUP; i++ {
+		dirty := gBarrier.takeDirty()
+		err := self.copyDirty(dirty)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(dirty) < MAX_FROZEN_FILES {
+			break
+		}
+	}
+
+	// Hold back writes while the last files are copied.
+	err = func() error {
+		gBarrier.freeze.Lock()
+		defer gBarrier.freeze.Unlock()
+
+		flush(config_obj)
+		self.manifest.Time = utils.GetTime().Now().Unix()
+		return self.copyDirty(gBarrier.takeDirty())
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range self.entries {
+		self.manifest.Files = append(self.manifest.Files, entry)
+		self.manifest.TotalFiles++
+		self.manifest.TotalBytes += entry.Size
+	}
+
+	sort.Slice(self.manifest.Files, func(i, j int) bool {
+		return self.manifest.Files[i].key() < self.manifest.Files[j].key()
+	})
+
+	self.manifest.Completed = utils.GetTime().Now().Unix()
+	err = repository.SaveManifest(self.manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	retention := int(config_obj.Datastore.Backup.Retention)
+	if retention == 0 {
+		retention = DEFAULT_RETENTION
+	}
+
+	_, err = repository.Prune(retention)
+	return self.manifest, err
+}
+
+func NewBackupService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	if !IsEnabled(config_obj) {
+		return nil
+	}
+
+	interval := DEFAULT_INTERVAL
+	if config_obj.Datastore.Backup.IntervalSec > 0 {
+		interval = time.Duration(
+			config_obj.Datastore.Backup.IntervalSec) * time.Second
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> backup service: snapshots to %v every %v.",
+		config_obj.Datastore.Backup.Location, interval)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(interval):
+			}
+
+			manifest, err := Snapshot(ctx, config_obj)
+			if err != nil {
+				logger.Error("BackupService: %v", err)
+				continue
+			}
+
+			logger.Info("BackupService: Snapshot %v has %v files (%v bytes), "+
+				"%v new files (%v bytes) were copied in %v seconds.",
+				manifest.Id, manifest.TotalFiles, manifest.TotalBytes,
+				manifest.NewFiles, manifest.NewBytes,
+				manifest.Completed-manifest.Started)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+func writeFile(t *testing.T, filename, data string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(filename), 0700))
+	assert.NoError(t, ioutil.WriteFile(filename, []byte(data), 0600))
+}
+
+func readFile(t *testing.T, filename string) string {
+	data, err := ioutil.ReadFile(filename)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func TestBackupRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	datastore_dir := filepath.Join(dir, "datastore")
+	config_obj := &config_proto.Config{
+		Datastore: &config_proto.DatastoreConfig{
+			Implementation:     "FileBaseDataStore",
+			Location:           datastore_dir,
+			FilestoreDirectory: datastore_dir,
+			Backup: &config_proto.DatastoreBackupConfig{
+				// The repository is inside the datastore and must
+				// not be backed up itself.
+				Location:  filepath.Join(datastore_dir, "backups"),
+				Retention: 2,
+			},
+		},
+	}
+
+	clock := &utils.MockClock{MockNow: time.Unix(1600000000, 0)}
+	closer := utils.MockTime(clock)
+	defer closer()
+
+	writeFile(t, filepath.Join(datastore_dir, "clients/C.1.db"), "client")
+	writeFile(t, filepath.Join(datastore_dir, "clients/C.2.db"), "client")
+	writeFile(t, filepath.Join(datastore_dir, "flows/F.1.json"), "first")
+
+	ctx := context.Background()
+	first, err := Snapshot(ctx, config_obj)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), first.TotalFiles)
+
+	// Both clients have the same contents so they are stored once.
+	assert.Equal(t, int64(2), first.NewFiles)
+
+	// A second snapshot only copies the changes.
+	clock.MockNow = clock.MockNow.Add(time.Hour)
+	writeFile(t, filepath.Join(datastore_dir, "flows/F.1.json"), "second")
+	writeFile(t, filepath.Join(datastore_dir, "flows/F.2.json"), "new")
+	assert.NoError(t, os.Remove(filepath.Join(datastore_dir, "clients/C.2.db")))
+
+	second, err := Snapshot(ctx, config_obj)
+	assert.NoError(t, err)
+	assert.Equal(t, first.Id, second.Parent)
+	assert.Equal(t, int64(3), second.TotalFiles)
+	assert.Equal(t, int64(2), second.NewFiles)
+
+	// Restore to the time of the first snapshot.
+	output := filepath.Join(dir, "restored")
+	restored, err := Restore(ctx, config_obj, RestoreOptions{
+		Time:   time.Unix(first.Time, 0).Add(time.Minute),
+		Output: output,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, first.Id, restored.Id)
+
+	assert.Equal(t, "first", readFile(t, filepath.Join(output, "flows/F.1.json")))
+	assert.Equal(t, "client", readFile(t, filepath.Join(output, "clients/C.2.db")))
+
+	_, err = os.Stat(filepath.Join(output, "flows/F.2.json"))
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = os.Stat(filepath.Join(output, "backups"))
+	assert.True(t, os.IsNotExist(err))
+
+	// Restoring requires an empty directory.
+	_, err = Restore(ctx, config_obj, RestoreOptions{Output: output})
+	assert.Error(t, err)
+
+	// Corrupted contents are detected.
+	repository, err := GetRepository(config_obj)
+	assert.NoError(t, err)
+
+	for _, entry := range second.Files {
+		if entry.Path == "flows/F.2.json" {
+			writeFile(t, repository.blobPath(entry.Sha256), "corrupted")
+		}
+	}
+
+	err = Verify(ctx, repository, second)
+	assert.True(t, errors.Is(err, IntegrityError))
+	assert.NoError(t, Verify(ctx, repository, first))
+
+	// Only the most recent snapshots are kept.
+	clock.MockNow = clock.MockNow.Add(time.Hour)
+	_, err = Snapshot(ctx, config_obj)
+	assert.NoError(t, err)
+
+	ids, err := repository.ListSnapshots()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(ids))
+	assert.Equal(t, second.Id, ids[0])
+}
+
+func TestWriteBarrier(t *testing.T) {
+	barrier := newWriteBarrier()
+
+	// Writes are only recorded while a snapshot runs.
+	barrier.enter("/a")()
+	barrier.startTracking()
+	barrier.enter("/b", "/c")()
+	assert.Equal(t, 2, len(barrier.takeDirty()))
+	assert.Equal(t, 0, len(barrier.takeDirty()))
+
+	// Writes wait while the barrier is frozen.
+	barrier.freeze.Lock()
+	done := make(chan bool)
+	go func() {
+		barrier.enter("/d")()
+		done <- true
+	}()
+
+	select {
+	case <-done:
+		t.Fatalf("Write was not held back")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	barrier.freeze.Unlock()
+	<-done
+	assert.Equal(t, []string{"/d"}, barrier.takeDirty())
+
+	barrier.stopTracking()
+	barrier.enter("/e")()
+	assert.Equal(t, 0, len(barrier.takeDirty()))
+}
@@ -0,0 +1,265 @@
+package backup
+
+import (
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	gBarrier = newWriteBarrier()
+)
+
+// While a snapshot runs the barrier records the files written so
+// they can be copied again before the snapshot completes. To complete
+// the snapshot the barrier briefly holds back all writes so the
+// snapshot is consistent.
+type writeBarrier struct {
+	// Writes hold a read lock - freezing takes the write lock.
+	freeze sync.RWMutex
+
+	mu       sync.Mutex
+	tracking bool
+	dirty    map[string]bool
+}
+
+func newWriteBarrier() *writeBarrier {
+	return &writeBarrier{
+		dirty: make(map[string]bool),
+	}
+}
+
+// Called before a write to the filename. The returned function must
+// be called when the write is done.
+func (self *writeBarrier) enter(filenames ...string) func() {
+	self.freeze.RLock()
+
+	self.mu.Lock()
+	if self.tracking {
+		for _, filename := range filenames {
+			self.dirty[strings.TrimPrefix(
+				filename, datastore.WINDOWS_LFN_PREFIX)] = true
+		}
+	}
+	self.mu.Unlock()
+
+	return self.freeze.RUnlock
+}
+
+func (self *writeBarrier) startTracking() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.tracking = true
+	self.dirty = make(map[string]bool)
+}
+
+// Returns the files written since the last call.
+func (self *writeBarrier) takeDirty() []string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := make([]string, 0, len(self.dirty))
+	for filename := range self.dirty {
+		result = append(result, filename)
+	}
+	self.dirty = make(map[string]bool)
+	return result
+}
+
+func (self *writeBarrier) stopTracking() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.tracking = false
+	self.dirty = make(map[string]bool)
+}
+
+// Completions may write themselves so they must not run while the
+// write holds the barrier.
+func asyncCompletion(completion func()) func() {
+	if completion == nil || utils.CompareFuncs(completion, utils.SyncCompleter) {
+		return completion
+	}
+	return func() { go completion() }
+}
+
+// Passes datastore writes through the write barrier.
+type BarrierDataStore struct {
+	datastore.DataStore
+}
+
+func (self *BarrierDataStore) SetSubject(
+	config_obj *config_proto.Config,
+	urn api.DSPathSpec,
+	message proto.Message) error {
+	return self.SetSubjectWithCompletion(config_obj, urn, message, nil)
+}
+
+func (self *BarrierDataStore) SetSubjectWithCompletion(
+	config_obj *config_proto.Config,
+	urn api.DSPathSpec,
+	message proto.Message,
+	completion func()) error {
+	defer gBarrier.enter(urn.AsDatastoreFilename(config_obj))()
+
+	return self.DataStore.SetSubjectWithCompletion(
+		config_obj, urn, message, asyncCompletion(completion))
+}
+
+func (self *BarrierDataStore) DeleteSubject(
+	config_obj *config_proto.Config,
+	urn api.DSPathSpec) error {
+	return self.DeleteSubjectWithCompletion(config_obj, urn, nil)
+}
+
+func (self *BarrierDataStore) DeleteSubjectWithCompletion(
+	config_obj *config_proto.Config,
+	urn api.DSPathSpec, completion func()) error {
+	defer gBarrier.enter(urn.AsDatastoreFilename(config_obj))()
+
+	return self.DataStore.DeleteSubjectWithCompletion(
+		config_obj, urn, asyncCompletion(completion))
+}
+
+// Keep the wrapped datastore's locking across frontends.
+func (self *BarrierDataStore) LockSubject(
+	config_obj *config_proto.Config,
+	urn api.DSPathSpec) (func(), error) {
+	locker, ok := self.DataStore.(datastore.SubjectLocker)
+	if !ok {
+		return func() {}, nil
+	}
+	return locker.LockSubject(config_obj, urn)
+}
+
+// Support RawDataStore interface for the remote datastore API.
+func (self *BarrierDataStore) GetBuffer(
+	config_obj *config_proto.Config, urn api.DSPathSpec) ([]byte, error) {
+	raw_db, ok := self.DataStore.(datastore.RawDataStore)
+	if !ok {
+		return nil, notSupportedError
+	}
+	return raw_db.GetBuffer(config_obj, urn)
+}
+
+func (self *BarrierDataStore) SetBuffer(
+	config_obj *config_proto.Config, urn api.DSPathSpec,
+	data []byte, completion func()) error {
+	raw_db, ok := self.DataStore.(datastore.RawDataStore)
+	if !ok {
+		return notSupportedError
+	}
+
+	defer gBarrier.enter(urn.AsDatastoreFilename(config_obj))()
+	return raw_db.SetBuffer(config_obj, urn, data, asyncCompletion(completion))
+}
+
+func (self *BarrierDataStore) Flush() {
+	flusher, ok := self.DataStore.(api.Flusher)
+	if ok {
+		flusher.Flush()
+	}
+}
+
+// Passes file store writes through the write barrier.
+type BarrierFileStore struct {
+	api.FileStore
+
+	config_obj *config_proto.Config
+}
+
+func (self *BarrierFileStore) WriteFile(
+	filename api.FSPathSpec) (api.FileWriter, error) {
+	return self.WriteFileWithCompletion(filename, nil)
+}
+
+func (self *BarrierFileStore) WriteFileWithCompletion(
+	filename api.FSPathSpec, completion func()) (api.FileWriter, error) {
+	writer, err := self.FileStore.WriteFileWithCompletion(
+		filename, asyncCompletion(completion))
+	if err != nil {
+		return nil, err
+	}
+
+	return &barrierWriter{
+		FileWriter: writer,
+		filename:   filename.AsFilestoreFilename(self.config_obj),
+	}, nil
+}
+
+func (self *BarrierFileStore) Delete(filename api.FSPathSpec) error {
+	defer gBarrier.enter(filename.AsFilestoreFilename(self.config_obj))()
+	return self.FileStore.Delete(filename)
+}
+
+func (self *BarrierFileStore) Move(src, dest api.FSPathSpec) error {
+	defer gBarrier.enter(
+		src.AsFilestoreFilename(self.config_obj),
+		dest.AsFilestoreFilename(self.config_obj))()
+	return self.FileStore.Move(src, dest)
+}
+
+func (self *BarrierFileStore) Flush() {
+	flusher, ok := self.FileStore.(api.Flusher)
+	if ok {
+		flusher.Flush()
+	}
+}
+
+type barrierWriter struct {
+	api.FileWriter
+
+	filename string
+}
+
+func (self *barrierWriter) Write(data []byte) (int, error) {
+	defer gBarrier.enter(self.filename)()
+	return self.FileWriter.Write(data)
+}
+
+func (self *barrierWriter) Truncate() error {
+	defer gBarrier.enter(self.filename)()
+	return self.FileWriter.Truncate()
+}
+
+func (self *barrierWriter) Close() error {
+	defer gBarrier.enter(self.filename)()
+	return self.FileWriter.Close()
+}
+
+// Install the write barrier for this org. Must be called before any
+// services start writing.
+func InstallWriteBarrier(config_obj *config_proto.Config) error {
+	if !IsEnabled(config_obj) {
+		return nil
+	}
+
+	// The datastore is shared by all orgs and minions access it
+	// through the master.
+	if utils.IsRootOrg(config_obj.OrgId) && services.IsMaster(config_obj) {
+		db, err := datastore.GetDB(config_obj)
+		if err != nil {
+			return err
+		}
+		datastore.OverrideDatastoreImplementation(&BarrierDataStore{DataStore: db})
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	if file_store_factory != nil {
+		file_store.OverrideFilestoreImplementation(config_obj,
+			&BarrierFileStore{
+				FileStore:  file_store_factory,
+				config_obj: config_obj,
+			})
+	}
+
+	return nil
+}
@@ -0,0 +1,327 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/json"
+)
+
+const (
+	SNAPSHOT_ID_FORMAT = "20060102T150405Z"
+)
+
+var (
+	IntegrityError = errors.New("Backup integrity check failed")
+)
+
+// A file in a snapshot. The contents are stored in the repository
+// by their hash so unchanged files are only stored once.
+type FileEntry struct {
+	// The datastore or filestore directory the file is in.
+	Root string `json:"root"`
+
+	// Relative to the root, with / separators.
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mtime"`
+	Sha256  string `json:"sha256"`
+}
+
+func (self *FileEntry) key() string {
+	return self.Root + ":" + self.Path
+}
+
+// Describes a snapshot. Each manifest lists every file so a snapshot
+// can be restored on its own.
+type Manifest struct {
+	Id string `json:"id"`
+
+	// The snapshot the unchanged file hashes were taken from.
+	Parent string `json:"parent,omitempty"`
+
+	Started int64 `json:"started"`
+
+	// The snapshot is consistent as of this time - writes were held
+	// back while the last changes were copied.
+	Time int64 `json:"time"`
+
+	Completed int64 `json:"completed"`
+
+	// The files copied into the repository by this snapshot.
+	NewFiles int64 `json:"new_files"`
+	NewBytes int64 `json:"new_bytes"`
+
+	TotalFiles int64 `json:"total_files"`
+	TotalBytes int64 `json:"total_bytes"`
+
+	Files []*FileEntry `json:"files,omitempty"`
+}
+
+// A copy of the manifest without the file list.
+func (self *Manifest) Summary() *Manifest {
+	result := *self
+	result.Files = nil
+	return &result
+}
+
+// The backup repository is a directory holding the snapshot
+// manifests and the file contents.
+//
+//	snapshots/<id>.json         The manifest.
+//	snapshots/<id>.json.sha256  The hash of the manifest.
+//	blobs/<xx>/<sha256>         File contents by hash.
+type Repository struct {
+	location string
+}
+
+func NewRepository(location string) (*Repository, error) {
+	if location == "" {
+		return nil, errors.New("Backup location is not configured")
+	}
+
+	for _, dir := range []string{"snapshots", "blobs"} {
+		err := os.MkdirAll(filepath.Join(location, dir), 0700)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Repository{location: location}, nil
+}
+
+func (self *Repository) blobPath(hash string) string {
+	return filepath.Join(self.location, "blobs", hash[:2], hash)
+}
+
+func (self *Repository) manifestPath(id string) string {
+	return filepath.Join(self.location, "snapshots", id+".json")
+}
+
+func (self *Repository) HasBlob(hash string) bool {
+	_, err := os.Stat(self.blobPath(hash))
+	return err == nil
+}
+
+// Copy the file into the repository. Returns the hash of its contents
+// and the number of bytes stored (0 if the contents were already
+// stored).
+func (self *Repository) StoreFile(filename string) (string, int64, error) {
+	fd, err := os.Open(filename)
+	if err != nil {
+		return "", 0, err
+	}
+	defer fd.Close()
+
+	tmp, err := ioutil.TempFile(self.location, "blob")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hasher), fd)
+	tmp.Close()
+	if err != nil {
+		return "", 0, err
+	}
+
+	hash := hex.EncodeToString(hasher.Sum(nil))
+	if self.HasBlob(hash) {
+		return hash, 0, nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(self.blobPath(hash)), 0700)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return hash, size, os.Rename(tmp.Name(), self.blobPath(hash))
+}
+
+// Open the stored contents. The caller should verify the hash of the
+// data read.
+func (self *Repository) OpenBlob(hash string) (*os.File, error) {
+	if len(hash) != sha256.Size*2 {
+		return nil, fmt.Errorf("%w: invalid hash %v", IntegrityError, hash)
+	}
+	return os.Open(self.blobPath(hash))
+}
+
+// Check that the stored contents match their hash.
+func (self *Repository) VerifyBlob(hash string) error {
+	fd, err := self.OpenBlob(hash)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	hasher := sha256.New()
+	_, err = io.Copy(hasher, fd)
+	if err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(hasher.Sum(nil)) != hash {
+		return fmt.Errorf("%w: blob %v is corrupted", IntegrityError, hash)
+	}
+	return nil
+}
+
+func (self *Repository) SaveManifest(manifest *Manifest) error {
+	serialized, err := json.MarshalIndent(manifest)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256(serialized)
+
+	// The hash is written first so a manifest is never visible
+	// without it.
+	filename := self.manifestPath(manifest.Id)
+	err = writeFileAtomic(filename+".sha256",
+		[]byte(hex.EncodeToString(hash[:])))
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filename, serialized)
+}
+
+func (self *Repository) LoadManifest(id string) (*Manifest, error) {
+	filename := self.manifestPath(id)
+	serialized, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := ioutil.ReadFile(filename + ".sha256")
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(serialized)
+	if hex.EncodeToString(hash[:]) != strings.TrimSpace(string(expected)) {
+		return nil, fmt.Errorf("%w: manifest %v is corrupted",
+			IntegrityError, id)
+	}
+
+	result := &Manifest{}
+	err = json.Unmarshal(serialized, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// The ids of the snapshots, oldest first.
+func (self *Repository) ListSnapshots() ([]string, error) {
+	children, err := ioutil.ReadDir(filepath.Join(self.location, "snapshots"))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, child := range children {
+		id := strings.TrimSuffix(child.Name(), ".json")
+		if id == child.Name() {
+			continue
+		}
+
+		_, err := time.Parse(SNAPSHOT_ID_FORMAT, id)
+		if err != nil {
+			continue
+		}
+		result = append(result, id)
+	}
+
+	sort.Strings(result)
+	return result, nil
+}
+
+// The most recent snapshot consistent as of the time (the latest if
+// the time is zero).
+func (self *Repository) FindSnapshot(at time.Time) (*Manifest, error) {
+	ids, err := self.ListSnapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(ids) - 1; i >= 0; i-- {
+		manifest, err := self.LoadManifest(ids[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if at.IsZero() || !time.Unix(manifest.Time, 0).After(at) {
+			return manifest, nil
+		}
+	}
+
+	if at.IsZero() {
+		return nil, errors.New("No snapshots found")
+	}
+	return nil, fmt.Errorf("No snapshot found before %v", at.UTC())
+}
+
+// Remove all but the most recent snapshots and the contents only the
+// removed snapshots refer to. Returns the number of snapshots removed.
+func (self *Repository) Prune(retention int) (int, error) {
+	ids, err := self.ListSnapshots()
+	if err != nil {
+		return 0, err
+	}
+
+	if retention <= 0 || len(ids) <= retention {
+		return 0, nil
+	}
+
+	for _, id := range ids[:len(ids)-retention] {
+		filename := self.manifestPath(id)
+		err = os.Remove(filename)
+		if err != nil {
+			return 0, err
+		}
+		_ = os.Remove(filename + ".sha256")
+	}
+
+	// Keep the contents referenced by the remaining snapshots.
+	referenced := make(map[string]bool)
+	for _, id := range ids[len(ids)-retention:] {
+		manifest, err := self.LoadManifest(id)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, entry := range manifest.Files {
+			referenced[entry.Sha256] = true
+		}
+	}
+
+	err = filepath.Walk(filepath.Join(self.location, "blobs"),
+		func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || referenced[info.Name()] {
+				return nil
+			}
+			return os.Remove(path)
+		})
+
+	return len(ids) - retention, err
+}
+
+func writeFileAtomic(filename string, data []byte) error {
+	err := ioutil.WriteFile(filename+".tmp", data, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(filename+".tmp", filename)
+}
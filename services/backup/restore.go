@@ -0,0 +1,183 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+type RestoreOptions struct {
+	// Restore the most recent snapshot consistent as of this time
+	// (the latest snapshot if not set).
+	Time time.Time
+
+	// Restore this snapshot instead.
+	SnapshotId string
+
+	// Restore into this directory instead of the configured
+	// datastore and file store directories.
+	Output string
+}
+
+// Check the manifest and all the contents of the snapshot.
+func Verify(ctx context.Context,
+	repository *Repository, manifest *Manifest) error {
+	seen := make(map[string]bool)
+	var bad []string
+
+	for _, entry := range manifest.Files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if seen[entry.Sha256] {
+			continue
+		}
+		seen[entry.Sha256] = true
+
+		err := repository.VerifyBlob(entry.Sha256)
+		if err != nil {
+			bad = append(bad, entry.Path)
+		}
+	}
+
+	if len(bad) > 0 {
+		return fmt.Errorf("%w: %v files of snapshot %v are missing or "+
+			"corrupted: %v", IntegrityError, len(bad), manifest.Id,
+			strings.Join(bad, ", "))
+	}
+	return nil
+}
+
+// Restore a snapshot. The target directories must be empty - the
+// server must not be running.
+func Restore(ctx context.Context,
+	config_obj *config_proto.Config,
+	options RestoreOptions) (*Manifest, error) {
+
+	repository, err := GetRepository(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest *Manifest
+	if options.SnapshotId != "" {
+		manifest, err = repository.LoadManifest(options.SnapshotId)
+	} else {
+		manifest, err = repository.FindSnapshot(options.Time)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// A separate file store is restored into its own subdirectory of
+	// the output directory.
+	roots := getRoots(config_obj)
+	targets := make(map[string]string)
+	for _, root := range roots {
+		targets[root.name] = root.path
+		if options.Output != "" {
+			targets[root.name] = options.Output
+			if len(roots) > 1 {
+				targets[root.name] = filepath.Join(options.Output, root.name)
+			}
+		}
+	}
+
+	for _, target := range targets {
+		err := checkEmpty(target)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entry := range manifest.Files {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		target, pres := targets[entry.Root]
+		if !pres {
+			return nil, fmt.Errorf("Unknown root %v in snapshot", entry.Root)
+		}
+
+		err := restoreFile(repository, target, entry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return manifest, nil
+}
+
+func checkEmpty(dir string) error {
+	children, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(children) > 0 {
+		return fmt.Errorf("Can not restore into %v: directory is not empty", dir)
+	}
+	return nil
+}
+
+func restoreFile(repository *Repository, target string, entry *FileEntry) error {
+	// Make sure the manifest can not write outside the target.
+	relative := filepath.Clean(filepath.FromSlash(entry.Path))
+	if filepath.IsAbs(relative) || relative == ".." ||
+		strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%w: invalid path %v", IntegrityError, entry.Path)
+	}
+
+	filename := filepath.Join(target, relative)
+	err := os.MkdirAll(filepath.Dir(filename), 0700)
+	if err != nil {
+		return err
+	}
+
+	in, err := repository.OpenBlob(entry.Sha256)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(filename+".tmp",
+		os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	hasher := sha256.New()
+	_, err = io.Copy(io.MultiWriter(out, hasher), in)
+	out.Close()
+	if err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(hasher.Sum(nil)) != entry.Sha256 {
+		os.Remove(filename + ".tmp")
+		return fmt.Errorf("%w: contents of %v are corrupted",
+			IntegrityError, entry.Path)
+	}
+
+	err = os.Rename(filename+".tmp", filename)
+	if err != nil {
+		return err
+	}
+
+	mtime := time.Unix(0, entry.ModTime)
+	return os.Chtimes(filename, mtime, mtime)
+}
@@ -0,0 +1,331 @@
+/*
+  Cases group the clients, flows, hunts and notebooks of an
+  investigation.
+
+  A case has a status, a list of assignees and a timeline of notes.
+  Users add notes explicitly, and a note is added automatically
+  whenever the case's status, assignees or linked items change, so
+  the timeline records how the investigation progressed.
+
+  Cases are stored in the datastore under /cases/.
+*/
+
+package cases
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	CASE_PREFIX = "CASE."
+
+	STATUS_OPEN        = "OPEN"
+	STATUS_IN_PROGRESS = "IN_PROGRESS"
+	STATUS_CLOSED      = "CLOSED"
+)
+
+var (
+	InvalidCaseError = errors.New("Invalid case")
+
+	validStatus = []string{STATUS_OPEN, STATUS_IN_PROGRESS, STATUS_CLOSED}
+)
+
+func NewCaseId() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+
+	binary.BigEndian.PutUint32(buf, uint32(time.Now().Unix()))
+	result := base32.HexEncoding.EncodeToString(buf)[:13]
+
+	return CASE_PREFIX + result
+}
+
+func ValidateCase(case_obj *api_proto.Case) error {
+	if !strings.HasPrefix(case_obj.CaseId, CASE_PREFIX) {
+		return fmt.Errorf("%w: case id must start with %v",
+			InvalidCaseError, CASE_PREFIX)
+	}
+
+	if case_obj.Title == "" {
+		return fmt.Errorf("%w: title must be set", InvalidCaseError)
+	}
+
+	if !utils.InString(validStatus, case_obj.Status) {
+		return fmt.Errorf("%w: status must be one of %v",
+			InvalidCaseError, strings.Join(validStatus, ", "))
+	}
+
+	for _, client_id := range case_obj.Clients {
+		if client_id != "server" && !strings.HasPrefix(client_id, "C.") {
+			return fmt.Errorf("%w: invalid client id %v",
+				InvalidCaseError, client_id)
+		}
+	}
+
+	for _, flow := range case_obj.Flows {
+		if flow.ClientId == "" ||
+			!strings.HasPrefix(flow.FlowId, constants.FLOW_PREFIX) {
+			return fmt.Errorf("%w: invalid flow %v/%v",
+				InvalidCaseError, flow.ClientId, flow.FlowId)
+		}
+	}
+
+	for _, hunt_id := range case_obj.Hunts {
+		if !strings.HasPrefix(hunt_id, constants.HUNT_PREFIX) {
+			return fmt.Errorf("%w: invalid hunt id %v",
+				InvalidCaseError, hunt_id)
+		}
+	}
+
+	for _, notebook_id := range case_obj.Notebooks {
+		if !strings.HasPrefix(notebook_id, "N.") {
+			return fmt.Errorf("%w: invalid notebook id %v",
+				InvalidCaseError, notebook_id)
+		}
+	}
+
+	return nil
+}
+
+func GetCase(config_obj *config_proto.Config,
+	case_id string) (*api_proto.Case, error) {
+	result := &api_proto.Case{}
+	err := datastore.GetExistingSubject(config_obj,
+		paths.CASES_ROOT.AddChild(case_id), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Create a new case from the template.
+func NewCase(config_obj *config_proto.Config,
+	principal string, template *api_proto.Case) (*api_proto.Case, error) {
+
+	case_obj := proto.Clone(template).(*api_proto.Case)
+	now := utils.GetTime().Now().Unix()
+
+	case_obj.CaseId = NewCaseId()
+	case_obj.Creator = principal
+	case_obj.CreateTime = now
+	case_obj.ModifiedTime = now
+	case_obj.Notes = nil
+	if case_obj.Status == "" {
+		case_obj.Status = STATUS_OPEN
+	}
+
+	addNote(case_obj, principal, "Case created")
+
+	err := ValidateCase(case_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	urn := paths.CASES_ROOT.AddChild(case_obj.CaseId)
+	unlock, err := datastore.LockSubject(config_obj, urn)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return case_obj, db.SetSubject(config_obj, urn, case_obj)
+}
+
+// Update the case under lock. Changes made by the callback are
+// recorded in the case's timeline.
+func ModifyCase(config_obj *config_proto.Config,
+	principal, case_id string,
+	cb func(case_obj *api_proto.Case) error) (*api_proto.Case, error) {
+	case_obj := &api_proto.Case{}
+	err := datastore.ModifySubject(config_obj,
+		paths.CASES_ROOT.AddChild(case_id), case_obj,
+		func(exists bool) error {
+			if !exists {
+				return os.ErrNotExist
+			}
+
+			old := proto.Clone(case_obj).(*api_proto.Case)
+			err := cb(case_obj)
+			if err != nil {
+				return err
+			}
+
+			// The callback may not change these.
+			case_obj.CaseId = old.CaseId
+			case_obj.Creator = old.Creator
+			case_obj.CreateTime = old.CreateTime
+
+			for _, change := range describeChanges(old, case_obj) {
+				addNote(case_obj, principal, change)
+			}
+			case_obj.ModifiedTime = utils.GetTime().Now().Unix()
+
+			return ValidateCase(case_obj)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return case_obj, nil
+}
+
+// Replace the case's editable fields with those of the update. Notes
+// are kept.
+func UpdateCase(config_obj *config_proto.Config,
+	principal string, update *api_proto.Case) (*api_proto.Case, error) {
+	return ModifyCase(config_obj, principal, update.CaseId,
+		func(case_obj *api_proto.Case) error {
+			notes := case_obj.Notes
+			proto.Reset(case_obj)
+			proto.Merge(case_obj, update)
+			case_obj.Notes = notes
+			if case_obj.Status == "" {
+				case_obj.Status = STATUS_OPEN
+			}
+			return nil
+		})
+}
+
+func AddNote(config_obj *config_proto.Config,
+	principal, case_id, note string) (*api_proto.Case, error) {
+	if note == "" {
+		return nil, errors.New("Empty note")
+	}
+
+	return ModifyCase(config_obj, principal, case_id,
+		func(case_obj *api_proto.Case) error {
+			addNote(case_obj, principal, note)
+			return nil
+		})
+}
+
+func addNote(case_obj *api_proto.Case, principal, note string) {
+	case_obj.Notes = append(case_obj.Notes, &api_proto.CaseNote{
+		User:      principal,
+		Timestamp: utils.GetTime().Now().Unix(),
+		Note:      note,
+	})
+}
+
+func DeleteCase(config_obj *config_proto.Config, case_id string) error {
+	return datastore.DeleteSubject(config_obj,
+		paths.CASES_ROOT.AddChild(case_id), &api_proto.Case{})
+}
+
+// List the cases matching the request, most recently modified
+// first.
+func ListCases(config_obj *config_proto.Config,
+	in *api_proto.CasesRequest) ([]*api_proto.Case, error) {
+
+	if in.CaseId != "" {
+		case_obj, err := GetCase(config_obj, in.CaseId)
+		if err != nil {
+			return nil, err
+		}
+		return []*api_proto.Case{case_obj}, nil
+	}
+
+	children, err := datastore.ListSubjects(config_obj, paths.CASES_ROOT)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api_proto.Case, 0, len(children))
+	for _, child := range children {
+		case_obj, err := GetCase(config_obj, child.Base())
+		if err != nil {
+			continue
+		}
+
+		if in.Status != "" && case_obj.Status != in.Status {
+			continue
+		}
+
+		if in.Assignee != "" &&
+			!utils.InString(case_obj.Assignees, in.Assignee) {
+			continue
+		}
+
+		result = append(result, case_obj)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ModifiedTime > result[j].ModifiedTime
+	})
+
+	return result, nil
+}
+
+// Describe the changes between the old and new case for the
+// timeline.
+func describeChanges(old, updated *api_proto.Case) []string {
+	result := []string{}
+
+	if old.Title != updated.Title {
+		result = append(result, fmt.Sprintf("Title changed to %q", updated.Title))
+	}
+
+	if old.Status != updated.Status {
+		result = append(result, fmt.Sprintf("Status changed from %v to %v",
+			old.Status, updated.Status))
+	}
+
+	result = append(result, describeListChanges("assignee",
+		old.Assignees, updated.Assignees)...)
+	result = append(result, describeListChanges("client",
+		old.Clients, updated.Clients)...)
+	result = append(result, describeListChanges("flow",
+		flowNames(old.Flows), flowNames(updated.Flows))...)
+	result = append(result, describeListChanges("hunt",
+		old.Hunts, updated.Hunts)...)
+	result = append(result, describeListChanges("notebook",
+		old.Notebooks, updated.Notebooks)...)
+
+	return result
+}
+
+func describeListChanges(kind string, before, after []string) []string {
+	result := []string{}
+	for _, item := range after {
+		if !utils.InString(before, item) {
+			result = append(result, fmt.Sprintf("Added %v %v", kind, item))
+		}
+	}
+
+	for _, item := range before {
+		if !utils.InString(after, item) {
+			result = append(result, fmt.Sprintf("Removed %v %v", kind, item))
+		}
+	}
+	return result
+}
+
+func flowNames(flows []*api_proto.CaseFlow) []string {
+	result := make([]string, 0, len(flows))
+	for _, flow := range flows {
+		result = append(result, flow.ClientId+"/"+flow.FlowId)
+	}
+	return result
+}
@@ -0,0 +1,142 @@
+package cases_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/services/cases"
+)
+
+type CasesTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *CasesTestSuite) notes(case_obj *api_proto.Case) []string {
+	result := []string{}
+	for _, note := range case_obj.Notes {
+		result = append(result, note.User+": "+note.Note)
+	}
+	return result
+}
+
+func (self *CasesTestSuite) TestCaseLifecycle() {
+	case_obj, err := cases.NewCase(self.ConfigObj, "admin", &api_proto.Case{
+		Title:   "Phishing",
+		Clients: []string{"C.123"},
+	})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), cases.STATUS_OPEN, case_obj.Status)
+	assert.Equal(self.T(), "admin", case_obj.Creator)
+
+	// Link a flow and a hunt and assign the case.
+	case_obj, err = cases.ModifyCase(self.ConfigObj, "bob", case_obj.CaseId,
+		func(case_obj *api_proto.Case) error {
+			case_obj.Status = cases.STATUS_IN_PROGRESS
+			case_obj.Assignees = []string{"bob"}
+			case_obj.Flows = append(case_obj.Flows, &api_proto.CaseFlow{
+				ClientId: "C.123", FlowId: "F.1234"})
+			case_obj.Hunts = []string{"H.1234"}
+			return nil
+		})
+	assert.NoError(self.T(), err)
+
+	case_obj, err = cases.AddNote(self.ConfigObj, "bob", case_obj.CaseId,
+		"Found the malicious attachment")
+	assert.NoError(self.T(), err)
+
+	// Removing a link is also recorded.
+	update := &api_proto.Case{
+		CaseId:    case_obj.CaseId,
+		Title:     case_obj.Title,
+		Status:    cases.STATUS_CLOSED,
+		Assignees: case_obj.Assignees,
+		Clients:   case_obj.Clients,
+		Flows:     case_obj.Flows,
+	}
+	case_obj, err = cases.UpdateCase(self.ConfigObj, "admin", update)
+	assert.NoError(self.T(), err)
+
+	assert.Equal(self.T(), []string{
+		"admin: Case created",
+		"bob: Status changed from OPEN to IN_PROGRESS",
+		"bob: Added assignee bob",
+		"bob: Added flow C.123/F.1234",
+		"bob: Added hunt H.1234",
+		"bob: Found the malicious attachment",
+		"admin: Status changed from IN_PROGRESS to CLOSED",
+		"admin: Removed hunt H.1234",
+	}, self.notes(case_obj))
+
+	// The stored case matches.
+	stored, err := cases.GetCase(self.ConfigObj, case_obj.CaseId)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "admin", stored.Creator)
+	assert.Equal(self.T(), 8, len(stored.Notes))
+
+	err = cases.DeleteCase(self.ConfigObj, case_obj.CaseId)
+	assert.NoError(self.T(), err)
+
+	_, err = cases.GetCase(self.ConfigObj, case_obj.CaseId)
+	assert.True(self.T(), errors.Is(err, os.ErrNotExist))
+}
+
+func (self *CasesTestSuite) TestListCases() {
+	open, err := cases.NewCase(self.ConfigObj, "admin", &api_proto.Case{
+		Title:     "Open case",
+		Assignees: []string{"alice"},
+	})
+	assert.NoError(self.T(), err)
+
+	closed, err := cases.NewCase(self.ConfigObj, "admin", &api_proto.Case{
+		Title:     "Closed case",
+		Status:    cases.STATUS_CLOSED,
+		Assignees: []string{"bob"},
+	})
+	assert.NoError(self.T(), err)
+
+	all, err := cases.ListCases(self.ConfigObj, &api_proto.CasesRequest{})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 2, len(all))
+
+	result, err := cases.ListCases(self.ConfigObj, &api_proto.CasesRequest{
+		Status: cases.STATUS_CLOSED,
+	})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(result))
+	assert.Equal(self.T(), closed.CaseId, result[0].CaseId)
+
+	result, err = cases.ListCases(self.ConfigObj, &api_proto.CasesRequest{
+		Assignee: "alice",
+	})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(result))
+	assert.Equal(self.T(), open.CaseId, result[0].CaseId)
+}
+
+func (self *CasesTestSuite) TestValidation() {
+	for _, template := range []*api_proto.Case{
+		{},
+		{Title: "Bad status", Status: "DONE"},
+		{Title: "Bad client", Clients: []string{"workstation"}},
+		{Title: "Bad flow", Flows: []*api_proto.CaseFlow{{FlowId: "F.1"}}},
+		{Title: "Bad hunt", Hunts: []string{"F.1234"}},
+		{Title: "Bad notebook", Notebooks: []string{"H.1234"}},
+	} {
+		_, err := cases.NewCase(self.ConfigObj, "admin", template)
+		assert.True(self.T(), errors.Is(err, cases.InvalidCaseError),
+			"Expected validation error for %v", template.Title)
+	}
+
+	all, err := cases.ListCases(self.ConfigObj, &api_proto.CasesRequest{})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 0, len(all))
+}
+
+func TestCases(t *testing.T) {
+	suite.Run(t, &CasesTestSuite{})
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+// The client health manager scores clients on how regularly they
+// check in, gaps in their monitoring events, version skew and the
+// error rate of their recent collections. It also moves clients
+// which are no longer seen through the stale client lifecycle.
+func GetClientHealthManager(config_obj *config_proto.Config) (ClientHealthManager, error) {
+	org_manager, err := GetOrgManager()
+	if err != nil {
+		return nil, err
+	}
+
+	return org_manager.Services(config_obj.OrgId).ClientHealthManager()
+}
+
+type ClientHealthManager interface {
+	// Get the last computed health of the client. Returns nil if
+	// the client was not scored yet.
+	GetClientHealth(ctx context.Context,
+		client_id string) (*api_proto.ClientHealth, error)
+
+	// Score the client now and apply the lifecycle policy to it.
+	ScoreClient(ctx context.Context,
+		client_id string) (*api_proto.ClientHealth, error)
+}
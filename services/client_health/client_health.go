@@ -0,0 +1,391 @@
+/*
+  The client health service scores clients and manages stale clients.
+
+  Each client starts with a score of 100 which is reduced when:
+
+  1. The client does not check in regularly - the longer since the
+     client was last seen, the larger the penalty up to the stale
+     period.
+
+  2. The client is expected to run monitoring artifacts but its
+     events stopped arriving even though it still checks in.
+
+  3. The client runs a different version than the server.
+
+  4. The client's recent collections failed.
+
+  Clients not seen for long enough go through the lifecycle set by
+  the policy in Defaults.client_health: they are labeled stale, their
+  collections are exported into an archive in the downloads area and
+  finally they are purged together with all their data.
+
+  The health of each client is stored next to the client record and
+  cached in memory so it can be shown in client searches
+  (e.g. health:<50).
+
+  The service runs on the master node.
+*/
+
+package client_health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/flows/archive"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+type policy struct {
+	interval         time.Duration
+	stale_after      time.Duration
+	max_event_gap    time.Duration
+	error_rate_flows uint64
+	stale_label      string
+	archive_after    time.Duration
+	purge_after      time.Duration
+}
+
+func getPolicy(config_obj *config_proto.Config) *policy {
+	result := &policy{
+		interval:         time.Hour,
+		stale_after:      7 * 24 * time.Hour,
+		max_event_gap:    24 * time.Hour,
+		error_rate_flows: 20,
+	}
+
+	if config_obj.Defaults == nil || config_obj.Defaults.ClientHealth == nil {
+		return result
+	}
+
+	config := config_obj.Defaults.ClientHealth
+	if config.IntervalSec > 0 {
+		result.interval = time.Duration(config.IntervalSec) * time.Second
+	}
+
+	if config.StaleAfterSec > 0 {
+		result.stale_after = time.Duration(config.StaleAfterSec) * time.Second
+	}
+
+	if config.MaxEventGapSec > 0 {
+		result.max_event_gap = time.Duration(config.MaxEventGapSec) * time.Second
+	}
+
+	if config.ErrorRateFlows > 0 {
+		result.error_rate_flows = config.ErrorRateFlows
+	}
+
+	result.stale_label = config.StaleLabel
+	result.archive_after = time.Duration(config.ArchiveAfterSec) * time.Second
+	result.purge_after = time.Duration(config.PurgeAfterSec) * time.Second
+
+	return result
+}
+
+type ClientHealthService struct {
+	mu sync.Mutex
+
+	config_obj *config_proto.Config
+	policy     *policy
+
+	// The last computed health of each client.
+	health map[string]*api_proto.ClientHealth
+}
+
+func (self *ClientHealthService) GetClientHealth(
+	ctx context.Context, client_id string) (*api_proto.ClientHealth, error) {
+	self.mu.Lock()
+	health, pres := self.health[client_id]
+	self.mu.Unlock()
+
+	if pres {
+		return health, nil
+	}
+
+	health = &api_proto.ClientHealth{}
+	err := datastore.GetExistingSubject(self.config_obj,
+		paths.NewClientPathManager(client_id).Health(), health)
+	if err != nil {
+		return nil, nil
+	}
+
+	self.mu.Lock()
+	self.health[client_id] = health
+	self.mu.Unlock()
+
+	return health, nil
+}
+
+func (self *ClientHealthService) ScoreClient(
+	ctx context.Context, client_id string) (*api_proto.ClientHealth, error) {
+
+	client_info_manager, err := services.GetClientInfoManager(self.config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	client_info, err := client_info_manager.Get(ctx, client_id)
+	if err != nil {
+		return nil, err
+	}
+
+	health, err := computeHealth(ctx, self.config_obj, self.policy,
+		client_info, utils.GetTime().Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Other frontends may score the same client so we hold the lock
+	// until the lifecycle is applied.
+	urn := paths.NewClientPathManager(client_id).Health()
+	unlock, err := datastore.LockSubject(self.config_obj, urn)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Remember if the client was already archived.
+	previous := &api_proto.ClientHealth{}
+	err = datastore.GetExistingSubject(self.config_obj, urn, previous)
+	if err == nil {
+		health.ArchivedTime = previous.ArchivedTime
+		health.ArchivePath = previous.ArchivePath
+	}
+
+	purged, err := self.applyLifecycle(ctx, client_id, health)
+	if err != nil {
+		return nil, err
+	}
+
+	if purged {
+		self.mu.Lock()
+		delete(self.health, client_id)
+		self.mu.Unlock()
+		return health, nil
+	}
+
+	db, err := datastore.GetDB(self.config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.SetSubject(self.config_obj, urn, health)
+	if err != nil {
+		return nil, err
+	}
+
+	self.mu.Lock()
+	self.health[client_id] = health
+	self.mu.Unlock()
+
+	return health, nil
+}
+
+// Label, archive and purge the client according to the policy.
+// Returns true if the client was purged.
+func (self *ClientHealthService) applyLifecycle(
+	ctx context.Context, client_id string,
+	health *api_proto.ClientHealth) (bool, error) {
+
+	if self.policy.stale_label != "" {
+		labeler := services.GetLabeler(self.config_obj)
+		is_set := labeler.IsLabelSet(ctx, self.config_obj,
+			client_id, self.policy.stale_label)
+
+		if health.Stale && !is_set {
+			err := labeler.SetClientLabel(ctx, self.config_obj,
+				client_id, self.policy.stale_label)
+			if err != nil {
+				return false, err
+			}
+
+		} else if !health.Stale && is_set {
+			err := labeler.RemoveClientLabel(ctx, self.config_obj,
+				client_id, self.policy.stale_label)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	age := time.Duration(health.LastSeenAge) * time.Second
+
+	// The client came back - it needs to be archived again when it
+	// goes away so the archive has its latest collections.
+	if self.policy.archive_after == 0 || age <= self.policy.archive_after {
+		health.ArchivedTime = 0
+		health.ArchivePath = ""
+
+	} else if health.ArchivedTime == 0 {
+		err := self.archiveClient(ctx, client_id, health)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if self.policy.purge_after > 0 && age > self.policy.purge_after &&
+		(self.policy.archive_after == 0 || health.ArchivedTime > 0) {
+		return true, self.purgeClient(ctx, client_id)
+	}
+
+	return false, nil
+}
+
+// Export all the client's collections into an archive which may be
+// imported again later.
+func (self *ClientHealthService) archiveClient(
+	ctx context.Context, client_id string,
+	health *api_proto.ClientHealth) error {
+
+	archive_path := paths.NewClientPathManager(client_id).GetArchiveFile()
+
+	file_store_factory := file_store.GetFileStore(self.config_obj)
+	fd, err := file_store_factory.WriteFile(archive_path)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	err = fd.Truncate()
+	if err != nil {
+		return err
+	}
+
+	err = archive.ExportClient(ctx, self.config_obj, fd, client_id)
+	if err != nil {
+		return err
+	}
+
+	health.ArchivedTime = uint64(utils.GetTime().Now().Unix())
+	health.ArchivePath = archive_path.AsClientPath()
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	logger.Info("client_health: Archived stale client %v to %v",
+		client_id, health.ArchivePath)
+
+	return nil
+}
+
+// Remove the client and all its data using the client_delete()
+// plugin.
+func (self *ClientHealthService) purgeClient(
+	ctx context.Context, client_id string) error {
+
+	manager, err := services.GetRepositoryManager(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.NewPlainLogger(self.config_obj,
+		&logging.FrontendComponent)
+
+	scope := manager.BuildScope(services.ScopeBuilder{
+		Config:     self.config_obj,
+		ACLManager: acl_managers.NullACLManager{},
+		Env: ordereddict.NewDict().
+			Set("ClientId", client_id),
+		Logger: logger,
+	})
+	defer scope.Close()
+
+	vql, err := vfilter.Parse(
+		"SELECT * FROM client_delete(client_id=ClientId, really_do_it=TRUE)")
+	if err != nil {
+		return err
+	}
+
+	for _ = range vql.Eval(ctx, scope) {
+	}
+
+	logging.LogAudit(self.config_obj, "ClientHealthService", "PurgeClient",
+		logrus.Fields{
+			"client_id": client_id,
+		})
+
+	return nil
+}
+
+// Score all the clients.
+func (self *ClientHealthService) RunOnce(ctx context.Context) error {
+	indexer, err := services.GetIndexer(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	// Collect the clients first since purging clients modifies the
+	// index.
+	var client_ids []string
+	seen := make(map[string]bool)
+	for hit := range indexer.SearchIndexWithPrefix(ctx, self.config_obj, "all") {
+		if hit == nil || seen[hit.Entity] {
+			continue
+		}
+		seen[hit.Entity] = true
+		client_ids = append(client_ids, hit.Entity)
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	for _, client_id := range client_ids {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		_, err := self.ScoreClient(ctx, client_id)
+		if err != nil {
+			logger.Error("client_health: %v: %v", client_id, err)
+		}
+	}
+
+	return nil
+}
+
+func NewClientHealthService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*ClientHealthService, error) {
+
+	result := &ClientHealthService{
+		config_obj: config_obj,
+		policy:     getPolicy(config_obj),
+		health:     make(map[string]*api_proto.ClientHealth),
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> client health service for %v.",
+		services.GetOrgName(config_obj))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(result.policy.interval):
+			}
+
+			err := result.RunOnce(ctx)
+			if err != nil {
+				logger.Error("client_health: %v", err)
+			}
+		}
+	}()
+
+	return result, nil
+}
@@ -0,0 +1,177 @@
+package client_health_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+
+	_ "www.velocidex.com/golang/velociraptor/result_sets/timed"
+	_ "www.velocidex.com/golang/velociraptor/vql/server/clients"
+)
+
+const DAY = 24 * time.Hour
+
+type ClientHealthTestSuite struct {
+	test_utils.TestSuite
+
+	now time.Time
+}
+
+func (self *ClientHealthTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Services.ClientMonitoring = true
+	self.ConfigObj.Services.HuntDispatcher = true
+	self.ConfigObj.Services.HuntManager = true
+	self.ConfigObj.Services.IndexServer = true
+	self.ConfigObj.Frontend.Resources.IndexSnapshotFrequency = 100000
+	self.ConfigObj.Version.Version = "0.6.9"
+	self.ConfigObj.Defaults.ClientHealth = &config_proto.ClientHealthConfig{
+		StaleLabel:      "Stale",
+		ArchiveAfterSec: uint64((30 * DAY).Seconds()),
+		PurgeAfterSec:   uint64((60 * DAY).Seconds()),
+	}
+
+	self.TestSuite.SetupTest()
+
+	self.now = time.Unix(1700000000, 0)
+
+	// An online client running the server's version.
+	self.setClient("C.1", "0.6.9", self.now.Add(-time.Minute))
+
+	// An old client not seen for 40 days.
+	self.setClient("C.2", "0.6.7", self.now.Add(-40*DAY))
+}
+
+func (self *ClientHealthTestSuite) setClient(
+	client_id, version string, last_seen time.Time) {
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	indexer, err := services.GetIndexer(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	assert.NoError(self.T(), indexer.SetIndex(client_id, client_id))
+	assert.NoError(self.T(), indexer.SetIndex(client_id, "all"))
+
+	client_path_manager := paths.NewClientPathManager(client_id)
+	err = db.SetSubject(self.ConfigObj, client_path_manager.Path(),
+		&actions_proto.ClientInfo{
+			ClientId:      client_id,
+			Hostname:      client_id,
+			ClientVersion: version,
+			FirstSeenAt:   uint64(last_seen.Add(-DAY).Unix()),
+		})
+	assert.NoError(self.T(), err)
+
+	err = db.SetSubject(self.ConfigObj, client_path_manager.Ping(),
+		&actions_proto.ClientInfo{
+			Ping: uint64(last_seen.UnixMicro()),
+		})
+	assert.NoError(self.T(), err)
+
+	client_info_manager, err := services.GetClientInfoManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	client_info_manager.Flush(self.Ctx, client_id)
+}
+
+func (self *ClientHealthTestSuite) TestScoring() {
+	closer := utils.MockTime(&utils.MockClock{MockNow: self.now})
+	defer closer()
+
+	client_health, err := services.GetClientHealthManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	// Clients are not scored until the service runs.
+	health, err := client_health.GetClientHealth(self.Ctx, "C.1")
+	assert.NoError(self.T(), err)
+	assert.Nil(self.T(), health)
+
+	health, err = client_health.ScoreClient(self.Ctx, "C.1")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), uint64(100), health.Score)
+	assert.False(self.T(), health.Stale)
+	assert.False(self.T(), health.VersionSkew)
+	assert.Equal(self.T(), 0, len(health.Issues))
+
+	// The old client is penalized for not checking in and for its
+	// version.
+	health, err = client_health.ScoreClient(self.Ctx, "C.2")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), uint64(50), health.Score)
+	assert.True(self.T(), health.Stale)
+	assert.True(self.T(), health.VersionSkew)
+	assert.Equal(self.T(), 2, len(health.Issues))
+
+	// The health is included in the client records.
+	indexer, err := services.GetIndexer(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	api_client, err := indexer.FastGetApiClient(
+		self.Ctx, self.ConfigObj, "C.2")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), uint64(50), api_client.Health.Score)
+}
+
+func (self *ClientHealthTestSuite) TestLifecycle() {
+	closer := utils.MockTime(&utils.MockClock{MockNow: self.now})
+
+	client_health, err := services.GetClientHealthManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	labeler := services.GetLabeler(self.ConfigObj)
+
+	// The stale client is labeled and archived.
+	health, err := client_health.ScoreClient(self.Ctx, "C.2")
+	assert.NoError(self.T(), err)
+	assert.True(self.T(), labeler.IsLabelSet(
+		self.Ctx, self.ConfigObj, "C.2", "Stale"))
+	assert.True(self.T(), health.ArchivedTime > 0)
+	assert.Equal(self.T(), "/downloads/C.2/Archive C.2.zip", health.ArchivePath)
+
+	archive_path := paths.NewClientPathManager("C.2").GetArchiveFile()
+	stat, err := file_store.GetFileStore(self.ConfigObj).StatFile(archive_path)
+	assert.NoError(self.T(), err)
+	assert.True(self.T(), stat.Size() > 0)
+
+	// The online client is left alone.
+	_, err = client_health.ScoreClient(self.Ctx, "C.1")
+	assert.NoError(self.T(), err)
+	assert.False(self.T(), labeler.IsLabelSet(
+		self.Ctx, self.ConfigObj, "C.1", "Stale"))
+	closer()
+
+	// A month later the archived client is purged.
+	closer = utils.MockTime(&utils.MockClock{MockNow: self.now.Add(30 * DAY)})
+	defer closer()
+
+	_, err = client_health.ScoreClient(self.Ctx, "C.2")
+	assert.NoError(self.T(), err)
+
+	health, err = client_health.GetClientHealth(self.Ctx, "C.2")
+	assert.NoError(self.T(), err)
+	assert.Nil(self.T(), health)
+
+	client_info_manager, err := services.GetClientInfoManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	_, err = client_info_manager.Get(self.Ctx, "C.2")
+	assert.Error(self.T(), err)
+
+	// The archive is kept.
+	_, err = file_store.GetFileStore(self.ConfigObj).StatFile(archive_path)
+	assert.NoError(self.T(), err)
+}
+
+func TestClientHealth(t *testing.T) {
+	suite.Run(t, &ClientHealthTestSuite{})
+}
@@ -0,0 +1,190 @@
+package client_health
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/services"
+)
+
+const (
+	// The most each problem can reduce the score by.
+	MAX_CHECKIN_PENALTY  = 40
+	EVENT_GAP_PENALTY    = 20
+	VERSION_SKEW_PENALTY = 10
+	MAX_ERROR_PENALTY    = 30
+
+	// Clients seen within this period are online and are not
+	// penalized for checking in.
+	ONLINE_PERIOD = 15 * time.Minute
+)
+
+func computeHealth(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	policy *policy, client_info *services.ClientInfo,
+	now time.Time) (*api_proto.ClientHealth, error) {
+
+	client_id := client_info.ClientId
+	health := &api_proto.ClientHealth{
+		Timestamp: uint64(now.Unix()),
+	}
+
+	// Ping is in microseconds and first seen in seconds. Clients
+	// which never checked in are as old as their enrollment.
+	last_seen := time.UnixMicro(int64(client_info.Ping))
+	if client_info.Ping == 0 {
+		last_seen = time.Unix(int64(client_info.FirstSeenAt), 0)
+	}
+
+	age := now.Sub(last_seen)
+	if age < 0 {
+		age = 0
+	}
+	health.LastSeenAge = uint64(age.Seconds())
+	health.Stale = age > policy.stale_after
+
+	penalty := 0.0
+	if age > ONLINE_PERIOD {
+		penalty += math.Min(1, float64(age)/float64(policy.stale_after)) *
+			MAX_CHECKIN_PENALTY
+		health.Issues = append(health.Issues, fmt.Sprintf(
+			"Last seen %v ago", age.Round(time.Minute)))
+	}
+
+	expected, err := expectsEvents(ctx, config_obj, client_id)
+	if err != nil {
+		return nil, err
+	}
+
+	if expected {
+		// Without any events the gap is counted from enrollment.
+		last_event, pres := lastEventTime(config_obj, client_id)
+		if !pres {
+			last_event = time.Unix(int64(client_info.FirstSeenAt), 0)
+		}
+
+		gap := last_seen.Sub(last_event)
+		if gap > 0 {
+			health.EventGap = uint64(gap.Seconds())
+		}
+
+		if gap > policy.max_event_gap {
+			penalty += EVENT_GAP_PENALTY
+			health.Issues = append(health.Issues, fmt.Sprintf(
+				"No monitoring events for %v", gap.Round(time.Minute)))
+		}
+	}
+
+	server_version := ""
+	if config_obj.Version != nil {
+		server_version = config_obj.Version.Version
+	}
+
+	if client_info.ClientVersion != "" && server_version != "" &&
+		client_info.ClientVersion != server_version {
+		health.VersionSkew = true
+		penalty += VERSION_SKEW_PENALTY
+		health.Issues = append(health.Issues, fmt.Sprintf(
+			"Client version %v differs from server version %v",
+			client_info.ClientVersion, server_version))
+	}
+
+	failed, completed, err := recentErrors(config_obj, client_id,
+		policy.error_rate_flows)
+	if err != nil {
+		return nil, err
+	}
+
+	if completed > 0 {
+		health.ErrorRate = float64(failed) * 100 / float64(completed)
+		if failed > 0 {
+			penalty += health.ErrorRate / 100 * MAX_ERROR_PENALTY
+			health.Issues = append(health.Issues, fmt.Sprintf(
+				"%v of %v recent collections failed", failed, completed))
+		}
+	}
+
+	health.Score = uint64(math.Max(0, 100-math.Round(penalty)))
+	return health, nil
+}
+
+// Clients are expected to send events if their event table has any
+// queries.
+func expectsEvents(
+	ctx context.Context,
+	config_obj *config_proto.Config, client_id string) (bool, error) {
+	client_event_manager, err := services.ClientEventManager(config_obj)
+	if err != nil {
+		return false, err
+	}
+
+	message := client_event_manager.GetClientUpdateEventTableMessage(
+		ctx, config_obj, client_id)
+	return message != nil && message.UpdateEventTable != nil &&
+		len(message.UpdateEventTable.Event) > 0, nil
+}
+
+// The last time any monitoring events were written for the client.
+func lastEventTime(
+	config_obj *config_proto.Config, client_id string) (time.Time, bool) {
+
+	// All the client's monitoring artifacts are stored under the
+	// same root.
+	path_manager := artifacts.NewArtifactPathManagerWithMode(
+		config_obj, client_id, "", "Generic.Client.Stats",
+		paths.MODE_CLIENT_EVENT)
+
+	var last time.Time
+	file_store_factory := file_store.GetFileStore(config_obj)
+	_ = api.Walk(file_store_factory, path_manager.GetRootPath(),
+		func(urn api.FSPathSpec, info os.FileInfo) error {
+			if !info.IsDir() && info.Size() > 0 &&
+				info.ModTime().After(last) {
+				last = info.ModTime()
+			}
+			return nil
+		})
+
+	return last, !last.IsZero()
+}
+
+// Count the failed collections among the client's most recent
+// completed collections.
+func recentErrors(
+	config_obj *config_proto.Config, client_id string,
+	count uint64) (failed, completed int, err error) {
+
+	launcher, err := services.GetLauncher(config_obj)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	flows, err := launcher.GetFlows(config_obj, client_id, true,
+		func(flow *flows_proto.ArtifactCollectorContext) bool {
+			return flow.State == flows_proto.ArtifactCollectorContext_FINISHED ||
+				flow.State == flows_proto.ArtifactCollectorContext_ERROR
+		}, 0, count)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, flow := range flows.Items {
+		completed++
+		if flow.State == flows_proto.ArtifactCollectorContext_ERROR {
+			failed++
+		}
+	}
+
+	return failed, completed, nil
+}
@@ -31,6 +31,13 @@
 
    4. Update mutations are sent periodically in a combined way to
       avoid RPC overheads.
+
+   5. The master writes dirty records behind in batches. Ping updates
+      happen on every client poll so they are only written to the
+      client's record when they become too old. In the mean time the
+      latest values are kept in a snapshot of the whole cache (see
+      snapshot.go) which is also used to warm up the cache on
+      startup.
 */
 
 package client_info
@@ -78,6 +85,9 @@ type CachedInfo struct {
 	// Fresh data may not be flushed yet.
 	dirty bool
 
+	// Only the ping changed since the last flush.
+	ping_dirty bool
+
 	// Data on disk
 	record *services.ClientInfo
 
@@ -128,7 +138,7 @@ func (self *CachedInfo) _UpdateStats(
 	defer self.mu.Unlock()
 
 	if stats.Ping > 0 && stats.Ping > self.record.Ping {
-		self.dirty = true
+		self.ping_dirty = true
 		if mutation_manager != nil {
 			mutation_manager.AddPing(client_id, stats.Ping)
 		}
@@ -165,12 +175,23 @@ func (self *CachedInfo) _UpdateStats(
 	}
 }
 
+// A record that only had its ping updated is written once the ping
+// on disk is older than ping_write_time (in microseconds) or if
+// there is no ping on disk yet.
+func (self *CachedInfo) needsFlush(now, ping_write_time uint64) bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.dirty || (self.ping_dirty &&
+		(self.last_flush == 0 || now > self.last_flush+ping_write_time))
+}
+
 // Write ping record to data store if it is dirty.
 func (self *CachedInfo) Flush() error {
 	self.mu.Lock()
 
 	// Nothing to do
-	if !self.dirty {
+	if !self.dirty && !self.ping_dirty {
 		self.mu.Unlock()
 		return nil
 	}
@@ -178,6 +199,7 @@ func (self *CachedInfo) Flush() error {
 	// Only the master actually writes the client stats to storage
 	if !self.is_master {
 		self.dirty = false
+		self.ping_dirty = false
 		self.mu.Unlock()
 		return nil
 	}
@@ -191,6 +213,7 @@ func (self *CachedInfo) Flush() error {
 	}
 	client_id := self.record.ClientId
 	self.dirty = false
+	self.ping_dirty = false
 	self.last_flush = uint64(self.owner.Clock.Now().UnixNano() / 1000)
 	self.mu.Unlock()
 
@@ -225,6 +248,12 @@ type ClientInfoManager struct {
 	is_master bool
 
 	mutation_manager *MutationManager
+
+	// Maximum number of records to write on each cycle.
+	write_batch int
+
+	// Pings are written when they are older than this (microseconds).
+	ping_write_time uint64
 }
 
 func (self *ClientInfoManager) GetCachedClients() []string {
@@ -280,6 +309,12 @@ func (self *ClientInfoManager) Start(
 	logger.Info("<green>Starting</> Client Info service for %v.",
 		services.GetOrgName(config_obj))
 
+	// Warm up the cache from the last snapshot.
+	err := self.LoadSnapshot(ctx)
+	if err != nil {
+		logger.Debug("ClientInfoManager: No snapshot loaded: %v", err)
+	}
+
 	// Start syncing the mutation_manager
 	wg.Add(1)
 	go self.MutationSync(ctx, wg, config_obj)
@@ -296,13 +331,22 @@ func (self *ClientInfoManager) Start(
 				time.Millisecond
 		}
 
+		snapshot_time := time.Duration(600) * time.Second
+		if config_obj.Frontend != nil && config_obj.Frontend.Resources != nil &&
+			config_obj.Frontend.Resources.ClientInfoSnapshotFrequency > 0 {
+			snapshot_time = time.Duration(
+				config_obj.Frontend.Resources.ClientInfoSnapshotFrequency) *
+				time.Second
+		}
+
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 
 			// When we teardown write the data to storage if needed.
-			defer self.lru.Flush()
+			defer self.Close()
 
+			last_snapshot := time.Now()
 			for {
 				select {
 				case <-ctx.Done():
@@ -310,13 +354,21 @@ func (self *ClientInfoManager) Start(
 
 				case <-time.After(write_time):
 					self.FlushAll()
+
+					if time.Now().Sub(last_snapshot) > snapshot_time {
+						last_snapshot = time.Now()
+						err := self.WriteSnapshot()
+						if err != nil {
+							logger.Error("ClientInfoManager: WriteSnapshot: %v", err)
+						}
+					}
 				}
 			}
 		}()
 	}
 
 	// Watch for clients that are deleted and remove from local cache.
-	err := journal.WatchQueueWithCB(ctx, config_obj, wg,
+	err = journal.WatchQueueWithCB(ctx, config_obj, wg,
 		"Server.Internal.ClientDelete",
 		"ClientInfoManager",
 		self.ProcessInterrogateResults)
@@ -514,20 +566,23 @@ func (self *ClientInfoManager) Flush(ctx context.Context, client_id string) {
 	self.lru.Remove(client_id)
 }
 
-// Flush all dirty caches to disk.
+// Flush the next batch of dirty caches to disk.
 func (self *ClientInfoManager) FlushAll() {
 	to_flush := []*CachedInfo{}
+	now := uint64(self.Clock.Now().UnixNano() / 1000)
 
 	for _, client_id := range self.lru.GetKeys() {
-		cache_info, err := self.GetCacheInfo(client_id)
+		cache_info, err := self.GetCacheInfoFromCache(client_id)
 		if err != nil {
 			continue
 		}
-		cache_info.mu.Lock()
-		if cache_info.dirty {
+
+		if cache_info.needsFlush(now, self.ping_write_time) {
 			to_flush = append(to_flush, cache_info)
+			if len(to_flush) >= self.write_batch {
+				break
+			}
 		}
-		cache_info.mu.Unlock()
 	}
 
 	if len(to_flush) == 0 {
@@ -672,6 +727,21 @@ func NewClientInfoManager(config_obj *config_proto.Config) *ClientInfoManager {
 		is_master:  services.IsMaster(config_obj),
 
 		mutation_manager: NewMutationManager(),
+
+		write_batch:     1000,
+		ping_write_time: 600 * 1000000,
+	}
+
+	if config_obj.Frontend != nil &&
+		config_obj.Frontend.Resources != nil {
+		resources := config_obj.Frontend.Resources
+		if resources.ClientInfoWriteBatch > 0 {
+			service.write_batch = int(resources.ClientInfoWriteBatch)
+		}
+
+		if resources.ClientInfoPingWriteTime > 0 {
+			service.ping_write_time = resources.ClientInfoPingWriteTime * 1000000
+		}
 	}
 
 	service.lru.SetCacheSizeLimit(int(expected_clients))
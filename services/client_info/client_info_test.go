@@ -157,3 +157,70 @@ func (self *ClientInfoTestSuite) TestMasterMinion() {
 func TestClientInfoService(t *testing.T) {
 	suite.Run(t, &ClientInfoTestSuite{})
 }
+
+func (self *ClientInfoTestSuite) TestPingWriteBehind() {
+	client_info_manager := client_info.NewClientInfoManager(self.ConfigObj)
+	client_info_manager.Clock = self.clock
+
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	client_path_manager := paths.NewClientPathManager(self.client_id)
+	getStoredPing := func() uint64 {
+		ping_info := &actions_proto.ClientInfo{}
+		db.GetSubject(self.ConfigObj, client_path_manager.Ping(), ping_info)
+		return ping_info.Ping
+	}
+
+	// The first ping is written since there is no ping on disk.
+	client_info_manager.UpdateStats(context.Background(),
+		self.client_id, &services.Stats{Ping: uint64(100 * 1000000)})
+	client_info_manager.FlushAll()
+	assert.Equal(self.T(), uint64(100*1000000), getStoredPing())
+
+	// A later ping is only kept in memory.
+	client_info_manager.UpdateStats(context.Background(),
+		self.client_id, &services.Stats{Ping: uint64(200 * 1000000)})
+	client_info_manager.FlushAll()
+	assert.Equal(self.T(), uint64(100*1000000), getStoredPing())
+
+	// Until the ping on disk is old enough.
+	self.clock.MockNow = time.Unix(1000, 0)
+	client_info_manager.FlushAll()
+	assert.Equal(self.T(), uint64(200*1000000), getStoredPing())
+
+	// Other changes are written straight away.
+	client_info_manager.UpdateStats(context.Background(),
+		self.client_id, &services.Stats{IpAddress: "10.0.0.1"})
+	client_info_manager.FlushAll()
+
+	ping_info := &actions_proto.ClientInfo{}
+	db.GetSubject(self.ConfigObj, client_path_manager.Ping(), ping_info)
+	assert.Equal(self.T(), "10.0.0.1", ping_info.IpAddress)
+}
+
+func (self *ClientInfoTestSuite) TestSnapshot() {
+	client_info_manager := client_info.NewClientInfoManager(self.ConfigObj)
+	client_info_manager.Clock = self.clock
+
+	client_info_manager.UpdateStats(context.Background(),
+		self.client_id, &services.Stats{Ping: uint64(300 * 1000000)})
+	assert.NoError(self.T(), client_info_manager.WriteSnapshot())
+
+	// A new manager loads the client from the snapshot even after
+	// the client record is gone.
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	client_path_manager := paths.NewClientPathManager(self.client_id)
+	assert.NoError(self.T(), db.DeleteSubject(
+		self.ConfigObj, client_path_manager.Path()))
+
+	new_manager := client_info.NewClientInfoManager(self.ConfigObj)
+	new_manager.Clock = self.clock
+	assert.NoError(self.T(), new_manager.LoadSnapshot(context.Background()))
+
+	info, err := new_manager.Get(context.Background(), self.client_id)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "Hostname", info.Hostname)
+	assert.Equal(self.T(), uint64(300*1000000), info.Ping)
+}
@@ -0,0 +1,134 @@
+package client_info
+
+// The client info cache is periodically written to a single result
+// set in the filestore. Reading the snapshot is much faster than
+// reading the client record and ping record of each client, so
+// frontends load it on startup to warm up the cache.
+
+// The snapshot also holds the latest ping of each client so the ping
+// records themselves only need to be updated occasionally.
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+func (self *ClientInfoManager) WriteSnapshot() error {
+	// Only the master writes to storage.
+	if !self.is_master {
+		return nil
+	}
+
+	keys := self.lru.GetKeys()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	file_store_factory := file_store.GetFileStore(self.config_obj)
+	rs_writer, err := result_sets.NewResultSetWriter(
+		file_store_factory, paths.CLIENT_INFO_SNAPSHOT,
+		json.DefaultEncOpts(), utils.SyncCompleter,
+		result_sets.TruncateMode)
+	if err != nil {
+		return err
+	}
+	defer rs_writer.Close()
+
+	count := 0
+	for _, client_id := range keys {
+		cache_info, err := self.GetCacheInfoFromCache(client_id)
+		if err != nil {
+			continue
+		}
+
+		cache_info.mu.Lock()
+		row := json.ConvertProtoToOrderedDict(&cache_info.record.ClientInfo)
+		cache_info.mu.Unlock()
+
+		rs_writer.Write(row)
+		count++
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	logger.Debug("ClientInfoManager: Wrote snapshot of %v records in %v",
+		count, time.Now().Sub(now))
+
+	return nil
+}
+
+func (self *ClientInfoManager) LoadSnapshot(ctx context.Context) error {
+	file_store_factory := file_store.GetFileStore(self.config_obj)
+	rs_reader, err := result_sets.NewResultSetReader(
+		file_store_factory, paths.CLIENT_INFO_SNAPSHOT)
+	if err != nil {
+		return err
+	}
+	defer rs_reader.Close()
+
+	now := self.Clock.Now()
+
+	count := 0
+	for row := range rs_reader.Rows(ctx) {
+		client_info := &services.ClientInfo{}
+		err := utils.ParseIntoProtobuf(row, &client_info.ClientInfo)
+		if err != nil || client_info.ClientId == "" {
+			continue
+		}
+
+		// We do not know how old the ping record on disk is so
+		// assume it was just written to avoid writing all the ping
+		// records at once.
+		cache_info := &CachedInfo{
+			owner:      self,
+			record:     client_info,
+			is_master:  self.is_master,
+			last_flush: uint64(now.UnixNano() / 1000),
+		}
+
+		self.mu.Lock()
+		self.lru.Set(client_info.ClientId, cache_info)
+		self.mu.Unlock()
+		count++
+	}
+
+	if count == 0 {
+		return errors.New("Empty snapshot")
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	logger.Info("ClientInfoManager: Loaded %v client records from snapshot in %v",
+		count, self.Clock.Now().Sub(now))
+
+	return nil
+}
+
+// Persist the cache on shutdown. The snapshot holds the latest pings
+// so only the other changes need to be written to the client
+// records.
+func (self *ClientInfoManager) Close() {
+	err := self.WriteSnapshot()
+	if err == nil {
+		for _, client_id := range self.lru.GetKeys() {
+			cache_info, err := self.GetCacheInfoFromCache(client_id)
+			if err != nil {
+				continue
+			}
+
+			cache_info.mu.Lock()
+			cache_info.ping_dirty = false
+			cache_info.mu.Unlock()
+		}
+	}
+
+	self.lru.Flush()
+}
@@ -13,11 +13,15 @@
 
 // 3. The artifact was deleted or updated.
 
+// Tables targeting client groups are evaluated when the client's
+// table is refreshed for one of the above reasons.
+
 package client_monitoring
 
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/rand"
 	"sync"
 
@@ -26,6 +30,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	"www.velocidex.com/golang/velociraptor/config"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/constants"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
@@ -211,6 +216,64 @@ func (self *ClientEventTable) compileState(
 		table.Artifacts.CompiledCollectorArgs = compiled
 	}
 
+	// Scheduled artifacts are compiled as regular queries which the
+	// client repeats.
+	for _, scheduled := range state.Scheduled {
+		if scheduled.Artifacts == nil {
+			scheduled.Artifacts = &flows_proto.ArtifactCollectorArgs{}
+		}
+
+		if scheduled.Period == 0 {
+			return fmt.Errorf("Scheduled artifacts %v: No period specified",
+				scheduled.Artifacts.Artifacts)
+		}
+
+		err := self.checkScheduledArtifacts(config_obj, scheduled.Artifacts)
+		if err != nil {
+			return err
+		}
+
+		compiled, err := self.compileArtifactCollectorArgs(
+			ctx, config_obj, scheduled.Artifacts)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range compiled {
+			event.SchedulePeriod = scheduled.Period
+		}
+		scheduled.Artifacts.CompiledCollectorArgs = compiled
+	}
+
+	return nil
+}
+
+// Only regular client artifacts can be scheduled - event artifacts
+// never complete.
+func (self *ClientEventTable) checkScheduledArtifacts(
+	config_obj *config_proto.Config,
+	artifacts *flows_proto.ArtifactCollectorArgs) error {
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return err
+	}
+
+	repository, err := manager.GetGlobalRepository(config_obj)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range artifacts.Artifacts {
+		artifact_type, err := repository.GetArtifactType(config_obj, name)
+		if err != nil {
+			// Missing artifacts are ignored by the compiler.
+			continue
+		}
+
+		if artifact_type != "client" {
+			return fmt.Errorf("Scheduled artifact %v must be a CLIENT artifact", name)
+		}
+	}
 	return nil
 }
 
@@ -304,10 +367,11 @@ func (self *ClientEventTable) GetClientUpdateEventTableMessage(
 		result.Event = append(result.Event, proto.Clone(event).(*actions_proto.VQLCollectorArgs))
 	}
 
-	// Now apply any event queries that belong to this client based on labels.
+	// Now apply any event queries that belong to this client based on
+	// labels or client groups.
 	labeler := services.GetLabeler(config_obj)
 	for _, table := range state.LabelEvents {
-		if labeler.IsLabelSet(ctx, config_obj, client_id, table.Label) {
+		if self.isClientInTable(ctx, config_obj, labeler, client_id, table) {
 			for _, event := range table.Artifacts.CompiledCollectorArgs {
 				result.Event = append(result.Event,
 					proto.Clone(event).(*actions_proto.VQLCollectorArgs))
@@ -315,6 +379,27 @@ func (self *ClientEventTable) GetClientUpdateEventTableMessage(
 		}
 	}
 
+	for _, scheduled := range state.Scheduled {
+		if scheduled.Artifacts == nil {
+			continue
+		}
+
+		if scheduled.Label != "" || scheduled.ClientGroup != "" {
+			if !self.isClientInTable(ctx, config_obj, labeler, client_id,
+				&flows_proto.LabelEvents{
+					Label:       scheduled.Label,
+					ClientGroup: scheduled.ClientGroup,
+				}) {
+				continue
+			}
+		}
+
+		for _, event := range scheduled.Artifacts.CompiledCollectorArgs {
+			result.Event = append(result.Event,
+				proto.Clone(event).(*actions_proto.VQLCollectorArgs))
+		}
+	}
+
 	// Add a bit of randomness to the max wait to spread out
 	// client's updates so they do not syncronize load on the
 	// server.
@@ -337,8 +422,11 @@ func (self *ClientEventTable) GetClientUpdateEventTableMessage(
 		}
 		event.MaxWait += uint64(rand.Intn(int(jitter)))
 
-		// Event queries never time out
-		event.Timeout = 99999999
+		// Event queries never time out but scheduled collections
+		// are regular queries.
+		if event.SchedulePeriod == 0 {
+			event.Timeout = 99999999
+		}
 	}
 
 	return &crypto_proto.VeloMessage{
@@ -347,6 +435,32 @@ func (self *ClientEventTable) GetClientUpdateEventTableMessage(
 	}
 }
 
+func (self *ClientEventTable) isClientInTable(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	labeler services.Labeler,
+	client_id string, table *flows_proto.LabelEvents) bool {
+
+	if table.ClientGroup == "" {
+		return labeler.IsLabelSet(ctx, config_obj, client_id, table.Label)
+	}
+
+	indexer, err := services.GetIndexer(config_obj)
+	if err != nil {
+		return false
+	}
+
+	member, err := indexer.IsClientInGroup(
+		ctx, config_obj, client_id, table.ClientGroup)
+	if err != nil {
+		logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+		logger.Error("ClientEventTable: client group %v: %v",
+			table.ClientGroup, err)
+		return false
+	}
+	return member
+}
+
 func (self *ClientEventTable) ProcessArtifactModificationEvent(
 	ctx context.Context,
 	config_obj *config_proto.Config, event *ordereddict.Dict) {
@@ -403,6 +517,12 @@ func clear_caches(state *flows_proto.ClientEventTable) {
 	for _, event := range state.LabelEvents {
 		event.Artifacts.CompiledCollectorArgs = nil
 	}
+
+	for _, scheduled := range state.Scheduled {
+		if scheduled.Artifacts != nil {
+			scheduled.Artifacts.CompiledCollectorArgs = nil
+		}
+	}
 }
 
 func (self *ClientEventTable) LoadFromFile(
@@ -461,6 +581,37 @@ func (self *ClientEventTable) load_from_file(
 	return self.compileState(ctx, config_obj, self.state)
 }
 
+// Apply changes to the default client monitoring artifacts when the
+// config is reloaded. Only the master updates the table since it is
+// shared by all frontends.
+func (self *ClientEventTable) ProcessConfigChange(
+	ctx context.Context, change *config.ConfigChange) error {
+	if !change.Changed(config.RELOAD_CLIENT_MONITORING) ||
+		!services.IsMaster(self.config_obj) {
+		return nil
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	state := proto.Clone(self.state).(*flows_proto.ClientEventTable)
+	if state.Artifacts == nil {
+		state.Artifacts = &flows_proto.ArtifactCollectorArgs{}
+	}
+
+	state.Artifacts.Artifacts = config.MergeDefaults(
+		state.Artifacts.Artifacts,
+		change.Old.Frontend.GetDefaultClientMonitoringArtifacts(),
+		change.New.Frontend.GetDefaultClientMonitoringArtifacts())
+	clear_caches(state)
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	logger.Info("client_monitoring: Updating default artifacts for %v: %v",
+		services.GetOrgName(self.config_obj), state.Artifacts.Artifacts)
+
+	return self.setClientMonitoringState(ctx, self.config_obj, "", state)
+}
+
 // Runs at frontend start to initialize the client monitoring table.
 func NewClientMonitoringService(
 	ctx context.Context,
@@ -485,10 +636,15 @@ func NewClientMonitoringService(
 		ctx, "Server.Internal.ArtifactModification",
 		"client_monitoring_service")
 
+	unregister := config.RegisterReloadHandler(
+		"client_monitoring/"+utils.NormalizedOrgId(config_obj.OrgId),
+		event_table.ProcessConfigChange)
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		defer cancel()
+		defer unregister()
 
 		for {
 			select {
@@ -10,12 +10,14 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
 	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
 	"www.velocidex.com/golang/velociraptor/json"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/services/client_monitoring"
+	"www.velocidex.com/golang/velociraptor/services/indexing"
 	"www.velocidex.com/golang/velociraptor/services/labels"
 	"www.velocidex.com/golang/velociraptor/utils"
 	"www.velocidex.com/golang/velociraptor/vtesting"
@@ -397,6 +399,121 @@ func (self *ClientMonitoringTestSuite) TestClientMonitoringCompiling() {
 		self.client_id, version))
 }
 
+func (self *ClientMonitoringTestSuite) TestClientMonitoringGroups() {
+	client_info_manager, err := services.GetClientInfoManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	linux_client_id := "C.12313"
+	for client_id, system := range map[string]string{
+		self.client_id:  "windows",
+		linux_client_id: "linux",
+	} {
+		err = client_info_manager.Set(self.Ctx, &services.ClientInfo{
+			ClientInfo: actions_proto.ClientInfo{
+				ClientId: client_id,
+				System:   system,
+			}})
+		assert.NoError(self.T(), err)
+	}
+
+	err = indexing.SetClientGroup(self.ConfigObj, &api_proto.ClientGroup{
+		Name:  "Windows",
+		Query: "os:windows",
+	})
+	assert.NoError(self.T(), err)
+
+	manager, err := services.ClientEventManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	err = manager.SetClientMonitoringState(
+		context.Background(),
+		self.ConfigObj, "", &flows_proto.ClientEventTable{
+			Artifacts: &flows_proto.ArtifactCollectorArgs{
+				Artifacts: []string{"Windows.Events.ServiceCreation"},
+			},
+			LabelEvents: []*flows_proto.LabelEvents{
+				{ClientGroup: "Windows", Artifacts: &flows_proto.ArtifactCollectorArgs{
+					Artifacts: []string{"Windows.Events.DNSQueries"},
+				}},
+			},
+		})
+	assert.NoError(self.T(), err)
+
+	// Only members of the group receive the group's artifacts.
+	table := manager.GetClientUpdateEventTableMessage(
+		context.Background(), self.ConfigObj, self.client_id)
+	assert.Equal(self.T(), []string{"Windows.Events.ServiceCreation",
+		"Windows.Events.DNSQueries"}, extractArtifacts(table.UpdateEventTable))
+
+	table = manager.GetClientUpdateEventTableMessage(
+		context.Background(), self.ConfigObj, linux_client_id)
+	assert.Equal(self.T(), []string{"Windows.Events.ServiceCreation"},
+		extractArtifacts(table.UpdateEventTable))
+}
+
+// Scheduled client artifacts are sent as regular queries with a
+// schedule period.
+func (self *ClientMonitoringTestSuite) TestClientMonitoringScheduled() {
+	manager, err := services.ClientEventManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	// Event artifacts never complete so can not be scheduled.
+	err = manager.SetClientMonitoringState(
+		context.Background(),
+		self.ConfigObj, "", &flows_proto.ClientEventTable{
+			Scheduled: []*flows_proto.ScheduledArtifacts{{
+				Period: 3600,
+				Artifacts: &flows_proto.ArtifactCollectorArgs{
+					Artifacts: []string{"Windows.Events.DNSQueries"},
+				}},
+			},
+		})
+	assert.Error(self.T(), err)
+
+	err = manager.SetClientMonitoringState(
+		context.Background(),
+		self.ConfigObj, "", &flows_proto.ClientEventTable{
+			Artifacts: &flows_proto.ArtifactCollectorArgs{
+				Artifacts: []string{"Windows.Events.ServiceCreation"},
+			},
+			Scheduled: []*flows_proto.ScheduledArtifacts{{
+				Period: 3600,
+				Artifacts: &flows_proto.ArtifactCollectorArgs{
+					Artifacts: []string{"TestArtifact"},
+				}}, {
+				Period: 60,
+				Label:  "Laptops",
+				Artifacts: &flows_proto.ArtifactCollectorArgs{
+					Artifacts: []string{"SomethingElse"},
+				}},
+			},
+		})
+	assert.NoError(self.T(), err)
+
+	table := manager.GetClientUpdateEventTableMessage(
+		context.Background(), self.ConfigObj, self.client_id)
+	assert.Equal(self.T(), []string{"Windows.Events.ServiceCreation",
+		"TestArtifact"}, extractArtifacts(table.UpdateEventTable))
+
+	events := table.UpdateEventTable.Event
+	assert.Equal(self.T(), uint64(0), events[0].SchedulePeriod)
+	assert.Equal(self.T(), uint64(99999999), events[0].Timeout)
+	assert.Equal(self.T(), uint64(3600), events[1].SchedulePeriod)
+	assert.NotEqual(self.T(), uint64(99999999), events[1].Timeout)
+
+	// Only labeled clients get the labeled schedule.
+	labeler := services.GetLabeler(self.ConfigObj)
+	assert.NoError(self.T(), labeler.SetClientLabel(
+		context.Background(), self.ConfigObj, self.client_id, "Laptops"))
+
+	table = manager.GetClientUpdateEventTableMessage(
+		context.Background(), self.ConfigObj, self.client_id)
+	assert.Equal(self.T(), []string{"Windows.Events.ServiceCreation",
+		"TestArtifact", "SomethingElse"}, extractArtifacts(table.UpdateEventTable))
+	assert.Equal(self.T(), uint64(60),
+		table.UpdateEventTable.Event[2].SchedulePeriod)
+}
+
 // Event queries are asyncronous and blocking so when collecting
 // multiple queries, we need to send each query in its own Event entry
 // so they can run in parallel. The client runs each Event object in a
@@ -0,0 +1,535 @@
+/*
+  Client identity coalescing for re-imaged and cloned machines.
+
+  A client's id is derived from its private key, which is kept in the
+  writeback file. Machines which are re-imaged or cloned from an image
+  (e.g. non persistent VDI) lose the writeback and enroll as a new
+  client every time. Large VDI fleets therefore fill the client index
+  with thousands of dead clients that were only alive for a day.
+
+  When Frontend.client_coalescing is configured, this service examines
+  each newly interrogated client and looks for a prior client of the
+  same machine - matched by hostname, fqdn or MAC address. Only prior
+  clients which have been offline for a while are considered, so live
+  machines sharing a name never take over each other.
+
+  In "link" mode the new client records the lineage of client ids,
+  inherits the prior client's labels and the prior client is hidden
+  from searches (it remains accessible by its client id).
+
+  In "reuse" mode the server accepts the new client's key for the
+  prior client id (using the key rotation mechanism) and asks the new
+  client to take over the prior client id. The machine then continues
+  with the history of the prior client and the transient client id
+  is hidden.
+
+  The service runs on the master node alongside the interrogation
+  service.
+*/
+
+package coalescing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	crypto_server "www.velocidex.com/golang/velociraptor/crypto/server"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	MATCH_HOSTNAME = "hostname"
+	MATCH_FQDN     = "fqdn"
+	MATCH_MAC      = "mac"
+
+	MODE_LINK  = "link"
+	MODE_REUSE = "reuse"
+
+	DEFAULT_MIN_OFFLINE_SECONDS = 3600
+
+	EVENT_ARTIFACT = "Server.Internal.ClientCoalescing"
+)
+
+type ClientCoalescingService struct {
+	// Serialize coalescing so two new clients can not claim the
+	// same prior client.
+	mu sync.Mutex
+
+	config_obj *config_proto.Config
+	policy     *config_proto.ClientCoalescingConfig
+
+	match, mode string
+}
+
+func GetLineage(config_obj *config_proto.Config,
+	client_id string) (*api_proto.ClientLineage, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// Most clients have no lineage.
+	result := &api_proto.ClientLineage{}
+	err = db.GetSubject(config_obj,
+		paths.CLIENT_LINEAGE_ROOT.AddChild("clients", client_id), result)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+	return result, err
+}
+
+func setLineage(config_obj *config_proto.Config,
+	client_id string, lineage *api_proto.ClientLineage) error {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	err = db.SetSubject(config_obj,
+		paths.CLIENT_LINEAGE_ROOT.AddChild("clients", client_id), lineage)
+	if err != nil {
+		return err
+	}
+
+	// Keep a separate list of superseded clients so the indexer can
+	// leave them out when it rebuilds the index.
+	if lineage.SupersededBy != "" {
+		err = db.SetSubject(config_obj,
+			paths.CLIENT_LINEAGE_ROOT.AddChild("superseded", client_id),
+			lineage)
+	}
+	return err
+}
+
+// All the clients which were replaced by another client.
+func GetSupersededClients(
+	config_obj *config_proto.Config) (map[string]bool, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := db.ListChildren(config_obj,
+		paths.CLIENT_LINEAGE_ROOT.AddChild("superseded"))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool)
+	for _, child := range children {
+		if !child.IsDir() {
+			result[child.Base()] = true
+		}
+	}
+	return result, nil
+}
+
+// Examine a newly interrogated client and coalesce it with a prior
+// client of the same machine.
+func (self *ClientCoalescingService) ProcessClient(
+	ctx context.Context, client_id string) error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	// Clients are only coalesced once.
+	lineage, err := GetLineage(self.config_obj, client_id)
+	if err != nil {
+		return err
+	}
+	if lineage.SupersededBy != "" || len(lineage.PreviousClientIds) > 0 {
+		return nil
+	}
+
+	client_info_manager, err := services.GetClientInfoManager(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	client_info, err := client_info_manager.Get(ctx, client_id)
+	if err != nil {
+		return err
+	}
+
+	if !self.hasLabel(ctx, client_id) {
+		return nil
+	}
+
+	prior := self.findPriorClient(ctx, client_info)
+	if prior == "" {
+		return nil
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	logger.Info("client_coalescing: %v is the same machine as %v (%v: %v)",
+		client_id, prior, self.mode, self.match)
+
+	if self.mode == MODE_REUSE {
+		err = self.reuse(ctx, client_id, prior)
+	} else {
+		err = self.link(ctx, client_id, prior)
+	}
+	if err != nil {
+		return err
+	}
+
+	journal, err := services.GetJournal(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	journal.PushRowsToArtifactAsync(self.config_obj,
+		ordereddict.NewDict().
+			Set("ClientId", client_id).
+			Set("PriorClientId", prior).
+			Set("Mode", self.mode).
+			Set("MatchedOn", self.match),
+		EVENT_ARTIFACT)
+
+	return nil
+}
+
+func (self *ClientCoalescingService) hasLabel(
+	ctx context.Context, client_id string) bool {
+	if len(self.policy.Labels) == 0 {
+		return true
+	}
+
+	labeler := services.GetLabeler(self.config_obj)
+	if labeler == nil {
+		return false
+	}
+
+	for _, label := range self.policy.Labels {
+		if labeler.IsLabelSet(ctx, self.config_obj, client_id, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// The index terms identifying the machine.
+func (self *ClientCoalescingService) matchTerms(
+	client_info *services.ClientInfo) []string {
+	var result []string
+	switch self.match {
+	case MATCH_FQDN:
+		if client_info.Fqdn != "" {
+			result = append(result, "host:"+client_info.Fqdn)
+		}
+
+	case MATCH_MAC:
+		for _, mac := range client_info.MacAddresses {
+			result = append(result, "mac:"+mac)
+		}
+
+	default:
+		if client_info.Hostname != "" {
+			result = append(result, "host:"+client_info.Hostname)
+		}
+	}
+	return result
+}
+
+// Find the most recently seen prior client of the same machine which
+// has been offline long enough.
+func (self *ClientCoalescingService) findPriorClient(
+	ctx context.Context, client_info *services.ClientInfo) string {
+	indexer, err := services.GetIndexer(self.config_obj)
+	if err != nil {
+		return ""
+	}
+
+	client_info_manager, err := services.GetClientInfoManager(self.config_obj)
+	if err != nil {
+		return ""
+	}
+
+	min_offline := self.policy.MinOfflineSeconds
+	if min_offline == 0 {
+		min_offline = DEFAULT_MIN_OFFLINE_SECONDS
+	}
+	cutoff := utils.GetTime().Now().Add(
+		-time.Duration(min_offline) * time.Second)
+
+	best := ""
+	best_ping := uint64(0)
+	for _, term := range self.matchTerms(client_info) {
+		for record := range indexer.SearchIndexWithPrefix(
+			ctx, self.config_obj, term) {
+
+			// Search is by prefix but we need the exact term.
+			candidate := record.Entity
+			if !strings.EqualFold(record.Term, term) ||
+				candidate == client_info.ClientId {
+				continue
+			}
+
+			info, err := client_info_manager.Get(ctx, candidate)
+			if err != nil {
+				continue
+			}
+
+			// Ping is in microseconds.
+			last_seen := time.Unix(0, int64(info.Ping)*1000)
+			if last_seen.After(cutoff) || info.Ping <= best_ping {
+				continue
+			}
+
+			lineage, err := GetLineage(self.config_obj, candidate)
+			if err != nil || lineage.SupersededBy != "" {
+				continue
+			}
+
+			best = candidate
+			best_ping = info.Ping
+		}
+	}
+
+	return best
+}
+
+// Record the lineage, carry the labels over to the new client and
+// hide the prior client.
+func (self *ClientCoalescingService) link(
+	ctx context.Context, client_id, prior string) error {
+	now := uint64(utils.GetTime().Now().Unix())
+
+	prior_lineage, err := GetLineage(self.config_obj, prior)
+	if err != nil {
+		return err
+	}
+
+	err = setLineage(self.config_obj, client_id, &api_proto.ClientLineage{
+		PreviousClientIds: append([]string{prior},
+			prior_lineage.PreviousClientIds...),
+		MatchedOn: self.match,
+		Timestamp: now,
+	})
+	if err != nil {
+		return err
+	}
+
+	prior_lineage.SupersededBy = client_id
+	prior_lineage.MatchedOn = self.match
+	prior_lineage.Timestamp = now
+	err = setLineage(self.config_obj, prior, prior_lineage)
+	if err != nil {
+		return err
+	}
+
+	labeler := services.GetLabeler(self.config_obj)
+	if labeler != nil {
+		for _, label := range labeler.GetClientLabels(
+			ctx, self.config_obj, prior) {
+			err := labeler.SetClientLabel(ctx, self.config_obj, client_id, label)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return self.hideClient(ctx, prior)
+}
+
+// Hand the prior client id over to the new client. The server
+// accepts the new client's key for the prior client id as if the
+// prior client rotated its key.
+func (self *ClientCoalescingService) reuse(
+	ctx context.Context, client_id, prior string) error {
+	db, err := datastore.GetDB(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	pem := &crypto_proto.PublicKey{}
+	err = db.GetSubject(self.config_obj,
+		paths.NewClientPathManager(client_id).Key(), pem)
+	if err != nil {
+		return err
+	}
+
+	public_key, err := crypto_utils.PemToPublicKey(pem.Pem)
+	if err != nil {
+		return fmt.Errorf("client_coalescing: key for %v: %w", client_id, err)
+	}
+
+	err = crypto_server.SetPendingPublicKey(self.config_obj, prior, public_key)
+	if err != nil {
+		return err
+	}
+
+	client_info_manager, err := services.GetClientInfoManager(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	// The re-imaged machine has no client monitoring table yet.
+	prior_info, err := client_info_manager.Get(ctx, prior)
+	if err == nil {
+		prior_info.LastEventTableVersion = 0
+		err = client_info_manager.Set(ctx, prior_info)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client_info_manager.QueueMessageForClient(ctx, client_id,
+		&crypto_proto.VeloMessage{
+			SessionId: constants.KEY_ROTATION_WELL_KNOWN_FLOW,
+			KeyRotation: &crypto_proto.KeyRotation{
+				ClientId: prior,
+			},
+		},
+		services.NOTIFY_CLIENT, utils.BackgroundWriter)
+	if err != nil {
+		return err
+	}
+
+	now := uint64(utils.GetTime().Now().Unix())
+	prior_lineage, err := GetLineage(self.config_obj, prior)
+	if err != nil {
+		return err
+	}
+
+	prior_lineage.PreviousClientIds = append([]string{client_id},
+		prior_lineage.PreviousClientIds...)
+	prior_lineage.MatchedOn = self.match
+	prior_lineage.Timestamp = now
+	err = setLineage(self.config_obj, prior, prior_lineage)
+	if err != nil {
+		return err
+	}
+
+	err = setLineage(self.config_obj, client_id, &api_proto.ClientLineage{
+		SupersededBy: prior,
+		MatchedOn:    self.match,
+		Timestamp:    now,
+	})
+	if err != nil {
+		return err
+	}
+
+	return self.hideClient(ctx, client_id)
+}
+
+// Remove the client from searches. It can still be found by its
+// client id.
+func (self *ClientCoalescingService) hideClient(
+	ctx context.Context, client_id string) error {
+	indexer, err := services.GetIndexer(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	client_info_manager, err := services.GetClientInfoManager(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	terms := []string{"all"}
+	client_info, err := client_info_manager.Get(ctx, client_id)
+	if err == nil {
+		for _, name := range []string{
+			client_info.Hostname, client_info.Fqdn} {
+			if name != "" {
+				terms = append(terms, "host:"+name)
+			}
+		}
+		for _, mac := range client_info.MacAddresses {
+			terms = append(terms, "mac:"+mac)
+		}
+	}
+
+	labeler := services.GetLabeler(self.config_obj)
+	if labeler != nil {
+		for _, label := range labeler.GetClientLabels(
+			ctx, self.config_obj, client_id) {
+			terms = append(terms, "label:"+strings.ToLower(label))
+		}
+	}
+
+	for _, term := range terms {
+		err := indexer.UnsetIndex(client_id, term)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Build a coalescer for the policy, filling in defaults.
+func NewClientCoalescer(config_obj *config_proto.Config,
+	policy *config_proto.ClientCoalescingConfig) (
+	*ClientCoalescingService, error) {
+	result := &ClientCoalescingService{
+		config_obj: config_obj,
+		policy:     policy,
+		match:      policy.Match,
+		mode:       policy.Mode,
+	}
+
+	switch result.match {
+	case "":
+		result.match = MATCH_HOSTNAME
+	case MATCH_HOSTNAME, MATCH_FQDN, MATCH_MAC:
+	default:
+		return nil, fmt.Errorf("client_coalescing: unknown match %v", policy.Match)
+	}
+
+	switch result.mode {
+	case "":
+		result.mode = MODE_LINK
+	case MODE_LINK, MODE_REUSE:
+	default:
+		return nil, fmt.Errorf("client_coalescing: unknown mode %v", policy.Mode)
+	}
+
+	return result, nil
+}
+
+func NewClientCoalescingService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	policy := config_obj.Frontend.GetClientCoalescing()
+	if policy == nil {
+		return nil
+	}
+
+	result, err := NewClientCoalescer(config_obj, policy)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> client coalescing service for %v.",
+		services.GetOrgName(config_obj))
+
+	return journal.WatchQueueWithCB(ctx, config_obj, wg,
+		"Server.Internal.Interrogation", "ClientCoalescing",
+		func(ctx context.Context, config_obj *config_proto.Config,
+			row *ordereddict.Dict) error {
+			client_id, pres := row.GetString("ClientId")
+			if !pres {
+				return nil
+			}
+			return result.ProcessClient(ctx, client_id)
+		})
+}
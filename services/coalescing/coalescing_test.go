@@ -0,0 +1,128 @@
+package coalescing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/coalescing"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+type CoalescingTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *CoalescingTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Services.IndexServer = true
+	self.ConfigObj.Frontend.Resources.IndexSnapshotFrequency = 100000
+	self.LoadArtifacts([]string{`
+name: Server.Internal.ClientCoalescing
+type: SERVER_EVENT
+`})
+	self.TestSuite.SetupTest()
+}
+
+func (self *CoalescingTestSuite) setClient(
+	client_id, hostname string, last_seen time.Time) {
+	client_info_manager, err := services.GetClientInfoManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	err = client_info_manager.Set(self.Ctx, &services.ClientInfo{
+		ClientInfo: actions_proto.ClientInfo{
+			ClientId: client_id,
+			Hostname: hostname,
+			Ping:     uint64(last_seen.UnixNano() / 1000),
+		}})
+	assert.NoError(self.T(), err)
+
+	indexer, err := services.GetIndexer(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	for _, term := range []string{client_id, "all", "host:" + hostname} {
+		assert.NoError(self.T(), indexer.SetIndex(client_id, term))
+	}
+}
+
+func (self *CoalescingTestSuite) service(
+	labels ...string) *coalescing.ClientCoalescingService {
+	result, err := coalescing.NewClientCoalescer(self.ConfigObj,
+		&config_proto.ClientCoalescingConfig{Labels: labels})
+	assert.NoError(self.T(), err)
+	return result
+}
+
+func (self *CoalescingTestSuite) TestLink() {
+	now := utils.GetTime().Now()
+
+	// An old client of the same machine, a live client sharing the
+	// name and an unrelated machine.
+	self.setClient("C.old", "vdi-01", now.Add(-10*time.Hour))
+	self.setClient("C.live", "vdi-01", now)
+	self.setClient("C.other", "vdi-02", now.Add(-10*time.Hour))
+	self.setClient("C.new", "vdi-01", now)
+
+	labeler := services.GetLabeler(self.ConfigObj)
+	assert.NoError(self.T(),
+		labeler.SetClientLabel(self.Ctx, self.ConfigObj, "C.old", "Finance"))
+
+	service := self.service()
+	assert.NoError(self.T(), service.ProcessClient(self.Ctx, "C.new"))
+
+	lineage, err := coalescing.GetLineage(self.ConfigObj, "C.new")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), []string{"C.old"}, lineage.PreviousClientIds)
+	assert.Equal(self.T(), coalescing.MATCH_HOSTNAME, lineage.MatchedOn)
+
+	lineage, err = coalescing.GetLineage(self.ConfigObj, "C.old")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "C.new", lineage.SupersededBy)
+
+	assert.True(self.T(),
+		labeler.IsLabelSet(self.Ctx, self.ConfigObj, "C.new", "Finance"))
+
+	superseded, err := coalescing.GetSupersededClients(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), map[string]bool{"C.old": true}, superseded)
+
+	// The superseded client can not be claimed again.
+	self.setClient("C.newer", "vdi-01", now)
+	assert.NoError(self.T(), service.ProcessClient(self.Ctx, "C.newer"))
+
+	lineage, err = coalescing.GetLineage(self.ConfigObj, "C.newer")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 0, len(lineage.PreviousClientIds))
+}
+
+func (self *CoalescingTestSuite) TestLabels() {
+	now := utils.GetTime().Now()
+	self.setClient("C.old", "vdi-01", now.Add(-10*time.Hour))
+	self.setClient("C.new", "vdi-01", now)
+
+	// Only clients with the label are coalesced.
+	service := self.service("VDI")
+	assert.NoError(self.T(), service.ProcessClient(self.Ctx, "C.new"))
+
+	lineage, err := coalescing.GetLineage(self.ConfigObj, "C.new")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 0, len(lineage.PreviousClientIds))
+
+	labeler := services.GetLabeler(self.ConfigObj)
+	assert.NoError(self.T(),
+		labeler.SetClientLabel(self.Ctx, self.ConfigObj, "C.new", "VDI"))
+	assert.NoError(self.T(), service.ProcessClient(self.Ctx, "C.new"))
+
+	lineage, err = coalescing.GetLineage(self.ConfigObj, "C.new")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), []string{"C.old"}, lineage.PreviousClientIds)
+}
+
+func TestClientCoalescing(t *testing.T) {
+	suite.Run(t, &CoalescingTestSuite{})
+}
@@ -0,0 +1,426 @@
+/*
+  Compact the FileBaseDataStore.
+
+  Large deployments accumulate millions of small files which are no
+  longer needed:
+
+  1. Task queues of deleted clients or of flows that were deleted
+     before the client picked up the task.
+
+  2. Ping files rewritten by the client info cache after the client
+     was deleted.
+
+  3. Empty directories left behind when files are removed (the
+     datastore never removes intermediate directories).
+
+  Compaction runs online: file operations are rate limited and files
+  younger than the minimum age are never touched so it does not race
+  with new writes.
+*/
+
+package compaction
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	bytesReclaimed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "datastore_compaction_bytes_reclaimed",
+		Help: "Total number of bytes removed by datastore compaction.",
+	})
+
+	filesRemoved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "datastore_compaction_files_removed",
+		Help: "Total number of files and directories removed by datastore compaction.",
+	})
+
+	// Only one compaction may run at the same time.
+	mu sync.Mutex
+)
+
+type Options struct {
+	// Only report what would be removed.
+	DryRun bool
+
+	// Maximum number of file operations per second (0 is unlimited).
+	OpsPerSecond uint64
+
+	// Never remove files younger than this.
+	MinAge time.Duration
+}
+
+type Stats struct {
+	ClientsScanned   uint64
+	OrphanedTasks    uint64
+	StalePings       uint64
+	EmptyDirectories uint64
+	BytesReclaimed   uint64
+	DryRun           bool
+	DurationSec      float64
+}
+
+type compactor struct {
+	config_obj *config_proto.Config
+	db         datastore.DataStore
+	options    Options
+	limiter    *rate.Limiter
+	cutoff     time.Time
+	stats      *Stats
+
+	// Directories we removed files from. Their modification time
+	// is recent but they are not in use.
+	touched map[string]bool
+}
+
+// Wait for the rate limiter before touching the filesystem.
+func (self *compactor) wait(ctx context.Context) error {
+	if self.limiter == nil {
+		return ctx.Err()
+	}
+	return self.limiter.Wait(ctx)
+}
+
+// Returns the size of the file backing the urn if it is old enough
+// to be removed.
+func (self *compactor) removable(urn api.DSPathSpec) (int64, bool) {
+	stat, err := os.Lstat(urn.AsDatastoreFilename(self.config_obj))
+	if err != nil || stat.IsDir() || stat.ModTime().After(self.cutoff) {
+		return 0, false
+	}
+	return stat.Size(), true
+}
+
+func (self *compactor) exists(urn api.DSPathSpec) bool {
+	_, err := os.Lstat(urn.AsDatastoreFilename(self.config_obj))
+	return err == nil
+}
+
+func (self *compactor) remove(urn api.DSPathSpec, counter *uint64) error {
+	size, ok := self.removable(urn)
+	if !ok {
+		return nil
+	}
+
+	*counter++
+	self.stats.BytesReclaimed += uint64(size)
+	if self.options.DryRun {
+		return nil
+	}
+
+	self.touched[filepath.Dir(urn.AsDatastoreFilename(self.config_obj))] = true
+	bytesReclaimed.Add(float64(size))
+	filesRemoved.Inc()
+	return self.db.DeleteSubject(self.config_obj, urn)
+}
+
+func (self *compactor) compactClients(ctx context.Context) error {
+	clients_dir := paths.CLIENTS_ROOT.AsDatastoreDirectory(self.config_obj)
+	children, err := utils.ReadDirUnsorted(clients_dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	for _, child := range children {
+		if !child.IsDir() {
+			continue
+		}
+
+		client_id := utils.UnsanitizeComponent(child.Name())
+		if !strings.HasPrefix(client_id, "C.") {
+			continue
+		}
+
+		err := self.wait(ctx)
+		if err != nil {
+			return err
+		}
+
+		self.stats.ClientsScanned++
+		err = self.compactClient(ctx, client_id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *compactor) compactClient(
+	ctx context.Context, client_id string) error {
+	client_path_manager := paths.NewClientPathManager(client_id)
+
+	// The client record and key are removed when a client is
+	// deleted.
+	deleted := !self.exists(client_path_manager.Path()) &&
+		!self.exists(client_path_manager.Key())
+
+	if deleted {
+		err := self.remove(client_path_manager.Ping(), &self.stats.StalePings)
+		if err != nil {
+			return err
+		}
+	}
+
+	tasks_dir := client_path_manager.TasksDirectory().
+		AsDatastoreDirectory(self.config_obj)
+	tasks, err := utils.ReadDirNames(tasks_dir)
+	if err != nil {
+		return nil
+	}
+
+	for _, name := range tasks {
+		task_id, err := strconv.ParseUint(strings.TrimSuffix(name, ".db"), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		err = self.wait(ctx)
+		if err != nil {
+			return err
+		}
+
+		task_urn := client_path_manager.Task(task_id)
+		if !deleted && !self.isOrphanedTask(client_id, task_urn) {
+			continue
+		}
+
+		err = self.remove(task_urn, &self.stats.OrphanedTasks)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// A task is orphaned if it can not be read or its flow was deleted.
+func (self *compactor) isOrphanedTask(
+	client_id string, task_urn api.DSPathSpec) bool {
+	task := &crypto_proto.VeloMessage{}
+	err := self.db.GetSubject(self.config_obj, task_urn, task)
+	if err != nil {
+		return true
+	}
+
+	flow_id := task.SessionId
+	if !strings.HasPrefix(flow_id, constants.FLOW_PREFIX) ||
+		flow_id == constants.MONITORING_WELL_KNOWN_FLOW {
+		return false
+	}
+
+	return !self.exists(paths.NewFlowPathManager(client_id, flow_id).Path())
+}
+
+// Remove empty directories below dir bottom up. Returns true if dir
+// itself is now empty.
+func (self *compactor) removeEmptyDirectories(
+	ctx context.Context, dir string, skip string) (bool, error) {
+	err := self.wait(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	children, err := utils.ReadDirUnsorted(dir)
+	if err != nil {
+		return false, nil
+	}
+
+	empty := true
+	for _, child := range children {
+		if !child.IsDir() {
+			empty = false
+			continue
+		}
+
+		child_path := filepath.Join(dir, child.Name())
+		if child_path == skip {
+			empty = false
+			continue
+		}
+
+		child_empty, err := self.removeEmptyDirectories(ctx, child_path, skip)
+		if err != nil {
+			return false, err
+		}
+
+		if !child_empty ||
+			(child.ModTime().After(self.cutoff) && !self.touched[child_path]) {
+			empty = false
+			continue
+		}
+
+		self.stats.EmptyDirectories++
+		if self.options.DryRun {
+			continue
+		}
+
+		// Fails if a file was just created in the directory.
+		err = os.Remove(child_path)
+		if err != nil {
+			empty = false
+			continue
+		}
+		filesRemoved.Inc()
+	}
+
+	return empty, nil
+}
+
+// Compact the org's datastore.
+func Compact(ctx context.Context,
+	config_obj *config_proto.Config, options Options) (*Stats, error) {
+
+	implementation, err := datastore.GetImplementationName(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	switch implementation {
+	case "FileBaseDataStore", "MemcacheFileDataStore":
+	default:
+		return nil, errors.New(
+			"Compaction is only supported for the FileBaseDataStore")
+	}
+
+	if config_obj.Datastore.Location == "" {
+		return nil, errors.New("Datastore location not configured")
+	}
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if !mu.TryLock() {
+		return nil, errors.New("Compaction is already running")
+	}
+	defer mu.Unlock()
+
+	start := utils.GetTime().Now()
+	self := &compactor{
+		config_obj: config_obj,
+		db:         db,
+		options:    options,
+		cutoff:     start.Add(-options.MinAge),
+		stats:      &Stats{DryRun: options.DryRun},
+		touched:    make(map[string]bool),
+	}
+
+	if options.OpsPerSecond > 0 {
+		self.limiter = rate.NewLimiter(
+			rate.Limit(options.OpsPerSecond), int(options.OpsPerSecond))
+	}
+
+	err = self.compactClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The root org's datastore contains the other orgs'
+	// datastores. They are compacted by their own org.
+	root := config_obj.Datastore.Location
+	skip := ""
+	if utils.IsRootOrg(config_obj.OrgId) {
+		skip = filepath.Join(root, "orgs")
+	}
+
+	_, err = self.removeEmptyDirectories(ctx, root, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	self.stats.DurationSec = utils.GetTime().Now().Sub(start).Seconds()
+	return self.stats, nil
+}
+
+func getOptions(config_obj *config_proto.Config) Options {
+	options := Options{
+		OpsPerSecond: 100,
+		MinAge:       time.Hour,
+	}
+
+	compaction_config := config_obj.Datastore.Compaction
+	if compaction_config.OpsPerSecond > 0 {
+		options.OpsPerSecond = compaction_config.OpsPerSecond
+	}
+
+	if compaction_config.MinAgeSec > 0 {
+		options.MinAge = time.Duration(compaction_config.MinAgeSec) * time.Second
+	}
+
+	return options
+}
+
+// Periodically compact the datastore. This runs on the master only.
+func NewCompactionService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	if config_obj.Datastore == nil || config_obj.Datastore.Compaction == nil {
+		return nil
+	}
+
+	interval := 24 * time.Hour
+	if config_obj.Datastore.Compaction.IntervalSec > 0 {
+		interval = time.Duration(
+			config_obj.Datastore.Compaction.IntervalSec) * time.Second
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> datastore compaction service for %v.",
+		services.GetOrgName(config_obj))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(interval):
+			}
+
+			stats, err := Compact(ctx, config_obj, getOptions(config_obj))
+			if err != nil {
+				logger.Error("CompactionService: %v", err)
+				continue
+			}
+
+			logger.Info("CompactionService: Removed %v orphaned tasks, "+
+				"%v stale pings and %v empty directories from %v clients, "+
+				"reclaiming %v bytes in %v seconds.",
+				stats.OrphanedTasks, stats.StalePings,
+				stats.EmptyDirectories, stats.ClientsScanned,
+				stats.BytesReclaimed, int(stats.DurationSec))
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package compaction_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services/compaction"
+)
+
+type CompactionTestSuite struct {
+	test_utils.TestSuite
+	dir string
+}
+
+func (self *CompactionTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+
+	var err error
+	self.dir, err = ioutil.TempDir("", "compaction_test")
+	assert.NoError(self.T(), err)
+
+	self.ConfigObj.Datastore.Implementation = "FileBaseDataStore"
+	self.ConfigObj.Datastore.FilestoreDirectory = self.dir
+	self.ConfigObj.Datastore.Location = self.dir
+
+	self.TestSuite.SetupTest()
+}
+
+func (self *CompactionTestSuite) TearDownTest() {
+	self.TestSuite.TearDownTest()
+	os.RemoveAll(self.dir)
+}
+
+func (self *CompactionTestSuite) exists(urn api.DSPathSpec) bool {
+	_, err := os.Stat(urn.AsDatastoreFilename(self.ConfigObj))
+	return err == nil
+}
+
+func (self *CompactionTestSuite) TestCompaction() {
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	// A live client with a task for a running flow, a deleted flow
+	// and the monitoring flow.
+	client := paths.NewClientPathManager("C.1")
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj, client.Path(),
+		&actions_proto.ClientInfo{ClientId: "C.1"}))
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj, client.Ping(),
+		&actions_proto.ClientInfo{ClientId: "C.1"}))
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj,
+		client.Flow("F.1").Path(), &flows_proto.ArtifactCollectorContext{}))
+
+	for task_id, flow_id := range map[uint64]string{
+		1: "F.1", 2: "F.2", 3: constants.MONITORING_WELL_KNOWN_FLOW} {
+		assert.NoError(self.T(), db.SetSubject(self.ConfigObj,
+			client.Task(task_id), &crypto_proto.VeloMessage{SessionId: flow_id}))
+	}
+
+	// A deleted client left its ping and a task behind.
+	deleted := paths.NewClientPathManager("C.2")
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj, deleted.Ping(),
+		&actions_proto.ClientInfo{ClientId: "C.2"}))
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj, deleted.Task(1),
+		&crypto_proto.VeloMessage{SessionId: "F.3"}))
+
+	empty_dir := filepath.Join(self.dir, "a", "b", "c")
+	assert.NoError(self.T(), os.MkdirAll(empty_dir, 0700))
+
+	// A dry run does not remove anything.
+	stats, err := compaction.Compact(context.Background(), self.ConfigObj,
+		compaction.Options{DryRun: true})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), uint64(2), stats.OrphanedTasks)
+	assert.Equal(self.T(), uint64(1), stats.StalePings)
+	assert.True(self.T(), self.exists(deleted.Ping()))
+
+	// Nothing is old enough to be removed.
+	stats, err = compaction.Compact(context.Background(), self.ConfigObj,
+		compaction.Options{MinAge: time.Hour})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), uint64(0), stats.OrphanedTasks+stats.StalePings+
+		stats.EmptyDirectories)
+
+	stats, err = compaction.Compact(context.Background(), self.ConfigObj,
+		compaction.Options{OpsPerSecond: 1000})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), uint64(2), stats.ClientsScanned)
+	assert.Equal(self.T(), uint64(2), stats.OrphanedTasks)
+	assert.Equal(self.T(), uint64(1), stats.StalePings)
+	assert.True(self.T(), stats.BytesReclaimed > 0)
+
+	assert.True(self.T(), self.exists(client.Ping()))
+	assert.True(self.T(), self.exists(client.Task(1)))
+	assert.False(self.T(), self.exists(client.Task(2)))
+	assert.True(self.T(), self.exists(client.Task(3)))
+
+	// The deleted client's directory is removed entirely.
+	_, err = os.Stat(paths.CLIENTS_ROOT.AddChild("C.2").
+		AsDatastoreDirectory(self.ConfigObj))
+	assert.True(self.T(), os.IsNotExist(err))
+
+	_, err = os.Stat(filepath.Join(self.dir, "a"))
+	assert.True(self.T(), os.IsNotExist(err))
+}
+
+func TestCompaction(t *testing.T) {
+	suite.Run(t, &CompactionTestSuite{})
+}
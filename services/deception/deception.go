@@ -0,0 +1,434 @@
+/*
+  Deception canaries.
+
+  Canaries are files, registry values and credentials which no
+  legitimate user or program has a reason to touch. They are planted
+  on endpoints by the Windows.Deception.Deploy artifact and removed
+  by Windows.Deception.Remove. Each canary has a unique token which
+  is embedded in its content.
+
+  The deception service keeps track of the canaries planted on each
+  client: when a deployment collection completes, its results are
+  recorded in the client's record in the datastore.
+
+  The Windows.Deception.Monitor client event artifact reports
+  accesses to anything that looks like a canary. The service matches
+  each event against the canaries planted on the client and raises a
+  high severity alert in the Server.Alerts.Canary event artifact.
+  Forward this artifact to a SIEM or TheHive to be notified. Repeated
+  accesses to the same canary are only alerted once per minute.
+*/
+
+package deception
+
+import (
+	"context"
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	DEPLOY_ARTIFACT  = "Windows.Deception.Deploy"
+	REMOVE_ARTIFACT  = "Windows.Deception.Remove"
+	MONITOR_ARTIFACT = "Windows.Deception.Monitor"
+	ALERT_ARTIFACT   = "Server.Alerts.Canary"
+
+	// Repeated accesses to the same canary are alerted at most once
+	// in this period.
+	ALERT_SUPPRESSION = time.Minute
+)
+
+var (
+	// Canaries are updated by deployments and events at the same
+	// time.
+	mu sync.Mutex
+
+	drive_regex  = regexp.MustCompile(`^([a-z]:|\\\\\?\\[a-z]:|\\device\\harddiskvolume[0-9]+)`)
+	hive_regex   = regexp.MustCompile(`^(hkey_[a-z_]+|hk[a-z]+|registry\\[a-z]+)\\`)
+	domain_regex = regexp.MustCompile(`^.+\\`)
+)
+
+// Get the canaries planted on the client.
+func GetCanaries(config_obj *config_proto.Config,
+	client_id string) (*api_proto.ClientCanaries, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api_proto.ClientCanaries{}
+	err = db.GetSubject(config_obj,
+		paths.NewClientPathManager(client_id).Canaries(), result)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	result.ClientId = client_id
+	return result, nil
+}
+
+func setCanaries(config_obj *config_proto.Config,
+	record *api_proto.ClientCanaries) error {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.SetSubject(config_obj,
+		paths.NewClientPathManager(record.ClientId).Canaries(), record)
+}
+
+func findToken(record *api_proto.ClientCanaries, token string) *api_proto.Canary {
+	for _, canary := range record.Canaries {
+		if canary.Token == token {
+			return canary
+		}
+	}
+	return nil
+}
+
+// Record the canaries planted or removed by the collection.
+func ProcessCollection(ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id, flow_id string) error {
+
+	launcher, err := services.GetLauncher(config_obj)
+	if err != nil {
+		return err
+	}
+
+	details, err := launcher.GetFlowDetails(config_obj, client_id, flow_id)
+	if err != nil {
+		return err
+	}
+
+	flow := details.Context
+	if flow == nil {
+		return nil
+	}
+
+	for _, artifact := range flow.ArtifactsWithResults {
+		if artifact != DEPLOY_ARTIFACT && artifact != REMOVE_ARTIFACT {
+			continue
+		}
+
+		rows, err := readResults(ctx, config_obj, client_id, flow_id, artifact)
+		if err != nil {
+			return err
+		}
+
+		err = RecordCanaries(config_obj, client_id, flow_id, artifact, rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readResults(ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id, flow_id, artifact string) ([]*ordereddict.Dict, error) {
+
+	path_manager, err := artifacts.NewArtifactPathManager(
+		config_obj, client_id, flow_id, artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := path_manager.GetPathForWriting()
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := result_sets.NewResultSetReader(
+		file_store.GetFileStore(config_obj), path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var result []*ordereddict.Dict
+	for row := range reader.Rows(ctx) {
+		result = append(result, row)
+	}
+	return result, nil
+}
+
+// Record the canaries planted or removed by the artifact.
+func RecordCanaries(config_obj *config_proto.Config,
+	client_id, flow_id, artifact string, rows []*ordereddict.Dict) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	record, err := GetCanaries(config_obj, client_id)
+	if err != nil {
+		return err
+	}
+
+	now := uint64(utils.GetTime().Now().Unix())
+	for _, row := range rows {
+		token, _ := row.GetString("Token")
+		if token == "" {
+			continue
+		}
+
+		canary := findToken(record, token)
+
+		switch artifact {
+		case DEPLOY_ARTIFACT:
+			if canary == nil {
+				canary = &api_proto.Canary{Token: token}
+				record.Canaries = append(record.Canaries, canary)
+			}
+			canary.Type, _ = row.GetString("Type")
+			canary.Location, _ = row.GetString("Location")
+			canary.Username, _ = row.GetString("Username")
+			canary.FlowId = flow_id
+			canary.Planted = now
+			canary.Removed = 0
+
+			// The new canary replaces any older one at the same
+			// location.
+			for _, other := range record.Canaries {
+				if other != canary && other.Removed == 0 &&
+					other.Type == canary.Type &&
+					strings.EqualFold(other.Location, canary.Location) {
+					other.Removed = now
+				}
+			}
+
+		case REMOVE_ARTIFACT:
+			// Canaries which could not be removed are still planted.
+			removed, pres := row.Get("Removed")
+			if pres && removed != true {
+				continue
+			}
+
+			if canary != nil && canary.Removed == 0 {
+				canary.Removed = now
+			}
+		}
+	}
+
+	return setCanaries(config_obj, record)
+}
+
+// Paths are compared without the drive since kernel events report
+// volume device names.
+func normalizePath(path string) string {
+	path = strings.ToLower(strings.ReplaceAll(path, "/", "\\"))
+	return drive_regex.ReplaceAllString(path, "")
+}
+
+// Registry paths are compared without the hive since kernel events
+// report HKEY_CURRENT_USER as the user's key in HKEY_USERS.
+func normalizeKey(key string) string {
+	key = strings.ToLower(strings.ReplaceAll(key, "/", "\\"))
+	return "\\" + hive_regex.ReplaceAllString(strings.TrimPrefix(key, "\\"), "")
+}
+
+func normalizeUsername(username string) string {
+	return domain_regex.ReplaceAllString(strings.ToLower(username), "")
+}
+
+// Find the planted canary the monitoring event refers to.
+func matchCanary(record *api_proto.ClientCanaries,
+	event *ordereddict.Dict) *api_proto.Canary {
+
+	event_type, _ := event.GetString("Type")
+	path, _ := event.GetString("Path")
+	token, _ := event.GetString("Token")
+
+	for _, canary := range record.Canaries {
+		if canary.Removed > 0 {
+			continue
+		}
+
+		if token != "" {
+			if canary.Token == token {
+				return canary
+			}
+			continue
+		}
+
+		switch event_type {
+		case "file":
+			// Credential canaries are planted in files too.
+			if canary.Type != "file" && canary.Type != "credential" {
+				continue
+			}
+
+			location := normalizePath(canary.Location)
+			if location != "" && strings.HasSuffix(normalizePath(path), location) {
+				return canary
+			}
+
+		case "registry":
+			if canary.Type != "registry" {
+				continue
+			}
+
+			// Opening the canary's key also counts.
+			value := normalizeKey(canary.Location)
+			key := value[:strings.LastIndex(value, "\\")]
+			event_key := normalizeKey(path)
+			if key != "" && (strings.HasSuffix(event_key, value) ||
+				strings.HasSuffix(event_key, key)) {
+				return canary
+			}
+
+		case "logon":
+			if canary.Type == "credential" && canary.Username != "" &&
+				normalizeUsername(path) == normalizeUsername(canary.Username) {
+				return canary
+			}
+		}
+	}
+
+	return nil
+}
+
+// Check the monitoring event against the client's canaries and
+// return the alert to raise (nil if none).
+func ProcessEvent(ctx context.Context,
+	config_obj *config_proto.Config,
+	event *ordereddict.Dict) (*ordereddict.Dict, error) {
+
+	client_id, _ := event.GetString("ClientId")
+	if client_id == "" {
+		return nil, nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	record, err := GetCanaries(config_obj, client_id)
+	if err != nil {
+		return nil, err
+	}
+
+	canary := matchCanary(record, event)
+	if canary == nil {
+		return nil, nil
+	}
+
+	now := utils.GetTime().Now()
+	last_triggered := time.Unix(int64(canary.LastTriggered), 0)
+	canary.LastTriggered = uint64(now.Unix())
+	canary.TriggerCount++
+
+	err = setCanaries(config_obj, record)
+	if err != nil {
+		return nil, err
+	}
+
+	if now.Sub(last_triggered) < ALERT_SUPPRESSION {
+		return nil, nil
+	}
+
+	var hostname string
+	client_info_manager, err := services.GetClientInfoManager(config_obj)
+	if err == nil {
+		client_info, err := client_info_manager.Get(ctx, client_id)
+		if err == nil {
+			hostname = client_info.Hostname
+		}
+	}
+
+	path, _ := event.GetString("Path")
+	event_type, _ := event.GetString("EventType")
+	process, _ := event.GetString("ProcessName")
+	pid, _ := event.Get("Pid")
+	user, _ := event.GetString("User")
+
+	return ordereddict.NewDict().
+		Set("Time", now.UTC()).
+		Set("ClientId", client_id).
+		Set("Hostname", hostname).
+		Set("Severity", "High").
+		Set("Type", canary.Type).
+		Set("Location", canary.Location).
+		Set("Username", canary.Username).
+		Set("Token", canary.Token).
+		Set("Planted", time.Unix(int64(canary.Planted), 0).UTC()).
+		Set("DeployFlowId", canary.FlowId).
+		Set("EventType", event_type).
+		Set("Path", path).
+		Set("ProcessName", process).
+		Set("Pid", pid).
+		Set("User", user).
+		Set("TriggerCount", canary.TriggerCount), nil
+}
+
+func NewDeceptionService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> deception service for %v.",
+		services.GetOrgName(config_obj))
+
+	err := journal.WatchQueueWithCB(ctx, config_obj, wg,
+		"System.Flow.Completion", "DeceptionService",
+		func(ctx context.Context, config_obj *config_proto.Config,
+			row *ordereddict.Dict) error {
+			client_id, _ := row.GetString("ClientId")
+			flow_id, _ := row.GetString("FlowId")
+			if client_id == "" || flow_id == "" {
+				return nil
+			}
+
+			err := ProcessCollection(ctx, config_obj, client_id, flow_id)
+			if err != nil {
+				logger.Error("DeceptionService: recording canaries of %v/%v: %v",
+					client_id, flow_id, err)
+			}
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	return journal.WatchQueueWithCB(ctx, config_obj, wg,
+		MONITOR_ARTIFACT, "DeceptionService",
+		func(ctx context.Context, config_obj *config_proto.Config,
+			row *ordereddict.Dict) error {
+			alert, err := ProcessEvent(ctx, config_obj, row)
+			if err != nil {
+				logger.Error("DeceptionService: %v", err)
+				return nil
+			}
+
+			if alert == nil {
+				return nil
+			}
+
+			journal_service, err := services.GetJournal(config_obj)
+			if err != nil {
+				return err
+			}
+
+			client_id, _ := alert.GetString("ClientId")
+			return journal_service.PushRowsToArtifact(config_obj,
+				[]*ordereddict.Dict{alert}, ALERT_ARTIFACT, client_id, "")
+		})
+}
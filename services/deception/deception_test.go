@@ -0,0 +1,157 @@
+package deception_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/services/deception"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+type DeceptionTestSuite struct {
+	test_utils.TestSuite
+
+	clock *utils.MockClock
+}
+
+func (self *DeceptionTestSuite) SetupTest() {
+	self.TestSuite.SetupTest()
+
+	self.clock = &utils.MockClock{MockNow: time.Unix(1600000000, 0)}
+
+	rows := []*ordereddict.Dict{
+		ordereddict.NewDict().
+			Set("Type", "file").
+			Set("Location", `C:\Users\Public\Documents\passwords.xlsx`).
+			Set("Token", "T1"),
+		ordereddict.NewDict().
+			Set("Type", "registry").
+			Set("Location", `HKEY_CURRENT_USER\Software\BackupAdmin\Password`).
+			Set("Token", "T2"),
+		ordereddict.NewDict().
+			Set("Type", "credential").
+			Set("Location", `C:\Users\Public\Desktop\admin.rdp`).
+			Set("Username", "svc_backup").
+			Set("Token", "T3"),
+	}
+
+	assert.NoError(self.T(), deception.RecordCanaries(self.ConfigObj,
+		"C.1", "F.1", deception.DEPLOY_ARTIFACT, rows))
+}
+
+// Report an access and return the token of the alerted canary.
+func (self *DeceptionTestSuite) access(event_type, path string) string {
+	closer := utils.MockTime(self.clock)
+	defer closer()
+
+	// Move past the suppression period of the previous alert.
+	self.clock.MockNow = self.clock.MockNow.Add(time.Hour)
+
+	alert, err := deception.ProcessEvent(self.Ctx, self.ConfigObj,
+		ordereddict.NewDict().
+			Set("ClientId", "C.1").
+			Set("Type", event_type).
+			Set("Path", path))
+	assert.NoError(self.T(), err)
+	if alert == nil {
+		return ""
+	}
+
+	token, _ := alert.GetString("Token")
+	return token
+}
+
+func (self *DeceptionTestSuite) TestMatching() {
+	record, err := deception.GetCanaries(self.ConfigObj, "C.1")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 3, len(record.Canaries))
+
+	// Kernel events report volume devices and the user's hive.
+	assert.Equal(self.T(), "T1", self.access("file",
+		`\Device\HarddiskVolume3\Users\Public\Documents\Passwords.xlsx`))
+	assert.Equal(self.T(), "T2", self.access("registry",
+		`\REGISTRY\USER\S-1-5-21-1-2-3-1001\Software\BackupAdmin`))
+	assert.Equal(self.T(), "T2", self.access("registry",
+		`\REGISTRY\USER\S-1-5-21-1-2-3-1001\Software\BackupAdmin\Password`))
+	assert.Equal(self.T(), "T3", self.access("file",
+		`c:/users/public/desktop/admin.rdp`))
+	assert.Equal(self.T(), "T3", self.access("logon", `CORP\SVC_Backup`))
+
+	assert.Equal(self.T(), "", self.access("file",
+		`C:\Users\Public\Documents\old_passwords.xlsx`))
+	assert.Equal(self.T(), "", self.access("registry",
+		`\REGISTRY\MACHINE\Software\Microsoft`))
+	assert.Equal(self.T(), "", self.access("logon", `Administrator`))
+
+	// Removed canaries are not matched.
+	assert.NoError(self.T(), deception.RecordCanaries(self.ConfigObj,
+		"C.1", "F.2", deception.REMOVE_ARTIFACT, []*ordereddict.Dict{
+			ordereddict.NewDict().Set("Token", "T1").Set("Removed", true),
+			ordereddict.NewDict().Set("Token", "T2").Set("Removed", false),
+		}))
+
+	assert.Equal(self.T(), "", self.access("file",
+		`C:\Users\Public\Documents\passwords.xlsx`))
+
+	// Canaries which could not be removed are still planted.
+	assert.Equal(self.T(), "T2", self.access("registry",
+		`HKCU\Software\BackupAdmin\Password`))
+
+	// Canaries planted again replace the old ones.
+	assert.NoError(self.T(), deception.RecordCanaries(self.ConfigObj,
+		"C.1", "F.3", deception.DEPLOY_ARTIFACT, []*ordereddict.Dict{
+			ordereddict.NewDict().
+				Set("Type", "registry").
+				Set("Location", `HKEY_CURRENT_USER\Software\BackupAdmin\Password`).
+				Set("Token", "T4"),
+		}))
+
+	assert.Equal(self.T(), "T4", self.access("registry",
+		`HKCU\Software\BackupAdmin\Password`))
+}
+
+func (self *DeceptionTestSuite) TestAlerts() {
+	closer := utils.MockTime(self.clock)
+	defer closer()
+
+	event := ordereddict.NewDict().
+		Set("ClientId", "C.1").
+		Set("Type", "logon").
+		Set("Path", "svc_backup").
+		Set("EventType", "4625")
+
+	alert, err := deception.ProcessEvent(self.Ctx, self.ConfigObj, event)
+	assert.NoError(self.T(), err)
+	assert.NotNil(self.T(), alert)
+
+	token, _ := alert.GetString("Token")
+	assert.Equal(self.T(), "T3", token)
+
+	// Repeated accesses are counted but not alerted.
+	self.clock.MockNow = self.clock.MockNow.Add(10 * time.Second)
+	alert, err = deception.ProcessEvent(self.Ctx, self.ConfigObj, event)
+	assert.NoError(self.T(), err)
+	assert.Nil(self.T(), alert)
+
+	self.clock.MockNow = self.clock.MockNow.Add(2 * time.Minute)
+	alert, err = deception.ProcessEvent(self.Ctx, self.ConfigObj, event)
+	assert.NoError(self.T(), err)
+	assert.NotNil(self.T(), alert)
+
+	count, _ := alert.Get("TriggerCount")
+	assert.Equal(self.T(), uint64(3), count)
+
+	// Other clients have no canaries.
+	alert, err = deception.ProcessEvent(self.Ctx, self.ConfigObj,
+		event.Set("ClientId", "C.2"))
+	assert.NoError(self.T(), err)
+	assert.Nil(self.T(), alert)
+}
+
+func TestDeceptionService(t *testing.T) {
+	suite.Run(t, &DeceptionTestSuite{})
+}
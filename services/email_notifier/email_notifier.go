@@ -0,0 +1,443 @@
+/*
+  The email notifier sends emails when things happen on the server.
+
+  Rules in the email_notifications section of the config select the
+  events to notify about:
+
+  - artifact: each row of a client or server event artifact. This is
+    how detection artifacts raise alerts by email.
+  - hunt_completed: a hunt is stopped or expires.
+  - server_error: the server logs an error.
+
+  The subject and body are Go templates so the email can be tailored
+  to the event. Each rule is throttled - after an email is sent, the
+  events in the next throttle period are collected and sent together
+  in a single email (up to max_events). A global limit on the number
+  of emails per hour protects the mail server if many rules fire at
+  once.
+
+  Emails are sent through the SMTP server configured in the Mail
+  section.
+*/
+
+package email_notifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	gomail "gopkg.in/gomail.v2"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	EVENT_ARTIFACT       = "artifact"
+	EVENT_HUNT_COMPLETED = "hunt_completed"
+	EVENT_SERVER_ERROR   = "server_error"
+
+	DEFAULT_THROTTLE            = 300
+	DEFAULT_MAX_EVENTS          = 100
+	DEFAULT_MAX_EMAILS_PER_HOUR = 60
+
+	FLUSH_FREQUENCY      = 10 * time.Second
+	HUNT_CHECK_FREQUENCY = 60 * time.Second
+)
+
+var (
+	template_funcs = template.FuncMap{
+		"json": func(value interface{}) string {
+			return json.MustMarshalString(value)
+		},
+	}
+
+	default_subject = "[Velociraptor] {{ .Rule }}"
+	default_body    = `{{ .Count }} event(s) for {{ .Rule }} in {{ .Org }}:
+{{ range .Events }}
+{{ json . }}
+{{ end }}{{ if .Dropped }}
+{{ .Dropped }} more event(s) were not included.
+{{ end }}`
+)
+
+// The data passed to the templates.
+type templateData struct {
+	Rule    string
+	Org     string
+	Events  []map[string]interface{}
+	Count   int
+	Dropped int
+}
+
+type rule struct {
+	config  *config_proto.EmailNotificationRule
+	subject *template.Template
+	body    *template.Template
+
+	throttle   time.Duration
+	max_events int
+
+	// Events waiting for the next email.
+	pending   []*ordereddict.Dict
+	dropped   int
+	last_sent time.Time
+}
+
+func newRule(rule_config *config_proto.EmailNotificationRule) (*rule, error) {
+	if len(rule_config.To) == 0 {
+		return nil, fmt.Errorf("Rule %v has no recipients", rule_config.Name)
+	}
+
+	switch rule_config.Event {
+	case EVENT_ARTIFACT:
+		if rule_config.Artifact == "" {
+			return nil, fmt.Errorf(
+				"Rule %v: artifact rules need an artifact", rule_config.Name)
+		}
+	case EVENT_HUNT_COMPLETED, EVENT_SERVER_ERROR:
+	default:
+		return nil, fmt.Errorf("Rule %v: unknown event %v",
+			rule_config.Name, rule_config.Event)
+	}
+
+	subject := rule_config.Subject
+	if subject == "" {
+		subject = default_subject
+	}
+
+	body := rule_config.Body
+	if body == "" {
+		body = default_body
+	}
+
+	result := &rule{
+		config:     rule_config,
+		throttle:   time.Duration(rule_config.Throttle) * time.Second,
+		max_events: int(rule_config.MaxEvents),
+	}
+
+	var err error
+	result.subject, err = template.New("subject").Funcs(template_funcs).Parse(subject)
+	if err != nil {
+		return nil, fmt.Errorf("Rule %v: subject: %w", rule_config.Name, err)
+	}
+
+	result.body, err = template.New("body").Funcs(template_funcs).Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("Rule %v: body: %w", rule_config.Name, err)
+	}
+
+	if result.throttle == 0 {
+		result.throttle = DEFAULT_THROTTLE * time.Second
+	}
+
+	if result.max_events == 0 {
+		result.max_events = DEFAULT_MAX_EVENTS
+	}
+
+	return result, nil
+}
+
+func (self *rule) add(event *ordereddict.Dict) {
+	if len(self.pending) >= self.max_events {
+		self.dropped++
+		return
+	}
+	self.pending = append(self.pending, event)
+}
+
+func (self *rule) ready(now time.Time) bool {
+	return (len(self.pending) > 0 || self.dropped > 0) &&
+		!now.Before(self.last_sent.Add(self.throttle))
+}
+
+type email struct {
+	rule    string
+	to      []string
+	subject string
+	body    string
+}
+
+// Render the pending events into an email.
+func (self *rule) render(org string) (*email, error) {
+	data := &templateData{
+		Rule:    self.config.Name,
+		Org:     org,
+		Count:   len(self.pending) + self.dropped,
+		Dropped: self.dropped,
+	}
+
+	for _, event := range self.pending {
+		data.Events = append(data.Events, *event.ToDict())
+	}
+
+	subject := &bytes.Buffer{}
+	err := self.subject.Execute(subject, data)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &bytes.Buffer{}
+	err = self.body.Execute(body, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &email{
+		rule:    self.config.Name,
+		to:      self.config.To,
+		subject: subject.String(),
+		body:    body.String(),
+	}, nil
+}
+
+type EmailNotifier struct {
+	mu sync.Mutex
+
+	config_obj          *config_proto.Config
+	rules               []*rule
+	max_emails_per_hour int
+
+	// When emails were sent in the last hour.
+	sent []time.Time
+
+	// Hunts which were already finished when last checked. Nil
+	// before the first check.
+	finished_hunts map[string]bool
+}
+
+// Queue an event for all the rules of this type.
+func (self *EmailNotifier) Emit(event_type string, event *ordereddict.Dict) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.emit(event_type, event)
+}
+
+func (self *EmailNotifier) emit(event_type string, event *ordereddict.Dict) {
+	for _, rule := range self.rules {
+		if rule.config.Event == event_type {
+			rule.add(event)
+		}
+	}
+}
+
+func (self *EmailNotifier) emitForRule(rule *rule, event *ordereddict.Dict) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	rule.add(event)
+}
+
+// Send emails for the rules which are not throttled.
+func (self *EmailNotifier) Flush() error {
+	emails, errs := self.collectEmails()
+	for _, email := range emails {
+		err := sendEmail(self.config_obj, email)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %v: %w", email.rule, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("EmailNotifier: %v", errs)
+	}
+	return nil
+}
+
+// Render the emails to send now. Errors are returned rather than
+// logged because logging an error may queue another event.
+func (self *EmailNotifier) collectEmails() ([]*email, []error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	now := utils.GetTime().Now()
+
+	// Expire sent times older than an hour.
+	sent := self.sent[:0]
+	for _, t := range self.sent {
+		if now.Sub(t) < time.Hour {
+			sent = append(sent, t)
+		}
+	}
+	self.sent = sent
+
+	org := services.GetOrgName(self.config_obj)
+
+	var result []*email
+	var errs []error
+	for _, rule := range self.rules {
+		if !rule.ready(now) {
+			continue
+		}
+
+		// Events stay pending until the next email is allowed.
+		if len(self.sent) >= self.max_emails_per_hour {
+			break
+		}
+
+		email, err := rule.render(org)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %v: %w", rule.config.Name, err))
+		} else {
+			result = append(result, email)
+			self.sent = append(self.sent, now)
+		}
+
+		rule.pending = nil
+		rule.dropped = 0
+		rule.last_sent = now
+	}
+
+	return result, errs
+}
+
+func sendEmail(config_obj *config_proto.Config, email *email) error {
+	mail_config := config_obj.Mail
+	if mail_config == nil || mail_config.Server == "" {
+		return errors.New("Mail server not configured")
+	}
+
+	from := mail_config.From
+	if from == "" {
+		from = mail_config.AuthUsername
+	}
+	if from == "" {
+		from = "Velociraptor"
+	}
+
+	m := gomail.NewMessage()
+	m.SetHeader("From", from)
+	m.SetHeader("To", email.to...)
+	m.SetHeader("Subject", email.subject)
+	m.SetBody("text/plain", email.body)
+
+	port := mail_config.ServerPort
+	if port == 0 {
+		port = 587
+	}
+
+	d := gomail.NewDialer(mail_config.Server, int(port),
+		mail_config.AuthUsername, mail_config.AuthPassword)
+	return d.DialAndSend(m)
+}
+
+func NewEmailNotificationService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*EmailNotifier, error) {
+
+	if config_obj.Mail == nil || config_obj.Mail.Server == "" {
+		return nil, errors.New(
+			"EmailNotifier: Mail server not configured")
+	}
+
+	email_config := config_obj.EmailNotifications
+	result := &EmailNotifier{
+		config_obj:          config_obj,
+		max_emails_per_hour: int(email_config.MaxEmailsPerHour),
+	}
+
+	if result.max_emails_per_hour == 0 {
+		result.max_emails_per_hour = DEFAULT_MAX_EMAILS_PER_HOUR
+	}
+
+	has_hunt_rules := false
+	has_error_rules := false
+	for _, rule_config := range email_config.Rules {
+		rule, err := newRule(rule_config)
+		if err != nil {
+			return nil, fmt.Errorf("EmailNotifier: %w", err)
+		}
+		result.rules = append(result.rules, rule)
+
+		switch rule_config.Event {
+		case EVENT_HUNT_COMPLETED:
+			has_hunt_rules = true
+		case EVENT_SERVER_ERROR:
+			has_error_rules = true
+		}
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> email notification service for %v.",
+		services.GetOrgName(config_obj))
+
+	for _, rule := range result.rules {
+		rule := rule
+		if rule.config.Event != EVENT_ARTIFACT {
+			continue
+		}
+
+		err := journal.WatchQueueWithCB(ctx, config_obj, wg,
+			rule.config.Artifact, "EmailNotifier",
+			func(ctx context.Context, config_obj *config_proto.Config,
+				row *ordereddict.Dict) error {
+				result.emitForRule(rule, row)
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Errors are logged by the whole server so are only reported
+	// once by the root org.
+	if has_error_rules && utils.IsRootOrg(config_obj.OrgId) {
+		logging.AddHook(&errorHook{ctx: ctx, notifier: result})
+	}
+
+	if has_hunt_rules {
+		// Hunts which are already finished are not reported.
+		err := result.CheckHunts()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		flush := time.NewTicker(FLUSH_FREQUENCY)
+		defer flush.Stop()
+
+		check_hunts := time.NewTicker(HUNT_CHECK_FREQUENCY)
+		defer check_hunts.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-flush.C:
+				err := result.Flush()
+				if err != nil {
+					logger.Error("%v", err)
+				}
+
+			case <-check_hunts.C:
+				if !has_hunt_rules {
+					continue
+				}
+
+				err := result.CheckHunts()
+				if err != nil {
+					logger.Error("EmailNotifier: %v", err)
+				}
+			}
+		}
+	}()
+
+	return result, nil
+}
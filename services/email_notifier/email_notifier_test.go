@@ -0,0 +1,333 @@
+package email_notifier_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/email_notifier"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+)
+
+type message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// A minimal SMTP server which records the messages it receives.
+type smtpServer struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	messages []message
+}
+
+func (self *smtpServer) serve() {
+	for {
+		conn, err := self.listener.Accept()
+		if err != nil {
+			return
+		}
+		go self.handle(conn)
+	}
+}
+
+func (self *smtpServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		fmt.Fprintf(conn, "%s\r\n", line)
+	}
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"),
+			strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+
+		case command == "DATA":
+			reply("354 Go ahead")
+			data := &strings.Builder{}
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			self.record(data.String())
+			reply("250 OK")
+
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func (self *smtpServer) record(data string) {
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	if err != nil {
+		return
+	}
+
+	body, err := io.ReadAll(msg.Body)
+	if err != nil {
+		return
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.messages = append(self.messages, message{
+		To:      msg.Header.Get("To"),
+		Subject: msg.Header.Get("Subject"),
+		Body: strings.TrimSuffix(
+			strings.ReplaceAll(string(body), "\r\n", "\n"), "\n"),
+	})
+}
+
+func (self *smtpServer) getMessages() []message {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := self.messages
+	self.messages = nil
+	return result
+}
+
+type EmailNotifierTestSuite struct {
+	test_utils.TestSuite
+
+	smtp  *smtpServer
+	clock *utils.MockClock
+	close func()
+}
+
+func (self *EmailNotifierTestSuite) SetupTest() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(self.T(), err)
+
+	self.smtp = &smtpServer{listener: listener}
+	go self.smtp.serve()
+
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Services.HuntDispatcher = true
+	self.ConfigObj.Mail = &config_proto.MailConfig{
+		From:       "velociraptor@example.com",
+		Server:     "127.0.0.1",
+		ServerPort: uint64(listener.Addr().(*net.TCPAddr).Port),
+	}
+	self.LoadArtifacts([]string{`
+name: Server.Audit.Test
+type: SERVER_EVENT
+`})
+	self.TestSuite.SetupTest()
+
+	self.clock = &utils.MockClock{MockNow: time.Unix(1600000000, 0)}
+	self.close = utils.MockTime(self.clock)
+}
+
+func (self *EmailNotifierTestSuite) TearDownTest() {
+	self.close()
+	self.smtp.listener.Close()
+	self.TestSuite.TearDownTest()
+}
+
+func (self *EmailNotifierTestSuite) start(
+	rules ...*config_proto.EmailNotificationRule) *email_notifier.EmailNotifier {
+	self.ConfigObj.EmailNotifications = &config_proto.EmailNotificationsConfig{
+		Rules:            rules,
+		MaxEmailsPerHour: 2,
+	}
+
+	notifier, err := email_notifier.NewEmailNotificationService(
+		self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+	return notifier
+}
+
+func (self *EmailNotifierTestSuite) TestArtifactRule() {
+	notifier := self.start(&config_proto.EmailNotificationRule{
+		Name:     "Audit",
+		Event:    email_notifier.EVENT_ARTIFACT,
+		Artifact: "Server.Audit.Test",
+		To:       []string{"soc@example.com"},
+		Subject:  "Audit: {{ .Count }} events",
+		Body: `{{ range .Events }}{{ .User }} did {{ .Action }}
+{{ end }}`,
+	})
+
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	err = journal.PushRowsToArtifact(self.ConfigObj,
+		[]*ordereddict.Dict{ordereddict.NewDict().
+			Set("User", "admin").
+			Set("Action", "DeleteClient")},
+		"Server.Audit.Test", "server", "")
+	assert.NoError(self.T(), err)
+
+	// The row is delivered asynchronously.
+	var messages []message
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		assert.NoError(self.T(), notifier.Flush())
+		messages = append(messages, self.smtp.getMessages()...)
+		return len(messages) > 0
+	})
+
+	assert.Equal(self.T(), []message{{
+		To:      "soc@example.com",
+		Subject: "Audit: 1 events",
+		Body:    "admin did DeleteClient",
+	}}, messages)
+}
+
+func (self *EmailNotifierTestSuite) TestThrottle() {
+	notifier := self.start(&config_proto.EmailNotificationRule{
+		Name:      "Errors",
+		Event:     email_notifier.EVENT_SERVER_ERROR,
+		To:        []string{"admin@example.com"},
+		Body:      "{{ range .Events }}{{ .Message }}\n{{ end }}{{ .Dropped }} dropped",
+		MaxEvents: 2,
+	})
+
+	logger := logging.GetLogger(self.ConfigObj, &logging.FrontendComponent)
+	logger.Error("boom 1")
+
+	assert.NoError(self.T(), notifier.Flush())
+	assert.Equal(self.T(), []message{{
+		To:      "admin@example.com",
+		Subject: "[Velociraptor] Errors",
+		Body:    "boom 1\n0 dropped",
+	}}, self.smtp.getMessages())
+
+	// Events are held until the throttle period passes and only
+	// max_events are included.
+	logger.Error("boom 2")
+	logger.Error("boom 3")
+	logger.Error("boom 4")
+
+	assert.NoError(self.T(), notifier.Flush())
+	assert.Equal(self.T(), 0, len(self.smtp.getMessages()))
+
+	self.clock.MockNow = self.clock.MockNow.Add(301 * time.Second)
+	assert.NoError(self.T(), notifier.Flush())
+
+	messages := self.smtp.getMessages()
+	assert.Equal(self.T(), 1, len(messages))
+	assert.Equal(self.T(), "boom 2\nboom 3\n1 dropped", messages[0].Body)
+
+	// Only 2 emails are sent per hour.
+	logger.Error("boom 5")
+	self.clock.MockNow = self.clock.MockNow.Add(301 * time.Second)
+	assert.NoError(self.T(), notifier.Flush())
+	assert.Equal(self.T(), 0, len(self.smtp.getMessages()))
+
+	self.clock.MockNow = self.clock.MockNow.Add(time.Hour)
+	assert.NoError(self.T(), notifier.Flush())
+
+	messages = self.smtp.getMessages()
+	assert.Equal(self.T(), 1, len(messages))
+	assert.Equal(self.T(), "boom 5\n0 dropped", messages[0].Body)
+}
+
+func (self *EmailNotifierTestSuite) addHunt(hunt_obj *api_proto.Hunt) {
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	hunt_path_manager := paths.NewHuntPathManager(hunt_obj.HuntId)
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj,
+		hunt_path_manager.Path(), hunt_obj))
+
+	hunt_dispatcher, err := services.GetHuntDispatcher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.NoError(self.T(), hunt_dispatcher.Refresh(self.ConfigObj))
+}
+
+func (self *EmailNotifierTestSuite) TestHuntCompleted() {
+	now := uint64(self.clock.MockNow.UnixNano() / 1000)
+
+	// Hunts finished before the service starts are not reported.
+	self.addHunt(&api_proto.Hunt{
+		HuntId: "H.1",
+		State:  api_proto.Hunt_STOPPED,
+	})
+
+	self.addHunt(&api_proto.Hunt{
+		HuntId:          "H.2",
+		HuntDescription: "Find evil",
+		State:           api_proto.Hunt_RUNNING,
+		StartTime:       now,
+		Expires:         now + 60*1000000,
+		Stats: &api_proto.HuntStats{
+			TotalClientsScheduled:   10,
+			TotalClientsWithResults: 8,
+		},
+	})
+
+	notifier := self.start(&config_proto.EmailNotificationRule{
+		Name:    "Hunts",
+		Event:   email_notifier.EVENT_HUNT_COMPLETED,
+		To:      []string{"soc@example.com"},
+		Subject: "{{ range .Events }}{{ .HuntId }} {{ .State }}{{ end }}",
+		Body:    `{{ range .Events }}{{ .Description }}: {{ .TotalClientsWithResults }}/{{ .TotalClientsScheduled }}{{ end }}`,
+	})
+
+	assert.NoError(self.T(), notifier.CheckHunts())
+	assert.NoError(self.T(), notifier.Flush())
+	assert.Equal(self.T(), 0, len(self.smtp.getMessages()))
+
+	// The hunt expires.
+	self.clock.MockNow = self.clock.MockNow.Add(2 * time.Minute)
+	assert.NoError(self.T(), notifier.CheckHunts())
+	assert.NoError(self.T(), notifier.Flush())
+
+	assert.Equal(self.T(), []message{{
+		To:      "soc@example.com",
+		Subject: "H.2 EXPIRED",
+		Body:    "Find evil: 8/10",
+	}}, self.smtp.getMessages())
+
+	// It is only reported once.
+	self.clock.MockNow = self.clock.MockNow.Add(10 * time.Minute)
+	assert.NoError(self.T(), notifier.CheckHunts())
+	assert.NoError(self.T(), notifier.Flush())
+	assert.Equal(self.T(), 0, len(self.smtp.getMessages()))
+}
+
+func TestEmailNotifier(t *testing.T) {
+	suite.Run(t, &EmailNotifierTestSuite{})
+}
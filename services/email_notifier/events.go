@@ -0,0 +1,124 @@
+package email_notifier
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// Hunts are finished when they are stopped or expire. Hunts do not
+// fire an event when they expire so we check them periodically.
+func (self *EmailNotifier) CheckHunts() error {
+	hunt_dispatcher, err := services.GetHuntDispatcher(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	now := utils.GetTime().Now()
+
+	type finishedHunt struct {
+		hunt_id string
+		event   *ordereddict.Dict
+	}
+
+	var finished []finishedHunt
+	err = hunt_dispatcher.ApplyFuncOnHunts(func(hunt_obj *api_proto.Hunt) error {
+		state := ""
+		switch hunt_obj.State {
+		case api_proto.Hunt_STOPPED, api_proto.Hunt_ARCHIVED:
+			state = "STOPPED"
+
+		case api_proto.Hunt_RUNNING, api_proto.Hunt_PAUSED:
+			expires := time.Unix(0, int64(hunt_obj.Expires)*1000)
+			if hunt_obj.Expires == 0 || now.Before(expires) {
+				return nil
+			}
+			state = "EXPIRED"
+
+		default:
+			return nil
+		}
+
+		finished = append(finished, finishedHunt{
+			hunt_id: hunt_obj.HuntId,
+			event:   huntEvent(hunt_obj, state),
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	first_check := self.finished_hunts == nil
+	if first_check {
+		self.finished_hunts = make(map[string]bool)
+	}
+
+	for _, hunt := range finished {
+		if self.finished_hunts[hunt.hunt_id] {
+			continue
+		}
+		self.finished_hunts[hunt.hunt_id] = true
+
+		if !first_check {
+			self.emit(EVENT_HUNT_COMPLETED, hunt.event)
+		}
+	}
+
+	return nil
+}
+
+func huntEvent(hunt_obj *api_proto.Hunt, state string) *ordereddict.Dict {
+	result := ordereddict.NewDict().
+		Set("HuntId", hunt_obj.HuntId).
+		Set("Description", hunt_obj.HuntDescription).
+		Set("Creator", hunt_obj.Creator).
+		Set("State", state).
+		Set("Artifacts", hunt_obj.ArtifactSources).
+		Set("StartTime", time.Unix(0, int64(hunt_obj.StartTime)*1000).UTC())
+
+	stats := hunt_obj.Stats
+	if stats != nil {
+		result.Set("TotalClientsScheduled", stats.TotalClientsScheduled).
+			Set("TotalClientsWithResults", stats.TotalClientsWithResults).
+			Set("TotalClientsWithErrors", stats.TotalClientsWithErrors)
+	}
+
+	return result
+}
+
+// Queues a server_error event for each error the server logs.
+type errorHook struct {
+	ctx      context.Context
+	notifier *EmailNotifier
+}
+
+func (self *errorHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.ErrorLevel}
+}
+
+func (self *errorHook) Fire(entry *logrus.Entry) error {
+	// Hooks can not be removed so stop when the service is done.
+	if self.ctx.Err() != nil {
+		return nil
+	}
+
+	// Failing to send an email must not cause more emails.
+	if strings.HasPrefix(entry.Message, "EmailNotifier:") {
+		return nil
+	}
+
+	self.notifier.Emit(EVENT_SERVER_ERROR, ordereddict.NewDict().
+		Set("Time", entry.Time.UTC()).
+		Set("Message", entry.Message))
+	return nil
+}
@@ -0,0 +1,348 @@
+/*
+  Manual approval of newly enrolled clients.
+
+  Velociraptor is a zero registration system - any machine which
+  obtains the client package can enroll with the server. When
+  Frontend.require_enrollment_approval is set, new clients are held in
+  a pending state until a user approves them.
+
+  Pending clients are still interrogated so the user can tell what
+  they are, but the launcher refuses any other collection, the hunt
+  manager does not schedule hunts on them and they do not receive the
+  client monitoring table. Once approved, the client picks up the
+  monitoring table and all running hunts on its next check in.
+
+  Rejected clients stay blocked until they are approved (or deleted).
+  Approved clients have no record - clients which enrolled before
+  approval was required are therefore not affected.
+
+  Records are stored in the org's datastore under /enrollment/ and
+  every decision is sent to the Server.Internal.EnrollmentApproval
+  event artifact.
+*/
+
+package enrollment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	STATE_PENDING  = "PENDING"
+	STATE_APPROVED = "APPROVED"
+	STATE_REJECTED = "REJECTED"
+
+	EVENT_ARTIFACT = "Server.Internal.EnrollmentApproval"
+
+	// Collections by the interrogation service are allowed on
+	// pending clients so they can be identified.
+	INTERROGATION_CREATOR = "InterrogationService"
+
+	// Other frontends learn about decisions within this time.
+	refreshInterval = 10 * time.Second
+)
+
+var (
+	// Blocked clients by org. This is checked for every client
+	// message so we avoid hitting the datastore each time.
+	cache_mu sync.Mutex
+	cache    = make(map[string]*blockedClients)
+
+	InvalidDecisionError = errors.New("Invalid enrollment decision")
+)
+
+type blockedClients struct {
+	// Client id -> state
+	states  map[string]string
+	expires time.Time
+}
+
+func IsRequired(config_obj *config_proto.Config) bool {
+	return config_obj.Frontend != nil &&
+		config_obj.Frontend.RequireEnrollmentApproval
+}
+
+func recordPath(state, client_id string) api.DSPathSpec {
+	return paths.ENROLLMENT_ROOT.AddChild(strings.ToLower(state), client_id)
+}
+
+// A client's record moves between the state directories so we lock
+// the client rather than the record.
+func lockClient(config_obj *config_proto.Config,
+	client_id string) (func(), error) {
+	return datastore.LockSubject(config_obj,
+		paths.ENROLLMENT_ROOT.AddChild(client_id))
+}
+
+// Get the state of a blocked client. Returns STATE_APPROVED for
+// clients which may receive collections.
+func GetState(config_obj *config_proto.Config, client_id string) string {
+	if !IsRequired(config_obj) || client_id == "server" {
+		return STATE_APPROVED
+	}
+
+	cache_mu.Lock()
+	defer cache_mu.Unlock()
+
+	now := utils.GetTime().Now()
+	blocked, pres := cache[config_obj.OrgId]
+	if !pres || now.After(blocked.expires) {
+		states, err := loadBlockedClients(config_obj)
+		if err != nil {
+			// Fail closed if we can not tell.
+			return STATE_PENDING
+		}
+
+		blocked = &blockedClients{
+			states:  states,
+			expires: now.Add(refreshInterval),
+		}
+		cache[config_obj.OrgId] = blocked
+	}
+
+	state, pres := blocked.states[client_id]
+	if !pres {
+		return STATE_APPROVED
+	}
+	return state
+}
+
+func IsApproved(config_obj *config_proto.Config, client_id string) bool {
+	return GetState(config_obj, client_id) == STATE_APPROVED
+}
+
+// Called by the launcher before scheduling a collection on the
+// client.
+func CheckCollection(config_obj *config_proto.Config,
+	client_id, creator string) error {
+	if creator == INTERROGATION_CREATOR {
+		return nil
+	}
+
+	state := GetState(config_obj, client_id)
+	if state == STATE_APPROVED {
+		return nil
+	}
+
+	return fmt.Errorf("%w: client %v is %v",
+		acls.EnrollmentPending, client_id, strings.ToLower(state))
+}
+
+func invalidateCache(config_obj *config_proto.Config) {
+	cache_mu.Lock()
+	defer cache_mu.Unlock()
+
+	delete(cache, config_obj.OrgId)
+}
+
+func loadBlockedClients(
+	config_obj *config_proto.Config) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, state := range []string{STATE_PENDING, STATE_REJECTED} {
+		children, err := datastore.ListSubjects(config_obj,
+			paths.ENROLLMENT_ROOT.AddChild(strings.ToLower(state)))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, child := range children {
+			result[child.Base()] = state
+		}
+	}
+
+	return result, nil
+}
+
+func getRecord(config_obj *config_proto.Config,
+	client_id string) (*api_proto.EnrollmentRecord, error) {
+	for _, state := range []string{STATE_PENDING, STATE_REJECTED} {
+		result := &api_proto.EnrollmentRecord{}
+		err := datastore.GetExistingSubject(config_obj,
+			recordPath(state, client_id), result)
+		if err == nil {
+			return result, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %v is not pending approval",
+		InvalidDecisionError, client_id)
+}
+
+// Hold a newly enrolled client until it is approved. Clients which
+// were already rejected remain rejected.
+func AddPendingClient(config_obj *config_proto.Config, client_id string) error {
+	if !IsRequired(config_obj) {
+		return nil
+	}
+
+	unlock, err := lockClient(config_obj, client_id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	_, err = getRecord(config_obj, client_id)
+	if err == nil {
+		return nil
+	}
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	record := &api_proto.EnrollmentRecord{
+		ClientId:   client_id,
+		State:      STATE_PENDING,
+		CreateTime: uint64(utils.GetTime().Now().Unix()),
+	}
+
+	err = db.SetSubject(config_obj, recordPath(STATE_PENDING, client_id), record)
+	if err != nil {
+		return err
+	}
+
+	invalidateCache(config_obj)
+	notify(config_obj, record)
+
+	return nil
+}
+
+// List the blocked clients, oldest first, with what we know about
+// them from their interrogation.
+func ListEnrollments(ctx context.Context, config_obj *config_proto.Config,
+	state string) ([]*api_proto.EnrollmentRecord, error) {
+	indexer, err := services.GetIndexer(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*api_proto.EnrollmentRecord
+	for _, s := range []string{STATE_PENDING, STATE_REJECTED} {
+		if state != "" && state != s {
+			continue
+		}
+
+		children, err := datastore.ListSubjects(config_obj,
+			paths.ENROLLMENT_ROOT.AddChild(strings.ToLower(s)))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, child := range children {
+			record := &api_proto.EnrollmentRecord{}
+			err = datastore.GetExistingSubject(config_obj, child, record)
+			if err != nil {
+				continue
+			}
+
+			// The client may not be interrogated yet.
+			record.Client, _ = indexer.FastGetApiClient(
+				ctx, config_obj, record.ClientId)
+			result = append(result, record)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreateTime < result[j].CreateTime
+	})
+
+	return result, nil
+}
+
+// Approve or reject a blocked client.
+func Decide(ctx context.Context, config_obj *config_proto.Config,
+	principal string, decision *api_proto.EnrollmentDecision) (
+	*api_proto.EnrollmentRecord, error) {
+
+	unlock, err := lockClient(config_obj, decision.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	record, err := getRecord(config_obj, decision.ClientId)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	old_state := record.State
+	record.DecidedBy = principal
+	record.Comment = decision.Comment
+	record.DecisionTime = uint64(utils.GetTime().Now().Unix())
+
+	if decision.Approve {
+		record.State = STATE_APPROVED
+	} else {
+		if old_state == STATE_REJECTED {
+			return nil, fmt.Errorf("%w: %v is already rejected",
+				InvalidDecisionError, decision.ClientId)
+		}
+
+		record.State = STATE_REJECTED
+		err = db.SetSubject(config_obj,
+			recordPath(STATE_REJECTED, record.ClientId), record)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = datastore.DeleteSubject(config_obj,
+		recordPath(old_state, record.ClientId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidateCache(config_obj)
+	notify(config_obj, record)
+
+	// Get the approved client to check in so it picks up the
+	// monitoring table and hunts straight away.
+	if record.State == STATE_APPROVED {
+		notifier, err := services.GetNotifier(config_obj)
+		if err == nil {
+			_ = notifier.NotifyListener(
+				config_obj, record.ClientId, "EnrollmentApproved")
+		}
+	}
+
+	return record, nil
+}
+
+// Send the decision to the event artifact so users can be notified.
+func notify(config_obj *config_proto.Config,
+	record *api_proto.EnrollmentRecord) {
+	journal, err := services.GetJournal(config_obj)
+	if err != nil {
+		return
+	}
+
+	journal.PushRowsToArtifactAsync(config_obj,
+		ordereddict.NewDict().
+			Set("ClientId", record.ClientId).
+			Set("State", record.State).
+			Set("DecidedBy", record.DecidedBy).
+			Set("Comment", record.Comment),
+		EVENT_ARTIFACT)
+}
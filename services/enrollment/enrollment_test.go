@@ -0,0 +1,117 @@
+package enrollment_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/enrollment"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+)
+
+type EnrollmentTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *EnrollmentTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Frontend.RequireEnrollmentApproval = true
+	self.LoadArtifacts([]string{`
+name: Server.Internal.EnrollmentApproval
+type: SERVER_EVENT
+`, `
+name: Custom.Safe
+sources:
+- query: SELECT * FROM info()
+`})
+	self.TestSuite.SetupTest()
+}
+
+func (self *EnrollmentTestSuite) collect(client_id, creator string) error {
+	manager, err := services.GetRepositoryManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	repository, err := manager.GetGlobalRepository(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	launcher, err := services.GetLauncher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	_, err = launcher.ScheduleArtifactCollection(self.Ctx, self.ConfigObj,
+		acl_managers.NullACLManager{}, repository,
+		&flows_proto.ArtifactCollectorArgs{
+			Creator:   creator,
+			ClientId:  client_id,
+			Artifacts: []string{"Custom.Safe"},
+		}, nil)
+	return err
+}
+
+func (self *EnrollmentTestSuite) TestApproval() {
+	// Clients without a record are not affected.
+	assert.NoError(self.T(), self.collect("C.1", "admin"))
+
+	assert.NoError(self.T(),
+		enrollment.AddPendingClient(self.ConfigObj, "C.2"))
+	assert.Equal(self.T(), enrollment.STATE_PENDING,
+		enrollment.GetState(self.ConfigObj, "C.2"))
+
+	// Pending clients may only be interrogated.
+	assert.ErrorIs(self.T(), self.collect("C.2", "admin"),
+		acls.EnrollmentPending)
+	assert.NoError(self.T(),
+		self.collect("C.2", enrollment.INTERROGATION_CREATOR))
+
+	pending, err := enrollment.ListEnrollments(
+		self.Ctx, self.ConfigObj, enrollment.STATE_PENDING)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(pending))
+	assert.Equal(self.T(), "C.2", pending[0].ClientId)
+
+	rejected, err := enrollment.Decide(self.Ctx, self.ConfigObj, "admin",
+		&api_proto.EnrollmentDecision{ClientId: "C.2", Comment: "Unknown"})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), enrollment.STATE_REJECTED, rejected.State)
+	assert.ErrorIs(self.T(), self.collect("C.2", "admin"),
+		acls.EnrollmentPending)
+
+	// Enrolling again does not reset a rejected client.
+	assert.NoError(self.T(),
+		enrollment.AddPendingClient(self.ConfigObj, "C.2"))
+	assert.Equal(self.T(), enrollment.STATE_REJECTED,
+		enrollment.GetState(self.ConfigObj, "C.2"))
+
+	approved, err := enrollment.Decide(self.Ctx, self.ConfigObj, "admin",
+		&api_proto.EnrollmentDecision{ClientId: "C.2", Approve: true})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), enrollment.STATE_APPROVED, approved.State)
+	assert.Equal(self.T(), "admin", approved.DecidedBy)
+	assert.NoError(self.T(), self.collect("C.2", "admin"))
+
+	// Approved clients have no record left.
+	_, err = enrollment.Decide(self.Ctx, self.ConfigObj, "admin",
+		&api_proto.EnrollmentDecision{ClientId: "C.2", Approve: true})
+	assert.ErrorIs(self.T(), err, enrollment.InvalidDecisionError)
+
+	all, err := enrollment.ListEnrollments(self.Ctx, self.ConfigObj, "")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 0, len(all))
+}
+
+func (self *EnrollmentTestSuite) TestNotRequired() {
+	self.ConfigObj.Frontend.RequireEnrollmentApproval = false
+
+	assert.NoError(self.T(),
+		enrollment.AddPendingClient(self.ConfigObj, "C.3"))
+	assert.True(self.T(), enrollment.IsApproved(self.ConfigObj, "C.3"))
+	assert.NoError(self.T(), self.collect("C.3", "admin"))
+}
+
+func TestEnrollment(t *testing.T) {
+	suite.Run(t, &EnrollmentTestSuite{})
+}
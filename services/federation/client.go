@@ -0,0 +1,152 @@
+package federation
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Velocidex/ordereddict"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	// Replaced in tests.
+	Factory PeerClientFactory = GRPCPeerClient{}
+)
+
+// Makes API clients connected to downstream servers.
+type PeerClientFactory interface {
+	GetPeerClient(ctx context.Context,
+		peer *config_proto.FederationPeerConfig) (
+		api_proto.APIClient, func() error, error)
+}
+
+// Connects to the downstream server's gRPC API authenticating with
+// the peer's API client certificate.
+type GRPCPeerClient struct{}
+
+func (self GRPCPeerClient) GetPeerClient(
+	ctx context.Context,
+	peer *config_proto.FederationPeerConfig) (
+	api_proto.APIClient, func() error, error) {
+
+	api_config := peer.Api
+	if api_config == nil || api_config.ApiConnectionString == "" {
+		return nil, nil, fmt.Errorf("Peer %v has no API config", peer.Name)
+	}
+
+	cert, err := tls.X509KeyPair(
+		[]byte(api_config.ClientCert),
+		[]byte(api_config.ClientPrivateKey))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The downstream server's certificate must be signed by its CA.
+	ca_pool := x509.NewCertPool()
+	if !ca_pool.AppendCertsFromPEM([]byte(api_config.CaCertificate)) {
+		return nil, nil, fmt.Errorf(
+			"Peer %v: unable to parse the CA certificate", peer.Name)
+	}
+
+	server_name := api_config.PinnedServerName
+	if server_name == "" {
+		server_name = "VelociraptorServer"
+	}
+
+	creds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      ca_pool,
+		ServerName:   server_name,
+	})
+
+	conn, err := grpc.DialContext(ctx, api_config.ApiConnectionString,
+		grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return api_proto.NewAPIClient(conn), conn.Close, nil
+}
+
+// Run a query on the peer and call cb with each row. Values in env
+// are passed to the query as strings. Returns the query's log
+// messages, which explain why a query produced no rows.
+func runQuery(ctx context.Context,
+	config_obj *config_proto.Config,
+	peer *config_proto.FederationPeerConfig,
+	query string, env *ordereddict.Dict,
+	cb func(row *ordereddict.Dict) error) (logs []string, err error) {
+
+	client, closer, err := Factory.GetPeerClient(ctx, peer)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = closer() }()
+
+	timeout := uint64(600)
+	if config_obj.Federation != nil && config_obj.Federation.Timeout > 0 {
+		timeout = config_obj.Federation.Timeout
+	}
+
+	request := &actions_proto.VQLCollectorArgs{
+		OrgId:   peer.OrgId,
+		MaxRow:  1000,
+		MaxWait: 1,
+		Timeout: timeout,
+		Query:   []*actions_proto.VQLRequest{{VQL: query}},
+	}
+
+	if env != nil {
+		for _, k := range env.Keys() {
+			v, _ := env.Get(k)
+			request.Env = append(request.Env, &actions_proto.VQLEnv{
+				Key: k, Value: utils.ToString(v)})
+		}
+	}
+
+	stream, err := client.Query(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return logs, nil
+		}
+		if err != nil {
+			return logs, err
+		}
+
+		if response.Log != "" {
+			logs = append(logs, response.Log)
+			continue
+		}
+
+		json_response := response.Response
+		if json_response == "" {
+			json_response = response.JSONLResponse
+		}
+
+		rows, err := utils.ParseJsonToDicts([]byte(json_response))
+		if err != nil {
+			return logs, err
+		}
+
+		for _, row := range rows {
+			err = cb(row)
+			if err != nil {
+				return logs, err
+			}
+		}
+	}
+}
@@ -0,0 +1,173 @@
+/*
+  Federation lets a central server run queries and hunts on
+  independent downstream Velociraptor deployments (e.g. other sites
+  or air-gapped enclaves).
+
+  Each downstream server is configured as a peer with an API client
+  config generated on that server. The central server connects to
+  the peer's gRPC API with the client certificate and runs VQL there,
+  so the downstream server keeps full control over what the
+  federation identity may do (through its roles).
+
+  Federated hunts create a hunt on each peer and record the remote
+  hunt ids locally. The federation service periodically fetches the
+  merged results of each remote hunt and merges the results of all
+  peers into local result sets, adding a Peer column to each row.
+  Once the hunts are finished on all peers (or have expired) the
+  results are fetched a final time and the hunt is no longer synced.
+
+  The service runs on the master node.
+*/
+
+package federation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+)
+
+// Find the named peers. All peers are returned if no names are
+// given.
+func getPeers(config_obj *config_proto.Config,
+	names []string) ([]*config_proto.FederationPeerConfig, error) {
+
+	if config_obj.Federation == nil || len(config_obj.Federation.Peers) == 0 {
+		return nil, fmt.Errorf("Federation is not configured")
+	}
+
+	if len(names) == 0 {
+		return config_obj.Federation.Peers, nil
+	}
+
+	var result []*config_proto.FederationPeerConfig
+	for _, name := range names {
+		peer := getPeer(config_obj, name)
+		if peer == nil {
+			return nil, fmt.Errorf("Peer %v is not configured", name)
+		}
+		result = append(result, peer)
+	}
+	return result, nil
+}
+
+func getPeer(config_obj *config_proto.Config,
+	name string) *config_proto.FederationPeerConfig {
+	if config_obj.Federation == nil {
+		return nil
+	}
+
+	for _, peer := range config_obj.Federation.Peers {
+		if peer.Name == name {
+			return peer
+		}
+	}
+	return nil
+}
+
+// Run the query on the peers in parallel. Each row is passed to cb
+// with a Peer column added. cb may be called concurrently. Peers
+// which fail do not stop the others - their errors are returned
+// together.
+func Query(ctx context.Context, config_obj *config_proto.Config,
+	peer_names []string, query string, env *ordereddict.Dict,
+	cb func(row *ordereddict.Dict)) error {
+
+	peers, err := getPeers(config_obj, peer_names)
+	if err != nil {
+		return err
+	}
+
+	mu := sync.Mutex{}
+	var errors []string
+
+	wg := &sync.WaitGroup{}
+	for _, peer := range peers {
+		wg.Add(1)
+		go func(peer *config_proto.FederationPeerConfig) {
+			defer wg.Done()
+
+			_, err := runQuery(ctx, config_obj, peer, query, env,
+				func(row *ordereddict.Dict) error {
+					cb(row.Set("Peer", peer.Name))
+					return nil
+				})
+			if err != nil {
+				mu.Lock()
+				errors = append(errors, fmt.Sprintf("%v: %v", peer.Name, err))
+				mu.Unlock()
+			}
+		}(peer)
+	}
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return fmt.Errorf("Query failed on peers: %v", strings.Join(errors, "; "))
+	}
+	return nil
+}
+
+// Sync all the federated hunts which are still active.
+func SyncHunts(ctx context.Context, config_obj *config_proto.Config) error {
+	hunts, err := ListHunts(config_obj)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	for _, hunt := range hunts {
+		if hunt.Stopped {
+			continue
+		}
+
+		_, err := SyncHunt(ctx, config_obj, hunt.HuntId)
+		if err != nil {
+			logger.Error("FederationService: syncing %v: %v", hunt.HuntId, err)
+		}
+	}
+
+	return nil
+}
+
+func NewFederationService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	interval := time.Duration(300) * time.Second
+	if config_obj.Federation.SyncPeriodSeconds > 0 {
+		interval = time.Duration(
+			config_obj.Federation.SyncPeriodSeconds) * time.Second
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> federation service for %v with %v peers.",
+		services.GetOrgName(config_obj), len(config_obj.Federation.Peers))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(interval):
+				err := SyncHunts(ctx, config_obj)
+				if err != nil {
+					logger.Error("FederationService: %v", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,258 @@
+package federation_test
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/services/federation"
+	"www.velocidex.com/golang/velociraptor/utils"
+
+	_ "www.velocidex.com/golang/velociraptor/result_sets/simple"
+)
+
+const artifact = "Generic.Client.Info/BasicInformation"
+
+type fakeStream struct {
+	grpc.ClientStream
+	responses []*actions_proto.VQLResponse
+}
+
+func (self *fakeStream) Recv() (*actions_proto.VQLResponse, error) {
+	if len(self.responses) == 0 {
+		return nil, io.EOF
+	}
+	response := self.responses[0]
+	self.responses = self.responses[1:]
+	return response, nil
+}
+
+// A downstream server which answers each query with canned rows.
+type fakePeer struct {
+	api_proto.APIClient
+
+	name    string
+	mu      sync.Mutex
+	queries []string
+	state   string
+	denied  bool
+}
+
+func (self *fakePeer) Query(ctx context.Context,
+	in *actions_proto.VQLCollectorArgs,
+	opts ...grpc.CallOption) (api_proto.API_QueryClient, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	query := in.Query[0].VQL
+	self.queries = append(self.queries, query)
+
+	var rows string
+	switch {
+	case self.denied:
+		return &fakeStream{responses: []*actions_proto.VQLResponse{{
+			Log: "hunt: PermissionDenied: federation not allowed to COLLECT_CLIENT"}}}, nil
+
+	case strings.Contains(query, "hunt_update("):
+		self.state = "STOPPED"
+		rows = `{"HuntId":"H.1234"}`
+
+	case strings.Contains(query, "hunt("):
+		rows = `{"HuntId":"H.1234"}`
+
+	case strings.Contains(query, "FROM hunts("):
+		rows = `{"State":"` + self.state + `","Scheduled":2,"WithResults":1,` +
+			`"ArtifactSources":["` + artifact + `"]}`
+
+	case strings.Contains(query, "hunt_results("):
+		rows = `{"Hostname":"` + self.name + `-host1"}` + "\n" +
+			`{"Hostname":"` + self.name + `-host2"}`
+
+	default:
+		rows = `{"Server":"` + self.name + `"}`
+	}
+
+	return &fakeStream{responses: []*actions_proto.VQLResponse{{
+		JSONLResponse: rows + "\n"}}}, nil
+}
+
+type fakeFactory struct {
+	peers map[string]*fakePeer
+}
+
+func (self fakeFactory) GetPeerClient(ctx context.Context,
+	peer *config_proto.FederationPeerConfig) (
+	api_proto.APIClient, func() error, error) {
+	return self.peers[peer.Name], func() error { return nil }, nil
+}
+
+type FederationTestSuite struct {
+	test_utils.TestSuite
+
+	peers map[string]*fakePeer
+}
+
+func (self *FederationTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Federation = &config_proto.FederationConfig{
+		Peers: []*config_proto.FederationPeerConfig{
+			{Name: "SiteA"}, {Name: "SiteB"},
+		},
+	}
+
+	self.TestSuite.SetupTest()
+
+	self.peers = map[string]*fakePeer{
+		"SiteA": {name: "SiteA", state: "RUNNING"},
+		"SiteB": {name: "SiteB", state: "RUNNING"},
+	}
+	federation.Factory = fakeFactory{peers: self.peers}
+}
+
+func (self *FederationTestSuite) TearDownTest() {
+	federation.Factory = federation.GRPCPeerClient{}
+	self.TestSuite.TearDownTest()
+}
+
+func (self *FederationTestSuite) createHunt() *api_proto.FederatedHunt {
+	hunt, err := federation.CreateHunt(self.Ctx, self.ConfigObj,
+		&federation.HuntRequest{
+			Description: "Test hunt",
+			Creator:     "admin",
+			Artifacts:   []string{"Generic.Client.Info"},
+			Expires:     utils.GetTime().Now().Add(time.Hour),
+		})
+	assert.NoError(self.T(), err)
+	return hunt
+}
+
+func (self *FederationTestSuite) readResults(hunt_id, peer string) []string {
+	rows, err := federation.ReadResults(self.Ctx, self.ConfigObj,
+		hunt_id, artifact, peer)
+	assert.NoError(self.T(), err)
+
+	result := []string{}
+	for row := range rows {
+		host, _ := row.GetString("Hostname")
+		peer, _ := row.GetString("Peer")
+		result = append(result, peer+":"+host)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (self *FederationTestSuite) TestQuery() {
+	result := []string{}
+	mu := sync.Mutex{}
+	err := federation.Query(self.Ctx, self.ConfigObj, nil,
+		"SELECT * FROM info()", ordereddict.NewDict(),
+		func(row *ordereddict.Dict) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			server, _ := row.GetString("Server")
+			peer, _ := row.GetString("Peer")
+			result = append(result, peer+":"+server)
+		})
+	assert.NoError(self.T(), err)
+
+	sort.Strings(result)
+	assert.Equal(self.T(), []string{"SiteA:SiteA", "SiteB:SiteB"}, result)
+
+	// Unknown peers are rejected.
+	err = federation.Query(self.Ctx, self.ConfigObj, []string{"SiteC"},
+		"SELECT * FROM info()", nil, func(row *ordereddict.Dict) {})
+	assert.Error(self.T(), err)
+}
+
+func (self *FederationTestSuite) TestCreateAndSyncHunt() {
+	hunt := self.createHunt()
+	assert.True(self.T(), strings.HasPrefix(
+		hunt.HuntId, federation.FEDERATED_HUNT_PREFIX))
+	assert.Equal(self.T(), 2, len(hunt.Peers))
+	for _, peer_state := range hunt.Peers {
+		assert.Equal(self.T(), "H.1234", peer_state.HuntId)
+		assert.Equal(self.T(), "RUNNING", peer_state.State)
+	}
+
+	hunt, err := federation.SyncHunt(self.Ctx, self.ConfigObj, hunt.HuntId)
+	assert.NoError(self.T(), err)
+	assert.False(self.T(), hunt.Stopped)
+	assert.Equal(self.T(), []string{artifact}, hunt.ArtifactSources)
+	assert.Equal(self.T(), uint64(2), hunt.Peers[0].TotalRows)
+	assert.Equal(self.T(), uint64(1), hunt.Peers[0].TotalClientsWithResults)
+
+	// Results from all peers are merged with a Peer column.
+	assert.Equal(self.T(), []string{
+		"SiteA:SiteA-host1", "SiteA:SiteA-host2",
+		"SiteB:SiteB-host1", "SiteB:SiteB-host2",
+	}, self.readResults(hunt.HuntId, ""))
+
+	assert.Equal(self.T(), []string{
+		"SiteB:SiteB-host1", "SiteB:SiteB-host2",
+	}, self.readResults(hunt.HuntId, "SiteB"))
+
+	// Syncing again replaces rather than duplicates the results.
+	_, err = federation.SyncHunt(self.Ctx, self.ConfigObj, hunt.HuntId)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 4, len(self.readResults(hunt.HuntId, "")))
+
+	hunts, err := federation.ListHunts(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(hunts))
+	assert.Equal(self.T(), hunt.HuntId, hunts[0].HuntId)
+}
+
+func (self *FederationTestSuite) TestCreateHuntPartialFailure() {
+	self.peers["SiteB"].denied = true
+
+	hunt := self.createHunt()
+	assert.Equal(self.T(), "RUNNING", hunt.Peers[0].State)
+	assert.Equal(self.T(), federation.STATE_ERROR, hunt.Peers[1].State)
+	assert.Contains(self.T(), hunt.Peers[1].Error, "PermissionDenied")
+
+	// The hunt fails if no peer accepted it.
+	self.peers["SiteA"].denied = true
+	_, err := federation.CreateHunt(self.Ctx, self.ConfigObj,
+		&federation.HuntRequest{
+			Artifacts: []string{"Generic.Client.Info"},
+			Expires:   utils.GetTime().Now().Add(time.Hour),
+		})
+	assert.Error(self.T(), err)
+}
+
+func (self *FederationTestSuite) TestStopHunt() {
+	hunt := self.createHunt()
+
+	hunt, err := federation.StopHunt(self.Ctx, self.ConfigObj, hunt.HuntId)
+	assert.NoError(self.T(), err)
+	assert.True(self.T(), hunt.Stopped)
+	for _, peer_state := range hunt.Peers {
+		assert.Equal(self.T(), "STOPPED", peer_state.State)
+	}
+
+	// The final results were fetched.
+	assert.Equal(self.T(), 4, len(self.readResults(hunt.HuntId, "")))
+
+	// Stopped hunts are no longer synced.
+	queries := len(self.peers["SiteA"].queries)
+	err = federation.SyncHunts(self.Ctx, self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), queries, len(self.peers["SiteA"].queries))
+}
+
+func TestFederation(t *testing.T) {
+	suite.Run(t, &FederationTestSuite{})
+}
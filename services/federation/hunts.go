@@ -0,0 +1,475 @@
+package federation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	FEDERATED_HUNT_PREFIX = "FH."
+
+	// The state of peers the hunt could not be created or read on.
+	STATE_ERROR = "ERROR"
+
+	createHuntQuery = `
+SELECT hunt(description=Description,
+            artifacts=parse_json_array(data=Artifacts),
+            spec=parse_json(data=Spec),
+            expires=timestamp(epoch=int(int=Expires))).HuntId AS HuntId
+FROM scope()`
+
+	huntStatusQuery = `
+SELECT state AS State,
+       stats.total_clients_scheduled AS Scheduled,
+       stats.total_clients_with_results AS WithResults,
+       artifact_sources AS ArtifactSources
+FROM hunts(hunt_id=HuntId)`
+
+	huntResultsQuery = `
+SELECT * FROM hunt_results(hunt_id=HuntId, artifact=Artifact, merged=TRUE)`
+
+	stopHuntQuery = `
+SELECT hunt_update(hunt_id=HuntId, state='stop') AS HuntId FROM scope()`
+)
+
+type HuntRequest struct {
+	Description string
+	Creator     string
+	Artifacts   []string
+	Spec        *ordereddict.Dict
+	Expires     time.Time
+
+	// Only schedule on these peers (default all peers).
+	Peers []string
+}
+
+func newHuntId() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+
+	binary.BigEndian.PutUint32(buf, uint32(time.Now().Unix()))
+	return FEDERATED_HUNT_PREFIX + base32.HexEncoding.EncodeToString(buf)[:13]
+}
+
+func GetHunt(config_obj *config_proto.Config,
+	hunt_id string) (*api_proto.FederatedHunt, error) {
+	result := &api_proto.FederatedHunt{}
+	err := datastore.GetExistingSubject(config_obj,
+		paths.NewFederatedHuntPathManager(hunt_id).Path(), result)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("Federated hunt %v not found", hunt_id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func ListHunts(config_obj *config_proto.Config) (
+	[]*api_proto.FederatedHunt, error) {
+	children, err := datastore.ListSubjects(config_obj, paths.FEDERATED_HUNTS_ROOT)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api_proto.FederatedHunt, 0, len(children))
+	for _, child := range children {
+		if child.Type() != api.PATH_TYPE_DATASTORE_PROTO {
+			continue
+		}
+
+		hunt, err := GetHunt(config_obj, child.Base())
+		if err != nil {
+			continue
+		}
+		result = append(result, hunt)
+	}
+
+	return result, nil
+}
+
+// Create a hunt on each of the peers. A peer the hunt could not be
+// created on is recorded with an ERROR state - the hunt is only
+// rejected if it failed on all peers.
+func CreateHunt(ctx context.Context, config_obj *config_proto.Config,
+	request *HuntRequest) (*api_proto.FederatedHunt, error) {
+
+	if len(request.Artifacts) == 0 {
+		return nil, errors.New("No artifacts to collect")
+	}
+
+	if !request.Expires.After(utils.GetTime().Now()) {
+		return nil, errors.New("Expiry time must be in the future")
+	}
+
+	peers, err := getPeers(config_obj, request.Peers)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := request.Spec
+	if spec == nil {
+		spec = ordereddict.NewDict()
+	}
+
+	hunt := &api_proto.FederatedHunt{
+		HuntId:      newHuntId(),
+		Description: request.Description,
+		Creator:     request.Creator,
+		CreateTime:  utils.GetTime().Now().Unix(),
+		Artifacts:   request.Artifacts,
+		Spec:        json.MustMarshalString(spec),
+		Expires:     uint64(request.Expires.Unix()),
+	}
+
+	env := ordereddict.NewDict().
+		Set("Description", fmt.Sprintf("%v (federated hunt %v from %v)",
+			request.Description, hunt.HuntId, request.Creator)).
+		Set("Artifacts", json.MustMarshalString(request.Artifacts)).
+		Set("Spec", hunt.Spec).
+		Set("Expires", hunt.Expires)
+
+	created := 0
+	for _, peer := range peers {
+		peer_state := &api_proto.FederatedHuntPeer{Peer: peer.Name}
+		hunt.Peers = append(hunt.Peers, peer_state)
+
+		logs, err := runQuery(ctx, config_obj, peer, createHuntQuery, env,
+			func(row *ordereddict.Dict) error {
+				peer_state.HuntId, _ = row.GetString("HuntId")
+				return nil
+			})
+		if err == nil && peer_state.HuntId == "" {
+			err = fmt.Errorf("Unable to create hunt: %v", huntErrors(logs))
+		}
+
+		if err != nil {
+			peer_state.State = STATE_ERROR
+			peer_state.Error = err.Error()
+			continue
+		}
+
+		peer_state.State = api_proto.Hunt_RUNNING.String()
+		created++
+	}
+
+	if created == 0 {
+		return hunt, fmt.Errorf("Unable to create the hunt on any peer: %v",
+			hunt.Peers[0].Error)
+	}
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return hunt, db.SetSubject(config_obj,
+		paths.NewFederatedHuntPathManager(hunt.HuntId).Path(), hunt)
+}
+
+// Pick out the hunt() errors from the query log.
+func huntErrors(logs []string) string {
+	var result []string
+	for _, log := range logs {
+		if strings.HasPrefix(log, "hunt") ||
+			strings.Contains(log, "PermissionDenied") {
+			result = append(result, strings.TrimSpace(log))
+		}
+	}
+
+	if len(result) == 0 {
+		return "no hunt id returned"
+	}
+	return strings.Join(result, "; ")
+}
+
+// Fetch the status and results of the hunt from each peer and merge
+// the results into local result sets.
+func SyncHunt(ctx context.Context, config_obj *config_proto.Config,
+	hunt_id string) (*api_proto.FederatedHunt, error) {
+	return syncHunt(ctx, config_obj, hunt_id, false)
+}
+
+// Syncing a hunt rewrites its result sets so the hunt stays locked
+// until the sync is complete.
+func syncHunt(ctx context.Context, config_obj *config_proto.Config,
+	hunt_id string, stop bool) (*api_proto.FederatedHunt, error) {
+	hunt := &api_proto.FederatedHunt{}
+	err := datastore.ModifySubject(config_obj,
+		paths.NewFederatedHuntPathManager(hunt_id).Path(), hunt,
+		func(exists bool) error {
+			if !exists {
+				return fmt.Errorf("Federated hunt %v not found", hunt_id)
+			}
+
+			for _, peer_state := range hunt.Peers {
+				if peer_state.HuntId == "" {
+					continue
+				}
+
+				err := syncPeer(ctx, config_obj, hunt, peer_state)
+				if err != nil {
+					// Keep the results we already have.
+					peer_state.Error = err.Error()
+					continue
+				}
+				peer_state.Error = ""
+			}
+
+			err := mergeResults(ctx, config_obj, hunt)
+			if err != nil {
+				return err
+			}
+
+			// The last sync after the hunt finished everywhere
+			// fetched all the results.
+			if stop || hunt.Expires < uint64(utils.GetTime().Now().Unix()) ||
+				allFinished(hunt) {
+				hunt.Stopped = true
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return hunt, nil
+}
+
+func allFinished(hunt *api_proto.FederatedHunt) bool {
+	for _, peer_state := range hunt.Peers {
+		switch peer_state.State {
+		case api_proto.Hunt_RUNNING.String(), api_proto.Hunt_PAUSED.String():
+			return false
+		}
+	}
+	return true
+}
+
+func syncPeer(ctx context.Context, config_obj *config_proto.Config,
+	hunt *api_proto.FederatedHunt,
+	peer_state *api_proto.FederatedHuntPeer) error {
+
+	peer := getPeer(config_obj, peer_state.Peer)
+	if peer == nil {
+		return fmt.Errorf("Peer %v is no longer configured", peer_state.Peer)
+	}
+
+	env := ordereddict.NewDict().Set("HuntId", peer_state.HuntId)
+
+	found := false
+	var artifact_sources []string
+	_, err := runQuery(ctx, config_obj, peer, huntStatusQuery, env,
+		func(row *ordereddict.Dict) error {
+			found = true
+			peer_state.State, _ = row.GetString("State")
+			peer_state.TotalClientsScheduled = toUint64(row, "Scheduled")
+			peer_state.TotalClientsWithResults = toUint64(row, "WithResults")
+			artifact_sources, _ = row.GetStrings("ArtifactSources")
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		peer_state.State = STATE_ERROR
+		return fmt.Errorf("Hunt %v not found on peer", peer_state.HuntId)
+	}
+
+	for _, artifact_source := range artifact_sources {
+		if !utils.InString(hunt.ArtifactSources, artifact_source) {
+			hunt.ArtifactSources = append(hunt.ArtifactSources, artifact_source)
+		}
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	path_manager := paths.NewFederatedHuntPathManager(hunt.HuntId)
+
+	var total_rows uint64
+	for _, artifact_source := range artifact_sources {
+		env.Set("Artifact", artifact_source)
+
+		// Results are fetched into a temporary result set so a
+		// failure does not lose the results we already have.
+		tmp_path := path_manager.PeerResults(
+			peer_state.Peer, artifact_source+".tmp")
+		writer, err := result_sets.NewResultSetWriter(file_store_factory,
+			tmp_path, json.DefaultEncOpts(),
+			utils.SyncCompleter, result_sets.TruncateMode)
+		if err != nil {
+			return err
+		}
+
+		var rows uint64
+		_, err = runQuery(ctx, config_obj, peer, huntResultsQuery, env,
+			func(row *ordereddict.Dict) error {
+				writer.Write(row.Set("Peer", peer_state.Peer))
+				rows++
+				return nil
+			})
+		writer.Close()
+
+		if err != nil {
+			_ = file_store_factory.Delete(tmp_path)
+			_ = file_store_factory.Delete(
+				tmp_path.SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX))
+			return err
+		}
+
+		err = moveResultSet(file_store_factory, tmp_path,
+			path_manager.PeerResults(peer_state.Peer, artifact_source))
+		if err != nil {
+			return err
+		}
+		total_rows += rows
+	}
+
+	peer_state.TotalRows = total_rows
+	peer_state.LastSync = utils.GetTime().Now().Unix()
+
+	return nil
+}
+
+// A result set is stored along with its index.
+func moveResultSet(file_store_factory api.FileStore,
+	src, dest api.FSPathSpec) error {
+	err := file_store_factory.Move(src, dest)
+	if err != nil {
+		return err
+	}
+
+	return file_store_factory.Move(
+		src.SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX),
+		dest.SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX))
+}
+
+// Rebuild the merged result set of each artifact source from the
+// results of all the peers.
+func mergeResults(ctx context.Context, config_obj *config_proto.Config,
+	hunt *api_proto.FederatedHunt) error {
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	path_manager := paths.NewFederatedHuntPathManager(hunt.HuntId)
+
+	for _, artifact_source := range hunt.ArtifactSources {
+		writer, err := result_sets.NewResultSetWriter(file_store_factory,
+			path_manager.Results(artifact_source), json.DefaultEncOpts(),
+			utils.SyncCompleter, result_sets.TruncateMode)
+		if err != nil {
+			return err
+		}
+
+		for _, peer_state := range hunt.Peers {
+			reader, err := result_sets.NewResultSetReader(file_store_factory,
+				path_manager.PeerResults(peer_state.Peer, artifact_source))
+			if err != nil {
+				// No results from this peer yet.
+				continue
+			}
+
+			for row := range reader.Rows(ctx) {
+				writer.Write(row)
+			}
+			reader.Close()
+		}
+		writer.Close()
+	}
+
+	return nil
+}
+
+// Stop the hunt on all the peers and fetch the final results.
+func StopHunt(ctx context.Context, config_obj *config_proto.Config,
+	hunt_id string) (*api_proto.FederatedHunt, error) {
+
+	hunt, err := GetHunt(config_obj, hunt_id)
+	if err != nil {
+		return nil, err
+	}
+
+	var errors []string
+	for _, peer_state := range hunt.Peers {
+		peer := getPeer(config_obj, peer_state.Peer)
+		if peer == nil || peer_state.HuntId == "" {
+			continue
+		}
+
+		env := ordereddict.NewDict().Set("HuntId", peer_state.HuntId)
+		_, err := runQuery(ctx, config_obj, peer, stopHuntQuery, env,
+			func(row *ordereddict.Dict) error { return nil })
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("%v: %v", peer.Name, err))
+		}
+	}
+
+	// Do not sync again even if a peer did not stop.
+	hunt, err = syncHunt(ctx, config_obj, hunt_id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(errors) > 0 {
+		return hunt, fmt.Errorf("Unable to stop the hunt on peers: %v",
+			strings.Join(errors, "; "))
+	}
+	return hunt, nil
+}
+
+// Read the merged results of all peers, or of a single peer.
+func ReadResults(ctx context.Context, config_obj *config_proto.Config,
+	hunt_id, artifact_source, peer string) (<-chan *ordereddict.Dict, error) {
+
+	path_manager := paths.NewFederatedHuntPathManager(hunt_id)
+	path := path_manager.Results(artifact_source)
+	if peer != "" {
+		path = path_manager.PeerResults(peer, artifact_source)
+	}
+
+	reader, err := result_sets.NewResultSetReader(
+		file_store.GetFileStore(config_obj), path)
+	if err != nil {
+		return nil, err
+	}
+
+	output_chan := make(chan *ordereddict.Dict)
+	go func() {
+		defer close(output_chan)
+		defer reader.Close()
+
+		for row := range reader.Rows(ctx) {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan, nil
+}
+
+func toUint64(row *ordereddict.Dict, column string) uint64 {
+	value, _ := row.Get(column)
+	result, _ := utils.ToInt64(value)
+	return uint64(result)
+}
@@ -0,0 +1,365 @@
+package flight_endpoint
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+)
+
+// Arrow IPC format constants. See
+// https://arrow.apache.org/docs/format/Columnar.html#serialization-and-interprocess-communication-ipc
+const (
+	METADATA_V5 = 4
+
+	// MessageHeader union
+	HEADER_SCHEMA       = 1
+	HEADER_RECORD_BATCH = 3
+
+	// Type union
+	TYPE_INT            = 2
+	TYPE_FLOATING_POINT = 3
+	TYPE_UTF8           = 5
+	TYPE_BOOL           = 6
+	TYPE_TIMESTAMP      = 10
+
+	PRECISION_DOUBLE = 2
+	UNIT_MICROSECOND = 2
+
+	IPC_CONTINUATION = 0xFFFFFFFF
+)
+
+type columnType int
+
+const (
+	columnNull columnType = iota
+	columnInt
+	columnFloat
+	columnBool
+	columnString
+	columnTimestamp
+)
+
+type column struct {
+	Name string
+	Type columnType
+}
+
+// The schema is inferred from the first batch of rows unless the
+// artifact declares the column type. Columns which mix types become
+// strings and columns which are always null are strings too.
+func inferSchema(columns []string, rows []*ordereddict.Dict,
+	schema *result_sets.Schema) []column {
+	if columns == nil {
+		seen := make(map[string]bool)
+		for _, row := range rows {
+			for _, k := range row.Keys() {
+				if !seen[k] {
+					seen[k] = true
+					columns = append(columns, k)
+				}
+			}
+		}
+	}
+
+	result := make([]column, 0, len(columns))
+	for _, name := range columns {
+		switch schema.ColumnType(name) {
+		case result_sets.COLUMN_TIMESTAMP:
+			result = append(result, column{Name: name, Type: columnTimestamp})
+			continue
+		case result_sets.COLUMN_INTEGER:
+			result = append(result, column{Name: name, Type: columnInt})
+			continue
+		case result_sets.COLUMN_CLIENT_PATH, result_sets.COLUMN_HASH:
+			result = append(result, column{Name: name, Type: columnString})
+			continue
+		}
+
+		t := columnNull
+		for _, row := range rows {
+			value, _ := row.Get(name)
+			t = mergeTypes(t, typeOf(value))
+		}
+		if t == columnNull {
+			t = columnString
+		}
+		result = append(result, column{Name: name, Type: t})
+	}
+	return result
+}
+
+func typeOf(value interface{}) columnType {
+	switch value.(type) {
+	case nil:
+		return columnNull
+	case bool:
+		return columnBool
+	case int, int64, uint64:
+		return columnInt
+	case float64:
+		return columnFloat
+	case time.Time:
+		return columnTimestamp
+	}
+	return columnString
+}
+
+func mergeTypes(a, b columnType) columnType {
+	switch {
+	case a == b || b == columnNull:
+		return a
+	case a == columnNull:
+		return b
+	case (a == columnInt && b == columnFloat) ||
+		(a == columnFloat && b == columnInt):
+		return columnFloat
+	}
+	return columnString
+}
+
+func toInt(value interface{}) (int64, bool) {
+	switch t := value.(type) {
+	case int:
+		return int64(t), true
+	case int64:
+		return t, true
+	case uint64:
+		return int64(t), true
+	case float64:
+		if t == math.Trunc(t) {
+			return int64(t), true
+		}
+	}
+	return 0, false
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch t := value.(type) {
+	case float64:
+		return t, true
+	}
+	i, ok := toInt(value)
+	return float64(i), ok
+}
+
+// Everything can be represented as a string - complex values are
+// JSON encoded.
+func toString(value interface{}) (string, bool) {
+	switch t := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return t, true
+	case time.Time:
+		return t.UTC().Format(time.RFC3339Nano), true
+	case float64:
+		return strconv.FormatFloat(t, 'g', -1, 64), true
+	}
+	return json.MustMarshalString(value), true
+}
+
+func encodeType(b *builder, t columnType) (uint8, int) {
+	switch t {
+	case columnInt:
+		b.startTable(2)
+		b.addInt32(0, 64)
+		b.addBool(1, true)
+		return TYPE_INT, b.endTable()
+
+	case columnFloat:
+		b.startTable(1)
+		b.addInt16(0, PRECISION_DOUBLE)
+		return TYPE_FLOATING_POINT, b.endTable()
+
+	case columnBool:
+		b.startTable(0)
+		return TYPE_BOOL, b.endTable()
+
+	case columnTimestamp:
+		timezone := b.createString("UTC")
+		b.startTable(2)
+		b.addOffset(1, timezone)
+		b.addInt16(0, UNIT_MICROSECOND)
+		return TYPE_TIMESTAMP, b.endTable()
+	}
+
+	b.startTable(0)
+	return TYPE_UTF8, b.endTable()
+}
+
+// Build the flatbuffer encoded Message header.
+func encodeMessage(b *builder, header_type uint8, header int, body_length int) []byte {
+	b.startTable(4)
+	b.addInt64(3, int64(body_length))
+	b.addOffset(2, header)
+	b.addInt16(0, METADATA_V5)
+	b.addUint8(1, header_type)
+	return b.finish(b.endTable())
+}
+
+func encodeSchema(columns []column) []byte {
+	b := newBuilder()
+
+	fields := make([]int, 0, len(columns))
+	for _, c := range columns {
+		name := b.createString(c.Name)
+		type_type, type_offset := encodeType(b, c.Type)
+		children := b.createOffsetVector(nil)
+
+		b.startTable(7)
+		b.addOffset(0, name)
+		b.addOffset(3, type_offset)
+		b.addOffset(5, children)
+		b.addBool(1, true)
+		b.addUint8(2, type_type)
+		fields = append(fields, b.endTable())
+	}
+	fields_vector := b.createOffsetVector(fields)
+
+	b.startTable(2)
+	b.addOffset(1, fields_vector)
+	b.addInt16(0, 0) // Little endian
+	schema := b.endTable()
+
+	return encodeMessage(b, HEADER_SCHEMA, schema, 0)
+}
+
+// Wrap a message header in the encapsulated IPC format used for the
+// schema in FlightInfo and SchemaResult.
+func encapsulate(header []byte) []byte {
+	padding := (8 - len(header)%8) % 8
+	result := make([]byte, 8, 8+len(header)+padding)
+	binary.LittleEndian.PutUint32(result, IPC_CONTINUATION)
+	binary.LittleEndian.PutUint32(result[4:], uint32(len(header)+padding))
+	result = append(result, header...)
+	return append(result, make([]byte, padding)...)
+}
+
+// Accumulates the buffers of a record batch body. Each buffer is
+// padded to 8 bytes.
+type body struct {
+	data    []byte
+	buffers [][]int64
+	nodes   [][]int64
+}
+
+func (self *body) addBuffer(buf []byte) {
+	self.buffers = append(self.buffers,
+		[]int64{int64(len(self.data)), int64(len(buf))})
+	self.data = append(self.data, buf...)
+	if len(self.data)%8 != 0 {
+		self.data = append(self.data, make([]byte, 8-len(self.data)%8)...)
+	}
+}
+
+type bitmap []byte
+
+func newBitmap(n int) bitmap {
+	return make(bitmap, (n+7)/8)
+}
+
+func (self bitmap) set(i int) {
+	self[i/8] |= 1 << (i % 8)
+}
+
+// Encode the rows as a record batch. Returns the message header and
+// body. Values which do not fit the column type are null.
+func encodeRecordBatch(
+	columns []column, rows []*ordereddict.Dict) ([]byte, []byte) {
+	result := &body{}
+	n := len(rows)
+
+	for _, c := range columns {
+		validity := newBitmap(n)
+		null_count := 0
+
+		switch c.Type {
+		case columnInt, columnFloat, columnTimestamp:
+			values := make([]byte, 8*n)
+			for i, row := range rows {
+				value, _ := row.Get(c.Name)
+
+				var bits uint64
+				var ok bool
+				switch c.Type {
+				case columnInt:
+					var v int64
+					v, ok = toInt(value)
+					bits = uint64(v)
+				case columnFloat:
+					var v float64
+					v, ok = toFloat(value)
+					bits = math.Float64bits(v)
+				default:
+					var v time.Time
+					v, ok = value.(time.Time)
+					bits = uint64(v.UnixMicro())
+				}
+
+				if !ok {
+					null_count++
+					continue
+				}
+				validity.set(i)
+				binary.LittleEndian.PutUint64(values[8*i:], bits)
+			}
+			result.addBuffer(validity)
+			result.addBuffer(values)
+
+		case columnBool:
+			values := newBitmap(n)
+			for i, row := range rows {
+				value, _ := row.Get(c.Name)
+				v, ok := value.(bool)
+				if !ok {
+					null_count++
+					continue
+				}
+				validity.set(i)
+				if v {
+					values.set(i)
+				}
+			}
+			result.addBuffer(validity)
+			result.addBuffer(values)
+
+		default:
+			offsets := make([]byte, 4*(n+1))
+			data := []byte{}
+			for i, row := range rows {
+				value, _ := row.Get(c.Name)
+				v, ok := toString(value)
+				if ok {
+					validity.set(i)
+					data = append(data, v...)
+				} else {
+					null_count++
+				}
+				binary.LittleEndian.PutUint32(offsets[4*(i+1):], uint32(len(data)))
+			}
+			result.addBuffer(validity)
+			result.addBuffer(offsets)
+			result.addBuffer(data)
+		}
+
+		result.nodes = append(result.nodes, []int64{int64(n), int64(null_count)})
+	}
+
+	b := newBuilder()
+	buffers := b.createStructVector(result.buffers)
+	nodes := b.createStructVector(result.nodes)
+
+	b.startTable(3)
+	b.addInt64(0, int64(n))
+	b.addOffset(1, nodes)
+	b.addOffset(2, buffers)
+	batch := b.endTable()
+
+	return encodeMessage(b, HEADER_RECORD_BATCH, batch, len(result.data)),
+		result.data
+}
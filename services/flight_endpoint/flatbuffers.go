@@ -0,0 +1,180 @@
+package flight_endpoint
+
+import (
+	"encoding/binary"
+)
+
+// A minimal flatbuffers builder - just enough to encode the Arrow
+// IPC message headers. See https://flatbuffers.dev/md__internals.html
+
+// Like the reference implementation the buffer is built back to
+// front so children are written before the objects referring to
+// them. Offsets are measured from the end of the buffer.
+type builder struct {
+	// The data in final order. The headers are small so prepending
+	// is cheap enough.
+	data     []byte
+	minalign int
+
+	// Offsets of the fields of the table being built (0 if unset).
+	vtable    []int
+	table_end int
+}
+
+func newBuilder() *builder {
+	return &builder{minalign: 1}
+}
+
+func (self *builder) offset() int {
+	return len(self.data)
+}
+
+func (self *builder) pad(n int) {
+	self.data = append(make([]byte, n), self.data...)
+}
+
+// Align so that after writing additional bytes a value of size is
+// aligned.
+func (self *builder) prep(size, additional int) {
+	if size > self.minalign {
+		self.minalign = size
+	}
+	self.pad((-(len(self.data) + additional)) & (size - 1))
+}
+
+func (self *builder) place(value []byte) {
+	self.data = append(append([]byte{}, value...), self.data...)
+}
+
+func (self *builder) prependUint8(value uint8) {
+	self.prep(1, 0)
+	self.place([]byte{value})
+}
+
+func (self *builder) prependInt16(value int16) {
+	self.prep(2, 0)
+	buf := make([]byte, 2)
+	binary.LittleEndian.PutUint16(buf, uint16(value))
+	self.place(buf)
+}
+
+func (self *builder) prependInt32(value int32) {
+	self.prep(4, 0)
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, uint32(value))
+	self.place(buf)
+}
+
+func (self *builder) prependInt64(value int64) {
+	self.prep(8, 0)
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, uint64(value))
+	self.place(buf)
+}
+
+// Offsets point forward from where they are stored.
+func (self *builder) prependOffset(off int) {
+	self.prep(4, 0)
+	self.prependInt32(int32(self.offset() - off + 4))
+}
+
+func (self *builder) createString(value string) int {
+	self.prep(4, len(value)+1)
+	self.place(append([]byte(value), 0))
+	self.prependInt32(int32(len(value)))
+	return self.offset()
+}
+
+func (self *builder) createOffsetVector(offsets []int) int {
+	self.prep(4, 4*len(offsets))
+	for i := len(offsets) - 1; i >= 0; i-- {
+		self.prependOffset(offsets[i])
+	}
+	self.prependInt32(int32(len(offsets)))
+	return self.offset()
+}
+
+// A vector of structs made of int64 fields.
+func (self *builder) createStructVector(structs [][]int64) int {
+	size := 0
+	if len(structs) > 0 {
+		size = 8 * len(structs[0])
+	}
+	self.prep(4, size*len(structs))
+	self.prep(8, size*len(structs))
+	for i := len(structs) - 1; i >= 0; i-- {
+		for j := len(structs[i]) - 1; j >= 0; j-- {
+			self.prependInt64(structs[i][j])
+		}
+	}
+	self.prependInt32(int32(len(structs)))
+	return self.offset()
+}
+
+func (self *builder) startTable(fields int) {
+	self.vtable = make([]int, fields)
+	self.table_end = self.offset()
+}
+
+func (self *builder) addUint8(slot int, value uint8) {
+	self.prependUint8(value)
+	self.vtable[slot] = self.offset()
+}
+
+func (self *builder) addBool(slot int, value bool) {
+	if value {
+		self.addUint8(slot, 1)
+	} else {
+		self.addUint8(slot, 0)
+	}
+}
+
+func (self *builder) addInt16(slot int, value int16) {
+	self.prependInt16(value)
+	self.vtable[slot] = self.offset()
+}
+
+func (self *builder) addInt32(slot int, value int32) {
+	self.prependInt32(value)
+	self.vtable[slot] = self.offset()
+}
+
+func (self *builder) addInt64(slot int, value int64) {
+	self.prependInt64(value)
+	self.vtable[slot] = self.offset()
+}
+
+func (self *builder) addOffset(slot int, off int) {
+	self.prependOffset(off)
+	self.vtable[slot] = self.offset()
+}
+
+// Write the vtable before the table. The table starts with the
+// signed offset back to its vtable.
+func (self *builder) endTable() int {
+	self.prependInt32(0)
+	table := self.offset()
+
+	for i := len(self.vtable) - 1; i >= 0; i-- {
+		field := 0
+		if self.vtable[i] != 0 {
+			field = table - self.vtable[i]
+		}
+		self.prependInt16(int16(field))
+	}
+	self.prependInt16(int16(table - self.table_end))
+	self.prependInt16(int16((len(self.vtable) + 2) * 2))
+
+	// Patch the vtable offset into the table.
+	binary.LittleEndian.PutUint32(self.data[len(self.data)-table:],
+		uint32(self.offset()-table))
+	self.vtable = nil
+
+	return table
+}
+
+func (self *builder) finish(root int) []byte {
+	self.prep(self.minalign, 4)
+	self.prependOffset(root)
+	return self.data
+}
@@ -0,0 +1,255 @@
+package flight_endpoint_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/crypto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/flight_endpoint"
+	flight_proto "www.velocidex.com/golang/velociraptor/services/flight_endpoint/proto"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+
+	_ "www.velocidex.com/golang/velociraptor/result_sets/timed"
+	_ "www.velocidex.com/golang/velociraptor/vql/server/flows"
+)
+
+type FlightEndpointTestSuite struct {
+	test_utils.TestSuite
+	address string
+}
+
+func (self *FlightEndpointTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.LoadArtifacts([]string{`
+name: Server.Audit.Test
+type: SERVER_EVENT
+`})
+	self.TestSuite.SetupTest()
+
+	port, err := vtesting.GetFreePort()
+	assert.NoError(self.T(), err)
+
+	// The frontend certificate in the test config has expired.
+	frontend_cert, err := crypto.GenerateServerCert(
+		self.ConfigObj, self.ConfigObj.Client.PinnedServerName)
+	assert.NoError(self.T(), err)
+	self.ConfigObj.Frontend.Certificate = frontend_cert.Cert
+	self.ConfigObj.Frontend.PrivateKey = frontend_cert.PrivateKey
+
+	self.ConfigObj.FlightEndpoint = &config_proto.FlightEndpointConfig{
+		BindPort:  uint32(port),
+		BatchSize: 2,
+	}
+	self.address = fmt.Sprintf("127.0.0.1:%d", port)
+
+	err = flight_endpoint.NewFlightEndpointService(
+		self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	user_manager := services.GetUserManager()
+	for _, name := range []string{"analyst", "nobody"} {
+		err = user_manager.SetUser(self.Ctx,
+			&api_proto.VelociraptorUser{Name: name})
+		assert.NoError(self.T(), err)
+	}
+	err = services.GrantRoles(self.ConfigObj, "analyst", []string{"reader"})
+	assert.NoError(self.T(), err)
+
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	for i := 0; i < 3; i++ {
+		row := ordereddict.NewDict().
+			Set("User", fmt.Sprintf("user%d", i)).
+			Set("Count", i).
+			Set("Ratio", float64(i)+0.5).
+			Set("Admin", i == 1).
+			Set("Time", time.Unix(1600000000+int64(i), 0).UTC()).
+			Set("Info", ordereddict.NewDict().Set("Id", i))
+		if i == 2 {
+			row.Set("Count", nil)
+		}
+
+		err = journal.PushRowsToArtifact(self.ConfigObj,
+			[]*ordereddict.Dict{row}, "Server.Audit.Test", "server", "")
+		assert.NoError(self.T(), err)
+	}
+}
+
+func (self *FlightEndpointTestSuite) connect(name string) flight_proto.FlightServiceClient {
+	bundle, err := crypto.GenerateServerCert(self.ConfigObj, name)
+	assert.NoError(self.T(), err)
+
+	cert, err := tls.X509KeyPair([]byte(bundle.Cert), []byte(bundle.PrivateKey))
+	assert.NoError(self.T(), err)
+
+	CA_Pool := x509.NewCertPool()
+	CA_Pool.AppendCertsFromPEM([]byte(self.ConfigObj.Client.CaCertificate))
+
+	creds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      CA_Pool,
+		ServerName:   self.ConfigObj.Client.PinnedServerName,
+	})
+
+	conn, err := grpc.Dial(self.address, grpc.WithTransportCredentials(creds))
+	assert.NoError(self.T(), err)
+
+	go func() {
+		<-self.Ctx.Done()
+		conn.Close()
+	}()
+
+	return flight_proto.NewFlightServiceClient(conn)
+}
+
+type fetchResult struct {
+	Schema  []arrowField
+	Rows    [][]interface{}
+	Batches int
+}
+
+func fetch(client flight_proto.FlightServiceClient, sql string) (*fetchResult, error) {
+	stream, err := client.DoGet(context.Background(),
+		&flight_proto.Ticket{Ticket: []byte(sql)})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &fetchResult{}
+	for {
+		data, err := stream.Recv()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Schema == nil {
+			result.Schema = readSchema(data.DataHeader)
+			continue
+		}
+
+		result.Rows = append(result.Rows,
+			readRecordBatch(result.Schema, data.DataHeader, data.DataBody)...)
+		result.Batches++
+	}
+}
+
+const all_events = `SELECT * FROM events."Server.Audit.Test" WHERE client_id = 'server'`
+
+func (self *FlightEndpointTestSuite) TestDoGet() {
+	client := self.connect("analyst")
+
+	var result *fetchResult
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		var err error
+		result, err = fetch(client, all_events)
+		assert.NoError(self.T(), err)
+		return len(result.Rows) == 3
+	})
+
+	assert.Equal(self.T(), []arrowField{
+		{"User", "utf8"},
+		{"Count", "int64"},
+		{"Ratio", "double"},
+		{"Admin", "bool"},
+		{"Time", "timestamp[2, UTC]"},
+		{"Info", "utf8"},
+		{"_ts", "int64"},
+	}, result.Schema)
+
+	// Rows are sent in batches of 2.
+	assert.Equal(self.T(), 2, result.Batches)
+	assert.Equal(self.T(), []interface{}{
+		"user2", nil, 2.5, false,
+		time.Unix(1600000002, 0).UTC(), `{"Id":2}`,
+	}, result.Rows[2][:6])
+
+	// Only the selected columns and rows are sent.
+	result, err := fetch(client, `
+SELECT User, Count FROM events."Server.Audit.Test"
+WHERE client_id = 'server' AND Ratio > 1`)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), &fetchResult{
+		Schema:  []arrowField{{"User", "utf8"}, {"Count", "int64"}},
+		Rows:    [][]interface{}{{"user1", int64(1)}, {"user2", nil}},
+		Batches: 1,
+	}, result)
+
+	// No rows still gets a schema.
+	result, err = fetch(client, `
+SELECT User FROM events."Server.Audit.Test"
+WHERE client_id = 'server' AND User = 'nobody'`)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), &fetchResult{
+		Schema: []arrowField{{"User", "utf8"}},
+	}, result)
+
+	// Invalid queries
+	_, err = fetch(client, "DELETE FROM hunts")
+	assert.Equal(self.T(), codes.InvalidArgument, status.Code(err))
+
+	_, err = fetch(client, "SELECT * FROM flows")
+	assert.Equal(self.T(), codes.InvalidArgument, status.Code(err))
+}
+
+func (self *FlightEndpointTestSuite) TestSchema() {
+	client := self.connect("analyst")
+	descriptor := &flight_proto.FlightDescriptor{
+		Type: flight_proto.FlightDescriptor_CMD,
+		Cmd:  []byte(`SELECT User, Ratio AS R FROM events."Server.Audit.Test" WHERE client_id = 'server'`),
+	}
+
+	info, err := client.GetFlightInfo(context.Background(), descriptor)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), descriptor.Cmd, info.Endpoint[0].Ticket.Ticket)
+
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		result, err := client.GetSchema(context.Background(), descriptor)
+		assert.NoError(self.T(), err)
+
+		schema := readSchema(readEncapsulated(result.Schema))
+		return schema[1].Type == "double"
+	})
+
+	_, err = client.GetSchema(context.Background(),
+		&flight_proto.FlightDescriptor{
+			Type: flight_proto.FlightDescriptor_PATH,
+			Path: []string{"hunts"},
+		})
+	assert.Equal(self.T(), codes.InvalidArgument, status.Code(err))
+}
+
+func (self *FlightEndpointTestSuite) TestPermissions() {
+	client := self.connect("nobody")
+	_, err := fetch(client, all_events)
+	assert.Equal(self.T(), codes.PermissionDenied, status.Code(err))
+	assert.Contains(self.T(), err.Error(), "not allowed to read results")
+
+	// Queries only get READ_RESULTS.
+	client = self.connect("analyst")
+	_, err = fetch(client, "SELECT cancel_flow(client_id='C.1', flow_id='F.1') AS X")
+	assert.Equal(self.T(), codes.PermissionDenied, status.Code(err))
+}
+
+func TestFlightEndpoint(t *testing.T) {
+	suite.Run(t, &FlightEndpointTestSuite{})
+}
@@ -0,0 +1,261 @@
+package flight_endpoint_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// An independent reader for the Arrow IPC messages so the tests do
+// not just check the encoder against itself.
+
+type fbTable struct {
+	buf []byte
+	pos int
+}
+
+func u16(buf []byte, pos int) int {
+	return int(binary.LittleEndian.Uint16(buf[pos:]))
+}
+
+func u32(buf []byte, pos int) int {
+	return int(binary.LittleEndian.Uint32(buf[pos:]))
+}
+
+func fbRoot(buf []byte) fbTable {
+	return fbTable{buf: buf, pos: u32(buf, 0)}
+}
+
+// The position of the field or 0 if it is absent.
+func (self fbTable) field(slot int) int {
+	vtable := self.pos - int(int32(u32(self.buf, self.pos)))
+	if vtable%2 != 0 {
+		panic("unaligned vtable")
+	}
+
+	o := 4 + 2*slot
+	if o >= u16(self.buf, vtable) {
+		return 0
+	}
+	offset := u16(self.buf, vtable+o)
+	if offset == 0 {
+		return 0
+	}
+	return self.pos + offset
+}
+
+func (self fbTable) aligned(slot, size int) int {
+	pos := self.field(slot)
+	if pos%size != 0 {
+		panic(fmt.Sprintf("unaligned field %v", slot))
+	}
+	return pos
+}
+
+func (self fbTable) uint8(slot int) int {
+	pos := self.field(slot)
+	if pos == 0 {
+		return 0
+	}
+	return int(self.buf[pos])
+}
+
+func (self fbTable) int16(slot int) int {
+	pos := self.aligned(slot, 2)
+	if pos == 0 {
+		return 0
+	}
+	return int(int16(u16(self.buf, pos)))
+}
+
+func (self fbTable) int32(slot int) int {
+	pos := self.aligned(slot, 4)
+	if pos == 0 {
+		return 0
+	}
+	return int(int32(u32(self.buf, pos)))
+}
+
+func (self fbTable) int64(slot int) int64 {
+	pos := self.aligned(slot, 8)
+	if pos == 0 {
+		return 0
+	}
+	return int64(binary.LittleEndian.Uint64(self.buf[pos:]))
+}
+
+func (self fbTable) deref(slot int) int {
+	pos := self.aligned(slot, 4)
+	if pos == 0 {
+		panic(fmt.Sprintf("missing field %v", slot))
+	}
+	return pos + u32(self.buf, pos)
+}
+
+func (self fbTable) table(slot int) fbTable {
+	return fbTable{buf: self.buf, pos: self.deref(slot)}
+}
+
+func (self fbTable) string(slot int) string {
+	pos := self.deref(slot)
+	length := u32(self.buf, pos)
+	if self.buf[pos+4+length] != 0 {
+		panic("unterminated string")
+	}
+	return string(self.buf[pos+4 : pos+4+length])
+}
+
+func (self fbTable) vector(slot int) (int, int) {
+	pos := self.deref(slot)
+	return pos + 4, u32(self.buf, pos)
+}
+
+func (self fbTable) tables(slot int) []fbTable {
+	start, n := self.vector(slot)
+	result := []fbTable{}
+	for i := 0; i < n; i++ {
+		pos := start + 4*i
+		result = append(result, fbTable{buf: self.buf, pos: pos + u32(self.buf, pos)})
+	}
+	return result
+}
+
+// Vectors of structs of int64s
+func (self fbTable) structs(slot, fields int) [][]int64 {
+	start, n := self.vector(slot)
+	if start%8 != 0 {
+		panic("unaligned struct vector")
+	}
+
+	result := [][]int64{}
+	for i := 0; i < n; i++ {
+		item := []int64{}
+		for j := 0; j < fields; j++ {
+			pos := start + 8*(i*fields+j)
+			item = append(item, int64(binary.LittleEndian.Uint64(self.buf[pos:])))
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+type arrowField struct {
+	Name string
+	Type string
+}
+
+// Returns the header type and the header table of a Message.
+func readMessage(header []byte) (int, fbTable) {
+	message := fbRoot(header)
+	if message.int16(0) != 4 {
+		panic("unexpected metadata version")
+	}
+	return message.uint8(1), message.table(2)
+}
+
+func readSchema(header []byte) []arrowField {
+	header_type, schema := readMessage(header)
+	if header_type != 1 {
+		panic("not a schema")
+	}
+
+	result := []arrowField{}
+	for _, field := range schema.tables(1) {
+		// Readers require the children even for flat types.
+		_, children := field.vector(5)
+		if children != 0 {
+			panic("unexpected children")
+		}
+
+		arrow_type := field.table(3)
+		name := ""
+		switch field.uint8(2) {
+		case 2:
+			name = fmt.Sprintf("int%d", arrow_type.int32(0))
+		case 3:
+			name = "double"
+		case 5:
+			name = "utf8"
+		case 6:
+			name = "bool"
+		case 10:
+			name = fmt.Sprintf("timestamp[%d, %s]",
+				arrow_type.int16(0), arrow_type.string(1))
+		}
+		result = append(result, arrowField{Name: field.string(0), Type: name})
+	}
+	return result
+}
+
+func isSet(bitmap []byte, i int) bool {
+	return bitmap[i/8]&(1<<(i%8)) != 0
+}
+
+// Decode the record batch into rows.
+func readRecordBatch(
+	schema []arrowField, header, body []byte) [][]interface{} {
+	header_type, batch := readMessage(header)
+	if header_type != 3 {
+		panic("not a record batch")
+	}
+
+	length := int(batch.int64(0))
+	nodes := batch.structs(1, 2)
+	buffers := batch.structs(2, 2)
+
+	get := func() []byte {
+		buf := buffers[0]
+		buffers = buffers[1:]
+		if buf[0]%8 != 0 {
+			panic("unaligned buffer")
+		}
+		return body[buf[0] : buf[0]+buf[1]]
+	}
+
+	result := make([][]interface{}, length)
+	for i, field := range schema {
+		if int(nodes[i][0]) != length {
+			panic("bad node length")
+		}
+
+		validity := get()
+		var values, data []byte
+		values = get()
+		if field.Type == "utf8" {
+			data = get()
+		}
+
+		for row := 0; row < length; row++ {
+			if !isSet(validity, row) {
+				result[row] = append(result[row], nil)
+				continue
+			}
+
+			var value interface{}
+			switch field.Type {
+			case "int64":
+				value = int64(binary.LittleEndian.Uint64(values[8*row:]))
+			case "double":
+				value = math.Float64frombits(binary.LittleEndian.Uint64(values[8*row:]))
+			case "bool":
+				value = isSet(values, row)
+			case "utf8":
+				value = string(data[u32(values, 4*row):u32(values, 4*(row+1))])
+			default:
+				value = time.UnixMicro(
+					int64(binary.LittleEndian.Uint64(values[8*row:]))).UTC()
+			}
+			result[row] = append(result[row], value)
+		}
+	}
+	return result
+}
+
+// Read an encapsulated IPC message.
+func readEncapsulated(buf []byte) []byte {
+	if u32(buf, 0) != 0xFFFFFFFF {
+		panic("missing continuation")
+	}
+	return buf[8 : 8+u32(buf, 4)]
+}
@@ -0,0 +1,540 @@
+/*
+  An Apache Arrow Flight endpoint for bulk data extraction.
+
+  Data science pipelines can pull large result sets (e.g. the results
+  of a hunt across the fleet) as Arrow record batches with any Flight
+  client, which is much faster than paging through the JSON API.
+
+  Clients authenticate with an API client certificate (created with
+  `velociraptor config api_client`) and select the org with the
+  "orgid" header. Users need the READ_RESULTS permission and queries
+  run with only that permission.
+
+  Flight tickets and CMD descriptors are SQL queries over the same
+  virtual tables as the SQL endpoint, for example
+
+     SELECT Fqdn, Name FROM hunt_results."Windows.Sys.Users"
+     WHERE hunt_id = 'H.1234' AND Name LIKE 'adm%'
+
+  The select list is used for column projection and the WHERE clause
+  is pushed down into the VQL query so only the requested columns and
+  rows are serialized.
+
+  The Arrow schema is inferred from the first batch of rows. Later
+  values which do not fit a column's type are sent as null and
+  columns which only appear in later rows are dropped.
+*/
+
+package flight_endpoint
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	flight_proto "www.velocidex.com/golang/velociraptor/services/flight_endpoint/proto"
+	"www.velocidex.com/golang/velociraptor/services/sql_endpoint"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+type FlightEndpoint struct {
+	flight_proto.UnimplementedFlightServiceServer
+
+	config_obj *config_proto.Config
+	batch_size int
+	timeout    time.Duration
+}
+
+// Identify the user from their client certificate and check they
+// may read results in the requested org.
+func (self *FlightEndpoint) authenticate(ctx context.Context) (
+	string, *config_proto.Config, error) {
+	principal := ""
+	p, ok := peer.FromContext(ctx)
+	if ok {
+		tls_info, ok := p.AuthInfo.(credentials.TLSInfo)
+		if ok && len(tls_info.State.VerifiedChains) > 0 &&
+			len(tls_info.State.VerifiedChains[0]) > 0 {
+			principal = crypto_utils.GetSubjectName(
+				tls_info.State.VerifiedChains[0][0])
+		}
+	}
+
+	if principal == "" {
+		return "", nil, status.Error(codes.Unauthenticated,
+			"A client certificate is required")
+	}
+
+	org_id := ""
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		values := md.Get("orgid")
+		if len(values) > 0 {
+			org_id = values[0]
+		}
+	}
+
+	org_manager, err := services.GetOrgManager()
+	if err != nil {
+		return "", nil, status.Error(codes.Internal, err.Error())
+	}
+
+	org_config, err := org_manager.GetOrgConfig(org_id)
+	if err != nil {
+		return "", nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	ok, err = services.CheckAccess(org_config, principal, acls.READ_RESULTS)
+	if err != nil || !ok {
+		return "", nil, status.Errorf(codes.PermissionDenied,
+			"User %v is not allowed to read results in org %v",
+			principal, utils.NormalizedOrgId(org_config.OrgId))
+	}
+
+	return principal, org_config, nil
+}
+
+func translate(cmd []byte) (*sql_endpoint.Query, error) {
+	query, err := sql_endpoint.TranslateQuery(string(cmd))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return query, nil
+}
+
+func getCommand(descriptor *flight_proto.FlightDescriptor) ([]byte, error) {
+	if descriptor.Type != flight_proto.FlightDescriptor_CMD {
+		return nil, status.Error(codes.InvalidArgument,
+			"Only CMD descriptors with an SQL query are supported")
+	}
+	return descriptor.Cmd, nil
+}
+
+// Lists the results of each hunt.
+func (self *FlightEndpoint) ListFlights(
+	criteria *flight_proto.Criteria,
+	stream flight_proto.FlightService_ListFlightsServer) error {
+
+	_, org_config, err := self.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	hunt_dispatcher, err := services.GetHuntDispatcher(org_config)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	var infos []*flight_proto.FlightInfo
+	err = hunt_dispatcher.ApplyFuncOnHunts(func(hunt *api_proto.Hunt) error {
+		sources := hunt.ArtifactSources
+		if len(sources) == 0 && hunt.StartRequest != nil {
+			sources = hunt.StartRequest.Artifacts
+		}
+
+		for _, source := range sources {
+			cmd := fmt.Sprintf(
+				`SELECT * FROM hunt_results."%s" WHERE hunt_id = '%s'`,
+				strings.ReplaceAll(source, `"`, `""`),
+				strings.ReplaceAll(hunt.HuntId, `'`, `''`))
+			infos = append(infos, flightInfo([]byte(cmd)))
+		}
+		return nil
+	})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	for _, info := range infos {
+		err := stream.Send(info)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The query is the ticket and can be redeemed on this endpoint. The
+// schema is not included because working it out means running the
+// query - use GetSchema.
+func flightInfo(cmd []byte) *flight_proto.FlightInfo {
+	return &flight_proto.FlightInfo{
+		FlightDescriptor: &flight_proto.FlightDescriptor{
+			Type: flight_proto.FlightDescriptor_CMD,
+			Cmd:  cmd,
+		},
+		Endpoint: []*flight_proto.FlightEndpoint{{
+			Ticket: &flight_proto.Ticket{Ticket: cmd},
+		}},
+		TotalRecords: -1,
+		TotalBytes:   -1,
+	}
+}
+
+func (self *FlightEndpoint) GetFlightInfo(
+	ctx context.Context,
+	descriptor *flight_proto.FlightDescriptor) (*flight_proto.FlightInfo, error) {
+
+	_, _, err := self.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := getCommand(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = translate(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return flightInfo(cmd), nil
+}
+
+func (self *FlightEndpoint) GetSchema(
+	ctx context.Context,
+	descriptor *flight_proto.FlightDescriptor) (*flight_proto.SchemaResult, error) {
+
+	principal, org_config, err := self.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := getCommand(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := translate(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := self.runQuery(ctx, principal, org_config, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the first batch is needed.
+	batch := result.nextBatch()
+	schema := inferSchema(query.Columns, batch, result.schema)
+	result.close()
+
+	err = result.err()
+	if err != nil {
+		return nil, err
+	}
+
+	return &flight_proto.SchemaResult{
+		Schema: encapsulate(encodeSchema(schema)),
+	}, nil
+}
+
+func (self *FlightEndpoint) DoGet(
+	ticket *flight_proto.Ticket,
+	stream flight_proto.FlightService_DoGetServer) error {
+
+	principal, org_config, err := self.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	query, err := translate(ticket.Ticket)
+	if err != nil {
+		return err
+	}
+
+	result, err := self.runQuery(stream.Context(), principal, org_config, query)
+	if err != nil {
+		return err
+	}
+	defer result.close()
+
+	batch := result.nextBatch()
+	schema := inferSchema(query.Columns, batch, result.schema)
+
+	err = stream.Send(&flight_proto.FlightData{
+		DataHeader: encodeSchema(schema),
+	})
+	if err != nil {
+		return err
+	}
+
+	for len(batch) > 0 {
+		header, body := encodeRecordBatch(schema, batch)
+		err = stream.Send(&flight_proto.FlightData{
+			DataHeader: header,
+			DataBody:   body,
+		})
+		if err != nil {
+			return err
+		}
+
+		batch = result.nextBatch()
+	}
+
+	return result.err()
+}
+
+type queryResult struct {
+	rows       chan *ordereddict.Dict
+	batch_size int
+	ctx        context.Context
+	cancel     func()
+	errors     *errorCollector
+
+	// The typed columns of the artifact being queried.
+	schema *result_sets.Schema
+}
+
+// Read up to batch_size rows. Returns an empty batch when the
+// query is done.
+func (self *queryResult) nextBatch() []*ordereddict.Dict {
+	var result []*ordereddict.Dict
+	for row := range self.rows {
+		result = append(result, row)
+		if len(result) >= self.batch_size {
+			break
+		}
+	}
+	return result
+}
+
+// Stop the query and wait for it to finish.
+func (self *queryResult) close() {
+	self.cancel()
+	for range self.rows {
+	}
+}
+
+// The error which stopped the query, if any.
+func (self *queryResult) err() error {
+	if errors.Is(self.ctx.Err(), context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, "Query timed out")
+	}
+
+	message := self.errors.get()
+	if message != "" {
+		return status.Error(codes.PermissionDenied, message)
+	}
+	return nil
+}
+
+func (self *FlightEndpoint) runQuery(
+	ctx context.Context, principal string,
+	org_config *config_proto.Config,
+	query *sql_endpoint.Query) (*queryResult, error) {
+
+	vql, err := vfilter.Parse(query.VQL)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	manager, err := services.GetRepositoryManager(org_config)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	repository, err := manager.GetGlobalRepository(org_config)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.APICmponent)
+	logger.WithFields(logrus.Fields{
+		"user": principal,
+		"org":  org_config.OrgId,
+		"vql":  query.VQL,
+	}).Info("Flight endpoint query")
+
+	collector := &errorCollector{}
+	scope := manager.BuildScope(services.ScopeBuilder{
+		Config: org_config,
+		ACLManager: acl_managers.NewReadOnlyACLManager(
+			org_config, principal),
+		Logger:     log.New(collector, "", 0),
+		Repository: repository,
+		Env:        query.Env,
+	})
+
+	sub_ctx, cancel := context.WithTimeout(ctx, self.timeout)
+	result := &queryResult{
+		rows:       make(chan *ordereddict.Dict, self.batch_size),
+		batch_size: self.batch_size,
+		ctx:        sub_ctx,
+		cancel:     cancel,
+		errors:     collector,
+	}
+
+	if query.Artifact != "" {
+		result.schema = services.GetArtifactSchema(org_config, query.Artifact)
+	}
+
+	go func() {
+		defer close(result.rows)
+		defer scope.Close()
+
+		opts := vql_subsystem.EncOptsFromScope(scope)
+		for row := range vql.Eval(sub_ctx, scope) {
+			// Serializing the row materializes lazy values the same
+			// way as the API does.
+			serialized, err := json.MarshalWithOptions(row, opts)
+			if err != nil {
+				continue
+			}
+
+			dicts, err := utils.ParseJsonToDicts(serialized)
+			if err != nil || len(dicts) == 0 {
+				continue
+			}
+
+			select {
+			case <-sub_ctx.Done():
+				return
+			case result.rows <- result.schema.Normalize(dicts[0]):
+			}
+		}
+	}()
+
+	return result, nil
+}
+
+// Watches the query log for permission errors. VQL reports these in
+// the log rather than failing the query.
+type errorCollector struct {
+	mu      sync.Mutex
+	message string
+}
+
+func (self *errorCollector) Write(b []byte) (int, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	line := string(b)
+	if self.message == "" && (strings.Contains(line, "PermissionDenied") ||
+		strings.Contains(line, "Permission denied")) {
+		self.message = strings.TrimSpace(line)
+	}
+	return len(b), nil
+}
+
+func (self *errorCollector) get() string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.message
+}
+
+func NewFlightEndpointService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	if config_obj.Frontend == nil || config_obj.Client == nil {
+		return errors.New("Flight endpoint: Frontend not configured")
+	}
+
+	flight_config := config_obj.FlightEndpoint
+
+	bind_address := flight_config.BindAddress
+	if bind_address == "" {
+		bind_address = "127.0.0.1"
+	}
+
+	bind_port := flight_config.BindPort
+	if bind_port == 0 {
+		bind_port = 8815
+	}
+
+	batch_size := int(flight_config.BatchSize)
+	if batch_size == 0 {
+		batch_size = 10000
+	}
+
+	timeout := time.Duration(600) * time.Second
+	if flight_config.Timeout > 0 {
+		timeout = time.Duration(flight_config.Timeout) * time.Second
+	}
+
+	// Use the server certificate to secure the connection and
+	// authenticate users with API client certificates.
+	cert, err := tls.X509KeyPair(
+		[]byte(config_obj.Frontend.Certificate),
+		[]byte(config_obj.Frontend.PrivateKey))
+	if err != nil {
+		return err
+	}
+
+	CA_Pool := x509.NewCertPool()
+	CA_Pool.AppendCertsFromPEM([]byte(config_obj.Client.CaCertificate))
+
+	creds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    CA_Pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})
+
+	address := net.JoinHostPort(bind_address, fmt.Sprintf("%d", bind_port))
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer(grpc.Creds(creds))
+	flight_proto.RegisterFlightServiceServer(server, &FlightEndpoint{
+		config_obj: config_obj,
+		batch_size: batch_size,
+		timeout:    timeout,
+	})
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> Flight endpoint on %v", address)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+
+		err := server.Serve(listener)
+		if err != nil {
+			logger.Error("Flight endpoint: %v", err)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+
+		// Do not wait for long running extractions to finish.
+		server.Stop()
+	}()
+
+	return nil
+}
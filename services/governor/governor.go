@@ -0,0 +1,368 @@
+/*
+  Resource governor for server side queries.
+
+  Server artifacts and notebook cells run inside the frontend
+  process. A heavy enrichment artifact or notebook could otherwise
+  take all the CPU and memory the frontend needs to service clients.
+
+  Each query must be admitted by the governor before it runs. Queries
+  are admitted while:
+
+  1. Fewer than max_concurrency governed queries run across all orgs.
+  2. Fewer than the org's max_server_concurrency quota run in the org.
+  3. The process CPU load is below max_cpu_percent.
+  4. The heap is smaller than max_memory.
+
+  Otherwise queries wait in a queue - server artifacts ahead of
+  notebook cells, then in the order they arrived - and fail if they
+  are not admitted within the queue timeout.
+
+  Admitted queries are throttled to max_cpu_percent. If the heap
+  grows well past max_memory the most recently started notebook cells
+  and then server artifacts are preempted (cancelled) one at a time
+  until it drops again.
+
+  The governor is shared by all orgs in the process.
+*/
+
+package governor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"www.velocidex.com/golang/velociraptor/actions"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/services/quotas"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/vfilter"
+)
+
+var (
+	ServerBusyError = errors.New("Resource governor: server is busy")
+
+	governorRunning = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "governor_running_queries",
+		Help: "Number of server artifact queries and notebook cells running.",
+	})
+
+	governorQueued = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "governor_queued_queries",
+		Help: "Number of server artifact queries and notebook cells waiting to run.",
+	})
+
+	governorPreempted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "governor_preempted_queries",
+		Help: "Number of queries cancelled to free memory.",
+	})
+
+	// The concurrency quota of the org - replaced in tests.
+	orgConcurrency = func(config_obj *config_proto.Config) uint64 {
+		return quotas.GetQuota(config_obj).MaxServerConcurrency
+	}
+
+	gGovernor = newGovernor()
+)
+
+const (
+	DEFAULT_QUEUE_TIMEOUT = 600 * time.Second
+
+	// Queries are preempted when the heap exceeds max_memory by
+	// this factor.
+	PREEMPT_FACTOR = 1.2
+)
+
+// Queries with a lower priority wait behind queries with a higher
+// priority and are preempted first.
+type Priority int
+
+const (
+	// Notebook cells can be recalculated by the user.
+	PRIORITY_NOTEBOOK Priority = iota
+	PRIORITY_SERVER_ARTIFACT
+)
+
+type limits struct {
+	max_concurrency uint64
+	org_concurrency uint64
+	max_cpu_percent float64
+	max_memory      uint64
+	queue_timeout   time.Duration
+}
+
+func getLimits(config_obj *config_proto.Config) *limits {
+	result := &limits{
+		org_concurrency: orgConcurrency(config_obj),
+		queue_timeout:   DEFAULT_QUEUE_TIMEOUT,
+	}
+
+	if config_obj.Frontend == nil || config_obj.Frontend.Resources == nil ||
+		config_obj.Frontend.Resources.ResourceGovernor == nil {
+		return result
+	}
+
+	settings := config_obj.Frontend.Resources.ResourceGovernor
+	result.max_concurrency = settings.MaxConcurrency
+	if result.max_concurrency == 0 {
+		result.max_concurrency = uint64(2 * runtime.NumCPU())
+	}
+	result.max_cpu_percent = settings.MaxCpuPercent
+	result.max_memory = settings.MaxMemory
+
+	if settings.QueueTimeout > 0 {
+		result.queue_timeout = time.Duration(settings.QueueTimeout) * time.Second
+	}
+
+	return result
+}
+
+// A query admitted by the governor. It must be closed when the query
+// is done.
+type Task struct {
+	id          uint64
+	org_id      string
+	description string
+	priority    Priority
+	limits      *limits
+	queued      time.Time
+	started     time.Time
+	cancel      func()
+
+	governor *governor
+}
+
+// How long the query waited to be admitted.
+func (self *Task) Waited() time.Duration {
+	return self.started.Sub(self.queued)
+}
+
+func (self *Task) Close() {
+	self.governor.release(self)
+}
+
+type governor struct {
+	mu sync.Mutex
+
+	id      uint64
+	running map[uint64]*Task
+
+	// In the order they arrived.
+	waiting []*Task
+
+	// Closed when a waiting query may be admitted.
+	changed chan struct{}
+
+	// The last load sampled by the monitor.
+	cpu_percent float64
+	heap        uint64
+}
+
+func newGovernor() *governor {
+	return &governor{
+		running: make(map[uint64]*Task),
+		changed: make(chan struct{}),
+	}
+}
+
+// Wake up the waiting queries. Must be called with mu held.
+func (self *governor) notify() {
+	close(self.changed)
+	self.changed = make(chan struct{})
+}
+
+func (self *governor) acquire(ctx context.Context,
+	org_id string, limits *limits, priority Priority,
+	description string, cancel func()) (*Task, error) {
+
+	self.mu.Lock()
+	self.id++
+	task := &Task{
+		id:          self.id,
+		org_id:      org_id,
+		description: description,
+		priority:    priority,
+		limits:      limits,
+		queued:      utils.GetTime().Now(),
+		cancel:      cancel,
+		governor:    self,
+	}
+	self.waiting = append(self.waiting, task)
+	governorQueued.Inc()
+	self.mu.Unlock()
+
+	deadline := time.After(limits.queue_timeout)
+	for {
+		self.mu.Lock()
+		admitted := self.tryAdmit(task)
+		changed := self.changed
+		self.mu.Unlock()
+
+		if admitted {
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			self.removeWaiting(task)
+			return nil, ctx.Err()
+
+		case <-deadline:
+			self.removeWaiting(task)
+			return nil, fmt.Errorf("%w: %v was not admitted within %v",
+				ServerBusyError, description, limits.queue_timeout)
+
+		case <-changed:
+		}
+	}
+}
+
+// Must be called with mu held.
+func (self *governor) hasCapacity(task *Task) bool {
+	limits := task.limits
+	if limits.max_concurrency > 0 &&
+		uint64(len(self.running)) >= limits.max_concurrency {
+		return false
+	}
+
+	if limits.max_cpu_percent > 0 && self.cpu_percent > limits.max_cpu_percent {
+		return false
+	}
+
+	if limits.max_memory > 0 && self.heap > limits.max_memory {
+		return false
+	}
+
+	if limits.org_concurrency > 0 {
+		org_running := uint64(0)
+		for _, running := range self.running {
+			if running.org_id == task.org_id {
+				org_running++
+			}
+		}
+		if org_running >= limits.org_concurrency {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Admit the task if it has capacity and no waiting query ahead of it
+// can be admitted instead. Must be called with mu held.
+func (self *governor) tryAdmit(task *Task) bool {
+	var next *Task
+	for _, waiting := range self.waiting {
+		if (next == nil || waiting.priority > next.priority) &&
+			self.hasCapacity(waiting) {
+			next = waiting
+		}
+	}
+
+	if next != task {
+		return false
+	}
+
+	self.removeWaitingLocked(task)
+	task.started = utils.GetTime().Now()
+	self.running[task.id] = task
+	governorRunning.Inc()
+
+	// A query behind this one may be in another org with spare
+	// capacity.
+	self.notify()
+
+	return true
+}
+
+func (self *governor) removeWaiting(task *Task) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.removeWaitingLocked(task)
+	self.notify()
+}
+
+func (self *governor) removeWaitingLocked(task *Task) {
+	for idx, waiting := range self.waiting {
+		if waiting == task {
+			self.waiting = append(self.waiting[:idx], self.waiting[idx+1:]...)
+			governorQueued.Dec()
+			return
+		}
+	}
+}
+
+func (self *governor) release(task *Task) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	_, pres := self.running[task.id]
+	if pres {
+		delete(self.running, task.id)
+		governorRunning.Dec()
+		self.notify()
+	}
+}
+
+// Record the current load and pick a query to preempt if the heap is
+// too large.
+func (self *governor) update(
+	cpu_percent float64, heap uint64, limits *limits) *Task {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.cpu_percent = cpu_percent
+	self.heap = heap
+	self.notify()
+
+	if limits.max_memory == 0 ||
+		float64(heap) < float64(limits.max_memory)*PREEMPT_FACTOR {
+		return nil
+	}
+
+	// The most recently started query of the lowest priority.
+	var victim *Task
+	for _, task := range self.running {
+		if victim == nil || task.priority < victim.priority ||
+			(task.priority == victim.priority &&
+				task.started.After(victim.started)) {
+			victim = task
+		}
+	}
+
+	// It no longer counts against the limits - the query may take a
+	// while to wind down.
+	if victim != nil {
+		delete(self.running, victim.id)
+		governorRunning.Dec()
+		governorPreempted.Inc()
+	}
+
+	return victim
+}
+
+// Wait until the governor admits the query. The governor calls cancel
+// if it preempts the query. The returned Task must be closed when the
+// query is done.
+func Acquire(ctx context.Context,
+	config_obj *config_proto.Config, priority Priority,
+	description string, cancel func()) (*Task, error) {
+	return gGovernor.acquire(ctx, config_obj.OrgId, getLimits(config_obj),
+		priority, description, cancel)
+}
+
+// Throttle the query to the governor's CPU limit.
+func Throttle(ctx context.Context,
+	config_obj *config_proto.Config, scope vfilter.Scope) {
+	max_cpu_percent := getLimits(config_obj).max_cpu_percent
+	if max_cpu_percent > 0 {
+		scope.SetThrottler(actions.NewThrottler(
+			ctx, scope, 0, max_cpu_percent, 0))
+	}
+}
@@ -0,0 +1,146 @@
+package governor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+func getConfig(org_id string) *config_proto.Config {
+	return &config_proto.Config{
+		OrgId: org_id,
+		Frontend: &config_proto.FrontendConfig{
+			Resources: &config_proto.FrontendResourceControl{
+				ResourceGovernor: &config_proto.ResourceGovernorConfig{
+					MaxConcurrency: 2,
+					MaxMemory:      1000,
+					QueueTimeout:   1,
+				},
+			},
+		},
+	}
+}
+
+// Acquire in the background and report the task when admitted.
+func acquireAsync(governor *governor, config_obj *config_proto.Config,
+	priority Priority, description string) chan *Task {
+	result := make(chan *Task, 1)
+	go func() {
+		limits := getLimits(config_obj)
+		limits.queue_timeout = 10 * time.Second
+		task, _ := governor.acquire(context.Background(), config_obj.OrgId,
+			limits, priority, description, func() {})
+		result <- task
+	}()
+	return result
+}
+
+func waitForQueue(t *testing.T, governor *governor, length int) {
+	for i := 0; i < 100; i++ {
+		governor.mu.Lock()
+		waiting := len(governor.waiting)
+		governor.mu.Unlock()
+		if waiting == length {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Queue did not reach %v", length)
+}
+
+func TestConcurrency(t *testing.T) {
+	orgConcurrency = func(config_obj *config_proto.Config) uint64 {
+		if config_obj.OrgId == "O1" {
+			return 1
+		}
+		return 0
+	}
+
+	governor := newGovernor()
+	root := getConfig("")
+	org := getConfig("O1")
+	ctx := context.Background()
+
+	// The org may only run one query at a time.
+	first, err := governor.acquire(ctx, "O1", getLimits(org),
+		PRIORITY_NOTEBOOK, "first", func() {})
+	assert.NoError(t, err)
+
+	_, err = governor.acquire(ctx, "O1", getLimits(org),
+		PRIORITY_NOTEBOOK, "second", func() {})
+	assert.True(t, errors.Is(err, ServerBusyError))
+
+	// Other orgs are not held up by it.
+	second, err := governor.acquire(ctx, "", getLimits(root),
+		PRIORITY_NOTEBOOK, "root", func() {})
+	assert.NoError(t, err)
+
+	// The server is now full - server artifacts are admitted ahead of
+	// notebook cells which arrived earlier.
+	notebook := acquireAsync(governor, root, PRIORITY_NOTEBOOK, "notebook")
+	waitForQueue(t, governor, 1)
+
+	artifact := acquireAsync(governor, root, PRIORITY_SERVER_ARTIFACT, "artifact")
+	waitForQueue(t, governor, 2)
+
+	first.Close()
+	task := <-artifact
+	assert.Equal(t, "artifact", task.description)
+
+	second.Close()
+	task = <-notebook
+	assert.Equal(t, "notebook", task.description)
+}
+
+func TestPreemption(t *testing.T) {
+	orgConcurrency = func(config_obj *config_proto.Config) uint64 {
+		return 0
+	}
+
+	governor := newGovernor()
+	config_obj := getConfig("")
+	limits := getLimits(config_obj)
+	ctx := context.Background()
+
+	cancelled := make(map[string]bool)
+	acquire := func(priority Priority, description string) *Task {
+		task, err := governor.acquire(ctx, "", limits, priority, description,
+			func() { cancelled[description] = true })
+		assert.NoError(t, err)
+		return task
+	}
+
+	artifact := acquire(PRIORITY_SERVER_ARTIFACT, "artifact")
+	notebook := acquire(PRIORITY_NOTEBOOK, "notebook")
+
+	// Over the memory limit new queries are queued.
+	victim := governor.update(0, 1100, limits)
+	assert.Nil(t, victim)
+
+	artifact.Close()
+	_, err := governor.acquire(ctx, "", limits, PRIORITY_NOTEBOOK,
+		"queued", func() {})
+	assert.True(t, errors.Is(err, ServerBusyError))
+
+	// Well over the limit the notebook is preempted first.
+	governor.update(0, 0, limits)
+	artifact = acquire(PRIORITY_SERVER_ARTIFACT, "artifact")
+	victim = governor.update(0, 2000, limits)
+	assert.Same(t, notebook, victim)
+	victim.cancel()
+	assert.True(t, cancelled["notebook"])
+
+	victim = governor.update(0, 2000, limits)
+	assert.Same(t, artifact, victim)
+
+	// Nothing left to preempt.
+	assert.Nil(t, governor.update(0, 2000, limits))
+
+	// Closing a preempted task is harmless.
+	notebook.Close()
+	artifact.Close()
+}
@@ -0,0 +1,116 @@
+package governor
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	// How often to sample the process load.
+	sampleInterval = 2 * time.Second
+)
+
+// Measures the CPU load of the process as a percentage of all cores
+// since the last sample.
+type cpuSampler struct {
+	proc     *process.Process
+	cores    float64
+	last_cpu float64
+	last     time.Time
+}
+
+func newCpuSampler() (*cpuSampler, error) {
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cpuSampler{
+		proc:  proc,
+		cores: float64(runtime.NumCPU()),
+	}
+	result.Sample()
+	return result, nil
+}
+
+func (self *cpuSampler) Sample() float64 {
+	times, err := self.proc.Times()
+	if err != nil {
+		return 0
+	}
+
+	now := utils.GetTime().Now()
+	total := times.Total()
+
+	result := float64(0)
+	elapsed := now.Sub(self.last).Seconds()
+	if !self.last.IsZero() && elapsed > 0 {
+		result = (total - self.last_cpu) / elapsed * 100 / self.cores
+	}
+
+	self.last_cpu = total
+	self.last = now
+	return result
+}
+
+// Samples the process load for the governor and preempts queries
+// when the heap grows too large. Only runs in the root org since the
+// governor is shared by all orgs.
+func NewResourceGovernorService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	if config_obj.Frontend == nil || config_obj.Frontend.Resources == nil ||
+		config_obj.Frontend.Resources.ResourceGovernor == nil {
+		return nil
+	}
+
+	sampler, err := newCpuSampler()
+	if err != nil {
+		return err
+	}
+
+	limits := getLimits(config_obj)
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> resource governor: %v concurrent queries, "+
+		"CPU limit %v%%, memory limit %v bytes", limits.max_concurrency,
+		limits.max_cpu_percent, limits.max_memory)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(sampleInterval):
+				mem_stats := &runtime.MemStats{}
+				runtime.ReadMemStats(mem_stats)
+
+				victim := gGovernor.update(
+					sampler.Sample(), mem_stats.HeapAlloc, limits)
+				if victim != nil {
+					logger.Info("Resource governor: <red>Preempting</> %v "+
+						"in org %v - heap is %v bytes (limit %v)",
+						victim.description, victim.org_id,
+						mem_stats.HeapAlloc, limits.max_memory)
+					victim.cancel()
+				}
+			}
+		}
+	}()
+
+	return nil
+}
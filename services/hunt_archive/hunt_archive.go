@@ -0,0 +1,281 @@
+/*
+  The hunt archive service uploads the results of completed hunts to
+  object storage (S3 or Azure Blob Storage) so long term retention
+  does not depend on the server's local disk.
+
+  A hunt is archived once it expired or was stopped, after a delay
+  to allow in flight collections to complete. The hunt's combined
+  results are packaged into a zip file with a manifest of the sha256
+  hashes of all its members. The zip is uploaded together with a
+  copy of the manifest (which also records the hash of the zip
+  itself) so the archive can be verified without downloading it.
+
+  The manifest is also written to the hunt's directory in the file
+  store to record that the hunt was archived.
+*/
+
+package hunt_archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	DEFAULT_DELAY           = 600
+	DEFAULT_CHECK_FREQUENCY = 60
+)
+
+type HuntArchiveService struct {
+	mu sync.Mutex
+
+	config_obj *config_proto.Config
+	uploaders  []Uploader
+
+	// The hunt object does not record when the hunt was stopped so
+	// we remember when we first saw it stopped.
+	stopped map[string]time.Time
+}
+
+// Returns the ids of the hunts that are ready to be archived.
+func (self *HuntArchiveService) pendingHunts() ([]string, error) {
+	hunt_dispatcher, err := services.GetHuntDispatcher(self.config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := time.Duration(self.config_obj.HuntArchive.Delay) * time.Second
+	if delay == 0 {
+		delay = DEFAULT_DELAY * time.Second
+	}
+
+	now := utils.GetTime().Now()
+	file_store_factory := file_store.GetFileStore(self.config_obj)
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	var result []string
+	err = hunt_dispatcher.ApplyFuncOnHunts(func(hunt_obj *api_proto.Hunt) error {
+		var done_time time.Time
+
+		switch hunt_obj.State {
+		case api_proto.Hunt_STOPPED, api_proto.Hunt_ARCHIVED:
+			first_seen, pres := self.stopped[hunt_obj.HuntId]
+			if !pres {
+				first_seen = now
+				self.stopped[hunt_obj.HuntId] = now
+			}
+			done_time = first_seen
+
+		case api_proto.Hunt_RUNNING, api_proto.Hunt_PAUSED:
+			expires := time.Unix(0, int64(hunt_obj.Expires)*1000)
+			if hunt_obj.Expires == 0 || now.Before(expires) {
+				return nil
+			}
+			done_time = expires
+
+		default:
+			return nil
+		}
+
+		if now.Before(done_time.Add(delay)) {
+			return nil
+		}
+
+		// Already archived.
+		path_manager := paths.NewHuntPathManager(hunt_obj.HuntId)
+		_, err := file_store_factory.StatFile(path_manager.ArchiveManifest())
+		if err == nil {
+			delete(self.stopped, hunt_obj.HuntId)
+			return nil
+		}
+
+		result = append(result, hunt_obj.HuntId)
+		return nil
+	})
+
+	return result, err
+}
+
+// Archive all the hunts that are ready. Failed hunts are retried
+// the next time.
+func (self *HuntArchiveService) ScanHunts(ctx context.Context) error {
+	hunt_ids, err := self.pendingHunts()
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	for _, hunt_id := range hunt_ids {
+		err := self.ArchiveHunt(ctx, hunt_id)
+		if err != nil {
+			logger.Error("HuntArchiveService: archiving %v: %v", hunt_id, err)
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// The name of the uploaded objects. Hunt ids are only unique within
+// an org.
+func (self *HuntArchiveService) objectName(hunt_id, suffix string) string {
+	name := hunt_id + suffix
+	if !utils.IsRootOrg(self.config_obj.OrgId) {
+		name = self.config_obj.OrgId + "/" + name
+	}
+	return self.config_obj.HuntArchive.Prefix + name
+}
+
+// Package the hunt's results and upload them to all the configured
+// stores.
+func (self *HuntArchiveService) ArchiveHunt(
+	ctx context.Context, hunt_id string) error {
+
+	hunt_dispatcher, err := services.GetHuntDispatcher(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	hunt_obj, pres := hunt_dispatcher.GetHunt(hunt_id)
+	if !pres {
+		return errors.New("Hunt not found")
+	}
+
+	tmpfile, err := ioutil.TempFile("", "hunt_archive")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpfile.Name())
+	defer tmpfile.Close()
+
+	writer := newHashingWriter(tmpfile)
+	manifest, err := packageHunt(ctx, self.config_obj, hunt_obj, writer)
+	if err != nil {
+		return err
+	}
+
+	archive_name := self.objectName(hunt_id, ".zip")
+	manifest.Archive = writer.Entry(archive_name)
+
+	for _, uploader := range self.uploaders {
+		_, err = tmpfile.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+
+		location, err := uploader.Upload(ctx, archive_name, tmpfile,
+			manifest.Archive.Size, manifest.Archive.SHA256, "application/zip")
+		if err != nil {
+			return err
+		}
+		manifest.Locations = append(manifest.Locations, location)
+	}
+
+	serialized, err := json.MarshalIndent(manifest)
+	if err != nil {
+		return err
+	}
+
+	// The manifest is uploaded last so its presence means the
+	// archive is complete.
+	manifest_name := self.objectName(hunt_id, ".manifest.json")
+	for _, uploader := range self.uploaders {
+		_, err = uploader.Upload(ctx, manifest_name,
+			bytes.NewReader(serialized), int64(len(serialized)),
+			sha256Hex(serialized), "application/json")
+		if err != nil {
+			return err
+		}
+	}
+
+	file_store_factory := file_store.GetFileStore(self.config_obj)
+	fd, err := file_store_factory.WriteFile(
+		paths.NewHuntPathManager(hunt_id).ArchiveManifest())
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	err = fd.Truncate()
+	if err != nil {
+		return err
+	}
+
+	_, err = fd.Write(serialized)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	logger.Info("HuntArchiveService: Archived hunt %v (%v bytes) to %v",
+		hunt_id, manifest.Archive.Size, manifest.Locations)
+
+	return nil
+}
+
+func NewHuntArchiveService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*HuntArchiveService, error) {
+
+	uploaders, err := getUploaders(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HuntArchiveService{
+		config_obj: config_obj,
+		uploaders:  uploaders,
+		stopped:    make(map[string]time.Time),
+	}
+
+	frequency := time.Duration(
+		config_obj.HuntArchive.CheckFrequency) * time.Second
+	if frequency == 0 {
+		frequency = DEFAULT_CHECK_FREQUENCY * time.Second
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> hunt archive service for %v.",
+		services.GetOrgName(config_obj))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(frequency):
+				err := result.ScanHunts(ctx)
+				if err != nil {
+					logger.Error("HuntArchiveService: %v", err)
+				}
+			}
+		}
+	}()
+
+	return result, nil
+}
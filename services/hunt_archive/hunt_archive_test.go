@@ -0,0 +1,243 @@
+package hunt_archive_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/hunt_archive"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	artifact = "Generic.Client.Info/BasicInformation"
+)
+
+type HuntArchiveTestSuite struct {
+	test_utils.TestSuite
+
+	server *httptest.Server
+
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (self *HuntArchiveTestSuite) SetupTest() {
+	self.objects = make(map[string][]byte)
+
+	// A fake S3 endpoint which checks the payload hash.
+	self.server = httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut || !strings.HasPrefix(
+				r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			hash := sha256.Sum256(body)
+			if hex.EncodeToString(hash[:]) != r.Header.Get("X-Amz-Content-Sha256") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			self.mu.Lock()
+			defer self.mu.Unlock()
+			self.objects[r.URL.Path] = body
+		}))
+
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Services.HuntDispatcher = true
+	self.ConfigObj.HuntArchive = &config_proto.HuntArchiveConfig{
+		S3: &config_proto.HuntArchiveS3Config{
+			Bucket:            "bucket",
+			Region:            "us-east-1",
+			Endpoint:          self.server.URL,
+			CredentialsKey:    "key",
+			CredentialsSecret: "secret",
+		},
+		Prefix: "hunts/",
+
+		// Do not let the service scan in the background.
+		CheckFrequency: 10000,
+	}
+
+	self.TestSuite.SetupTest()
+}
+
+func (self *HuntArchiveTestSuite) TearDownTest() {
+	self.TestSuite.TearDownTest()
+	self.server.Close()
+}
+
+func (self *HuntArchiveTestSuite) addHunt(hunt_obj *api_proto.Hunt) {
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	hunt_path_manager := paths.NewHuntPathManager(hunt_obj.HuntId)
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj,
+		hunt_path_manager.Path(), hunt_obj))
+
+	writer, err := result_sets.NewResultSetWriter(
+		file_store.GetFileStore(self.ConfigObj),
+		hunt_path_manager.Results(artifact),
+		json.DefaultEncOpts(), utils.SyncCompleter, result_sets.TruncateMode)
+	assert.NoError(self.T(), err)
+	writer.Write(ordereddict.NewDict().
+		Set("Hostname", "MyHost").
+		Set("ClientId", "C.1234"))
+	writer.Close()
+
+	hunt_dispatcher, err := services.GetHuntDispatcher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.NoError(self.T(), hunt_dispatcher.Refresh(self.ConfigObj))
+}
+
+func (self *HuntArchiveTestSuite) getObjects() map[string][]byte {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := make(map[string][]byte)
+	for k, v := range self.objects {
+		result[k] = v
+	}
+	return result
+}
+
+func (self *HuntArchiveTestSuite) TestArchiveHunt() {
+	self.addHunt(&api_proto.Hunt{
+		HuntId:          "H.1",
+		State:           api_proto.Hunt_STOPPED,
+		ArtifactSources: []string{artifact},
+	})
+
+	service, err := hunt_archive.NewHuntArchiveService(
+		self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	assert.NoError(self.T(), service.ArchiveHunt(self.Ctx, "H.1"))
+
+	objects := self.getObjects()
+	archive, pres := objects["/bucket/hunts/H.1.zip"]
+	assert.True(self.T(), pres)
+
+	manifest := &hunt_archive.Manifest{}
+	assert.NoError(self.T(), json.Unmarshal(
+		objects["/bucket/hunts/H.1.manifest.json"], manifest))
+
+	// The uploaded manifest describes the archive.
+	hash := sha256.Sum256(archive)
+	assert.Equal(self.T(), "hunts/H.1.zip", manifest.Archive.Name)
+	assert.Equal(self.T(), hex.EncodeToString(hash[:]), manifest.Archive.SHA256)
+	assert.Equal(self.T(), int64(len(archive)), manifest.Archive.Size)
+	assert.Equal(self.T(), []string{"s3://bucket/hunts/H.1.zip"},
+		manifest.Locations)
+
+	// Each member of the archive matches its hash.
+	zip_reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	assert.NoError(self.T(), err)
+
+	members := make(map[string][]byte)
+	for _, file := range zip_reader.File {
+		fd, err := file.Open()
+		assert.NoError(self.T(), err)
+		members[file.Name], _ = io.ReadAll(fd)
+		fd.Close()
+	}
+
+	assert.Equal(self.T(), 2, len(manifest.Members))
+	for _, member := range manifest.Members {
+		data, pres := members[member.Name]
+		assert.True(self.T(), pres, member.Name)
+
+		hash := sha256.Sum256(data)
+		assert.Equal(self.T(), hex.EncodeToString(hash[:]), member.SHA256)
+	}
+
+	result := manifest.Members[1]
+	assert.Equal(self.T(), artifact, result.Artifact)
+	assert.Equal(self.T(), int64(1), result.Rows)
+	assert.Contains(self.T(), string(members[result.Name]), `"Hostname":"MyHost"`)
+	assert.Contains(self.T(), string(members["manifest.json"]), result.SHA256)
+
+	// The manifest is recorded locally.
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+	_, err = file_store_factory.StatFile(
+		paths.NewHuntPathManager("H.1").ArchiveManifest())
+	assert.NoError(self.T(), err)
+}
+
+func (self *HuntArchiveTestSuite) TestScanHunts() {
+	now := time.Unix(1600000000, 0)
+	closer := utils.MockTime(&utils.MockClock{MockNow: now})
+	defer closer()
+
+	expires := uint64(now.Add(-time.Hour).UnixNano() / 1000)
+
+	// Expired an hour ago.
+	self.addHunt(&api_proto.Hunt{
+		HuntId:          "H.expired",
+		State:           api_proto.Hunt_RUNNING,
+		Expires:         expires,
+		ArtifactSources: []string{artifact},
+	})
+
+	// Expired recently - wait for late collections.
+	self.addHunt(&api_proto.Hunt{
+		HuntId:          "H.recent",
+		State:           api_proto.Hunt_RUNNING,
+		Expires:         uint64(now.Add(-time.Minute).UnixNano() / 1000),
+		ArtifactSources: []string{artifact},
+	})
+
+	// Still running.
+	self.addHunt(&api_proto.Hunt{
+		HuntId:          "H.running",
+		State:           api_proto.Hunt_RUNNING,
+		Expires:         uint64(now.Add(time.Hour).UnixNano() / 1000),
+		ArtifactSources: []string{artifact},
+	})
+
+	service, err := hunt_archive.NewHuntArchiveService(
+		self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	assert.NoError(self.T(), service.ScanHunts(self.Ctx))
+
+	objects := self.getObjects()
+	assert.Equal(self.T(), 2, len(objects))
+	_, pres := objects["/bucket/hunts/H.expired.zip"]
+	assert.True(self.T(), pres)
+
+	// Archived hunts are not uploaded again.
+	self.mu.Lock()
+	self.objects = make(map[string][]byte)
+	self.mu.Unlock()
+
+	assert.NoError(self.T(), service.ScanHunts(self.Ctx))
+	assert.Equal(self.T(), 0, len(self.getObjects()))
+}
+
+func TestHuntArchive(t *testing.T) {
+	suite.Run(t, &HuntArchiveTestSuite{})
+}
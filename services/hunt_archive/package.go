@@ -0,0 +1,185 @@
+package hunt_archive
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	MANIFEST_NAME = "manifest.json"
+)
+
+// Describes a single file together with its hash.
+type ManifestEntry struct {
+	Name     string `json:"name"`
+	Artifact string `json:"artifact,omitempty"`
+	Rows     int64  `json:"rows,omitempty"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+}
+
+// The manifest is stored inside the archive and uploaded next to it
+// so the archive can be verified without opening it. Only the
+// uploaded copy describes the archive itself.
+type Manifest struct {
+	HuntId      string           `json:"hunt_id"`
+	OrgId       string           `json:"org_id,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Creator     string           `json:"creator,omitempty"`
+	CreateTime  uint64           `json:"create_time"`
+	Expires     uint64           `json:"expires"`
+	ArchiveTime int64            `json:"archive_time"`
+	Members     []*ManifestEntry `json:"members"`
+	Archive     *ManifestEntry   `json:"archive,omitempty"`
+	Locations   []string         `json:"locations,omitempty"`
+}
+
+// Counts and hashes everything written through it.
+type hashingWriter struct {
+	writer io.Writer
+	hash   hash.Hash
+	size   int64
+}
+
+func newHashingWriter(writer io.Writer) *hashingWriter {
+	return &hashingWriter{
+		writer: writer,
+		hash:   sha256.New(),
+	}
+}
+
+func (self *hashingWriter) Write(buf []byte) (int, error) {
+	n, err := self.writer.Write(buf)
+	_, _ = self.hash.Write(buf[:n])
+	self.size += int64(n)
+	return n, err
+}
+
+func (self *hashingWriter) Entry(name string) *ManifestEntry {
+	return &ManifestEntry{
+		Name:   name,
+		Size:   self.size,
+		SHA256: hex.EncodeToString(self.hash.Sum(nil)),
+	}
+}
+
+// Write the hunt's combined results into a zip file at out. Each
+// artifact source is stored as JSONL under results/ along with the
+// hunt definition and a manifest of all members.
+func packageHunt(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	hunt_obj *api_proto.Hunt, out io.Writer) (*Manifest, error) {
+
+	manifest := &Manifest{
+		HuntId:      hunt_obj.HuntId,
+		OrgId:       config_obj.OrgId,
+		Description: hunt_obj.HuntDescription,
+		Creator:     hunt_obj.Creator,
+		CreateTime:  hunt_obj.CreateTime,
+		Expires:     hunt_obj.Expires,
+		ArchiveTime: utils.GetTime().Now().Unix(),
+	}
+
+	zip_writer := zip.NewWriter(out)
+
+	serialized, err := protojson.MarshalOptions{Indent: " "}.Marshal(hunt_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := writeMember(zip_writer, "hunt.json", func(w io.Writer) error {
+		_, err := w.Write(serialized)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	manifest.Members = append(manifest.Members, entry)
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	hunt_path_manager := paths.NewHuntPathManager(hunt_obj.HuntId)
+
+	for _, artifact_source := range hunt_obj.ArtifactSources {
+		reader, err := result_sets.NewResultSetReader(file_store_factory,
+			hunt_path_manager.Results(artifact_source))
+		if err != nil {
+			// No results were merged for this source.
+			continue
+		}
+
+		entry, err := writeMember(zip_writer,
+			"results/"+utils.SanitizeString(artifact_source)+".json",
+			func(w io.Writer) error {
+				json_chan, err := reader.JSON(ctx)
+				if err != nil {
+					return err
+				}
+
+				for buf := range json_chan {
+					_, err := w.Write(buf)
+					if err != nil {
+						return err
+					}
+				}
+				return ctx.Err()
+			})
+		rows := reader.TotalRows()
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Artifact = artifact_source
+		entry.Rows = rows
+		manifest.Members = append(manifest.Members, entry)
+	}
+
+	serialized, err = json.MarshalIndent(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = writeMember(zip_writer, MANIFEST_NAME, func(w io.Writer) error {
+		_, err := w.Write(serialized)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, zip_writer.Close()
+}
+
+func writeMember(zip_writer *zip.Writer, name string,
+	cb func(w io.Writer) error) (*ManifestEntry, error) {
+	fd, err := zip_writer.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: utils.GetTime().Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	writer := newHashingWriter(fd)
+	err = cb(writer)
+	if err != nil {
+		return nil, err
+	}
+
+	return writer.Entry(name), nil
+}
@@ -0,0 +1,312 @@
+package hunt_archive
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/vql/networking"
+)
+
+const (
+	maxResponseSize = 64 * 1024
+
+	AZURE_API_VERSION = "2020-10-02"
+
+	amzDateFormat = "20060102T150405Z"
+)
+
+// An object store the archives are uploaded to. Objects are written
+// in a single request so are limited to 5GB.
+type Uploader interface {
+	// Upload size bytes from reader. The payload_hash is the hex
+	// encoded sha256 of the data. Returns the location of the
+	// object.
+	Upload(ctx context.Context, name string, reader io.Reader, size int64,
+		payload_hash, content_type string) (string, error)
+}
+
+func getUploaders(config_obj *config_proto.Config) ([]Uploader, error) {
+	var result []Uploader
+
+	archive_config := config_obj.HuntArchive
+	if archive_config.S3 != nil && archive_config.S3.Bucket != "" {
+		uploader, err := newS3Uploader(config_obj, archive_config.S3)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, uploader)
+	}
+
+	if archive_config.Azure != nil && archive_config.Azure.SasUrl != "" {
+		client, err := networking.GetDefaultHTTPClient(config_obj.Client, "")
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &azureUploader{
+			sas_url: archive_config.Azure.SasUrl,
+			client:  client,
+		})
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("HuntArchiveService: No S3 or Azure storage configured")
+	}
+
+	return result, nil
+}
+
+// Uploads objects with a PUT request signed with AWS Signature
+// Version 4.
+type s3Uploader struct {
+	config *config_proto.HuntArchiveS3Config
+	client *http.Client
+}
+
+func newS3Uploader(config_obj *config_proto.Config,
+	s3_config *config_proto.HuntArchiveS3Config) (*s3Uploader, error) {
+	if s3_config.Region == "" {
+		return nil, errors.New("HuntArchiveService: S3 region must be specified")
+	}
+
+	if s3_config.CredentialsKey == "" || s3_config.CredentialsSecret == "" {
+		return nil, errors.New("HuntArchiveService: S3 credentials must be specified")
+	}
+
+	client, err := networking.GetDefaultHTTPClient(config_obj.Client, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if s3_config.SkipVerify {
+		client = &http.Client{
+			Transport: &http.Transport{
+				Proxy:           networking.GetProxy(),
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+	}
+
+	return &s3Uploader{
+		config: s3_config,
+		client: client,
+	}, nil
+}
+
+// Custom endpoints (e.g. minio) use path style requests, otherwise
+// we use virtual hosted style requests to AWS.
+func (self *s3Uploader) objectURL(name string) (*url.URL, error) {
+	if self.config.Endpoint != "" {
+		result, err := url.Parse(self.config.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		result.Path = path.Join("/", result.Path, self.config.Bucket, name)
+		return result, nil
+	}
+
+	return &url.URL{
+		Scheme: "https",
+		Host: fmt.Sprintf("%s.s3.%s.amazonaws.com",
+			self.config.Bucket, self.config.Region),
+		Path: path.Join("/", name),
+	}, nil
+}
+
+func (self *s3Uploader) Upload(ctx context.Context,
+	name string, reader io.Reader, size int64,
+	payload_hash, content_type string) (string, error) {
+
+	object_url, err := self.objectURL(name)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPut, object_url.String(), reader)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", content_type)
+	req.Header.Set("X-Amz-Content-Sha256", payload_hash)
+	if self.config.ServerSideEncryption != "" {
+		req.Header.Set("X-Amz-Server-Side-Encryption",
+			self.config.ServerSideEncryption)
+	}
+
+	signS3Request(req, self.config.CredentialsKey,
+		self.config.CredentialsSecret, self.config.Region,
+		payload_hash, time.Now().UTC())
+
+	err = doRequest(self.client, req)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("s3://%s/%s", self.config.Bucket, name), nil
+}
+
+// Sign the request by setting the Authorization header. All the
+// x-amz-* headers, the host and the content type are signed.
+func signS3Request(req *http.Request,
+	key, secret, region, payload_hash string, now time.Time) {
+
+	amz_date := req.Header.Get("X-Amz-Date")
+	if amz_date == "" {
+		amz_date = now.Format(amzDateFormat)
+		req.Header.Set("X-Amz-Date", amz_date)
+	}
+	date := amz_date[:8]
+
+	headers := map[string]string{
+		"host": req.URL.Host,
+	}
+	for k, v := range req.Header {
+		k = strings.ToLower(k)
+		if strings.HasPrefix(k, "x-amz-") || k == "content-type" ||
+			k == "content-md5" || k == "date" {
+			headers[k] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+
+	header_names := make([]string, 0, len(headers))
+	for k := range headers {
+		header_names = append(header_names, k)
+	}
+	sort.Strings(header_names)
+
+	canonical_headers := &strings.Builder{}
+	for _, k := range header_names {
+		canonical_headers.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signed_headers := strings.Join(header_names, ";")
+
+	canonical_request := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		canonicalQuery(req.URL.Query()),
+		canonical_headers.String(),
+		signed_headers,
+		payload_hash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	string_to_sign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amz_date,
+		scope,
+		sha256Hex([]byte(canonical_request)),
+	}, "\n")
+
+	signing_key := hmacSHA256([]byte("AWS4"+secret), date)
+	signing_key = hmacSHA256(signing_key, region)
+	signing_key = hmacSHA256(signing_key, "s3")
+	signing_key = hmacSHA256(signing_key, "aws4_request")
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		key, scope, signed_headers,
+		hex.EncodeToString(hmacSHA256(signing_key, string_to_sign))))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// S3 escapes everything except the unreserved characters. Unlike
+// other services the path is only escaped once.
+func s3EscapePath(name string) string {
+	result := &strings.Builder{}
+	for _, c := range []byte(name) {
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+			(c >= '0' && c <= '9') {
+			result.WriteByte(c)
+		} else {
+			fmt.Fprintf(result, "%%%02X", c)
+		}
+	}
+	return result.String()
+}
+
+func canonicalQuery(query url.Values) string {
+	// url.Values.Encode() sorts by key but escapes spaces as +
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+// Uploads blobs with a single Put Blob request authorized by a
+// container SAS URL.
+type azureUploader struct {
+	sas_url string
+	client  *http.Client
+}
+
+func (self *azureUploader) Upload(ctx context.Context,
+	name string, reader io.Reader, size int64,
+	payload_hash, content_type string) (string, error) {
+
+	blob_url, err := url.Parse(self.sas_url)
+	if err != nil {
+		return "", err
+	}
+	blob_url.Path = path.Join(blob_url.Path, name)
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPut, blob_url.String(), reader)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", content_type)
+	req.Header.Set("x-ms-version", AZURE_API_VERSION)
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+
+	// Stored with the blob so it can be checked later.
+	req.Header.Set("x-ms-meta-sha256", payload_hash)
+
+	err = doRequest(self.client, req)
+	if err != nil {
+		return "", err
+	}
+
+	// Do not leak the SAS token into the manifest.
+	blob_url.RawQuery = ""
+	return blob_url.String(), nil
+}
+
+func doRequest(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		return fmt.Errorf("HuntArchiveService: %v returned %v: %v",
+			req.URL.Host, resp.Status, string(body))
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
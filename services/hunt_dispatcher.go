@@ -77,6 +77,7 @@ type IHuntDispatcher interface {
 	ModifyHunt(
 		ctx context.Context,
 		config_obj *config_proto.Config,
+		acl_manager vql_subsystem.ACLManager,
 		hunt_modification *api_proto.Hunt,
 		user string) error
 
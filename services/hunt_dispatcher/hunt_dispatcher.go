@@ -66,7 +66,10 @@ import (
 	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/paths"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/approvals"
 	"www.velocidex.com/golang/velociraptor/services/journal"
+	"www.velocidex.com/golang/velociraptor/services/launcher"
+	"www.velocidex.com/golang/velociraptor/services/quotas"
 	"www.velocidex.com/golang/velociraptor/utils"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 )
@@ -77,6 +80,21 @@ var (
 		Help: "Last timestamp of most recent hunt.",
 	})
 
+	dispatcherHuntsByState = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hunt_dispatcher_hunts",
+			Help: "Number of hunts known to the dispatcher in each state.",
+		},
+		[]string{"org", "state"},
+	)
+
+	dispatcherRefreshLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "hunt_dispatcher_refresh_latency",
+			Help:    "Latency to refresh hunts from the datastore in seconds.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 4, 8),
+		})
+
 	Clock utils.Clock = &utils.RealClock{}
 )
 
@@ -398,6 +416,9 @@ func (self *HuntDispatcher) Close(config_obj *config_proto.Config) {
 // Check for new hunts from the datastore. The master frontend will
 // also flush updated hunt records to the datastore.
 func (self *HuntDispatcher) Refresh(config_obj *config_proto.Config) error {
+	timer := prometheus.NewTimer(dispatcherRefreshLatency)
+	defer timer.ObserveDuration()
+
 	// Now read all the data again from the data store.
 	db, err := datastore.GetDB(config_obj)
 	if err != nil {
@@ -464,9 +485,38 @@ func (self *HuntDispatcher) Refresh(config_obj *config_proto.Config) error {
 
 		self.hunts[hunt_id] = &HuntRecord{Hunt: hunt_obj}
 	}
+
+	self.updateStateMetrics(config_obj)
 	return nil
 }
 
+// Export the number of hunts in each state. Called under lock.
+func (self *HuntDispatcher) updateStateMetrics(config_obj *config_proto.Config) {
+	counts := make(map[api_proto.Hunt_State]int)
+	for _, hunt_obj := range self.hunts {
+		counts[hunt_obj.State]++
+	}
+
+	org_id := utils.NormalizedOrgId(config_obj.OrgId)
+	for state := range api_proto.Hunt_State_name {
+		hunt_state := api_proto.Hunt_State(state)
+		dispatcherHuntsByState.WithLabelValues(org_id, hunt_state.String()).
+			Set(float64(counts[hunt_state]))
+	}
+}
+
+// Count the running hunts, other than the specified hunt.
+func (self *HuntDispatcher) countRunningHunts(hunt_id string) uint64 {
+	var result uint64
+	_ = self.ApplyFuncOnHunts(func(hunt *api_proto.Hunt) error {
+		if hunt.HuntId != hunt_id && hunt.State == api_proto.Hunt_RUNNING {
+			result++
+		}
+		return nil
+	})
+	return result
+}
+
 func (self *HuntDispatcher) CreateHunt(
 	ctx context.Context,
 	config_obj *config_proto.Config,
@@ -489,6 +539,16 @@ func (self *HuntDispatcher) CreateHunt(
 		return "", errors.New("No artifacts to collect.")
 	}
 
+	err = launcher.CheckArtifactScope(acl_manager, hunt.StartRequest.Artifacts)
+	if err != nil {
+		return "", err
+	}
+
+	err = launcher.CheckHuntScope(acl_manager, hunt)
+	if err != nil {
+		return "", err
+	}
+
 	hunt.CreateTime = uint64(time.Now().UTC().UnixNano() / 1000)
 	if hunt.Expires == 0 {
 		default_expiry := config_obj.Defaults.HuntExpiryHours
@@ -525,6 +585,12 @@ func (self *HuntDispatcher) CreateHunt(
 		return "", err
 	}
 
+	err = approvals.CheckApproval(ctx, config_obj, acl_manager, repository,
+		hunt.StartRequest)
+	if err != nil {
+		return "", err
+	}
+
 	// Compile the start request and store it in the hunt. We will
 	// use this compiled version to launch all other flows from
 	// this hunt rather than re-compile the artifact each
@@ -556,6 +622,11 @@ func (self *HuntDispatcher) CreateHunt(
 		// IF we are creating the hunt in the running state
 		// set it started.
 	} else if hunt.State == api_proto.Hunt_RUNNING {
+		err = quotas.CheckHuntQuota(
+			config_obj, self.countRunningHunts(hunt.HuntId))
+		if err != nil {
+			return "", err
+		}
 		hunt.StartTime = hunt.CreateTime
 	}
 
@@ -673,4 +744,7 @@ func GetNewHuntId() string {
 
 func init() {
 	json.RegisterCustomEncoder(&api_proto.Hunt{}, json.MarshalHuntProtobuf)
+
+	// Mutations may carry a hunt condition which is a oneof.
+	json.RegisterCustomEncoder(&api_proto.HuntMutation{}, json.MarshalHuntProtobuf)
 }
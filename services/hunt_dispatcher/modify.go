@@ -2,23 +2,32 @@ package hunt_dispatcher
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/Velocidex/ordereddict"
 	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/launcher"
+	"www.velocidex.com/golang/velociraptor/services/quotas"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 )
 
 // This method modifies the hunt. Only the following modifications are allowed:
 
 // 1. A hunt in the paused state can go to the running state. This
 //    will update the StartTime.
-// 2. A hunt in the running state can go to the Stop state
+// 2. A hunt in the running state can go to the Stop or Paused state.
+//    A paused hunt does not schedule new clients.
 // 3. A hunt's description can be modified.
+// 4. A hunt's condition can be modified. Clients already scheduled
+//    are not affected but the new condition applies to all clients
+//    seen from now on.
 func (self *HuntDispatcher) ModifyHunt(
 	ctx context.Context,
 	config_obj *config_proto.Config,
+	acl_manager vql_subsystem.ACLManager,
 	hunt_modification *api_proto.Hunt,
 	user string) error {
 
@@ -67,13 +76,51 @@ func (self *HuntDispatcher) ModifyHunt(
 		// not). Usually the most reliable way
 		// to re-do a hunt is to copy it and
 		// do it again.
+		err := quotas.CheckHuntQuota(config_obj,
+			self.countRunningHunts(hunt_modification.HuntId))
+		if err != nil {
+			return err
+		}
+
 		mutation.State = api_proto.Hunt_RUNNING
 		mutation.StartTime = uint64(time.Now().UnixNano() / 1000)
 
-		// We are trying to pause or stop the hunt.
-	} else if hunt_modification.State == api_proto.Hunt_STOPPED ||
-		hunt_modification.State == api_proto.Hunt_PAUSED {
+		// Retarget the hunt.
+	} else if hunt_modification.Condition != nil {
+		// A scoped principal may only retarget hunts they could have
+		// created with the new condition.
+		hunt_obj, pres := self.GetHunt(hunt_modification.HuntId)
+		if !pres {
+			return fmt.Errorf("Hunt %v not found", hunt_modification.HuntId)
+		}
+
+		err := launcher.CheckArtifactScope(
+			acl_manager, hunt_obj.StartRequest.GetArtifacts())
+		if err != nil {
+			return err
+		}
+
+		err = launcher.CheckHuntScope(acl_manager, &api_proto.Hunt{
+			Condition: hunt_modification.Condition,
+		})
+		if err != nil {
+			return err
+		}
+
+		mutation.Condition = hunt_modification.Condition
+
+		// Updating the start time makes the foreman offer the hunt
+		// again to all clients so the new condition can be checked
+		// on them. Clients that already ran the hunt are skipped.
+		mutation.StartTime = uint64(time.Now().UnixNano() / 1000)
+
+		// We are trying to stop the hunt.
+	} else if hunt_modification.State == api_proto.Hunt_STOPPED {
 		mutation.State = api_proto.Hunt_STOPPED
+
+		// We are trying to pause the hunt.
+	} else if hunt_modification.State == api_proto.Hunt_PAUSED {
+		mutation.State = api_proto.Hunt_PAUSED
 	}
 
 	return self.MutateHunt(config_obj, mutation)
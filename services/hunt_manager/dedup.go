@@ -0,0 +1,202 @@
+package hunt_manager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// A collection recently scheduled by a hunt.
+type dedupEntry struct {
+	hunt_id string
+	flow_id string
+	time    time.Time
+}
+
+// Hunts collecting the same artifacts with the same parameters on a
+// client within the dedup window share a single collection. The
+// first hunt schedules the flow as usual, later hunts are linked to
+// it and receive its completion as if they had scheduled it.
+//
+// Both the recent collections and the links are only kept in
+// memory. Links are lost when the server restarts before the shared
+// collection completes - the linked hunt still lists the flow but
+// its stats and merged results will not include it.
+type huntDeduplicator struct {
+	mu sync.Mutex
+
+	// Recent collections keyed by client id and request
+	// fingerprint.
+	recent map[string]*dedupEntry
+
+	// Hunts waiting for a collection scheduled by another hunt to
+	// complete, keyed by client id and flow id.
+	links map[string][]string
+}
+
+func newHuntDeduplicator() *huntDeduplicator {
+	return &huntDeduplicator{
+		recent: make(map[string]*dedupEntry),
+		links:  make(map[string][]string),
+	}
+}
+
+// Find a collection of the same request scheduled by another hunt
+// within the window.
+func (self *huntDeduplicator) Get(
+	client_id, fingerprint string, window time.Duration) (*dedupEntry, bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	key := client_id + "/" + fingerprint
+	entry, pres := self.recent[key]
+	if !pres {
+		return nil, false
+	}
+
+	if utils.GetTime().Now().Sub(entry.time) > window {
+		delete(self.recent, key)
+		return nil, false
+	}
+
+	return entry, true
+}
+
+func (self *huntDeduplicator) Set(
+	client_id, fingerprint, hunt_id, flow_id string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.recent[client_id+"/"+fingerprint] = &dedupEntry{
+		hunt_id: hunt_id,
+		flow_id: flow_id,
+		time:    utils.GetTime().Now(),
+	}
+}
+
+// Link the hunt to the completion of the flow.
+func (self *huntDeduplicator) Link(client_id, flow_id, hunt_id string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	key := client_id + "/" + flow_id
+	self.links[key] = append(self.links[key], hunt_id)
+}
+
+// Remove the link between the hunt and the flow. Returns false if
+// the link was already taken by the flow's completion.
+func (self *huntDeduplicator) Unlink(client_id, flow_id, hunt_id string) bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	key := client_id + "/" + flow_id
+	hunts := self.links[key]
+	for i, linked := range hunts {
+		if linked == hunt_id {
+			hunts = append(hunts[:i], hunts[i+1:]...)
+			if len(hunts) == 0 {
+				delete(self.links, key)
+			} else {
+				self.links[key] = hunts
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Take all the hunts linked to the flow.
+func (self *huntDeduplicator) TakeLinks(client_id, flow_id string) []string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	key := client_id + "/" + flow_id
+	hunts := self.links[key]
+	delete(self.links, key)
+	return hunts
+}
+
+// Drop recent collections which are outside the window.
+func (self *huntDeduplicator) expire(window time.Duration) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	now := utils.GetTime().Now()
+	for key, entry := range self.recent {
+		if now.Sub(entry.time) > window {
+			delete(self.recent, key)
+		}
+	}
+}
+
+func (self *HuntManager) startDeduplicator(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	wg *sync.WaitGroup) {
+
+	window := getDedupWindow(config_obj)
+	if window == 0 {
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(time.Minute):
+				self.dedup.expire(window)
+			}
+		}
+	}()
+}
+
+func getDedupWindow(config_obj *config_proto.Config) time.Duration {
+	if config_obj.Frontend == nil || config_obj.Frontend.Resources == nil {
+		return 0
+	}
+	return time.Duration(
+		config_obj.Frontend.Resources.HuntDedupWindow) * time.Second
+}
+
+// Two requests with the same fingerprint collect the same data from
+// the client. The order of artifacts and parameters does not matter.
+func requestFingerprint(request *flows_proto.ArtifactCollectorArgs) string {
+	request = proto.Clone(request).(*flows_proto.ArtifactCollectorArgs)
+
+	// These do not change what is collected.
+	request.ClientId = ""
+	request.Creator = ""
+	request.Urgent = false
+	request.CompiledCollectorArgs = nil
+
+	sort.Strings(request.Artifacts)
+	sort.Slice(request.Specs, func(i, j int) bool {
+		return request.Specs[i].Artifact < request.Specs[j].Artifact
+	})
+	for _, spec := range request.Specs {
+		if spec.Parameters == nil {
+			continue
+		}
+		env := spec.Parameters.Env
+		sort.Slice(env, func(i, j int) bool {
+			return env[i].Key < env[j].Key
+		})
+	}
+
+	serialized, _ := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	hash := sha256.Sum256(serialized)
+	return hex.EncodeToString(hash[:])
+}
@@ -37,7 +37,9 @@
    schedules collection on the client.
 
 4) Hunt manager watches for flow completions and updates hunt stats re
-   success or error of flow completion.
+   success or error of flow completion. The results of the flow are
+   merged into the hunt's result sets by a pool of post processing
+   workers.
 
 Note that steps 1 & 2 are on the critical path (and may be on a minion
 frontend) and 3-4 are run on the master node.
@@ -64,6 +66,7 @@ import (
 	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/paths"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/enrollment"
 	"www.velocidex.com/golang/velociraptor/services/journal"
 	"www.velocidex.com/golang/velociraptor/utils"
 	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
@@ -95,6 +98,19 @@ type HuntManager struct {
 	// Limits how quickly we schedule hunts. Should be fast enough
 	// to be reasoable without overloading frontends
 	limiter *rate.Limiter
+
+	// Queues clients for hunts with their own rate cap.
+	scheduler *huntScheduler
+
+	// Merges the results of completed collections into the hunt's
+	// result sets.
+	post_processor *huntPostProcessor
+
+	// Links hunts to identical collections scheduled by other hunts.
+	dedup *huntDeduplicator
+
+	// Reschedules failed collections of hunts with a retry policy.
+	retrier *huntRetrier
 }
 
 func (self *HuntManager) Start(
@@ -139,7 +155,20 @@ func (self *HuntManager) Start(
 	err = journal.WatchQueueWithCB(ctx, config_obj, wg,
 		"System.Flow.Completion", "HuntManager",
 		self.ProcessFlowCompletion)
-	return err
+	if err != nil {
+		return err
+	}
+
+	err = self.loadPendingRetries(config_obj)
+	if err != nil {
+		logger.Error("hunt_manager: loading pending retries: %v", err)
+	}
+
+	self.startScheduler(ctx, config_obj, wg)
+	self.post_processor.Start(ctx, config_obj, wg)
+	self.startDeduplicator(ctx, config_obj, wg)
+
+	return nil
 }
 
 // Modify a hunt object.
@@ -212,18 +241,30 @@ func (self *HuntManager) processMutation(
 				modification = services.HuntFlushToDatastoreAsync
 			}
 
+			if mutation.Stats.TotalRetries > 0 {
+				hunt_obj.Stats.TotalRetries += mutation.Stats.TotalRetries
+
+				modification = services.HuntFlushToDatastoreAsync
+			}
+
 			// These modifications affect the state of the hunt and so
 			// need to propagate to all minions
 			// immediately. Eventually they will also hit the
 			// filesystem too.
-			if mutation.State == api_proto.Hunt_STOPPED ||
-				mutation.State == api_proto.Hunt_PAUSED {
+			if mutation.State == api_proto.Hunt_STOPPED {
 				hunt_obj.Stats.Stopped = true
 				hunt_obj.State = api_proto.Hunt_STOPPED
 
 				// Let all dispatchers know this hunt is stopped.
 				modification = services.HuntPropagateChanges
 
+				// A paused hunt does not schedule new clients but
+				// may be started again.
+			} else if mutation.State == api_proto.Hunt_PAUSED {
+				hunt_obj.State = api_proto.Hunt_PAUSED
+
+				modification = services.HuntPropagateChanges
+
 			} else if mutation.State == api_proto.Hunt_RUNNING {
 				hunt_obj.Stats.Stopped = false
 				hunt_obj.State = api_proto.Hunt_RUNNING
@@ -244,6 +285,16 @@ func (self *HuntManager) processMutation(
 				modification = services.HuntPropagateChanges
 			}
 
+			// The hunt is retargeted - clients waiting for their wave
+			// will be checked against the new condition when they
+			// participate again.
+			if mutation.Condition != nil {
+				hunt_obj.Condition = mutation.Condition
+				self.scheduler.drop(hunt_obj.HuntId)
+
+				modification = services.HuntPropagateChanges
+			}
+
 			// Hunt is restarted, notify all connected clients
 			if mutation.StartTime > 0 {
 				hunt_obj.StartTime = mutation.StartTime
@@ -323,10 +374,11 @@ func (self *HuntManager) ProcessInterrogation(
 
 	return self.participateInAllHunts(ctx, config_obj, client_id,
 		// When a new client is interrogated, it can only really
-		// affect hunts with OS conditions.
+		// affect hunts with OS or client group conditions.
 		func(hunt *api_proto.Hunt) bool {
 			return hunt.Condition != nil &&
-				hunt.Condition.GetOs() != nil
+				(hunt.Condition.GetOs() != nil ||
+					hunt.Condition.GetGroups() != nil)
 		})
 }
 
@@ -349,6 +401,36 @@ func (self *HuntManager) ProcessFlowCompletion(
 		return nil
 	}
 
+	err = self.processHuntCompletion(ctx, config_obj, hunt_id, flow)
+	if err != nil {
+		return err
+	}
+
+	// Other hunts may be sharing this collection.
+	for _, linked_hunt_id := range self.dedup.TakeLinks(
+		flow.ClientId, flow.SessionId) {
+		err = self.processHuntCompletion(ctx, config_obj, linked_hunt_id, flow)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Add the completed flow to the hunt's stats and results.
+func (self *HuntManager) processHuntCompletion(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	hunt_id string, flow *flows_proto.ArtifactCollectorContext) error {
+
+	// A failed collection which will be retried is not complete
+	// yet. Its retry's completion is counted instead.
+	retrying, err := self.maybeRetry(config_obj, hunt_id, flow)
+	if err != nil || retrying {
+		return err
+	}
+
 	// Flow is complete so add it to the hunt stats. We send a
 	// mutation to the hunt dispatcher to mediate internal hunt state
 	// manipulation.
@@ -374,6 +456,15 @@ func (self *HuntManager) ProcessFlowCompletion(
 		return err
 	}
 
+	err = self.checkErrorRate(config_obj, hunt_id)
+	if err != nil {
+		return err
+	}
+
+	// Merging the results is slow for large collections so it
+	// happens in the background.
+	self.post_processor.Submit(ctx, hunt_id, flow)
+
 	journal, err := services.GetJournal(config_obj)
 	if err != nil {
 		return err
@@ -410,10 +501,11 @@ func (self *HuntManager) ProcessLabelChange(
 
 	return self.participateInAllHunts(ctx, config_obj, client_id,
 		// When a label changes it can only really affect hunts with
-		// include label conditions.
+		// include label or client group conditions.
 		func(hunt *api_proto.Hunt) bool {
 			return hunt.Condition != nil &&
-				hunt.Condition.GetLabels() != nil
+				(hunt.Condition.GetLabels() != nil ||
+					hunt.Condition.GetGroups() != nil)
 		})
 }
 
@@ -498,10 +590,17 @@ func (self *HuntManager) ProcessParticipation(
 		return fmt.Errorf("Hunt %v not known", participation_row.HuntId)
 	}
 
+	// The client will participate once its enrollment is approved.
+	if !enrollment.IsApproved(config_obj, participation_row.ClientId) {
+		return fmt.Errorf("Hunt %v: %v is not approved yet",
+			participation_row.HuntId, participation_row.ClientId)
+	}
+
 	// The event may override the regular hunt logic.
 	if participation_row.Override {
-		return scheduleHuntOnClient(ctx, config_obj,
+		_, err := scheduleHuntOnClient(ctx, config_obj,
 			hunt_obj, participation_row.ClientId)
+		return err
 	}
 
 	// Ignore stopped hunts.
@@ -522,13 +621,15 @@ func (self *HuntManager) ProcessParticipation(
 		participation_row.ClientId) {
 		return fmt.Errorf("Hunt %v: hunt label does not match with %v",
 			participation_row.HuntId, participation_row.ClientId)
+
+	} else if !huntMatchesGroup(ctx, config_obj, hunt_obj,
+		participation_row.ClientId) {
+		return fmt.Errorf("Hunt %v: %v is not in the hunt's client groups",
+			participation_row.HuntId, participation_row.ClientId)
 	}
 
 	// Hunt limit exceeded or it expired - we stop it.
-	now := uint64(time.Now().UnixNano() / 1000)
-	if (hunt_obj.ClientLimit > 0 &&
-		hunt_obj.Stats.TotalClientsScheduled >= hunt_obj.ClientLimit) ||
-		now > hunt_obj.Expires {
+	if huntLimitReached(hunt_obj, 0) {
 
 		// Hunt is expired, stop the hunt.
 		return dispatcher.MutateHunt(config_obj,
@@ -537,11 +638,18 @@ func (self *HuntManager) ProcessParticipation(
 				Stats:  &api_proto.HuntStats{Stopped: true}})
 	}
 
+	// The hunt is rate capped - the scheduler will launch the flow
+	// when the client's wave comes up.
+	if hunt_obj.Scheduling != nil && hunt_obj.Scheduling.ClientsPerMinute > 0 {
+		self.scheduler.Queue(hunt_obj, participation_row.ClientId)
+		return nil
+	}
+
 	// Use hunt information to launch the flow against this
 	// client.
 	self.limiter.Wait(ctx)
 
-	return scheduleHuntOnClient(ctx,
+	return self.scheduleHunt(ctx,
 		config_obj, hunt_obj, participation_row.ClientId)
 }
 
@@ -558,6 +666,10 @@ func NewHuntManager(
 	result := &HuntManager{
 		limiter: rate.NewLimiter(rate.Limit(
 			config_obj.Frontend.Resources.NotificationsPerSecond), 1),
+		scheduler:      newHuntScheduler(),
+		post_processor: newHuntPostProcessor(),
+		dedup:          newHuntDeduplicator(),
+		retrier:        newHuntRetrier(),
 		scope: manager.BuildScope(
 			services.ScopeBuilder{
 				Config: config_obj,
@@ -619,6 +731,37 @@ func huntHasExcludeLabel(
 	return true
 }
 
+// Check if the client is a member of any of the hunt's client
+// groups.
+func huntMatchesGroup(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	hunt_obj *api_proto.Hunt, client_id string) bool {
+
+	if hunt_obj.Condition == nil {
+		return true
+	}
+
+	group_condition := hunt_obj.Condition.GetGroups()
+	if group_condition == nil {
+		return true
+	}
+
+	indexer, err := services.GetIndexer(config_obj)
+	if err != nil {
+		return false
+	}
+
+	for _, group := range group_condition.Group {
+		member, err := indexer.IsClientInGroup(ctx, config_obj, client_id, group)
+		if err == nil && member {
+			return true
+		}
+	}
+
+	return false
+}
+
 func huntMatchesOS(hunt_obj *api_proto.Hunt, client_info *services.ClientInfo) bool {
 	if hunt_obj.Condition == nil {
 		return true
@@ -684,26 +827,131 @@ func setHuntRanOnClient(config_obj *config_proto.Config,
 	return nil
 }
 
-func scheduleHuntOnClient(
+// Schedule the hunt on the client unless another hunt recently
+// collected the same request from it, in which case the hunt is
+// linked to that collection.
+func (self *HuntManager) scheduleHunt(
 	ctx context.Context,
 	config_obj *config_proto.Config,
 	hunt_obj *api_proto.Hunt, client_id string) error {
 
+	window := getDedupWindow(config_obj)
+	if window == 0 || hunt_obj.StartRequest == nil {
+		_, err := scheduleHuntOnClient(ctx, config_obj, hunt_obj, client_id)
+		return err
+	}
+
+	fingerprint := requestFingerprint(hunt_obj.StartRequest)
+	entry, pres := self.dedup.Get(client_id, fingerprint, window)
+	if pres && entry.hunt_id != hunt_obj.HuntId {
+		err := self.linkHuntToFlow(ctx, config_obj,
+			hunt_obj.HuntId, client_id, entry.flow_id)
+		if err == nil {
+			logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+			logger.Info("hunt_manager: Linked hunt %v to collection %v "+
+				"of hunt %v on %v", hunt_obj.HuntId, entry.flow_id,
+				entry.hunt_id, client_id)
+			return nil
+		}
+
+		// The collection is gone - schedule a new one.
+		if !errors.Is(err, errFlowNotFound) {
+			return err
+		}
+	}
+
+	flow_id, err := scheduleHuntOnClient(ctx, config_obj, hunt_obj, client_id)
+	if err != nil {
+		return err
+	}
+
+	self.dedup.Set(client_id, fingerprint, hunt_obj.HuntId, flow_id)
+	return nil
+}
+
+var errFlowNotFound = errors.New("Flow not found")
+
+// Add an existing flow to the hunt. The hunt receives the flow's
+// completion when it finishes.
+func (self *HuntManager) linkHuntToFlow(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	hunt_id, client_id, flow_id string) error {
+
+	launcher, err := services.GetLauncher(config_obj)
+	if err != nil {
+		return err
+	}
+
+	_, err = launcher.GetFlowDetails(config_obj, client_id, flow_id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errFlowNotFound, err)
+	}
+
+	// Link before checking the flow state so the completion is
+	// not missed if the flow completes in the mean time.
+	self.dedup.Link(client_id, flow_id, hunt_id)
+
+	err = addFlowToHunt(config_obj, hunt_id, client_id, flow_id)
+	if err != nil {
+		self.dedup.Unlink(client_id, flow_id, hunt_id)
+		return err
+	}
+
+	flow_details, err := launcher.GetFlowDetails(config_obj, client_id, flow_id)
+	if err != nil {
+		return err
+	}
+
+	// The flow already completed so there will not be another
+	// completion message for it.
+	collection_context := flow_details.Context
+	if collection_context.State != flows_proto.ArtifactCollectorContext_FINISHED &&
+		collection_context.State != flows_proto.ArtifactCollectorContext_ERROR {
+		return nil
+	}
+
+	if !self.dedup.Unlink(client_id, flow_id, hunt_id) {
+		return nil
+	}
+
+	return self.processHuntCompletion(ctx, config_obj, hunt_id, collection_context)
+}
+
+func scheduleHuntOnClient(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	hunt_obj *api_proto.Hunt, client_id string) (string, error) {
+
+	flow_id, err := launchHuntFlow(ctx, config_obj, hunt_obj, client_id)
+	if err != nil {
+		return "", err
+	}
+
+	return flow_id, addFlowToHunt(config_obj, hunt_obj.HuntId, client_id, flow_id)
+}
+
+// Schedule the hunt's collection on the client.
+func launchHuntFlow(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	hunt_obj *api_proto.Hunt, client_id string) (string, error) {
+
 	hunt_id := hunt_obj.HuntId
 
 	manager, err := services.GetRepositoryManager(config_obj)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	repository, err := manager.GetGlobalRepository(config_obj)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	launcher, err := services.GetLauncher(config_obj)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// The request is pre-compiled into the hunt object.
@@ -716,29 +964,17 @@ func scheduleHuntOnClient(
 	// track it.
 	request.Creator = hunt_id
 
-	flow_id, err := launcher.ScheduleArtifactCollection(
+	return launcher.ScheduleArtifactCollection(
 		ctx, config_obj, acl_managers.NullACLManager{},
 		repository, request, nil)
-	if err != nil {
-		return err
-	}
-
-	journal, err := services.GetJournal(config_obj)
-	if err != nil {
-		return err
-	}
+}
 
-	// Append the row to the hunt so we can quickly query all
-	// clients that belong on this hunt and their flow id.
-	row := ordereddict.NewDict().
-		Set("HuntId", hunt_id).
-		Set("ClientId", client_id).
-		Set("FlowId", flow_id).
-		Set("Timestamp", time.Now().Unix())
+// Record the flow as the hunt's collection on the client.
+func addFlowToHunt(
+	config_obj *config_proto.Config,
+	hunt_id, client_id, flow_id string) error {
 
-	path_manager := paths.NewHuntPathManager(hunt_id)
-	err = journal.AppendToResultSet(config_obj,
-		path_manager.Clients(), []*ordereddict.Dict{row})
+	err := appendHuntFlow(config_obj, hunt_id, client_id, flow_id)
 	if err != nil {
 		return err
 	}
@@ -765,3 +1001,26 @@ func scheduleHuntOnClient(
 
 	return nil
 }
+
+// Append the flow to the hunt's clients.
+func appendHuntFlow(
+	config_obj *config_proto.Config,
+	hunt_id, client_id, flow_id string) error {
+
+	journal, err := services.GetJournal(config_obj)
+	if err != nil {
+		return err
+	}
+
+	// Append the row to the hunt so we can quickly query all
+	// clients that belong on this hunt and their flow id.
+	row := ordereddict.NewDict().
+		Set("HuntId", hunt_id).
+		Set("ClientId", client_id).
+		Set("FlowId", flow_id).
+		Set("Timestamp", time.Now().Unix())
+
+	path_manager := paths.NewHuntPathManager(hunt_id)
+	return journal.AppendToResultSet(config_obj,
+		path_manager.Clients(), []*ordereddict.Dict{row})
+}
@@ -3,6 +3,7 @@ package hunt_manager_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -10,16 +11,24 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/protobuf/proto"
+	"www.velocidex.com/golang/velociraptor/acls"
+	acl_proto "www.velocidex.com/golang/velociraptor/acls/proto"
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
 	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
 	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
 	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/json"
 	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/services/hunt_manager"
+	"www.velocidex.com/golang/velociraptor/services/indexing"
+	"www.velocidex.com/golang/velociraptor/utils"
 	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
 	"www.velocidex.com/golang/velociraptor/vtesting"
 
@@ -548,6 +557,89 @@ func (self *HuntTestSuite) TestHuntClientOSCondition() {
 	assert.Error(t, err)
 }
 
+func (self *HuntTestSuite) TestHuntClientGroupCondition() {
+	t := self.T()
+
+	launcher, err := services.GetLauncher(self.ConfigObj)
+	assert.NoError(t, err)
+
+	launcher.SetFlowIdForTests("F.1234")
+
+	err = indexing.SetClientGroup(self.ConfigObj, &api_proto.ClientGroup{
+		Name:  "Windows Hosts",
+		Query: "os:windows host:MyHost*",
+	})
+	assert.NoError(t, err)
+
+	hunt_obj := &api_proto.Hunt{
+		HuntId:       self.hunt_id,
+		StartRequest: self.expected,
+		State:        api_proto.Hunt_RUNNING,
+		Stats:        &api_proto.HuntStats{},
+		Expires:      uint64(time.Now().Add(7*24*time.Hour).UTC().UnixNano() / 1000),
+		Condition: &api_proto.HuntCondition{
+			UnionField: &api_proto.HuntCondition_Groups{
+				Groups: &api_proto.HuntGroupCondition{
+					Group: []string{"Windows Hosts"},
+				},
+			},
+		},
+	}
+
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(t, err)
+
+	// Only the first client is in the group.
+	client_id_1 := "C.12331"
+	client_id_2 := "C.12332"
+
+	client_path_manager := paths.NewClientPathManager(client_id_1)
+	err = db.SetSubject(self.ConfigObj,
+		client_path_manager.Path(), &actions_proto.ClientInfo{
+			System:   "windows",
+			Hostname: "MyHost1",
+		})
+	assert.NoError(t, err)
+
+	client_path_manager = paths.NewClientPathManager(client_id_2)
+	err = db.SetSubject(self.ConfigObj,
+		client_path_manager.Path(), &actions_proto.ClientInfo{
+			System:   "windows",
+			Hostname: "OtherHost",
+		})
+	assert.NoError(t, err)
+
+	hunt_path_manager := paths.NewHuntPathManager(hunt_obj.HuntId)
+	err = db.SetSubject(self.ConfigObj, hunt_path_manager.Path(), hunt_obj)
+	assert.NoError(t, err)
+
+	hunt_dispatcher, err := services.GetHuntDispatcher(self.ConfigObj)
+	assert.NoError(t, err)
+	hunt_dispatcher.Refresh(self.ConfigObj)
+
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(t, err)
+
+	journal.PushRowsToArtifact(self.ConfigObj,
+		[]*ordereddict.Dict{
+			ordereddict.NewDict().
+				Set("HuntId", self.hunt_id).
+				Set("ClientId", client_id_1),
+			ordereddict.NewDict().
+				Set("HuntId", self.hunt_id).
+				Set("ClientId", client_id_2),
+		},
+		"System.Hunt.Participation", self.client_id, "")
+
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		_, err = LoadCollectionContext(self.ConfigObj, client_id_1, "F.1234")
+		return err == nil
+	})
+
+	_, err = LoadCollectionContext(self.ConfigObj, client_id_2, "F.1234")
+	assert.Error(t, err)
+}
+
 // When interrogating for the first time, the initial client record
 // has no OS populated so might not trigger an OS condition hunt. This
 // test ensures that after interrogating the client gets another
@@ -789,6 +881,460 @@ func (self *HuntTestSuite) TestHuntManagerErrors() {
 	})
 }
 
+// A hunt with an error rate threshold is paused when too many
+// collections fail.
+func (self *HuntTestSuite) TestHuntManagerPauseOnErrors() {
+	hunt_obj := &api_proto.Hunt{
+		HuntId:       self.hunt_id,
+		StartRequest: self.expected,
+		State:        api_proto.Hunt_RUNNING,
+		Stats:        &api_proto.HuntStats{},
+		Expires:      uint64(time.Now().Add(7*24*time.Hour).UTC().UnixNano() / 1000),
+		Scheduling: &api_proto.HuntScheduling{
+			MaxErrorRate: 50,
+			MinCompleted: 1,
+		},
+	}
+
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	hunt_path_manager := paths.NewHuntPathManager(hunt_obj.HuntId)
+	err = db.SetSubject(self.ConfigObj, hunt_path_manager.Path(), hunt_obj)
+	assert.NoError(self.T(), err)
+
+	dispatcher, err := services.GetHuntDispatcher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	dispatcher.Refresh(self.ConfigObj)
+
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	// Send an error response - collection failed.
+	flow_obj := &flows_proto.ArtifactCollectorContext{
+		Request: proto.Clone(hunt_obj.StartRequest).(*flows_proto.ArtifactCollectorArgs),
+		State:   flows_proto.ArtifactCollectorContext_ERROR,
+	}
+
+	assert.NoError(self.T(), journal.PushRowsToArtifact(self.ConfigObj,
+		[]*ordereddict.Dict{ordereddict.NewDict().
+			Set("Timestamp", time.Now().UTC().Unix()).
+			Set("Flow", flow_obj).
+			Set("FlowId", flow_obj.SessionId).
+			Set("ClientId", self.client_id),
+		}, "System.Flow.Completion", self.client_id, ""))
+
+	// The hunt is paused but not stopped.
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		h, _ := dispatcher.GetHunt(hunt_obj.HuntId)
+		return h.State == api_proto.Hunt_PAUSED
+	})
+
+	h, _ := dispatcher.GetHunt(hunt_obj.HuntId)
+	assert.False(self.T(), h.Stats.Stopped)
+}
+
+// Failed collections of a hunt with a retry policy are rescheduled
+// and only counted as errors once the retries run out.
+func (self *HuntTestSuite) TestHuntManagerRetry() {
+	hunt_obj := &api_proto.Hunt{
+		HuntId:       self.hunt_id,
+		StartRequest: self.expected,
+		State:        api_proto.Hunt_RUNNING,
+		Stats:        &api_proto.HuntStats{},
+		Expires:      uint64(time.Now().Add(7*24*time.Hour).UTC().UnixNano() / 1000),
+		Retry: &api_proto.HuntRetryPolicy{
+			MaxRetries:     1,
+			BackoffSeconds: 1,
+		},
+	}
+
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	hunt_path_manager := paths.NewHuntPathManager(hunt_obj.HuntId)
+	err = db.SetSubject(self.ConfigObj, hunt_path_manager.Path(), hunt_obj)
+	assert.NoError(self.T(), err)
+
+	dispatcher, err := services.GetHuntDispatcher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	dispatcher.Refresh(self.ConfigObj)
+
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	sendError := func(flow_id string) {
+		flow_obj := &flows_proto.ArtifactCollectorContext{
+			SessionId: flow_id,
+			ClientId:  self.client_id,
+			Request:   proto.Clone(hunt_obj.StartRequest).(*flows_proto.ArtifactCollectorArgs),
+			State:     flows_proto.ArtifactCollectorContext_ERROR,
+			Status:    "Timeout",
+		}
+
+		// The hunt manager reads the stored collection.
+		err := db.SetSubject(self.ConfigObj,
+			paths.NewFlowPathManager(self.client_id, flow_id).Path(), flow_obj)
+		assert.NoError(self.T(), err)
+
+		assert.NoError(self.T(), journal.PushRowsToArtifact(self.ConfigObj,
+			[]*ordereddict.Dict{ordereddict.NewDict().
+				Set("Timestamp", time.Now().UTC().Unix()).
+				Set("Flow", flow_obj).
+				Set("FlowId", flow_obj.SessionId).
+				Set("ClientId", self.client_id),
+			}, "System.Flow.Completion", self.client_id, ""))
+	}
+
+	// The first failure is retried rather than counted.
+	sendError("F.1")
+
+	var record *api_proto.HuntClientRetries
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		record, err = hunt_manager.GetClientRetries(
+			self.ConfigObj, hunt_obj.HuntId, self.client_id)
+		return err == nil && len(record.Attempts) == 1 &&
+			record.Attempts[0].RetryFlowId != ""
+	})
+
+	assert.Equal(self.T(), "F.1", record.Attempts[0].FlowId)
+	assert.Equal(self.T(), "Timeout", record.Attempts[0].Error)
+
+	h, _ := dispatcher.GetHunt(hunt_obj.HuntId)
+	assert.Equal(self.T(), uint64(1), h.Stats.TotalRetries)
+	assert.Equal(self.T(), uint64(0), h.Stats.TotalClientsWithErrors)
+
+	// The retry is scheduled as the hunt's collection.
+	retry_flow, err := LoadCollectionContext(self.ConfigObj,
+		self.client_id, record.Attempts[0].RetryFlowId)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), hunt_obj.HuntId, retry_flow.Request.Creator)
+
+	// The retry fails too - the client is out of retries.
+	sendError(record.Attempts[0].RetryFlowId)
+
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		h, _ := dispatcher.GetHunt(hunt_obj.HuntId)
+		return h.Stats.TotalClientsWithResults == 1 &&
+			h.Stats.TotalClientsWithErrors == 1
+	})
+
+	h, _ = dispatcher.GetHunt(hunt_obj.HuntId)
+	assert.Equal(self.T(), uint64(1), h.Stats.TotalRetries)
+}
+
+// Results of completed collections are merged into the hunt's result
+// sets in the order the collections completed.
+func (self *HuntTestSuite) TestHuntManagerMergeResults() {
+	hunt_obj := &api_proto.Hunt{
+		HuntId:       self.hunt_id,
+		StartRequest: self.expected,
+		State:        api_proto.Hunt_RUNNING,
+		Stats:        &api_proto.HuntStats{},
+		Expires:      uint64(time.Now().Add(7*24*time.Hour).UTC().UnixNano() / 1000),
+	}
+
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	hunt_path_manager := paths.NewHuntPathManager(hunt_obj.HuntId)
+	err = db.SetSubject(self.ConfigObj, hunt_path_manager.Path(), hunt_obj)
+	assert.NoError(self.T(), err)
+
+	dispatcher, err := services.GetHuntDispatcher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	dispatcher.Refresh(self.ConfigObj)
+
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	artifact := "Generic.Client.Info/BasicInformation"
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+
+	// Flows with more results take longer to merge but must still
+	// appear in completion order.
+	flow_count := 10
+	for i := 0; i < flow_count; i++ {
+		flow_id := fmt.Sprintf("F.%d", i)
+		path_manager, err := artifacts.NewArtifactPathManager(
+			self.ConfigObj, self.client_id, flow_id, artifact)
+		assert.NoError(self.T(), err)
+
+		writer, err := result_sets.NewResultSetWriter(file_store_factory,
+			path_manager.Path(), json.DefaultEncOpts(),
+			utils.SyncCompleter, result_sets.TruncateMode)
+		assert.NoError(self.T(), err)
+
+		for j := 0; j < (flow_count-i)*10; j++ {
+			writer.Write(ordereddict.NewDict().Set("Row", j))
+		}
+		writer.Close()
+	}
+
+	for i := 0; i < flow_count; i++ {
+		flow_obj := &flows_proto.ArtifactCollectorContext{
+			SessionId:            fmt.Sprintf("F.%d", i),
+			ClientId:             self.client_id,
+			Request:              proto.Clone(hunt_obj.StartRequest).(*flows_proto.ArtifactCollectorArgs),
+			ArtifactsWithResults: []string{artifact},
+			State:                flows_proto.ArtifactCollectorContext_FINISHED,
+		}
+
+		assert.NoError(self.T(), journal.PushRowsToArtifact(self.ConfigObj,
+			[]*ordereddict.Dict{ordereddict.NewDict().
+				Set("Timestamp", time.Now().UTC().Unix()).
+				Set("Flow", flow_obj).
+				Set("FlowId", flow_obj.SessionId).
+				Set("ClientId", self.client_id),
+			}, "System.Flow.Completion", self.client_id, ""))
+	}
+
+	total_rows := 0
+	for i := 0; i < flow_count; i++ {
+		total_rows += (flow_count - i) * 10
+	}
+
+	var rows []*ordereddict.Dict
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		reader, err := result_sets.NewResultSetReader(file_store_factory,
+			hunt_path_manager.Results(artifact))
+		if err != nil {
+			return false
+		}
+		defer reader.Close()
+
+		rows = nil
+		for row := range reader.Rows(self.Ctx) {
+			rows = append(rows, row)
+		}
+		return len(rows) == total_rows
+	})
+
+	// Rows of each flow are contiguous and in completion order.
+	idx := 0
+	for i := 0; i < flow_count; i++ {
+		for j := 0; j < (flow_count-i)*10; j++ {
+			flow_id, _ := rows[idx].GetString("FlowId")
+			assert.Equal(self.T(), fmt.Sprintf("F.%d", i), flow_id)
+
+			client_id, _ := rows[idx].GetString("ClientId")
+			assert.Equal(self.T(), self.client_id, client_id)
+
+			row_id, _ := rows[idx].GetInt64("Row")
+			assert.Equal(self.T(), int64(j), row_id)
+			idx++
+		}
+	}
+}
+
+// A second hunt collecting the same request on the client is linked
+// to the first hunt's collection.
+func (self *HuntTestSuite) TestHuntManagerDedup() {
+	self.ConfigObj.Frontend.Resources.HuntDedupWindow = 3600
+
+	launcher, err := services.GetLauncher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	launcher.SetFlowIdForTests("F.1234")
+
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	// Both hunts collect the same artifact.
+	hunt_ids := []string{self.hunt_id, self.hunt_id + "B"}
+	for _, hunt_id := range hunt_ids {
+		request := proto.Clone(self.expected).(*flows_proto.ArtifactCollectorArgs)
+		request.Creator = hunt_id
+
+		hunt_obj := &api_proto.Hunt{
+			HuntId:       hunt_id,
+			StartRequest: request,
+			State:        api_proto.Hunt_RUNNING,
+			Stats:        &api_proto.HuntStats{},
+			Expires:      uint64(time.Now().Add(7*24*time.Hour).UTC().UnixNano() / 1000),
+		}
+
+		hunt_path_manager := paths.NewHuntPathManager(hunt_id)
+		err = db.SetSubject(self.ConfigObj, hunt_path_manager.Path(), hunt_obj)
+		assert.NoError(self.T(), err)
+	}
+
+	dispatcher, err := services.GetHuntDispatcher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	dispatcher.Refresh(self.ConfigObj)
+
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	indexer, err := services.GetIndexer(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	for _, hunt_id := range hunt_ids {
+		journal.PushRowsToArtifact(self.ConfigObj,
+			[]*ordereddict.Dict{ordereddict.NewDict().
+				Set("HuntId", hunt_id).
+				Set("ClientId", self.client_id),
+			},
+			"System.Hunt.Participation", self.client_id, "")
+
+		vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+			err = indexer.CheckSimpleIndex(self.ConfigObj, paths.HUNT_INDEX,
+				self.client_id, []string{hunt_id})
+			return err == nil
+		})
+
+		// Any further collection would get a new flow id.
+		launcher.SetFlowIdForTests("F.5678")
+	}
+
+	// Only the first hunt scheduled a collection.
+	_, err = LoadCollectionContext(self.ConfigObj, self.client_id, "F.1234")
+	assert.NoError(self.T(), err)
+
+	_, err = LoadCollectionContext(self.ConfigObj, self.client_id, "F.5678")
+	assert.Error(self.T(), err)
+
+	// The second hunt lists the first hunt's flow.
+	rows := test_utils.FileReadRows(self.T(), self.ConfigObj,
+		paths.NewHuntPathManager(hunt_ids[1]).Clients())
+	assert.Equal(self.T(), 1, len(rows))
+	assert.Equal(self.T(), "F.1234", utils.GetString(rows[0], "FlowId"))
+
+	// Completing the flow completes it in both hunts.
+	flow_obj := &flows_proto.ArtifactCollectorContext{
+		SessionId: "F.1234",
+		ClientId:  self.client_id,
+		Request:   proto.Clone(self.expected).(*flows_proto.ArtifactCollectorArgs),
+		State:     flows_proto.ArtifactCollectorContext_FINISHED,
+	}
+
+	assert.NoError(self.T(), journal.PushRowsToArtifact(self.ConfigObj,
+		[]*ordereddict.Dict{ordereddict.NewDict().
+			Set("Timestamp", time.Now().UTC().Unix()).
+			Set("Flow", flow_obj).
+			Set("FlowId", flow_obj.SessionId).
+			Set("ClientId", self.client_id),
+		}, "System.Flow.Completion", self.client_id, ""))
+
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		for _, hunt_id := range hunt_ids {
+			h, _ := dispatcher.GetHunt(hunt_id)
+			if h.Stats.TotalClientsWithResults != 1 {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Pause a running hunt, change its condition and resume it.
+func (self *HuntTestSuite) TestHuntManagerPauseAndRetarget() {
+	hunt_obj := &api_proto.Hunt{
+		HuntId:       self.hunt_id,
+		StartRequest: self.expected,
+		State:        api_proto.Hunt_RUNNING,
+		Stats:        &api_proto.HuntStats{},
+		StartTime:    1,
+		Expires:      uint64(time.Now().Add(7*24*time.Hour).UTC().UnixNano() / 1000),
+	}
+
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	hunt_path_manager := paths.NewHuntPathManager(hunt_obj.HuntId)
+	err = db.SetSubject(self.ConfigObj, hunt_path_manager.Path(), hunt_obj)
+	assert.NoError(self.T(), err)
+
+	dispatcher, err := services.GetHuntDispatcher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	dispatcher.Refresh(self.ConfigObj)
+
+	ctx := context.Background()
+	err = dispatcher.ModifyHunt(ctx, self.ConfigObj, acl_managers.NullACLManager{}, &api_proto.Hunt{
+		HuntId: hunt_obj.HuntId,
+		State:  api_proto.Hunt_PAUSED,
+	}, "admin")
+	assert.NoError(self.T(), err)
+
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		h, _ := dispatcher.GetHunt(hunt_obj.HuntId)
+		return h.State == api_proto.Hunt_PAUSED
+	})
+
+	// A paused hunt is not stopped - it may be resumed.
+	h, _ := dispatcher.GetHunt(hunt_obj.HuntId)
+	assert.False(self.T(), h.Stats.Stopped)
+
+	// Participation is ignored while the hunt is paused.
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	assert.NoError(self.T(), journal.PushRowsToArtifact(self.ConfigObj,
+		[]*ordereddict.Dict{ordereddict.NewDict().
+			Set("HuntId", hunt_obj.HuntId).
+			Set("ClientId", self.client_id),
+		}, "System.Hunt.Participation", self.client_id, ""))
+
+	time.Sleep(time.Second)
+	h, _ = dispatcher.GetHunt(hunt_obj.HuntId)
+	assert.Equal(self.T(), uint64(0), h.Stats.TotalClientsScheduled)
+
+	// A principal scoped to a label may not retarget the hunt to
+	// other clients.
+	scoped := &acl_managers.RoleACLManager{
+		Token: &acl_proto.ApiClientACL{
+			CollectClient: true,
+			LabelScope:    []string{"MyLabel"},
+		},
+	}
+	err = dispatcher.ModifyHunt(ctx, self.ConfigObj, scoped, &api_proto.Hunt{
+		HuntId: hunt_obj.HuntId,
+		Condition: &api_proto.HuntCondition{
+			UnionField: &api_proto.HuntCondition_Os{
+				Os: &api_proto.HuntOsCondition{
+					Os: api_proto.HuntOsCondition_LINUX,
+				},
+			},
+		},
+	}, "scoped")
+	assert.ErrorIs(self.T(), err, acls.PermissionDenied)
+
+	// Retarget the hunt to a label.
+	err = dispatcher.ModifyHunt(ctx, self.ConfigObj, scoped, &api_proto.Hunt{
+		HuntId: hunt_obj.HuntId,
+		Condition: &api_proto.HuntCondition{
+			UnionField: &api_proto.HuntCondition_Labels{
+				Labels: &api_proto.HuntLabelCondition{
+					Label: []string{"MyLabel"},
+				},
+			},
+		},
+	}, "admin")
+	assert.NoError(self.T(), err)
+
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		h, _ := dispatcher.GetHunt(hunt_obj.HuntId)
+		return h.Condition != nil && h.Condition.GetLabels() != nil
+	})
+
+	// The hunt is offered to all clients again.
+	h, _ = dispatcher.GetHunt(hunt_obj.HuntId)
+	assert.True(self.T(), h.StartTime > hunt_obj.StartTime)
+	assert.Equal(self.T(), api_proto.Hunt_PAUSED, h.State)
+
+	// Resume the hunt.
+	err = dispatcher.ModifyHunt(ctx, self.ConfigObj, acl_managers.NullACLManager{}, &api_proto.Hunt{
+		HuntId: hunt_obj.HuntId,
+		State:  api_proto.Hunt_RUNNING,
+	}, "admin")
+	assert.NoError(self.T(), err)
+
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		h, _ := dispatcher.GetHunt(hunt_obj.HuntId)
+		return h.State == api_proto.Hunt_RUNNING
+	})
+}
+
 func TestHuntTestSuite(t *testing.T) {
 	suite.Run(t, &HuntTestSuite{
 		client_id: "C.234",
@@ -0,0 +1,332 @@
+package hunt_manager
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	artifact_paths "www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	DEFAULT_POST_PROCESS_WORKERS = 4
+)
+
+// The results of a single collection ready to be appended to the
+// hunt's result sets.
+type mergeBatch struct {
+	flow_id string
+
+	// Serialized JSONL and row counts keyed by artifact source.
+	artifacts []string
+	data      map[string][]byte
+	rows      map[string]uint64
+}
+
+type postProcessJob struct {
+	hunt_id string
+	state   *huntMergeState
+	seq     uint64
+	flow    *flows_proto.ArtifactCollectorContext
+}
+
+// Keeps the merge order of a single hunt. Batches are appended to the
+// hunt's result sets in the order the collections completed, even
+// though they are prepared by different workers.
+type huntMergeState struct {
+	mu sync.Mutex
+
+	// The next sequence number to hand out.
+	next_seq uint64
+
+	// The next sequence number to write.
+	next_write uint64
+
+	// Prepared batches waiting for earlier ones to be written. A nil
+	// batch means there is nothing to write for that collection.
+	pending map[uint64]*mergeBatch
+}
+
+// Completed hunt collections are merged into hunt level result sets
+// by a pool of workers. Reading and annotating the results of each
+// collection is the expensive part so it is spread over the workers,
+// while the (cheap) appends for each hunt are serialized.
+//
+// The merge state is only kept in memory - collections that complete
+// while the server is shutting down may be missing from the merged
+// results. hunt_results() can still read them from the collections
+// directly.
+type huntPostProcessor struct {
+	// Lock order is huntPostProcessor.mu then huntMergeState.mu
+	mu    sync.Mutex
+	hunts map[string]*huntMergeState
+
+	jobs chan *postProcessJob
+}
+
+func newHuntPostProcessor() *huntPostProcessor {
+	return &huntPostProcessor{
+		hunts: make(map[string]*huntMergeState),
+		jobs:  make(chan *postProcessJob, 1000),
+	}
+}
+
+func (self *huntPostProcessor) Start(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	wg *sync.WaitGroup) {
+
+	workers := int(config_obj.Frontend.Resources.HuntPostProcessWorkers)
+	if workers == 0 {
+		workers = DEFAULT_POST_PROCESS_WORKERS
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+
+				case job := <-self.jobs:
+					self.processJob(ctx, config_obj, job)
+				}
+			}
+		}()
+	}
+}
+
+// Queue the collection for merging. Blocks if the workers fall too
+// far behind.
+func (self *huntPostProcessor) Submit(
+	ctx context.Context,
+	hunt_id string, flow *flows_proto.ArtifactCollectorContext) {
+
+	self.mu.Lock()
+	state, pres := self.hunts[hunt_id]
+	if !pres {
+		state = &huntMergeState{
+			pending: make(map[uint64]*mergeBatch),
+		}
+		self.hunts[hunt_id] = state
+	}
+
+	state.mu.Lock()
+	seq := state.next_seq
+	state.next_seq++
+	state.mu.Unlock()
+	self.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+	case self.jobs <- &postProcessJob{
+		hunt_id: hunt_id,
+		state:   state,
+		seq:     seq,
+		flow:    flow,
+	}:
+	}
+}
+
+func (self *huntPostProcessor) processJob(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	job *postProcessJob) {
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+
+	batch, err := prepareBatch(ctx, config_obj, job.flow)
+	if err != nil {
+		logger.Error("hunt_manager: merging results of %v into %v: %v",
+			job.flow.SessionId, job.hunt_id, err)
+	}
+
+	self.commit(config_obj, job, batch)
+	self.dropIdle(job.hunt_id, job.state)
+}
+
+// Holding the hunt's lock while writing ensures the batches are
+// appended in order.
+func (self *huntPostProcessor) commit(
+	config_obj *config_proto.Config,
+	job *postProcessJob, batch *mergeBatch) {
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+
+	state := job.state
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.pending[job.seq] = batch
+	for {
+		batch, pres := state.pending[state.next_write]
+		if !pres {
+			break
+		}
+		delete(state.pending, state.next_write)
+		state.next_write++
+
+		err := writeBatch(config_obj, job.hunt_id, batch)
+		if err != nil {
+			logger.Error("hunt_manager: merging results of %v into %v: %v",
+				batch.flow_id, job.hunt_id, err)
+		}
+	}
+}
+
+// Forget hunts with no outstanding collections.
+func (self *huntPostProcessor) dropIdle(
+	hunt_id string, state *huntMergeState) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if self.hunts[hunt_id] == state && state.next_write == state.next_seq {
+		delete(self.hunts, hunt_id)
+	}
+}
+
+// Read the results of the collection and add the columns identifying
+// the client.
+func prepareBatch(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	flow *flows_proto.ArtifactCollectorContext) (*mergeBatch, error) {
+
+	if len(flow.ArtifactsWithResults) == 0 {
+		return nil, nil
+	}
+
+	extra := ordereddict.NewDict().
+		Set("FlowId", flow.SessionId).
+		Set("ClientId", flow.ClientId)
+
+	indexer, err := services.GetIndexer(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	api_client, err := indexer.FastGetApiClient(ctx, config_obj, flow.ClientId)
+	if err == nil && api_client.OsInfo != nil {
+		extra.Set("Fqdn", api_client.OsInfo.Fqdn)
+	}
+
+	serialized_extra, err := json.Marshal(extra)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop the braces so the columns can be spliced into each row.
+	columns := serialized_extra[1 : len(serialized_extra)-1]
+
+	result := &mergeBatch{
+		flow_id: flow.SessionId,
+		data:    make(map[string][]byte),
+		rows:    make(map[string]uint64),
+	}
+	file_store_factory := file_store.GetFileStore(config_obj)
+
+	for _, artifact := range flow.ArtifactsWithResults {
+		path_manager, err := artifact_paths.NewArtifactPathManager(
+			config_obj, flow.ClientId, flow.SessionId, artifact)
+		if err != nil {
+			return nil, err
+		}
+
+		// Skip sources without a result set.
+		data, rows, err := readAnnotatedRows(ctx,
+			file_store_factory, path_manager.Path(), columns)
+		if err != nil {
+			continue
+		}
+
+		if rows == 0 {
+			continue
+		}
+
+		result.artifacts = append(result.artifacts, artifact)
+		result.data[artifact] = data
+		result.rows[artifact] = rows
+	}
+
+	return result, nil
+}
+
+// Append the extra columns to each serialized row without parsing
+// it.
+func readAnnotatedRows(
+	ctx context.Context,
+	file_store_factory api.FileStore,
+	path api.FSPathSpec, columns []byte) ([]byte, uint64, error) {
+
+	reader, err := result_sets.NewResultSetReader(file_store_factory, path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer reader.Close()
+
+	json_chan, err := reader.JSON(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	buf := &bytes.Buffer{}
+	rows := uint64(0)
+	for row := range json_chan {
+		row = bytes.TrimSpace(row)
+		if len(row) < 2 || row[len(row)-1] != '}' {
+			continue
+		}
+
+		buf.Write(row[:len(row)-1])
+		if len(row) > 2 {
+			buf.WriteByte(',')
+		}
+		buf.Write(columns)
+		buf.WriteString("}\n")
+		rows++
+	}
+
+	return buf.Bytes(), rows, nil
+}
+
+func writeBatch(
+	config_obj *config_proto.Config,
+	hunt_id string, batch *mergeBatch) error {
+	if batch == nil {
+		return nil
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	path_manager := paths.NewHuntPathManager(hunt_id)
+
+	for _, artifact := range batch.artifacts {
+		writer, err := result_sets.NewResultSetWriter(file_store_factory,
+			path_manager.Results(artifact), json.DefaultEncOpts(),
+			utils.SyncCompleter, result_sets.AppendMode)
+		if err != nil {
+			return err
+		}
+
+		writer.WriteJSONL(batch.data[artifact], batch.rows[artifact])
+		writer.Close()
+	}
+
+	return nil
+}
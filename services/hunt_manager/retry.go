@@ -0,0 +1,343 @@
+package hunt_manager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	DEFAULT_RETRY_BACKOFF = 300 * time.Second
+
+	// The backoff doubles with each retry up to this.
+	MAX_RETRY_BACKOFF = 24 * time.Hour
+)
+
+// A failed collection waiting for its backoff to expire.
+type pendingRetry struct {
+	hunt_id   string
+	client_id string
+	due       time.Time
+}
+
+// Hunts with a retry policy reschedule collections which failed on a
+// client after a backoff. Failed collections are not counted in the
+// hunt's completions or errors until the client runs out of retries.
+//
+// The retry history of each client is stored with the hunt so
+// pending retries are reloaded when the server restarts.
+type huntRetrier struct {
+	mu sync.Mutex
+
+	// Keyed by hunt id and client id.
+	pending map[string]*pendingRetry
+}
+
+func newHuntRetrier() *huntRetrier {
+	return &huntRetrier{
+		pending: make(map[string]*pendingRetry),
+	}
+}
+
+func (self *huntRetrier) Add(hunt_id, client_id string, due time.Time) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.pending[hunt_id+"/"+client_id] = &pendingRetry{
+		hunt_id:   hunt_id,
+		client_id: client_id,
+		due:       due,
+	}
+}
+
+// Remove and return the retries which are due.
+func (self *huntRetrier) Due(now time.Time) []*pendingRetry {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	var result []*pendingRetry
+	for key, retry := range self.pending {
+		if !now.Before(retry.due) {
+			result = append(result, retry)
+			delete(self.pending, key)
+		}
+	}
+	return result
+}
+
+// Get the retry history of the client in the hunt.
+func GetClientRetries(config_obj *config_proto.Config,
+	hunt_id, client_id string) (*api_proto.HuntClientRetries, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clients without a record were not retried yet.
+	result := &api_proto.HuntClientRetries{}
+	err = db.GetSubject(config_obj,
+		paths.NewHuntPathManager(hunt_id).Retries(client_id), result)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	result.HuntId = hunt_id
+	result.ClientId = client_id
+	return result, nil
+}
+
+// Get the retry history of all clients in the hunt.
+func ListClientRetries(config_obj *config_proto.Config,
+	hunt_id string) ([]*api_proto.HuntClientRetries, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := db.ListChildren(config_obj,
+		paths.NewHuntPathManager(hunt_id).RetriesDirectory())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*api_proto.HuntClientRetries
+	for _, child := range children {
+		if child.IsDir() {
+			continue
+		}
+
+		record := &api_proto.HuntClientRetries{}
+		err = db.GetSubject(config_obj, child, record)
+		if err != nil || len(record.Attempts) == 0 {
+			continue
+		}
+		result = append(result, record)
+	}
+
+	return result, nil
+}
+
+func setClientRetries(config_obj *config_proto.Config,
+	record *api_proto.HuntClientRetries) error {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.SetSubject(config_obj,
+		paths.NewHuntPathManager(record.HuntId).Retries(record.ClientId),
+		record)
+}
+
+// The wait before the retry following this many failed attempts.
+func retryBackoff(policy *api_proto.HuntRetryPolicy, attempts int) time.Duration {
+	backoff := DEFAULT_RETRY_BACKOFF
+	if policy.BackoffSeconds > 0 {
+		backoff = time.Duration(policy.BackoffSeconds) * time.Second
+	}
+
+	for i := 1; i < attempts && backoff < MAX_RETRY_BACKOFF; i++ {
+		backoff *= 2
+	}
+
+	if backoff > MAX_RETRY_BACKOFF {
+		backoff = MAX_RETRY_BACKOFF
+	}
+	return backoff
+}
+
+// Check if the hunt's failed collection should be retried. Returns
+// true if a retry is scheduled - the collection is then not counted
+// as completed.
+func (self *HuntManager) maybeRetry(
+	config_obj *config_proto.Config,
+	hunt_id string, flow *flows_proto.ArtifactCollectorContext) (bool, error) {
+
+	// Only failures and timeouts are retried - not collections
+	// which were cancelled by a user.
+	if flow.State != flows_proto.ArtifactCollectorContext_ERROR ||
+		strings.HasPrefix(flow.Status, "Cancelled by ") {
+		return false, nil
+	}
+
+	dispatcher, err := services.GetHuntDispatcher(config_obj)
+	if err != nil {
+		return false, err
+	}
+
+	hunt_obj, pres := dispatcher.GetHunt(hunt_id)
+	if !pres || hunt_obj.Retry == nil || hunt_obj.Retry.MaxRetries == 0 {
+		return false, nil
+	}
+
+	if hunt_obj.State != api_proto.Hunt_RUNNING &&
+		hunt_obj.State != api_proto.Hunt_PAUSED {
+		return false, nil
+	}
+
+	record, err := GetClientRetries(config_obj, hunt_id, flow.ClientId)
+	if err != nil {
+		return false, err
+	}
+
+	if uint64(len(record.Attempts)) >= hunt_obj.Retry.MaxRetries {
+		return false, nil
+	}
+
+	now := utils.GetTime().Now()
+	due := now.Add(retryBackoff(hunt_obj.Retry, len(record.Attempts)+1))
+
+	record.Attempts = append(record.Attempts, &api_proto.HuntRetryAttempt{
+		FlowId:     flow.SessionId,
+		Error:      flow.Status,
+		FailedTime: uint64(now.Unix()),
+		RetryTime:  uint64(due.Unix()),
+	})
+
+	err = setClientRetries(config_obj, record)
+	if err != nil {
+		return false, err
+	}
+
+	self.retrier.Add(hunt_id, flow.ClientId, due)
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("hunt_manager: Collection %v of hunt %v failed on %v (%v), "+
+		"retry %v of %v at %v", flow.SessionId, hunt_id, flow.ClientId,
+		flow.Status, len(record.Attempts), hunt_obj.Retry.MaxRetries,
+		due.UTC().Format(time.RFC3339))
+
+	return true, self.processMutation(config_obj, &api_proto.HuntMutation{
+		HuntId: hunt_id,
+		Stats:  &api_proto.HuntStats{TotalRetries: 1},
+	})
+}
+
+// Reschedule the failed collections whose backoff expired.
+func (self *HuntManager) scheduleRetries(
+	ctx context.Context,
+	config_obj *config_proto.Config) {
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+
+	for _, retry := range self.retrier.Due(utils.GetTime().Now()) {
+		err := self.retryCollection(ctx, config_obj, retry)
+		if err != nil {
+			logger.Error("hunt_manager: retrying %v on %v: %v",
+				retry.hunt_id, retry.client_id, err)
+		}
+	}
+}
+
+func (self *HuntManager) retryCollection(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	retry *pendingRetry) error {
+
+	dispatcher, err := services.GetHuntDispatcher(config_obj)
+	if err != nil {
+		return err
+	}
+
+	hunt_obj, pres := dispatcher.GetHunt(retry.hunt_id)
+	if !pres {
+		return nil
+	}
+
+	switch hunt_obj.State {
+	case api_proto.Hunt_RUNNING:
+
+	// Wait until the hunt is resumed.
+	case api_proto.Hunt_PAUSED:
+		self.retrier.Add(retry.hunt_id, retry.client_id,
+			utils.GetTime().Now().Add(time.Minute))
+		return nil
+
+	// The hunt is stopped - give up.
+	default:
+		return nil
+	}
+
+	if uint64(utils.GetTime().Now().UnixNano()/1000) > hunt_obj.Expires {
+		return nil
+	}
+
+	record, err := GetClientRetries(config_obj, retry.hunt_id, retry.client_id)
+	if err != nil {
+		return err
+	}
+
+	if len(record.Attempts) == 0 {
+		return nil
+	}
+
+	attempt := record.Attempts[len(record.Attempts)-1]
+	if attempt.RetryFlowId != "" {
+		return nil
+	}
+
+	self.limiter.Wait(ctx)
+
+	flow_id, err := launchHuntFlow(ctx, config_obj, hunt_obj, retry.client_id)
+	if err != nil {
+		return err
+	}
+
+	attempt.RetryFlowId = flow_id
+	err = setClientRetries(config_obj, record)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("hunt_manager: Retrying hunt %v on %v with collection %v",
+		retry.hunt_id, retry.client_id, flow_id)
+
+	// The client was already counted as scheduled by its first
+	// collection.
+	return appendHuntFlow(config_obj, retry.hunt_id, retry.client_id, flow_id)
+}
+
+// Reload the retries which were pending when the server stopped.
+func (self *HuntManager) loadPendingRetries(
+	config_obj *config_proto.Config) error {
+
+	dispatcher, err := services.GetHuntDispatcher(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return dispatcher.ApplyFuncOnHunts(func(hunt_obj *api_proto.Hunt) error {
+		if hunt_obj.Retry == nil || hunt_obj.Retry.MaxRetries == 0 ||
+			(hunt_obj.State != api_proto.Hunt_RUNNING &&
+				hunt_obj.State != api_proto.Hunt_PAUSED) {
+			return nil
+		}
+
+		records, err := ListClientRetries(config_obj, hunt_obj.HuntId)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			attempt := record.Attempts[len(record.Attempts)-1]
+			if attempt.RetryFlowId == "" {
+				self.retrier.Add(hunt_obj.HuntId, record.ClientId,
+					time.Unix(int64(attempt.RetryTime), 0))
+			}
+		}
+		return nil
+	})
+}
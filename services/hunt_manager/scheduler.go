@@ -0,0 +1,248 @@
+package hunt_manager
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+)
+
+const (
+	// By default only check the error rate once this many
+	// collections are complete so a couple of early failures do not
+	// pause the hunt.
+	DEFAULT_MIN_COMPLETED = 10
+)
+
+// Clients waiting to be scheduled on a hunt with a rate cap.
+type huntQueue struct {
+	limiter *rate.Limiter
+	clients []string
+	seen    map[string]bool
+}
+
+// Hunts with scheduling set release clients in waves rather than all
+// at once. Participating clients are queued here and released by
+// the scheduler loop at the hunt's rate.
+//
+// The queue is only kept in memory: clients queued when the server
+// restarts will be picked up again when the hunt is restarted.
+type huntScheduler struct {
+	mu     sync.Mutex
+	queues map[string]*huntQueue
+}
+
+func newHuntScheduler() *huntScheduler {
+	return &huntScheduler{
+		queues: make(map[string]*huntQueue),
+	}
+}
+
+func (self *huntScheduler) Queue(hunt_obj *api_proto.Hunt, client_id string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	queue, pres := self.queues[hunt_obj.HuntId]
+	if !pres {
+		// Allow a full wave to go out immediately then refill the
+		// bucket over the next minute.
+		per_minute := int(hunt_obj.Scheduling.ClientsPerMinute)
+		queue = &huntQueue{
+			limiter: rate.NewLimiter(
+				rate.Limit(float64(per_minute)/60), per_minute),
+			seen: make(map[string]bool),
+		}
+		self.queues[hunt_obj.HuntId] = queue
+	}
+
+	if queue.seen[client_id] {
+		return
+	}
+	queue.seen[client_id] = true
+	queue.clients = append(queue.clients, client_id)
+}
+
+// Take the clients that may be scheduled now from the queue.
+func (self *huntScheduler) release(hunt_id string) []string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	queue, pres := self.queues[hunt_id]
+	if !pres {
+		return nil
+	}
+
+	count := 0
+	for count < len(queue.clients) && queue.limiter.Allow() {
+		count++
+	}
+
+	result := queue.clients[:count]
+	queue.clients = queue.clients[count:]
+	for _, client_id := range result {
+		delete(queue.seen, client_id)
+	}
+
+	if len(queue.clients) == 0 {
+		delete(self.queues, hunt_id)
+	}
+
+	return result
+}
+
+func (self *huntScheduler) drop(hunt_id string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	delete(self.queues, hunt_id)
+}
+
+func (self *huntScheduler) huntIds() []string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := make([]string, 0, len(self.queues))
+	for hunt_id := range self.queues {
+		result = append(result, hunt_id)
+	}
+	return result
+}
+
+func (self *huntScheduler) Pending(hunt_id string) int {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	queue, pres := self.queues[hunt_id]
+	if !pres {
+		return 0
+	}
+	return len(queue.clients)
+}
+
+func (self *HuntManager) startScheduler(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	wg *sync.WaitGroup) {
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(time.Second):
+				self.scheduleQueuedClients(ctx, config_obj)
+				self.scheduleRetries(ctx, config_obj)
+			}
+		}
+	}()
+}
+
+func (self *HuntManager) scheduleQueuedClients(
+	ctx context.Context,
+	config_obj *config_proto.Config) {
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+
+	dispatcher, err := services.GetHuntDispatcher(config_obj)
+	if err != nil {
+		return
+	}
+
+	for _, hunt_id := range self.scheduler.huntIds() {
+		// The hunt may have been paused or stopped while the clients
+		// were waiting. The clients will participate again when the
+		// hunt is restarted.
+		hunt_obj, pres := dispatcher.GetHunt(hunt_id)
+		if !pres || hunt_obj.State != api_proto.Hunt_RUNNING {
+			self.scheduler.drop(hunt_id)
+			continue
+		}
+
+		scheduled := uint64(0)
+		for _, client_id := range self.scheduler.release(hunt_id) {
+			if huntLimitReached(hunt_obj, scheduled) {
+				self.scheduler.drop(hunt_id)
+				break
+			}
+
+			err = checkHuntRanOnClient(config_obj, client_id, hunt_id)
+			if err != nil {
+				continue
+			}
+
+			self.limiter.Wait(ctx)
+
+			err = self.scheduleHunt(ctx, config_obj, hunt_obj, client_id)
+			if err != nil {
+				logger.Error("hunt_manager: scheduling %v on %v: %v",
+					hunt_id, client_id, err)
+				continue
+			}
+			scheduled++
+		}
+	}
+}
+
+// Checks if the hunt has reached its client limit or expired. The
+// hunt stats are updated asynchronously so the caller passes the
+// number of clients it scheduled since getting the hunt object.
+func huntLimitReached(hunt_obj *api_proto.Hunt, scheduled uint64) bool {
+	now := uint64(time.Now().UnixNano() / 1000)
+	return (hunt_obj.ClientLimit > 0 &&
+		hunt_obj.Stats.TotalClientsScheduled+scheduled >= hunt_obj.ClientLimit) ||
+		now > hunt_obj.Expires
+}
+
+// Pause the hunt if too many of its collections failed.
+func (self *HuntManager) checkErrorRate(
+	config_obj *config_proto.Config, hunt_id string) error {
+
+	dispatcher, err := services.GetHuntDispatcher(config_obj)
+	if err != nil {
+		return err
+	}
+
+	hunt_obj, pres := dispatcher.GetHunt(hunt_id)
+	if !pres || hunt_obj.State != api_proto.Hunt_RUNNING ||
+		hunt_obj.Scheduling == nil || hunt_obj.Scheduling.MaxErrorRate <= 0 {
+		return nil
+	}
+
+	min_completed := hunt_obj.Scheduling.MinCompleted
+	if min_completed == 0 {
+		min_completed = DEFAULT_MIN_COMPLETED
+	}
+
+	completed := hunt_obj.Stats.TotalClientsWithResults
+	if completed == 0 || completed < min_completed {
+		return nil
+	}
+
+	error_rate := float64(hunt_obj.Stats.TotalClientsWithErrors) * 100 /
+		float64(completed)
+	if error_rate < hunt_obj.Scheduling.MaxErrorRate {
+		return nil
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("hunt_manager: <red>Pausing</> hunt %v: %v of %v collections "+
+		"failed (%.1f%%, threshold %v%%)", hunt_id,
+		hunt_obj.Stats.TotalClientsWithErrors, completed,
+		error_rate, hunt_obj.Scheduling.MaxErrorRate)
+
+	self.scheduler.drop(hunt_id)
+
+	return self.processMutation(config_obj, &api_proto.HuntMutation{
+		HuntId: hunt_id,
+		State:  api_proto.Hunt_PAUSED,
+	})
+}
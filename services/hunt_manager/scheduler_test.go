@@ -0,0 +1,37 @@
+package hunt_manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+)
+
+func TestHuntSchedulerWaves(t *testing.T) {
+	scheduler := newHuntScheduler()
+	hunt_obj := &api_proto.Hunt{
+		HuntId: "H.1",
+		Scheduling: &api_proto.HuntScheduling{
+			ClientsPerMinute: 2,
+		},
+	}
+
+	for _, client_id := range []string{"C.1", "C.2", "C.3", "C.1", "C.4"} {
+		scheduler.Queue(hunt_obj, client_id)
+	}
+
+	// Duplicate participations are only queued once.
+	assert.Equal(t, 4, scheduler.Pending("H.1"))
+
+	// The first wave goes out immediately.
+	assert.Equal(t, []string{"C.1", "C.2"}, scheduler.release("H.1"))
+
+	// The rest wait for the next wave.
+	assert.Equal(t, 0, len(scheduler.release("H.1")))
+	assert.Equal(t, 2, scheduler.Pending("H.1"))
+
+	// Dropping the hunt clears its queue.
+	scheduler.drop("H.1")
+	assert.Equal(t, 0, scheduler.Pending("H.1"))
+	assert.Equal(t, 0, len(scheduler.huntIds()))
+}
@@ -0,0 +1,204 @@
+package hunt_scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	invalidCronError = errors.New("Invalid cron specification")
+
+	cronMacros = map[string]string{
+		"@hourly":  "0 * * * *",
+		"@daily":   "0 0 * * *",
+		"@weekly":  "0 0 * * 0",
+		"@monthly": "0 0 1 * *",
+		"@yearly":  "0 0 1 1 *",
+	}
+
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+
+	dayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+// A parsed cron specification in the usual 5 field format (minute
+// hour day-of-month month day-of-week). All times are in UTC.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// As in cron, if both day fields are restricted a day matching
+	// either is selected.
+	dom_star, dow_star bool
+}
+
+func ParseCron(spec string) (*CronSchedule, error) {
+	spec = strings.TrimSpace(strings.ToLower(spec))
+	macro, pres := cronMacros[spec]
+	if pres {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields in %q",
+			invalidCronError, spec)
+	}
+
+	result := &CronSchedule{
+		dom_star: fields[2] == "*",
+		dow_star: fields[4] == "*",
+	}
+
+	var err error
+	result.minute, err = parseCronField(fields[0], 0, 59, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result.hour, err = parseCronField(fields[1], 0, 23, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result.dom, err = parseCronField(fields[2], 1, 31, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result.month, err = parseCronField(fields[3], 1, 12, monthNames)
+	if err != nil {
+		return nil, err
+	}
+
+	// Allow 7 for Sunday.
+	result.dow, err = parseCronField(fields[4], 0, 7, dayNames)
+	if err != nil {
+		return nil, err
+	}
+	if result.dow&(1<<7) != 0 {
+		result.dow |= 1
+	}
+
+	return result, nil
+}
+
+// Parse a comma separated list of values, ranges and steps into a
+// bitmask.
+func parseCronField(field string, min, max int,
+	names map[string]int) (uint64, error) {
+	var result uint64
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		idx := strings.Index(part, "/")
+		if idx >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("%w: bad step in %q",
+					invalidCronError, field)
+			}
+			part = part[:idx]
+		}
+
+		start, end := min, max
+		if part != "*" {
+			range_parts := strings.SplitN(part, "-", 2)
+
+			var err error
+			start, err = parseCronValue(range_parts[0], names)
+			if err != nil {
+				return 0, fmt.Errorf("%w: %q", err, field)
+			}
+
+			end = start
+			if len(range_parts) == 2 {
+				end, err = parseCronValue(range_parts[1], names)
+				if err != nil {
+					return 0, fmt.Errorf("%w: %q", err, field)
+				}
+
+				// A single value with a step runs to the end of the
+				// range (e.g. 5/15).
+			} else if idx >= 0 {
+				end = max
+			}
+		}
+
+		if start < min || end > max || start > end {
+			return 0, fmt.Errorf("%w: %q out of range %v-%v",
+				invalidCronError, field, min, max)
+		}
+
+		for i := start; i <= end; i += step {
+			result |= 1 << uint(i)
+		}
+	}
+
+	return result, nil
+}
+
+func parseCronValue(value string, names map[string]int) (int, error) {
+	number, pres := names[value]
+	if pres {
+		return number, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, invalidCronError
+	}
+	return number, nil
+}
+
+func (self *CronSchedule) dayMatches(t time.Time) bool {
+	dom := self.dom&(1<<uint(t.Day())) != 0
+	dow := self.dow&(1<<uint(t.Weekday())) != 0
+
+	if self.dom_star || self.dow_star {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Returns the first time after t that matches the schedule.
+func (self *CronSchedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+
+	// Give up if nothing matches within a few years (e.g. 30 Feb).
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if self.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		if !self.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		if self.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+
+		if self.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
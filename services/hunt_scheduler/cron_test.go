@@ -0,0 +1,45 @@
+package hunt_scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronNext(t *testing.T) {
+	// Thursday
+	start := time.Date(2022, 6, 16, 10, 30, 15, 0, time.UTC)
+
+	for _, test_case := range []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2022, 6, 16, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2022, 6, 16, 10, 45, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2022, 6, 17, 3, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2022, 6, 16, 11, 0, 0, 0, time.UTC)},
+		{"0 3 * * mon", time.Date(2022, 6, 20, 3, 0, 0, 0, time.UTC)},
+		{"0 3 * * 1-5", time.Date(2022, 6, 17, 3, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+
+		// Either day field matches when both are given.
+		{"0 0 20 * 5", time.Date(2022, 6, 17, 0, 0, 0, 0, time.UTC)},
+
+		// Never matches.
+		{"0 0 30 2 *", time.Time{}},
+	} {
+		schedule, err := ParseCron(test_case.spec)
+		require.NoError(t, err, test_case.spec)
+		assert.Equal(t, test_case.expected, schedule.Next(start), test_case.spec)
+	}
+
+	for _, spec := range []string{
+		"", "* * * *", "60 * * * *", "* * * 13 *", "*/0 * * * *", "a * * * *",
+	} {
+		_, err := ParseCron(spec)
+		assert.ErrorIs(t, err, invalidCronError, spec)
+	}
+}
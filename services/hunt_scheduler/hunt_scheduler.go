@@ -0,0 +1,236 @@
+/*
+  The hunt scheduler re-runs hunts on a cron like schedule.
+
+  A schedule holds a copy of a hunt. Each time the schedule fires a
+  new hunt is created from the copy, with its own hunt id and expiry,
+  and linked back to the schedule by its schedule_id field. The
+  artifacts are compiled again on each run so the latest artifact
+  definitions are used.
+
+  If the previous run is still active when the schedule fires, the
+  run is skipped so runs never overlap.
+
+  The service runs on the master node alongside the hunt manager.
+*/
+
+package hunt_scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+)
+
+type HuntScheduler struct {
+	config_obj *config_proto.Config
+}
+
+// Check all schedules and launch the ones that are due.
+func (self *HuntScheduler) RunOnce(ctx context.Context, now time.Time) error {
+	schedules, err := ListSchedules(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	for _, schedule := range schedules {
+		if schedule.Disabled {
+			continue
+		}
+
+		err := self.maybeRun(ctx, schedule, now)
+		if err != nil {
+			logger.Error("hunt_scheduler: schedule %v: %v",
+				schedule.ScheduleId, err)
+		}
+	}
+
+	return nil
+}
+
+func (self *HuntScheduler) maybeRun(
+	ctx context.Context, schedule *api_proto.HuntSchedule,
+	now time.Time) error {
+
+	cron, err := ParseCron(schedule.Cron)
+	if err != nil {
+		return err
+	}
+
+	last_run := schedule.LastRun
+	if last_run == 0 {
+		last_run = schedule.CreateTime
+	}
+
+	// If the server was down when the schedule should have fired we
+	// only run once to catch up.
+	next := cron.Next(time.Unix(int64(last_run), 0))
+	if next.IsZero() || next.After(now) {
+		return nil
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+
+	active, err := self.isActive(schedule.LastHuntId, now)
+	if err != nil {
+		return err
+	}
+
+	if active {
+		logger.Info("hunt_scheduler: Skipping run of schedule %v: "+
+			"hunt %v is still running", schedule.ScheduleId,
+			schedule.LastHuntId)
+
+		_, err = ModifySchedule(self.config_obj, schedule.ScheduleId,
+			func(schedule *api_proto.HuntSchedule) error {
+				schedule.LastRun = uint64(now.Unix())
+				schedule.SkippedRuns++
+				return nil
+			})
+		return err
+	}
+
+	hunt_id, launch_err := self.launch(ctx, schedule, now)
+
+	// Record the run even if the launch failed so we do not retry
+	// every minute.
+	_, err = ModifySchedule(self.config_obj, schedule.ScheduleId,
+		func(schedule *api_proto.HuntSchedule) error {
+			schedule.LastRun = uint64(now.Unix())
+			if hunt_id != "" {
+				schedule.LastHuntId = hunt_id
+				schedule.HuntIds = append(schedule.HuntIds, hunt_id)
+			}
+			return nil
+		})
+	if launch_err != nil {
+		return launch_err
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Info("hunt_scheduler: Schedule %v launched hunt %v",
+		schedule.ScheduleId, hunt_id)
+	return nil
+}
+
+// Is the previous run still going?
+func (self *HuntScheduler) isActive(hunt_id string, now time.Time) (bool, error) {
+	if hunt_id == "" {
+		return false, nil
+	}
+
+	dispatcher, err := services.GetHuntDispatcher(self.config_obj)
+	if err != nil {
+		return false, err
+	}
+
+	hunt_obj, pres := dispatcher.GetHunt(hunt_id)
+	if !pres {
+		return false, nil
+	}
+
+	return hunt_obj.State == api_proto.Hunt_RUNNING &&
+		uint64(now.UnixNano()/1000) < hunt_obj.Expires, nil
+}
+
+func (self *HuntScheduler) launch(
+	ctx context.Context, schedule *api_proto.HuntSchedule,
+	now time.Time) (string, error) {
+
+	if schedule.Hunt == nil || schedule.Hunt.StartRequest == nil {
+		return "", errors.New("Schedule has no hunt")
+	}
+
+	hunt := proto.Clone(schedule.Hunt).(*api_proto.Hunt)
+	hunt.HuntId = ""
+	hunt.Stats = nil
+	hunt.StartTime = 0
+	hunt.State = api_proto.Hunt_RUNNING
+	hunt.ScheduleId = schedule.ScheduleId
+	hunt.Creator = schedule.Creator
+	hunt.HuntDescription = fmt.Sprintf("%v (scheduled run %v)",
+		schedule.Hunt.HuntDescription, now.UTC().Format(time.RFC3339))
+
+	// Compile the artifacts again in case they changed.
+	hunt.StartRequest.CompiledCollectorArgs = nil
+
+	hunt.Expires = 0
+	if schedule.RunExpiry > 0 {
+		hunt.Expires = uint64(now.Add(
+			time.Duration(schedule.RunExpiry)*time.Second).UnixNano() / 1000)
+	}
+
+	dispatcher, err := services.GetHuntDispatcher(self.config_obj)
+	if err != nil {
+		return "", err
+	}
+
+	// Run the hunt with the permissions of the schedule's creator.
+	acl_manager := acl_managers.NewServerACLManager(
+		self.config_obj, schedule.Creator)
+
+	hunt_id, err := dispatcher.CreateHunt(
+		ctx, self.config_obj, acl_manager, hunt)
+	if err != nil {
+		return "", err
+	}
+
+	logging.LogAudit(self.config_obj, schedule.Creator, "CreateHunt",
+		logrus.Fields{
+			"hunt_id":     hunt_id,
+			"schedule_id": schedule.ScheduleId,
+		})
+
+	return hunt_id, nil
+}
+
+func NewHuntSchedulerService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*HuntScheduler, error) {
+
+	result := &HuntScheduler{
+		config_obj: config_obj,
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> hunt scheduler service for %v.",
+		services.GetOrgName(config_obj))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			// Wake up at the start of each minute.
+			now := utils.GetTime().Now()
+			delay := now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(delay):
+				err := result.RunOnce(ctx, utils.GetTime().Now())
+				if err != nil {
+					logger.Error("hunt_scheduler: %v", err)
+				}
+			}
+		}
+	}()
+
+	return result, nil
+}
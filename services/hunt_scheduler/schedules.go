@@ -0,0 +1,121 @@
+package hunt_scheduler
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"os"
+	"time"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/paths"
+)
+
+const (
+	SCHEDULE_PREFIX = "HS."
+)
+
+func NewScheduleId() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+
+	binary.BigEndian.PutUint32(buf, uint32(time.Now().Unix()))
+	result := base32.HexEncoding.EncodeToString(buf)[:13]
+
+	return SCHEDULE_PREFIX + result
+}
+
+func GetSchedule(config_obj *config_proto.Config,
+	schedule_id string) (*api_proto.HuntSchedule, error) {
+	result := &api_proto.HuntSchedule{}
+	err := datastore.GetExistingSubject(config_obj,
+		paths.HUNT_SCHEDULES_ROOT.AddChild(schedule_id), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func validateSchedule(schedule *api_proto.HuntSchedule) error {
+	if schedule.ScheduleId == "" {
+		return errors.New("ScheduleId must be set")
+	}
+
+	_, err := ParseCron(schedule.Cron)
+	return err
+}
+
+func SetSchedule(config_obj *config_proto.Config,
+	schedule *api_proto.HuntSchedule) error {
+	err := validateSchedule(schedule)
+	if err != nil {
+		return err
+	}
+
+	urn := paths.HUNT_SCHEDULES_ROOT.AddChild(schedule.ScheduleId)
+	unlock, err := datastore.LockSubject(config_obj, urn)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.SetSubject(config_obj, urn, schedule)
+}
+
+// Update the schedule under lock.
+func ModifySchedule(config_obj *config_proto.Config, schedule_id string,
+	cb func(schedule *api_proto.HuntSchedule) error) (
+	*api_proto.HuntSchedule, error) {
+	schedule := &api_proto.HuntSchedule{}
+	err := datastore.ModifySubject(config_obj,
+		paths.HUNT_SCHEDULES_ROOT.AddChild(schedule_id), schedule,
+		func(exists bool) error {
+			if !exists {
+				return os.ErrNotExist
+			}
+
+			err := cb(schedule)
+			if err != nil {
+				return err
+			}
+			return validateSchedule(schedule)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func DeleteSchedule(config_obj *config_proto.Config, schedule_id string) error {
+	return datastore.DeleteSubject(config_obj,
+		paths.HUNT_SCHEDULES_ROOT.AddChild(schedule_id), nil)
+}
+
+func ListSchedules(
+	config_obj *config_proto.Config) ([]*api_proto.HuntSchedule, error) {
+	children, err := datastore.ListSubjects(config_obj, paths.HUNT_SCHEDULES_ROOT)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api_proto.HuntSchedule, 0, len(children))
+	for _, child := range children {
+		schedule, err := GetSchedule(config_obj, child.Base())
+		if err != nil {
+			continue
+		}
+		result = append(result, schedule)
+	}
+
+	return result, nil
+}
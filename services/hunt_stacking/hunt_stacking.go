@@ -0,0 +1,171 @@
+/*
+  The hunt stacking service computes frequency stacks of the results
+  of completed hunts.
+
+  Least frequency analysis is the main way to triage large hunts: a
+  service name or scheduled task hash seen on only a few clients is
+  far more interesting than one seen everywhere. For each artifact
+  source of the hunt, every value of every column is counted by the
+  number of clients (and rows) it was seen on. The stack is stored
+  as its own result set with the least frequent values first (see
+  the hunt_stack() plugin).
+
+  A hunt is stacked once it expired or was stopped, after a delay to
+  allow in flight collections to complete. Running hunts may be
+  stacked on demand with hunt_stack(refresh=TRUE).
+*/
+
+package hunt_stacking
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	DEFAULT_DELAY           = 600
+	DEFAULT_CHECK_FREQUENCY = 60
+)
+
+type HuntStackingService struct {
+	mu sync.Mutex
+
+	config_obj *config_proto.Config
+
+	// The hunt object does not record when the hunt was stopped so
+	// we remember when we first saw it stopped.
+	stopped map[string]time.Time
+}
+
+// Returns the ids of the hunts that are ready to be stacked.
+func (self *HuntStackingService) pendingHunts() ([]string, error) {
+	hunt_dispatcher, err := services.GetHuntDispatcher(self.config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := time.Duration(self.config_obj.HuntStacking.Delay) * time.Second
+	if delay == 0 {
+		delay = DEFAULT_DELAY * time.Second
+	}
+
+	now := utils.GetTime().Now()
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	var result []string
+	err = hunt_dispatcher.ApplyFuncOnHunts(func(hunt_obj *api_proto.Hunt) error {
+		var done_time time.Time
+
+		switch hunt_obj.State {
+		case api_proto.Hunt_STOPPED, api_proto.Hunt_ARCHIVED:
+			first_seen, pres := self.stopped[hunt_obj.HuntId]
+			if !pres {
+				first_seen = now
+				self.stopped[hunt_obj.HuntId] = now
+			}
+			done_time = first_seen
+
+		case api_proto.Hunt_RUNNING, api_proto.Hunt_PAUSED:
+			expires := time.Unix(0, int64(hunt_obj.Expires)*1000)
+			if hunt_obj.Expires == 0 || now.Before(expires) {
+				return nil
+			}
+			done_time = expires
+
+		default:
+			return nil
+		}
+
+		if now.Before(done_time.Add(delay)) {
+			return nil
+		}
+
+		// Already stacked after it was done.
+		summary, err := GetStackSummary(self.config_obj, hunt_obj.HuntId)
+		if err == nil && summary.Complete {
+			delete(self.stopped, hunt_obj.HuntId)
+			return nil
+		}
+
+		result = append(result, hunt_obj.HuntId)
+		return nil
+	})
+
+	return result, err
+}
+
+// Stack all the hunts that are ready. Failed hunts are retried the
+// next time.
+func (self *HuntStackingService) ScanHunts(ctx context.Context) error {
+	hunt_ids, err := self.pendingHunts()
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	for _, hunt_id := range hunt_ids {
+		summary, err := stackHunt(ctx, self.config_obj, hunt_id, true)
+		if err != nil {
+			logger.Error("HuntStackingService: stacking %v: %v", hunt_id, err)
+		} else {
+			logger.Info("HuntStackingService: Stacked %v artifact sources of hunt %v",
+				len(summary.Artifacts), hunt_id)
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func NewHuntStackingService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*HuntStackingService, error) {
+
+	result := &HuntStackingService{
+		config_obj: config_obj,
+		stopped:    make(map[string]time.Time),
+	}
+
+	frequency := time.Duration(
+		config_obj.HuntStacking.CheckFrequency) * time.Second
+	if frequency == 0 {
+		frequency = DEFAULT_CHECK_FREQUENCY * time.Second
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> hunt stacking service for %v.",
+		services.GetOrgName(config_obj))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(frequency):
+				err := result.ScanHunts(ctx)
+				if err != nil {
+					logger.Error("HuntStackingService: %v", err)
+				}
+			}
+		}
+	}()
+
+	return result, nil
+}
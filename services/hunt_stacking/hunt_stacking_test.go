@@ -0,0 +1,195 @@
+package hunt_stacking_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/hunt_stacking"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	artifact = "Windows.System.Services"
+)
+
+type HuntStackingTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *HuntStackingTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Services.HuntDispatcher = true
+	self.ConfigObj.HuntStacking = &config_proto.HuntStackingConfig{
+		MaxValues:  4,
+		MaxSamples: 2,
+
+		// Do not let the service scan in the background.
+		CheckFrequency: 10000,
+	}
+
+	self.TestSuite.SetupTest()
+}
+
+// Add a hunt where every client runs the same services except for
+// the first client which also runs a rare one.
+func (self *HuntStackingTestSuite) addHunt(hunt_obj *api_proto.Hunt) {
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	hunt_path_manager := paths.NewHuntPathManager(hunt_obj.HuntId)
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj,
+		hunt_path_manager.Path(), hunt_obj))
+
+	writer, err := result_sets.NewResultSetWriter(
+		file_store.GetFileStore(self.ConfigObj),
+		hunt_path_manager.Results(artifact),
+		json.DefaultEncOpts(), utils.SyncCompleter, result_sets.TruncateMode)
+	assert.NoError(self.T(), err)
+
+	pid := 0
+	for i := 0; i < 4; i++ {
+		client_id := fmt.Sprintf("C.%d", i)
+		names := []string{"Dhcp", "Spooler"}
+		if i == 0 {
+			names = append(names, "Evil")
+		}
+
+		for _, name := range names {
+			pid++
+			writer.Write(ordereddict.NewDict().
+				Set("Name", name).
+				Set("Pid", pid).
+				Set("ClientId", client_id).
+				Set("FlowId", "F.1234"))
+		}
+	}
+	writer.Close()
+
+	hunt_dispatcher, err := services.GetHuntDispatcher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.NoError(self.T(), hunt_dispatcher.Refresh(self.ConfigObj))
+}
+
+func (self *HuntStackingTestSuite) readStack(hunt_id string) []*ordereddict.Dict {
+	reader, err := result_sets.NewResultSetReader(
+		file_store.GetFileStore(self.ConfigObj),
+		paths.NewHuntPathManager(hunt_id).Stack(artifact))
+	assert.NoError(self.T(), err)
+	defer reader.Close()
+
+	result := []*ordereddict.Dict{}
+	for row := range reader.Rows(context.Background()) {
+		result = append(result, row)
+	}
+	return result
+}
+
+func (self *HuntStackingTestSuite) TestStackHunt() {
+	self.addHunt(&api_proto.Hunt{
+		HuntId:          "H.1",
+		State:           api_proto.Hunt_RUNNING,
+		ArtifactSources: []string{artifact},
+	})
+
+	summary, err := hunt_stacking.StackHunt(self.Ctx, self.ConfigObj, "H.1")
+	assert.NoError(self.T(), err)
+
+	// Stacking on demand does not prevent stacking the hunt again
+	// once it is done.
+	assert.False(self.T(), summary.Complete)
+	assert.Equal(self.T(), 1, len(summary.Artifacts))
+
+	stack := summary.Artifacts[0]
+	assert.Equal(self.T(), artifact, stack.Artifact)
+	assert.Equal(self.T(), uint64(9), stack.Rows)
+	assert.Equal(self.T(), uint64(4), stack.Clients)
+
+	// Every Pid is different so it is not stacked and the identity
+	// columns are never stacked.
+	assert.Equal(self.T(), []string{"Name"}, stack.Columns)
+	assert.Equal(self.T(), []string{"Pid"}, stack.Skipped)
+
+	rows := self.readStack("H.1")
+	assert.Equal(self.T(), 3, len(rows))
+
+	// The rarest value comes first.
+	assert.Equal(self.T(), "Evil", utils.GetString(rows[0], "Value"))
+	assert.Equal(self.T(), int64(1), utils.GetInt64(rows[0], "Clients"))
+	assert.Equal(self.T(), "25", fmt.Sprint(utils.GetAny(rows[0], "Frequency")))
+
+	assert.Equal(self.T(), "Spooler", utils.GetString(rows[2], "Value"))
+	assert.Equal(self.T(), int64(4), utils.GetInt64(rows[2], "Clients"))
+	assert.Equal(self.T(), "100", fmt.Sprint(utils.GetAny(rows[2], "Frequency")))
+
+	// Only a sample of the clients is kept.
+	client_ids, _ := rows[2].Get("ClientIds")
+	assert.Equal(self.T(), []interface{}{"C.0", "C.1"}, client_ids)
+
+	// The summary is stored with the hunt.
+	stored, err := hunt_stacking.GetStackSummary(self.ConfigObj, "H.1")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), json.MustMarshalString(summary),
+		json.MustMarshalString(stored))
+}
+
+func (self *HuntStackingTestSuite) TestScanHunts() {
+	now := time.Unix(1600000000, 0)
+	closer := utils.MockTime(&utils.MockClock{MockNow: now})
+	defer closer()
+
+	// Expired an hour ago.
+	self.addHunt(&api_proto.Hunt{
+		HuntId:          "H.expired",
+		State:           api_proto.Hunt_RUNNING,
+		Expires:         uint64(now.Add(-time.Hour).UnixNano() / 1000),
+		ArtifactSources: []string{artifact},
+	})
+
+	// Expired recently - wait for late collections.
+	self.addHunt(&api_proto.Hunt{
+		HuntId:          "H.recent",
+		State:           api_proto.Hunt_RUNNING,
+		Expires:         uint64(now.Add(-time.Minute).UnixNano() / 1000),
+		ArtifactSources: []string{artifact},
+	})
+
+	service, err := hunt_stacking.NewHuntStackingService(
+		self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	assert.NoError(self.T(), service.ScanHunts(self.Ctx))
+
+	summary, err := hunt_stacking.GetStackSummary(self.ConfigObj, "H.expired")
+	assert.NoError(self.T(), err)
+	assert.True(self.T(), summary.Complete)
+
+	_, err = hunt_stacking.GetStackSummary(self.ConfigObj, "H.recent")
+	assert.Error(self.T(), err)
+
+	// Stacked hunts are not stacked again.
+	closer = utils.MockTime(&utils.MockClock{MockNow: now.Add(time.Hour)})
+	defer closer()
+
+	assert.NoError(self.T(), service.ScanHunts(self.Ctx))
+	stored, err := hunt_stacking.GetStackSummary(self.ConfigObj, "H.expired")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), summary.Timestamp, stored.Timestamp)
+}
+
+func TestHuntStacking(t *testing.T) {
+	suite.Run(t, &HuntStackingTestSuite{})
+}
@@ -0,0 +1,350 @@
+package hunt_stacking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	DEFAULT_MAX_VALUES  = 10000
+	DEFAULT_MAX_SAMPLES = 10
+)
+
+var (
+	// The hunt manager adds these columns to identify the
+	// collection - they are never stacked.
+	identity_columns = []string{"FlowId", "ClientId", "Fqdn"}
+)
+
+type ArtifactStack struct {
+	Artifact string `json:"artifact"`
+	Rows     uint64 `json:"rows"`
+	Clients  uint64 `json:"clients"`
+
+	// The columns in the stack.
+	Columns []string `json:"columns"`
+
+	// Columns with too many distinct values to stack.
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+type StackSummary struct {
+	HuntId    string `json:"hunt_id"`
+	Timestamp int64  `json:"timestamp"`
+
+	// Set when the hunt was stacked after it was done so it does
+	// not need to be stacked again.
+	Complete bool `json:"complete"`
+
+	Artifacts []*ArtifactStack `json:"artifacts"`
+}
+
+type valueStats struct {
+	rows        uint64
+	clients     uint64
+	last_client string
+	samples     []string
+}
+
+type columnStats struct {
+	values map[string]*valueStats
+
+	// Set once the column has too many values to stack.
+	skipped bool
+}
+
+// Counts the rows and clients for each value of each column.
+type stacker struct {
+	max_values  int
+	max_samples int
+	exclude     []string
+
+	// Columns in the order they were first seen.
+	columns []string
+	stats   map[string]*columnStats
+
+	rows    uint64
+	clients map[string]bool
+}
+
+func newStacker(config_obj *config_proto.Config) *stacker {
+	result := &stacker{
+		max_values:  DEFAULT_MAX_VALUES,
+		max_samples: DEFAULT_MAX_SAMPLES,
+		exclude:     append([]string{}, identity_columns...),
+		stats:       make(map[string]*columnStats),
+		clients:     make(map[string]bool),
+	}
+
+	stacking_config := config_obj.HuntStacking
+	if stacking_config != nil {
+		if stacking_config.MaxValues > 0 {
+			result.max_values = int(stacking_config.MaxValues)
+		}
+		if stacking_config.MaxSamples > 0 {
+			result.max_samples = int(stacking_config.MaxSamples)
+		}
+		result.exclude = append(result.exclude, stacking_config.ExcludeColumns...)
+	}
+
+	return result
+}
+
+// The hunt manager merges the rows of each collection together, so
+// clients are counted when the client changes between rows rather
+// than keeping a set of clients for every value.
+func (self *stacker) add(row *ordereddict.Dict) {
+	client_id, _ := row.GetString("ClientId")
+	self.rows++
+	self.clients[client_id] = true
+
+	for _, column := range row.Keys() {
+		if utils.InString(self.exclude, column) {
+			continue
+		}
+
+		stats, pres := self.stats[column]
+		if !pres {
+			stats = &columnStats{values: make(map[string]*valueStats)}
+			self.stats[column] = stats
+			self.columns = append(self.columns, column)
+		}
+
+		if stats.skipped {
+			continue
+		}
+
+		value, _ := row.Get(column)
+		key := stackValue(value)
+		value_stats, pres := stats.values[key]
+		if !pres {
+			if len(stats.values) >= self.max_values {
+				stats.skipped = true
+				stats.values = nil
+				continue
+			}
+			value_stats = &valueStats{}
+			stats.values[key] = value_stats
+		}
+
+		value_stats.rows++
+		if value_stats.rows == 1 || value_stats.last_client != client_id {
+			value_stats.clients++
+			value_stats.last_client = client_id
+			if len(value_stats.samples) < self.max_samples &&
+				!utils.InString(value_stats.samples, client_id) {
+				value_stats.samples = append(value_stats.samples, client_id)
+			}
+		}
+	}
+}
+
+// The stacked rows - for each column the least frequent values come
+// first.
+func (self *stacker) Rows() []*ordereddict.Dict {
+	result := []*ordereddict.Dict{}
+	for _, column := range self.columns {
+		stats := self.stats[column]
+		if stats.skipped {
+			continue
+		}
+
+		values := make([]string, 0, len(stats.values))
+		for value := range stats.values {
+			values = append(values, value)
+		}
+
+		sort.Slice(values, func(i, j int) bool {
+			a, b := stats.values[values[i]], stats.values[values[j]]
+			if a.clients != b.clients {
+				return a.clients < b.clients
+			}
+			if a.rows != b.rows {
+				return a.rows < b.rows
+			}
+			return values[i] < values[j]
+		})
+
+		for _, value := range values {
+			value_stats := stats.values[value]
+			result = append(result, ordereddict.NewDict().
+				Set("Column", column).
+				Set("Value", value).
+				Set("Clients", value_stats.clients).
+				Set("Rows", value_stats.rows).
+				Set("Frequency", self.frequency(value_stats.clients)).
+				Set("ClientIds", value_stats.samples))
+		}
+	}
+
+	return result
+}
+
+// The percentage of the clients with results which returned the
+// value.
+func (self *stacker) frequency(clients uint64) float64 {
+	if len(self.clients) == 0 {
+		return 0
+	}
+	return math.Round(
+		float64(clients)*10000/float64(len(self.clients))) / 100
+}
+
+func (self *stacker) Summary(artifact string) *ArtifactStack {
+	result := &ArtifactStack{
+		Artifact: artifact,
+		Rows:     self.rows,
+		Clients:  uint64(len(self.clients)),
+		Columns:  []string{},
+	}
+
+	for _, column := range self.columns {
+		if self.stats[column].skipped {
+			result.Skipped = append(result.Skipped, column)
+		} else {
+			result.Columns = append(result.Columns, column)
+		}
+	}
+	return result
+}
+
+// Values are stacked by their string representation.
+func stackValue(value interface{}) string {
+	switch t := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case time.Time:
+		return t.UTC().Format(time.RFC3339Nano)
+	case int, int64, uint64, float64, bool:
+		return fmt.Sprintf("%v", t)
+	}
+	return json.MustMarshalString(value)
+}
+
+// Stack all the merged results of the hunt and store the stacks next
+// to them.
+func StackHunt(ctx context.Context,
+	config_obj *config_proto.Config, hunt_id string) (*StackSummary, error) {
+	return stackHunt(ctx, config_obj, hunt_id, false)
+}
+
+func stackHunt(ctx context.Context,
+	config_obj *config_proto.Config,
+	hunt_id string, complete bool) (*StackSummary, error) {
+
+	hunt_dispatcher, err := services.GetHuntDispatcher(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	hunt_obj, pres := hunt_dispatcher.GetHunt(hunt_id)
+	if !pres {
+		return nil, errors.New("Hunt not found")
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	path_manager := paths.NewHuntPathManager(hunt_id)
+
+	summary := &StackSummary{
+		HuntId:    hunt_id,
+		Timestamp: utils.GetTime().Now().Unix(),
+		Complete:  complete,
+		Artifacts: []*ArtifactStack{},
+	}
+
+	for _, artifact_source := range hunt_obj.ArtifactSources {
+		reader, err := result_sets.NewResultSetReader(file_store_factory,
+			path_manager.Results(artifact_source))
+		if err != nil {
+			// No results were merged for this source.
+			continue
+		}
+
+		stacker := newStacker(config_obj)
+		for row := range reader.Rows(ctx) {
+			stacker.add(row)
+		}
+		reader.Close()
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		writer, err := result_sets.NewResultSetWriter(file_store_factory,
+			path_manager.Stack(artifact_source), json.DefaultEncOpts(),
+			utils.SyncCompleter, result_sets.TruncateMode)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range stacker.Rows() {
+			writer.Write(row)
+		}
+		writer.Close()
+
+		summary.Artifacts = append(summary.Artifacts,
+			stacker.Summary(artifact_source))
+	}
+
+	serialized, err := json.MarshalIndent(summary)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := file_store_factory.WriteFile(path_manager.StackSummary())
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	err = fd.Truncate()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = fd.Write(serialized)
+	return summary, err
+}
+
+// The summary of the hunt's stacks. Returns os.ErrNotExist if the
+// hunt was not stacked yet.
+func GetStackSummary(config_obj *config_proto.Config,
+	hunt_id string) (*StackSummary, error) {
+	file_store_factory := file_store.GetFileStore(config_obj)
+	fd, err := file_store_factory.ReadFile(
+		paths.NewHuntPathManager(hunt_id).StackSummary())
+	if err != nil {
+		return nil, os.ErrNotExist
+	}
+	defer fd.Close()
+
+	serialized, err := ioutil.ReadAll(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(serialized) == 0 {
+		return nil, os.ErrNotExist
+	}
+
+	result := &StackSummary{}
+	err = json.Unmarshal(serialized, result)
+	return result, err
+}
@@ -69,4 +69,10 @@ type Indexer interface {
 		ctx context.Context,
 		config_obj *config_proto.Config,
 		client_id string) (*api_proto.ApiClient, error)
+
+	// Check if the client is a member of the saved client group.
+	IsClientInGroup(
+		ctx context.Context,
+		config_obj *config_proto.Config,
+		client_id, group string) (bool, error)
 }
@@ -10,6 +10,7 @@ import (
 	"www.velocidex.com/golang/velociraptor/datastore"
 	"www.velocidex.com/golang/velociraptor/paths"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/coalescing"
 )
 
 func GetApiClient(
@@ -87,10 +88,36 @@ func GetApiClient(
 	result.FirstSeenAt = public_key_info.EnrollTime
 	result.LastSeenAt = client_info.Ping
 	result.LastIp = client_info.IpAddress
+	result.Quarantined = client_info.Quarantined
+	result.Health = getClientHealth(ctx, config_obj, client_id)
+
+	lineage, err := coalescing.GetLineage(config_obj, client_id)
+	if err == nil && (lineage.SupersededBy != "" ||
+		len(lineage.PreviousClientIds) > 0) {
+		result.Lineage = lineage
+	}
 
 	return result, nil
 }
 
+// The health is only computed on the master so it may not be
+// available.
+func getClientHealth(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id string) *api_proto.ClientHealth {
+	client_health, err := services.GetClientHealthManager(config_obj)
+	if err != nil {
+		return nil
+	}
+
+	health, err := client_health.GetClientHealth(ctx, client_id)
+	if err != nil {
+		return nil
+	}
+	return health
+}
+
 // A fast way of getting some client information. Reduces reads from
 // the backend by caching as much data as possible - may not be the
 // most up to date information but does contain the latest ping
@@ -142,5 +169,7 @@ func (self *Indexer) FastGetApiClient(
 		LastIp:                      client_info.IpAddress,
 		LastInterrogateFlowId:       client_info.LastInterrogateFlowId,
 		LastInterrogateArtifactName: client_info.LastInterrogateArtifactName,
+		Quarantined:                 client_info.Quarantined,
+		Health:                      getClientHealth(ctx, config_obj, client_id),
 	}, nil
 }
@@ -0,0 +1,478 @@
+package indexing
+
+// Saved client groups.
+
+// A client group is a named search query which is evaluated against
+// the client records. The query is a list of space separated terms
+// which must all match:
+
+// label:<glob>     The client has a matching label.
+// host:<glob>      The client's hostname or fqdn matches.
+// mac:<glob>       One of the client's MAC addresses matches.
+// client:<glob>    The client id matches.
+// ip:<glob>        The last IP address the client connected from.
+// os:<glob>        The operating system (windows, linux, darwin).
+// release:<glob>   The OS release as reported by interrogation.
+// arch:<glob>      The machine architecture (e.g. amd64).
+// version:<glob>   The Velociraptor client version.
+// seen:<24h        The client was last seen within the duration
+//                  (seen:>7d selects clients not seen for 7 days).
+// enrolled:<30d    The client enrolled within the duration.
+// health:<50       The client's health score is below (or with >
+//                  above) the value.
+
+// Globs are case insensitive and durations may use the s, m, h and d
+// units. Prefixing a term with - excludes matching clients.
+
+// Since groups are evaluated when they are used, their membership
+// follows the clients as they are interrogated, labeled or go
+// offline.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/glob"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+)
+
+var (
+	InvalidGroupError = errors.New("Invalid client group")
+)
+
+type groupTerm struct {
+	negate bool
+	field  string
+
+	// For glob terms.
+	filter *regexp.Regexp
+
+	// For time range terms: match clients older or newer than age.
+	older bool
+	age   time.Duration
+
+	// For score terms: match clients above or below the score.
+	above bool
+	score uint64
+}
+
+type groupQuery struct {
+	terms []groupTerm
+}
+
+func parseGroupQuery(query string) (*groupQuery, error) {
+	result := &groupQuery{}
+
+	for _, term := range strings.Fields(query) {
+		item := groupTerm{}
+		if strings.HasPrefix(term, "-") {
+			item.negate = true
+			term = term[1:]
+		}
+
+		parts := strings.SplitN(term, ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("%w: term %v should be of the form field:value",
+				InvalidGroupError, term)
+		}
+
+		item.field = strings.ToLower(parts[0])
+		value := parts[1]
+
+		switch item.field {
+		case "label", "host", "mac", "client", "ip",
+			"os", "release", "arch", "version":
+			filter, err := regexp.Compile(
+				"(?i)^" + glob.FNmatchTranslate(value))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v: %v",
+					InvalidGroupError, term, err)
+			}
+			item.filter = filter
+
+		case "seen", "enrolled":
+			switch value[0] {
+			case '<':
+			case '>':
+				item.older = true
+			default:
+				return nil, fmt.Errorf(
+					"%w: %v should be of the form %v:<duration or %v:>duration",
+					InvalidGroupError, term, item.field, item.field)
+			}
+
+			age, err := parseAge(value[1:])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v: %v",
+					InvalidGroupError, term, err)
+			}
+			item.age = age
+
+		case "health":
+			switch value[0] {
+			case '<':
+			case '>':
+				item.above = true
+			default:
+				return nil, fmt.Errorf(
+					"%w: %v should be of the form health:<score or health:>score",
+					InvalidGroupError, term)
+			}
+
+			score, err := strconv.ParseUint(value[1:], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v: %v",
+					InvalidGroupError, term, err)
+			}
+			item.score = score
+
+		default:
+			return nil, fmt.Errorf("%w: unknown search field %v",
+				InvalidGroupError, item.field)
+		}
+
+		result.terms = append(result.terms, item)
+	}
+
+	if len(result.terms) == 0 {
+		return nil, fmt.Errorf("%w: query must be set", InvalidGroupError)
+	}
+
+	return result, nil
+}
+
+// Durations may be specified in days in addition to the units Go
+// understands.
+func parseAge(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.ParseUint(strings.TrimSuffix(value, "d"), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(value)
+}
+
+func (self *groupQuery) Match(now time.Time, client *api_proto.ApiClient) bool {
+	for _, term := range self.terms {
+		if term.match(now, client) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (self *groupTerm) match(now time.Time, client *api_proto.ApiClient) bool {
+	os_info := client.OsInfo
+	if os_info == nil {
+		os_info = &api_proto.Uname{}
+	}
+
+	switch self.field {
+	case "label":
+		return self.matchAny(client.Labels...)
+
+	case "host":
+		return self.matchAny(os_info.Hostname, os_info.Fqdn)
+
+	case "mac":
+		return self.matchAny(os_info.MacAddresses...)
+
+	case "client":
+		return self.matchAny(client.ClientId)
+
+	case "ip":
+		return self.matchAny(client.LastIp)
+
+	case "os":
+		return self.matchAny(os_info.System)
+
+	case "release":
+		return self.matchAny(os_info.Release)
+
+	case "arch":
+		return self.matchAny(os_info.Machine)
+
+	case "version":
+		if client.AgentInformation == nil {
+			return false
+		}
+		return self.matchAny(client.AgentInformation.Version)
+
+	case "seen":
+		// Microseconds
+		return self.matchAge(now, time.UnixMicro(int64(client.LastSeenAt)))
+
+	case "enrolled":
+		// Seconds
+		return self.matchAge(now, time.Unix(int64(client.FirstSeenAt), 0))
+
+	case "health":
+		// Clients which were not scored yet do not match either way.
+		if client.Health == nil {
+			return false
+		}
+		if self.above {
+			return client.Health.Score > self.score
+		}
+		return client.Health.Score < self.score
+	}
+
+	return false
+}
+
+func (self *groupTerm) matchAny(values ...string) bool {
+	for _, value := range values {
+		if value != "" && self.filter.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *groupTerm) matchAge(now, timestamp time.Time) bool {
+	age := now.Sub(timestamp)
+	if self.older {
+		return age > self.age
+	}
+	return age < self.age
+}
+
+func ValidateClientGroup(group *api_proto.ClientGroup) error {
+	if group.Name == "" {
+		return fmt.Errorf("%w: name must be set", InvalidGroupError)
+	}
+
+	_, err := parseGroupQuery(group.Query)
+	return err
+}
+
+func GetClientGroup(config_obj *config_proto.Config,
+	name string) (*api_proto.ClientGroup, error) {
+	result := &api_proto.ClientGroup{}
+	err := datastore.GetExistingSubject(config_obj,
+		paths.CLIENT_GROUPS_ROOT.AddChild(name), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func SetClientGroup(config_obj *config_proto.Config,
+	group *api_proto.ClientGroup) error {
+	err := ValidateClientGroup(group)
+	if err != nil {
+		return err
+	}
+
+	urn := paths.CLIENT_GROUPS_ROOT.AddChild(group.Name)
+	unlock, err := datastore.LockSubject(config_obj, urn)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	group.ModifiedTime = uint64(utils.GetTime().Now().Unix())
+	return db.SetSubject(config_obj, urn, group)
+}
+
+func DeleteClientGroup(config_obj *config_proto.Config, name string) error {
+	return datastore.DeleteSubject(config_obj,
+		paths.CLIENT_GROUPS_ROOT.AddChild(name), &api_proto.ClientGroup{})
+}
+
+func ListClientGroups(
+	config_obj *config_proto.Config) ([]*api_proto.ClientGroup, error) {
+	children, err := datastore.ListSubjects(config_obj, paths.CLIENT_GROUPS_ROOT)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api_proto.ClientGroup, 0, len(children))
+	for _, child := range children {
+		group, err := GetClientGroup(config_obj, child.Base())
+		if err != nil {
+			continue
+		}
+		result = append(result, group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// Check if the client is currently a member of the group.
+func (self *Indexer) IsClientInGroup(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id, name string) (bool, error) {
+
+	group, err := GetClientGroup(config_obj, name)
+	if err != nil {
+		return false, err
+	}
+
+	query, err := parseGroupQuery(group.Query)
+	if err != nil {
+		return false, err
+	}
+
+	api_client, err := self.FastGetApiClient(ctx, config_obj, client_id)
+	if err != nil {
+		return false, err
+	}
+
+	return query.Match(utils.GetTime().Now(), api_client), nil
+}
+
+// Enumerate the members of the group.
+func (self *Indexer) searchGroupChan(
+	ctx context.Context,
+	scope vfilter.Scope,
+	config_obj *config_proto.Config,
+	name string) (chan *api_proto.ApiClient, error) {
+
+	group, err := GetClientGroup(config_obj, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.searchQueryChan(ctx, scope, config_obj, group.Query)
+}
+
+// Enumerate the clients matching the query.
+func (self *Indexer) searchQueryChan(
+	ctx context.Context,
+	scope vfilter.Scope,
+	config_obj *config_proto.Config,
+	query_str string) (chan *api_proto.ApiClient, error) {
+
+	query, err := parseGroupQuery(query_str)
+	if err != nil {
+		return nil, err
+	}
+
+	search_chan, err := self.searchClientIndexChan(
+		ctx, scope, config_obj, "all")
+	if err != nil {
+		return nil, err
+	}
+
+	output_chan := make(chan *api_proto.ApiClient)
+
+	go func() {
+		defer close(output_chan)
+
+		now := utils.GetTime().Now()
+		for api_client := range search_chan {
+			if !query.Match(now, api_client) {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- api_client:
+			}
+		}
+	}()
+
+	return output_chan, nil
+}
+
+func (self *Indexer) searchGroup(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	in *api_proto.SearchClientsRequest,
+	name string, limit uint64) (*api_proto.SearchClientsResponse, error) {
+
+	// Complete on the group names for the suggestion box.
+	if in.NameOnly {
+		result := &api_proto.SearchClientsResponse{}
+		groups, err := ListClientGroups(config_obj)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, group := range groups {
+			if strings.HasPrefix(strings.ToLower(group.Name),
+				strings.ToLower(name)) {
+				result.Names = append(result.Names, "group:"+group.Name)
+			}
+		}
+		return result, nil
+	}
+
+	group, err := GetClientGroup(config_obj, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.searchQuery(ctx, config_obj, in, group.Query, limit)
+}
+
+func (self *Indexer) searchQuery(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	in *api_proto.SearchClientsRequest,
+	query string, limit uint64) (*api_proto.SearchClientsResponse, error) {
+
+	result := &api_proto.SearchClientsResponse{}
+
+	// Stop enumerating once we have enough results.
+	sub_ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	scope := vql_subsystem.MakeScope()
+	search_chan, err := self.searchQueryChan(sub_ctx, scope, config_obj, query)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uint64(time.Now().UnixNano() / 1000)
+	total_count := 0
+
+	for api_client := range search_chan {
+		// Skip clients that are offline
+		if in.Filter == api_proto.SearchClientsRequest_ONLINE &&
+			now > api_client.LastSeenAt &&
+			now-api_client.LastSeenAt > 1000000*60*15 {
+			continue
+		}
+
+		total_count++
+		if uint64(total_count) < in.Offset {
+			continue
+		}
+
+		result.Items = append(result.Items, api_client)
+		if uint64(len(result.Items)) > limit {
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
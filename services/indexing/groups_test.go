@@ -0,0 +1,140 @@
+package indexing_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/alecthomas/assert"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/indexing"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+)
+
+func (self *TestSuite) addGroupClient(
+	client_id, hostname, system string, last_seen time.Time,
+	labels ...string) {
+	client_info_manager, err := services.GetClientInfoManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	err = client_info_manager.Set(self.Ctx, &services.ClientInfo{
+		ClientInfo: actions_proto.ClientInfo{
+			ClientId: client_id,
+			Hostname: hostname,
+			System:   system,
+			Ping:     uint64(last_seen.UnixNano() / 1000),
+		}})
+	assert.NoError(self.T(), err)
+
+	indexer, err := services.GetIndexer(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	err = indexer.SetIndex(client_id, "all")
+	assert.NoError(self.T(), err)
+
+	labeler := services.GetLabeler(self.ConfigObj)
+	for _, label := range labels {
+		err = labeler.SetClientLabel(self.Ctx, self.ConfigObj, client_id, label)
+		assert.NoError(self.T(), err)
+	}
+}
+
+func (self *TestSuite) searchGroup(name string) []string {
+	indexer, err := services.GetIndexer(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	ctx := context.Background()
+	scope := vql_subsystem.MakeScope()
+	search_chan, err := indexer.SearchClientsChan(
+		ctx, scope, self.ConfigObj, "group:"+name, "")
+	assert.NoError(self.T(), err)
+
+	result := []string{}
+	for hit := range search_chan {
+		result = append(result, hit.ClientId)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (self *TestSuite) TestClientGroups() {
+	now := utils.GetTime().Now()
+	self.addGroupClient("C.1000000000000001", "dc01", "windows",
+		now, "Servers")
+	self.addGroupClient("C.1000000000000002", "web01", "linux",
+		now, "Servers")
+	self.addGroupClient("C.1000000000000003", "laptop01", "windows",
+		now.Add(-10*24*time.Hour))
+
+	for _, group := range []*api_proto.ClientGroup{
+		{Name: "Windows Servers", Query: "label:servers os:windows"},
+		{Name: "Stale", Query: "seen:>7d"},
+		{Name: "Active Windows", Query: "os:win* -seen:>1d"},
+		{Name: "Not servers", Query: "-label:Servers client:C.1*"},
+	} {
+		err := indexing.SetClientGroup(self.ConfigObj, group)
+		assert.NoError(self.T(), err)
+	}
+
+	assert.Equal(self.T(), []string{"C.1000000000000001"},
+		self.searchGroup("Windows Servers"))
+	assert.Equal(self.T(), []string{"C.1000000000000003"},
+		self.searchGroup("Stale"))
+	assert.Equal(self.T(), []string{"C.1000000000000001"},
+		self.searchGroup("Active Windows"))
+	assert.Equal(self.T(), []string{"C.1000000000000003"},
+		self.searchGroup("Not servers"))
+
+	indexer, err := services.GetIndexer(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	member, err := indexer.IsClientInGroup(self.Ctx, self.ConfigObj,
+		"C.1000000000000002", "Windows Servers")
+	assert.NoError(self.T(), err)
+	assert.False(self.T(), member)
+
+	// Labeling the client changes its membership.
+	labeler := services.GetLabeler(self.ConfigObj)
+	err = labeler.SetClientLabel(self.Ctx, self.ConfigObj,
+		"C.1000000000000003", "Servers")
+	assert.NoError(self.T(), err)
+
+	member, err = indexer.IsClientInGroup(self.Ctx, self.ConfigObj,
+		"C.1000000000000003", "Windows Servers")
+	assert.NoError(self.T(), err)
+	assert.True(self.T(), member)
+
+	groups, err := indexing.ListClientGroups(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 4, len(groups))
+	assert.Equal(self.T(), "Active Windows", groups[0].Name)
+
+	err = indexing.DeleteClientGroup(self.ConfigObj, "Stale")
+	assert.NoError(self.T(), err)
+
+	_, err = indexer.IsClientInGroup(self.Ctx, self.ConfigObj,
+		"C.1000000000000003", "Stale")
+	assert.Error(self.T(), err)
+}
+
+func (self *TestSuite) TestClientGroupValidation() {
+	for _, query := range []string{
+		"",
+		"windows",
+		"color:red",
+		"seen:24h",
+		"seen:<yesterday",
+		"health:50",
+		"health:<high",
+	} {
+		err := indexing.SetClientGroup(self.ConfigObj, &api_proto.ClientGroup{
+			Name: "Invalid", Query: query,
+		})
+		assert.True(self.T(), errors.Is(err, indexing.InvalidGroupError),
+			"Expected %v to be invalid", query)
+	}
+}
@@ -17,6 +17,11 @@
 // terms. We dump the btree into disk periodically called a
 // Snapshot. Reading and writing the snapshot is quite fast.
 
+// To keep snapshots and searches fast with hundreds of thousands of
+// clients, the btree is split into shards by client id (see
+// shards.go). Each shard is snapshotted into its own file and only
+// shards that changed are rewritten.
+
 // The master node is responsible for maintaining the snapshot in sync
 // - While the snapshot may be read by any node, the master is the
 // only node that is allowed to write it.
@@ -104,12 +109,10 @@ func (self Record) Less(than btree.Item) bool {
 }
 
 type Indexer struct {
-	mu    sync.Mutex
-	btree *btree.BTree
-	items int
+	mu     sync.Mutex
+	shards []*indexShard
 
 	ready bool
-	dirty bool
 
 	last_snapshot_read time.Time
 
@@ -117,24 +120,41 @@ type Indexer struct {
 }
 
 func NewIndexer(config_obj *config_proto.Config) *Indexer {
+	shards := make([]*indexShard, 0, numShards)
+	for i := 0; i < numShards; i++ {
+		shards = append(shards, newIndexShard())
+	}
+
 	return &Indexer{
-		btree:      btree.New(10),
+		shards:     shards,
 		config_obj: config_obj,
 	}
 }
 
+func (self *Indexer) shardFor(entity string) *indexShard {
+	return self.shards[shardForEntity(entity)]
+}
+
 func (self *Indexer) ItemCount() int {
+	count := 0
+	for _, shard := range self.shards {
+		count += shard.Len()
+	}
+	return count
+}
+
+func (self *Indexer) IsReady() bool {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
-	return self.items
+	return self.ready
 }
 
-func (self *Indexer) IsReady() bool {
+func (self *Indexer) setReady() {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
-	return self.ready
+	self.ready = true
 }
 
 // Check for newer snapshot files we need to load.
@@ -150,6 +170,10 @@ func (self *Indexer) ScanForNewSnapshots(
 		return nil
 	}
 
+	self.mu.Lock()
+	last_snapshot_read := self.last_snapshot_read
+	self.mu.Unlock()
+
 	for _, child := range children {
 		int_value, ok := utils.ToInt64(child.Name())
 		if !ok {
@@ -157,7 +181,7 @@ func (self *Indexer) ScanForNewSnapshots(
 		}
 
 		timestamp := time.Unix(int_value, 0)
-		if timestamp.After(self.last_snapshot_read) {
+		if timestamp.After(last_snapshot_read) {
 			// Reload the index.
 			logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
 			logger.Info("Reloading index snapshot %v",
@@ -168,18 +192,23 @@ func (self *Indexer) ScanForNewSnapshots(
 	return nil
 }
 
+// Write the entire index into a single snapshot file. This is used
+// by "velociraptor index rebuild" to hand the index over to the
+// master.
 func (self *Indexer) WriteSnapshot(
 	config_obj *config_proto.Config, dest api.FSPathSpec) error {
 
-	// Check if we need to flush the index, if not we can skip it.
-	self.mu.Lock()
-	defer self.mu.Unlock()
+	results := make([][]Record, 0, len(self.shards))
+	count := 0
+	for _, shard := range self.shards {
+		records := shard.SearchPrefix("")
+		count += len(records)
+		results = append(results, records)
+	}
 
-	// Only write the snapshot if we need to.
-	if !self.dirty || self.btree.Len() == 0 {
+	if count == 0 {
 		return nil
 	}
-	self.dirty = false
 
 	now := time.Now()
 
@@ -187,7 +216,7 @@ func (self *Indexer) WriteSnapshot(
 		logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
 		logger.Debug("<green>Indexing Service</>: Wrote index on %v in %v (%v entries)\n",
 			dest.AsFilestoreFilename(config_obj),
-			time.Now().Sub(now), self.btree.Len())
+			time.Now().Sub(now), count)
 	}()
 
 	// Write the snapshot syncronously to make sure it hits the
@@ -201,74 +230,181 @@ func (self *Indexer) WriteSnapshot(
 	}
 	defer rs_writer.Close()
 
-	// Just write them all down.
-	self.btree.Ascend(func(i btree.Item) bool {
-		record := i.(Record)
-		row := ordereddict.NewDict().
+	mergeShardResults(results, func(record Record) bool {
+		rs_writer.Write(ordereddict.NewDict().
 			Set("Entity", record.Entity).
-			Set("Term", record.Term)
-
-		rs_writer.Write(row)
-
+			Set("Term", record.Term))
 		return true
 	})
 	return nil
 }
 
-func (self *Indexer) Ready() bool {
-	self.mu.Lock()
-	defer self.mu.Unlock()
+// Write the snapshots of all shards that changed since they were
+// last written.
+func (self *Indexer) WriteShardSnapshots(
+	config_obj *config_proto.Config) error {
+	path_manager := paths.NewIndexPathManager()
 
-	return self.ready
+	for idx, shard := range self.shards {
+		if !shard.IsDirty() {
+			continue
+		}
+
+		err := self.writeShardSnapshot(config_obj, shard,
+			path_manager.ShardSnapshot(idx))
+		if err != nil {
+			// Try again next time.
+			shard.SetDirty(true)
+			return err
+		}
+	}
+	return nil
 }
 
-func (self *Indexer) Items() int {
-	self.mu.Lock()
-	defer self.mu.Unlock()
+func (self *Indexer) writeShardSnapshot(
+	config_obj *config_proto.Config,
+	shard *indexShard, dest api.FSPathSpec) error {
 
-	return self.items
-}
+	tree := shard.Snapshot()
+	now := time.Now()
 
-func (self *Indexer) AscendGreaterOrEqual(
-	record Record, iterator btree.ItemIterator) {
-	self.mu.Lock()
-	defer self.mu.Unlock()
+	defer func() {
+		logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+		logger.Debug("<green>Indexing Service</>: Wrote index shard on %v in %v (%v entries)\n",
+			dest.AsFilestoreFilename(config_obj),
+			time.Now().Sub(now), tree.Len())
+	}()
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	rs_writer, err := result_sets.NewResultSetWriter(
+		file_store_factory, dest, json.DefaultEncOpts(),
+		utils.SyncCompleter, result_sets.TruncateMode)
+	if err != nil {
+		return err
+	}
+	defer rs_writer.Close()
 
-	self.btree.AscendGreaterOrEqual(record, iterator)
+	tree.Ascend(func(i btree.Item) bool {
+		record := i.(Record)
+		rs_writer.Write(ordereddict.NewDict().
+			Set("Entity", record.Entity).
+			Set("Term", record.Term))
+		return true
+	})
+	return nil
 }
 
-func (self *Indexer) Ascend(iterator btree.ItemIterator) {
-	self.mu.Lock()
-	defer self.mu.Unlock()
+func (self *Indexer) Ready() bool {
+	return self.IsReady()
+}
 
-	self.btree.Ascend(iterator)
+func (self *Indexer) Items() int {
+	return self.ItemCount()
 }
 
-// A much faster alternative - store all the client index in a single
-// file and read it at once.
+// Load the index from the shard snapshots. Older versions wrote the
+// index into a single snapshot file which we fall back to.
 func (self *Indexer) LoadIndexFromSnapshot(
 	ctx context.Context,
 	config_obj *config_proto.Config) error {
 
+	err := self.LoadShardSnapshots(ctx, config_obj)
+	if err == nil {
+		return nil
+	}
+
 	path_manager := paths.NewIndexPathManager()
 	return self.LoadSnapshot(ctx, config_obj, path_manager.Snapshot())
 }
 
+// Load all shard snapshots in parallel.
+func (self *Indexer) LoadShardSnapshots(
+	ctx context.Context,
+	config_obj *config_proto.Config) error {
+
+	now := time.Now()
+	path_manager := paths.NewIndexPathManager()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	total := 0
+
+	for idx := range self.shards {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+
+			count, _ := self.loadSnapshotFile(
+				ctx, config_obj, path_manager.ShardSnapshot(idx))
+
+			mu.Lock()
+			total += count
+			mu.Unlock()
+		}(idx)
+	}
+	wg.Wait()
+
+	if total == 0 {
+		return errors.New("No snapshot")
+	}
+
+	// The index now matches the shard snapshots.
+	for _, shard := range self.shards {
+		shard.SetDirty(false)
+	}
+
+	self.mu.Lock()
+	self.last_snapshot_read = now
+	self.ready = true
+	self.mu.Unlock()
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Loaded index from %v shard snapshots</> in %v (%v entries)\n",
+		len(self.shards), time.Now().Sub(now), total)
+
+	return nil
+}
+
+// Load a single snapshot file into the index. All shards that
+// receive new terms will be written out on the next snapshot.
 func (self *Indexer) LoadSnapshot(
 	ctx context.Context,
 	config_obj *config_proto.Config,
 	pathspec api.FSPathSpec) error {
 
+	now := time.Now()
+
 	self.mu.Lock()
-	defer self.mu.Unlock()
+	self.last_snapshot_read = now
+	self.mu.Unlock()
+
+	count, err := self.loadSnapshotFile(ctx, config_obj, pathspec)
+	if err != nil {
+		return err
+	}
 
-	self.last_snapshot_read = time.Now()
+	if count == 0 {
+		return errors.New("No snapshot")
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Loaded index from snapshot</> in %v\n",
+		time.Now().Sub(now))
+
+	self.setReady()
+	return nil
+}
+
+func (self *Indexer) loadSnapshotFile(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	pathspec api.FSPathSpec) (int, error) {
 
 	file_store_factory := file_store.GetFileStore(config_obj)
 	rs_reader, err := result_sets.NewResultSetReader(
 		file_store_factory, pathspec)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer rs_reader.Close()
 
@@ -293,30 +429,21 @@ func (self *Indexer) LoadSnapshot(
 
 		// We should be able to search for the client by client id
 		// directly.
-		self.setIndex(entity, entity)
-		self.setIndex(entity, term)
+		_ = self.SetIndex(entity, entity)
+		_ = self.SetIndex(entity, term)
 		count++
 	}
 
-	if count == 0 {
-		return errors.New("No snapshot")
+	if count > 0 {
+		go func() {
+			for c := range clients {
+				// Get the full record to warm up all client attributes.
+				_, _ = self.FastGetApiClient(ctx, config_obj, c)
+			}
+		}()
 	}
 
-	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
-	logger.Info("<green>Loaded index from snapshot</> in %v\n",
-		time.Now().Sub(self.last_snapshot_read))
-
-	self.ready = true
-	self.dirty = false
-
-	go func() {
-		for c := range clients {
-			// Get the full record to warm up all client attributes.
-			_, _ = self.FastGetApiClient(ctx, config_obj, c)
-		}
-	}()
-
-	return nil
+	return count, nil
 }
 
 func (self *Indexer) Start(
@@ -359,15 +486,16 @@ func (self *Indexer) Start(
 			select {
 			case <-ctx.Done():
 				// When we are done, force a snapshot to be written.
-				self.WriteSnapshot(
-					config_obj, paths.NewIndexPathManager().Snapshot())
+				self.WriteShardSnapshots(config_obj)
 				return
 
 			case <-time.After(snapshot_wait):
-				// Write the snapshot if needed (this is noop if the
+				// Write the dirty shards (this is noop if the
 				// indexer is not dirty).
-				self.WriteSnapshot(
-					config_obj, paths.NewIndexPathManager().Snapshot())
+				err := self.WriteShardSnapshots(config_obj)
+				if err != nil {
+					logger.Error("WriteShardSnapshots: %v", err)
+				}
 			}
 		}
 	}()
@@ -375,41 +503,27 @@ func (self *Indexer) Start(
 
 // Set in memory indexer - it will be flushed later.
 func (self *Indexer) SetIndex(client_id, term string) error {
-	self.mu.Lock()
-	defer self.mu.Unlock()
-
-	return self.setIndex(client_id, term)
-}
-
-func (self *Indexer) setIndex(client_id, term string) error {
 	record := NewRecord(&api_proto.IndexRecord{
 		Term:   term,
 		Entity: client_id,
 	})
 
-	old := self.btree.ReplaceOrInsert(record)
-	if old == nil {
-		self.items++
-		self.dirty = true
+	if self.shardFor(client_id).Set(record) {
+		metricLRUTotalTerms.Inc()
 	}
-	metricLRUTotalTerms.Inc()
 	return nil
 }
 
 // Remove from memory indexer
 func (self *Indexer) UnsetIndex(client_id, term string) error {
-	self.mu.Lock()
-	defer self.mu.Unlock()
-
 	record := NewRecord(&api_proto.IndexRecord{
 		Term:   term,
 		Entity: client_id,
 	})
 
-	self.btree.Delete(record)
-	self.items--
-	metricLRUTotalTerms.Dec()
-
+	if self.shardFor(client_id).Unset(record) {
+		metricLRUTotalTerms.Dec()
+	}
 	return nil
 }
 
@@ -425,34 +539,28 @@ func (self *Indexer) SearchIndexWithPrefix(
 	go func() {
 		defer close(output_chan)
 
-		// Take a local copy of all results to avoid having a lock on
-		// the search index.
-		results := []*Record{}
-
-		// Walk the btree and get all prefixes
-		self.AscendGreaterOrEqual(Record{
-			IndexTerm: prefix,
-		}, func(i btree.Item) bool {
-			record := i.(Record)
-
-			// Detect when we exceeded the prefix constraint to quit
-			// early.
-			if !strings.HasPrefix(record.IndexTerm, prefix) {
-				return false
-			}
-
-			results = append(results, &record)
-			return true
-		})
+		// Take a local copy of all results from each shard in
+		// parallel to avoid holding locks on the search index.
+		results := make([][]Record, len(self.shards))
+		var wg sync.WaitGroup
+		for idx, shard := range self.shards {
+			wg.Add(1)
+			go func(idx int, shard *indexShard) {
+				defer wg.Done()
+				results[idx] = shard.SearchPrefix(prefix)
+			}(idx, shard)
+		}
+		wg.Wait()
 
-		for _, record := range results {
+		mergeShardResults(results, func(record Record) bool {
 			select {
 			case <-ctx.Done():
-				return
+				return false
 
 			case output_chan <- record.IndexRecord:
+				return true
 			}
-		}
+		})
 	}()
 
 	return output_chan
@@ -6,15 +6,22 @@ import (
 	"strings"
 	"time"
 
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/datastore"
 	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services/coalescing"
 )
 
 // Load all the client records slowly and rebuild the index. This
 // takes a long time. It mirrors the job of the interrogation service
 // and so should be kept in sync with it.
+
+// The index is rebuilt one shard at a time: each shard is built on
+// the side and swapped in when complete, so the rest of the index
+// remains searchable and updates made during the rebuild are not
+// lost.
 func (self *Indexer) LoadIndexFromDatastore(
 	ctx context.Context, config_obj *config_proto.Config) error {
 
@@ -31,15 +38,16 @@ func (self *Indexer) LoadIndexFromDatastore(
 		return err
 	}
 
-	now := time.Now()
-	count := 0
-	for _, child := range children {
-		select {
-		case <-ctx.Done():
-			return errors.New("Cancelled")
-		default:
-		}
+	// Clients replaced by a newer client of the same machine are
+	// only found by their client id.
+	superseded, err := coalescing.GetSupersededClients(config_obj)
+	if err != nil {
+		return err
+	}
 
+	// Group the clients by the shard they belong to.
+	clients_by_shard := make([][]string, len(self.shards))
+	for _, child := range children {
 		if child.IsDir() {
 			continue
 		}
@@ -49,41 +57,77 @@ func (self *Indexer) LoadIndexFromDatastore(
 			continue
 		}
 
-		client_info, err := self.FastGetApiClient(ctx, config_obj, child.Base())
-		if err != nil {
-			continue
-		}
+		idx := shardForEntity(client_id)
+		clients_by_shard[idx] = append(clients_by_shard[idx], client_id)
+	}
 
-		count++
+	now := time.Now()
+	count := 0
+	for idx, client_ids := range clients_by_shard {
+		shard := self.shards[idx]
+		shard.StartRebuild()
+
+		for _, client_id := range client_ids {
+			select {
+			case <-ctx.Done():
+				shard.AbortRebuild()
+				return errors.New("Cancelled")
+			default:
+			}
 
-		// The all item corresponds to the "." search term.
-		self.SetIndex(client_id, "all")
+			client_info, err := self.FastGetApiClient(ctx, config_obj, client_id)
+			if err != nil {
+				continue
+			}
 
-		if client_info.OsInfo.Hostname != "" {
-			self.SetIndex(client_id, "host:"+client_info.OsInfo.Hostname)
-		}
+			count++
 
-		// Add labels to the index.
-		for _, label := range client_info.Labels {
-			self.SetIndex(client_id, "label:"+strings.ToLower(label))
-		}
+			terms := clientTerms(client_info)
+			if superseded[client_id] {
+				terms = terms[:1]
+			}
 
-		// Add MAC addresses to the index.
-		if client_info.OsInfo != nil {
-			for _, mac := range client_info.OsInfo.MacAddresses {
-				self.SetIndex(client_id, "mac:"+mac)
+			for _, term := range terms {
+				shard.AddToRebuild(NewRecord(&api_proto.IndexRecord{
+					Term:   term,
+					Entity: client_id,
+				}))
 			}
 		}
+
+		metricLRUTotalTerms.Add(float64(shard.CommitRebuild()))
 	}
 
 	logger.Info("<green>Indexing service</> search index loaded %v items in %v",
 		count, time.Now().Sub(now))
 
-	// Merge the new index quickly and mark ourselves as ready.
-	self.mu.Lock()
-	self.ready = true
-	self.dirty = true
-	self.mu.Unlock()
+	// Mark ourselves as ready.
+	self.setReady()
 
 	return nil
 }
+
+// The terms a client is indexed under.
+func clientTerms(client_info *api_proto.ApiClient) []string {
+	// We should be able to search for the client by client id
+	// directly. The all item corresponds to the "." search term.
+	result := []string{client_info.ClientId, "all"}
+
+	if client_info.OsInfo != nil && client_info.OsInfo.Hostname != "" {
+		result = append(result, "host:"+client_info.OsInfo.Hostname)
+	}
+
+	// Add labels to the index.
+	for _, label := range client_info.Labels {
+		result = append(result, "label:"+strings.ToLower(label))
+	}
+
+	// Add MAC addresses to the index.
+	if client_info.OsInfo != nil {
+		for _, mac := range client_info.OsInfo.MacAddresses {
+			result = append(result, "mac:"+mac)
+		}
+	}
+
+	return result
+}
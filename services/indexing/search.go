@@ -24,6 +24,8 @@ var (
 		"client:",
 		"recent:",
 		"ip:",
+		"group:",
+		"health:",
 	}
 )
 
@@ -136,6 +138,16 @@ func (self *Indexer) SearchClients(
 	case "ip":
 		return self.searchLastIP(ctx, config_obj, in, term, limit)
 
+	case "group":
+		return self.searchGroup(ctx, config_obj, in, term, limit)
+
+	case "health":
+		// There is nothing to complete for a score.
+		if in.NameOnly {
+			return &api_proto.SearchClientsResponse{}, nil
+		}
+		return self.searchQuery(ctx, config_obj, in, in.Query, limit)
+
 	default:
 		return self.searchVerbs(ctx, config_obj, in, limit)
 	}
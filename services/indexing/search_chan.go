@@ -83,6 +83,12 @@ func (self *Indexer) SearchClientsChan(
 	case "recent":
 		return self.searchRecentsChan(ctx, scope, config_obj, principal)
 
+	case "group":
+		return self.searchGroupChan(ctx, scope, config_obj, term)
+
+	case "health":
+		return self.searchQueryChan(ctx, scope, config_obj, search_term)
+
 	default:
 		return nil, errors.New("Invalid search operator " + operator)
 	}
@@ -0,0 +1,245 @@
+package indexing
+
+// The index is split into shards by client id. Each shard has its own
+// btree, lock and snapshot file so that with a very large number of
+// clients:
+
+// 1. Updating a client only locks (and dirties) a single shard.
+
+// 2. Only the shards that changed are written to the filestore when
+//    the index is snapshotted.
+
+// 3. Shard snapshots are loaded in parallel at startup.
+
+// 4. The index can be rebuilt one shard at a time while the other
+//    shards remain searchable.
+
+// Prefix searches visit every shard and merge the results in term
+// order.
+
+import (
+	"container/heap"
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/google/btree"
+)
+
+const numShards = 32
+
+type indexShard struct {
+	mu    sync.Mutex
+	btree *btree.BTree
+
+	// While the shard is being rebuilt the new tree is built here
+	// and live updates are applied to both trees.
+	rebuild *btree.BTree
+
+	// Set when the shard changed since its snapshot was written.
+	dirty bool
+}
+
+func newIndexShard() *indexShard {
+	return &indexShard{
+		btree: btree.New(10),
+	}
+}
+
+// Returns true if the record was not already in the shard.
+func (self *indexShard) Set(record Record) bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.rebuild != nil {
+		self.rebuild.ReplaceOrInsert(record)
+	}
+
+	old := self.btree.ReplaceOrInsert(record)
+	if old != nil {
+		return false
+	}
+
+	self.dirty = true
+	return true
+}
+
+// Returns true if the record was removed from the shard.
+func (self *indexShard) Unset(record Record) bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.rebuild != nil {
+		self.rebuild.Delete(record)
+	}
+
+	old := self.btree.Delete(record)
+	if old == nil {
+		return false
+	}
+
+	self.dirty = true
+	return true
+}
+
+func (self *indexShard) Len() int {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.btree.Len()
+}
+
+// Take a copy of all records starting with the prefix so the caller
+// does not hold the lock while consuming them.
+func (self *indexShard) SearchPrefix(prefix string) []Record {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := []Record{}
+	self.btree.AscendGreaterOrEqual(Record{
+		IndexTerm: prefix,
+	}, func(i btree.Item) bool {
+		record := i.(Record)
+
+		// Detect when we exceeded the prefix constraint to quit
+		// early.
+		if !strings.HasPrefix(record.IndexTerm, prefix) {
+			return false
+		}
+
+		result = append(result, record)
+		return true
+	})
+
+	return result
+}
+
+// Returns a copy of the shard for writing a snapshot. The btree is
+// copy on write so this is cheap.
+func (self *indexShard) Snapshot() *btree.BTree {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.dirty = false
+	return self.btree.Clone()
+}
+
+func (self *indexShard) IsDirty() bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.dirty
+}
+
+func (self *indexShard) SetDirty(dirty bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.dirty = dirty
+}
+
+func (self *indexShard) StartRebuild() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.rebuild = btree.New(10)
+}
+
+func (self *indexShard) AddToRebuild(record Record) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.rebuild != nil {
+		self.rebuild.ReplaceOrInsert(record)
+	}
+}
+
+func (self *indexShard) AbortRebuild() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.rebuild = nil
+}
+
+// Swap the rebuilt tree in. Returns the change in the number of
+// records.
+func (self *indexShard) CommitRebuild() int {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.rebuild == nil {
+		return 0
+	}
+
+	delta := self.rebuild.Len() - self.btree.Len()
+	self.btree = self.rebuild
+	self.rebuild = nil
+	self.dirty = true
+
+	return delta
+}
+
+// All terms of a client live in the same shard.
+func shardForEntity(entity string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.ToLower(entity)))
+	return int(h.Sum32() % numShards)
+}
+
+// Merges the sorted results from each shard.
+type mergeCursor struct {
+	records []Record
+	idx     int
+}
+
+type mergeHeap []*mergeCursor
+
+func (self mergeHeap) Len() int {
+	return len(self)
+}
+
+func (self mergeHeap) Less(i, j int) bool {
+	return self[i].records[self[i].idx].IndexTerm <
+		self[j].records[self[j].idx].IndexTerm
+}
+
+func (self mergeHeap) Swap(i, j int) {
+	self[i], self[j] = self[j], self[i]
+}
+
+func (self *mergeHeap) Push(x interface{}) {
+	*self = append(*self, x.(*mergeCursor))
+}
+
+func (self *mergeHeap) Pop() interface{} {
+	old := *self
+	n := len(old)
+	item := old[n-1]
+	*self = old[:n-1]
+	return item
+}
+
+// Call cb on all records in term order until it returns false.
+func mergeShardResults(results [][]Record, cb func(record Record) bool) {
+	h := &mergeHeap{}
+	for _, records := range results {
+		if len(records) > 0 {
+			*h = append(*h, &mergeCursor{records: records})
+		}
+	}
+	heap.Init(h)
+
+	for h.Len() > 0 {
+		cursor := (*h)[0]
+		if !cb(cursor.records[cursor.idx]) {
+			return
+		}
+
+		cursor.idx++
+		if cursor.idx >= len(cursor.records) {
+			heap.Pop(h)
+		} else {
+			heap.Fix(h, 0)
+		}
+	}
+}
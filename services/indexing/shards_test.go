@@ -0,0 +1,84 @@
+package indexing_test
+
+import (
+	"context"
+
+	"github.com/alecthomas/assert"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/indexing"
+)
+
+func (self *TestSuite) enumerate(indexer services.Indexer, prefix string) []string {
+	result := []string{}
+	for hit := range indexer.SearchIndexWithPrefix(
+		context.Background(), self.ConfigObj, prefix) {
+		result = append(result, hit.Entity)
+	}
+	return result
+}
+
+// Returns the shard snapshot files.
+func (self *TestSuite) shardSnapshots() []api.FSPathSpec {
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+	children, err := file_store_factory.ListDirectory(
+		paths.NewIndexPathManager().ShardSnapshotDirectory())
+	assert.NoError(self.T(), err)
+
+	result := []api.FSPathSpec{}
+	for _, child := range children {
+		if child.PathSpec().Type() == api.PATH_TYPE_FILESTORE_JSON {
+			result = append(result, child.PathSpec())
+		}
+	}
+	return result
+}
+
+func (self *TestSuite) TestShardSnapshots() {
+	indexer, err := services.GetIndexer(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	idx := indexer.(*indexing.Indexer)
+	assert.NoError(self.T(), idx.WriteShardSnapshots(self.ConfigObj))
+
+	// The clients are spread over several shards.
+	snapshots := self.shardSnapshots()
+	assert.True(self.T(), len(snapshots) > 1)
+
+	// Load the snapshots into a new indexer.
+	new_indexer := indexing.NewIndexer(self.ConfigObj)
+	assert.NoError(self.T(), new_indexer.LoadIndexFromSnapshot(
+		self.Ctx, self.ConfigObj))
+	assert.Equal(self.T(), self.clients, self.enumerate(new_indexer, "C."))
+
+	// Only the shard of the updated client is rewritten.
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+	for _, snapshot := range snapshots {
+		assert.NoError(self.T(), file_store_factory.Delete(snapshot))
+	}
+
+	assert.NoError(self.T(), indexer.SetIndex(self.clients[0], "label:foo"))
+	assert.NoError(self.T(), idx.WriteShardSnapshots(self.ConfigObj))
+
+	assert.Equal(self.T(), 1, len(self.shardSnapshots()))
+
+	new_indexer = indexing.NewIndexer(self.ConfigObj)
+	assert.NoError(self.T(), new_indexer.LoadIndexFromSnapshot(
+		self.Ctx, self.ConfigObj))
+	assert.Equal(self.T(), []string{self.clients[0]},
+		self.enumerate(new_indexer, "label:foo"))
+}
+
+func (self *TestSuite) TestRebuildIndex() {
+	indexer, err := services.GetIndexer(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	idx := indexer.(*indexing.Indexer)
+	assert.NoError(self.T(), idx.LoadIndexFromDatastore(
+		self.Ctx, self.ConfigObj))
+
+	assert.Equal(self.T(), self.clients, self.enumerate(indexer, "all/"))
+	assert.Equal(self.T(), self.clients, self.enumerate(indexer, "C."))
+}
@@ -43,6 +43,7 @@ import (
 	"www.velocidex.com/golang/velociraptor/paths/artifacts"
 	"www.velocidex.com/golang/velociraptor/result_sets"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/enrollment"
 	"www.velocidex.com/golang/velociraptor/services/journal"
 	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
 )
@@ -118,6 +119,13 @@ func (self *EnrollmentService) ProcessEnrollment(
 		return nil
 	}
 
+	// This is a new client - hold it until it is approved if
+	// required.
+	err = enrollment.AddPendingClient(config_obj, client_id)
+	if err != nil {
+		return err
+	}
+
 	// Wait for rate token
 	self.limiter.Wait(ctx)
 
@@ -150,7 +158,7 @@ func (self *EnrollmentService) ProcessEnrollment(
 		ctx, config_obj, acl_managers.NullACLManager{},
 		repository,
 		&flows_proto.ArtifactCollectorArgs{
-			Creator:   "InterrogationService",
+			Creator:   enrollment.INTERROGATION_CREATOR,
 			ClientId:  client_id,
 			Artifacts: []string{interrogation_artifact},
 		}, func() {
@@ -254,6 +262,11 @@ func (self *EnrollmentService) ProcessInterrogateResults(
 			client_info.Labels = append(client_info.Labels, label_array...)
 		}
 
+		quarantined, ok := row.GetBool("Quarantined")
+		if ok {
+			client_info.Quarantined = quarantined
+		}
+
 		mac_addresses, ok := row.GetStrings("MACAddresses")
 		if ok {
 			client_info.MacAddresses = append(
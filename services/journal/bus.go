@@ -0,0 +1,118 @@
+package journal
+
+// A message bus carries events between frontends sharing the same
+// datastore. Every frontend publishes the events it writes and
+// delivers events published by the others to its local watchers.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+var (
+	// How long to wait before reconnecting to the bus.
+	busRetryDuration = 5 * time.Second
+)
+
+type MessageBus interface {
+	Publish(ctx context.Context, subject string, data []byte) error
+
+	// Receive messages published on the subject after the call
+	// until the context is done.
+	Subscribe(ctx context.Context, subject string) (<-chan []byte, error)
+
+	Close() error
+}
+
+func NewMessageBus(config_obj *config_proto.MessageBusConfig) (MessageBus, error) {
+	config_obj = proto.Clone(config_obj).(*config_proto.MessageBusConfig)
+	if config_obj.Prefix == "" {
+		config_obj.Prefix = "velociraptor"
+	}
+
+	if config_obj.MaxStreamLength == 0 {
+		config_obj.MaxStreamLength = 10000
+	}
+
+	switch strings.ToLower(config_obj.Type) {
+	case "redis":
+		return NewRedisBus(config_obj), nil
+
+	case "nats":
+		return NewNatsBus(config_obj), nil
+	}
+
+	return nil, fmt.Errorf("Unsupported message bus type %q", config_obj.Type)
+}
+
+// Is this frontend configured to use a message bus?
+func UseMessageBus(config_obj *config_proto.Config) bool {
+	return config_obj.Frontend != nil &&
+		config_obj.Frontend.MessageBus != nil &&
+		config_obj.Frontend.MessageBus.Type != ""
+}
+
+// An in process bus used for tests.
+type MemoryBus struct {
+	mu          sync.Mutex
+	subscribers map[string][]chan []byte
+}
+
+func (self *MemoryBus) Publish(
+	ctx context.Context, subject string, data []byte) error {
+	self.mu.Lock()
+	subscribers := append([]chan []byte{}, self.subscribers[subject]...)
+	self.mu.Unlock()
+
+	for _, subscriber := range subscribers {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case subscriber <- data:
+		}
+	}
+	return nil
+}
+
+func (self *MemoryBus) Subscribe(
+	ctx context.Context, subject string) (<-chan []byte, error) {
+	output := make(chan []byte, 100)
+
+	self.mu.Lock()
+	self.subscribers[subject] = append(self.subscribers[subject], output)
+	self.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		self.mu.Lock()
+		defer self.mu.Unlock()
+
+		var subscribers []chan []byte
+		for _, subscriber := range self.subscribers[subject] {
+			if subscriber != output {
+				subscribers = append(subscribers, subscriber)
+			}
+		}
+		self.subscribers[subject] = subscribers
+		close(output)
+	}()
+
+	return output, nil
+}
+
+func (self *MemoryBus) Close() error {
+	return nil
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{
+		subscribers: make(map[string][]chan []byte),
+	}
+}
@@ -0,0 +1,220 @@
+package journal
+
+// A journal service for frontends sharing a datastore and a message
+// bus. Unlike the master/minion replication, no frontend is special:
+// each frontend writes its own events to the datastore and publishes
+// them on the bus so watchers on all the other frontends see them
+// too.
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+type busMessage struct {
+	Node     string `json:"node"`
+	Artifact string `json:"artifact"`
+	ClientId string `json:"client_id,omitempty"`
+	FlowId   string `json:"flow_id,omitempty"`
+	Jsonl    string `json:"jsonl"`
+}
+
+type BusJournalService struct {
+	*JournalService
+
+	bus     MessageBus
+	subject string
+	node    string
+	ctx     context.Context
+}
+
+func (self *BusJournalService) publish(
+	config_obj *config_proto.Config, jsonl []byte,
+	artifact, client_id, flow_id string) error {
+	serialized, err := json.Marshal(&busMessage{
+		Node:     self.node,
+		Artifact: artifact,
+		ClientId: client_id,
+		FlowId:   flow_id,
+		Jsonl:    string(jsonl),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = self.bus.Publish(self.ctx, self.subject, serialized)
+	if err != nil {
+		logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+		logger.Error("<red>Message Bus</> Unable to publish %v: %v",
+			artifact, err)
+	}
+	return err
+}
+
+func (self *BusJournalService) isEvent(
+	config_obj *config_proto.Config,
+	artifact, client_id, flow_id string) bool {
+	path_manager, err := artifacts.NewArtifactPathManager(
+		config_obj, client_id, flow_id, artifact)
+	return err == nil && path_manager.IsEvent()
+}
+
+func (self *BusJournalService) PushRowsToArtifact(
+	config_obj *config_proto.Config, rows []*ordereddict.Dict,
+	artifact, client_id, flow_id string) error {
+	err := self.JournalService.PushRowsToArtifact(
+		config_obj, rows, artifact, client_id, flow_id)
+	if err != nil || !self.isEvent(config_obj, artifact, client_id, flow_id) {
+		return err
+	}
+
+	jsonl, err := json.MarshalJsonl(rows)
+	if err != nil {
+		return err
+	}
+
+	return self.publish(config_obj, jsonl, artifact, client_id, flow_id)
+}
+
+func (self *BusJournalService) PushJsonlToArtifact(
+	config_obj *config_proto.Config, jsonl []byte, row_count int,
+	artifact, client_id, flow_id string) error {
+	err := self.JournalService.PushJsonlToArtifact(
+		config_obj, jsonl, row_count, artifact, client_id, flow_id)
+	if err != nil || !self.isEvent(config_obj, artifact, client_id, flow_id) {
+		return err
+	}
+
+	return self.publish(config_obj, jsonl, artifact, client_id, flow_id)
+}
+
+func (self *BusJournalService) PushRowsToArtifactAsync(
+	config_obj *config_proto.Config, row *ordereddict.Dict,
+	artifact string) {
+
+	go func() {
+		err := self.PushRowsToArtifact(config_obj, []*ordereddict.Dict{row},
+			artifact, "server", "")
+		if err != nil {
+			logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+			logger.Error("<red>PushRowsToArtifactAsync</> %v", err)
+		}
+	}()
+}
+
+func (self *BusJournalService) Broadcast(
+	config_obj *config_proto.Config, rows []*ordereddict.Dict,
+	artifact, client_id, flow_id string) error {
+	err := self.JournalService.Broadcast(
+		config_obj, rows, artifact, client_id, flow_id)
+	if err != nil {
+		return err
+	}
+
+	jsonl, err := json.MarshalJsonl(rows)
+	if err != nil {
+		return err
+	}
+
+	return self.publish(config_obj, jsonl, artifact, client_id, flow_id)
+}
+
+// Deliver events published by other frontends to our local
+// watchers. The publishing frontend already wrote them to the shared
+// datastore.
+func (self *BusJournalService) receive(
+	config_obj *config_proto.Config, serialized []byte) error {
+	message := &busMessage{}
+	err := json.Unmarshal(serialized, message)
+	if err != nil {
+		return err
+	}
+
+	if message.Node == self.node || self.qm == nil {
+		return nil
+	}
+
+	rows, err := utils.ParseJsonToDicts([]byte(message.Jsonl))
+	if err != nil {
+		return err
+	}
+
+	path_manager, err := artifacts.NewArtifactPathManager(
+		config_obj, message.ClientId, message.FlowId, message.Artifact)
+	if err != nil {
+		return err
+	}
+
+	self.qm.Broadcast(path_manager, rows)
+	return nil
+}
+
+func (self *BusJournalService) Start(
+	ctx context.Context, wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> Journal service on message bus %v for %v.",
+		self.subject, services.GetOrgName(config_obj))
+
+	events, err := self.bus.Subscribe(ctx, self.subject)
+	if err != nil {
+		return err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case serialized, ok := <-events:
+				if !ok {
+					return
+				}
+
+				err := self.receive(config_obj, serialized)
+				if err != nil {
+					logger.Error("<red>Message Bus</> %v", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+func NewBusJournalService(
+	ctx context.Context, wg *sync.WaitGroup,
+	config_obj *config_proto.Config, bus MessageBus) (*BusJournalService, error) {
+	service := &BusJournalService{
+		JournalService: &JournalService{
+			config_obj: config_obj,
+			locks:      make(map[string]*sync.Mutex),
+			Clock:      utils.RealClock{},
+		},
+		bus:     bus,
+		subject: "journal." + utils.NormalizedOrgId(config_obj.OrgId),
+		node:    services.GetNodeName(config_obj.Frontend),
+		ctx:     ctx,
+	}
+
+	qm, err := file_store.GetQueueManager(config_obj)
+	if err == nil && qm != nil {
+		qm.SetClock(service.Clock)
+		service.qm = qm
+	}
+
+	return service, service.Start(ctx, wg, config_obj)
+}
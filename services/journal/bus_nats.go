@@ -0,0 +1,309 @@
+package journal
+
+// A message bus over NATS core pub/sub. NATS does not retain
+// messages so events published while a frontend is disconnected are
+// only delivered to the frontends which were connected.
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+)
+
+type natsSubscription struct {
+	subject string
+	output  chan []byte
+	ctx     context.Context
+}
+
+type NatsBus struct {
+	config_obj *config_proto.MessageBusConfig
+
+	mu            sync.Mutex
+	conn          net.Conn
+	next_sid      int
+	subscriptions map[int]*natsSubscription
+
+	ctx    context.Context
+	cancel func()
+}
+
+func (self *NatsBus) subject(subject string) string {
+	return self.config_obj.Prefix + "." + subject
+}
+
+// Connect if needed. Must be called with the lock held.
+func (self *NatsBus) connect() (net.Conn, error) {
+	if self.conn != nil {
+		return self.conn, nil
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(self.ctx, "tcp", self.config_obj.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	err = self.handshake(conn, reader)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// Resubscribe all current subscriptions on the new connection.
+	for sid, sub := range self.subscriptions {
+		_, err = fmt.Fprintf(conn, "SUB %s %d\r\n", self.subject(sub.subject), sid)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	self.conn = conn
+	go self.readLoop(conn, reader)
+
+	return conn, nil
+}
+
+func (self *NatsBus) handshake(conn net.Conn, reader *bufio.Reader) error {
+	err := conn.SetDeadline(time.Now().Add(10 * time.Second))
+	if err != nil {
+		return err
+	}
+	defer conn.SetDeadline(time.Time{})
+
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("NATS: Unexpected greeting %q", line)
+	}
+
+	options := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "velociraptor",
+	}
+	if self.config_obj.Username != "" {
+		options["user"] = self.config_obj.Username
+		options["pass"] = self.config_obj.Password
+	}
+
+	serialized, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", serialized)
+	if err != nil {
+		return err
+	}
+
+	line, err = reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(line, "-ERR") {
+		return fmt.Errorf("NATS: %v", strings.TrimSpace(line[4:]))
+	}
+	if !strings.HasPrefix(line, "PONG") {
+		return fmt.Errorf("NATS: Unexpected reply %q", line)
+	}
+
+	return nil
+}
+
+func (self *NatsBus) readLoop(conn net.Conn, reader *bufio.Reader) {
+	err := self.processMessages(conn, reader)
+
+	self.mu.Lock()
+	if self.conn == conn {
+		self.conn = nil
+	}
+	self.mu.Unlock()
+	conn.Close()
+
+	if err == nil || self.ctx.Err() != nil {
+		return
+	}
+
+	// Keep trying to reconnect while there are subscribers.
+	for {
+		select {
+		case <-self.ctx.Done():
+			return
+		case <-time.After(busRetryDuration):
+		}
+
+		self.mu.Lock()
+		if len(self.subscriptions) == 0 {
+			self.mu.Unlock()
+			return
+		}
+		_, err := self.connect()
+		self.mu.Unlock()
+		if err == nil {
+			return
+		}
+	}
+}
+
+func (self *NatsBus) processMessages(
+	conn net.Conn, reader *bufio.Reader) error {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "PING":
+			self.mu.Lock()
+			_, err = conn.Write([]byte("PONG\r\n"))
+			self.mu.Unlock()
+			if err != nil {
+				return err
+			}
+
+		case strings.HasPrefix(line, "MSG "):
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			fields := strings.Fields(line)
+			if len(fields) < 4 {
+				return fmt.Errorf("NATS: Invalid message %q", line)
+			}
+
+			sid, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return err
+			}
+
+			length, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return err
+			}
+
+			buf := make([]byte, length+2)
+			_, err = io.ReadFull(reader, buf)
+			if err != nil {
+				return err
+			}
+
+			self.mu.Lock()
+			sub, pres := self.subscriptions[sid]
+			self.mu.Unlock()
+
+			if pres {
+				select {
+				case <-sub.ctx.Done():
+				case sub.output <- buf[:length]:
+				}
+			}
+
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("NATS: " + strings.TrimSpace(line[4:]))
+		}
+	}
+}
+
+func (self *NatsBus) Publish(
+	ctx context.Context, subject string, data []byte) error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	conn, err := self.connect()
+	if err != nil {
+		return err
+	}
+
+	buf := []byte(fmt.Sprintf("PUB %s %d\r\n", self.subject(subject), len(data)))
+	buf = append(buf, data...)
+	buf = append(buf, "\r\n"...)
+
+	_, err = conn.Write(buf)
+	if err != nil {
+		conn.Close()
+	}
+	return err
+}
+
+func (self *NatsBus) Subscribe(
+	ctx context.Context, subject string) (<-chan []byte, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.next_sid++
+	sid := self.next_sid
+	sub := &natsSubscription{
+		subject: subject,
+		output:  make(chan []byte, 100),
+		ctx:     ctx,
+	}
+	self.subscriptions[sid] = sub
+
+	// Connecting subscribes all the current subscriptions.
+	if self.conn == nil {
+		_, err := self.connect()
+		if err != nil {
+			delete(self.subscriptions, sid)
+			return nil, err
+		}
+	} else {
+		_, err := fmt.Fprintf(self.conn, "SUB %s %d\r\n",
+			self.subject(subject), sid)
+		if err != nil {
+			delete(self.subscriptions, sid)
+			return nil, err
+		}
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		self.mu.Lock()
+		defer self.mu.Unlock()
+
+		delete(self.subscriptions, sid)
+		if self.conn != nil {
+			_, _ = fmt.Fprintf(self.conn, "UNSUB %d\r\n", sid)
+		}
+	}()
+
+	return sub.output, nil
+}
+
+func (self *NatsBus) Close() error {
+	self.cancel()
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.conn != nil {
+		err := self.conn.Close()
+		self.conn = nil
+		return err
+	}
+	return nil
+}
+
+func NewNatsBus(config_obj *config_proto.MessageBusConfig) *NatsBus {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &NatsBus{
+		config_obj:    config_obj,
+		subscriptions: make(map[int]*natsSubscription),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
@@ -0,0 +1,327 @@
+package journal
+
+// A message bus over Redis streams. Each subject is a stream capped
+// at max_stream_length entries so a frontend which briefly loses the
+// connection can resume from the last entry it saw.
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+type RedisBus struct {
+	config_obj *config_proto.MessageBusConfig
+
+	// Connection used for publishing. Subscribers each use their
+	// own connection because XREAD BLOCK ties up the connection.
+	mu   sync.Mutex
+	conn *redisConn
+}
+
+func (self *RedisBus) key(subject string) string {
+	return self.config_obj.Prefix + ":" + subject
+}
+
+func (self *RedisBus) Publish(
+	ctx context.Context, subject string, data []byte) error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.conn == nil {
+		conn, err := dialRedis(ctx, self.config_obj)
+		if err != nil {
+			return err
+		}
+		self.conn = conn
+	}
+
+	_, err := self.conn.Do("XADD", self.key(subject), "MAXLEN", "~",
+		fmt.Sprintf("%d", self.config_obj.MaxStreamLength),
+		"*", "data", string(data))
+	if err != nil {
+		// Reconnect on the next call.
+		self.conn.Close()
+		self.conn = nil
+	}
+	return err
+}
+
+func (self *RedisBus) Subscribe(
+	ctx context.Context, subject string) (<-chan []byte, error) {
+	conn, err := dialRedis(ctx, self.config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	key := self.key(subject)
+
+	// Only deliver entries added after we subscribe.
+	last_id, err := conn.lastId(key)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	output := make(chan []byte, 100)
+	go func() {
+		defer close(output)
+		defer func() {
+			if conn != nil {
+				conn.Close()
+			}
+		}()
+
+		for {
+			if conn == nil {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(busRetryDuration):
+				}
+
+				conn, err = dialRedis(ctx, self.config_obj)
+				if err != nil {
+					conn = nil
+					continue
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			reply, err := conn.Do("XREAD", "BLOCK", "1000", "COUNT", "100",
+				"STREAMS", key, last_id)
+			if err != nil {
+				// Resume from last_id after reconnecting.
+				conn.Close()
+				conn = nil
+				continue
+			}
+
+			for _, entry := range parseStreamEntries(reply) {
+				last_id = entry.id
+				select {
+				case <-ctx.Done():
+					return
+				case output <- entry.data:
+				}
+			}
+		}
+	}()
+
+	return output, nil
+}
+
+func (self *RedisBus) Close() error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.conn != nil {
+		err := self.conn.Close()
+		self.conn = nil
+		return err
+	}
+	return nil
+}
+
+func NewRedisBus(config_obj *config_proto.MessageBusConfig) *RedisBus {
+	return &RedisBus{config_obj: config_obj}
+}
+
+type streamEntry struct {
+	id   string
+	data []byte
+}
+
+// XREAD replies with [[key, [[id, [field, value, ...]], ...]]] or nil
+// on timeout.
+func parseStreamEntries(reply interface{}) (result []streamEntry) {
+	streams, _ := reply.([]interface{})
+	for _, stream := range streams {
+		stream_reply, ok := stream.([]interface{})
+		if !ok || len(stream_reply) != 2 {
+			continue
+		}
+
+		entries, _ := stream_reply[1].([]interface{})
+		for _, entry := range entries {
+			entry_reply, ok := entry.([]interface{})
+			if !ok || len(entry_reply) != 2 {
+				continue
+			}
+
+			id, _ := entry_reply[0].(string)
+			fields, _ := entry_reply[1].([]interface{})
+			for i := 0; i+1 < len(fields); i += 2 {
+				name, _ := fields[i].(string)
+				value, _ := fields[i+1].(string)
+				if name == "data" {
+					result = append(result, streamEntry{
+						id: id, data: []byte(value)})
+				}
+			}
+		}
+	}
+	return result
+}
+
+// A minimal RESP2 client - just enough for the stream commands.
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialRedis(ctx context.Context,
+	config_obj *config_proto.MessageBusConfig) (*redisConn, error) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", config_obj.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &redisConn{
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+	}
+
+	if config_obj.Password != "" {
+		args := []string{"AUTH", config_obj.Password}
+		if config_obj.Username != "" {
+			args = []string{"AUTH", config_obj.Username, config_obj.Password}
+		}
+		_, err := result.Do(args...)
+		if err != nil {
+			result.Close()
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (self *redisConn) lastId(key string) (string, error) {
+	reply, err := self.Do("XREVRANGE", key, "+", "-", "COUNT", "1")
+	if err != nil {
+		return "", err
+	}
+
+	entries, _ := reply.([]interface{})
+	if len(entries) > 0 {
+		entry, ok := entries[0].([]interface{})
+		if ok && len(entry) > 0 {
+			id, ok := entry[0].(string)
+			if ok {
+				return id, nil
+			}
+		}
+	}
+	return "0-0", nil
+}
+
+func (self *redisConn) Do(args ...string) (interface{}, error) {
+	// XREAD may block for a while so allow for that.
+	err := self.conn.SetDeadline(time.Now().Add(30 * time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	buf := []byte(fmt.Sprintf("*%d\r\n", len(args)))
+	for _, arg := range args {
+		buf = append(buf, fmt.Sprintf("$%d\r\n", len(arg))...)
+		buf = append(buf, arg...)
+		buf = append(buf, "\r\n"...)
+	}
+
+	_, err = self.conn.Write(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.readReply()
+}
+
+func (self *redisConn) readLine() (string, error) {
+	line, err := self.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", errors.New("Redis: Invalid reply")
+	}
+	return line[:len(line)-2], nil
+}
+
+func (self *redisConn) readReply() (interface{}, error) {
+	line, err := self.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) == 0 {
+		return nil, errors.New("Redis: Empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+
+	case '-':
+		return nil, fmt.Errorf("Redis: %v", line[1:])
+
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if length < 0 {
+			return nil, nil
+		}
+
+		buf := make([]byte, length+2)
+		_, err = io.ReadFull(self.reader, buf)
+		if err != nil {
+			return nil, err
+		}
+		return string(buf[:length]), nil
+
+	case '*':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if length < 0 {
+			return nil, nil
+		}
+
+		result := make([]interface{}, 0, length)
+		for i := 0; i < length; i++ {
+			item, err := self.readReply()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, item)
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("Redis: Unexpected reply %q", line)
+}
+
+func (self *redisConn) Close() error {
+	return self.conn.Close()
+}
@@ -255,6 +255,24 @@ func (self *JournalService) Start(config_obj *config_proto.Config) error {
 
 func NewJournalService(
 	ctx context.Context, wg *sync.WaitGroup, config_obj *config_proto.Config) (services.JournalService, error) {
+	// Frontends sharing a message bus are all equal so there is no
+	// need for replication.
+	if UseMessageBus(config_obj) {
+		bus, err := NewMessageBus(config_obj.Frontend.MessageBus)
+		if err != nil {
+			return nil, err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ctx.Done()
+			bus.Close()
+		}()
+
+		return NewBusJournalService(ctx, wg, config_obj, bus)
+	}
+
 	// Are we running on a minion frontend? If so we try to start
 	// our replication service.
 	if !services.IsMaster(config_obj) {
@@ -1,20 +1,26 @@
 package journal_test
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Velocidex/ordereddict"
 	"github.com/alecthomas/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
 	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/file_store"
 	"www.velocidex.com/golang/velociraptor/file_store/api"
 	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
 	"www.velocidex.com/golang/velociraptor/utils"
 	"www.velocidex.com/golang/velociraptor/vtesting"
 
@@ -159,6 +165,57 @@ func (self *JournalTestSuite) TestJournalJsonlWriting() {
 	assert.True(self.T(), 0.07*10 > total_time)
 }
 
+// Two frontends sharing a message bus see each other's events.
+func (self *JournalTestSuite) TestMessageBus() {
+	ctx, cancel := context.WithCancel(self.Ctx)
+	defer cancel()
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	bus := journal.NewMemoryBus()
+
+	config_obj := proto.Clone(self.ConfigObj).(*config_proto.Config)
+	config_obj.Frontend.BindPort = 1
+	frontend1, err := journal.NewBusJournalService(ctx, wg, config_obj, bus)
+	assert.NoError(self.T(), err)
+
+	config_obj = proto.Clone(self.ConfigObj).(*config_proto.Config)
+	config_obj.Frontend.BindPort = 2
+	frontend2, err := journal.NewBusJournalService(ctx, wg, config_obj, bus)
+	assert.NoError(self.T(), err)
+
+	events, closer := frontend2.Watch(ctx, "System.Hunt.Participation", "test")
+	defer closer()
+
+	// Each frontend publishes an event. Frontend 2 sees its own
+	// event locally and frontend 1's event through the bus.
+	for _, frontend := range []*journal.BusJournalService{frontend1, frontend2} {
+		err = frontend.PushRowsToArtifact(self.ConfigObj,
+			[]*ordereddict.Dict{ordereddict.NewDict().Set("Foo", "Bar")},
+			"System.Hunt.Participation", "server", "")
+		assert.NoError(self.T(), err)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case row := <-events:
+			foo, _ := row.GetString("Foo")
+			assert.Equal(self.T(), "Bar", foo)
+
+		case <-time.After(5 * time.Second):
+			self.T().Fatalf("Timed out waiting for event %v", i)
+		}
+	}
+
+	// Frontend 2 does not receive its own event twice.
+	select {
+	case row := <-events:
+		self.T().Fatalf("Unexpected event %v", row)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 func TestJournalTestSuite(t *testing.T) {
 	suite.Run(t, &JournalTestSuite{})
 }
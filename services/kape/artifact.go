@@ -0,0 +1,59 @@
+package kape
+
+// The artifact generated for each target. The field order determines
+// the order in the generated yaml.
+type kapeArtifact struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Author      string                   `json:"author,omitempty"`
+	Reference   []string                 `json:"reference"`
+	Type        string                   `json:"type"`
+	Parameters  []*kapeArtifactParameter `json:"parameters"`
+	Sources     []*kapeArtifactSource    `json:"sources"`
+}
+
+type kapeArtifactParameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Default     string `json:"default,omitempty"`
+}
+
+type kapeArtifactSource struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+const (
+	kapeMetadataQuery = `-- Targets with $ in their name probably refer to ntfs special
+-- files and so they are designated as ntfs accessor. Other targets
+-- may need ntfs parsing but not necessary - they are designated
+-- with the lazy_ntfs accessor.
+LET rule_specs_ntfs <= SELECT Glob FROM KapeRules
+  WHERE Accessor = 'ntfs'
+  AND log(message="ntfs: Selecting glob " + Glob)
+
+LET rule_specs_lazy_ntfs <= SELECT Glob FROM KapeRules
+  WHERE Accessor = 'lazy_ntfs'
+  AND log(message="auto: Selecting glob " + Glob)
+
+LET all_results <= SELECT * FROM chain(
+  a={
+    SELECT * FROM Artifact.Generic.Collectors.File(
+       Root=Device,
+       Accessor="ntfs",
+       collectionSpec=rule_specs_ntfs)
+  }, b={
+    SELECT * FROM Artifact.Generic.Collectors.File(
+       Root=Device,
+       Accessor=if(condition=UseAutoAccessor,
+                   then="auto", else="lazy_ntfs"),
+       collectionSpec=rule_specs_lazy_ntfs)
+  })
+
+SELECT * FROM all_results WHERE _Source =~ "Metadata"
+`
+
+	kapeUploadsQuery = `SELECT * FROM all_results WHERE _Source =~ "Uploads"
+`
+)
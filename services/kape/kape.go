@@ -0,0 +1,479 @@
+/*
+  Converts KAPE targets into Velociraptor artifacts.
+
+  KAPE is a popular triage collector driven by target files (.tkape)
+  - yaml files describing which files to collect. Many DFIR teams
+  maintain their own targets in addition to the community KapeFiles
+  repository (https://github.com/EricZimmerman/KapeFiles).
+
+  Imported targets are stored in the datastore and each target is
+  converted into an artifact in the KAPE.Targets. namespace. Compound
+  targets (targets that include other .tkape files) are expanded
+  using all the targets imported so far. Whenever targets are
+  imported all the artifacts are regenerated so compound targets pick
+  up new versions of the targets they include. Artifacts are only
+  rewritten when their definition changes.
+
+  This is the same conversion performed by scripts/kape_files.py to
+  build the Windows.KapeFiles.Targets artifact, but each target gets
+  its own artifact.
+*/
+
+package kape
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Velocidex/yaml/v2"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	// Serialize imports.
+	kape_mu sync.Mutex
+
+	InvalidTargetError = errors.New("Invalid KAPE target")
+
+	sanitizeRegex = regexp.MustCompile("[^a-zA-Z0-9]")
+	driveRegex    = regexp.MustCompile(`^[a-zA-Z]:(\\|/)`)
+	userRegex     = regexp.MustCompile(`(?i)%user%`)
+)
+
+// The parts of the .tkape file we use.
+type kapeTargetFile struct {
+	Description string             `json:"Description"`
+	Author      string             `json:"Author"`
+	Version     string             `json:"Version"`
+	Id          string             `json:"Id"`
+	Targets     []*kapeTargetEntry `json:"Targets"`
+}
+
+type kapeTargetEntry struct {
+	Name      string `json:"Name"`
+	Category  string `json:"Category"`
+	Path      string `json:"Path"`
+	FileMask  string `json:"FileMask"`
+	Recursive bool   `json:"Recursive"`
+	Comment   string `json:"Comment"`
+}
+
+// A single glob in the generated artifact.
+type kapeRule struct {
+	Target, Category, Glob, Accessor, Comment string
+}
+
+func parseTarget(definition string) (*kapeTargetFile, error) {
+	result := &kapeTargetFile{}
+	err := yaml.Unmarshal([]byte(definition), result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidTargetError, err)
+	}
+
+	if len(result.Targets) == 0 {
+		return nil, fmt.Errorf("%w: no targets defined", InvalidTargetError)
+	}
+
+	return result, nil
+}
+
+// The name of the artifact generated for the target file.
+func ArtifactName(name string) string {
+	name = strings.TrimSuffix(path.Base(name), ".tkape")
+	name = strings.TrimLeft(sanitizeRegex.ReplaceAllString(name, "_"), "_")
+	return constants.ARTIFACT_KAPE_NAME_PREFIX + name
+}
+
+// Read the .tkape files from a zip file (e.g. an archive of the
+// KapeFiles repository).
+func ReadTargetsFromZip(data []byte) ([]*api_proto.KapeTarget, error) {
+	zip_reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*api_proto.KapeTarget{}
+	for _, file := range zip_reader.File {
+		if !strings.HasSuffix(strings.ToLower(file.Name), ".tkape") {
+			continue
+		}
+
+		fd, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		definition, err := ioutil.ReadAll(fd)
+		fd.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, &api_proto.KapeTarget{
+			Name:       path.Base(file.Name),
+			Definition: string(definition),
+		})
+	}
+
+	return result, nil
+}
+
+func ListTargets(config_obj *config_proto.Config) ([]*api_proto.KapeTarget, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := db.ListChildren(config_obj, paths.KAPE_TARGETS_ROOT)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api_proto.KapeTarget, 0, len(children))
+	for _, child := range children {
+		if child.IsDir() {
+			continue
+		}
+
+		target := &api_proto.KapeTarget{}
+		err := db.GetSubject(config_obj, child, target)
+		if err != nil || target.Name == "" {
+			continue
+		}
+		result = append(result, target)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// Import the targets and regenerate the artifacts. An uploaded target
+// replaces a previously imported target with the same name unless it
+// is an older version.
+func ImportTargets(config_obj *config_proto.Config, principal string,
+	targets []*api_proto.KapeTarget) (*api_proto.LoadArtifactPackResponse, error) {
+	kape_mu.Lock()
+	defer kape_mu.Unlock()
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := ListTargets(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// KAPE refers to other targets case insensitively.
+	lookup := make(map[string]*api_proto.KapeTarget)
+	for _, target := range existing {
+		lookup[strings.ToLower(target.Name)] = target
+	}
+
+	result := &api_proto.LoadArtifactPackResponse{}
+	now := uint64(utils.GetTime().Now().Unix())
+
+	for _, target := range targets {
+		parsed, err := parseTarget(target.Definition)
+		if err != nil {
+			result.Errors = append(result.Errors, &api_proto.LoadArtifactError{
+				Filename: target.Name,
+				Error:    err.Error(),
+			})
+			continue
+		}
+
+		old, pres := lookup[strings.ToLower(target.Name)]
+		if pres {
+			if old.Definition == target.Definition {
+				continue
+			}
+
+			if compareVersions(parsed.Version, old.Version) < 0 {
+				result.Errors = append(result.Errors, &api_proto.LoadArtifactError{
+					Filename: target.Name,
+					Error: fmt.Sprintf(
+						"Version %v is older than the imported version %v",
+						parsed.Version, old.Version),
+				})
+				continue
+			}
+		}
+
+		record := &api_proto.KapeTarget{
+			Name:        target.Name,
+			Description: parsed.Description,
+			Author:      parsed.Author,
+			Version:     parsed.Version,
+			Definition:  target.Definition,
+			Artifact:    ArtifactName(target.Name),
+			Imported:    now,
+			ImportedBy:  principal,
+		}
+
+		err = db.SetSubject(config_obj,
+			paths.KAPE_TARGETS_ROOT.AddChild(record.Name), record)
+		if err != nil {
+			return nil, err
+		}
+
+		lookup[strings.ToLower(record.Name)] = record
+	}
+
+	err = syncArtifacts(config_obj, principal, lookup, result)
+	return result, err
+}
+
+// Regenerate the artifacts for all the targets and update the ones
+// that changed.
+func syncArtifacts(config_obj *config_proto.Config, principal string,
+	lookup map[string]*api_proto.KapeTarget,
+	result *api_proto.LoadArtifactPackResponse) error {
+
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return err
+	}
+
+	repository, err := manager.GetGlobalRepository(config_obj)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(lookup))
+	for name := range lookup {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		target := lookup[name]
+		definition, err := convertTarget(target, lookup)
+		if err != nil {
+			result.Errors = append(result.Errors, &api_proto.LoadArtifactError{
+				Filename: target.Name,
+				Error:    err.Error(),
+			})
+			continue
+		}
+
+		current, pres := repository.Get(config_obj, target.Artifact)
+		if pres && current.Raw == definition {
+			continue
+		}
+
+		_, err = manager.SetArtifactFile(config_obj, principal,
+			definition, constants.ARTIFACT_KAPE_NAME_PREFIX)
+		if err != nil {
+			result.Errors = append(result.Errors, &api_proto.LoadArtifactError{
+				Filename: target.Name,
+				Error:    err.Error(),
+			})
+			continue
+		}
+
+		result.SuccessfulArtifacts = append(result.SuccessfulArtifacts,
+			target.Artifact)
+	}
+
+	return nil
+}
+
+// Expand the target into the list of globs, following any included
+// targets.
+func expandRules(target *api_proto.KapeTarget,
+	lookup map[string]*api_proto.KapeTarget,
+	seen map[string]bool) ([]*kapeRule, error) {
+
+	key := strings.ToLower(target.Name)
+	if seen[key] {
+		return nil, nil
+	}
+	seen[key] = true
+
+	parsed, err := parseTarget(target.Definition)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*kapeRule{}
+	for _, entry := range parsed.Targets {
+		if strings.HasSuffix(strings.ToLower(entry.Path), ".tkape") {
+			dependency, pres := lookup[strings.ToLower(entry.Path)]
+			if !pres {
+				return nil, fmt.Errorf("%w: %v includes unknown target %v",
+					InvalidTargetError, target.Name, entry.Path)
+			}
+
+			rules, err := expandRules(dependency, lookup, seen)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, rules...)
+			continue
+		}
+
+		glob := strings.ReplaceAll(entry.Path, "/", "\\")
+		if entry.Recursive {
+			glob = strings.TrimRight(glob, "\\") + "\\**10"
+		}
+
+		if entry.FileMask != "" {
+			glob = strings.TrimRight(glob, "\\") + "\\" + entry.FileMask
+		}
+
+		// A trailing separator means all the files in the directory.
+		if strings.HasSuffix(glob, "\\") {
+			glob += "*"
+		}
+
+		glob = driveRegex.ReplaceAllString(glob, "")
+		glob = userRegex.ReplaceAllString(glob, "*")
+
+		result = append(result, &kapeRule{
+			Target:   entry.Name,
+			Category: entry.Category,
+			Glob:     glob,
+			Accessor: findAccessor(glob),
+			Comment:  entry.Comment,
+		})
+	}
+
+	return result, nil
+}
+
+// Targets with $ in their name probably refer to NTFS special files
+// so they need the ntfs accessor. Other targets may not need raw
+// NTFS access so they use the lazy_ntfs accessor.
+func findAccessor(glob string) string {
+	if strings.Contains(glob, "$Recycle.Bin") {
+		return "lazy_ntfs"
+	}
+
+	if strings.Contains(glob, ":") || strings.Contains(glob, "$") {
+		return "ntfs"
+	}
+
+	return "lazy_ntfs"
+}
+
+func convertTarget(target *api_proto.KapeTarget,
+	lookup map[string]*api_proto.KapeTarget) (string, error) {
+
+	parsed, err := parseTarget(target.Definition)
+	if err != nil {
+		return "", err
+	}
+
+	rules, err := expandRules(target, lookup, make(map[string]bool))
+	if err != nil {
+		return "", err
+	}
+
+	rule_table := &bytes.Buffer{}
+	writer := csv.NewWriter(rule_table)
+	_ = writer.Write([]string{"Target", "Category", "Glob", "Accessor", "Comment"})
+
+	// Remove duplicate globs introduced by included targets.
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		key := rule.Accessor + rule.Glob
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		_ = writer.Write([]string{rule.Target, rule.Category,
+			rule.Glob, rule.Accessor, rule.Comment})
+	}
+	writer.Flush()
+
+	description := fmt.Sprintf(`%s
+
+Converted from the KAPE target %s (version %s by %s).
+
+This artifact is generated automatically and will be overwritten
+when the target is imported again.
+`, parsed.Description, target.Name, parsed.Version, parsed.Author)
+
+	serialized, err := yaml.Marshal(&kapeArtifact{
+		Name:        target.Artifact,
+		Description: description,
+		Author:      parsed.Author,
+		Reference:   []string{"https://github.com/EricZimmerman/KapeFiles"},
+		Type:        "CLIENT",
+		Parameters: []*kapeArtifactParameter{{
+			Name:        "Device",
+			Description: "Name of the drive letter to search.",
+			Default:     "C:",
+		}, {
+			Name:        "UseAutoAccessor",
+			Description: "Uses file accessor when possible instead of ntfs parser - this is much faster.",
+			Type:        "bool",
+			Default:     "Y",
+		}, {
+			Name:        "KapeRules",
+			Description: "The globs converted from the KAPE target",
+			Type:        "csv",
+			Default:     rule_table.String(),
+		}},
+		Sources: []*kapeArtifactSource{{
+			Name:  "All File Metadata",
+			Query: kapeMetadataQuery,
+		}, {
+			Name:  "Uploads",
+			Query: kapeUploadsQuery,
+		}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(serialized), nil
+}
+
+// Compare dotted version strings numerically. Returns a negative
+// number if a is older than b.
+func compareVersions(a, b string) int {
+	a_parts := strings.Split(a, ".")
+	b_parts := strings.Split(b, ".")
+
+	for i := 0; i < len(a_parts) || i < len(b_parts); i++ {
+		var a_part, b_part int64
+		if i < len(a_parts) {
+			a_part, _ = strconv.ParseInt(a_parts[i], 10, 64)
+		}
+		if i < len(b_parts) {
+			b_part, _ = strconv.ParseInt(b_parts[i], 10, 64)
+		}
+
+		if a_part != b_part {
+			if a_part < b_part {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,188 @@
+package kape_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/kape"
+)
+
+const (
+	mftTarget = `
+Description: $MFT
+Author: Eric Zimmerman
+Version: 1.0
+Id: 1
+Targets:
+    -
+        Name: $MFT
+        Category: FileSystem
+        Path: C:\
+        FileMask: $MFT
+`
+
+	chromeTarget = `
+Description: Chrome
+Author: Eric Zimmerman
+Version: 1.0
+Id: 2
+Targets:
+    -
+        Name: Chrome bookmarks
+        Category: Communications
+        Path: C:\Users\%user%\AppData\Local\Google\Chrome\User Data\*\
+        FileMask: Bookmarks*
+`
+
+	chromeTargetV2 = `
+Description: Chrome
+Author: Eric Zimmerman
+Version: 1.1
+Id: 2
+Targets:
+    -
+        Name: Chrome history
+        Category: Communications
+        Path: C:\Users\%user%\AppData\Local\Google\Chrome\User Data\*\
+        FileMask: History*
+`
+
+	triageTarget = `
+Description: Triage
+Author: Me
+Version: 1.0
+Id: 3
+Targets:
+    -
+        Name: $MFT
+        Category: FileSystem
+        Path: $MFT.tkape
+    -
+        Name: Chrome
+        Category: Communications
+        Path: Chrome.tkape
+`
+)
+
+type KapeTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *KapeTestSuite) makeZip(files map[string]string) []byte {
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for name, data := range files {
+		fd, err := writer.Create(name)
+		assert.NoError(self.T(), err)
+		_, err = fd.Write([]byte(data))
+		assert.NoError(self.T(), err)
+	}
+	assert.NoError(self.T(), writer.Close())
+	return buffer.Bytes()
+}
+
+func (self *KapeTestSuite) importZip(files map[string]string) *api_proto.LoadArtifactPackResponse {
+	targets, err := kape.ReadTargetsFromZip(self.makeZip(files))
+	assert.NoError(self.T(), err)
+
+	result, err := kape.ImportTargets(self.ConfigObj, "admin", targets)
+	assert.NoError(self.T(), err)
+	return result
+}
+
+func (self *KapeTestSuite) getArtifact(name string) string {
+	manager, err := services.GetRepositoryManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	repository, err := manager.GetGlobalRepository(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	artifact, pres := repository.Get(self.ConfigObj, name)
+	assert.True(self.T(), pres, name)
+	return artifact.Raw
+}
+
+func (self *KapeTestSuite) TestImportTargets() {
+	result := self.importZip(map[string]string{
+		"KapeFiles/Targets/Windows/$MFT.tkape":    mftTarget,
+		"KapeFiles/Targets/Browsers/Chrome.tkape": chromeTarget,
+		"KapeFiles/Targets/Compound/Triage.tkape": triageTarget,
+		"KapeFiles/Modules/Ignored.mkape":         "Ignored",
+	})
+	assert.Equal(self.T(), 0, len(result.Errors))
+	assert.Equal(self.T(), []string{
+		"KAPE.Targets.MFT", "KAPE.Targets.Chrome", "KAPE.Targets.Triage",
+	}, result.SuccessfulArtifacts)
+
+	// NTFS special files use the ntfs accessor.
+	mft := self.getArtifact("KAPE.Targets.MFT")
+	assert.Contains(self.T(), mft, "$MFT,FileSystem,$MFT,ntfs,")
+
+	chrome := self.getArtifact("KAPE.Targets.Chrome")
+	assert.Contains(self.T(), chrome,
+		`Users\*\AppData\Local\Google\Chrome\User Data\*\Bookmarks*,lazy_ntfs`)
+
+	// The compound target includes the rules of both targets.
+	triage := self.getArtifact("KAPE.Targets.Triage")
+	assert.Contains(self.T(), triage, "$MFT,FileSystem,$MFT,ntfs,")
+	assert.Contains(self.T(), triage, "Bookmarks*")
+
+	targets, err := kape.ListTargets(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 3, len(targets))
+	assert.Equal(self.T(), "$MFT.tkape", targets[0].Name)
+	assert.Equal(self.T(), "admin", targets[0].ImportedBy)
+
+	// Importing the same targets again changes nothing.
+	result = self.importZip(map[string]string{
+		"Chrome.tkape": chromeTarget,
+	})
+	assert.Equal(self.T(), 0, len(result.Errors))
+	assert.Equal(self.T(), 0, len(result.SuccessfulArtifacts))
+
+	// A new version of Chrome also updates the compound target.
+	result = self.importZip(map[string]string{
+		"Chrome.tkape": chromeTargetV2,
+	})
+	assert.Equal(self.T(), 0, len(result.Errors))
+	assert.Equal(self.T(), []string{
+		"KAPE.Targets.Chrome", "KAPE.Targets.Triage",
+	}, result.SuccessfulArtifacts)
+
+	triage = self.getArtifact("KAPE.Targets.Triage")
+	assert.Contains(self.T(), triage, "History*")
+	assert.NotContains(self.T(), triage, "Bookmarks*")
+
+	// Older versions are rejected.
+	result = self.importZip(map[string]string{
+		"Chrome.tkape": chromeTarget,
+	})
+	assert.Equal(self.T(), 1, len(result.Errors))
+	assert.Contains(self.T(), result.Errors[0].Error, "older")
+	assert.Contains(self.T(), self.getArtifact("KAPE.Targets.Chrome"), "History*")
+}
+
+func (self *KapeTestSuite) TestInvalidTargets() {
+	result := self.importZip(map[string]string{
+		"Broken.tkape": "Description: Broken\n",
+		"Triage.tkape": triageTarget,
+	})
+
+	// The compound target refers to targets that were never
+	// imported.
+	assert.Equal(self.T(), 2, len(result.Errors))
+	assert.Equal(self.T(), 0, len(result.SuccessfulArtifacts))
+
+	_, err := kape.ReadTargetsFromZip([]byte("not a zip"))
+	assert.Error(self.T(), err)
+}
+
+func TestKape(t *testing.T) {
+	suite.Run(t, &KapeTestSuite{})
+}
@@ -0,0 +1,335 @@
+/*
+  The label rules service keeps client labels in sync with VQL
+  conditions.
+
+  A label rule owns a label: clients matching the rule's condition
+  are given the label and clients that no longer match have it
+  removed. This allows hunts and client monitoring tables to target
+  classes of machines (e.g. domain controllers or laptops) without
+  labeling them by hand.
+
+  The condition is a VQL expression evaluated over the same rows the
+  clients() plugin produces, so it can refer to any of the client's fields
+  (e.g. os_info.system or last_interrogate_flow_id).
+
+  All rules are evaluated periodically, and a client is evaluated
+  again as soon as its interrogation completes.
+
+  The service runs on the master node alongside the interrogation
+  service.
+*/
+
+package label_rules
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+type LabelRulesService struct {
+	config_obj *config_proto.Config
+}
+
+// Evaluate all rules over all clients.
+func (self *LabelRulesService) RunOnce(ctx context.Context) error {
+	rules, err := ListRules(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	for _, rule := range rules {
+		if rule.Disabled {
+			continue
+		}
+
+		_, err := ApplyRule(ctx, self.config_obj, rule)
+		if err != nil {
+			logger.Error("label_rules: rule %v: %v", rule.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Evaluate all rules for a single client.
+func (self *LabelRulesService) ProcessClient(
+	ctx context.Context, client_id string) error {
+	rules, err := ListRules(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	labeler := services.GetLabeler(self.config_obj)
+	if labeler == nil {
+		return nil
+	}
+
+	for _, rule := range rules {
+		if rule.Disabled {
+			continue
+		}
+
+		matching, err := matchingClients(
+			ctx, self.config_obj, rule, client_id)
+		if err != nil {
+			return err
+		}
+
+		err = syncLabel(ctx, self.config_obj, labeler, rule,
+			client_id, matching[client_id])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Apply the rule to all clients and record the outcome in the rule.
+func ApplyRule(ctx context.Context, config_obj *config_proto.Config,
+	rule *api_proto.LabelRule) (*api_proto.LabelRule, error) {
+
+	matched, apply_err := applyRule(ctx, config_obj, rule)
+
+	updated, err := ModifyRule(config_obj, rule.Name,
+		func(rule *api_proto.LabelRule) error {
+			rule.LastRun = uint64(utils.GetTime().Now().Unix())
+			rule.MatchedClients = uint64(matched)
+			rule.LastError = ""
+			if apply_err != nil {
+				rule.LastError = apply_err.Error()
+			}
+			return nil
+		})
+	if apply_err != nil {
+		return nil, apply_err
+	}
+	return updated, err
+}
+
+func applyRule(ctx context.Context, config_obj *config_proto.Config,
+	rule *api_proto.LabelRule) (int, error) {
+
+	labeler := services.GetLabeler(config_obj)
+	if labeler == nil {
+		return 0, nil
+	}
+
+	matching, err := matchingClients(ctx, config_obj, rule, "")
+	if err != nil {
+		return 0, err
+	}
+
+	for client_id := range matching {
+		err := syncLabel(ctx, config_obj, labeler, rule, client_id, true)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Remove the label from clients that no longer match.
+	labeled, err := getClients(ctx, config_obj, rule, "", "label:"+rule.Label)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, api_client := range labeled {
+		client_id := api_client.ClientId
+		if matching[client_id] {
+			continue
+		}
+
+		err := syncLabel(ctx, config_obj, labeler, rule, client_id, false)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(matching), nil
+}
+
+func syncLabel(ctx context.Context, config_obj *config_proto.Config,
+	labeler services.Labeler, rule *api_proto.LabelRule,
+	client_id string, match bool) error {
+
+	is_set := labeler.IsLabelSet(ctx, config_obj, client_id, rule.Label)
+	if match && !is_set {
+		return labeler.SetClientLabel(ctx, config_obj, client_id, rule.Label)
+	}
+
+	if !match && is_set {
+		return labeler.RemoveClientLabel(
+			ctx, config_obj, client_id, rule.Label)
+	}
+
+	return nil
+}
+
+// Returns the set of clients matching the rule. If client_id is set
+// only that client is considered.
+func matchingClients(ctx context.Context, config_obj *config_proto.Config,
+	rule *api_proto.LabelRule, client_id string) (map[string]bool, error) {
+
+	clients, err := getClients(ctx, config_obj, rule, client_id, "all")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*ordereddict.Dict, 0, len(clients))
+	for _, api_client := range clients {
+		rows = append(rows, json.ConvertProtoToOrderedDict(api_client))
+	}
+
+	vql, err := vfilter.Parse(ruleQuery(rule))
+	if err != nil {
+		return nil, err
+	}
+
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// Run the condition with the permissions of the rule's creator.
+	scope := manager.BuildScope(services.ScopeBuilder{
+		Config: config_obj,
+		ACLManager: acl_managers.NewServerACLManager(
+			config_obj, rule.Creator),
+		Env: ordereddict.NewDict().Set("Clients", rows),
+		Logger: logging.NewPlainLogger(config_obj,
+			&logging.FrontendComponent),
+	})
+	defer scope.Close()
+
+	result := make(map[string]bool)
+	for row := range vql.Eval(ctx, scope) {
+		client_id, pres := scope.Associative(row, "client_id")
+		if pres {
+			client_id_str, ok := client_id.(string)
+			if ok && client_id_str != "" {
+				result[client_id_str] = true
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Returns the clients matching the search term, or only client_id
+// if it is set. Rules only see the clients their creator may read.
+func getClients(ctx context.Context, config_obj *config_proto.Config,
+	rule *api_proto.LabelRule,
+	client_id, search string) ([]*api_proto.ApiClient, error) {
+
+	ok, err := services.CheckAccess(config_obj, rule.Creator, acls.READ_RESULTS)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %v is not allowed to read clients",
+			acls.PermissionDenied, rule.Creator)
+	}
+
+	indexer, err := services.GetIndexer(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if client_id != "" {
+		api_client, err := indexer.FastGetApiClient(ctx, config_obj, client_id)
+		if err != nil {
+			// Unknown clients do not match any rule.
+			return nil, nil
+		}
+		return []*api_proto.ApiClient{api_client}, nil
+	}
+
+	scope := vql_subsystem.MakeScope()
+	defer scope.Close()
+
+	search_chan, err := indexer.SearchClientsChan(
+		ctx, scope, config_obj, search, rule.Creator)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*api_proto.ApiClient{}
+	for api_client := range search_chan {
+		result = append(result, api_client)
+	}
+
+	return result, nil
+}
+
+func NewLabelRulesService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*LabelRulesService, error) {
+
+	result := &LabelRulesService{
+		config_obj: config_obj,
+	}
+
+	interval := time.Duration(600) * time.Second
+	if config_obj.Defaults != nil &&
+		config_obj.Defaults.LabelRulesIntervalSec > 0 {
+		interval = time.Duration(
+			config_obj.Defaults.LabelRulesIntervalSec) * time.Second
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> label rules service for %v.",
+		services.GetOrgName(config_obj))
+
+	// Evaluate the rules again for newly interrogated clients.
+	err := journal.WatchQueueWithCB(ctx, config_obj, wg,
+		"Server.Internal.Interrogation", "LabelRulesService",
+		func(ctx context.Context, config_obj *config_proto.Config,
+			row *ordereddict.Dict) error {
+			client_id, pres := row.GetString("ClientId")
+			if !pres {
+				return nil
+			}
+			return result.ProcessClient(ctx, client_id)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(interval):
+				err := result.RunOnce(ctx)
+				if err != nil {
+					logger.Error("label_rules: %v", err)
+				}
+			}
+		}
+	}()
+
+	return result, nil
+}
@@ -0,0 +1,140 @@
+package label_rules_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/label_rules"
+
+	_ "www.velocidex.com/golang/velociraptor/result_sets/timed"
+)
+
+type LabelRulesTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *LabelRulesTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Services.IndexServer = true
+	self.ConfigObj.Frontend.Resources.IndexSnapshotFrequency = 100000
+
+	self.TestSuite.SetupTest()
+
+	self.setClient("C.1", "windows")
+	self.setClient("C.2", "linux")
+	self.setClient("C.3", "linux")
+}
+
+func (self *LabelRulesTestSuite) setClient(client_id, system string) {
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	indexer, err := services.GetIndexer(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	assert.NoError(self.T(), indexer.SetIndex(client_id, client_id))
+	assert.NoError(self.T(), indexer.SetIndex(client_id, "all"))
+
+	err = db.SetSubject(self.ConfigObj,
+		paths.NewClientPathManager(client_id).Path(),
+		&actions_proto.ClientInfo{
+			ClientId: client_id,
+			Hostname: client_id,
+			System:   system,
+		})
+	assert.NoError(self.T(), err)
+
+	client_info_manager, err := services.GetClientInfoManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	client_info_manager.Flush(self.Ctx, client_id)
+}
+
+func (self *LabelRulesTestSuite) labeled(label string) []string {
+	labeler := services.GetLabeler(self.ConfigObj)
+
+	result := []string{}
+	for _, client_id := range []string{"C.1", "C.2", "C.3"} {
+		if labeler.IsLabelSet(self.Ctx, self.ConfigObj, client_id, label) {
+			result = append(result, client_id)
+		}
+	}
+	return result
+}
+
+func (self *LabelRulesTestSuite) TestApplyRule() {
+	labeler := services.GetLabeler(self.ConfigObj)
+
+	// A stale label from a previous run of the rule.
+	err := labeler.SetClientLabel(self.Ctx, self.ConfigObj, "C.3", "windows")
+	assert.NoError(self.T(), err)
+
+	rule := &api_proto.LabelRule{
+		Name:      "Windows",
+		Label:     "windows",
+		Condition: "os_info.system = 'windows'",
+		Creator:   self.ConfigObj.Client.PinnedServerName,
+	}
+	assert.NoError(self.T(), label_rules.SetRule(self.ConfigObj, rule))
+
+	rule, err = label_rules.ApplyRule(self.Ctx, self.ConfigObj, rule)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), uint64(1), rule.MatchedClients)
+	assert.Equal(self.T(), "", rule.LastError)
+
+	// The stale label is removed.
+	assert.Equal(self.T(), []string{"C.1"}, self.labeled("windows"))
+
+	// A client that changes is labeled when it is processed again.
+	self.setClient("C.2", "windows")
+
+	wg := &sync.WaitGroup{}
+	service, err := label_rules.NewLabelRulesService(
+		self.Ctx, wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	assert.NoError(self.T(), service.ProcessClient(self.Ctx, "C.2"))
+	assert.Equal(self.T(), []string{"C.1", "C.2"}, self.labeled("windows"))
+
+	// Disabled rules are skipped.
+	_, err = label_rules.ModifyRule(self.ConfigObj, "Windows",
+		func(rule *api_proto.LabelRule) error {
+			rule.Disabled = true
+			return nil
+		})
+	assert.NoError(self.T(), err)
+
+	self.setClient("C.1", "linux")
+	assert.NoError(self.T(), service.RunOnce(self.Ctx))
+	assert.Equal(self.T(), []string{"C.1", "C.2"}, self.labeled("windows"))
+}
+
+func (self *LabelRulesTestSuite) TestValidateRule() {
+	err := label_rules.SetRule(self.ConfigObj, &api_proto.LabelRule{
+		Name:      "Bad",
+		Label:     "bad",
+		Condition: "os_info.system = ",
+	})
+	assert.Error(self.T(), err)
+
+	err = label_rules.SetRule(self.ConfigObj, &api_proto.LabelRule{
+		Name:  "NoCondition",
+		Label: "bad",
+	})
+	assert.Error(self.T(), err)
+
+	rules, err := label_rules.ListRules(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 0, len(rules))
+}
+
+func TestLabelRules(t *testing.T) {
+	suite.Run(t, &LabelRulesTestSuite{})
+}
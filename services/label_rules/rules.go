@@ -0,0 +1,126 @@
+package label_rules
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/vfilter"
+)
+
+var (
+	invalidRuleError = errors.New("Invalid label rule")
+)
+
+// Build the query that selects the clients matching the rule from
+// the client rows in the Clients variable.
+func ruleQuery(rule *api_proto.LabelRule) string {
+	return fmt.Sprintf("SELECT client_id FROM Clients WHERE %s",
+		rule.Condition)
+}
+
+func ValidateRule(rule *api_proto.LabelRule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("%w: name must be set", invalidRuleError)
+	}
+
+	if rule.Label == "" {
+		return fmt.Errorf("%w: label must be set", invalidRuleError)
+	}
+
+	if rule.Condition == "" {
+		return fmt.Errorf("%w: condition must be set", invalidRuleError)
+	}
+
+	_, err := vfilter.Parse(ruleQuery(rule))
+	if err != nil {
+		return fmt.Errorf("%w: condition: %v", invalidRuleError, err)
+	}
+
+	return nil
+}
+
+func GetRule(config_obj *config_proto.Config,
+	name string) (*api_proto.LabelRule, error) {
+	result := &api_proto.LabelRule{}
+	err := datastore.GetExistingSubject(config_obj,
+		paths.LABEL_RULES_ROOT.AddChild(name), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func SetRule(config_obj *config_proto.Config,
+	rule *api_proto.LabelRule) error {
+	err := ValidateRule(rule)
+	if err != nil {
+		return err
+	}
+
+	urn := paths.LABEL_RULES_ROOT.AddChild(rule.Name)
+	unlock, err := datastore.LockSubject(config_obj, urn)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.SetSubject(config_obj, urn, rule)
+}
+
+// Update the rule under lock.
+func ModifyRule(config_obj *config_proto.Config, name string,
+	cb func(rule *api_proto.LabelRule) error) (*api_proto.LabelRule, error) {
+	rule := &api_proto.LabelRule{}
+	err := datastore.ModifySubject(config_obj,
+		paths.LABEL_RULES_ROOT.AddChild(name), rule,
+		func(exists bool) error {
+			if !exists {
+				return os.ErrNotExist
+			}
+
+			err := cb(rule)
+			if err != nil {
+				return err
+			}
+			return ValidateRule(rule)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func DeleteRule(config_obj *config_proto.Config, name string) error {
+	return datastore.DeleteSubject(config_obj,
+		paths.LABEL_RULES_ROOT.AddChild(name), nil)
+}
+
+func ListRules(config_obj *config_proto.Config) ([]*api_proto.LabelRule, error) {
+	children, err := datastore.ListSubjects(config_obj, paths.LABEL_RULES_ROOT)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api_proto.LabelRule, 0, len(children))
+	for _, child := range children {
+		rule, err := GetRule(config_obj, child.Base())
+		if err != nil {
+			continue
+		}
+		result = append(result, rule)
+	}
+
+	return result, nil
+}
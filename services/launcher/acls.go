@@ -1,12 +1,17 @@
 package launcher
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-errors/errors"
 	"www.velocidex.com/golang/velociraptor/acls"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/secrets"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 )
 
@@ -32,3 +37,117 @@ func CheckAccess(
 
 	return nil
 }
+
+// Check that the principal is allowed to collect all the artifacts.
+// Principals without a scope may collect anything.
+func CheckArtifactScope(
+	acl_manager vql_subsystem.ACLManager, artifacts []string) error {
+	scoped, ok := acl_manager.(vql_subsystem.ScopedACLManager)
+	if !ok {
+		return nil
+	}
+
+	for _, artifact := range artifacts {
+		allowed, err := scoped.CheckArtifactScope(artifact)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return fmt.Errorf("%w: artifact %v is outside the allowed scope",
+				acls.PermissionDenied, artifact)
+		}
+	}
+
+	return nil
+}
+
+// Check that the principal is allowed to collect from the client.
+func CheckClientScope(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	acl_manager vql_subsystem.ACLManager, client_id string) error {
+	scoped, ok := acl_manager.(vql_subsystem.ScopedACLManager)
+	if !ok || client_id == "server" {
+		return nil
+	}
+
+	var labels []string
+	labeler := services.GetLabeler(config_obj)
+	if labeler != nil {
+		labels = labeler.GetClientLabels(ctx, config_obj, client_id)
+	}
+
+	allowed, err := scoped.CheckLabelScope(labels...)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("%w: client %v is outside the allowed label scope",
+			acls.PermissionDenied, client_id)
+	}
+
+	return nil
+}
+
+// Check that a hunt only targets clients within the principal's label
+// scope. A scoped principal may only hunt by labels they are allowed
+// to collect from.
+func CheckHuntScope(
+	acl_manager vql_subsystem.ACLManager, hunt *api_proto.Hunt) error {
+	scoped, ok := acl_manager.(vql_subsystem.ScopedACLManager)
+	if !ok {
+		return nil
+	}
+
+	// An unscoped principal is allowed any label.
+	allowed, err := scoped.CheckLabelScope()
+	if err != nil || allowed {
+		return err
+	}
+
+	labels := hunt.Condition.GetLabels().GetLabel()
+	if len(labels) == 0 {
+		return fmt.Errorf("%w: hunts must be restricted to the allowed labels",
+			acls.PermissionDenied)
+	}
+
+	for _, label := range labels {
+		allowed, err := scoped.CheckLabelScope(label)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return fmt.Errorf("%w: label %v is outside the allowed label scope",
+				acls.PermissionDenied, label)
+		}
+	}
+
+	return nil
+}
+
+// Check that the principal may use the secrets the collection
+// refers to. Queries without a principal (e.g. server internal
+// queries) may use any secret.
+func checkSecretAccess(
+	config_obj *config_proto.Config,
+	acl_manager vql_subsystem.ACLManager,
+	args *actions_proto.VQLCollectorArgs) error {
+	names := secrets.GetSecretNames(args)
+	if len(names) == 0 {
+		return nil
+	}
+
+	principal_manager, ok := acl_manager.(vql_subsystem.PrincipalACLManager)
+	if !ok {
+		return nil
+	}
+
+	principal := principal_manager.GetPrincipal()
+	for _, name := range names {
+		err := secrets.CheckAccess(config_obj, principal, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -10,9 +10,11 @@ import (
 
 	"github.com/go-errors/errors"
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	"www.velocidex.com/golang/velociraptor/artifacts"
 	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 	"www.velocidex.com/golang/vfilter"
 )
@@ -59,9 +61,17 @@ func (self *Launcher) CompileSingleArtifact(
 		escaped_name := maybeEscape(name)
 
 		switch parameter.Type {
-		case "", "string", "regex", "yara":
+		case "", "string", "regex", "yara", "client_path":
 			// Nothing to do with these types.
 
+		case "secret":
+			// The value is the name of a secret which is only
+			// replaced by its value when the task is sent to the
+			// client.
+			if !utils.InString(result.Secrets, name) {
+				result.Secrets = append(result.Secrets, name)
+			}
+
 		case "upload":
 			result.Query = append(result.Query, &actions_proto.VQLRequest{
 				VQL: fmt.Sprintf(`LET %v <= if(condition=%v, then={
@@ -91,6 +101,19 @@ func (self *Launcher) CompileSingleArtifact(
 				VQL: fmt.Sprintf("LET %v <= timestamp(epoch=%v)", escaped_name,
 					escaped_name),
 			})
+		case "timestamp_range":
+			result.Query = append(result.Query, &actions_proto.VQLRequest{
+				VQL: fmt.Sprintf(`
+LET %v <= if(
+    condition=format(format="%%T", args=%v) =~ "string",
+    then=dict(
+       Start=timestamp(epoch=split(string=%v, sep="/")[0]),
+       End=timestamp(epoch=split(string=%v, sep="/")[1])),
+    else=%v)
+`,
+					escaped_name, escaped_name, escaped_name, escaped_name,
+					escaped_name)})
+
 		case "starlark":
 			result.Query = append(result.Query, &actions_proto.VQLRequest{
 				VQL: fmt.Sprintf(`
@@ -501,24 +524,65 @@ func PopulateArtifactsVQLCollectorArgs(
 					})
 			}
 
-			request.Artifacts = append(request.Artifacts,
-				&artifacts_proto.Artifact{
-					Name:         artifact.Name,
-					Type:         artifact.Type,
-					Precondition: stripComments(artifact.Precondition),
-					Export:       stripComments(artifact.Export),
-					Imports:      artifact.Imports,
-					Parameters:   filtered_parameters,
-					Sources:      sources,
-
-					// Do not pass tool
-					// definitions to the
-					// client. Otherwise they will
-					// be added to it's local
-					// inventory and confuse the
-					// next request.
-					Tools: nil,
-				})
+			dependency := &artifacts_proto.Artifact{
+				Name:         artifact.Name,
+				Type:         artifact.Type,
+				Precondition: stripComments(artifact.Precondition),
+				Export:       stripComments(artifact.Export),
+				Imports:      artifact.Imports,
+				Parameters:   filtered_parameters,
+				Sources:      sources,
+
+				// Do not pass tool
+				// definitions to the
+				// client. Otherwise they will
+				// be added to it's local
+				// inventory and confuse the
+				// next request.
+				Tools: nil,
+			}
+
+			// Clients cache the dependencies by version so they may
+			// be pinned in later collections.
+			dependency.Version = artifacts.ArtifactVersion(dependency)
+			request.Artifacts = append(request.Artifacts, dependency)
+		}
+	}
+
+	return nil
+}
+
+// Replace dependencies with references to the pinned versions. The
+// client runs its cached copy of these instead. If the pinned
+// version is the current version we send it anyway so the client can
+// cache it.
+func applyPinnedVersions(
+	requests []*actions_proto.VQLCollectorArgs,
+	pinned_versions map[string]string) error {
+
+	used := make(map[string]bool)
+	for _, request := range requests {
+		for idx, artifact := range request.Artifacts {
+			version, pres := pinned_versions[artifact.Name]
+			if !pres {
+				continue
+			}
+			used[artifact.Name] = true
+
+			if version != artifact.Version {
+				request.Artifacts[idx] = &artifacts_proto.Artifact{
+					Name:    artifact.Name,
+					Version: version,
+					Pinned:  true,
+				}
+			}
+		}
+	}
+
+	for name := range pinned_versions {
+		if !used[name] {
+			return fmt.Errorf(
+				"Pinned artifact %v is not a dependency of this collection", name)
 		}
 	}
 
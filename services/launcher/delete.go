@@ -67,6 +67,12 @@ func (self *Launcher) DeleteFlow(
 	r.emit_fs("UploadMetadata", upload_metadata_path)
 	r.emit_fs("UploadMetadataIndex", upload_metadata_path.
 		SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX))
+	r.emit_fs("UploadIndex", flow_path_manager.UploadIndex())
+	r.emit_fs("UploadIndexIndex", flow_path_manager.UploadIndex().
+		SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX))
+	r.emit_fs("ResultIndex", flow_path_manager.ResultIndex())
+	r.emit_fs("ResultIndexIndex", flow_path_manager.ResultIndex().
+		SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX))
 
 	// Remove all result sets from artifacts.
 	for _, artifact_name := range collection_context.ArtifactsWithResults {
@@ -89,6 +95,9 @@ func (self *Launcher) DeleteFlow(
 	r.emit_fs("Log", flow_path_manager.Log())
 	r.emit_fs("LogIndex", flow_path_manager.Log().
 		SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX))
+	r.emit_fs("ShellRecording", flow_path_manager.ShellRecording())
+	r.emit_fs("ShellRecordingIndex", flow_path_manager.ShellRecording().
+		SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX))
 	r.emit_ds("CollectionContext", flow_path_manager.Path())
 	r.emit_ds("Task", flow_path_manager.Task())
 
@@ -121,10 +121,13 @@ import (
 	"encoding/base32"
 	"encoding/binary"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-errors/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"google.golang.org/protobuf/proto"
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
 	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
@@ -138,10 +141,32 @@ import (
 	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/paths"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/approvals"
+	"www.velocidex.com/golang/velociraptor/services/enrollment"
+	"www.velocidex.com/golang/velociraptor/services/quotas"
+	"www.velocidex.com/golang/velociraptor/services/secrets"
+	"www.velocidex.com/golang/velociraptor/tracing"
 	"www.velocidex.com/golang/velociraptor/utils"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 )
 
+var (
+	launcherCompileLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "launcher_compile_latency",
+			Help:    "Latency to compile a collection request in seconds.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 8),
+		})
+
+	launcherScheduledCollections = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "launcher_scheduled_collections",
+			Help: "Number of collections scheduled for each artifact.",
+		},
+		[]string{"artifact", "priority"},
+	)
+)
+
 // Ensures the specs field corresponds exactly with the
 // collector_request.Artifacts field: Extra fields are removed and
 // missing fields are added.
@@ -224,6 +249,11 @@ func (self *Launcher) CompileCollectorArgs(
 			return nil, err
 		}
 
+		err = ValidateParameters(artifact, spec)
+		if err != nil {
+			return nil, err
+		}
+
 		// Adjust collection wide resources to be the maximum
 		// number of all default
 		if artifact.Resources != nil {
@@ -244,6 +274,11 @@ func (self *Launcher) CompileCollectorArgs(
 				return nil, err
 			}
 
+			err = checkSecretAccess(config_obj, acl_manager, vql_collector_args)
+			if err != nil {
+				return nil, err
+			}
+
 			// If the request specifies resource controls
 			// they override the defaults.
 			if collector_request.OpsPerSecond > 0 {
@@ -303,6 +338,11 @@ func (self *Launcher) CompileCollectorArgs(
 		collector_request.IopsLimit = iops_limit
 	}
 
+	err := applyPinnedVersions(result, collector_request.PinnedVersions)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update the total count of requests
 	for idx, item := range result {
 		item.QueryId = int64(idx + 1)
@@ -504,7 +544,33 @@ func (self *Launcher) ScheduleArtifactCollection(
 	acl_manager vql_subsystem.ACLManager,
 	repository services.Repository,
 	collector_request *flows_proto.ArtifactCollectorArgs,
-	completion func()) (string, error) {
+	completion func()) (flow_id string, err error) {
+
+	ctx, span := tracing.StartSpan(ctx, "launcher.ScheduleArtifactCollection")
+	defer func() {
+		span.SetAttribute("flow_id", flow_id)
+		span.SetError(err)
+		span.End()
+	}()
+	span.SetAttribute("client_id", collector_request.ClientId)
+	span.SetAttribute("artifacts", strings.Join(collector_request.Artifacts, ","))
+
+	err = CheckArtifactScope(acl_manager, collector_request.Artifacts)
+	if err != nil {
+		return "", err
+	}
+
+	err = CheckClientScope(ctx, config_obj, acl_manager,
+		collector_request.ClientId)
+	if err != nil {
+		return "", err
+	}
+
+	err = approvals.CheckApproval(ctx, config_obj, acl_manager, repository,
+		collector_request)
+	if err != nil {
+		return "", err
+	}
 
 	args := collector_request.CompiledCollectorArgs
 	if args == nil {
@@ -513,11 +579,16 @@ func (self *Launcher) ScheduleArtifactCollection(
 
 		// NOTE: We assume that compiling the artifact is a
 		// pure function so caching is appropriate.
+		timer := prometheus.NewTimer(launcherCompileLatency)
+		_, compile_span := tracing.StartSpan(ctx, "launcher.CompileCollectorArgs")
 		compiled, err := self.CompileCollectorArgs(
 			ctx, config_obj, acl_manager, repository,
 			services.CompilerOptions{
 				ObfuscateNames: true,
 			}, collector_request)
+		compile_span.SetError(err)
+		compile_span.End()
+		timer.ObserveDuration()
 		if err != nil {
 			return "", err
 		}
@@ -544,6 +615,18 @@ func (self *Launcher) ScheduleArtifactCollectionFromCollectorArgs(
 		return "", errors.New("Client id not valid.")
 	}
 
+	// Clients waiting for approval are only interrogated.
+	err := enrollment.CheckCollection(
+		config_obj, client_id, collector_request.Creator)
+	if err != nil {
+		return "", err
+	}
+
+	err = quotas.CheckCollectionQuota(config_obj)
+	if err != nil {
+		return "", err
+	}
+
 	db, err := datastore.GetDB(config_obj)
 	if err != nil {
 		return "", err
@@ -574,6 +657,9 @@ func (self *Launcher) ScheduleArtifactCollectionFromCollectorArgs(
 		FlowRequest: &crypto_proto.FlowRequest{
 			LogBatchTime: batch_delay,
 		},
+
+		// The client returns this with all its responses.
+		TraceParent: tracing.TraceParent(ctx),
 	}
 
 	for _, arg := range vql_collector_args {
@@ -587,6 +673,13 @@ func (self *Launcher) ScheduleArtifactCollectionFromCollectorArgs(
 			task.Urgent = true
 		}
 
+		// Hunt uploads may be limited differently from collections
+		// scheduled by a user.
+		arg.PriorityClass = utils.PRIORITY_CLASS_INTERACTIVE
+		if strings.HasPrefix(collector_request.Creator, constants.HUNT_PREFIX) {
+			arg.PriorityClass = utils.PRIORITY_CLASS_HUNT
+		}
+
 		task.FlowRequest.VQLClientActions = append(
 			task.FlowRequest.VQLClientActions, arg)
 	}
@@ -605,6 +698,13 @@ func (self *Launcher) ScheduleArtifactCollectionFromCollectorArgs(
 		OutstandingRequests: int64(len(vql_collector_args)),
 	}
 
+	// Only the message queued for the client contains the secret
+	// values.
+	client_task, err := secrets.ResolveTask(config_obj, task)
+	if err != nil {
+		return "", err
+	}
+
 	// Store the collection_context first, then queue all the tasks.
 	err = db.SetSubjectWithCompletion(config_obj,
 		flow_path_manager.Path(),
@@ -613,7 +713,7 @@ func (self *Launcher) ScheduleArtifactCollectionFromCollectorArgs(
 		func() {
 			// Queue and notify the client about the new tasks
 			client_manager.QueueMessageForClient(
-				ctx, client_id, task,
+				ctx, client_id, client_task,
 				services.NOTIFY_CLIENT, utils.BackgroundWriter)
 		})
 	if err != nil {
@@ -630,6 +730,14 @@ func (self *Launcher) ScheduleArtifactCollectionFromCollectorArgs(
 		return "", err
 	}
 
+	priority := "interactive"
+	if strings.HasPrefix(collector_request.Creator, constants.HUNT_PREFIX) {
+		priority = "hunt"
+	}
+	for _, artifact := range collector_request.Artifacts {
+		launcherScheduledCollections.WithLabelValues(artifact, priority).Inc()
+	}
+
 	return collection_context.SessionId, nil
 }
 
@@ -15,9 +15,11 @@ import (
 	"github.com/sebdah/goldie"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/acls"
 	acl_proto "www.velocidex.com/golang/velociraptor/acls/proto"
 	"www.velocidex.com/golang/velociraptor/actions"
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
@@ -27,6 +29,7 @@ import (
 	"www.velocidex.com/golang/velociraptor/paths"
 	"www.velocidex.com/golang/velociraptor/responder"
 	"www.velocidex.com/golang/velociraptor/services"
+	launcher_pkg "www.velocidex.com/golang/velociraptor/services/launcher"
 	"www.velocidex.com/golang/velociraptor/vtesting"
 	"www.velocidex.com/golang/vfilter"
 
@@ -223,6 +226,72 @@ func (self *LauncherTestSuite) TestGetDependentArtifacts() {
 		"Test.Artifact.Deps", "Test.Artifact.Deps2"}, res)
 }
 
+func (self *LauncherTestSuite) TestPinnedVersions() {
+	repository := self.LoadArtifacts(DependentArtifacts)
+
+	launcher, err := services.GetLauncher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	request := &flows_proto.ArtifactCollectorArgs{
+		Creator:   "UserX",
+		ClientId:  "C.1234",
+		Artifacts: []string{"Test.Artifact.Deps2"},
+	}
+	ctx := context.Background()
+	acl_manager := acl_managers.NullACLManager{}
+
+	compiled, err := launcher.CompileCollectorArgs(ctx, self.ConfigObj,
+		acl_manager, repository, services.CompilerOptions{}, request)
+	assert.NoError(self.T(), err)
+
+	// All dependencies are sent with their current version.
+	versions := make(map[string]string)
+	for _, artifact := range compiled[0].Artifacts {
+		assert.NotEqual(self.T(), "", artifact.Version)
+		assert.False(self.T(), artifact.Pinned)
+		versions[artifact.Name] = artifact.Version
+	}
+
+	// Pinning the current version still sends the definition so the
+	// client can cache it.
+	request.PinnedVersions = map[string]string{
+		"Test.Artifact": versions["Test.Artifact"],
+	}
+	compiled, err = launcher.CompileCollectorArgs(ctx, self.ConfigObj,
+		acl_manager, repository, services.CompilerOptions{}, request)
+	assert.NoError(self.T(), err)
+
+	for _, artifact := range compiled[0].Artifacts {
+		assert.False(self.T(), artifact.Pinned)
+	}
+
+	// Pinning an older version only sends a reference to it.
+	request.PinnedVersions = map[string]string{
+		"Test.Artifact": "0123456789abcdef",
+	}
+	compiled, err = launcher.CompileCollectorArgs(ctx, self.ConfigObj,
+		acl_manager, repository, services.CompilerOptions{}, request)
+	assert.NoError(self.T(), err)
+
+	for _, artifact := range compiled[0].Artifacts {
+		if artifact.Name == "Test.Artifact" {
+			assert.True(self.T(), artifact.Pinned)
+			assert.Equal(self.T(), "0123456789abcdef", artifact.Version)
+			assert.Nil(self.T(), artifact.Sources)
+		} else {
+			assert.False(self.T(), artifact.Pinned)
+		}
+	}
+
+	// Pinning an artifact which is not used is an error.
+	request.PinnedVersions = map[string]string{
+		"Test.Unknown": "0123456789abcdef",
+	}
+	_, err = launcher.CompileCollectorArgs(ctx, self.ConfigObj,
+		acl_manager, repository, services.CompilerOptions{}, request)
+	assert.Error(self.T(), err)
+}
+
 // https://github.com/Velocidex/velociraptor/issues/1287
 var DependentArtifactsWithImports = []string{`
 name: DependedArtifactInExport
@@ -615,6 +684,78 @@ sources:
 	assert.Equal(self.T(), len(compiled[0].Query), 2)
 }
 
+func (self *LauncherTestSuite) TestCollectionScope() {
+	repository := self.LoadArtifacts([]string{`
+name: Windows.Triage.Test
+sources:
+- query:  |
+    SELECT * FROM info()
+`, `
+name: Windows.Memory.Test
+sources:
+- query:  |
+    SELECT * FROM info()
+`})
+
+	ctx := context.Background()
+	launcher, err := services.GetLauncher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	labeler := services.GetLabeler(self.ConfigObj)
+	assert.NoError(self.T(), labeler.SetClientLabel(
+		ctx, self.ConfigObj, "C.1234", "Workstation"))
+
+	err = services.SetPolicy(self.ConfigObj, "UserX",
+		&acl_proto.ApiClientACL{
+			CollectClient: true,
+			ArtifactScope: []string{"Windows.Triage.*"},
+			LabelScope:    []string{"workstation"},
+		})
+	assert.NoError(self.T(), err)
+
+	acl_manager := acl_managers.NewServerACLManager(self.ConfigObj, "UserX")
+
+	schedule := func(client_id string, artifacts ...string) error {
+		_, err := launcher.ScheduleArtifactCollection(
+			ctx, self.ConfigObj, acl_manager, repository,
+			&flows_proto.ArtifactCollectorArgs{
+				Creator:   "UserX",
+				ClientId:  client_id,
+				Artifacts: artifacts,
+			}, nil)
+		return err
+	}
+
+	// Triage artifacts on workstations are allowed.
+	assert.NoError(self.T(), schedule("C.1234", "Windows.Triage.Test"))
+
+	// Artifacts outside the scope are denied.
+	err = schedule("C.1234", "Windows.Triage.Test", "Windows.Memory.Test")
+	assert.ErrorIs(self.T(), err, acls.PermissionDenied)
+
+	// Clients without a scoped label are denied.
+	err = schedule("C.5678", "Windows.Triage.Test")
+	assert.ErrorIs(self.T(), err, acls.PermissionDenied)
+
+	// Hunts must be restricted to the scoped labels.
+	hunt := &api_proto.Hunt{}
+	assert.ErrorIs(self.T(), launcher_pkg.CheckHuntScope(acl_manager, hunt),
+		acls.PermissionDenied)
+
+	hunt.Condition = &api_proto.HuntCondition{
+		UnionField: &api_proto.HuntCondition_Labels{
+			Labels: &api_proto.HuntLabelCondition{
+				Label: []string{"Workstation"},
+			},
+		},
+	}
+	assert.NoError(self.T(), launcher_pkg.CheckHuntScope(acl_manager, hunt))
+
+	// Unscoped principals are not restricted.
+	assert.NoError(self.T(), launcher_pkg.CheckHuntScope(
+		acl_managers.NullACLManager{}, &api_proto.Hunt{}))
+}
+
 func (self *LauncherTestSuite) TestParameterTypes() {
 	repository := self.LoadArtifacts(testArtifactWithTypes)
 
@@ -0,0 +1,193 @@
+package launcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/functions"
+)
+
+var (
+	// Absolute paths on Windows (C:\ or \\server\share) or Unix.
+	clientPathRegex = regexp.MustCompile(`^([a-zA-Z]:[\\/]|\\\\|/)`)
+)
+
+// Parameters are always passed to the client as strings. Check the
+// values of typed parameters before the collection is scheduled so
+// a malformed value is reported to the user rather than silently
+// failing on the endpoint. Empty values are allowed unless the
+// parameter is required.
+func ValidateParameters(
+	artifact *artifacts_proto.Artifact, spec *flows_proto.ArtifactSpec) error {
+	for _, parameter := range artifact.Parameters {
+		value, pres := getParameterValue(spec, parameter.Name)
+		if !pres {
+			value = parameter.Default
+		}
+
+		err := validateParameter(parameter, value)
+		if err != nil {
+			return fmt.Errorf("Invalid parameter %v for artifact %v: %w",
+				parameter.Name, artifact.Name, err)
+		}
+	}
+	return nil
+}
+
+func getParameterValue(spec *flows_proto.ArtifactSpec, name string) (string, bool) {
+	if spec == nil || spec.Parameters == nil {
+		return "", false
+	}
+
+	for _, item := range spec.Parameters.Env {
+		if item.Key == name {
+			return item.Value, true
+		}
+	}
+	return "", false
+}
+
+func validateParameter(
+	parameter *artifacts_proto.ArtifactParameter, value string) error {
+	if strings.TrimSpace(value) == "" {
+		if parameter.Required {
+			return errors.New("A value is required")
+		}
+		return nil
+	}
+
+	if parameter.ValidatingRegex != "" {
+		re, err := regexp.Compile(parameter.ValidatingRegex)
+		if err != nil {
+			return fmt.Errorf("Invalid validating_regex: %w", err)
+		}
+
+		if !re.MatchString(value) {
+			return fmt.Errorf("Value does not match %v", parameter.ValidatingRegex)
+		}
+	}
+
+	switch parameter.Type {
+	case "int", "int64", "integer":
+		_, err := strconv.ParseInt(strings.TrimSpace(value), 0, 64)
+		if err != nil {
+			return fmt.Errorf("Expected an integer: %v", value)
+		}
+
+	case "float":
+		_, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return fmt.Errorf("Expected a number: %v", value)
+		}
+
+	case "timestamp":
+		return validateTimestamp(value)
+
+	case "timestamp_range":
+		return validateTimestampRange(value)
+
+	case "choices":
+		for _, choice := range parameter.Choices {
+			if choice == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("Value must be one of %v", strings.Join(
+			parameter.Choices, ", "))
+
+	case "regex":
+		_, err := regexp.Compile(value)
+		if err != nil {
+			return fmt.Errorf("Invalid regex: %w", err)
+		}
+
+	case "regex_array":
+		items := []string{}
+		err := json.Unmarshal([]byte(value), &items)
+		if err != nil {
+			return fmt.Errorf("Expected a JSON array of regexes: %w", err)
+		}
+
+		for _, item := range items {
+			_, err := regexp.Compile(item)
+			if err != nil {
+				return fmt.Errorf("Invalid regex: %w", err)
+			}
+		}
+
+	case "json":
+		if !json.Valid([]byte(value)) {
+			return errors.New("Invalid JSON")
+		}
+
+	case "json_array":
+		items := []interface{}{}
+		err := json.Unmarshal([]byte(value), &items)
+		if err != nil {
+			return fmt.Errorf("Expected a JSON array: %w", err)
+		}
+
+	case "client_path":
+		if strings.Contains(value, "\x00") || !clientPathRegex.MatchString(value) {
+			return fmt.Errorf("Expected an absolute path: %v", value)
+		}
+
+	case "upload":
+		// Uploaded files are fetched by the client from the server.
+		parsed, err := url.Parse(value)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return fmt.Errorf("Expected the URL of an uploaded file: %v", value)
+		}
+	}
+
+	return nil
+}
+
+func validateTimestamp(value string) error {
+	scope := vql_subsystem.MakeScope()
+	defer scope.Close()
+
+	_, err := functions.TimeFromAny(scope, strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("Invalid timestamp %v", value)
+	}
+	return nil
+}
+
+// A timestamp range is two timestamps separated by / (like ISO 8601
+// intervals), e.g. 2022-01-01T00:00:00Z/2022-02-01T00:00:00Z. The
+// compiler passes it to the query as a dict with Start and End.
+func validateTimestampRange(value string) error {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 {
+		return fmt.Errorf(
+			"Expected a timestamp range of the form start/end: %v", value)
+	}
+
+	scope := vql_subsystem.MakeScope()
+	defer scope.Close()
+
+	start, err := functions.TimeFromAny(scope, strings.TrimSpace(parts[0]))
+	if err != nil {
+		return fmt.Errorf("Invalid start timestamp %v", parts[0])
+	}
+
+	end, err := functions.TimeFromAny(scope, strings.TrimSpace(parts[1]))
+	if err != nil {
+		return fmt.Errorf("Invalid end timestamp %v", parts[1])
+	}
+
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return errors.New("The end of the timestamp range is before its start")
+	}
+
+	return nil
+}
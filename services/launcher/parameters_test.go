@@ -0,0 +1,114 @@
+package launcher_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"www.velocidex.com/golang/velociraptor/actions"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/responder"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+)
+
+var testArtifactWithValidatedTypes = []string{`
+name: Test.Artifact.Validated
+parameters:
+- name: IntValue
+  type: int
+  default: "5"
+- name: Hostname
+  validating_regex: "^[a-z0-9.-]+$"
+- name: Level
+  type: choices
+  default: Low
+  choices:
+    - Low
+    - High
+- name: Path
+  type: client_path
+  required: true
+  default: C:/Windows
+- name: Range
+  type: timestamp_range
+- name: Upload
+  type: upload
+sources:
+- query: |
+    SELECT IntValue, Path, Range.Start.Unix AS Start, Range.End.Unix AS End
+    FROM scope()
+`}
+
+func (self *LauncherTestSuite) TestParameterValidation() {
+	repository := self.LoadArtifacts(testArtifactWithValidatedTypes)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	launcher, err := services.GetLauncher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	compile := func(env ...*actions_proto.VQLEnv) (
+		[]*actions_proto.VQLCollectorArgs, error) {
+		return launcher.CompileCollectorArgs(
+			ctx, self.ConfigObj, acl_managers.NullACLManager{}, repository,
+			services.CompilerOptions{}, &flows_proto.ArtifactCollectorArgs{
+				Creator:   "UserX",
+				ClientId:  "C.1234",
+				Artifacts: []string{"Test.Artifact.Validated"},
+				Specs: []*flows_proto.ArtifactSpec{{
+					Artifact: "Test.Artifact.Validated",
+					Parameters: &flows_proto.ArtifactParameters{
+						Env: env,
+					},
+				}},
+			})
+	}
+
+	for _, env := range []*actions_proto.VQLEnv{
+		{Key: "IntValue", Value: "five"},
+		{Key: "Hostname", Value: "host; rm -rf /"},
+		{Key: "Level", Value: "Medium"},
+		{Key: "Path", Value: "Windows\\System32"},
+		{Key: "Path", Value: ""},
+		{Key: "Range", Value: "2022-02-01T00:00:00Z"},
+		{Key: "Range", Value: "2022-02-01T00:00:00Z/2022-01-01T00:00:00Z"},
+		{Key: "Upload", Value: "/etc/passwd"},
+	} {
+		_, err := compile(env)
+		assert.Error(self.T(), err, "Accepted %v=%v", env.Key, env.Value)
+		assert.Contains(self.T(), err.Error(), env.Key)
+	}
+
+	compiled, err := compile(
+		&actions_proto.VQLEnv{Key: "Hostname", Value: "www.example.com"},
+		&actions_proto.VQLEnv{Key: "Path", Value: "/usr/bin"},
+		&actions_proto.VQLEnv{Key: "Range",
+			Value: "2022-01-01T00:00:00Z/2022-02-01T00:00:00Z"},
+		&actions_proto.VQLEnv{Key: "Upload",
+			Value: "https://www.example.com/public/file.bin"})
+	assert.NoError(self.T(), err)
+
+	// Timestamp ranges are converted to a dict of timestamps.
+	test_responder := responder.TestResponderWithFlowId(
+		self.ConfigObj, "F.TestParameterValidation")
+	for _, vql_request := range compiled {
+		actions.VQLClientAction{}.StartQuery(
+			self.ConfigObj, ctx, test_responder, vql_request)
+	}
+
+	var messages []*crypto_proto.VeloMessage
+	vtesting.WaitUntil(time.Second, self.T(), func() bool {
+		messages = test_responder.Drain.Messages()
+		return len(messages) > 0
+	})
+
+	assert.Equal(self.T(),
+		`[{"IntValue":5,"Path":"/usr/bin","Start":1640995200,"End":1643673600}]`,
+		json.MustMarshalString(getResponses(messages)))
+}
@@ -0,0 +1,213 @@
+/*
+  The materialized views service maintains aggregations of event
+  artifacts as the events arrive.
+
+  Event artifacts are stored as raw rows, so questions like "which
+  processes ran on each client each day" require reading months of
+  events again every time. A view watches an event artifact and runs
+  its query over each batch of new events (available as the rows
+  variable). The results are grouped by the view's group_by columns
+  into buckets of period seconds (one day by default), and each group
+  keeps the number of events (Count) and when it was first and last
+  seen.
+
+  Each bucket is stored as its own small result set which is
+  rewritten while the bucket is current, so reading a view is cheap
+  (see the materialized_view() plugin). Buckets are loaded again
+  after a restart so the aggregation continues where it left off.
+
+  Events are placed in the bucket of the time the server received
+  them. FirstSeen and LastSeen are the times the events were received
+  unless the query keeps the event's _ts column.
+*/
+
+package materialized_views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
+)
+
+const (
+	DEFAULT_FLUSH_FREQUENCY = 60
+
+	// Pending events are aggregated this often to bound the memory
+	// they use.
+	PROCESS_FREQUENCY = 10 * time.Second
+)
+
+type MaterializedViewService struct {
+	config_obj *config_proto.Config
+	views      []*view
+}
+
+// Aggregate the pending events of all views.
+func (self *MaterializedViewService) Process(ctx context.Context) error {
+	for _, view := range self.views {
+		err := view.process(ctx)
+		if err != nil {
+			return fmt.Errorf("View %v: %w", view.config.Name, err)
+		}
+	}
+	return nil
+}
+
+// Aggregate the pending events and write the changed buckets.
+func (self *MaterializedViewService) Flush(ctx context.Context) error {
+	err := self.Process(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, view := range self.views {
+		err := view.flush()
+		if err != nil {
+			return fmt.Errorf("View %v: %w", view.config.Name, err)
+		}
+	}
+	return nil
+}
+
+// Find the view's definition in the config.
+func GetView(config_obj *config_proto.Config,
+	name string) (*config_proto.MaterializedViewConfig, error) {
+	if config_obj.MaterializedViews != nil {
+		for _, view_config := range config_obj.MaterializedViews.Views {
+			if view_config.Name == name {
+				return view_config, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("Materialized view %v not found", name)
+}
+
+// The start times of the view's stored buckets, oldest first.
+func ListBuckets(config_obj *config_proto.Config,
+	name string) ([]time.Time, error) {
+	file_store_factory := file_store.GetFileStore(config_obj)
+	children, err := file_store_factory.ListDirectory(
+		paths.NewMaterializedViewPathManager(name).Directory())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]time.Time, 0, len(children))
+	for _, child := range children {
+		// We only want to see the JSON files
+		if child.PathSpec().Type() != api.PATH_TYPE_FILESTORE_JSON {
+			continue
+		}
+
+		start, err := strconv.ParseInt(child.PathSpec().Base(), 10, 64)
+		if err != nil {
+			continue
+		}
+		result = append(result, time.Unix(start, 0).UTC())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Before(result[j])
+	})
+	return result, nil
+}
+
+func NewMaterializedViewService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*MaterializedViewService, error) {
+
+	result := &MaterializedViewService{
+		config_obj: config_obj,
+	}
+
+	views_config := config_obj.MaterializedViews
+	names := make(map[string]bool)
+	for _, view_config := range views_config.Views {
+		view, err := newView(config_obj, view_config)
+		if err != nil {
+			return nil, fmt.Errorf("MaterializedViews: %w", err)
+		}
+
+		if names[view_config.Name] {
+			return nil, errors.New(
+				"MaterializedViews: Duplicate view " + view_config.Name)
+		}
+		names[view_config.Name] = true
+
+		result.views = append(result.views, view)
+	}
+
+	frequency := time.Duration(views_config.FlushFrequency) * time.Second
+	if frequency == 0 {
+		frequency = DEFAULT_FLUSH_FREQUENCY * time.Second
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> materialized views service for %v.",
+		services.GetOrgName(config_obj))
+
+	for _, view := range result.views {
+		view := view
+		err := journal.WatchQueueWithCB(ctx, config_obj, wg,
+			view.config.Artifact, "MaterializedViews",
+			func(ctx context.Context, config_obj *config_proto.Config,
+				row *ordereddict.Dict) error {
+				view.add(row)
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		process := time.NewTicker(PROCESS_FREQUENCY)
+		defer process.Stop()
+
+		flush := time.NewTicker(frequency)
+		defer flush.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				// Write what we have so far before we exit.
+				err := result.Flush(context.Background())
+				if err != nil {
+					logger.Error("MaterializedViews: %v", err)
+				}
+				return
+
+			case <-process.C:
+				err := result.Process(ctx)
+				if err != nil {
+					logger.Error("MaterializedViews: %v", err)
+				}
+
+			case <-flush.C:
+				err := result.Flush(ctx)
+				if err != nil {
+					logger.Error("MaterializedViews: %v", err)
+				}
+			}
+		}
+	}()
+
+	return result, nil
+}
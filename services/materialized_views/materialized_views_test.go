@@ -0,0 +1,170 @@
+package materialized_views_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/materialized_views"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+)
+
+type MaterializedViewsTestSuite struct {
+	test_utils.TestSuite
+
+	clock *utils.MockClock
+	close func()
+}
+
+func (self *MaterializedViewsTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.MaterializedViews = &config_proto.MaterializedViewsConfig{
+		Views: []*config_proto.MaterializedViewConfig{{
+			Name:     "DailyProcesses",
+			Artifact: "Server.Process.Test",
+			Query:    "SELECT ClientId, Name FROM rows WHERE Name != 'idle'",
+		}},
+	}
+	self.LoadArtifacts([]string{`
+name: Server.Process.Test
+type: SERVER_EVENT
+`})
+	self.TestSuite.SetupTest()
+
+	self.clock = &utils.MockClock{MockNow: time.Unix(1600000000, 0)}
+	self.close = utils.MockTime(self.clock)
+}
+
+func (self *MaterializedViewsTestSuite) TearDownTest() {
+	self.close()
+	self.TestSuite.TearDownTest()
+}
+
+func (self *MaterializedViewsTestSuite) pushEvents(names ...string) {
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	rows := []*ordereddict.Dict{}
+	for _, name := range names {
+		rows = append(rows, ordereddict.NewDict().
+			Set("ClientId", "C.1").
+			Set("Name", name).
+			Set("Pid", len(rows)))
+	}
+
+	err = journal.PushRowsToArtifact(self.ConfigObj,
+		rows, "Server.Process.Test", "server", "")
+	assert.NoError(self.T(), err)
+}
+
+func (self *MaterializedViewsTestSuite) readBucket(start time.Time) []*ordereddict.Dict {
+	reader, err := result_sets.NewResultSetReader(
+		file_store.GetFileStore(self.ConfigObj),
+		paths.NewMaterializedViewPathManager("DailyProcesses").Bucket(start))
+	if err != nil {
+		return nil
+	}
+	defer reader.Close()
+
+	result := []*ordereddict.Dict{}
+	for row := range reader.Rows(context.Background()) {
+		result = append(result, row)
+	}
+	return result
+}
+
+// Wait for the events to be delivered and written.
+func (self *MaterializedViewsTestSuite) waitForCount(
+	service *materialized_views.MaterializedViewService,
+	start time.Time, name string, count int64) []*ordereddict.Dict {
+	var rows []*ordereddict.Dict
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		assert.NoError(self.T(), service.Flush(self.Ctx))
+		rows = self.readBucket(start)
+		for _, row := range rows {
+			if utils.GetString(row, "Name") == name {
+				return utils.GetInt64(row, "Count") == count
+			}
+		}
+		return false
+	})
+	return rows
+}
+
+func (self *MaterializedViewsTestSuite) TestView() {
+	service, err := materialized_views.NewMaterializedViewService(
+		self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	day := time.Date(2020, 9, 13, 0, 0, 0, 0, time.UTC)
+
+	self.pushEvents("cmd.exe", "idle", "cmd.exe", "svchost.exe")
+	rows := self.waitForCount(service, day, "cmd.exe", 2)
+
+	// The query filtered out idle and dropped the Pid column.
+	assert.Equal(self.T(), 2, len(rows))
+	golden := ordereddict.NewDict().
+		Set("ClientId", "C.1").
+		Set("Name", "cmd.exe").
+		Set("Count", 2).
+		Set("FirstSeen", self.clock.MockNow.UTC()).
+		Set("LastSeen", self.clock.MockNow.UTC())
+	assert.Equal(self.T(), json.MustMarshalString(golden),
+		json.MustMarshalString(rows[0]))
+
+	// Later events in the same day update the bucket.
+	self.clock.MockNow = self.clock.MockNow.Add(time.Hour)
+	self.pushEvents("cmd.exe")
+	rows = self.waitForCount(service, day, "cmd.exe", 3)
+	assert.Equal(self.T(), self.clock.MockNow.UTC(), utils.GetAny(rows[0], "LastSeen"))
+
+	// The next day starts a new bucket.
+	self.clock.MockNow = day.Add(25 * time.Hour)
+	self.pushEvents("cmd.exe")
+	self.waitForCount(service, day.Add(24*time.Hour), "cmd.exe", 1)
+
+	buckets, err := materialized_views.ListBuckets(
+		self.ConfigObj, "DailyProcesses")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), []time.Time{day, day.Add(24 * time.Hour)}, buckets)
+
+	// The previous day is not changed.
+	assert.Equal(self.T(), int64(3),
+		utils.GetInt64(self.readBucket(day)[0], "Count"))
+}
+
+func (self *MaterializedViewsTestSuite) TestRestart() {
+	ctx, cancel := context.WithCancel(self.Ctx)
+	service, err := materialized_views.NewMaterializedViewService(
+		ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	day := time.Date(2020, 9, 13, 0, 0, 0, 0, time.UTC)
+
+	self.pushEvents("cmd.exe")
+	self.waitForCount(service, day, "cmd.exe", 1)
+	cancel()
+
+	// A new service continues counting from the stored bucket.
+	service, err = materialized_views.NewMaterializedViewService(
+		self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	self.pushEvents("cmd.exe")
+	self.waitForCount(service, day, "cmd.exe", 2)
+}
+
+func TestMaterializedViews(t *testing.T) {
+	suite.Run(t, &MaterializedViewsTestSuite{})
+}
@@ -0,0 +1,381 @@
+package materialized_views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	DEFAULT_QUERY      = "SELECT * FROM rows"
+	DEFAULT_PERIOD     = 86400
+	DEFAULT_MAX_GROUPS = 100000
+)
+
+var (
+	// Columns added by the view to each group.
+	aggregate_columns = []string{"Count", "FirstSeen", "LastSeen"}
+)
+
+// The aggregated values of a single group.
+type group struct {
+	key        string
+	values     *ordereddict.Dict
+	count      uint64
+	first_seen time.Time
+	last_seen  time.Time
+}
+
+type bucket struct {
+	start  time.Time
+	groups map[string]*group
+
+	// Changed since it was last written.
+	dirty bool
+
+	// New groups dropped because the bucket is full.
+	dropped uint64
+}
+
+// Events which were received but not aggregated yet.
+type batch struct {
+	rows []*ordereddict.Dict
+	last time.Time
+}
+
+type view struct {
+	mu sync.Mutex
+
+	config_obj *config_proto.Config
+	config     *config_proto.MaterializedViewConfig
+
+	query      []*vfilter.VQL
+	period     int64
+	max_groups int
+
+	// Keyed by the bucket start time in epoch seconds.
+	pending map[int64]*batch
+	buckets map[int64]*bucket
+}
+
+func newView(config_obj *config_proto.Config,
+	view_config *config_proto.MaterializedViewConfig) (*view, error) {
+
+	if view_config.Name == "" {
+		return nil, errors.New("View name must be set")
+	}
+
+	if view_config.Artifact == "" {
+		return nil, fmt.Errorf("View %v: artifact must be set", view_config.Name)
+	}
+
+	query := view_config.Query
+	if query == "" {
+		query = DEFAULT_QUERY
+	}
+
+	vql, err := vfilter.MultiParse(query)
+	if err != nil {
+		return nil, fmt.Errorf("View %v: %w", view_config.Name, err)
+	}
+
+	result := &view{
+		config_obj: config_obj,
+		config:     view_config,
+		query:      vql,
+		period:     int64(view_config.Period),
+		max_groups: int(view_config.MaxGroups),
+		pending:    make(map[int64]*batch),
+		buckets:    make(map[int64]*bucket),
+	}
+
+	if result.period == 0 {
+		result.period = DEFAULT_PERIOD
+	}
+
+	if result.max_groups == 0 {
+		result.max_groups = DEFAULT_MAX_GROUPS
+	}
+
+	return result, nil
+}
+
+// Events are placed in the bucket of the time they were received.
+func (self *view) bucketStart(now time.Time) int64 {
+	ts := now.Unix()
+	return ts - ts%self.period
+}
+
+// Queue an event until the next batch is aggregated.
+func (self *view) add(row *ordereddict.Dict) {
+	now := utils.GetTime().Now()
+	start := self.bucketStart(now)
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	pending, pres := self.pending[start]
+	if !pres {
+		pending = &batch{}
+		self.pending[start] = pending
+	}
+	pending.rows = append(pending.rows, row)
+	pending.last = now
+}
+
+// Run the query over the pending events and add the results to
+// their buckets.
+func (self *view) process(ctx context.Context) error {
+	self.mu.Lock()
+	pending := self.pending
+	self.pending = make(map[int64]*batch)
+	self.mu.Unlock()
+
+	for start, batch := range pending {
+		rows, err := self.evaluate(ctx, batch.rows)
+		if err != nil {
+			return err
+		}
+
+		self.aggregate(start, batch.last, rows)
+	}
+
+	return nil
+}
+
+func (self *view) evaluate(ctx context.Context,
+	rows []*ordereddict.Dict) ([]*ordereddict.Dict, error) {
+
+	manager, err := services.GetRepositoryManager(self.config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logging.NewPlainLogger(self.config_obj,
+		&logging.FrontendComponent)
+
+	// The view is defined in the server config so the query runs with
+	// full permissions.
+	scope := manager.BuildScope(services.ScopeBuilder{
+		Config:     self.config_obj,
+		ACLManager: acl_managers.NullACLManager{},
+		Env: ordereddict.NewDict().
+			Set("rows", rows),
+		Logger: logger,
+	})
+	defer scope.Close()
+
+	var result []*ordereddict.Dict
+	for _, vql := range self.query {
+		for row := range vql.Eval(ctx, scope) {
+			// Round trip the row through json so values compare the
+			// same way as the values loaded from stored buckets.
+			serialized, err := json.Marshal(row)
+			if err != nil {
+				continue
+			}
+
+			dicts, err := utils.ParseJsonToDicts(serialized)
+			if err != nil || len(dicts) == 0 {
+				continue
+			}
+			result = append(result, dicts[0])
+		}
+	}
+
+	return result, ctx.Err()
+}
+
+// The columns the row is grouped by and the group's key.
+func (self *view) groupOf(row *ordereddict.Dict) (*ordereddict.Dict, string) {
+	values := ordereddict.NewDict()
+	if len(self.config.GroupBy) > 0 {
+		for _, column := range self.config.GroupBy {
+			value, _ := row.Get(column)
+			values.Set(column, value)
+		}
+	} else {
+		for _, column := range row.Keys() {
+			if column == "_ts" || utils.InString(aggregate_columns, column) {
+				continue
+			}
+			value, _ := row.Get(column)
+			values.Set(column, value)
+		}
+	}
+
+	return values, json.MustMarshalString(values)
+}
+
+func (self *view) aggregate(start int64, received time.Time,
+	rows []*ordereddict.Dict) {
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	bucket := self.getBucket(start)
+	for _, row := range rows {
+		// Use the event time if the query kept it.
+		ts := received
+		value, pres := row.Get("_ts")
+		if pres {
+			epoch, ok := utils.ToInt64(value)
+			if ok {
+				ts = utils.ParseTimeFromInt64(epoch)
+			}
+		}
+
+		values, key := self.groupOf(row)
+		g, pres := bucket.groups[key]
+		if !pres {
+			if len(bucket.groups) >= self.max_groups {
+				if bucket.dropped == 0 {
+					logger := logging.GetLogger(
+						self.config_obj, &logging.FrontendComponent)
+					logger.Error("MaterializedViews: View %v has more than "+
+						"%v groups - dropping new groups.",
+						self.config.Name, self.max_groups)
+				}
+				bucket.dropped++
+				continue
+			}
+
+			g = &group{
+				key:        key,
+				values:     values,
+				first_seen: ts,
+				last_seen:  ts,
+			}
+			bucket.groups[key] = g
+		}
+
+		g.count++
+		if ts.Before(g.first_seen) {
+			g.first_seen = ts
+		}
+		if ts.After(g.last_seen) {
+			g.last_seen = ts
+		}
+		bucket.dirty = true
+	}
+}
+
+// Get the bucket from memory, or load it from the file store so
+// aggregation continues where it left off before a restart.
+func (self *view) getBucket(start int64) *bucket {
+	result, pres := self.buckets[start]
+	if pres {
+		return result
+	}
+
+	result = &bucket{
+		start:  time.Unix(start, 0).UTC(),
+		groups: make(map[string]*group),
+	}
+
+	reader, err := result_sets.NewResultSetReader(
+		file_store.GetFileStore(self.config_obj),
+		paths.NewMaterializedViewPathManager(self.config.Name).
+			Bucket(result.start))
+	if err == nil {
+		defer reader.Close()
+
+		for row := range reader.Rows(context.Background()) {
+			g := &group{values: ordereddict.NewDict()}
+			for _, column := range row.Keys() {
+				value, _ := row.Get(column)
+				switch column {
+				case "Count":
+					count, _ := utils.ToInt64(value)
+					g.count = uint64(count)
+				case "FirstSeen":
+					g.first_seen, _ = value.(time.Time)
+				case "LastSeen":
+					g.last_seen, _ = value.(time.Time)
+				default:
+					g.values.Set(column, value)
+				}
+			}
+			g.key = json.MustMarshalString(g.values)
+			result.groups[g.key] = g
+		}
+	}
+
+	self.buckets[start] = result
+	return result
+}
+
+// Write the changed buckets and forget the ones which will not
+// change any more.
+func (self *view) flush() error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	current := self.bucketStart(utils.GetTime().Now())
+	for start, bucket := range self.buckets {
+		if bucket.dirty {
+			err := self.writeBucket(bucket)
+			if err != nil {
+				return err
+			}
+			bucket.dirty = false
+		}
+
+		_, pending := self.pending[start]
+		if start < current && !pending {
+			delete(self.buckets, start)
+		}
+	}
+
+	return nil
+}
+
+func (self *view) writeBucket(bucket *bucket) error {
+	groups := make([]*group, 0, len(bucket.groups))
+	for _, g := range bucket.groups {
+		groups = append(groups, g)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if !groups[i].first_seen.Equal(groups[j].first_seen) {
+			return groups[i].first_seen.Before(groups[j].first_seen)
+		}
+		return groups[i].key < groups[j].key
+	})
+
+	writer, err := result_sets.NewResultSetWriter(
+		file_store.GetFileStore(self.config_obj),
+		paths.NewMaterializedViewPathManager(self.config.Name).
+			Bucket(bucket.start),
+		json.DefaultEncOpts(), utils.SyncCompleter, result_sets.TruncateMode)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	for _, g := range groups {
+		row := ordereddict.NewDict()
+		row.MergeFrom(g.values)
+		writer.Write(row.
+			Set("Count", g.count).
+			Set("FirstSeen", g.first_seen.UTC()).
+			Set("LastSeen", g.last_seen.UTC()))
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package misp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql/networking"
+)
+
+const (
+	// Number of attributes requested from MISP at a time.
+	pageSize = 5000
+
+	// Do not read unreasonably large responses into memory.
+	maxResponseSize = 100 * 1024 * 1024
+)
+
+type mispTag struct {
+	Name string `json:"name"`
+}
+
+// An attribute as returned by the MISP restSearch API. MISP returns
+// most fields as strings.
+type mispAttribute struct {
+	Id        string    `json:"id"`
+	EventId   string    `json:"event_id"`
+	Type      string    `json:"type"`
+	Category  string    `json:"category"`
+	Value     string    `json:"value"`
+	Comment   string    `json:"comment"`
+	ToIds     bool      `json:"to_ids"`
+	Timestamp string    `json:"timestamp"`
+	Tag       []mispTag `json:"Tag"`
+}
+
+type restSearchResponse struct {
+	Response struct {
+		Attribute []*mispAttribute `json:"Attribute"`
+	} `json:"response"`
+}
+
+// A minimal client for the MISP REST API.
+type MISPClient struct {
+	config_obj *config_proto.Config
+	client     *http.Client
+}
+
+func (self *MISPClient) call(ctx context.Context,
+	path string, request interface{}, response interface{}) error {
+
+	misp_config := self.config_obj.Misp
+	if misp_config == nil || misp_config.Url == "" {
+		return errors.New("MISP is not configured")
+	}
+
+	serialized, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(misp_config.Url, "/") + path
+	req, err := http.NewRequestWithContext(ctx, "POST", url,
+		bytes.NewReader(serialized))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", misp_config.ApiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := self.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("MISP %v returned %v", path, resp.Status)
+	}
+
+	if response == nil {
+		return nil
+	}
+	return json.Unmarshal(body, response)
+}
+
+// Fetch all the attributes matching the feed.
+func (self *MISPClient) FetchAttributes(ctx context.Context,
+	feed *config_proto.MISPFeedConfig) ([]*ordereddict.Dict, error) {
+
+	request := ordereddict.NewDict().
+		Set("returnFormat", "json").
+		Set("limit", pageSize)
+
+	if len(feed.Types) > 0 {
+		request.Set("type", feed.Types)
+	}
+	if len(feed.Tags) > 0 {
+		request.Set("tags", feed.Tags)
+	}
+	if feed.Last != "" {
+		request.Set("last", feed.Last)
+	}
+	if !feed.IncludeNonIds {
+		request.Set("to_ids", 1)
+	}
+
+	result := []*ordereddict.Dict{}
+	for page := 1; ; page++ {
+		request.Update("page", page)
+
+		response := &restSearchResponse{}
+		err := self.call(ctx, "/attributes/restSearch", request, response)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, attribute := range response.Response.Attribute {
+			result = append(result, attributeToRows(attribute)...)
+		}
+
+		if len(response.Response.Attribute) < pageSize {
+			return result, nil
+		}
+	}
+}
+
+// Report a sighting of an attribute. If the attribute id is not
+// known the sighting is recorded against all attributes with the
+// value.
+func (self *MISPClient) AddSighting(ctx context.Context,
+	attribute_id, value, source string) error {
+
+	if attribute_id == "" && value == "" {
+		return errors.New("A sighting needs an attribute id or a value")
+	}
+
+	request := ordereddict.NewDict().
+		Set("source", source).
+		Set("timestamp", utils.GetTime().Now().Unix())
+
+	if attribute_id != "" {
+		request.Set("id", attribute_id)
+	} else {
+		request.Set("values", []string{value})
+	}
+
+	return self.call(ctx, "/sightings/add", request, nil)
+}
+
+// Composite attributes (e.g. filename|sha256) hold two indicators
+// which are stored as separate rows.
+func attributeToRows(attribute *mispAttribute) []*ordereddict.Dict {
+	tags := make([]string, 0, len(attribute.Tag))
+	for _, tag := range attribute.Tag {
+		tags = append(tags, tag.Name)
+	}
+
+	types := strings.Split(attribute.Type, "|")
+	values := strings.Split(attribute.Value, "|")
+	if len(types) != len(values) {
+		types = []string{attribute.Type}
+		values = []string{attribute.Value}
+	}
+
+	timestamp, _ := utils.ToInt64(attribute.Timestamp)
+
+	result := make([]*ordereddict.Dict, 0, len(types))
+	for i := range types {
+		result = append(result, ordereddict.NewDict().
+			Set("Type", types[i]).
+			Set("Value", values[i]).
+			Set("Category", attribute.Category).
+			Set("Comment", attribute.Comment).
+			Set("Tags", tags).
+			Set("EventId", attribute.EventId).
+			Set("AttributeId", attribute.Id).
+			Set("ToIds", attribute.ToIds).
+			Set("Timestamp", timestamp))
+	}
+	return result
+}
+
+func NewMISPClient(config_obj *config_proto.Config) (*MISPClient, error) {
+	client, err := networking.GetDefaultHTTPClient(config_obj.Client, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &MISPClient{
+		config_obj: config_obj,
+		client:     client,
+	}, nil
+}
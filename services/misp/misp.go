@@ -0,0 +1,411 @@
+/*
+  The MISP service connects the server to a MISP threat intelligence
+  platform.
+
+  Each configured feed is periodically synced from the MISP
+  attributes API into an IOC table (see the iocs package) so hunts
+  can use the indicators, e.g. as the parameters of a hash or domain
+  search artifact. Composite attributes are split and duplicate
+  indicators are removed.
+
+  When hunt flows collecting one of the configured sighting
+  artifacts complete, their results are matched against the
+  indicators in the MISP tables and each match is reported back to
+  MISP as a sighting. A sighting is only reported once per client
+  and attribute.
+
+  The service runs on the master node alongside the hunt manager.
+*/
+
+package misp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/iocs"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	// IOC tables synced from MISP have this source.
+	SOURCE = "misp"
+
+	// Bound the memory used to remember reported sightings.
+	maxReportedSightings = 100000
+)
+
+// Sync the configured feeds into their IOC tables. If name is
+// specified only that feed is synced. A failing feed does not stop
+// the other feeds from syncing.
+func Sync(ctx context.Context, config_obj *config_proto.Config,
+	name string) ([]*api_proto.IOCTable, error) {
+
+	if config_obj.Misp == nil {
+		return nil, fmt.Errorf("MISP is not configured")
+	}
+
+	client, err := NewMISPClient(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*api_proto.IOCTable{}
+	found := false
+	var last_err error
+	for _, feed := range config_obj.Misp.Feeds {
+		if name != "" && feed.Name != name {
+			continue
+		}
+		found = true
+
+		table, err := syncFeed(ctx, config_obj, client, feed)
+		if err != nil {
+			last_err = fmt.Errorf("Feed %v: %w", feed.Name, err)
+			continue
+		}
+		result = append(result, table)
+	}
+
+	if !found && name != "" {
+		return nil, fmt.Errorf("Feed %v is not configured", name)
+	}
+
+	return result, last_err
+}
+
+func syncFeed(ctx context.Context, config_obj *config_proto.Config,
+	client *MISPClient, feed *config_proto.MISPFeedConfig) (
+	*api_proto.IOCTable, error) {
+
+	table := &api_proto.IOCTable{
+		Name:        feed.Name,
+		Source:      SOURCE,
+		Description: "Attributes synced from " + config_obj.Misp.Url,
+		LastSync:    utils.GetTime().Now().Unix(),
+	}
+
+	rows, err := client.FetchAttributes(ctx, feed)
+	if err != nil {
+		// Keep the previous indicators but record the error.
+		previous, err1 := iocs.GetTable(config_obj, feed.Name)
+		if err1 == nil {
+			table.TotalIocs = previous.TotalIocs
+		}
+		table.LastError = err.Error()
+		_ = iocs.SetTableMetadata(config_obj, table)
+		return nil, err
+	}
+
+	err = iocs.SetTable(config_obj, table, rows)
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// Report a sighting to MISP.
+func AddSighting(ctx context.Context, config_obj *config_proto.Config,
+	attribute_id, value, source string) error {
+
+	if config_obj.Misp == nil {
+		return fmt.Errorf("MISP is not configured")
+	}
+
+	if source == "" {
+		source = config_obj.Misp.SightingSource
+	}
+	if source == "" {
+		source = "Velociraptor"
+	}
+
+	client, err := NewMISPClient(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return client.AddSighting(ctx, attribute_id, value, source)
+}
+
+type MISPService struct {
+	config_obj *config_proto.Config
+	client     *MISPClient
+
+	mu sync.Mutex
+
+	// Map of lowercased indicator value to MISP attribute id.
+	index map[string]string
+
+	// The LastSync of the tables the index was built from.
+	index_version map[string]int64
+
+	// Sightings already reported keyed by attribute id and client.
+	reported map[string]bool
+}
+
+// Rebuild the index if any of the MISP tables changed.
+func (self *MISPService) getIndex(
+	ctx context.Context) (map[string]string, error) {
+	tables, err := iocs.ListTables(self.config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	version := make(map[string]int64)
+	for _, table := range tables {
+		if table.Source == SOURCE {
+			version[table.Name] = table.LastSync
+		}
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.index != nil && sameVersion(version, self.index_version) {
+		return self.index, nil
+	}
+
+	index := make(map[string]string)
+	for name := range version {
+		rows, err := iocs.ReadTable(ctx, self.config_obj, name)
+		if err != nil {
+			continue
+		}
+
+		for row := range rows {
+			value, _ := row.GetString("Value")
+			attribute_id, _ := row.GetString("AttributeId")
+			value = strings.ToLower(strings.TrimSpace(value))
+			if value != "" && attribute_id != "" {
+				index[value] = attribute_id
+			}
+		}
+	}
+
+	self.index = index
+	self.index_version = version
+	return index, nil
+}
+
+func sameVersion(a, b map[string]int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (self *MISPService) ProcessFlowCompletion(ctx context.Context,
+	config_obj *config_proto.Config, row *ordereddict.Dict) error {
+
+	misp_config := self.config_obj.Misp
+	if len(misp_config.SightingArtifacts) == 0 {
+		return nil
+	}
+
+	flow, err := journal.GetFlowFromQueue(config_obj, row)
+	if err != nil {
+		return err
+	}
+
+	// Only hunt results are reported.
+	if flow.Request == nil ||
+		!strings.HasPrefix(flow.Request.Creator, constants.HUNT_PREFIX) {
+		return nil
+	}
+
+	var index map[string]string
+	for _, artifact_name := range flow.ArtifactsWithResults {
+		if !self.isSightingArtifact(artifact_name) {
+			continue
+		}
+
+		if index == nil {
+			index, err = self.getIndex(ctx)
+			if err != nil {
+				return err
+			}
+
+			// No indicators to match.
+			if len(index) == 0 {
+				return nil
+			}
+		}
+
+		err = self.matchArtifact(ctx, flow, artifact_name, index)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Artifacts are matched by their full name (including the source)
+// or by the artifact name alone.
+func (self *MISPService) isSightingArtifact(artifact_name string) bool {
+	base_name, _ := paths.SplitFullSourceName(artifact_name)
+	for _, name := range self.config_obj.Misp.SightingArtifacts {
+		if name == artifact_name || name == base_name {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *MISPService) matchArtifact(ctx context.Context,
+	flow *flows_proto.ArtifactCollectorContext,
+	artifact_name string, index map[string]string) error {
+
+	path_manager, err := artifacts.NewArtifactPathManager(self.config_obj,
+		flow.ClientId, flow.SessionId, artifact_name)
+	if err != nil {
+		return err
+	}
+
+	file_store_factory := file_store.GetFileStore(self.config_obj)
+	reader, err := result_sets.NewResultSetReader(
+		file_store_factory, path_manager.Path())
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	columns := self.config_obj.Misp.SightingColumns
+	for row := range reader.Rows(ctx) {
+		keys := columns
+		if len(keys) == 0 {
+			keys = row.Keys()
+		}
+
+		for _, column := range keys {
+			value, _ := row.GetString(column)
+			attribute_id, pres := index[strings.ToLower(strings.TrimSpace(value))]
+			if !pres {
+				continue
+			}
+
+			self.reportSighting(ctx, attribute_id, flow)
+		}
+	}
+
+	return nil
+}
+
+func (self *MISPService) reportSighting(ctx context.Context,
+	attribute_id string, flow *flows_proto.ArtifactCollectorContext) {
+
+	key := attribute_id + "|" + flow.ClientId
+
+	self.mu.Lock()
+	if self.reported[key] {
+		self.mu.Unlock()
+		return
+	}
+	if len(self.reported) >= maxReportedSightings {
+		self.reported = make(map[string]bool)
+	}
+	self.reported[key] = true
+	self.mu.Unlock()
+
+	source := self.config_obj.Misp.SightingSource
+	if source == "" {
+		source = "Velociraptor"
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	err := self.client.AddSighting(ctx, attribute_id, "", source)
+	if err != nil {
+		logger.Error("MISPService: reporting sighting of %v on %v: %v",
+			attribute_id, flow.ClientId, err)
+
+		// Try again next time.
+		self.mu.Lock()
+		delete(self.reported, key)
+		self.mu.Unlock()
+		return
+	}
+
+	logger.Info("MISPService: reported sighting of attribute %v on %v (%v)",
+		attribute_id, flow.ClientId, flow.Request.Creator)
+}
+
+func NewMISPService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*MISPService, error) {
+
+	client, err := NewMISPClient(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MISPService{
+		config_obj: config_obj,
+		client:     client,
+		reported:   make(map[string]bool),
+	}
+
+	interval := time.Duration(3600) * time.Second
+	if config_obj.Misp.SyncPeriodSeconds > 0 {
+		interval = time.Duration(
+			config_obj.Misp.SyncPeriodSeconds) * time.Second
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> MISP service for %v.",
+		services.GetOrgName(config_obj))
+
+	err = journal.WatchQueueWithCB(ctx, config_obj, wg,
+		"System.Flow.Completion", "MISPService",
+		result.ProcessFlowCompletion)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config_obj.Misp.Feeds) == 0 {
+		return result, nil
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		// Sync as soon as we start.
+		delay := time.Duration(0)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(delay):
+				delay = interval
+				_, err := Sync(ctx, config_obj, "")
+				if err != nil {
+					logger.Error("MISPService: %v", err)
+				}
+			}
+		}
+	}()
+
+	return result, nil
+}
@@ -0,0 +1,209 @@
+package misp_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/iocs"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services/misp"
+	"www.velocidex.com/golang/velociraptor/utils"
+
+	_ "www.velocidex.com/golang/velociraptor/result_sets/simple"
+)
+
+var attributes = `{"response": {"Attribute": [
+ {"id": "1", "event_id": "10", "type": "sha256", "category": "Payload delivery",
+  "value": "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855",
+  "to_ids": true, "timestamp": "1600000000", "Tag": [{"name": "tlp:white"}]},
+ {"id": "2", "event_id": "10", "type": "filename|md5",
+  "value": "evil.exe|d41d8cd98f00b204e9800998ecf8427e",
+  "to_ids": true, "timestamp": "1600000000"},
+ {"id": "3", "event_id": "11", "type": "sha256",
+  "value": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+  "to_ids": true, "timestamp": "1600000100"}
+]}}`
+
+type MISPTestSuite struct {
+	test_utils.TestSuite
+
+	server *httptest.Server
+
+	mu        sync.Mutex
+	searches  []*ordereddict.Dict
+	sightings []*ordereddict.Dict
+}
+
+func (self *MISPTestSuite) SetupTest() {
+	self.searches = nil
+	self.sightings = nil
+
+	self.server = httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "secret" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			request := ordereddict.NewDict()
+			_ = json.Unmarshal(body, request)
+
+			self.mu.Lock()
+			defer self.mu.Unlock()
+
+			switch r.URL.Path {
+			case "/attributes/restSearch":
+				self.searches = append(self.searches, request)
+				w.Write([]byte(attributes))
+
+			case "/sightings/add":
+				self.sightings = append(self.sightings, request)
+				w.Write([]byte(`{}`))
+
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Misp = &config_proto.MISPConfig{
+		Url:    self.server.URL + "/",
+		ApiKey: "secret",
+		Feeds: []*config_proto.MISPFeedConfig{{
+			Name:  "MISPHashes",
+			Types: []string{"sha256", "filename|md5"},
+			Last:  "30d",
+		}},
+		SightingArtifacts: []string{"Custom.Hashes"},
+		SightingColumns:   []string{"Hash"},
+	}
+	self.LoadArtifacts([]string{`
+name: Custom.Hashes
+type: CLIENT
+`})
+
+	self.TestSuite.SetupTest()
+}
+
+func (self *MISPTestSuite) TearDownTest() {
+	self.TestSuite.TearDownTest()
+	self.server.Close()
+}
+
+func (self *MISPTestSuite) TestSync() {
+	tables, err := misp.Sync(self.Ctx, self.ConfigObj, "")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(tables))
+
+	self.mu.Lock()
+	search := self.searches[len(self.searches)-1]
+	self.mu.Unlock()
+
+	assert.Equal(self.T(), `{"returnFormat":"json","limit":5000,"type":["sha256","filename|md5"],"last":"30d","to_ids":1,"page":1}`,
+		json.MustMarshalString(search))
+
+	table, err := iocs.GetTable(self.ConfigObj, "MISPHashes")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), misp.SOURCE, table.Source)
+
+	// The composite attribute is split and the duplicate hash is
+	// removed.
+	assert.Equal(self.T(), uint64(3), table.TotalIocs)
+
+	rows, err := iocs.ReadTable(self.Ctx, self.ConfigObj, "MISPHashes")
+	assert.NoError(self.T(), err)
+
+	summary := []string{}
+	for row := range rows {
+		ioc_type, _ := row.GetString("Type")
+		value, _ := row.GetString("Value")
+		id, _ := row.GetString("AttributeId")
+		summary = append(summary, ioc_type+" "+value+" "+id)
+	}
+	sort.Strings(summary)
+
+	assert.Equal(self.T(), []string{
+		"filename evil.exe 2",
+		"md5 d41d8cd98f00b204e9800998ecf8427e 2",
+		"sha256 e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855 3",
+	}, summary)
+
+	// Unknown feeds are an error.
+	_, err = misp.Sync(self.Ctx, self.ConfigObj, "Unknown")
+	assert.Error(self.T(), err)
+}
+
+func (self *MISPTestSuite) TestSightings() {
+	_, err := misp.Sync(self.Ctx, self.ConfigObj, "")
+	assert.NoError(self.T(), err)
+
+	service, err := misp.NewMISPService(self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	for _, flow_id := range []string{"F.1", "F.2"} {
+		path_manager, err := artifacts.NewArtifactPathManager(
+			self.ConfigObj, "C.123", flow_id, "Custom.Hashes")
+		assert.NoError(self.T(), err)
+
+		writer, err := result_sets.NewResultSetWriter(
+			file_store.GetFileStore(self.ConfigObj), path_manager.Path(),
+			nil, utils.SyncCompleter, result_sets.TruncateMode)
+		assert.NoError(self.T(), err)
+
+		writer.Write(ordereddict.NewDict().
+			Set("Path", "C:/Windows/evil.exe").
+			Set("Hash", "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855"))
+		writer.Write(ordereddict.NewDict().
+			Set("Path", "C:/Windows/notepad.exe").
+			Set("Hash", "0000"))
+		writer.Close()
+	}
+
+	process := func(flow_id, creator string) {
+		err := service.ProcessFlowCompletion(self.Ctx, self.ConfigObj,
+			ordereddict.NewDict().
+				Set("FlowId", flow_id).
+				Set("ClientId", "C.123").
+				Set("Flow", ordereddict.NewDict().
+					Set("session_id", flow_id).
+					Set("client_id", "C.123").
+					Set("request", ordereddict.NewDict().
+						Set("creator", creator)).
+					Set("artifacts_with_results", []string{"Custom.Hashes"})))
+		assert.NoError(self.T(), err)
+	}
+
+	// Flows which are not part of a hunt are not reported.
+	process("F.1", "admin")
+	assert.Equal(self.T(), 0, len(self.sightings))
+
+	process("F.1", "H.1234")
+	assert.Equal(self.T(), 1, len(self.sightings))
+
+	id, _ := self.sightings[0].GetString("id")
+	assert.Equal(self.T(), "3", id)
+
+	source, _ := self.sightings[0].GetString("source")
+	assert.Equal(self.T(), "Velociraptor", source)
+
+	// The same sighting on the same client is only reported once.
+	process("F.2", "H.1234")
+	assert.Equal(self.T(), 1, len(self.sightings))
+}
+
+func TestMISP(t *testing.T) {
+	suite.Run(t, &MISPTestSuite{})
+}
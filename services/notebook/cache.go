@@ -0,0 +1,116 @@
+package notebook
+
+import (
+	"fmt"
+
+	"github.com/Velocidex/ordereddict"
+	"google.golang.org/protobuf/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+)
+
+// A cell does not need to be calculated again if its input and
+// environment are the same, and the results it reads did not change
+// since it was last calculated. We only know which results a cell
+// reads for notebooks attached to a flow or hunt, and only cache
+// those once the collection is no longer running.
+func (self *NotebookManager) cellCacheKey(
+	notebook_metadata *api_proto.NotebookMetadata,
+	in *api_proto.NotebookCellRequest) string {
+
+	version := self.notebookSourceVersion(notebook_metadata)
+	params := ordereddict.NewDict().
+		Set("OrgId", self.config_obj.OrgId).
+		Set("NotebookId", in.NotebookId).
+		Set("CellId", in.CellId).
+		Set("Type", in.Type).
+		Set("NotebookEnv", notebook_metadata.Env).
+		Set("CellEnv", in.Env)
+
+	return result_sets.MakeCacheKey(in.Input, params, version)
+}
+
+// Returns a string that changes whenever the results of the
+// notebook's flow or hunt change, or an empty string if the
+// results may change at any time.
+func (self *NotebookManager) notebookSourceVersion(
+	notebook_metadata *api_proto.NotebookMetadata) string {
+	notebook_context := notebook_metadata.Context
+	if notebook_context == nil {
+		return ""
+	}
+
+	if notebook_context.HuntId != "" {
+		dispatcher, err := services.GetHuntDispatcher(self.config_obj)
+		if err != nil {
+			return ""
+		}
+
+		hunt_obj, pres := dispatcher.GetHunt(notebook_context.HuntId)
+		if !pres || hunt_obj.State == api_proto.Hunt_RUNNING ||
+			hunt_obj.Stats == nil {
+			return ""
+		}
+
+		return fmt.Sprintf("hunt:%v:%v:%v:%v:%v", hunt_obj.Version,
+			hunt_obj.State, hunt_obj.Stats.TotalClientsScheduled,
+			hunt_obj.Stats.TotalClientsWithResults,
+			hunt_obj.Stats.TotalClientsWithErrors)
+	}
+
+	if notebook_context.FlowId != "" && notebook_context.ClientId != "" {
+		launcher, err := services.GetLauncher(self.config_obj)
+		if err != nil {
+			return ""
+		}
+
+		details, err := launcher.GetFlowDetails(self.config_obj,
+			notebook_context.ClientId, notebook_context.FlowId)
+		if err != nil || details.Context == nil ||
+			details.Context.State == flows_proto.ArtifactCollectorContext_RUNNING {
+			return ""
+		}
+
+		return fmt.Sprintf("flow:%v:%v:%v:%v", details.Context.State,
+			details.Context.ActiveTime, details.Context.TotalCollectedRows,
+			details.Context.TotalUploadedBytes)
+	}
+
+	return ""
+}
+
+// Serve the cell from the cache. The cell's tables are stored with
+// the cell, so this is only valid if the cell was not calculated
+// again (e.g. with a different input) since we cached it.
+func (self *NotebookManager) getCachedCell(cache_key string,
+	in *api_proto.NotebookCellRequest) (*api_proto.NotebookCell, bool) {
+
+	cached_any, pres := self.cell_cache.Get(cache_key)
+	if !pres {
+		return nil, false
+	}
+
+	cached, ok := cached_any.(*api_proto.NotebookCell)
+	if !ok {
+		return nil, false
+	}
+
+	stored, err := self.Store.GetNotebookCell(in.NotebookId, in.CellId)
+	if err != nil || !proto.Equal(stored, cached) {
+		self.cell_cache.Remove(cache_key)
+		return nil, false
+	}
+
+	result := proto.Clone(cached).(*api_proto.NotebookCell)
+	result.CurrentlyEditing = in.CurrentlyEditing
+
+	err = self.Store.SetNotebookCell(in.NotebookId, result)
+	if err != nil {
+		return nil, false
+	}
+
+	self.cell_cache.Set(cache_key, proto.Clone(result))
+	return result, true
+}
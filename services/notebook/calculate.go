@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"google.golang.org/protobuf/proto"
 	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
 	"www.velocidex.com/golang/velociraptor/json"
@@ -15,6 +16,8 @@ import (
 	"www.velocidex.com/golang/velociraptor/paths"
 	"www.velocidex.com/golang/velociraptor/reporting"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/governor"
+	"www.velocidex.com/golang/velociraptor/services/quotas"
 	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
 	"www.velocidex.com/golang/vfilter"
 )
@@ -45,6 +48,18 @@ func (self *NotebookManager) UpdateNotebookCell(
 		return nil, err
 	}
 
+	// Nothing changed since the cell was last calculated.
+	cache_key := self.cellCacheKey(notebook_metadata, in)
+	cached_cell, pres := self.getCachedCell(cache_key, in)
+	if pres {
+		return cached_cell, nil
+	}
+
+	err = quotas.CheckNotebookQuota(self.config_obj)
+	if err != nil {
+		return nil, err
+	}
+
 	// Run the actual query independently.
 	query_ctx, query_cancel := context.WithCancel(context.Background())
 
@@ -52,10 +67,12 @@ func (self *NotebookManager) UpdateNotebookCell(
 
 	manager, err := services.GetRepositoryManager(self.config_obj)
 	if err != nil {
+		query_cancel()
 		return nil, err
 	}
 	global_repo, err := manager.GetGlobalRepository(self.config_obj)
 	if err != nil {
+		query_cancel()
 		return nil, err
 	}
 
@@ -64,6 +81,7 @@ func (self *NotebookManager) UpdateNotebookCell(
 		notebook_path_manager.Cell(in.CellId),
 		"Server.Internal.ArtifactDescription")
 	if err != nil {
+		query_cancel()
 		return nil, err
 	}
 
@@ -149,9 +167,31 @@ func (self *NotebookManager) UpdateNotebookCell(
 		// Close the template when we are done with it.
 		defer tmpl.Close()
 
+		// Wait until the server has capacity to calculate the cell.
+		task, err := governor.Acquire(query_ctx, self.config_obj,
+			governor.PRIORITY_NOTEBOOK, "notebook cell "+in.CellId,
+			func() {
+				tmpl.Error("ERROR:Cell preempted by the resource governor to free memory")
+				query_cancel()
+			})
+		if err != nil {
+			main_err = err
+			error_cell := proto.Clone(notebook_cell).(*api_proto.NotebookCell)
+			error_cell.Output = ""
+			error_cell.Calculating = false
+			error_cell.Messages = []string{"ERROR:" + err.Error()}
+			_ = self.Store.SetNotebookCell(in.NotebookId, error_cell)
+			notebook_cell = error_cell
+			return
+		}
+		defer task.Close()
+
+		governor.Throttle(query_ctx, self.config_obj, tmpl.Scope)
+
 		resp, err := self.updateCellContents(query_ctx, tmpl,
 			in.CurrentlyEditing, in.NotebookId,
 			in.CellId, cell_type, in.Env, input, in.Input)
+		quotas.AddNotebookTime(self.config_obj, time.Since(start_time))
 		if err != nil {
 			main_err = err
 			logger := logging.GetLogger(self.config_obj, &logging.GUIComponent)
@@ -161,6 +201,11 @@ func (self *NotebookManager) UpdateNotebookCell(
 		// Update the response if we can.
 		if resp != nil {
 			notebook_cell = resp
+
+			// Do not cache cancelled or timed out cells.
+			if err == nil && query_ctx.Err() == nil {
+				self.cell_cache.Set(cache_key, proto.Clone(resp))
+			}
 		}
 	}()
 
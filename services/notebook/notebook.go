@@ -12,6 +12,7 @@ import (
 
 	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/result_sets"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/utils"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
@@ -22,6 +23,12 @@ import (
 type NotebookManager struct {
 	config_obj *config_proto.Config
 	Store      NotebookStore
+
+	// Cells calculated from unchanged results.
+	cell_cache *result_sets.ResultCache
+
+	// Only set on the master.
+	scheduler *NotebookScheduler
 }
 
 func (self *NotebookManager) GetNotebook(
@@ -79,11 +86,20 @@ func (self *NotebookManager) NewNotebook(
 func (self *NotebookManager) UpdateNotebook(
 	ctx context.Context, in *api_proto.NotebookMetadata) error {
 
-	err := self.Store.SetNotebook(in)
+	err := ValidateSchedule(in)
+	if err != nil {
+		return err
+	}
+
+	err = self.Store.SetNotebook(in)
 	if err != nil {
 		return err
 	}
 
+	if self.scheduler != nil {
+		self.scheduler.Update(in)
+	}
+
 	return self.Store.UpdateShareIndex(in)
 }
 
@@ -166,6 +182,7 @@ func NewNotebookManager(
 	result := &NotebookManager{
 		config_obj: config_obj,
 		Store:      storage,
+		cell_cache: result_sets.NewResultCache(config_obj, "NotebookCell"),
 	}
 	return result
 }
@@ -175,10 +192,22 @@ func NewNotebookManagerService(
 	wg *sync.WaitGroup,
 	config_obj *config_proto.Config) (services.NotebookManager, error) {
 
-	return NewNotebookManager(config_obj,
+	result := NewNotebookManager(config_obj,
 		&NotebookStoreImpl{
 			config_obj: config_obj,
-		}), nil
+		})
+
+	// Scheduled notebooks are only run on the master.
+	if services.IsMaster(config_obj) {
+		scheduler, err := NewNotebookScheduler(config_obj, result)
+		if err != nil {
+			return nil, err
+		}
+		scheduler.Start(ctx, wg)
+		result.scheduler = scheduler
+	}
+
+	return result, nil
 }
 
 func (self *NotebookManager) ReformatVQL(
@@ -0,0 +1,400 @@
+package notebook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gomail "gopkg.in/gomail.v2"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/reporting"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql/networking"
+)
+
+const (
+	// Do not run scheduled notebooks more often than this.
+	MIN_SCHEDULE_PERIOD = 300
+
+	scheduleCheckInterval = time.Minute
+)
+
+// Checks the schedule of a notebook is valid before it is saved.
+func ValidateSchedule(notebook *api_proto.NotebookMetadata) error {
+	schedule := notebook.Schedule
+	if schedule == nil || schedule.PeriodSeconds == 0 {
+		return nil
+	}
+
+	if strings.HasPrefix(notebook.NotebookId, "N.H.") ||
+		strings.HasPrefix(notebook.NotebookId, "N.F.") ||
+		strings.HasPrefix(notebook.NotebookId, "N.E.") ||
+		strings.HasPrefix(notebook.NotebookId, "Dashboard") {
+		return errors.New("Only global notebooks may be scheduled")
+	}
+
+	if schedule.PeriodSeconds < MIN_SCHEDULE_PERIOD {
+		return fmt.Errorf("Schedule period must be at least %v seconds",
+			MIN_SCHEDULE_PERIOD)
+	}
+
+	switch schedule.ExportType {
+	case "", "html", "pdf", "zip":
+	default:
+		return fmt.Errorf("Unsupported export type %v", schedule.ExportType)
+	}
+
+	return nil
+}
+
+// The NotebookScheduler periodically recalculates scheduled notebooks
+// and delivers their exports.
+type NotebookScheduler struct {
+	mu sync.Mutex
+
+	config_obj *config_proto.Config
+	manager    *NotebookManager
+
+	// Notebook id -> schedule
+	schedules map[string]*api_proto.NotebookSchedule
+}
+
+// Called when a notebook is updated to track its schedule.
+func (self *NotebookScheduler) Update(notebook *api_proto.NotebookMetadata) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	schedule := notebook.Schedule
+	if schedule == nil || schedule.PeriodSeconds == 0 {
+		delete(self.schedules, notebook.NotebookId)
+		return
+	}
+
+	self.schedules[notebook.NotebookId] = schedule
+}
+
+// Returns the ids of notebooks that are due to run.
+func (self *NotebookScheduler) getDue(now time.Time) []string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := []string{}
+	for notebook_id, schedule := range self.schedules {
+		if schedule.NextRun <= now.Unix() {
+			result = append(result, notebook_id)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (self *NotebookScheduler) runDue(ctx context.Context) {
+	logger := logging.GetLogger(self.config_obj, &logging.GUIComponent)
+
+	for _, notebook_id := range self.getDue(utils.GetTime().Now()) {
+		notebook, err := self.manager.Store.GetNotebook(notebook_id)
+		if err != nil {
+			logger.Error("NotebookScheduler: %v: %v", notebook_id, err)
+			continue
+		}
+
+		// The schedule was removed since we last looked.
+		if notebook.Schedule == nil || notebook.Schedule.PeriodSeconds == 0 {
+			self.Update(notebook)
+			continue
+		}
+
+		logger.Info("NotebookScheduler: Running notebook %v as %v",
+			notebook_id, notebook.Schedule.Principal)
+
+		err = self.runNotebook(ctx, notebook)
+		if err != nil {
+			logger.Error("NotebookScheduler: %v: %v", notebook_id, err)
+			notebook.Schedule.LastError = err.Error()
+		} else {
+			notebook.Schedule.LastError = ""
+		}
+
+		now := utils.GetTime().Now().Unix()
+		notebook.Schedule.LastRun = now
+		notebook.Schedule.NextRun = now + int64(notebook.Schedule.PeriodSeconds)
+
+		err = self.manager.Store.SetNotebook(notebook)
+		if err != nil {
+			logger.Error("NotebookScheduler: %v: %v", notebook_id, err)
+		}
+		self.Update(notebook)
+	}
+}
+
+// Recalculate all the cells and deliver the export.
+func (self *NotebookScheduler) runNotebook(
+	ctx context.Context, notebook *api_proto.NotebookMetadata) error {
+	schedule := notebook.Schedule
+
+	for _, cell_md := range notebook.CellMetadata {
+		err := self.recalculateCell(ctx, notebook, cell_md.CellId)
+		if err != nil {
+			return fmt.Errorf("Cell %v: %w", cell_md.CellId, err)
+		}
+	}
+
+	// The export is always created so it is available in the GUI.
+	data, filename, err := self.export(ctx, notebook)
+	if err != nil {
+		return err
+	}
+
+	if len(schedule.EmailTo) > 0 {
+		err = self.sendEmail(notebook, data, filename)
+		if err != nil {
+			return fmt.Errorf("Sending email: %w", err)
+		}
+	}
+
+	if schedule.WebhookUrl != "" {
+		err = self.postWebhook(ctx, notebook, data, filename)
+		if err != nil {
+			return fmt.Errorf("Posting to webhook: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Update the cell and wait for the calculation to complete.
+func (self *NotebookScheduler) recalculateCell(ctx context.Context,
+	notebook *api_proto.NotebookMetadata, cell_id string) error {
+	cell, err := self.manager.Store.GetNotebookCell(notebook.NotebookId, cell_id)
+	if err != nil {
+		return err
+	}
+
+	_, err = self.manager.UpdateNotebookCell(ctx, notebook,
+		notebook.Schedule.Principal, &api_proto.NotebookCellRequest{
+			NotebookId: notebook.NotebookId,
+			CellId:     cell_id,
+			Input:      cell.Input,
+			Type:       cell.Type,
+			Env:        cell.Env,
+		})
+	if err != nil {
+		return err
+	}
+
+	// Cells are calculated in the background - wait for them to
+	// finish.
+	for {
+		cell, err := self.manager.Store.GetNotebookCell(
+			notebook.NotebookId, cell_id)
+		if err != nil {
+			return err
+		}
+
+		// Errors in the cell are reported in the cell's messages
+		// and so end up in the export.
+		if !cell.Calculating {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// Export the notebook into the file store and return its content.
+func (self *NotebookScheduler) export(ctx context.Context,
+	notebook *api_proto.NotebookMetadata) ([]byte, string, error) {
+
+	// Freeze the clock so we know what the export file is called.
+	path_manager := paths.NewNotebookPathManager(notebook.NotebookId)
+	path_manager.Clock = &utils.MockClock{MockNow: utils.GetTime().Now()}
+
+	file_store_factory := file_store.GetFileStore(self.config_obj)
+
+	var filename api.FSPathSpec
+	switch notebook.Schedule.ExportType {
+	case "zip":
+		filename = path_manager.ZipExport()
+
+		wg := &sync.WaitGroup{}
+		err := reporting.ExportNotebookToZip(
+			ctx, self.config_obj, wg, path_manager)
+		if err != nil {
+			return nil, "", err
+		}
+		wg.Wait()
+
+	case "pdf":
+		filename = path_manager.PdfExport()
+
+		writer, err := file_store_factory.WriteFile(filename)
+		if err != nil {
+			return nil, "", err
+		}
+
+		err = reporting.ExportNotebookToPDF(
+			ctx, self.config_obj, notebook.NotebookId, writer)
+		writer.Close()
+		if err != nil {
+			return nil, "", err
+		}
+
+	default:
+		filename = path_manager.HtmlExport()
+
+		writer, err := file_store_factory.WriteFile(filename)
+		if err != nil {
+			return nil, "", err
+		}
+
+		err = reporting.ExportNotebookToHTML(
+			ctx, self.config_obj, notebook.NotebookId, writer)
+		writer.Close()
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	reader, err := file_store_factory.ReadFile(filename)
+	if err != nil {
+		return nil, "", err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, filename.Base() + api.GetExtensionForFilestore(filename), nil
+}
+
+func (self *NotebookScheduler) sendEmail(
+	notebook *api_proto.NotebookMetadata, data []byte, filename string) error {
+	mail_config := self.config_obj.Mail
+	if mail_config == nil || mail_config.Server == "" {
+		return errors.New("Mail server not configured")
+	}
+
+	from := mail_config.From
+	if from == "" {
+		from = mail_config.AuthUsername
+	}
+	if from == "" {
+		from = "Velociraptor"
+	}
+
+	m := gomail.NewMessage()
+	m.SetHeader("From", from)
+	m.SetHeader("To", notebook.Schedule.EmailTo...)
+	m.SetHeader("Subject", "Velociraptor notebook: "+notebook.Name)
+	m.SetBody("text/plain", fmt.Sprintf(
+		"The scheduled notebook %v (%v) was run at %v.\n\n%v\n",
+		notebook.Name, notebook.NotebookId,
+		utils.GetTime().Now().UTC().Format(time.RFC3339),
+		notebook.Description))
+	m.Attach(filename, gomail.SetCopyFunc(func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	}))
+
+	port := mail_config.ServerPort
+	if port == 0 {
+		port = 587
+	}
+
+	d := gomail.NewDialer(mail_config.Server, int(port),
+		mail_config.AuthUsername, mail_config.AuthPassword)
+	return d.DialAndSend(m)
+}
+
+func (self *NotebookScheduler) postWebhook(ctx context.Context,
+	notebook *api_proto.NotebookMetadata, data []byte, filename string) error {
+	client, err := networking.GetDefaultHTTPClient(self.config_obj.Client, "")
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		notebook.Schedule.WebhookUrl, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	content_type := "text/html"
+	switch notebook.Schedule.ExportType {
+	case "zip":
+		content_type = "application/zip"
+	case "pdf":
+		content_type = "application/pdf"
+	}
+	req.Header.Set("Content-Type", content_type)
+	req.Header.Set("X-Velociraptor-Notebook", notebook.NotebookId)
+	req.Header.Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=%q", filename))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Webhook returned %v", resp.Status)
+	}
+	return nil
+}
+
+func (self *NotebookScheduler) Start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			self.runDue(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-utils.GetTime().After(scheduleCheckInterval):
+			}
+		}
+	}()
+}
+
+func NewNotebookScheduler(
+	config_obj *config_proto.Config,
+	manager *NotebookManager) (*NotebookScheduler, error) {
+	result := &NotebookScheduler{
+		config_obj: config_obj,
+		manager:    manager,
+		schedules:  make(map[string]*api_proto.NotebookSchedule),
+	}
+
+	notebooks, err := GetAllNotebooks(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, notebook := range notebooks {
+		result.Update(notebook)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package notebook
+
+import (
+	"testing"
+	"time"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+func TestNotebookSchedule(t *testing.T) {
+	schedule := func(notebook_id string, period uint64,
+		export_type string) *api_proto.NotebookMetadata {
+		return &api_proto.NotebookMetadata{
+			NotebookId: notebook_id,
+			Schedule: &api_proto.NotebookSchedule{
+				PeriodSeconds: period,
+				ExportType:    export_type,
+			},
+		}
+	}
+
+	assert.NoError(t, ValidateSchedule(schedule("N.1", 3600, "html")))
+	assert.NoError(t, ValidateSchedule(schedule("N.1", 0, "")))
+	assert.Error(t, ValidateSchedule(schedule("N.1", 60, "html")))
+	assert.NoError(t, ValidateSchedule(schedule("N.1", 3600, "pdf")))
+	assert.Error(t, ValidateSchedule(schedule("N.1", 3600, "docx")))
+	assert.Error(t, ValidateSchedule(schedule("N.H.1234", 3600, "")))
+	assert.Error(t, ValidateSchedule(schedule("N.F.1234-C.123", 3600, "")))
+
+	scheduler := &NotebookScheduler{
+		schedules: make(map[string]*api_proto.NotebookSchedule),
+	}
+
+	now := time.Unix(1600000000, 0)
+
+	// Never run before so it is due immediately.
+	scheduler.Update(schedule("N.1", 3600, ""))
+
+	later := schedule("N.2", 3600, "")
+	later.Schedule.NextRun = now.Unix() + 100
+	scheduler.Update(later)
+
+	assert.Equal(t, []string{"N.1"}, scheduler.getDue(now))
+	assert.Equal(t, []string{"N.1", "N.2"},
+		scheduler.getDue(now.Add(200*time.Second)))
+
+	// Removing the schedule stops the notebook from running.
+	scheduler.Update(schedule("N.1", 0, ""))
+	assert.Equal(t, []string{}, scheduler.getDue(now))
+}
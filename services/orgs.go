@@ -55,6 +55,8 @@ type ServiceContainer interface {
 	HuntDispatcher() (IHuntDispatcher, error)
 	Launcher() (Launcher, error)
 	NotebookManager() (NotebookManager, error)
+	TimelineBuilder() (TimelineBuilder, error)
+	ClientHealthManager() (ClientHealthManager, error)
 	ClientEventManager() (ClientEventTable, error)
 	ServerEventManager() (ServerEventManager, error)
 	Notifier() (Notifier, error)
@@ -70,6 +72,15 @@ type OrgManager interface {
 	GetOrg(org_id string) (*api_proto.OrgRecord, error)
 	DeleteOrg(ctx context.Context, org_id string) error
 
+	// Set the resource quota of the org. Quotas can not be set on
+	// the root org.
+	SetOrgQuota(org_id string, quota *api_proto.OrgQuota) error
+
+	// Set which built in and root org artifacts the org sees. The
+	// root org has no parent repository so this can not be set on
+	// it.
+	SetOrgArtifactPolicy(org_id string, policy *api_proto.OrgArtifactPolicy) error
+
 	// The manager is responsible for running multiple services - one
 	// for each org. This ensures org services are separated out and
 	// one org can not access data from another org.
@@ -107,6 +107,75 @@ func (self *OrgManager) GetOrg(org_id string) (*api_proto.OrgRecord, error) {
 	return result.record, nil
 }
 
+func (self *OrgManager) SetOrgQuota(
+	org_id string, quota *api_proto.OrgQuota) error {
+	if utils.IsRootOrg(org_id) {
+		return errors.New("SetOrgQuota: Quotas can not be set on the root org")
+	}
+
+	self.mu.Lock()
+	org_context, pres := self.orgs[org_id]
+	if !pres {
+		self.mu.Unlock()
+		return services.NotFoundError
+	}
+
+	// Replace the record rather than modifying it because callers
+	// of GetOrg() may hold the old one.
+	record := proto.Clone(org_context.record).(*api_proto.OrgRecord)
+	record.Quota = quota
+	org_context.record = record
+	self.mu.Unlock()
+
+	db, err := datastore.GetDB(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	org_path_manager := paths.NewOrgPathManager(org_id)
+	return db.SetSubject(self.config_obj, org_path_manager.Path(), record)
+}
+
+func (self *OrgManager) SetOrgArtifactPolicy(
+	org_id string, policy *api_proto.OrgArtifactPolicy) error {
+	if utils.IsRootOrg(org_id) {
+		return errors.New("SetOrgArtifactPolicy: The root org has no parent repository")
+	}
+
+	self.mu.Lock()
+	org_context, pres := self.orgs[org_id]
+	if !pres {
+		self.mu.Unlock()
+		return services.NotFoundError
+	}
+
+	// The policy is checked when the org repository applies it.
+	repository_manager, err := org_context.service.RepositoryManager()
+	if err != nil {
+		self.mu.Unlock()
+		return err
+	}
+
+	err = repository_manager.SetArtifactPolicy(org_context.config_obj, policy)
+	if err != nil {
+		self.mu.Unlock()
+		return err
+	}
+
+	record := proto.Clone(org_context.record).(*api_proto.OrgRecord)
+	record.Artifacts = policy
+	org_context.record = record
+	self.mu.Unlock()
+
+	db, err := datastore.GetDB(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	org_path_manager := paths.NewOrgPathManager(org_id)
+	return db.SetSubject(self.config_obj, org_path_manager.Path(), record)
+}
+
 func (self *OrgManager) OrgIdByNonce(nonce string) (string, error) {
 	self.mu.Lock()
 	defer self.mu.Unlock()
@@ -233,7 +302,17 @@ func (self *OrgManager) Scan() error {
 			if err != nil {
 				return err
 			}
+			continue
+		}
+
+		// Pick up changes to the record made on other frontends
+		// (e.g. quotas).
+		self.mu.Lock()
+		org_context, pres := self.orgs[org_id]
+		if pres && !proto.Equal(org_context.record, org_record) {
+			org_context.record = org_record
 		}
+		self.mu.Unlock()
 	}
 
 	// Now shut down the orgs that were removed
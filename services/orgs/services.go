@@ -9,28 +9,56 @@ import (
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/datastore"
 	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/transaction"
 	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/services/acl_manager"
+	"www.velocidex.com/golang/velociraptor/services/artifact_sync"
+	"www.velocidex.com/golang/velociraptor/services/audit"
+	"www.velocidex.com/golang/velociraptor/services/backup"
 	"www.velocidex.com/golang/velociraptor/services/broadcast"
+	"www.velocidex.com/golang/velociraptor/services/client_health"
 	"www.velocidex.com/golang/velociraptor/services/client_info"
 	"www.velocidex.com/golang/velociraptor/services/client_monitoring"
+	"www.velocidex.com/golang/velociraptor/services/coalescing"
+	"www.velocidex.com/golang/velociraptor/services/compaction"
 	"www.velocidex.com/golang/velociraptor/services/ddclient"
+	"www.velocidex.com/golang/velociraptor/services/deception"
+	"www.velocidex.com/golang/velociraptor/services/email_notifier"
+	"www.velocidex.com/golang/velociraptor/services/federation"
+	"www.velocidex.com/golang/velociraptor/services/flight_endpoint"
 	"www.velocidex.com/golang/velociraptor/services/frontend"
+	"www.velocidex.com/golang/velociraptor/services/hunt_archive"
+	"www.velocidex.com/golang/velociraptor/services/governor"
 	"www.velocidex.com/golang/velociraptor/services/hunt_dispatcher"
 	"www.velocidex.com/golang/velociraptor/services/hunt_manager"
+	"www.velocidex.com/golang/velociraptor/services/hunt_scheduler"
+	"www.velocidex.com/golang/velociraptor/services/hunt_stacking"
 	"www.velocidex.com/golang/velociraptor/services/indexing"
 	"www.velocidex.com/golang/velociraptor/services/interrogation"
 	"www.velocidex.com/golang/velociraptor/services/inventory"
 	"www.velocidex.com/golang/velociraptor/services/journal"
+	"www.velocidex.com/golang/velociraptor/services/label_rules"
 	"www.velocidex.com/golang/velociraptor/services/labels"
 	"www.velocidex.com/golang/velociraptor/services/launcher"
+	"www.velocidex.com/golang/velociraptor/services/materialized_views"
+	"www.velocidex.com/golang/velociraptor/services/misp"
 	"www.velocidex.com/golang/velociraptor/services/notebook"
 	"www.velocidex.com/golang/velociraptor/services/notifications"
+	"www.velocidex.com/golang/velociraptor/services/quotas"
 	"www.velocidex.com/golang/velociraptor/services/repository"
+	"www.velocidex.com/golang/velociraptor/services/result_index"
 	"www.velocidex.com/golang/velociraptor/services/sanity"
 	"www.velocidex.com/golang/velociraptor/services/server_artifacts"
 	"www.velocidex.com/golang/velociraptor/services/server_monitoring"
+	"www.velocidex.com/golang/velociraptor/services/siem"
+	"www.velocidex.com/golang/velociraptor/services/splunk"
+	"www.velocidex.com/golang/velociraptor/services/sql_endpoint"
+	"www.velocidex.com/golang/velociraptor/services/standby"
+	"www.velocidex.com/golang/velociraptor/services/stix"
+	"www.velocidex.com/golang/velociraptor/services/thehive"
+	"www.velocidex.com/golang/velociraptor/services/timeline_builder"
+	"www.velocidex.com/golang/velociraptor/services/upload_index"
 	"www.velocidex.com/golang/velociraptor/services/users"
 	"www.velocidex.com/golang/velociraptor/services/vfs_service"
 	"www.velocidex.com/golang/velociraptor/utils"
@@ -51,10 +79,12 @@ type ServiceContainer struct {
 	hunt_dispatcher      services.IHuntDispatcher
 	launcher             services.Launcher
 	notebook_manager     services.NotebookManager
+	timeline_builder     services.TimelineBuilder
 	client_event_manager services.ClientEventTable
 	server_event_manager services.ServerEventManager
 	notifier             services.Notifier
 	acl_manager          services.ACLManager
+	client_health        services.ClientHealthManager
 }
 
 func (self *ServiceContainer) MockFrontendManager(svc services.FrontendManager) {
@@ -118,6 +148,17 @@ func (self *ServiceContainer) NotebookManager() (services.NotebookManager, error
 	return self.notebook_manager, nil
 }
 
+func (self *ServiceContainer) TimelineBuilder() (services.TimelineBuilder, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.timeline_builder == nil {
+		return nil, errors.New("Timeline Builder service not initialized")
+	}
+
+	return self.timeline_builder, nil
+}
+
 func (self *ServiceContainer) Launcher() (services.Launcher, error) {
 	self.mu.Lock()
 	defer self.mu.Unlock()
@@ -234,6 +275,16 @@ func (self *ServiceContainer) ACLManager() (services.ACLManager, error) {
 	return self.acl_manager, nil
 }
 
+func (self *ServiceContainer) ClientHealthManager() (services.ClientHealthManager, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.client_health == nil {
+		return nil, errors.New("Client Health service not initialized")
+	}
+	return self.client_health, nil
+}
+
 // Start all the services for the org and install it in the
 // manager. This function is used both in the client and the server to
 // start all the needed services.
@@ -280,7 +331,8 @@ func (self *OrgManager) startRootOrgServices(
 		return err
 	}
 
-	if spec.ReplicationService {
+	// With a message bus every frontend runs a full journal service.
+	if spec.ReplicationService && !journal.UseMessageBus(org_config) {
 		j, err := journal.NewReplicationService(ctx, wg, org_config)
 		if err != nil {
 			return err
@@ -345,6 +397,29 @@ func (self *OrgManager) startOrgFromContext(org_ctx *OrgContext) (err error) {
 		}
 	}
 
+	// Ship datastore writes to the warm standby before any services
+	// start writing.
+	if spec.FrontendServer {
+		err := standby.StartStandbyReplication(ctx, wg, org_config)
+		if err != nil {
+			return err
+		}
+
+		// Backups need to hold back all writes - including those
+		// shipped to the standby.
+		err = backup.InstallWriteBarrier(org_config)
+		if err != nil {
+			return err
+		}
+
+		// Complete any file store transactions interrupted by a
+		// crash before clients start sending results.
+		err = transaction.Recover(org_config)
+		if err != nil {
+			return err
+		}
+	}
+
 	if spec.UserManager {
 		m, err := acl_manager.NewACLManager(ctx, wg, org_config)
 		if err != nil {
@@ -443,6 +518,14 @@ func (self *OrgManager) startOrgFromContext(org_ctx *OrgContext) (err error) {
 			root_repo, _ := root_repo_manager.GetGlobalRepository(root_org_config)
 			repo_manager.SetParent(root_org_config, root_repo)
 
+			// Apply the policy before loading the org's artifacts
+			// so they can not replace protected ones.
+			err = repo_manager.SetArtifactPolicy(
+				org_config, org_ctx.record.Artifacts)
+			if err != nil {
+				return err
+			}
+
 			global_repository, err := repo_manager.GetGlobalRepository(org_config)
 			if err != nil {
 				return err
@@ -459,6 +542,16 @@ func (self *OrgManager) startOrgFromContext(org_ctx *OrgContext) (err error) {
 		service_container.mu.Unlock()
 	}
 
+	// The audit log is kept in the root org and written by the
+	// master frontend only so there is a single chain.
+	if utils.IsRootOrg(org_id) && spec.FrontendServer &&
+		services.IsMaster(org_config) {
+		err = audit.NewAuditService(ctx, wg, org_config)
+		if err != nil {
+			return err
+		}
+	}
+
 	if spec.InventoryService {
 		i, err := inventory.NewInventoryService(
 			ctx, wg, org_config)
@@ -489,6 +582,90 @@ func (self *OrgManager) startOrgFromContext(org_ctx *OrgContext) (err error) {
 		if err != nil {
 			return err
 		}
+
+		_, err = hunt_scheduler.NewHuntSchedulerService(
+			ctx, wg, org_config)
+		if err != nil {
+			return err
+		}
+
+		err = quotas.NewQuotaService(ctx, wg, org_config)
+		if err != nil {
+			return err
+		}
+
+		err = compaction.NewCompactionService(ctx, wg, org_config)
+		if err != nil {
+			return err
+		}
+
+		err = deception.NewDeceptionService(ctx, wg, org_config)
+		if err != nil {
+			return err
+		}
+
+		// The backup covers the datastore of all orgs.
+		if utils.IsRootOrg(org_config.OrgId) {
+			err = backup.NewBackupService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		ch, err := client_health.NewClientHealthService(ctx, wg, org_config)
+		if err != nil {
+			return err
+		}
+
+		service_container.mu.Lock()
+		service_container.client_health = ch
+		service_container.mu.Unlock()
+
+		if org_config.Misp != nil {
+			_, err = misp.NewMISPService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		if org_config.Stix != nil {
+			err = stix.NewSTIXService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		if org_config.Federation != nil {
+			err = federation.NewFederationService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Users select the org when they connect.
+		if org_config.SqlEndpoint != nil &&
+			utils.IsRootOrg(org_config.OrgId) {
+			err = sql_endpoint.NewSQLEndpointService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		if org_config.FlightEndpoint != nil &&
+			utils.IsRootOrg(org_config.OrgId) {
+			err = flight_endpoint.NewFlightEndpointService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		if org_config.ArtifactRepoSync != nil &&
+			utils.IsRootOrg(org_config.OrgId) {
+			err = artifact_sync.NewArtifactSyncService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	if spec.Interrogation {
@@ -498,6 +675,18 @@ func (self *OrgManager) startOrgFromContext(org_ctx *OrgContext) (err error) {
 		if err != nil {
 			return err
 		}
+
+		_, err = label_rules.NewLabelRulesService(
+			ctx, wg, org_config)
+		if err != nil {
+			return err
+		}
+
+		err = coalescing.NewClientCoalescingService(
+			ctx, wg, org_config)
+		if err != nil {
+			return err
+		}
 	}
 
 	if spec.ClientInfo {
@@ -556,6 +745,16 @@ func (self *OrgManager) startOrgFromContext(org_ctx *OrgContext) (err error) {
 		service_container.mu.Lock()
 		service_container.notebook_manager = nb
 		service_container.mu.Unlock()
+
+		tb, err := timeline_builder.NewTimelineBuilderService(
+			ctx, wg, org_config)
+		if err != nil {
+			return err
+		}
+
+		service_container.mu.Lock()
+		service_container.timeline_builder = tb
+		service_container.mu.Unlock()
 	}
 
 	if spec.ServerArtifacts {
@@ -563,6 +762,14 @@ func (self *OrgManager) startOrgFromContext(org_ctx *OrgContext) (err error) {
 		if err != nil {
 			return err
 		}
+
+		// The governor is shared by all orgs.
+		if utils.IsRootOrg(org_config.OrgId) {
+			err = governor.NewResourceGovernorService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	if spec.ClientMonitoring {
@@ -585,6 +792,71 @@ func (self *OrgManager) startOrgFromContext(org_ctx *OrgContext) (err error) {
 		service_container.mu.Lock()
 		service_container.server_event_manager = server_event_manager
 		service_container.mu.Unlock()
+
+		if org_config.Thehive != nil {
+			_, err = thehive.NewTheHiveService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		if org_config.Splunk != nil {
+			_, err = splunk.NewSplunkService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		if org_config.Siem != nil {
+			_, err = siem.NewSIEMService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		if org_config.HuntArchive != nil {
+			_, err = hunt_archive.NewHuntArchiveService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		if org_config.HuntStacking != nil {
+			_, err = hunt_stacking.NewHuntStackingService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		if org_config.MaterializedViews != nil {
+			_, err = materialized_views.NewMaterializedViewService(
+				ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		if org_config.UploadIndex != nil {
+			err = upload_index.NewUploadIndexService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		if org_config.ResultIndex != nil {
+			err = result_index.NewResultIndexService(ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
+
+		if org_config.EmailNotifications != nil {
+			_, err = email_notifier.NewEmailNotificationService(
+				ctx, wg, org_config)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	// Must be run after all the other services are up
@@ -41,6 +41,14 @@ func (self *TestOrgManager) Start(
 	self.orgs[""] = org_context
 	self.mu.Unlock()
 
+	// Let the caller wait for the org services to exit.
+	wg.Add(1)
+	go func() {
+		<-org_context.sm.Ctx.Done()
+		org_context.sm.Wg.Wait()
+		wg.Done()
+	}()
+
 	return self.startOrgFromContext(org_context)
 }
 
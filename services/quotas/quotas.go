@@ -0,0 +1,189 @@
+/*
+  Per org resource quotas.
+
+  In multi-org deployments all orgs share the same server, so a
+  single tenant could exhaust its resources. Each org record may
+  carry a quota limiting:
+
+  1. The number of hunts running at the same time.
+  2. The size of the org's file store.
+  3. The number of rows collected per day.
+  4. The time spent calculating notebook cells per day.
+
+  Usage is accounted in memory as it happens and periodically written
+  to the org's datastore so daily counters survive a restart. The
+  file store size is measured by periodically walking the org's file
+  store directory.
+
+  Quotas are enforced when new work is started: hunts can not be
+  started, new collections can not be scheduled and notebook cells
+  can not be calculated while the org is over its quota. Work already
+  in progress is not interrupted.
+*/
+
+package quotas
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	QuotaExceededError = errors.New("Quota exceeded")
+
+	mu sync.Mutex
+
+	// Usage records by org id.
+	usages = make(map[string]*api_proto.OrgUsage)
+)
+
+func today() string {
+	return utils.GetTime().Now().UTC().Format("2006-01-02")
+}
+
+// Get the usage record for the org, resetting the daily counters if
+// the day changed. Must be called with mu held.
+func getUsage(org_id string) *api_proto.OrgUsage {
+	usage, pres := usages[org_id]
+	if !pres {
+		usage = &api_proto.OrgUsage{OrgId: org_id}
+		usages[org_id] = usage
+	}
+
+	day := today()
+	if usage.Day != day {
+		usage.Day = day
+		usage.RowsToday = 0
+		usage.NotebookCpuSecToday = 0
+	}
+
+	return usage
+}
+
+// Returns the org's quota. A missing quota means no limits apply.
+func GetQuota(config_obj *config_proto.Config) *api_proto.OrgQuota {
+	org_manager, err := services.GetOrgManager()
+	if err != nil {
+		return &api_proto.OrgQuota{}
+	}
+
+	record, err := org_manager.GetOrg(config_obj.OrgId)
+	if err != nil || record.Quota == nil {
+		return &api_proto.OrgQuota{}
+	}
+
+	return record.Quota
+}
+
+// Account for rows collected by the org.
+func AddRows(config_obj *config_proto.Config, rows uint64) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	getUsage(config_obj.OrgId).RowsToday += rows
+}
+
+// Account for time spent calculating notebook cells.
+func AddNotebookTime(config_obj *config_proto.Config, duration time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	getUsage(config_obj.OrgId).NotebookCpuSecToday += duration.Seconds()
+}
+
+func setFilestoreBytes(org_id string, size uint64) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	usage := getUsage(org_id)
+	usage.FilestoreBytes = size
+	usage.FilestoreScanTime = uint64(utils.GetTime().Now().Unix())
+}
+
+// Returns the org's current usage and quota.
+func GetUsage(config_obj *config_proto.Config) *api_proto.OrgUsage {
+	mu.Lock()
+	result := proto.Clone(getUsage(config_obj.OrgId)).(*api_proto.OrgUsage)
+	mu.Unlock()
+
+	result.Quota = GetQuota(config_obj)
+
+	hunt_dispatcher, err := services.GetHuntDispatcher(config_obj)
+	if err == nil {
+		_ = hunt_dispatcher.ApplyFuncOnHunts(
+			func(hunt *api_proto.Hunt) error {
+				if hunt.State == api_proto.Hunt_RUNNING {
+					result.RunningHunts++
+				}
+				return nil
+			})
+	}
+
+	return result
+}
+
+// Check if the org may start another hunt while it already has
+// running_hunts running.
+func CheckHuntQuota(
+	config_obj *config_proto.Config, running_hunts uint64) error {
+	quota := GetQuota(config_obj)
+	if quota.MaxConcurrentHunts > 0 &&
+		running_hunts >= quota.MaxConcurrentHunts {
+		return fmt.Errorf("%w: %v already has %v running hunts (limit %v)",
+			QuotaExceededError, services.GetOrgName(config_obj),
+			running_hunts, quota.MaxConcurrentHunts)
+	}
+	return nil
+}
+
+// Check if the org may schedule new collections.
+func CheckCollectionQuota(config_obj *config_proto.Config) error {
+	quota := GetQuota(config_obj)
+
+	mu.Lock()
+	usage := getUsage(config_obj.OrgId)
+	filestore_bytes := usage.FilestoreBytes
+	rows_today := usage.RowsToday
+	mu.Unlock()
+
+	if quota.MaxFilestoreBytes > 0 &&
+		filestore_bytes >= quota.MaxFilestoreBytes {
+		return fmt.Errorf("%w: %v uses %v bytes of file store (limit %v)",
+			QuotaExceededError, services.GetOrgName(config_obj),
+			filestore_bytes, quota.MaxFilestoreBytes)
+	}
+
+	if quota.MaxRowsPerDay > 0 && rows_today >= quota.MaxRowsPerDay {
+		return fmt.Errorf("%w: %v collected %v rows today (limit %v)",
+			QuotaExceededError, services.GetOrgName(config_obj),
+			rows_today, quota.MaxRowsPerDay)
+	}
+
+	return nil
+}
+
+// Check if the org may calculate notebook cells.
+func CheckNotebookQuota(config_obj *config_proto.Config) error {
+	quota := GetQuota(config_obj)
+
+	mu.Lock()
+	used := getUsage(config_obj.OrgId).NotebookCpuSecToday
+	mu.Unlock()
+
+	if quota.MaxNotebookCpuSec > 0 &&
+		used >= float64(quota.MaxNotebookCpuSec) {
+		return fmt.Errorf("%w: %v used %.0f notebook seconds today (limit %v)",
+			QuotaExceededError, services.GetOrgName(config_obj),
+			used, quota.MaxNotebookCpuSec)
+	}
+
+	return nil
+}
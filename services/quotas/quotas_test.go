@@ -0,0 +1,94 @@
+package quotas_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/quotas"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+type QuotasTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *QuotasTestSuite) makeOrg(org_id string,
+	quota *api_proto.OrgQuota) services.OrgManager {
+	org_manager, err := services.GetOrgManager()
+	assert.NoError(self.T(), err)
+
+	_, err = org_manager.CreateNewOrg(org_id, org_id)
+	assert.NoError(self.T(), err)
+
+	assert.NoError(self.T(), org_manager.SetOrgQuota(org_id, quota))
+	return org_manager
+}
+
+func (self *QuotasTestSuite) TestRowsPerDay() {
+	org_manager := self.makeOrg("O1", &api_proto.OrgQuota{
+		MaxRowsPerDay: 100,
+	})
+
+	clock := &utils.MockClock{MockNow: time.Unix(1600000000, 0)}
+	defer utils.MockTime(clock)()
+
+	org_config, err := org_manager.GetOrgConfig("O1")
+	assert.NoError(self.T(), err)
+
+	quotas.AddRows(org_config, 50)
+	assert.NoError(self.T(), quotas.CheckCollectionQuota(org_config))
+
+	quotas.AddRows(org_config, 50)
+	err = quotas.CheckCollectionQuota(org_config)
+	assert.True(self.T(), errors.Is(err, quotas.QuotaExceededError))
+
+	// Other orgs are not affected.
+	assert.NoError(self.T(), quotas.CheckCollectionQuota(self.ConfigObj))
+
+	usage := quotas.GetUsage(org_config)
+	assert.Equal(self.T(), uint64(100), usage.RowsToday)
+	assert.Equal(self.T(), uint64(100), usage.Quota.MaxRowsPerDay)
+
+	// The counter is reset the next day.
+	clock.MockNow = clock.MockNow.Add(24 * time.Hour)
+	assert.NoError(self.T(), quotas.CheckCollectionQuota(org_config))
+	assert.Equal(self.T(), uint64(0), quotas.GetUsage(org_config).RowsToday)
+}
+
+func (self *QuotasTestSuite) TestNotebookAndHunts() {
+	org_manager := self.makeOrg("O2", &api_proto.OrgQuota{
+		MaxNotebookCpuSec:  10,
+		MaxConcurrentHunts: 2,
+	})
+	org_config, err := org_manager.GetOrgConfig("O2")
+	assert.NoError(self.T(), err)
+
+	quotas.AddNotebookTime(org_config, 5*time.Second)
+	assert.NoError(self.T(), quotas.CheckNotebookQuota(org_config))
+
+	quotas.AddNotebookTime(org_config, 6*time.Second)
+	err = quotas.CheckNotebookQuota(org_config)
+	assert.True(self.T(), errors.Is(err, quotas.QuotaExceededError))
+
+	assert.NoError(self.T(), quotas.CheckHuntQuota(org_config, 1))
+	err = quotas.CheckHuntQuota(org_config, 2)
+	assert.True(self.T(), errors.Is(err, quotas.QuotaExceededError))
+}
+
+func (self *QuotasTestSuite) TestRootOrgQuota() {
+	org_manager, err := services.GetOrgManager()
+	assert.NoError(self.T(), err)
+
+	err = org_manager.SetOrgQuota("", &api_proto.OrgQuota{MaxRowsPerDay: 1})
+	assert.Error(self.T(), err)
+}
+
+func TestQuotas(t *testing.T) {
+	suite.Run(t, &QuotasTestSuite{})
+}
@@ -0,0 +1,180 @@
+package quotas
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	// How often to write the usage to the datastore.
+	flushInterval = time.Minute
+
+	// Walking the file store is expensive so do it rarely.
+	scanInterval = 10 * time.Minute
+)
+
+// Restore today's counters from the datastore. Usage accounted
+// before the service started is added to the stored counters.
+func loadUsage(config_obj *config_proto.Config) error {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	stored := &api_proto.OrgUsage{}
+	err = db.GetSubject(config_obj, paths.OrgUsageURN, stored)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	usage := getUsage(config_obj.OrgId)
+	if stored.Day == usage.Day {
+		usage.RowsToday += stored.RowsToday
+		usage.NotebookCpuSecToday += stored.NotebookCpuSecToday
+	}
+	if usage.FilestoreScanTime == 0 {
+		usage.FilestoreBytes = stored.FilestoreBytes
+		usage.FilestoreScanTime = stored.FilestoreScanTime
+	}
+
+	return nil
+}
+
+func flushUsage(config_obj *config_proto.Config) error {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	usage := getUsage(config_obj.OrgId)
+	record := &api_proto.OrgUsage{
+		OrgId:               usage.OrgId,
+		Day:                 usage.Day,
+		FilestoreBytes:      usage.FilestoreBytes,
+		FilestoreScanTime:   usage.FilestoreScanTime,
+		RowsToday:           usage.RowsToday,
+		NotebookCpuSecToday: usage.NotebookCpuSecToday,
+	}
+	mu.Unlock()
+
+	return db.SetSubject(config_obj, paths.OrgUsageURN, record)
+}
+
+// Measure the size of the org's file store on disk. The root org's
+// file store contains the other orgs' file stores which are not
+// counted against it.
+func scanFilestore(
+	ctx context.Context, config_obj *config_proto.Config) (uint64, error) {
+	if config_obj.Datastore == nil ||
+		config_obj.Datastore.FilestoreDirectory == "" {
+		return 0, nil
+	}
+
+	root := config_obj.Datastore.FilestoreDirectory
+	orgs_dir := filepath.Join(root, "orgs")
+	is_root_org := utils.IsRootOrg(config_obj.OrgId)
+
+	var size uint64
+	err := filepath.WalkDir(root,
+		func(path string, d fs.DirEntry, err error) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			// Skip files we can not read.
+			if err != nil {
+				if d != nil && d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+
+			if d.IsDir() {
+				if is_root_org && path == orgs_dir {
+					return fs.SkipDir
+				}
+				return nil
+			}
+
+			info, err := d.Info()
+			if err == nil {
+				size += uint64(info.Size())
+			}
+			return nil
+		})
+	return size, err
+}
+
+// Keeps the org's usage up to date. This runs on the master only:
+// usage accounted on minions is not persisted.
+func NewQuotaService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> quota service for %v.",
+		services.GetOrgName(config_obj))
+
+	err := loadUsage(config_obj)
+	if err != nil {
+		logger.Error("QuotaService: loading usage: %v", err)
+	}
+
+	scan := func() {
+		size, err := scanFilestore(ctx, config_obj)
+		if err != nil {
+			logger.Error("QuotaService: scanning file store: %v", err)
+			return
+		}
+		setFilestoreBytes(config_obj.OrgId, size)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		scan()
+		last_scan := utils.GetTime().Now()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(flushInterval):
+				if utils.GetTime().Now().Sub(last_scan) > scanInterval {
+					scan()
+					last_scan = utils.GetTime().Now()
+				}
+
+				err := flushUsage(config_obj)
+				if err != nil {
+					logger.Error("QuotaService: writing usage: %v", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
@@ -30,9 +30,11 @@ import (
 	"log"
 
 	"github.com/Velocidex/ordereddict"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	"www.velocidex.com/golang/velociraptor/artifacts"
 	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/result_sets"
 	"www.velocidex.com/golang/velociraptor/uploads"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 	"www.velocidex.com/golang/vfilter"
@@ -52,6 +54,28 @@ func GetRepositoryManager(config_obj *config_proto.Config) (RepositoryManager, e
 	return org_manager.Services(config_obj.OrgId).RepositoryManager()
 }
 
+// The typed columns declared by the artifact (which may be given as
+// artifact/source). Returns nil if the artifact declares none.
+func GetArtifactSchema(
+	config_obj *config_proto.Config, name string) *result_sets.Schema {
+	manager, err := GetRepositoryManager(config_obj)
+	if err != nil {
+		return nil
+	}
+
+	repository, err := manager.GetGlobalRepository(config_obj)
+	if err != nil {
+		return nil
+	}
+
+	artifact, pres := repository.Get(config_obj, name)
+	if !pres {
+		return nil
+	}
+
+	return result_sets.NewSchema(artifact.ColumnTypes)
+}
+
 // Make it easier to build a query scope using the aritfact
 // repository.
 type ScopeBuilder struct {
@@ -122,6 +146,11 @@ type RepositoryManager interface {
 
 	SetParent(config_obj *config_proto.Config, parent Repository)
 
+	// Apply an org's policy to the artifacts it inherits from the
+	// built in and root org repositories.
+	SetArtifactPolicy(config_obj *config_proto.Config,
+		policy *api_proto.OrgArtifactPolicy) error
+
 	// Before callers can run VQL queries they need to create a
 	// query scope. This function uses the builder pattern above
 	// to create a new scope.
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/Velocidex/ordereddict"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	"www.velocidex.com/golang/velociraptor/artifacts/assets"
 	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
@@ -219,6 +220,17 @@ func (self *RepositoryManager) SetParent(
 	self.global_repository.SetParent(parent, config_obj)
 }
 
+func (self *RepositoryManager) SetArtifactPolicy(
+	config_obj *config_proto.Config,
+	policy *api_proto.OrgArtifactPolicy) error {
+	global_repository, err := self.GetGlobalRepository(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return global_repository.(*Repository).SetArtifactPolicy(policy)
+}
+
 func (self *RepositoryManager) DeleteArtifactFile(
 	config_obj *config_proto.Config, principal, name string) error {
 	global_repository, err := self.GetGlobalRepository(config_obj)
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"fmt"
+	"regexp"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/paths"
+)
+
+// Controls which artifacts of the parent repository are visible in
+// an org's repository (see OrgArtifactPolicy).
+type artifactPolicy struct {
+	share   []*regexp.Regexp
+	hide    []*regexp.Regexp
+	protect bool
+}
+
+func compileRegexes(field string, exprs []string) ([]*regexp.Regexp, error) {
+	var result []*regexp.Regexp
+	for _, expr := range exprs {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("Artifact policy %v: %w", field, err)
+		}
+		result = append(result, re)
+	}
+	return result, nil
+}
+
+func newArtifactPolicy(
+	policy *api_proto.OrgArtifactPolicy) (*artifactPolicy, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	share, err := compileRegexes("share", policy.Share)
+	if err != nil {
+		return nil, err
+	}
+
+	hide, err := compileRegexes("hide", policy.Hide)
+	if err != nil {
+		return nil, err
+	}
+
+	return &artifactPolicy{
+		share:   share,
+		hide:    hide,
+		protect: policy.ProtectRootArtifacts,
+	}, nil
+}
+
+func matchAny(regexes []*regexp.Regexp, name string) bool {
+	for _, re := range regexes {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Is the parent's artifact visible in the org?
+func (self *artifactPolicy) visible(name string, built_in bool) bool {
+	if self == nil {
+		return true
+	}
+
+	name, _ = paths.SplitFullSourceName(name)
+	if matchAny(self.hide, name) {
+		return false
+	}
+
+	// Built in artifacts are always shared.
+	if built_in || len(self.share) == 0 {
+		return true
+	}
+
+	return matchAny(self.share, name)
+}
+
+// Validate the policy without applying it.
+func ValidateArtifactPolicy(policy *api_proto.OrgArtifactPolicy) error {
+	_, err := newArtifactPolicy(policy)
+	return err
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/go-errors/errors"
 	"google.golang.org/protobuf/proto"
 	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/logging"
@@ -57,6 +58,10 @@ type Repository struct {
 	// in our parents as well.
 	parent            services.Repository
 	parent_config_obj *config_proto.Config
+
+	// Controls which of the parent's artifacts are visible in this
+	// repository.
+	policy *artifactPolicy
 }
 
 func (self *Repository) SetParent(
@@ -68,6 +73,66 @@ func (self *Repository) SetParent(
 	self.parent_config_obj = parent_config_obj
 }
 
+// Apply the org's artifact policy to the artifacts inherited from
+// the parent.
+func (self *Repository) SetArtifactPolicy(
+	policy *api_proto.OrgArtifactPolicy) error {
+	compiled, err := newArtifactPolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.policy = compiled
+	return nil
+}
+
+// Find an artifact visible in the parent repository without
+// compiling it. Returns if the artifact is built in and if it is
+// present.
+func (self *Repository) getFromParent(name string) (bool, bool) {
+	self.mu.Lock()
+	parent := self.parent
+	parent_config_obj := self.parent_config_obj
+	policy := self.policy
+	self.mu.Unlock()
+
+	if parent == nil {
+		return false, false
+	}
+
+	var built_in, pres bool
+	parent_repository, ok := parent.(*Repository)
+	if ok {
+		built_in, pres = parent_repository.lookup(name)
+	} else {
+		artifact, ok := parent.Get(parent_config_obj, name)
+		if ok {
+			built_in, pres = artifact.BuiltIn, true
+		}
+	}
+
+	if !pres || !policy.visible(name, built_in) {
+		return false, false
+	}
+	return built_in, true
+}
+
+func (self *Repository) lookup(name string) (bool, bool) {
+	artifact_name, _ := paths.SplitFullSourceName(name)
+
+	self.mu.Lock()
+	artifact, pres := self.Data[artifact_name]
+	self.mu.Unlock()
+
+	if pres {
+		return artifact.BuiltIn, true
+	}
+	return self.getFromParent(name)
+}
+
 func (self *Repository) Copy() services.Repository {
 	self.mu.Lock()
 	defer self.mu.Unlock()
@@ -76,6 +141,7 @@ func (self *Repository) Copy() services.Repository {
 		Data:              make(map[string]*artifacts_proto.Artifact),
 		parent:            self.parent,
 		parent_config_obj: self.parent_config_obj,
+		policy:            self.policy,
 	}
 	for k, v := range self.Data {
 		result.Data[k] = v
@@ -339,11 +405,27 @@ func (self *Repository) LoadProto(artifact *artifacts_proto.Artifact, validate b
 	if !artifact.BuiltIn {
 		self.mu.Lock()
 		existing_artifact, pres := self.Data[artifact.Name]
+		policy := self.policy
 		self.mu.Unlock()
 		if pres && existing_artifact.BuiltIn {
 			return nil, fmt.Errorf("Unable to override built in artifact %v",
 				artifact.Name)
 		}
+
+		// Org artifacts may replace the root org's artifacts unless
+		// protected, but never built in ones.
+		if !pres {
+			built_in, pres := self.getFromParent(artifact.Name)
+			if pres && built_in {
+				return nil, fmt.Errorf("Unable to override built in artifact %v",
+					artifact.Name)
+			}
+
+			if pres && policy != nil && policy.protect {
+				return nil, fmt.Errorf(
+					"Unable to override root org artifact %v", artifact.Name)
+			}
+		}
 	}
 
 	self.mu.Lock()
@@ -391,11 +473,18 @@ func (self *Repository) Get(
 	self.mu.Lock()
 	cached_artifact, pres := self.get(name)
 	if !pres {
+		parent := self.parent
+		parent_config_obj := self.parent_config_obj
+		policy := self.policy
 		self.mu.Unlock()
 
 		// If we have a parent repository just get it from there.
-		if self.parent != nil {
-			return self.parent.Get(self.parent_config_obj, name)
+		if parent != nil {
+			artifact, pres := parent.Get(parent_config_obj, name)
+			if !pres || !policy.visible(name, artifact.BuiltIn) {
+				return nil, false
+			}
+			return artifact, true
 		}
 		return nil, false
 	}
@@ -469,22 +558,34 @@ func (self *Repository) Del(name string) {
 func (self *Repository) List(ctx context.Context,
 	config_obj *config_proto.Config) ([]string, error) {
 	self.mu.Lock()
-	defer self.mu.Unlock()
-
 	results := self.list()
-	if self.parent != nil {
+	parent := self.parent
+	parent_config_obj := self.parent_config_obj
+	policy := self.policy
+	self.mu.Unlock()
+
+	if parent != nil {
 		seen := make(map[string]bool)
 		for _, name := range results {
 			seen[name] = true
 		}
 
-		parent_list, err := self.parent.List(ctx, self.parent_config_obj)
+		parent_list, err := parent.List(ctx, parent_config_obj)
 		if err == nil {
 			for _, name := range parent_list {
 				_, pres := seen[name]
-				if !pres {
-					results = append(results, name)
+				if pres {
+					continue
 				}
+
+				if policy != nil {
+					_, pres = self.getFromParent(name)
+					if !pres {
+						continue
+					}
+				}
+
+				results = append(results, name)
 			}
 		}
 	}
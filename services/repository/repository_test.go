@@ -9,12 +9,15 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
 	"www.velocidex.com/golang/velociraptor/config"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/file_store"
 	"www.velocidex.com/golang/velociraptor/paths"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/services/orgs"
+	"www.velocidex.com/golang/velociraptor/services/repository"
 )
 
 func TestLoadingFromFilestore(t *testing.T) {
@@ -64,3 +67,81 @@ func TestLoadingFromFilestore(t *testing.T) {
 
 	assert.Equal(t, artifact.Name, "Custom.TestArtifact")
 }
+
+func TestOrgArtifactPolicy(t *testing.T) {
+	config_obj := config.GetDefaultConfig()
+
+	root := &repository.Repository{
+		Data: make(map[string]*artifacts_proto.Artifact),
+	}
+	for _, name := range []string{"Generic.Client.Info", "Server.Monitor.Health"} {
+		_, err := root.LoadYaml("name: "+name,
+			!services.ValidateArtifact, services.ArtifactIsBuiltIn)
+		assert.NoError(t, err)
+	}
+
+	for _, name := range []string{"Custom.Shared.Triage", "Custom.Internal"} {
+		_, err := root.LoadYaml("name: "+name,
+			!services.ValidateArtifact, !services.ArtifactIsBuiltIn)
+		assert.NoError(t, err)
+	}
+
+	org := &repository.Repository{
+		Data: make(map[string]*artifacts_proto.Artifact),
+	}
+	org.SetParent(root, config_obj)
+
+	// Without a policy the org sees everything.
+	names, err := org.List(context.Background(), config_obj)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(names))
+
+	// Built in artifacts can not be replaced.
+	_, err = org.LoadYaml("name: Generic.Client.Info",
+		!services.ValidateArtifact, !services.ArtifactIsBuiltIn)
+	assert.Error(t, err)
+
+	// Root org artifacts can.
+	_, err = org.LoadYaml("name: Custom.Internal\ndescription: Org version",
+		!services.ValidateArtifact, !services.ArtifactIsBuiltIn)
+	assert.NoError(t, err)
+
+	artifact, pres := org.Get(config_obj, "Custom.Internal")
+	assert.True(t, pres)
+	assert.Equal(t, "Org version", artifact.Description)
+
+	assert.Error(t, org.SetArtifactPolicy(&api_proto.OrgArtifactPolicy{
+		Share: []string{"("},
+	}))
+
+	assert.NoError(t, org.SetArtifactPolicy(&api_proto.OrgArtifactPolicy{
+		Share:                []string{"^Custom.Shared."},
+		Hide:                 []string{"^Server.Monitor."},
+		ProtectRootArtifacts: true,
+	}))
+
+	names, err = org.List(context.Background(), config_obj)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Custom.Internal",
+		"Custom.Shared.Triage", "Generic.Client.Info"}, names)
+
+	_, pres = org.Get(config_obj, "Server.Monitor.Health")
+	assert.False(t, pres)
+
+	_, pres = org.Get(config_obj, "Custom.Shared.Triage")
+	assert.True(t, pres)
+
+	// Protected root org artifacts can not be replaced.
+	_, err = org.LoadYaml("name: Custom.Shared.Triage",
+		!services.ValidateArtifact, !services.ArtifactIsBuiltIn)
+	assert.Error(t, err)
+
+	// Hidden built in artifacts free up their name.
+	_, err = org.LoadYaml("name: Server.Monitor.Health",
+		!services.ValidateArtifact, !services.ArtifactIsBuiltIn)
+	assert.NoError(t, err)
+
+	artifact, pres = org.Get(config_obj, "Server.Monitor.Health")
+	assert.True(t, pres)
+	assert.False(t, artifact.BuiltIn)
+}
@@ -0,0 +1,244 @@
+package result_index
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/upload_index"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	DEFAULT_MAX_ROWS  = 100000
+	DEFAULT_MAX_TERMS = 1000000
+
+	numShards = 64
+)
+
+var (
+	// Serializes writers to the shards.
+	index_mu sync.Mutex
+)
+
+func shardForTerm(term string) string {
+	h := fnv.New32a()
+	h.Write([]byte(term))
+	return fmt.Sprintf("%02x", h.Sum32()%numShards)
+}
+
+// Split the values of the row into terms. Column names are not
+// indexed.
+func tokenizeRow(row *ordereddict.Dict, emit func(string)) {
+	for _, k := range row.Keys() {
+		value, _ := row.Get(k)
+		switch t := value.(type) {
+		case nil:
+		case string:
+			upload_index.Tokenize(t, emit)
+		default:
+			upload_index.Tokenize(json.AnyToString(t, json.DefaultEncOpts()), emit)
+		}
+	}
+}
+
+type limits struct {
+	artifacts []string
+	max_rows  int
+	max_terms int
+}
+
+func getLimits(config_obj *config_proto.Config) *limits {
+	result := &limits{
+		max_rows:  DEFAULT_MAX_ROWS,
+		max_terms: DEFAULT_MAX_TERMS,
+	}
+
+	settings := config_obj.ResultIndex
+	if settings == nil {
+		return result
+	}
+
+	result.artifacts = settings.Artifacts
+	if settings.MaxRows > 0 {
+		result.max_rows = int(settings.MaxRows)
+	}
+	if settings.MaxTerms > 0 {
+		result.max_terms = int(settings.MaxTerms)
+	}
+	return result
+}
+
+// Artifacts with sources are indexed when their artifact is
+// selected.
+func (self *limits) shouldIndex(artifact string) bool {
+	if len(self.artifacts) == 0 {
+		return true
+	}
+
+	name, _ := paths.SplitFullSourceName(artifact)
+	return utils.InString(self.artifacts, artifact) ||
+		utils.InString(self.artifacts, name)
+}
+
+// Extract the distinct terms of the artifact's results.
+func extractTerms(ctx context.Context,
+	file_store_factory api.FileStore,
+	path api.FSPathSpec, limits *limits) ([]string, int, error) {
+	reader, err := result_sets.NewResultSetReader(file_store_factory, path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer reader.Close()
+
+	terms := make(map[string]bool)
+	add := func(term string) {
+		if len(terms) < limits.max_terms {
+			terms[term] = true
+		}
+	}
+
+	count := 0
+	for row := range reader.Rows(ctx) {
+		if count >= limits.max_rows {
+			break
+		}
+		tokenizeRow(row, add)
+		count++
+	}
+
+	result := make([]string, 0, len(terms))
+	for term := range terms {
+		result = append(result, term)
+	}
+	sort.Strings(result)
+	return result, count, ctx.Err()
+}
+
+// Add the results of the flow to the index. Flows are only indexed
+// once - returns the number of artifacts which were indexed.
+func IndexFlow(ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id, flow_id string) (int, error) {
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	flow_path_manager := paths.NewFlowPathManager(client_id, flow_id)
+
+	// Already indexed.
+	_, err := file_store_factory.StatFile(flow_path_manager.ResultIndex())
+	if err == nil {
+		return 0, nil
+	}
+
+	launcher, err := services.GetLauncher(config_obj)
+	if err != nil {
+		return 0, err
+	}
+
+	details, err := launcher.GetFlowDetails(config_obj, client_id, flow_id)
+	if err != nil {
+		return 0, err
+	}
+
+	flow := details.Context
+	if flow == nil || len(flow.ArtifactsWithResults) == 0 {
+		return 0, nil
+	}
+
+	// Results of hunts can be searched by the hunt id.
+	var hunt_id string
+	if flow.Request != nil && strings.HasPrefix(flow.Request.Creator, "H.") {
+		hunt_id = flow.Request.Creator
+	}
+
+	created := time.Unix(0, int64(flow.CreateTime)*1000)
+	partition := paths.NewResultIndexPathManager().PartitionName(created)
+
+	limits := getLimits(config_obj)
+	postings := make(map[string][]*ordereddict.Dict)
+	var indexed []*ordereddict.Dict
+
+	for _, artifact := range flow.ArtifactsWithResults {
+		if !limits.shouldIndex(artifact) {
+			continue
+		}
+
+		path_manager, err := artifacts.NewArtifactPathManager(
+			config_obj, client_id, flow_id, artifact)
+		if err != nil {
+			continue
+		}
+
+		path, err := path_manager.GetPathForWriting()
+		if err != nil {
+			continue
+		}
+
+		terms, rows, err := extractTerms(ctx, file_store_factory, path, limits)
+		if err != nil {
+			continue
+		}
+
+		indexed = append(indexed, ordereddict.NewDict().
+			Set("Artifact", artifact).
+			Set("Partition", partition).
+			Set("Rows", rows).
+			Set("Terms", len(terms)))
+
+		for _, term := range terms {
+			shard := shardForTerm(term)
+			postings[shard] = append(postings[shard], ordereddict.NewDict().
+				Set("Term", term).
+				Set("ClientId", client_id).
+				Set("FlowId", flow_id).
+				Set("HuntId", hunt_id).
+				Set("Artifact", artifact).
+				Set("Time", created.Unix()))
+		}
+	}
+
+	index_mu.Lock()
+	defer index_mu.Unlock()
+
+	path_manager := paths.NewResultIndexPathManager()
+	for shard, rows := range postings {
+		err := writeRows(file_store_factory,
+			path_manager.Shard(partition, shard), rows, result_sets.AppendMode)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Written last so a flow which failed to index is retried.
+	return len(indexed), writeRows(file_store_factory,
+		flow_path_manager.ResultIndex(), indexed, result_sets.TruncateMode)
+}
+
+func writeRows(file_store_factory api.FileStore,
+	path api.FSPathSpec, rows []*ordereddict.Dict,
+	mode result_sets.WriteMode) error {
+	writer, err := result_sets.NewResultSetWriter(file_store_factory,
+		path, json.DefaultEncOpts(), utils.SyncCompleter, mode)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	for _, row := range rows {
+		writer.Write(row)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package result_index_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/result_index"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+type ResultIndexTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *ResultIndexTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.ResultIndex = &config_proto.ResultIndexConfig{
+		Artifacts: []string{"Windows.Test", "Generic.Test"},
+	}
+	self.LoadArtifacts([]string{`
+name: Windows.Test
+sources:
+- name: Processes
+  query: SELECT * FROM info()
+- name: Services
+  query: SELECT * FROM info()
+`, `
+name: Generic.Test
+`, `
+name: Generic.NotIndexed
+`})
+	self.TestSuite.SetupTest()
+}
+
+func (self *ResultIndexTestSuite) addFlow(
+	client_id, flow_id, hunt_id string, created time.Time,
+	results map[string][]*ordereddict.Dict) {
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	flow := &flows_proto.ArtifactCollectorContext{
+		ClientId:   client_id,
+		SessionId:  flow_id,
+		CreateTime: uint64(created.UnixNano() / 1000),
+		Request: &flows_proto.ArtifactCollectorArgs{
+			Creator: hunt_id,
+		},
+	}
+
+	for artifact, rows := range results {
+		err = journal.PushRowsToArtifact(self.ConfigObj, rows,
+			artifact, client_id, flow_id)
+		assert.NoError(self.T(), err)
+		flow.ArtifactsWithResults = append(flow.ArtifactsWithResults, artifact)
+	}
+
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	err = db.SetSubject(self.ConfigObj,
+		paths.NewFlowPathManager(client_id, flow_id).Path(), flow)
+	assert.NoError(self.T(), err)
+}
+
+func (self *ResultIndexTestSuite) search(
+	query string, options result_index.SearchOptions) []string {
+	rows, err := result_index.Search(self.Ctx, self.ConfigObj, query, options)
+	assert.NoError(self.T(), err)
+
+	result := []string{}
+	for _, row := range rows {
+		result_row, _ := row.Get("Row")
+		result = append(result, utils.GetString(row, "FlowId")+" "+
+			utils.GetString(row, "Artifact")+" "+
+			utils.GetString(result_row.(*ordereddict.Dict), "Name"))
+	}
+	return result
+}
+
+func (self *ResultIndexTestSuite) TestSearch() {
+	september := time.Date(2020, 9, 13, 0, 0, 0, 0, time.UTC)
+	october := time.Date(2020, 10, 2, 0, 0, 0, 0, time.UTC)
+
+	self.addFlow("C.1", "F.1", "", september, map[string][]*ordereddict.Dict{
+		"Windows.Test/Processes": {
+			ordereddict.NewDict().Set("Name", "beacon.exe").
+				Set("CommandLine", "beacon.exe -c update.evil-domain.com"),
+			ordereddict.NewDict().Set("Name", "svchost.exe").
+				Set("CommandLine", "svchost.exe -k netsvcs"),
+		},
+		"Windows.Test/Services": {
+			ordereddict.NewDict().Set("Name", "Updater").
+				Set("Binary", `C:\ProgramData\beacon.exe`),
+		},
+		"Generic.NotIndexed": {
+			ordereddict.NewDict().Set("Name", "beacon.exe"),
+		},
+	})
+
+	self.addFlow("C.2", "F.2", "H.1", october, map[string][]*ordereddict.Dict{
+		"Generic.Test": {
+			ordereddict.NewDict().Set("Name", "dns").
+				Set("Query", "update.evil-domain.com").
+				Set("Answers", []string{"10.1.2.3"}),
+		},
+	})
+
+	count, err := result_index.IndexFlow(self.Ctx, self.ConfigObj, "C.1", "F.1")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 2, count)
+
+	count, err = result_index.IndexFlow(self.Ctx, self.ConfigObj, "C.2", "F.2")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, count)
+
+	// Flows are only indexed once.
+	count, err = result_index.IndexFlow(self.Ctx, self.ConfigObj, "C.1", "F.1")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 0, count)
+
+	// Most recent collections first.
+	assert.Equal(self.T(), []string{
+		"F.2 Generic.Test dns",
+		"F.1 Windows.Test/Processes beacon.exe",
+	}, self.search("update.evil-domain.com", result_index.SearchOptions{}))
+
+	// Only the rows with all the terms are returned.
+	assert.Equal(self.T(), []string{
+		"F.1 Windows.Test/Services Updater",
+	}, self.search(`programdata\beacon.exe`, result_index.SearchOptions{}))
+
+	// Values in lists are indexed.
+	assert.Equal(self.T(), []string{"F.2 Generic.Test dns"},
+		self.search("10.1.2.3", result_index.SearchOptions{}))
+
+	// Filters
+	assert.Equal(self.T(), []string{"F.1 Windows.Test/Processes beacon.exe"},
+		self.search("update.evil-domain.com", result_index.SearchOptions{
+			Artifact: "Windows.Test",
+		}))
+
+	assert.Equal(self.T(), []string{"F.2 Generic.Test dns"},
+		self.search("update.evil-domain.com", result_index.SearchOptions{
+			HuntId: "H.1",
+		}))
+
+	assert.Equal(self.T(), []string{"F.1 Windows.Test/Processes beacon.exe"},
+		self.search("update.evil-domain.com", result_index.SearchOptions{
+			EndTime: october,
+		}))
+
+	assert.Equal(self.T(), []string{"F.2 Generic.Test dns"},
+		self.search("update.evil-domain.com", result_index.SearchOptions{
+			StartTime: september.Add(time.Hour),
+		}))
+
+	// Terms are whole words unless searching for substrings.
+	assert.Equal(self.T(), []string{},
+		self.search("beacon", result_index.SearchOptions{}))
+
+	assert.Equal(self.T(), []string{
+		"F.1 Windows.Test/Processes beacon.exe",
+		"F.1 Windows.Test/Services Updater",
+	}, self.search("beacon", result_index.SearchOptions{Substring: true}))
+
+	// Deleted flows are not returned.
+	err = file_store.GetFileStore(self.ConfigObj).Delete(
+		paths.NewFlowPathManager("C.2", "F.2").ResultIndex())
+	assert.NoError(self.T(), err)
+
+	assert.Equal(self.T(), []string{"F.1 Windows.Test/Processes beacon.exe"},
+		self.search("update.evil-domain.com", result_index.SearchOptions{}))
+}
+
+func TestResultIndex(t *testing.T) {
+	suite.Run(t, &ResultIndexTestSuite{})
+}
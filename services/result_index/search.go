@@ -0,0 +1,367 @@
+package result_index
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services/upload_index"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	DEFAULT_LIMIT = 1000
+)
+
+type SearchOptions struct {
+	// Match terms containing the query instead of whole terms. This
+	// reads every shard of the searched partitions.
+	Substring bool
+
+	// Only search the results of this artifact (or artifact source).
+	Artifact string
+
+	ClientId string
+	HuntId   string
+
+	// Only search collections created in this time range.
+	StartTime time.Time
+	EndTime   time.Time
+
+	// The most rows returned.
+	Limit int
+}
+
+// The results of an artifact in a flow which contain the query.
+type indexMatch struct {
+	client_id, flow_id, hunt_id, artifact string
+	time                                  int64
+
+	terms []string
+}
+
+func (self *indexMatch) key() string {
+	return self.client_id + "/" + self.flow_id + "/" + self.artifact
+}
+
+func (self *SearchOptions) matchPosting(posting *ordereddict.Dict) bool {
+	if self.ClientId != "" &&
+		utils.GetString(posting, "ClientId") != self.ClientId {
+		return false
+	}
+
+	if self.HuntId != "" &&
+		utils.GetString(posting, "HuntId") != self.HuntId {
+		return false
+	}
+
+	if self.Artifact != "" {
+		artifact := utils.GetString(posting, "Artifact")
+		name, _ := paths.SplitFullSourceName(artifact)
+		if artifact != self.Artifact && name != self.Artifact {
+			return false
+		}
+	}
+
+	created := time.Unix(utils.GetInt64(posting, "Time"), 0)
+	if !self.StartTime.IsZero() && created.Before(self.StartTime) {
+		return false
+	}
+
+	if !self.EndTime.IsZero() && !created.Before(self.EndTime) {
+		return false
+	}
+
+	return true
+}
+
+// The partitions which may hold collections in the time range.
+func (self *SearchOptions) partitions(
+	file_store_factory api.FileStore) ([]string, error) {
+	path_manager := paths.NewResultIndexPathManager()
+	children, err := file_store_factory.ListDirectory(
+		path_manager.PartitionsDirectory())
+	if err != nil {
+		// Nothing was indexed yet.
+		return nil, nil
+	}
+
+	var result []string
+	for _, child := range children {
+		if !child.IsDir() {
+			continue
+		}
+
+		name := child.Name()
+		month, err := time.Parse("2006-01", name)
+		if err != nil {
+			continue
+		}
+
+		if !self.StartTime.IsZero() &&
+			!month.AddDate(0, 1, 0).After(self.StartTime) {
+			continue
+		}
+
+		if !self.EndTime.IsZero() && !month.Before(self.EndTime) {
+			continue
+		}
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+	return result, nil
+}
+
+// Call cb on each posting in the shard.
+func scanShard(ctx context.Context,
+	file_store_factory api.FileStore, shard api.FSPathSpec,
+	cb func(posting *ordereddict.Dict)) error {
+	reader, err := result_sets.NewResultSetReader(file_store_factory, shard)
+	if err != nil {
+		// Nothing was indexed in this shard yet.
+		return nil
+	}
+	defer reader.Close()
+
+	for row := range reader.Rows(ctx) {
+		cb(row)
+	}
+	return ctx.Err()
+}
+
+// Find the artifact results in the partition with all the terms.
+func findMatches(ctx context.Context,
+	file_store_factory api.FileStore,
+	partition string, terms []string, options SearchOptions,
+	matches map[string]*indexMatch) error {
+	path_manager := paths.NewResultIndexPathManager()
+
+	add := func(posting *ordereddict.Dict, term string) *indexMatch {
+		result := &indexMatch{
+			client_id: utils.GetString(posting, "ClientId"),
+			flow_id:   utils.GetString(posting, "FlowId"),
+			hunt_id:   utils.GetString(posting, "HuntId"),
+			artifact:  utils.GetString(posting, "Artifact"),
+			time:      utils.GetInt64(posting, "Time"),
+		}
+		existing, pres := matches[result.key()]
+		if pres {
+			result = existing
+		} else {
+			matches[result.key()] = result
+		}
+
+		if !utils.InString(result.terms, term) {
+			result.terms = append(result.terms, term)
+		}
+		return result
+	}
+
+	if options.Substring {
+		needle := strings.ToLower(terms[0])
+		children, err := file_store_factory.ListDirectory(
+			path_manager.PartitionsDirectory().AddChild(partition))
+		if err != nil {
+			return err
+		}
+
+		for _, child := range children {
+			if child.PathSpec().Type() != api.PATH_TYPE_FILESTORE_JSON {
+				continue
+			}
+
+			err := scanShard(ctx, file_store_factory, child.PathSpec(),
+				func(posting *ordereddict.Dict) {
+					term := utils.GetString(posting, "Term")
+					if strings.Contains(term, needle) &&
+						options.matchPosting(posting) {
+						add(posting, term)
+					}
+				})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Only keep the results which have all the terms.
+	partition_matches := make(map[string]*indexMatch)
+	for idx, term := range terms {
+		err := scanShard(ctx, file_store_factory,
+			path_manager.Shard(partition, shardForTerm(term)),
+			func(posting *ordereddict.Dict) {
+				if utils.GetString(posting, "Term") != term ||
+					!options.matchPosting(posting) {
+					return
+				}
+
+				key := utils.GetString(posting, "ClientId") + "/" +
+					utils.GetString(posting, "FlowId") + "/" +
+					utils.GetString(posting, "Artifact")
+				_, pres := partition_matches[key]
+				if idx == 0 || pres {
+					partition_matches[key] = add(posting, term)
+				}
+			})
+		if err != nil {
+			return err
+		}
+
+		for key, match := range partition_matches {
+			if len(match.terms) <= idx {
+				delete(partition_matches, key)
+				delete(matches, key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Does the row contain the query?
+func matchRow(row *ordereddict.Dict, terms []string, substring bool) bool {
+	found := make(map[string]bool)
+	tokenizeRow(row, func(term string) {
+		if substring {
+			if strings.Contains(term, terms[0]) {
+				found[terms[0]] = true
+			}
+		} else if utils.InString(terms, term) {
+			found[term] = true
+		}
+	})
+	return len(found) == len(terms)
+}
+
+// Search the index for results containing the query. Returns the
+// matching rows with the collection they came from - the most recent
+// collections first.
+func Search(ctx context.Context,
+	config_obj *config_proto.Config,
+	query string, options SearchOptions) ([]*ordereddict.Dict, error) {
+
+	var terms []string
+	if options.Substring {
+		terms = []string{strings.ToLower(query)}
+	} else {
+		upload_index.Tokenize(query, func(term string) {
+			if !utils.InString(terms, term) {
+				terms = append(terms, term)
+			}
+		})
+	}
+
+	if len(terms) == 0 || terms[0] == "" {
+		return nil, errors.New("result_index: query has no searchable terms")
+	}
+
+	if options.Limit == 0 {
+		options.Limit = DEFAULT_LIMIT
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	partitions, err := options.partitions(file_store_factory)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make(map[string]*indexMatch)
+	for _, partition := range partitions {
+		err := findMatches(ctx, file_store_factory, partition,
+			terms, options, matches)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sorted := make([]*indexMatch, 0, len(matches))
+	for _, match := range matches {
+		sorted = append(sorted, match)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].time != sorted[j].time {
+			return sorted[i].time > sorted[j].time
+		}
+		return sorted[i].key() < sorted[j].key()
+	})
+
+	var result []*ordereddict.Dict
+	for _, match := range sorted {
+		rows, err := readMatchingRows(ctx, config_obj, file_store_factory,
+			match, terms, options)
+		if err != nil {
+			continue
+		}
+
+		for _, row := range rows {
+			if len(result) >= options.Limit {
+				return result, nil
+			}
+			result = append(result, row)
+		}
+	}
+
+	return result, nil
+}
+
+func readMatchingRows(ctx context.Context,
+	config_obj *config_proto.Config,
+	file_store_factory api.FileStore,
+	match *indexMatch, terms []string,
+	options SearchOptions) ([]*ordereddict.Dict, error) {
+
+	// Flows deleted since they were indexed are no longer searched.
+	_, err := file_store_factory.StatFile(
+		paths.NewFlowPathManager(match.client_id, match.flow_id).ResultIndex())
+	if err != nil {
+		return nil, err
+	}
+
+	path_manager, err := artifacts.NewArtifactPathManager(
+		config_obj, match.client_id, match.flow_id, match.artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := path_manager.GetPathForWriting()
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := result_sets.NewResultSetReader(file_store_factory, path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var result []*ordereddict.Dict
+	for row := range reader.Rows(ctx) {
+		if len(result) >= options.Limit {
+			break
+		}
+
+		if !matchRow(row, terms, options.Substring) {
+			continue
+		}
+
+		result = append(result, ordereddict.NewDict().
+			Set("ClientId", match.client_id).
+			Set("FlowId", match.flow_id).
+			Set("HuntId", match.hunt_id).
+			Set("Artifact", match.artifact).
+			Set("Created", time.Unix(match.time, 0).UTC()).
+			Set("Row", row))
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+/*
+  The result index service maintains a full text index of the results
+  of collections.
+
+  An analyst looking for an indicator (a hash, a domain or a user
+  name) across all the historical collections of an org would
+  otherwise have to know which flows to look in. When a flow
+  completes, the values of each row of its results are split into
+  terms (see upload_index.Tokenize) and the distinct terms of each
+  artifact are added to the index. Hunt collections are indexed with
+  their hunt id.
+
+  The index is partitioned by the month the collection was created,
+  and each partition is split into shards by the term's hash, so a
+  search only reads the shards of its terms in the months it
+  covers. Postings record the client, flow, hunt and artifact so
+  searches are filtered without reading the results. The matching
+  rows are then read from the results themselves.
+
+  Each org has its own index in its file store. The indexed artifacts
+  of each flow are stored with the flow and are removed when the flow
+  is deleted, which also drops it from search results.
+
+  Search the index with the result_search() plugin.
+*/
+
+package result_index
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
+)
+
+func NewResultIndexService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> result index service for %v.",
+		services.GetOrgName(config_obj))
+
+	return journal.WatchQueueWithCB(ctx, config_obj, wg,
+		"System.Flow.Completion", "ResultIndex",
+		func(ctx context.Context, config_obj *config_proto.Config,
+			row *ordereddict.Dict) error {
+			client_id, _ := row.GetString("ClientId")
+			flow_id, _ := row.GetString("FlowId")
+			if client_id == "" || flow_id == "" {
+				return nil
+			}
+
+			count, err := IndexFlow(ctx, config_obj, client_id, flow_id)
+			if err != nil {
+				logger.Error("ResultIndex: indexing %v/%v: %v",
+					client_id, flow_id, err)
+				return nil
+			}
+
+			if count > 0 {
+				logger.Debug("ResultIndex: indexed %v artifacts of %v/%v",
+					count, client_id, flow_id)
+			}
+			return nil
+		})
+}
@@ -0,0 +1,251 @@
+/*
+  Secrets are credentials (API keys, passwords etc) which artifacts
+  need but which should not be visible to everyone who can read the
+  collection.
+
+  A secret is stored in the datastore encrypted with a key derived
+  from the server's private key. An artifact declares a parameter of
+  type secret and the user launching the collection sets it to the
+  name of the secret. The launcher checks that the user may use the
+  secret, and only replaces the name with the decrypted value in the
+  message queued to the client (or to the server for server
+  artifacts). The collection request, the recorded tasks and the
+  flow's logs only ever contain the secret's name. The resolved
+  message is only kept in the client's task queue until the client
+  receives it.
+*/
+
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"www.velocidex.com/golang/velociraptor/acls"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// Derive the key used to encrypt secrets from the server's private
+// key. Secrets can not be decrypted without the server config.
+func getCipher(config_obj *config_proto.Config) (cipher.AEAD, error) {
+	if config_obj.Frontend == nil || config_obj.Frontend.PrivateKey == "" {
+		return nil, errors.New("Secrets: Frontend private key not configured")
+	}
+
+	mac := hmac.New(sha256.New, []byte(config_obj.Frontend.PrivateKey))
+	_, _ = mac.Write([]byte("velociraptor secrets"))
+
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// The secret's name is authenticated along with the value so an
+// encrypted value can not be copied to another secret.
+func encrypt(config_obj *config_proto.Config,
+	name string, value []byte) ([]byte, error) {
+	gcm, err := getCipher(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, value, []byte(name)), nil
+}
+
+func decrypt(config_obj *config_proto.Config,
+	name string, encrypted []byte) ([]byte, error) {
+	gcm, err := getCipher(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(encrypted) < gcm.NonceSize() {
+		return nil, fmt.Errorf("Secret %v is corrupted", name)
+	}
+
+	nonce := encrypted[:gcm.NonceSize()]
+	value, err := gcm.Open(nil, nonce, encrypted[gcm.NonceSize():], []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decrypt secret %v: %w", name, err)
+	}
+	return value, nil
+}
+
+// Get the stored secret. The value is still encrypted.
+func GetSecret(config_obj *config_proto.Config,
+	name string) (*api_proto.Secret, error) {
+	result := &api_proto.Secret{}
+	err := datastore.GetExistingSubject(config_obj,
+		paths.SECRETS_ROOT.AddChild(name), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Add a new secret or replace the value of an existing one. The
+// original creator of an existing secret is kept.
+func AddSecret(config_obj *config_proto.Config,
+	principal, name, description, value string,
+	users []string) (*api_proto.Secret, error) {
+	if name == "" {
+		return nil, errors.New("Secret name must be set")
+	}
+
+	encrypted, err := encrypt(config_obj, name, []byte(value))
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &api_proto.Secret{}
+	err = datastore.ModifySubject(config_obj,
+		paths.SECRETS_ROOT.AddChild(name), secret,
+		func(exists bool) error {
+			now := uint64(utils.GetTime().Now().Unix())
+			if !exists {
+				secret.Name = name
+				secret.Creator = principal
+				secret.CreateTime = now
+			}
+
+			secret.Description = description
+			secret.EncryptedValue = encrypted
+			secret.Users = users
+			secret.ModifyTime = now
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return Redact(secret), nil
+}
+
+func DeleteSecret(config_obj *config_proto.Config, name string) error {
+	return datastore.DeleteSubject(config_obj,
+		paths.SECRETS_ROOT.AddChild(name), &api_proto.Secret{})
+}
+
+// List all the secrets with their values removed.
+func ListSecrets(
+	config_obj *config_proto.Config) ([]*api_proto.Secret, error) {
+	children, err := datastore.ListSubjects(config_obj, paths.SECRETS_ROOT)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api_proto.Secret, 0, len(children))
+	for _, child := range children {
+		secret, err := GetSecret(config_obj, child.Base())
+		if err != nil {
+			continue
+		}
+		result = append(result, Redact(secret))
+	}
+
+	return result, nil
+}
+
+// A copy of the secret without its value.
+func Redact(secret *api_proto.Secret) *api_proto.Secret {
+	result := proto.Clone(secret).(*api_proto.Secret)
+	result.EncryptedValue = nil
+	return result
+}
+
+// Only the creator and the users the secret is shared with may use
+// it.
+func CanUse(secret *api_proto.Secret, principal string) bool {
+	return principal != "" &&
+		(secret.Creator == principal || utils.InString(secret.Users, principal))
+}
+
+// Check that the principal may use the named secret.
+func CheckAccess(config_obj *config_proto.Config,
+	principal, name string) error {
+	secret, err := GetSecret(config_obj, name)
+	if err != nil {
+		return fmt.Errorf("Secret %v: %w", name, err)
+	}
+
+	if !CanUse(secret, principal) {
+		return fmt.Errorf("%w: %v may not use secret %v",
+			acls.PermissionDenied, principal, name)
+	}
+	return nil
+}
+
+// Returns a copy of the task with the secret parameters replaced by
+// their values. The original task is not modified so it can be
+// recorded without the secrets.
+func ResolveTask(config_obj *config_proto.Config,
+	task *crypto_proto.VeloMessage) (*crypto_proto.VeloMessage, error) {
+	if task.FlowRequest == nil || !hasSecrets(task.FlowRequest) {
+		return task, nil
+	}
+
+	result := proto.Clone(task).(*crypto_proto.VeloMessage)
+	for _, arg := range result.FlowRequest.VQLClientActions {
+		for _, env := range arg.Env {
+			if env.Value == "" || !utils.InString(arg.Secrets, env.Key) {
+				continue
+			}
+
+			secret, err := GetSecret(config_obj, env.Value)
+			if err != nil {
+				return nil, fmt.Errorf("Secret %v: %w", env.Value, err)
+			}
+
+			value, err := decrypt(config_obj, secret.Name, secret.EncryptedValue)
+			if err != nil {
+				return nil, err
+			}
+			env.Value = string(value)
+		}
+	}
+
+	return result, nil
+}
+
+func hasSecrets(request *crypto_proto.FlowRequest) bool {
+	for _, arg := range request.VQLClientActions {
+		if len(arg.Secrets) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// The names of the secrets the collection uses.
+func GetSecretNames(arg *actions_proto.VQLCollectorArgs) []string {
+	result := []string{}
+	for _, env := range arg.Env {
+		if env.Value != "" && utils.InString(arg.Secrets, env.Key) &&
+			!utils.InString(result, env.Value) {
+			result = append(result, env.Value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package secrets_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/acls"
+	acl_proto "www.velocidex.com/golang/velociraptor/acls/proto"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/secrets"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+)
+
+type SecretsTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *SecretsTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.LoadArtifacts([]string{`
+name: Server.Secret.Test
+parameters:
+- name: APIKey
+  type: secret
+sources:
+- query: SELECT * FROM info()
+`})
+	self.TestSuite.SetupTest()
+}
+
+func (self *SecretsTestSuite) TestStore() {
+	secret, err := secrets.AddSecret(self.ConfigObj, "admin",
+		"VTKey", "VirusTotal", "hunter2", []string{"UserY"})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "admin", secret.Creator)
+	assert.Empty(self.T(), secret.EncryptedValue)
+
+	// The value is encrypted at rest.
+	stored, err := secrets.GetSecret(self.ConfigObj, "VTKey")
+	assert.NoError(self.T(), err)
+	assert.NotEmpty(self.T(), stored.EncryptedValue)
+	assert.False(self.T(), strings.Contains(
+		string(stored.EncryptedValue), "hunter2"))
+
+	// Listing never returns the value.
+	all, err := secrets.ListSecrets(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(all))
+	assert.Empty(self.T(), all[0].EncryptedValue)
+
+	// Only the creator and the listed users may use the secret.
+	assert.NoError(self.T(), secrets.CheckAccess(self.ConfigObj, "admin", "VTKey"))
+	assert.NoError(self.T(), secrets.CheckAccess(self.ConfigObj, "UserY", "VTKey"))
+	assert.ErrorIs(self.T(), secrets.CheckAccess(
+		self.ConfigObj, "UserX", "VTKey"), acls.PermissionDenied)
+
+	assert.NoError(self.T(), secrets.DeleteSecret(self.ConfigObj, "VTKey"))
+	_, err = secrets.GetSecret(self.ConfigObj, "VTKey")
+	assert.Error(self.T(), err)
+}
+
+func (self *SecretsTestSuite) TestCollection() {
+	ctx := context.Background()
+
+	_, err := secrets.AddSecret(self.ConfigObj, "admin",
+		"VTKey", "", "hunter2", []string{"UserY"})
+	assert.NoError(self.T(), err)
+
+	for _, user := range []string{"UserX", "UserY"} {
+		assert.NoError(self.T(), services.SetPolicy(self.ConfigObj, user,
+			&acl_proto.ApiClientACL{CollectServer: true}))
+	}
+
+	manager, err := services.GetRepositoryManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	repository, err := manager.GetGlobalRepository(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	launcher, err := services.GetLauncher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	schedule := func(user string) (string, error) {
+		return launcher.ScheduleArtifactCollection(ctx, self.ConfigObj,
+			acl_managers.NewServerACLManager(self.ConfigObj, user),
+			repository, &flows_proto.ArtifactCollectorArgs{
+				Creator:   user,
+				ClientId:  "server",
+				Artifacts: []string{"Server.Secret.Test"},
+				Specs: []*flows_proto.ArtifactSpec{{
+					Artifact: "Server.Secret.Test",
+					Parameters: &flows_proto.ArtifactParameters{
+						Env: []*actions_proto.VQLEnv{{
+							Key: "APIKey", Value: "VTKey",
+						}},
+					},
+				}},
+			}, nil)
+	}
+
+	// UserX may not use the secret.
+	_, err = schedule("UserX")
+	assert.ErrorIs(self.T(), err, acls.PermissionDenied)
+
+	flow_id, err := schedule("UserY")
+	assert.NoError(self.T(), err)
+
+	// The recorded requests only contain the name of the secret.
+	requests, err := launcher.GetFlowRequests(
+		self.ConfigObj, "server", flow_id, 0, 10)
+	assert.NoError(self.T(), err)
+	serialized := json.MustMarshalString(requests)
+	assert.Contains(self.T(), serialized, "VTKey")
+	assert.NotContains(self.T(), serialized, "hunter2")
+
+	// The task queued for the client contains the value.
+	client_info_manager, err := services.GetClientInfoManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	tasks, err := client_info_manager.PeekClientTasks(ctx, "server")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(tasks))
+	assert.Contains(self.T(), json.MustMarshalString(tasks[0]), "hunter2")
+}
+
+func TestSecrets(t *testing.T) {
+	suite.Run(t, &SecretsTestSuite{})
+}
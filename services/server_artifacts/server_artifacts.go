@@ -34,6 +34,7 @@ import (
 	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
 
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/governor"
 	"www.velocidex.com/golang/velociraptor/utils"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 	"www.velocidex.com/golang/vfilter"
@@ -216,6 +217,30 @@ func (self *ServerArtifactsRunner) runQuery(
 		return errors.New("Query should be specified")
 	}
 
+	// Wait until the server has capacity to run the query. If the
+	// governor preempts the query to free memory, the collection
+	// fails.
+	logger := query_context.Logger()
+	task, err := governor.Acquire(sub_ctx, self.config_obj,
+		governor.PRIORITY_SERVER_ARTIFACT, "collection "+session_id,
+		func() {
+			logger.Write([]byte(
+				"ERROR:Query preempted by the resource governor to free memory"))
+			cancel()
+		})
+	if err != nil {
+		// Logging the error to the query fails the collection.
+		logger.Write([]byte("ERROR:" + err.Error()))
+		return nil
+	}
+	defer task.Close()
+
+	if task.Waited() > time.Second {
+		logger.Write([]byte(fmt.Sprintf(
+			"INFO:Query waited %v for the resource governor",
+			task.Waited().Round(time.Second))))
+	}
+
 	// timeout the entire query if it takes too long. Timeout is
 	// specified in the artifact definition and set in the query by
 	// the artifact compiler.
@@ -260,6 +285,8 @@ func (self *ServerArtifactsRunner) runQuery(
 
 	scope.Log("Running query on behalf of user %v", principal)
 
+	governor.Throttle(sub_ctx, self.config_obj, scope)
+
 	env := ordereddict.NewDict()
 	for _, env_spec := range arg.Env {
 		env.Set(env_spec.Key, env_spec.Value)
@@ -304,6 +331,10 @@ func (self *ServerArtifactsRunner) runQuery(
 		// Allow query scope to control encoding details.
 		opts := vql_subsystem.EncOptsFromScope(scope)
 
+		// Typed columns declared by the artifact are normalized
+		// before the rows are stored.
+		schema := services.GetArtifactSchema(self.config_obj, name)
+
 		artifact_path_manager := artifact_paths.NewArtifactPathManagerWithMode(
 			self.config_obj, "server", session_id, name, paths.MODE_SERVER)
 		file_store_factory := file_store.GetFileStore(self.config_obj)
@@ -350,7 +381,8 @@ func (self *ServerArtifactsRunner) runQuery(
 
 				// rs_writer has its own internal buffering so it is
 				// ok to write a row at a time.
-				rs_writer.Write(vfilter.RowToDict(sub_ctx, scope, row))
+				rs_writer.Write(schema.Normalize(
+					vfilter.RowToDict(sub_ctx, scope, row)))
 				query_context.UpdateStatus(func(s *crypto_proto.VeloStatus) {
 					s.ResultRows++
 					_, pres := names_with_response[name]
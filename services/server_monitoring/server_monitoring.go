@@ -11,10 +11,13 @@ import (
 	"time"
 
 	"github.com/Velocidex/ordereddict"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
 	"www.velocidex.com/golang/velociraptor/actions"
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	"www.velocidex.com/golang/velociraptor/config"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/datastore"
 	"www.velocidex.com/golang/velociraptor/file_store"
@@ -30,6 +33,25 @@ import (
 	"www.velocidex.com/golang/vfilter"
 )
 
+var (
+	serverMonitoringRows = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "server_monitoring_rows",
+			Help: "Number of rows written by each server event artifact.",
+		},
+		[]string{"artifact"},
+	)
+
+	serverMonitoringWriteLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "server_monitoring_write_latency",
+			Help:    "Latency to write a server event row in seconds.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 4, 8),
+		},
+		[]string{"artifact"},
+	)
+)
+
 type EventTable struct {
 	mu sync.Mutex
 
@@ -415,9 +437,14 @@ func (self *EventTable) RunQuery(
 						break one_query
 					}
 
+					start := time.Now()
 					rs_writer.Write(vfilter.RowToDict(ctx, scope, row).
 						Set("_ts", self.Clock().Now().Unix()))
 					rs_writer.Flush()
+
+					serverMonitoringWriteLatency.WithLabelValues(artifact_name).
+						Observe(time.Since(start).Seconds())
+					serverMonitoringRows.WithLabelValues(artifact_name).Inc()
 				}
 			}
 			self.tracer.Clear(query.VQL)
@@ -428,6 +455,28 @@ func (self *EventTable) RunQuery(
 	return nil
 }
 
+// Apply changes to the default server monitoring artifacts when the
+// config is reloaded.
+func (self *EventTable) ProcessConfigChange(
+	ctx context.Context, change *config.ConfigChange) error {
+	if !change.Changed(config.RELOAD_SERVER_MONITORING) ||
+		!services.IsMaster(self.config_obj) {
+		return nil
+	}
+
+	request := self.Get()
+	request.Artifacts = config.MergeDefaults(request.Artifacts,
+		change.Old.Frontend.GetDefaultServerMonitoringArtifacts(),
+		change.New.Frontend.GetDefaultServerMonitoringArtifacts())
+	request.CompiledCollectorArgs = nil
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	logger.Info("server_monitoring: Updating default artifacts for %v: %v",
+		services.GetOrgName(self.config_obj), request.Artifacts)
+
+	return self.Update(self.config_obj, "", request)
+}
+
 // Bring up the server monitoring service.
 func NewServerMonitoringService(
 	ctx context.Context,
@@ -471,10 +520,15 @@ func NewServerMonitoringService(
 		ctx, "Server.Internal.ArtifactModification",
 		"server_monitoring_service")
 
+	unregister := config.RegisterReloadHandler(
+		"server_monitoring/"+utils.NormalizedOrgId(config_obj.OrgId),
+		manager.ProcessConfigChange)
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		defer cancel()
+		defer unregister()
 
 		// Shut down all server queries in an orderly fasion
 		defer manager.Close()
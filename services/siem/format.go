@@ -0,0 +1,224 @@
+package siem
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	cefHeaderEscaper = strings.NewReplacer(
+		`\`, `\\`, `|`, `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+
+	cefValueEscaper = strings.NewReplacer(
+		`\`, `\\`, `=`, `\=`, "\r\n", `\n`, "\n", `\n`, "\r", `\r`)
+
+	leefValueEscaper = strings.NewReplacer(
+		`\`, `\\`, "\t", `\t`, "\r\n", `\n`, "\n", `\n`, "\r", `\r`)
+)
+
+type field struct {
+	key, value string
+}
+
+// Formats event artifact rows for legacy SIEMs.
+type Formatter struct {
+	vendor  string
+	product string
+	version string
+}
+
+func (self *Formatter) Format(
+	artifact_config *config_proto.SIEMArtifactConfig,
+	row *ordereddict.Dict) (string, error) {
+
+	switch strings.ToLower(artifact_config.Format) {
+	case "", "cef":
+		return self.FormatCEF(artifact_config, row), nil
+
+	case "leef":
+		return self.FormatLEEF(artifact_config, row), nil
+
+	case "json":
+		serialized, err := json.Marshal(row)
+		return string(serialized), err
+	}
+
+	return "", fmt.Errorf("Unsupported SIEM format %v", artifact_config.Format)
+}
+
+// CEF:Version|Device Vendor|Device Product|Device Version|Signature ID|Name|Severity|Extension
+func (self *Formatter) FormatCEF(
+	artifact_config *config_proto.SIEMArtifactConfig,
+	row *ordereddict.Dict) string {
+
+	name := artifact_config.Name
+	if name == "" {
+		name = artifact_config.Artifact
+	}
+
+	severity := artifact_config.Severity
+	if severity == 0 {
+		severity = 5
+	} else if severity > 10 {
+		severity = 10
+	}
+
+	header := []string{"CEF:0", self.vendor, self.product, self.version,
+		eventId(artifact_config), name, strconv.FormatInt(severity, 10)}
+	for i := 1; i < len(header); i++ {
+		header[i] = cefHeaderEscaper.Replace(header[i])
+	}
+
+	extension := make([]string, 0, len(row.Keys()))
+	timestamp, pres := getTimestamp(row)
+	if pres {
+		extension = append(extension, "rt="+timestamp)
+	}
+
+	for _, f := range getFields(artifact_config, row) {
+		extension = append(extension, f.key+"="+cefValueEscaper.Replace(f.value))
+	}
+
+	return strings.Join(header, "|") + "|" + strings.Join(extension, " ")
+}
+
+// LEEF:1.0|Vendor|Product|Version|EventID|key=value<tab>key=value
+func (self *Formatter) FormatLEEF(
+	artifact_config *config_proto.SIEMArtifactConfig,
+	row *ordereddict.Dict) string {
+
+	header := []string{"LEEF:1.0", self.vendor, self.product, self.version,
+		eventId(artifact_config)}
+	for i := 1; i < len(header); i++ {
+		header[i] = cefHeaderEscaper.Replace(header[i])
+	}
+
+	attributes := make([]string, 0, len(row.Keys()))
+	timestamp, pres := getTimestamp(row)
+	if pres {
+		attributes = append(attributes, "devTime="+timestamp)
+	}
+
+	for _, f := range getFields(artifact_config, row) {
+		attributes = append(attributes, f.key+"="+leefValueEscaper.Replace(f.value))
+	}
+
+	return strings.Join(header, "|") + "|" + strings.Join(attributes, "\t")
+}
+
+func eventId(artifact_config *config_proto.SIEMArtifactConfig) string {
+	if artifact_config.EventId != "" {
+		return artifact_config.EventId
+	}
+	return artifact_config.Artifact
+}
+
+// The time the server received the row in epoch milliseconds.
+func getTimestamp(row *ordereddict.Dict) (string, bool) {
+	value, pres := row.Get("_ts")
+	if !pres {
+		return "", false
+	}
+
+	ts, ok := utils.ToInt64(value)
+	if !ok {
+		return "", false
+	}
+
+	return strconv.FormatInt(
+		utils.ParseTimeFromInt64(ts).UnixNano()/1000000, 10), true
+}
+
+// Apply the mappings to the row. Without mappings all the columns
+// are sent.
+func getFields(artifact_config *config_proto.SIEMArtifactConfig,
+	row *ordereddict.Dict) []field {
+
+	result := []field{}
+	if len(artifact_config.Mappings) == 0 {
+		for _, column := range row.Keys() {
+			if column == "_ts" {
+				continue
+			}
+			value, _ := row.Get(column)
+			if utils.IsNil(value) {
+				continue
+			}
+			key := sanitizeKey(column)
+			if key == "" {
+				continue
+			}
+			result = append(result, field{key: key, value: toString(value)})
+		}
+		return result
+	}
+
+	for _, mapping := range artifact_config.Mappings {
+		value, pres := row.Get(mapping.Column)
+		if !pres || utils.IsNil(value) {
+			continue
+		}
+
+		key := sanitizeKey(mapping.Key)
+		if key == "" {
+			continue
+		}
+		result = append(result, field{key: key, value: toString(value)})
+	}
+	return result
+}
+
+// Keys may only contain alphanumeric characters.
+func sanitizeKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') ||
+			(r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, key)
+}
+
+func toString(value interface{}) string {
+	switch t := value.(type) {
+	case string:
+		return t
+
+	case time.Time:
+		return t.UTC().Format(time.RFC3339Nano)
+
+	case int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64, bool:
+		return fmt.Sprintf("%v", t)
+	}
+
+	return json.MustMarshalString(value)
+}
+
+func NewFormatter(config_obj *config_proto.Config) *Formatter {
+	result := &Formatter{
+		vendor:  "Velocidex",
+		product: "Velociraptor",
+		version: constants.VERSION,
+	}
+
+	if config_obj.Siem != nil {
+		if config_obj.Siem.Vendor != "" {
+			result.vendor = config_obj.Siem.Vendor
+		}
+		if config_obj.Siem.Product != "" {
+			result.product = config_obj.Siem.Product
+		}
+	}
+
+	return result
+}
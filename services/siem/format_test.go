@@ -0,0 +1,90 @@
+package siem
+
+import (
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+var formatterTestCases = []struct {
+	name     string
+	config   *config_proto.SIEMArtifactConfig
+	row      *ordereddict.Dict
+	expected string
+}{
+	{"CEF all columns",
+		&config_proto.SIEMArtifactConfig{
+			Artifact: "Windows.Events.ProcessCreation",
+		},
+		ordereddict.NewDict().
+			Set("Pid", 123).
+			Set("CommandLine", `cmd.exe /c "a=b" \x`).
+			Set("Missing", nil).
+			Set("_ts", 1600000000),
+		"CEF:0|Velocidex|Velociraptor|1.0|Windows.Events.ProcessCreation|" +
+			"Windows.Events.ProcessCreation|5|rt=1600000000000 Pid=123 " +
+			`CommandLine=cmd.exe /c "a\=b" \\x`},
+
+	{"CEF mappings and header escaping",
+		&config_proto.SIEMArtifactConfig{
+			Artifact: "Custom.Detection",
+			EventId:  "100",
+			Name:     "Bad|thing",
+			Severity: 12,
+			Mappings: []*config_proto.SIEMFieldMapping{
+				{Column: "Hostname", Key: "dhost"},
+				{Column: "User", Key: "suser"},
+				{Column: "Unknown", Key: "cs1"},
+			},
+		},
+		ordereddict.NewDict().
+			Set("Hostname", "host\nname").
+			Set("User", "admin").
+			Set("Other", "ignored"),
+		`CEF:0|Velocidex|Velociraptor|1.0|100|Bad\|thing|10|` +
+			`dhost=host\nname suser=admin`},
+
+	{"LEEF",
+		&config_proto.SIEMArtifactConfig{
+			Artifact: "Custom.Detection",
+			Format:   "leef",
+			Mappings: []*config_proto.SIEMFieldMapping{
+				{Column: "User", Key: "usrName"},
+				{Column: "Data", Key: "data"},
+			},
+		},
+		ordereddict.NewDict().
+			Set("User", "admin").
+			Set("Data", ordereddict.NewDict().Set("A", "x\ty")).
+			Set("_ts", 1600000000),
+		"LEEF:1.0|Velocidex|Velociraptor|1.0|Custom.Detection|" +
+			"devTime=1600000000000\tusrName=admin\tdata={\"A\":\"x\\\\ty\"}"},
+
+	{"JSON",
+		&config_proto.SIEMArtifactConfig{
+			Artifact: "Custom.Detection",
+			Format:   "json",
+		},
+		ordereddict.NewDict().Set("User", "admin"),
+		`{"User":"admin"}`},
+}
+
+func TestFormatter(t *testing.T) {
+	formatter := &Formatter{
+		vendor:  "Velocidex",
+		product: "Velociraptor",
+		version: "1.0",
+	}
+
+	for _, test_case := range formatterTestCases {
+		result, err := formatter.Format(test_case.config, test_case.row)
+		assert.NoError(t, err, test_case.name)
+		assert.Equal(t, test_case.expected, result, test_case.name)
+	}
+
+	_, err := formatter.Format(&config_proto.SIEMArtifactConfig{
+		Format: "xml"}, ordereddict.NewDict())
+	assert.Error(t, err)
+}
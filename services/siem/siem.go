@@ -0,0 +1,163 @@
+/*
+  The SIEM service forwards the rows of event artifacts to legacy
+  SIEMs which do not understand JSON.
+
+  Each configured artifact is formatted as CEF or LEEF (or JSON) with
+  optional mappings from the row's columns to the standard keys of
+  these formats (e.g. src, dhost, usrName), so the SIEM can ingest
+  them without a custom parser. The formatted events are sent to a
+  syslog server and/or POSTed to a webhook.
+*/
+
+package siem
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
+)
+
+const (
+	// Events queued while the sinks are slow. Beyond this events
+	// are dropped.
+	queueSize     = 10000
+	flushInterval = time.Second
+	maxBatchSize  = 1000
+)
+
+type SIEMService struct {
+	config_obj *config_proto.Config
+	formatter  *Formatter
+	sinks      []Sink
+	queue      chan string
+}
+
+func (self *SIEMService) ProcessRow(ctx context.Context,
+	artifact_config *config_proto.SIEMArtifactConfig,
+	row *ordereddict.Dict) error {
+
+	event, err := self.formatter.Format(artifact_config, row)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case self.queue <- event:
+		return nil
+	default:
+		return errors.New("SIEMService: queue full, dropping event")
+	}
+}
+
+func (self *SIEMService) send(ctx context.Context, batch []string) {
+	for _, sink := range self.sinks {
+		err := sink.Send(ctx, batch)
+		if err != nil {
+			logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+			logger.Error("SIEMService: sending %v events: %v", len(batch), err)
+		}
+	}
+}
+
+func (self *SIEMService) run(ctx context.Context) {
+	defer func() {
+		for _, sink := range self.sinks {
+			sink.Close()
+		}
+	}()
+
+	var batch []string
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+		self.send(ctx, batch)
+		batch = nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			sub_ctx, cancel := context.WithTimeout(
+				context.Background(), 10*time.Second)
+			flush(sub_ctx)
+			cancel()
+			return
+
+		case event := <-self.queue:
+			batch = append(batch, event)
+			if len(batch) >= maxBatchSize {
+				flush(ctx)
+			}
+
+		case <-time.After(flushInterval):
+			flush(ctx)
+		}
+	}
+}
+
+func NewSIEMService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*SIEMService, error) {
+
+	siem_config := config_obj.Siem
+
+	result := &SIEMService{
+		config_obj: config_obj,
+		formatter:  NewFormatter(config_obj),
+		queue:      make(chan string, queueSize),
+	}
+
+	if siem_config.Syslog != nil && siem_config.Syslog.Address != "" {
+		result.sinks = append(result.sinks, newSyslogSink(config_obj))
+	}
+
+	if siem_config.Webhook != nil && siem_config.Webhook.Url != "" {
+		sink, err := newWebhookSink(config_obj)
+		if err != nil {
+			return nil, err
+		}
+		result.sinks = append(result.sinks, sink)
+	}
+
+	if len(result.sinks) == 0 {
+		return nil, errors.New("SIEMService: No syslog or webhook configured")
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> SIEM forwarding service for %v.",
+		services.GetOrgName(config_obj))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		result.run(ctx)
+	}()
+
+	for _, artifact_config := range siem_config.Artifacts {
+		artifact_config := artifact_config
+		if artifact_config.Artifact == "" {
+			continue
+		}
+
+		err := journal.WatchQueueWithCB(ctx, config_obj, wg,
+			artifact_config.Artifact, "SIEMService",
+			func(ctx context.Context, config_obj *config_proto.Config,
+				row *ordereddict.Dict) error {
+				return result.ProcessRow(ctx, artifact_config, row)
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
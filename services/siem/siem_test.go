@@ -0,0 +1,142 @@
+package siem_test
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/siem"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+)
+
+type SIEMTestSuite struct {
+	test_utils.TestSuite
+
+	server *httptest.Server
+	syslog net.PacketConn
+
+	mu       sync.Mutex
+	webhook  []string
+	messages []string
+}
+
+func (self *SIEMTestSuite) SetupTest() {
+	self.webhook = nil
+	self.messages = nil
+
+	self.server = httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			body, _ := io.ReadAll(r.Body)
+
+			self.mu.Lock()
+			defer self.mu.Unlock()
+			self.webhook = append(self.webhook,
+				strings.Split(strings.TrimSpace(string(body)), "\n")...)
+		}))
+
+	var err error
+	self.syslog, err = net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(self.T(), err)
+
+	go func() {
+		buf := make([]byte, 65536)
+		for {
+			n, _, err := self.syslog.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			self.mu.Lock()
+			self.messages = append(self.messages, string(buf[:n]))
+			self.mu.Unlock()
+		}
+	}()
+
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Siem = &config_proto.SIEMConfig{
+		Syslog: &config_proto.SIEMSyslogConfig{
+			Address: self.syslog.LocalAddr().String(),
+		},
+		Webhook: &config_proto.SIEMWebhookConfig{
+			Url:           self.server.URL,
+			Authorization: "Bearer secret",
+		},
+		Artifacts: []*config_proto.SIEMArtifactConfig{{
+			Artifact: "Server.Detections",
+			EventId:  "100",
+			Mappings: []*config_proto.SIEMFieldMapping{{
+				Column: "Hostname",
+				Key:    "dhost",
+			}},
+		}},
+	}
+	self.LoadArtifacts([]string{`
+name: Server.Detections
+type: SERVER_EVENT
+`})
+
+	self.TestSuite.SetupTest()
+}
+
+func (self *SIEMTestSuite) TearDownTest() {
+	self.TestSuite.TearDownTest()
+	self.server.Close()
+	self.syslog.Close()
+}
+
+func (self *SIEMTestSuite) getEvents() ([]string, []string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return append([]string{}, self.webhook...),
+		append([]string{}, self.messages...)
+}
+
+func (self *SIEMTestSuite) TestForwardEvents() {
+	_, err := siem.NewSIEMService(self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	err = journal.PushRowsToArtifact(self.ConfigObj,
+		[]*ordereddict.Dict{
+			ordereddict.NewDict().Set("Hostname", "host1"),
+			ordereddict.NewDict().Set("Hostname", "host2"),
+		}, "Server.Detections", "server", "")
+	assert.NoError(self.T(), err)
+
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		webhook, messages := self.getEvents()
+		return len(webhook) == 2 && len(messages) == 2
+	})
+
+	webhook, messages := self.getEvents()
+	assert.True(self.T(), strings.HasPrefix(webhook[0],
+		"CEF:0|Velocidex|Velociraptor|"))
+	assert.True(self.T(), strings.Contains(webhook[0], "|100|"))
+	assert.True(self.T(), strings.HasSuffix(webhook[0], " dhost=host1"))
+
+	// Syslog messages carry the priority and the same event.
+	assert.True(self.T(), strings.HasPrefix(messages[0], "<134>"))
+	assert.True(self.T(), strings.HasSuffix(messages[1], " dhost=host2\n"))
+}
+
+func TestSIEM(t *testing.T) {
+	suite.Run(t, &SIEMTestSuite{})
+}
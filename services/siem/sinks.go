@@ -0,0 +1,183 @@
+package siem
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/crypto"
+	"www.velocidex.com/golang/velociraptor/vql/networking"
+)
+
+const (
+	// facility local0, severity info
+	syslogPriority = 16*8 + 6
+
+	dialTimeout = 10 * time.Second
+)
+
+// A destination for formatted events.
+type Sink interface {
+	Send(ctx context.Context, events []string) error
+	Close()
+}
+
+// Sends each event as an RFC 3164 syslog message which is what most
+// CEF and LEEF collectors expect.
+type syslogSink struct {
+	mu sync.Mutex
+
+	config_obj *config_proto.Config
+	conn       net.Conn
+	hostname   string
+}
+
+func (self *syslogSink) connect() (net.Conn, error) {
+	syslog_config := self.config_obj.Siem.Syslog
+	switch strings.ToLower(syslog_config.Protocol) {
+	case "", "udp":
+		return net.DialTimeout("udp", syslog_config.Address, dialTimeout)
+
+	case "tcp":
+		return net.DialTimeout("tcp", syslog_config.Address, dialTimeout)
+
+	case "tls":
+		CA_Pool := x509.NewCertPool()
+		crypto.AddPublicRoots(CA_Pool)
+		if syslog_config.RootCa != "" &&
+			!CA_Pool.AppendCertsFromPEM([]byte(syslog_config.RootCa)) {
+			return nil, errors.New("Unable to parse root CA")
+		}
+
+		return tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout},
+			"tcp", syslog_config.Address, &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				RootCAs:    CA_Pool,
+			})
+	}
+
+	return nil, fmt.Errorf("Unsupported syslog protocol %v",
+		syslog_config.Protocol)
+}
+
+func (self *syslogSink) Send(ctx context.Context, events []string) error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	for _, event := range events {
+		if self.conn == nil {
+			conn, err := self.connect()
+			if err != nil {
+				return err
+			}
+			self.conn = conn
+		}
+
+		// Stream protocols are newline framed.
+		message := fmt.Sprintf("<%d>%s %s %s\n", syslogPriority,
+			time.Now().UTC().Format(time.Stamp), self.hostname, event)
+
+		_ = self.conn.SetWriteDeadline(time.Now().Add(dialTimeout))
+		_, err := self.conn.Write([]byte(message))
+		if err != nil {
+			// Reconnect next time.
+			self.conn.Close()
+			self.conn = nil
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *syslogSink) Close() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.conn != nil {
+		self.conn.Close()
+		self.conn = nil
+	}
+}
+
+func newSyslogSink(config_obj *config_proto.Config) *syslogSink {
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "-"
+	}
+
+	return &syslogSink{
+		config_obj: config_obj,
+		hostname:   hostname,
+	}
+}
+
+// POSTs the events to a webhook, one per line.
+type webhookSink struct {
+	config_obj *config_proto.Config
+	client     *http.Client
+}
+
+func (self *webhookSink) Send(ctx context.Context, events []string) error {
+	webhook_config := self.config_obj.Siem.Webhook
+
+	batch_size := int(webhook_config.BatchSize)
+	if batch_size == 0 {
+		batch_size = 100
+	}
+
+	for len(events) > 0 {
+		batch := events
+		if len(batch) > batch_size {
+			batch = events[:batch_size]
+		}
+		events = events[len(batch):]
+
+		body := strings.Join(batch, "\n") + "\n"
+		req, err := http.NewRequestWithContext(ctx, "POST",
+			webhook_config.Url, bytes.NewReader([]byte(body)))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "text/plain")
+		if webhook_config.Authorization != "" {
+			req.Header.Set("Authorization", webhook_config.Authorization)
+		}
+
+		resp, err := self.client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("Webhook returned %v", resp.Status)
+		}
+	}
+
+	return nil
+}
+
+func (self *webhookSink) Close() {}
+
+func newWebhookSink(config_obj *config_proto.Config) (*webhookSink, error) {
+	client, err := networking.GetDefaultHTTPClient(config_obj.Client, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookSink{
+		config_obj: config_obj,
+		client:     client,
+	}, nil
+}
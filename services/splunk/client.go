@@ -0,0 +1,119 @@
+package splunk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/vql/networking"
+)
+
+const (
+	maxResponseSize = 1024 * 1024
+
+	defaultEventPath = "/services/collector/event"
+)
+
+// A minimal client for the Splunk HTTP Event Collector.
+type HECClient struct {
+	config_obj *config_proto.Config
+	client     *http.Client
+	url        string
+}
+
+// Send a batch of events in a single request. HEC accepts the events
+// concatenated together.
+func (self *HECClient) Send(ctx context.Context, events []*Event) error {
+	splunk_config := self.config_obj.Splunk
+
+	body := &bytes.Buffer{}
+	var writer io.Writer = body
+
+	var gzip_writer *gzip.Writer
+	if !splunk_config.DisableCompression {
+		gzip_writer = gzip.NewWriter(body)
+		writer = gzip_writer
+	}
+
+	for _, event := range events {
+		serialized, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		_, err = writer.Write(serialized)
+		if err != nil {
+			return err
+		}
+		_, _ = writer.Write([]byte{'\n'})
+	}
+
+	if gzip_writer != nil {
+		err := gzip_writer.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", self.url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Splunk "+splunk_config.Token)
+	req.Header.Set("Content-Type", "application/json")
+	if gzip_writer != nil {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	resp, err := self.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	response, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Splunk HEC returned %v: %v",
+			resp.Status, string(response))
+	}
+
+	return nil
+}
+
+func NewHECClient(config_obj *config_proto.Config) (*HECClient, error) {
+	splunk_config := config_obj.Splunk
+	if splunk_config == nil || splunk_config.Url == "" {
+		return nil, errors.New("Splunk is not configured")
+	}
+
+	// Allow the URL to be just the server.
+	parsed, err := url.Parse(splunk_config.Url)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Path == "" || parsed.Path == "/" {
+		parsed.Path = defaultEventPath
+	}
+
+	client, err := networking.GetDefaultHTTPClient(
+		config_obj.Client, splunk_config.RootCa)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HECClient{
+		config_obj: config_obj,
+		client:     client,
+		url:        parsed.String(),
+	}, nil
+}
@@ -0,0 +1,288 @@
+/*
+  The Splunk service forwards the rows of event artifacts to a Splunk
+  HTTP Event Collector (HEC).
+
+  Each configured artifact (client or server monitoring) is watched
+  and its rows are queued. The queue is sent in batches, either when
+  it reaches the batch size or after the batch delay, as gzip
+  compressed HEC events. Each artifact may be mapped to its own
+  index, source and sourcetype.
+
+  Client events are sent with the client's hostname as the host of
+  the event so they can be searched in Splunk without joining on the
+  client id.
+*/
+
+package splunk
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	// Give up on a batch after this many attempts.
+	maxAttempts = 3
+)
+
+// A HEC event as described in
+// https://docs.splunk.com/Documentation/Splunk/latest/Data/FormateventsforHTTPEventCollector
+type Event struct {
+	Time       float64           `json:"time"`
+	Host       string            `json:"host,omitempty"`
+	Source     string            `json:"source,omitempty"`
+	Sourcetype string            `json:"sourcetype,omitempty"`
+	Index      string            `json:"index,omitempty"`
+	Event      *ordereddict.Dict `json:"event"`
+}
+
+type SplunkService struct {
+	mu sync.Mutex
+
+	config_obj *config_proto.Config
+	client     *HECClient
+	queue      []*Event
+
+	// Signalled when the queue reaches the batch size.
+	flush_chan chan bool
+
+	batch_size  int
+	batch_delay time.Duration
+
+	// The server's hostname for server events.
+	hostname string
+}
+
+// Build the HEC event for a row of an event artifact.
+func (self *SplunkService) eventFromRow(
+	ctx context.Context,
+	artifact_config *config_proto.SplunkArtifactConfig,
+	row *ordereddict.Dict) *Event {
+
+	splunk_config := self.config_obj.Splunk
+
+	result := &Event{
+		Host:       self.hostname,
+		Source:     artifact_config.Source,
+		Sourcetype: artifact_config.Sourcetype,
+		Index:      artifact_config.Index,
+		Event:      row,
+	}
+
+	if result.Source == "" {
+		result.Source = artifact_config.Artifact
+	}
+
+	if result.Sourcetype == "" {
+		result.Sourcetype = splunk_config.Sourcetype
+	}
+
+	if result.Sourcetype == "" {
+		result.Sourcetype = "vql"
+	}
+
+	if result.Index == "" {
+		result.Index = splunk_config.Index
+	}
+
+	client_id, _ := row.GetString("ClientId")
+	if client_id != "" {
+		hostname := services.GetHostname(ctx, self.config_obj, client_id)
+		if hostname != "" {
+			result.Host = hostname
+		}
+	}
+
+	timestamp_field := artifact_config.TimestampField
+	if timestamp_field == "" {
+		timestamp_field = "_ts"
+	}
+
+	timestamp := utils.GetTime().Now()
+	value, pres := row.Get(timestamp_field)
+	if pres {
+		parsed, ok := parseTime(value)
+		if ok {
+			timestamp = parsed
+		}
+	}
+	result.Time = float64(timestamp.UnixNano()/1000000) / 1000
+
+	return result
+}
+
+func parseTime(value interface{}) (time.Time, bool) {
+	switch t := value.(type) {
+	case time.Time:
+		return t, true
+
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, t)
+		return parsed, err == nil
+	}
+
+	number, ok := utils.ToInt64(value)
+	if !ok {
+		return time.Time{}, false
+	}
+	return utils.ParseTimeFromInt64(number), true
+}
+
+func (self *SplunkService) ProcessRow(ctx context.Context,
+	artifact_config *config_proto.SplunkArtifactConfig,
+	row *ordereddict.Dict) error {
+
+	event := self.eventFromRow(ctx, artifact_config, row)
+
+	self.mu.Lock()
+	self.queue = append(self.queue, event)
+	full := len(self.queue) >= self.batch_size
+	self.mu.Unlock()
+
+	if full {
+		select {
+		case self.flush_chan <- true:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Send all the queued events.
+func (self *SplunkService) Flush(ctx context.Context) error {
+	self.mu.Lock()
+	queue := self.queue
+	self.queue = nil
+	self.mu.Unlock()
+
+	for len(queue) > 0 {
+		batch := queue
+		if len(batch) > self.batch_size {
+			batch = queue[:self.batch_size]
+		}
+		queue = queue[len(batch):]
+
+		var err error
+		for i := 0; i < maxAttempts; i++ {
+			err = self.client.Send(ctx, batch)
+			if err == nil {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(time.Duration(i+1) * time.Second):
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *SplunkService) flushWithLog(ctx context.Context) {
+	err := self.Flush(ctx)
+	if err != nil {
+		logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+		logger.Error("SplunkService: Dropped events: %v", err)
+	}
+}
+
+func (self *SplunkService) Start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				// Try to send the remaining events on exit.
+				sub_ctx, cancel := context.WithTimeout(
+					context.Background(), 10*time.Second)
+				self.flushWithLog(sub_ctx)
+				cancel()
+				return
+
+			case <-self.flush_chan:
+				self.flushWithLog(ctx)
+
+			case <-time.After(self.batch_delay):
+				self.flushWithLog(ctx)
+			}
+		}
+	}()
+}
+
+func NewSplunkService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*SplunkService, error) {
+
+	splunk_config := config_obj.Splunk
+
+	client, err := NewHECClient(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	batch_size := int(splunk_config.BatchSize)
+	if batch_size == 0 {
+		batch_size = 1000
+	}
+
+	batch_delay := time.Duration(splunk_config.BatchDelay) * time.Second
+	if batch_delay == 0 {
+		batch_delay = 2 * time.Second
+	}
+
+	hostname, _ := os.Hostname()
+
+	result := &SplunkService{
+		config_obj:  config_obj,
+		client:      client,
+		flush_chan:  make(chan bool, 1),
+		batch_size:  batch_size,
+		batch_delay: batch_delay,
+		hostname:    hostname,
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> Splunk service for %v.",
+		services.GetOrgName(config_obj))
+
+	result.Start(ctx, wg)
+
+	for _, artifact_config := range splunk_config.Artifacts {
+		artifact_config := artifact_config
+		if strings.TrimSpace(artifact_config.Artifact) == "" {
+			continue
+		}
+
+		err := journal.WatchQueueWithCB(ctx, config_obj, wg,
+			artifact_config.Artifact, "SplunkService",
+			func(ctx context.Context, config_obj *config_proto.Config,
+				row *ordereddict.Dict) error {
+				return result.ProcessRow(ctx, artifact_config, row)
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
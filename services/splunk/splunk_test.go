@@ -0,0 +1,169 @@
+package splunk_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/splunk"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+)
+
+type request struct {
+	path   string
+	events []*ordereddict.Dict
+}
+
+type SplunkTestSuite struct {
+	test_utils.TestSuite
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	requests []request
+}
+
+func (self *SplunkTestSuite) SetupTest() {
+	self.requests = nil
+
+	self.server = httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Splunk secret" ||
+				r.Header.Get("Content-Encoding") != "gzip" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			reader, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			req := request{path: r.URL.Path}
+			scanner := bufio.NewScanner(reader)
+			for scanner.Scan() {
+				event := ordereddict.NewDict()
+				_ = json.Unmarshal(scanner.Bytes(), event)
+				req.events = append(req.events, event)
+			}
+
+			self.mu.Lock()
+			defer self.mu.Unlock()
+
+			self.requests = append(self.requests, req)
+			_, _ = io.WriteString(w, `{"text":"Success","code":0}`)
+		}))
+
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Splunk = &config_proto.SplunkConfig{
+		Url:        self.server.URL,
+		Token:      "secret",
+		Index:      "velociraptor",
+		BatchSize:  2,
+		BatchDelay: 1,
+		Artifacts: []*config_proto.SplunkArtifactConfig{{
+			Artifact:   "Server.Detections",
+			Index:      "detections",
+			Sourcetype: "velociraptor:detection",
+		}, {
+			Artifact:       "Server.Events",
+			TimestampField: "EventTime",
+		}},
+	}
+	self.LoadArtifacts([]string{`
+name: Server.Detections
+type: SERVER_EVENT
+`, `
+name: Server.Events
+type: SERVER_EVENT
+`})
+
+	self.TestSuite.SetupTest()
+}
+
+func (self *SplunkTestSuite) TearDownTest() {
+	self.TestSuite.TearDownTest()
+	self.server.Close()
+}
+
+func (self *SplunkTestSuite) getRequests() []request {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return append([]request{}, self.requests...)
+}
+
+func (self *SplunkTestSuite) TestForwardEvents() {
+	_, err := splunk.NewSplunkService(self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	// Two rows fill a batch and are sent together.
+	err = journal.PushRowsToArtifact(self.ConfigObj,
+		[]*ordereddict.Dict{
+			ordereddict.NewDict().Set("Hash", "A"),
+			ordereddict.NewDict().Set("Hash", "B"),
+		}, "Server.Detections", "server", "")
+	assert.NoError(self.T(), err)
+
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		return len(self.getRequests()) == 1
+	})
+
+	req := self.getRequests()[0]
+	assert.Equal(self.T(), "/services/collector/event", req.path)
+	assert.Equal(self.T(), 2, len(req.events))
+
+	event := req.events[0]
+	index, _ := event.GetString("index")
+	assert.Equal(self.T(), "detections", index)
+
+	sourcetype, _ := event.GetString("sourcetype")
+	assert.Equal(self.T(), "velociraptor:detection", sourcetype)
+
+	source, _ := event.GetString("source")
+	assert.Equal(self.T(), "Server.Detections", source)
+
+	row, _ := event.Get("event")
+	assert.Contains(self.T(), json.MustMarshalString(row), `"Hash":"A"`)
+
+	// A single row is sent after the batch delay with the default
+	// index and the configured timestamp.
+	err = journal.PushRowsToArtifact(self.ConfigObj,
+		[]*ordereddict.Dict{
+			ordereddict.NewDict().Set("EventTime", 1600000000),
+		}, "Server.Events", "server", "")
+	assert.NoError(self.T(), err)
+
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		return len(self.getRequests()) == 2
+	})
+
+	event = self.getRequests()[1].events[0]
+	index, _ = event.GetString("index")
+	assert.Equal(self.T(), "velociraptor", index)
+
+	sourcetype, _ = event.GetString("sourcetype")
+	assert.Equal(self.T(), "vql", sourcetype)
+
+	timestamp, _ := event.Get("time")
+	assert.Equal(self.T(), "1600000000", json.MustMarshalString(timestamp))
+}
+
+func TestSplunk(t *testing.T) {
+	suite.Run(t, &SplunkTestSuite{})
+}
@@ -0,0 +1,240 @@
+package sql_endpoint
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Postgres frontend/backend protocol version 3.0 messages. See
+// https://www.postgresql.org/docs/current/protocol-message-formats.html
+const (
+	PROTOCOL_VERSION = 196608
+	SSL_REQUEST      = 80877103
+	GSSENC_REQUEST   = 80877104
+	CANCEL_REQUEST   = 80877102
+
+	// Maximum size of a message from the client.
+	MAX_MESSAGE_SIZE = 1024 * 1024
+
+	// All values are sent as text.
+	OID_TEXT = 25
+)
+
+var (
+	// Parameter types which are bound as numbers.
+	numeric_oids = map[uint32]bool{
+		20: true, 21: true, 23: true, 700: true, 701: true, 1700: true,
+	}
+)
+
+// Reads the messages sent by the client.
+type reader struct {
+	r *bufio.Reader
+}
+
+// Read the startup packet which has no type byte.
+func (self *reader) readStartup() (uint32, []byte, error) {
+	body, err := self.readBody()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(body) < 4 {
+		return 0, nil, errors.New("startup packet too short")
+	}
+	return binary.BigEndian.Uint32(body), body[4:], nil
+}
+
+func (self *reader) readMessage() (byte, []byte, error) {
+	message_type, err := self.r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	body, err := self.readBody()
+	return message_type, body, err
+}
+
+func (self *reader) readBody() ([]byte, error) {
+	header := make([]byte, 4)
+	_, err := io.ReadFull(self.r, header)
+	if err != nil {
+		return nil, err
+	}
+
+	length := int(binary.BigEndian.Uint32(header)) - 4
+	if length < 0 || length > MAX_MESSAGE_SIZE {
+		return nil, errors.New("invalid message length")
+	}
+
+	body := make([]byte, length)
+	_, err = io.ReadFull(self.r, body)
+	return body, err
+}
+
+// Decodes the fields of a message body.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (self *decoder) string() string {
+	idx := bytes.IndexByte(self.buf, 0)
+	if idx < 0 {
+		self.err = errors.New("unterminated string in message")
+		return ""
+	}
+	result := string(self.buf[:idx])
+	self.buf = self.buf[idx+1:]
+	return result
+}
+
+func (self *decoder) byte() byte {
+	if len(self.buf) < 1 {
+		self.err = errors.New("message too short")
+		return 0
+	}
+	result := self.buf[0]
+	self.buf = self.buf[1:]
+	return result
+}
+
+func (self *decoder) int16() int {
+	if len(self.buf) < 2 {
+		self.err = errors.New("message too short")
+		return 0
+	}
+	result := int16(binary.BigEndian.Uint16(self.buf))
+	self.buf = self.buf[2:]
+	return int(result)
+}
+
+func (self *decoder) int32() int {
+	if len(self.buf) < 4 {
+		self.err = errors.New("message too short")
+		return 0
+	}
+	result := int32(binary.BigEndian.Uint32(self.buf))
+	self.buf = self.buf[4:]
+	return int(result)
+}
+
+// A length prefixed value. Length -1 is NULL.
+func (self *decoder) value() *string {
+	length := self.int32()
+	if length < 0 || self.err != nil {
+		return nil
+	}
+
+	if len(self.buf) < length {
+		self.err = errors.New("message too short")
+		return nil
+	}
+	result := string(self.buf[:length])
+	self.buf = self.buf[length:]
+	return &result
+}
+
+// Builds a message to the client.
+type message struct {
+	buf bytes.Buffer
+}
+
+func newMessage(message_type byte) *message {
+	result := &message{}
+	result.buf.WriteByte(message_type)
+
+	// Length placeholder
+	result.buf.Write([]byte{0, 0, 0, 0})
+	return result
+}
+
+func (self *message) string(value string) *message {
+	self.buf.WriteString(value)
+	self.buf.WriteByte(0)
+	return self
+}
+
+func (self *message) byte(value byte) *message {
+	self.buf.WriteByte(value)
+	return self
+}
+
+func (self *message) int16(value int) *message {
+	_ = binary.Write(&self.buf, binary.BigEndian, int16(value))
+	return self
+}
+
+func (self *message) int32(value int) *message {
+	_ = binary.Write(&self.buf, binary.BigEndian, int32(value))
+	return self
+}
+
+func (self *message) value(value *string) *message {
+	if value == nil {
+		return self.int32(-1)
+	}
+	self.int32(len(*value))
+	self.buf.WriteString(*value)
+	return self
+}
+
+func (self *message) bytes() []byte {
+	result := self.buf.Bytes()
+	binary.BigEndian.PutUint32(result[1:], uint32(len(result)-1))
+	return result
+}
+
+func authenticationMessage(code int) *message {
+	return newMessage('R').int32(code)
+}
+
+func parameterStatusMessage(name, value string) *message {
+	return newMessage('S').string(name).string(value)
+}
+
+func readyForQueryMessage(status byte) *message {
+	return newMessage('Z').byte(status)
+}
+
+func errorMessage(code, text string) *message {
+	return newMessage('E').
+		byte('S').string("ERROR").
+		byte('V').string("ERROR").
+		byte('C').string(code).
+		byte('M').string(text).
+		byte(0)
+}
+
+func rowDescriptionMessage(columns []string) *message {
+	result := newMessage('T').int16(len(columns))
+	for _, column := range columns {
+		result.string(column).
+			int32(0).        // Table OID
+			int16(0).        // Column number
+			int32(OID_TEXT). // Type OID
+			int16(-1).       // Type size
+			int32(-1).       // Type modifier
+			int16(0)         // Text format
+	}
+	return result
+}
+
+func dataRowMessage(values []*string) *message {
+	result := newMessage('D').int16(len(values))
+	for _, value := range values {
+		result.value(value)
+	}
+	return result
+}
+
+func parameterDescriptionMessage(oids []uint32) *message {
+	result := newMessage('t').int16(len(oids))
+	for _, oid := range oids {
+		result.int32(int(oid))
+	}
+	return result
+}
@@ -0,0 +1,906 @@
+/*
+  A read-only SQL endpoint speaking the Postgres wire protocol.
+
+  BI tools and analysts' SQL tooling can connect to the endpoint with
+  a standard Postgres driver and query the collected data directly.
+  Users log in with their Velociraptor username and password (basic
+  authentication users) and the database name selects the org.
+
+  SQL queries are translated to VQL and run with the user's
+  permissions, restricted to READ_RESULTS so nothing can be modified
+  through the endpoint. Virtual tables map onto the VQL plugins which
+  read the result sets:
+
+  - clients, hunts and flows (WHERE client_id = '...')
+  - hunt_results."<Artifact>" (WHERE hunt_id = '...')
+  - flow_results."<Artifact>" (WHERE client_id = '...' AND flow_id = '...')
+  - events."<Artifact>" (WHERE client_id = '...' - use 'server' for
+    server events). Conditions on _ts limit the days read.
+
+  Only a subset of SQL is supported: a single table with WHERE, GROUP
+  BY, ORDER BY and LIMIT clauses. All values are returned as text.
+
+  The endpoint runs on the master node of the root org.
+*/
+
+package sql_endpoint
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/users"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+type SQLEndpoint struct {
+	config_obj *config_proto.Config
+	tls_config *tls.Config
+	timeout    time.Duration
+}
+
+func (self *SQLEndpoint) handleConnection(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	session := &session{
+		endpoint:   self,
+		conn:       conn,
+		reader:     &reader{r: bufio.NewReader(conn)},
+		writer:     bufio.NewWriter(conn),
+		statements: make(map[string]*preparedStatement),
+		portals:    make(map[string]*portal),
+	}
+	defer session.closePortals()
+
+	err := session.serve(ctx)
+	if err != nil {
+		var sql_err *sqlError
+		if errors.As(err, &sql_err) {
+			session.send(errorMessage(sql_err.Code, sql_err.Message))
+			_ = session.writer.Flush()
+		}
+
+		logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+		logger.Debug("SQL endpoint: %v: %v", conn.RemoteAddr(), err)
+	}
+}
+
+type preparedStatement struct {
+	tokens     []token
+	param_oids []uint32
+}
+
+// A statement being executed. Rows are read as the client asks for
+// them.
+type portal struct {
+	stmt *statement
+
+	started bool
+	columns []string
+	rows    <-chan *ordereddict.Dict
+	next    *ordereddict.Dict
+	count   int
+	logs    *logCollector
+
+	query_ctx context.Context
+	cancel    func()
+}
+
+type session struct {
+	endpoint *SQLEndpoint
+	conn     net.Conn
+	reader   *reader
+	writer   *bufio.Writer
+
+	principal  string
+	org_config *config_proto.Config
+	params     map[string]string
+
+	statements map[string]*preparedStatement
+	portals    map[string]*portal
+}
+
+func (self *session) send(message *message) {
+	_, _ = self.writer.Write(message.bytes())
+}
+
+func (self *session) serve(ctx context.Context) error {
+	startup, err := self.startup()
+	if err != nil {
+		return err
+	}
+
+	err = self.authenticate(ctx, startup)
+	if err != nil {
+		return err
+	}
+
+	// Errors in the extended query protocol skip messages until the
+	// next Sync.
+	skip_to_sync := false
+
+	for {
+		message_type, body, err := self.reader.readMessage()
+		if err != nil {
+			return err
+		}
+
+		if message_type == 'X' {
+			return nil
+		}
+
+		if skip_to_sync && message_type != 'S' {
+			continue
+		}
+
+		err = self.handleMessage(ctx, message_type, body)
+		if err != nil {
+			var sql_err *sqlError
+			if !errors.As(err, &sql_err) {
+				return err
+			}
+
+			self.send(errorMessage(sql_err.Code, sql_err.Message))
+			switch message_type {
+			case 'Q':
+				self.send(readyForQueryMessage('I'))
+			default:
+				skip_to_sync = true
+			}
+		}
+
+		if message_type == 'S' {
+			skip_to_sync = false
+		}
+
+		switch message_type {
+		case 'Q', 'S', 'H':
+			err = self.writer.Flush()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Negotiate TLS and read the startup parameters.
+func (self *session) startup() (map[string]string, error) {
+	is_tls := false
+
+	for {
+		code, body, err := self.reader.readStartup()
+		if err != nil {
+			return nil, err
+		}
+
+		switch code {
+		case SSL_REQUEST:
+			if self.endpoint.tls_config == nil || is_tls {
+				_, err = self.conn.Write([]byte{'N'})
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			_, err = self.conn.Write([]byte{'S'})
+			if err != nil {
+				return nil, err
+			}
+
+			tls_conn := tls.Server(self.conn, self.endpoint.tls_config)
+			err = tls_conn.Handshake()
+			if err != nil {
+				return nil, err
+			}
+
+			self.conn = tls_conn
+			self.reader = &reader{r: bufio.NewReader(tls_conn)}
+			self.writer = bufio.NewWriter(tls_conn)
+			is_tls = true
+
+		case GSSENC_REQUEST:
+			_, err = self.conn.Write([]byte{'N'})
+			if err != nil {
+				return nil, err
+			}
+
+		case PROTOCOL_VERSION:
+			if !is_tls && !self.endpoint.config_obj.SqlEndpoint.AllowPlaintext {
+				return nil, newError(ERR_NO_ACCESS,
+					"the SQL endpoint requires TLS (use sslmode=require)")
+			}
+
+			params := make(map[string]string)
+			decoder := &decoder{buf: body}
+			for decoder.err == nil {
+				key := decoder.string()
+				if key == "" {
+					break
+				}
+				params[key] = decoder.string()
+			}
+			return params, decoder.err
+
+		case CANCEL_REQUEST:
+			return nil, errors.New("cancel requests are not supported")
+
+		default:
+			return nil, newError(ERR_PROTOCOL,
+				"unsupported frontend protocol %v", code)
+		}
+	}
+}
+
+func (self *session) authenticate(
+	ctx context.Context, startup map[string]string) error {
+	config_obj := self.endpoint.config_obj
+	username := startup["user"]
+	remote := self.conn.RemoteAddr().String()
+
+	self.send(authenticationMessage(3)) // Cleartext password
+	err := self.writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	message_type, body, err := self.reader.readMessage()
+	if err != nil {
+		return err
+	}
+	if message_type != 'p' {
+		return newError(ERR_PROTOCOL, "expected password message")
+	}
+	password := (&decoder{buf: body}).string()
+
+	users_manager := services.GetUserManager()
+	user_record, err := users_manager.GetUserWithHashes(ctx, username)
+	if err != nil || user_record.Name != username || user_record.Locked ||
+		!users.VerifyPassword(user_record, password) {
+		logging.LogAudit(config_obj, username, "SQL endpoint login failed",
+			logrus.Fields{
+				"remote": remote,
+			})
+		return newError(ERR_AUTH_FAILED,
+			"password authentication failed for user \"%v\"", username)
+	}
+
+	// The database selects the org. Clients default the database to
+	// the username.
+	org_id := startup["database"]
+	if org_id == username || org_id == "velociraptor" {
+		org_id = ""
+	}
+
+	org_manager, err := services.GetOrgManager()
+	if err != nil {
+		return err
+	}
+
+	org_config, err := org_manager.GetOrgConfig(org_id)
+	if err != nil {
+		return newError(ERR_NO_ACCESS,
+			"database \"%v\" does not exist", startup["database"])
+	}
+
+	ok, err := services.CheckAccess(org_config, username, acls.READ_RESULTS)
+	if err != nil || !ok {
+		logging.LogAudit(config_obj, username, "SQL endpoint access denied",
+			logrus.Fields{
+				"remote": remote,
+				"org_id": org_config.OrgId,
+			})
+		return newError(ERR_NO_ACCESS,
+			"user \"%v\" is not allowed to read results in \"%v\"",
+			username, startup["database"])
+	}
+
+	self.principal = username
+	self.org_config = org_config
+	self.params = map[string]string{
+		"server_version":              "14.0",
+		"server_encoding":             "UTF8",
+		"client_encoding":             "UTF8",
+		"datestyle":                   "ISO, MDY",
+		"timezone":                    "UTC",
+		"integer_datetimes":           "on",
+		"standard_conforming_strings": "on",
+		"transaction_read_only":       "on",
+		"application_name":            startup["application_name"],
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.APICmponent)
+	logger.WithFields(logrus.Fields{
+		"user":   username,
+		"org":    org_config.OrgId,
+		"remote": remote,
+	}).Info("SQL endpoint login")
+
+	self.send(authenticationMessage(0))
+	for _, name := range []string{"server_version", "server_encoding",
+		"client_encoding", "DateStyle", "TimeZone", "integer_datetimes",
+		"standard_conforming_strings", "application_name"} {
+		self.send(parameterStatusMessage(name, self.params[strings.ToLower(name)]))
+	}
+
+	// We do not support cancel requests so the key is not recorded.
+	key := make([]byte, 8)
+	_, _ = rand.Read(key)
+	self.send(newMessage('K').
+		int32(int(binary.BigEndian.Uint32(key)) & 0x7fffffff).
+		int32(int(binary.BigEndian.Uint32(key[4:])) & 0x7fffffff))
+	self.send(readyForQueryMessage('I'))
+
+	return self.writer.Flush()
+}
+
+func (self *session) handleMessage(
+	ctx context.Context, message_type byte, body []byte) error {
+	decoder := &decoder{buf: body}
+
+	switch message_type {
+	case 'Q':
+		return self.simpleQuery(ctx, decoder.string())
+
+	case 'P':
+		name := decoder.string()
+		query := decoder.string()
+		oids := make([]uint32, decoder.int16())
+		for i := range oids {
+			oids[i] = uint32(decoder.int32())
+		}
+		if decoder.err != nil {
+			return newError(ERR_PROTOCOL, "%v", decoder.err)
+		}
+
+		tokens, err := tokenize(query)
+		if err != nil {
+			return err
+		}
+
+		statements := splitStatements(tokens)
+		if len(statements) > 1 {
+			return newError(ERR_SYNTAX,
+				"cannot insert multiple commands into a prepared statement")
+		}
+		if len(statements) == 1 {
+			tokens = statements[0]
+		}
+
+		self.statements[name] = &preparedStatement{
+			tokens: tokens, param_oids: oids}
+		self.send(newMessage('1'))
+
+	case 'B':
+		return self.bind(decoder)
+
+	case 'D':
+		kind := decoder.byte()
+		name := decoder.string()
+		if kind == 'S' {
+			return self.describeStatement(name)
+		}
+		return self.describePortal(ctx, name)
+
+	case 'E':
+		name := decoder.string()
+		max_rows := decoder.int32()
+
+		p, pres := self.portals[name]
+		if !pres {
+			return newError(ERR_PROTOCOL, "portal \"%v\" does not exist", name)
+		}
+		return self.execute(ctx, p, max_rows)
+
+	case 'S':
+		self.closePortal("")
+		self.send(readyForQueryMessage('I'))
+
+	case 'C':
+		kind := decoder.byte()
+		name := decoder.string()
+		if kind == 'S' {
+			delete(self.statements, name)
+		} else {
+			self.closePortal(name)
+		}
+		self.send(newMessage('3'))
+
+	case 'H':
+
+	default:
+		return newError(ERR_PROTOCOL, "unsupported message type %c", message_type)
+	}
+
+	return nil
+}
+
+func (self *session) simpleQuery(ctx context.Context, query string) error {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return err
+	}
+
+	statements := splitStatements(tokens)
+	if len(statements) == 0 {
+		self.send(newMessage('I'))
+	}
+
+	for _, tokens := range statements {
+		if countParams(tokens) > 0 {
+			return newError(ERR_UNDEFINED_PARAM, "there is no parameter $1")
+		}
+
+		stmt, err := translate(tokens)
+		if err != nil {
+			return err
+		}
+
+		p := &portal{stmt: stmt}
+		err = self.start(ctx, p)
+		if err == nil {
+			if p.rows != nil {
+				self.send(rowDescriptionMessage(p.columns))
+			}
+			err = self.execute(ctx, p, 0)
+		}
+		p.close()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	self.send(readyForQueryMessage('I'))
+	return nil
+}
+
+func (self *session) bind(decoder *decoder) error {
+	portal_name := decoder.string()
+	name := decoder.string()
+
+	formats := make([]int, decoder.int16())
+	for i := range formats {
+		formats[i] = decoder.int16()
+	}
+
+	params := make([]*string, decoder.int16())
+	for i := range params {
+		params[i] = decoder.value()
+	}
+
+	result_formats := make([]int, decoder.int16())
+	for i := range result_formats {
+		result_formats[i] = decoder.int16()
+	}
+
+	if decoder.err != nil {
+		return newError(ERR_PROTOCOL, "%v", decoder.err)
+	}
+
+	for _, format := range append(formats, result_formats...) {
+		if format != 0 {
+			return newError(ERR_NOT_SUPPORTED, "binary format is not supported")
+		}
+	}
+
+	prepared, pres := self.statements[name]
+	if !pres {
+		return newError(ERR_PROTOCOL,
+			"prepared statement \"%v\" does not exist", name)
+	}
+
+	numeric := make([]bool, len(prepared.param_oids))
+	for i, oid := range prepared.param_oids {
+		numeric[i] = numeric_oids[oid]
+	}
+
+	tokens, err := bindParams(prepared.tokens, params, numeric)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := translate(tokens)
+	if err != nil {
+		return err
+	}
+
+	self.closePortal(portal_name)
+	self.portals[portal_name] = &portal{stmt: stmt}
+	self.send(newMessage('2'))
+
+	return nil
+}
+
+// Describe a prepared statement before its parameters are bound. The
+// columns are only known if the select list names them all.
+func (self *session) describeStatement(name string) error {
+	prepared, pres := self.statements[name]
+	if !pres {
+		return newError(ERR_PROTOCOL,
+			"prepared statement \"%v\" does not exist", name)
+	}
+
+	oids := make([]uint32, countParams(prepared.tokens))
+	for i := range oids {
+		oids[i] = OID_TEXT
+		if i < len(prepared.param_oids) && prepared.param_oids[i] != 0 {
+			oids[i] = prepared.param_oids[i]
+		}
+	}
+	self.send(parameterDescriptionMessage(oids))
+
+	tokens, err := bindParams(prepared.tokens, make([]*string, len(oids)), nil)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := translate(tokens)
+	switch {
+	case err == nil && stmt.Show != "":
+		self.send(rowDescriptionMessage([]string{stmt.Show}))
+	case err == nil && stmt.Columns != nil:
+		self.send(rowDescriptionMessage(stmt.Columns))
+	default:
+		self.send(newMessage('n'))
+	}
+
+	return nil
+}
+
+// Describing a portal starts the query so the columns can be taken
+// from the first row.
+func (self *session) describePortal(ctx context.Context, name string) error {
+	p, pres := self.portals[name]
+	if !pres {
+		return newError(ERR_PROTOCOL, "portal \"%v\" does not exist", name)
+	}
+
+	err := self.start(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	if p.rows == nil {
+		self.send(newMessage('n'))
+	} else {
+		self.send(rowDescriptionMessage(p.columns))
+	}
+	return nil
+}
+
+func (self *session) start(ctx context.Context, p *portal) error {
+	if p.started {
+		return nil
+	}
+	p.started = true
+
+	switch {
+	case p.stmt.Show != "":
+		value, pres := self.params[p.stmt.Show]
+		if !pres {
+			return newError(ERR_UNDEFINED_PARAM,
+				"unrecognized configuration parameter \"%v\"", p.stmt.Show)
+		}
+
+		rows := make(chan *ordereddict.Dict, 1)
+		rows <- ordereddict.NewDict().Set(p.stmt.Show, value)
+		close(rows)
+		p.rows = rows
+
+	case p.stmt.VQL != "":
+		err := self.runQuery(ctx, p)
+		if err != nil {
+			return err
+		}
+
+	default:
+		return nil
+	}
+
+	p.next = <-p.rows
+	p.columns = p.stmt.Columns
+	if p.columns == nil && p.next != nil {
+		p.columns = p.next.Keys()
+	}
+	if p.columns == nil {
+		p.columns = []string{}
+	}
+
+	return nil
+}
+
+func (self *session) runQuery(ctx context.Context, p *portal) error {
+	vql, err := vfilter.Parse(p.stmt.VQL)
+	if err != nil {
+		return newError(ERR_SYNTAX, "%v", err)
+	}
+
+	manager, err := services.GetRepositoryManager(self.org_config)
+	if err != nil {
+		return err
+	}
+	repository, err := manager.GetGlobalRepository(self.org_config)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(self.endpoint.config_obj, &logging.APICmponent)
+	logger.WithFields(logrus.Fields{
+		"user": self.principal,
+		"org":  self.org_config.OrgId,
+		"vql":  p.stmt.VQL,
+	}).Info("SQL endpoint query")
+
+	p.logs = &logCollector{}
+	scope := manager.BuildScope(services.ScopeBuilder{
+		Config: self.org_config,
+		ACLManager: acl_managers.NewReadOnlyACLManager(
+			self.org_config, self.principal),
+		Logger:     log.New(p.logs, "", 0),
+		Repository: repository,
+		Env:        p.stmt.Env,
+	})
+
+	sub_ctx, cancel := context.WithTimeout(ctx, self.endpoint.timeout)
+	p.query_ctx = sub_ctx
+	p.cancel = cancel
+
+	rows := make(chan *ordereddict.Dict)
+	p.rows = rows
+
+	go func() {
+		defer close(rows)
+		defer scope.Close()
+
+		opts := vql_subsystem.EncOptsFromScope(scope)
+		for row := range vql.Eval(sub_ctx, scope) {
+			// Serializing the row materializes lazy values the same
+			// way as the API does.
+			serialized, err := json.MarshalWithOptions(row, opts)
+			if err != nil {
+				scope.Log("SQL endpoint: %v", err)
+				continue
+			}
+
+			dicts, err := utils.ParseJsonToDicts(serialized)
+			if err != nil || len(dicts) == 0 {
+				continue
+			}
+
+			select {
+			case <-sub_ctx.Done():
+				return
+			case rows <- dicts[0]:
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Send up to max_rows rows (0 for all rows).
+func (self *session) execute(ctx context.Context, p *portal, max_rows int) error {
+	err := self.start(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	if p.rows == nil {
+		self.send(newMessage('C').string(p.stmt.Tag))
+		return nil
+	}
+
+	sent := 0
+	for p.next != nil {
+		if max_rows > 0 && sent >= max_rows {
+			self.send(newMessage('s'))
+			return nil
+		}
+
+		values := make([]*string, 0, len(p.columns))
+		for _, column := range p.columns {
+			value, _ := p.next.Get(column)
+			values = append(values, formatValue(value))
+		}
+		self.send(dataRowMessage(values))
+
+		sent++
+		p.count++
+		p.next = <-p.rows
+	}
+
+	if p.query_ctx != nil &&
+		errors.Is(p.query_ctx.Err(), context.DeadlineExceeded) {
+		return newError(ERR_QUERY_CANCELED,
+			"canceling statement due to statement timeout")
+	}
+
+	if p.logs != nil {
+		for _, line := range p.logs.get() {
+			// Plugins report permission errors in different ways.
+			if strings.Contains(line, acls.PermissionDenied.Error()) ||
+				strings.Contains(line, "Permission denied") {
+				return newError(ERR_PRIVILEGE, "%v", strings.TrimSpace(line))
+			}
+
+			if strings.HasPrefix(line, "DEBUG:") {
+				continue
+			}
+			self.send(newMessage('N').
+				byte('S').string("NOTICE").
+				byte('V').string("NOTICE").
+				byte('C').string("00000").
+				byte('M').string(strings.TrimSpace(line)).
+				byte(0))
+		}
+	}
+
+	self.send(newMessage('C').string(fmt.Sprintf("SELECT %d", p.count)))
+	return nil
+}
+
+func formatValue(value interface{}) *string {
+	switch t := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return &t
+	default:
+		result := json.MustMarshalString(t)
+		return &result
+	}
+}
+
+func (self *portal) close() {
+	if self.cancel != nil {
+		self.cancel()
+	}
+}
+
+func (self *session) closePortal(name string) {
+	p, pres := self.portals[name]
+	if pres {
+		p.close()
+		delete(self.portals, name)
+	}
+}
+
+func (self *session) closePortals() {
+	for name := range self.portals {
+		self.closePortal(name)
+	}
+}
+
+// Collects the query log.
+type logCollector struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (self *logCollector) Write(b []byte) (int, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.lines = append(self.lines, string(b))
+	return len(b), nil
+}
+
+func (self *logCollector) get() []string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := self.lines
+	self.lines = nil
+	return result
+}
+
+func NewSQLEndpointService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	sql_config := config_obj.SqlEndpoint
+
+	bind_address := sql_config.BindAddress
+	if bind_address == "" {
+		bind_address = "127.0.0.1"
+	}
+
+	bind_port := sql_config.BindPort
+	if bind_port == 0 {
+		bind_port = 5432
+	}
+
+	timeout := time.Duration(600) * time.Second
+	if sql_config.Timeout > 0 {
+		timeout = time.Duration(sql_config.Timeout) * time.Second
+	}
+
+	endpoint := &SQLEndpoint{
+		config_obj: config_obj,
+		timeout:    timeout,
+	}
+
+	// Use the server certificate to secure the connection.
+	if config_obj.Frontend != nil && config_obj.Frontend.Certificate != "" {
+		cert, err := tls.X509KeyPair(
+			[]byte(config_obj.Frontend.Certificate),
+			[]byte(config_obj.Frontend.PrivateKey))
+		if err != nil {
+			return err
+		}
+		endpoint.tls_config = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+
+	address := net.JoinHostPort(bind_address, fmt.Sprintf("%d", bind_port))
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> SQL endpoint on %v", address)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Error("SQL endpoint: %v", err)
+				}
+				return
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				conn_ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+
+				// Close the connection when the service shuts down.
+				go func() {
+					<-conn_ctx.Done()
+					conn.Close()
+				}()
+
+				endpoint.handleConnection(conn_ctx, conn)
+			}()
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	return nil
+}
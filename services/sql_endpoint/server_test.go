@@ -0,0 +1,354 @@
+package sql_endpoint_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/sql_endpoint"
+	"www.velocidex.com/golang/velociraptor/services/users"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+
+	_ "www.velocidex.com/golang/velociraptor/result_sets/timed"
+	_ "www.velocidex.com/golang/velociraptor/vql/server/flows"
+)
+
+// A minimal Postgres client.
+type pgClient struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+type pgResult struct {
+	Columns []string
+	Rows    [][]interface{}
+	Tag     string
+	Notices []string
+	Error   string
+}
+
+func writeMessage(w io.Writer, message_type byte, body []byte) error {
+	buf := &bytes.Buffer{}
+	if message_type != 0 {
+		buf.WriteByte(message_type)
+	}
+	_ = binary.Write(buf, binary.BigEndian, int32(len(body)+4))
+	buf.Write(body)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (self *pgClient) readMessage() (byte, []byte, error) {
+	header := make([]byte, 5)
+	_, err := io.ReadFull(self.r, header)
+	if err != nil {
+		return 0, nil, err
+	}
+	body := make([]byte, binary.BigEndian.Uint32(header[1:])-4)
+	_, err = io.ReadFull(self.r, body)
+	return header[0], body, err
+}
+
+func cstrings(values ...string) []byte {
+	buf := &bytes.Buffer{}
+	for _, v := range values {
+		buf.WriteString(v)
+		buf.WriteByte(0)
+	}
+	return buf.Bytes()
+}
+
+// The M field of an error or notice.
+func messageField(body []byte) string {
+	for _, field := range bytes.Split(body, []byte{0}) {
+		if len(field) > 0 && field[0] == 'M' {
+			return string(field[1:])
+		}
+	}
+	return ""
+}
+
+func connect(address, user, password, database string) (*pgClient, error) {
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &pgClient{conn: conn, r: bufio.NewReader(conn)}
+	startup := &bytes.Buffer{}
+	_ = binary.Write(startup, binary.BigEndian, int32(sql_endpoint.PROTOCOL_VERSION))
+	startup.Write(cstrings("user", user, "database", database, ""))
+
+	err = writeMessage(conn, 0, startup.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		message_type, body, err := client.readMessage()
+		if err != nil {
+			return nil, err
+		}
+
+		switch message_type {
+		case 'R':
+			if binary.BigEndian.Uint32(body) == 3 {
+				err = writeMessage(conn, 'p', cstrings(password))
+				if err != nil {
+					return nil, err
+				}
+			}
+		case 'E':
+			conn.Close()
+			return nil, fmt.Errorf("%v", messageField(body))
+		case 'Z':
+			return client, nil
+		}
+	}
+}
+
+// Read the results until ReadyForQuery
+func (self *pgClient) readResults() (*pgResult, error) {
+	result := &pgResult{}
+	for {
+		message_type, body, err := self.readMessage()
+		if err != nil {
+			return nil, err
+		}
+
+		switch message_type {
+		case 'T':
+			count := int(binary.BigEndian.Uint16(body))
+			body = body[2:]
+			result.Columns = []string{}
+			for i := 0; i < count; i++ {
+				end := bytes.IndexByte(body, 0)
+				result.Columns = append(result.Columns, string(body[:end]))
+
+				// Skip the fixed size field attributes.
+				body = body[end+1+18:]
+			}
+
+		case 'D':
+			count := int(binary.BigEndian.Uint16(body))
+			body = body[2:]
+			row := []interface{}{}
+			for i := 0; i < count; i++ {
+				length := int32(binary.BigEndian.Uint32(body))
+				body = body[4:]
+				if length < 0 {
+					row = append(row, nil)
+					continue
+				}
+				row = append(row, string(body[:length]))
+				body = body[length:]
+			}
+			result.Rows = append(result.Rows, row)
+
+		case 'C':
+			result.Tag = string(bytes.TrimRight(body, "\x00"))
+
+		case 'N':
+			result.Notices = append(result.Notices, messageField(body))
+
+		case 'E':
+			result.Error = messageField(body)
+
+		case 'Z':
+			return result, nil
+		}
+	}
+}
+
+func (self *pgClient) query(sql string) (*pgResult, error) {
+	err := writeMessage(self.conn, 'Q', cstrings(sql))
+	if err != nil {
+		return nil, err
+	}
+	return self.readResults()
+}
+
+// Run a query with parameters through the extended query protocol.
+func (self *pgClient) execute(sql string, params ...string) (*pgResult, error) {
+	parse := &bytes.Buffer{}
+	parse.Write(cstrings("", sql))
+	_ = binary.Write(parse, binary.BigEndian, int16(0))
+
+	bind := &bytes.Buffer{}
+	bind.Write(cstrings("", ""))
+	_ = binary.Write(bind, binary.BigEndian, int16(0))
+	_ = binary.Write(bind, binary.BigEndian, int16(len(params)))
+	for _, p := range params {
+		_ = binary.Write(bind, binary.BigEndian, int32(len(p)))
+		bind.WriteString(p)
+	}
+	_ = binary.Write(bind, binary.BigEndian, int16(0))
+
+	execute := &bytes.Buffer{}
+	execute.Write(cstrings(""))
+	_ = binary.Write(execute, binary.BigEndian, int32(0))
+
+	for _, message := range []struct {
+		message_type byte
+		body         []byte
+	}{
+		{'P', parse.Bytes()},
+		{'B', bind.Bytes()},
+		{'D', append([]byte{'P'}, cstrings("")...)},
+		{'E', execute.Bytes()},
+		{'S', nil},
+	} {
+		err := writeMessage(self.conn, message.message_type, message.body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return self.readResults()
+}
+
+type SQLEndpointTestSuite struct {
+	test_utils.TestSuite
+	address string
+}
+
+func (self *SQLEndpointTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.LoadArtifacts([]string{`
+name: Server.Audit.Test
+type: SERVER_EVENT
+`})
+	self.TestSuite.SetupTest()
+
+	port, err := vtesting.GetFreePort()
+	assert.NoError(self.T(), err)
+
+	self.ConfigObj.SqlEndpoint = &config_proto.SQLEndpointConfig{
+		BindPort:       uint32(port),
+		AllowPlaintext: true,
+	}
+	self.address = fmt.Sprintf("127.0.0.1:%d", port)
+
+	err = sql_endpoint.NewSQLEndpointService(self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	self.makeUser("analyst", "reader")
+	self.makeUser("admin", "administrator")
+	self.makeUser("nobody", "")
+
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	now := time.Now().Unix()
+	for i := 0; i < 3; i++ {
+		err = journal.PushRowsToArtifact(self.ConfigObj,
+			[]*ordereddict.Dict{ordereddict.NewDict().
+				Set("_ts", now).
+				Set("User", fmt.Sprintf("user%d", i)).
+				Set("Count", i),
+			}, "Server.Audit.Test", "server", "")
+		assert.NoError(self.T(), err)
+	}
+}
+
+func (self *SQLEndpointTestSuite) makeUser(name, role string) {
+	user_record := &api_proto.VelociraptorUser{Name: name}
+	users.SetPassword(user_record, "secret")
+
+	user_manager := services.GetUserManager()
+	err := user_manager.SetUser(self.Ctx, user_record)
+	assert.NoError(self.T(), err)
+
+	if role != "" {
+		err = services.GrantRoles(self.ConfigObj, name, []string{role})
+		assert.NoError(self.T(), err)
+	}
+}
+
+func (self *SQLEndpointTestSuite) TestLogin() {
+	_, err := connect(self.address, "analyst", "wrong", "")
+	assert.Error(self.T(), err)
+	assert.Contains(self.T(), err.Error(), "password authentication failed")
+
+	_, err = connect(self.address, "nobody", "secret", "")
+	assert.Error(self.T(), err)
+	assert.Contains(self.T(), err.Error(), "not allowed to read results")
+
+	client, err := connect(self.address, "analyst", "secret", "analyst")
+	assert.NoError(self.T(), err)
+	defer client.conn.Close()
+
+	result, err := client.query("SHOW server_version")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), [][]interface{}{{"14.0"}}, result.Rows)
+}
+
+func (self *SQLEndpointTestSuite) TestQueries() {
+	client, err := connect(self.address, "analyst", "secret", "")
+	assert.NoError(self.T(), err)
+	defer client.conn.Close()
+
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		result, err := client.query(`
+SELECT "User", Count FROM events."Server.Audit.Test"
+WHERE client_id = 'server' AND User LIKE 'user%'`)
+		assert.NoError(self.T(), err)
+		return len(result.Rows) == 3
+	})
+
+	result, err := client.query(`
+SELECT "User", Count FROM events."Server.Audit.Test"
+WHERE client_id = 'server' AND Count > 0 ORDER BY Count DESC LIMIT 1`)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), &pgResult{
+		Columns: []string{"User", "Count"},
+		Rows:    [][]interface{}{{"user2", "2"}},
+		Tag:     "SELECT 1",
+	}, result)
+
+	// Parameters are bound through the extended query protocol.
+	result, err = client.execute(`
+SELECT * FROM events."Server.Audit.Test" WHERE client_id = $1 AND User = $2`,
+		"server", "user1")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), []string{"User", "Count", "_ts"}, result.Columns)
+	assert.Equal(self.T(), 1, len(result.Rows))
+	assert.Equal(self.T(), "user1", result.Rows[0][0])
+
+	// Writes are rejected.
+	result, err = client.query("DROP TABLE hunts")
+	assert.NoError(self.T(), err)
+	assert.Contains(self.T(), result.Error, "read-only")
+
+	// VQL which needs more than READ_RESULTS is denied even for
+	// administrators.
+	admin, err := connect(self.address, "admin", "secret", "")
+	assert.NoError(self.T(), err)
+	defer admin.conn.Close()
+
+	result, err = admin.query(
+		"SELECT cancel_flow(client_id='C.123', flow_id='F.1') AS X")
+	assert.NoError(self.T(), err)
+	assert.Contains(self.T(), result.Error, "Permission denied")
+
+	// The connection is still usable after errors.
+	result, err = client.query("SELECT 1 AS A; SELECT 'x' AS B")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), [][]interface{}{{"1"}, {"x"}}, result.Rows)
+}
+
+func TestSQLEndpoint(t *testing.T) {
+	suite.Run(t, &SQLEndpointTestSuite{})
+}
@@ -0,0 +1,986 @@
+package sql_endpoint
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// SQLSTATE codes reported to clients.
+const (
+	ERR_SYNTAX          = "42601"
+	ERR_UNDEFINED_TABLE = "42P01"
+	ERR_UNDEFINED_PARAM = "42704"
+	ERR_NOT_SUPPORTED   = "0A000"
+	ERR_READ_ONLY       = "25006"
+	ERR_AUTH_FAILED     = "28P01"
+	ERR_NO_ACCESS       = "28000"
+	ERR_PROTOCOL        = "08P01"
+	ERR_QUERY_CANCELED  = "57014"
+	ERR_PRIVILEGE       = "42501"
+	ERR_INTERNAL        = "XX000"
+)
+
+type sqlError struct {
+	Code    string
+	Message string
+}
+
+func (self *sqlError) Error() string {
+	return self.Message
+}
+
+func newError(code, format string, args ...interface{}) error {
+	return &sqlError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+type tokenType int
+
+const (
+	tokenIdent tokenType = iota
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenParam
+	tokenOperator
+)
+
+type token struct {
+	Type  tokenType
+	Value string
+}
+
+func (self token) is(keyword string) bool {
+	return self.Type == tokenIdent && strings.EqualFold(self.Value, keyword)
+}
+
+func (self token) isOp(op string) bool {
+	return self.Type == tokenOperator && self.Value == op
+}
+
+var (
+	numberRegex = regexp.MustCompile(`^(\d+(\.\d*)?|\.\d+)([eE][-+]?\d+)?`)
+	paramRegex  = regexp.MustCompile(`^\$\d+`)
+	identRegex  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_$]*`)
+
+	operators = []string{"<>", "!=", "<=", ">=", "||", "::",
+		"=", "<", ">", "+", "-", "*", "/", "%", "(", ")", ",", ".", ";"}
+)
+
+func tokenize(sql string) ([]token, error) {
+	var result []token
+
+	for i := 0; i < len(sql); {
+		rest := sql[i:]
+
+		switch {
+		case strings.ContainsRune(" \t\r\n\f", rune(sql[i])):
+			i++
+
+		case strings.HasPrefix(rest, "--"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			i += end
+
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest, "*/")
+			if end < 0 {
+				return nil, newError(ERR_SYNTAX, "unterminated /* comment")
+			}
+			i += end + 2
+
+		case sql[i] == '\'' || sql[i] == '"':
+			value, length, err := readQuoted(rest)
+			if err != nil {
+				return nil, err
+			}
+
+			token_type := tokenString
+			if sql[i] == '"' {
+				token_type = tokenQuotedIdent
+			}
+			result = append(result, token{Type: token_type, Value: value})
+			i += length
+
+		case numberRegex.MatchString(rest):
+			match := numberRegex.FindString(rest)
+			result = append(result, token{Type: tokenNumber, Value: match})
+			i += len(match)
+
+		case paramRegex.MatchString(rest):
+			match := paramRegex.FindString(rest)
+			result = append(result, token{Type: tokenParam, Value: match[1:]})
+			i += len(match)
+
+		case identRegex.MatchString(rest):
+			match := identRegex.FindString(rest)
+			result = append(result, token{Type: tokenIdent, Value: match})
+			i += len(match)
+
+		default:
+			found := false
+			for _, op := range operators {
+				if strings.HasPrefix(rest, op) {
+					result = append(result, token{Type: tokenOperator, Value: op})
+					i += len(op)
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				return nil, newError(ERR_SYNTAX,
+					"syntax error at or near \"%c\"", sql[i])
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Read a quoted string or identifier. The quote is escaped by
+// doubling it.
+func readQuoted(text string) (string, int, error) {
+	quote := text[0]
+	result := strings.Builder{}
+
+	for i := 1; i < len(text); i++ {
+		if text[i] != quote {
+			result.WriteByte(text[i])
+			continue
+		}
+
+		if i+1 < len(text) && text[i+1] == quote {
+			result.WriteByte(quote)
+			i++
+			continue
+		}
+
+		return result.String(), i + 1, nil
+	}
+
+	return "", 0, newError(ERR_SYNTAX, "unterminated quoted string")
+}
+
+// Split the tokens into statements on ;
+func splitStatements(tokens []token) [][]token {
+	var result [][]token
+	var current []token
+
+	for _, t := range tokens {
+		if t.isOp(";") {
+			if len(current) > 0 {
+				result = append(result, current)
+			}
+			current = nil
+			continue
+		}
+		current = append(current, t)
+	}
+
+	if len(current) > 0 {
+		result = append(result, current)
+	}
+	return result
+}
+
+// Replace $n parameters with their values. A nil value is NULL.
+// Values of numeric parameters are passed as numbers, all others
+// as strings.
+func bindParams(tokens []token, params []*string, numeric []bool) ([]token, error) {
+	result := make([]token, 0, len(tokens))
+	for _, t := range tokens {
+		if t.Type != tokenParam {
+			result = append(result, t)
+			continue
+		}
+
+		idx, _ := strconv.Atoi(t.Value)
+		if idx < 1 || idx > len(params) {
+			return nil, newError(ERR_UNDEFINED_PARAM,
+				"there is no parameter $%v", t.Value)
+		}
+
+		value := params[idx-1]
+		switch {
+		case value == nil:
+			result = append(result, token{Type: tokenIdent, Value: "NULL"})
+
+		case idx <= len(numeric) && numeric[idx-1] &&
+			numberRegex.FindString(*value) == *value:
+			result = append(result, token{Type: tokenNumber, Value: *value})
+
+		default:
+			result = append(result, token{Type: tokenString, Value: *value})
+		}
+	}
+	return result, nil
+}
+
+// The highest $n parameter referenced.
+func countParams(tokens []token) int {
+	result := 0
+	for _, t := range tokens {
+		if t.Type == tokenParam {
+			idx, _ := strconv.Atoi(t.Value)
+			if idx > result {
+				result = idx
+			}
+		}
+	}
+	return result
+}
+
+// A statement translated from SQL.
+type statement struct {
+	// The command tag reported on completion (e.g. SELECT or SET).
+	Tag string
+
+	// For queries: the VQL to run with the literal values in Env.
+	VQL string
+	Env *ordereddict.Dict
+
+	// The output column names if they are known from the select
+	// list. Otherwise they are taken from the first row.
+	Columns []string
+
+	// The artifact source the query reads from, if any.
+	Artifact string
+
+	// For SHOW: the parameter to show.
+	Show string
+}
+
+// A virtual table backed by a VQL plugin. Keys are columns which
+// must be given with an equality condition in the WHERE clause -
+// they are passed to the plugin as arguments.
+type virtualTable struct {
+	plugin string
+	keys   []string
+
+	// The plugin argument the table name is passed in.
+	artifact_arg string
+
+	// Conditions on _ts are passed as start_time and end_time.
+	time_range bool
+}
+
+var (
+	// Tables in the public schema.
+	tables = map[string]*virtualTable{
+		"clients": {plugin: "clients"},
+		"hunts":   {plugin: "hunts"},
+		"flows":   {plugin: "flows", keys: []string{"client_id"}},
+	}
+
+	// Schemas holding a table for each artifact source.
+	artifact_schemas = map[string]*virtualTable{
+		"hunt_results": {
+			plugin:       "hunt_results",
+			keys:         []string{"hunt_id"},
+			artifact_arg: "artifact",
+		},
+		"flow_results": {
+			plugin:       "source",
+			keys:         []string{"client_id", "flow_id"},
+			artifact_arg: "artifact",
+		},
+		"events": {
+			plugin:       "monitoring",
+			keys:         []string{"client_id"},
+			artifact_arg: "artifact",
+			time_range:   true,
+		},
+	}
+
+	// SQL functions with a single positional argument are mapped
+	// to the VQL function and its argument name.
+	functions = map[string][2]string{
+		"count":  {"count", "items"},
+		"sum":    {"sum", "item"},
+		"min":    {"min", "item"},
+		"max":    {"max", "item"},
+		"lower":  {"lowcase", "string"},
+		"upper":  {"upcase", "string"},
+		"length": {"len", "list"},
+	}
+
+	// Keywords passed through to VQL.
+	keywords = []string{"AND", "OR", "NOT", "AS", "IN", "ASC", "DESC",
+		"TRUE", "FALSE", "NULL"}
+
+	// Clauses of a SELECT statement in order.
+	clauses = []string{"FROM", "WHERE", "GROUP", "ORDER", "LIMIT", "OFFSET"}
+
+	// Statements which are accepted but do nothing.
+	noop_statements = map[string]string{
+		"SET":        "SET",
+		"RESET":      "RESET",
+		"BEGIN":      "BEGIN",
+		"START":      "START TRANSACTION",
+		"COMMIT":     "COMMIT",
+		"END":        "COMMIT",
+		"ROLLBACK":   "ROLLBACK",
+		"ABORT":      "ROLLBACK",
+		"DISCARD":    "DISCARD ALL",
+		"DEALLOCATE": "DEALLOCATE",
+	}
+)
+
+func translate(tokens []token) (*statement, error) {
+	if len(tokens) == 0 {
+		return &statement{}, nil
+	}
+
+	first := strings.ToUpper(tokens[0].Value)
+	if tag, pres := noop_statements[first]; pres && tokens[0].Type == tokenIdent {
+		return &statement{Tag: tag}, nil
+	}
+
+	switch {
+	case tokens[0].is("SHOW"):
+		if len(tokens) != 2 || tokens[1].Type == tokenString {
+			return nil, newError(ERR_SYNTAX, "syntax error in SHOW")
+		}
+		return &statement{Tag: "SHOW", Show: strings.ToLower(tokens[1].Value)}, nil
+
+	case tokens[0].is("SELECT"):
+		return translateSelect(tokens[1:])
+
+	case tokens[0].Type == tokenIdent:
+		return nil, newError(ERR_READ_ONLY,
+			"cannot execute %v in a read-only transaction", first)
+	}
+
+	return nil, newError(ERR_SYNTAX, "syntax error at or near \"%v\"",
+		tokens[0].Value)
+}
+
+// A SELECT query over the virtual tables translated to VQL.
+type Query struct {
+	VQL      string
+	Env      *ordereddict.Dict
+	Columns  []string
+	Artifact string
+}
+
+// Translate a single SELECT statement for other endpoints which
+// expose the same virtual tables.
+func TranslateQuery(sql string) (*Query, error) {
+	tokens, err := tokenize(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := splitStatements(tokens)
+	if len(statements) != 1 || !statements[0][0].is("SELECT") {
+		return nil, newError(ERR_NOT_SUPPORTED,
+			"only a single SELECT statement is supported")
+	}
+
+	if countParams(statements[0]) > 0 {
+		return nil, newError(ERR_UNDEFINED_PARAM,
+			"parameters are not supported")
+	}
+
+	stmt, err := translate(statements[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{
+		VQL:      stmt.VQL,
+		Env:      stmt.Env,
+		Columns:  stmt.Columns,
+		Artifact: stmt.Artifact,
+	}, nil
+}
+
+type translator struct {
+	env *ordereddict.Dict
+
+	// Qualifiers which are removed from column references.
+	qualifiers []string
+
+	// Set when the query reads an artifact source.
+	artifact string
+}
+
+// Literals are passed to the query as variables so they never need
+// to be escaped.
+func (self *translator) literal(value interface{}) string {
+	name := fmt.Sprintf("__sql_%d", self.env.Len())
+	self.env.Set(name, value)
+	return name
+}
+
+func translateSelect(tokens []token) (*statement, error) {
+	// Split the statement into its clauses.
+	parts := map[string][]token{}
+	current := "SELECT"
+	depth := 0
+	last := -1
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case t.isOp("("):
+			depth++
+		case t.isOp(")"):
+			depth--
+		}
+
+		if depth == 0 && t.Type == tokenIdent {
+			keyword := strings.ToUpper(t.Value)
+			switch keyword {
+			case "DISTINCT", "HAVING", "JOIN", "UNION", "INTERSECT",
+				"EXCEPT", "FETCH", "WINDOW", "FOR":
+				return nil, newError(ERR_NOT_SUPPORTED,
+					"%v is not supported", keyword)
+			}
+
+			idx := indexOf(clauses, keyword)
+			if idx >= 0 {
+				if idx <= last {
+					return nil, newError(ERR_SYNTAX,
+						"syntax error at or near \"%v\"", t.Value)
+				}
+				last = idx
+				current = keyword
+
+				// GROUP BY and ORDER BY
+				if keyword == "GROUP" || keyword == "ORDER" {
+					if i+1 >= len(tokens) || !tokens[i+1].is("BY") {
+						return nil, newError(ERR_SYNTAX,
+							"syntax error at or near \"%v\"", t.Value)
+					}
+					i++
+				}
+				parts[current] = []token{}
+				continue
+			}
+		}
+
+		parts[current] = append(parts[current], t)
+	}
+
+	if len(parts["SELECT"]) == 0 {
+		return nil, newError(ERR_SYNTAX, "SELECT list is empty")
+	}
+
+	if _, pres := parts["OFFSET"]; pres {
+		return nil, newError(ERR_NOT_SUPPORTED, "OFFSET is not supported")
+	}
+
+	self := &translator{env: ordereddict.NewDict()}
+	from := "scope()"
+	where := parts["WHERE"]
+
+	from_tokens, pres := parts["FROM"]
+	if pres {
+		var err error
+		from, where, err = self.translateFrom(from_tokens, where)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	select_list, err := self.translateExpr(parts["SELECT"])
+	if err != nil {
+		return nil, err
+	}
+
+	vql := fmt.Sprintf("SELECT %v FROM %v", select_list, from)
+
+	if len(where) > 0 {
+		expr, err := self.translateExpr(where)
+		if err != nil {
+			return nil, err
+		}
+		vql += " WHERE " + expr
+	}
+
+	for _, clause := range []string{"GROUP", "ORDER"} {
+		clause_tokens, pres := parts[clause]
+		if !pres {
+			continue
+		}
+		expr, err := self.translateExpr(clause_tokens)
+		if err != nil {
+			return nil, err
+		}
+		vql += fmt.Sprintf(" %v BY %v", clause, expr)
+	}
+
+	limit, pres := parts["LIMIT"]
+	if pres {
+		switch {
+		case len(limit) == 1 && limit[0].is("ALL"):
+		case len(limit) == 1 && limit[0].Type == tokenNumber:
+			vql += " LIMIT " + limit[0].Value
+		default:
+			return nil, newError(ERR_SYNTAX, "LIMIT must be a number")
+		}
+	}
+
+	return &statement{
+		Tag:      "SELECT",
+		VQL:      vql,
+		Env:      self.env,
+		Columns:  self.columnNames(parts["SELECT"]),
+		Artifact: self.artifact,
+	}, nil
+}
+
+// Resolve the table to a VQL plugin call. Conditions on the table's
+// keys are taken out of the WHERE clause and passed to the plugin.
+func (self *translator) translateFrom(
+	tokens []token, where []token) (string, []token, error) {
+	var names []string
+	i := 0
+	for {
+		if i >= len(tokens) || (tokens[i].Type != tokenIdent &&
+			tokens[i].Type != tokenQuotedIdent) {
+			return "", nil, newError(ERR_SYNTAX, "syntax error in FROM")
+		}
+
+		name := tokens[i].Value
+		if tokens[i].Type == tokenIdent {
+			name = strings.ToLower(name)
+		}
+		names = append(names, name)
+		i++
+
+		if i < len(tokens) && tokens[i].isOp(".") {
+			i++
+			continue
+		}
+		break
+	}
+
+	// An optional alias which may qualify column references.
+	self.qualifiers = append(self.qualifiers, names[len(names)-1])
+	alias := tokens[i:]
+	if len(alias) > 0 && alias[0].is("AS") {
+		alias = alias[1:]
+	}
+	switch {
+	case len(alias) == 0:
+	case len(alias) == 1 && (alias[0].Type == tokenIdent ||
+		alias[0].Type == tokenQuotedIdent):
+		self.qualifiers = append(self.qualifiers, alias[0].Value)
+	default:
+		return "", nil, newError(ERR_NOT_SUPPORTED,
+			"only a single table may be queried")
+	}
+
+	var table *virtualTable
+	var artifact string
+
+	switch len(names) {
+	case 1:
+		table = tables[names[0]]
+	case 2:
+		if names[0] == "public" {
+			table = tables[names[1]]
+		} else {
+			table = artifact_schemas[names[0]]
+			artifact = names[1]
+		}
+	}
+
+	if table == nil {
+		return "", nil, newError(ERR_UNDEFINED_TABLE,
+			"relation \"%v\" does not exist", strings.Join(names, "."))
+	}
+
+	args := ordereddict.NewDict()
+	if table.artifact_arg != "" {
+		args.Set(table.artifact_arg, artifact)
+		self.artifact = artifact
+	}
+
+	where = self.stripQualifiers(where)
+	conditions := splitConjunction(where)
+	var remaining [][]token
+
+	for _, condition := range conditions {
+		if key, value, ok := keyCondition(condition, table.keys); ok {
+			args.Set(key, value)
+			continue
+		}
+
+		if table.time_range {
+			if arg, value, ok := timeCondition(condition); ok {
+				args.Set(arg, value)
+			}
+		}
+		remaining = append(remaining, condition)
+	}
+
+	for _, key := range table.keys {
+		if _, pres := args.Get(key); !pres {
+			return "", nil, newError(ERR_NOT_SUPPORTED,
+				"queries on \"%v\" must specify %v in the WHERE clause "+
+					"(e.g. WHERE %v = '...')",
+				strings.Join(names, "."), strings.Join(table.keys, " and "), key)
+		}
+	}
+
+	var arg_list []string
+	for _, k := range args.Keys() {
+		v, _ := args.Get(k)
+		arg_list = append(arg_list, fmt.Sprintf("%v=%v", k, self.literal(v)))
+	}
+
+	return fmt.Sprintf("%v(%v)", table.plugin, strings.Join(arg_list, ", ")),
+		joinConjunction(remaining), nil
+}
+
+// Split the condition on top level AND. A top level OR makes the
+// whole condition a single term.
+func splitConjunction(tokens []token) [][]token {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	var result [][]token
+	var current []token
+	depth := 0
+
+	for _, t := range tokens {
+		switch {
+		case t.isOp("("):
+			depth++
+		case t.isOp(")"):
+			depth--
+		case depth == 0 && t.is("OR"):
+			return [][]token{tokens}
+		case depth == 0 && t.is("AND"):
+			result = append(result, current)
+			current = nil
+			continue
+		}
+		current = append(current, t)
+	}
+	return append(result, current)
+}
+
+func joinConjunction(conditions [][]token) []token {
+	var result []token
+	for i, condition := range conditions {
+		if i > 0 {
+			result = append(result, token{Type: tokenIdent, Value: "AND"})
+		}
+		result = append(result, condition...)
+	}
+	return result
+}
+
+// Match key = 'value' or 'value' = key
+func keyCondition(condition []token, keys []string) (string, string, bool) {
+	if len(condition) != 3 || !condition[1].isOp("=") {
+		return "", "", false
+	}
+
+	for _, pair := range [][2]token{
+		{condition[0], condition[2]}, {condition[2], condition[0]}} {
+		column, value := pair[0], pair[1]
+		if column.Type != tokenIdent && column.Type != tokenQuotedIdent {
+			continue
+		}
+		if value.Type != tokenString && value.Type != tokenNumber {
+			continue
+		}
+
+		for _, key := range keys {
+			if column.Value == key ||
+				(column.Type == tokenIdent && strings.EqualFold(column.Value, key)) {
+				return key, value.Value, true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+// Match _ts >= value and _ts <= value
+func timeCondition(condition []token) (string, interface{}, bool) {
+	if len(condition) != 3 || !condition[0].is("_ts") ||
+		(condition[2].Type != tokenString && condition[2].Type != tokenNumber) {
+		return "", "", false
+	}
+
+	switch condition[1].Value {
+	case ">", ">=":
+		return "start_time", literalValue(condition[2]), true
+	case "<", "<=":
+		return "end_time", literalValue(condition[2]), true
+	}
+	return "", nil, false
+}
+
+// Numbers are passed as numbers so they are not mistaken for time
+// strings.
+func literalValue(t token) interface{} {
+	if t.Type == tokenNumber {
+		if value, err := strconv.ParseInt(t.Value, 10, 64); err == nil {
+			return value
+		}
+		if value, err := strconv.ParseFloat(t.Value, 64); err == nil {
+			return value
+		}
+	}
+	return t.Value
+}
+
+// Remove table qualifiers from column references (e.g. t.Name)
+func (self *translator) stripQualifiers(tokens []token) []token {
+	result := make([]token, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if (t.Type == tokenIdent || t.Type == tokenQuotedIdent) &&
+			i+2 < len(tokens) && tokens[i+1].isOp(".") &&
+			self.isQualifier(t) {
+			i++
+			continue
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+func (self *translator) isQualifier(t token) bool {
+	for _, q := range self.qualifiers {
+		if t.Value == q || (t.Type == tokenIdent && strings.EqualFold(t.Value, q)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Translate an SQL expression into VQL.
+func (self *translator) translateExpr(tokens []token) (string, error) {
+	tokens = self.stripQualifiers(tokens)
+
+	var result []string
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		var next *token
+		if i+1 < len(tokens) {
+			next = &tokens[i+1]
+		}
+
+		switch t.Type {
+		case tokenString:
+			result = append(result, self.literal(t.Value))
+
+		case tokenNumber:
+			result = append(result, t.Value)
+
+		case tokenParam:
+			return "", newError(ERR_UNDEFINED_PARAM,
+				"there is no parameter $%v", t.Value)
+
+		case tokenQuotedIdent:
+			if strings.Contains(t.Value, "`") {
+				return "", newError(ERR_SYNTAX, "invalid identifier %v", t.Value)
+			}
+			result = append(result, "`"+t.Value+"`")
+
+		case tokenOperator:
+			switch t.Value {
+			case "<>":
+				result = append(result, "!=")
+			case "||":
+				result = append(result, "+")
+			case "::":
+				return "", newError(ERR_NOT_SUPPORTED, "casts are not supported")
+			default:
+				result = append(result, t.Value)
+			}
+
+		case tokenIdent:
+			keyword := strings.ToUpper(t.Value)
+
+			switch {
+			case keyword == "SELECT":
+				return "", newError(ERR_NOT_SUPPORTED,
+					"subqueries are not supported")
+
+			case keyword == "IS":
+				// IS [NOT] NULL
+				if next != nil && next.is("NOT") {
+					result = append(result, "!=")
+					i++
+				} else {
+					result = append(result, "=")
+				}
+				if i+1 >= len(tokens) || !tokens[i+1].is("NULL") {
+					return "", newError(ERR_NOT_SUPPORTED,
+						"only IS NULL and IS NOT NULL are supported")
+				}
+
+			case keyword == "LIKE" || keyword == "ILIKE":
+				if len(result) > 0 && result[len(result)-1] == "NOT" {
+					return "", newError(ERR_NOT_SUPPORTED,
+						"NOT %v is not supported - use NOT (x %v y)",
+						keyword, keyword)
+				}
+				if next == nil || next.Type != tokenString {
+					return "", newError(ERR_NOT_SUPPORTED,
+						"%v requires a string pattern", keyword)
+				}
+				result = append(result, "=~",
+					self.literal(likeToRegex(next.Value, keyword == "ILIKE")))
+				i++
+
+			case keyword == "BETWEEN":
+				return "", newError(ERR_NOT_SUPPORTED,
+					"BETWEEN is not supported")
+
+			case next != nil && next.isOp("("):
+				// A function call
+				mapped, pres := functions[strings.ToLower(t.Value)]
+				if !pres {
+					result = append(result, t.Value)
+					continue
+				}
+
+				switch {
+				// count(*)
+				case i+2 < len(tokens) && tokens[i+2].isOp("*"):
+					if i+3 >= len(tokens) || !tokens[i+3].isOp(")") {
+						return "", newError(ERR_SYNTAX, "syntax error in %v", t.Value)
+					}
+					result = append(result, mapped[0]+"()")
+					i += 3
+
+				case i+2 < len(tokens) && tokens[i+2].isOp(")"):
+					result = append(result, mapped[0]+"()")
+					i += 2
+
+				default:
+					result = append(result, mapped[0]+"(", mapped[1], "=")
+					i++
+				}
+
+			case utils.InString(keywords, keyword):
+				result = append(result, keyword)
+
+			default:
+				result = append(result, t.Value)
+			}
+		}
+	}
+
+	return joinTokens(result), nil
+}
+
+// Join the VQL tokens with spaces except around . and inside
+// brackets.
+func joinTokens(tokens []string) string {
+	result := strings.Builder{}
+	for i, t := range tokens {
+		if i > 0 {
+			prev := tokens[i-1]
+			if t != "." && t != ")" && t != "," && prev != "." &&
+				!strings.HasSuffix(prev, "(") {
+				result.WriteString(" ")
+			}
+		}
+		result.WriteString(t)
+	}
+	return result.String()
+}
+
+// Work out the column names from the select list. Returns nil if any
+// column is an expression without an alias.
+func (self *translator) columnNames(tokens []token) []string {
+	tokens = self.stripQualifiers(tokens)
+
+	var result []string
+	var current []token
+	depth := 0
+
+	add := func() bool {
+		n := len(current)
+		switch {
+		case n == 1 && (current[0].Type == tokenIdent ||
+			current[0].Type == tokenQuotedIdent):
+			result = append(result, current[0].Value)
+		case n >= 3 && current[n-2].is("AS") &&
+			(current[n-1].Type == tokenIdent ||
+				current[n-1].Type == tokenQuotedIdent):
+			result = append(result, current[n-1].Value)
+		default:
+			return false
+		}
+		current = nil
+		return true
+	}
+
+	for _, t := range tokens {
+		switch {
+		case t.isOp("("):
+			depth++
+		case t.isOp(")"):
+			depth--
+		case depth == 0 && t.isOp(","):
+			if !add() {
+				return nil
+			}
+			continue
+		}
+		current = append(current, t)
+	}
+
+	if !add() {
+		return nil
+	}
+	return result
+}
+
+// Convert an SQL LIKE pattern to an anchored regular expression.
+func likeToRegex(pattern string, case_insensitive bool) string {
+	result := strings.Builder{}
+	if case_insensitive {
+		result.WriteString("(?i)")
+	}
+	result.WriteString("(?s)^")
+
+	escaped := false
+	for _, c := range pattern {
+		switch {
+		case escaped:
+			result.WriteString(regexp.QuoteMeta(string(c)))
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '%':
+			result.WriteString(".*")
+		case c == '_':
+			result.WriteString(".")
+		default:
+			result.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	result.WriteString("$")
+
+	return result.String()
+}
+
+func indexOf(list []string, item string) int {
+	for i, v := range list {
+		if v == item {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,106 @@
+package sql_endpoint
+
+import (
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/sebdah/goldie/v2"
+	"www.velocidex.com/golang/velociraptor/json"
+)
+
+var translationTestCases = []struct {
+	name string
+	sql  string
+}{
+	{"No FROM", "SELECT 1 AS A, 'x' AS B"},
+	{"Clients", `SELECT client_id, os_info.hostname AS "Host Name" FROM clients
+                     WHERE os_info.system = 'windows' LIMIT 10`},
+	{"Qualified columns", `SELECT c.client_id FROM public.clients AS c
+                               WHERE c.client_id <> 'server'`},
+	{"Flows need a client", "SELECT * FROM flows WHERE client_id = 'C.123' ORDER BY create_time DESC"},
+	{"Flows without a client", "SELECT * FROM flows"},
+	{"Hunt results", `SELECT Fqdn, count(*) AS Total FROM hunt_results."Windows.Sys.Users"
+                          WHERE 'H.1234' = hunt_id AND Name ILIKE 'adm%' GROUP BY Fqdn`},
+	{"Flow results", `SELECT * FROM flow_results."Generic.Client.Info/Users"
+                          WHERE flow_id = 'F.1' AND client_id = 'C.1'`},
+	{"Events with time range", `SELECT * FROM events."Server.Audit.Logs"
+                                    WHERE client_id = 'server' AND _ts >= 1600000000
+                                    AND _ts < 1600086400`},
+	{"OR is not pushed down", `SELECT * FROM hunt_results."X"
+                                   WHERE hunt_id = 'H.1' OR hunt_id = 'H.2'`},
+	{"Null checks", "SELECT * FROM hunts WHERE state IS NOT NULL AND creator IS NULL"},
+	{"Functions", "SELECT lower(hostname) || '-x' AS Name, max(size) AS Biggest FROM clients"},
+	{"Unknown table", "SELECT * FROM users"},
+	{"Writes", "DELETE FROM hunts"},
+	{"Subquery", "SELECT * FROM hunts WHERE hunt_id IN (SELECT 1)"},
+	{"Offset", "SELECT * FROM hunts LIMIT 10 OFFSET 10"},
+	{"Joins", "SELECT * FROM hunts JOIN clients"},
+	{"Comments", "SELECT /* all */ * FROM hunts -- the hunts"},
+	{"Set", "SET extra_float_digits = 3"},
+	{"Show", "SHOW TimeZone"},
+}
+
+func TestTranslation(t *testing.T) {
+	result := ordereddict.NewDict()
+	for _, test_case := range translationTestCases {
+		tokens, err := tokenize(test_case.sql)
+		assert.NoError(t, err)
+
+		stmt, err := translate(tokens)
+		if err != nil {
+			result.Set(test_case.name, err.(*sqlError))
+			continue
+		}
+		result.Set(test_case.name, stmt)
+	}
+
+	g := goldie.New(t)
+	g.Assert(t, "TestTranslation", json.MustMarshalIndent(result))
+}
+
+func TestParams(t *testing.T) {
+	tokens, err := tokenize("SELECT * FROM flows WHERE client_id = $1 AND size > $2 AND x = $3")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, countParams(tokens))
+
+	client_id := "C.123"
+	size := "10"
+	tokens, err = bindParams(tokens, []*string{&client_id, &size, nil},
+		[]bool{false, true})
+	assert.NoError(t, err)
+
+	stmt, err := translate(tokens)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM flows(client_id=__sql_0) WHERE size > 10 AND x = NULL",
+		stmt.VQL)
+
+	_, err = bindParams(tokens, nil, nil)
+	assert.NoError(t, err)
+
+	tokens, _ = tokenize("SELECT $2")
+	_, err = bindParams(tokens, []*string{&size}, nil)
+	assert.Error(t, err)
+}
+
+func TestLikeToRegex(t *testing.T) {
+	assert.Equal(t, `(?s)^adm.*\.exe.$`, likeToRegex("adm%.exe_", false))
+	assert.Equal(t, `(?i)(?s)^100%$`, likeToRegex(`100\%`, true))
+}
+
+func TestTokenize(t *testing.T) {
+	tokens, err := tokenize(`SELECT 'it''s', "a ""b""", 1.5e3, $1;`)
+	assert.NoError(t, err)
+	assert.Equal(t, []token{
+		{tokenIdent, "SELECT"}, {tokenString, "it's"}, {tokenOperator, ","},
+		{tokenQuotedIdent, `a "b"`}, {tokenOperator, ","},
+		{tokenNumber, "1.5e3"}, {tokenOperator, ","},
+		{tokenParam, "1"}, {tokenOperator, ";"},
+	}, tokens)
+
+	_, err = tokenize("SELECT 'unterminated")
+	assert.Error(t, err)
+
+	_, err = tokenize("SELECT {1}")
+	assert.Error(t, err)
+}
@@ -0,0 +1,248 @@
+package standby
+
+// A file backed queue of ops waiting to be shipped to the standby.
+
+// The below is similar to journal.BufferFile except:
+// * Items are of type api_proto.ReplicationOp
+// * Items are only removed once the standby acknowledged them so a
+//   failed send is retried.
+// * The buffer persists across restarts so ops are not lost when the
+//   frontend is restarted while the standby is down.
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	logging "www.velocidex.com/golang/velociraptor/logging"
+)
+
+const (
+	FileMagic         = "VRS\x5f"
+	FirstRecordOffset = 50
+)
+
+var (
+	ErrorsCorrupted = errors.New("File is corrupted")
+)
+
+type Header struct {
+	ReadPointer  int64 // Peeking will start at this file offset.
+	WritePointer int64 // Enqueue will write at this file position.
+}
+
+func (self *Header) MarshalBinary() ([]byte, error) {
+	data := make([]byte, FirstRecordOffset)
+	copy(data, FileMagic)
+
+	binary.LittleEndian.PutUint64(data[4:12], uint64(self.ReadPointer))
+	binary.LittleEndian.PutUint64(data[12:20], uint64(self.WritePointer))
+
+	return data, nil
+}
+
+func (self *Header) UnmarshalBinary(data []byte) error {
+	if len(data) < FirstRecordOffset {
+		return errors.New("Invalid header length")
+	}
+
+	if string(data[:4]) != FileMagic {
+		return errors.New("Invalid Magic")
+	}
+
+	self.ReadPointer = int64(binary.LittleEndian.Uint64(data[4:12]))
+	self.WritePointer = int64(binary.LittleEndian.Uint64(data[12:20]))
+
+	return nil
+}
+
+type BufferFile struct {
+	mu sync.Mutex
+
+	fd     *os.File
+	Header *Header
+
+	// Number of bytes returned by the last Peek()
+	peeked int64
+
+	log_ctx *logging.LogContext
+}
+
+func (self *BufferFile) Enqueue(item *api_proto.ReplicationOp) error {
+	serialized, err := proto.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	buf := make([]byte, 8+len(serialized))
+	binary.LittleEndian.PutUint64(buf, uint64(len(serialized)))
+	copy(buf[8:], serialized)
+
+	_, err = self.fd.WriteAt(buf, self.Header.WritePointer)
+	if err != nil {
+		return err
+	}
+
+	self.Header.WritePointer += int64(len(buf))
+	return self.writeHeader()
+}
+
+// Returns up to max_count items from the front of the queue without
+// removing them.
+func (self *BufferFile) Peek(max_count int) ([]*api_proto.ReplicationOp, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	var result []*api_proto.ReplicationOp
+
+	read_buf := make([]byte, 8)
+	offset := self.Header.ReadPointer
+	for len(result) < max_count && offset < self.Header.WritePointer {
+		n, err := self.fd.ReadAt(read_buf, offset)
+		if err != nil || n != len(read_buf) {
+			self.log_ctx.Error("Possible corruption detected: file too short.")
+			self._Truncate()
+			return nil, ErrorsCorrupted
+		}
+
+		length := int64(binary.LittleEndian.Uint64(read_buf))
+		if length > constants.MAX_MEMORY*2 || length <= 0 {
+			self.log_ctx.Error("Possible corruption detected - item length is too large.")
+			self._Truncate()
+			return nil, ErrorsCorrupted
+		}
+
+		serialized := make([]byte, length)
+		n, _ = self.fd.ReadAt(serialized, offset+8)
+		if int64(n) != length {
+			self.log_ctx.Errorf(
+				"Possible corruption detected - expected item of length %v received %v.",
+				length, n)
+			self._Truncate()
+			return nil, ErrorsCorrupted
+		}
+
+		item := &api_proto.ReplicationOp{}
+		err = proto.Unmarshal(serialized, item)
+		if err != nil {
+			self.log_ctx.Errorf(
+				"Possible corruption detected - unable to decode item.")
+			self._Truncate()
+			return nil, ErrorsCorrupted
+		}
+
+		result = append(result, item)
+		offset += 8 + length
+	}
+
+	if len(result) == 0 {
+		return nil, io.EOF
+	}
+
+	self.peeked = offset - self.Header.ReadPointer
+	return result, nil
+}
+
+// Remove the items returned by the last Peek()
+func (self *BufferFile) Ack() error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.Header.ReadPointer += self.peeked
+	self.peeked = 0
+
+	// We read up to the write pointer, we may truncate the file
+	// now.
+	if self.Header.ReadPointer >= self.Header.WritePointer {
+		self._Truncate()
+		return nil
+	}
+
+	return self.writeHeader()
+}
+
+// Number of bytes waiting to be shipped.
+func (self *BufferFile) Pending() int64 {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.Header.WritePointer - self.Header.ReadPointer
+}
+
+func (self *BufferFile) writeHeader() error {
+	serialized, err := self.Header.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	_, err = self.fd.WriteAt(serialized, 0)
+	return err
+}
+
+// _Truncate returns the file to a virgin state. Assumes
+// BufferFile is already under lock.
+func (self *BufferFile) _Truncate() {
+	_ = self.fd.Truncate(0)
+	self.Header.ReadPointer = FirstRecordOffset
+	self.Header.WritePointer = FirstRecordOffset
+	self.peeked = 0
+	_ = self.writeHeader()
+}
+
+// Unlike journal.BufferFile the file is kept so unsent ops are
+// shipped after a restart.
+func (self *BufferFile) Close() {
+	self.fd.Close()
+}
+
+func NewBufferFile(
+	config_obj *config_proto.Config, fd *os.File) (*BufferFile, error) {
+
+	log_ctx := logging.GetLogger(config_obj, &logging.FrontendComponent)
+
+	header := &Header{
+		// Pad the header a bit to allow for extensions.
+		WritePointer: FirstRecordOffset,
+		ReadPointer:  FirstRecordOffset,
+	}
+	data := make([]byte, FirstRecordOffset)
+	n, err := fd.ReadAt(data, 0)
+	if n > 0 && n < FirstRecordOffset && err == io.EOF {
+		log_ctx.Error("Possible corruption detected: file too short.")
+		err = fd.Truncate(0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if n >= FirstRecordOffset && (err == nil || err == io.EOF) {
+		err := header.UnmarshalBinary(data[:n])
+		// The header is not valid, truncate the file and
+		// start again.
+		if err != nil {
+			log_ctx.Errorf("Possible corruption detected: %v.", err)
+			header.ReadPointer = FirstRecordOffset
+			header.WritePointer = FirstRecordOffset
+			err = fd.Truncate(0)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := &BufferFile{
+		fd:      fd,
+		Header:  header,
+		log_ctx: log_ctx,
+	}
+
+	return result, result.writeHeader()
+}
@@ -0,0 +1,137 @@
+package standby
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+)
+
+// Queues all datastore writes for the standby after they are applied
+// locally.
+type ReplicatingDataStore struct {
+	datastore.DataStore
+
+	sender *Sender
+}
+
+func (self *ReplicatingDataStore) SetSubject(
+	config_obj *config_proto.Config,
+	urn api.DSPathSpec,
+	message proto.Message) error {
+	return self.SetSubjectWithCompletion(config_obj, urn, message, nil)
+}
+
+func (self *ReplicatingDataStore) SetSubjectWithCompletion(
+	config_obj *config_proto.Config,
+	urn api.DSPathSpec,
+	message proto.Message,
+	completion func()) error {
+
+	var value []byte
+	var err error
+
+	// Serialize the same way as the datastore does.
+	if urn.Type() == api.PATH_TYPE_DATASTORE_JSON {
+		value, err = protojson.Marshal(message)
+	} else {
+		value, err = proto.Marshal(message)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = self.DataStore.SetSubjectWithCompletion(
+		config_obj, urn, message, completion)
+	if err != nil {
+		return err
+	}
+
+	return self.sender.Enqueue(&api_proto.ReplicationOp{
+		Type:     api_proto.ReplicationOp_SET_SUBJECT,
+		OrgId:    config_obj.OrgId,
+		Pathspec: datastorePathToProto(config_obj, urn),
+		Data:     value,
+	})
+}
+
+func (self *ReplicatingDataStore) DeleteSubject(
+	config_obj *config_proto.Config,
+	urn api.DSPathSpec) error {
+	return self.DeleteSubjectWithCompletion(config_obj, urn, nil)
+}
+
+func (self *ReplicatingDataStore) DeleteSubjectWithCompletion(
+	config_obj *config_proto.Config,
+	urn api.DSPathSpec, completion func()) error {
+	err := self.DataStore.DeleteSubjectWithCompletion(
+		config_obj, urn, completion)
+	if err != nil {
+		return err
+	}
+
+	return self.sender.Enqueue(&api_proto.ReplicationOp{
+		Type:     api_proto.ReplicationOp_DELETE_SUBJECT,
+		OrgId:    config_obj.OrgId,
+		Pathspec: datastorePathToProto(config_obj, urn),
+	})
+}
+
+// Keep the wrapped datastore's locking across frontends.
+func (self *ReplicatingDataStore) LockSubject(
+	config_obj *config_proto.Config,
+	urn api.DSPathSpec) (func(), error) {
+	locker, ok := self.DataStore.(datastore.SubjectLocker)
+	if !ok {
+		return func() {}, nil
+	}
+	return locker.LockSubject(config_obj, urn)
+}
+
+// Support RawDataStore interface for the remote datastore API.
+func (self *ReplicatingDataStore) GetBuffer(
+	config_obj *config_proto.Config, urn api.DSPathSpec) ([]byte, error) {
+	raw_db, ok := self.DataStore.(datastore.RawDataStore)
+	if !ok {
+		return nil, notSupportedError
+	}
+	return raw_db.GetBuffer(config_obj, urn)
+}
+
+func (self *ReplicatingDataStore) SetBuffer(
+	config_obj *config_proto.Config, urn api.DSPathSpec,
+	data []byte, completion func()) error {
+	raw_db, ok := self.DataStore.(datastore.RawDataStore)
+	if !ok {
+		return notSupportedError
+	}
+
+	err := raw_db.SetBuffer(config_obj, urn, data, completion)
+	if err != nil {
+		return err
+	}
+
+	return self.sender.Enqueue(&api_proto.ReplicationOp{
+		Type:     api_proto.ReplicationOp_SET_SUBJECT,
+		OrgId:    config_obj.OrgId,
+		Pathspec: datastorePathToProto(config_obj, urn),
+		Data:     data,
+	})
+}
+
+func (self *ReplicatingDataStore) Flush() {
+	flusher, ok := self.DataStore.(api.Flusher)
+	if ok {
+		flusher.Flush()
+	}
+}
+
+func NewReplicatingDataStore(
+	db datastore.DataStore, sender *Sender) *ReplicatingDataStore {
+	return &ReplicatingDataStore{
+		DataStore: db,
+		sender:    sender,
+	}
+}
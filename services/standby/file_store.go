@@ -0,0 +1,115 @@
+package standby
+
+import (
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+)
+
+// Queues all file store writes for the standby after they are
+// applied locally. File store files are append only so appended data
+// can be replayed on the standby in order.
+type ReplicatingFileStore struct {
+	api.FileStore
+
+	config_obj *config_proto.Config
+	sender     *Sender
+}
+
+func (self *ReplicatingFileStore) WriteFile(
+	filename api.FSPathSpec) (api.FileWriter, error) {
+	return self.WriteFileWithCompletion(filename, nil)
+}
+
+func (self *ReplicatingFileStore) WriteFileWithCompletion(
+	filename api.FSPathSpec, completion func()) (api.FileWriter, error) {
+	writer, err := self.FileStore.WriteFileWithCompletion(filename, completion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &replicatingWriter{
+		FileWriter: writer,
+		pathspec:   filestorePathToProto(self.config_obj, filename),
+		org_id:     self.config_obj.OrgId,
+		sender:     self.sender,
+	}, nil
+}
+
+func (self *ReplicatingFileStore) Delete(filename api.FSPathSpec) error {
+	err := self.FileStore.Delete(filename)
+	if err != nil {
+		return err
+	}
+
+	return self.sender.Enqueue(&api_proto.ReplicationOp{
+		Type:     api_proto.ReplicationOp_DELETE_FILE,
+		OrgId:    self.config_obj.OrgId,
+		Pathspec: filestorePathToProto(self.config_obj, filename),
+	})
+}
+
+func (self *ReplicatingFileStore) Move(src, dest api.FSPathSpec) error {
+	err := self.FileStore.Move(src, dest)
+	if err != nil {
+		return err
+	}
+
+	return self.sender.Enqueue(&api_proto.ReplicationOp{
+		Type:        api_proto.ReplicationOp_MOVE_FILE,
+		OrgId:       self.config_obj.OrgId,
+		Pathspec:    filestorePathToProto(self.config_obj, src),
+		Destination: filestorePathToProto(self.config_obj, dest),
+	})
+}
+
+func (self *ReplicatingFileStore) Flush() {
+	flusher, ok := self.FileStore.(api.Flusher)
+	if ok {
+		flusher.Flush()
+	}
+}
+
+type replicatingWriter struct {
+	api.FileWriter
+
+	pathspec *api_proto.DSPathSpec
+	org_id   string
+	sender   *Sender
+}
+
+func (self *replicatingWriter) Write(data []byte) (int, error) {
+	n, err := self.FileWriter.Write(data)
+	if err != nil {
+		return n, err
+	}
+
+	return n, self.sender.Enqueue(&api_proto.ReplicationOp{
+		Type:     api_proto.ReplicationOp_WRITE_FILE,
+		OrgId:    self.org_id,
+		Pathspec: self.pathspec,
+		Data:     data[:n],
+	})
+}
+
+func (self *replicatingWriter) Truncate() error {
+	err := self.FileWriter.Truncate()
+	if err != nil {
+		return err
+	}
+
+	return self.sender.Enqueue(&api_proto.ReplicationOp{
+		Type:     api_proto.ReplicationOp_TRUNCATE_FILE,
+		OrgId:    self.org_id,
+		Pathspec: self.pathspec,
+	})
+}
+
+func NewReplicatingFileStore(config_obj *config_proto.Config,
+	file_store api.FileStore, sender *Sender) *ReplicatingFileStore {
+	return &ReplicatingFileStore{
+		FileStore:  file_store,
+		config_obj: config_obj,
+		sender:     sender,
+	}
+}
@@ -0,0 +1,68 @@
+package standby
+
+import (
+	"path/filepath"
+	"strings"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/file_store/path_specs"
+)
+
+// Paths are shipped as the sanitized components of the on disk
+// filename so the standby writes exactly the same files regardless
+// of how the path spec was built.
+func relativeComponents(path, root string) []string {
+	path = strings.TrimPrefix(path, datastore.WINDOWS_LFN_PREFIX)
+	root = strings.TrimPrefix(root, datastore.WINDOWS_LFN_PREFIX)
+	path = strings.TrimPrefix(path, root)
+
+	var result []string
+	for _, component := range strings.Split(path, string(filepath.Separator)) {
+		if component != "" {
+			result = append(result, component)
+		}
+	}
+	return result
+}
+
+func datastorePathToProto(
+	config_obj *config_proto.Config, urn api.DSPathSpec) *api_proto.DSPathSpec {
+	return &api_proto.DSPathSpec{
+		Components: relativeComponents(
+			urn.AsDatastoreDirectory(config_obj), config_obj.Datastore.Location),
+		PathType: int64(urn.Type()),
+		Tag:      urn.Tag(),
+	}
+}
+
+func filestorePathToProto(
+	config_obj *config_proto.Config, path api.FSPathSpec) *api_proto.DSPathSpec {
+	return &api_proto.DSPathSpec{
+		Components: relativeComponents(
+			path.AsFilestoreDirectory(config_obj),
+			config_obj.Datastore.FilestoreDirectory),
+		PathType: int64(path.Type()),
+	}
+}
+
+func datastorePathFromProto(path *api_proto.DSPathSpec) api.DSPathSpec {
+	if path == nil {
+		path = &api_proto.DSPathSpec{}
+	}
+
+	return path_specs.NewSafeDatastorePath(path.Components...).
+		SetType(api.PathType(path.PathType)).
+		SetTag(path.Tag)
+}
+
+func filestorePathFromProto(path *api_proto.DSPathSpec) api.FSPathSpec {
+	if path == nil {
+		path = &api_proto.DSPathSpec{}
+	}
+
+	return path_specs.NewSafeFilestorePath(path.Components...).
+		SetType(api.PathType(path.PathType))
+}
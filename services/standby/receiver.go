@@ -0,0 +1,274 @@
+package standby
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store/directory"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	notSupportedError = errors.New("Not supported")
+	PromotedError     = errors.New("Standby was promoted and no longer accepts replication")
+)
+
+const (
+	STATE_FILE = "standby_state.json"
+)
+
+type NodeState struct {
+	Epoch    int64  `json:"epoch"`
+	Sequence uint64 `json:"sequence"`
+}
+
+// Persisted in the standby's datastore directory.
+type StandbyState struct {
+	// The last op applied from each frontend.
+	Nodes map[string]*NodeState `json:"nodes"`
+
+	Promoted     bool  `json:"promoted"`
+	PromotedTime int64 `json:"promoted_time,omitempty"`
+
+	LastReplication int64 `json:"last_replication,omitempty"`
+}
+
+func stateFilename(config_obj *config_proto.Config) string {
+	return filepath.Join(config_obj.Datastore.Location, STATE_FILE)
+}
+
+func LoadState(config_obj *config_proto.Config) (*StandbyState, error) {
+	result := &StandbyState{Nodes: make(map[string]*NodeState)}
+
+	data, err := ioutil.ReadFile(stateFilename(config_obj))
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Nodes == nil {
+		result.Nodes = make(map[string]*NodeState)
+	}
+	return result, nil
+}
+
+func SaveState(config_obj *config_proto.Config, state *StandbyState) error {
+	serialized, err := json.MarshalIndent(state)
+	if err != nil {
+		return err
+	}
+
+	// Write atomically so a crash does not lose the state.
+	filename := stateFilename(config_obj)
+	err = ioutil.WriteFile(filename+".tmp", serialized, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(filename+".tmp", filename)
+}
+
+// Stop accepting replication so the standby can take over. Any
+// frontend still trying to replicate to us will keep queuing.
+func Promote(config_obj *config_proto.Config) error {
+	state, err := LoadState(config_obj)
+	if err != nil {
+		return err
+	}
+
+	state.Promoted = true
+	state.PromotedTime = utils.GetTime().Now().Unix()
+	return SaveState(config_obj, state)
+}
+
+// Applies ops shipped from the frontends to the standby's datastore.
+type Receiver struct {
+	api_proto.UnimplementedStandbyServer
+
+	config_obj *config_proto.Config
+
+	mu    sync.Mutex
+	state *StandbyState
+	db    *datastore.FileBaseDataStore
+}
+
+func (self *Receiver) Replicate(
+	ctx context.Context,
+	in *api_proto.ReplicationBatch) (*api_proto.ReplicationAck, error) {
+
+	err := self.checkPeer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sequence, err := self.applyBatch(in)
+	if errors.Is(err, PromotedError) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &api_proto.ReplicationAck{Sequence: sequence}, nil
+}
+
+// Only frontends may replicate - they present the frontend
+// certificate.
+func (self *Receiver) checkPeer(ctx context.Context) error {
+	peer, ok := peer.FromContext(ctx)
+	if ok {
+		tlsInfo, ok := peer.AuthInfo.(credentials.TLSInfo)
+		if ok && len(tlsInfo.State.VerifiedChains) > 0 &&
+			len(tlsInfo.State.VerifiedChains[0]) > 0 {
+			name := tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+			if name == self.config_obj.Client.PinnedServerName {
+				return nil
+			}
+		}
+	}
+
+	return status.Error(codes.PermissionDenied,
+		"Only frontends may replicate to the standby")
+}
+
+func (self *Receiver) applyBatch(in *api_proto.ReplicationBatch) (uint64, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.state.Promoted {
+		return 0, PromotedError
+	}
+
+	var last uint64
+	for _, op := range in.Ops {
+		last = op.Sequence
+
+		// Skip ops we already applied from a resent batch.
+		node_state, pres := self.state.Nodes[op.Node]
+		if !pres {
+			node_state = &NodeState{}
+			self.state.Nodes[op.Node] = node_state
+		}
+
+		if op.Epoch < node_state.Epoch ||
+			(op.Epoch == node_state.Epoch && op.Sequence <= node_state.Sequence) {
+			continue
+		}
+
+		err := self.applyOp(op)
+		if err != nil {
+			return 0, fmt.Errorf("While applying op %v: %w", op.Sequence, err)
+		}
+
+		node_state.Epoch = op.Epoch
+		node_state.Sequence = op.Sequence
+	}
+
+	self.state.LastReplication = utils.GetTime().Now().Unix()
+	return last, SaveState(self.config_obj, self.state)
+}
+
+func (self *Receiver) applyOp(op *api_proto.ReplicationOp) error {
+	org_config := self.orgConfig(op.OrgId)
+	file_store := directory.NewDirectoryFileStore(org_config)
+
+	switch op.Type {
+	case api_proto.ReplicationOp_SET_SUBJECT:
+		return self.db.SetBuffer(org_config,
+			datastorePathFromProto(op.Pathspec), op.Data, nil)
+
+	case api_proto.ReplicationOp_DELETE_SUBJECT:
+		return self.db.DeleteSubject(org_config,
+			datastorePathFromProto(op.Pathspec))
+
+	case api_proto.ReplicationOp_WRITE_FILE, api_proto.ReplicationOp_TRUNCATE_FILE:
+		writer, err := file_store.WriteFile(filestorePathFromProto(op.Pathspec))
+		if err != nil {
+			return err
+		}
+		defer writer.Close()
+
+		if op.Type == api_proto.ReplicationOp_TRUNCATE_FILE {
+			return writer.Truncate()
+		}
+
+		_, err = writer.Write(op.Data)
+		return err
+
+	case api_proto.ReplicationOp_DELETE_FILE:
+		err := file_store.Delete(filestorePathFromProto(op.Pathspec))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+
+	case api_proto.ReplicationOp_MOVE_FILE:
+		return file_store.Move(filestorePathFromProto(op.Pathspec),
+			filestorePathFromProto(op.Destination))
+	}
+
+	return fmt.Errorf("Unknown op type %v", op.Type)
+}
+
+// Same layout as the org manager uses.
+func (self *Receiver) orgConfig(org_id string) *config_proto.Config {
+	if utils.IsRootOrg(org_id) {
+		return self.config_obj
+	}
+
+	result := &config_proto.Config{
+		OrgId: org_id,
+		Datastore: &config_proto.DatastoreConfig{
+			Location: filepath.Join(
+				self.config_obj.Datastore.Location, "orgs", org_id),
+			FilestoreDirectory: filepath.Join(
+				self.config_obj.Datastore.FilestoreDirectory, "orgs", org_id),
+		},
+	}
+	return result
+}
+
+func (self *Receiver) Status() StandbyState {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return *self.state
+}
+
+func NewReceiver(config_obj *config_proto.Config) (*Receiver, error) {
+	state, err := LoadState(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Promoted {
+		return nil, fmt.Errorf("%w (promoted at %v)", PromotedError,
+			time.Unix(state.PromotedTime, 0).UTC())
+	}
+
+	return &Receiver{
+		config_obj: config_obj,
+		state:      state,
+		db:         &datastore.FileBaseDataStore{},
+	}, nil
+}
@@ -0,0 +1,228 @@
+package standby
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+)
+
+var (
+	standbyOpsSent = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "standby_replication_ops_sent",
+		Help: "Total number of datastore ops shipped to the standby.",
+	})
+
+	standbySendErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "standby_replication_send_errors",
+		Help: "Total number of failed attempts to ship ops to the standby.",
+	})
+
+	standbyPendingBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "standby_replication_pending_bytes",
+		Help: "Size of ops queued for the standby.",
+	})
+
+	// How long to wait before retrying a failed send.
+	retryDuration = 10 * time.Second
+
+	// Maximum number of ops sent in one batch.
+	batchSize = 100
+)
+
+// Ships ops to the standby in the order they were queued.
+type Sender struct {
+	config_obj *config_proto.Config
+
+	mu       sync.Mutex
+	buffer   *BufferFile
+	node     string
+	epoch    int64
+	sequence uint64
+
+	// Signalled when new ops are queued.
+	ready chan bool
+}
+
+func (self *Sender) Enqueue(op *api_proto.ReplicationOp) error {
+	self.mu.Lock()
+	self.sequence++
+	op.Node = self.node
+	op.Epoch = self.epoch
+	op.Sequence = self.sequence
+	err := self.buffer.Enqueue(op)
+	self.mu.Unlock()
+
+	if err != nil {
+		logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+		logger.Error("<red>Standby Replication</> Unable to queue op: %v", err)
+		return err
+	}
+
+	select {
+	case self.ready <- true:
+	default:
+	}
+
+	return nil
+}
+
+func (self *Sender) Start(ctx context.Context, wg *sync.WaitGroup) error {
+	creds, err := getCreds(self.config_obj, false)
+	if err != nil {
+		return err
+	}
+
+	address := self.config_obj.Datastore.Replication.StandbyAddress
+	conn, err := grpc.DialContext(ctx, address,
+		grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+
+	client := api_proto.NewStandbyClient(conn)
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> standby replication to %v", address)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer conn.Close()
+		defer self.buffer.Close()
+
+		connected := true
+		for {
+			err := self.sendBatch(ctx, client)
+			switch {
+			case err == nil:
+				if !connected {
+					logger.Info("<green>Standby Replication</> Connected to %v",
+						address)
+					connected = true
+				}
+				continue
+
+			case errors.Is(err, io.EOF):
+				// Nothing to send - wait for more ops.
+				select {
+				case <-ctx.Done():
+					return
+				case <-self.ready:
+				case <-time.After(retryDuration):
+				}
+
+			default:
+				standbySendErrors.Inc()
+				if connected {
+					logger.Error("<red>Standby Replication</> Unable to send "+
+						"to %v, will queue until it is available: %v",
+						address, err)
+					connected = false
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(retryDuration):
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (self *Sender) sendBatch(
+	ctx context.Context, client api_proto.StandbyClient) error {
+	standbyPendingBytes.Set(float64(self.buffer.Pending()))
+
+	ops, err := self.buffer.Peek(batchSize)
+	if err != nil {
+		return err
+	}
+
+	sub_ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	_, err = client.Replicate(sub_ctx, &api_proto.ReplicationBatch{Ops: ops})
+	if err != nil {
+		return err
+	}
+
+	standbyOpsSent.Add(float64(len(ops)))
+	return self.buffer.Ack()
+}
+
+func NewSender(config_obj *config_proto.Config) (*Sender, error) {
+	buffer_file := config_obj.Datastore.Replication.BufferFile
+	if buffer_file == "" {
+		buffer_file = filepath.Join(
+			config_obj.Datastore.Location, "replication.buffer")
+	}
+
+	fd, err := os.OpenFile(buffer_file, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer, err := NewBufferFile(config_obj, fd)
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+
+	return &Sender{
+		config_obj: config_obj,
+		buffer:     buffer,
+		node:       services.GetNodeName(config_obj.Frontend),
+		epoch:      time.Now().UnixNano(),
+		ready:      make(chan bool, 1),
+	}, nil
+}
+
+// Frontends and the standby authenticate each other with the
+// frontend certificate.
+func getCreds(config_obj *config_proto.Config,
+	server bool) (credentials.TransportCredentials, error) {
+	if config_obj.Frontend == nil || config_obj.Client == nil {
+		return nil, errors.New("Frontend not configured")
+	}
+
+	cert, err := tls.X509KeyPair(
+		[]byte(config_obj.Frontend.Certificate),
+		[]byte(config_obj.Frontend.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	CA_Pool := x509.NewCertPool()
+	CA_Pool.AppendCertsFromPEM([]byte(config_obj.Client.CaCertificate))
+
+	if server {
+		return credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientCAs:    CA_Pool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		}), nil
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      CA_Pool,
+		ServerName:   config_obj.Client.PinnedServerName,
+	}), nil
+}
@@ -0,0 +1,157 @@
+// Keeps a warm standby server up to date by shipping all datastore
+// and file store writes to it as they happen.
+//
+// On the frontends every write is applied locally, then queued in a
+// file backed buffer and shipped in order to the standby. On the
+// standby the `velociraptor standby` command applies the writes to
+// its own datastore directory. When the frontend host fails, the
+// standby is promoted (`velociraptor standby promote`) and started
+// as a regular frontend on the replicated datastore.
+//
+// The standby must be seeded with a copy of the datastore (e.g. from
+// a backup) before replication starts - only writes made after
+// replication is enabled are shipped.
+package standby
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	// All orgs share the same sender.
+	mu       sync.Mutex
+	g_sender *Sender
+)
+
+func IsReplicating(config_obj *config_proto.Config) bool {
+	return config_obj.Datastore != nil &&
+		config_obj.Datastore.Replication != nil &&
+		config_obj.Datastore.Replication.StandbyAddress != ""
+}
+
+func getSender(ctx context.Context, wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*Sender, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if g_sender != nil {
+		return g_sender, nil
+	}
+
+	sender, err := NewSender(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sender.Start(ctx, wg)
+	if err != nil {
+		return nil, err
+	}
+
+	g_sender = sender
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+
+		mu.Lock()
+		g_sender = nil
+		mu.Unlock()
+	}()
+
+	return sender, nil
+}
+
+// Install the replicating datastore and file store for this org.
+func StartStandbyReplication(
+	ctx context.Context, wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+	if !IsReplicating(config_obj) {
+		return nil
+	}
+
+	sender, err := getSender(ctx, wg, config_obj)
+	if err != nil {
+		return err
+	}
+
+	// The datastore is shared by all orgs. Minions access the
+	// datastore through the master so only the master replicates
+	// it.
+	if utils.IsRootOrg(config_obj.OrgId) && services.IsMaster(config_obj) {
+		db, err := datastore.GetDB(config_obj)
+		if err != nil {
+			return err
+		}
+		datastore.OverrideDatastoreImplementation(
+			NewReplicatingDataStore(db, sender))
+	}
+
+	// Each frontend writes to the file store directly so they all
+	// replicate it.
+	file_store_factory := file_store.GetFileStore(config_obj)
+	if file_store_factory != nil {
+		file_store.OverrideFilestoreImplementation(config_obj,
+			NewReplicatingFileStore(config_obj, file_store_factory, sender))
+	}
+
+	return nil
+}
+
+// Serve replication on the standby until the context is done.
+func StartStandbyServer(
+	ctx context.Context, wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*Receiver, error) {
+
+	receiver, err := NewReceiver(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := getCreds(config_obj, true)
+	if err != nil {
+		return nil, err
+	}
+
+	address := config_obj.Datastore.Replication.BindAddress
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	server := grpc.NewServer(grpc.Creds(creds))
+	api_proto.RegisterStandbyServer(server, receiver)
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> standby replication server on %v", address)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+
+		err := server.Serve(listener)
+		if err != nil {
+			logger.Error("Standby server: %v", err)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	return receiver, nil
+}
@@ -0,0 +1,106 @@
+package standby
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store/directory"
+	"www.velocidex.com/golang/velociraptor/file_store/path_specs"
+)
+
+func makeConfig(t *testing.T, dir string) *config_proto.Config {
+	err := os.MkdirAll(dir, 0700)
+	require.NoError(t, err)
+
+	return &config_proto.Config{
+		Client:   &config_proto.ClientConfig{PinnedServerName: "VelociraptorServer"},
+		Frontend: &config_proto.FrontendConfig{Hostname: "primary", BindPort: 8000},
+		Datastore: &config_proto.DatastoreConfig{
+			Location:           dir,
+			FilestoreDirectory: dir,
+			Replication: &config_proto.DatastoreReplicationConfig{
+				StandbyAddress: "standby:8003",
+			},
+		},
+	}
+}
+
+func TestReplication(t *testing.T) {
+	dir, err := ioutil.TempDir("", "standby_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	primary_config := makeConfig(t, filepath.Join(dir, "primary"))
+	standby_config := makeConfig(t, filepath.Join(dir, "standby"))
+
+	sender, err := NewSender(primary_config)
+	require.NoError(t, err)
+	defer sender.buffer.Close()
+
+	db := NewReplicatingDataStore(&datastore.FileBaseDataStore{}, sender)
+	file_store := NewReplicatingFileStore(primary_config,
+		directory.NewDirectoryFileStore(primary_config), sender)
+
+	// Write a subject and a file on the primary.
+	urn := path_specs.NewUnsafeDatastorePath("clients", "C.123", "ping")
+	err = db.SetSubject(primary_config, urn,
+		&api_proto.ClientMetadata{ClientId: "C.123"})
+	assert.NoError(t, err)
+
+	path := path_specs.NewUnsafeFilestorePath("clients", "C.123", "uploads", "a:b")
+	writer, err := file_store.WriteFile(path)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("hello "))
+	assert.NoError(t, err)
+	_, err = writer.Write([]byte("world"))
+	assert.NoError(t, err)
+	writer.Close()
+
+	ops, err := sender.buffer.Peek(100)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(ops))
+
+	receiver, err := NewReceiver(standby_config)
+	require.NoError(t, err)
+
+	batch := &api_proto.ReplicationBatch{Ops: ops}
+	sequence, err := receiver.applyBatch(batch)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), sequence)
+	assert.NoError(t, sender.buffer.Ack())
+
+	// Resending the same batch (e.g. the ack was lost) does not
+	// append the data twice.
+	_, err = receiver.applyBatch(batch)
+	assert.NoError(t, err)
+
+	// The standby now has the same data.
+	metadata := &api_proto.ClientMetadata{}
+	err = (&datastore.FileBaseDataStore{}).GetSubject(standby_config, urn, metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, "C.123", metadata.ClientId)
+
+	reader, err := directory.NewDirectoryFileStore(standby_config).ReadFile(path)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	reader.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+
+	// The state survives a restart of the standby.
+	receiver, err = NewReceiver(standby_config)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), receiver.Status().Nodes["primary-8000"].Sequence)
+
+	// Once promoted the standby refuses replication.
+	assert.NoError(t, Promote(standby_config))
+	_, err = NewReceiver(standby_config)
+	assert.ErrorIs(t, err, PromotedError)
+}
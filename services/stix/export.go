@@ -0,0 +1,400 @@
+package stix
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/google/uuid"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/iocs"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	// The namespace STIX 2.1 uses for deterministic identifiers of
+	// cyber observables (section 2.9).
+	stixNamespace = uuid.MustParse("00abedb4-aa42-466c-9c01-fed23315a9b7")
+)
+
+type ExportOptions struct {
+	HuntId string
+
+	// Only match the indicators in these IOC tables. By default all
+	// the STIX tables are used.
+	Tables []string
+
+	// Only match the results of these artifact sources. By default
+	// all the hunt's sources are used.
+	Artifacts []string
+
+	// Only match these columns. By default all columns are matched.
+	Columns []string
+}
+
+type indicator struct {
+	ioc_type     string
+	value        string
+	indicator_id string
+}
+
+// All the times an indicator was seen on a client.
+type finding struct {
+	indicator *indicator
+	client_id string
+	hostname  string
+	count     int
+}
+
+// Export the hunt results which match indicators as a STIX bundle.
+// Each match is exported as an observed-data object and, if the
+// indicator came from STIX, a sighting of the indicator on the
+// client where it was found.
+func ExportHunt(ctx context.Context, config_obj *config_proto.Config,
+	options *ExportOptions) (*ordereddict.Dict, error) {
+
+	hunt_dispatcher, err := services.GetHuntDispatcher(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	hunt_obj, pres := hunt_dispatcher.GetHunt(options.HuntId)
+	if !pres {
+		return nil, errors.New("Hunt not found")
+	}
+
+	index, err := buildIndex(ctx, config_obj, options.Tables)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make(map[string]*finding)
+	hunt_path_manager := paths.NewHuntPathManager(hunt_obj.HuntId)
+	file_store_factory := file_store.GetFileStore(config_obj)
+
+	for _, artifact_source := range hunt_obj.ArtifactSources {
+		if len(options.Artifacts) > 0 &&
+			!utils.InString(options.Artifacts, artifact_source) {
+			continue
+		}
+
+		reader, err := result_sets.NewResultSetReader(file_store_factory,
+			hunt_path_manager.Results(artifact_source))
+		if err != nil {
+			// No results were merged for this source.
+			continue
+		}
+
+		for row := range reader.Rows(ctx) {
+			matchRow(row, options.Columns, index, findings)
+		}
+		reader.Close()
+	}
+
+	stix_config := config_obj.Stix
+	if stix_config == nil {
+		stix_config = &config_proto.STIXConfig{}
+	}
+
+	identity_name := stix_config.IdentityName
+	if identity_name == "" {
+		identity_name = "Velociraptor"
+	}
+
+	now := utils.GetTime().Now()
+	exporter := &bundleExporter{
+		hunt_id:      hunt_obj.HuntId,
+		created:      formatTime(now),
+		first_seen:   formatTime(time.Unix(0, int64(hunt_obj.StartTime)*1000)),
+		identity_id:  deterministicId("identity", "organization|"+identity_name),
+		seen_objects: make(map[string]bool),
+	}
+	if hunt_obj.StartTime == 0 {
+		exporter.first_seen = formatTime(time.Unix(0, int64(hunt_obj.CreateTime)*1000))
+	}
+
+	exporter.add(ordereddict.NewDict().
+		Set("type", "identity").
+		Set("spec_version", "2.1").
+		Set("id", exporter.identity_id).
+		Set("created", exporter.created).
+		Set("modified", exporter.created).
+		Set("name", identity_name).
+		Set("identity_class", "organization"))
+
+	// Export in a stable order.
+	keys := make([]string, 0, len(findings))
+	for k := range findings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		exporter.addFinding(findings[k])
+	}
+
+	return ordereddict.NewDict().
+		Set("type", "bundle").
+		Set("id", "bundle--"+uuid.New().String()).
+		Set("objects", exporter.objects), nil
+}
+
+// Map of lowercased value to the indicators with that value.
+func buildIndex(ctx context.Context, config_obj *config_proto.Config,
+	tables []string) (map[string][]*indicator, error) {
+
+	if len(tables) == 0 {
+		all_tables, err := iocs.ListTables(config_obj)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, table := range all_tables {
+			if table.Source == SOURCE {
+				tables = append(tables, table.Name)
+			}
+		}
+	}
+
+	index := make(map[string][]*indicator)
+	for _, name := range tables {
+		rows, err := iocs.ReadTable(ctx, config_obj, name)
+		if err != nil {
+			return nil, err
+		}
+
+		for row := range rows {
+			ioc_type, _ := row.GetString("Type")
+			value, _ := row.GetString("Value")
+			indicator_id, _ := row.GetString("IndicatorId")
+
+			key := strings.ToLower(strings.TrimSpace(value))
+			if key == "" {
+				continue
+			}
+
+			index[key] = append(index[key], &indicator{
+				ioc_type:     ioc_type,
+				value:        value,
+				indicator_id: indicator_id,
+			})
+		}
+	}
+
+	return index, nil
+}
+
+func matchRow(row *ordereddict.Dict, columns []string,
+	index map[string][]*indicator, findings map[string]*finding) {
+
+	client_id, _ := row.GetString("ClientId")
+	hostname, _ := row.GetString("Fqdn")
+
+	keys := columns
+	if len(keys) == 0 {
+		keys = row.Keys()
+	}
+
+	for _, column := range keys {
+		value, _ := row.GetString(column)
+		matches, pres := index[strings.ToLower(strings.TrimSpace(value))]
+		if !pres {
+			continue
+		}
+
+		for _, match := range matches {
+			key := client_id + "|" + iocs.Key(match.ioc_type, match.value) +
+				"|" + match.indicator_id
+			item, pres := findings[key]
+			if !pres {
+				item = &finding{
+					indicator: match,
+					client_id: client_id,
+					hostname:  hostname,
+				}
+				findings[key] = item
+			}
+			item.count++
+		}
+	}
+}
+
+type bundleExporter struct {
+	hunt_id     string
+	created     string
+	first_seen  string
+	identity_id string
+
+	objects      []*ordereddict.Dict
+	seen_objects map[string]bool
+}
+
+// Add an object unless an object with the same id was already added.
+func (self *bundleExporter) add(obj *ordereddict.Dict) {
+	id, _ := obj.GetString("id")
+	if self.seen_objects[id] {
+		return
+	}
+	self.seen_objects[id] = true
+	self.objects = append(self.objects, obj)
+}
+
+func (self *bundleExporter) addFinding(item *finding) {
+	// The client is the system the indicator was sighted on.
+	name := item.hostname
+	if name == "" {
+		name = item.client_id
+	}
+
+	system_id := deterministicId("identity", "system|"+item.client_id)
+	self.add(ordereddict.NewDict().
+		Set("type", "identity").
+		Set("spec_version", "2.1").
+		Set("id", system_id).
+		Set("created_by_ref", self.identity_id).
+		Set("created", self.created).
+		Set("modified", self.created).
+		Set("name", name).
+		Set("identity_class", "system").
+		Set("x_velociraptor_client_id", item.client_id))
+
+	var observed_data_refs []string
+	observable := newObservable(item.indicator.ioc_type, item.indicator.value)
+	if observable != nil {
+		observable_id, _ := observable.GetString("id")
+		self.add(observable)
+
+		observed_data_id := deterministicId("observed-data",
+			self.hunt_id+"|"+item.client_id+"|"+observable_id)
+		self.add(ordereddict.NewDict().
+			Set("type", "observed-data").
+			Set("spec_version", "2.1").
+			Set("id", observed_data_id).
+			Set("created_by_ref", self.identity_id).
+			Set("created", self.created).
+			Set("modified", self.created).
+			Set("first_observed", self.first_seen).
+			Set("last_observed", self.created).
+			Set("number_observed", item.count).
+			Set("object_refs", []string{observable_id}).
+			Set("x_velociraptor_hunt_id", self.hunt_id).
+			Set("x_velociraptor_client_id", item.client_id))
+		observed_data_refs = append(observed_data_refs, observed_data_id)
+	}
+
+	// Only indicators which came from STIX can be sighted.
+	if !strings.HasPrefix(item.indicator.indicator_id, "indicator--") {
+		return
+	}
+
+	sighting := ordereddict.NewDict().
+		Set("type", "sighting").
+		Set("spec_version", "2.1").
+		Set("id", deterministicId("sighting",
+			self.hunt_id+"|"+item.client_id+"|"+item.indicator.indicator_id)).
+		Set("created_by_ref", self.identity_id).
+		Set("created", self.created).
+		Set("modified", self.created).
+		Set("first_seen", self.first_seen).
+		Set("last_seen", self.created).
+		Set("count", item.count).
+		Set("sighting_of_ref", item.indicator.indicator_id).
+		Set("where_sighted_refs", []string{system_id}).
+		Set("x_velociraptor_hunt_id", self.hunt_id)
+
+	if len(observed_data_refs) > 0 {
+		sighting.Set("observed_data_refs", observed_data_refs)
+	}
+
+	self.add(sighting)
+}
+
+// Hash names as used in STIX hashes dictionaries.
+var hashNames = map[string]string{
+	"md5":    "MD5",
+	"sha1":   "SHA-1",
+	"sha256": "SHA-256",
+	"sha512": "SHA-512",
+}
+
+// Build the STIX cyber observable for an IOC table value. Returns
+// nil for types which have no STIX equivalent.
+func newObservable(ioc_type, value string) *ordereddict.Dict {
+	var sco_type string
+	properties := ordereddict.NewDict()
+
+	switch ioc_type {
+	case "md5", "sha1", "sha256", "sha512":
+		sco_type = "file"
+		properties.Set("hashes", ordereddict.NewDict().
+			Set(hashNames[ioc_type], strings.ToLower(value)))
+
+	case "filename":
+		sco_type = "file"
+		properties.Set("name", value)
+
+	case "ip-dst", "ip-src":
+		sco_type = "ipv4-addr"
+		if strings.Contains(value, ":") {
+			sco_type = "ipv6-addr"
+		}
+		properties.Set("value", value)
+
+	case "domain", "hostname":
+		sco_type = "domain-name"
+		properties.Set("value", strings.ToLower(value))
+
+	case "url":
+		sco_type = "url"
+		properties.Set("value", value)
+
+	case "email", "email-src", "email-dst":
+		sco_type = "email-addr"
+		properties.Set("value", strings.ToLower(value))
+
+	case "mutex":
+		sco_type = "mutex"
+		properties.Set("name", value)
+
+	case "regkey":
+		sco_type = "windows-registry-key"
+		properties.Set("key", value)
+
+	default:
+		return nil
+	}
+
+	// The id is derived from the id contributing properties so the
+	// same observable always has the same id.
+	result := ordereddict.NewDict().
+		Set("type", sco_type).
+		Set("spec_version", "2.1").
+		Set("id", sco_type+"--"+uuid.NewSHA1(stixNamespace,
+			[]byte(json.MustMarshalString(properties))).String())
+
+	for _, k := range properties.Keys() {
+		v, _ := properties.Get(k)
+		result.Set(k, v)
+	}
+
+	return result
+}
+
+// Ids of objects we create are stable so exporting a hunt twice
+// updates the same objects rather than creating new ones.
+func deterministicId(stix_type, name string) string {
+	return stix_type + "--" + uuid.NewSHA1(stixNamespace,
+		[]byte(stix_type+"|"+name)).String()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
@@ -0,0 +1,193 @@
+package stix
+
+import (
+	"errors"
+	"strings"
+)
+
+// Maps STIX object paths to the IOC types used by the IOC tables
+// (these are the same as the MISP attribute types).
+var objectPathTypes = map[string]string{
+	"file:hashes.md5":                "md5",
+	"file:hashes.sha1":               "sha1",
+	"file:hashes.sha256":             "sha256",
+	"file:hashes.sha512":             "sha512",
+	"file:name":                      "filename",
+	"ipv4-addr:value":                "ip-dst",
+	"ipv6-addr:value":                "ip-dst",
+	"domain-name:value":              "domain",
+	"url:value":                      "url",
+	"email-addr:value":               "email",
+	"mutex:name":                     "mutex",
+	"windows-registry-key:key":       "regkey",
+	"process:name":                   "process",
+	"x509-certificate:hashes.sha1":   "x509-fingerprint-sha1",
+	"x509-certificate:hashes.sha256": "x509-fingerprint-sha256",
+	"network-traffic:dst_ref.value":  "ip-dst",
+	"email-message:from_ref.value":   "email-src",
+	"email-message:sender_ref.value": "email-src",
+	"email-message:to_ref[*].value":  "email-dst",
+}
+
+// An indicator value extracted from a pattern.
+type Observable struct {
+	Type  string
+	Value string
+}
+
+type tokenType int
+
+const (
+	tokenPath tokenType = iota
+	tokenString
+	tokenPunct
+)
+
+type token struct {
+	kind  tokenType
+	value string
+}
+
+// Split a STIX pattern into tokens. Object paths may contain quoted
+// components (e.g. file:hashes.'SHA-256') which are unquoted.
+func tokenize(pattern string) ([]token, error) {
+	var result []token
+
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+
+		case c == '\'':
+			value, next, err := readString(pattern, i)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, token{kind: tokenString, value: value})
+			i = next
+
+		case strings.IndexByte("[](),", c) >= 0:
+			result = append(result, token{kind: tokenPunct, value: string(c)})
+			i++
+
+		case strings.IndexByte("=!<>", c) >= 0:
+			start := i
+			for i < len(pattern) && strings.IndexByte("=!<>", pattern[i]) >= 0 {
+				i++
+			}
+			result = append(result, token{
+				kind: tokenPunct, value: pattern[start:i]})
+
+		default:
+			path := strings.Builder{}
+			for i < len(pattern) {
+				c := pattern[i]
+				if c == '\'' && strings.HasSuffix(path.String(), ".") {
+					value, next, err := readString(pattern, i)
+					if err != nil {
+						return nil, err
+					}
+					path.WriteString(value)
+					i = next
+					continue
+				}
+
+				if strings.IndexByte(" \t\r\n'()[],=!<>", c) >= 0 {
+					// A list index is part of the path.
+					if c == '[' && i+2 < len(pattern) &&
+						pattern[i+1] == '*' && pattern[i+2] == ']' {
+						path.WriteString("[*]")
+						i += 3
+						continue
+					}
+					break
+				}
+				path.WriteByte(c)
+				i++
+			}
+			result = append(result, token{kind: tokenPath, value: path.String()})
+		}
+	}
+
+	return result, nil
+}
+
+// Read a single quoted string starting at pattern[start]. Returns
+// the unescaped value and the offset after the closing quote.
+func readString(pattern string, start int) (string, int, error) {
+	result := strings.Builder{}
+	for i := start + 1; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				result.WriteByte(pattern[i])
+			}
+		case '\'':
+			return result.String(), i + 1, nil
+		default:
+			result.WriteByte(pattern[i])
+		}
+	}
+	return "", 0, errors.New("Unterminated string in pattern")
+}
+
+// Normalize an object path so hash names match regardless of how
+// they are written (e.g. 'SHA-256' and SHA256).
+func normalizePath(path string) string {
+	path = strings.ToLower(path)
+	idx := strings.Index(path, ":hashes.")
+	if idx >= 0 {
+		prefix := path[:idx+len(":hashes.")]
+		return prefix + strings.ReplaceAll(path[len(prefix):], "-", "")
+	}
+	return path
+}
+
+// Extract the observables a STIX pattern matches on. Only equality
+// and IN comparisons against object paths we know about are
+// extracted - anything else (e.g. MATCHES or negated comparisons)
+// can not be expressed as an IOC table row and is ignored.
+func ParsePattern(pattern string) ([]*Observable, error) {
+	tokens, err := tokenize(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Observable
+	add := func(path, value string) {
+		ioc_type, pres := objectPathTypes[normalizePath(path)]
+		if pres && value != "" {
+			result = append(result, &Observable{Type: ioc_type, Value: value})
+		}
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].kind != tokenPath || !strings.Contains(tokens[i].value, ":") {
+			continue
+		}
+		path := tokens[i].value
+
+		if i+2 < len(tokens) &&
+			tokens[i+1].kind == tokenPunct && tokens[i+1].value == "=" &&
+			tokens[i+2].kind == tokenString {
+			add(path, tokens[i+2].value)
+			i += 2
+			continue
+		}
+
+		if i+2 < len(tokens) &&
+			tokens[i+1].kind == tokenPath &&
+			strings.ToUpper(tokens[i+1].value) == "IN" &&
+			tokens[i+2].value == "(" {
+			for i += 3; i < len(tokens) && tokens[i].value != ")"; i++ {
+				if tokens[i].kind == tokenString {
+					add(path, tokens[i].value)
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
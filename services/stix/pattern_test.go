@@ -0,0 +1,60 @@
+package stix
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestParsePattern(t *testing.T) {
+	for _, test_case := range []struct {
+		pattern  string
+		expected []Observable
+	}{
+		{`[file:hashes.'SHA-256' = 'E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855']`,
+			[]Observable{{"sha256", "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855"}}},
+
+		{`[file:hashes.MD5 = 'd41d8cd98f00b204e9800998ecf8427e' OR file:name = 'evil.exe']`,
+			[]Observable{
+				{"md5", "d41d8cd98f00b204e9800998ecf8427e"},
+				{"filename", "evil.exe"},
+			}},
+
+		{`[ipv4-addr:value IN ('10.0.0.1', '10.0.0.2')] AND [domain-name:value = 'evil.com']`,
+			[]Observable{
+				{"ip-dst", "10.0.0.1"},
+				{"ip-dst", "10.0.0.2"},
+				{"domain", "evil.com"},
+			}},
+
+		// Escaped quotes and list indexes.
+		{`[email-message:to_ref[*].value = 'o\'brien@example.com']`,
+			[]Observable{{"email-dst", "o'brien@example.com"}}},
+
+		{`[windows-registry-key:key = 'HKEY_LOCAL_MACHINE\\Software\\Evil']`,
+			[]Observable{{"regkey", `HKEY_LOCAL_MACHINE\Software\Evil`}}},
+
+		// Comparisons which are not equality are ignored.
+		{`[url:value MATCHES '^http://evil'] OR [url:value != 'http://good.com']`, nil},
+		{`[file:name NOT = 'good.exe']`, nil},
+
+		// Unknown objects are ignored.
+		{`[artifact:mime_type = 'application/exe']`, nil},
+
+		// Qualifiers are ignored.
+		{`[url:value = 'http://evil.com/'] START t'2020-01-01T00:00:00Z' STOP t'2021-01-01T00:00:00Z'`,
+			[]Observable{{"url", "http://evil.com/"}}},
+	} {
+		observables, err := ParsePattern(test_case.pattern)
+		assert.NoError(t, err, test_case.pattern)
+
+		var result []Observable
+		for _, observable := range observables {
+			result = append(result, *observable)
+		}
+		assert.Equal(t, test_case.expected, result, test_case.pattern)
+	}
+
+	_, err := ParsePattern(`[file:name = 'unterminated]`)
+	assert.Error(t, err)
+}
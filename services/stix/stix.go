@@ -0,0 +1,415 @@
+/*
+  The STIX service exchanges threat intelligence as STIX 2.1 bundles.
+
+  Indicators in imported bundles are converted to IOC table rows (see
+  the iocs package) so hunts can use them in the same way as
+  indicators synced from MISP. Each row records the indicator's id,
+  its source (the identity which created it, or the feed) and when
+  it expires. Bundles are merged into the table so a table may
+  collect indicators from several bundles and sources. Revoked
+  indicators are removed and expired indicators are pruned
+  periodically.
+
+  Hunt findings are exported as a bundle of observed-data objects
+  and sightings of the matching indicators, to be shared with
+  partners (see ExportHunt).
+
+  The service runs on the master node and periodically syncs the
+  configured feeds.
+*/
+
+package stix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/iocs"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql/networking"
+)
+
+const (
+	// IOC tables imported from STIX bundles have this source.
+	SOURCE = "stix"
+
+	// Do not read unreasonably large bundles into memory.
+	maxBundleSize = 100 * 1024 * 1024
+)
+
+var (
+	// Imports read and then rewrite the table.
+	import_mu sync.Mutex
+)
+
+// The parts of a STIX object we use. Objects of all types are
+// decoded into this.
+type stixObject struct {
+	Type         string `json:"type"`
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	CreatedByRef string `json:"created_by_ref"`
+	Revoked      bool   `json:"revoked"`
+	Pattern      string `json:"pattern"`
+	PatternType  string `json:"pattern_type"`
+	ValidFrom    string `json:"valid_from"`
+	ValidUntil   string `json:"valid_until"`
+}
+
+type stixBundle struct {
+	Type    string        `json:"type"`
+	Id      string        `json:"id"`
+	Objects []*stixObject `json:"objects"`
+}
+
+// Summarizes an import.
+type ImportStats struct {
+	Indicators int `json:"indicators"`
+	Imported   int `json:"imported"`
+	Revoked    int `json:"revoked"`
+	Expired    int `json:"expired"`
+
+	// Indicators whose patterns have no observables we can store
+	// in an IOC table.
+	Unsupported int `json:"unsupported"`
+}
+
+func parseTime(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// Convert the indicators in the bundle into IOC table rows.
+func bundleToRows(bundle *stixBundle, source string, now time.Time) (
+	rows []*ordereddict.Dict, revoked map[string]bool, stats *ImportStats) {
+
+	stats = &ImportStats{}
+	revoked = make(map[string]bool)
+
+	// Indicators are attributed to the identity that created them.
+	identities := make(map[string]string)
+	for _, obj := range bundle.Objects {
+		if obj.Type == "identity" && obj.Name != "" {
+			identities[obj.Id] = obj.Name
+		}
+	}
+
+	for _, obj := range bundle.Objects {
+		if obj.Type != "indicator" {
+			continue
+		}
+		stats.Indicators++
+
+		if obj.Revoked {
+			revoked[obj.Id] = true
+			stats.Revoked++
+			continue
+		}
+
+		var expires int64
+		if obj.ValidUntil != "" {
+			valid_until, err := parseTime(obj.ValidUntil)
+			if err == nil {
+				expires = valid_until.Unix()
+			}
+		}
+
+		if expires > 0 && expires <= now.Unix() {
+			// Remove any older version of the indicator.
+			revoked[obj.Id] = true
+			stats.Expired++
+			continue
+		}
+
+		if obj.PatternType != "" && obj.PatternType != "stix" {
+			stats.Unsupported++
+			continue
+		}
+
+		observables, err := ParsePattern(obj.Pattern)
+		if err != nil || len(observables) == 0 {
+			stats.Unsupported++
+			continue
+		}
+
+		indicator_source := identities[obj.CreatedByRef]
+		if indicator_source == "" {
+			indicator_source = source
+		}
+
+		for _, observable := range observables {
+			rows = append(rows, ordereddict.NewDict().
+				Set("Type", observable.Type).
+				Set("Value", observable.Value).
+				Set("IndicatorId", obj.Id).
+				Set("Name", obj.Name).
+				Set("Source", indicator_source).
+				Set("ValidFrom", obj.ValidFrom).
+				Set("Expires", expires))
+		}
+		stats.Imported++
+	}
+
+	return rows, revoked, stats
+}
+
+// Import the indicators in a STIX bundle into the named IOC table.
+// The table is created if needed. Indicators already in the table
+// are kept unless they are revoked, expired or replaced by a newer
+// version in the bundle.
+func Import(ctx context.Context, config_obj *config_proto.Config,
+	name, source string, serialized []byte) (*api_proto.IOCTable, *ImportStats, error) {
+
+	bundle := &stixBundle{}
+	err := json.Unmarshal(serialized, bundle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Invalid STIX bundle: %w", err)
+	}
+
+	if bundle.Type != "bundle" {
+		return nil, nil, fmt.Errorf("Invalid STIX bundle: type is %q", bundle.Type)
+	}
+
+	if source == "" {
+		source = name
+	}
+
+	import_mu.Lock()
+	defer import_mu.Unlock()
+
+	now := utils.GetTime().Now()
+	rows, revoked, stats := bundleToRows(bundle, source, now)
+
+	table, err := iocs.GetTable(config_obj, name)
+	if errors.Is(err, os.ErrNotExist) {
+		table = &api_proto.IOCTable{
+			Name:        name,
+			Source:      SOURCE,
+			Description: "Indicators imported from STIX bundles",
+		}
+		err = nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if table.Source != SOURCE {
+		return nil, nil, fmt.Errorf(
+			"IOC table %v is not a STIX table (source %v)", name, table.Source)
+	}
+
+	// The bundle's indicators replace their previous versions.
+	for _, row := range rows {
+		indicator_id, _ := row.GetString("IndicatorId")
+		revoked[indicator_id] = true
+	}
+
+	existing, err := iocs.ReadTable(ctx, config_obj, name)
+	if err == nil {
+		var kept []*ordereddict.Dict
+		for row := range existing {
+			indicator_id, _ := row.GetString("IndicatorId")
+			if !revoked[indicator_id] {
+				kept = append(kept, row)
+			}
+		}
+		rows = append(kept, rows...)
+	}
+
+	table.LastSync = now.Unix()
+	table.LastError = ""
+
+	err = iocs.SetTable(config_obj, table, rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return table, stats, nil
+}
+
+// Remove expired indicators from all the STIX tables.
+func Prune(ctx context.Context, config_obj *config_proto.Config) error {
+	tables, err := iocs.ListTables(config_obj)
+	if err != nil {
+		return err
+	}
+
+	import_mu.Lock()
+	defer import_mu.Unlock()
+
+	for _, table := range tables {
+		if table.Source != SOURCE {
+			continue
+		}
+
+		// ReadTable() already skips the expired indicators.
+		rows_chan, err := iocs.ReadTable(ctx, config_obj, table.Name)
+		if err != nil {
+			return err
+		}
+
+		var rows []*ordereddict.Dict
+		for row := range rows_chan {
+			rows = append(rows, row)
+		}
+
+		if uint64(len(rows)) == table.TotalIocs {
+			continue
+		}
+
+		err = iocs.SetTable(config_obj, table, rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Fetch the configured feeds and import them. If name is specified
+// only that feed is synced. A failing feed does not stop the other
+// feeds from syncing.
+func Sync(ctx context.Context, config_obj *config_proto.Config,
+	name string) ([]*api_proto.IOCTable, error) {
+
+	if config_obj.Stix == nil {
+		return nil, errors.New("STIX is not configured")
+	}
+
+	client, err := networking.GetDefaultHTTPClient(
+		config_obj.Client, config_obj.Stix.RootCa)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*api_proto.IOCTable{}
+	found := false
+	var last_err error
+	for _, feed := range config_obj.Stix.Feeds {
+		if name != "" && feed.Name != name {
+			continue
+		}
+		found = true
+
+		table, err := syncFeed(ctx, config_obj, client, feed)
+		if err != nil {
+			last_err = fmt.Errorf("Feed %v: %w", feed.Name, err)
+			continue
+		}
+		result = append(result, table)
+	}
+
+	if !found && name != "" {
+		return nil, fmt.Errorf("Feed %v is not configured", name)
+	}
+
+	return result, last_err
+}
+
+func syncFeed(ctx context.Context, config_obj *config_proto.Config,
+	client *http.Client, feed *config_proto.STIXFeedConfig) (
+	*api_proto.IOCTable, error) {
+
+	serialized, err := fetchBundle(ctx, client, feed)
+	if err == nil {
+		var table *api_proto.IOCTable
+		table, _, err = Import(ctx, config_obj, feed.Name, feed.Source, serialized)
+		if err == nil {
+			return table, nil
+		}
+	}
+
+	// Keep the previous indicators but record the error.
+	table, err1 := iocs.GetTable(config_obj, feed.Name)
+	if err1 == nil && table.Source == SOURCE {
+		table.LastError = err.Error()
+		_ = iocs.SetTableMetadata(config_obj, table)
+	}
+	return nil, err
+}
+
+func fetchBundle(ctx context.Context, client *http.Client,
+	feed *config_proto.STIXFeedConfig) ([]byte, error) {
+
+	req, err := http.NewRequestWithContext(ctx, "GET", feed.Url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// TAXII 2.1 servers return bundles with this content type.
+	req.Header.Set("Accept",
+		"application/taxii+json;version=2.1, application/stix+json;version=2.1, application/json")
+	if feed.Authorization != "" {
+		req.Header.Set("Authorization", feed.Authorization)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Fetching %v returned %v", feed.Url, resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxBundleSize))
+}
+
+func NewSTIXService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	interval := time.Duration(3600) * time.Second
+	if config_obj.Stix.SyncPeriodSeconds > 0 {
+		interval = time.Duration(
+			config_obj.Stix.SyncPeriodSeconds) * time.Second
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> STIX service for %v.",
+		services.GetOrgName(config_obj))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		// Sync as soon as we start.
+		delay := time.Duration(0)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-time.After(delay):
+				delay = interval
+
+				if len(config_obj.Stix.Feeds) > 0 {
+					_, err := Sync(ctx, config_obj, "")
+					if err != nil {
+						logger.Error("STIXService: %v", err)
+					}
+				}
+
+				err := Prune(ctx, config_obj)
+				if err != nil {
+					logger.Error("STIXService: pruning expired indicators: %v", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
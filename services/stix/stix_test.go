@@ -0,0 +1,302 @@
+package stix_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/iocs"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/stix"
+	"www.velocidex.com/golang/velociraptor/utils"
+
+	_ "www.velocidex.com/golang/velociraptor/result_sets/simple"
+)
+
+const (
+	artifact = "Custom.Hashes"
+	hash     = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+var bundle = `{"type": "bundle", "id": "bundle--1", "objects": [
+ {"type": "identity", "id": "identity--partner", "name": "Partner CERT",
+  "identity_class": "organization"},
+ {"type": "indicator", "id": "indicator--hash", "name": "Evil hash",
+  "created_by_ref": "identity--partner", "pattern_type": "stix",
+  "pattern": "[file:hashes.'SHA-256' = 'E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855']",
+  "valid_from": "2020-09-01T00:00:00Z"},
+ {"type": "indicator", "id": "indicator--domain", "name": "Evil domain",
+  "pattern_type": "stix",
+  "pattern": "[domain-name:value = 'evil.com']",
+  "valid_from": "2020-09-01T00:00:00Z", "valid_until": "2020-09-14T00:00:00Z"},
+ {"type": "indicator", "id": "indicator--old", "pattern_type": "stix",
+  "pattern": "[domain-name:value = 'old.com']",
+  "valid_from": "2020-01-01T00:00:00Z", "valid_until": "2020-02-01T00:00:00Z"},
+ {"type": "indicator", "id": "indicator--sigma", "pattern_type": "sigma",
+  "pattern": "title: Evil"}
+]}`
+
+type STIXTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *STIXTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.Services.HuntDispatcher = true
+	self.ConfigObj.Stix = &config_proto.STIXConfig{
+		IdentityName: "Example Corp",
+	}
+
+	self.TestSuite.SetupTest()
+}
+
+func (self *STIXTestSuite) readTable(name string) []string {
+	rows, err := iocs.ReadTable(self.Ctx, self.ConfigObj, name)
+	assert.NoError(self.T(), err)
+
+	result := []string{}
+	for row := range rows {
+		ioc_type, _ := row.GetString("Type")
+		value, _ := row.GetString("Value")
+		id, _ := row.GetString("IndicatorId")
+		source, _ := row.GetString("Source")
+		result = append(result, ioc_type+" "+value+" "+id+" "+source)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (self *STIXTestSuite) TestImport() {
+	now := time.Date(2020, 9, 10, 0, 0, 0, 0, time.UTC)
+	closer := utils.MockTime(&utils.MockClock{MockNow: now})
+	defer closer()
+
+	table, stats, err := stix.Import(self.Ctx, self.ConfigObj,
+		"PartnerIOCs", "Partner", []byte(bundle))
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), stix.SOURCE, table.Source)
+	assert.Equal(self.T(), uint64(2), table.TotalIocs)
+	assert.Equal(self.T(), &stix.ImportStats{
+		Indicators: 4, Imported: 2, Expired: 1, Unsupported: 1,
+	}, stats)
+
+	// Indicators are attributed to their creator if known.
+	assert.Equal(self.T(), []string{
+		"domain evil.com indicator--domain Partner",
+		"sha256 E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855 indicator--hash Partner CERT",
+	}, self.readTable("PartnerIOCs"))
+
+	// A later bundle updates and revokes indicators and adds to
+	// the existing ones.
+	_, _, err = stix.Import(self.Ctx, self.ConfigObj, "PartnerIOCs", "Partner",
+		[]byte(`{"type": "bundle", "id": "bundle--2", "objects": [
+ {"type": "indicator", "id": "indicator--hash", "revoked": true},
+ {"type": "indicator", "id": "indicator--domain",
+  "pattern": "[domain-name:value = 'evil.org']"},
+ {"type": "indicator", "id": "indicator--url",
+  "pattern": "[url:value = 'http://evil.com/']"}
+]}`))
+	assert.NoError(self.T(), err)
+
+	assert.Equal(self.T(), []string{
+		"domain evil.org indicator--domain Partner",
+		"url http://evil.com/ indicator--url Partner",
+	}, self.readTable("PartnerIOCs"))
+
+	// Tables from other sources can not be imported into.
+	assert.NoError(self.T(), iocs.SetTable(self.ConfigObj,
+		&api_proto.IOCTable{Name: "MISPHashes", Source: "misp"}, nil))
+	_, _, err = stix.Import(self.Ctx, self.ConfigObj,
+		"MISPHashes", "", []byte(bundle))
+	assert.Error(self.T(), err)
+
+	_, _, err = stix.Import(self.Ctx, self.ConfigObj,
+		"PartnerIOCs", "", []byte(`{"type": "indicator"}`))
+	assert.Error(self.T(), err)
+}
+
+func (self *STIXTestSuite) TestExpiry() {
+	clock := &utils.MockClock{MockNow: time.Date(2020, 9, 10, 0, 0, 0, 0, time.UTC)}
+	closer := utils.MockTime(clock)
+	defer closer()
+
+	_, _, err := stix.Import(self.Ctx, self.ConfigObj,
+		"PartnerIOCs", "Partner", []byte(bundle))
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 2, len(self.readTable("PartnerIOCs")))
+
+	// Expired indicators are no longer returned.
+	clock.MockNow = time.Date(2020, 9, 20, 0, 0, 0, 0, time.UTC)
+	assert.Equal(self.T(), 1, len(self.readTable("PartnerIOCs")))
+
+	// Pruning removes them from the table.
+	assert.NoError(self.T(), stix.Prune(self.Ctx, self.ConfigObj))
+	table, err := iocs.GetTable(self.ConfigObj, "PartnerIOCs")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), uint64(1), table.TotalIocs)
+}
+
+func (self *STIXTestSuite) TestSync() {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte(bundle))
+		}))
+	defer server.Close()
+
+	closer := utils.MockTime(&utils.MockClock{
+		MockNow: time.Date(2020, 9, 10, 0, 0, 0, 0, time.UTC)})
+	defer closer()
+
+	self.ConfigObj.Stix.Feeds = []*config_proto.STIXFeedConfig{{
+		Name:          "PartnerIOCs",
+		Url:           server.URL,
+		Authorization: "Bearer secret",
+	}, {
+		Name: "Forbidden",
+		Url:  server.URL,
+	}}
+
+	// The failing feed does not stop the other feed.
+	tables, err := stix.Sync(self.Ctx, self.ConfigObj, "")
+	assert.Error(self.T(), err)
+	assert.Equal(self.T(), 1, len(tables))
+	assert.Equal(self.T(), "PartnerIOCs", tables[0].Name)
+	assert.Equal(self.T(), 2, len(self.readTable("PartnerIOCs")))
+
+	_, err = stix.Sync(self.Ctx, self.ConfigObj, "Unknown")
+	assert.Error(self.T(), err)
+}
+
+func (self *STIXTestSuite) addHunt(hunt_obj *api_proto.Hunt, rows ...*ordereddict.Dict) {
+	db, err := datastore.GetDB(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	hunt_path_manager := paths.NewHuntPathManager(hunt_obj.HuntId)
+	assert.NoError(self.T(), db.SetSubject(self.ConfigObj,
+		hunt_path_manager.Path(), hunt_obj))
+
+	writer, err := result_sets.NewResultSetWriter(
+		file_store.GetFileStore(self.ConfigObj),
+		hunt_path_manager.Results(artifact),
+		json.DefaultEncOpts(), utils.SyncCompleter, result_sets.TruncateMode)
+	assert.NoError(self.T(), err)
+	for _, row := range rows {
+		writer.Write(row)
+	}
+	writer.Close()
+
+	hunt_dispatcher, err := services.GetHuntDispatcher(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.NoError(self.T(), hunt_dispatcher.Refresh(self.ConfigObj))
+}
+
+func (self *STIXTestSuite) TestExportHunt() {
+	now := time.Date(2020, 9, 10, 0, 0, 0, 0, time.UTC)
+	closer := utils.MockTime(&utils.MockClock{MockNow: now})
+	defer closer()
+
+	_, _, err := stix.Import(self.Ctx, self.ConfigObj,
+		"PartnerIOCs", "Partner", []byte(bundle))
+	assert.NoError(self.T(), err)
+
+	self.addHunt(&api_proto.Hunt{
+		HuntId:          "H.1",
+		State:           api_proto.Hunt_STOPPED,
+		StartTime:       uint64(now.Add(-time.Hour).UnixNano() / 1000),
+		ArtifactSources: []string{artifact},
+	},
+		ordereddict.NewDict().
+			Set("ClientId", "C.1").Set("Fqdn", "host1").
+			Set("Path", "C:/evil.exe").Set("Hash", hash),
+		ordereddict.NewDict().
+			Set("ClientId", "C.1").Set("Fqdn", "host1").
+			Set("Path", "C:/copy.exe").Set("Hash", hash),
+		ordereddict.NewDict().
+			Set("ClientId", "C.2").Set("Fqdn", "host2").
+			Set("Path", "C:/notepad.exe").Set("Hash", "0000"))
+
+	result, err := stix.ExportHunt(self.Ctx, self.ConfigObj,
+		&stix.ExportOptions{HuntId: "H.1", Columns: []string{"Hash"}})
+	assert.NoError(self.T(), err)
+
+	bundle_type, _ := result.GetString("type")
+	assert.Equal(self.T(), "bundle", bundle_type)
+
+	objects_any, _ := result.Get("objects")
+	objects := objects_any.([]*ordereddict.Dict)
+
+	by_type := make(map[string][]*ordereddict.Dict)
+	for _, obj := range objects {
+		obj_type, _ := obj.GetString("type")
+		by_type[obj_type] = append(by_type[obj_type], obj)
+	}
+
+	// The organization and the client the hash was seen on.
+	assert.Equal(self.T(), 2, len(by_type["identity"]))
+	assert.Equal(self.T(), 1, len(by_type["file"]))
+	assert.Equal(self.T(), 1, len(by_type["observed-data"]))
+	assert.Equal(self.T(), 1, len(by_type["sighting"]))
+
+	hashes, _ := by_type["file"][0].Get("hashes")
+	assert.Equal(self.T(), `{"SHA-256":"`+hash+`"}`,
+		json.MustMarshalString(hashes))
+
+	sighting := by_type["sighting"][0]
+	sighting_of_ref, _ := sighting.GetString("sighting_of_ref")
+	assert.Equal(self.T(), "indicator--hash", sighting_of_ref)
+
+	count, _ := sighting.GetInt64("count")
+	assert.Equal(self.T(), int64(2), count)
+
+	first_seen, _ := sighting.GetString("first_seen")
+	assert.Equal(self.T(), "2020-09-09T23:00:00.000Z", first_seen)
+
+	system_id, _ := by_type["identity"][1].GetString("id")
+	where_sighted_refs, _ := sighting.Get("where_sighted_refs")
+	assert.Equal(self.T(), []string{system_id}, where_sighted_refs)
+
+	observed_data_id, _ := by_type["observed-data"][0].GetString("id")
+	observed_data_refs, _ := sighting.Get("observed_data_refs")
+	assert.Equal(self.T(), []string{observed_data_id}, observed_data_refs)
+
+	// Exporting again produces the same object ids.
+	result2, err := stix.ExportHunt(self.Ctx, self.ConfigObj,
+		&stix.ExportOptions{HuntId: "H.1", Columns: []string{"Hash"}})
+	assert.NoError(self.T(), err)
+
+	objects_any, _ = result2.Get("objects")
+	sighting_id, _ := sighting.GetString("id")
+	found := false
+	for _, obj := range objects_any.([]*ordereddict.Dict) {
+		id, _ := obj.GetString("id")
+		if id == sighting_id {
+			found = true
+		}
+	}
+	assert.True(self.T(), found)
+
+	_, err = stix.ExportHunt(self.Ctx, self.ConfigObj,
+		&stix.ExportOptions{HuntId: "H.unknown"})
+	assert.Error(self.T(), err)
+}
+
+func TestSTIX(t *testing.T) {
+	suite.Run(t, &STIXTestSuite{})
+}
@@ -0,0 +1,118 @@
+package thehive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/vql/networking"
+)
+
+const (
+	maxResponseSize = 10 * 1024 * 1024
+)
+
+type createResponse struct {
+	Id string `json:"_id"`
+}
+
+// A minimal client for TheHive's v1 REST API.
+type TheHiveClient struct {
+	config_obj *config_proto.Config
+	client     *http.Client
+}
+
+func (self *TheHiveClient) post(ctx context.Context,
+	path string, request interface{}) (string, error) {
+
+	thehive_config := self.config_obj.Thehive
+	if thehive_config == nil || thehive_config.Url == "" {
+		return "", errors.New("TheHive is not configured")
+	}
+
+	serialized, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	url := strings.TrimSuffix(thehive_config.Url, "/") + path
+	req, err := http.NewRequestWithContext(ctx, "POST", url,
+		bytes.NewReader(serialized))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+thehive_config.ApiKey)
+	req.Header.Set("Content-Type", "application/json")
+	if thehive_config.Organisation != "" {
+		req.Header.Set("X-Organisation", thehive_config.Organisation)
+	}
+
+	resp, err := self.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("TheHive %v returned %v: %v",
+			path, resp.Status, string(body))
+	}
+
+	response := &createResponse{}
+	err = json.Unmarshal(body, response)
+	if err != nil {
+		return "", err
+	}
+	return response.Id, nil
+}
+
+func (self *TheHiveClient) CreateAlert(ctx context.Context,
+	alert *ordereddict.Dict) (string, error) {
+	return self.post(ctx, "/api/v1/alert", alert)
+}
+
+// Cases do not accept observables when they are created so they
+// are added one at a time.
+func (self *TheHiveClient) CreateCase(ctx context.Context,
+	thehive_case *ordereddict.Dict,
+	observables []*ordereddict.Dict) (string, error) {
+
+	case_id, err := self.post(ctx, "/api/v1/case", thehive_case)
+	if err != nil {
+		return "", err
+	}
+
+	for _, observable := range observables {
+		_, err := self.post(ctx, "/api/v1/case/"+case_id+"/observable",
+			observable)
+		if err != nil {
+			return case_id, err
+		}
+	}
+
+	return case_id, nil
+}
+
+func NewTheHiveClient(config_obj *config_proto.Config) (*TheHiveClient, error) {
+	client, err := networking.GetDefaultHTTPClient(config_obj.Client, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &TheHiveClient{
+		config_obj: config_obj,
+		client:     client,
+	}, nil
+}
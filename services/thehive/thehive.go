@@ -0,0 +1,296 @@
+/*
+  The TheHive service raises alerts (or cases) in TheHive when
+  event artifacts fire.
+
+  Each configured alert watches an event artifact (client or server
+  monitoring). Every row of the artifact creates an alert with the
+  configured columns attached as observables. The alert's
+  description refers back to the client, flow and artifact in the
+  Velociraptor GUI so analysts can pivot without copying ids by
+  hand.
+
+  Artifacts can also raise alerts directly with the thehive_alert()
+  VQL function.
+
+  TheHive deduplicates alerts on their sourceRef, which is derived
+  from the row, so replayed events do not create duplicate alerts.
+*/
+
+package thehive
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+type Observable struct {
+	DataType string
+	Data     string
+	Message  string
+}
+
+type Alert struct {
+	Title       string
+	Description string
+	Severity    int64
+	Tags        []string
+	Observables []Observable
+
+	// Back references to Velociraptor.
+	ClientId string
+	FlowId   string
+	Artifact string
+
+	// Uniquely identifies the alert in TheHive. Derived from the
+	// alert if not set.
+	SourceRef string
+
+	CreateCase bool
+}
+
+// Send the alert to TheHive. Returns the id of the new alert or
+// case.
+func Send(ctx context.Context, config_obj *config_proto.Config,
+	client *TheHiveClient, alert *Alert) (string, error) {
+
+	thehive_config := config_obj.Thehive
+	if thehive_config == nil {
+		return "", errors.New("TheHive is not configured")
+	}
+
+	if alert.Title == "" {
+		return "", errors.New("Alerts must have a title")
+	}
+
+	severity := alert.Severity
+	if severity == 0 {
+		severity = 2
+	}
+
+	tlp := thehive_config.Tlp
+	if tlp == 0 {
+		tlp = 2
+	}
+
+	source := thehive_config.Source
+	if source == "" {
+		source = "Velociraptor"
+	}
+
+	tags := append([]string{}, alert.Tags...)
+	if alert.Artifact != "" {
+		tags = append(tags, "artifact:"+alert.Artifact)
+	}
+
+	observables := make([]*ordereddict.Dict, 0, len(alert.Observables))
+	for _, observable := range alert.Observables {
+		if observable.Data == "" {
+			continue
+		}
+		observables = append(observables, ordereddict.NewDict().
+			Set("dataType", observable.DataType).
+			Set("data", observable.Data).
+			Set("message", observable.Message))
+	}
+
+	description := alert.Description + backReferences(config_obj, alert)
+
+	if client == nil {
+		var err error
+		client, err = NewTheHiveClient(config_obj)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if alert.CreateCase {
+		return client.CreateCase(ctx, ordereddict.NewDict().
+			Set("title", alert.Title).
+			Set("description", description).
+			Set("severity", severity).
+			Set("tlp", tlp).
+			Set("tags", tags), observables)
+	}
+
+	source_ref := alert.SourceRef
+	if source_ref == "" {
+		source_ref = sourceRef(json.MustMarshalString(alert))
+	}
+
+	return client.CreateAlert(ctx, ordereddict.NewDict().
+		Set("type", "velociraptor").
+		Set("source", source).
+		Set("sourceRef", source_ref).
+		Set("title", alert.Title).
+		Set("description", description).
+		Set("severity", severity).
+		Set("tlp", tlp).
+		Set("tags", tags).
+		Set("observables", observables))
+}
+
+func sourceRef(data string) string {
+	hash := sha256.Sum256([]byte(data))
+	return fmt.Sprintf("%x", hash[:8])
+}
+
+// A markdown section linking back to the Velociraptor GUI.
+func backReferences(config_obj *config_proto.Config, alert *Alert) string {
+	if alert.ClientId == "" && alert.FlowId == "" && alert.Artifact == "" {
+		return ""
+	}
+
+	base := ""
+	if config_obj.GUI != nil && config_obj.GUI.PublicUrl != "" {
+		base = strings.TrimSuffix(config_obj.GUI.PublicUrl, "/") +
+			"/app/index.html?org_id=" +
+			url.QueryEscape(utils.NormalizedOrgId(config_obj.OrgId)) + "#"
+	}
+
+	link := func(text, path string) string {
+		if base == "" {
+			return "`" + text + "`"
+		}
+		return fmt.Sprintf("[%s](%s%s)", text, base, path)
+	}
+
+	client_id := alert.ClientId
+	if client_id == "" {
+		client_id = "server"
+	}
+
+	result := "\n\n**Velociraptor**\n\n"
+	if alert.ClientId != "" {
+		result += "- Client: " + link(alert.ClientId,
+			"/host/"+alert.ClientId) + "\n"
+	}
+
+	if alert.FlowId != "" {
+		result += "- Flow: " + link(alert.FlowId,
+			"/collected/"+client_id+"/"+alert.FlowId) + "\n"
+	}
+
+	if alert.Artifact != "" {
+		if alert.FlowId == "" {
+			result += "- Artifact: " + link(alert.Artifact,
+				"/events/"+client_id+"/"+alert.Artifact) + "\n"
+		} else {
+			result += "- Artifact: `" + alert.Artifact + "`\n"
+		}
+	}
+
+	return result
+}
+
+// Build the alert for a row of an event artifact.
+func alertFromRow(alert_config *config_proto.TheHiveAlertConfig,
+	row *ordereddict.Dict) *Alert {
+
+	title := alert_config.Title
+	if title == "" {
+		title = alert_config.Artifact
+	}
+
+	client_id, _ := row.GetString("ClientId")
+	flow_id, _ := row.GetString("FlowId")
+
+	serialized := json.MustMarshalString(row)
+	result := &Alert{
+		Title:       title,
+		Description: "```json\n" + serialized + "\n```",
+		Severity:    alert_config.Severity,
+		Tags:        alert_config.Tags,
+		ClientId:    client_id,
+		FlowId:      flow_id,
+		Artifact:    alert_config.Artifact,
+		SourceRef:   sourceRef(alert_config.Artifact + "|" + serialized),
+		CreateCase:  alert_config.CreateCase,
+	}
+
+	for _, observable := range alert_config.Observables {
+		value, pres := row.Get(observable.Column)
+		if !pres || utils.IsNil(value) {
+			continue
+		}
+
+		result.Observables = append(result.Observables, Observable{
+			DataType: observable.DataType,
+			Data:     utils.ToString(value),
+			Message:  alert_config.Artifact + ": " + observable.Column,
+		})
+	}
+
+	return result
+}
+
+type TheHiveService struct {
+	config_obj *config_proto.Config
+	client     *TheHiveClient
+}
+
+func (self *TheHiveService) ProcessRow(ctx context.Context,
+	alert_config *config_proto.TheHiveAlertConfig,
+	row *ordereddict.Dict) error {
+
+	alert := alertFromRow(alert_config, row)
+	id, err := Send(ctx, self.config_obj, self.client, alert)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	logger.Info("TheHiveService: created %v for %v", id, alert_config.Artifact)
+	return nil
+}
+
+func NewTheHiveService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (*TheHiveService, error) {
+
+	client, err := NewTheHiveClient(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TheHiveService{
+		config_obj: config_obj,
+		client:     client,
+	}
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> TheHive service for %v.",
+		services.GetOrgName(config_obj))
+
+	for _, alert_config := range config_obj.Thehive.Alerts {
+		alert_config := alert_config
+		if alert_config.Artifact == "" {
+			continue
+		}
+
+		err := journal.WatchQueueWithCB(ctx, config_obj, wg,
+			alert_config.Artifact, "TheHiveService",
+			func(ctx context.Context, config_obj *config_proto.Config,
+				row *ordereddict.Dict) error {
+				return result.ProcessRow(ctx, alert_config, row)
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,171 @@
+package thehive_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/thehive"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+)
+
+type request struct {
+	path string
+	body *ordereddict.Dict
+}
+
+type TheHiveTestSuite struct {
+	test_utils.TestSuite
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	requests []request
+}
+
+func (self *TheHiveTestSuite) SetupTest() {
+	self.requests = nil
+
+	self.server = httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			parsed := ordereddict.NewDict()
+			_ = json.Unmarshal(body, parsed)
+
+			self.mu.Lock()
+			defer self.mu.Unlock()
+
+			self.requests = append(self.requests, request{
+				path: r.URL.Path,
+				body: parsed,
+			})
+			w.Write([]byte(`{"_id": "~1234"}`))
+		}))
+
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.GUI.PublicUrl = "https://velociraptor.example.com/"
+	self.ConfigObj.Thehive = &config_proto.TheHiveConfig{
+		Url:    self.server.URL,
+		ApiKey: "secret",
+		Alerts: []*config_proto.TheHiveAlertConfig{{
+			Artifact: "Server.Detections",
+			Title:    "Suspicious process",
+			Severity: 3,
+			Tags:     []string{"velociraptor"},
+			Observables: []*config_proto.TheHiveObservableConfig{{
+				Column:   "Hash",
+				DataType: "hash",
+			}, {
+				Column:   "Missing",
+				DataType: "ip",
+			}},
+		}},
+	}
+	self.LoadArtifacts([]string{`
+name: Server.Detections
+type: SERVER_EVENT
+`})
+
+	self.TestSuite.SetupTest()
+}
+
+func (self *TheHiveTestSuite) TearDownTest() {
+	self.TestSuite.TearDownTest()
+	self.server.Close()
+}
+
+func (self *TheHiveTestSuite) getRequests() []request {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return append([]request{}, self.requests...)
+}
+
+func (self *TheHiveTestSuite) TestEventAlerts() {
+	_, err := thehive.NewTheHiveService(self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	err = journal.PushRowsToArtifact(self.ConfigObj,
+		[]*ordereddict.Dict{ordereddict.NewDict().
+			Set("ClientId", "C.123").
+			Set("FlowId", "F.1").
+			Set("Hash", "d41d8cd98f00b204e9800998ecf8427e")},
+		"Server.Detections", "server", "")
+	assert.NoError(self.T(), err)
+
+	vtesting.WaitUntil(5*time.Second, self.T(), func() bool {
+		return len(self.getRequests()) == 1
+	})
+
+	req := self.getRequests()[0]
+	assert.Equal(self.T(), "/api/v1/alert", req.path)
+
+	title, _ := req.body.GetString("title")
+	assert.Equal(self.T(), "Suspicious process", title)
+
+	severity, _ := req.body.Get("severity")
+	assert.Equal(self.T(), "3", json.MustMarshalString(severity))
+
+	tags, _ := req.body.Get("tags")
+	assert.Equal(self.T(),
+		`["velociraptor","artifact:Server.Detections"]`,
+		json.MustMarshalString(tags))
+
+	// Only the present columns become observables.
+	observables, _ := req.body.Get("observables")
+	assert.Equal(self.T(),
+		`[{"dataType":"hash","data":"d41d8cd98f00b204e9800998ecf8427e","message":"Server.Detections: Hash"}]`,
+		json.MustMarshalString(observables))
+
+	// The description links back to the flow.
+	description, _ := req.body.GetString("description")
+	assert.True(self.T(), strings.Contains(description,
+		"[F.1](https://velociraptor.example.com/app/index.html?org_id=root#/collected/C.123/F.1)"))
+
+	source_ref, _ := req.body.GetString("sourceRef")
+	assert.NotEqual(self.T(), "", source_ref)
+}
+
+func (self *TheHiveTestSuite) TestCreateCase() {
+	id, err := thehive.Send(self.Ctx, self.ConfigObj, nil, &thehive.Alert{
+		Title:      "Incident",
+		ClientId:   "C.123",
+		CreateCase: true,
+		Observables: []thehive.Observable{{
+			DataType: "hostname",
+			Data:     "workstation",
+		}},
+	})
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "~1234", id)
+
+	requests := self.getRequests()
+	assert.Equal(self.T(), 2, len(requests))
+	assert.Equal(self.T(), "/api/v1/case", requests[0].path)
+	assert.Equal(self.T(), "/api/v1/case/~1234/observable", requests[1].path)
+
+	data, _ := requests[1].body.GetString("data")
+	assert.Equal(self.T(), "workstation", data)
+}
+
+func TestTheHive(t *testing.T) {
+	suite.Run(t, &TheHiveTestSuite{})
+}
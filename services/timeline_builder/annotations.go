@@ -0,0 +1,85 @@
+package timeline_builder
+
+import (
+	"context"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	timelines_proto "www.velocidex.com/golang/velociraptor/timelines/proto"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// Annotations are appended to a result set. Later annotations of
+// the same event replace earlier ones and an empty note removes the
+// annotation.
+func (self *TimelineBuilderService) AnnotateEvent(ctx context.Context,
+	config_obj *config_proto.Config,
+	principal, client_id, name, event_id, note string) error {
+
+	_, err := self.GetTimeline(ctx, config_obj, client_id, name)
+	if err != nil {
+		return err
+	}
+
+	// Serialize writers to the annotations.
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	path_manager := paths.NewClientTimelinePathManager(client_id, name)
+	file_store_factory := file_store.GetFileStore(config_obj)
+	writer, err := result_sets.NewResultSetWriter(file_store_factory,
+		path_manager.Annotations(), json.DefaultEncOpts(),
+		utils.SyncCompleter, result_sets.AppendMode)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	writer.Write(json.ConvertProtoToOrderedDict(
+		&timelines_proto.TimelineAnnotation{
+			EventId:   event_id,
+			Note:      note,
+			User:      principal,
+			Timestamp: utils.GetTime().Now().Unix(),
+		}))
+
+	return nil
+}
+
+// Read the current annotations keyed by event id.
+func readAnnotations(ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id, name string) (
+	map[string]*timelines_proto.TimelineAnnotation, error) {
+
+	result := make(map[string]*timelines_proto.TimelineAnnotation)
+
+	path_manager := paths.NewClientTimelinePathManager(client_id, name)
+	file_store_factory := file_store.GetFileStore(config_obj)
+	reader, err := result_sets.NewResultSetReader(
+		file_store_factory, path_manager.Annotations())
+	if err != nil {
+		// No annotations yet.
+		return result, nil
+	}
+	defer reader.Close()
+
+	for row := range reader.Rows(ctx) {
+		annotation := &timelines_proto.TimelineAnnotation{}
+		err := utils.ParseIntoProtobuf(row, annotation)
+		if err != nil {
+			continue
+		}
+
+		if annotation.Note == "" {
+			delete(result, annotation.EventId)
+			continue
+		}
+		result[annotation.EventId] = annotation
+	}
+
+	return result, nil
+}
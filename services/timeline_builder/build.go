@@ -0,0 +1,348 @@
+package timeline_builder
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"google.golang.org/protobuf/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/timelines"
+	timelines_proto "www.velocidex.com/golang/velociraptor/timelines/proto"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vql/functions"
+	"www.velocidex.com/golang/velociraptor/vql/sorter"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	// The events are sorted on this column which is removed before
+	// they are written.
+	sortColumn = "_ts"
+)
+
+// When the artifact does not declare timestamp columns, columns with
+// these names (or ending with "time" or "date") are guessed to hold
+// timestamps.
+var timeColumnNames = []string{
+	"_ts", "created", "modified", "accessed", "changed",
+	"timestamp", "lastwritten",
+}
+
+func (self *TimelineBuilderService) BuildTimeline(ctx context.Context,
+	config_obj *config_proto.Config,
+	principal string,
+	timeline *timelines_proto.ClientTimeline) (
+	*timelines_proto.ClientTimeline, error) {
+
+	if timeline.ClientId == "" || timeline.Name == "" {
+		return nil, errors.New("Timeline must have a client id and a name")
+	}
+
+	if len(timeline.Sources) == 0 {
+		return nil, errors.New("Timeline must have at least one source")
+	}
+
+	for _, source := range timeline.Sources {
+		if source.FlowId == "" {
+			return nil, errors.New("Timeline sources must specify a flow id")
+		}
+	}
+
+	err := self.lock(timeline.ClientId, timeline.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer self.unlock(timeline.ClientId, timeline.Name)
+
+	result := proto.Clone(timeline).(*timelines_proto.ClientTimeline)
+	result.Creator = principal
+	result.Created = utils.GetTime().Now().Unix()
+	result.Errors = nil
+	result.TotalEvents = 0
+	result.StartTime = 0
+	result.EndTime = 0
+
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// The sources are read directly from the file store so the
+	// scope is only used for sorting and parsing times.
+	scope := manager.BuildScope(services.ScopeBuilder{
+		Config:     config_obj,
+		ACLManager: acl_managers.NullACLManager{},
+		Logger: logging.NewPlainLogger(config_obj,
+			&logging.FrontendComponent),
+	})
+	defer scope.Close()
+
+	sub_ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events := make(chan vfilter.Row)
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(events)
+
+		for _, source := range result.Sources {
+			err := readSource(sub_ctx, config_obj, scope,
+				result.ClientId, source, events)
+			if err != nil {
+				result.Errors = append(result.Errors,
+					fmt.Sprintf("Flow %v: %v", source.FlowId, err))
+			}
+		}
+	}()
+
+	// Timelines have to be sorted. The merge sorter spills to disk
+	// so large timelines do not need to fit in memory.
+	sorted_chan := sorter.MergeSorter{ChunkSize: 10000}.Sort(
+		sub_ctx, scope, events, sortColumn, false /* desc */)
+
+	path_manager := paths.NewClientTimelinePathManager(
+		result.ClientId, result.Name)
+	file_store_factory := file_store.GetFileStore(config_obj)
+	writer, err := timelines.NewTimelineWriter(file_store_factory,
+		path_manager.Timeline(), utils.SyncCompleter, result_sets.TruncateMode)
+	if err != nil {
+		return nil, err
+	}
+
+	for row := range sorted_chan {
+		event, ok := row.(*ordereddict.Dict)
+		if !ok {
+			continue
+		}
+
+		ts_any, _ := event.Get(sortColumn)
+		ts, _ := utils.ToInt64(ts_any)
+		event.Delete(sortColumn)
+
+		// Rows may have been serialized by the sorter so restore
+		// the time.
+		timestamp := time.Unix(0, ts).UTC()
+		event.Update("Time", timestamp)
+
+		err = writer.Write(timestamp, event)
+		if err != nil {
+			break
+		}
+
+		if result.TotalEvents == 0 {
+			result.StartTime = ts
+		}
+		result.EndTime = ts
+		result.TotalEvents++
+	}
+	writer.Close()
+
+	// Wait for the reader to finish adding errors.
+	cancel()
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.SetSubject(config_obj, path_manager.Path(), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Send the events from all artifacts in the source.
+func readSource(ctx context.Context,
+	config_obj *config_proto.Config,
+	scope vfilter.Scope,
+	client_id string,
+	source *timelines_proto.TimelineSource,
+	output chan vfilter.Row) error {
+
+	artifact_names := []string{source.Artifact}
+	if source.Artifact == "" {
+		launcher, err := services.GetLauncher(config_obj)
+		if err != nil {
+			return err
+		}
+
+		details, err := launcher.GetFlowDetails(
+			config_obj, client_id, source.FlowId)
+		if err != nil {
+			return err
+		}
+
+		if details.Context == nil {
+			return errors.New("Flow not found")
+		}
+		artifact_names = details.Context.ArtifactsWithResults
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	for _, artifact_name := range artifact_names {
+		path_manager, err := artifacts.NewArtifactPathManager(
+			config_obj, client_id, source.FlowId, artifact_name)
+		if err != nil {
+			return err
+		}
+
+		reader, err := result_sets.NewResultSetReader(
+			file_store_factory, path_manager.Path())
+		if err != nil {
+			return fmt.Errorf("%v: %w", artifact_name, err)
+		}
+
+		schema := services.GetArtifactSchema(config_obj, artifact_name)
+		err = readArtifact(ctx, scope, reader, schema,
+			artifact_name, source, output)
+		reader.Close()
+		if err != nil {
+			return fmt.Errorf("%v: %w", artifact_name, err)
+		}
+	}
+
+	return nil
+}
+
+func readArtifact(ctx context.Context,
+	scope vfilter.Scope,
+	reader result_sets.ResultSetReader,
+	schema *result_sets.Schema,
+	artifact_name string,
+	source *timelines_proto.TimelineSource,
+	output chan vfilter.Row) error {
+
+	time_columns := source.TimeColumns
+	message_column := source.MessageColumn
+
+	// Prefer the timestamp columns declared by the artifact over
+	// guessing.
+	if len(time_columns) == 0 {
+		time_columns = schema.ColumnsOfType(result_sets.COLUMN_TIMESTAMP)
+	}
+
+	for row := range reader.Rows(ctx) {
+		// Guess the columns from the first row.
+		if len(time_columns) == 0 {
+			time_columns = guessTimeColumns(scope, row)
+			if len(time_columns) == 0 {
+				return errors.New("No time columns found")
+			}
+		}
+
+		if message_column == "" {
+			message_column = guessMessageColumn(row, time_columns)
+		}
+
+		message := ""
+		if message_column != "" {
+			value, _ := row.Get(message_column)
+			message = utils.ToString(value)
+		}
+
+		serialized := json.MustMarshalString(row)
+
+		for _, column := range time_columns {
+			value, pres := row.Get(column)
+			if !pres || utils.IsNil(value) {
+				continue
+			}
+
+			ts, err := functions.TimeFromAny(scope, value)
+			if err != nil || ts.IsZero() {
+				continue
+			}
+
+			event := ordereddict.NewDict().
+				Set("Time", ts.UTC()).
+				Set("EventId", eventId(artifact_name, source.FlowId,
+					column, ts, serialized)).
+				Set("Source", artifact_name).
+				Set("FlowId", source.FlowId).
+				Set("TimestampDescription", column).
+				Set("Message", message).
+				Set("Data", row).
+				Set(sortColumn, ts.UnixNano())
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case output <- event:
+			}
+		}
+	}
+
+	return nil
+}
+
+// A stable id for the event which does not depend on its position
+// in the timeline.
+func eventId(artifact_name, flow_id, column string,
+	ts time.Time, serialized string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%d|%s",
+		artifact_name, flow_id, column, ts.UnixNano(), serialized)))
+	return fmt.Sprintf("%x", hash[:8])
+}
+
+func guessTimeColumns(scope vfilter.Scope, row *ordereddict.Dict) []string {
+	result := []string{}
+	for _, column := range row.Keys() {
+		lower := strings.ToLower(column)
+		if !strings.HasSuffix(lower, "time") &&
+			!strings.HasSuffix(lower, "date") &&
+			!utils.InString(timeColumnNames, lower) {
+			continue
+		}
+
+		value, _ := row.Get(column)
+		if utils.IsNil(value) {
+			continue
+		}
+
+		_, err := functions.TimeFromAny(scope, value)
+		if err == nil {
+			result = append(result, column)
+		}
+	}
+	return result
+}
+
+// Use the first string column which is not a time as the message.
+func guessMessageColumn(row *ordereddict.Dict, time_columns []string) string {
+	for _, column := range row.Keys() {
+		if utils.InString(time_columns, column) {
+			continue
+		}
+
+		value, _ := row.Get(column)
+		str, ok := value.(string)
+		if ok && str != "" {
+			return column
+		}
+	}
+	return ""
+}
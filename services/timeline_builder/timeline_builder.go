@@ -0,0 +1,219 @@
+/*
+  The timeline builder service merges timestamped rows from a
+  client's collections into a single super-timeline.
+
+  Each source is an artifact collected in a flow. Every row of the
+  source produces one event for each of its time columns (so a file
+  with modified, accessed and created times produces three events)
+  and all events are sorted into a single indexed timeline stored
+  with the client. The timeline can be seeked to a point in time
+  quickly using its index.
+
+  Events have a fixed set of columns (see services/timelines.go) so
+  rows from different artifacts can be viewed side by side, with the
+  original row kept in the Data column.
+
+  Analysts may annotate events. Annotations are stored separately
+  from the events and refer to them by a stable event id so they
+  survive rebuilding the timeline (e.g. after adding more sources).
+*/
+
+package timeline_builder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/timelines"
+	timelines_proto "www.velocidex.com/golang/velociraptor/timelines/proto"
+)
+
+var (
+	timelineBusyError = errors.New("Timeline is currently being built")
+)
+
+type TimelineBuilderService struct {
+	mu sync.Mutex
+
+	// Timelines currently being built - keyed by client id and name.
+	building map[string]bool
+}
+
+func (self *TimelineBuilderService) lock(client_id, name string) error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	key := client_id + "/" + name
+	if self.building[key] {
+		return timelineBusyError
+	}
+	self.building[key] = true
+	return nil
+}
+
+func (self *TimelineBuilderService) unlock(client_id, name string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	delete(self.building, client_id+"/"+name)
+}
+
+func (self *TimelineBuilderService) GetTimeline(ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id, name string) (*timelines_proto.ClientTimeline, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &timelines_proto.ClientTimeline{}
+	err = db.GetSubject(config_obj,
+		paths.NewClientTimelinePathManager(client_id, name).Path(), result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Name == "" {
+		return nil, errors.New("Timeline not found")
+	}
+	return result, nil
+}
+
+func (self *TimelineBuilderService) ListTimelines(ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id string) ([]*timelines_proto.ClientTimeline, error) {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := db.ListChildren(config_obj,
+		paths.NewClientTimelinePathManager(client_id, "").Directory())
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*timelines_proto.ClientTimeline{}
+	for _, child := range children {
+		if child.IsDir() {
+			continue
+		}
+
+		timeline, err := self.GetTimeline(ctx, config_obj, client_id, child.Base())
+		if err != nil {
+			continue
+		}
+		result = append(result, timeline)
+	}
+
+	return result, nil
+}
+
+func (self *TimelineBuilderService) DeleteTimeline(ctx context.Context,
+	config_obj *config_proto.Config,
+	principal, client_id, name string) error {
+
+	err := self.lock(client_id, name)
+	if err != nil {
+		return err
+	}
+	defer self.unlock(client_id, name)
+
+	_, err = self.GetTimeline(ctx, config_obj, client_id, name)
+	if err != nil {
+		return err
+	}
+
+	path_manager := paths.NewClientTimelinePathManager(client_id, name)
+	file_store_factory := file_store.GetFileStore(config_obj)
+	timeline_path_manager := path_manager.Timeline()
+	_ = file_store_factory.Delete(timeline_path_manager.Path())
+	_ = file_store_factory.Delete(timeline_path_manager.Index())
+	_ = file_store_factory.Delete(path_manager.Annotations())
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	return db.DeleteSubject(config_obj, path_manager.Path())
+}
+
+func (self *TimelineBuilderService) ReadTimeline(ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id, name string,
+	start time.Time) (<-chan *ordereddict.Dict, error) {
+
+	_, err := self.GetTimeline(ctx, config_obj, client_id, name)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations, err := readAnnotations(ctx, config_obj, client_id, name)
+	if err != nil {
+		return nil, err
+	}
+
+	path_manager := paths.NewClientTimelinePathManager(client_id, name)
+	file_store_factory := file_store.GetFileStore(config_obj)
+	reader, err := timelines.NewTimelineReader(
+		file_store_factory, path_manager.Timeline())
+	if err != nil {
+		return nil, err
+	}
+
+	if !start.IsZero() {
+		reader.SeekToTime(start)
+	}
+
+	output_chan := make(chan *ordereddict.Dict)
+	go func() {
+		defer close(output_chan)
+		defer reader.Close()
+
+		for item := range reader.Read(ctx) {
+			row := item.Row.Update("Time", item.Time.UTC())
+
+			event_id, _ := row.GetString("EventId")
+			annotation, pres := annotations[event_id]
+			if pres {
+				row.Set("Annotation", annotation.Note).
+					Set("AnnotatedBy", annotation.User)
+			} else {
+				row.Set("Annotation", "").
+					Set("AnnotatedBy", "")
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan, nil
+}
+
+func NewTimelineBuilderService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) (services.TimelineBuilder, error) {
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> timeline builder service for %v.",
+		services.GetOrgName(config_obj))
+
+	return &TimelineBuilderService{
+		building: make(map[string]bool),
+	}, nil
+}
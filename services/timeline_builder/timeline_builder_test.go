@@ -0,0 +1,184 @@
+package timeline_builder_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/timeline_builder"
+	timelines_proto "www.velocidex.com/golang/velociraptor/timelines/proto"
+	"www.velocidex.com/golang/velociraptor/utils"
+
+	_ "www.velocidex.com/golang/velociraptor/result_sets/simple"
+)
+
+type TimelineBuilderTestSuite struct {
+	test_utils.TestSuite
+
+	builder services.TimelineBuilder
+}
+
+func (self *TimelineBuilderTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.LoadArtifacts([]string{`
+name: Custom.Files
+type: CLIENT
+`, `
+name: Custom.Logs
+type: CLIENT
+`})
+
+	self.TestSuite.SetupTest()
+
+	var err error
+	self.builder, err = timeline_builder.NewTimelineBuilderService(
+		self.Ctx, self.Wg, self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	self.writeResults("F.1", "Custom.Files",
+		ordereddict.NewDict().
+			Set("Path", "/bin/ls").
+			Set("Mtime", "2021-01-01T10:00:00Z").
+			Set("Btime", "2021-01-01T08:00:00Z"),
+		ordereddict.NewDict().
+			Set("Path", "/bin/cat").
+			Set("Mtime", "2021-01-01T12:00:00Z").
+			Set("Btime", nil))
+
+	self.writeResults("F.2", "Custom.Logs",
+		ordereddict.NewDict().
+			Set("EventTime", "2021-01-01T11:00:00Z").
+			Set("Message", "Login"))
+}
+
+func (self *TimelineBuilderTestSuite) writeResults(
+	flow_id, artifact string, rows ...*ordereddict.Dict) {
+	path_manager, err := artifacts.NewArtifactPathManager(
+		self.ConfigObj, "C.123", flow_id, artifact)
+	assert.NoError(self.T(), err)
+
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+	writer, err := result_sets.NewResultSetWriter(file_store_factory,
+		path_manager.Path(), nil, utils.SyncCompleter, result_sets.TruncateMode)
+	assert.NoError(self.T(), err)
+	defer writer.Close()
+
+	for _, row := range rows {
+		writer.Write(row)
+	}
+}
+
+func (self *TimelineBuilderTestSuite) read(start time.Time) []*ordereddict.Dict {
+	events, err := self.builder.ReadTimeline(
+		self.Ctx, self.ConfigObj, "C.123", "Incident", start)
+	assert.NoError(self.T(), err)
+
+	result := []*ordereddict.Dict{}
+	for event := range events {
+		result = append(result, event)
+	}
+	return result
+}
+
+func (self *TimelineBuilderTestSuite) build(
+	sources ...*timelines_proto.TimelineSource) *timelines_proto.ClientTimeline {
+	timeline, err := self.builder.BuildTimeline(self.Ctx, self.ConfigObj,
+		"admin", &timelines_proto.ClientTimeline{
+			ClientId: "C.123",
+			Name:     "Incident",
+			Sources:  sources,
+		})
+	assert.NoError(self.T(), err)
+	return timeline
+}
+
+func (self *TimelineBuilderTestSuite) TestBuildTimeline() {
+	timeline := self.build(&timelines_proto.TimelineSource{
+		FlowId:   "F.1",
+		Artifact: "Custom.Files",
+	}, &timelines_proto.TimelineSource{
+		FlowId:        "F.2",
+		Artifact:      "Custom.Logs",
+		TimeColumns:   []string{"EventTime"},
+		MessageColumn: "Message",
+	})
+
+	assert.Equal(self.T(), uint64(4), timeline.TotalEvents)
+	assert.Equal(self.T(), "admin", timeline.Creator)
+	assert.Equal(self.T(), 0, len(timeline.Errors))
+
+	// Events from all sources are merged in time order.
+	events := self.read(time.Time{})
+	summary := []string{}
+	for _, event := range events {
+		ts, _ := event.Get("Time")
+		source, _ := event.GetString("Source")
+		desc, _ := event.GetString("TimestampDescription")
+		message, _ := event.GetString("Message")
+		summary = append(summary, ts.(time.Time).Format(time.RFC3339)+" "+
+			source+" "+desc+" "+message)
+	}
+
+	assert.Equal(self.T(), []string{
+		"2021-01-01T08:00:00Z Custom.Files Btime /bin/ls",
+		"2021-01-01T10:00:00Z Custom.Files Mtime /bin/ls",
+		"2021-01-01T11:00:00Z Custom.Logs EventTime Login",
+		"2021-01-01T12:00:00Z Custom.Files Mtime /bin/cat",
+	}, summary)
+
+	// Seek into the timeline.
+	events = self.read(time.Date(2021, 1, 1, 10, 30, 0, 0, time.UTC))
+	assert.Equal(self.T(), 2, len(events))
+
+	// Annotate the login event.
+	login_id, _ := events[0].GetString("EventId")
+	assert.NoError(self.T(), self.builder.AnnotateEvent(self.Ctx,
+		self.ConfigObj, "admin", "C.123", "Incident", login_id, "Attacker login"))
+
+	// Rebuilding the timeline keeps the annotations.
+	timeline = self.build(&timelines_proto.TimelineSource{
+		FlowId:   "F.2",
+		Artifact: "Custom.Logs",
+	})
+	assert.Equal(self.T(), uint64(1), timeline.TotalEvents)
+
+	events = self.read(time.Time{})
+	assert.Equal(self.T(), 1, len(events))
+
+	event_id, _ := events[0].GetString("EventId")
+	assert.Equal(self.T(), login_id, event_id)
+
+	annotation, _ := events[0].GetString("Annotation")
+	assert.Equal(self.T(), "Attacker login", annotation)
+
+	// Removing the annotation
+	assert.NoError(self.T(), self.builder.AnnotateEvent(self.Ctx,
+		self.ConfigObj, "admin", "C.123", "Incident", login_id, ""))
+	events = self.read(time.Time{})
+	annotation, _ = events[0].GetString("Annotation")
+	assert.Equal(self.T(), "", annotation)
+
+	timelines, err := self.builder.ListTimelines(
+		self.Ctx, self.ConfigObj, "C.123")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(timelines))
+
+	assert.NoError(self.T(), self.builder.DeleteTimeline(
+		self.Ctx, self.ConfigObj, "admin", "C.123", "Incident"))
+
+	timelines, err = self.builder.ListTimelines(
+		self.Ctx, self.ConfigObj, "C.123")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 0, len(timelines))
+}
+
+func TestTimelineBuilder(t *testing.T) {
+	suite.Run(t, &TimelineBuilderTestSuite{})
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	timelines_proto "www.velocidex.com/golang/velociraptor/timelines/proto"
+)
+
+// The timeline builder merges timestamped rows from a client's
+// collections into a single timeline sorted by time. Each event in
+// the timeline has the same columns:
+//
+// - Time: The time of the event.
+// - EventId: A stable id for the event used to annotate it.
+// - Source: The artifact the event came from.
+// - FlowId: The collection the event came from.
+// - TimestampDescription: The column the time was taken from.
+// - Message: A short description of the event.
+// - Data: The original row.
+func GetTimelineBuilder(config_obj *config_proto.Config) (TimelineBuilder, error) {
+	org_manager, err := GetOrgManager()
+	if err != nil {
+		return nil, err
+	}
+
+	return org_manager.Services(config_obj.OrgId).TimelineBuilder()
+}
+
+type TimelineBuilder interface {
+	// Build (or rebuild) the named timeline from the sources.
+	// Existing annotations are kept.
+	BuildTimeline(ctx context.Context,
+		config_obj *config_proto.Config,
+		principal string,
+		timeline *timelines_proto.ClientTimeline) (
+		*timelines_proto.ClientTimeline, error)
+
+	GetTimeline(ctx context.Context,
+		config_obj *config_proto.Config,
+		client_id, name string) (*timelines_proto.ClientTimeline, error)
+
+	ListTimelines(ctx context.Context,
+		config_obj *config_proto.Config,
+		client_id string) ([]*timelines_proto.ClientTimeline, error)
+
+	DeleteTimeline(ctx context.Context,
+		config_obj *config_proto.Config,
+		principal, client_id, name string) error
+
+	// Read the events from the timeline starting at start. Events
+	// are returned with their annotations.
+	ReadTimeline(ctx context.Context,
+		config_obj *config_proto.Config,
+		client_id, name string,
+		start time.Time) (<-chan *ordereddict.Dict, error)
+
+	// Annotate an event. An empty note removes the annotation.
+	AnnotateEvent(ctx context.Context,
+		config_obj *config_proto.Config,
+		principal, client_id, name, event_id, note string) error
+}
@@ -0,0 +1,285 @@
+package upload_index
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/file_store/path_specs"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	DEFAULT_MAX_FILE_SIZE = 10 * 1024 * 1024
+	DEFAULT_MAX_TERMS     = 100000
+
+	numShards = 64
+)
+
+var (
+	// Serializes writers to the shards.
+	index_mu sync.Mutex
+)
+
+// An indexed upload.
+type Document struct {
+	DocId      string
+	ClientId   string
+	FlowId     string
+	VFSPath    string
+	Components []string
+	Size       uint64
+	Sha256     string
+	Uploaded   int64
+	Terms      int
+}
+
+func (self *Document) toDict() *ordereddict.Dict {
+	return ordereddict.NewDict().
+		Set("DocId", self.DocId).
+		Set("ClientId", self.ClientId).
+		Set("FlowId", self.FlowId).
+		Set("VFSPath", self.VFSPath).
+		Set("Size", self.Size).
+		Set("Sha256", self.Sha256).
+		Set("Uploaded", self.Uploaded).
+		Set("Terms", self.Terms).
+		Set("_Components", self.Components)
+}
+
+func docId(client_id, flow_id string, idx int) string {
+	return fmt.Sprintf("%s/%s/%d", client_id, flow_id, idx)
+}
+
+// Returns the client id and flow id of the document.
+func parseDocId(doc_id string) (string, string, error) {
+	parts := strings.SplitN(doc_id, "/", 3)
+	if len(parts) != 3 {
+		return "", "", fmt.Errorf("Invalid document id %v", doc_id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func shardForTerm(term string) string {
+	h := fnv.New32a()
+	h.Write([]byte(term))
+	return fmt.Sprintf("%02x", h.Sum32()%numShards)
+}
+
+type limits struct {
+	max_file_size     uint64
+	min_string_length int
+	max_terms         int
+	extensions        []string
+}
+
+func getLimits(config_obj *config_proto.Config) *limits {
+	result := &limits{
+		max_file_size:     DEFAULT_MAX_FILE_SIZE,
+		min_string_length: DEFAULT_MIN_STRING_LENGTH,
+		max_terms:         DEFAULT_MAX_TERMS,
+	}
+
+	settings := config_obj.UploadIndex
+	if settings == nil {
+		return result
+	}
+
+	if settings.MaxFileSize > 0 {
+		result.max_file_size = settings.MaxFileSize
+	}
+	if settings.MinStringLength > 0 {
+		result.min_string_length = int(settings.MinStringLength)
+	}
+	if settings.MaxTerms > 0 {
+		result.max_terms = int(settings.MaxTerms)
+	}
+	for _, ext := range settings.Extensions {
+		result.extensions = append(result.extensions,
+			"."+strings.TrimPrefix(strings.ToLower(ext), "."))
+	}
+	return result
+}
+
+func (self *limits) shouldIndex(vfs_path string, size uint64) bool {
+	if size == 0 || size > self.max_file_size {
+		return false
+	}
+
+	if len(self.extensions) == 0 {
+		return true
+	}
+	return utils.InString(self.extensions,
+		strings.ToLower(path.Ext(vfs_path)))
+}
+
+// Extract the terms of the file. The file's name and hash are indexed
+// as well so uploads can also be found by them.
+func extractTerms(reader io.Reader, doc *Document, limits *limits) (
+	[]string, error) {
+	terms := make(map[string]bool)
+	add := func(term string) {
+		if len(terms) < limits.max_terms {
+			terms[term] = true
+		}
+	}
+
+	hasher := sha256.New()
+	err := ExtractStrings(io.TeeReader(reader, hasher),
+		limits.min_string_length, func(s string) {
+			Tokenize(s, add)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Sha256 = hex.EncodeToString(hasher.Sum(nil))
+	add(doc.Sha256)
+	Tokenize(doc.VFSPath, add)
+
+	result := make([]string, 0, len(terms))
+	for term := range terms {
+		result = append(result, term)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// Add the uploads of the flow to the index. Flows are only indexed
+// once - returns the number of uploads which were indexed.
+func IndexFlow(ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id, flow_id string) (int, error) {
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	flow_path_manager := paths.NewFlowPathManager(client_id, flow_id)
+
+	// Already indexed.
+	_, err := file_store_factory.StatFile(flow_path_manager.UploadIndex())
+	if err == nil {
+		return 0, nil
+	}
+
+	reader, err := result_sets.NewResultSetReader(
+		file_store_factory, flow_path_manager.UploadMetadata())
+	if err != nil {
+		// The flow has no uploads.
+		return 0, nil
+	}
+	defer reader.Close()
+
+	limits := getLimits(config_obj)
+	seen := make(map[string]bool)
+	postings := make(map[string][]*ordereddict.Dict)
+	var docs []*Document
+
+	for row := range reader.Rows(ctx) {
+		// Sparse files have an additional row for their index.
+		file_type, _ := row.GetString("Type")
+		if file_type == "idx" {
+			continue
+		}
+
+		components, pres := row.GetStrings("_Components")
+		if !pres || len(components) == 0 {
+			continue
+		}
+
+		key := strings.Join(components, "/")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		vfs_path, _ := row.GetString("vfs_path")
+		size := uint64(utils.GetInt64(row, "uploaded_size"))
+		if !limits.shouldIndex(vfs_path, size) {
+			continue
+		}
+
+		doc := &Document{
+			DocId:      docId(client_id, flow_id, len(docs)),
+			ClientId:   client_id,
+			FlowId:     flow_id,
+			VFSPath:    vfs_path,
+			Components: components,
+			Size:       size,
+			Uploaded:   utils.GetInt64(row, "Timestamp"),
+		}
+
+		fd, err := file_store_factory.ReadFile(
+			path_specs.NewUnsafeFilestorePath(components...).
+				SetType(api.PATH_TYPE_FILESTORE_ANY))
+		if err != nil {
+			continue
+		}
+
+		terms, err := extractTerms(
+			io.LimitReader(fd, int64(limits.max_file_size)), doc, limits)
+		fd.Close()
+		if err != nil {
+			return 0, err
+		}
+
+		doc.Terms = len(terms)
+		docs = append(docs, doc)
+
+		for _, term := range terms {
+			shard := shardForTerm(term)
+			postings[shard] = append(postings[shard], ordereddict.NewDict().
+				Set("Term", term).
+				Set("DocId", doc.DocId))
+		}
+	}
+
+	index_mu.Lock()
+	defer index_mu.Unlock()
+
+	path_manager := paths.NewUploadIndexPathManager()
+	for shard, rows := range postings {
+		err := writeRows(file_store_factory, path_manager.Shard(shard),
+			rows, result_sets.AppendMode)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Written last so a flow which failed to index is retried.
+	rows := make([]*ordereddict.Dict, 0, len(docs))
+	for _, doc := range docs {
+		rows = append(rows, doc.toDict())
+	}
+
+	return len(docs), writeRows(file_store_factory,
+		flow_path_manager.UploadIndex(), rows, result_sets.TruncateMode)
+}
+
+func writeRows(file_store_factory api.FileStore,
+	path api.FSPathSpec, rows []*ordereddict.Dict,
+	mode result_sets.WriteMode) error {
+	writer, err := result_sets.NewResultSetWriter(file_store_factory,
+		path, json.DefaultEncOpts(), utils.SyncCompleter, mode)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	for _, row := range rows {
+		writer.Write(row)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package upload_index
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+type SearchOptions struct {
+	// Match terms containing the query instead of whole terms. This
+	// reads the entire index.
+	Substring bool
+
+	// Only return uploads from this client.
+	ClientId string
+}
+
+// Call cb on each posting in the shard.
+func scanShard(ctx context.Context,
+	file_store_factory api.FileStore, shard api.FSPathSpec,
+	cb func(term, doc_id string)) error {
+	reader, err := result_sets.NewResultSetReader(file_store_factory, shard)
+	if err != nil {
+		// Nothing was indexed in this shard yet.
+		return nil
+	}
+	defer reader.Close()
+
+	for row := range reader.Rows(ctx) {
+		term, _ := row.GetString("Term")
+		doc_id, _ := row.GetString("DocId")
+		cb(term, doc_id)
+	}
+	return ctx.Err()
+}
+
+// Find the documents with all the terms of the query. Returns the
+// matched terms of each document.
+func findDocuments(ctx context.Context,
+	config_obj *config_proto.Config,
+	query string, options SearchOptions) (map[string][]string, error) {
+	file_store_factory := file_store.GetFileStore(config_obj)
+	path_manager := paths.NewUploadIndexPathManager()
+
+	matches := make(map[string][]string)
+
+	if options.Substring {
+		needle := strings.ToLower(query)
+		children, err := file_store_factory.ListDirectory(
+			path_manager.ShardsDirectory())
+		if err != nil {
+			return nil, nil
+		}
+
+		for _, child := range children {
+			if child.PathSpec().Type() != api.PATH_TYPE_FILESTORE_JSON {
+				continue
+			}
+
+			err := scanShard(ctx, file_store_factory, child.PathSpec(),
+				func(term, doc_id string) {
+					if strings.Contains(term, needle) &&
+						!utils.InString(matches[doc_id], term) {
+						matches[doc_id] = append(matches[doc_id], term)
+					}
+				})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return matches, nil
+	}
+
+	var terms []string
+	Tokenize(query, func(term string) {
+		if !utils.InString(terms, term) {
+			terms = append(terms, term)
+		}
+	})
+	if len(terms) == 0 {
+		return nil, errors.New("upload_index: query has no searchable terms")
+	}
+
+	for idx, term := range terms {
+		found := make(map[string]bool)
+		err := scanShard(ctx, file_store_factory,
+			path_manager.Shard(shardForTerm(term)),
+			func(posting, doc_id string) {
+				if posting == term {
+					found[doc_id] = true
+				}
+			})
+		if err != nil {
+			return nil, err
+		}
+
+		// Keep the documents which had all the previous terms.
+		for doc_id := range found {
+			_, pres := matches[doc_id]
+			if idx == 0 || pres {
+				matches[doc_id] = append(matches[doc_id], term)
+			}
+		}
+
+		for doc_id, doc_terms := range matches {
+			if len(doc_terms) <= idx {
+				delete(matches, doc_id)
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// Search the index for uploads containing the query. Returns the
+// indexed documents with the terms they matched.
+func Search(ctx context.Context,
+	config_obj *config_proto.Config,
+	query string, options SearchOptions) ([]*ordereddict.Dict, error) {
+
+	matches, err := findDocuments(ctx, config_obj, query, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the documents by flow so each flow's documents are only
+	// read once.
+	type flowKey struct {
+		client_id, flow_id string
+	}
+	flows := make(map[flowKey]bool)
+	for doc_id := range matches {
+		client_id, flow_id, err := parseDocId(doc_id)
+		if err != nil {
+			continue
+		}
+		if options.ClientId != "" && options.ClientId != client_id {
+			continue
+		}
+		flows[flowKey{client_id, flow_id}] = true
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+
+	var result []*ordereddict.Dict
+	for key := range flows {
+		// Flows deleted since they were indexed have no documents.
+		reader, err := result_sets.NewResultSetReader(file_store_factory,
+			paths.NewFlowPathManager(key.client_id, key.flow_id).UploadIndex())
+		if err != nil {
+			continue
+		}
+
+		for row := range reader.Rows(ctx) {
+			doc_id, _ := row.GetString("DocId")
+			terms, pres := matches[doc_id]
+			if pres {
+				sort.Strings(terms)
+				result = append(result, row.Set("Matches", terms))
+			}
+		}
+		reader.Close()
+	}
+
+	// Most recent uploads first.
+	sort.Slice(result, func(i, j int) bool {
+		uploaded_i := utils.GetInt64(result[i], "Uploaded")
+		uploaded_j := utils.GetInt64(result[j], "Uploaded")
+		if uploaded_i != uploaded_j {
+			return uploaded_i > uploaded_j
+		}
+		return utils.GetString(result[i], "DocId") <
+			utils.GetString(result[j], "DocId")
+	})
+
+	return result, nil
+}
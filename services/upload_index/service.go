@@ -0,0 +1,66 @@
+/*
+  The upload index service extracts the strings of files uploaded by
+  collections into a term index.
+
+  Finding which collected files contain an indicator (for example a
+  C2 domain) would otherwise require downloading every upload and
+  grepping it. When a flow completes, the ASCII and UTF-16 strings of
+  each of its uploads (within the configured size and extension
+  limits) are split into terms - words, domain names, IP and email
+  addresses. The file's name and SHA256 hash are indexed too.
+
+  The postings of each term are appended to one of a fixed number of
+  shards selected by the term's hash, so looking up a term only reads
+  its shard. The indexed uploads of each flow are stored with the
+  flow and are removed when the flow is deleted, which also drops
+  them from search results.
+
+  Search the index with the upload_search() plugin.
+*/
+
+package upload_index
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/journal"
+)
+
+func NewUploadIndexService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> upload index service for %v.",
+		services.GetOrgName(config_obj))
+
+	return journal.WatchQueueWithCB(ctx, config_obj, wg,
+		"System.Flow.Completion", "UploadIndex",
+		func(ctx context.Context, config_obj *config_proto.Config,
+			row *ordereddict.Dict) error {
+			client_id, _ := row.GetString("ClientId")
+			flow_id, _ := row.GetString("FlowId")
+			if client_id == "" || flow_id == "" {
+				return nil
+			}
+
+			count, err := IndexFlow(ctx, config_obj, client_id, flow_id)
+			if err != nil {
+				logger.Error("UploadIndex: indexing %v/%v: %v",
+					client_id, flow_id, err)
+				return nil
+			}
+
+			if count > 0 {
+				logger.Debug("UploadIndex: indexed %v uploads of %v/%v",
+					count, client_id, flow_id)
+			}
+			return nil
+		})
+}
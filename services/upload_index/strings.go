@@ -0,0 +1,118 @@
+package upload_index
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+const (
+	DEFAULT_MIN_STRING_LENGTH = 6
+
+	// Shorter terms are too common to be useful.
+	MIN_TERM_LENGTH = 3
+
+	// Longer terms are truncated.
+	MAX_TERM_LENGTH = 128
+)
+
+func isPrintable(b byte) bool {
+	return (b >= 0x20 && b < 0x7f) || b == '\t'
+}
+
+// Collects runs of printable characters of at least min_length.
+type runExtractor struct {
+	buf        []byte
+	min_length int
+	emit       func(string)
+}
+
+func (self *runExtractor) add(b byte) {
+	if isPrintable(b) {
+		self.buf = append(self.buf, b)
+		return
+	}
+	self.flush()
+}
+
+func (self *runExtractor) flush() {
+	if len(self.buf) >= self.min_length {
+		self.emit(string(self.buf))
+	}
+	self.buf = self.buf[:0]
+}
+
+// Extracts the ASCII and UTF-16LE strings from the reader, like the
+// strings utility.
+func ExtractStrings(reader io.Reader, min_length int, emit func(string)) error {
+	if min_length <= 0 {
+		min_length = DEFAULT_MIN_STRING_LENGTH
+	}
+
+	ascii := &runExtractor{min_length: min_length, emit: emit}
+
+	// UTF-16 strings may start on an even or odd offset.
+	utf16 := []*runExtractor{
+		{min_length: min_length, emit: emit},
+		{min_length: min_length, emit: emit},
+	}
+	var last byte
+
+	buffered := bufio.NewReader(reader)
+	for offset := 0; ; offset++ {
+		b, err := buffered.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		ascii.add(b)
+
+		// Each character is a printable byte followed by a 0
+		// byte. The extractor aligned with this pair sees the
+		// character.
+		if offset > 0 {
+			extractor := utf16[offset%2]
+			if b == 0 && last != 0 {
+				extractor.add(last)
+			} else {
+				extractor.flush()
+			}
+		}
+		last = b
+	}
+
+	ascii.flush()
+	for _, extractor := range utf16 {
+		extractor.flush()
+	}
+	return nil
+}
+
+func isTermChar(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+		c == '.' || c == '-' || c == '_' || c == '@'
+}
+
+// Split text into lower case terms. Terms are words, domain names,
+// email addresses, IP addresses and hashes - anything else separates
+// them.
+func Tokenize(text string, emit func(string)) {
+	for _, term := range strings.FieldsFunc(strings.ToLower(text),
+		func(c rune) bool { return !isTermChar(c) }) {
+
+		// Punctuation at the edges is not part of the term, e.g. the
+		// full stop at the end of a sentence.
+		term = strings.Trim(term, ".-_@")
+		if len(term) < MIN_TERM_LENGTH {
+			continue
+		}
+
+		if len(term) > MAX_TERM_LENGTH {
+			term = term[:MAX_TERM_LENGTH]
+		}
+		emit(term)
+	}
+}
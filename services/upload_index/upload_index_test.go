@@ -0,0 +1,164 @@
+package upload_index_test
+
+import (
+	"bytes"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services/upload_index"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+func utf16le(s string) []byte {
+	result := []byte{}
+	for _, c := range utf16.Encode([]rune(s)) {
+		result = append(result, byte(c), byte(c>>8))
+	}
+	return result
+}
+
+func TestExtractStrings(t *testing.T) {
+	data := []byte("\x00\x01Connect to https://Update.Evil-Domain.com/x.\x00\x02ab\x00")
+	data = append(data, 0xff)
+	data = append(data, utf16le("admin@corp.example")...)
+	data = append(data, 0, 0, 'x')
+
+	strings := []string{}
+	err := upload_index.ExtractStrings(bytes.NewReader(data), 6,
+		func(s string) { strings = append(strings, s) })
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"Connect to https://Update.Evil-Domain.com/x.",
+		"admin@corp.example",
+	}, strings)
+
+	terms := []string{}
+	for _, s := range strings {
+		upload_index.Tokenize(s, func(term string) {
+			terms = append(terms, term)
+		})
+	}
+	assert.Equal(t, []string{"connect", "https", "update.evil-domain.com",
+		"admin@corp.example"}, terms)
+}
+
+type UploadIndexTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *UploadIndexTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.ConfigObj.UploadIndex = &config_proto.UploadIndexConfig{
+		Extensions: []string{"ps1", ".txt"},
+	}
+	self.TestSuite.SetupTest()
+}
+
+func (self *UploadIndexTestSuite) addUpload(
+	client_id, flow_id, name string, data []byte) {
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+	flow_path_manager := paths.NewFlowPathManager(client_id, flow_id)
+	path := flow_path_manager.UploadContainer().AddChild("auto", name)
+
+	fd, err := file_store_factory.WriteFile(path)
+	assert.NoError(self.T(), err)
+	_, err = fd.Write(data)
+	assert.NoError(self.T(), err)
+	fd.Close()
+
+	writer, err := result_sets.NewResultSetWriter(file_store_factory,
+		flow_path_manager.UploadMetadata(), json.DefaultEncOpts(),
+		utils.SyncCompleter, result_sets.AppendMode)
+	assert.NoError(self.T(), err)
+	writer.Write(ordereddict.NewDict().
+		Set("Timestamp", 1600000000).
+		Set("vfs_path", "C:/"+name).
+		Set("_Components", path.Components()).
+		Set("file_size", len(data)).
+		Set("uploaded_size", len(data)))
+	writer.Close()
+}
+
+func (self *UploadIndexTestSuite) search(
+	query string, options upload_index.SearchOptions) []string {
+	rows, err := upload_index.Search(self.Ctx, self.ConfigObj, query, options)
+	assert.NoError(self.T(), err)
+
+	result := []string{}
+	for _, row := range rows {
+		result = append(result, utils.GetString(row, "ClientId")+" "+
+			utils.GetString(row, "VFSPath"))
+	}
+	return result
+}
+
+func (self *UploadIndexTestSuite) TestIndexAndSearch() {
+	self.addUpload("C.1", "F.1", "beacon.ps1",
+		[]byte("$u = 'http://update.evil-domain.com/stage2'"))
+	self.addUpload("C.1", "F.1", "notes.txt",
+		append([]byte("\x00\x00"), utf16le("contact admin@evil-domain.com")...))
+
+	// Not an indexed extension.
+	self.addUpload("C.1", "F.1", "tool.exe",
+		[]byte("update.evil-domain.com"))
+
+	self.addUpload("C.2", "F.2", "other.ps1",
+		[]byte("Write-Host update.evil-domain.com"))
+
+	count, err := upload_index.IndexFlow(
+		self.Ctx, self.ConfigObj, "C.1", "F.1")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 2, count)
+
+	count, err = upload_index.IndexFlow(
+		self.Ctx, self.ConfigObj, "C.2", "F.2")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, count)
+
+	// Flows are only indexed once.
+	count, err = upload_index.IndexFlow(
+		self.Ctx, self.ConfigObj, "C.1", "F.1")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 0, count)
+
+	assert.Equal(self.T(), []string{"C.1 C:/beacon.ps1", "C.2 C:/other.ps1"},
+		self.search("Update.Evil-Domain.com", upload_index.SearchOptions{}))
+
+	// All terms must match.
+	assert.Equal(self.T(), []string{"C.2 C:/other.ps1"},
+		self.search("write-host update.evil-domain.com",
+			upload_index.SearchOptions{}))
+
+	assert.Equal(self.T(), []string{"C.1 C:/beacon.ps1"},
+		self.search("update.evil-domain.com",
+			upload_index.SearchOptions{ClientId: "C.1"}))
+
+	// UTF-16 strings and file names are indexed.
+	assert.Equal(self.T(), []string{"C.1 C:/notes.txt"},
+		self.search("admin@evil-domain.com", upload_index.SearchOptions{}))
+
+	assert.Equal(self.T(), []string{"C.2 C:/other.ps1"},
+		self.search("other.ps1", upload_index.SearchOptions{}))
+
+	assert.Equal(self.T(), []string{
+		"C.1 C:/beacon.ps1", "C.1 C:/notes.txt", "C.2 C:/other.ps1"},
+		self.search("evil-domain", upload_index.SearchOptions{
+			Substring: true,
+		}))
+
+	assert.Equal(self.T(), []string{},
+		self.search("evil-domain", upload_index.SearchOptions{}))
+}
+
+func TestUploadIndex(t *testing.T) {
+	suite.Run(t, &UploadIndexTestSuite{})
+}
@@ -36,6 +36,7 @@ import (
 	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/paths"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/api_keys"
 )
 
 const (
@@ -123,12 +124,26 @@ func validateUsername(config_obj *config_proto.Config, name string) error {
 	return nil
 }
 
+// API key principals are only created together with their key so
+// users may not take their names.
+func validateNewUsername(name string) error {
+	if api_keys.IsApiKey(name) {
+		return fmt.Errorf("Unacceptable username %v: reserved for API keys", name)
+	}
+	return nil
+}
+
 func NewUserRecord(config_obj *config_proto.Config,
 	name string) (*api_proto.VelociraptorUser, error) {
 	err := validateUsername(config_obj, name)
 	if err != nil {
 		return nil, err
 	}
+
+	err = validateNewUsername(name)
+	if err != nil {
+		return nil, err
+	}
 	return &api_proto.VelociraptorUser{Name: name}, nil
 }
 
@@ -161,6 +176,11 @@ func (self UserManager) SetUser(
 		return err
 	}
 
+	err = validateNewUsername(user_record.Name)
+	if err != nil {
+		return err
+	}
+
 	db, err := datastore.GetDB(self.config_obj)
 	if err != nil {
 		return err
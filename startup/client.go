@@ -6,9 +6,12 @@ import (
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/executor"
 	"www.velocidex.com/golang/velociraptor/http_comms"
+	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/responder"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/services/orgs"
+	"www.velocidex.com/golang/velociraptor/tracing"
+	"www.velocidex.com/golang/velociraptor/vql/tools/quarantine"
 )
 
 // StartClientServices starts the various services needed by the
@@ -40,11 +43,24 @@ func StartClientServices(
 		return sm, err
 	}
 
+	err = sm.Start(tracing.StartTracingService)
+	if err != nil {
+		return sm, err
+	}
+
 	_, err = orgs.NewOrgManager(sm.Ctx, sm.Wg, sm.Config)
 	if err != nil {
 		return sm, err
 	}
 
+	// Install the quarantine again if we were quarantined before we
+	// restarted. This must not prevent the client from starting.
+	err = quarantine.Restore(ctx, config_obj.Client)
+	if err != nil {
+		logger := logging.GetLogger(config_obj, &logging.ClientComponent)
+		logger.Error("Unable to restore quarantine: %v", err)
+	}
+
 	_, err = http_comms.StartHttpCommunicatorService(
 		ctx, sm.Wg, config_obj, exe, on_error)
 	if err != nil {
@@ -53,6 +69,12 @@ func StartClientServices(
 
 	err = executor.StartEventTableService(
 		ctx, sm.Wg, config_obj, exe.Outbound)
+	if err != nil {
+		return sm, err
+	}
+
+	err = executor.StartTelemetryService(
+		ctx, sm.Wg, config_obj, exe.Outbound)
 
 	return sm, err
 }
@@ -9,6 +9,7 @@ import (
 	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/services/orgs"
+	"www.velocidex.com/golang/velociraptor/tracing"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 )
 
@@ -18,7 +19,14 @@ func StartFrontendServices(
 	config_obj *config_proto.Config) (*services.Service, error) {
 
 	sm := services.NewServiceManager(ctx, config_obj)
-	_, err := orgs.NewOrgManager(sm.Ctx, sm.Wg, config_obj)
+
+	// Start tracing first so it covers the other services.
+	err := sm.Start(tracing.StartTracingService)
+	if err != nil {
+		return sm, err
+	}
+
+	_, err = orgs.NewOrgManager(sm.Ctx, sm.Wg, config_obj)
 	if err != nil {
 		return sm, err
 	}
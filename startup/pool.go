@@ -1,46 +1,31 @@
-// +build XXXX
-
 package startup
 
 import (
 	"context"
 
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
-	"www.velocidex.com/golang/velociraptor/executor"
-	"www.velocidex.com/golang/velociraptor/http_comms"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/services/orgs"
 )
 
-// StartClientServices starts the various services needed by the
-// client.
+// StartPoolClientServices starts the services shared by all the
+// simulated clients of the pool client. The comms of each client are
+// started separately so they can be disconnected independently.
 func StartPoolClientServices(
-	sm *services.Service,
-	config_obj *config_proto.Config,
-	exe *executor.PoolClientExecutor) error {
+	ctx context.Context,
+	config_obj *config_proto.Config) (*services.Service, error) {
 
 	// Create a suitable service plan.
 	if config_obj.Services == nil {
 		config_obj.Services = services.ClientServicesSpec()
 	}
 
-	_, err := services.GetOrgManager()
-	if err != nil {
-		_, err = orgs.NewOrgManager(sm.Ctx, sm.Wg, config_obj)
-		if err != nil {
-			return err
-		}
-	}
+	sm := services.NewServiceManager(ctx, config_obj)
 
-	_, err = http_comms.StartHttpCommunicatorService(
-		sm.Ctx, sm.Wg, config_obj, exe,
-		func(ctx context.Context, config_obj *config_proto.Config) {})
+	_, err := orgs.NewOrgManager(sm.Ctx, sm.Wg, sm.Config)
 	if err != nil {
-		return err
+		return sm, err
 	}
 
-	err = executor.StartEventTableService(
-		sm.Ctx, sm.Wg, config_obj, exe.Outbound)
-
-	return nil
+	return sm, nil
 }
@@ -0,0 +1,173 @@
+package startup
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+)
+
+const (
+	// The principal recorded in the audit log for config reloads.
+	reload_principal = "ConfigReloader"
+)
+
+var (
+	// How often to check the config file for changes.
+	ConfigReloadPollInterval = 10 * time.Second
+)
+
+// Reloads the server config without restarting the frontend.
+type ConfigReloader struct {
+	mu sync.Mutex
+
+	// The live config used by all the services.
+	config_obj *config_proto.Config
+
+	// The config as it was last loaded from disk, before the
+	// frontend applied its own changes to it.
+	loaded *config_proto.Config
+
+	load func() (*config_proto.Config, error)
+}
+
+// Apply a newly loaded config. Only the reloadable sections are
+// applied - changes to other sections are ignored until the next
+// restart.
+func (self *ConfigReloader) Apply(
+	ctx context.Context, new_config *config_proto.Config) error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+
+	if config.RestartRequired(self.loaded, new_config) {
+		logger.Warn("ConfigReloader: Config file has changes which " +
+			"require a restart to take effect.")
+	}
+
+	sections := config.ReloadableChanges(self.loaded, new_config)
+	if len(sections) == 0 {
+		logger.Info("ConfigReloader: No reloadable changes found.")
+		self.loaded = new_config
+		return nil
+	}
+
+	change := &config.ConfigChange{
+		Old:      self.loaded,
+		New:      new_config,
+		Sections: sections,
+	}
+
+	// Update the root config and all the org configs which were
+	// copied from it.
+	config.ApplyReloadable(self.config_obj, new_config)
+	org_manager, err := services.GetOrgManager()
+	if err == nil {
+		for _, org := range org_manager.ListOrgs() {
+			org_config_obj, err := org_manager.GetOrgConfig(org.Id)
+			if err == nil && org_config_obj != self.config_obj {
+				config.ApplyReloadable(org_config_obj, new_config)
+			}
+		}
+	}
+
+	if change.Changed(config.RELOAD_LOGGING) {
+		err := logging.Reload(self.config_obj)
+		if err != nil {
+			logger.Error("ConfigReloader: Reloading logging: %v", err)
+		}
+	}
+
+	err = config.NotifyReload(ctx, change)
+
+	logger.Info("ConfigReloader: <green>Reloaded</> config sections %v", sections)
+	logging.LogAudit(self.config_obj, reload_principal, "ReloadConfig",
+		logrus.Fields{
+			"sections": sections,
+		})
+
+	self.loaded = new_config
+	return err
+}
+
+// Load the config from its source and apply it.
+func (self *ConfigReloader) Reload(ctx context.Context) error {
+	new_config, err := self.load()
+	if err != nil {
+		return err
+	}
+	return self.Apply(ctx, new_config)
+}
+
+// Reload the config whenever the config file changes or a signal is
+// received on the trigger channel.
+func (self *ConfigReloader) Start(
+	ctx context.Context, wg *sync.WaitGroup,
+	path string, trigger <-chan os.Signal) {
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	last_modified := getModTime(path)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-trigger:
+				logger.Info("ConfigReloader: Reload requested")
+
+			case <-time.After(ConfigReloadPollInterval):
+				modified := getModTime(path)
+				if modified.Equal(last_modified) {
+					continue
+				}
+				last_modified = modified
+				logger.Info("ConfigReloader: Config file %v changed", path)
+			}
+
+			err := self.Reload(ctx)
+			if err != nil {
+				logger.Error("ConfigReloader: Unable to reload config: %v", err)
+			}
+		}
+	}()
+}
+
+func getModTime(path string) time.Time {
+	if path == "" {
+		return time.Time{}
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return stat.ModTime()
+}
+
+// NewConfigReloader creates a reloader for the live config
+// object. The loaded config is the config as it was originally
+// loaded (before any changes made at startup) and load is used to
+// load it again.
+func NewConfigReloader(
+	config_obj *config_proto.Config,
+	loaded *config_proto.Config,
+	load func() (*config_proto.Config, error)) *ConfigReloader {
+	return &ConfigReloader{
+		config_obj: config_obj,
+		loaded:     proto.Clone(loaded).(*config_proto.Config),
+		load:       load,
+	}
+}
@@ -0,0 +1,354 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/vql/networking"
+)
+
+const (
+	// Spans queued while the collector is slow. Beyond this spans
+	// are dropped.
+	queueSize = 10000
+
+	maxResponseSize = 64 * 1024
+)
+
+var (
+	mu              sync.Mutex
+	global_exporter *Exporter
+)
+
+func getExporter() *Exporter {
+	mu.Lock()
+	defer mu.Unlock()
+	return global_exporter
+}
+
+// Sends finished spans to an OpenTelemetry collector using the
+// OTLP/HTTP protocol with JSON encoding.
+type Exporter struct {
+	config_obj *config_proto.Config
+	client     *http.Client
+	resource   *otlpResource
+
+	sample_ratio float64
+	batch_size   int
+	batch_delay  time.Duration
+
+	spans chan *Span
+}
+
+func (self *Exporter) sample() bool {
+	return self.sample_ratio >= 1 || rand.Float64() < self.sample_ratio
+}
+
+func (self *Exporter) export(span *Span) {
+	select {
+	case self.spans <- span:
+	default:
+		// Never block the traced code.
+	}
+}
+
+func (self *Exporter) send(ctx context.Context, batch []*Span) error {
+	otlp_spans := make([]*otlpSpan, 0, len(batch))
+	for _, span := range batch {
+		otlp_spans = append(otlp_spans, span.toOTLP())
+	}
+
+	serialized, err := json.Marshal(&otlpRequest{
+		ResourceSpans: []*otlpResourceSpans{{
+			Resource: self.resource,
+			ScopeSpans: []*otlpScopeSpans{{
+				Scope: &otlpScope{
+					Name:    "www.velocidex.com/golang/velociraptor",
+					Version: constants.VERSION,
+				},
+				Spans: otlp_spans,
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	tracing_config := self.config_obj.Tracing
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		tracing_config.Endpoint, bytes.NewReader(serialized))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if tracing_config.Authorization != "" {
+		req.Header.Set("Authorization", tracing_config.Authorization)
+	}
+
+	resp, err := self.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		response, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		return fmt.Errorf("Tracing collector returned %v: %v",
+			resp.Status, string(response))
+	}
+
+	return nil
+}
+
+func (self *Exporter) run(ctx context.Context) {
+	logger := logging.GetLogger(self.config_obj, &logging.GenericComponent)
+
+	var batch []*Span
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+
+		err := self.send(ctx, batch)
+		if err != nil {
+			logger.Error("Tracing: exporting %v spans: %v", len(batch), err)
+		}
+		batch = nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			sub_ctx, cancel := context.WithTimeout(
+				context.Background(), 10*time.Second)
+			flush(sub_ctx)
+			cancel()
+			return
+
+		case span := <-self.spans:
+			batch = append(batch, span)
+			if len(batch) >= self.batch_size {
+				flush(ctx)
+			}
+
+		case <-time.After(self.batch_delay):
+			flush(ctx)
+		}
+	}
+}
+
+func NewExporter(config_obj *config_proto.Config) (*Exporter, error) {
+	tracing_config := config_obj.Tracing
+
+	client, err := networking.GetDefaultHTTPClient(
+		config_obj.Client, tracing_config.RootCa)
+	if err != nil {
+		return nil, err
+	}
+
+	service_name := tracing_config.ServiceName
+	if service_name == "" {
+		service_name = "velociraptor"
+	}
+
+	hostname, _ := os.Hostname()
+
+	result := &Exporter{
+		config_obj: config_obj,
+		client:     client,
+		resource: &otlpResource{
+			Attributes: []*otlpAttribute{
+				newOTLPAttribute("service.name", service_name),
+				newOTLPAttribute("service.version", constants.VERSION),
+				newOTLPAttribute("host.name", hostname),
+			},
+		},
+		sample_ratio: tracing_config.SampleRatio,
+		batch_size:   int(tracing_config.BatchSize),
+		batch_delay:  time.Duration(tracing_config.BatchDelay) * time.Second,
+		spans:        make(chan *Span, queueSize),
+	}
+
+	if result.sample_ratio <= 0 {
+		result.sample_ratio = 1
+	}
+
+	if result.batch_size <= 0 {
+		result.batch_size = 512
+	}
+
+	if result.batch_delay <= 0 {
+		result.batch_delay = 5 * time.Second
+	}
+
+	return result, nil
+}
+
+// Install the exporter for the life of ctx. Does nothing if tracing
+// is not configured.
+func StartTracingService(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	if config_obj.Tracing == nil || config_obj.Tracing.Endpoint == "" {
+		return nil
+	}
+
+	exporter, err := NewExporter(config_obj)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	global_exporter = exporter
+	mu.Unlock()
+
+	logger := logging.GetLogger(config_obj, &logging.GenericComponent)
+	logger.Info("<green>Starting</> tracing exporter to %v",
+		config_obj.Tracing.Endpoint)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		exporter.run(ctx)
+
+		mu.Lock()
+		if global_exporter == exporter {
+			global_exporter = nil
+		}
+		mu.Unlock()
+	}()
+
+	return nil
+}
+
+// The OTLP/HTTP JSON encoding. Ids are hex encoded and 64 bit
+// integers are strings.
+type otlpRequest struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   *otlpResource     `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []*otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope *otlpScope  `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceId           string           `json:"traceId"`
+	SpanId            string           `json:"spanId"`
+	ParentSpanId      string           `json:"parentSpanId,omitempty"`
+	Name              string           `json:"name"`
+	Kind              int              `json:"kind"`
+	StartTimeUnixNano string           `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string           `json:"endTimeUnixNano"`
+	Attributes        []*otlpAttribute `json:"attributes,omitempty"`
+	Status            *otlpStatus      `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string     `json:"key"`
+	Value *otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func newOTLPAttribute(key string, value interface{}) *otlpAttribute {
+	result := &otlpValue{}
+
+	switch t := value.(type) {
+	case string:
+		result.StringValue = &t
+	case bool:
+		result.BoolValue = &t
+	case int:
+		result.IntValue = intString(int64(t))
+	case int64:
+		result.IntValue = intString(t)
+	case uint64:
+		s := strconv.FormatUint(t, 10)
+		result.IntValue = &s
+	case float64:
+		result.DoubleValue = &t
+	default:
+		s := fmt.Sprintf("%v", value)
+		result.StringValue = &s
+	}
+
+	return &otlpAttribute{Key: key, Value: result}
+}
+
+func intString(value int64) *string {
+	s := strconv.FormatInt(value, 10)
+	return &s
+}
+
+func (self *Span) toOTLP() *otlpSpan {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := &otlpSpan{
+		TraceId:           hex.EncodeToString(self.trace_id[:]),
+		SpanId:            hex.EncodeToString(self.span_id[:]),
+		Name:              self.name,
+		Kind:              self.kind,
+		StartTimeUnixNano: strconv.FormatInt(self.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(self.end.UnixNano(), 10),
+	}
+
+	if self.parent_id != [8]byte{} {
+		result.ParentSpanId = hex.EncodeToString(self.parent_id[:])
+	}
+
+	for _, attr := range self.attributes {
+		result.Attributes = append(result.Attributes,
+			newOTLPAttribute(attr.key, attr.value))
+	}
+
+	if self.status_code != STATUS_CODE_UNSET {
+		result.Status = &otlpStatus{
+			Code:    self.status_code,
+			Message: self.status_message,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,227 @@
+/*
+  Tracing records OpenTelemetry spans along the path of a collection
+  so slow collections can be followed from the API call to the
+  result set writes in a standard tracing backend.
+
+  Spans are carried in the context.Context within a process. Across
+  process boundaries (server to client and back) the span is
+  propagated as a W3C traceparent string in the VeloMessage.
+
+  When tracing is not configured StartSpan() returns a nil span. All
+  the span's methods are safe to call on a nil span so callers do
+  not need to check.
+*/
+
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SPAN_KIND_INTERNAL = 1
+	SPAN_KIND_SERVER   = 2
+	SPAN_KIND_CLIENT   = 3
+
+	STATUS_CODE_UNSET = 0
+	STATUS_CODE_OK    = 1
+	STATUS_CODE_ERROR = 2
+)
+
+type spanContextKey struct{}
+
+type attribute struct {
+	key   string
+	value interface{}
+}
+
+type Span struct {
+	mu sync.Mutex
+
+	exporter *Exporter
+
+	trace_id  [16]byte
+	span_id   [8]byte
+	parent_id [8]byte
+
+	name  string
+	kind  int
+	start time.Time
+	end   time.Time
+
+	attributes     []attribute
+	status_code    int
+	status_message string
+	ended          bool
+}
+
+// Start a new span as a child of the span in ctx. If there is no
+// span in ctx a new trace is started, subject to sampling.
+func StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	exporter := getExporter()
+	if exporter == nil {
+		return ctx, nil
+	}
+
+	parent := SpanFromContext(ctx)
+	if parent != nil {
+		return newSpan(ctx, exporter, name, parent.trace_id, parent.span_id)
+	}
+
+	if !exporter.sample() {
+		return ctx, nil
+	}
+
+	var trace_id [16]byte
+	_, _ = rand.Read(trace_id[:])
+
+	return newSpan(ctx, exporter, name, trace_id, [8]byte{})
+}
+
+// Start a span continuing a remote trace. Nothing is recorded if
+// the trace_parent is empty or was not sampled.
+func StartSpanWithParent(ctx context.Context,
+	name, trace_parent string) (context.Context, *Span) {
+	if trace_parent == "" {
+		return ctx, nil
+	}
+
+	exporter := getExporter()
+	if exporter == nil {
+		return ctx, nil
+	}
+
+	trace_id, parent_id, sampled, err := ParseTraceParent(trace_parent)
+	if err != nil || !sampled {
+		return ctx, nil
+	}
+
+	return newSpan(ctx, exporter, name, trace_id, parent_id)
+}
+
+func newSpan(ctx context.Context, exporter *Exporter, name string,
+	trace_id [16]byte, parent_id [8]byte) (context.Context, *Span) {
+	result := &Span{
+		exporter:  exporter,
+		trace_id:  trace_id,
+		parent_id: parent_id,
+		name:      name,
+		kind:      SPAN_KIND_INTERNAL,
+		start:     time.Now(),
+	}
+	_, _ = rand.Read(result.span_id[:])
+
+	return context.WithValue(ctx, spanContextKey{}, result), result
+}
+
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// The W3C traceparent of the span in ctx or "" if there is none.
+func TraceParent(ctx context.Context) string {
+	return SpanFromContext(ctx).TraceParent()
+}
+
+func (self *Span) TraceParent() string {
+	if self == nil {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01",
+		hex.EncodeToString(self.trace_id[:]),
+		hex.EncodeToString(self.span_id[:]))
+}
+
+func (self *Span) SetKind(kind int) {
+	if self == nil {
+		return
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.kind = kind
+}
+
+// Values may be strings, bools, integers or floats. Anything else
+// is recorded as its string representation.
+func (self *Span) SetAttribute(key string, value interface{}) {
+	if self == nil {
+		return
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.attributes = append(self.attributes, attribute{key: key, value: value})
+}
+
+// Mark the span as failed. A nil error is ignored so callers can
+// pass their return value.
+func (self *Span) SetError(err error) {
+	if self == nil || err == nil {
+		return
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.status_code = STATUS_CODE_ERROR
+	self.status_message = err.Error()
+}
+
+// Finish the span and queue it for export. Only the first call has
+// any effect.
+func (self *Span) End() {
+	if self == nil {
+		return
+	}
+
+	self.mu.Lock()
+	if self.ended {
+		self.mu.Unlock()
+		return
+	}
+	self.ended = true
+	self.end = time.Now()
+	self.mu.Unlock()
+
+	self.exporter.export(self)
+}
+
+// Parse a W3C traceparent header: version-trace_id-parent_id-flags
+func ParseTraceParent(trace_parent string) (
+	trace_id [16]byte, parent_id [8]byte, sampled bool, err error) {
+
+	parts := strings.Split(trace_parent, "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return trace_id, parent_id, false, errors.New("Invalid traceparent")
+	}
+
+	_, err = hex.Decode(trace_id[:], []byte(parts[1]))
+	if err != nil {
+		return trace_id, parent_id, false, err
+	}
+
+	_, err = hex.Decode(parent_id[:], []byte(parts[2]))
+	if err != nil {
+		return trace_id, parent_id, false, err
+	}
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return trace_id, parent_id, false, err
+	}
+
+	if trace_id == [16]byte{} || parent_id == [8]byte{} {
+		return trace_id, parent_id, false, errors.New("Invalid traceparent")
+	}
+
+	return trace_id, parent_id, flags[0]&1 == 1, nil
+}
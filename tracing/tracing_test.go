@@ -0,0 +1,169 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/tracing"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	trace_id, parent_id, sampled, err := tracing.ParseTraceParent(
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.NoError(t, err)
+	assert.True(t, sampled)
+	assert.Equal(t, byte(0x4b), trace_id[0])
+	assert.Equal(t, byte(0xb7), parent_id[7])
+
+	_, _, sampled, err = tracing.ParseTraceParent(
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	assert.NoError(t, err)
+	assert.False(t, sampled)
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e47zz-00f067aa0ba902b7-01",
+	} {
+		_, _, _, err := tracing.ParseTraceParent(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// Without an exporter no spans are created and nil spans are safe
+// to use.
+func TestTracingDisabled(t *testing.T) {
+	ctx, span := tracing.StartSpan(context.Background(), "test")
+	assert.Nil(t, span)
+
+	span.SetAttribute("key", "value")
+	span.SetError(errors.New("error"))
+	span.End()
+
+	assert.Equal(t, "", tracing.TraceParent(ctx))
+}
+
+func TestExportSpans(t *testing.T) {
+	mu := sync.Mutex{}
+	var spans []*ordereddict.Dict
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer secret" ||
+				r.Header.Get("Content-Type") != "application/json" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			request := ordereddict.NewDict()
+			assert.NoError(t, json.Unmarshal(body, request))
+
+			// Flatten the resource and scope spans.
+			resource_spans, _ := request.Get("resourceSpans")
+			for _, resource_span := range resource_spans.([]interface{}) {
+				scope_spans, _ := resource_span.(*ordereddict.Dict).Get("scopeSpans")
+				for _, scope_span := range scope_spans.([]interface{}) {
+					items, _ := scope_span.(*ordereddict.Dict).Get("spans")
+
+					mu.Lock()
+					for _, item := range items.([]interface{}) {
+						spans = append(spans, item.(*ordereddict.Dict))
+					}
+					mu.Unlock()
+				}
+			}
+		}))
+	defer server.Close()
+
+	config_obj := config.GetDefaultConfig()
+	config_obj.Tracing = &config_proto.TracingConfig{
+		Endpoint:      server.URL,
+		Authorization: "Bearer secret",
+		BatchSize:     1,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	assert.NoError(t, tracing.StartTracingService(ctx, wg, config_obj))
+
+	// A new trace with a child.
+	root_ctx, root := tracing.StartSpan(ctx, "root")
+	root.SetAttribute("rows", 10)
+
+	_, child := tracing.StartSpan(root_ctx, "child")
+	child.SetError(errors.New("failed"))
+	child.End()
+	root.End()
+
+	// Continuing a remote trace, e.g. a message from a client.
+	trace_parent := tracing.TraceParent(root_ctx)
+	assert.True(t, strings.HasPrefix(trace_parent, "00-"))
+	assert.True(t, strings.HasSuffix(trace_parent, "-01"))
+
+	_, remote := tracing.StartSpanWithParent(ctx, "remote", trace_parent)
+	remote.End()
+
+	// Unsampled traces are not recorded.
+	_, unsampled := tracing.StartSpanWithParent(ctx, "unsampled",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	assert.Nil(t, unsampled)
+
+	vtesting.WaitUntil(5*time.Second, t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(spans) == 3
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	get := func(span *ordereddict.Dict, key string) string {
+		value, _ := span.GetString(key)
+		return value
+	}
+
+	serialize := func(span *ordereddict.Dict, key string) string {
+		value, _ := span.Get(key)
+		return json.MustMarshalString(value)
+	}
+
+	child_span, root_span, remote_span := spans[0], spans[1], spans[2]
+	assert.Equal(t, "child", get(child_span, "name"))
+	assert.Equal(t, "root", get(root_span, "name"))
+	assert.Equal(t, "remote", get(remote_span, "name"))
+
+	// All spans are in the same trace.
+	trace_id := get(root_span, "traceId")
+	assert.Equal(t, 32, len(trace_id))
+	assert.Equal(t, trace_id, get(child_span, "traceId"))
+	assert.Equal(t, trace_id, get(remote_span, "traceId"))
+
+	assert.Equal(t, "", get(root_span, "parentSpanId"))
+	assert.Equal(t, get(root_span, "spanId"), get(child_span, "parentSpanId"))
+	assert.Equal(t, get(root_span, "spanId"), get(remote_span, "parentSpanId"))
+
+	assert.Equal(t, `[{"key":"rows","value":{"intValue":"10"}}]`,
+		serialize(root_span, "attributes"))
+	assert.Equal(t, `{"code":2,"message":"failed"}`,
+		serialize(child_span, "status"))
+}
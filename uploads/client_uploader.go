@@ -14,6 +14,7 @@ import (
 	"www.velocidex.com/golang/velociraptor/constants"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 	"www.velocidex.com/golang/velociraptor/responder"
+	"www.velocidex.com/golang/velociraptor/utils"
 	"www.velocidex.com/golang/vfilter"
 )
 
@@ -25,6 +26,10 @@ var (
 type VelociraptorUploader struct {
 	Responder responder.Responder
 	Count     int
+
+	// The priority class of the collection selects the bandwidth
+	// limit applied to uploads (see Client.bandwidth).
+	PriorityClass string
 }
 
 func (self *VelociraptorUploader) Upload(
@@ -107,6 +112,11 @@ func (self *VelociraptorUploader) Upload(
 			Eof:          read_bytes == 0,
 		}
 
+		err = self.waitForBandwidth(ctx, read_bytes)
+		if err != nil {
+			return nil, err
+		}
+
 		select {
 		case <-ctx.Done():
 			return nil, errors.New("Cancelled!")
@@ -302,6 +312,11 @@ func (self *VelociraptorUploader) maybeUploadSparse(
 				UploadNumber: upload_id,
 			}
 
+			err = self.waitForBandwidth(ctx, read_bytes)
+			if err != nil {
+				return nil, err
+			}
+
 			select {
 			case <-ctx.Done():
 				return nil, errors.New("Cancelled!")
@@ -351,3 +366,15 @@ func (self *VelociraptorUploader) maybeUploadSparse(
 
 	return result, nil
 }
+
+// Block until the bandwidth limit for our priority class allows
+// sending size bytes.
+func (self *VelociraptorUploader) waitForBandwidth(
+	ctx context.Context, size int) error {
+	err := utils.GetBandwidthLimiter().WaitUpload(
+		ctx, self.PriorityClass, size)
+	if err != nil {
+		return errors.New("Cancelled!")
+	}
+	return nil
+}
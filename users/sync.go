@@ -0,0 +1,111 @@
+package users
+
+import (
+	"context"
+	"sort"
+
+	acl_proto "www.velocidex.com/golang/velociraptor/acls/proto"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// Make the user's org memberships and roles exactly match
+// roles_by_org (org id -> roles). This is used by SSO authenticators
+// where the identity provider is authoritative, so there is no
+// principal to check permissions for: The user is created if needed,
+// and removed from orgs (or deleted entirely) when the identity
+// provider no longer grants them any roles there.
+func SyncUserRoles(
+	ctx context.Context,
+	username string,
+	roles_by_org map[string][]string) error {
+
+	if isNameReserved(username) {
+		return NameReservedError
+	}
+
+	// Orgs without roles do not count as memberships.
+	desired := make(map[string][]string)
+	for org_id, roles := range roles_by_org {
+		if len(roles) > 0 {
+			desired[utils.NormalizedOrgId(org_id)] = roles
+		}
+	}
+
+	org_manager, err := services.GetOrgManager()
+	if err != nil {
+		return err
+	}
+
+	root_config_obj, err := org_manager.GetOrgConfig(services.ROOT_ORG_ID)
+	if err != nil {
+		return err
+	}
+
+	user_manager := services.GetUserManager()
+	user_record, err := user_manager.GetUserWithHashes(ctx, username)
+	if err != nil {
+		if err != services.UserNotFoundError {
+			return err
+		}
+
+		// No roles and no user - nothing to do.
+		if len(desired) == 0 {
+			return nil
+		}
+
+		user_record = &api_proto.VelociraptorUser{
+			Name: username,
+		}
+	}
+
+	// Remove the user from orgs they no longer have roles in.
+	for _, user_org := range user_record.Orgs {
+		_, pres := desired[utils.NormalizedOrgId(user_org.Id)]
+		if pres {
+			continue
+		}
+
+		org_config_obj, err := org_manager.GetOrgConfig(user_org.Id)
+		if err != nil {
+			continue
+		}
+
+		err = services.SetPolicy(
+			org_config_obj, username, &acl_proto.ApiClientACL{})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(desired) == 0 {
+		return user_manager.DeleteUser(ctx, root_config_obj, username)
+	}
+
+	org_ids := make([]string, 0, len(desired))
+	for org_id := range desired {
+		org_ids = append(org_ids, org_id)
+	}
+	sort.Strings(org_ids)
+
+	user_record.Orgs = nil
+	for _, org_id := range org_ids {
+		org_config_obj, err := org_manager.GetOrgConfig(org_id)
+		if err != nil {
+			return err
+		}
+
+		err = services.SetPolicy(org_config_obj, username,
+			&acl_proto.ApiClientACL{Roles: desired[org_id]})
+		if err != nil {
+			return err
+		}
+
+		user_record.Orgs = append(user_record.Orgs, &api_proto.OrgRecord{
+			Id: org_id,
+		})
+	}
+
+	return user_manager.SetUser(ctx, user_record)
+}
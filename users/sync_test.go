@@ -0,0 +1,68 @@
+package users_test
+
+import (
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/users"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+func (self *UserManagerTestSuite) TestSyncUserRoles() {
+	self.makeUsers()
+
+	org_manager, err := services.GetOrgManager()
+	assert.NoError(self.T(), err)
+
+	user_manager := services.GetUserManager()
+
+	getRoles := func(org_id string) []string {
+		org_config, err := org_manager.GetOrgConfig(org_id)
+		assert.NoError(self.T(), err)
+
+		policy, err := services.GetPolicy(org_config, "sso@example.com")
+		assert.NoError(self.T(), err)
+		return policy.Roles
+	}
+
+	// A new user is created with the mapped roles.
+	err = users.SyncUserRoles(self.Ctx, "sso@example.com",
+		map[string][]string{
+			"root": {"reader"},
+			"O1":   {"investigator", "reader"},
+		})
+	assert.NoError(self.T(), err)
+
+	user_record, err := user_manager.GetUser(self.Ctx, "sso@example.com")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 2, len(user_record.Orgs))
+	assert.Equal(self.T(), []string{"reader"}, getRoles("root"))
+	assert.Equal(self.T(), []string{"investigator", "reader"}, getRoles("O1"))
+
+	// The user's groups changed: They are removed from O1 and added
+	// to O2.
+	err = users.SyncUserRoles(self.Ctx, "sso@example.com",
+		map[string][]string{
+			"root": {"reader"},
+			"O1":   {},
+			"O2":   {"administrator"},
+		})
+	assert.NoError(self.T(), err)
+
+	user_record, err = user_manager.GetUser(self.Ctx, "sso@example.com")
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 2, len(user_record.Orgs))
+	assert.Equal(self.T(), "O2", user_record.Orgs[0].Id)
+	assert.Equal(self.T(), 0, len(getRoles("O1")))
+	assert.Equal(self.T(), []string{"administrator"}, getRoles("O2"))
+
+	// No groups map to roles any more: The user is removed.
+	err = users.SyncUserRoles(self.Ctx, "sso@example.com", nil)
+	assert.NoError(self.T(), err)
+
+	_, err = user_manager.GetUser(self.Ctx, "sso@example.com")
+	assert.Error(self.T(), err)
+
+	// Reserved names can not be synced.
+	err = users.SyncUserRoles(self.Ctx, "VelociraptorServer",
+		map[string][]string{"root": {"reader"}})
+	assert.ErrorContains(self.T(), err, "reserved")
+}
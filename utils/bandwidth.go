@@ -0,0 +1,286 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+const (
+	PRIORITY_CLASS_HUNT        = "hunt"
+	PRIORITY_CLASS_INTERACTIVE = "interactive"
+)
+
+var (
+	bandwidth_mu      sync.Mutex
+	bandwidth_limiter *BandwidthLimiter
+)
+
+type bandwidthWindow struct {
+	days       map[time.Weekday]bool
+	start, end int // Minutes since midnight
+	rate       uint64
+}
+
+func (self *bandwidthWindow) contains(now time.Time) bool {
+	minute := now.Hour()*60 + now.Minute()
+	day := now.Weekday()
+
+	// The window wraps around midnight so the part after
+	// midnight belongs to the previous day's window.
+	if self.start > self.end {
+		if minute < self.end {
+			day = (day + 6) % 7
+		} else if minute < self.start {
+			return false
+		}
+	} else if minute < self.start || minute >= self.end {
+		return false
+	}
+
+	return len(self.days) == 0 || self.days[day]
+}
+
+// Limits the bandwidth the client uses to talk to the server. The
+// communicator is limited by the rate in effect at the time of day
+// and uploads are further limited by the priority class of their
+// collection. A nil limiter does not limit anything.
+type BandwidthLimiter struct {
+	mu sync.Mutex
+
+	default_rate uint64
+	windows      []*bandwidthWindow
+
+	comms   *rate.Limiter
+	classes map[string]*rate.Limiter
+
+	clock Clock
+}
+
+func NewBandwidthLimiter(
+	config_obj *config_proto.BandwidthConfig) (*BandwidthLimiter, error) {
+	if config_obj == nil {
+		return nil, nil
+	}
+
+	result := &BandwidthLimiter{
+		default_rate: config_obj.Rate,
+		comms:        rate.NewLimiter(rate.Inf, 0),
+		classes:      make(map[string]*rate.Limiter),
+		clock:        GetTime(),
+	}
+
+	for _, window := range config_obj.Windows {
+		parsed, err := parseBandwidthWindow(window)
+		if err != nil {
+			return nil, err
+		}
+		result.windows = append(result.windows, parsed)
+	}
+
+	if config_obj.HuntUploadRate > 0 {
+		result.classes[PRIORITY_CLASS_HUNT] = newByteLimiter(
+			config_obj.HuntUploadRate, config_obj.HuntUploadRate)
+	}
+
+	if config_obj.InteractiveUploadRate > 0 {
+		burst := config_obj.InteractiveBurst
+		if burst < config_obj.InteractiveUploadRate {
+			burst = config_obj.InteractiveUploadRate
+		}
+		result.classes[PRIORITY_CLASS_INTERACTIVE] = newByteLimiter(
+			config_obj.InteractiveUploadRate, burst)
+	}
+
+	return result, nil
+}
+
+func newByteLimiter(bytes_per_sec, burst uint64) *rate.Limiter {
+	if bytes_per_sec == 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(bytes_per_sec), int(burst))
+}
+
+func parseBandwidthWindow(
+	window *config_proto.BandwidthWindow) (*bandwidthWindow, error) {
+	result := &bandwidthWindow{
+		days: make(map[time.Weekday]bool),
+		rate: window.Rate,
+	}
+
+	var err error
+	result.start, err = parseTimeOfDay(window.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	result.end, err = parseTimeOfDay(window.End)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, day := range window.Days {
+		weekday, err := parseWeekday(day)
+		if err != nil {
+			return nil, err
+		}
+		result.days[weekday] = true
+	}
+
+	return result, nil
+}
+
+func parseTimeOfDay(spec string) (int, error) {
+	var hour, minute int
+	_, err := fmt.Sscanf(spec, "%d:%d", &hour, &minute)
+	if err != nil || hour < 0 || hour > 24 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("Invalid time of day %q: expected HH:MM", spec)
+	}
+	return hour*60 + minute, nil
+}
+
+func parseWeekday(day string) (time.Weekday, error) {
+	for i := time.Sunday; i <= time.Saturday; i++ {
+		name := i.String()
+		if len(day) >= 3 && strings.HasPrefix(
+			strings.ToLower(name), strings.ToLower(day)) {
+			return i, nil
+		}
+	}
+	return 0, errors.New("Invalid day of week: " + day)
+}
+
+// The rate in bytes per second the communicator may currently send
+// at (0 means unlimited). The first matching window wins.
+func (self *BandwidthLimiter) CurrentRate(now time.Time) uint64 {
+	for _, window := range self.windows {
+		if window.contains(now) {
+			return window.rate
+		}
+	}
+	return self.default_rate
+}
+
+// Block until the communicator may send size bytes.
+func (self *BandwidthLimiter) WaitComms(ctx context.Context, size int) error {
+	if self == nil {
+		return nil
+	}
+
+	self.mu.Lock()
+	current := self.CurrentRate(self.clock.Now())
+	limit := rate.Limit(current)
+	if current == 0 {
+		limit = rate.Inf
+	}
+
+	// Allow a second's worth of data to be sent at once.
+	if self.comms.Limit() != limit {
+		self.comms.SetLimit(limit)
+		self.comms.SetBurst(int(current))
+	}
+	limiter := self.comms
+	self.mu.Unlock()
+
+	return waitBytes(ctx, limiter, size)
+}
+
+// Block until an upload of the given priority class may send size
+// bytes.
+func (self *BandwidthLimiter) WaitUpload(
+	ctx context.Context, class string, size int) error {
+	if self == nil {
+		return nil
+	}
+
+	self.mu.Lock()
+	limiter, pres := self.classes[class]
+	self.mu.Unlock()
+
+	if !pres {
+		return nil
+	}
+
+	return waitBytes(ctx, limiter, size)
+}
+
+// rate.Limiter can not wait for more than its burst so large
+// requests are split.
+func waitBytes(ctx context.Context, limiter *rate.Limiter, size int) error {
+	if limiter.Limit() == rate.Inf {
+		return nil
+	}
+
+	burst := limiter.Burst()
+	if burst <= 0 {
+		burst = 1
+	}
+
+	for size > 0 {
+		n := size
+		if n > burst {
+			n = burst
+		}
+
+		err := limiter.WaitN(ctx, n)
+		if err != nil {
+			return err
+		}
+		size -= n
+	}
+	return nil
+}
+
+type rateLimitedReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *rate.Limiter
+}
+
+func (self *rateLimitedReader) Read(buf []byte) (int, error) {
+	n, err := self.reader.Read(buf)
+	if n > 0 {
+		wait_err := waitBytes(self.ctx, self.limiter, n)
+		if wait_err != nil {
+			return n, wait_err
+		}
+	}
+	return n, err
+}
+
+// Limit reading from the reader to bytes_per_sec. A rate of 0 does
+// not limit the reader.
+func NewRateLimitedReader(ctx context.Context,
+	reader io.Reader, bytes_per_sec uint64) io.Reader {
+	if bytes_per_sec == 0 {
+		return reader
+	}
+
+	return &rateLimitedReader{
+		ctx:     ctx,
+		reader:  reader,
+		limiter: newByteLimiter(bytes_per_sec, bytes_per_sec),
+	}
+}
+
+func SetBandwidthLimiter(limiter *BandwidthLimiter) {
+	bandwidth_mu.Lock()
+	defer bandwidth_mu.Unlock()
+
+	bandwidth_limiter = limiter
+}
+
+func GetBandwidthLimiter() *BandwidthLimiter {
+	bandwidth_mu.Lock()
+	defer bandwidth_mu.Unlock()
+
+	return bandwidth_limiter
+}
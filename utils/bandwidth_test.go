@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+func TestBandwidthWindows(t *testing.T) {
+	limiter, err := NewBandwidthLimiter(&config_proto.BandwidthConfig{
+		Rate: 1000,
+		Windows: []*config_proto.BandwidthWindow{{
+			Days:  []string{"Mon", "tuesday"},
+			Start: "08:00",
+			End:   "18:00",
+			Rate:  10,
+		}, {
+			Start: "22:00",
+			End:   "02:30",
+			Rate:  20,
+		}},
+	})
+	assert.NoError(t, err)
+
+	// Monday 10 Oct 2022
+	monday := time.Date(2022, 10, 10, 9, 0, 0, 0, time.Local)
+	assert.Equal(t, uint64(10), limiter.CurrentRate(monday))
+	assert.Equal(t, uint64(1000), limiter.CurrentRate(monday.Add(9*time.Hour)))
+
+	// Wednesday is not in the first window.
+	assert.Equal(t, uint64(1000), limiter.CurrentRate(monday.Add(48*time.Hour)))
+
+	// The second window wraps around midnight.
+	assert.Equal(t, uint64(20), limiter.CurrentRate(monday.Add(14*time.Hour)))
+	assert.Equal(t, uint64(20), limiter.CurrentRate(monday.Add(17*time.Hour)))
+	assert.Equal(t, uint64(1000), limiter.CurrentRate(monday.Add(18*time.Hour)))
+
+	_, err = NewBandwidthLimiter(&config_proto.BandwidthConfig{
+		Windows: []*config_proto.BandwidthWindow{{Start: "8am"}},
+	})
+	assert.Error(t, err)
+
+	_, err = NewBandwidthLimiter(&config_proto.BandwidthConfig{
+		Windows: []*config_proto.BandwidthWindow{{
+			Days: []string{"Someday"}, Start: "08:00", End: "09:00"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestRateLimitedReader(t *testing.T) {
+	data := make([]byte, 300)
+	reader := NewRateLimitedReader(context.Background(),
+		bytes.NewReader(data), 100)
+
+	// The first second's worth of data is allowed as a burst.
+	start := time.Now()
+	n, err := io.Copy(ioutil.Discard, reader)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), n)
+	assert.True(t, time.Now().Sub(start) >= time.Second)
+
+	// Cancelling the context aborts the read.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	reader = NewRateLimitedReader(ctx, bytes.NewReader(data), 100)
+	_, err = io.Copy(ioutil.Discard, reader)
+	assert.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package utils
+
+import "sync"
+
+var (
+	quarantine_mu sync.Mutex
+	quarantined   bool
+)
+
+// Record if the client's network is currently quarantined so it can
+// be reported in the client's info.
+func SetQuarantined(value bool) {
+	quarantine_mu.Lock()
+	defer quarantine_mu.Unlock()
+
+	quarantined = value
+}
+
+func IsQuarantined() bool {
+	quarantine_mu.Lock()
+	defer quarantine_mu.Unlock()
+
+	return quarantined
+}
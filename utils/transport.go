@@ -0,0 +1,41 @@
+package utils
+
+import "sync"
+
+var (
+	transport_mu sync.Mutex
+	transport    string
+	frontend     string
+)
+
+// Record the transport the client currently uses to talk to the
+// server (e.g. "http" or "websocket") so it can be reported in the
+// client's info.
+func SetCommsTransport(name string) {
+	transport_mu.Lock()
+	defer transport_mu.Unlock()
+
+	transport = name
+}
+
+func GetCommsTransport() string {
+	transport_mu.Lock()
+	defer transport_mu.Unlock()
+
+	return transport
+}
+
+// Record the frontend the client is currently connected to.
+func SetCommsFrontend(url string) {
+	transport_mu.Lock()
+	defer transport_mu.Unlock()
+
+	frontend = url
+}
+
+func GetCommsFrontend() string {
+	transport_mu.Lock()
+	defer transport_mu.Unlock()
+
+	return frontend
+}
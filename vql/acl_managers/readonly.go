@@ -0,0 +1,61 @@
+package acl_managers
+
+import (
+	"www.velocidex.com/golang/velociraptor/acls"
+	acl_proto "www.velocidex.com/golang/velociraptor/acls/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+)
+
+// ReadOnlyACLManager only grants the principal's READ_RESULTS
+// permission. Plugins which modify state or reach outside the result
+// sets (e.g. collect_client() or execve()) are denied even for
+// administrators, while artifact and label scopes still apply.
+type ReadOnlyACLManager struct {
+	*ServerACLManager
+}
+
+func (self *ReadOnlyACLManager) CheckAccess(
+	permissions ...acls.ACL_PERMISSION) (bool, error) {
+	if !readOnly(permissions) {
+		return false, nil
+	}
+	return self.ServerACLManager.CheckAccess(permissions...)
+}
+
+func (self *ReadOnlyACLManager) CheckAccessInOrg(
+	org_id string, permissions ...acls.ACL_PERMISSION) (bool, error) {
+	if !readOnly(permissions) {
+		return false, nil
+	}
+	return self.ServerACLManager.CheckAccessInOrg(org_id, permissions...)
+}
+
+func (self *ReadOnlyACLManager) CheckAccessWithArgs(
+	permission acls.ACL_PERMISSION, args ...string) (bool, error) {
+	if !readOnly([]acls.ACL_PERMISSION{permission}) {
+		return false, nil
+	}
+	return self.ServerACLManager.CheckAccessWithArgs(permission, args...)
+}
+
+func readOnly(permissions []acls.ACL_PERMISSION) bool {
+	for _, permission := range permissions {
+		if permission != acls.READ_RESULTS {
+			return false
+		}
+	}
+	return true
+}
+
+func NewReadOnlyACLManager(
+	config_obj *config_proto.Config,
+	principal string) vql_subsystem.ACLManager {
+	return &ReadOnlyACLManager{
+		ServerACLManager: &ServerACLManager{
+			principal:  principal,
+			config_obj: config_obj,
+			TokenCache: make(map[string]*acl_proto.ApiClientACL),
+		},
+	}
+}
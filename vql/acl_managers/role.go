@@ -50,6 +50,14 @@ func (self *RoleACLManager) CheckAccessWithArgs(
 	return services.CheckAccessWithToken(self.Token, permission, args...)
 }
 
+func (self *RoleACLManager) CheckArtifactScope(artifact string) (bool, error) {
+	return services.CheckArtifactScopeWithToken(self.Token, artifact), nil
+}
+
+func (self *RoleACLManager) CheckLabelScope(labels ...string) (bool, error) {
+	return services.CheckLabelScopeWithToken(self.Token, labels...), nil
+}
+
 // NewRoleACLManager creates an ACL manager with only the assigned
 // roles. This is useful for creating limited VQL permissions
 // internally.
@@ -100,6 +100,24 @@ func (self *ServerACLManager) CheckAccessWithArgs(
 	return services.CheckAccessWithToken(policy, permission, args...)
 }
 
+func (self *ServerACLManager) CheckArtifactScope(artifact string) (bool, error) {
+	policy, err := self.getPolicyInOrg(self.config_obj.OrgId)
+	if err != nil {
+		return false, err
+	}
+
+	return services.CheckArtifactScopeWithToken(policy, artifact), nil
+}
+
+func (self *ServerACLManager) CheckLabelScope(labels ...string) (bool, error) {
+	policy, err := self.getPolicyInOrg(self.config_obj.OrgId)
+	if err != nil {
+		return false, err
+	}
+
+	return services.CheckLabelScopeWithToken(policy, labels...), nil
+}
+
 func NewServerACLManager(
 	config_obj *config_proto.Config,
 	principal string) vql_subsystem.ACLManager {
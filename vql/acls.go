@@ -27,6 +27,13 @@ type PrincipalACLManager interface {
 	GetPrincipal() string
 }
 
+// An ACLManager may additionally restrict the principal to a subset
+// of artifacts and clients.
+type ScopedACLManager interface {
+	CheckArtifactScope(artifact string) (bool, error)
+	CheckLabelScope(labels ...string) (bool, error)
+}
+
 // Check access through the ACL manager in the scope.  NOTE: This
 // assumes it is not possible for a user to mask the ACL manager in
 // the scope! There is currently no way to create an acl manager type
@@ -0,0 +1,480 @@
+/*
+  Extensions are separate processes which provide VQL plugins and
+  functions over gRPC (see api/proto/extensions.proto). Since the
+  protocol is plain gRPC, extensions may be written in any language.
+
+  The extension process serves the VQLExtension service on a local
+  address. When it is registered (using the extension_register() VQL
+  function) we connect to it, list its plugins and functions and
+  register them with the VQL subsystem so they can be used by any
+  subsequent query. Since the VQL subsystem is shared by all orgs,
+  only server admins in the root org may register extensions.
+
+  Each call is forwarded to the extension with its args encoded as
+  JSON. The executor enforces the following on every call:
+
+  1. The calling query must hold all the capabilities (ACL
+     permissions) the plugin declares. Plugins which declare no
+     capabilities require EXECVE since they run outside the VQL
+     sandbox.
+  2. The call is cancelled after the extension's timeout.
+  3. The call is cancelled once it produced more than max_rows rows
+     or max_bytes bytes.
+
+  The resources used by each extension are accounted for and can be
+  inspected with the extensions() plugin.
+*/
+
+package extensions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	DEFAULT_TIMEOUT = 600 * time.Second
+)
+
+var (
+	mu sync.Mutex
+
+	// Registered extensions by name.
+	extensions = make(map[string]*Extension)
+
+	// The extension owning each plugin and function we registered
+	// with the VQL subsystem.
+	plugin_owners   = make(map[string]string)
+	function_owners = make(map[string]string)
+
+	timeoutError = errors.New("timed out")
+)
+
+type ExtensionOptions struct {
+	Address string
+	Timeout time.Duration
+
+	// Limits on each call. 0 means unlimited.
+	MaxRows  uint64
+	MaxBytes uint64
+
+	// If set, the only capabilities the extension's plugins may
+	// declare. Plugins declaring other capabilities are not
+	// registered.
+	Capabilities []string
+}
+
+type Extension struct {
+	mu sync.Mutex
+
+	options     ExtensionOptions
+	description *api_proto.ExtensionDescription
+	conn        *grpc.ClientConn
+	client      api_proto.VQLExtensionClient
+
+	// The plugins we accepted from the extension's description.
+	plugins map[string]*api_proto.ExtensionPlugin
+
+	// Resource accounting
+	calls    uint64
+	rows     uint64
+	bytes    uint64
+	errors   uint64
+	timeouts uint64
+	duration time.Duration
+}
+
+func (self *Extension) Name() string {
+	return self.description.Name
+}
+
+func (self *Extension) Stats() *ordereddict.Dict {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	plugins := make([]string, 0, len(self.plugins))
+	for name := range self.plugins {
+		plugins = append(plugins, name)
+	}
+	sort.Strings(plugins)
+
+	return ordereddict.NewDict().
+		Set("Name", self.description.Name).
+		Set("Version", self.description.Version).
+		Set("Address", self.options.Address).
+		Set("Plugins", plugins).
+		Set("Calls", self.calls).
+		Set("Rows", self.rows).
+		Set("Bytes", self.bytes).
+		Set("Errors", self.errors).
+		Set("Timeouts", self.timeouts).
+		Set("Duration", self.duration.Seconds())
+}
+
+func (self *Extension) getPlugin(name string) (*api_proto.ExtensionPlugin, bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	plugin, pres := self.plugins[name]
+	return plugin, pres
+}
+
+func (self *Extension) account(rows, bytes uint64,
+	duration time.Duration, err error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.calls++
+	self.rows += rows
+	self.bytes += bytes
+	self.duration += duration
+
+	if errors.Is(err, timeoutError) {
+		self.timeouts++
+	} else if err != nil {
+		self.errors++
+	}
+}
+
+// Forward the call to the extension and pass each result to the
+// callback. The callback returns false to stop the call early.
+func (self *Extension) Call(
+	ctx context.Context, scope vfilter.Scope,
+	name string, args *ordereddict.Dict,
+	cb func(item vfilter.Any) bool) (err error) {
+
+	plugin, pres := self.getPlugin(name)
+	if !pres {
+		return fmt.Errorf("Extension %v does not provide %v",
+			self.Name(), name)
+	}
+
+	// Make sure the query is allowed to use the plugin.
+	permissions, err := getPermissions(plugin.Capabilities)
+	if err != nil {
+		return err
+	}
+
+	err = vql_subsystem.CheckAccess(scope, permissions...)
+	if err != nil {
+		return err
+	}
+
+	serialized, err := json.Marshal(vfilter.RowToDict(ctx, scope, args))
+	if err != nil {
+		return err
+	}
+
+	var rows, bytes uint64
+	start := utils.GetTime().Now()
+	defer func() {
+		self.account(rows, bytes, utils.GetTime().Now().Sub(start), err)
+	}()
+
+	sub_ctx, cancel := context.WithTimeout(ctx, self.options.Timeout)
+	defer cancel()
+
+	stream, err := self.client.Call(sub_ctx, &api_proto.ExtensionCallRequest{
+		Name:     name,
+		Args:     string(serialized),
+		Timeout:  uint64(self.options.Timeout.Seconds()),
+		MaxRows:  self.options.MaxRows,
+		MaxBytes: self.options.MaxBytes,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			if errors.Is(sub_ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("%w after %v", timeoutError,
+					self.options.Timeout)
+			}
+
+			// The query was cancelled - this is not an error.
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if response.Log != "" {
+			scope.Log("%v: %v", name, response.Log)
+		}
+
+		if response.Row == "" {
+			continue
+		}
+
+		bytes += uint64(len(response.Row))
+		if self.options.MaxBytes > 0 && bytes > self.options.MaxBytes {
+			return fmt.Errorf("Call exceeded max_bytes (%v)",
+				self.options.MaxBytes)
+		}
+
+		rows++
+		if self.options.MaxRows > 0 && rows > self.options.MaxRows {
+			return fmt.Errorf("Call exceeded max_rows (%v)",
+				self.options.MaxRows)
+		}
+
+		item, err := decodeValue(response.Row)
+		if err != nil {
+			return err
+		}
+
+		if !cb(item) {
+			return nil
+		}
+	}
+}
+
+func (self *Extension) Close() {
+	self.conn.Close()
+}
+
+// Objects are decoded into dicts to preserve the key order - other
+// values are passed as is.
+func decodeValue(serialized string) (vfilter.Any, error) {
+	if strings.HasPrefix(strings.TrimSpace(serialized), "{") {
+		result := ordereddict.NewDict()
+		err := json.Unmarshal([]byte(serialized), result)
+		return result, err
+	}
+
+	var result interface{}
+	err := json.Unmarshal([]byte(serialized), &result)
+	return result, err
+}
+
+// Plugins which do not declare their capabilities could do anything
+// so they are treated like running an external program.
+func getPermissions(capabilities []string) ([]acls.ACL_PERMISSION, error) {
+	if len(capabilities) == 0 {
+		return []acls.ACL_PERMISSION{acls.EXECVE}, nil
+	}
+
+	result := make([]acls.ACL_PERMISSION, 0, len(capabilities))
+	for _, capability := range capabilities {
+		permission := acls.GetPermission(capability)
+		if permission == acls.NO_PERMISSIONS {
+			return nil, fmt.Errorf("Unknown capability %v", capability)
+		}
+		result = append(result, permission)
+	}
+	return result, nil
+}
+
+// Extensions are served without TLS so we only talk to them over
+// local transports.
+func checkAddress(address string) error {
+	if strings.HasPrefix(address, "unix:") {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if host == "localhost" {
+		return nil
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf(
+			"Extension address %v must be a unix socket or a loopback address",
+			address)
+	}
+	return nil
+}
+
+func validatePlugin(
+	plugin *api_proto.ExtensionPlugin, allowed []string) error {
+	if plugin.Name == "" {
+		return errors.New("Plugin has no name")
+	}
+
+	_, err := getPermissions(plugin.Capabilities)
+	if err != nil {
+		return err
+	}
+
+	if len(allowed) > 0 {
+		for _, capability := range plugin.Capabilities {
+			if !utils.InString(allowed, strings.ToUpper(capability)) {
+				return fmt.Errorf("Capability %v is not allowed", capability)
+			}
+		}
+	}
+	return nil
+}
+
+// Connect to the extension and register its plugins and
+// functions. If an extension with the same name is already
+// registered it is replaced.
+func RegisterExtension(
+	ctx context.Context, scope vfilter.Scope,
+	options ExtensionOptions) (*Extension, error) {
+
+	err := checkAddress(options.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Timeout == 0 {
+		options.Timeout = DEFAULT_TIMEOUT
+	}
+
+	for i := range options.Capabilities {
+		options.Capabilities[i] = strings.ToUpper(options.Capabilities[i])
+	}
+
+	conn, err := grpc.DialContext(ctx, options.Address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	client := api_proto.NewVQLExtensionClient(conn)
+	sub_ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	description, err := client.Describe(sub_ctx,
+		&api_proto.ExtensionDescribeRequest{})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if description.Name == "" {
+		conn.Close()
+		return nil, errors.New("Extension has no name")
+	}
+
+	self := &Extension{
+		options:     options,
+		description: description,
+		conn:        conn,
+		client:      client,
+		plugins:     make(map[string]*api_proto.ExtensionPlugin),
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, plugin := range description.Plugins {
+		err := validatePlugin(plugin, options.Capabilities)
+		if err == nil {
+			err = claimName(self.Name(), plugin)
+		}
+		if err != nil {
+			scope.Log("extension_register: Skipping %v from %v: %v",
+				plugin.Name, self.Name(), err)
+			continue
+		}
+
+		self.plugins[plugin.Name] = plugin
+	}
+
+	old, pres := extensions[self.Name()]
+	if pres {
+		old.Close()
+	}
+	extensions[self.Name()] = self
+
+	for _, plugin := range self.plugins {
+		switch plugin.Type {
+		case api_proto.ExtensionPlugin_FUNCTION:
+			vql_subsystem.OverrideFunction(&ExtensionFunction{
+				extension: self.Name(),
+				plugin:    plugin,
+			})
+
+		default:
+			vql_subsystem.OverridePlugin(&ExtensionPlugin{
+				extension: self.Name(),
+				plugin:    plugin,
+			})
+		}
+	}
+
+	return self, nil
+}
+
+// Extensions may only use names that are free or that they already
+// own. Must be called with the lock held.
+func claimName(extension string, plugin *api_proto.ExtensionPlugin) error {
+	owners := plugin_owners
+	present := func() bool {
+		_, pres := vql_subsystem.GetPlugin(plugin.Name)
+		return pres
+	}
+
+	if plugin.Type == api_proto.ExtensionPlugin_FUNCTION {
+		owners = function_owners
+		present = func() bool {
+			_, pres := vql_subsystem.GetFunction(plugin.Name)
+			return pres
+		}
+	}
+
+	owner, pres := owners[plugin.Name]
+	if pres && owner != extension {
+		return fmt.Errorf("Name already registered by extension %v", owner)
+	}
+
+	if !pres && present() {
+		return errors.New("Name clashes with a built in VQL plugin or function")
+	}
+
+	owners[plugin.Name] = extension
+	return nil
+}
+
+func GetExtension(name string) (*Extension, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	extension, pres := extensions[name]
+	return extension, pres
+}
+
+func ListExtensions() []*Extension {
+	mu.Lock()
+	defer mu.Unlock()
+
+	result := make([]*Extension, 0, len(extensions))
+	for _, extension := range extensions {
+		result = append(result, extension)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name() < result[j].Name()
+	})
+	return result
+}
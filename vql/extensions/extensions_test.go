@@ -0,0 +1,296 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/config"
+	"www.velocidex.com/golang/velociraptor/json"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+	"www.velocidex.com/golang/vfilter"
+)
+
+type testExtension struct {
+	api_proto.UnimplementedVQLExtensionServer
+}
+
+func (self *testExtension) Describe(
+	ctx context.Context,
+	in *api_proto.ExtensionDescribeRequest) (*api_proto.ExtensionDescription, error) {
+	return &api_proto.ExtensionDescription{
+		Name:    "TestExtension",
+		Version: "1.0",
+		Plugins: []*api_proto.ExtensionPlugin{{
+			Name: "test_range",
+			Doc:  "Emit count rows.",
+			Args: []*api_proto.ExtensionArg{{
+				Name: "count", Type: "int64", Required: true,
+			}},
+		}, {
+			Name: "test_upcase",
+			Type: api_proto.ExtensionPlugin_FUNCTION,
+		}, {
+			Name:         "test_files",
+			Capabilities: []string{"FILESYSTEM_READ"},
+		}, {
+			Name: "test_sleep",
+		}, {
+			// Clashes with a built in plugin.
+			Name: "extensions",
+		}, {
+			Name:         "test_bad",
+			Capabilities: []string{"NO_SUCH_CAPABILITY"},
+		}},
+	}, nil
+}
+
+func (self *testExtension) Call(
+	in *api_proto.ExtensionCallRequest,
+	stream api_proto.VQLExtension_CallServer) error {
+
+	args := ordereddict.NewDict()
+	err := json.Unmarshal([]byte(in.Args), args)
+	if err != nil {
+		return err
+	}
+
+	switch in.Name {
+	case "test_range":
+		count, _ := args.GetInt64("count")
+		for i := int64(0); i < count; i++ {
+			err := stream.Send(&api_proto.ExtensionCallResponse{
+				Row: fmt.Sprintf(`{"Index": %d}`, i),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+	case "test_upcase":
+		value, _ := args.GetString("value")
+		return stream.Send(&api_proto.ExtensionCallResponse{
+			Log: "Upcasing " + value,
+			Row: json.MustMarshalString(strings.ToUpper(value)),
+		})
+
+	case "test_files":
+		return stream.Send(&api_proto.ExtensionCallResponse{
+			Row: `{"Name": "file.txt"}`,
+		})
+
+	case "test_sleep":
+		<-stream.Context().Done()
+	}
+
+	return nil
+}
+
+type ExtensionsTestSuite struct {
+	suite.Suite
+
+	ctx     context.Context
+	cancel  func()
+	server  *grpc.Server
+	tmpdir  string
+	address string
+}
+
+func (self *ExtensionsTestSuite) SetupTest() {
+	var err error
+	self.tmpdir, err = os.MkdirTemp("", "tmp")
+	assert.NoError(self.T(), err)
+
+	socket := filepath.Join(self.tmpdir, "extension.sock")
+	listener, err := net.Listen("unix", socket)
+	assert.NoError(self.T(), err)
+
+	self.server = grpc.NewServer()
+	api_proto.RegisterVQLExtensionServer(self.server, &testExtension{})
+	go self.server.Serve(listener)
+
+	self.address = "unix://" + socket
+	self.ctx, self.cancel = context.WithTimeout(
+		context.Background(), 60*time.Second)
+}
+
+func (self *ExtensionsTestSuite) TearDownTest() {
+	self.cancel()
+	self.server.Stop()
+	os.RemoveAll(self.tmpdir)
+}
+
+func (self *ExtensionsTestSuite) run(
+	acl_manager vql_subsystem.ACLManager, query string) ([]vfilter.Row, []string) {
+	logs := &strings.Builder{}
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_manager))
+	scope.SetLogger(log.New(logs, "", 0))
+	defer scope.Close()
+
+	vql, err := vfilter.Parse(query)
+	assert.NoError(self.T(), err)
+
+	rows := []vfilter.Row{}
+	for row := range vql.Eval(self.ctx, scope) {
+		rows = append(rows, row)
+	}
+	return rows, strings.Split(logs.String(), "\n")
+}
+
+func (self *ExtensionsTestSuite) register(options ExtensionOptions) {
+	options.Address = self.address
+
+	logs := &strings.Builder{}
+	scope := vql_subsystem.MakeScope()
+	scope.SetLogger(log.New(logs, "", 0))
+	defer scope.Close()
+
+	extension, err := RegisterExtension(self.ctx, scope, options)
+	assert.NoError(self.T(), err)
+
+	plugins, _ := extension.Stats().Get("Plugins")
+	assert.Equal(self.T(),
+		[]string{"test_files", "test_range", "test_sleep", "test_upcase"},
+		plugins)
+
+	// Clashing and invalid plugins are skipped.
+	assert.Contains(self.T(), logs.String(),
+		"Skipping extensions from TestExtension: Name clashes")
+	assert.Contains(self.T(), logs.String(),
+		"Skipping test_bad from TestExtension: Unknown capability")
+}
+
+func (self *ExtensionsTestSuite) TestCalls() {
+	self.register(ExtensionOptions{})
+
+	rows, _ := self.run(acl_managers.NullACLManager{},
+		"SELECT * FROM test_range(count=3)")
+	assert.Equal(self.T(), 3, len(rows))
+	assert.Equal(self.T(), `{"Index":2}`, json.MustMarshalString(rows[2]))
+
+	rows, logs := self.run(acl_managers.NullACLManager{},
+		"SELECT test_upcase(value='hello') AS Value FROM scope()")
+	assert.Equal(self.T(), 1, len(rows))
+	assert.Equal(self.T(), `{"Value":"HELLO"}`, json.MustMarshalString(rows[0]))
+	assert.Contains(self.T(), logs, "test_upcase: Upcasing hello")
+
+	// Capabilities are enforced.
+	config_obj := config.GetDefaultConfig()
+	rows, logs = self.run(acl_managers.NewRoleACLManager(config_obj, "reader"),
+		"SELECT * FROM test_files()")
+	assert.Equal(self.T(), 0, len(rows))
+	assert.Contains(self.T(), strings.Join(logs, "\n"),
+		"test_files: Permission denied")
+
+	rows, _ = self.run(acl_managers.NullACLManager{},
+		"SELECT * FROM test_files()")
+	assert.Equal(self.T(), 1, len(rows))
+
+	// Plugins which do not declare capabilities require EXECVE.
+	rows, logs = self.run(acl_managers.NewRoleACLManager(config_obj, "reader"),
+		"SELECT * FROM test_range(count=3)")
+	assert.Equal(self.T(), 0, len(rows))
+	assert.Contains(self.T(), strings.Join(logs, "\n"),
+		"test_range: Permission denied: [EXECVE]")
+
+	extension, pres := GetExtension("TestExtension")
+	assert.True(self.T(), pres)
+
+	stats := extension.Stats()
+	calls, _ := stats.GetInt64("Calls")
+	assert.Equal(self.T(), int64(3), calls)
+
+	total_rows, _ := stats.GetInt64("Rows")
+	assert.Equal(self.T(), int64(5), total_rows)
+}
+
+func (self *ExtensionsTestSuite) TestLimits() {
+	self.register(ExtensionOptions{
+		Timeout: time.Second,
+		MaxRows: 5,
+	})
+
+	rows, logs := self.run(acl_managers.NullACLManager{},
+		"SELECT * FROM test_range(count=10)")
+	assert.Equal(self.T(), 5, len(rows))
+	assert.Contains(self.T(), logs, "test_range: Call exceeded max_rows (5)")
+
+	rows, logs = self.run(acl_managers.NullACLManager{},
+		"SELECT * FROM test_sleep()")
+	assert.Equal(self.T(), 0, len(rows))
+	assert.Contains(self.T(), logs, "test_sleep: timed out after 1s")
+
+	extension, _ := GetExtension("TestExtension")
+	stats := extension.Stats()
+	errors, _ := stats.GetInt64("Errors")
+	assert.Equal(self.T(), int64(1), errors)
+
+	timeouts, _ := stats.GetInt64("Timeouts")
+	assert.Equal(self.T(), int64(1), timeouts)
+}
+
+// Holds all permissions but only in a single org.
+type orgACLManager struct {
+	acl_managers.NullACLManager
+
+	org_id string
+}
+
+func (self orgACLManager) CheckAccessInOrg(
+	org_id string, permissions ...acls.ACL_PERMISSION) (bool, error) {
+	return org_id == self.org_id, nil
+}
+
+func (self *ExtensionsTestSuite) TestRegister() {
+	query := fmt.Sprintf(
+		"SELECT extension_register(address='%v') AS Extension FROM scope()",
+		self.address)
+
+	// Registration is limited to server admins in the root org
+	// since the plugins are visible to all orgs.
+	config_obj := config.GetDefaultConfig()
+	for _, acl_manager := range []vql_subsystem.ACLManager{
+		acl_managers.NewRoleACLManager(config_obj, "investigator"),
+		orgACLManager{org_id: "O123"},
+	} {
+		rows, logs := self.run(acl_manager, query)
+		assert.Equal(self.T(), 1, len(rows))
+		assert.Equal(self.T(), `{"Extension":null}`, json.MustMarshalString(rows[0]))
+		assert.Contains(self.T(), strings.Join(logs, "\n"),
+			"extension_register: Permission denied")
+	}
+
+	rows, _ := self.run(orgACLManager{org_id: ""}, query)
+	assert.Equal(self.T(), 1, len(rows))
+
+	extension, _ := rows[0].(*ordereddict.Dict).Get("Extension")
+	name, _ := extension.(*ordereddict.Dict).GetString("Name")
+	assert.Equal(self.T(), "TestExtension", name)
+}
+
+func (self *ExtensionsTestSuite) TestAddress() {
+	assert.NoError(self.T(), checkAddress("unix:///tmp/socket"))
+	assert.NoError(self.T(), checkAddress("127.0.0.1:8010"))
+	assert.NoError(self.T(), checkAddress("localhost:8010"))
+	assert.NoError(self.T(), checkAddress("[::1]:8010"))
+	assert.Error(self.T(), checkAddress("10.0.0.1:8010"))
+	assert.Error(self.T(), checkAddress("example.com:8010"))
+}
+
+func TestExtensions(t *testing.T) {
+	suite.Run(t, &ExtensionsTestSuite{})
+}
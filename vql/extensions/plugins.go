@@ -0,0 +1,223 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+func describe(extension string, plugin *api_proto.ExtensionPlugin) string {
+	doc := plugin.Doc
+	if doc == "" {
+		doc = fmt.Sprintf("Provided by extension %v.", extension)
+	}
+
+	args := []string{}
+	for _, arg := range plugin.Args {
+		desc := fmt.Sprintf("%v (%v): %v", arg.Name, arg.Type, arg.Doc)
+		if arg.Required {
+			desc += " (required)"
+		}
+		args = append(args, desc)
+	}
+
+	if len(args) > 0 {
+		doc += "\n\nArgs:\n" + strings.Join(args, "\n")
+	}
+	return doc
+}
+
+// A VQL plugin implemented by an extension.
+type ExtensionPlugin struct {
+	extension string
+	plugin    *api_proto.ExtensionPlugin
+}
+
+func (self *ExtensionPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		extension, pres := GetExtension(self.extension)
+		if !pres {
+			scope.Log("%v: Extension %v is not registered",
+				self.plugin.Name, self.extension)
+			return
+		}
+
+		err := extension.Call(ctx, scope, self.plugin.Name, args,
+			func(item vfilter.Any) bool {
+				select {
+				case <-ctx.Done():
+					return false
+				case output_chan <- item:
+					return true
+				}
+			})
+		if err != nil {
+			scope.Log("%v: %v", self.plugin.Name, err)
+		}
+	}()
+
+	return output_chan
+}
+
+func (self *ExtensionPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: self.plugin.Name,
+		Doc:  describe(self.extension, self.plugin),
+	}
+}
+
+// A VQL function implemented by an extension.
+type ExtensionFunction struct {
+	extension string
+	plugin    *api_proto.ExtensionPlugin
+}
+
+func (self *ExtensionFunction) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	extension, pres := GetExtension(self.extension)
+	if !pres {
+		scope.Log("%v: Extension %v is not registered",
+			self.plugin.Name, self.extension)
+		return vfilter.Null{}
+	}
+
+	var result vfilter.Any = vfilter.Null{}
+	err := extension.Call(ctx, scope, self.plugin.Name, args,
+		func(item vfilter.Any) bool {
+			result = item
+			return false
+		})
+	if err != nil {
+		scope.Log("%v: %v", self.plugin.Name, err)
+		return vfilter.Null{}
+	}
+
+	return result
+}
+
+// The default copier would lose the extension details.
+func (self *ExtensionFunction) Copy() vfilter.FunctionInterface {
+	return &ExtensionFunction{
+		extension: self.extension,
+		plugin:    self.plugin,
+	}
+}
+
+func (self *ExtensionFunction) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name: self.plugin.Name,
+		Doc:  describe(self.extension, self.plugin),
+	}
+}
+
+type ExtensionRegisterFunctionArgs struct {
+	Address      string   `vfilter:"required,field=address,doc=The address the extension listens on (e.g. unix:///path/to/socket or 127.0.0.1:8010)."`
+	Timeout      uint64   `vfilter:"optional,field=timeout,doc=Cancel each call after this many seconds (default 600)."`
+	MaxRows      uint64   `vfilter:"optional,field=max_rows,doc=Cancel each call after it produced this many rows."`
+	MaxBytes     uint64   `vfilter:"optional,field=max_bytes,doc=Cancel each call after it produced this many bytes of results."`
+	Capabilities []string `vfilter:"optional,field=capabilities,doc=If set, only register plugins which declare these capabilities (e.g. FILESYSTEM_READ)."`
+}
+
+type ExtensionRegisterFunction struct{}
+
+func (self ExtensionRegisterFunction) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	// Extensions run outside the VQL sandbox so registering one is
+	// as powerful as running an external program. Their plugins are
+	// visible to all orgs so only root org admins may register them.
+	err := vql_subsystem.CheckAccessInOrg(scope, "root",
+		acls.EXECVE, acls.SERVER_ADMIN)
+	if err != nil {
+		scope.Log("extension_register: %s", err)
+		return vfilter.Null{}
+	}
+
+	arg := &ExtensionRegisterFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("extension_register: %s", err)
+		return vfilter.Null{}
+	}
+
+	extension, err := RegisterExtension(ctx, scope, ExtensionOptions{
+		Address:      arg.Address,
+		Timeout:      time.Duration(arg.Timeout) * time.Second,
+		MaxRows:      arg.MaxRows,
+		MaxBytes:     arg.MaxBytes,
+		Capabilities: arg.Capabilities,
+	})
+	if err != nil {
+		scope.Log("extension_register: %s", err)
+		return vfilter.Null{}
+	}
+
+	return extension.Stats()
+}
+
+func (self ExtensionRegisterFunction) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "extension_register",
+		Doc:     "Register the VQL plugins and functions served by an extension process.",
+		ArgType: type_map.AddType(scope, &ExtensionRegisterFunctionArgs{}),
+	}
+}
+
+type ExtensionsPlugin struct{}
+
+func (self ExtensionsPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		for _, extension := range ListExtensions() {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- extension.Stats():
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self ExtensionsPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "extensions",
+		Doc:  "List the registered extensions and the resources they used.",
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&ExtensionRegisterFunction{})
+	vql_subsystem.RegisterPlugin(&ExtensionsPlugin{})
+}
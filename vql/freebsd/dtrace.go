@@ -0,0 +1,151 @@
+// +build freebsd
+
+package freebsd
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type DTracePluginArgs struct {
+	Probes []string `vfilter:"optional,field=probes,doc=The probes to enable: exec, open and connect (default all)."`
+	DTrace string   `vfilter:"optional,field=dtrace,doc=The path to the dtrace binary (default /usr/sbin/dtrace)."`
+}
+
+type DTracePlugin struct{}
+
+func (self DTracePlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "dtrace",
+		Doc:     "Watch process executions, file opens and TCP connections using DTrace.",
+		ArgType: type_map.AddType(scope, &DTracePluginArgs{}),
+	}
+}
+
+func (self DTracePlugin) Call(
+	ctx context.Context, scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.MACHINE_STATE)
+		if err != nil {
+			scope.Log("dtrace: %s", err)
+			return
+		}
+
+		arg := &DTracePluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("dtrace: %s", err)
+			return
+		}
+
+		if len(arg.Probes) == 0 {
+			arg.Probes = []string{"exec", "open", "connect"}
+		}
+
+		if arg.DTrace == "" {
+			arg.DTrace = "/usr/sbin/dtrace"
+		}
+
+		script, err := buildScript(arg.Probes, os.Getpid())
+		if err != nil {
+			scope.Log("dtrace: %s", err)
+			return
+		}
+
+		tmpfile, err := ioutil.TempFile("", "dtrace*.d")
+		if err != nil {
+			scope.Log("dtrace: %s", err)
+			return
+		}
+		defer os.Remove(tmpfile.Name())
+
+		_, err = tmpfile.Write([]byte(script))
+		tmpfile.Close()
+		if err != nil {
+			scope.Log("dtrace: %s", err)
+			return
+		}
+
+		sub_ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// Drop events rather than slow down the system when the
+		// query can not keep up.
+		command := exec.CommandContext(sub_ctx, arg.DTrace,
+			"-x", "strsize=1024", "-x", "bufsize=4m",
+			"-x", "switchrate=10hz", "-s", tmpfile.Name())
+
+		stdout, err := command.StdoutPipe()
+		if err != nil {
+			scope.Log("dtrace: %s", err)
+			return
+		}
+
+		stderr, err := command.StderrPipe()
+		if err != nil {
+			scope.Log("dtrace: %s", err)
+			return
+		}
+
+		err = command.Start()
+		if err != nil {
+			scope.Log("dtrace: %s", err)
+			return
+		}
+
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+
+		// Report errors and dropped events.
+		go func() {
+			defer wg.Done()
+
+			scanner := bufio.NewScanner(stderr)
+			for scanner.Scan() {
+				scope.Log("dtrace: %s", scanner.Text())
+			}
+		}()
+
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			event := parseEvent(scanner.Text())
+			if event == nil {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				cancel()
+			case output_chan <- event:
+			}
+		}
+
+		wg.Wait()
+		err = command.Wait()
+		if err != nil && ctx.Err() == nil {
+			scope.Log("dtrace: %s - is the dtraceall kernel module loaded?", err)
+		}
+	}()
+
+	return output_chan
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&DTracePlugin{})
+}
@@ -0,0 +1,152 @@
+package freebsd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+// Each probe prints one tab separated line starting with the probe
+// name and the process which triggered it. Fields which may contain
+// tabs (command lines and paths) are printed last.
+const (
+	probe_header = `"%s\t%d\t%d\t%d\t%d\t%s`
+	probe_args   = `walltimestamp, pid, ppid, uid, execname`
+)
+
+var (
+	probes = map[string]string{
+		"exec": `
+proc:::exec-success
+/PREDICATE/
+{
+	printf(` + probe_header + `\t%s\n", "exec", ` + probe_args + `,
+		curpsinfo->pr_psargs);
+}
+`,
+		"open": `
+syscall::open:entry
+/PREDICATE/
+{
+	self->path = arg0;
+	self->flags = arg1;
+}
+
+syscall::openat:entry
+/PREDICATE/
+{
+	self->path = arg1;
+	self->flags = arg2;
+}
+
+syscall::open:return, syscall::openat:return
+/self->path/
+{
+	printf(` + probe_header + `\t%d\t%d\t%s\n", "open", ` + probe_args + `,
+		self->flags, (int)arg0 < 0 ? errno : 0, copyinstr(self->path));
+	self->path = 0;
+	self->flags = 0;
+}
+`,
+		"connect": `
+tcp:::connect-request
+/PREDICATE/
+{
+	printf(` + probe_header + `\t%s\t%d\t%s\t%d\n", "connect", ` + probe_args + `,
+		args[2]->ip_saddr, args[4]->tcp_sport,
+		args[2]->ip_daddr, args[4]->tcp_dport);
+}
+`,
+	}
+
+	// The number of fields each probe prints after the header.
+	probe_fields = map[string]int{
+		"exec":    1,
+		"open":    3,
+		"connect": 4,
+	}
+)
+
+// Build the D script enabling the probes. Events caused by dtrace
+// itself and by the pids in exclude are ignored - otherwise opening
+// files to store the events would trigger more events.
+func buildScript(names []string, exclude ...int) (string, error) {
+	predicate := []string{"pid != $pid"}
+	for _, pid := range exclude {
+		predicate = append(predicate, fmt.Sprintf("pid != %d", pid))
+	}
+
+	result := []string{"#pragma D option quiet"}
+	for _, name := range names {
+		probe, pres := probes[name]
+		if !pres {
+			return "", fmt.Errorf("Unknown probe %v", name)
+		}
+		result = append(result, strings.ReplaceAll(
+			probe, "PREDICATE", strings.Join(predicate, " && ")))
+	}
+
+	return strings.Join(result, "\n"), nil
+}
+
+// Parse a line printed by the script into an event. Returns nil for
+// lines which were not printed by a probe.
+func parseEvent(line string) *ordereddict.Dict {
+	fields := strings.SplitN(line, "\t", 6)
+	if len(fields) < 6 {
+		return nil
+	}
+
+	count, pres := probe_fields[fields[0]]
+	if !pres {
+		return nil
+	}
+
+	var header []int64
+	for _, field := range fields[1:5] {
+		value, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil
+		}
+		header = append(header, value)
+	}
+
+	// The process name is followed by the probe's own fields.
+	rest := strings.SplitN(fields[5], "\t", count+1)
+	if len(rest) != count+1 {
+		return nil
+	}
+
+	result := ordereddict.NewDict().
+		Set("Probe", fields[0]).
+		Set("Time", time.Unix(0, header[0]).UTC()).
+		Set("Pid", header[1]).
+		Set("Ppid", header[2]).
+		Set("Uid", header[3]).
+		Set("Name", rest[0])
+
+	switch fields[0] {
+	case "exec":
+		result.Set("CommandLine", rest[1])
+
+	case "open":
+		flags, _ := strconv.ParseInt(rest[1], 10, 64)
+		errno, _ := strconv.ParseInt(rest[2], 10, 64)
+		result.Set("Flags", flags).
+			Set("Errno", errno).
+			Set("Path", rest[3])
+
+	case "connect":
+		sport, _ := strconv.ParseInt(rest[2], 10, 64)
+		dport, _ := strconv.ParseInt(rest[4], 10, 64)
+		result.Set("SourceAddr", rest[1]).
+			Set("SourcePort", sport).
+			Set("DestAddr", rest[3]).
+			Set("DestPort", dport)
+	}
+
+	return result
+}
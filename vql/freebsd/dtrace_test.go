@@ -0,0 +1,59 @@
+package freebsd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestBuildScript(t *testing.T) {
+	script, err := buildScript([]string{"exec", "connect"}, 1234)
+	assert.NoError(t, err)
+
+	assert.Contains(t, script, "proc:::exec-success")
+	assert.Contains(t, script, "tcp:::connect-request")
+	assert.Contains(t, script, "/pid != $pid && pid != 1234/")
+	assert.False(t, strings.Contains(script, "syscall::open"))
+
+	_, err = buildScript([]string{"exec", "fork"})
+	assert.Error(t, err)
+}
+
+func TestParseEvent(t *testing.T) {
+	event := parseEvent("exec\t1600000000000000000\t10\t1\t0\tsh\t/bin/sh -c echo\thello")
+	assert.NotNil(t, event)
+
+	probe, _ := event.Get("Probe")
+	assert.Equal(t, "exec", probe)
+
+	timestamp, _ := event.Get("Time")
+	assert.Equal(t, time.Unix(1600000000, 0).UTC(), timestamp)
+
+	pid, _ := event.Get("Pid")
+	assert.Equal(t, int64(10), pid)
+
+	// Command lines may contain tabs.
+	command_line, _ := event.Get("CommandLine")
+	assert.Equal(t, "/bin/sh -c echo\thello", command_line)
+
+	event = parseEvent("open\t1600000000000000000\t10\t1\t0\tcat\t0\t2\t/etc/missing")
+	assert.NotNil(t, event)
+
+	errno, _ := event.Get("Errno")
+	assert.Equal(t, int64(2), errno)
+
+	path, _ := event.Get("Path")
+	assert.Equal(t, "/etc/missing", path)
+
+	event = parseEvent("connect\t1600000000000000000\t10\t1\t0\tfetch\t10.0.0.1\t40000\t10.0.0.2\t443")
+	assert.NotNil(t, event)
+
+	port, _ := event.Get("DestPort")
+	assert.Equal(t, int64(443), port)
+
+	// Other output is ignored.
+	assert.Nil(t, parseEvent("dtrace: 10 drops on CPU 0"))
+	assert.Nil(t, parseEvent("connect\t1\t2\t3\t4\tfetch\t10.0.0.1"))
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/shirou/gopsutil/v3/host"
 
 	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/utils"
 	"www.velocidex.com/golang/vfilter"
 	"www.velocidex.com/golang/vfilter/arg_parser"
 )
@@ -89,7 +90,10 @@ func init() {
 
 				item := GetInfo(info).
 					Set("Fqdn", fqdn.Get()).
-					Set("Architecture", runtime.GOARCH)
+					Set("Architecture", runtime.GOARCH).
+					Set("Transport", utils.GetCommsTransport()).
+					Set("Frontend", utils.GetCommsFrontend()).
+					Set("Quarantined", utils.IsQuarantined())
 				result = append(result, item)
 
 				return result
@@ -0,0 +1,86 @@
+// +build linux
+
+package linux
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors/container"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/artifacts"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+)
+
+func getContainerOptions(scope vfilter.Scope) container.Options {
+	opts := container.Options{}
+	config_obj, ok := artifacts.GetConfig(scope)
+	if ok {
+		opts.PreventExecve = config_obj.PreventExecve
+	}
+	return opts
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(
+		&vfilter.GenericListPlugin{
+			PluginName: "containers",
+			Function: func(
+				ctx context.Context,
+				scope vfilter.Scope,
+				args *ordereddict.Dict) []vfilter.Row {
+				var result []vfilter.Row
+
+				err := vql_subsystem.CheckAccess(scope, acls.MACHINE_STATE)
+				if err != nil {
+					scope.Log("containers: %s", err)
+					return result
+				}
+
+				containers, err := container.ListContainers(
+					ctx, getContainerOptions(scope))
+				if err != nil {
+					scope.Log("containers: %s", err)
+					return result
+				}
+
+				for _, item := range containers {
+					result = append(result, item)
+				}
+				return result
+			},
+			Doc: "List running containers (docker, containerd and CRI-O). " +
+				"Files inside a container can be read with the " +
+				"'container' accessor.",
+		})
+
+	vql_subsystem.RegisterPlugin(
+		&vfilter.GenericListPlugin{
+			PluginName: "k8s_pods",
+			Function: func(
+				ctx context.Context,
+				scope vfilter.Scope,
+				args *ordereddict.Dict) []vfilter.Row {
+				var result []vfilter.Row
+
+				err := vql_subsystem.CheckAccess(scope, acls.MACHINE_STATE)
+				if err != nil {
+					scope.Log("k8s_pods: %s", err)
+					return result
+				}
+
+				pods, err := container.ListPods(ctx, getContainerOptions(scope))
+				if err != nil {
+					scope.Log("k8s_pods: %s", err)
+					return result
+				}
+
+				for _, item := range pods {
+					result = append(result, item)
+				}
+				return result
+			},
+			Doc: "List Kubernetes pods running on this node through the CRI runtime.",
+		})
+}
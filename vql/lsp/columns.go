@@ -0,0 +1,102 @@
+package lsp
+
+import (
+	"sync"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+	"www.velocidex.com/golang/vfilter"
+)
+
+// Parsing all the artifact queries is slow so the columns are
+// cached until the artifact changes.
+type columnCacheEntry struct {
+	raw     string
+	columns []*api_proto.ArgDescriptor
+}
+
+var (
+	column_mu    sync.Mutex
+	column_cache = make(map[string]*columnCacheEntry)
+)
+
+// The columns produced by the artifact's sources. Columns are named
+// by the last SELECT of each source. Sources selecting * can only be
+// described by the artifact's column_types.
+func artifactColumns(
+	artifact *artifacts_proto.Artifact) []*api_proto.ArgDescriptor {
+	column_mu.Lock()
+	entry, pres := column_cache[artifact.Name]
+	column_mu.Unlock()
+
+	if pres && entry.raw == artifact.Raw {
+		return entry.columns
+	}
+
+	columns := getArtifactColumns(artifact)
+
+	column_mu.Lock()
+	column_cache[artifact.Name] = &columnCacheEntry{
+		raw:     artifact.Raw,
+		columns: columns,
+	}
+	column_mu.Unlock()
+
+	return columns
+}
+
+func getArtifactColumns(
+	artifact *artifacts_proto.Artifact) []*api_proto.ArgDescriptor {
+	result := []*api_proto.ArgDescriptor{}
+	seen := make(map[string]*api_proto.ArgDescriptor)
+
+	add := func(name string) *api_proto.ArgDescriptor {
+		column, pres := seen[name]
+		if !pres {
+			column = &api_proto.ArgDescriptor{Name: name}
+			seen[name] = column
+			result = append(result, column)
+		}
+		return column
+	}
+
+	scope := vfilter.NewScope()
+	defer scope.Close()
+
+	for _, source := range artifact.Sources {
+		for _, name := range queryColumns(scope, source.Query) {
+			add(name)
+		}
+	}
+
+	for _, column_type := range artifact.ColumnTypes {
+		column := add(column_type.Name)
+		column.Type = column_type.Type
+		column.Description = column_type.Description
+	}
+
+	return result
+}
+
+func queryColumns(scope vfilter.Scope, query string) []string {
+	statements, err := vfilter.MultiParse(query)
+	if err != nil {
+		return nil
+	}
+
+	// The last statement produces the rows.
+	for i := len(statements) - 1; i >= 0; i-- {
+		vql := statements[i]
+		if vql.Query == nil {
+			continue
+		}
+
+		result := []string{}
+		for _, expression := range vql.Query.SelectExpression.Expressions {
+			result = append(result, expression.GetName(scope))
+		}
+		return result
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+)
+
+func TestArtifactColumns(t *testing.T) {
+	artifact := &artifacts_proto.Artifact{
+		Name: "Custom.Test",
+		Raw:  "1",
+		Sources: []*artifacts_proto.ArtifactSource{{
+			Query: `
+LET X = SELECT * FROM info()
+SELECT Fqdn, OS AS System, count() AS Count FROM X`,
+		}, {
+			Query: "SELECT * FROM glob(globs='/*')",
+		}, {
+			Query: "SELECT Fqdn, Size FROM stat(filename='/')",
+		}},
+		ColumnTypes: []*artifacts_proto.ColumnType{
+			{Name: "Count", Type: "int"},
+			{Name: "Mtime", Type: "timestamp", Description: "From glob"},
+		},
+	}
+
+	expected := []*api_proto.ArgDescriptor{
+		{Name: "Fqdn"},
+		{Name: "System"},
+		{Name: "Count", Type: "int"},
+		{Name: "Size"},
+		{Name: "Mtime", Type: "timestamp", Description: "From glob"},
+	}
+	assert.Equal(t, expected, artifactColumns(artifact))
+
+	// Columns are recalculated when the artifact changes.
+	artifact.Raw = "2"
+	artifact.Sources = artifact.Sources[1:2]
+	assert.Equal(t, []*api_proto.ArgDescriptor{
+		{Name: "Count", Type: "int"},
+		{Name: "Mtime", Type: "timestamp", Description: "From glob"},
+	}, artifactColumns(artifact))
+}
@@ -0,0 +1,341 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/services"
+)
+
+var (
+	artifact_regex = regexp.MustCompile(`Artifact(\.[a-zA-Z0-9_]+)+`)
+)
+
+var keywords = []string{
+	"SELECT", "FROM", "LET", "WHERE", "LIMIT", "GROUP BY", "ORDER BY",
+}
+
+type completer struct {
+	items  []*api_proto.Completion
+	lookup map[string]*api_proto.Completion
+}
+
+func newCompleter(items []*api_proto.Completion) *completer {
+	result := &completer{
+		lookup: make(map[string]*api_proto.Completion),
+	}
+
+	for _, keyword := range keywords {
+		result.items = append(result.items, &api_proto.Completion{
+			Name: keyword,
+			Type: "Keyword",
+		})
+	}
+
+	for _, item := range items {
+		result.items = append(result.items, item)
+		result.lookup[item.Name] = item
+	}
+
+	return result
+}
+
+func (self *completer) Complete(text string, pos position) *completionList {
+	result := &completionList{Items: []completionItem{}}
+
+	line := getLine(text, pos.Line)
+	prefix := line[:clampColumn(line, pos.Character)]
+
+	// The word being typed may include dots (Artifact.Windows.)
+	start := len(prefix)
+	for start > 0 && isIdentChar(prefix[start-1]) {
+		start--
+	}
+	word := prefix[start:]
+
+	edit_range := textRange{
+		Start: position{Line: pos.Line, Character: runeColumn(line, start)},
+		End:   pos,
+	}
+
+	// Inside a call the arguments are completed first.
+	if !strings.Contains(word, ".") {
+		call, pres := self.lookup[enclosingCall(text, pos)]
+		if pres {
+			for _, arg := range call.Args {
+				if !hasPrefix(arg.Name, word) {
+					continue
+				}
+
+				result.Items = append(result.Items, completionItem{
+					Label:  arg.Name,
+					Kind:   kindField,
+					Detail: arg.Type,
+					Documentation: &markupContent{
+						Kind: markdown, Value: arg.Description},
+					SortText: "0" + arg.Name,
+					TextEdit: &textEdit{
+						Range: edit_range, NewText: arg.Name + "="},
+				})
+			}
+		}
+	}
+
+	// Columns of the artifacts referenced in the query.
+	if !strings.Contains(word, ".") {
+		for _, column := range self.referencedColumns(text) {
+			if !hasPrefix(column.Name, word) {
+				continue
+			}
+
+			result.Items = append(result.Items, completionItem{
+				Label:  column.Name,
+				Kind:   kindField,
+				Detail: column.Type,
+				Documentation: &markupContent{
+					Kind: markdown, Value: column.Description},
+				SortText: "2" + column.Name,
+				TextEdit: &textEdit{Range: edit_range, NewText: column.Name},
+			})
+		}
+	}
+
+	for _, item := range self.items {
+		if !hasPrefix(item.Name, word) {
+			continue
+		}
+
+		result.Items = append(result.Items, completionItem{
+			Label:         item.Name,
+			Kind:          completionKind(item.Type),
+			Detail:        item.Type,
+			Documentation: &markupContent{Kind: markdown, Value: item.Description},
+			SortText:      "1" + item.Name,
+			TextEdit:      &textEdit{Range: edit_range, NewText: item.Name},
+		})
+	}
+
+	return result
+}
+
+func (self *completer) referencedColumns(text string) []*api_proto.ArgDescriptor {
+	result := []*api_proto.ArgDescriptor{}
+	seen := make(map[string]bool)
+
+	for _, name := range artifact_regex.FindAllString(text, -1) {
+		item, pres := self.lookup[name]
+		if !pres {
+			continue
+		}
+
+		for _, column := range item.Columns {
+			if !seen[column.Name] {
+				seen[column.Name] = true
+				result = append(result, column)
+			}
+		}
+	}
+
+	return result
+}
+
+func (self *completer) Hover(text string, pos position) *hover {
+	line := getLine(text, pos.Line)
+	column := clampColumn(line, pos.Character)
+
+	start := column
+	for start > 0 && isIdentChar(line[start-1]) {
+		start--
+	}
+
+	end := column
+	for end < len(line) && isIdentChar(line[end]) {
+		end++
+	}
+
+	// Artifact references may name a source
+	// (Artifact.Windows.Sys.Users/Source) so try the shorter
+	// names as well.
+	word := strings.Trim(line[start:end], ".")
+	for word != "" {
+		item, pres := self.lookup[word]
+		if pres {
+			return &hover{
+				Contents: markupContent{
+					Kind:  markdown,
+					Value: formatDocumentation(item),
+				},
+				Range: &textRange{
+					Start: position{Line: pos.Line, Character: runeColumn(line, start)},
+					End:   position{Line: pos.Line, Character: runeColumn(line, end)},
+				},
+			}
+		}
+
+		idx := strings.LastIndex(word, ".")
+		if idx < 0 {
+			break
+		}
+		word = word[:idx]
+	}
+
+	return nil
+}
+
+func formatDocumentation(item *api_proto.Completion) string {
+	result := fmt.Sprintf("**%s** (%s)\n\n%s\n", item.Name, item.Type,
+		strings.TrimSpace(item.Description))
+
+	if len(item.Args) > 0 {
+		result += "\nArg | Description | Type\n----|-------------|-----\n"
+		for _, arg := range item.Args {
+			required := ""
+			if arg.Required {
+				required = " (required)"
+			}
+			result += fmt.Sprintf("%s%s | %s | %s\n", arg.Name, required,
+				strings.ReplaceAll(arg.Description, "\n", " "), arg.Type)
+		}
+	}
+
+	if len(item.Columns) > 0 {
+		result += "\nColumn | Description | Type\n-------|-------------|-----\n"
+		for _, column := range item.Columns {
+			result += fmt.Sprintf("%s | %s | %s\n", column.Name,
+				strings.ReplaceAll(column.Description, "\n", " "), column.Type)
+		}
+	}
+
+	return result
+}
+
+func completionKind(item_type string) int {
+	switch item_type {
+	case "Function":
+		return kindFunction
+	case "Plugin":
+		return kindModule
+	case "Artifact":
+		return kindClass
+	}
+	return kindKeyword
+}
+
+// Find the name of the plugin or function whose argument list
+// contains the position.
+func enclosingCall(text string, pos position) string {
+	lines := strings.Split(text, "\n")
+	if pos.Line >= len(lines) {
+		return ""
+	}
+
+	// Walk back from the position to the unmatched open
+	// parenthesis.
+	depth := 0
+	for i := pos.Line; i >= 0; i-- {
+		line := lines[i]
+		end := len(line)
+		if i == pos.Line {
+			end = clampColumn(line, pos.Character)
+		}
+
+		for j := end - 1; j >= 0; j-- {
+			switch line[j] {
+			case ')':
+				depth++
+			case '(':
+				if depth > 0 {
+					depth--
+					continue
+				}
+
+				start := j
+				for start > 0 && isIdentChar(line[start-1]) {
+					start--
+				}
+				return line[start:j]
+			}
+		}
+	}
+
+	return ""
+}
+
+// Describe the artifacts in the repository so they can be
+// completed.
+func ArtifactCompletions(ctx context.Context,
+	config_obj *config_proto.Config,
+	repository services.Repository) ([]*api_proto.Completion, error) {
+	names, err := repository.List(ctx, config_obj)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	result := []*api_proto.Completion{}
+	for _, name := range names {
+		artifact, pres := repository.Get(config_obj, name)
+		if !pres {
+			continue
+		}
+
+		item := &api_proto.Completion{
+			Name:        "Artifact." + name,
+			Type:        "Artifact",
+			Description: artifact.Description,
+			Columns:     artifactColumns(artifact),
+		}
+
+		for _, parameter := range artifact.Parameters {
+			item.Args = append(item.Args, &api_proto.ArgDescriptor{
+				Name:        parameter.Name,
+				Description: parameter.Description,
+				Type:        "Artifact Parameter",
+			})
+		}
+
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+func hasPrefix(name, prefix string) bool {
+	return strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix))
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '.' ||
+		c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func getLine(text string, line int) string {
+	lines := strings.Split(text, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	return strings.TrimRight(lines[line], "\r")
+}
+
+// LSP columns count UTF-16 code units. We count runes which is the
+// same for most text. Returns the byte offset of the column.
+func clampColumn(line string, character int) int {
+	count := 0
+	for idx := range line {
+		if count == character {
+			return idx
+		}
+		count++
+	}
+	return len(line)
+}
+
+// Convert a byte offset back into a column.
+func runeColumn(line string, offset int) int {
+	return len([]rune(line[:offset]))
+}
@@ -0,0 +1,141 @@
+package lsp
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Velocidex/yaml/v2"
+	"github.com/alecthomas/participle"
+	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/vfilter"
+)
+
+var (
+	yamlLineRegex = regexp.MustCompile(`line (\d+):`)
+)
+
+func newDiagnostic(line, column int, message string) diagnostic {
+	if line < 0 {
+		line = 0
+	}
+	if column < 0 {
+		column = 0
+	}
+
+	return diagnostic{
+		Range: textRange{
+			Start: position{Line: line, Character: column},
+			End:   position{Line: line, Character: column + 1},
+		},
+		Severity: severityError,
+		Source:   "velociraptor",
+		Message:  message,
+	}
+}
+
+// Parse the VQL and report syntax errors. The query starts at the
+// given line and column of the document.
+func vqlDiagnostics(query string, line, column int) []diagnostic {
+	result := []diagnostic{}
+
+	_, err := vfilter.MultiParse(query)
+	if err == nil {
+		return result
+	}
+
+	// Participle positions are 1 based while LSP positions are 0
+	// based.
+	var parse_error participle.Error
+	if errors.As(err, &parse_error) {
+		// Block scalars are indented by the same amount on every
+		// line.
+		pos := parse_error.Token().Pos
+		return append(result, newDiagnostic(
+			line+pos.Line-1, column+pos.Column-1, parse_error.Message()))
+	}
+
+	return append(result, newDiagnostic(line, column, err.Error()))
+}
+
+// Check an artifact definition: the YAML must parse, the queries
+// must be valid VQL and the artifact must pass validation.
+func artifactDiagnostics(
+	text string, repository services.Repository) []diagnostic {
+	result := []diagnostic{}
+
+	artifact := &artifacts_proto.Artifact{}
+	err := yaml.UnmarshalStrict([]byte(text), artifact)
+	if err != nil {
+		line := 0
+		match := yamlLineRegex.FindStringSubmatch(err.Error())
+		if match != nil {
+			line, _ = strconv.Atoi(match[1])
+			line--
+		}
+		return append(result, newDiagnostic(line, 0, err.Error()))
+	}
+
+	locator := &queryLocator{lines: strings.Split(text, "\n")}
+	queries := []string{artifact.Precondition, artifact.Export}
+	for _, source := range artifact.Sources {
+		queries = append(queries, source.Precondition, source.Query)
+		queries = append(queries, source.Queries...)
+	}
+
+	for _, query := range queries {
+		if strings.TrimSpace(query) == "" {
+			continue
+		}
+
+		line, column := locator.Find(query)
+		result = append(result, vqlDiagnostics(query, line, column)...)
+	}
+
+	if len(result) > 0 || repository == nil {
+		return result
+	}
+
+	// Validate the artifact against the repository (e.g. its
+	// dependencies must exist) without loading it.
+	_, err = repository.Copy().LoadYaml(text,
+		services.ValidateArtifact, !services.ArtifactIsBuiltIn)
+	if err != nil {
+		result = append(result, newDiagnostic(0, 0, err.Error()))
+	}
+
+	return result
+}
+
+// Finds where a query appears in the YAML document. Queries are
+// searched in document order so repeated queries are located
+// correctly.
+type queryLocator struct {
+	lines []string
+	next  int
+}
+
+func (self *queryLocator) Find(query string) (line, column int) {
+	// Block scalars strip the indentation, so match on the first
+	// non empty line of the query.
+	query_lines := strings.Split(query, "\n")
+	first := 0
+	for first < len(query_lines) && strings.TrimSpace(query_lines[first]) == "" {
+		first++
+	}
+	if first == len(query_lines) {
+		return 0, 0
+	}
+	needle := strings.TrimSpace(query_lines[first])
+
+	for i := self.next; i < len(self.lines); i++ {
+		idx := strings.Index(self.lines[i], needle)
+		if idx >= 0 {
+			self.next = i + 1
+			return i - first, idx
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,216 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"sync"
+)
+
+// The subset of the Language Server Protocol we implement. See
+// https://microsoft.github.io/language-server-protocol/specification
+
+const (
+	// Error codes
+	invalidRequest = -32600
+	methodNotFound = -32601
+	invalidParams  = -32602
+
+	// TextDocumentSyncKind: the client always sends the full
+	// document.
+	syncFull = 1
+
+	// DiagnosticSeverity
+	severityError = 1
+
+	// CompletionItemKind
+	kindFunction = 3
+	kindField    = 5
+	kindClass    = 7
+	kindModule   = 9
+	kindKeyword  = 14
+
+	// MarkupKind
+	markdown = "markdown"
+)
+
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type textRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+type textDocumentItem struct {
+	URI        string `json:"uri"`
+	LanguageID string `json:"languageId"`
+	Version    int    `json:"version"`
+	Text       string `json:"text"`
+}
+
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type didOpenParams struct {
+	TextDocument textDocumentItem `json:"textDocument"`
+}
+
+type contentChange struct {
+	Text string `json:"text"`
+}
+
+type didChangeParams struct {
+	TextDocument   textDocumentIdentifier `json:"textDocument"`
+	ContentChanges []contentChange        `json:"contentChanges"`
+}
+
+type didCloseParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+}
+
+type textDocumentPositionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Position     position               `json:"position"`
+}
+
+type diagnostic struct {
+	Range    textRange `json:"range"`
+	Severity int       `json:"severity"`
+	Source   string    `json:"source"`
+	Message  string    `json:"message"`
+}
+
+type publishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+}
+
+type markupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type hover struct {
+	Contents markupContent `json:"contents"`
+	Range    *textRange    `json:"range,omitempty"`
+}
+
+type textEdit struct {
+	Range   textRange `json:"range"`
+	NewText string    `json:"newText"`
+}
+
+type completionItem struct {
+	Label         string         `json:"label"`
+	Kind          int            `json:"kind"`
+	Detail        string         `json:"detail,omitempty"`
+	Documentation *markupContent `json:"documentation,omitempty"`
+	SortText      string         `json:"sortText,omitempty"`
+	TextEdit      *textEdit      `json:"textEdit,omitempty"`
+}
+
+type completionList struct {
+	IsIncomplete bool             `json:"isIncomplete"`
+	Items        []completionItem `json:"items"`
+}
+
+// Messages are framed with a Content-Length header.
+type conn struct {
+	reader *bufio.Reader
+
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+func newConn(reader io.Reader, writer io.Writer) *conn {
+	return &conn{
+		reader: bufio.NewReader(reader),
+		writer: writer,
+	}
+}
+
+func (self *conn) Read() (*message, error) {
+	body, err := self.ReadBody()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &message{}
+	err = json.Unmarshal(body, result)
+	return result, err
+}
+
+func (self *conn) ReadBody() ([]byte, error) {
+	headers, err := textproto.NewReader(self.reader).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := strconv.Atoi(headers.Get("Content-Length"))
+	if err != nil || length <= 0 {
+		return nil, errors.New("lsp: Invalid Content-Length header")
+	}
+
+	body := make([]byte, length)
+	_, err = io.ReadFull(self.reader, body)
+	return body, err
+}
+
+func (self *conn) write(msg interface{}) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	_, err = fmt.Fprintf(self.writer, "Content-Length: %d\r\n\r\n%s",
+		len(body), body)
+	return err
+}
+
+// Replies must carry a result member even when it is null.
+func (self *conn) Reply(id *json.RawMessage, result interface{}) error {
+	return self.write(&struct {
+		JSONRPC string           `json:"jsonrpc"`
+		ID      *json.RawMessage `json:"id"`
+		Result  interface{}      `json:"result"`
+	}{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (self *conn) ReplyError(id *json.RawMessage, code int, msg string) error {
+	return self.write(&struct {
+		JSONRPC string           `json:"jsonrpc"`
+		ID      *json.RawMessage `json:"id"`
+		Error   *responseError   `json:"error"`
+	}{JSONRPC: "2.0", ID: id, Error: &responseError{Code: code, Message: msg}})
+}
+
+func (self *conn) Notify(method string, params interface{}) error {
+	return self.write(&struct {
+		JSONRPC string      `json:"jsonrpc"`
+		Method  string      `json:"method"`
+		Params  interface{} `json:"params"`
+	}{JSONRPC: "2.0", Method: method, Params: params})
+}
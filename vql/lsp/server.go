@@ -0,0 +1,262 @@
+/*
+  A Language Server Protocol server for VQL.
+
+  Editors start the server (velociraptor lsp) and talk to it over
+  stdin/stdout. The server provides:
+
+  - Completions for keywords, plugins, functions, their arguments
+    and artifacts.
+
+  - Hover documentation from the plugin, function and artifact
+    descriptions.
+
+  - Diagnostics for VQL files (syntax errors) and artifact YAML files
+    (YAML errors, syntax errors in the queries and artifact
+    validation errors).
+*/
+
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/config"
+	"www.velocidex.com/golang/velociraptor/services"
+)
+
+type document struct {
+	uri         string
+	language_id string
+	text        string
+}
+
+// Artifact definitions are YAML, anything else is VQL.
+func (self *document) IsArtifact() bool {
+	switch self.language_id {
+	case "yaml":
+		return true
+	case "vql":
+		return false
+	}
+	return strings.HasSuffix(self.uri, ".yaml") ||
+		strings.HasSuffix(self.uri, ".yml")
+}
+
+type Server struct {
+	mu        sync.Mutex
+	documents map[string]*document
+
+	completions *completer
+
+	// Used to validate artifacts - may be nil.
+	repository services.Repository
+
+	shutdown bool
+}
+
+func NewServer(
+	completions []*api_proto.Completion,
+	repository services.Repository) *Server {
+	return &Server{
+		documents:   make(map[string]*document),
+		completions: newCompleter(completions),
+		repository:  repository,
+	}
+}
+
+// Serve requests until the client exits or the connection is
+// closed.
+func (self *Server) Serve(ctx context.Context,
+	reader io.Reader, writer io.Writer) error {
+	conn := newConn(reader, writer)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		msg, err := conn.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		err = self.handle(conn, msg)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (self *Server) handle(conn *conn, msg *message) error {
+	self.mu.Lock()
+	shutdown := self.shutdown
+	self.mu.Unlock()
+
+	// After a shutdown request only exit is allowed.
+	if shutdown {
+		if msg.ID != nil {
+			return conn.ReplyError(msg.ID, invalidRequest, "Server is shut down")
+		}
+		return nil
+	}
+
+	switch msg.Method {
+	case "initialize":
+		return conn.Reply(msg.ID, map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync": syncFull,
+				"hoverProvider":    true,
+				"completionProvider": map[string]interface{}{
+					"triggerCharacters": []string{".", "("},
+				},
+			},
+			"serverInfo": map[string]interface{}{
+				"name":    "velociraptor",
+				"version": config.GetVersion().Version,
+			},
+		})
+
+	case "shutdown":
+		self.mu.Lock()
+		self.shutdown = true
+		self.mu.Unlock()
+		return conn.Reply(msg.ID, nil)
+
+	case "textDocument/didOpen":
+		params := &didOpenParams{}
+		if json.Unmarshal(msg.Params, params) != nil {
+			return nil
+		}
+
+		doc := &document{
+			uri:         params.TextDocument.URI,
+			language_id: params.TextDocument.LanguageID,
+			text:        params.TextDocument.Text,
+		}
+		self.setDocument(doc)
+		return self.publishDiagnostics(conn, doc)
+
+	case "textDocument/didChange":
+		params := &didChangeParams{}
+		if json.Unmarshal(msg.Params, params) != nil ||
+			len(params.ContentChanges) == 0 {
+			return nil
+		}
+
+		doc, pres := self.getDocument(params.TextDocument.URI)
+		if !pres {
+			return nil
+		}
+
+		// With full sync the last change is the whole document.
+		doc = &document{
+			uri:         doc.uri,
+			language_id: doc.language_id,
+			text:        params.ContentChanges[len(params.ContentChanges)-1].Text,
+		}
+		self.setDocument(doc)
+		return self.publishDiagnostics(conn, doc)
+
+	case "textDocument/didClose":
+		params := &didCloseParams{}
+		if json.Unmarshal(msg.Params, params) != nil {
+			return nil
+		}
+
+		self.mu.Lock()
+		delete(self.documents, params.TextDocument.URI)
+		self.mu.Unlock()
+
+		// Clear the diagnostics of the closed document.
+		return conn.Notify("textDocument/publishDiagnostics",
+			&publishDiagnosticsParams{
+				URI:         params.TextDocument.URI,
+				Diagnostics: []diagnostic{},
+			})
+
+	case "textDocument/completion":
+		params := &textDocumentPositionParams{}
+		err := json.Unmarshal(msg.Params, params)
+		if err != nil {
+			return conn.ReplyError(msg.ID, invalidParams, err.Error())
+		}
+
+		doc, pres := self.getDocument(params.TextDocument.URI)
+		if !pres {
+			return conn.Reply(msg.ID, nil)
+		}
+		return conn.Reply(msg.ID, self.completions.Complete(
+			doc.text, params.Position))
+
+	case "textDocument/hover":
+		params := &textDocumentPositionParams{}
+		err := json.Unmarshal(msg.Params, params)
+		if err != nil {
+			return conn.ReplyError(msg.ID, invalidParams, err.Error())
+		}
+
+		doc, pres := self.getDocument(params.TextDocument.URI)
+		if !pres {
+			return conn.Reply(msg.ID, nil)
+		}
+
+		result := self.completions.Hover(doc.text, params.Position)
+		if result == nil {
+			return conn.Reply(msg.ID, nil)
+		}
+		return conn.Reply(msg.ID, result)
+	}
+
+	// Notifications we do not handle are ignored but requests
+	// must be answered.
+	if msg.ID != nil {
+		return conn.ReplyError(msg.ID, methodNotFound,
+			"Method not supported: "+msg.Method)
+	}
+	return nil
+}
+
+func (self *Server) getDocument(uri string) (*document, bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	doc, pres := self.documents[uri]
+	return doc, pres
+}
+
+func (self *Server) setDocument(doc *document) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.documents[doc.uri] = doc
+}
+
+func (self *Server) publishDiagnostics(conn *conn, doc *document) error {
+	var diagnostics []diagnostic
+	if doc.IsArtifact() {
+		diagnostics = artifactDiagnostics(doc.text, self.repository)
+	} else {
+		diagnostics = vqlDiagnostics(doc.text, 0, 0)
+	}
+
+	return conn.Notify("textDocument/publishDiagnostics",
+		&publishDiagnosticsParams{
+			URI:         doc.uri,
+			Diagnostics: diagnostics,
+		})
+}
@@ -0,0 +1,201 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+)
+
+var testCompletions = []*api_proto.Completion{
+	{
+		Name:        "glob",
+		Type:        "Plugin",
+		Description: "Retrieve files based on a list of glob expressions",
+		Args: []*api_proto.ArgDescriptor{
+			{Name: "globs", Type: "string", Required: true},
+			{Name: "root", Type: "OSPath"},
+		},
+	},
+	{
+		Name:        "format",
+		Type:        "Function",
+		Description: "Format one or more items according to a format string.",
+	},
+	{
+		Name:        "Artifact.Windows.Sys.Users",
+		Type:        "Artifact",
+		Description: "List User accounts.",
+		Columns: []*api_proto.ArgDescriptor{
+			{Name: "Name", Type: "string"},
+			{Name: "Uid", Type: "int", Description: "The user id"},
+		},
+	},
+}
+
+type testClient struct {
+	t      *testing.T
+	conn   *conn
+	writer io.Closer
+	id     int
+}
+
+func newTestClient(t *testing.T) *testClient {
+	server_reader, client_writer := io.Pipe()
+	client_reader, server_writer := io.Pipe()
+
+	go func() {
+		err := NewServer(testCompletions, nil).Serve(
+			context.Background(), server_reader, server_writer)
+		assert.NoError(t, err)
+		server_writer.Close()
+	}()
+
+	return &testClient{
+		t:      t,
+		conn:   newConn(client_reader, client_writer),
+		writer: client_writer,
+	}
+}
+
+func (self *testClient) Notify(method string, params interface{}) {
+	assert.NoError(self.t, self.conn.Notify(method, params))
+}
+
+func (self *testClient) Call(method string, params interface{}, result interface{}) {
+	self.id++
+	id := json.RawMessage(strconv.Itoa(self.id))
+	assert.NoError(self.t, self.conn.write(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      &id,
+		"method":  method,
+		"params":  params,
+	}))
+
+	assert.NoError(self.t, json.Unmarshal(self.read().Result, result))
+}
+
+// Read the parameters of the next notification.
+func (self *testClient) Read(params interface{}) {
+	assert.NoError(self.t, json.Unmarshal(self.read().Params, params))
+}
+
+func (self *testClient) read() *testMessage {
+	body, err := self.conn.ReadBody()
+	assert.NoError(self.t, err)
+
+	result := &testMessage{}
+	assert.NoError(self.t, json.Unmarshal(body, result))
+	return result
+}
+
+type testMessage struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+}
+
+func TestLSP(t *testing.T) {
+	client := newTestClient(t)
+	defer client.writer.Close()
+
+	init_result := make(map[string]interface{})
+	client.Call("initialize", map[string]interface{}{}, &init_result)
+	_, pres := init_result["capabilities"]
+	assert.True(t, pres)
+
+	// A syntax error in a VQL file.
+	client.Notify("textDocument/didOpen", &didOpenParams{
+		TextDocument: textDocumentItem{
+			URI:  "file:///test.vql",
+			Text: "SELECT * FROM glob(globs='/*')\nSELECT foo( FROM x",
+		},
+	})
+
+	diagnostics := &publishDiagnosticsParams{}
+	client.Read(diagnostics)
+	assert.Equal(t, 1, len(diagnostics.Diagnostics))
+	assert.Equal(t, position{Line: 1, Character: 10},
+		diagnostics.Diagnostics[0].Range.Start)
+
+	// Fixing the query clears the diagnostics.
+	client.Notify("textDocument/didChange", &didChangeParams{
+		TextDocument: textDocumentIdentifier{URI: "file:///test.vql"},
+		ContentChanges: []contentChange{{
+			Text: "SELECT * FROM glob(g=1)\nSELECT * FROM Artifact.Windows.Sys.Users()",
+		}},
+	})
+	client.Read(diagnostics)
+	assert.Equal(t, 0, len(diagnostics.Diagnostics))
+
+	// Arguments are completed inside a call, before the plugins
+	// and keywords.
+	completions := &completionList{}
+	client.Call("textDocument/completion", &textDocumentPositionParams{
+		TextDocument: textDocumentIdentifier{URI: "file:///test.vql"},
+		Position:     position{Line: 0, Character: 20},
+	}, completions)
+	assert.Equal(t, 3, len(completions.Items))
+	assert.Equal(t, "globs", completions.Items[0].Label)
+	assert.Equal(t, "globs=", completions.Items[0].TextEdit.NewText)
+
+	// Artifacts are completed with their full name.
+	client.Call("textDocument/completion", &textDocumentPositionParams{
+		TextDocument: textDocumentIdentifier{URI: "file:///test.vql"},
+		Position:     position{Line: 1, Character: 26},
+	}, completions)
+	assert.Equal(t, 1, len(completions.Items))
+	assert.Equal(t, "Artifact.Windows.Sys.Users", completions.Items[0].Label)
+	assert.Equal(t, 14, completions.Items[0].TextEdit.Range.Start.Character)
+
+	// Hover shows the documentation.
+	hover_result := &hover{}
+	client.Call("textDocument/hover", &textDocumentPositionParams{
+		TextDocument: textDocumentIdentifier{URI: "file:///test.vql"},
+		Position:     position{Line: 1, Character: 30},
+	}, hover_result)
+	assert.Contains(t, hover_result.Contents.Value, "List User accounts.")
+	assert.Contains(t, hover_result.Contents.Value, "Uid | The user id | int")
+
+	// Columns of the referenced artifacts are completed.
+	client.Notify("textDocument/didChange", &didChangeParams{
+		TextDocument: textDocumentIdentifier{URI: "file:///test.vql"},
+		ContentChanges: []contentChange{{
+			Text: "SELECT * FROM Artifact.Windows.Sys.Users() WHERE U",
+		}},
+	})
+	client.Read(diagnostics)
+
+	client.Call("textDocument/completion", &textDocumentPositionParams{
+		TextDocument: textDocumentIdentifier{URI: "file:///test.vql"},
+		Position:     position{Line: 0, Character: 50},
+	}, completions)
+	assert.Equal(t, 1, len(completions.Items))
+	assert.Equal(t, "Uid", completions.Items[0].Label)
+
+	// Errors in artifact queries are reported at their location
+	// in the YAML.
+	client.Notify("textDocument/didOpen", &didOpenParams{
+		TextDocument: textDocumentItem{
+			URI: "file:///test.yaml",
+			Text: `name: Custom.Test
+sources:
+  - query: |
+      SELECT *
+      FROM glob(globs="/*"))
+`,
+		},
+	})
+	client.Read(diagnostics)
+	assert.Equal(t, 1, len(diagnostics.Diagnostics))
+	assert.Equal(t, position{Line: 4, Character: 27},
+		diagnostics.Diagnostics[0].Range.Start)
+
+	var shutdown interface{}
+	client.Call("shutdown", nil, &shutdown)
+	assert.Nil(t, shutdown)
+}
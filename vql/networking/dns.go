@@ -0,0 +1,242 @@
+package networking
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// A single record from the operating system's DNS cache.
+type DNSCacheEntry struct {
+	Name   string `json:"Name"`
+	Type   string `json:"Type"`
+	TTL    uint32 `json:"TTL"`
+	Data   string `json:"Data"`
+	Source string `json:"Source"`
+}
+
+// A DNS message observed on the wire.
+type DNSEvent struct {
+	Time     time.Time `json:"Time"`
+	SrcIP    string    `json:"SrcIP"`
+	SrcPort  uint16    `json:"SrcPort"`
+	DestIP   string    `json:"DestIP"`
+	DestPort uint16    `json:"DestPort"`
+	ID       uint16    `json:"ID"`
+	Response bool      `json:"Response"`
+	RCode    string    `json:"RCode"`
+	Query    string    `json:"Query"`
+	Type     string    `json:"Type"`
+	Answers  []string  `json:"Answers"`
+}
+
+var (
+	notDNSError = errors.New("Not a DNS packet")
+
+	dnsTypeNames = map[uint16]string{
+		1:   "A",
+		2:   "NS",
+		5:   "CNAME",
+		6:   "SOA",
+		12:  "PTR",
+		13:  "HINFO",
+		15:  "MX",
+		16:  "TXT",
+		28:  "AAAA",
+		33:  "SRV",
+		35:  "NAPTR",
+		39:  "DNAME",
+		41:  "OPT",
+		43:  "DS",
+		46:  "RRSIG",
+		47:  "NSEC",
+		48:  "DNSKEY",
+		64:  "SVCB",
+		65:  "HTTPS",
+		255: "ANY",
+		257: "CAA",
+	}
+)
+
+func dnsTypeName(t uint16) string {
+	name, pres := dnsTypeNames[t]
+	if pres {
+		return name
+	}
+	return fmt.Sprintf("TYPE%d", t)
+}
+
+// Parse a raw ethernet frame into a DNS event. Only UDP messages to
+// or from port 53 (and mDNS on 5353) are considered.
+func parseDNSFrame(frame []byte) (*DNSEvent, error) {
+	if len(frame) < 14 {
+		return nil, notDNSError
+	}
+
+	ether_type := binary.BigEndian.Uint16(frame[12:14])
+	payload := frame[14:]
+
+	// Skip a single 802.1Q VLAN tag
+	if ether_type == 0x8100 {
+		if len(payload) < 4 {
+			return nil, notDNSError
+		}
+		ether_type = binary.BigEndian.Uint16(payload[2:4])
+		payload = payload[4:]
+	}
+
+	return parseDNSIPPacket(ether_type, payload)
+}
+
+func parseDNSIPPacket(ether_type uint16, packet []byte) (*DNSEvent, error) {
+	result := &DNSEvent{}
+	var udp []byte
+
+	switch ether_type {
+	case 0x0800:
+		if len(packet) < 20 {
+			return nil, notDNSError
+		}
+		header_len := int(packet[0]&0x0f) * 4
+		if header_len < 20 || len(packet) < header_len ||
+			packet[9] != 17 /* UDP */ {
+			return nil, notDNSError
+		}
+		result.SrcIP = net.IP(packet[12:16]).String()
+		result.DestIP = net.IP(packet[16:20]).String()
+		udp = packet[header_len:]
+
+	case 0x86DD:
+		// We do not follow extension headers.
+		if len(packet) < 40 || packet[6] != 17 {
+			return nil, notDNSError
+		}
+		result.SrcIP = net.IP(packet[8:24]).String()
+		result.DestIP = net.IP(packet[24:40]).String()
+		udp = packet[40:]
+
+	default:
+		return nil, notDNSError
+	}
+
+	if len(udp) < 8 {
+		return nil, notDNSError
+	}
+
+	result.SrcPort = binary.BigEndian.Uint16(udp[0:2])
+	result.DestPort = binary.BigEndian.Uint16(udp[2:4])
+	if !isDNSPort(result.SrcPort) && !isDNSPort(result.DestPort) {
+		return nil, notDNSError
+	}
+
+	err := parseDNSMessage(udp[8:], result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func isDNSPort(port uint16) bool {
+	return port == 53 || port == 5353
+}
+
+func parseDNSMessage(data []byte, result *DNSEvent) error {
+	var parser dnsmessage.Parser
+
+	header, err := parser.Start(data)
+	if err != nil {
+		return err
+	}
+
+	result.ID = header.ID
+	result.Response = header.Response
+	result.RCode = strings.TrimPrefix(header.RCode.String(), "RCode")
+
+	question, err := parser.Question()
+	if err != nil {
+		return err
+	}
+	result.Query = strings.TrimSuffix(question.Name.String(), ".")
+	result.Type = dnsTypeName(uint16(question.Type))
+
+	err = parser.SkipAllQuestions()
+	if err != nil {
+		return err
+	}
+
+	for {
+		answer_header, err := parser.AnswerHeader()
+		if err == dnsmessage.ErrSectionDone {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		answer, err := formatDNSAnswer(&parser, answer_header)
+		if err != nil {
+			return err
+		}
+		if answer != "" {
+			result.Answers = append(result.Answers, answer)
+		}
+	}
+
+	return nil
+}
+
+func formatDNSAnswer(parser *dnsmessage.Parser,
+	header dnsmessage.ResourceHeader) (string, error) {
+	switch header.Type {
+	case dnsmessage.TypeA:
+		r, err := parser.AResource()
+		if err != nil {
+			return "", err
+		}
+		return net.IP(r.A[:]).String(), nil
+
+	case dnsmessage.TypeAAAA:
+		r, err := parser.AAAAResource()
+		if err != nil {
+			return "", err
+		}
+		return net.IP(r.AAAA[:]).String(), nil
+
+	case dnsmessage.TypeCNAME:
+		r, err := parser.CNAMEResource()
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(r.CNAME.String(), "."), nil
+
+	case dnsmessage.TypePTR:
+		r, err := parser.PTRResource()
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(r.PTR.String(), "."), nil
+
+	case dnsmessage.TypeMX:
+		r, err := parser.MXResource()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %s", r.Pref,
+			strings.TrimSuffix(r.MX.String(), ".")), nil
+
+	case dnsmessage.TypeTXT:
+		r, err := parser.TXTResource()
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(r.TXT, ""), nil
+
+	default:
+		return "", parser.SkipAnswer()
+	}
+}
@@ -0,0 +1,59 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	vfilter "www.velocidex.com/golang/vfilter"
+)
+
+type DNSCachePlugin struct{}
+
+func (self DNSCachePlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.MACHINE_STATE)
+		if err != nil {
+			scope.Log("dns_cache: %v", err)
+			return
+		}
+
+		// Each platform keeps the cache in a different place -
+		// see dns_cache_*.go
+		entries, err := getDNSCache(ctx, scope)
+		if err != nil {
+			scope.Log("dns_cache: %v", err)
+			return
+		}
+
+		for _, entry := range entries {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- entry:
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self DNSCachePlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "dns_cache",
+		Doc: "Dump the operating system's DNS resolver cache (Windows DNS " +
+			"client, systemd-resolved or mDNSResponder).",
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&DNSCachePlugin{})
+}
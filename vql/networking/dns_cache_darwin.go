@@ -0,0 +1,78 @@
+// +build darwin
+
+package networking
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/artifacts"
+	vfilter "www.velocidex.com/golang/vfilter"
+)
+
+const (
+	mdnsStateDumpDir = "/private/var/log/mDNSResponder"
+)
+
+// Sending SIGINFO to mDNSResponder makes it write a state dump
+// (which includes its record cache) into mdnsStateDumpDir.
+func getDNSCache(ctx context.Context, scope vfilter.Scope) ([]*DNSCacheEntry, error) {
+	config_obj, ok := artifacts.GetConfig(scope)
+	if ok && config_obj.PreventExecve {
+		return nil, errors.New("Not allowed to execve by configuration.")
+	}
+
+	start := time.Now()
+	err := exec.CommandContext(ctx, "/usr/bin/killall",
+		"-INFO", "mDNSResponder").Run()
+	if err != nil {
+		return nil, err
+	}
+
+	// Wait for the dump to be written.
+	for i := 0; i < 10; i++ {
+		path, pres := newestStateDump(start)
+		if pres {
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			return parseMDNSResponderCache(string(data)), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+
+	return nil, errors.New("mDNSResponder did not produce a state dump")
+}
+
+func newestStateDump(after time.Time) (string, bool) {
+	files, err := ioutil.ReadDir(mdnsStateDumpDir)
+	if err != nil {
+		return "", false
+	}
+
+	var newest os.FileInfo
+	for _, f := range files {
+		if f.ModTime().Before(after) {
+			continue
+		}
+		if newest == nil || f.ModTime().After(newest.ModTime()) {
+			newest = f
+		}
+	}
+
+	if newest == nil {
+		return "", false
+	}
+	return filepath.Join(mdnsStateDumpDir, newest.Name()), true
+}
@@ -0,0 +1,34 @@
+// +build linux
+
+package networking
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+
+	"www.velocidex.com/golang/velociraptor/artifacts"
+	vfilter "www.velocidex.com/golang/vfilter"
+)
+
+// systemd-resolved exposes its cache through resolvectl (systemd
+// 250 and later). Older systems do not cache lookups locally at all
+// unless nscd is installed.
+func getDNSCache(ctx context.Context, scope vfilter.Scope) ([]*DNSCacheEntry, error) {
+	config_obj, ok := artifacts.GetConfig(scope)
+	if ok && config_obj.PreventExecve {
+		return nil, errors.New("Not allowed to execve by configuration.")
+	}
+
+	path, err := exec.LookPath("resolvectl")
+	if err != nil {
+		return nil, errors.New("systemd-resolved is not available")
+	}
+
+	out, err := exec.CommandContext(ctx, path, "show-cache").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResolvectlCache(string(out)), nil
+}
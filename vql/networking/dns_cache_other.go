@@ -0,0 +1,14 @@
+// +build !windows,!linux,!darwin
+
+package networking
+
+import (
+	"context"
+	"errors"
+
+	vfilter "www.velocidex.com/golang/vfilter"
+)
+
+func getDNSCache(ctx context.Context, scope vfilter.Scope) ([]*DNSCacheEntry, error) {
+	return nil, errors.New("Not supported on this platform")
+}
@@ -0,0 +1,97 @@
+package networking
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Example: "  2     4497 -U-      Addr    4 www.apple.com. Addr 17.253.144.10"
+	mdnsCacheLineRegex = regexp.MustCompile(
+		`^\s*\d+\s+(?:\S\s+)?(\d+)\s+\S+\s+\S+\s+(\S+)\s+\d+\s+(\S+)\s+(.*)$`)
+)
+
+// Parse the output of `resolvectl show-cache`. The output consists
+// of a "Scope" header followed by one line per resource record in
+// zone file format, e.g.
+//
+//	Scope protocol=dns interface=eth0
+//	www.example.com IN A 93.184.216.34
+func parseResolvectlCache(output string) []*DNSCacheEntry {
+	var result []*DNSCacheEntry
+
+	scope := ""
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Scope ") {
+			scope = strings.TrimPrefix(line, "Scope ")
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[1] != "IN" {
+			continue
+		}
+
+		result = append(result, &DNSCacheEntry{
+			Name:   strings.TrimSuffix(fields[0], "."),
+			Type:   fields[2],
+			Data:   strings.Join(fields[3:], " "),
+			Source: "systemd-resolved " + scope,
+		})
+	}
+
+	return result
+}
+
+// Parse the cache section of an mDNSResponder state dump. The cache
+// section starts with a line containing "Cache" and ends at the next
+// section separator.
+func parseMDNSResponderCache(output string) []*DNSCacheEntry {
+	var result []*DNSCacheEntry
+
+	in_cache := false
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "----") {
+			in_cache = strings.Contains(line, "Cache")
+			continue
+		}
+
+		if !in_cache {
+			continue
+		}
+
+		match := mdnsCacheLineRegex.FindStringSubmatch(line)
+		if len(match) < 5 {
+			continue
+		}
+
+		ttl, _ := strconv.ParseUint(match[1], 10, 32)
+		data := strings.TrimSpace(match[4])
+
+		// The rdata is prefixed by the record type again.
+		data = strings.TrimSpace(strings.TrimPrefix(data, match[2]))
+
+		result = append(result, &DNSCacheEntry{
+			Name:   strings.TrimSuffix(match[3], "."),
+			Type:   mdnsTypeName(match[2]),
+			TTL:    uint32(ttl),
+			Data:   strings.TrimSuffix(data, "."),
+			Source: "mDNSResponder",
+		})
+	}
+
+	return result
+}
+
+// mDNSResponder uses its own names for some record types.
+func mdnsTypeName(name string) string {
+	switch name {
+	case "Addr":
+		return "A"
+	case "AAAA":
+		return "AAAA"
+	}
+	return name
+}
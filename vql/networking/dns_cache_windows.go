@@ -0,0 +1,105 @@
+// +build windows
+
+package networking
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	vfilter "www.velocidex.com/golang/vfilter"
+)
+
+const (
+	// Only consult the local cache - never go to the network.
+	DNS_QUERY_NO_WIRE_QUERY = 0x10
+)
+
+var (
+	dnsapiDll                = windows.NewLazySystemDLL("dnsapi.dll")
+	procDnsGetCacheDataTable = dnsapiDll.NewProc("DnsGetCacheDataTable")
+)
+
+// Undocumented structure returned by DnsGetCacheDataTable
+// https://github.com/malcomvetter/DnsCache
+type dnsCacheEntry struct {
+	Next       *dnsCacheEntry
+	Name       *uint16
+	Type       uint16
+	DataLength uint16
+	Flags      uint32
+}
+
+func getDNSCache(ctx context.Context, scope vfilter.Scope) ([]*DNSCacheEntry, error) {
+	err := procDnsGetCacheDataTable.Find()
+	if err != nil {
+		return nil, err
+	}
+
+	var table *dnsCacheEntry
+	ret, _, err := procDnsGetCacheDataTable.Call(
+		uintptr(unsafe.Pointer(&table)))
+	if ret == 0 {
+		return nil, fmt.Errorf("DnsGetCacheDataTable: %w", err)
+	}
+
+	var result []*DNSCacheEntry
+	for entry := table; entry != nil; entry = entry.Next {
+		name := windows.UTF16PtrToString(entry.Name)
+		records, err := queryCachedRecords(name, entry.Type)
+		if err != nil {
+			// The entry may have expired since we got the table.
+			continue
+		}
+		result = append(result, records...)
+	}
+
+	return result, nil
+}
+
+// Resolve the cached name without hitting the network to recover
+// the record data and TTL.
+func queryCachedRecords(name string, rtype uint16) ([]*DNSCacheEntry, error) {
+	var records *windows.DNSRecord
+	err := windows.DnsQuery(name, rtype, DNS_QUERY_NO_WIRE_QUERY,
+		nil, &records, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.DnsRecordListFree(records, 1)
+
+	var result []*DNSCacheEntry
+	for r := records; r != nil; r = r.Next {
+		result = append(result, &DNSCacheEntry{
+			Name:   windows.UTF16PtrToString(r.Name),
+			Type:   dnsTypeName(r.Type),
+			TTL:    r.Ttl,
+			Data:   formatDNSRecordData(r),
+			Source: "dnsapi",
+		})
+	}
+	return result, nil
+}
+
+func formatDNSRecordData(r *windows.DNSRecord) string {
+	switch r.Type {
+	case windows.DNS_TYPE_A:
+		return net.IP(r.Data[:4]).String()
+
+	case windows.DNS_TYPE_AAAA:
+		return net.IP(r.Data[:16]).String()
+
+	case windows.DNS_TYPE_CNAME, windows.DNS_TYPE_PTR, windows.DNS_TYPE_NS:
+		ptr := (*windows.DNSPTRData)(unsafe.Pointer(&r.Data[0]))
+		return windows.UTF16PtrToString(ptr.Host)
+
+	case windows.DNS_TYPE_MX:
+		mx := (*windows.DNSMXData)(unsafe.Pointer(&r.Data[0]))
+		return fmt.Sprintf("%d %s", mx.Preference,
+			windows.UTF16PtrToString(mx.NameExchange))
+	}
+
+	return ""
+}
@@ -0,0 +1,90 @@
+package networking
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+func buildDNSResponse(t *testing.T) []byte {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+		ID: 1234, Response: true,
+	})
+	builder.EnableCompression()
+
+	name := dnsmessage.MustNewName("www.example.com.")
+	assert.NoError(t, builder.StartQuestions())
+	assert.NoError(t, builder.Question(dnsmessage.Question{
+		Name:  name,
+		Type:  dnsmessage.TypeA,
+		Class: dnsmessage.ClassINET,
+	}))
+
+	assert.NoError(t, builder.StartAnswers())
+	assert.NoError(t, builder.AResource(dnsmessage.ResourceHeader{
+		Name:  name,
+		Class: dnsmessage.ClassINET,
+		TTL:   300,
+	}, dnsmessage.AResource{A: [4]byte{93, 184, 216, 34}}))
+
+	msg, err := builder.Finish()
+	assert.NoError(t, err)
+
+	return msg
+}
+
+// Wrap the DNS message in Ethernet/IPv4/UDP headers.
+func buildFrame(payload []byte) []byte {
+	frame := make([]byte, 14+20+8)
+	binary.BigEndian.PutUint16(frame[12:], 0x0800)
+
+	ip := frame[14:]
+	ip[0] = 0x45
+	ip[9] = 17
+	copy(ip[12:16], []byte{8, 8, 8, 8})
+	copy(ip[16:20], []byte{10, 0, 0, 1})
+
+	udp := ip[20:]
+	binary.BigEndian.PutUint16(udp[0:], 53)
+	binary.BigEndian.PutUint16(udp[2:], 40000)
+
+	return append(frame, payload...)
+}
+
+func TestParseDNSFrame(t *testing.T) {
+	event, err := parseDNSFrame(buildFrame(buildDNSResponse(t)))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "8.8.8.8", event.SrcIP)
+	assert.Equal(t, "10.0.0.1", event.DestIP)
+	assert.Equal(t, uint16(1234), event.ID)
+	assert.Equal(t, true, event.Response)
+	assert.Equal(t, "Success", event.RCode)
+	assert.Equal(t, "www.example.com", event.Query)
+	assert.Equal(t, "A", event.Type)
+	assert.Equal(t, []string{"93.184.216.34"}, event.Answers)
+
+	// Not DNS traffic
+	frame := buildFrame(nil)
+	binary.BigEndian.PutUint16(frame[14+20:], 443)
+	_, err = parseDNSFrame(frame)
+	assert.Error(t, err)
+}
+
+func TestParseResolvectlCache(t *testing.T) {
+	entries := parseResolvectlCache(`Scope protocol=dns interface=eth0
+    www.example.com IN A 93.184.216.34
+    www.example.com IN AAAA 2606:2800:220:1:248:1893:25c8:1946
+Scope protocol=llmnr interface=eth0 family=ipv4
+No entries.
+`)
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "www.example.com", entries[0].Name)
+	assert.Equal(t, "A", entries[0].Type)
+	assert.Equal(t, "93.184.216.34", entries[0].Data)
+	assert.Equal(t, "systemd-resolved protocol=dns interface=eth0",
+		entries[0].Source)
+	assert.Equal(t, "AAAA", entries[1].Type)
+}
@@ -0,0 +1,79 @@
+package networking
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	vfilter "www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type WatchDNSPluginArgs struct {
+	Interface     string `vfilter:"optional,field=interface,doc=Only watch this interface (default all interfaces)"`
+	ResponsesOnly bool   `vfilter:"optional,field=responses_only,doc=Only emit DNS responses (default both queries and responses)"`
+}
+
+type WatchDNSPlugin struct{}
+
+func (self WatchDNSPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.MACHINE_STATE)
+		if err != nil {
+			scope.Log("watch_dns: %v", err)
+			return
+		}
+
+		arg := &WatchDNSPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("watch_dns: %v", err)
+			return
+		}
+
+		events := make(chan *DNSEvent)
+		go func() {
+			defer close(events)
+
+			// Platform specific capture - see watch_dns_*.go
+			err := captureDNS(ctx, arg.Interface, events)
+			if err != nil {
+				scope.Log("watch_dns: %v", err)
+			}
+		}()
+
+		for event := range events {
+			if arg.ResponsesOnly && !event.Response {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- event:
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self WatchDNSPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "watch_dns",
+		Doc:     "Watch DNS queries and responses observed on the network interfaces.",
+		ArgType: type_map.AddType(scope, &WatchDNSPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&WatchDNSPlugin{})
+}
@@ -0,0 +1,87 @@
+// +build linux
+
+package networking
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Capture DNS traffic using a raw AF_PACKET socket. This sees
+// traffic for all processes on the host (including containers in
+// other network namespaces when watching the bridge interface).
+func captureDNS(ctx context.Context,
+	interface_name string, output chan *DNSEvent) error {
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW,
+		int(htons(unix.ETH_P_ALL)))
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	if interface_name != "" {
+		iface, err := net.InterfaceByName(interface_name)
+		if err != nil {
+			return err
+		}
+
+		err = unix.Bind(fd, &unix.SockaddrLinklayer{
+			Protocol: htons(unix.ETH_P_ALL),
+			Ifindex:  iface.Index,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Wake up periodically to check for cancellation.
+	tv := unix.NsecToTimeval(int64(time.Second))
+	err = unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 65536)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		n, from, err := unix.Recvfrom(fd, buf, 0)
+		if err == unix.EAGAIN || err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		// Packets on the loopback interface are seen twice - once
+		// going out and once coming in.
+		ll, ok := from.(*unix.SockaddrLinklayer)
+		if ok && ll.Hatype == unix.ARPHRD_LOOPBACK &&
+			ll.Pkttype == unix.PACKET_OUTGOING {
+			continue
+		}
+
+		event, err := parseDNSFrame(buf[:n])
+		if err != nil {
+			continue
+		}
+		event.Time = time.Now().UTC()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case output <- event:
+		}
+	}
+}
+
+func htons(i uint16) uint16 {
+	return (i<<8)&0xff00 | i>>8
+}
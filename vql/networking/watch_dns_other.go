@@ -0,0 +1,15 @@
+// +build !linux
+
+package networking
+
+import (
+	"context"
+	"errors"
+)
+
+// On Windows the DNS client ETW provider is a better source - see
+// the Windows.ETW.DNS artifact.
+func captureDNS(ctx context.Context,
+	interface_name string, output chan *DNSEvent) error {
+	return errors.New("Not supported on this platform")
+}
@@ -0,0 +1,52 @@
+package crypto
+
+import (
+	"github.com/Velocidex/ordereddict"
+	"golang.org/x/net/context"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type PKEncryptRecipientsArgs struct {
+	Data       string   `vfilter:"required,field=data,doc=The data to encrypt (usually a session password)"`
+	PublicKeys []string `vfilter:"required,field=public_keys,doc=A list of PEM encoded X509 certificates or armored PGP public keys"`
+}
+
+type PKEncryptRecipientsFunction struct{}
+
+func (self *PKEncryptRecipientsFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	arg := &PKEncryptRecipientsArgs{}
+	err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("ERROR:pk_encrypt_recipients: %s", err.Error())
+		return vfilter.Null{}
+	}
+
+	recipients, err := crypto_utils.EncryptForRecipients(
+		[]byte(arg.Data), arg.PublicKeys)
+	if err != nil {
+		scope.Log("ERROR:pk_encrypt_recipients: %s", err.Error())
+		return vfilter.Null{}
+	}
+
+	return recipients
+}
+
+func (self PKEncryptRecipientsFunction) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name: "pk_encrypt_recipients",
+		Doc: "Encrypt data separately for each recipient public key " +
+			"(X509 certificates or PGP keys).",
+		ArgType: type_map.AddType(scope, &PKEncryptRecipientsArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&PKEncryptRecipientsFunction{})
+}
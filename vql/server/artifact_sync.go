@@ -0,0 +1,189 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/artifact_sync"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type ArtifactRepoSyncPlugin struct{}
+
+func (self ArtifactRepoSyncPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.ARTIFACT_WRITER)
+		if err != nil {
+			scope.Log("artifact_repo_sync: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+			"artifact_repo_sync", logrus.Fields{})
+
+		state, err := artifact_sync.Sync(ctx, config_obj)
+		if err != nil {
+			scope.Log("artifact_repo_sync: %v", err)
+			return
+		}
+
+		writeArtifactSyncChanges(ctx, output_chan, state)
+	}()
+
+	return output_chan
+}
+
+func (self ArtifactRepoSyncPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "artifact_repo_sync",
+		Doc: "Sync the artifact git repository now and show the changes " +
+			"staged for approval.",
+	}
+}
+
+type ArtifactRepoChangesPlugin struct{}
+
+func (self ArtifactRepoChangesPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("artifact_repo_changes: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		state, err := artifact_sync.GetState(config_obj)
+		if err != nil {
+			scope.Log("artifact_repo_changes: %v", err)
+			return
+		}
+
+		writeArtifactSyncChanges(ctx, output_chan, state)
+	}()
+
+	return output_chan
+}
+
+func (self ArtifactRepoChangesPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "artifact_repo_changes",
+		Doc:  "Show the artifact changes staged for approval.",
+	}
+}
+
+func writeArtifactSyncChanges(ctx context.Context,
+	output_chan chan vfilter.Row, state *api_proto.ArtifactSyncState) {
+	for _, change := range state.Changes {
+		row := ordereddict.NewDict().
+			Set("Commit", state.StagedCommit).
+			Set("Name", change.Name).
+			Set("Action", change.Action).
+			Set("Filename", change.Filename).
+			Set("Definition", change.Definition).
+			Set("Previous", change.Previous).
+			Set("Error", change.Error)
+
+		select {
+		case <-ctx.Done():
+			return
+		case output_chan <- row:
+		}
+	}
+}
+
+type ArtifactRepoApproveFunctionArgs struct {
+	Commit string `vfilter:"required,field=commit,doc=The staged commit to approve"`
+	Reject bool   `vfilter:"optional,field=reject,doc=Reject the changes instead"`
+}
+
+type ArtifactRepoApproveFunction struct{}
+
+func (self *ArtifactRepoApproveFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.SERVER_ADMIN)
+	if err != nil {
+		scope.Log("artifact_repo_approve: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &ArtifactRepoApproveFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("artifact_repo_approve: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+	var state *api_proto.ArtifactSyncState
+	if arg.Reject {
+		state, err = artifact_sync.Reject(config_obj, principal, arg.Commit)
+	} else {
+		state, err = artifact_sync.Approve(ctx, config_obj, principal, arg.Commit)
+	}
+	if err != nil {
+		scope.Log("artifact_repo_approve: %v", err)
+		return vfilter.Null{}
+	}
+
+	return json.ConvertProtoToOrderedDict(state)
+}
+
+func (self ArtifactRepoApproveFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name: "artifact_repo_approve",
+		Doc: "Approve (or reject) the artifact changes staged from the " +
+			"artifact git repository.",
+		ArgType: type_map.AddType(scope, &ArtifactRepoApproveFunctionArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&ArtifactRepoSyncPlugin{})
+	vql_subsystem.RegisterPlugin(&ArtifactRepoChangesPlugin{})
+	vql_subsystem.RegisterFunction(&ArtifactRepoApproveFunction{})
+}
@@ -0,0 +1,171 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/services/artifact_tests"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type ArtifactTestPluginArgs struct {
+	Name       string `vfilter:"optional,field=name,doc=The name of the artifact to test"`
+	Definition string `vfilter:"optional,field=definition,doc=Test this artifact definition instead (it need not be loaded)"`
+}
+
+type ArtifactTestPlugin struct{}
+
+func (self ArtifactTestPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.ARTIFACT_WRITER)
+		if err != nil {
+			scope.Log("artifact_test: %v", err)
+			return
+		}
+
+		arg := &ArtifactTestPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("artifact_test: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		principal := vql_subsystem.GetPrincipal(scope)
+		var run *api_proto.ArtifactTestRun
+		switch {
+		case arg.Definition != "":
+			run, err = artifact_tests.RunTests(ctx, config_obj, principal,
+				arg.Definition)
+		case arg.Name != "":
+			run, err = artifact_tests.RunTestsForArtifact(ctx, config_obj,
+				principal, arg.Name)
+		default:
+			scope.Log("artifact_test: One of name or definition must be specified")
+			return
+		}
+		if err != nil {
+			scope.Log("artifact_test: %v", err)
+			return
+		}
+
+		writeArtifactTestResults(ctx, output_chan, run)
+	}()
+
+	return output_chan
+}
+
+func (self ArtifactTestPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "artifact_test",
+		Doc:     "Run the tests embedded in an artifact.",
+		ArgType: type_map.AddType(scope, &ArtifactTestPluginArgs{}),
+	}
+}
+
+func writeArtifactTestResults(ctx context.Context,
+	output_chan chan vfilter.Row, run *api_proto.ArtifactTestRun) {
+	for _, result := range run.Results {
+		row := ordereddict.NewDict().
+			Set("Artifact", run.Artifact).
+			Set("Version", run.Version).
+			Set("Test", result.Name).
+			Set("Passed", result.Passed).
+			Set("Error", result.Error).
+			Set("Rows", result.Rows).
+			Set("Output", result.Output).
+			Set("Log", result.Log)
+
+		select {
+		case <-ctx.Done():
+			return
+		case output_chan <- row:
+		}
+	}
+}
+
+type ArtifactTestResultsPluginArgs struct {
+	Name string `vfilter:"required,field=name,doc=The name of the artifact"`
+}
+
+type ArtifactTestResultsPlugin struct{}
+
+func (self ArtifactTestResultsPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("artifact_test_results: %v", err)
+			return
+		}
+
+		arg := &ArtifactTestResultsPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("artifact_test_results: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		runs, err := artifact_tests.ListResults(config_obj, arg.Name)
+		if err != nil {
+			scope.Log("artifact_test_results: %v", err)
+			return
+		}
+
+		for _, run := range runs {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- json.ConvertProtoToOrderedDict(run):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self ArtifactTestResultsPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "artifact_test_results",
+		Doc:     "Show the results of previous test runs of each version of an artifact.",
+		ArgType: type_map.AddType(scope, &ArtifactTestResultsPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&ArtifactTestPlugin{})
+	vql_subsystem.RegisterPlugin(&ArtifactTestResultsPlugin{})
+}
@@ -0,0 +1,111 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/audit"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/functions"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type AuditVerifyPluginArgs struct {
+	StartTime vfilter.Any `vfilter:"optional,field=start_time,doc=Only verify records after this time."`
+	EndTime   vfilter.Any `vfilter:"optional,field=end_time,doc=Only verify records before this time."`
+}
+
+type AuditVerifyPlugin struct{}
+
+func (self AuditVerifyPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		// The audit log is kept in the root org.
+		err := vql_subsystem.CheckAccessInOrg(scope, "root", acls.SERVER_ADMIN)
+		if err != nil {
+			scope.Log("audit_verify: %v", err)
+			return
+		}
+
+		arg := &AuditVerifyPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("audit_verify: %v", err)
+			return
+		}
+
+		org_manager, err := services.GetOrgManager()
+		if err != nil {
+			scope.Log("audit_verify: %v", err)
+			return
+		}
+
+		config_obj, err := org_manager.GetOrgConfig(services.ROOT_ORG_ID)
+		if err != nil {
+			scope.Log("audit_verify: %v", err)
+			return
+		}
+
+		var start, end time.Time
+		if !utils.IsNil(arg.StartTime) {
+			start, err = functions.TimeFromAny(scope, arg.StartTime)
+			if err != nil {
+				scope.Log("audit_verify: %v", err)
+				return
+			}
+		}
+
+		if !utils.IsNil(arg.EndTime) {
+			end, err = functions.TimeFromAny(scope, arg.EndTime)
+			if err != nil {
+				scope.Log("audit_verify: %v", err)
+				return
+			}
+		}
+
+		summary, err := audit.Verify(ctx, config_obj, start, end,
+			func(row *ordereddict.Dict) {
+				select {
+				case <-ctx.Done():
+				case output_chan <- row:
+				}
+			})
+		if err != nil {
+			scope.Log("audit_verify: %v", err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+		case output_chan <- summary:
+		}
+	}()
+
+	return output_chan
+}
+
+func (self AuditVerifyPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "audit_verify",
+		Doc:     "Verify the hash chain and signatures of the audit log. Emits a row for each problem found followed by a summary row.",
+		ArgType: type_map.AddType(scope, &AuditVerifyPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&AuditVerifyPlugin{})
+}
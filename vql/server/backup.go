@@ -0,0 +1,145 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/backup"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+)
+
+type BackupCreatePlugin struct{}
+
+func (self BackupCreatePlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		// Backups cover the datastore of all orgs.
+		err := vql_subsystem.CheckAccessInOrg(scope, "root", acls.SERVER_ADMIN)
+		if err != nil {
+			scope.Log("backup_create: %v", err)
+			return
+		}
+
+		org_manager, err := services.GetOrgManager()
+		if err != nil {
+			scope.Log("backup_create: %v", err)
+			return
+		}
+
+		config_obj, err := org_manager.GetOrgConfig(services.ROOT_ORG_ID)
+		if err != nil {
+			scope.Log("backup_create: %v", err)
+			return
+		}
+
+		logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+			"backup_create", logrus.Fields{})
+
+		manifest, err := backup.Snapshot(ctx, config_obj)
+		if err != nil {
+			scope.Log("backup_create: %v", err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+		case output_chan <- manifest.Summary():
+		}
+	}()
+
+	return output_chan
+}
+
+func (self BackupCreatePlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "backup_create",
+		Doc: "Take a snapshot of the datastore and file store into the " +
+			"backup repository now.",
+	}
+}
+
+type BackupsPlugin struct{}
+
+func (self BackupsPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccessInOrg(scope, "root", acls.SERVER_ADMIN)
+		if err != nil {
+			scope.Log("backups: %v", err)
+			return
+		}
+
+		org_manager, err := services.GetOrgManager()
+		if err != nil {
+			scope.Log("backups: %v", err)
+			return
+		}
+
+		config_obj, err := org_manager.GetOrgConfig(services.ROOT_ORG_ID)
+		if err != nil {
+			scope.Log("backups: %v", err)
+			return
+		}
+
+		repository, err := backup.GetRepository(config_obj)
+		if err != nil {
+			scope.Log("backups: %v", err)
+			return
+		}
+
+		ids, err := repository.ListSnapshots()
+		if err != nil {
+			scope.Log("backups: %v", err)
+			return
+		}
+
+		for _, id := range ids {
+			manifest, err := repository.LoadManifest(id)
+			if err != nil {
+				scope.Log("backups: %v", err)
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- manifest.Summary():
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self BackupsPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "backups",
+		Doc:  "List the snapshots in the backup repository.",
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&BackupCreatePlugin{})
+	vql_subsystem.RegisterPlugin(&BackupsPlugin{})
+}
@@ -0,0 +1,98 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/services/deception"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type CanariesPluginArgs struct {
+	ClientId string `vfilter:"required,field=client_id,doc=The client to list canaries for."`
+}
+
+type CanariesPlugin struct{}
+
+func (self CanariesPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("canaries: %v", err)
+			return
+		}
+
+		arg := &CanariesPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("canaries: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		record, err := deception.GetCanaries(config_obj, arg.ClientId)
+		if err != nil {
+			scope.Log("canaries: %v", err)
+			return
+		}
+
+		timestamp := func(sec uint64) interface{} {
+			if sec == 0 {
+				return nil
+			}
+			return time.Unix(int64(sec), 0).UTC()
+		}
+
+		for _, canary := range record.Canaries {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- ordereddict.NewDict().
+				Set("ClientId", arg.ClientId).
+				Set("Type", canary.Type).
+				Set("Location", canary.Location).
+				Set("Username", canary.Username).
+				Set("Token", canary.Token).
+				Set("FlowId", canary.FlowId).
+				Set("Planted", timestamp(canary.Planted)).
+				Set("Removed", timestamp(canary.Removed)).
+				Set("LastTriggered", timestamp(canary.LastTriggered)).
+				Set("TriggerCount", canary.TriggerCount):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self CanariesPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "canaries",
+		Doc: "List the deception canaries planted on a client and " +
+			"when they were last triggered.",
+		ArgType: type_map.AddType(scope, &CanariesPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&CanariesPlugin{})
+}
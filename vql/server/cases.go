@@ -0,0 +1,305 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/cases"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type CaseFunctionArgs struct {
+	CaseId      string      `vfilter:"optional,field=case_id,doc=The case to update. If not set a new case is created"`
+	Title       string      `vfilter:"optional,field=title,doc=The title of the case"`
+	Description string      `vfilter:"optional,field=description,doc=A description of the case"`
+	Status      string      `vfilter:"optional,field=status,doc=One of OPEN, IN_PROGRESS or CLOSED"`
+	Assignees   []string    `vfilter:"optional,field=assignees,doc=Users assigned to the case"`
+	Tags        []string    `vfilter:"optional,field=tags,doc=Tags for the case"`
+	Clients     []string    `vfilter:"optional,field=clients,doc=Client ids linked to the case"`
+	Flows       vfilter.Any `vfilter:"optional,field=flows,doc=A list of dicts with client_id and flow_id linked to the case"`
+	Hunts       []string    `vfilter:"optional,field=hunts,doc=Hunt ids linked to the case"`
+	Notebooks   []string    `vfilter:"optional,field=notebooks,doc=Notebook ids linked to the case"`
+}
+
+type CaseFunction struct{}
+
+func (self *CaseFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.NOTEBOOK_EDITOR)
+	if err != nil {
+		scope.Log("case_set: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &CaseFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("case_set: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	flows := []*api_proto.CaseFlow{}
+	if !utils.IsNil(arg.Flows) {
+		parsed := &api_proto.Case{}
+		err = utils.ParseIntoProtobuf(
+			ordereddict.NewDict().Set("flows", arg.Flows), parsed)
+		if err != nil {
+			scope.Log("case_set: flows: %v", err)
+			return vfilter.Null{}
+		}
+		flows = parsed.Flows
+	}
+
+	// Only the fields which were specified are changed.
+	update := func(case_obj *api_proto.Case) error {
+		for _, field := range args.Keys() {
+			switch field {
+			case "title":
+				case_obj.Title = arg.Title
+			case "description":
+				case_obj.Description = arg.Description
+			case "status":
+				case_obj.Status = arg.Status
+			case "assignees":
+				case_obj.Assignees = arg.Assignees
+			case "tags":
+				case_obj.Tags = arg.Tags
+			case "clients":
+				case_obj.Clients = arg.Clients
+			case "flows":
+				case_obj.Flows = flows
+			case "hunts":
+				case_obj.Hunts = arg.Hunts
+			case "notebooks":
+				case_obj.Notebooks = arg.Notebooks
+			}
+		}
+		return nil
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+
+	var case_obj *api_proto.Case
+	if arg.CaseId == "" {
+		case_obj = &api_proto.Case{}
+		_ = update(case_obj)
+		case_obj, err = cases.NewCase(config_obj, principal, case_obj)
+	} else {
+		case_obj, err = cases.ModifyCase(config_obj, principal,
+			arg.CaseId, update)
+	}
+	if err != nil {
+		scope.Log("case_set: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, principal, "case_set",
+		logrus.Fields{
+			"case_id": case_obj.CaseId,
+			"details": json.MustMarshalString(arg),
+		})
+
+	return json.ConvertProtoToOrderedDict(case_obj)
+}
+
+func (self CaseFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "case_set",
+		Doc:     "Create or update a case.",
+		ArgType: type_map.AddType(scope, &CaseFunctionArgs{}),
+	}
+}
+
+type CaseNoteFunctionArgs struct {
+	CaseId string `vfilter:"required,field=case_id,doc=The case to add the note to"`
+	Note   string `vfilter:"required,field=note,doc=The note to add to the case timeline"`
+}
+
+type CaseNoteFunction struct{}
+
+func (self *CaseNoteFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.NOTEBOOK_EDITOR)
+	if err != nil {
+		scope.Log("case_note: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &CaseNoteFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("case_note: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	case_obj, err := cases.AddNote(config_obj,
+		vql_subsystem.GetPrincipal(scope), arg.CaseId, arg.Note)
+	if err != nil {
+		scope.Log("case_note: %v", err)
+		return vfilter.Null{}
+	}
+
+	return json.ConvertProtoToOrderedDict(case_obj)
+}
+
+func (self CaseNoteFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "case_note",
+		Doc:     "Add a note to a case's timeline.",
+		ArgType: type_map.AddType(scope, &CaseNoteFunctionArgs{}),
+	}
+}
+
+type DeleteCaseFunctionArgs struct {
+	CaseId string `vfilter:"required,field=case_id,doc=The case to delete"`
+}
+
+type DeleteCaseFunction struct{}
+
+func (self *DeleteCaseFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.NOTEBOOK_EDITOR)
+	if err != nil {
+		scope.Log("case_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &DeleteCaseFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("case_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	err = cases.DeleteCase(config_obj, arg.CaseId)
+	if err != nil {
+		scope.Log("case_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+		"case_delete", logrus.Fields{
+			"case_id": arg.CaseId,
+		})
+
+	return arg.CaseId
+}
+
+func (self DeleteCaseFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "case_delete",
+		Doc:     "Delete a case. The linked flows, hunts and notebooks are kept.",
+		ArgType: type_map.AddType(scope, &DeleteCaseFunctionArgs{}),
+	}
+}
+
+type CasesPluginArgs struct {
+	CaseId   string `vfilter:"optional,field=case_id,doc=Only return this case"`
+	Status   string `vfilter:"optional,field=status,doc=Only return cases with this status"`
+	Assignee string `vfilter:"optional,field=assignee,doc=Only return cases assigned to this user"`
+}
+
+type CasesPlugin struct{}
+
+func (self CasesPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("cases: %v", err)
+			return
+		}
+
+		arg := &CasesPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("cases: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		items, err := cases.ListCases(config_obj, &api_proto.CasesRequest{
+			CaseId:   arg.CaseId,
+			Status:   arg.Status,
+			Assignee: arg.Assignee,
+		})
+		if err != nil {
+			scope.Log("cases: %v", err)
+			return
+		}
+
+		for _, case_obj := range items {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- json.ConvertProtoToOrderedDict(case_obj):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self CasesPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "cases",
+		Doc:     "List cases.",
+		ArgType: type_map.AddType(scope, &CasesPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&CaseFunction{})
+	vql_subsystem.RegisterFunction(&CaseNoteFunction{})
+	vql_subsystem.RegisterFunction(&DeleteCaseFunction{})
+	vql_subsystem.RegisterPlugin(&CasesPlugin{})
+}
@@ -0,0 +1,190 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/indexing"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type ClientGroupFunctionArgs struct {
+	Name        string `vfilter:"required,field=name,doc=The name of the group"`
+	Query       string `vfilter:"required,field=query,doc=The search terms clients must match (e.g. label:Servers os:windows seen:<24h)"`
+	Description string `vfilter:"optional,field=description,doc=A description of the group"`
+}
+
+type ClientGroupFunction struct{}
+
+func (self *ClientGroupFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.LABEL_CLIENT)
+	if err != nil {
+		scope.Log("client_group: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &ClientGroupFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("client_group: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+	group := &api_proto.ClientGroup{
+		Name:        arg.Name,
+		Query:       arg.Query,
+		Description: arg.Description,
+		Creator:     principal,
+	}
+
+	err = indexing.SetClientGroup(config_obj, group)
+	if err != nil {
+		scope.Log("client_group: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, principal, "client_group",
+		logrus.Fields{
+			"name":  arg.Name,
+			"query": arg.Query,
+		})
+
+	return json.ConvertProtoToOrderedDict(group)
+}
+
+func (self ClientGroupFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name: "client_group",
+		Doc: "Create or update a saved client group. Members can be " +
+			"searched with group:<name>.",
+		ArgType: type_map.AddType(scope, &ClientGroupFunctionArgs{}),
+	}
+}
+
+type DeleteClientGroupFunctionArgs struct {
+	Name string `vfilter:"required,field=name,doc=The group to delete"`
+}
+
+type DeleteClientGroupFunction struct{}
+
+func (self *DeleteClientGroupFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.LABEL_CLIENT)
+	if err != nil {
+		scope.Log("client_group_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &DeleteClientGroupFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("client_group_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	err = indexing.DeleteClientGroup(config_obj, arg.Name)
+	if err != nil {
+		scope.Log("client_group_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+		"client_group_delete", logrus.Fields{
+			"name": arg.Name,
+		})
+
+	return arg.Name
+}
+
+func (self DeleteClientGroupFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name: "client_group_delete",
+		Doc: "Delete a saved client group. Hunts and monitoring tables " +
+			"targeting the group no longer match any clients.",
+		ArgType: type_map.AddType(scope, &DeleteClientGroupFunctionArgs{}),
+	}
+}
+
+type ClientGroupsPlugin struct{}
+
+func (self ClientGroupsPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("client_groups: %s", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		groups, err := indexing.ListClientGroups(config_obj)
+		if err != nil {
+			scope.Log("client_groups: %v", err)
+			return
+		}
+
+		for _, group := range groups {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- json.ConvertProtoToOrderedDict(group):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self ClientGroupsPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "client_groups",
+		Doc:  "List all saved client groups.",
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&ClientGroupFunction{})
+	vql_subsystem.RegisterFunction(&DeleteClientGroupFunction{})
+	vql_subsystem.RegisterPlugin(&ClientGroupsPlugin{})
+}
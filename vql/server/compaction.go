@@ -0,0 +1,101 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/compaction"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type CompactDatastorePluginArgs struct {
+	DryRun       bool   `vfilter:"optional,field=dry_run,doc=Only report what would be removed."`
+	OpsPerSecond uint64 `vfilter:"optional,field=ops_per_second,doc=Maximum number of file operations per second (default 100)."`
+	MinAge       uint64 `vfilter:"optional,field=min_age,doc=Only remove files older than this many seconds (default 3600)."`
+}
+
+type CompactDatastorePlugin struct{}
+
+func (self CompactDatastorePlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.SERVER_ADMIN)
+		if err != nil {
+			scope.Log("compact_datastore: %v", err)
+			return
+		}
+
+		arg := &CompactDatastorePluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("compact_datastore: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		options := compaction.Options{
+			DryRun:       arg.DryRun,
+			OpsPerSecond: arg.OpsPerSecond,
+			MinAge:       time.Duration(arg.MinAge) * time.Second,
+		}
+
+		if options.OpsPerSecond == 0 {
+			options.OpsPerSecond = 100
+		}
+
+		if arg.MinAge == 0 {
+			options.MinAge = time.Hour
+		}
+
+		logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+			"compact_datastore", logrus.Fields{
+				"dry_run": arg.DryRun,
+			})
+
+		stats, err := compaction.Compact(ctx, config_obj, options)
+		if err != nil {
+			scope.Log("compact_datastore: %v", err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+		case output_chan <- stats:
+		}
+	}()
+
+	return output_chan
+}
+
+func (self CompactDatastorePlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "compact_datastore",
+		Doc: "Remove orphaned tasks, stale client ping files and empty " +
+			"directories from the datastore and report the reclaimed space.",
+		ArgType: type_map.AddType(scope, &CompactDatastorePluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&CompactDatastorePlugin{})
+}
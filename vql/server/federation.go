@@ -0,0 +1,371 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/federation"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/functions"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+// Queries on the peers run as the federation API identity, so all
+// the plugins which act on peers require the SERVER_ADMIN
+// permission.
+type FederatedQueryPluginArgs struct {
+	Query string            `vfilter:"required,field=query,doc=The VQL query to run on the peers"`
+	Peers []string          `vfilter:"optional,field=peers,doc=Only run on these peers (default all peers)"`
+	Env   *ordereddict.Dict `vfilter:"optional,field=env,doc=Variables to pass to the query (passed as strings)"`
+}
+
+type FederatedQueryPlugin struct{}
+
+func (self FederatedQueryPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.SERVER_ADMIN)
+		if err != nil {
+			scope.Log("federated_query: %v", err)
+			return
+		}
+
+		arg := &FederatedQueryPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("federated_query: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+			"federated_query", logrus.Fields{
+				"query": arg.Query,
+				"peers": arg.Peers,
+			})
+
+		// Rows from peers which succeed are returned even if others
+		// fail.
+		err = federation.Query(ctx, config_obj, arg.Peers, arg.Query, arg.Env,
+			func(row *ordereddict.Dict) {
+				select {
+				case <-ctx.Done():
+				case output_chan <- row:
+				}
+			})
+		if err != nil {
+			scope.Log("federated_query: %v", err)
+		}
+	}()
+
+	return output_chan
+}
+
+func (self FederatedQueryPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "federated_query",
+		Doc:     "Run a VQL query on the federation peers.",
+		ArgType: type_map.AddType(scope, &FederatedQueryPluginArgs{}),
+	}
+}
+
+type FederatedHuntFunctionArgs struct {
+	Description string            `vfilter:"optional,field=description,doc=Description of the hunt"`
+	Artifacts   []string          `vfilter:"required,field=artifacts,doc=A list of artifacts to collect"`
+	Spec        *ordereddict.Dict `vfilter:"optional,field=spec,doc=Parameters to apply to the artifacts"`
+	Expires     vfilter.LazyExpr  `vfilter:"optional,field=expires,doc=A time for expiry (default one week)"`
+	Peers       []string          `vfilter:"optional,field=peers,doc=Only hunt on these peers (default all peers)"`
+}
+
+type FederatedHuntFunction struct{}
+
+func (self *FederatedHuntFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.SERVER_ADMIN)
+	if err != nil {
+		scope.Log("federated_hunt: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &FederatedHuntFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("federated_hunt: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	expires := utils.GetTime().Now().Add(7 * 24 * time.Hour)
+	if !utils.IsNil(arg.Expires) {
+		expires, err = functions.TimeFromAny(scope, arg.Expires.Reduce(ctx))
+		if err != nil {
+			scope.Log("federated_hunt: expiry time invalid: %v", err)
+			return vfilter.Null{}
+		}
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+	hunt, err := federation.CreateHunt(ctx, config_obj, &federation.HuntRequest{
+		Description: arg.Description,
+		Creator:     principal,
+		Artifacts:   arg.Artifacts,
+		Spec:        arg.Spec,
+		Expires:     expires,
+		Peers:       arg.Peers,
+	})
+	if err != nil {
+		scope.Log("federated_hunt: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, principal, "federated_hunt", logrus.Fields{
+		"hunt_id":   hunt.HuntId,
+		"artifacts": arg.Artifacts,
+		"peers":     arg.Peers,
+	})
+
+	return json.ConvertProtoToOrderedDict(hunt)
+}
+
+func (self FederatedHuntFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "federated_hunt",
+		Doc:     "Schedule a hunt on the federation peers.",
+		ArgType: type_map.AddType(scope, &FederatedHuntFunctionArgs{}),
+	}
+}
+
+type FederatedHuntStopFunctionArgs struct {
+	HuntId string `vfilter:"required,field=hunt_id,doc=The federated hunt to stop"`
+}
+
+type FederatedHuntStopFunction struct{}
+
+func (self *FederatedHuntStopFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.SERVER_ADMIN)
+	if err != nil {
+		scope.Log("federated_hunt_stop: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &FederatedHuntStopFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("federated_hunt_stop: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+		"federated_hunt_stop", logrus.Fields{
+			"hunt_id": arg.HuntId,
+		})
+
+	// The hunt may be stopped on some peers but not others.
+	hunt, err := federation.StopHunt(ctx, config_obj, arg.HuntId)
+	if err != nil {
+		scope.Log("federated_hunt_stop: %v", err)
+	}
+
+	if hunt == nil {
+		return vfilter.Null{}
+	}
+	return json.ConvertProtoToOrderedDict(hunt)
+}
+
+func (self FederatedHuntStopFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "federated_hunt_stop",
+		Doc:     "Stop a federated hunt on all peers and fetch the final results.",
+		ArgType: type_map.AddType(scope, &FederatedHuntStopFunctionArgs{}),
+	}
+}
+
+type FederatedHuntsPluginArgs struct {
+	HuntId string `vfilter:"optional,field=hunt_id,doc=Only show this hunt"`
+}
+
+type FederatedHuntsPlugin struct{}
+
+func (self FederatedHuntsPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("federated_hunts: %v", err)
+			return
+		}
+
+		arg := &FederatedHuntsPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("federated_hunts: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		hunts, err := federation.ListHunts(config_obj)
+		if err != nil {
+			scope.Log("federated_hunts: %v", err)
+			return
+		}
+
+		for _, hunt := range hunts {
+			if arg.HuntId != "" && hunt.HuntId != arg.HuntId {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- json.ConvertProtoToOrderedDict(hunt):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self FederatedHuntsPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "federated_hunts",
+		Doc:     "List the federated hunts and their state on each peer.",
+		ArgType: type_map.AddType(scope, &FederatedHuntsPluginArgs{}),
+	}
+}
+
+type FederatedHuntResultsPluginArgs struct {
+	HuntId   string `vfilter:"required,field=hunt_id,doc=The federated hunt to read"`
+	Artifact string `vfilter:"optional,field=artifact,doc=The artifact source to read (default the first one)"`
+	Peer     string `vfilter:"optional,field=peer,doc=Only read the results from this peer"`
+}
+
+type FederatedHuntResultsPlugin struct{}
+
+func (self FederatedHuntResultsPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("federated_hunt_results: %v", err)
+			return
+		}
+
+		arg := &FederatedHuntResultsPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("federated_hunt_results: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		if arg.Artifact == "" {
+			hunt, err := federation.GetHunt(config_obj, arg.HuntId)
+			if err != nil {
+				scope.Log("federated_hunt_results: %v", err)
+				return
+			}
+
+			if len(hunt.ArtifactSources) == 0 {
+				scope.Log("federated_hunt_results: no results yet")
+				return
+			}
+			arg.Artifact = hunt.ArtifactSources[0]
+		}
+
+		rows, err := federation.ReadResults(ctx, config_obj,
+			arg.HuntId, arg.Artifact, arg.Peer)
+		if err != nil {
+			scope.Log("federated_hunt_results: %v", err)
+			return
+		}
+
+		for row := range rows {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self FederatedHuntResultsPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "federated_hunt_results",
+		Doc:     "Read the merged results of a federated hunt.",
+		ArgType: type_map.AddType(scope, &FederatedHuntResultsPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&FederatedQueryPlugin{})
+	vql_subsystem.RegisterFunction(&FederatedHuntFunction{})
+	vql_subsystem.RegisterFunction(&FederatedHuntStopFunction{})
+	vql_subsystem.RegisterPlugin(&FederatedHuntsPlugin{})
+	vql_subsystem.RegisterPlugin(&FederatedHuntResultsPlugin{})
+}
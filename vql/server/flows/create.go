@@ -22,10 +22,12 @@ import (
 	"strings"
 
 	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
 	"www.velocidex.com/golang/velociraptor/acls"
 	"www.velocidex.com/golang/velociraptor/artifacts"
 	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
 	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/services"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
@@ -181,6 +183,13 @@ func (self *ScheduleCollectionFunction) Call(ctx context.Context,
 		return vfilter.Null{}
 	}
 
+	logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+		"ScheduleFlow", logrus.Fields{
+			"client":  arg.ClientId,
+			"flow_id": flow_id,
+			"details": json.MustMarshalString(request),
+		})
+
 	result.FlowId = flow_id
 	return json.ConvertProtoToOrderedDict(result)
 }
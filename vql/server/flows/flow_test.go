@@ -2,6 +2,7 @@ package flows_test
 
 import (
 	"context"
+	"io/ioutil"
 	"strings"
 	"testing"
 	"time"
@@ -11,6 +12,8 @@ import (
 	"github.com/sebdah/goldie"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"www.velocidex.com/golang/velociraptor/acls"
+	acl_proto "www.velocidex.com/golang/velociraptor/acls/proto"
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
 	"www.velocidex.com/golang/velociraptor/datastore"
 	"www.velocidex.com/golang/velociraptor/file_store"
@@ -20,9 +23,13 @@ import (
 	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/paths"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
 	"www.velocidex.com/golang/velociraptor/vql/server/flows"
+	"www.velocidex.com/golang/velociraptor/vql/tools/minidump"
 	"www.velocidex.com/golang/velociraptor/vtesting"
+	"www.velocidex.com/golang/vfilter"
 )
 
 var (
@@ -109,6 +116,159 @@ func (self *FilestoreTestSuite) TestEnumerateFlow() {
 	goldie.Assert(self.T(), "TestEnumerateFlow", json.MustMarshalIndent(result))
 }
 
+func (self *FilestoreTestSuite) TestShellRecording() {
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+	flow_pm := paths.NewFlowPathManager(self.client_id, self.flow_id)
+
+	fd, err := file_store_factory.WriteFile(flow_pm.ShellRecording())
+	assert.NoError(self.T(), err)
+	fd.Write([]byte(`{"Timestamp":90000000,"Type":"i","Data":"id\n"}
+{"Timestamp":91500000,"Type":"o","Data":"uid=0(root)\n"}
+`))
+	fd.Close()
+
+	manager, _ := services.GetRepositoryManager(self.ConfigObj)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
+
+	run := func(acl_manager vql_subsystem.ACLManager,
+		asciicast bool) []vfilter.Row {
+		scope := manager.BuildScope(services.ScopeBuilder{
+			Config:     self.ConfigObj,
+			ACLManager: acl_manager,
+			Logger: logging.NewPlainLogger(self.ConfigObj,
+				&logging.FrontendComponent),
+			Env: ordereddict.NewDict(),
+		})
+		defer scope.Close()
+
+		return vtesting.RunPlugin(flows.ShellRecordingPlugin{}.Call(ctx, scope,
+			ordereddict.NewDict().
+				Set("flow_id", self.flow_id).
+				Set("client_id", self.client_id).
+				Set("asciicast", asciicast)))
+	}
+
+	// Even administrators need the explicit permission.
+	result := run(acl_managers.NewRoleACLManager(
+		self.ConfigObj, "administrator"), false)
+	assert.Equal(self.T(), 0, len(result))
+
+	token := &acl_proto.ApiClientACL{}
+	assert.NoError(self.T(), acls.SetTokenPermission(
+		token, "READ_SHELL_RECORDINGS"))
+	acl_manager := &acl_managers.RoleACLManager{Token: token}
+
+	result = run(acl_manager, false)
+	assert.Equal(self.T(), 2, len(result))
+	assert.Equal(self.T(), `{"Time":1.5,"Timestamp":91500000,"Type":"o","Data":"uid=0(root)\n"}`,
+		json.MustMarshalString(result[1]))
+
+	result = run(acl_manager, true)
+	assert.Equal(self.T(), 1, len(result))
+	cast, _ := result[0].(*ordereddict.Dict).GetString("Cast")
+	assert.Equal(self.T(), `{"version":2,"width":80,"height":24,"timestamp":90,"title":"C.123/F.1234"}
+[0,"i","id\r\n"]
+[1.5,"o","uid=0(root)\r\n"]
+`, cast)
+}
+
+func (self *FilestoreTestSuite) TestAssembleMinidumps() {
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+	flow_pm := paths.NewFlowPathManager(self.client_id, self.flow_id)
+
+	// Two regions uploaded back to back as a sparse file.
+	memory := flow_pm.GetUploadsFile("process", "/test/memory")
+	fd, err := file_store_factory.WriteFile(memory.Path())
+	assert.NoError(self.T(), err)
+	fd.Write([]byte(strings.Repeat("A", 0x10) + strings.Repeat("B", 0x20)))
+	fd.Close()
+
+	fd, err = file_store_factory.WriteFile(memory.IndexPath())
+	assert.NoError(self.T(), err)
+	fd.Write([]byte(json.MustMarshalString(&actions_proto.Index{
+		Ranges: []*actions_proto.Range{
+			{FileOffset: 0, OriginalOffset: 0, FileLength: 0, Length: 0x1000},
+			{FileOffset: 0, OriginalOffset: 0x1000, FileLength: 0x10, Length: 0x10},
+			{FileOffset: 0x10, OriginalOffset: 0x2000, FileLength: 0x20, Length: 0x20},
+		},
+	})))
+	fd.Close()
+
+	manifest := flow_pm.GetUploadsFile("process", "/test/regions.json")
+	fd, err = file_store_factory.WriteFile(manifest.Path())
+	assert.NoError(self.T(), err)
+	fd.Write([]byte(json.MustMarshalString(&minidump.Manifest{
+		Version:  minidump.MANIFEST_VERSION,
+		Pid:      10,
+		Name:     "test.exe",
+		OS:       "windows",
+		Arch:     "amd64",
+		Accessor: "process",
+		Memory:   "/test/memory",
+		Dump:     "/test/test.dmp",
+		Regions: []minidump.Region{
+			{Address: 0x1000, Size: 0x10},
+			{Address: 0x2000, Size: 0x20},
+		},
+	})))
+	fd.Close()
+
+	journal, err := services.GetJournal(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	assert.NoError(self.T(), journal.AppendToResultSet(self.ConfigObj,
+		flow_pm.UploadMetadata(), []*ordereddict.Dict{
+			ordereddict.NewDict().
+				Set("vfs_path", memory.VisibleVFSPath()).
+				Set("_Components", memory.Path().Components()),
+			ordereddict.NewDict().
+				Set("vfs_path", manifest.VisibleVFSPath()).
+				Set("_Components", manifest.Path().Components()),
+		}))
+
+	manager, _ := services.GetRepositoryManager(self.ConfigObj)
+	scope := manager.BuildScope(services.ScopeBuilder{
+		Config:     self.ConfigObj,
+		ACLManager: acl_managers.NullACLManager{},
+		Logger: logging.NewPlainLogger(self.ConfigObj,
+			&logging.FrontendComponent),
+		Env: ordereddict.NewDict(),
+	})
+	defer scope.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
+
+	run := func() []vfilter.Row {
+		return vtesting.RunPlugin(flows.AssembleMinidumpsPlugin{}.Call(
+			ctx, scope, ordereddict.NewDict().
+				Set("flow_id", self.flow_id).
+				Set("client_id", self.client_id)))
+	}
+
+	result := run()
+	assert.Equal(self.T(), 1, len(result))
+
+	row := result[0].(*ordereddict.Dict)
+	assert.Equal(self.T(), int64(2), utils.GetInt64(row, "Regions"))
+
+	// The memory follows the streams at the end of the minidump.
+	fd_dump, err := file_store_factory.ReadFile(
+		flow_pm.GetUploadsFile("process", "/test/test.dmp").Path())
+	assert.NoError(self.T(), err)
+	data, err := ioutil.ReadAll(fd_dump)
+	fd_dump.Close()
+	assert.NoError(self.T(), err)
+
+	assert.Equal(self.T(), "MDMP", string(data[:4]))
+	assert.Equal(self.T(), int64(len(data)), utils.GetInt64(row, "Size"))
+	assert.True(self.T(), strings.HasSuffix(string(data),
+		strings.Repeat("A", 0x10)+strings.Repeat("B", 0x20)))
+
+	// Already assembled minidumps are skipped.
+	assert.Equal(self.T(), 0, len(run()))
+}
+
 func TestFilestorePlugin(t *testing.T) {
 	suite.Run(t, &FilestoreTestSuite{
 		client_id: "C.123",
@@ -0,0 +1,267 @@
+package flows
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/file_store/path_specs"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/tools/minidump"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type AssembleMinidumpsPluginArgs struct {
+	FlowId   string `vfilter:"required,field=flow_id,doc=The flow id containing the memory regions."`
+	ClientId string `vfilter:"required,field=client_id,doc=The client id of the flow."`
+}
+
+type AssembleMinidumpsPlugin struct{}
+
+func (self AssembleMinidumpsPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+	go func() {
+		defer close(output_chan)
+
+		// The minidumps are written into the flow like downloads.
+		err := vql_subsystem.CheckAccess(scope, acls.PREPARE_RESULTS)
+		if err != nil {
+			scope.Log("assemble_minidumps: %s", err)
+			return
+		}
+
+		arg := &AssembleMinidumpsPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("assemble_minidumps: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		path_manager := paths.NewFlowPathManager(arg.ClientId, arg.FlowId)
+		manifests, err := findManifests(ctx, config_obj, path_manager)
+		if err != nil {
+			scope.Log("assemble_minidumps: %v", err)
+			return
+		}
+
+		for _, manifest_path := range manifests {
+			row, err := assembleMinidump(config_obj,
+				arg.ClientId, arg.FlowId, path_manager, manifest_path)
+			if err != nil {
+				scope.Log("assemble_minidumps: %v: %v",
+					manifest_path.AsClientPath(), err)
+				continue
+			}
+
+			if row == nil {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+// Find all the memory region manifests uploaded in the flow.
+func findManifests(ctx context.Context,
+	config_obj *config_proto.Config,
+	path_manager *paths.FlowPathManager) ([]api.FSPathSpec, error) {
+	file_store_factory := file_store.GetFileStore(config_obj)
+	reader, err := result_sets.NewResultSetReader(
+		file_store_factory, path_manager.UploadMetadata())
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	result := []api.FSPathSpec{}
+	seen := make(map[string]bool)
+	for row := range reader.Rows(ctx) {
+		components, pres := row.GetStrings("_Components")
+		if !pres || len(components) == 0 ||
+			components[len(components)-1] != minidump.MANIFEST_NAME {
+			continue
+		}
+
+		key := strings.Join(components, "/")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		result = append(result, path_specs.NewUnsafeFilestorePath(
+			components...).SetType(api.PATH_TYPE_FILESTORE_ANY))
+	}
+
+	return result, nil
+}
+
+func readUploadIndex(file_store_factory api.FileStore,
+	path api.FSPathSpec) ([]minidump.MemoryRange, error) {
+	fd, err := file_store_factory.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	serialized, err := ioutil.ReadAll(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &actions_proto.Index{}
+	err = json.Unmarshal(serialized, index)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []minidump.MemoryRange{}
+	for _, rng := range index.Ranges {
+		if rng.FileLength == 0 {
+			continue
+		}
+		result = append(result, minidump.MemoryRange{
+			Address: uint64(rng.OriginalOffset),
+			Size:    uint64(rng.FileLength),
+		})
+	}
+	return result, nil
+}
+
+// Assemble the minidump described by the manifest. Returns nil if
+// the minidump was already assembled.
+func assembleMinidump(
+	config_obj *config_proto.Config,
+	client_id, flow_id string,
+	path_manager *paths.FlowPathManager,
+	manifest_path api.FSPathSpec) (*ordereddict.Dict, error) {
+	file_store_factory := file_store.GetFileStore(config_obj)
+
+	fd, err := file_store_factory.ReadFile(manifest_path)
+	if err != nil {
+		return nil, err
+	}
+	serialized, err := ioutil.ReadAll(fd)
+	fd.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &minidump.Manifest{}
+	err = json.Unmarshal(serialized, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Memory == "" || manifest.Dump == "" {
+		return nil, errors.New("Invalid manifest")
+	}
+
+	memory_file := path_manager.GetUploadsFile(
+		manifest.Accessor, manifest.Memory)
+	dump_file := path_manager.GetUploadsFile(
+		manifest.Accessor, manifest.Dump)
+
+	_, err = file_store_factory.StatFile(dump_file.Path())
+	if err == nil {
+		return nil, nil
+	}
+
+	ranges, err := readUploadIndex(file_store_factory, memory_file.IndexPath())
+	if err != nil {
+		return nil, err
+	}
+
+	memory_fd, err := file_store_factory.ReadFile(memory_file.Path())
+	if err != nil {
+		return nil, err
+	}
+	defer memory_fd.Close()
+
+	out_fd, err := file_store_factory.WriteFile(dump_file.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	err = out_fd.Truncate()
+	if err != nil {
+		out_fd.Close()
+		return nil, err
+	}
+
+	size, err := minidump.WriteMinidump(out_fd, manifest, ranges, memory_fd)
+	out_fd.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make the minidump visible in the flow's uploads.
+	journal, err := services.GetJournal(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	err = journal.AppendToResultSet(config_obj,
+		path_manager.UploadMetadata(),
+		[]*ordereddict.Dict{ordereddict.NewDict().
+			Set("Timestamp", utils.GetTime().Now().UTC().Unix()).
+			Set("started", utils.GetTime().Now().UTC().String()).
+			Set("vfs_path", dump_file.VisibleVFSPath()).
+			Set("_Components", dump_file.Path().Components()).
+			Set("file_size", size).
+			Set("uploaded_size", size),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return ordereddict.NewDict().
+		Set("ClientId", client_id).
+		Set("FlowId", flow_id).
+		Set("Pid", manifest.Pid).
+		Set("Name", manifest.Name).
+		Set("Regions", len(ranges)).
+		Set("Size", size).
+		Set("Dump", dump_file.VisibleVFSPath()).
+		Set("_Components", dump_file.Path().Components()), nil
+}
+
+func (self AssembleMinidumpsPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "assemble_minidumps",
+		Doc:     "Assemble memory regions uploaded by upload_memory_regions() into minidumps in the flow's uploads.",
+		ArgType: type_map.AddType(scope, &AssembleMinidumpsPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&AssembleMinidumpsPlugin{})
+}
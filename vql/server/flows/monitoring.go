@@ -22,6 +22,7 @@ import (
 
 	"github.com/Velocidex/ordereddict"
 	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/constants"
 	"www.velocidex.com/golang/velociraptor/file_store"
 	"www.velocidex.com/golang/velociraptor/paths"
 	artifact_paths "www.velocidex.com/golang/velociraptor/paths/artifacts"
@@ -76,6 +77,12 @@ func (self MonitoringPlugin) Call(
 			arg.Source = ""
 		}
 
+		// Scheduled client artifacts are stored with the events of
+		// the monitoring flow.
+		if arg.FlowId == "" {
+			arg.FlowId = constants.MONITORING_WELL_KNOWN_FLOW
+		}
+
 		path_manager, err := artifact_paths.NewArtifactPathManager(
 			config_obj, arg.ClientId, arg.FlowId, arg.Artifact)
 		if err != nil {
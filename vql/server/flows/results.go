@@ -80,6 +80,8 @@ type SourcePluginArgs struct {
 
 	StartRow int64 `vfilter:"optional,field=start_row,doc=Start reading the result set from this row"`
 	Limit    int64 `vfilter:"optional,field=count,doc=Maximum number of clients to fetch (default unlimited)'"`
+
+	Columns []string `vfilter:"optional,field=columns,doc=Only read these columns from each row (default all columns)"`
 }
 
 type SourcePlugin struct{}
@@ -151,6 +153,10 @@ func (self SourcePlugin) Call(
 			scope.Log("source: %v", err)
 			return
 		}
+		defer result_set_reader.Close()
+
+		result_set_reader = result_sets.ProjectColumns(
+			result_set_reader, arg.Columns)
 
 		if arg.StartRow > 0 {
 			err = result_set_reader.SeekToRow(arg.StartRow)
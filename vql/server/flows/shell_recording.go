@@ -0,0 +1,158 @@
+package flows
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type ShellRecordingPluginArgs struct {
+	FlowId    string `vfilter:"required,field=flow_id,doc=The flow id to read."`
+	ClientId  string `vfilter:"required,field=client_id,doc=The client id to extract"`
+	Asciicast bool   `vfilter:"optional,field=asciicast,doc=Emit a single row with the recording in asciicast v2 format."`
+}
+
+type ShellRecordingPlugin struct{}
+
+func (self ShellRecordingPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+	go func() {
+		defer close(output_chan)
+
+		// Recordings are not covered by READ_RESULTS - they need
+		// their own permission.
+		err := vql_subsystem.CheckAccess(scope, acls.READ_SHELL_RECORDINGS)
+		if err != nil {
+			scope.Log("shell_recording: %s", err)
+			return
+		}
+
+		arg := &ShellRecordingPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("shell_recording: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		path_manager := paths.NewFlowPathManager(arg.ClientId, arg.FlowId)
+		file_store_factory := file_store.GetFileStore(config_obj)
+		rs_reader, err := result_sets.NewResultSetReader(
+			file_store_factory, path_manager.ShellRecording())
+		if err != nil {
+			scope.Log("shell_recording: %v", err)
+			return
+		}
+		defer rs_reader.Close()
+
+		logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+			"ReadShellRecording", logrus.Fields{
+				"client":  arg.ClientId,
+				"flow_id": arg.FlowId,
+			})
+
+		// Event times are relative to the start of the session.
+		var start int64
+		count := 0
+		events := []*ordereddict.Dict{}
+		for row := range rs_reader.Rows(ctx) {
+			timestamp, _ := row.GetInt64("Timestamp")
+			event_type, _ := row.GetString("Type")
+			data, _ := row.GetString("Data")
+
+			if count == 0 {
+				start = timestamp
+			}
+			count++
+
+			event := ordereddict.NewDict().
+				Set("Time", float64(timestamp-start)/1e6).
+				Set("Timestamp", timestamp).
+				Set("Type", event_type).
+				Set("Data", data)
+
+			if arg.Asciicast {
+				events = append(events, event)
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- event:
+			}
+		}
+
+		if arg.Asciicast && len(events) > 0 {
+			select {
+			case <-ctx.Done():
+			case output_chan <- ordereddict.NewDict().
+				Set("ClientId", arg.ClientId).
+				Set("FlowId", arg.FlowId).
+				Set("Cast", formatAsciicast(
+					arg.ClientId, arg.FlowId, start, events)):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+// Render the events in the asciicast v2 format: a header object
+// followed by one [time, type, data] array per line.
+func formatAsciicast(client_id, flow_id string,
+	start int64, events []*ordereddict.Dict) string {
+	header := ordereddict.NewDict().
+		Set("version", 2).
+		Set("width", 80).
+		Set("height", 24).
+		Set("timestamp", start/1000000).
+		Set("title", client_id+"/"+flow_id)
+
+	lines := []string{json.MustMarshalString(header)}
+	for _, event := range events {
+		time, _ := event.Get("Time")
+		event_type, _ := event.GetString("Type")
+		data, _ := event.GetString("Data")
+
+		// Terminals expect CRLF line endings.
+		data = strings.ReplaceAll(data, "\r\n", "\n")
+		data = strings.ReplaceAll(data, "\n", "\r\n")
+
+		lines = append(lines, json.MustMarshalString(
+			[]interface{}{time, event_type, data}))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (self ShellRecordingPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "shell_recording",
+		Doc:     "Retrieve the recorded input and output of a remote shell flow. Requires the READ_SHELL_RECORDINGS permission.",
+		ArgType: type_map.AddType(scope, &ShellRecordingPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&ShellRecordingPlugin{})
+}
@@ -19,6 +19,7 @@ package hunts
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/Velocidex/ordereddict"
@@ -40,21 +41,26 @@ import (
 )
 
 type ScheduleHuntFunctionArg struct {
-	Description   string           `vfilter:"optional,field=description,doc=Description of the hunt"`
-	Artifacts     []string         `vfilter:"required,field=artifacts,doc=A list of artifacts to collect"`
-	Expires       vfilter.LazyExpr `vfilter:"optional,field=expires,doc=A time for expiry (e.g. now() + 1800)"`
-	Spec          vfilter.Any      `vfilter:"optional,field=spec,doc=Parameters to apply to the artifacts"`
-	Timeout       uint64           `vfilter:"optional,field=timeout,doc=Set query timeout (default 10 min)"`
-	OpsPerSecond  float64          `vfilter:"optional,field=ops_per_sec,doc=Set query ops_per_sec value"`
-	CpuLimit      float64          `vfilter:"optional,field=cpu_limit,doc=Set query ops_per_sec value"`
-	IopsLimit     float64          `vfilter:"optional,field=iops_limit,doc=Set query ops_per_sec value"`
-	MaxRows       uint64           `vfilter:"optional,field=max_rows,doc=Max number of rows to fetch"`
-	MaxBytes      uint64           `vfilter:"optional,field=max_bytes,doc=Max number of bytes to upload"`
-	Pause         bool             `vfilter:"optional,field=pause,doc=If specified the new hunt will be in the paused state"`
-	IncludeLabels []string         `vfilter:"optional,field=include_labels,doc=If specified only include these labels"`
-	ExcludeLabels []string         `vfilter:"optional,field=exclude_labels,doc=If specified exclude these labels"`
-	OS            string           `vfilter:"optional,field=os,doc=If specified target this OS"`
-	OrgIds        []string         `vfilter:"optional,field=org_id,doc=If set the collection will be started in the specified orgs."`
+	Description      string           `vfilter:"optional,field=description,doc=Description of the hunt"`
+	Artifacts        []string         `vfilter:"required,field=artifacts,doc=A list of artifacts to collect"`
+	Expires          vfilter.LazyExpr `vfilter:"optional,field=expires,doc=A time for expiry (e.g. now() + 1800)"`
+	Spec             vfilter.Any      `vfilter:"optional,field=spec,doc=Parameters to apply to the artifacts"`
+	Timeout          uint64           `vfilter:"optional,field=timeout,doc=Set query timeout (default 10 min)"`
+	OpsPerSecond     float64          `vfilter:"optional,field=ops_per_sec,doc=Set query ops_per_sec value"`
+	CpuLimit         float64          `vfilter:"optional,field=cpu_limit,doc=Set query ops_per_sec value"`
+	IopsLimit        float64          `vfilter:"optional,field=iops_limit,doc=Set query ops_per_sec value"`
+	MaxRows          uint64           `vfilter:"optional,field=max_rows,doc=Max number of rows to fetch"`
+	MaxBytes         uint64           `vfilter:"optional,field=max_bytes,doc=Max number of bytes to upload"`
+	Pause            bool             `vfilter:"optional,field=pause,doc=If specified the new hunt will be in the paused state"`
+	IncludeLabels    []string         `vfilter:"optional,field=include_labels,doc=If specified only include these labels"`
+	ExcludeLabels    []string         `vfilter:"optional,field=exclude_labels,doc=If specified exclude these labels"`
+	ClientGroups     []string         `vfilter:"optional,field=client_groups,doc=If specified only include members of these saved client groups"`
+	OS               string           `vfilter:"optional,field=os,doc=If specified target this OS"`
+	OrgIds           []string         `vfilter:"optional,field=org_id,doc=If set the collection will be started in the specified orgs."`
+	ClientsPerMinute uint64           `vfilter:"optional,field=clients_per_minute,doc=If set schedule at most this many clients per minute"`
+	MaxErrorRate     float64          `vfilter:"optional,field=max_error_rate,doc=Pause the hunt when this percentage of collections failed"`
+	MaxRetries       uint64           `vfilter:"optional,field=max_retries,doc=Reschedule a client's failed or timed out collection up to this many times"`
+	RetryBackoff     uint64           `vfilter:"optional,field=retry_backoff,doc=Seconds to wait before the first retry, doubled for each further retry (default 300)"`
 }
 
 type ScheduleHuntFunction struct{}
@@ -155,57 +161,32 @@ func (self *ScheduleHuntFunction) Call(ctx context.Context,
 		State:           state,
 	}
 
-	if len(arg.IncludeLabels) > 0 {
-		if arg.OS != "" {
-			scope.Log("hunt: Both OS and label conditions set, ignoring OS")
-		}
-
-		hunt_request.Condition = &api_proto.HuntCondition{
-			UnionField: &api_proto.HuntCondition_Labels{
-				Labels: &api_proto.HuntLabelCondition{
-					Label: arg.IncludeLabels,
-				},
-			},
+	if arg.ClientsPerMinute > 0 || arg.MaxErrorRate > 0 {
+		hunt_request.Scheduling = &api_proto.HuntScheduling{
+			ClientsPerMinute: arg.ClientsPerMinute,
+			MaxErrorRate:     arg.MaxErrorRate,
 		}
+	}
 
-		if len(arg.ExcludeLabels) > 0 {
-			hunt_request.Condition.ExcludedLabels = &api_proto.HuntLabelCondition{
-				Label: arg.ExcludeLabels,
-			}
+	if arg.MaxRetries > 0 {
+		hunt_request.Retry = &api_proto.HuntRetryPolicy{
+			MaxRetries:     arg.MaxRetries,
+			BackoffSeconds: arg.RetryBackoff,
 		}
 	}
 
-	switch arg.OS {
-	case "":
-		// Not specified
-	case "linux":
-		hunt_request.Condition = &api_proto.HuntCondition{
-			UnionField: &api_proto.HuntCondition_Os{
-				Os: &api_proto.HuntOsCondition{
-					Os: api_proto.HuntOsCondition_LINUX,
-				},
-			},
-		}
-	case "windows":
-		hunt_request.Condition = &api_proto.HuntCondition{
-			UnionField: &api_proto.HuntCondition_Os{
-				Os: &api_proto.HuntOsCondition{
-					Os: api_proto.HuntOsCondition_WINDOWS,
-				},
-			},
-		}
+	if len(arg.IncludeLabels) > 0 && arg.OS != "" {
+		scope.Log("hunt: Both OS and label conditions set, ignoring OS")
+	}
 
-	case "darwin":
-		hunt_request.Condition = &api_proto.HuntCondition{
-			UnionField: &api_proto.HuntCondition_Os{
-				Os: &api_proto.HuntOsCondition{
-					Os: api_proto.HuntOsCondition_OSX,
-				},
-			},
-		}
+	if len(arg.ClientGroups) > 0 && (len(arg.IncludeLabels) > 0 || arg.OS != "") {
+		scope.Log("hunt: Client groups set, ignoring label and OS conditions")
+	}
 
-	default:
-		scope.Log("hunt: OS condition invalid %v (should be linux, windows, darwin)", arg.OS)
+	hunt_request.Condition, err = makeHuntCondition(
+		arg.IncludeLabels, arg.ExcludeLabels, arg.ClientGroups, arg.OS)
+	if err != nil {
+		scope.Log("hunt: %v", err)
 		return vfilter.Null{}
 	}
 
@@ -266,6 +247,80 @@ func (self *ScheduleHuntFunction) Call(ctx context.Context,
 		Set("Request", hunt_request)
 }
 
+// Build the hunt condition from the VQL args. Returns nil if the
+// hunt should run on all clients.
+func makeHuntCondition(include_labels, exclude_labels, client_groups []string,
+	os string) (*api_proto.HuntCondition, error) {
+	var result *api_proto.HuntCondition
+
+	if len(include_labels) > 0 {
+		result = &api_proto.HuntCondition{
+			UnionField: &api_proto.HuntCondition_Labels{
+				Labels: &api_proto.HuntLabelCondition{
+					Label: include_labels,
+				},
+			},
+		}
+	}
+
+	switch os {
+	case "":
+		// Not specified
+	case "linux":
+		result = &api_proto.HuntCondition{
+			UnionField: &api_proto.HuntCondition_Os{
+				Os: &api_proto.HuntOsCondition{
+					Os: api_proto.HuntOsCondition_LINUX,
+				},
+			},
+		}
+	case "windows":
+		result = &api_proto.HuntCondition{
+			UnionField: &api_proto.HuntCondition_Os{
+				Os: &api_proto.HuntOsCondition{
+					Os: api_proto.HuntOsCondition_WINDOWS,
+				},
+			},
+		}
+
+	case "darwin":
+		result = &api_proto.HuntCondition{
+			UnionField: &api_proto.HuntCondition_Os{
+				Os: &api_proto.HuntOsCondition{
+					Os: api_proto.HuntOsCondition_OSX,
+				},
+			},
+		}
+
+	default:
+		return nil, fmt.Errorf(
+			"OS condition invalid %v (should be linux, windows, darwin)", os)
+	}
+
+	// Client groups can express label and OS conditions themselves
+	// so they take precedence.
+	if len(client_groups) > 0 {
+		result = &api_proto.HuntCondition{
+			UnionField: &api_proto.HuntCondition_Groups{
+				Groups: &api_proto.HuntGroupCondition{
+					Group: client_groups,
+				},
+			},
+		}
+	}
+
+	if len(exclude_labels) > 0 {
+		if result == nil {
+			result = &api_proto.HuntCondition{}
+		}
+		result.ExcludedLabels = &api_proto.HuntLabelCondition{
+			Label: exclude_labels,
+		}
+	}
+
+	return result, nil
+}
+
 func (self ScheduleHuntFunction) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
 	return &vfilter.FunctionInfo{
 		Name:    "hunt",
@@ -132,6 +132,7 @@ type HuntResultsPluginArgs struct {
 	Source   string `vfilter:"optional,field=source,doc=An optional source within the artifact."`
 	HuntId   string `vfilter:"required,field=hunt_id,doc=The hunt id to read."`
 	Brief    bool   `vfilter:"optional,field=brief,doc=If set we return less columns."`
+	Merged   bool   `vfilter:"optional,field=merged,doc=If set read the hunt's merged result set instead of each collection."`
 }
 
 type HuntResultsPlugin struct{}
@@ -216,6 +217,28 @@ func (self HuntResultsPlugin) Call(
 			arg.Artifact += "/" + arg.Source
 		}
 
+		// The hunt manager already merged the results of all
+		// completed collections with the extra columns.
+		if arg.Merged {
+			file_store_factory := file_store.GetFileStore(config_obj)
+			reader, err := result_sets.NewResultSetReader(file_store_factory,
+				paths.NewHuntPathManager(arg.HuntId).Results(arg.Artifact))
+			if err != nil {
+				scope.Log("hunt_results: %v", err)
+				return
+			}
+			defer reader.Close()
+
+			for row := range reader.Rows(ctx) {
+				select {
+				case <-ctx.Done():
+					return
+				case output_chan <- row:
+				}
+			}
+			return
+		}
+
 		indexer, err := services.GetIndexer(config_obj)
 		if err != nil {
 			return
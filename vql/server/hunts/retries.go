@@ -0,0 +1,102 @@
+package hunts
+
+import (
+	"context"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/services/hunt_manager"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type HuntRetriesPluginArgs struct {
+	HuntId   string `vfilter:"required,field=hunt_id,doc=The hunt id to inspect."`
+	ClientId string `vfilter:"optional,field=client_id,doc=If set only show the retries of this client."`
+}
+
+type HuntRetriesPlugin struct{}
+
+func (self HuntRetriesPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("hunt_retries: %s", err)
+			return
+		}
+
+		arg := &HuntRetriesPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("hunt_retries: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		var records []*api_proto.HuntClientRetries
+		if arg.ClientId != "" {
+			record, err := hunt_manager.GetClientRetries(
+				config_obj, arg.HuntId, arg.ClientId)
+			if err != nil {
+				scope.Log("hunt_retries: %v", err)
+				return
+			}
+			records = append(records, record)
+
+		} else {
+			records, err = hunt_manager.ListClientRetries(config_obj, arg.HuntId)
+			if err != nil {
+				scope.Log("hunt_retries: %v", err)
+				return
+			}
+		}
+
+		for _, record := range records {
+			for idx, attempt := range record.Attempts {
+				result := ordereddict.NewDict().
+					Set("HuntId", record.HuntId).
+					Set("ClientId", record.ClientId).
+					Set("Attempt", idx+1).
+					Set("FlowId", attempt.FlowId).
+					Set("Error", attempt.Error).
+					Set("FailedTime", time.Unix(int64(attempt.FailedTime), 0).UTC()).
+					Set("RetryTime", time.Unix(int64(attempt.RetryTime), 0).UTC()).
+					Set("RetryFlowId", attempt.RetryFlowId)
+
+				select {
+				case <-ctx.Done():
+					return
+				case output_chan <- result:
+				}
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self HuntRetriesPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "hunt_retries",
+		Doc:     "Retrieve the retry history of failed collections in a hunt.",
+		ArgType: type_map.AddType(scope, &HuntRetriesPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&HuntRetriesPlugin{})
+}
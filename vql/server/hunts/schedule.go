@@ -0,0 +1,237 @@
+package hunts
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/hunt_scheduler"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type ScheduleRecurringHuntFunctionArg struct {
+	ScheduleId string `vfilter:"optional,field=schedule_id,doc=Update an existing schedule"`
+	HuntId     string `vfilter:"optional,field=hunt_id,doc=The hunt to re-run (required for new schedules)"`
+	Cron       string `vfilter:"optional,field=cron,doc=When to run the hunt in cron format (e.g. '0 3 * * mon'), times are UTC"`
+	RunExpiry  uint64 `vfilter:"optional,field=run_expiry,doc=Each run expires after this many seconds"`
+	Disabled   bool   `vfilter:"optional,field=disabled,doc=If set the schedule will not run"`
+}
+
+type ScheduleRecurringHuntFunction struct{}
+
+func (self *ScheduleRecurringHuntFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.COLLECT_CLIENT)
+	if err != nil {
+		scope.Log("hunt_schedule: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &ScheduleRecurringHuntFunctionArg{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("hunt_schedule: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	var hunt_obj *api_proto.Hunt
+	if arg.HuntId != "" {
+		hunt_dispatcher, err := services.GetHuntDispatcher(config_obj)
+		if err != nil {
+			scope.Log("hunt_schedule: %v", err)
+			return vfilter.Null{}
+		}
+
+		var pres bool
+		hunt_obj, pres = hunt_dispatcher.GetHunt(arg.HuntId)
+		if !pres {
+			scope.Log("hunt_schedule: hunt %v not found", arg.HuntId)
+			return vfilter.Null{}
+		}
+		hunt_obj = proto.Clone(hunt_obj).(*api_proto.Hunt)
+		hunt_obj.Stats = nil
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+	var schedule *api_proto.HuntSchedule
+
+	if arg.ScheduleId == "" {
+		if hunt_obj == nil || arg.Cron == "" {
+			scope.Log("hunt_schedule: hunt_id and cron are required for new schedules")
+			return vfilter.Null{}
+		}
+
+		schedule = &api_proto.HuntSchedule{
+			ScheduleId: hunt_scheduler.NewScheduleId(),
+			Cron:       arg.Cron,
+			Hunt:       hunt_obj,
+			RunExpiry:  arg.RunExpiry,
+			Creator:    principal,
+			CreateTime: uint64(utils.GetTime().Now().Unix()),
+			Disabled:   arg.Disabled,
+		}
+		err = hunt_scheduler.SetSchedule(config_obj, schedule)
+
+	} else {
+		schedule, err = hunt_scheduler.ModifySchedule(config_obj, arg.ScheduleId,
+			func(schedule *api_proto.HuntSchedule) error {
+				if arg.Cron != "" {
+					schedule.Cron = arg.Cron
+				}
+				if hunt_obj != nil {
+					schedule.Hunt = hunt_obj
+				}
+				if arg.RunExpiry > 0 {
+					schedule.RunExpiry = arg.RunExpiry
+				}
+				schedule.Disabled = arg.Disabled
+				return nil
+			})
+	}
+
+	if err != nil {
+		scope.Log("hunt_schedule: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, principal, "hunt_schedule",
+		logrus.Fields{
+			"schedule_id": schedule.ScheduleId,
+			"details":     json.MustMarshalString(arg),
+		})
+
+	return json.ConvertProtoToOrderedDict(schedule)
+}
+
+func (self ScheduleRecurringHuntFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "hunt_schedule",
+		Doc:     "Re-run a hunt on a schedule.",
+		ArgType: type_map.AddType(scope, &ScheduleRecurringHuntFunctionArg{}),
+	}
+}
+
+type DeleteHuntScheduleFunctionArg struct {
+	ScheduleId string `vfilter:"required,field=schedule_id,doc=The schedule to delete"`
+}
+
+type DeleteHuntScheduleFunction struct{}
+
+func (self *DeleteHuntScheduleFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.COLLECT_CLIENT)
+	if err != nil {
+		scope.Log("hunt_schedule_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &DeleteHuntScheduleFunctionArg{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("hunt_schedule_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	err = hunt_scheduler.DeleteSchedule(config_obj, arg.ScheduleId)
+	if err != nil {
+		scope.Log("hunt_schedule_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+		"hunt_schedule_delete", logrus.Fields{
+			"schedule_id": arg.ScheduleId,
+		})
+
+	return arg.ScheduleId
+}
+
+func (self DeleteHuntScheduleFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "hunt_schedule_delete",
+		Doc:     "Delete a hunt schedule. Hunts it already launched are not affected.",
+		ArgType: type_map.AddType(scope, &DeleteHuntScheduleFunctionArg{}),
+	}
+}
+
+type HuntSchedulesPlugin struct{}
+
+func (self HuntSchedulesPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("hunt_schedules: %s", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		schedules, err := hunt_scheduler.ListSchedules(config_obj)
+		if err != nil {
+			scope.Log("hunt_schedules: %v", err)
+			return
+		}
+
+		for _, schedule := range schedules {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- json.ConvertProtoToOrderedDict(schedule):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self HuntSchedulesPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "hunt_schedules",
+		Doc:  "List all hunt schedules.",
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&ScheduleRecurringHuntFunction{})
+	vql_subsystem.RegisterFunction(&DeleteHuntScheduleFunction{})
+	vql_subsystem.RegisterPlugin(&HuntSchedulesPlugin{})
+}
@@ -0,0 +1,125 @@
+package hunts
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services/hunt_stacking"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type HuntStackPluginArgs struct {
+	HuntId   string `vfilter:"required,field=hunt_id,doc=The hunt id to read."`
+	Artifact string `vfilter:"optional,field=artifact,doc=Only show the stack of this artifact."`
+	Source   string `vfilter:"optional,field=source,doc=An optional source within the artifact."`
+	Column   string `vfilter:"optional,field=column,doc=Only show the values of this column."`
+	Refresh  bool   `vfilter:"optional,field=refresh,doc=Stack the hunt's current results again (e.g. while the hunt is running)."`
+}
+
+type HuntStackPlugin struct{}
+
+func (self HuntStackPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("hunt_stack: %s", err)
+			return
+		}
+
+		arg := &HuntStackPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("hunt_stack: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		if arg.Artifact != "" && arg.Source != "" {
+			arg.Artifact += "/" + arg.Source
+		}
+
+		// Hunts are stacked when they complete - otherwise stack
+		// them now.
+		summary, err := hunt_stacking.GetStackSummary(config_obj, arg.HuntId)
+		if err != nil || arg.Refresh {
+			err = vql_subsystem.CheckAccess(scope, acls.PREPARE_RESULTS)
+			if err != nil {
+				scope.Log("hunt_stack: %s", err)
+				return
+			}
+
+			summary, err = hunt_stacking.StackHunt(ctx, config_obj, arg.HuntId)
+			if err != nil {
+				scope.Log("hunt_stack: %v", err)
+				return
+			}
+		}
+
+		file_store_factory := file_store.GetFileStore(config_obj)
+		path_manager := paths.NewHuntPathManager(arg.HuntId)
+
+		for _, stack := range summary.Artifacts {
+			if arg.Artifact != "" && arg.Artifact != stack.Artifact {
+				continue
+			}
+
+			reader, err := result_sets.NewResultSetReader(
+				file_store_factory, path_manager.Stack(stack.Artifact))
+			if err != nil {
+				scope.Log("hunt_stack: %v", err)
+				continue
+			}
+
+			for row := range reader.Rows(ctx) {
+				column, _ := row.GetString("Column")
+				if arg.Column != "" && arg.Column != column {
+					continue
+				}
+
+				result := ordereddict.NewDict().Set("Artifact", stack.Artifact)
+				result.MergeFrom(row)
+
+				select {
+				case <-ctx.Done():
+					reader.Close()
+					return
+				case output_chan <- result:
+				}
+			}
+			reader.Close()
+		}
+	}()
+
+	return output_chan
+}
+
+func (self HuntStackPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "hunt_stack",
+		Doc:     "Show how many clients returned each value of each column of a hunt's results.",
+		ArgType: type_map.AddType(scope, &HuntStackPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&HuntStackPlugin{})
+}
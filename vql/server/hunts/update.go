@@ -0,0 +1,150 @@
+package hunts
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/artifacts"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type UpdateHuntFunctionArg struct {
+	HuntId        string   `vfilter:"required,field=hunt_id,doc=The hunt to update"`
+	State         string   `vfilter:"optional,field=state,doc=Change the hunt state (pause, resume or stop)"`
+	IncludeLabels []string `vfilter:"optional,field=include_labels,doc=Retarget the hunt to these labels"`
+	ExcludeLabels []string `vfilter:"optional,field=exclude_labels,doc=Retarget the hunt to exclude these labels"`
+	OS            string   `vfilter:"optional,field=os,doc=Retarget the hunt to this OS"`
+	ClientGroups  []string `vfilter:"optional,field=client_groups,doc=Retarget the hunt to members of these saved client groups"`
+}
+
+type UpdateHuntFunction struct{}
+
+func (self *UpdateHuntFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.COLLECT_CLIENT)
+	if err != nil {
+		scope.Log("hunt_update: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &UpdateHuntFunctionArg{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("hunt_update: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	hunt_dispatcher, err := services.GetHuntDispatcher(config_obj)
+	if err != nil {
+		scope.Log("hunt_update: %v", err)
+		return vfilter.Null{}
+	}
+
+	_, pres := hunt_dispatcher.GetHunt(arg.HuntId)
+	if !pres {
+		scope.Log("hunt_update: hunt %v not found", arg.HuntId)
+		return vfilter.Null{}
+	}
+
+	// Each modification is sent separately to the hunt manager.
+	var modifications []*api_proto.Hunt
+
+	if len(arg.IncludeLabels) > 0 || len(arg.ExcludeLabels) > 0 ||
+		len(arg.ClientGroups) > 0 || arg.OS != "" {
+		condition, err := makeHuntCondition(arg.IncludeLabels,
+			arg.ExcludeLabels, arg.ClientGroups, arg.OS)
+		if err != nil {
+			scope.Log("hunt_update: %v", err)
+			return vfilter.Null{}
+		}
+
+		modifications = append(modifications, &api_proto.Hunt{
+			HuntId:    arg.HuntId,
+			Condition: condition,
+		})
+	}
+
+	switch arg.State {
+	case "":
+	case "pause":
+		modifications = append(modifications, &api_proto.Hunt{
+			HuntId: arg.HuntId,
+			State:  api_proto.Hunt_PAUSED,
+		})
+
+	case "resume":
+		modifications = append(modifications, &api_proto.Hunt{
+			HuntId: arg.HuntId,
+			State:  api_proto.Hunt_RUNNING,
+		})
+
+	case "stop":
+		modifications = append(modifications, &api_proto.Hunt{
+			HuntId: arg.HuntId,
+			State:  api_proto.Hunt_STOPPED,
+		})
+
+	default:
+		scope.Log("hunt_update: state invalid %v (should be pause, resume, stop)",
+			arg.State)
+		return vfilter.Null{}
+	}
+
+	if len(modifications) == 0 {
+		scope.Log("hunt_update: nothing to update")
+		return vfilter.Null{}
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+	logging.LogAudit(config_obj, principal, "hunt_update",
+		logrus.Fields{
+			"hunt_id": arg.HuntId,
+			"details": json.MustMarshalString(arg),
+		})
+
+	acl_manager, ok := artifacts.GetACLManager(scope)
+	if !ok {
+		acl_manager = acl_managers.NullACLManager{}
+	}
+
+	for _, modification := range modifications {
+		err = hunt_dispatcher.ModifyHunt(
+			ctx, config_obj, acl_manager, modification, principal)
+		if err != nil {
+			scope.Log("hunt_update: %v", err)
+			return vfilter.Null{}
+		}
+	}
+
+	return arg.HuntId
+}
+
+func (self UpdateHuntFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "hunt_update",
+		Doc:     "Pause, resume, stop or retarget a hunt.",
+		ArgType: type_map.AddType(scope, &UpdateHuntFunctionArg{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&UpdateHuntFunction{})
+}
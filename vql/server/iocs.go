@@ -0,0 +1,139 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/iocs"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type IOCTablePluginArgs struct {
+	Name  string   `vfilter:"required,field=name,doc=The name of the IOC table"`
+	Types []string `vfilter:"optional,field=types,doc=Only return indicators of these types"`
+}
+
+type IOCTablePlugin struct{}
+
+func (self IOCTablePlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("ioc_table: %v", err)
+			return
+		}
+
+		arg := &IOCTablePluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("ioc_table: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		rows, err := iocs.ReadTable(ctx, config_obj, arg.Name)
+		if err != nil {
+			scope.Log("ioc_table: %v: %v", arg.Name, err)
+			return
+		}
+
+		for row := range rows {
+			if len(arg.Types) > 0 {
+				ioc_type, _ := row.GetString("Type")
+				if !utils.InString(arg.Types, ioc_type) {
+					continue
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self IOCTablePlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "ioc_table",
+		Doc:     "Read the indicators in a server side IOC table.",
+		ArgType: type_map.AddType(scope, &IOCTablePluginArgs{}),
+	}
+}
+
+type IOCTablesPlugin struct{}
+
+func (self IOCTablesPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("ioc_tables: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		tables, err := iocs.ListTables(config_obj)
+		if err != nil {
+			scope.Log("ioc_tables: %v", err)
+			return
+		}
+
+		for _, table := range tables {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- json.ConvertProtoToOrderedDict(table):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self IOCTablesPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "ioc_tables",
+		Doc:  "List the server side IOC tables.",
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&IOCTablePlugin{})
+	vql_subsystem.RegisterPlugin(&IOCTablesPlugin{})
+}
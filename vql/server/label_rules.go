@@ -0,0 +1,220 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/label_rules"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type LabelRuleFunctionArgs struct {
+	Name      string `vfilter:"required,field=name,doc=The name of the rule"`
+	Label     string `vfilter:"optional,field=label,doc=The label the rule maintains (required for new rules)"`
+	Condition string `vfilter:"optional,field=condition,doc=A VQL expression over the rows of clients() (required for new rules)"`
+	Disabled  bool   `vfilter:"optional,field=disabled,doc=If set the rule is not evaluated"`
+}
+
+type LabelRuleFunction struct{}
+
+func (self *LabelRuleFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.LABEL_CLIENT)
+	if err != nil {
+		scope.Log("label_rule: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &LabelRuleFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("label_rule: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+	rule, err := label_rules.ModifyRule(config_obj, arg.Name,
+		func(rule *api_proto.LabelRule) error {
+			if arg.Label != "" {
+				rule.Label = arg.Label
+			}
+			if arg.Condition != "" {
+				rule.Condition = arg.Condition
+			}
+			rule.Disabled = arg.Disabled
+			return nil
+		})
+
+	if errors.Is(err, os.ErrNotExist) {
+		rule = &api_proto.LabelRule{
+			Name:       arg.Name,
+			Label:      arg.Label,
+			Condition:  arg.Condition,
+			Disabled:   arg.Disabled,
+			Creator:    principal,
+			CreateTime: uint64(utils.GetTime().Now().Unix()),
+		}
+		err = label_rules.SetRule(config_obj, rule)
+	}
+
+	if err != nil {
+		scope.Log("label_rule: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, principal, "label_rule",
+		logrus.Fields{
+			"name":    arg.Name,
+			"details": json.MustMarshalString(arg),
+		})
+
+	// Apply the rule right away rather than waiting for the next
+	// periodic run.
+	if !rule.Disabled {
+		rule, err = label_rules.ApplyRule(ctx, config_obj, rule)
+		if err != nil {
+			scope.Log("label_rule: %v", err)
+			return vfilter.Null{}
+		}
+	}
+
+	return json.ConvertProtoToOrderedDict(rule)
+}
+
+func (self LabelRuleFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name: "label_rule",
+		Doc: "Create or update a rule that keeps a label on all " +
+			"clients matching a condition.",
+		ArgType: type_map.AddType(scope, &LabelRuleFunctionArgs{}),
+	}
+}
+
+type DeleteLabelRuleFunctionArgs struct {
+	Name string `vfilter:"required,field=name,doc=The rule to delete"`
+}
+
+type DeleteLabelRuleFunction struct{}
+
+func (self *DeleteLabelRuleFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.LABEL_CLIENT)
+	if err != nil {
+		scope.Log("label_rule_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &DeleteLabelRuleFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("label_rule_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	err = label_rules.DeleteRule(config_obj, arg.Name)
+	if err != nil {
+		scope.Log("label_rule_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+		"label_rule_delete", logrus.Fields{
+			"name": arg.Name,
+		})
+
+	return arg.Name
+}
+
+func (self DeleteLabelRuleFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name: "label_rule_delete",
+		Doc: "Delete a label rule. Clients keep the label until it " +
+			"is removed by hand.",
+		ArgType: type_map.AddType(scope, &DeleteLabelRuleFunctionArgs{}),
+	}
+}
+
+type LabelRulesPlugin struct{}
+
+func (self LabelRulesPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("label_rules: %s", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		rules, err := label_rules.ListRules(config_obj)
+		if err != nil {
+			scope.Log("label_rules: %v", err)
+			return
+		}
+
+		for _, rule := range rules {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- json.ConvertProtoToOrderedDict(rule):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self LabelRulesPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "label_rules",
+		Doc:  "List all label rules.",
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&LabelRuleFunction{})
+	vql_subsystem.RegisterFunction(&DeleteLabelRuleFunction{})
+	vql_subsystem.RegisterPlugin(&LabelRulesPlugin{})
+}
@@ -0,0 +1,144 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/misp"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type MISPSyncPluginArgs struct {
+	Feed string `vfilter:"optional,field=feed,doc=Only sync this feed (default all configured feeds)"`
+}
+
+type MISPSyncPlugin struct{}
+
+func (self MISPSyncPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.SERVER_ADMIN)
+		if err != nil {
+			scope.Log("misp_sync: %v", err)
+			return
+		}
+
+		arg := &MISPSyncPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("misp_sync: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+			"misp_sync", logrus.Fields{
+				"feed": arg.Feed,
+			})
+
+		// Report the feeds which synced even if others failed.
+		tables, err := misp.Sync(ctx, config_obj, arg.Feed)
+		if err != nil {
+			scope.Log("misp_sync: %v", err)
+		}
+
+		for _, table := range tables {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- json.ConvertProtoToOrderedDict(table):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self MISPSyncPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "misp_sync",
+		Doc:     "Sync the configured MISP feeds into their IOC tables now.",
+		ArgType: type_map.AddType(scope, &MISPSyncPluginArgs{}),
+	}
+}
+
+type MISPSightingFunctionArgs struct {
+	AttributeId string `vfilter:"optional,field=id,doc=The MISP attribute id that was seen"`
+	Value       string `vfilter:"optional,field=value,doc=The value that was seen (if the attribute id is not known)"`
+	Source      string `vfilter:"optional,field=source,doc=The source of the sighting (default from the config)"`
+}
+
+type MISPSightingFunction struct{}
+
+func (self *MISPSightingFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.SERVER_ADMIN)
+	if err != nil {
+		scope.Log("misp_sighting: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &MISPSightingFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("misp_sighting: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	err = misp.AddSighting(ctx, config_obj,
+		arg.AttributeId, arg.Value, arg.Source)
+	if err != nil {
+		scope.Log("misp_sighting: %v", err)
+		return false
+	}
+
+	logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+		"misp_sighting", logrus.Fields{
+			"id":    arg.AttributeId,
+			"value": arg.Value,
+		})
+
+	return true
+}
+
+func (self MISPSightingFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "misp_sighting",
+		Doc:     "Report a sighting of an indicator to MISP.",
+		ArgType: type_map.AddType(scope, &MISPSightingFunctionArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&MISPSyncPlugin{})
+	vql_subsystem.RegisterFunction(&MISPSightingFunction{})
+}
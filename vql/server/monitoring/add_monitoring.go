@@ -17,6 +17,7 @@ type AddClientMonitoringFunctionArgs struct {
 	Artifact   string           `vfilter:"required,field=artifact,doc=The name of the artifact to add"`
 	Parameters vfilter.LazyExpr `vfilter:"optional,field=parameters,doc=A dict of artifact parameters"`
 	Label      string           `vfilter:"optional,field=label,doc=Add this artifact to this label group (default all)"`
+	Group      string           `vfilter:"optional,field=client_group,doc=Add this artifact to the members of this saved client group"`
 }
 
 type AddClientMonitoringFunction struct{}
@@ -79,7 +80,7 @@ func (self AddClientMonitoringFunction) Call(
 
 	event_config := client_event_manager.GetClientMonitoringState()
 
-	label_config := getArtifactCollectorArgs(event_config, arg.Label)
+	label_config := getArtifactCollectorArgs(event_config, arg.Label, arg.Group)
 
 	// First remove the current artifact if it is there already
 	removeArtifact(label_config, arg.Artifact)
@@ -138,20 +139,26 @@ func (self AddClientMonitoringFunction) Info(scope vfilter.Scope, type_map *vfil
 }
 
 func getArtifactCollectorArgs(
-	config *flows_proto.ClientEventTable, label string) *flows_proto.ArtifactCollectorArgs {
-	if label == "" || label == "all" {
+	config *flows_proto.ClientEventTable,
+	label, client_group string) *flows_proto.ArtifactCollectorArgs {
+	if client_group != "" {
+		label = ""
+	} else if label == "" || label == "all" {
 		return config.Artifacts
 	}
 
 	for _, label_event := range config.LabelEvents {
-		if label_event.Label == label {
+		if label_event.Label == label &&
+			label_event.ClientGroup == client_group {
 			return label_event.Artifacts
 		}
 	}
 
 	// If we get here there is no label group yet so add it.
 	label_group := &flows_proto.LabelEvents{
-		Label: label, Artifacts: &flows_proto.ArtifactCollectorArgs{},
+		Label:       label,
+		ClientGroup: client_group,
+		Artifacts:   &flows_proto.ArtifactCollectorArgs{},
 	}
 
 	config.LabelEvents = append(config.LabelEvents, label_group)
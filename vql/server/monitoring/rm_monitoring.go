@@ -49,7 +49,7 @@ func (self RemoveClientMonitoringFunction) Call(
 	}
 	event_config := client_event_manager.GetClientMonitoringState()
 
-	label_config := getArtifactCollectorArgs(event_config, arg.Label)
+	label_config := getArtifactCollectorArgs(event_config, arg.Label, arg.Group)
 
 	// First remove the current artifact if it is there already
 	removeArtifact(label_config, arg.Artifact)
@@ -0,0 +1,91 @@
+package orgs
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type OrgSetArtifactPolicyFunctionArgs struct {
+	OrgId   string   `vfilter:"required,field=org,doc=The org ID to set the policy on."`
+	Share   []string `vfilter:"optional,field=share,doc=Regular expressions of the root org's custom artifacts visible in the org (default all)."`
+	Hide    []string `vfilter:"optional,field=hide,doc=Regular expressions of built in and root org artifacts hidden from the org."`
+	Protect bool     `vfilter:"optional,field=protect,doc=Prevent org artifacts from replacing visible root org artifacts."`
+}
+
+type OrgSetArtifactPolicyFunction struct{}
+
+func (self OrgSetArtifactPolicyFunction) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	// Org admins should not be able to see artifacts hidden from
+	// them.
+	err := vql_subsystem.CheckAccess(scope, acls.SERVER_ADMIN)
+	if err != nil {
+		scope.Log("org_set_artifact_policy: %s", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("org_set_artifact_policy: Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	arg := &OrgSetArtifactPolicyFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("org_set_artifact_policy: %s", err)
+		return vfilter.Null{}
+	}
+
+	org_manager, err := services.GetOrgManager()
+	if err != nil {
+		scope.Log("org_set_artifact_policy: %s", err)
+		return vfilter.Null{}
+	}
+
+	policy := &api_proto.OrgArtifactPolicy{
+		Share:                arg.Share,
+		Hide:                 arg.Hide,
+		ProtectRootArtifacts: arg.Protect,
+	}
+
+	err = org_manager.SetOrgArtifactPolicy(arg.OrgId, policy)
+	if err != nil {
+		scope.Log("org_set_artifact_policy: %s", err)
+		return vfilter.Null{}
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+	logging.LogAudit(config_obj, principal, "org_set_artifact_policy",
+		logrus.Fields{
+			"org_id":  arg.OrgId,
+			"details": json.MustMarshalString(policy),
+		})
+
+	return json.ConvertProtoToOrderedDict(policy)
+}
+
+func (self OrgSetArtifactPolicyFunction) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "org_set_artifact_policy",
+		Doc:     "Sets which built in and root org artifacts are visible in an org.",
+		ArgType: type_map.AddType(scope, &OrgSetArtifactPolicyFunctionArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&OrgSetArtifactPolicyFunction{})
+}
@@ -0,0 +1,157 @@
+package orgs
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/quotas"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type OrgSetQuotaFunctionArgs struct {
+	OrgId                string `vfilter:"required,field=org,doc=The org ID to set the quota on."`
+	MaxConcurrentHunts   uint64 `vfilter:"optional,field=max_concurrent_hunts,doc=Maximum number of hunts running at the same time (0 for unlimited)."`
+	MaxFilestoreBytes    uint64 `vfilter:"optional,field=max_filestore_bytes,doc=Maximum size of the org's file store (0 for unlimited)."`
+	MaxRowsPerDay        uint64 `vfilter:"optional,field=max_rows_per_day,doc=Maximum number of rows collected per day (0 for unlimited)."`
+	MaxNotebookCpuSec    uint64 `vfilter:"optional,field=max_notebook_cpu_sec,doc=Maximum seconds spent calculating notebook cells per day (0 for unlimited)."`
+	MaxServerConcurrency uint64 `vfilter:"optional,field=max_server_concurrency,doc=Maximum number of server artifact queries and notebook cells running at the same time (0 for unlimited)."`
+}
+
+type OrgSetQuotaFunction struct{}
+
+func (self OrgSetQuotaFunction) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	// Org admins should not be able to raise their own quota.
+	err := vql_subsystem.CheckAccess(scope, acls.SERVER_ADMIN)
+	if err != nil {
+		scope.Log("org_set_quota: %s", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("org_set_quota: Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	arg := &OrgSetQuotaFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("org_set_quota: %s", err)
+		return vfilter.Null{}
+	}
+
+	org_manager, err := services.GetOrgManager()
+	if err != nil {
+		scope.Log("org_set_quota: %s", err)
+		return vfilter.Null{}
+	}
+
+	quota := &api_proto.OrgQuota{
+		MaxConcurrentHunts:   arg.MaxConcurrentHunts,
+		MaxFilestoreBytes:    arg.MaxFilestoreBytes,
+		MaxRowsPerDay:        arg.MaxRowsPerDay,
+		MaxNotebookCpuSec:    arg.MaxNotebookCpuSec,
+		MaxServerConcurrency: arg.MaxServerConcurrency,
+	}
+
+	err = org_manager.SetOrgQuota(arg.OrgId, quota)
+	if err != nil {
+		scope.Log("org_set_quota: %s", err)
+		return vfilter.Null{}
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+	logging.LogAudit(config_obj, principal, "org_set_quota",
+		logrus.Fields{
+			"org_id":  arg.OrgId,
+			"details": json.MustMarshalString(quota),
+		})
+
+	return json.ConvertProtoToOrderedDict(quota)
+}
+
+func (self OrgSetQuotaFunction) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "org_set_quota",
+		Doc:     "Sets the resource quota of an org.",
+		ArgType: type_map.AddType(scope, &OrgSetQuotaFunctionArgs{}),
+	}
+}
+
+type OrgUsageFunctionArgs struct {
+	OrgId string `vfilter:"optional,field=org,doc=The org ID to report on (default the current org)."`
+}
+
+type OrgUsageFunction struct{}
+
+func (self OrgUsageFunction) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("org_usage: Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	arg := &OrgUsageFunctionArgs{}
+	err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("org_usage: %s", err)
+		return vfilter.Null{}
+	}
+
+	// Reporting on other orgs requires server admin.
+	permission := acls.READ_RESULTS
+	if arg.OrgId != "" && arg.OrgId != config_obj.OrgId {
+		permission = acls.SERVER_ADMIN
+	}
+
+	err = vql_subsystem.CheckAccess(scope, permission)
+	if err != nil {
+		scope.Log("org_usage: %s", err)
+		return vfilter.Null{}
+	}
+
+	if arg.OrgId != "" {
+		org_manager, err := services.GetOrgManager()
+		if err != nil {
+			scope.Log("org_usage: %s", err)
+			return vfilter.Null{}
+		}
+
+		config_obj, err = org_manager.GetOrgConfig(arg.OrgId)
+		if err != nil {
+			scope.Log("org_usage: %s", err)
+			return vfilter.Null{}
+		}
+	}
+
+	return json.ConvertProtoToOrderedDict(quotas.GetUsage(config_obj))
+}
+
+func (self OrgUsageFunction) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "org_usage",
+		Doc:     "Reports the resource usage and quota of an org.",
+		ArgType: type_map.AddType(scope, &OrgUsageFunctionArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&OrgSetQuotaFunction{})
+	vql_subsystem.RegisterFunction(&OrgUsageFunction{})
+}
@@ -5,10 +5,13 @@ import (
 	"strings"
 
 	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
 	"www.velocidex.com/golang/velociraptor/acls"
 	artifacts_proto "www.velocidex.com/golang/velociraptor/artifacts/proto"
 	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/services/artifact_tests"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 	"www.velocidex.com/golang/vfilter"
 	"www.velocidex.com/golang/vfilter/arg_parser"
@@ -79,6 +82,13 @@ func (self *ArtifactSetFunction) Call(ctx context.Context,
 
 	principal := vql_subsystem.GetPrincipal(scope)
 
+	// Artifacts with failing tests are not activated.
+	err = artifact_tests.CheckArtifact(ctx, config_obj, principal, arg.Definition)
+	if err != nil {
+		scope.Log("artifact_set: %v", err)
+		return vfilter.Null{}
+	}
+
 	definition, err = manager.SetArtifactFile(
 		config_obj, principal, arg.Definition, arg.Prefix)
 	if err != nil {
@@ -86,6 +96,12 @@ func (self *ArtifactSetFunction) Call(ctx context.Context,
 		return vfilter.Null{}
 	}
 
+	logging.LogAudit(config_obj, principal, "artifact_set",
+		logrus.Fields{
+			"artifact": definition.Name,
+			"details":  arg.Definition,
+		})
+
 	return json.ConvertProtoToOrderedDict(definition)
 }
 
@@ -167,6 +183,11 @@ func (self *ArtifactDeleteFunction) Call(ctx context.Context,
 		return vfilter.Null{}
 	}
 
+	logging.LogAudit(config_obj, principal, "artifact_delete",
+		logrus.Fields{
+			"artifact": arg.Name,
+		})
+
 	return arg.Name
 }
 
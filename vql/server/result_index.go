@@ -0,0 +1,164 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/services/result_index"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/functions"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type ResultSearchPluginArgs struct {
+	Query     string      `vfilter:"required,field=query,doc=The terms to search for. Rows must contain all of them."`
+	Substring bool        `vfilter:"optional,field=substring,doc=Match indexed terms containing the query (reads the entire index)."`
+	Artifact  string      `vfilter:"optional,field=artifact,doc=Only search the results of this artifact or artifact source."`
+	ClientId  string      `vfilter:"optional,field=client_id,doc=Only search the collections of this client."`
+	HuntId    string      `vfilter:"optional,field=hunt_id,doc=Only search the collections of this hunt."`
+	StartTime vfilter.Any `vfilter:"optional,field=start_time,doc=Only search collections created after this time."`
+	EndTime   vfilter.Any `vfilter:"optional,field=end_time,doc=Only search collections created before this time."`
+	Limit     int64       `vfilter:"optional,field=limit,doc=The most rows returned (default 1000)."`
+}
+
+type ResultSearchPlugin struct{}
+
+func (self ResultSearchPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("result_search: %v", err)
+			return
+		}
+
+		arg := &ResultSearchPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("result_search: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		options := result_index.SearchOptions{
+			Substring: arg.Substring,
+			Artifact:  arg.Artifact,
+			ClientId:  arg.ClientId,
+			HuntId:    arg.HuntId,
+			Limit:     int(arg.Limit),
+		}
+
+		if !utils.IsNil(arg.StartTime) {
+			options.StartTime, err = functions.TimeFromAny(scope, arg.StartTime)
+			if err != nil {
+				scope.Log("result_search: %v", err)
+				return
+			}
+		}
+
+		if !utils.IsNil(arg.EndTime) {
+			options.EndTime, err = functions.TimeFromAny(scope, arg.EndTime)
+			if err != nil {
+				scope.Log("result_search: %v", err)
+				return
+			}
+		}
+
+		rows, err := result_index.Search(ctx, config_obj, arg.Query, options)
+		if err != nil {
+			scope.Log("result_search: %v", err)
+			return
+		}
+
+		for _, row := range rows {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self ResultSearchPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "result_search",
+		Doc:     "Search the results of all collections in the result index.",
+		ArgType: type_map.AddType(scope, &ResultSearchPluginArgs{}),
+	}
+}
+
+type IndexResultsFunctionArgs struct {
+	ClientId string `vfilter:"required,field=client_id,doc=The client id of the flow."`
+	FlowId   string `vfilter:"required,field=flow_id,doc=The flow whose results are indexed."`
+}
+
+type IndexResultsFunction struct{}
+
+func (self IndexResultsFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.PREPARE_RESULTS)
+	if err != nil {
+		scope.Log("index_results: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &IndexResultsFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("index_results: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	count, err := result_index.IndexFlow(ctx, config_obj,
+		arg.ClientId, arg.FlowId)
+	if err != nil {
+		scope.Log("index_results: %v", err)
+		return vfilter.Null{}
+	}
+
+	return count
+}
+
+func (self IndexResultsFunction) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name: "index_results",
+		Doc: "Add the results of a flow to the result index. Flows are " +
+			"indexed when they complete if the result index is enabled - " +
+			"use this to index older flows.",
+		ArgType: type_map.AddType(scope, &IndexResultsFunctionArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&ResultSearchPlugin{})
+	vql_subsystem.RegisterFunction(&IndexResultsFunction{})
+}
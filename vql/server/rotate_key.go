@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/constants"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type RotateClientKeyFunctionArgs struct {
+	ClientId string `vfilter:"required,field=client_id,doc=The client to rotate."`
+}
+
+type RotateClientKeyFunction struct{}
+
+func (self *RotateClientKeyFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.COLLECT_CLIENT)
+	if err != nil {
+		scope.Log("rotate_client_key: %s", err)
+		return vfilter.Null{}
+	}
+
+	arg := &RotateClientKeyFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("rotate_client_key: %s", err.Error())
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	client_manager, err := services.GetClientInfoManager(config_obj)
+	if err != nil {
+		scope.Log("rotate_client_key: %s", err.Error())
+		return vfilter.Null{}
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+	logging.LogAudit(config_obj, principal, "rotate_client_key",
+		logrus.Fields{
+			"client_id": arg.ClientId,
+		})
+
+	err = client_manager.QueueMessageForClient(ctx, arg.ClientId,
+		&crypto_proto.VeloMessage{
+			KeyRotation: &crypto_proto.KeyRotation{
+				Request: true,
+			},
+			SessionId: constants.KEY_ROTATION_WELL_KNOWN_FLOW,
+		},
+		services.NOTIFY_CLIENT, utils.BackgroundWriter)
+	if err != nil {
+		scope.Log("rotate_client_key: %s", err.Error())
+		return vfilter.Null{}
+	}
+
+	return arg.ClientId
+}
+
+func (self RotateClientKeyFunction) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "rotate_client_key",
+		Doc:     "Ask the client to rotate its private key while keeping its client id.",
+		ArgType: type_map.AddType(scope, &RotateClientKeyFunctionArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&RotateClientKeyFunction{})
+}
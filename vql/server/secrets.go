@@ -0,0 +1,186 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/secrets"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type SecretAddFunctionArgs struct {
+	Name        string   `vfilter:"required,field=name,doc=The name of the secret"`
+	Value       string   `vfilter:"required,field=value,doc=The value of the secret"`
+	Description string   `vfilter:"optional,field=description,doc=A description of the secret"`
+	Users       []string `vfilter:"optional,field=users,doc=Other users which may use the secret in collections"`
+}
+
+type SecretAddFunction struct{}
+
+func (self *SecretAddFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.SECRETS_MANAGER)
+	if err != nil {
+		scope.Log("secret_add: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &SecretAddFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("secret_add: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+	secret, err := secrets.AddSecret(config_obj, principal,
+		arg.Name, arg.Description, arg.Value, arg.Users)
+	if err != nil {
+		scope.Log("secret_add: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, principal, "secret_add",
+		logrus.Fields{
+			"name":  arg.Name,
+			"users": arg.Users,
+		})
+
+	return json.ConvertProtoToOrderedDict(secret)
+}
+
+func (self SecretAddFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name: "secret_add",
+		Doc: "Add or update a secret which artifacts can use through " +
+			"parameters of type secret.",
+		ArgType: type_map.AddType(scope, &SecretAddFunctionArgs{}),
+	}
+}
+
+type SecretDeleteFunctionArgs struct {
+	Name string `vfilter:"required,field=name,doc=The secret to delete"`
+}
+
+type SecretDeleteFunction struct{}
+
+func (self *SecretDeleteFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.SECRETS_MANAGER)
+	if err != nil {
+		scope.Log("secret_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &SecretDeleteFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("secret_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	err = secrets.DeleteSecret(config_obj, arg.Name)
+	if err != nil {
+		scope.Log("secret_delete: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+		"secret_delete", logrus.Fields{
+			"name": arg.Name,
+		})
+
+	return arg.Name
+}
+
+func (self SecretDeleteFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "secret_delete",
+		Doc:     "Delete a secret.",
+		ArgType: type_map.AddType(scope, &SecretDeleteFunctionArgs{}),
+	}
+}
+
+type SecretsPlugin struct{}
+
+func (self SecretsPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		all_secrets, err := secrets.ListSecrets(config_obj)
+		if err != nil {
+			scope.Log("secrets: %v", err)
+			return
+		}
+
+		// Secrets managers see all secrets, other users only see
+		// the secrets they may use.
+		is_manager := vql_subsystem.CheckAccess(scope, acls.SECRETS_MANAGER) == nil
+		principal := vql_subsystem.GetPrincipal(scope)
+
+		for _, secret := range all_secrets {
+			if !is_manager && !secrets.CanUse(secret, principal) {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- json.ConvertProtoToOrderedDict(secret):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self SecretsPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "secrets",
+		Doc:  "List the secrets without their values.",
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&SecretAddFunction{})
+	vql_subsystem.RegisterFunction(&SecretDeleteFunction{})
+	vql_subsystem.RegisterPlugin(&SecretsPlugin{})
+}
@@ -0,0 +1,204 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/stix"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type STIXImportFunctionArgs struct {
+	Table  string `vfilter:"required,field=table,doc=The IOC table to import the indicators into"`
+	Bundle string `vfilter:"required,field=bundle,doc=The STIX 2.1 bundle as a JSON string"`
+	Source string `vfilter:"optional,field=source,doc=The source of indicators which do not name their creator (default the table name)"`
+}
+
+type STIXImportFunction struct{}
+
+func (self *STIXImportFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.SERVER_ADMIN)
+	if err != nil {
+		scope.Log("stix_import: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &STIXImportFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("stix_import: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	table, stats, err := stix.Import(ctx, config_obj,
+		arg.Table, arg.Source, []byte(arg.Bundle))
+	if err != nil {
+		scope.Log("stix_import: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+		"stix_import", logrus.Fields{
+			"table":    arg.Table,
+			"source":   arg.Source,
+			"imported": stats.Imported,
+		})
+
+	return ordereddict.NewDict().
+		Set("Table", json.ConvertProtoToOrderedDict(table)).
+		Set("Stats", stats)
+}
+
+func (self STIXImportFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "stix_import",
+		Doc:     "Import the indicators in a STIX 2.1 bundle into an IOC table.",
+		ArgType: type_map.AddType(scope, &STIXImportFunctionArgs{}),
+	}
+}
+
+type STIXSyncPluginArgs struct {
+	Feed string `vfilter:"optional,field=feed,doc=Only sync this feed (default all configured feeds)"`
+}
+
+type STIXSyncPlugin struct{}
+
+func (self STIXSyncPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.SERVER_ADMIN)
+		if err != nil {
+			scope.Log("stix_sync: %v", err)
+			return
+		}
+
+		arg := &STIXSyncPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("stix_sync: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+			"stix_sync", logrus.Fields{
+				"feed": arg.Feed,
+			})
+
+		// Report the feeds which synced even if others failed.
+		tables, err := stix.Sync(ctx, config_obj, arg.Feed)
+		if err != nil {
+			scope.Log("stix_sync: %v", err)
+		}
+
+		for _, table := range tables {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- json.ConvertProtoToOrderedDict(table):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self STIXSyncPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "stix_sync",
+		Doc:     "Sync the configured STIX feeds into their IOC tables now.",
+		ArgType: type_map.AddType(scope, &STIXSyncPluginArgs{}),
+	}
+}
+
+type STIXExportFunctionArgs struct {
+	HuntId    string   `vfilter:"required,field=hunt_id,doc=The hunt to export"`
+	Tables    []string `vfilter:"optional,field=tables,doc=Match indicators in these IOC tables (default all STIX tables)"`
+	Artifacts []string `vfilter:"optional,field=artifacts,doc=Only match results from these artifact sources"`
+	Columns   []string `vfilter:"optional,field=columns,doc=Only match these columns (default all columns)"`
+}
+
+type STIXExportFunction struct{}
+
+func (self *STIXExportFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+	if err != nil {
+		scope.Log("stix_export: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &STIXExportFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("stix_export: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	bundle, err := stix.ExportHunt(ctx, config_obj, &stix.ExportOptions{
+		HuntId:    arg.HuntId,
+		Tables:    arg.Tables,
+		Artifacts: arg.Artifacts,
+		Columns:   arg.Columns,
+	})
+	if err != nil {
+		scope.Log("stix_export: %v: %v", arg.HuntId, err)
+		return vfilter.Null{}
+	}
+
+	return bundle
+}
+
+func (self STIXExportFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name: "stix_export",
+		Doc: "Export the hunt results which match IOC table indicators " +
+			"as a STIX 2.1 bundle of sightings and observables.",
+		ArgType: type_map.AddType(scope, &STIXExportFunctionArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&STIXImportFunction{})
+	vql_subsystem.RegisterPlugin(&STIXSyncPlugin{})
+	vql_subsystem.RegisterFunction(&STIXExportFunction{})
+}
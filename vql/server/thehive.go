@@ -0,0 +1,121 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services/thehive"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type TheHiveAlertFunctionArgs struct {
+	Title       string      `vfilter:"required,field=title,doc=The title of the alert"`
+	Description string      `vfilter:"optional,field=description,doc=A markdown description of the alert"`
+	Severity    int64       `vfilter:"optional,field=severity,doc=Severity of the alert (1 low - 4 critical, default 2)"`
+	Tags        []string    `vfilter:"optional,field=tags,doc=Tags to add to the alert"`
+	Observables vfilter.Any `vfilter:"optional,field=observables,doc=A list of dicts with data_type, data and message"`
+	ClientId    string      `vfilter:"optional,field=client_id,doc=The client the alert refers to"`
+	FlowId      string      `vfilter:"optional,field=flow_id,doc=The flow the alert refers to"`
+	Artifact    string      `vfilter:"optional,field=artifact,doc=The artifact that raised the alert"`
+	SourceRef   string      `vfilter:"optional,field=source_ref,doc=A unique reference for the alert (default derived from the alert)"`
+	CreateCase  bool        `vfilter:"optional,field=create_case,doc=Create a case rather than an alert"`
+}
+
+type TheHiveAlertFunction struct{}
+
+func (self *TheHiveAlertFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.SERVER_ADMIN)
+	if err != nil {
+		scope.Log("thehive_alert: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &TheHiveAlertFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("thehive_alert: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	alert := &thehive.Alert{
+		Title:       arg.Title,
+		Description: arg.Description,
+		Severity:    arg.Severity,
+		Tags:        arg.Tags,
+		ClientId:    arg.ClientId,
+		FlowId:      arg.FlowId,
+		Artifact:    arg.Artifact,
+		SourceRef:   arg.SourceRef,
+		CreateCase:  arg.CreateCase,
+	}
+
+	if !utils.IsNil(arg.Observables) {
+		observables := []struct {
+			DataType string `json:"data_type"`
+			Data     string `json:"data"`
+			Message  string `json:"message"`
+		}{}
+
+		serialized, err := json.Marshal(arg.Observables)
+		if err == nil {
+			err = json.Unmarshal(serialized, &observables)
+		}
+		if err != nil {
+			scope.Log("thehive_alert: observables: %v", err)
+			return vfilter.Null{}
+		}
+
+		for _, observable := range observables {
+			alert.Observables = append(alert.Observables, thehive.Observable{
+				DataType: observable.DataType,
+				Data:     observable.Data,
+				Message:  observable.Message,
+			})
+		}
+	}
+
+	id, err := thehive.Send(ctx, config_obj, nil, alert)
+	if err != nil {
+		scope.Log("thehive_alert: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, vql_subsystem.GetPrincipal(scope),
+		"thehive_alert", logrus.Fields{
+			"id":    id,
+			"title": arg.Title,
+		})
+
+	return id
+}
+
+func (self TheHiveAlertFunction) Info(scope vfilter.Scope,
+	type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "thehive_alert",
+		Doc:     "Create an alert or case in TheHive.",
+		ArgType: type_map.AddType(scope, &TheHiveAlertFunctionArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&TheHiveAlertFunction{})
+}
@@ -0,0 +1,228 @@
+package timelines
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/sirupsen/logrus"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	timelines_proto "www.velocidex.com/golang/velociraptor/timelines/proto"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type BuildTimelineFunctionArgs struct {
+	ClientId string      `vfilter:"required,field=client_id,doc=The client to build the timeline for"`
+	Name     string      `vfilter:"required,field=name,doc=Name of the timeline"`
+	Flows    []string    `vfilter:"optional,field=flows,doc=Add all artifacts from these flows to the timeline"`
+	Sources  vfilter.Any `vfilter:"optional,field=sources,doc=A list of dicts with flow_id, artifact, time_columns and message_column"`
+}
+
+type BuildTimelineFunction struct{}
+
+func (self *BuildTimelineFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+	if err != nil {
+		scope.Log("timeline_build: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &BuildTimelineFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("timeline_build: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	timeline := &timelines_proto.ClientTimeline{}
+	if !utils.IsNil(arg.Sources) {
+		err = utils.ParseIntoProtobuf(
+			ordereddict.NewDict().Set("sources", arg.Sources), timeline)
+		if err != nil {
+			scope.Log("timeline_build: sources: %v", err)
+			return vfilter.Null{}
+		}
+	}
+
+	for _, flow_id := range arg.Flows {
+		timeline.Sources = append(timeline.Sources,
+			&timelines_proto.TimelineSource{FlowId: flow_id})
+	}
+
+	timeline.ClientId = arg.ClientId
+	timeline.Name = arg.Name
+
+	builder, err := services.GetTimelineBuilder(config_obj)
+	if err != nil {
+		scope.Log("timeline_build: %v", err)
+		return vfilter.Null{}
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+	result, err := builder.BuildTimeline(ctx, config_obj, principal, timeline)
+	if err != nil {
+		scope.Log("timeline_build: %v", err)
+		return vfilter.Null{}
+	}
+
+	logging.LogAudit(config_obj, principal, "timeline_build",
+		logrus.Fields{
+			"client_id": arg.ClientId,
+			"name":      arg.Name,
+		})
+
+	return json.ConvertProtoToOrderedDict(result)
+}
+
+func (self BuildTimelineFunction) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "timeline_build",
+		Doc:     "Build a client timeline by merging the results of its collections.",
+		ArgType: type_map.AddType(scope, &BuildTimelineFunctionArgs{}),
+	}
+}
+
+type AnnotateTimelineFunctionArgs struct {
+	ClientId string `vfilter:"required,field=client_id,doc=The client the timeline belongs to"`
+	Name     string `vfilter:"required,field=name,doc=Name of the timeline"`
+	EventId  string `vfilter:"required,field=event_id,doc=The EventId of the event to annotate"`
+	Note     string `vfilter:"optional,field=note,doc=The annotation. If empty the annotation is removed"`
+}
+
+type AnnotateTimelineFunction struct{}
+
+func (self *AnnotateTimelineFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+	if err != nil {
+		scope.Log("timeline_annotate: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &AnnotateTimelineFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("timeline_annotate: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	builder, err := services.GetTimelineBuilder(config_obj)
+	if err != nil {
+		scope.Log("timeline_annotate: %v", err)
+		return vfilter.Null{}
+	}
+
+	err = builder.AnnotateEvent(ctx, config_obj,
+		vql_subsystem.GetPrincipal(scope),
+		arg.ClientId, arg.Name, arg.EventId, arg.Note)
+	if err != nil {
+		scope.Log("timeline_annotate: %v", err)
+		return vfilter.Null{}
+	}
+
+	return arg.EventId
+}
+
+func (self AnnotateTimelineFunction) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "timeline_annotate",
+		Doc:     "Annotate an event in a client timeline.",
+		ArgType: type_map.AddType(scope, &AnnotateTimelineFunctionArgs{}),
+	}
+}
+
+type ClientTimelinesPluginArgs struct {
+	ClientId string `vfilter:"required,field=client_id,doc=The client to list timelines for"`
+}
+
+type ClientTimelinesPlugin struct{}
+
+func (self ClientTimelinesPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("client_timelines: %v", err)
+			return
+		}
+
+		arg := &ClientTimelinesPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("client_timelines: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		builder, err := services.GetTimelineBuilder(config_obj)
+		if err != nil {
+			scope.Log("client_timelines: %v", err)
+			return
+		}
+
+		timelines, err := builder.ListTimelines(ctx, config_obj, arg.ClientId)
+		if err != nil {
+			scope.Log("client_timelines: %v", err)
+			return
+		}
+
+		for _, timeline := range timelines {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- json.ConvertProtoToOrderedDict(timeline):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self ClientTimelinesPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "client_timelines",
+		Doc:     "List the timelines built for a client with timeline_build().",
+		ArgType: type_map.AddType(scope, &ClientTimelinesPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&BuildTimelineFunction{})
+	vql_subsystem.RegisterFunction(&AnnotateTimelineFunction{})
+	vql_subsystem.RegisterPlugin(&ClientTimelinesPlugin{})
+}
@@ -2,10 +2,13 @@ package timelines
 
 import (
 	"context"
+	"time"
 
 	"github.com/Velocidex/ordereddict"
 	"www.velocidex.com/golang/velociraptor/acls"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/timelines"
 	"www.velocidex.com/golang/velociraptor/utils"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
@@ -19,6 +22,7 @@ type TimelinePluginArgs struct {
 	SkipComponents []string    `vfilter:"optional,field=skip,doc=List of child components to skip"`
 	StartTime      vfilter.Any `vfilter:"optional,field=start,doc=First timestamp to fetch"`
 	NotebookId     string      `vfilter:"optional,field=notebook_id,doc=The notebook ID the timeline is stored in."`
+	ClientId       string      `vfilter:"optional,field=client_id,doc=Read a client timeline built with timeline_build() instead."`
 }
 
 type TimelinePlugin struct{}
@@ -51,6 +55,11 @@ func (self TimelinePlugin) Call(
 			return
 		}
 
+		if arg.ClientId != "" {
+			readClientTimeline(ctx, scope, config_obj, arg, output_chan)
+			return
+		}
+
 		notebook_id := arg.NotebookId
 		if notebook_id == "" {
 			notebook_id = vql_subsystem.GetStringFromRow(scope, scope, "NotebookId")
@@ -94,10 +103,46 @@ func (self TimelinePlugin) Call(
 	return output_chan
 }
 
+func readClientTimeline(
+	ctx context.Context, scope vfilter.Scope,
+	config_obj *config_proto.Config,
+	arg *TimelinePluginArgs, output_chan chan vfilter.Row) {
+
+	builder, err := services.GetTimelineBuilder(config_obj)
+	if err != nil {
+		scope.Log("timeline: %v", err)
+		return
+	}
+
+	var start time.Time
+	if !utils.IsNil(arg.StartTime) {
+		start, err = functions.TimeFromAny(scope, arg.StartTime)
+		if err != nil {
+			scope.Log("timeline: %v", err)
+			return
+		}
+	}
+
+	events, err := builder.ReadTimeline(
+		ctx, config_obj, arg.ClientId, arg.Timeline, start)
+	if err != nil {
+		scope.Log("timeline: %v", err)
+		return
+	}
+
+	for event := range events {
+		select {
+		case <-ctx.Done():
+			return
+		case output_chan <- event:
+		}
+	}
+}
+
 func (self TimelinePlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
 	return &vfilter.PluginInfo{
 		Name:    "timeline",
-		Doc:     "Read a timeline. You can create a timeline with the timeline_add() function",
+		Doc:     "Read a timeline. You can create a timeline with the timeline_add() or timeline_build() functions",
 		ArgType: type_map.AddType(scope, &TimelinePluginArgs{}),
 	}
 }
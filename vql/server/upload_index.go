@@ -0,0 +1,137 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/services/upload_index"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type UploadSearchPluginArgs struct {
+	Query     string `vfilter:"required,field=query,doc=The terms to search for. Uploads must contain all of them."`
+	Substring bool   `vfilter:"optional,field=substring,doc=Match indexed terms containing the query (reads the entire index)."`
+	ClientId  string `vfilter:"optional,field=client_id,doc=Only search the uploads of this client."`
+}
+
+type UploadSearchPlugin struct{}
+
+func (self UploadSearchPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("upload_search: %v", err)
+			return
+		}
+
+		arg := &UploadSearchPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("upload_search: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		rows, err := upload_index.Search(ctx, config_obj, arg.Query,
+			upload_index.SearchOptions{
+				Substring: arg.Substring,
+				ClientId:  arg.ClientId,
+			})
+		if err != nil {
+			scope.Log("upload_search: %v", err)
+			return
+		}
+
+		for _, row := range rows {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self UploadSearchPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "upload_search",
+		Doc:     "Search the strings of uploaded files in the upload index.",
+		ArgType: type_map.AddType(scope, &UploadSearchPluginArgs{}),
+	}
+}
+
+type IndexUploadsFunctionArgs struct {
+	ClientId string `vfilter:"required,field=client_id,doc=The client id of the flow."`
+	FlowId   string `vfilter:"required,field=flow_id,doc=The flow whose uploads are indexed."`
+}
+
+type IndexUploadsFunction struct{}
+
+func (self IndexUploadsFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	err := vql_subsystem.CheckAccess(scope, acls.PREPARE_RESULTS)
+	if err != nil {
+		scope.Log("index_uploads: %v", err)
+		return vfilter.Null{}
+	}
+
+	arg := &IndexUploadsFunctionArgs{}
+	err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("index_uploads: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		scope.Log("Command can only run on the server")
+		return vfilter.Null{}
+	}
+
+	count, err := upload_index.IndexFlow(ctx, config_obj,
+		arg.ClientId, arg.FlowId)
+	if err != nil {
+		scope.Log("index_uploads: %v", err)
+		return vfilter.Null{}
+	}
+
+	return count
+}
+
+func (self IndexUploadsFunction) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name: "index_uploads",
+		Doc: "Add the uploads of a flow to the upload index. Flows are " +
+			"indexed when they complete if the upload index is enabled - " +
+			"use this to index older flows.",
+		ArgType: type_map.AddType(scope, &IndexUploadsFunctionArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&UploadSearchPlugin{})
+	vql_subsystem.RegisterFunction(&IndexUploadsFunction{})
+}
@@ -14,10 +14,12 @@ import (
 )
 
 type GrantFunctionArgs struct {
-	Username string            `vfilter:"required,field=user,doc=The user to create or update."`
-	Roles    []string          `vfilter:"optional,field=roles,doc=List of roles to give the user."`
-	OrgIds   []string          `vfilter:"optional,field=orgs,doc=One or more org IDs to grant access to. If not specified we use current org"`
-	Policy   *ordereddict.Dict `vfilter:"optional,field=policy,doc=A dict of permissions to set (e.g. as obtained from the gui_users() function)."`
+	Username      string            `vfilter:"required,field=user,doc=The user to create or update."`
+	Roles         []string          `vfilter:"optional,field=roles,doc=List of roles to give the user."`
+	OrgIds        []string          `vfilter:"optional,field=orgs,doc=One or more org IDs to grant access to. If not specified we use current org"`
+	Policy        *ordereddict.Dict `vfilter:"optional,field=policy,doc=A dict of permissions to set (e.g. as obtained from the gui_users() function)."`
+	ArtifactScope []string          `vfilter:"optional,field=artifact_scope,doc=Restrict the user to collecting artifacts matching these glob patterns."`
+	LabelScope    []string          `vfilter:"optional,field=label_scope,doc=Restrict the user to collecting from clients with these labels."`
 }
 
 type GrantFunction struct{}
@@ -64,6 +66,12 @@ func (self GrantFunction) Call(
 		return vfilter.Null{}
 	}
 	policy.Roles = arg.Roles
+	if len(arg.ArtifactScope) > 0 {
+		policy.ArtifactScope = arg.ArtifactScope
+	}
+	if len(arg.LabelScope) > 0 {
+		policy.LabelScope = arg.LabelScope
+	}
 
 	principal := vql_subsystem.GetPrincipal(scope)
 	err = users.GrantUserToOrg(ctx, principal, arg.Username, orgs, policy)
@@ -0,0 +1,140 @@
+// +build server_vql
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services/materialized_views"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/functions"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type MaterializedViewPluginArgs struct {
+	Name      string      `vfilter:"required,field=name,doc=The name of the view."`
+	StartTime vfilter.Any `vfilter:"optional,field=start_time,doc=Only show buckets after this time."`
+	EndTime   vfilter.Any `vfilter:"optional,field=end_time,doc=Only show buckets before this time."`
+}
+
+type MaterializedViewPlugin struct{}
+
+func (self MaterializedViewPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("materialized_view: %v", err)
+			return
+		}
+
+		arg := &MaterializedViewPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("materialized_view: %v", err)
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("Command can only run on the server")
+			return
+		}
+
+		view_config, err := materialized_views.GetView(config_obj, arg.Name)
+		if err != nil {
+			scope.Log("materialized_view: %v", err)
+			return
+		}
+
+		var start, end time.Time
+		if !utils.IsNil(arg.StartTime) {
+			start, err = functions.TimeFromAny(scope, arg.StartTime)
+			if err != nil {
+				scope.Log("materialized_view: %v", err)
+				return
+			}
+		}
+
+		if !utils.IsNil(arg.EndTime) {
+			end, err = functions.TimeFromAny(scope, arg.EndTime)
+			if err != nil {
+				scope.Log("materialized_view: %v", err)
+				return
+			}
+		}
+
+		period := time.Duration(view_config.Period) * time.Second
+		if period == 0 {
+			period = materialized_views.DEFAULT_PERIOD * time.Second
+		}
+
+		buckets, err := materialized_views.ListBuckets(config_obj, arg.Name)
+		if err != nil {
+			scope.Log("materialized_view: %v", err)
+			return
+		}
+
+		file_store_factory := file_store.GetFileStore(config_obj)
+		path_manager := paths.NewMaterializedViewPathManager(arg.Name)
+
+		for _, bucket := range buckets {
+			// Skip buckets which do not overlap the time range.
+			if !start.IsZero() && !bucket.Add(period).After(start) {
+				continue
+			}
+			if !end.IsZero() && !bucket.Before(end) {
+				continue
+			}
+
+			reader, err := result_sets.NewResultSetReader(
+				file_store_factory, path_manager.Bucket(bucket))
+			if err != nil {
+				scope.Log("materialized_view: %v", err)
+				continue
+			}
+
+			for row := range reader.Rows(ctx) {
+				result := ordereddict.NewDict().Set("Bucket", bucket)
+				result.MergeFrom(row)
+
+				select {
+				case <-ctx.Done():
+					reader.Close()
+					return
+				case output_chan <- result:
+				}
+			}
+			reader.Close()
+		}
+	}()
+
+	return output_chan
+}
+
+func (self MaterializedViewPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "materialized_view",
+		Doc:     "Read the aggregated buckets of a materialized view.",
+		ArgType: type_map.AddType(scope, &MaterializedViewPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&MaterializedViewPlugin{})
+}
@@ -0,0 +1,386 @@
+//+build extras
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/uploads"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/networking"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+const (
+	AZURE_API_VERSION        = "2020-10-02"
+	AZURE_DEFAULT_BLOCK_SIZE = 4 * 1024 * 1024
+)
+
+type AzureUploadArgs struct {
+	File      *accessors.OSPath `vfilter:"required,field=file,doc=The file to upload"`
+	Name      string            `vfilter:"optional,field=name,doc=The name of the blob that should be stored in the container"`
+	Accessor  string            `vfilter:"optional,field=accessor,doc=The accessor to use"`
+	SASURL    string            `vfilter:"required,field=sas_url,doc=A container SAS URL with write permission"`
+	BlockSize uint64            `vfilter:"optional,field=block_size,doc=The size of each uploaded block (default 4mb)"`
+	MaxRate   uint64            `vfilter:"optional,field=max_rate,doc=Maximum upload rate in bytes per second (0 for unlimited)"`
+	Retries   int64             `vfilter:"optional,field=retries,doc=How many times to retry each block (default 3)"`
+}
+
+type AzureUploadFunction struct{}
+
+func (self *AzureUploadFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	arg := &AzureUploadArgs{}
+	err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("upload_azure: %s", err.Error())
+		return vfilter.Null{}
+	}
+
+	err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+	if err != nil {
+		scope.Log("upload_azure: %s", err)
+		return vfilter.Null{}
+	}
+
+	accessor, err := accessors.GetAccessor(arg.Accessor, scope)
+	if err != nil {
+		scope.Log("upload_azure: %v", err)
+		return vfilter.Null{}
+	}
+
+	file, err := accessor.OpenWithOSPath(arg.File)
+	if err != nil {
+		scope.Log("upload_azure: Unable to open %s: %s",
+			arg.File, err.Error())
+		return &vfilter.Null{}
+	}
+	defer file.Close()
+
+	if arg.Name == "" {
+		arg.Name = arg.File.String()
+	}
+
+	if arg.BlockSize == 0 {
+		arg.BlockSize = AZURE_DEFAULT_BLOCK_SIZE
+	}
+
+	if _, pres := args.Get("retries"); !pres {
+		arg.Retries = 3
+	}
+
+	stat, err := accessor.LstatWithOSPath(arg.File)
+	if err != nil {
+		scope.Log("upload_azure: Unable to stat %s: %v",
+			arg.File, err)
+	} else if !stat.IsDir() {
+		// Abort uploading when the scope is destroyed.
+		sub_ctx, cancel := context.WithCancel(ctx)
+		_ = scope.AddDestructor(cancel)
+
+		uploader := &azureUploader{
+			scope:      scope,
+			block_size: arg.BlockSize,
+			max_rate:   arg.MaxRate,
+			retries:    arg.Retries,
+			client: &http.Client{
+				Transport: &http.Transport{
+					Proxy: networking.GetProxy(),
+					DialContext: (&net.Dialer{
+						Timeout: 30 * time.Second, // TCP connect timeout
+					}).DialContext,
+					TLSHandshakeTimeout: 30 * time.Second,
+				},
+			},
+		}
+
+		upload_response, err := uploader.Upload(
+			sub_ctx, file, stat.Size(), arg.Name, arg.SASURL)
+		if err != nil {
+			scope.Log("upload_azure: %v", err)
+			// Relay the error in the UploadResponse
+			return upload_response
+		}
+		return upload_response
+	}
+
+	return vfilter.Null{}
+}
+
+// Uploads a file as a block blob. Each block is staged separately
+// and the blob is committed at the end. Blocks staged by a previous
+// (interrupted) upload of the same blob remain in the uncommitted
+// block list for a week so the upload resumes from the first missing
+// block.
+type azureUploader struct {
+	scope      vfilter.Scope
+	client     *http.Client
+	block_size uint64
+	max_rate   uint64
+	retries    int64
+}
+
+type azureBlock struct {
+	Name string `xml:"Name"`
+	Size int64  `xml:"Size"`
+}
+
+type azureBlockList struct {
+	UncommittedBlocks []azureBlock `xml:"UncommittedBlocks>Block"`
+}
+
+type azureCommitBlockList struct {
+	XMLName xml.Name `xml:"BlockList"`
+	Latest  []string `xml:"Latest"`
+}
+
+func (self *azureUploader) Upload(ctx context.Context,
+	reader io.ReadSeeker, size int64, name, sas_url string) (
+	*uploads.UploadResponse, error) {
+
+	// The blob URL is the container URL with the blob name appended.
+	// The SAS token in the query string authorizes all requests.
+	parsed, err := url.Parse(sas_url)
+	if err != nil {
+		return &uploads.UploadResponse{
+			Error: err.Error(),
+		}, err
+	}
+	parsed.Path = path.Join(parsed.Path, name)
+	blob_url := *parsed
+
+	// Do not leak the SAS token into the upload response.
+	parsed.RawQuery = ""
+	response := &uploads.UploadResponse{
+		Path: parsed.String(),
+		Size: uint64(size),
+	}
+
+	self.scope.Log("upload_azure: Uploading %v to %v", name, response.Path)
+
+	staged, err := self.getUncommittedBlocks(ctx, blob_url)
+	if err != nil {
+		response.Error = err.Error()
+		return response, err
+	}
+
+	block_ids := []string{}
+	block_size := int64(self.block_size)
+	buf := make([]byte, block_size)
+
+	for idx, offset := 0, int64(0); offset < size; idx, offset = idx+1, offset+block_size {
+		length := size - offset
+		if length > block_size {
+			length = block_size
+		}
+
+		block_id := azureBlockId(idx)
+		block_ids = append(block_ids, block_id)
+
+		// This block was already staged by a previous attempt.
+		if staged[block_id] == length {
+			continue
+		}
+
+		_, err := reader.Seek(offset, io.SeekStart)
+		if err != nil {
+			response.Error = err.Error()
+			return response, err
+		}
+
+		n, err := io.ReadFull(reader, buf[:length])
+		if err != nil {
+			response.Error = err.Error()
+			return response, err
+		}
+
+		err = self.putBlock(ctx, blob_url, block_id, buf[:n])
+		if err != nil {
+			response.Error = err.Error()
+			return response, err
+		}
+	}
+
+	err = self.commitBlockList(ctx, blob_url, block_ids)
+	if err != nil {
+		response.Error = err.Error()
+		return response, err
+	}
+
+	return response, nil
+}
+
+// Block ids must all have the same length within a blob.
+func azureBlockId(idx int) string {
+	return base64.StdEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("%010d", idx)))
+}
+
+// Returns the sizes of the blocks that were already staged, keyed
+// by block id.
+func (self *azureUploader) getUncommittedBlocks(
+	ctx context.Context, blob_url url.URL) (map[string]int64, error) {
+	result := make(map[string]int64)
+
+	query := blob_url.Query()
+	query.Set("comp", "blocklist")
+	query.Set("blocklisttype", "uncommitted")
+	blob_url.RawQuery = query.Encode()
+
+	resp, err := self.do(ctx, http.MethodGet, blob_url, nil, "")
+	if err != nil {
+		// The blob does not exist yet so nothing was staged.
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return result, nil
+		}
+		return nil, err
+	}
+
+	block_list := &azureBlockList{}
+	err = xml.Unmarshal(resp.Body, block_list)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, block := range block_list.UncommittedBlocks {
+		result[block.Name] = block.Size
+	}
+
+	if len(result) > 0 {
+		self.scope.Log("upload_azure: Resuming upload with %v staged blocks",
+			len(result))
+	}
+
+	return result, nil
+}
+
+func (self *azureUploader) putBlock(ctx context.Context,
+	blob_url url.URL, block_id string, data []byte) error {
+	query := blob_url.Query()
+	query.Set("comp", "block")
+	query.Set("blockid", block_id)
+	blob_url.RawQuery = query.Encode()
+
+	_, err := self.do(ctx, http.MethodPut, blob_url, data, "")
+	return err
+}
+
+func (self *azureUploader) commitBlockList(ctx context.Context,
+	blob_url url.URL, block_ids []string) error {
+	query := blob_url.Query()
+	query.Set("comp", "blocklist")
+	blob_url.RawQuery = query.Encode()
+
+	serialized, err := xml.Marshal(&azureCommitBlockList{Latest: block_ids})
+	if err != nil {
+		return err
+	}
+
+	_, err = self.do(ctx, http.MethodPut, blob_url,
+		append([]byte(xml.Header), serialized...), "application/xml")
+	return err
+}
+
+type azureResponse struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Issue the request, retrying transient failures. Request bodies are
+// subject to the rate limit.
+func (self *azureUploader) do(ctx context.Context,
+	method string, blob_url url.URL, data []byte, content_type string) (
+	*azureResponse, error) {
+
+	var last_err error
+	for attempt := int64(0); attempt <= self.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(attempt) * 5 * time.Second):
+			}
+		}
+
+		var body io.Reader
+		if data != nil {
+			body = utils.NewRateLimitedReader(
+				ctx, bytes.NewReader(data), self.max_rate)
+		}
+
+		req, err := http.NewRequestWithContext(
+			ctx, method, blob_url.String(), body)
+		if err != nil {
+			return nil, err
+		}
+
+		req.ContentLength = int64(len(data))
+		req.Header.Set("x-ms-version", AZURE_API_VERSION)
+		if content_type != "" {
+			req.Header.Set("Content-Type", content_type)
+		}
+
+		resp, err := self.client.Do(req)
+		if err != nil {
+			last_err = err
+			continue
+		}
+
+		resp_body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			last_err = err
+			continue
+		}
+
+		result := &azureResponse{
+			StatusCode: resp.StatusCode,
+			Body:       resp_body,
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return result, nil
+		}
+
+		last_err = fmt.Errorf("upload_azure: HTTP status %v: %v",
+			resp.StatusCode, string(resp_body))
+
+		// Only server errors and throttling are worth retrying.
+		if resp.StatusCode < 500 &&
+			resp.StatusCode != http.StatusTooManyRequests {
+			return result, last_err
+		}
+	}
+
+	return nil, last_err
+}
+
+func (self AzureUploadFunction) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "upload_azure",
+		Doc:     "Upload files to Azure Blob Storage using a container SAS URL.",
+		ArgType: type_map.AddType(scope, &AzureUploadArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&AzureUploadFunction{})
+}
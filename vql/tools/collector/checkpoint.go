@@ -0,0 +1,224 @@
+package collector
+
+// Offline collections may take hours on a busy machine. When a
+// checkpoint directory is given, each artifact is collected into its
+// own part container inside the checkpoint directory and recorded in
+// checkpoint.json once it is complete. If the collection is
+// interrupted (e.g. the machine reboots or the collector crashes),
+// running it again with the same checkpoint directory skips the
+// artifacts that were already completed.
+
+// When the collection finishes the parts are merged into the final
+// container and the checkpoint directory is removed. Note that the
+// parts are not encrypted - the checkpoint directory is only
+// accessible by the user running the collector.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/reporting"
+	"www.velocidex.com/golang/velociraptor/third_party/zip"
+)
+
+const (
+	CHECKPOINT_FILE = "checkpoint.json"
+)
+
+// A completed part of the collection.
+type checkpointPart struct {
+	RequestNumber int      `json:"request_number"`
+	Artifacts     []string `json:"artifacts"`
+	Filename      string   `json:"filename"`
+
+	UploadedFiles uint64                     `json:"uploaded_files"`
+	UploadedBytes uint64                     `json:"uploaded_bytes"`
+	QueryStats    []*crypto_proto.VeloStatus `json:"query_stats"`
+}
+
+type checkpointState struct {
+	// A checkpoint can only be resumed by the same collection.
+	Fingerprint string            `json:"fingerprint"`
+	Parts       []*checkpointPart `json:"parts"`
+}
+
+type checkpoint struct {
+	dir   string
+	state checkpointState
+}
+
+// Open the checkpoint directory and load any previous progress. If
+// the previous progress belongs to a different collection it is
+// discarded.
+func newCheckpoint(dir string, request *flows_proto.ArtifactCollectorArgs,
+	extra ...interface{}) (*checkpoint, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(json.MustMarshalString(request)))
+	for _, item := range extra {
+		hash.Write([]byte(json.MustMarshalString(item)))
+	}
+	fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+	result := &checkpoint{dir: dir}
+
+	serialized, err := ioutil.ReadFile(filepath.Join(dir, CHECKPOINT_FILE))
+	if err == nil {
+		err = json.Unmarshal(serialized, &result.state)
+		if err == nil && result.state.Fingerprint == fingerprint {
+			return result, nil
+		}
+	}
+
+	// Start a fresh checkpoint: Remove any stale parts.
+	parts, _ := filepath.Glob(filepath.Join(dir, "part-*.zip"))
+	for _, part := range parts {
+		os.Remove(part)
+	}
+
+	result.state = checkpointState{Fingerprint: fingerprint}
+	return result, result.save()
+}
+
+// Get the completed part for this request or nil if the request
+// still needs to be collected.
+func (self *checkpoint) GetPart(request_number int) *checkpointPart {
+	for _, part := range self.state.Parts {
+		if part.RequestNumber == request_number {
+			return part
+		}
+	}
+	return nil
+}
+
+func (self *checkpoint) NewPartContainer(
+	config_obj *config_proto.Config, level int64,
+	request_number int) (*reporting.Container, string, error) {
+	filename := fmt.Sprintf("part-%03d.zip", request_number)
+	container, err := reporting.NewContainer(config_obj,
+		filepath.Join(self.dir, filename), "", level, reporting.NO_METADATA)
+	return container, filename, err
+}
+
+// Record the part as completed. The part container must already be
+// closed.
+func (self *checkpoint) CompletePart(part *checkpointPart) error {
+	self.state.Parts = append(self.state.Parts, part)
+	return self.save()
+}
+
+// Write the checkpoint file atomically so a crash never leaves a
+// corrupted checkpoint.
+func (self *checkpoint) save() error {
+	filename := filepath.Join(self.dir, CHECKPOINT_FILE)
+	err := ioutil.WriteFile(filename+".tmp",
+		json.MustMarshalIndent(self.state), 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(filename+".tmp", filename)
+}
+
+func (self *checkpoint) Stats() (uploaded_files, uploaded_bytes uint64) {
+	for _, part := range self.state.Parts {
+		uploaded_files += part.UploadedFiles
+		uploaded_bytes += part.UploadedBytes
+	}
+	return uploaded_files, uploaded_bytes
+}
+
+// Copy all the completed parts into the final container. Each part
+// carries its own uploads.json so these are combined into a single
+// member.
+func (self *checkpoint) MergeInto(container *reporting.Container) error {
+	var uploads []byte
+
+	for _, part := range self.state.Parts {
+		reader, err := zip.OpenReader(filepath.Join(self.dir, part.Filename))
+		if err != nil {
+			return err
+		}
+
+		for _, member := range reader.File {
+			if member.Name == "uploads.json" {
+				data, err := readMember(member)
+				if err != nil {
+					reader.Close()
+					return err
+				}
+				uploads = append(uploads, data...)
+				continue
+			}
+
+			err = copyMember(container, member)
+			if err != nil {
+				reader.Close()
+				return err
+			}
+		}
+		reader.Close()
+	}
+
+	if len(uploads) == 0 {
+		return nil
+	}
+
+	fd, err := container.Create("uploads.json", Clock.Now())
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	_, err = fd.Write(uploads)
+	return err
+}
+
+func (self *checkpoint) Remove() error {
+	return os.RemoveAll(self.dir)
+}
+
+func readMember(member *zip.File) ([]byte, error) {
+	fd, err := member.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	return ioutil.ReadAll(fd)
+}
+
+func copyMember(container *reporting.Container, member *zip.File) error {
+	in, err := member.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	mtime := member.Modified
+	if mtime.IsZero() {
+		mtime = time.Unix(0, 0)
+	}
+
+	out, err := container.Create(member.Name, mtime)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,199 @@
+package collector
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/third_party/zip"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/data"
+	_ "www.velocidex.com/golang/velociraptor/vql/filesystem"
+	_ "www.velocidex.com/golang/velociraptor/vql/functions"
+)
+
+// The second artifact hangs until the marker file exists.
+var checkpointArtifacts = []string{`
+name: Custom.Checkpoint.First
+sources:
+- query: |
+    SELECT "First" AS Name,
+           upload(file="hello world", accessor="data", name="first.txt") AS Upload
+    FROM scope()
+`, `
+name: Custom.Checkpoint.Second
+parameters:
+- name: Marker
+sources:
+- query: |
+    SELECT * FROM if(condition=read_file(filename=Marker),
+      then={ SELECT "Second" AS Name FROM scope() },
+      else={ SELECT * FROM scope() WHERE sleep(time=20) })
+`}
+
+type CheckpointTestSuite struct {
+	test_utils.TestSuite
+
+	tmpdir string
+}
+
+func (self *CheckpointTestSuite) SetupTest() {
+	self.ConfigObj = self.LoadConfig()
+	self.LoadArtifacts(checkpointArtifacts)
+	self.TestSuite.SetupTest()
+
+	var err error
+	self.tmpdir, err = ioutil.TempDir("", "checkpoint")
+	assert.NoError(self.T(), err)
+}
+
+func (self *CheckpointTestSuite) TearDownTest() {
+	os.RemoveAll(self.tmpdir)
+	self.TestSuite.TearDownTest()
+}
+
+func (self *CheckpointTestSuite) collect(output string, timeout int) []string {
+	builder := services.ScopeBuilder{
+		Config:     self.ConfigObj,
+		ACLManager: acl_managers.NullACLManager{},
+		Logger:     logging.NewPlainLogger(self.ConfigObj, &logging.FrontendComponent),
+		Env:        ordereddict.NewDict(),
+	}
+
+	manager, err := services.GetRepositoryManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	scope := manager.BuildScope(builder)
+	defer scope.Close()
+
+	args := ordereddict.NewDict().
+		Set("artifacts", []string{
+			"Custom.Checkpoint.First", "Custom.Checkpoint.Second"}).
+		Set("args", ordereddict.NewDict().
+			Set("Custom.Checkpoint.Second", ordereddict.NewDict().
+				Set("Marker", filepath.Join(self.tmpdir, "marker")))).
+		Set("output", output).
+		Set("checkpoint", filepath.Join(self.tmpdir, "checkpoint"))
+	if timeout > 0 {
+		args.Set("timeout", timeout)
+	}
+
+	for range (CollectPlugin{}).Call(context.Background(), scope, args) {
+	}
+
+	return self.members(output)
+}
+
+func (self *CheckpointTestSuite) members(filename string) []string {
+	result := []string{}
+
+	r, err := zip.OpenReader(filename)
+	assert.NoError(self.T(), err)
+	defer r.Close()
+
+	for _, f := range r.File {
+		result = append(result, f.Name)
+	}
+	return result
+}
+
+func (self *CheckpointTestSuite) readMember(filename, name string) string {
+	r, err := zip.OpenReader(filename)
+	assert.NoError(self.T(), err)
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.Name == name {
+			data, err := readMember(f)
+			assert.NoError(self.T(), err)
+			return string(data)
+		}
+	}
+	return ""
+}
+
+func (self *CheckpointTestSuite) TestResumeCollection() {
+	checkpoint_dir := filepath.Join(self.tmpdir, "checkpoint")
+
+	// The first run times out while collecting the second artifact.
+	first_output := filepath.Join(self.tmpdir, "first.zip")
+	members := self.collect(first_output, 1)
+	assert.Contains(self.T(), members, "results/Custom.Checkpoint.First.json")
+	assert.NotContains(self.T(), members, "results/Custom.Checkpoint.Second.json")
+
+	// The checkpoint remembers the first artifact.
+	state := &checkpointState{}
+	serialized, err := ioutil.ReadFile(
+		filepath.Join(checkpoint_dir, CHECKPOINT_FILE))
+	assert.NoError(self.T(), err)
+	assert.NoError(self.T(), json.Unmarshal(serialized, state))
+	assert.Equal(self.T(), 1, len(state.Parts))
+	assert.Equal(self.T(), []string{"Custom.Checkpoint.First"},
+		state.Parts[0].Artifacts)
+
+	// Release the second artifact and run again.
+	err = ioutil.WriteFile(filepath.Join(self.tmpdir, "marker"),
+		[]byte("Y"), 0600)
+	assert.NoError(self.T(), err)
+
+	second_output := filepath.Join(self.tmpdir, "second.zip")
+	members = self.collect(second_output, 0)
+	assert.Contains(self.T(), members, "results/Custom.Checkpoint.First.json")
+	assert.Contains(self.T(), members, "results/Custom.Checkpoint.Second.json")
+	assert.Contains(self.T(), members, "uploads/data/first.txt")
+
+	// The first artifact was not collected again.
+	logs := self.readMember(second_output, "log.json")
+	assert.Contains(self.T(), logs, "already collected by a previous run")
+	assert.NotContains(self.T(), logs, "Starting collection of Custom.Checkpoint.First")
+
+	// The upload from the first run is still accounted for.
+	assert.Contains(self.T(), self.readMember(second_output, "uploads.json"),
+		"first.txt")
+
+	// The checkpoint is removed once the collection is complete.
+	_, err = os.Stat(checkpoint_dir)
+	assert.True(self.T(), os.IsNotExist(err))
+}
+
+func (self *CheckpointTestSuite) TestCheckpointFingerprint() {
+	checkpoint_dir := filepath.Join(self.tmpdir, "checkpoint")
+
+	// Simulate a checkpoint left behind by a different collection.
+	err := os.MkdirAll(checkpoint_dir, 0700)
+	assert.NoError(self.T(), err)
+
+	stale := &checkpointState{
+		Fingerprint: "stale",
+		Parts: []*checkpointPart{{
+			Filename: "part-000.zip",
+		}},
+	}
+	err = ioutil.WriteFile(filepath.Join(checkpoint_dir, CHECKPOINT_FILE),
+		json.MustMarshalIndent(stale), 0600)
+	assert.NoError(self.T(), err)
+	err = ioutil.WriteFile(filepath.Join(checkpoint_dir, "part-000.zip"),
+		[]byte("junk"), 0600)
+	assert.NoError(self.T(), err)
+
+	c, err := newCheckpoint(checkpoint_dir, nil)
+	assert.NoError(self.T(), err)
+	assert.Nil(self.T(), c.GetPart(0))
+
+	_, err = os.Stat(filepath.Join(checkpoint_dir, "part-000.zip"))
+	assert.True(self.T(), os.IsNotExist(err))
+}
+
+func TestCheckpoint(t *testing.T) {
+	suite.Run(t, &CheckpointTestSuite{})
+}
@@ -45,6 +45,7 @@ type CollectPluginArgs struct {
 	ProgressTimeout     float64             `vfilter:"optional,field=progress_timeout,doc=If no progress is detected in this many seconds, we terminate the query and output debugging information"`
 	Timeout             float64             `vfilter:"optional,field=timeout,doc=Total amount of time in seconds, this collection will take. Collection is cancelled when timeout is exceeded."`
 	Metadata            vfilter.StoredQuery `vfilter:"optional,field=metadata,doc=Metadata to store in the zip archive. Outputs to metadata.json in top level of zip file."`
+	Checkpoint          string              `vfilter:"optional,field=checkpoint,doc=A directory to checkpoint progress in. An interrupted collection resumes from the checkpoint when run again."`
 }
 
 type CollectPlugin struct{}
@@ -150,8 +151,24 @@ func (self CollectPlugin) configureCollection(
 
 	// Compile the request into vql requests protobuf ready for
 	// acquisition.
-	return getArtifactCollectorArgs(
+	request, err := getArtifactCollectorArgs(
 		manager.config_obj, manager.repository, manager.scope, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	if arg.Checkpoint != "" {
+		if arg.Output == "" {
+			return nil, errors.New("checkpoint requires an output container")
+		}
+
+		err = manager.SetCheckpoint(arg.Checkpoint, request)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return request, nil
 }
 
 func (self CollectPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
@@ -58,6 +58,12 @@ type collectionManager struct {
 	metadata []vfilter.Row
 
 	format reporting.ContainerFormat
+	level  int64
+
+	// If set, progress is checkpointed so an interrupted collection
+	// can be resumed.
+	checkpoint *checkpoint
+	completed  bool
 
 	scope vfilter.Scope
 }
@@ -188,6 +194,25 @@ func (self *collectionManager) SetFormat(
 	return nil
 }
 
+// Resume the collection from the checkpoint directory if possible.
+func (self *collectionManager) SetCheckpoint(
+	dir string, request *flows_proto.ArtifactCollectorArgs) (err error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.checkpoint, err = newCheckpoint(dir, request,
+		self.custom_artifacts, self.format)
+	if err != nil {
+		return err
+	}
+
+	if len(self.checkpoint.state.Parts) > 0 {
+		self.scope.Log("collect: Resuming collection from checkpoint %v: %v parts already collected",
+			dir, len(self.checkpoint.state.Parts))
+	}
+	return nil
+}
+
 func (self *collectionManager) storeHostInfo() error {
 	return nil
 
@@ -237,6 +262,7 @@ func (self *collectionManager) storeCollectionMetadata() error {
 }
 
 func (self *collectionManager) collectQuery(
+	container *reporting.Container,
 	subscope vfilter.Scope, query *actions_proto.VQLRequest) (err error) {
 
 	query_start_time := Clock.Now()
@@ -260,7 +286,7 @@ func (self *collectionManager) collectQuery(
 
 	// If there is no container we just
 	// return the rows to our caller.
-	if self.container == nil {
+	if container == nil {
 		query_log := actions.QueryLog.AddQuery(query.VQL)
 
 		vql, err := vfilter.Parse(query.VQL)
@@ -284,7 +310,7 @@ func (self *collectionManager) collectQuery(
 		return nil
 	}
 
-	total_rows, err := self.container.StoreArtifact(
+	total_rows, err := container.StoreArtifact(
 		self.config_obj, self.ctx, subscope, query,
 		path_specs.NewUnsafeFilestorePath("results"),
 		self.format)
@@ -369,24 +395,110 @@ func (self *collectionManager) Collect(request *flows_proto.ArtifactCollectorArg
 			env.Set(env_spec.Key, env_spec.Value)
 		}
 
+		self.collection_context.TotalRequests = int64(len(vql_request.Query))
+
+		if self.checkpoint != nil {
+			err = self.collectCheckpointedRequest(
+				builder, env, request_number, vql_request)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		subscope := manager.BuildScope(builder)
 		subscope.AppendVars(env)
 		defer subscope.Close()
 
-		self.collection_context.TotalRequests = int64(len(vql_request.Query))
-
 		// Run each query and store the results in the container
 		for _, query := range vql_request.Query {
-			err := self.collectQuery(subscope, query)
+			err := self.collectQuery(self.container, subscope, query)
 			if err != nil {
 				return err
 			}
 		}
 	}
 
+	// A cancelled collection (e.g. timeout) is not complete.
+	self.completed = self.ctx.Err() == nil
+
 	return nil
 }
 
+// Collect the request into its own part container in the checkpoint
+// directory, unless it was already collected by a previous run.
+func (self *collectionManager) collectCheckpointedRequest(
+	builder services.ScopeBuilder, env *ordereddict.Dict,
+	request_number int, vql_request *actions_proto.VQLCollectorArgs) error {
+
+	manager, err := services.GetRepositoryManager(self.config_obj)
+	if err != nil {
+		return err
+	}
+
+	part := self.checkpoint.GetPart(request_number)
+	if part != nil {
+		// Log into the collection log.
+		subscope := manager.BuildScope(builder)
+		defer subscope.Close()
+
+		subscope.Log("collect: Skipping %v: already collected by a previous run",
+			part.Artifacts)
+		for _, status := range part.QueryStats {
+			self.collection_context.QueryStats = append(
+				self.collection_context.QueryStats, status)
+			self.collection_context.TotalCollectedRows += uint64(status.ResultRows)
+		}
+		return nil
+	}
+
+	part_container, filename, err := self.checkpoint.NewPartContainer(
+		self.config_obj, self.level, request_number)
+	if err != nil {
+		return err
+	}
+	defer part_container.Close()
+
+	builder.Uploader = part_container
+	subscope := manager.BuildScope(builder)
+	subscope.AppendVars(env)
+	defer subscope.Close()
+
+	first_stat := len(self.collection_context.QueryStats)
+	part = &checkpointPart{
+		RequestNumber: request_number,
+		Filename:      filename,
+	}
+
+	for _, query := range vql_request.Query {
+		if query.Name != "" {
+			part.Artifacts = append(part.Artifacts, query.Name)
+		}
+
+		err := self.collectQuery(part_container, subscope, query)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Do not record partially collected requests.
+	if self.ctx.Err() != nil {
+		return nil
+	}
+
+	err = part_container.Close()
+	if err != nil {
+		return err
+	}
+
+	stats := part_container.Stats()
+	part.UploadedFiles = stats.TotalUploadedFiles
+	part.UploadedBytes = stats.TotalUploadedBytes
+	part.QueryStats = self.collection_context.QueryStats[first_stat:]
+
+	return self.checkpoint.CompletePart(part)
+}
+
 func (self *collectionManager) SetTimeout(ns float64) {
 	go func() {
 		start := Clock.Now()
@@ -431,6 +543,7 @@ func (self *collectionManager) MakeContainer(filename, password string, level in
 	}
 
 	self.scope.Log("Setting compression level to %v", level)
+	self.level = level
 
 	self.Output = filename
 	self.container, err = reporting.NewContainer(
@@ -459,6 +572,14 @@ func (self *collectionManager) Close() error {
 		self.log_file.Close()
 	}
 
+	// Copy the checkpointed parts into the final container.
+	if self.checkpoint != nil {
+		err := self.checkpoint.MergeInto(self.container)
+		if err != nil {
+			self.scope.Log("collect: Unable to merge checkpoint: %v", err)
+		}
+	}
+
 	fd, err := self.container.Create("collection_context.json", Clock.Now())
 	if err == nil {
 		self.collection_context.StartTime = uint64(self.start_time.UnixNano())
@@ -470,7 +591,12 @@ func (self *collectionManager) Close() error {
 		container_stats := self.container.Stats()
 		self.collection_context.TotalUploadedFiles = container_stats.TotalUploadedFiles
 		self.collection_context.TotalUploadedBytes = container_stats.TotalUploadedBytes
-		self.collection_context.TotalExpectedUploadedBytes = container_stats.TotalUploadedBytes
+		if self.checkpoint != nil {
+			uploaded_files, uploaded_bytes := self.checkpoint.Stats()
+			self.collection_context.TotalUploadedFiles += uploaded_files
+			self.collection_context.TotalUploadedBytes += uploaded_bytes
+		}
+		self.collection_context.TotalExpectedUploadedBytes = self.collection_context.TotalUploadedBytes
 
 		fd.Write([]byte(json.MustMarshalIndent(self.collection_context)))
 		fd.Close()
@@ -491,6 +617,12 @@ func (self *collectionManager) Close() error {
 	// Finalize the container now.
 	err = self.container.Close()
 
+	// The checkpoint is no longer needed once the collection is
+	// complete.
+	if err == nil && self.checkpoint != nil && self.completed {
+		err = self.checkpoint.Remove()
+	}
+
 	// Emit the result set for consumption by the
 	// rest of the query.
 	select {
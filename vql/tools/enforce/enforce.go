@@ -0,0 +1,273 @@
+/*
+  Process blocking and kill-by-rule response actions.
+
+  The enforce_process_rules() plugin matches processes against rules
+  pushed from the server. A rule matches an executable by its hash,
+  its path or the subject of its Authenticode signature, and either
+  kills the matching processes or only reports them. Every match is
+  reported as a row so the server has a record of each enforcement.
+
+  Blocking execution before a process runs requires a kernel
+  component (a minifilter or WFP callout on Windows, eBPF LSM on
+  Linux or an Endpoint Security extension on macOS) which the client
+  does not ship. Instead new processes are examined as soon as the
+  platform reports them (the process events connector on Linux and
+  the WMI process start trace on Windows) and matching processes are
+  killed immediately. The process list is also polled, which is the
+  only mechanism on other platforms, to catch any missed events.
+*/
+
+package enforce
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/shirou/gopsutil/v3/process"
+	"www.velocidex.com/golang/velociraptor/acls"
+	utils "www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+const (
+	// Poll period when process start notifications are not
+	// available.
+	DEFAULT_PERIOD = 1 // Seconds
+
+	// Poll period to catch missed process start notifications.
+	DEFAULT_NOTIFY_PERIOD = 10 // Seconds
+
+	// Never touch the idle, init or System processes.
+	MIN_PID = 4
+)
+
+type EnforceProcessRulesArgs struct {
+	Rules  vfilter.StoredQuery `vfilter:"required,field=rules,doc=A query returning the rules with columns Name, Action (kill or report), Hash, Path and Signer."`
+	Period int64               `vfilter:"optional,field=period,doc=Poll the process list this often in seconds (default 1, or 10 when process start notifications are available)."`
+	Once   bool                `vfilter:"optional,field=once,doc=Only examine the currently running processes and exit."`
+}
+
+// The identity of a process we already examined. Pids are reused
+// and exec replaces the executable so both are needed.
+type seenProcess struct {
+	create_time int64
+	exe         string
+}
+
+type engine struct {
+	scope       vfilter.Scope
+	output_chan chan vfilter.Row
+
+	rules []*rule
+	cache *fileCache
+	seen  map[int32]seenProcess
+
+	self_pid int32
+}
+
+func newEngine(scope vfilter.Scope,
+	output_chan chan vfilter.Row, rules []*rule) *engine {
+	return &engine{
+		scope:       scope,
+		output_chan: output_chan,
+		rules:       rules,
+		cache:       newFileCache(),
+		seen:        make(map[int32]seenProcess),
+		self_pid:    int32(os.Getpid()),
+	}
+}
+
+func (self *engine) examine(ctx context.Context, pid int32) {
+	if pid <= MIN_PID || pid == self.self_pid {
+		return
+	}
+
+	info, err := getProcessInfo(ctx, pid, self.cache)
+	if err != nil {
+		return
+	}
+
+	key := seenProcess{create_time: info.CreateTime, exe: info.Exe}
+	if self.seen[pid] == key {
+		return
+	}
+	self.seen[pid] = key
+
+	for _, rule := range self.rules {
+		if rule.Match(info) {
+			self.enforce(ctx, rule, info)
+			return
+		}
+	}
+}
+
+func (self *engine) enforce(
+	ctx context.Context, rule *rule, info *processInfo) {
+	result := "Reported"
+	if rule.Action == ACTION_KILL {
+		err := info.proc.KillWithContext(ctx)
+		if err != nil {
+			result = fmt.Sprintf("Failed: %v", err)
+		} else {
+			result = "Killed"
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+	case self.output_chan <- ordereddict.NewDict().
+		Set("Time", utils.GetTime().Now().UTC()).
+		Set("Rule", rule.Name).
+		Set("Action", rule.Action).
+		Set("Pid", info.Pid).
+		Set("Ppid", info.Ppid).
+		Set("Name", info.Name).
+		Set("Exe", info.Exe).
+		Set("CommandLine", info.CommandLine).
+		Set("Username", info.Username).
+		Set("Hash", info.SHA256()).
+		Set("Signer", info.Signer()).
+		Set("Result", result):
+	}
+}
+
+// Examine all the running processes we have not seen before.
+func (self *engine) sweep(ctx context.Context) {
+	pids, err := process.PidsWithContext(ctx)
+	if err != nil {
+		self.scope.Log("enforce_process_rules: %v", err)
+		return
+	}
+
+	running := make(map[int32]bool)
+	for _, pid := range pids {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		running[pid] = true
+		self.examine(ctx, pid)
+	}
+
+	for pid := range self.seen {
+		if !running[pid] {
+			delete(self.seen, pid)
+		}
+	}
+}
+
+func (self *engine) run(ctx context.Context, period time.Duration) {
+	var pids <-chan int32
+
+	notifier, err := newNotifier(ctx, self.scope)
+	if err != nil {
+		if period == 0 {
+			period = DEFAULT_PERIOD * time.Second
+		}
+		self.scope.Log("enforce_process_rules: process start notifications "+
+			"are not available (%v), polling every %v", err, period)
+	} else {
+		defer notifier.Close()
+		pids = notifier.Pids()
+
+		if period == 0 {
+			period = DEFAULT_NOTIFY_PERIOD * time.Second
+		}
+	}
+
+	self.sweep(ctx)
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case pid, ok := <-pids:
+			if !ok {
+				pids = nil
+				continue
+			}
+			self.examine(ctx, pid)
+
+		case <-ticker.C:
+			self.sweep(ctx)
+		}
+	}
+}
+
+type EnforceProcessRulesPlugin struct{}
+
+func (self EnforceProcessRulesPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+
+		err := vql_subsystem.CheckAccess(scope, acls.MACHINE_STATE)
+		if err != nil {
+			scope.Log("enforce_process_rules: %v", err)
+			return
+		}
+
+		arg := &EnforceProcessRulesArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("enforce_process_rules: %v", err)
+			return
+		}
+
+		rules, err := parseRules(ctx, scope, arg.Rules)
+		if err != nil {
+			scope.Log("enforce_process_rules: %v", err)
+			return
+		}
+
+		// Killing processes is a privileged operation.
+		for _, rule := range rules {
+			if rule.Action == ACTION_KILL {
+				err := vql_subsystem.CheckAccess(scope, acls.EXECVE)
+				if err != nil {
+					scope.Log("enforce_process_rules: %v", err)
+					return
+				}
+				break
+			}
+		}
+
+		enforcer := newEngine(scope, output_chan, rules)
+		if arg.Once {
+			enforcer.sweep(ctx)
+			return
+		}
+
+		enforcer.run(ctx, time.Duration(arg.Period)*time.Second)
+	}()
+
+	return output_chan
+}
+
+func (self EnforceProcessRulesPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "enforce_process_rules",
+		Doc:     "Kill or report processes matching hash, path or signer rules.",
+		ArgType: type_map.AddType(scope, &EnforceProcessRulesArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&EnforceProcessRulesPlugin{})
+}
@@ -0,0 +1,136 @@
+package enforce
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+func TestParseRule(t *testing.T) {
+	_, err := parseRule(ordereddict.NewDict().
+		Set("Name", "Everything"))
+	assert.Error(t, err)
+
+	_, err = parseRule(ordereddict.NewDict().
+		Set("Name", "BadHash").
+		Set("Hash", "1234"))
+	assert.Error(t, err)
+
+	_, err = parseRule(ordereddict.NewDict().
+		Set("Name", "BadAction").
+		Set("Action", "quarantine").
+		Set("Path", "evil.exe"))
+	assert.Error(t, err)
+
+	rule, err := parseRule(ordereddict.NewDict().
+		Set("Name", "Evil").
+		Set("Path", `\\evil\.exe$`))
+	assert.NoError(t, err)
+	assert.Equal(t, ACTION_KILL, rule.Action)
+
+	assert.True(t, rule.Match(&processInfo{Exe: `C:\Temp\EVIL.exe`}))
+	assert.False(t, rule.Match(&processInfo{Exe: `C:\Temp\notevil.exe`}))
+}
+
+// Start a copy of sleep with a unique name so the rules only match
+// it.
+func startProcess(t *testing.T, dir string) (*exec.Cmd, string) {
+	sleep, err := exec.LookPath("sleep")
+	if err != nil {
+		t.Skip("sleep is not available")
+	}
+
+	data, err := ioutil.ReadFile(sleep)
+	assert.NoError(t, err)
+
+	path := filepath.Join(dir, "velociraptor_enforce_test")
+	assert.NoError(t, ioutil.WriteFile(path, data, 0755))
+
+	cmd := exec.Command(path, "60")
+	assert.NoError(t, cmd.Start())
+
+	sum := sha256.Sum256(data)
+	return cmd, hex.EncodeToString(sum[:])
+}
+
+func enforceOnce(t *testing.T, rules []*ordereddict.Dict) []*ordereddict.Dict {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}).
+		Set("Rules", rules))
+	defer scope.Close()
+
+	query, err := vfilter.Parse("SELECT * FROM Rules")
+	assert.NoError(t, err)
+
+	result := []*ordereddict.Dict{}
+	for row := range (EnforceProcessRulesPlugin{}).Call(ctx, scope,
+		ordereddict.NewDict().
+			Set("rules", query).
+			Set("once", true)) {
+		result = append(result, row.(*ordereddict.Dict))
+	}
+	return result
+}
+
+func TestEnforceProcessRules(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Only supported on linux")
+	}
+
+	dir, err := ioutil.TempDir("", "enforce")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cmd, hash := startProcess(t, dir)
+	defer cmd.Process.Kill()
+
+	// Report only rules leave the process running.
+	rows := enforceOnce(t, []*ordereddict.Dict{
+		ordereddict.NewDict().
+			Set("Name", "ByHash").
+			Set("Action", "report").
+			Set("Hash", hash),
+	})
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, "ByHash", utils.GetString(rows[0], "Rule"))
+	assert.Equal(t, "Reported", utils.GetString(rows[0], "Result"))
+	assert.Equal(t, int64(cmd.Process.Pid), utils.GetInt64(rows[0], "Pid"))
+	assert.Equal(t, hash, utils.GetString(rows[0], "Hash"))
+
+	// Rules are matched in order and a process is only enforced once.
+	rows = enforceOnce(t, []*ordereddict.Dict{
+		ordereddict.NewDict().
+			Set("Name", "Signed").
+			Set("Path", "velociraptor_enforce_test$").
+			Set("Signer", "Microsoft"),
+		ordereddict.NewDict().
+			Set("Name", "ByPath").
+			Set("Path", "velociraptor_enforce_test$"),
+		ordereddict.NewDict().
+			Set("Name", "ByHash").
+			Set("Hash", hash),
+	})
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, "ByPath", utils.GetString(rows[0], "Rule"))
+	assert.Equal(t, "Killed", utils.GetString(rows[0], "Result"))
+
+	// The process was killed.
+	assert.Error(t, cmd.Wait())
+}
@@ -0,0 +1,15 @@
+package enforce
+
+import "errors"
+
+var (
+	NotSupportedError = errors.New(
+		"Process start notifications are not supported on this platform")
+)
+
+// Reports the pids of processes as they start so they can be
+// examined immediately rather than at the next poll.
+type startNotifier interface {
+	Pids() <-chan int32
+	Close()
+}
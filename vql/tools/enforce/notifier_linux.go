@@ -0,0 +1,204 @@
+// +build linux
+
+package enforce
+
+import (
+	"context"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+	"www.velocidex.com/golang/vfilter"
+)
+
+// From linux/connector.h and linux/cn_proc.h
+const (
+	CN_IDX_PROC = 1
+	CN_VAL_PROC = 1
+
+	PROC_CN_MCAST_LISTEN = 1
+	PROC_EVENT_EXEC      = 0x00000002
+
+	// How often the reader checks if it should exit.
+	POLL_TIMEOUT_MS = 500
+)
+
+type cnMsg struct {
+	Idx   uint32
+	Val   uint32
+	Seq   uint32
+	Ack   uint32
+	Len   uint16
+	Flags uint16
+}
+
+type procEventHeader struct {
+	What      uint32
+	Cpu       uint32
+	Timestamp uint64
+}
+
+type execProcEvent struct {
+	Pid  uint32
+	Tgid uint32
+}
+
+type listenMsg struct {
+	Header unix.NlMsghdr
+	Msg    cnMsg
+	Op     uint32
+}
+
+// Receives exec events from the kernel's process events connector.
+// This requires root (CAP_NET_ADMIN).
+type procConnector struct {
+	fd     int
+	scope  vfilter.Scope
+	output chan int32
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func newNotifier(ctx context.Context,
+	scope vfilter.Scope) (startNotifier, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK,
+		unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.NETLINK_CONNECTOR)
+	if err != nil {
+		return nil, err
+	}
+
+	err = unix.Bind(fd, &unix.SockaddrNetlink{
+		Family: unix.AF_NETLINK,
+		Groups: CN_IDX_PROC,
+	})
+	if err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	msg := listenMsg{
+		Header: unix.NlMsghdr{
+			Len:  uint32(unsafe.Sizeof(listenMsg{})),
+			Type: unix.NLMSG_DONE,
+		},
+		Msg: cnMsg{
+			Idx: CN_IDX_PROC,
+			Val: CN_VAL_PROC,
+			Len: uint16(unsafe.Sizeof(uint32(0))),
+		},
+		Op: PROC_CN_MCAST_LISTEN,
+	}
+	buf := (*[unsafe.Sizeof(listenMsg{})]byte)(unsafe.Pointer(&msg))[:]
+
+	err = unix.Sendto(fd, buf, 0, &unix.SockaddrNetlink{
+		Family: unix.AF_NETLINK,
+	})
+	if err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	subctx, cancel := context.WithCancel(ctx)
+	result := &procConnector{
+		fd:     fd,
+		scope:  scope,
+		output: make(chan int32, 1000),
+		cancel: cancel,
+	}
+
+	result.wg.Add(1)
+	go result.readEvents(subctx)
+
+	return result, nil
+}
+
+func (self *procConnector) Pids() <-chan int32 {
+	return self.output
+}
+
+func (self *procConnector) Close() {
+	self.cancel()
+	self.wg.Wait()
+	unix.Close(self.fd)
+}
+
+func (self *procConnector) readEvents(ctx context.Context) {
+	defer self.wg.Done()
+	defer close(self.output)
+
+	buf := make([]byte, 64*1024)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		fds := []unix.PollFd{{Fd: int32(self.fd), Events: unix.POLLIN}}
+		n, err := unix.Poll(fds, POLL_TIMEOUT_MS)
+		if err == unix.EINTR || n == 0 {
+			continue
+		}
+		if err != nil {
+			self.scope.Log("enforce_process_rules: %v", err)
+			return
+		}
+
+		n, _, err = unix.Recvfrom(self.fd, buf, unix.MSG_DONTWAIT)
+		if err == unix.EAGAIN || err == unix.EINTR {
+			continue
+		}
+
+		// The socket buffer overflowed and events were lost - the
+		// next poll will pick up the missed processes.
+		if err == unix.ENOBUFS {
+			continue
+		}
+		if err != nil {
+			self.scope.Log("enforce_process_rules: %v", err)
+			return
+		}
+
+		messages, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			continue
+		}
+
+		for _, message := range messages {
+			pid, ok := parseExecEvent(message.Data)
+			if !ok {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case self.output <- pid:
+			}
+		}
+	}
+}
+
+func parseExecEvent(data []byte) (int32, bool) {
+	header_offset := int(unsafe.Sizeof(cnMsg{}))
+	event_offset := header_offset + int(unsafe.Sizeof(procEventHeader{}))
+	if len(data) < event_offset+int(unsafe.Sizeof(execProcEvent{})) {
+		return 0, false
+	}
+
+	msg := (*cnMsg)(unsafe.Pointer(&data[0]))
+	if msg.Idx != CN_IDX_PROC || msg.Val != CN_VAL_PROC {
+		return 0, false
+	}
+
+	header := (*procEventHeader)(unsafe.Pointer(&data[header_offset]))
+	if header.What != PROC_EVENT_EXEC {
+		return 0, false
+	}
+
+	// The tgid is the process id - the pid is the thread which
+	// called exec.
+	event := (*execProcEvent)(unsafe.Pointer(&data[event_offset]))
+	return int32(event.Tgid), true
+}
@@ -0,0 +1,14 @@
+// +build !linux,!windows
+
+package enforce
+
+import (
+	"context"
+
+	"www.velocidex.com/golang/vfilter"
+)
+
+func newNotifier(ctx context.Context,
+	scope vfilter.Scope) (startNotifier, error) {
+	return nil, NotSupportedError
+}
@@ -0,0 +1,73 @@
+// +build windows
+
+package enforce
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	PROCESS_START_QUERY = "SELECT * FROM Win32_ProcessStartTrace"
+
+	// wmi_events() requires a timeout - use a year.
+	WMI_WAIT = 365 * 24 * 60 * 60
+)
+
+// Receives process start events from the WMI process start trace.
+type wmiNotifier struct {
+	output chan int32
+	cancel func()
+}
+
+func newNotifier(ctx context.Context,
+	scope vfilter.Scope) (startNotifier, error) {
+	plugin, pres := scope.GetPlugin("wmi_events")
+	if !pres {
+		return nil, NotSupportedError
+	}
+
+	subctx, cancel := context.WithCancel(ctx)
+	result := &wmiNotifier{
+		output: make(chan int32, 1000),
+		cancel: cancel,
+	}
+
+	events := plugin.Call(subctx, scope, ordereddict.NewDict().
+		Set("query", PROCESS_START_QUERY).
+		Set("namespace", "ROOT/CIMV2").
+		Set("wait", WMI_WAIT))
+
+	go func() {
+		defer close(result.output)
+
+		for row := range events {
+			value, _ := scope.Associative(row, "ProcessID")
+			pid, ok := utils.ToInt64(value)
+			if !ok {
+				continue
+			}
+
+			select {
+			case <-subctx.Done():
+				return
+			case result.output <- int32(pid):
+			}
+		}
+	}()
+
+	return result, nil
+}
+
+func (self *wmiNotifier) Pids() <-chan int32 {
+	return self.output
+}
+
+// The WMI query only notices it was cancelled when the next event
+// arrives so do not wait for it here.
+func (self *wmiNotifier) Close() {
+	self.cancel()
+}
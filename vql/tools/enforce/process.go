@@ -0,0 +1,221 @@
+package enforce
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/shirou/gopsutil/v3/process"
+	"www.velocidex.com/golang/go-pe"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	// Larger executables are not hashed.
+	MAX_HASH_SIZE = 100 * 1024 * 1024
+
+	// The cache is cleared when it grows beyond this.
+	MAX_CACHE_SIZE = 10000
+)
+
+type fileHashes struct {
+	MD5    string
+	SHA1   string
+	SHA256 string
+}
+
+// Hashes and signers are expensive to calculate so they are only
+// calculated when a rule needs them, and cached for as long as the
+// executable does not change.
+type fileInfo struct {
+	mu sync.Mutex
+
+	path  string
+	size  int64
+	mtime int64
+
+	hashed bool
+	hashes *fileHashes
+
+	signer_checked bool
+	signer         string
+}
+
+func (self *fileInfo) Hashes() *fileHashes {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if !self.hashed {
+		self.hashed = true
+		self.hashes = hashFile(self.path, self.size)
+	}
+	return self.hashes
+}
+
+func (self *fileInfo) Signer() string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if !self.signer_checked {
+		self.signer_checked = true
+		self.signer = getSigner(self.path)
+	}
+	return self.signer
+}
+
+func hashFile(path string, size int64) *fileHashes {
+	if size > MAX_HASH_SIZE {
+		return nil
+	}
+
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer fd.Close()
+
+	md5_sum := md5.New()
+	sha1_sum := sha1.New()
+	sha256_sum := sha256.New()
+
+	_, err = io.Copy(io.MultiWriter(md5_sum, sha1_sum, sha256_sum), fd)
+	if err != nil {
+		return nil
+	}
+
+	return &fileHashes{
+		MD5:    hex.EncodeToString(md5_sum.Sum(nil)),
+		SHA1:   hex.EncodeToString(sha1_sum.Sum(nil)),
+		SHA256: hex.EncodeToString(sha256_sum.Sum(nil)),
+	}
+}
+
+// The subject of the embedded Authenticode signature. Only PE files
+// are supported - other files are reported as unsigned.
+func getSigner(path string) string {
+	fd, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer fd.Close()
+
+	pe_file, err := pe.NewPEFile(fd)
+	if err != nil {
+		return ""
+	}
+
+	pkcs7_obj, err := pe.ParseAuthenticode(pe_file)
+	if err != nil {
+		return ""
+	}
+
+	return utils.GetString(pe.PKCS7ToOrderedDict(pkcs7_obj), "Signer.Subject")
+}
+
+type fileCache struct {
+	mu    sync.Mutex
+	items map[string]*fileInfo
+}
+
+func (self *fileCache) Get(path string) *fileInfo {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	item, pres := self.items[path]
+	if pres && item.size == stat.Size() &&
+		item.mtime == stat.ModTime().UnixNano() {
+		return item
+	}
+
+	if len(self.items) > MAX_CACHE_SIZE {
+		self.items = make(map[string]*fileInfo)
+	}
+
+	item = &fileInfo{
+		path:  path,
+		size:  stat.Size(),
+		mtime: stat.ModTime().UnixNano(),
+	}
+	self.items[path] = item
+	return item
+}
+
+func newFileCache() *fileCache {
+	return &fileCache{
+		items: make(map[string]*fileInfo),
+	}
+}
+
+type processInfo struct {
+	proc *process.Process
+
+	Pid         int32
+	Ppid        int32
+	CreateTime  int64
+	Name        string
+	Exe         string
+	CommandLine string
+	Username    string
+
+	file *fileInfo
+}
+
+func (self *processInfo) Hashes() *fileHashes {
+	if self.file == nil {
+		return nil
+	}
+	return self.file.Hashes()
+}
+
+func (self *processInfo) Signer() string {
+	if self.file == nil {
+		return ""
+	}
+	return self.file.Signer()
+}
+
+func (self *processInfo) SHA256() string {
+	hashes := self.Hashes()
+	if hashes == nil {
+		return ""
+	}
+	return hashes.SHA256
+}
+
+func getProcessInfo(ctx context.Context,
+	pid int32, cache *fileCache) (*processInfo, error) {
+	proc, err := process.NewProcessWithContext(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &processInfo{
+		proc: proc,
+		Pid:  pid,
+	}
+
+	// Not all the details are available for all processes so
+	// errors are ignored.
+	result.CreateTime, _ = proc.CreateTimeWithContext(ctx)
+	result.Ppid, _ = proc.PpidWithContext(ctx)
+	result.Name, _ = proc.NameWithContext(ctx)
+	result.Exe, _ = proc.ExeWithContext(ctx)
+	result.CommandLine, _ = proc.CmdlineWithContext(ctx)
+	result.Username, _ = proc.UsernameWithContext(ctx)
+
+	if result.Exe != "" {
+		result.file = cache.Get(result.Exe)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package enforce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	ACTION_KILL   = "kill"
+	ACTION_REPORT = "report"
+)
+
+// A rule matches a process when all of its criteria match.
+type rule struct {
+	Name   string
+	Action string
+
+	// A MD5, SHA1 or SHA256 of the executable (lower case hex).
+	Hash   string
+	Path   *regexp.Regexp
+	Signer *regexp.Regexp
+}
+
+func (self *rule) Match(info *processInfo) bool {
+	if self.Path != nil && !self.Path.MatchString(info.Exe) {
+		return false
+	}
+
+	if self.Hash != "" {
+		hashes := info.Hashes()
+		if hashes == nil {
+			return false
+		}
+
+		switch self.Hash {
+		case hashes.MD5, hashes.SHA1, hashes.SHA256:
+		default:
+			return false
+		}
+	}
+
+	if self.Signer != nil && !self.Signer.MatchString(info.Signer()) {
+		return false
+	}
+
+	return true
+}
+
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+func parseRule(row *ordereddict.Dict) (*rule, error) {
+	get := func(field string) string {
+		value, _ := row.GetString(field)
+		return strings.TrimSpace(value)
+	}
+
+	result := &rule{
+		Name:   get("Name"),
+		Action: strings.ToLower(get("Action")),
+		Hash:   strings.ToLower(get("Hash")),
+	}
+
+	if result.Name == "" {
+		return nil, errors.New("Rule has no Name")
+	}
+
+	switch result.Action {
+	case "":
+		result.Action = ACTION_KILL
+	case ACTION_KILL, ACTION_REPORT:
+	default:
+		return nil, fmt.Errorf("Rule %v: unknown action %v",
+			result.Name, result.Action)
+	}
+
+	switch len(result.Hash) {
+	case 0, 32, 40, 64:
+	default:
+		return nil, fmt.Errorf("Rule %v: Hash must be a MD5, SHA1 or SHA256",
+			result.Name)
+	}
+
+	var err error
+	result.Path, err = compileRegex(get("Path"))
+	if err != nil {
+		return nil, fmt.Errorf("Rule %v: Path: %w", result.Name, err)
+	}
+
+	result.Signer, err = compileRegex(get("Signer"))
+	if err != nil {
+		return nil, fmt.Errorf("Rule %v: Signer: %w", result.Name, err)
+	}
+
+	// A rule without criteria would match every process.
+	if result.Hash == "" && result.Path == nil && result.Signer == nil {
+		return nil, fmt.Errorf(
+			"Rule %v: at least one of Hash, Path or Signer is required",
+			result.Name)
+	}
+
+	return result, nil
+}
+
+func parseRules(ctx context.Context,
+	scope vfilter.Scope, query vfilter.StoredQuery) ([]*rule, error) {
+	result := []*rule{}
+	for row := range query.Eval(ctx, scope) {
+		rule, err := parseRule(vfilter.RowToDict(ctx, scope, row))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rule)
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("No rules provided")
+	}
+	return result, nil
+}
@@ -0,0 +1,383 @@
+/*
+  Explain a VQL query.
+
+  The query is evaluated in a new scope where every plugin and
+  function is wrapped by a profiler. The profiler counts the calls
+  and rows of each plugin and the time spent in it, and attributes
+  them to the top level statement (stage) being evaluated, so
+  artifact authors can see which part of a slow query takes the
+  time.
+
+  Note that LET statements are lazy - the time spent evaluating a
+  stored query is attributed to the stage which uses it. Timings are
+  inclusive: a plugin's duration includes the time spent by its
+  consumers and the time of any functions called on its rows.
+*/
+
+package explain
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type callStats struct {
+	name     string
+	type_    string
+	calls    uint64
+	rows     uint64
+	duration time.Duration
+}
+
+type profiler struct {
+	mu sync.Mutex
+
+	// The current stage
+	stage  int
+	calls  map[string]*callStats
+	stages map[int]map[string]bool
+}
+
+func newProfiler() *profiler {
+	return &profiler{
+		calls:  make(map[string]*callStats),
+		stages: make(map[int]map[string]bool),
+	}
+}
+
+func (self *profiler) getStats(type_, name string) *callStats {
+	key := type_ + ":" + name
+	stats, pres := self.calls[key]
+	if !pres {
+		stats = &callStats{name: name, type_: type_}
+		self.calls[key] = stats
+	}
+	return stats
+}
+
+func (self *profiler) call(type_, name string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.getStats(type_, name).calls++
+
+	if type_ == "plugin" {
+		plugins, pres := self.stages[self.stage]
+		if !pres {
+			plugins = make(map[string]bool)
+			self.stages[self.stage] = plugins
+		}
+		plugins[name] = true
+	}
+}
+
+func (self *profiler) row(type_, name string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.getStats(type_, name).rows++
+}
+
+func (self *profiler) done(type_, name string, duration time.Duration) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.getStats(type_, name).duration += duration
+}
+
+func (self *profiler) setStage(stage int) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.stage = stage
+}
+
+func (self *profiler) stagePlugins(stage int) []string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := []string{}
+	for name := range self.stages[stage] {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// The slowest calls first.
+func (self *profiler) getCalls() []*api_proto.ExplainCall {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := []*api_proto.ExplainCall{}
+	for _, stats := range self.calls {
+		result = append(result, &api_proto.ExplainCall{
+			Name:       stats.name,
+			Type:       stats.type_,
+			Calls:      stats.calls,
+			Rows:       stats.rows,
+			DurationMs: durationMs(stats.duration),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].DurationMs == result[j].DurationMs {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].DurationMs > result[j].DurationMs
+	})
+
+	return result
+}
+
+type profiledPlugin struct {
+	delegate vfilter.PluginGeneratorInterface
+	name     string
+	profiler *profiler
+}
+
+func (self profiledPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	self.profiler.call("plugin", self.name)
+
+	go func() {
+		defer close(output_chan)
+
+		start := time.Now()
+		defer func() {
+			self.profiler.done("plugin", self.name, time.Since(start))
+		}()
+
+		for row := range self.delegate.Call(ctx, scope, args) {
+			self.profiler.row("plugin", self.name)
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self profiledPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return self.delegate.Info(scope, type_map)
+}
+
+type profiledFunction struct {
+	delegate vfilter.FunctionInterface
+	name     string
+	profiler *profiler
+}
+
+func (self *profiledFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+	self.profiler.call("function", self.name)
+
+	start := time.Now()
+	defer func() {
+		self.profiler.done("function", self.name, time.Since(start))
+	}()
+
+	return self.delegate.Call(ctx, scope, args)
+}
+
+func (self *profiledFunction) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return self.delegate.Info(scope, type_map)
+}
+
+// Each reference in the query gets its own copy of the function so
+// aggregate functions keep their own state.
+func (self *profiledFunction) Copy() vfilter.FunctionInterface {
+	return &profiledFunction{
+		delegate: vfilter.CopyFunction(self.delegate),
+		name:     self.name,
+		profiler: self.profiler,
+	}
+}
+
+// Run the query in a sub scope of scope and report the time spent
+// in each stage, plugin and function. The rows of the query are
+// discarded.
+func Explain(ctx context.Context, scope vfilter.Scope,
+	query string) (*api_proto.ExplainResponse, error) {
+	statements, err := vfilter.MultiParse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	profiler := newProfiler()
+
+	// A new scope has its own copy of the plugins so wrapping them
+	// does not affect the caller.
+	subscope := scope.NewScope()
+	subscope.AppendVars(scope)
+	defer subscope.Close()
+
+	info := scope.Describe(nil)
+	for _, plugin_info := range info.Plugins {
+		plugin, pres := scope.GetPlugin(plugin_info.Name)
+		if pres {
+			subscope.AppendPlugins(profiledPlugin{
+				delegate: plugin,
+				name:     plugin_info.Name,
+				profiler: profiler,
+			})
+		}
+	}
+
+	for _, function_info := range info.Functions {
+		function, pres := scope.GetFunction(function_info.Name)
+		if pres {
+			subscope.AppendFunctions(&profiledFunction{
+				delegate: function,
+				name:     function_info.Name,
+				profiler: profiler,
+			})
+		}
+	}
+
+	result := &api_proto.ExplainResponse{}
+	query_start := time.Now()
+
+	for idx, vql := range statements {
+		profiler.setStage(idx)
+
+		stage := &api_proto.ExplainStage{
+			Stage: uint64(idx),
+			Query: vfilter.FormatToString(subscope, vql),
+		}
+
+		start := time.Now()
+		row_chan := vql.Eval(ctx, subscope)
+	get_rows:
+		for {
+			select {
+			case <-ctx.Done():
+				break get_rows
+
+			case _, ok := <-row_chan:
+				if !ok {
+					break get_rows
+				}
+				stage.Rows++
+			}
+		}
+
+		stage.DurationMs = durationMs(time.Since(start))
+		stage.Plugins = profiler.stagePlugins(idx)
+		result.Stages = append(result.Stages, stage)
+	}
+
+	result.DurationMs = durationMs(time.Since(query_start))
+	result.Calls = profiler.getCalls()
+
+	return result, nil
+}
+
+func durationMs(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / 1000
+}
+
+type ExplainPluginArgs struct {
+	Query string            `vfilter:"required,field=query,doc=The VQL query to explain."`
+	Env   *ordereddict.Dict `vfilter:"optional,field=env,doc=A dict of args to insert into the scope."`
+}
+
+type ExplainPlugin struct{}
+
+func (self ExplainPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		// Like query(), the query runs with the privileges of the
+		// calling query so there is no permissions check.
+		arg := &ExplainPluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("explain: %v", err)
+			return
+		}
+
+		subscope := scope.Copy()
+		if arg.Env != nil {
+			subscope.AppendVars(arg.Env)
+		}
+		defer subscope.Close()
+
+		result, err := Explain(ctx, subscope, arg.Query)
+		if err != nil {
+			scope.Log("explain: %v", err)
+			return
+		}
+
+		for _, stage := range result.Stages {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- ordereddict.NewDict().
+				Set("Type", "stage").
+				Set("Stage", stage.Stage).
+				Set("Name", strings.Join(stage.Plugins, ", ")).
+				Set("Query", stage.Query).
+				Set("Calls", 1).
+				Set("Rows", stage.Rows).
+				Set("DurationMs", stage.DurationMs):
+			}
+		}
+
+		for _, call := range result.Calls {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- ordereddict.NewDict().
+				Set("Type", call.Type).
+				Set("Stage", vfilter.Null{}).
+				Set("Name", call.Name).
+				Set("Query", "").
+				Set("Calls", call.Calls).
+				Set("Rows", call.Rows).
+				Set("DurationMs", call.DurationMs):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self ExplainPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "explain",
+		Doc: "Run a query and report the rows and time of each stage, " +
+			"and the calls, rows and time of each plugin and function.",
+		ArgType: type_map.AddType(scope, &ExplainPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&ExplainPlugin{})
+}
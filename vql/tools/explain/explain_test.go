@@ -0,0 +1,75 @@
+package explain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+)
+
+func TestExplain(t *testing.T) {
+	ctx := context.Background()
+	scope := vql_subsystem.MakeScope().AppendVars(
+		ordereddict.NewDict().Set("Start", 1))
+	defer scope.Close()
+
+	result, err := Explain(ctx, scope, `
+LET X = SELECT _value FROM range(start=Start, end=11, step=1)
+SELECT format(format="%v", args=_value) AS A FROM X WHERE _value > 5
+SELECT count() AS C FROM X GROUP BY 1
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(result.Stages))
+
+	// The LET statement is lazy so does not call any plugins.
+	assert.Equal(t, uint64(0), result.Stages[0].Rows)
+	assert.Equal(t, []string{}, result.Stages[0].Plugins)
+
+	assert.Equal(t, uint64(5), result.Stages[1].Rows)
+	assert.Equal(t, []string{"range"}, result.Stages[1].Plugins)
+	assert.Equal(t, uint64(1), result.Stages[2].Rows)
+
+	calls := make(map[string]uint64)
+	rows := make(map[string]uint64)
+	for _, call := range result.Calls {
+		calls[call.Type+":"+call.Name] = call.Calls
+		rows[call.Type+":"+call.Name] = call.Rows
+	}
+
+	assert.Equal(t, uint64(2), calls["plugin:range"])
+	assert.Equal(t, uint64(20), rows["plugin:range"])
+	assert.Equal(t, uint64(5), calls["function:format"])
+	assert.Equal(t, uint64(10), calls["function:count"])
+
+	// The caller's plugins are not changed.
+	plugin, _ := scope.GetPlugin("range")
+	_, ok := plugin.(profiledPlugin)
+	assert.False(t, ok)
+}
+
+func TestExplainPlugin(t *testing.T) {
+	ctx := context.Background()
+	scope := vql_subsystem.MakeScope()
+	defer scope.Close()
+
+	vql, err := vfilter.Parse(`
+SELECT * FROM explain(query="SELECT count() AS C FROM range(start=1, end=11, step=1) GROUP BY 1")`)
+	assert.NoError(t, err)
+
+	rows := []vfilter.Row{}
+	for row := range vql.Eval(ctx, scope) {
+		rows = append(rows, row)
+	}
+
+	// One stage row then the range() and count() calls.
+	assert.Equal(t, 3, len(rows))
+
+	stage := rows[0].(*ordereddict.Dict)
+	stage_type, _ := stage.Get("Type")
+	assert.Equal(t, "stage", stage_type)
+	stage_rows, _ := stage.Get("Rows")
+	assert.Equal(t, uint64(1), stage_rows)
+}
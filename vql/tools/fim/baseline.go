@@ -0,0 +1,230 @@
+package fim
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"www.velocidex.com/golang/velociraptor/json"
+)
+
+// The state of a file when it was last hashed. The hash is only
+// recomputed when the stat information changes.
+type entry struct {
+	Size    int64       `json:"size"`
+	Mode    os.FileMode `json:"mode"`
+	ModTime int64       `json:"mtime"`
+	Hash    string      `json:"sha256,omitempty"`
+}
+
+func newEntry(info os.FileInfo) *entry {
+	return &entry{
+		Size:    info.Size(),
+		Mode:    info.Mode(),
+		ModTime: info.ModTime().UnixNano(),
+	}
+}
+
+func (self *entry) sameStat(other *entry) bool {
+	return self.Size == other.Size &&
+		self.Mode == other.Mode &&
+		self.ModTime == other.ModTime
+}
+
+// Describe what changed between the old and new state.
+func (self *entry) changes(new_entry *entry) []string {
+	result := []string{}
+	if self.Hash != new_entry.Hash {
+		result = append(result, "content")
+	}
+	if self.Size != new_entry.Size {
+		result = append(result, "size")
+	}
+	if self.Mode != new_entry.Mode {
+		result = append(result, "mode")
+	}
+	if self.ModTime != new_entry.ModTime {
+		result = append(result, "mtime")
+	}
+	return result
+}
+
+type baselineFile struct {
+	Version int               `json:"version"`
+	Entries map[string]*entry `json:"entries"`
+}
+
+// The baseline of all monitored files. It is optionally persisted to
+// a local file so changes made while the client was not running are
+// detected on the next start.
+type baseline struct {
+	mu sync.Mutex
+
+	path    string
+	entries map[string]*entry
+
+	// Set when the baseline was loaded from disk or after the
+	// first full scan. Before that changes are not reported.
+	established bool
+	dirty       bool
+}
+
+func loadBaseline(path string) (*baseline, error) {
+	result := &baseline{
+		path:    path,
+		entries: make(map[string]*entry),
+	}
+
+	if path == "" {
+		return result, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &baselineFile{}
+	err = json.Unmarshal(data, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.Entries != nil {
+		result.entries = stored.Entries
+	}
+	result.established = true
+
+	return result, nil
+}
+
+func (self *baseline) Get(path string) (*entry, bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result, pres := self.entries[path]
+	return result, pres
+}
+
+func (self *baseline) Set(path string, item *entry) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.entries[path] = item
+	self.dirty = true
+}
+
+func (self *baseline) Delete(path string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	delete(self.entries, path)
+	self.dirty = true
+}
+
+// All the paths at or below the path.
+func (self *baseline) Children(path string) []string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	prefix := strings.TrimSuffix(path, string(filepath.Separator)) +
+		string(filepath.Separator)
+
+	result := []string{}
+	for k := range self.entries {
+		if k == path || strings.HasPrefix(k, prefix) {
+			result = append(result, k)
+		}
+	}
+	return result
+}
+
+func (self *baseline) Paths() []string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := make([]string, 0, len(self.entries))
+	for k := range self.entries {
+		result = append(result, k)
+	}
+	return result
+}
+
+func (self *baseline) Len() int {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return len(self.entries)
+}
+
+func (self *baseline) Established() bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.established
+}
+
+func (self *baseline) SetEstablished() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.established = true
+	self.dirty = true
+}
+
+// Write the baseline if it changed. The file is replaced atomically
+// so a crash never leaves a truncated baseline behind.
+func (self *baseline) Save() error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.path == "" || !self.dirty {
+		return nil
+	}
+
+	data, err := json.Marshal(&baselineFile{
+		Version: 1,
+		Entries: self.entries,
+	})
+	if err != nil {
+		return err
+	}
+
+	tmp_path := self.path + ".tmp"
+	err = ioutil.WriteFile(tmp_path, data, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp_path, self.path)
+	if err != nil {
+		return err
+	}
+
+	self.dirty = false
+	return nil
+}
+
+func hashFile(path string) (string, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer fd.Close()
+
+	hasher := sha256.New()
+	_, err = io.Copy(hasher, fd)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,393 @@
+/*
+  File integrity monitoring.
+
+  The watch_fim() plugin keeps a baseline of the hashes of all files
+  below the configured paths. Rather than re-hashing the entire tree
+  each interval, it listens to the platform's change notifications
+  (inotify on Linux, the USN journal on Windows and FSEvents on
+  macOS) and only re-examines the paths that were reported. A file
+  is only re-hashed when its stat information changed.
+
+  When no change notifications are available (or they overflow) the
+  tree is rescanned, comparing only the stat information.
+*/
+
+package fim
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	utils "www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+const (
+	// Rescan period when change notifications are not available.
+	DEFAULT_PERIOD = 60 // Seconds
+
+	// Larger files are tracked by their stat information only.
+	DEFAULT_MAX_SIZE = 100 * 1024 * 1024
+
+	// Changes are coalesced for this long before they are examined.
+	DEBOUNCE = time.Second
+
+	// Reported by a watcher when events were lost and everything
+	// must be rescanned.
+	RESCAN = ""
+)
+
+type FIMPluginArgs struct {
+	Paths    []string `vfilter:"required,field=paths,doc=Files or directories to monitor (directories are monitored recursively)."`
+	Exclude  []string `vfilter:"optional,field=exclude,doc=Regular expressions of paths to ignore."`
+	Baseline string   `vfilter:"optional,field=baseline,doc=A local file to keep the baseline in so changes are detected across restarts."`
+	Period   int64    `vfilter:"optional,field=period,doc=Rescan the paths this often in seconds (default only when change notifications are not available)."`
+	MaxSize  int64    `vfilter:"optional,field=max_size,doc=Do not hash files larger than this (default 100mb)."`
+}
+
+type engine struct {
+	scope       vfilter.Scope
+	output_chan chan vfilter.Row
+
+	roots    []string
+	exclude  []*regexp.Regexp
+	max_size int64
+	baseline *baseline
+}
+
+func (self *engine) isMonitored(path string) bool {
+	for _, re := range self.exclude {
+		if re.MatchString(path) {
+			return false
+		}
+	}
+
+	for _, root := range self.roots {
+		if path == root || strings.HasPrefix(path,
+			strings.TrimSuffix(root, string(filepath.Separator))+
+				string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Examine a path that may have changed. Directories are walked
+// recursively.
+func (self *engine) checkPath(ctx context.Context, path string) {
+	if !self.isMonitored(path) {
+		return
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			self.removed(ctx, path, nil)
+		}
+		return
+	}
+
+	if !info.IsDir() {
+		self.checkFile(ctx, path, info)
+		return
+	}
+
+	seen := make(map[string]bool)
+	_ = filepath.Walk(path, func(
+		path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if !self.isMonitored(path) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !info.IsDir() {
+			seen[path] = true
+			self.checkFile(ctx, path, info)
+		}
+		return nil
+	})
+
+	if ctx.Err() == nil {
+		self.removed(ctx, path, seen)
+	}
+}
+
+// Report all baseline entries at or below the path which were not
+// seen as deleted.
+func (self *engine) removed(ctx context.Context,
+	path string, seen map[string]bool) {
+	for _, child := range self.baseline.Children(path) {
+		if seen[child] {
+			continue
+		}
+
+		old, pres := self.baseline.Get(child)
+		if !pres {
+			continue
+		}
+		self.baseline.Delete(child)
+
+		self.emit(ctx, ordereddict.NewDict().
+			Set("Time", utils.GetTime().Now().UTC()).
+			Set("Action", "Deleted").
+			Set("Path", child).
+			Set("Size", old.Size).
+			Set("Mode", old.Mode.String()).
+			Set("ModTime", time.Unix(0, old.ModTime).UTC()).
+			Set("Hash", "").
+			Set("PreviousHash", old.Hash).
+			Set("Changes", []string{}))
+	}
+}
+
+func (self *engine) checkFile(
+	ctx context.Context, path string, info os.FileInfo) {
+	current := newEntry(info)
+
+	old, pres := self.baseline.Get(path)
+	if pres && old.sameStat(current) {
+		return
+	}
+
+	if info.Mode().IsRegular() && info.Size() <= self.max_size {
+		hash, err := hashFile(path)
+		if err != nil {
+			// The file may have been removed in the meantime -
+			// it will be picked up by the next event.
+			return
+		}
+		current.Hash = hash
+	}
+
+	self.baseline.Set(path, current)
+
+	action := "Added"
+	changes := []string{}
+	previous_hash := ""
+	if pres {
+		action = "Modified"
+		changes = old.changes(current)
+		previous_hash = old.Hash
+	}
+
+	self.emit(ctx, ordereddict.NewDict().
+		Set("Time", utils.GetTime().Now().UTC()).
+		Set("Action", action).
+		Set("Path", path).
+		Set("Size", current.Size).
+		Set("Mode", current.Mode.String()).
+		Set("ModTime", info.ModTime().UTC()).
+		Set("Hash", current.Hash).
+		Set("PreviousHash", previous_hash).
+		Set("Changes", changes))
+}
+
+// Changes are only reported once the baseline is established.
+func (self *engine) emit(ctx context.Context, row *ordereddict.Dict) {
+	if !self.baseline.Established() {
+		return
+	}
+
+	select {
+	case <-ctx.Done():
+	case self.output_chan <- row:
+	}
+}
+
+func (self *engine) scanAll(ctx context.Context) {
+	for _, root := range self.roots {
+		self.checkPath(ctx, root)
+	}
+
+	// Drop entries for paths which are no longer monitored.
+	for _, path := range self.baseline.Paths() {
+		if !self.isMonitored(path) {
+			self.baseline.Delete(path)
+		}
+	}
+}
+
+func (self *engine) save() {
+	err := self.baseline.Save()
+	if err != nil {
+		self.scope.Log("watch_fim: saving baseline: %v", err)
+	}
+}
+
+func (self *engine) run(ctx context.Context, period time.Duration) {
+	watcher, err := newWatcher(ctx, self.scope, self.roots)
+	if err != nil {
+		if period == 0 {
+			period = DEFAULT_PERIOD * time.Second
+		}
+		self.scope.Log("watch_fim: change notifications are not available (%v), "+
+			"rescanning every %v", err, period)
+	} else {
+		defer watcher.Close()
+	}
+
+	// Establish the baseline, or catch up with changes made while
+	// we were not running.
+	established := self.baseline.Established()
+	self.scanAll(ctx)
+	if !established {
+		self.baseline.SetEstablished()
+		self.scope.Log("watch_fim: Established baseline of %v files",
+			self.baseline.Len())
+	}
+	self.save()
+
+	var changes <-chan string
+	if watcher != nil {
+		changes = watcher.Changes()
+	}
+
+	var rescan <-chan time.Time
+	if period > 0 {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		rescan = ticker.C
+	}
+
+	debounce := time.NewTicker(DEBOUNCE)
+	defer debounce.Stop()
+
+	pending := make(map[string]bool)
+	for {
+		select {
+		case <-ctx.Done():
+			self.save()
+			return
+
+		case path, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+			pending[path] = true
+
+		case <-debounce.C:
+			if len(pending) == 0 {
+				continue
+			}
+
+			if pending[RESCAN] {
+				self.scanAll(ctx)
+			} else {
+				for path := range pending {
+					self.checkPath(ctx, path)
+				}
+			}
+			pending = make(map[string]bool)
+			self.save()
+
+		case <-rescan:
+			self.scanAll(ctx)
+			self.save()
+		}
+	}
+}
+
+type FIMPlugin struct{}
+
+func (self FIMPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+
+		err := vql_subsystem.CheckAccess(scope, acls.FILESYSTEM_READ)
+		if err != nil {
+			scope.Log("watch_fim: %v", err)
+			return
+		}
+
+		arg := &FIMPluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("watch_fim: %v", err)
+			return
+		}
+
+		fim := &engine{
+			scope:       scope,
+			output_chan: output_chan,
+			max_size:    arg.MaxSize,
+		}
+
+		if fim.max_size == 0 {
+			fim.max_size = DEFAULT_MAX_SIZE
+		}
+
+		for _, path := range arg.Paths {
+			abs_path, err := filepath.Abs(path)
+			if err != nil {
+				scope.Log("watch_fim: %v", err)
+				return
+			}
+
+			// Use the canonical path so it matches the paths
+			// reported by change notifications.
+			real_path, err := filepath.EvalSymlinks(abs_path)
+			if err == nil {
+				abs_path = real_path
+			}
+			fim.roots = append(fim.roots, abs_path)
+		}
+
+		for _, exclude := range arg.Exclude {
+			re, err := regexp.Compile("(?i)" + exclude)
+			if err != nil {
+				scope.Log("watch_fim: %v", err)
+				return
+			}
+			fim.exclude = append(fim.exclude, re)
+		}
+
+		fim.baseline, err = loadBaseline(arg.Baseline)
+		if err != nil {
+			scope.Log("watch_fim: loading baseline %v: %v", arg.Baseline, err)
+			return
+		}
+
+		fim.run(ctx, time.Duration(arg.Period)*time.Second)
+	}()
+
+	return output_chan
+}
+
+func (self FIMPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:    "watch_fim",
+		Doc:     "Monitor files for changes against a baseline of their hashes.",
+		ArgType: type_map.AddType(scope, &FIMPluginArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&FIMPlugin{})
+}
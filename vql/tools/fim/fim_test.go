@@ -0,0 +1,184 @@
+package fim
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+	"www.velocidex.com/golang/vfilter"
+)
+
+func writeFile(t *testing.T, path, data string) {
+	assert.NoError(t, ioutil.WriteFile(path, []byte(data), 0644))
+}
+
+// Collect all the rows emitted while running the function.
+func collect(t *testing.T, fim *engine, cb func()) []*ordereddict.Dict {
+	output_chan := make(chan vfilter.Row)
+	fim.output_chan = output_chan
+
+	result := []*ordereddict.Dict{}
+	done := make(chan bool)
+	go func() {
+		defer close(done)
+		for row := range output_chan {
+			result = append(result, row.(*ordereddict.Dict))
+		}
+	}()
+
+	cb()
+	close(output_chan)
+	<-done
+
+	return result
+}
+
+func actions(rows []*ordereddict.Dict) []string {
+	result := []string{}
+	for _, row := range rows {
+		action, _ := row.GetString("Action")
+		path, _ := row.GetString("Path")
+		result = append(result, action+" "+filepath.Base(path))
+	}
+	return result
+}
+
+func TestBaseline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fim")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	root := filepath.Join(dir, "root")
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0755))
+	writeFile(t, filepath.Join(root, "a.txt"), "hello")
+	writeFile(t, filepath.Join(root, "sub", "b.txt"), "world")
+	writeFile(t, filepath.Join(root, "ignored.swp"), "x")
+
+	baseline_path := filepath.Join(dir, "baseline.json")
+	ctx := context.Background()
+
+	new_engine := func() *engine {
+		baseline, err := loadBaseline(baseline_path)
+		assert.NoError(t, err)
+
+		return &engine{
+			scope:    vql_subsystem.MakeScope(),
+			roots:    []string{root},
+			exclude:  []*regexp.Regexp{regexp.MustCompile(`\.swp$`)},
+			max_size: DEFAULT_MAX_SIZE,
+			baseline: baseline,
+		}
+	}
+
+	// The first scan establishes the baseline silently.
+	fim := new_engine()
+	rows := collect(t, fim, func() { fim.scanAll(ctx) })
+	assert.Equal(t, 0, len(rows))
+	assert.Equal(t, 2, fim.baseline.Len())
+
+	fim.baseline.SetEstablished()
+	assert.NoError(t, fim.baseline.Save())
+
+	// The baseline holds the SHA256 of each file.
+	item, pres := fim.baseline.Get(filepath.Join(root, "a.txt"))
+	assert.True(t, pres)
+	assert.Equal(t,
+		"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		item.Hash)
+
+	// Change the files while we are not running.
+	writeFile(t, filepath.Join(root, "a.txt"), "hello there")
+	assert.NoError(t, os.Remove(filepath.Join(root, "sub", "b.txt")))
+	writeFile(t, filepath.Join(root, "c.txt"), "new")
+
+	// The next start catches up with the changes.
+	fim = new_engine()
+	assert.True(t, fim.baseline.Established())
+	rows = collect(t, fim, func() { fim.scanAll(ctx) })
+
+	sorted := actions(rows)
+	assert.Equal(t, 3, len(sorted))
+	assert.Contains(t, sorted, "Modified a.txt")
+	assert.Contains(t, sorted, "Added c.txt")
+	assert.Contains(t, sorted, "Deleted b.txt")
+
+	for _, row := range rows {
+		if action, _ := row.GetString("Action"); action == "Modified" {
+			changes, _ := row.Get("Changes")
+			assert.Contains(t, changes, "content")
+			previous, _ := row.GetString("PreviousHash")
+			assert.Equal(t, item.Hash, previous)
+		}
+	}
+
+	// Nothing changed so nothing is reported.
+	rows = collect(t, fim, func() { fim.scanAll(ctx) })
+	assert.Equal(t, 0, len(rows))
+
+	// Removing a directory reports all the files below it.
+	writeFile(t, filepath.Join(root, "sub", "d.txt"), "d")
+	rows = collect(t, fim, func() {
+		fim.checkPath(ctx, filepath.Join(root, "sub", "d.txt"))
+		assert.NoError(t, os.RemoveAll(filepath.Join(root, "sub")))
+		fim.checkPath(ctx, filepath.Join(root, "sub"))
+	})
+	assert.Equal(t, []string{"Added d.txt", "Deleted d.txt"}, actions(rows))
+
+	// Paths outside the roots are ignored.
+	rows = collect(t, fim, func() { fim.checkPath(ctx, baseline_path) })
+	assert.Equal(t, 0, len(rows))
+}
+
+// Changes are picked up from the change notifications.
+func TestWatchFim(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Only supported on linux")
+	}
+
+	dir, err := ioutil.TempDir("", "fim")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFile(t, filepath.Join(dir, "a.txt"), "hello")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	defer scope.Close()
+
+	output_chan := FIMPlugin{}.Call(ctx, scope, ordereddict.NewDict().
+		Set("paths", []string{dir}))
+
+	// Wait for the baseline to be established.
+	time.Sleep(time.Second)
+
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "new"), 0755))
+	time.Sleep(100 * time.Millisecond)
+	writeFile(t, filepath.Join(dir, "new", "b.txt"), "world")
+
+	rows := []string{}
+	vtesting.WaitUntil(10*time.Second, t, func() bool {
+		select {
+		case row, ok := <-output_chan:
+			assert.True(t, ok)
+			rows = append(rows, actions(
+				[]*ordereddict.Dict{row.(*ordereddict.Dict)})...)
+		case <-time.After(100 * time.Millisecond):
+		}
+		return len(rows) > 0
+	})
+
+	assert.Equal(t, []string{"Added b.txt"}, rows)
+}
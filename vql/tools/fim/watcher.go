@@ -0,0 +1,15 @@
+package fim
+
+import "errors"
+
+var (
+	NotSupportedError = errors.New(
+		"Change notifications are not supported on this platform")
+)
+
+// A source of paths that may have changed. A watcher reports RESCAN
+// when it lost events and everything must be examined again.
+type changeWatcher interface {
+	Changes() <-chan string
+	Close()
+}
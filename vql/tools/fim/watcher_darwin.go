@@ -0,0 +1,168 @@
+// +build darwin,cgo
+
+package fim
+
+/*
+#cgo LDFLAGS: -framework CoreServices
+#include <stdlib.h>
+#include <CoreServices/CoreServices.h>
+#include <dispatch/dispatch.h>
+
+extern void fimEventsCallback(uintptr_t handle, size_t num_events,
+    char **paths, FSEventStreamEventFlags *flags);
+
+static void fim_callback(ConstFSEventStreamRef stream, void *info,
+    size_t num_events, void *event_paths,
+    const FSEventStreamEventFlags flags[],
+    const FSEventStreamEventId ids[]) {
+  fimEventsCallback((uintptr_t)info, num_events, (char **)event_paths,
+      (FSEventStreamEventFlags *)flags);
+}
+
+static CFArrayRef fim_make_paths(char **paths, int count) {
+  CFMutableArrayRef result = CFArrayCreateMutable(
+      NULL, count, &kCFTypeArrayCallBacks);
+  for (int i = 0; i < count; i++) {
+    CFStringRef path = CFStringCreateWithCString(
+        NULL, paths[i], kCFStringEncodingUTF8);
+    CFArrayAppendValue(result, path);
+    CFRelease(path);
+  }
+  return result;
+}
+
+static FSEventStreamRef fim_start_stream(uintptr_t handle,
+    char **paths, int count, double latency, dispatch_queue_t queue) {
+  FSEventStreamContext context = {0, (void *)handle, NULL, NULL, NULL};
+  CFArrayRef path_array = fim_make_paths(paths, count);
+
+  FSEventStreamRef stream = FSEventStreamCreate(
+      NULL, fim_callback, &context, path_array,
+      kFSEventStreamEventIdSinceNow, latency,
+      kFSEventStreamCreateFlagFileEvents | kFSEventStreamCreateFlagNoDefer);
+  CFRelease(path_array);
+
+  if (stream == NULL) {
+    return NULL;
+  }
+
+  FSEventStreamSetDispatchQueue(stream, queue);
+  if (!FSEventStreamStart(stream)) {
+    FSEventStreamInvalidate(stream);
+    FSEventStreamRelease(stream);
+    return NULL;
+  }
+  return stream;
+}
+
+static void fim_stop_stream(FSEventStreamRef stream) {
+  FSEventStreamStop(stream);
+  FSEventStreamInvalidate(stream);
+  FSEventStreamRelease(stream);
+}
+
+static dispatch_queue_t fim_make_queue() {
+  return dispatch_queue_create("com.velocidex.fim", NULL);
+}
+
+static void fim_release_queue(dispatch_queue_t queue) {
+  dispatch_release(queue);
+}
+*/
+import "C"
+
+import (
+	"context"
+	"errors"
+	"runtime/cgo"
+	"sync"
+	"unsafe"
+
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	// Seconds FSEvents coalesces events for.
+	FSEVENTS_LATENCY = 1.0
+)
+
+// Receives file level events from FSEvents for all the roots. The
+// callbacks run on a dispatch queue owned by the stream.
+type fseventsWatcher struct {
+	mu     sync.Mutex
+	closed bool
+
+	ctx    context.Context
+	cancel func()
+
+	output chan string
+	handle cgo.Handle
+	stream C.FSEventStreamRef
+	queue  C.dispatch_queue_t
+}
+
+func newWatcher(ctx context.Context,
+	scope vfilter.Scope, roots []string) (changeWatcher, error) {
+	if len(roots) == 0 {
+		return nil, NotSupportedError
+	}
+
+	subctx, cancel := context.WithCancel(ctx)
+	result := &fseventsWatcher{
+		ctx:    subctx,
+		cancel: cancel,
+		output: make(chan string, 1000),
+	}
+	result.handle = cgo.NewHandle(result)
+
+	c_paths := make([]*C.char, 0, len(roots))
+	for _, root := range roots {
+		c_paths = append(c_paths, C.CString(root))
+	}
+	defer func() {
+		for _, path := range c_paths {
+			C.free(unsafe.Pointer(path))
+		}
+	}()
+
+	result.queue = C.fim_make_queue()
+	result.stream = C.fim_start_stream(C.uintptr_t(result.handle),
+		(**C.char)(unsafe.Pointer(&c_paths[0])), C.int(len(c_paths)),
+		C.double(FSEVENTS_LATENCY), result.queue)
+	if result.stream == nil {
+		C.fim_release_queue(result.queue)
+		result.handle.Delete()
+		cancel()
+		return nil, errors.New("Unable to start FSEvents stream")
+	}
+
+	return result, nil
+}
+
+func (self *fseventsWatcher) Changes() <-chan string {
+	return self.output
+}
+
+func (self *fseventsWatcher) send(path string) {
+	select {
+	case <-self.ctx.Done():
+	case self.output <- path:
+	}
+}
+
+func (self *fseventsWatcher) Close() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.closed {
+		return
+	}
+	self.closed = true
+
+	// Unblock any callback before stopping the stream - stopping
+	// waits for callbacks in flight.
+	self.cancel()
+	C.fim_stop_stream(self.stream)
+	C.fim_release_queue(self.queue)
+	self.handle.Delete()
+}
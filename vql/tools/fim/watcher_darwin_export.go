@@ -0,0 +1,39 @@
+// +build darwin,cgo
+
+package fim
+
+/*
+#include <CoreServices/CoreServices.h>
+*/
+import "C"
+
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+// Called by FSEvents on the stream's dispatch queue. This lives in
+// its own file because cgo does not allow C definitions in the
+// preamble of a file with exported functions.
+//
+//export fimEventsCallback
+func fimEventsCallback(handle C.uintptr_t, num_events C.size_t,
+	paths **C.char, flags *C.FSEventStreamEventFlags) {
+	watcher, ok := cgo.Handle(handle).Value().(*fseventsWatcher)
+	if !ok {
+		return
+	}
+
+	path_list := unsafe.Slice(paths, int(num_events))
+	flag_list := unsafe.Slice(flags, int(num_events))
+	for i := 0; i < int(num_events); i++ {
+		// Events were dropped so the whole tree must be checked.
+		if flag_list[i]&(C.kFSEventStreamEventFlagMustScanSubDirs|
+			C.kFSEventStreamEventFlagUserDropped|
+			C.kFSEventStreamEventFlagKernelDropped) != 0 {
+			watcher.send(RESCAN)
+			continue
+		}
+		watcher.send(C.GoString(path_list[i]))
+	}
+}
@@ -0,0 +1,213 @@
+// +build linux
+
+package fim
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	INOTIFY_MASK = unix.IN_ATTRIB | unix.IN_CLOSE_WRITE | unix.IN_CREATE |
+		unix.IN_DELETE | unix.IN_DELETE_SELF | unix.IN_MODIFY |
+		unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_MOVE_SELF
+
+	// How often the reader checks if it should exit.
+	POLL_TIMEOUT_MS = 500
+)
+
+// Watches directories with inotify. Inotify is not recursive so
+// every directory below the roots is watched separately.
+type inotifyWatcher struct {
+	mu sync.Mutex
+
+	fd      int
+	scope   vfilter.Scope
+	watches map[int]string
+
+	output chan string
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func newWatcher(ctx context.Context,
+	scope vfilter.Scope, roots []string) (changeWatcher, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+
+	subctx, cancel := context.WithCancel(ctx)
+	result := &inotifyWatcher{
+		fd:      fd,
+		scope:   scope,
+		watches: make(map[int]string),
+		output:  make(chan string, 1000),
+		cancel:  cancel,
+	}
+
+	for _, root := range roots {
+		info, err := os.Lstat(root)
+		if err != nil {
+			scope.Log("watch_fim: Unable to watch %v: %v", root, err)
+			continue
+		}
+
+		// Files are watched through their directory so they can
+		// be followed when they are replaced.
+		if !info.IsDir() {
+			err = result.addWatch(filepath.Dir(root))
+		} else {
+			err = result.addTree(root)
+		}
+		if err != nil {
+			unix.Close(fd)
+			cancel()
+			return nil, err
+		}
+	}
+
+	result.wg.Add(1)
+	go result.readEvents(subctx)
+
+	return result, nil
+}
+
+func (self *inotifyWatcher) Changes() <-chan string {
+	return self.output
+}
+
+func (self *inotifyWatcher) Close() {
+	self.cancel()
+	self.wg.Wait()
+	unix.Close(self.fd)
+}
+
+func (self *inotifyWatcher) addWatch(path string) error {
+	wd, err := unix.InotifyAddWatch(self.fd, path, INOTIFY_MASK)
+	if err != nil {
+		return err
+	}
+
+	self.mu.Lock()
+	self.watches[wd] = path
+	self.mu.Unlock()
+
+	return nil
+}
+
+func (self *inotifyWatcher) addTree(root string) error {
+	return filepath.Walk(root, func(
+		path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+
+		err = self.addWatch(path)
+		if err == unix.ENOSPC {
+			// Out of watches - this can not be fixed by
+			// continuing.
+			return err
+		}
+		return nil
+	})
+}
+
+func (self *inotifyWatcher) send(ctx context.Context, path string) {
+	select {
+	case <-ctx.Done():
+	case self.output <- path:
+	}
+}
+
+func (self *inotifyWatcher) readEvents(ctx context.Context) {
+	defer self.wg.Done()
+	defer close(self.output)
+
+	buf := make([]byte, 64*1024)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		fds := []unix.PollFd{{Fd: int32(self.fd), Events: unix.POLLIN}}
+		n, err := unix.Poll(fds, POLL_TIMEOUT_MS)
+		if err == unix.EINTR || n == 0 {
+			continue
+		}
+		if err != nil {
+			self.scope.Log("watch_fim: %v", err)
+			return
+		}
+
+		n, err = unix.Read(self.fd, buf)
+		if err == unix.EAGAIN || err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			self.scope.Log("watch_fim: %v", err)
+			return
+		}
+
+		self.processEvents(ctx, buf[:n])
+	}
+}
+
+func (self *inotifyWatcher) processEvents(ctx context.Context, buf []byte) {
+	offset := 0
+	for offset+unix.SizeofInotifyEvent <= len(buf) {
+		event := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+		name_start := offset + unix.SizeofInotifyEvent
+		name_end := name_start + int(event.Len)
+		if name_end > len(buf) {
+			return
+		}
+
+		// The name is padded with NUL bytes.
+		name := string(buf[name_start:name_end])
+		for len(name) > 0 && name[len(name)-1] == 0 {
+			name = name[:len(name)-1]
+		}
+		offset = name_end
+
+		if event.Mask&unix.IN_Q_OVERFLOW != 0 {
+			self.send(ctx, RESCAN)
+			continue
+		}
+
+		self.mu.Lock()
+		dir, pres := self.watches[int(event.Wd)]
+		if event.Mask&unix.IN_IGNORED != 0 {
+			delete(self.watches, int(event.Wd))
+		}
+		self.mu.Unlock()
+
+		if !pres {
+			continue
+		}
+
+		path := dir
+		if name != "" {
+			path = filepath.Join(dir, name)
+		}
+
+		// New directories need to be watched too.
+		if event.Mask&unix.IN_ISDIR != 0 &&
+			event.Mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0 {
+			err := self.addTree(path)
+			if err != nil {
+				self.scope.Log("watch_fim: Unable to watch %v: %v", path, err)
+			}
+		}
+
+		self.send(ctx, path)
+	}
+}
@@ -0,0 +1,14 @@
+// +build !linux,!windows,!darwin darwin,!cgo
+
+package fim
+
+import (
+	"context"
+
+	"www.velocidex.com/golang/vfilter"
+)
+
+func newWatcher(ctx context.Context,
+	scope vfilter.Scope, roots []string) (changeWatcher, error) {
+	return nil, NotSupportedError
+}
@@ -0,0 +1,106 @@
+// +build windows
+
+package fim
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/parsers/usn"
+	"www.velocidex.com/golang/vfilter"
+)
+
+// Follows the USN journal of each volume containing a root. The
+// journal records every change on the volume so watching it is
+// cheap regardless of the size of the monitored trees.
+type usnWatcher struct {
+	output chan string
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func newWatcher(ctx context.Context,
+	scope vfilter.Scope, roots []string) (changeWatcher, error) {
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		return nil, NotSupportedError
+	}
+
+	subctx, cancel := context.WithCancel(ctx)
+	result := &usnWatcher{
+		output: make(chan string, 1000),
+		cancel: cancel,
+	}
+
+	volumes := make(map[string]bool)
+	for _, root := range roots {
+		volume := filepath.VolumeName(root)
+		if volume == "" || volumes[volume] {
+			continue
+		}
+		volumes[volume] = true
+
+		device, err := accessors.NewWindowsNTFSPath(`\\.\` + volume)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		event_channel := make(chan vfilter.Row)
+		closer, err := usn.GlobalEventLogService.Register(
+			device, "ntfs", subctx, config_obj, scope, event_channel)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		result.wg.Add(1)
+		go func(volume string) {
+			defer result.wg.Done()
+			defer closer()
+
+			for {
+				select {
+				case <-subctx.Done():
+					return
+
+				case event, ok := <-event_channel:
+					if !ok {
+						return
+					}
+					dict, ok := event.(*ordereddict.Dict)
+					if !ok {
+						continue
+					}
+
+					// The path is relative to the volume.
+					full_path, _ := dict.GetString("FullPath")
+					path := volume + `\` + strings.TrimLeft(
+						strings.ReplaceAll(full_path, "/", `\`), `\`)
+
+					select {
+					case <-subctx.Done():
+						return
+					case result.output <- path:
+					}
+				}
+			}
+		}(volume)
+	}
+
+	return result, nil
+}
+
+func (self *usnWatcher) Changes() <-chan string {
+	return self.output
+}
+
+func (self *usnWatcher) Close() {
+	self.cancel()
+	self.wg.Wait()
+}
@@ -0,0 +1,195 @@
+package minidump
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	MANIFEST_VERSION = 1
+
+	// The name of the manifest upload. The server looks for uploads
+	// with this name to assemble.
+	MANIFEST_NAME = "regions.json"
+	MEMORY_NAME   = "memory"
+)
+
+// A collected memory region.
+type Region struct {
+	Address       uint64 `json:"Address"`
+	Size          uint64 `json:"Size"`
+	Protection    string `json:"Protection,omitempty"`
+	ProtectionRaw uint32 `json:"ProtectionRaw,omitempty"`
+	State         string `json:"State,omitempty"`
+	Type          string `json:"Type,omitempty"`
+	MappingName   string `json:"MappingName,omitempty"`
+}
+
+func (self Region) End() uint64 {
+	return self.Address + self.Size
+}
+
+// A module loaded in the process.
+type Module struct {
+	BaseAddress uint64 `json:"BaseAddress"`
+	Size        uint64 `json:"Size"`
+	Name        string `json:"Name"`
+}
+
+// The manifest is uploaded with the memory and describes everything
+// the server needs to assemble the minidump.
+type Manifest struct {
+	Version     int    `json:"Version"`
+	Time        int64  `json:"Time"`
+	Pid         int64  `json:"Pid"`
+	Name        string `json:"Name"`
+	Exe         string `json:"Exe"`
+	CommandLine string `json:"CommandLine"`
+	OS          string `json:"OS"`
+	Arch        string `json:"Arch"`
+
+	// Where the memory and the minidump are stored in the flow's
+	// uploads.
+	Accessor string `json:"Accessor"`
+	Memory   string `json:"Memory"`
+	Dump     string `json:"Dump"`
+
+	Regions []Region `json:"Regions"`
+	Modules []Module `json:"Modules"`
+}
+
+func getUint64(row *ordereddict.Dict, fields ...string) uint64 {
+	for _, field := range fields {
+		value, pres := row.Get(field)
+		if !pres {
+			continue
+		}
+
+		result, ok := utils.ToInt64(value)
+		if ok {
+			return uint64(result)
+		}
+	}
+	return 0
+}
+
+func getString(row *ordereddict.Dict, fields ...string) string {
+	for _, field := range fields {
+		value, pres := row.GetString(field)
+		if pres && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Regions may come from the vad() plugin or any query with Address
+// and Size columns.
+func parseRegions(ctx context.Context,
+	scope vfilter.Scope, query vfilter.StoredQuery) []Region {
+	result := []Region{}
+	for row := range query.Eval(ctx, scope) {
+		dict := vfilter.RowToDict(ctx, scope, row)
+		region := Region{
+			Address:       getUint64(dict, "Address"),
+			Size:          getUint64(dict, "Size", "SectionSize"),
+			Protection:    getString(dict, "Protection"),
+			ProtectionRaw: uint32(getUint64(dict, "ProtectionRaw")),
+			State:         getString(dict, "State"),
+			Type:          getString(dict, "Type"),
+			MappingName:   getString(dict, "MappingName"),
+		}
+		if region.Size == 0 {
+			continue
+		}
+		result = append(result, region)
+	}
+
+	return mergeRegions(result)
+}
+
+// Sort the regions and drop overlapping parts so every byte is only
+// collected once.
+func mergeRegions(regions []Region) []Region {
+	sort.Slice(regions, func(i, j int) bool {
+		return regions[i].Address < regions[j].Address
+	})
+
+	result := make([]Region, 0, len(regions))
+	for _, region := range regions {
+		if len(result) > 0 {
+			last := result[len(result)-1]
+			if region.End() <= last.End() {
+				continue
+			}
+
+			if region.Address < last.End() {
+				region.Size = region.End() - last.End()
+				region.Address = last.End()
+			}
+		}
+		result = append(result, region)
+	}
+	return result
+}
+
+// Modules may come from the modules() plugin or any query with
+// BaseAddress, Size and Name columns.
+func parseModules(ctx context.Context,
+	scope vfilter.Scope, query vfilter.StoredQuery) []Module {
+	result := []Module{}
+	for row := range query.Eval(ctx, scope) {
+		dict := vfilter.RowToDict(ctx, scope, row)
+		module := Module{
+			BaseAddress: getUint64(dict, "BaseAddress", "ModuleBaseAddress"),
+			Size:        getUint64(dict, "Size", "ModuleBaseSize"),
+			Name:        getString(dict, "Name", "ExePath", "ModuleName"),
+		}
+		if module.Size == 0 || module.Name == "" {
+			continue
+		}
+		result = append(result, module)
+	}
+	return result
+}
+
+// Without an explicit module list, derive the modules from the
+// mapped files of the regions.
+func modulesFromRegions(regions []Region) []Module {
+	modules := make(map[string]*Module)
+	for _, region := range regions {
+		name := region.MappingName
+		if name == "" || strings.HasPrefix(name, "[") {
+			continue
+		}
+
+		module, pres := modules[name]
+		if !pres {
+			modules[name] = &Module{
+				BaseAddress: region.Address,
+				Size:        region.Size,
+				Name:        name,
+			}
+			continue
+		}
+
+		if region.End() > module.BaseAddress+module.Size {
+			module.Size = region.End() - module.BaseAddress
+		}
+	}
+
+	result := make([]Module, 0, len(modules))
+	for _, module := range modules {
+		result = append(result, *module)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].BaseAddress < result[j].BaseAddress
+	})
+	return result
+}
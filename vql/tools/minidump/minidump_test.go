@@ -0,0 +1,187 @@
+package minidump
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	_ "www.velocidex.com/golang/velociraptor/accessors/process"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/uploads"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+// Keeps the uploaded data in memory. Sparse uploads keep only their
+// data ranges like the server does.
+type testUploader struct {
+	files  map[string][]byte
+	ranges map[string][]MemoryRange
+}
+
+func (self *testUploader) Upload(
+	ctx context.Context,
+	scope vfilter.Scope,
+	filename *accessors.OSPath,
+	accessor string,
+	store_as_name *accessors.OSPath,
+	expected_size int64,
+	mtime time.Time,
+	atime time.Time,
+	ctime time.Time,
+	btime time.Time,
+	reader io.Reader) (*uploads.UploadResponse, error) {
+	name := store_as_name.String()
+	buf := &bytes.Buffer{}
+
+	range_reader, ok := reader.(uploads.RangeReader)
+	if !ok {
+		_, err := io.Copy(buf, reader)
+		self.files[name] = buf.Bytes()
+		return &uploads.UploadResponse{Path: name}, err
+	}
+
+	for _, rng := range range_reader.Ranges() {
+		if rng.IsSparse {
+			continue
+		}
+		_, err := range_reader.Seek(rng.Offset, io.SeekStart)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.CopyN(buf, range_reader, rng.Length)
+		if err != nil {
+			return nil, err
+		}
+
+		self.ranges[name] = append(self.ranges[name], MemoryRange{
+			Address: uint64(rng.Offset),
+			Size:    uint64(rng.Length),
+		})
+	}
+	self.files[name] = buf.Bytes()
+
+	return &uploads.UploadResponse{Path: name}, nil
+}
+
+func TestMergeRegions(t *testing.T) {
+	regions := mergeRegions([]Region{
+		{Address: 0x3000, Size: 0x1000},
+		{Address: 0x1000, Size: 0x2000},
+		{Address: 0x2000, Size: 0x2000},
+		{Address: 0x1000, Size: 0x1000},
+	})
+
+	assert.Equal(t, []Region{
+		{Address: 0x1000, Size: 0x2000},
+		{Address: 0x3000, Size: 0x1000},
+	}, regions)
+}
+
+var test_buffer []byte
+
+func TestUploadMemoryRegions(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Only supported on linux")
+	}
+
+	// A page aligned buffer in our own address space. It is kept
+	// on the heap so it does not move.
+	buffer := make([]byte, 3*0x1000)
+	test_buffer = buffer
+	start := (uintptr(unsafe.Pointer(&buffer[0])) + 0xfff) &^ 0xfff
+	page := buffer[start-uintptr(unsafe.Pointer(&buffer[0])):][:0x1000]
+	copy(page, "Hello world")
+
+	uploader := &testUploader{
+		files:  make(map[string][]byte),
+		ranges: make(map[string][]MemoryRange),
+	}
+
+	ctx := context.Background()
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}).
+		Set(constants.SCOPE_UPLOADER, uploader).
+		Set("Regions", []*ordereddict.Dict{
+			ordereddict.NewDict().
+				Set("Address", uint64(start)).
+				Set("Size", 0x1000).
+				Set("Protection", "rw-").
+				Set("MappingName", "/heap"),
+		}))
+	defer scope.Close()
+
+	query, err := vfilter.Parse("SELECT * FROM Regions")
+	assert.NoError(t, err)
+
+	result := (&UploadMemoryRegionsFunction{}).Call(ctx, scope,
+		ordereddict.NewDict().
+			Set("pid", os.Getpid()).
+			Set("regions", query).
+			Set("name", "test"))
+	assert.IsType(t, &ordereddict.Dict{}, result)
+
+	// The manifest describes the upload.
+	manifest := &Manifest{}
+	assert.NoError(t, json.Unmarshal(
+		uploader.files["/test/"+MANIFEST_NAME], manifest))
+	assert.Equal(t, int64(os.Getpid()), manifest.Pid)
+	assert.Equal(t, "/test/memory", manifest.Memory)
+	assert.Equal(t, 1, len(manifest.Regions))
+	assert.Equal(t, []Module{{
+		BaseAddress: uint64(start), Size: 0x1000, Name: "/heap"}},
+		manifest.Modules)
+
+	// Only the selected region was uploaded.
+	memory := uploader.files[manifest.Memory]
+	assert.Equal(t, 0x1000, len(memory))
+	assert.Equal(t, "Hello world", string(memory[:11]))
+
+	// Assemble the minidump.
+	dump := &bytes.Buffer{}
+	_, err = WriteMinidump(dump, manifest,
+		uploader.ranges[manifest.Memory], bytes.NewReader(memory))
+	assert.NoError(t, err)
+
+	data := dump.Bytes()
+	hdr := &header{}
+	assert.NoError(t, binary.Read(bytes.NewReader(data),
+		binary.LittleEndian, hdr))
+	assert.Equal(t, uint32(MINIDUMP_SIGNATURE), hdr.Signature)
+	assert.Equal(t, uint32(5), hdr.NumberOfStreams)
+
+	// Find the memory list and check the memory is where it says.
+	found := false
+	for i := uint32(0); i < hdr.NumberOfStreams; i++ {
+		dir := &directory{}
+		offset := hdr.StreamDirectoryRva + i*uint32(binary.Size(dir))
+		assert.NoError(t, binary.Read(bytes.NewReader(data[offset:]),
+			binary.LittleEndian, dir))
+
+		if dir.StreamType != MEMORY_64_LIST_STREAM {
+			continue
+		}
+		found = true
+
+		stream := data[dir.Rva:]
+		assert.Equal(t, uint64(1), binary.LittleEndian.Uint64(stream))
+		base_rva := binary.LittleEndian.Uint64(stream[8:])
+		assert.Equal(t, uint64(start), binary.LittleEndian.Uint64(stream[16:]))
+		assert.Equal(t, uint64(0x1000), binary.LittleEndian.Uint64(stream[24:]))
+		assert.Equal(t, "Hello world", string(data[base_rva:base_rva+11]))
+		assert.Equal(t, uint64(len(data)), base_rva+0x1000)
+	}
+	assert.True(t, found)
+}
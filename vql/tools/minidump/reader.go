@@ -0,0 +1,109 @@
+package minidump
+
+import (
+	"io"
+	"sort"
+	"sync"
+
+	"www.velocidex.com/golang/velociraptor/uploads"
+)
+
+// Presents only the selected regions of the process address space
+// so the uploader streams them as a sparse file. Unreadable pages
+// are returned as zeros so the uploaded data always matches the
+// index.
+type regionReader struct {
+	mu      sync.Mutex
+	reader  io.ReadSeeker
+	regions []Region
+	offset  uint64
+}
+
+func (self *regionReader) find(offset uint64) *Region {
+	idx := sort.Search(len(self.regions), func(i int) bool {
+		return self.regions[i].End() > offset
+	})
+	if idx < len(self.regions) && self.regions[idx].Address <= offset {
+		return &self.regions[idx]
+	}
+	return nil
+}
+
+func (self *regionReader) Read(buf []byte) (int, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	region := self.find(self.offset)
+	if region == nil {
+		return 0, io.EOF
+	}
+
+	to_read := region.End() - self.offset
+	if to_read > uint64(len(buf)) {
+		to_read = uint64(len(buf))
+	}
+	buf = buf[:to_read]
+
+	n := 0
+	_, err := self.reader.Seek(int64(self.offset), io.SeekStart)
+	if err == nil {
+		n, _ = io.ReadFull(self.reader, buf)
+	}
+
+	for i := n; i < len(buf); i++ {
+		buf[i] = 0
+	}
+
+	self.offset += to_read
+	return len(buf), nil
+}
+
+func (self *regionReader) Seek(offset int64, whence int) (int64, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	switch whence {
+	case io.SeekStart:
+		self.offset = uint64(offset)
+	case io.SeekCurrent:
+		self.offset += uint64(offset)
+	case io.SeekEnd:
+		if len(self.regions) > 0 {
+			self.offset = self.regions[len(self.regions)-1].End()
+		}
+	}
+
+	return int64(self.offset), nil
+}
+
+func (self *regionReader) Ranges() []uploads.Range {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result := []uploads.Range{}
+	offset := uint64(0)
+	for _, region := range self.regions {
+		if region.Address > offset {
+			result = append(result, uploads.Range{
+				Offset:   int64(offset),
+				Length:   int64(region.Address - offset),
+				IsSparse: true,
+			})
+		}
+
+		result = append(result, uploads.Range{
+			Offset: int64(region.Address),
+			Length: int64(region.Size),
+		})
+		offset = region.End()
+	}
+	return result
+}
+
+func (self *regionReader) Size() int64 {
+	result := int64(0)
+	for _, region := range self.regions {
+		result += int64(region.Size)
+	}
+	return result
+}
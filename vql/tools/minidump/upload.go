@@ -0,0 +1,198 @@
+/*
+  Targeted process memory collection.
+
+  Rather than acquiring all of the physical memory, or all of a
+  process's address space, the upload_memory_regions() function
+  collects only selected regions of a process (e.g. by VAD
+  protection or regions containing a yara hit). The regions are
+  streamed to the server as a single sparse upload together with a
+  manifest describing the process, its modules and the regions.
+
+  The server then assembles the upload into a minidump in the flow's
+  upload area using assemble_minidumps(), so it can be analyzed with
+  the usual tools.
+*/
+
+package minidump
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/shirou/gopsutil/v3/process"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/artifacts"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+const (
+	PROCESS_ACCESSOR = "process"
+
+	DEFAULT_MAX_SIZE = 1024 * 1024 * 1024
+)
+
+type UploadMemoryRegionsArgs struct {
+	Pid     int64               `vfilter:"required,field=pid,doc=The process to collect from."`
+	Regions vfilter.StoredQuery `vfilter:"required,field=regions,doc=A query returning the regions to collect with Address and Size columns (e.g. from vad())."`
+	Modules vfilter.StoredQuery `vfilter:"optional,field=modules,doc=A query returning the process modules (e.g. from modules()). By default modules are derived from the MappingName of the regions."`
+	Name    string              `vfilter:"optional,field=name,doc=The name to store the upload under (default <process name>_<pid>)."`
+	MaxSize uint64              `vfilter:"optional,field=max_size,doc=Stop adding regions once this many bytes are selected (default 1Gb)."`
+}
+
+type UploadMemoryRegionsFunction struct{}
+
+func (self *UploadMemoryRegionsFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	arg := &UploadMemoryRegionsArgs{}
+	err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("upload_memory_regions: %v", err)
+		return vfilter.Null{}
+	}
+
+	err = vql_subsystem.CheckAccess(scope, acls.MACHINE_STATE)
+	if err != nil {
+		scope.Log("upload_memory_regions: %v", err)
+		return vfilter.Null{}
+	}
+
+	err = vql_subsystem.CheckFilesystemAccess(scope, PROCESS_ACCESSOR)
+	if err != nil {
+		scope.Log("upload_memory_regions: %v", err)
+		return vfilter.Null{}
+	}
+
+	uploader, ok := artifacts.GetUploader(scope)
+	if !ok {
+		scope.Log("upload_memory_regions: Uploader not configured.")
+		return vfilter.Null{}
+	}
+
+	if arg.MaxSize == 0 {
+		arg.MaxSize = DEFAULT_MAX_SIZE
+	}
+
+	manifest := &Manifest{
+		Version:  MANIFEST_VERSION,
+		Time:     utils.GetTime().Now().Unix(),
+		Pid:      arg.Pid,
+		OS:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+		Accessor: PROCESS_ACCESSOR,
+	}
+
+	proc, err := process.NewProcessWithContext(ctx, int32(arg.Pid))
+	if err == nil {
+		manifest.Name, _ = proc.NameWithContext(ctx)
+		manifest.Exe, _ = proc.ExeWithContext(ctx)
+		manifest.CommandLine, _ = proc.CmdlineWithContext(ctx)
+	}
+
+	total := uint64(0)
+	for _, region := range parseRegions(ctx, scope, arg.Regions) {
+		if total+region.Size > arg.MaxSize {
+			scope.Log("upload_memory_regions: Selected regions exceed max_size "+
+				"(%v bytes), skipping remaining regions", arg.MaxSize)
+			break
+		}
+		total += region.Size
+		manifest.Regions = append(manifest.Regions, region)
+	}
+
+	if len(manifest.Regions) == 0 {
+		scope.Log("upload_memory_regions: No regions selected for pid %v", arg.Pid)
+		return vfilter.Null{}
+	}
+
+	if arg.Modules != nil {
+		manifest.Modules = parseModules(ctx, scope, arg.Modules)
+	} else {
+		manifest.Modules = modulesFromRegions(manifest.Regions)
+	}
+
+	name := arg.Name
+	if name == "" {
+		name = fmt.Sprintf("%v_%v", manifest.Name, arg.Pid)
+	}
+
+	root, _ := accessors.NewGenericOSPath("")
+	memory_name := root.Append(name, MEMORY_NAME)
+	manifest_name := root.Append(name, MANIFEST_NAME)
+	manifest.Memory = memory_name.String()
+	manifest.Dump = root.Append(name, name+".dmp").String()
+
+	accessor, err := accessors.GetAccessor(PROCESS_ACCESSOR, scope)
+	if err != nil {
+		scope.Log("upload_memory_regions: %v", err)
+		return vfilter.Null{}
+	}
+
+	pid_path, err := accessor.ParsePath(fmt.Sprintf("%v", arg.Pid))
+	if err != nil {
+		scope.Log("upload_memory_regions: %v", err)
+		return vfilter.Null{}
+	}
+
+	fd, err := accessor.OpenWithOSPath(pid_path)
+	if err != nil {
+		scope.Log("upload_memory_regions: %v", err)
+		return vfilter.Null{}
+	}
+	defer fd.Close()
+
+	reader := &regionReader{
+		reader:  fd,
+		regions: manifest.Regions,
+	}
+
+	now := utils.GetTime().Now()
+	memory_response, err := uploader.Upload(ctx, scope,
+		pid_path, PROCESS_ACCESSOR, memory_name, reader.Size(),
+		now, now, now, now, reader)
+	if err != nil {
+		scope.Log("upload_memory_regions: %v", err)
+		return vfilter.Null{}
+	}
+
+	// The manifest goes last so the server only sees it once the
+	// memory is uploaded.
+	serialized := json.MustMarshalIndent(manifest)
+	manifest_response, err := uploader.Upload(ctx, scope,
+		manifest_name, PROCESS_ACCESSOR, manifest_name,
+		int64(len(serialized)), now, now, now, now,
+		bytes.NewReader(serialized))
+	if err != nil {
+		scope.Log("upload_memory_regions: %v", err)
+		return vfilter.Null{}
+	}
+
+	return ordereddict.NewDict().
+		Set("Pid", arg.Pid).
+		Set("Name", manifest.Name).
+		Set("Regions", len(manifest.Regions)).
+		Set("Size", total).
+		Set("Memory", memory_response).
+		Set("Manifest", manifest_response)
+}
+
+func (self UploadMemoryRegionsFunction) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "upload_memory_regions",
+		Doc:     "Upload selected memory regions of a process so the server can assemble them into a minidump.",
+		ArgType: type_map.AddType(scope, &UploadMemoryRegionsArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&UploadMemoryRegionsFunction{})
+}
@@ -0,0 +1,366 @@
+package minidump
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// Minidump structures are documented in
+// https://docs.microsoft.com/en-us/windows/win32/api/minidumpapiset/
+const (
+	MINIDUMP_SIGNATURE = 0x504d444d // MDMP
+	MINIDUMP_VERSION   = 0xa793
+
+	// MiniDumpWithFullMemory | MiniDumpWithFullMemoryInfo
+	MINIDUMP_FLAGS = 0x00000802
+
+	MODULE_LIST_STREAM      = 4
+	SYSTEM_INFO_STREAM      = 7
+	MEMORY_64_LIST_STREAM   = 9
+	COMMENT_STREAM_A        = 10
+	MEMORY_INFO_LIST_STREAM = 16
+
+	PROCESSOR_ARCHITECTURE_INTEL   = 0
+	PROCESSOR_ARCHITECTURE_ARM     = 5
+	PROCESSOR_ARCHITECTURE_AMD64   = 9
+	PROCESSOR_ARCHITECTURE_ARM64   = 12
+	PROCESSOR_ARCHITECTURE_UNKNOWN = 0xffff
+
+	// Non Windows platform ids follow the breakpad conventions.
+	PLATFORM_WIN32_NT = 2
+	PLATFORM_MACOS    = 0x8101
+	PLATFORM_LINUX    = 0x8201
+
+	MEM_COMMIT  = 0x1000
+	MEM_RESERVE = 0x2000
+	MEM_FREE    = 0x10000
+	MEM_PRIVATE = 0x20000
+	MEM_MAPPED  = 0x40000
+	MEM_IMAGE   = 0x1000000
+
+	PAGE_NOACCESS          = 0x01
+	PAGE_READONLY          = 0x02
+	PAGE_READWRITE         = 0x04
+	PAGE_EXECUTE           = 0x10
+	PAGE_EXECUTE_READ      = 0x20
+	PAGE_EXECUTE_READWRITE = 0x40
+)
+
+type header struct {
+	Signature          uint32
+	Version            uint32
+	NumberOfStreams    uint32
+	StreamDirectoryRva uint32
+	CheckSum           uint32
+	TimeDateStamp      uint32
+	Flags              uint64
+}
+
+type directory struct {
+	StreamType uint32
+	DataSize   uint32
+	Rva        uint32
+}
+
+type systemInfo struct {
+	ProcessorArchitecture uint16
+	ProcessorLevel        uint16
+	ProcessorRevision     uint16
+	NumberOfProcessors    uint8
+	ProductType           uint8
+	MajorVersion          uint32
+	MinorVersion          uint32
+	BuildNumber           uint32
+	PlatformId            uint32
+	CSDVersionRva         uint32
+	SuiteMask             uint16
+	Reserved2             uint16
+	Cpu                   [24]byte
+}
+
+type module struct {
+	BaseOfImage   uint64
+	SizeOfImage   uint32
+	CheckSum      uint32
+	TimeDateStamp uint32
+	ModuleNameRva uint32
+	VersionInfo   [13]uint32
+	CvRecord      [2]uint32
+	MiscRecord    [2]uint32
+	Reserved0     uint64
+	Reserved1     uint64
+}
+
+type memoryInfoHeader struct {
+	SizeOfHeader    uint32
+	SizeOfEntry     uint32
+	NumberOfEntries uint64
+}
+
+type memoryInfo struct {
+	BaseAddress       uint64
+	AllocationBase    uint64
+	AllocationProtect uint32
+	Alignment1        uint32
+	RegionSize        uint64
+	State             uint32
+	Protect           uint32
+	Type              uint32
+	Alignment2        uint32
+}
+
+type memoryDescriptor64 struct {
+	StartOfMemoryRange uint64
+	DataSize           uint64
+}
+
+// A range of memory stored in the memory reader.
+type MemoryRange struct {
+	Address uint64
+	Size    uint64
+}
+
+func getArchitecture(arch string) uint16 {
+	switch arch {
+	case "amd64":
+		return PROCESSOR_ARCHITECTURE_AMD64
+	case "386":
+		return PROCESSOR_ARCHITECTURE_INTEL
+	case "arm64":
+		return PROCESSOR_ARCHITECTURE_ARM64
+	case "arm":
+		return PROCESSOR_ARCHITECTURE_ARM
+	default:
+		return PROCESSOR_ARCHITECTURE_UNKNOWN
+	}
+}
+
+func getPlatform(os string) uint32 {
+	switch os {
+	case "linux":
+		return PLATFORM_LINUX
+	case "darwin":
+		return PLATFORM_MACOS
+	default:
+		return PLATFORM_WIN32_NT
+	}
+}
+
+func getProtection(region Region) uint32 {
+	if region.ProtectionRaw != 0 {
+		return region.ProtectionRaw
+	}
+
+	read := strings.Contains(region.Protection, "r")
+	write := strings.Contains(region.Protection, "w")
+	execute := strings.Contains(region.Protection, "x")
+
+	switch {
+	case execute && write:
+		return PAGE_EXECUTE_READWRITE
+	case execute && read:
+		return PAGE_EXECUTE_READ
+	case execute:
+		return PAGE_EXECUTE
+	case write:
+		return PAGE_READWRITE
+	case read:
+		return PAGE_READONLY
+	default:
+		return PAGE_NOACCESS
+	}
+}
+
+func getState(region Region) uint32 {
+	switch region.State {
+	case "MEM_RESERVE":
+		return MEM_RESERVE
+	case "MEM_FREE":
+		return MEM_FREE
+	default:
+		return MEM_COMMIT
+	}
+}
+
+func getType(region Region) uint32 {
+	switch region.Type {
+	case "MEM_IMAGE":
+		return MEM_IMAGE
+	case "MEM_MAPPED":
+		return MEM_MAPPED
+	case "MEM_PRIVATE":
+		return MEM_PRIVATE
+	}
+
+	if region.MappingName != "" {
+		return MEM_MAPPED
+	}
+	return MEM_PRIVATE
+}
+
+// Builds the part of the minidump before the memory. All offsets
+// (RVAs) are relative to the start of the file.
+type builder struct {
+	buf         bytes.Buffer
+	directories []directory
+}
+
+func (self *builder) rva() uint32 {
+	return uint32(self.buf.Len())
+}
+
+func (self *builder) write(data interface{}) {
+	_ = binary.Write(&self.buf, binary.LittleEndian, data)
+}
+
+// Write a MINIDUMP_STRING and return its RVA.
+func (self *builder) writeString(value string) uint32 {
+	rva := self.rva()
+	encoded := utf16.Encode([]rune(value))
+	self.write(uint32(len(encoded) * 2))
+	self.write(encoded)
+	self.write(uint16(0))
+	return rva
+}
+
+func (self *builder) addStream(stream_type uint32, data []byte) {
+	self.directories = append(self.directories, directory{
+		StreamType: stream_type,
+		DataSize:   uint32(len(data)),
+		Rva:        self.rva(),
+	})
+	self.buf.Write(data)
+}
+
+func encode(data ...interface{}) []byte {
+	buf := &bytes.Buffer{}
+	for _, item := range data {
+		_ = binary.Write(buf, binary.LittleEndian, item)
+	}
+	return buf.Bytes()
+}
+
+// Write a minidump containing the memory ranges. The memory reader
+// holds the data of the ranges back to back, in order.
+func WriteMinidump(writer io.Writer, manifest *Manifest,
+	ranges []MemoryRange, memory io.Reader) (int64, error) {
+	if manifest == nil {
+		return 0, errors.New("No manifest")
+	}
+
+	// Streams are laid out after the header and the directory.
+	const number_of_streams = 5
+	b := &builder{}
+	b.buf.Write(make([]byte, binary.Size(header{})+
+		number_of_streams*binary.Size(directory{})))
+
+	// The system information.
+	csd_rva := b.writeString("")
+	b.addStream(SYSTEM_INFO_STREAM, encode(&systemInfo{
+		ProcessorArchitecture: getArchitecture(manifest.Arch),
+		ProductType:           1,
+		PlatformId:            getPlatform(manifest.OS),
+		CSDVersionRva:         csd_rva,
+	}))
+
+	// The modules refer to their names.
+	name_rvas := make([]uint32, 0, len(manifest.Modules))
+	for _, m := range manifest.Modules {
+		name_rvas = append(name_rvas, b.writeString(m.Name))
+	}
+
+	modules := []interface{}{uint32(len(manifest.Modules))}
+	for idx, m := range manifest.Modules {
+		modules = append(modules, &module{
+			BaseOfImage:   m.BaseAddress,
+			SizeOfImage:   uint32(m.Size),
+			ModuleNameRva: name_rvas[idx],
+		})
+	}
+	b.addStream(MODULE_LIST_STREAM, encode(modules...))
+
+	// The properties of the collected regions.
+	infos := []interface{}{&memoryInfoHeader{
+		SizeOfHeader:    uint32(binary.Size(memoryInfoHeader{})),
+		SizeOfEntry:     uint32(binary.Size(memoryInfo{})),
+		NumberOfEntries: uint64(len(manifest.Regions)),
+	}}
+	for _, region := range manifest.Regions {
+		infos = append(infos, &memoryInfo{
+			BaseAddress:       region.Address,
+			AllocationBase:    region.Address,
+			AllocationProtect: getProtection(region),
+			RegionSize:        region.Size,
+			State:             getState(region),
+			Protect:           getProtection(region),
+			Type:              getType(region),
+		})
+	}
+	b.addStream(MEMORY_INFO_LIST_STREAM, encode(infos...))
+
+	b.addStream(COMMENT_STREAM_A, append([]byte(fmt.Sprintf(
+		"Collected by Velociraptor from %v (pid %v): %v",
+		manifest.Name, manifest.Pid, manifest.CommandLine)), 0))
+
+	// The memory list must be last since the memory follows it.
+	descriptors := []interface{}{uint64(len(ranges)), uint64(0)}
+	total := uint64(0)
+	for _, rng := range ranges {
+		descriptors = append(descriptors, &memoryDescriptor64{
+			StartOfMemoryRange: rng.Address,
+			DataSize:           rng.Size,
+		})
+		total += rng.Size
+	}
+	memory_list := encode(descriptors...)
+
+	// Patch the BaseRva now we know where the memory starts.
+	base_rva := uint64(b.buf.Len() + len(memory_list))
+	binary.LittleEndian.PutUint64(memory_list[8:], base_rva)
+	b.addStream(MEMORY_64_LIST_STREAM, memory_list)
+
+	// Now fill in the header and directory.
+	data := b.buf.Bytes()
+	prefix := encode(&header{
+		Signature:          MINIDUMP_SIGNATURE,
+		Version:            MINIDUMP_VERSION,
+		NumberOfStreams:    uint32(len(b.directories)),
+		StreamDirectoryRva: uint32(binary.Size(header{})),
+		TimeDateStamp:      uint32(manifest.Time),
+		Flags:              MINIDUMP_FLAGS,
+	})
+	for _, dir := range b.directories {
+		prefix = append(prefix, encode(&dir)...)
+	}
+	copy(data, prefix)
+
+	n, err := writer.Write(data)
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	// Copy the memory, padding it if the upload was short.
+	copied, err := io.CopyN(writer, memory, int64(total))
+	written += copied
+	if err == io.EOF {
+		padded, err := io.CopyN(writer,
+			zeroReader{}, int64(total)-copied)
+		return written + padded, err
+	}
+	return written, err
+}
+
+type zeroReader struct{}
+
+func (self zeroReader) Read(buf []byte) (int, error) {
+	for i := range buf {
+		buf[i] = 0
+	}
+	return len(buf), nil
+}
@@ -0,0 +1,70 @@
+package quarantine
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	NFT_TABLE = "velociraptor_quarantine"
+
+	// The table installed by older versions of the
+	// Linux.Remediation.Quarantine artifact.
+	LEGACY_NFT_TABLE = "vrr_quarantine_table"
+)
+
+// Build the nftables script installing the quarantine table. The
+// table is declared and deleted first so loading the script
+// atomically replaces any previous quarantine.
+func nftRuleset(endpoints []endpoint) string {
+	ipv4, ipv6 := splitEndpoints(endpoints)
+
+	var input, output []string
+	add := func(family string, items []endpoint) {
+		for _, item := range items {
+			input = append(input, fmt.Sprintf(
+				"%s saddr %s tcp sport %d ct state established accept",
+				family, item.ip, item.port))
+			output = append(output, fmt.Sprintf(
+				"%s daddr %s tcp dport %d ct state new,established accept",
+				family, item.ip, item.port))
+		}
+	}
+	add("ip", ipv4)
+	add("ip6", ipv6)
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "table inet %s\n", NFT_TABLE)
+	fmt.Fprintf(b, "delete table inet %s\n", NFT_TABLE)
+	fmt.Fprintf(b, "table inet %s {\n", NFT_TABLE)
+
+	writeChain(b, "input", append([]string{
+		"iif \"lo\" accept",
+		"udp sport 53 ct state established accept",
+		"tcp sport 53 ct state established accept",
+		"udp sport { 67, 68, 546, 547 } accept",
+		"icmpv6 type { nd-neighbor-solicit, nd-neighbor-advert, nd-router-advert } accept",
+	}, input...))
+
+	writeChain(b, "output", append([]string{
+		"oif \"lo\" accept",
+		"udp dport 53 accept",
+		"tcp dport 53 accept",
+		"udp dport { 67, 68, 546, 547 } accept",
+		"icmpv6 type { nd-neighbor-solicit, nd-neighbor-advert, nd-router-solicit } accept",
+	}, output...))
+
+	writeChain(b, "forward", nil)
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func writeChain(b *strings.Builder, hook string, rules []string) {
+	fmt.Fprintf(b, "\tchain %s {\n", hook)
+	fmt.Fprintf(b, "\t\ttype filter hook %s priority 0; policy drop;\n", hook)
+	for _, rule := range rules {
+		fmt.Fprintf(b, "\t\t%s\n", rule)
+	}
+	b.WriteString("\t}\n")
+}
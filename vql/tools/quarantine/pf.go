@@ -0,0 +1,66 @@
+package quarantine
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// The default pf.conf on macOS evaluates all anchors below
+	// com.apple so rules loaded here apply without changing it.
+	PF_ANCHOR = "com.apple/velociraptor_quarantine"
+)
+
+var (
+	// pfctl -E prints the reference token on stderr.
+	pfTokenRegex = regexp.MustCompile(`Token : (\d+)`)
+)
+
+// Build the pf rules loaded into the quarantine anchor. The rules
+// are all quick so they take precedence over the main ruleset.
+func pfRules(endpoints []endpoint) string {
+	ipv4, ipv6 := splitEndpoints(endpoints)
+
+	b := &strings.Builder{}
+	b.WriteString("pass quick on lo0 all\n")
+	b.WriteString("pass out quick proto { tcp, udp } to any port 53 keep state\n")
+	b.WriteString("pass quick proto udp from any port { 67, 68, 546, 547 }\n")
+	b.WriteString("pass out quick proto udp to any port { 67, 68, 546, 547 } keep state\n")
+	b.WriteString("pass quick inet6 proto ipv6-icmp all icmp6-type " +
+		"{ neighbrsol, neighbradv, routersol, routeradv }\n")
+
+	add := func(family string, items []endpoint) {
+		for _, item := range items {
+			fmt.Fprintf(b, "pass out quick %s proto tcp to %s port %d keep state\n",
+				family, item.ip, item.port)
+		}
+	}
+	add("inet", ipv4)
+	add("inet6", ipv6)
+
+	b.WriteString("block drop quick all\n")
+
+	return b.String()
+}
+
+func parsePfToken(output string) string {
+	match := pfTokenRegex.FindStringSubmatch(output)
+	if len(match) < 2 {
+		return ""
+	}
+	return match[1]
+}
+
+// Check if the token is still held (pfctl -s References). Tokens do
+// not survive a reboot.
+func pfTokenListed(output, token string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		for _, field := range strings.Fields(line) {
+			if field == token {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,327 @@
+/*
+  Quarantine the client's network using the native firewall.
+
+  While quarantined the client may only talk to the Velociraptor
+  server (and any additional allowed endpoints). DNS, DHCP and IPv6
+  neighbor discovery remain allowed so the network stays
+  configured. All other traffic is dropped.
+
+  On Linux the rules are installed into their own nftables table and
+  on macOS into a pf anchor below com.apple/* which the default
+  pf.conf evaluates. Removing the quarantine simply removes the table
+  or anchor, leaving the rest of the firewall untouched.
+
+  The quarantine is recorded in the writeback file and installed
+  again when the client starts, so it survives a reboot. Since the
+  server's addresses are resolved when the rules are installed, they
+  follow the server if it moves.
+*/
+
+package quarantine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	NotSupportedError = errors.New("Quarantine is not supported on this platform")
+)
+
+// An address the client is allowed to connect to.
+type endpoint struct {
+	ip   net.IP
+	port uint16
+}
+
+func (self endpoint) String() string {
+	return net.JoinHostPort(self.ip.String(), strconv.Itoa(int(self.port)))
+}
+
+// Parse a server url into its host and port.
+func parseServerURL(server_url string) (string, uint16, error) {
+	parsed, err := url.Parse(server_url)
+	if err != nil {
+		return "", 0, err
+	}
+
+	host := parsed.Hostname()
+	if host == "" {
+		return "", 0, fmt.Errorf("Invalid server url %v", server_url)
+	}
+
+	port := parsed.Port()
+	if port == "" {
+		switch parsed.Scheme {
+		case "http", "ws":
+			return host, 80, nil
+		default:
+			return host, 443, nil
+		}
+	}
+
+	value, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return "", 0, fmt.Errorf("Invalid server url %v: %w", server_url, err)
+	}
+	return host, uint16(value), nil
+}
+
+// Resolve the server urls and additional host:port endpoints into
+// the addresses the firewall should allow.
+func getEndpoints(
+	ctx context.Context,
+	config_obj *config_proto.ClientConfig,
+	allowed []string) ([]endpoint, error) {
+
+	type hostPort struct {
+		host string
+		port uint16
+	}
+
+	var targets []hostPort
+	for _, server_url := range config_obj.ServerUrls {
+		host, port, err := parseServerURL(server_url)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, hostPort{host, port})
+	}
+
+	for _, item := range allowed {
+		host, port, err := net.SplitHostPort(item)
+		if err != nil {
+			return nil, fmt.Errorf("Allowed endpoint %v: %w", item, err)
+		}
+
+		value, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("Allowed endpoint %v: %w", item, err)
+		}
+		targets = append(targets, hostPort{host, uint16(value)})
+	}
+
+	if len(targets) == 0 {
+		return nil, errors.New("No server urls configured")
+	}
+
+	seen := make(map[string]bool)
+	var result []endpoint
+	for _, target := range targets {
+		ips := []net.IP{net.ParseIP(target.host)}
+		if ips[0] == nil {
+			addrs, err := net.DefaultResolver.LookupIPAddr(ctx, target.host)
+			if err != nil {
+				return nil, err
+			}
+
+			ips = nil
+			for _, addr := range addrs {
+				ips = append(ips, addr.IP)
+			}
+		}
+
+		for _, ip := range ips {
+			item := endpoint{ip: ip, port: target.port}
+			key := item.String()
+			if !seen[key] {
+				seen[key] = true
+				result = append(result, item)
+			}
+		}
+	}
+
+	// Keep the rules stable between runs.
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+
+	if len(result) == 0 {
+		return nil, errors.New("Unable to resolve any server address")
+	}
+
+	return result, nil
+}
+
+// Split the endpoints by address family.
+func splitEndpoints(endpoints []endpoint) (ipv4, ipv6 []endpoint) {
+	for _, item := range endpoints {
+		if item.ip.To4() != nil {
+			ipv4 = append(ipv4, item)
+		} else {
+			ipv6 = append(ipv6, item)
+		}
+	}
+	return ipv4, ipv6
+}
+
+// Quarantine the client. Applying the quarantine again replaces the
+// previous rules.
+func Quarantine(
+	ctx context.Context,
+	config_obj *config_proto.ClientConfig,
+	allowed []string) ([]string, error) {
+
+	writeback, err := config.GetWriteback(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	state := writeback.Quarantine
+	if state == nil {
+		state = &config_proto.QuarantineState{}
+	}
+	state.Allowed = allowed
+
+	err = apply(ctx, config_obj, state)
+	if err != nil {
+		return nil, err
+	}
+
+	state.Timestamp = uint64(utils.GetTime().Now().Unix())
+	writeback.Quarantine = state
+	err = config.UpdateWriteback(config_obj, writeback)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.Endpoints, nil
+}
+
+// Remove the quarantine rules.
+func Unquarantine(
+	ctx context.Context, config_obj *config_proto.ClientConfig) error {
+	if config_obj.PreventExecve {
+		return errors.New("Not allowed to execve by configuration.")
+	}
+
+	writeback, err := config.GetWriteback(config_obj)
+	if err != nil {
+		return err
+	}
+
+	state := writeback.Quarantine
+	if state == nil {
+		state = &config_proto.QuarantineState{}
+	}
+
+	err = removeRules(ctx, state)
+	if err != nil {
+		return err
+	}
+	utils.SetQuarantined(false)
+
+	writeback.Quarantine = nil
+	return config.UpdateWriteback(config_obj, writeback)
+}
+
+// Install the quarantine again if the client was quarantined before
+// it restarted.
+func Restore(
+	ctx context.Context, config_obj *config_proto.ClientConfig) error {
+	if config_obj == nil {
+		return nil
+	}
+
+	writeback, err := config.GetWriteback(config_obj)
+	if err != nil {
+		return err
+	}
+
+	if writeback.Quarantine == nil {
+		return nil
+	}
+
+	err = apply(ctx, config_obj, writeback.Quarantine)
+	if err != nil {
+		return err
+	}
+
+	// The pf token and the server addresses may have changed.
+	return config.UpdateWriteback(config_obj, writeback)
+}
+
+func apply(
+	ctx context.Context,
+	config_obj *config_proto.ClientConfig,
+	state *config_proto.QuarantineState) error {
+
+	if config_obj.PreventExecve {
+		return errors.New("Not allowed to execve by configuration.")
+	}
+
+	endpoints, err := getEndpoints(ctx, config_obj, state.Allowed)
+	if err != nil {
+		// Fall back to the addresses we used last time.
+		if len(state.Endpoints) == 0 {
+			return err
+		}
+
+		endpoints, err = parseEndpoints(state.Endpoints)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = installRules(ctx, endpoints, state)
+	if err != nil {
+		return err
+	}
+	utils.SetQuarantined(true)
+
+	state.Endpoints = nil
+	for _, item := range endpoints {
+		state.Endpoints = append(state.Endpoints, item.String())
+	}
+
+	return nil
+}
+
+func parseEndpoints(items []string) ([]endpoint, error) {
+	var result []endpoint
+	for _, item := range items {
+		host, port, err := net.SplitHostPort(item)
+		if err != nil {
+			return nil, err
+		}
+
+		ip := net.ParseIP(host)
+		if ip == nil {
+			return nil, fmt.Errorf("Invalid endpoint %v", item)
+		}
+
+		value, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid endpoint %v: %w", item, err)
+		}
+		result = append(result, endpoint{ip: ip, port: uint16(value)})
+	}
+	return result, nil
+}
+
+// Run a firewall tool feeding it the rules on stdin.
+func runCommand(ctx context.Context, stdin string,
+	argv ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("%v: %w: %v",
+			argv[0], err, strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
@@ -0,0 +1,56 @@
+// +build darwin
+
+package quarantine
+
+import (
+	"context"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+const (
+	PFCTL_PATH = "/sbin/pfctl"
+)
+
+func installRules(ctx context.Context,
+	endpoints []endpoint, state *config_proto.QuarantineState) error {
+	_, err := runCommand(ctx, pfRules(endpoints),
+		PFCTL_PATH, "-a", PF_ANCHOR, "-f", "-")
+	if err != nil {
+		return err
+	}
+
+	// Enabling pf takes a reference which keeps it enabled until
+	// we release it. We only need one reference.
+	if state.PfToken != "" {
+		output, err := runCommand(ctx, "", PFCTL_PATH, "-s", "References")
+		if err == nil && pfTokenListed(output, state.PfToken) {
+			return nil
+		}
+	}
+
+	output, err := runCommand(ctx, "", PFCTL_PATH, "-E")
+	if err != nil {
+		return err
+	}
+	state.PfToken = parsePfToken(output)
+
+	return nil
+}
+
+func removeRules(ctx context.Context,
+	state *config_proto.QuarantineState) error {
+	_, err := runCommand(ctx, "", PFCTL_PATH, "-a", PF_ANCHOR, "-F", "all")
+	if err != nil {
+		return err
+	}
+
+	// Releasing our reference disables pf again unless someone
+	// else enabled it.
+	if state.PfToken != "" {
+		_, _ = runCommand(ctx, "", PFCTL_PATH, "-X", state.PfToken)
+		state.PfToken = ""
+	}
+
+	return nil
+}
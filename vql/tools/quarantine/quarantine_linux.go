@@ -0,0 +1,36 @@
+// +build linux
+
+package quarantine
+
+import (
+	"context"
+	"strings"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+const (
+	NFT_PATH = "/usr/sbin/nft"
+)
+
+func installRules(ctx context.Context,
+	endpoints []endpoint, state *config_proto.QuarantineState) error {
+	_, err := runCommand(ctx, nftRuleset(endpoints), NFT_PATH, "-f", "-")
+	return err
+}
+
+func removeRules(ctx context.Context,
+	state *config_proto.QuarantineState) error {
+	// Also release hosts quarantined by older versions of the
+	// artifact.
+	for _, table := range []string{NFT_TABLE, LEGACY_NFT_TABLE} {
+		output, err := runCommand(ctx, "",
+			NFT_PATH, "delete", "table", "inet", table)
+
+		// The quarantine is already removed.
+		if err != nil && !strings.Contains(output, "No such file or directory") {
+			return err
+		}
+	}
+	return nil
+}
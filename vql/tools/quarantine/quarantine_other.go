@@ -0,0 +1,19 @@
+// +build !linux,!darwin
+
+package quarantine
+
+import (
+	"context"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+func installRules(ctx context.Context,
+	endpoints []endpoint, state *config_proto.QuarantineState) error {
+	return NotSupportedError
+}
+
+func removeRules(ctx context.Context,
+	state *config_proto.QuarantineState) error {
+	return NotSupportedError
+}
@@ -0,0 +1,79 @@
+package quarantine
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+)
+
+func TestGetEndpoints(t *testing.T) {
+	config_obj := &config_proto.ClientConfig{
+		ServerUrls: []string{
+			"https://10.1.1.1:8000/",
+			"wss://10.1.1.1:8000/",
+			"http://[2001:db8::1]/",
+			"https://10.1.1.2/",
+		},
+	}
+
+	endpoints, err := getEndpoints(context.Background(), config_obj,
+		[]string{"10.2.2.2:9000"})
+	assert.NoError(t, err)
+
+	result := []string{}
+	for _, item := range endpoints {
+		result = append(result, item.String())
+	}
+
+	// Duplicates are removed and the endpoints are sorted.
+	assert.Equal(t, []string{
+		"10.1.1.1:8000", "10.1.1.2:443", "10.2.2.2:9000", "[2001:db8::1]:80",
+	}, result)
+
+	_, err = getEndpoints(context.Background(), config_obj,
+		[]string{"10.2.2.2"})
+	assert.Error(t, err)
+
+	_, err = getEndpoints(context.Background(),
+		&config_proto.ClientConfig{}, nil)
+	assert.Error(t, err)
+}
+
+func TestRules(t *testing.T) {
+	endpoints, err := parseEndpoints([]string{
+		"10.1.1.1:8000", "[2001:db8::1]:443"})
+	assert.NoError(t, err)
+
+	nft := nftRuleset(endpoints)
+
+	// The table is replaced atomically.
+	assert.True(t, strings.HasPrefix(nft,
+		"table inet velociraptor_quarantine\n"+
+			"delete table inet velociraptor_quarantine\n"))
+	assert.Contains(t, nft,
+		"ip daddr 10.1.1.1 tcp dport 8000 ct state new,established accept")
+	assert.Contains(t, nft,
+		"ip saddr 10.1.1.1 tcp sport 8000 ct state established accept")
+	assert.Contains(t, nft,
+		"ip6 daddr 2001:db8::1 tcp dport 443 ct state new,established accept")
+	assert.Contains(t, nft, "udp sport 53 ct state established accept")
+	assert.NotContains(t, nft, "udp sport 53 accept")
+	assert.Equal(t, 3, strings.Count(nft, "policy drop;"))
+
+	pf := pfRules(endpoints)
+	assert.Contains(t, pf, "pass out quick inet proto tcp to 10.1.1.1 port 8000 keep state")
+	assert.Contains(t, pf, "pass out quick inet6 proto tcp to 2001:db8::1 port 443 keep state")
+
+	// Everything else is blocked.
+	assert.True(t, strings.HasSuffix(pf, "block drop quick all\n"))
+
+	assert.Equal(t, "1234", parsePfToken(
+		"No ALTQ support in kernel\npf enabled\nToken : 1234\n"))
+	assert.True(t, pfTokenListed(
+		"PID  Process Name  TOKEN  TIMESTAMP\n 12 velociraptor 1234 10 secs\n",
+		"1234"))
+	assert.False(t, pfTokenListed("", "1234"))
+}
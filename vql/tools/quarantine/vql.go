@@ -0,0 +1,109 @@
+package quarantine
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/artifacts"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type QuarantineFunctionArgs struct {
+	Allow []string `vfilter:"optional,field=allow,doc=Additional host:port endpoints the client may connect to."`
+}
+
+type QuarantineFunction struct{}
+
+func (self *QuarantineFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	arg := &QuarantineFunctionArgs{}
+	err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("quarantine: %v", err)
+		return vfilter.Null{}
+	}
+
+	// Changing the firewall is a privileged operation.
+	err = vql_subsystem.CheckAccess(scope, acls.EXECVE)
+	if err != nil {
+		scope.Log("quarantine: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := artifacts.GetConfig(scope)
+	if !ok || config_obj == nil {
+		scope.Log("quarantine: Must be running on a client")
+		return vfilter.Null{}
+	}
+
+	endpoints, err := Quarantine(ctx, config_obj, arg.Allow)
+	if err != nil {
+		scope.Log("quarantine: %v", err)
+		return vfilter.Null{}
+	}
+
+	return ordereddict.NewDict().
+		Set("Quarantined", true).
+		Set("Allowed", endpoints)
+}
+
+func (self QuarantineFunction) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "quarantine",
+		Doc:     "Quarantine the client's network (nftables on Linux, pf on macOS) so it may only talk to the server. The quarantine is kept across reboots until removed with unquarantine().",
+		ArgType: type_map.AddType(scope, &QuarantineFunctionArgs{}),
+	}
+}
+
+type UnquarantineFunction struct{}
+
+func (self *UnquarantineFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	arg := &vfilter.Empty{}
+	err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("unquarantine: %v", err)
+		return vfilter.Null{}
+	}
+
+	err = vql_subsystem.CheckAccess(scope, acls.EXECVE)
+	if err != nil {
+		scope.Log("unquarantine: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := artifacts.GetConfig(scope)
+	if !ok || config_obj == nil {
+		scope.Log("unquarantine: Must be running on a client")
+		return vfilter.Null{}
+	}
+
+	err = Unquarantine(ctx, config_obj)
+	if err != nil {
+		scope.Log("unquarantine: %v", err)
+		return vfilter.Null{}
+	}
+
+	return ordereddict.NewDict().
+		Set("Quarantined", false)
+}
+
+func (self UnquarantineFunction) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "unquarantine",
+		Doc:     "Remove the client's network quarantine.",
+		ArgType: type_map.AddType(scope, &vfilter.Empty{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&QuarantineFunction{})
+	vql_subsystem.RegisterFunction(&UnquarantineFunction{})
+}
@@ -6,8 +6,10 @@ import (
 
 	"github.com/Velocidex/ordereddict"
 	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/actions"
 	"www.velocidex.com/golang/velociraptor/artifacts"
 	"www.velocidex.com/golang/velociraptor/config"
+	"www.velocidex.com/golang/velociraptor/crypto/tpm"
 	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 	"www.velocidex.com/golang/vfilter"
@@ -15,7 +17,7 @@ import (
 )
 
 var (
-	ClientRestart = make(chan string)
+	ClientRestart = actions.ClientRestart
 )
 
 type RekeyFunctionArgs struct {
@@ -55,27 +57,33 @@ func (self *RekeyFunction) Call(ctx context.Context,
 		return vfilter.Null{}
 	}
 
-	pem, err := crypto_utils.GeneratePrivateKey()
-	if err != nil {
-		scope.Log("rekey: %v", err)
-		return vfilter.Null{}
-	}
+	old_key_name := writeback.HardwareKeyName
 
-	private_key, err := crypto_utils.ParseRsaPrivateKeyFromPemStr(pem)
+	// The new key means a new client id so any key rotation state is
+	// reset.
+	err = crypto_utils.GenerateWritebackKey(config_obj, writeback)
 	if err != nil {
 		scope.Log("rekey: %v", err)
 		return vfilter.Null{}
 	}
+	new_client_id := writeback.ClientId
 
-	// Update the write back.
-	writeback.PrivateKey = string(pem)
 	err = config.UpdateWriteback(config_obj, writeback)
 	if err != nil {
 		scope.Log("rekey: %v", err)
 		return vfilter.Null{}
 	}
 
-	new_client_id := crypto_utils.ClientIDFromPublicKey(&private_key.PublicKey)
+	// The old identity is gone now.
+	if old_key_name != "" {
+		old_key, err := tpm.OpenKey(old_key_name)
+		if err == nil {
+			err = old_key.Delete()
+		}
+		if err != nil {
+			scope.Log("rekey: Unable to remove old TPM key: %v", err)
+		}
+	}
 
 	// Send the new client id to the main client loop so it can
 	// restart, but wait a bit to allow messages to be sent to the
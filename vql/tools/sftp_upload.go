@@ -10,6 +10,7 @@ import (
 	"net"
 	"os"
 	"path"
+	"time"
 
 	"github.com/Velocidex/ordereddict"
 	"github.com/pkg/sftp"
@@ -17,6 +18,7 @@ import (
 	"golang.org/x/net/context"
 	"www.velocidex.com/golang/velociraptor/accessors"
 	"www.velocidex.com/golang/velociraptor/uploads"
+	"www.velocidex.com/golang/velociraptor/utils"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 	"www.velocidex.com/golang/vfilter"
 	"www.velocidex.com/golang/vfilter/arg_parser"
@@ -31,6 +33,8 @@ type SFTPUploadArgs struct {
 	PrivateKey string            `vfilter:"required,field=privatekey,doc=The private key to use"`
 	Endpoint   string            `vfilter:"required,field=endpoint,doc=The Endpoint to use including port number (e.g. 192.168.1.1:22 )"`
 	HostKey    string            `vfilter:"optional,field=hostkey,doc=Host key to verify. Blank to disable"`
+	MaxRate    uint64            `vfilter:"optional,field=max_rate,doc=Maximum upload rate in bytes per second (0 for unlimited)"`
+	Retries    int64             `vfilter:"optional,field=retries,doc=How many times to resume the transfer after a failure (default 3)"`
 }
 
 type SFTPUploadFunction struct{}
@@ -79,6 +83,10 @@ func (self *SFTPUploadFunction) Call(ctx context.Context,
 		sub_ctx, cancel := context.WithCancel(ctx)
 		_ = scope.AddDestructor(cancel)
 
+		if _, pres := args.Get("retries"); !pres {
+			arg.Retries = 3
+		}
+
 		upload_response, err := upload_SFTP(
 			sub_ctx, scope, file,
 			arg.User,
@@ -86,7 +94,9 @@ func (self *SFTPUploadFunction) Call(ctx context.Context,
 			arg.Name,
 			arg.PrivateKey,
 			arg.Endpoint,
-			arg.HostKey)
+			arg.HostKey,
+			arg.MaxRate,
+			arg.Retries)
 		if err != nil {
 			scope.Log("upload_SFTP: %v", err)
 			// Relay the error in the UploadResponse
@@ -114,7 +124,7 @@ func hostkeycallback(trustedkey string) ssh.HostKeyCallback {
 
 func getSFTPClient(scope vfilter.Scope, user string, privateKey string,
 	endpoint string, hostKey string) (*sftp.Client, error) {
-	cacheKey := fmt.Sprintf("%s %s", user, endpoint)
+	cacheKey := sftpCacheKey(user, endpoint)
 	client := vql_subsystem.CacheGet(scope, cacheKey)
 	if client == nil {
 		signer, err := ssh.ParsePrivateKey([]byte(privateKey))
@@ -176,36 +186,52 @@ func getSFTPClient(scope vfilter.Scope, user string, privateKey string,
 }
 
 func upload_SFTP(ctx context.Context, scope vfilter.Scope,
-	reader io.Reader,
+	reader io.ReadSeeker,
 	user, filepath, name string,
-	privateKey string, endpoint string, hostKey string) (
+	privateKey string, endpoint string, hostKey string,
+	max_rate uint64, retries int64) (
 	*uploads.UploadResponse, error) {
 
 	scope.Log("upload_SFTP: Uploading %v to %v", name, endpoint)
-	client, err := getSFTPClient(scope, user, privateKey, endpoint, hostKey)
-	if err != nil {
-		return &uploads.UploadResponse{
-			Error: err.Error(),
-		}, err
-	}
 
 	// The sftp spec requires a forward slash for separators, but some
 	// servers also accept backslash while some do not. To be safe we
 	// use the unix join in all cases.
 	fpath := path.Join(filepath, name)
-	file, err := client.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
-	if err != nil {
-		return &uploads.UploadResponse{
-			Error: err.Error(),
-		}, err
-	}
-	if _, err := file.ReadFrom(reader); err != nil {
-		return &uploads.UploadResponse{
-			Error: err.Error(),
-		}, err
+
+	var client *sftp.Client
+	var err error
+
+	// After a failure the transfer resumes from the end of the
+	// partially uploaded file.
+	for attempt := int64(0); ; attempt++ {
+		client, err = sftpTransfer(ctx, scope, reader, fpath, attempt > 0,
+			user, privateKey, endpoint, hostKey, max_rate)
+		if err == nil {
+			break
+		}
+
+		if attempt >= retries || ctx.Err() != nil {
+			return &uploads.UploadResponse{
+				Error: err.Error(),
+			}, err
+		}
+
+		scope.Log("upload_SFTP: Transfer of %v failed (%v), resuming in %v",
+			name, err, sftpRetryDelay(attempt))
+
+		// Reconnect on the next attempt.
+		vql_subsystem.CacheSet(scope, sftpCacheKey(user, endpoint), nil)
+
+		select {
+		case <-ctx.Done():
+			return &uploads.UploadResponse{
+				Error: ctx.Err().Error(),
+			}, ctx.Err()
+		case <-time.After(sftpRetryDelay(attempt)):
+		}
 	}
 
-	file.Close()
 	check, err := client.Lstat(fpath)
 	if e, ok := err.(*sftp.StatusError); ok && e.FxCode() == sftp.ErrSSHFxPermissionDenied {
 		scope.Log("upload_SFTP: Unable to verify size of uploaded file due to insufficient read permissions.")
@@ -226,6 +252,60 @@ func upload_SFTP(ctx context.Context, scope vfilter.Scope,
 	return response, nil
 }
 
+func sftpRetryDelay(attempt int64) time.Duration {
+	return time.Duration(attempt+1) * 5 * time.Second
+}
+
+func sftpCacheKey(user, endpoint string) string {
+	return fmt.Sprintf("%s %s", user, endpoint)
+}
+
+// Transfer the file. When resuming, the transfer continues from the
+// current size of the remote file.
+func sftpTransfer(ctx context.Context, scope vfilter.Scope,
+	reader io.ReadSeeker, fpath string, resume bool,
+	user, privateKey, endpoint, hostKey string,
+	max_rate uint64) (*sftp.Client, error) {
+
+	client, err := getSFTPClient(scope, user, privateKey, endpoint, hostKey)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	offset := int64(0)
+	if resume {
+		flags = os.O_WRONLY | os.O_CREATE
+		stat, err := client.Lstat(fpath)
+		if err == nil {
+			offset = stat.Size()
+		}
+	}
+
+	_, err = reader.Seek(offset, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := client.OpenFile(fpath, flags)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	_, err = file.Seek(offset, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = file.ReadFrom(utils.NewRateLimitedReader(ctx, reader, max_rate))
+	if err != nil {
+		return nil, err
+	}
+
+	return client, file.Close()
+}
+
 func (self SFTPUploadFunction) Info(
 	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
 	return &vfilter.FunctionInfo{
@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/go-errors/errors"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/artifacts"
+	crypto_utils "www.velocidex.com/golang/velociraptor/crypto/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+const (
+	// Client binaries are large but not this large.
+	maxUpgradeBinarySize = 500 * 1024 * 1024
+)
+
+type UpgradeClientFunctionArgs struct {
+	Path     *accessors.OSPath `vfilter:"required,field=path,doc=Path to the signed client binary."`
+	Accessor string            `vfilter:"optional,field=accessor,doc=The accessor to use"`
+	Wait     int64             `vfilter:"optional,field=wait,doc=Wait this long before restarting the client (default 10 seconds)."`
+}
+
+type UpgradeClientFunction struct{}
+
+func (self *UpgradeClientFunction) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) vfilter.Any {
+
+	arg := &UpgradeClientFunctionArgs{}
+	err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+	if err != nil {
+		scope.Log("upgrade_client: %v", err)
+		return vfilter.Null{}
+	}
+
+	// This is a privileged operation
+	err = vql_subsystem.CheckAccess(scope, acls.EXECVE)
+	if err != nil {
+		scope.Log("upgrade_client: %v", err)
+		return vfilter.Null{}
+	}
+
+	config_obj, ok := artifacts.GetConfig(scope)
+	if !ok || config_obj == nil {
+		scope.Log("upgrade_client: Must be running on a client")
+		return vfilter.Null{}
+	}
+
+	if len(config_obj.UpgradeSigningKeys) == 0 {
+		scope.Log("upgrade_client: No upgrade signing keys configured in Client.upgrade_signing_keys")
+		return vfilter.Null{}
+	}
+
+	err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+	if err != nil {
+		scope.Log("upgrade_client: %v", err)
+		return vfilter.Null{}
+	}
+
+	accessor, err := accessors.GetAccessor(arg.Accessor, scope)
+	if err != nil {
+		scope.Log("upgrade_client: %v", err)
+		return vfilter.Null{}
+	}
+
+	fd, err := accessor.OpenWithOSPath(arg.Path)
+	if err != nil {
+		scope.Log("upgrade_client: %v", err)
+		return vfilter.Null{}
+	}
+	defer fd.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(fd, maxUpgradeBinarySize))
+	if err != nil {
+		scope.Log("upgrade_client: %v", err)
+		return vfilter.Null{}
+	}
+
+	// Never install a binary we can not verify.
+	binary, err := crypto_utils.VerifyBinarySignature(
+		data, config_obj.UpgradeSigningKeys)
+	if err != nil {
+		scope.Log("upgrade_client: %v", err)
+		return vfilter.Null{}
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		scope.Log("upgrade_client: %v", err)
+		return vfilter.Null{}
+	}
+
+	err = replaceExecutable(exe, binary)
+	if err != nil {
+		scope.Log("upgrade_client: %v", err)
+		return vfilter.Null{}
+	}
+
+	wait := arg.Wait
+	if wait == 0 {
+		wait = 10
+	}
+
+	scope.Log("upgrade_client: Installed new client binary in %v, restarting in %v seconds",
+		exe, wait)
+
+	// Exit so the service manager starts the new binary, but wait a
+	// bit to allow our messages to be sent to the server. We exit
+	// with an error so the service manager's recovery actions
+	// restart the service.
+	go func() {
+		time.Sleep(time.Duration(wait) * time.Second)
+		os.Exit(-1)
+	}()
+
+	return exe
+}
+
+// Swap the new binary into place. A running executable can not be
+// overwritten on Windows but it may be renamed so we move it aside
+// first.
+func replaceExecutable(exe string, binary []byte) error {
+	stat, err := os.Stat(exe)
+	if err != nil {
+		return err
+	}
+
+	new_path := exe + ".new"
+	old_path := exe + ".old"
+
+	err = ioutil.WriteFile(new_path, binary, stat.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	// May fail if the old binary is still locked - this is not
+	// fatal unless we can not rename below.
+	_ = os.Remove(old_path)
+
+	err = os.Rename(exe, old_path)
+	if err != nil {
+		os.Remove(new_path)
+		return errors.Wrap(err, 0)
+	}
+
+	err = os.Rename(new_path, exe)
+	if err != nil {
+		// Restore the old binary so the service still starts.
+		_ = os.Rename(old_path, exe)
+		os.Remove(new_path)
+		return errors.Wrap(err, 0)
+	}
+
+	return nil
+}
+
+func (self UpgradeClientFunction) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
+	return &vfilter.FunctionInfo{
+		Name:    "upgrade_client",
+		Doc:     "Replace the client binary with a signed binary and restart the client. The binary must be signed by one of the keys in Client.upgrade_signing_keys.",
+		ArgType: type_map.AddType(scope, &UpgradeClientFunctionArgs{}),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterFunction(&UpgradeClientFunction{})
+}
@@ -50,7 +50,9 @@ var (
 // Used when we deliberately want to override a registered plugin.
 func OverridePlugin(plugin vfilter.PluginGeneratorInterface) {
 	name := plugin.Info(nil, nil).Name
+	mu.Lock()
 	exportedPlugins[name] = plugin
+	mu.Unlock()
 
 	ResetGlobalScopeCache()
 }
@@ -58,7 +60,9 @@ func OverridePlugin(plugin vfilter.PluginGeneratorInterface) {
 // Used when we deliberately want to override a registered function.
 func OverrideFunction(function vfilter.FunctionInterface) {
 	name := function.Info(nil, nil).Name
+	mu.Lock()
 	exportedFunctions[name] = function
+	mu.Unlock()
 
 	ResetGlobalScopeCache()
 }
@@ -94,10 +98,21 @@ func RegisterProtocol(plugin vfilter.Any) {
 }
 
 func GetFunction(name string) (vfilter.FunctionInterface, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+
 	res, pres := exportedFunctions[name]
 	return res, pres
 }
 
+func GetPlugin(name string) (vfilter.PluginGeneratorInterface, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	res, pres := exportedPlugins[name]
+	return res, pres
+}
+
 func EnforceVQLAllowList(
 	allowed_plugins []string, allowed_functions []string) error {
 
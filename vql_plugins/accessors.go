@@ -3,6 +3,7 @@ package plugins
 import (
 	_ "www.velocidex.com/golang/velociraptor/accessors"
 	_ "www.velocidex.com/golang/velociraptor/accessors/collector"
+	_ "www.velocidex.com/golang/velociraptor/accessors/container"
 	_ "www.velocidex.com/golang/velociraptor/accessors/data"
 	_ "www.velocidex.com/golang/velociraptor/accessors/file"
 	_ "www.velocidex.com/golang/velociraptor/accessors/file_store"
@@ -24,6 +24,7 @@ package plugins
 import (
 	_ "www.velocidex.com/golang/velociraptor/vql/aggregates"
 	_ "www.velocidex.com/golang/velociraptor/vql/common"
+	_ "www.velocidex.com/golang/velociraptor/vql/extensions"
 	_ "www.velocidex.com/golang/velociraptor/vql/filesystem"
 	_ "www.velocidex.com/golang/velociraptor/vql/functions"
 	_ "www.velocidex.com/golang/velociraptor/vql/golang"
@@ -39,5 +40,10 @@ import (
 	_ "www.velocidex.com/golang/velociraptor/vql/protocols"
 	_ "www.velocidex.com/golang/velociraptor/vql/tools"
 	_ "www.velocidex.com/golang/velociraptor/vql/tools/collector"
+	_ "www.velocidex.com/golang/velociraptor/vql/tools/explain"
+	_ "www.velocidex.com/golang/velociraptor/vql/tools/enforce"
+	_ "www.velocidex.com/golang/velociraptor/vql/tools/fim"
+	_ "www.velocidex.com/golang/velociraptor/vql/tools/minidump"
 	_ "www.velocidex.com/golang/velociraptor/vql/tools/process"
+	_ "www.velocidex.com/golang/velociraptor/vql/tools/quarantine"
 )
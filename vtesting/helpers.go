@@ -74,7 +74,7 @@ func WaitUntil(deadline time.Duration, t *testing.T, cb func() bool) {
 		time.Sleep(50 * time.Millisecond)
 	}
 
-	t.Fatalf("Timed out " + string(debug.Stack()))
+	t.Fatalf("Timed out %s", debug.Stack())
 }
 
 func RunPlugin(in <-chan types.Row) []types.Row {